@@ -0,0 +1,264 @@
+package ccq
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// adminServer serves operator endpoints for the query server. It does not authenticate its callers, so it only listens on loopback
+// addresses or on a unix socket only accessible to the user running the server.
+type adminServer struct {
+	logger      *zap.Logger
+	permissions *Permissions
+	httpServer  *http.Server
+}
+
+type reloadPermissionsResponse struct {
+	Success  bool   `json:"success"`
+	NumUsers int    `json:"numUsers"`
+	Error    string `json:"error,omitempty"`
+}
+
+type listUsersResponse struct {
+	Users []User `json:"users"`
+}
+
+type updateUserResponse struct {
+	Success bool   `json:"success"`
+	User    *User  `json:"user,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// errUserNotFound and errUserExists are returned by the user update functions, so that the handlers can map them to status codes.
+var (
+	errUserNotFound = errors.New("user not found")
+	errUserExists   = errors.New("user already exists")
+)
+
+func NewAdminServer(addr string, logger *zap.Logger, permissions *Permissions) *adminServer {
+	s := &adminServer{
+		logger:      logger.With(zap.String("component", "admin")),
+		permissions: permissions,
+	}
+	r := mux.NewRouter()
+	r.HandleFunc("/v1/permissions/reload", s.handleReloadPermissions).Methods("POST")
+	r.HandleFunc("/v1/users", s.handleListUsers).Methods("GET")
+	r.HandleFunc("/v1/users", s.handleCreateUser).Methods("POST")
+	r.HandleFunc("/v1/users/{userName}", s.handleUpdateUser).Methods("PUT")
+	r.HandleFunc("/v1/users/{userName}/disable", s.handleSetUserDisabled(true)).Methods("POST")
+	r.HandleFunc("/v1/users/{userName}/enable", s.handleSetUserDisabled(false)).Methods("POST")
+	s.httpServer = &http.Server{
+		Addr:              addr,
+		Handler:           r,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	return s
+}
+
+// handleReloadPermissions rereads the permissions file. On failure the previous permissions remain active.
+func (s *adminServer) handleReloadPermissions(w http.ResponseWriter, r *http.Request) {
+	s.logger.Info("permissions reload requested", zap.String("remoteAddr", r.RemoteAddr))
+
+	resp := reloadPermissionsResponse{Success: true}
+	status := http.StatusOK
+	if err := s.permissions.Reload(s.logger); err != nil {
+		resp.Success = false
+		resp.Error = err.Error()
+		status = http.StatusBadRequest
+	}
+	resp.NumUsers = s.permissions.NumUsers()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		s.logger.Error("failed to encode reload response", zap.Error(err))
+	}
+}
+
+// adminListener listens on addr, which may be a TCP address on a loopback interface or "unix:" followed by the path of a unix socket.
+// The ipMode only applies to TCP addresses.
+func adminListener(addr string, ipMode common.IPMode) (net.Listener, error) {
+	if path, ok := strings.CutPrefix(addr, "unix:"); ok {
+		// Remove a socket left behind by a previous run, but nothing else.
+		if fi, err := os.Stat(path); err == nil && fi.Mode()&os.ModeSocket != 0 {
+			_ = os.Remove(path)
+		}
+		lis, err := net.Listen("unix", path)
+		if err != nil {
+			return nil, err
+		}
+		// The socket is created according to the umask, which usually lets other users connect.
+		if err := os.Chmod(path, 0600); err != nil {
+			lis.Close()
+			return nil, fmt.Errorf("failed to restrict the permissions of the admin socket: %w", err)
+		}
+		return lis, nil
+	}
+	if err := checkLoopbackAddr(addr); err != nil {
+		return nil, err
+	}
+	return common.ListenTCP(ipMode, addr)
+}
+
+// checkLoopbackAddr returns an error unless addr is a host:port address on a loopback interface.
+func checkLoopbackAddr(addr string) error {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("invalid admin listen address: %w", err)
+	}
+	if host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return nil
+	}
+	return fmt.Errorf(`the admin server does not authenticate its callers, so it can only listen on a loopback address or a unix socket, not "%s"`, addr)
+}
+
+// Serve serves the admin API on the configured address.
+func (s *adminServer) Serve(ipMode common.IPMode) error {
+	lis, err := adminListener(s.httpServer.Addr, ipMode)
+	if err != nil {
+		return err
+	}
+	return s.httpServer.Serve(lis)
+}
+
+// handleListUsers returns all configured users, including disabled ones. API keys are masked.
+func (s *adminServer) handleListUsers(w http.ResponseWriter, r *http.Request) {
+	users, err := s.permissions.Users()
+	if err != nil {
+		s.logger.Error("failed to list users", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	resp := listUsersResponse{Users: make([]User, 0, len(users))}
+	for _, user := range users {
+		resp.Users = append(resp.Users, maskApiKey(user))
+	}
+	s.writeJSON(w, http.StatusOK, resp)
+}
+
+// handleCreateUser adds a new user to the permissions file.
+func (s *adminServer) handleCreateUser(w http.ResponseWriter, r *http.Request) {
+	var user User
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, MAX_BODY_SIZE)).Decode(&user); err != nil {
+		s.writeJSON(w, http.StatusBadRequest, updateUserResponse{Error: fmt.Sprintf("failed to decode user: %v", err)})
+		return
+	}
+	if user.UserName == "" || user.ApiKey == "" {
+		s.writeJSON(w, http.StatusBadRequest, updateUserResponse{Error: "userName and apiKey are required"})
+		return
+	}
+
+	s.logger.Info("user creation requested", zap.String("userName", user.UserName), zap.String("remoteAddr", r.RemoteAddr))
+	err := s.permissions.UpdateUsers(s.logger, func(users []User) ([]User, error) {
+		if findUser(users, user.UserName) >= 0 {
+			return nil, errUserExists
+		}
+		return append(users, user), nil
+	})
+	s.writeUpdateResult(w, user, err, http.StatusCreated)
+}
+
+// handleUpdateUser replaces the settings of an existing user. If the API key is omitted, the existing one is kept.
+func (s *adminServer) handleUpdateUser(w http.ResponseWriter, r *http.Request) {
+	userName := mux.Vars(r)["userName"]
+	var user User
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, MAX_BODY_SIZE)).Decode(&user); err != nil {
+		s.writeJSON(w, http.StatusBadRequest, updateUserResponse{Error: fmt.Sprintf("failed to decode user: %v", err)})
+		return
+	}
+	if user.UserName != "" && user.UserName != userName {
+		s.writeJSON(w, http.StatusBadRequest, updateUserResponse{Error: "users may not be renamed"})
+		return
+	}
+	user.UserName = userName
+
+	s.logger.Info("user update requested", zap.String("userName", userName), zap.String("remoteAddr", r.RemoteAddr))
+	err := s.permissions.UpdateUsers(s.logger, func(users []User) ([]User, error) {
+		idx := findUser(users, userName)
+		if idx < 0 {
+			return nil, errUserNotFound
+		}
+		if user.ApiKey == "" {
+			user.ApiKey = users[idx].ApiKey
+		}
+		users[idx] = user
+		return users, nil
+	})
+	s.writeUpdateResult(w, user, err, http.StatusOK)
+}
+
+// handleSetUserDisabled returns a handler that disables or re-enables a user without touching the rest of their settings.
+func (s *adminServer) handleSetUserDisabled(disabled bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userName := mux.Vars(r)["userName"]
+		s.logger.Info("user status change requested", zap.String("userName", userName), zap.Bool("disabled", disabled), zap.String("remoteAddr", r.RemoteAddr))
+
+		user := User{UserName: userName}
+		err := s.permissions.UpdateUsers(s.logger, func(users []User) ([]User, error) {
+			idx := findUser(users, userName)
+			if idx < 0 {
+				return nil, errUserNotFound
+			}
+			users[idx].Disabled = disabled
+			user = users[idx]
+			return users, nil
+		})
+		s.writeUpdateResult(w, user, err, http.StatusOK)
+	}
+}
+
+func (s *adminServer) writeUpdateResult(w http.ResponseWriter, user User, err error, successStatus int) {
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, errUserNotFound) {
+			status = http.StatusNotFound
+		} else if errors.Is(err, errUserExists) {
+			status = http.StatusConflict
+		}
+		s.logger.Error("failed to update permissions", zap.String("userName", user.UserName), zap.Error(err))
+		s.writeJSON(w, status, updateUserResponse{Error: err.Error()})
+		return
+	}
+	masked := maskApiKey(user)
+	s.writeJSON(w, successStatus, updateUserResponse{Success: true, User: &masked})
+}
+
+func (s *adminServer) writeJSON(w http.ResponseWriter, status int, resp interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		s.logger.Error("failed to encode admin response", zap.Error(err))
+	}
+}
+
+func findUser(users []User, userName string) int {
+	for idx := range users {
+		if users[idx].UserName == userName {
+			return idx
+		}
+	}
+	return -1
+}
+
+// maskApiKey hides all but the last four characters of the API key, which is enough for an operator to tell keys apart.
+func maskApiKey(user User) User {
+	if len(user.ApiKey) > 4 {
+		user.ApiKey = strings.Repeat("*", len(user.ApiKey)-4) + user.ApiKey[len(user.ApiKey)-4:]
+	} else {
+		user.ApiKey = strings.Repeat("*", len(user.ApiKey))
+	}
+	return user
+}
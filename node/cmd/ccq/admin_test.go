@@ -0,0 +1,218 @@
+package ccq
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const adminTestPermsOneUser = `
+{
+  "permissions": [
+    {
+      "userName": "Test User",
+      "apiKey": "my_secret_key",
+      "allowedCalls": [
+        {
+          "solAccount": {
+            "chain": 1,
+            "account": "BVxyYhm498L79r4HMQ9sxZ5bi41DmJmeWZ7SCS7Cyvna"
+          }
+        }
+      ]
+    }
+  ]
+}`
+
+const adminTestPermsTwoUsers = `
+{
+  "permissions": [
+    {
+      "userName": "Test User",
+      "apiKey": "my_secret_key",
+      "allowedCalls": []
+    },
+    {
+      "userName": "Test User 2",
+      "apiKey": "my_secret_key_2",
+      "allowedCalls": []
+    }
+  ]
+}`
+
+func reloadViaAdminServer(t *testing.T, s *adminServer) (int, reloadPermissionsResponse) {
+	t.Helper()
+	req := httptest.NewRequest("POST", "/v1/permissions/reload", nil)
+	rec := httptest.NewRecorder()
+	s.httpServer.Handler.ServeHTTP(rec, req)
+
+	var resp reloadPermissionsResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return rec.Code, resp
+}
+
+func TestAdminReloadPermissions(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "perms.json")
+	require.NoError(t, os.WriteFile(fileName, []byte(adminTestPermsOneUser), 0600))
+
+	perms, err := NewPermissions(fileName)
+	require.NoError(t, err)
+	s := NewAdminServer("", zap.NewNop(), perms)
+
+	require.NoError(t, os.WriteFile(fileName, []byte(adminTestPermsTwoUsers), 0600))
+	code, resp := reloadViaAdminServer(t, s)
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, resp.Success)
+	assert.Equal(t, 2, resp.NumUsers)
+
+	_, exists := perms.GetUserEntry("my_secret_key_2")
+	assert.True(t, exists)
+}
+
+func TestAdminReloadPermissionsInvalidFileKeepsOldPermissions(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "perms.json")
+	require.NoError(t, os.WriteFile(fileName, []byte(adminTestPermsOneUser), 0600))
+
+	perms, err := NewPermissions(fileName)
+	require.NoError(t, err)
+	s := NewAdminServer("", zap.NewNop(), perms)
+
+	require.NoError(t, os.WriteFile(fileName, []byte(`{"permissions": [`), 0600))
+	code, resp := reloadViaAdminServer(t, s)
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.False(t, resp.Success)
+	assert.NotEmpty(t, resp.Error)
+	assert.Equal(t, 1, resp.NumUsers)
+
+	_, exists := perms.GetUserEntry("my_secret_key")
+	assert.True(t, exists)
+}
+
+func TestAdminReloadPermissionsRequiresPost(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "perms.json")
+	require.NoError(t, os.WriteFile(fileName, []byte(adminTestPermsOneUser), 0600))
+
+	perms, err := NewPermissions(fileName)
+	require.NoError(t, err)
+	s := NewAdminServer("", zap.NewNop(), perms)
+
+	req := httptest.NewRequest("GET", "/v1/permissions/reload", nil)
+	rec := httptest.NewRecorder()
+	s.httpServer.Handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func adminRequest(t *testing.T, s *adminServer, method, url, body string) (int, updateUserResponse) {
+	t.Helper()
+	req := httptest.NewRequest(method, url, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	s.httpServer.Handler.ServeHTTP(rec, req)
+
+	var resp updateUserResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return rec.Code, resp
+}
+
+func TestAdminManageUsers(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "perms.json")
+	require.NoError(t, os.WriteFile(fileName, []byte(adminTestPermsOneUser), 0600))
+
+	perms, err := NewPermissions(fileName)
+	require.NoError(t, err)
+	s := NewAdminServer("", zap.NewNop(), perms)
+
+	// Create a user.
+	code, resp := adminRequest(t, s, "POST", "/v1/users", `{"userName": "Test User 2", "apiKey": "my_secret_key_2", "rateLimit": 5}`)
+	require.Equal(t, http.StatusCreated, code, resp.Error)
+	assert.Equal(t, "***********ey_2", resp.User.ApiKey)
+	pe, exists := perms.GetUserEntry("my_secret_key_2")
+	require.True(t, exists)
+	assert.Equal(t, 5.0, pe.rateLimit)
+
+	code, _ = adminRequest(t, s, "POST", "/v1/users", `{"userName": "Test User 2", "apiKey": "my_secret_key_3"}`)
+	assert.Equal(t, http.StatusConflict, code)
+
+	// Invalid updates are rejected without touching the file.
+	code, resp = adminRequest(t, s, "POST", "/v1/users", `{"userName": "Test User 3", "apiKey": "my_secret_key"}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, resp.Error, "duplicate")
+
+	// Update a user, keeping their API key.
+	code, resp = adminRequest(t, s, "PUT", "/v1/users/Test%20User%202", `{"rateLimit": 10}`)
+	require.Equal(t, http.StatusOK, code, resp.Error)
+	pe, exists = perms.GetUserEntry("my_secret_key_2")
+	require.True(t, exists)
+	assert.Equal(t, 10.0, pe.rateLimit)
+
+	code, _ = adminRequest(t, s, "PUT", "/v1/users/Nobody", `{"rateLimit": 10}`)
+	assert.Equal(t, http.StatusNotFound, code)
+
+	// Disable and re-enable a user.
+	code, _ = adminRequest(t, s, "POST", "/v1/users/Test%20User%202/disable", "")
+	require.Equal(t, http.StatusOK, code)
+	_, exists = perms.GetUserEntry("my_secret_key_2")
+	assert.False(t, exists)
+
+	code, _ = adminRequest(t, s, "POST", "/v1/users/Test%20User%202/enable", "")
+	require.Equal(t, http.StatusOK, code)
+	_, exists = perms.GetUserEntry("my_secret_key_2")
+	assert.True(t, exists)
+
+	// The changes were persisted, so they survive a reload from the file.
+	require.NoError(t, perms.Reload(zap.NewNop()))
+	pe, exists = perms.GetUserEntry("my_secret_key_2")
+	require.True(t, exists)
+	assert.Equal(t, 10.0, pe.rateLimit)
+
+	req := httptest.NewRequest("GET", "/v1/users", nil)
+	rec := httptest.NewRecorder()
+	s.httpServer.Handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var list listUsersResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+	require.Len(t, list.Users, 2)
+	assert.Equal(t, "Test User", list.Users[0].UserName)
+	assert.Equal(t, "*********_key", list.Users[0].ApiKey)
+}
+
+func TestParseConfigDisabledUser(t *testing.T) {
+	permMap, err := parseConfig([]byte(adminTestPermsTwoUsers))
+	require.NoError(t, err)
+	assert.Len(t, permMap, 2)
+
+	str := strings.Replace(adminTestPermsTwoUsers, `"apiKey": "my_secret_key_2",`, `"apiKey": "my_secret_key_2", "disabled": true,`, 1)
+	permMap, err = parseConfig([]byte(str))
+	require.NoError(t, err)
+	assert.Len(t, permMap, 1)
+	_, exists := permMap["my_secret_key_2"]
+	assert.False(t, exists)
+}
+
+func TestAdminListener(t *testing.T) {
+	for _, addr := range []string{"0.0.0.0:0", ":0", "[::]:0", "example.com:0", "10.0.0.1:0"} {
+		_, err := adminListener(addr, common.IPModeDual)
+		assert.ErrorContains(t, err, "loopback", addr)
+	}
+
+	lis, err := adminListener("127.0.0.1:0", common.IPModeDual)
+	require.NoError(t, err)
+	lis.Close()
+
+	// The socket is only accessible to the user running the server.
+	path := filepath.Join(t.TempDir(), "admin.sock")
+	lis, err = adminListener("unix:"+path, common.IPModeDual)
+	require.NoError(t, err)
+	defer lis.Close()
+	fi, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+}
@@ -0,0 +1,69 @@
+package ccq
+
+import (
+	"sync"
+)
+
+// ADMISSION_RETRY_AFTER_SECS is the value returned in the Retry-After header when a request is rejected because the server is saturated.
+const ADMISSION_RETRY_AFTER_SECS = "1"
+
+const (
+	admissionOK           = ""
+	admissionGlobalLimit  = "global_limit"
+	admissionPerUserLimit = "per_user_limit"
+)
+
+// AdmissionControl bounds the number of query requests being processed concurrently, both across all users and per user.
+// A limit of zero means unlimited. Requests that cannot be admitted are rejected immediately rather than queued, so a
+// burst of traffic cannot pile up an unbounded number of goroutines waiting on the p2p network.
+type AdmissionControl struct {
+	maxInFlight        int
+	maxInFlightPerUser int
+
+	mutex       sync.Mutex
+	inFlight    int
+	perUserLoad map[string]int
+}
+
+// NewAdmissionControl creates an admission controller with the specified global and per user limits.
+func NewAdmissionControl(maxInFlight int, maxInFlightPerUser int) *AdmissionControl {
+	return &AdmissionControl{
+		maxInFlight:        maxInFlight,
+		maxInFlightPerUser: maxInFlightPerUser,
+		perUserLoad:        make(map[string]int),
+	}
+}
+
+// tryAcquire attempts to reserve a slot for a request from the specified user. On success, it returns a release function
+// that must be called when the request completes. On failure, it returns the reason the request was rejected.
+func (ac *AdmissionControl) tryAcquire(userName string) (func(), string) {
+	ac.mutex.Lock()
+	defer ac.mutex.Unlock()
+
+	if ac.maxInFlight > 0 && ac.inFlight >= ac.maxInFlight {
+		return nil, admissionGlobalLimit
+	}
+
+	if ac.maxInFlightPerUser > 0 && ac.perUserLoad[userName] >= ac.maxInFlightPerUser {
+		return nil, admissionPerUserLimit
+	}
+
+	ac.inFlight++
+	ac.perUserLoad[userName]++
+	currentInFlightRequests.Set(float64(ac.inFlight))
+
+	var once sync.Once
+	return func() { once.Do(func() { ac.release(userName) }) }, admissionOK
+}
+
+func (ac *AdmissionControl) release(userName string) {
+	ac.mutex.Lock()
+	defer ac.mutex.Unlock()
+
+	ac.inFlight--
+	ac.perUserLoad[userName]--
+	if ac.perUserLoad[userName] <= 0 {
+		delete(ac.perUserLoad, userName)
+	}
+	currentInFlightRequests.Set(float64(ac.inFlight))
+}
@@ -0,0 +1,65 @@
+package ccq
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdmissionControlUnlimited(t *testing.T) {
+	ac := NewAdmissionControl(0, 0)
+	for i := 0; i < 100; i++ {
+		_, reason := ac.tryAcquire("alice")
+		require.Equal(t, admissionOK, reason)
+	}
+	assert.Equal(t, 100, ac.inFlight)
+}
+
+func TestAdmissionControlPerUserLimit(t *testing.T) {
+	ac := NewAdmissionControl(0, 2)
+
+	release1, reason := ac.tryAcquire("alice")
+	require.Equal(t, admissionOK, reason)
+	_, reason = ac.tryAcquire("alice")
+	require.Equal(t, admissionOK, reason)
+
+	_, reason = ac.tryAcquire("alice")
+	assert.Equal(t, admissionPerUserLimit, reason)
+
+	// Another user is not affected by alice's load.
+	_, reason = ac.tryAcquire("bob")
+	assert.Equal(t, admissionOK, reason)
+
+	release1()
+	_, reason = ac.tryAcquire("alice")
+	assert.Equal(t, admissionOK, reason)
+}
+
+func TestAdmissionControlGlobalLimit(t *testing.T) {
+	ac := NewAdmissionControl(2, 0)
+
+	release1, reason := ac.tryAcquire("alice")
+	require.Equal(t, admissionOK, reason)
+	_, reason = ac.tryAcquire("bob")
+	require.Equal(t, admissionOK, reason)
+
+	_, reason = ac.tryAcquire("carol")
+	assert.Equal(t, admissionGlobalLimit, reason)
+
+	release1()
+	_, reason = ac.tryAcquire("carol")
+	assert.Equal(t, admissionOK, reason)
+}
+
+func TestAdmissionControlReleaseIsIdempotent(t *testing.T) {
+	ac := NewAdmissionControl(1, 1)
+
+	release, reason := ac.tryAcquire("alice")
+	require.Equal(t, admissionOK, reason)
+	release()
+	release()
+
+	assert.Equal(t, 0, ac.inFlight)
+	assert.Equal(t, 0, len(ac.perUserLoad))
+}
@@ -0,0 +1,100 @@
+package ccq
+
+import (
+	"crypto/sha256"
+	"sync"
+	"time"
+)
+
+// ResponseCache holds recent signed responses so that identical queries received within the TTL can be answered without going back
+// to the guardians. Entries are keyed by the serialized query request, so a cached response carries the signature of the request
+// that populated the cache. A nil cache is valid and disables caching.
+type ResponseCache struct {
+	ttl        time.Duration
+	maxEntries int
+
+	mutex   sync.Mutex
+	entries map[[sha256.Size]byte]*cacheEntry
+}
+
+type cacheEntry struct {
+	res     *SignedResponse
+	expires time.Time
+}
+
+// NewResponseCache creates a response cache. It returns nil if the TTL is zero, which disables caching.
+func NewResponseCache(ttl time.Duration, maxEntries int) *ResponseCache {
+	if ttl <= 0 || maxEntries <= 0 {
+		return nil
+	}
+	return &ResponseCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[[sha256.Size]byte]*cacheEntry),
+	}
+}
+
+// Get returns the cached response for the query request, if there is one that has not expired.
+func (c *ResponseCache) Get(queryRequest []byte, now time.Time) (*SignedResponse, bool) {
+	if c == nil {
+		return nil, false
+	}
+	key := sha256.Sum256(queryRequest)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	entry, exists := c.entries[key]
+	if !exists {
+		return nil, false
+	}
+	if !now.Before(entry.expires) {
+		delete(c.entries, key)
+		queryCacheEntries.Set(float64(len(c.entries)))
+		return nil, false
+	}
+
+	// The signatures get sorted in place when the response is published, so every caller gets their own copy.
+	signatures := make([]GuardianSignature, len(entry.res.Signatures))
+	copy(signatures, entry.res.Signatures)
+	return &SignedResponse{Response: entry.res.Response, Signatures: signatures}, true
+}
+
+// Add caches a response that reached quorum. If the cache is full, expired entries are dropped, followed by the oldest ones.
+func (c *ResponseCache) Add(queryRequest []byte, res *SignedResponse, now time.Time) {
+	if c == nil {
+		return
+	}
+	key := sha256.Sum256(queryRequest)
+	signatures := make([]GuardianSignature, len(res.Signatures))
+	copy(signatures, res.Signatures)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, exists := c.entries[key]; !exists && len(c.entries) >= c.maxEntries {
+		c.evictAlreadyLocked(now)
+	}
+	c.entries[key] = &cacheEntry{
+		res:     &SignedResponse{Response: res.Response, Signatures: signatures},
+		expires: now.Add(c.ttl),
+	}
+	queryCacheEntries.Set(float64(len(c.entries)))
+}
+
+// evictAlreadyLocked makes room for at least one more entry. Since every entry has the same TTL, the oldest entry is the one which expires first.
+func (c *ResponseCache) evictAlreadyLocked(now time.Time) {
+	var oldestKey [sha256.Size]byte
+	var oldest time.Time
+	for key, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, key)
+			continue
+		}
+		if oldest.IsZero() || entry.expires.Before(oldest) {
+			oldestKey = key
+			oldest = entry.expires
+		}
+	}
+	if len(c.entries) >= c.maxEntries {
+		delete(c.entries, oldestKey)
+	}
+}
@@ -0,0 +1,68 @@
+package ccq
+
+import (
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseCacheDisabled(t *testing.T) {
+	c := NewResponseCache(0, 100)
+	assert.Nil(t, c)
+
+	// A nil cache is safe to use.
+	c.Add([]byte("query"), &SignedResponse{}, time.Now())
+	_, exists := c.Get([]byte("query"), time.Now())
+	assert.False(t, exists)
+}
+
+func TestResponseCacheExpires(t *testing.T) {
+	c := NewResponseCache(time.Minute, 100)
+	require.NotNil(t, c)
+
+	now := time.Now()
+	res := &SignedResponse{
+		Response:   &query.QueryResponsePublication{},
+		Signatures: []GuardianSignature{{Index: 1, Signature: "01"}, {Index: 0, Signature: "00"}},
+	}
+	c.Add([]byte("query"), res, now)
+
+	cached, exists := c.Get([]byte("query"), now.Add(59*time.Second))
+	require.True(t, exists)
+	assert.Equal(t, res.Response, cached.Response)
+	assert.Equal(t, res.Signatures, cached.Signatures)
+
+	// Callers may sort the signatures without affecting the cached copy.
+	cached.Signatures[0], cached.Signatures[1] = cached.Signatures[1], cached.Signatures[0]
+	cached, exists = c.Get([]byte("query"), now)
+	require.True(t, exists)
+	assert.Equal(t, 1, cached.Signatures[0].Index)
+
+	_, exists = c.Get([]byte("other query"), now)
+	assert.False(t, exists)
+
+	_, exists = c.Get([]byte("query"), now.Add(time.Minute))
+	assert.False(t, exists)
+	assert.Empty(t, c.entries)
+}
+
+func TestResponseCacheEvictsOldest(t *testing.T) {
+	c := NewResponseCache(time.Minute, 2)
+	require.NotNil(t, c)
+
+	now := time.Now()
+	c.Add([]byte("first"), &SignedResponse{}, now)
+	c.Add([]byte("second"), &SignedResponse{}, now.Add(time.Second))
+	c.Add([]byte("third"), &SignedResponse{}, now.Add(2*time.Second))
+
+	assert.Equal(t, 2, len(c.entries))
+	_, exists := c.Get([]byte("first"), now.Add(2*time.Second))
+	assert.False(t, exists)
+	_, exists = c.Get([]byte("second"), now.Add(2*time.Second))
+	assert.True(t, exists)
+	_, exists = c.Get([]byte("third"), now.Add(2*time.Second))
+	assert.True(t, exists)
+}
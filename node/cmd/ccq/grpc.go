@@ -0,0 +1,216 @@
+package ccq
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	ccqv1 "github.com/certusone/wormhole/node/pkg/proto/ccq/v1"
+	"github.com/certusone/wormhole/node/pkg/query"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// grpcQueryServer implements the gRPC query service on top of the same submission logic as the REST API.
+type grpcQueryServer struct {
+	ccqv1.UnimplementedQueryServiceServer
+	s *httpServer
+}
+
+func NewGRPCServer(t *pubsub.Topic, permissions *Permissions, signerKey *ecdsa.PrivateKey, p *PendingResponses, logger *zap.Logger, env common.Environment, loggingMap *LoggingMap, admission *AdmissionControl, cache *ResponseCache, jwtAuth *JWTAuthenticator, journal *RequestJournal, selector *GuardianSelector) *grpc.Server {
+	s := &grpcQueryServer{
+		s: &httpServer{
+			topic:            t,
+			permissions:      permissions,
+			signerKey:        signerKey,
+			pendingResponses: p,
+			logger:           logger,
+			env:              env,
+			loggingMap:       loggingMap,
+			admission:        admission,
+			cache:            cache,
+			jwtAuth:          jwtAuth,
+			journal:          journal,
+			selector:         selector,
+		},
+	}
+	grpcServer := common.NewInstrumentedGRPCServer(logger, common.GrpcLogDetailMinimal)
+	ccqv1.RegisterQueryServiceServer(grpcServer, s)
+	return grpcServer
+}
+
+func (g *grpcQueryServer) SubmitQuery(ctx context.Context, req *ccqv1.SubmitQueryRequest) (*ccqv1.SubmitQueryResponse, error) {
+	return g.runQuery(ctx, req, nil)
+}
+
+func (g *grpcQueryServer) StreamQuery(req *ccqv1.SubmitQueryRequest, stream ccqv1.QueryService_StreamQueryServer) error {
+	resp, err := g.runQuery(stream.Context(), req, func(msg *ccqv1.StreamQueryResponse) error {
+		return stream.Send(msg)
+	})
+	if err != nil {
+		return err
+	}
+	return stream.Send(&ccqv1.StreamQueryResponse{Message: &ccqv1.StreamQueryResponse_Response{Response: resp}})
+}
+
+// runQuery authenticates the caller, submits the query and waits for the result. If send is not nil, it is used to report that the
+// query was accepted and how it is progressing. Errors are returned as gRPC status errors.
+func (g *grpcQueryServer) runQuery(ctx context.Context, req *ccqv1.SubmitQueryRequest, send func(*ccqv1.StreamQueryResponse) error) (*ccqv1.SubmitQueryResponse, error) {
+	s := g.s
+	start := time.Now()
+	allQueryRequestsReceived.Inc()
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	permEntry, httpStatus, reason, err := s.authenticate(ctx, md.Get("x-api-key"), md.Get("authorization"))
+	if err != nil {
+		s.logger.Error("failed to authenticate grpc request", zap.String("reason", reason), zap.Error(err))
+		invalidQueryRequestReceived.WithLabelValues(reason).Inc()
+		return nil, status.Error(grpcCodeFromHTTPStatus(httpStatus), err.Error())
+	}
+	apiKey := permEntry.apiKey
+	totalRequestsByUser.WithLabelValues(permEntry.userName).Inc()
+
+	release, reason := s.admission.tryAcquire(permEntry.userName)
+	if reason != admissionOK {
+		s.logger.Warn("rejecting grpc request because the server is saturated", zap.String("userId", permEntry.userName), zap.String("reason", reason))
+		invalidQueryRequestReceived.WithLabelValues("server_saturated").Inc()
+		requestsRejectedByAdmissionControl.WithLabelValues(reason, permEntry.userName).Inc()
+		setRetryAfter(ctx, ADMISSION_RETRY_AFTER_SECS)
+		return nil, status.Error(codes.Unavailable, "server is busy, please retry later")
+	}
+	defer release()
+
+	q := &queryRequest{Bytes: hex.EncodeToString(req.QueryRequest), Signature: hex.EncodeToString(req.Signature)}
+	pendingResponse, requestId, httpStatus, err := s.submitQuery(ctx, permEntry, apiKey, q, send != nil)
+	if err != nil {
+		var rle *rateLimitError
+		if errors.As(err, &rle) {
+			setRetryAfter(ctx, rle.retryAfterSecs())
+		}
+		return nil, status.Error(grpcCodeFromHTTPStatus(httpStatus), err.Error())
+	}
+	defer s.pendingResponses.Remove(pendingResponse)
+
+	if send != nil {
+		if err := send(&ccqv1.StreamQueryResponse{Message: &ccqv1.StreamQueryResponse_Accepted{Accepted: &ccqv1.QueryAccepted{RequestId: requestId}}}); err != nil {
+			return nil, err
+		}
+	}
+
+	timeout := time.NewTimer(query.RequestTimeout + 5*time.Second)
+	defer timeout.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("grpc client went away before query completed", zap.String("userId", permEntry.userName), zap.String("requestId", requestId))
+			return nil, status.FromContextError(ctx.Err()).Err()
+		case <-timeout.C:
+			s.logger.Info("publishing time out to grpc client", zap.String("userId", permEntry.userName), zap.String("requestId", requestId))
+			queryTimeoutsByUser.WithLabelValues(permEntry.userName).Inc()
+			failedQueriesByUser.WithLabelValues(permEntry.userName).Inc()
+			return nil, status.Error(codes.DeadlineExceeded, "Timed out waiting for response")
+		case p := <-pendingResponse.progressCh:
+			if err := send(&ccqv1.StreamQueryResponse{Message: &ccqv1.StreamQueryResponse_Progress{Progress: newGRPCQueryProgress(p)}}); err != nil {
+				return nil, err
+			}
+		case res := <-pendingResponse.ch:
+			s.logger.Info("publishing response to grpc client", zap.String("userId", permEntry.userName), zap.String("requestId", requestId))
+			resp, err := newGRPCQueryResponse(requestId, res)
+			if err != nil {
+				s.logger.Error("failed to marshal response", zap.String("userId", permEntry.userName), zap.String("requestId", requestId), zap.Error(err))
+				invalidQueryRequestReceived.WithLabelValues("failed_to_marshal_response").Inc()
+				failedQueriesByUser.WithLabelValues(permEntry.userName).Inc()
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			successfulQueriesByUser.WithLabelValues(permEntry.userName).Inc()
+			totalQueryTime.Observe(float64(time.Since(start).Milliseconds()))
+			validQueryRequestsReceived.Inc()
+			return resp, nil
+		case errEntry := <-pendingResponse.errCh:
+			s.logger.Info("publishing error response to grpc client", zap.String("userId", permEntry.userName), zap.String("requestId", requestId), zap.Int("status", errEntry.status), zap.Error(errEntry.err))
+			// Metrics have already been pegged.
+			return nil, status.Error(grpcCodeFromHTTPStatus(errEntry.status), errEntry.err.Error())
+		}
+	}
+}
+
+// newGRPCQueryResponse converts a response which reached quorum into the gRPC response message.
+func newGRPCQueryResponse(requestId string, res *SignedResponse) (*ccqv1.SubmitQueryResponse, error) {
+	resp, err := newQueryResponse(res)
+	if err != nil {
+		return nil, err
+	}
+	respBytes, err := hex.DecodeString(resp.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	signatures := make([][]byte, 0, len(resp.Signatures))
+	for _, sig := range resp.Signatures {
+		b, err := hex.DecodeString(sig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode signature: %w", err)
+		}
+		signatures = append(signatures, b)
+	}
+	return &ccqv1.SubmitQueryResponse{
+		RequestId:     requestId,
+		QueryResponse: respBytes,
+		Signatures:    signatures,
+	}, nil
+}
+
+// newGRPCQueryProgress converts a progress report into the gRPC progress message.
+func newGRPCQueryProgress(p *QueryProgress) *ccqv1.QueryProgress {
+	chains := make([]*ccqv1.ChainProgress, len(p.Chains))
+	for i, c := range p.Chains {
+		chains[i] = &ccqv1.ChainProgress{ChainId: uint32(c.ChainId), Response: c.Response, MatchingResponses: uint32(c.MatchingResponses)}
+	}
+	return &ccqv1.QueryProgress{
+		NumResponses:         uint32(p.NumSigners),
+		MaxMatchingResponses: uint32(p.MaxMatchingResponses),
+		OutstandingResponses: uint32(p.OutstandingResponses),
+		Quorum:               uint32(p.Quorum),
+		Chains:               chains,
+		GuardiansQueried:     uint32(p.GuardiansQueried),
+		FailedOver:           p.FailedOver,
+	}
+}
+
+// setRetryAfter tells the client when to resubmit a rejected query, using the same value as the Retry-After header of the REST API.
+func setRetryAfter(ctx context.Context, secs string) {
+	_ = grpc.SetTrailer(ctx, metadata.Pairs("retry-after", secs))
+}
+
+// grpcCodeFromHTTPStatus maps the HTTP status codes used by the REST API to the equivalent gRPC codes.
+func grpcCodeFromHTTPStatus(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusOK:
+		return codes.OK
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	case http.StatusInternalServerError:
+		return codes.Internal
+	default:
+		return codes.Unknown
+	}
+}
@@ -0,0 +1,77 @@
+package ccq
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	ccqv1 "github.com/certusone/wormhole/node/pkg/proto/ccq/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newTestGRPCClient(t *testing.T) ccqv1.QueryServiceClient {
+	t.Helper()
+	perms := &Permissions{
+		permMap: PermissionsMap{
+			"my_secret_key": &permissionEntry{userName: "Test User", apiKey: "my_secret_key"},
+		},
+	}
+	logger := zap.NewNop()
+	s := NewGRPCServer(nil, perms, nil, NewPendingResponses(logger, nil), logger, common.GoTest, NewLoggingMap(), NewAdmissionControl(0, 0), nil, nil, nil, nil)
+
+	lis := bufconn.Listen(1024 * 1024)
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return ccqv1.NewQueryServiceClient(conn)
+}
+
+func TestGRPCRequiresApiKey(t *testing.T) {
+	client := newTestGRPCClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := client.SubmitQuery(ctx, &ccqv1.SubmitQueryRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = client.SubmitQuery(metadata.AppendToOutgoingContext(ctx, "x-api-key", "bogus"), &ccqv1.SubmitQueryRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestGRPCInvalidQuery(t *testing.T) {
+	client := newTestGRPCClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "x-api-key", "My_Secret_Key")
+
+	_, err := client.SubmitQuery(ctx, &ccqv1.SubmitQueryRequest{QueryRequest: []byte{0x01}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	stream, err := client.StreamQuery(ctx, &ccqv1.SubmitQueryRequest{QueryRequest: []byte{0x01}})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGRPCCodeFromHTTPStatus(t *testing.T) {
+	assert.Equal(t, codes.ResourceExhausted, grpcCodeFromHTTPStatus(http.StatusTooManyRequests))
+	assert.Equal(t, codes.Unavailable, grpcCodeFromHTTPStatus(http.StatusServiceUnavailable))
+	assert.Equal(t, codes.Unknown, grpcCodeFromHTTPStatus(http.StatusTeapot))
+}
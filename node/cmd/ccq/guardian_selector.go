@@ -0,0 +1,237 @@
+package ccq
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// guardianLatencyWeight is the weight of the newest sample in a guardian's moving average latency.
+	guardianLatencyWeight = 0.2
+
+	// guardianMaxMisses is the number of consecutive requests a guardian may fail to answer before it is no longer selected.
+	guardianMaxMisses = 3
+
+	// guardianExclusionPeriod is how long a guardian that stopped answering is skipped before it is given another chance.
+	guardianExclusionPeriod = time.Minute
+)
+
+// GuardianSelector decides which guardians a request is sent to. It prefers the guardians that have answered fastest in the past and
+// skips those that stopped answering. A nil GuardianSelector, or one with a fan-out of zero, sends every request to all guardians.
+type GuardianSelector struct {
+	fanOut          int
+	failoverTimeout time.Duration
+
+	mutex  sync.Mutex
+	keys   []ethCommon.Address
+	stats  map[ethCommon.Address]*guardianStats
+	quorum int
+}
+
+// guardianStats is what the selector knows about a single guardian.
+type guardianStats struct {
+	// latency is the moving average time to answer a request. It is zero until the first answer.
+	latency           time.Duration
+	consecutiveMisses int
+	excludedUntil     time.Time
+}
+
+// NewGuardianSelector creates a selector which sends each request to fanOut guardians, or to a quorum of them if that is more. Requests
+// which have not reached quorum after failoverTimeout are sent to the remaining guardians.
+func NewGuardianSelector(fanOut int, failoverTimeout time.Duration) *GuardianSelector {
+	return &GuardianSelector{
+		fanOut:          fanOut,
+		failoverTimeout: failoverTimeout,
+		stats:           make(map[ethCommon.Address]*guardianStats),
+	}
+}
+
+// setGuardianSet sets the guardians to select from. Statistics are kept for guardians which remain in the set.
+func (s *GuardianSelector) setGuardianSet(keys []ethCommon.Address) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.keys = keys
+	s.quorum = vaa.CalculateQuorum(len(keys))
+	stats := make(map[ethCommon.Address]*guardianStats, len(keys))
+	for _, key := range keys {
+		if st, exists := s.stats[key]; exists {
+			stats[key] = st
+		} else {
+			stats[key] = &guardianStats{}
+		}
+	}
+	s.stats = stats
+}
+
+// Select returns the guardians a new request should be sent to, or nil if it should be sent to all of them. Guardians without a latency
+// sample sort first so that every guardian gets measured.
+func (s *GuardianSelector) Select(now time.Time) []ethCommon.Address {
+	if s == nil || s.fanOut <= 0 {
+		return nil
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	fanOut := s.fanOut
+	if fanOut < s.quorum {
+		fanOut = s.quorum
+	}
+	if len(s.keys) == 0 || fanOut >= len(s.keys) {
+		return nil
+	}
+
+	candidates := make([]ethCommon.Address, 0, len(s.keys))
+	var excluded []ethCommon.Address
+	for _, key := range s.keys {
+		if now.Before(s.stats[key].excludedUntil) {
+			excluded = append(excluded, key)
+		} else {
+			candidates = append(candidates, key)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return s.stats[candidates[i]].latency < s.stats[candidates[j]].latency
+	})
+
+	// If too many guardians are excluded, fill up with them rather than making quorum impossible.
+	candidates = append(candidates, excluded...)
+	return candidates[:fanOut]
+}
+
+// remaining returns the guardians in the current set which are not in targets.
+func (s *GuardianSelector) remaining(targets []ethCommon.Address) []ethCommon.Address {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	targeted := make(map[ethCommon.Address]struct{}, len(targets))
+	for _, key := range targets {
+		targeted[key] = struct{}{}
+	}
+	var rest []ethCommon.Address
+	for _, key := range s.keys {
+		if _, exists := targeted[key]; !exists {
+			rest = append(rest, key)
+		}
+	}
+	return rest
+}
+
+// size returns the number of guardians in the guardian set and its quorum.
+func (s *GuardianSelector) size() (int, int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return len(s.keys), s.quorum
+}
+
+// guardianAnswered records that a guardian answered a request after the given latency.
+func (s *GuardianSelector) guardianAnswered(key ethCommon.Address, latency time.Duration) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	st, exists := s.stats[key]
+	if !exists {
+		return
+	}
+	if st.latency == 0 {
+		st.latency = latency
+	} else {
+		st.latency = time.Duration(guardianLatencyWeight*float64(latency) + (1-guardianLatencyWeight)*float64(st.latency))
+	}
+	st.consecutiveMisses = 0
+	st.excludedUntil = time.Time{}
+}
+
+// guardianMissed records that a guardian did not answer a request it was sent within the failover timeout.
+func (s *GuardianSelector) guardianMissed(key ethCommon.Address, now time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	st, exists := s.stats[key]
+	if !exists {
+		return
+	}
+	st.consecutiveMisses++
+	if st.consecutiveMisses >= guardianMaxMisses {
+		st.consecutiveMisses = 0
+		st.excludedUntil = now.Add(guardianExclusionPeriod)
+		guardiansExcludedFromFanOut.Inc()
+	}
+}
+
+// publishQueryRequest sends a request to the guardians chosen by the selector. If the request has not reached quorum once the failover
+// timeout has passed, the guardians that have not answered are charged with a miss and the request is sent to the remaining guardians.
+func publishQueryRequest(ctx context.Context, logger *zap.Logger, topic *pubsub.Topic, selector *GuardianSelector, pendingResponse *PendingResponse) error {
+	now := time.Now()
+	targets := selector.Select(now)
+	if err := publishToGuardians(ctx, topic, pendingResponse.req, targets); err != nil {
+		return err
+	}
+	if targets == nil {
+		return nil
+	}
+	pendingResponse.sentTo(targets, now)
+
+	go func() {
+		timer := time.NewTimer(selector.failoverTimeout)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+		if pendingResponse.completed.Load() {
+			return
+		}
+
+		now := time.Now()
+		for _, key := range pendingResponse.unanswered(targets) {
+			selector.guardianMissed(key, now)
+		}
+		rest := selector.remaining(targets)
+		requestId := hex.EncodeToString(pendingResponse.req.Signature)
+		logger.Info("request has not reached quorum, sending it to the remaining guardians", zap.String("requestId", requestId), zap.Int("numGuardians", len(rest)))
+		queryFailovers.Inc()
+		if err := publishToGuardians(ctx, topic, pendingResponse.req, rest); err != nil {
+			logger.Error("failed to publish gossip message for failover", zap.String("requestId", requestId), zap.Error(err))
+			return
+		}
+		pendingResponse.sentTo(rest, now)
+		pendingResponse.reportFailover(selector.size())
+	}()
+	return nil
+}
+
+// publishToGuardians publishes a request addressed to the given guardians, or to all of them if targets is nil.
+func publishToGuardians(ctx context.Context, topic *pubsub.Topic, req *gossipv1.SignedQueryRequest, targets []ethCommon.Address) error {
+	// The targets are not part of the pending request, since the guardians echo only the query and its signature back.
+	signedQueryRequest := &gossipv1.SignedQueryRequest{
+		QueryRequest: req.QueryRequest,
+		Signature:    req.Signature,
+	}
+	for _, key := range targets {
+		signedQueryRequest.TargetGuardians = append(signedQueryRequest.TargetGuardians, key.Bytes())
+	}
+	b, err := proto.Marshal(&gossipv1.GossipMessage{
+		Message: &gossipv1.GossipMessage_SignedQueryRequest{
+			SignedQueryRequest: signedQueryRequest,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal gossip message: %w", err)
+	}
+	return topic.Publish(ctx, b)
+}
@@ -0,0 +1,93 @@
+package ccq
+
+import (
+	"testing"
+	"time"
+
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func selectorTestKeys(n int) []ethCommon.Address {
+	keys := make([]ethCommon.Address, n)
+	for i := range keys {
+		keys[i] = ethCommon.BytesToAddress([]byte{byte(i + 1)})
+	}
+	return keys
+}
+
+func TestGuardianSelectorDisabled(t *testing.T) {
+	var nilSelector *GuardianSelector
+	assert.Nil(t, nilSelector.Select(time.Now()))
+
+	s := NewGuardianSelector(0, time.Second)
+	s.setGuardianSet(selectorTestKeys(4))
+	assert.Nil(t, s.Select(time.Now()))
+
+	// The fan-out is raised to quorum.
+	s = NewGuardianSelector(1, time.Second)
+	s.setGuardianSet(selectorTestKeys(4))
+	assert.Len(t, s.Select(time.Now()), 3)
+
+	// Sending to every guardian is the same as not selecting any.
+	s = NewGuardianSelector(4, time.Second)
+	s.setGuardianSet(selectorTestKeys(4))
+	assert.Nil(t, s.Select(time.Now()))
+}
+
+func TestGuardianSelectorPrefersFastGuardians(t *testing.T) {
+	keys := selectorTestKeys(7)
+	s := NewGuardianSelector(5, time.Second)
+	s.setGuardianSet(keys)
+	now := time.Now()
+
+	// Nobody has been measured yet, so the guardians are picked in order.
+	assert.Equal(t, keys[:5], s.Select(now))
+
+	for i, key := range keys {
+		s.guardianAnswered(key, time.Duration(len(keys)-i)*time.Millisecond)
+	}
+	assert.Equal(t, []ethCommon.Address{keys[6], keys[5], keys[4], keys[3], keys[2]}, s.Select(now))
+
+	// One slow answer does not outweigh the history.
+	s.guardianAnswered(keys[6], 5*time.Millisecond)
+	assert.Equal(t, keys[6], s.Select(now)[0])
+}
+
+func TestGuardianSelectorFailover(t *testing.T) {
+	keys := selectorTestKeys(7)
+	s := NewGuardianSelector(5, time.Second)
+	s.setGuardianSet(keys)
+	now := time.Now()
+
+	for i := 0; i < guardianMaxMisses; i++ {
+		s.guardianMissed(keys[0], now)
+	}
+	selected := s.Select(now)
+	assert.NotContains(t, selected, keys[0])
+	assert.Equal(t, []ethCommon.Address{keys[0], keys[6]}, s.remaining(selected))
+
+	// Once excluded guardians are the only ones left, they are used anyway.
+	for _, key := range keys[1:4] {
+		for i := 0; i < guardianMaxMisses; i++ {
+			s.guardianMissed(key, now)
+		}
+	}
+	assert.Equal(t, []ethCommon.Address{keys[4], keys[5], keys[6], keys[0], keys[1]}, s.Select(now))
+
+	// They get another chance after the exclusion period.
+	assert.Equal(t, keys[:5], s.Select(now.Add(guardianExclusionPeriod)))
+}
+
+func TestPendingResponseFanOut(t *testing.T) {
+	keys := selectorTestKeys(3)
+	pr, _ := createJournalTestRequest(t, 1)
+	now := pr.created.Add(time.Second)
+
+	pr.sentTo(keys[:2], now)
+	assert.Equal(t, 100*time.Millisecond, pr.guardianAnswered(keys[0], now.Add(100*time.Millisecond)))
+	assert.Equal(t, []ethCommon.Address{keys[1]}, pr.unanswered(keys[:2]))
+
+	// Guardians the request was not explicitly sent to are measured from its creation.
+	assert.Equal(t, 2*time.Second, pr.guardianAnswered(keys[2], now.Add(time.Second)))
+}
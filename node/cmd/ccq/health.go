@@ -0,0 +1,178 @@
+package ccq
+
+import (
+	"sync"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/query"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+const (
+	// healthOutcomesPerChain is the number of recent query outcomes per chain used to compute the success rates.
+	healthOutcomesPerChain = 100
+
+	// guardianResponseTimeout is how long the proxy may go without a guardian response while requests are outstanding before it is considered not ready.
+	guardianResponseTimeout = 30 * time.Second
+)
+
+// ProxyHealth tracks the state of the proxy's connection to the guardians, for reporting by the status server. A nil ProxyHealth is
+// valid and tracks nothing.
+type ProxyHealth struct {
+	minPeers int
+
+	mutex                   sync.Mutex
+	numPeers                func() int
+	bootstrapped            bool
+	connectedBootstrapPeers int
+	awaitingResponseSince   time.Time
+	lastResponse            time.Time
+	chains                  map[vaa.ChainID]*chainOutcomes
+}
+
+// chainOutcomes is a ring buffer of the most recent query outcomes for a chain.
+type chainOutcomes struct {
+	outcomes [healthOutcomesPerChain]bool
+	next     int
+	count    int
+}
+
+func (c *chainOutcomes) add(success bool) {
+	c.outcomes[c.next] = success
+	c.next = (c.next + 1) % healthOutcomesPerChain
+	if c.count < healthOutcomesPerChain {
+		c.count++
+	}
+}
+
+func (c *chainOutcomes) successes() int {
+	n := 0
+	for i := 0; i < c.count; i++ {
+		if c.outcomes[i] {
+			n++
+		}
+	}
+	return n
+}
+
+// HealthReport is the JSON body returned by the health endpoints.
+type HealthReport struct {
+	Ready                            bool                   `json:"ready"`
+	NotReadyReasons                  []string               `json:"notReadyReasons,omitempty"`
+	NumPeers                         int                    `json:"numPeers"`
+	Bootstrapped                     bool                   `json:"bootstrapped"`
+	ConnectedBootstrapPeers          int                    `json:"connectedBootstrapPeers"`
+	LastGuardianResponse             *time.Time             `json:"lastGuardianResponse,omitempty"`
+	SecondsSinceLastGuardianResponse *float64               `json:"secondsSinceLastGuardianResponse,omitempty"`
+	Chains                           map[string]ChainHealth `json:"chains"`
+}
+
+// ChainHealth summarizes the recent query outcomes for a chain.
+type ChainHealth struct {
+	Successes   int     `json:"successes"`
+	Failures    int     `json:"failures"`
+	SuccessRate float64 `json:"successRate"`
+}
+
+// NewProxyHealth creates a health tracker. The proxy is not ready while it is connected to fewer than minPeers peers.
+func NewProxyHealth(minPeers int) *ProxyHealth {
+	return &ProxyHealth{
+		minPeers: minPeers,
+		chains:   make(map[vaa.ChainID]*chainOutcomes),
+	}
+}
+
+// setBootstrapped records that the p2p host is up and how many bootstrap peers it connected to. numPeers returns the current number of
+// peers on the request topic.
+func (h *ProxyHealth) setBootstrapped(connectedBootstrapPeers int, numPeers func() int) {
+	if h == nil {
+		return
+	}
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.bootstrapped = true
+	h.connectedBootstrapPeers = connectedBootstrapPeers
+	h.numPeers = numPeers
+}
+
+// requestPublished records that a request was sent to the guardians.
+func (h *ProxyHealth) requestPublished(now time.Time) {
+	if h == nil {
+		return
+	}
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if h.awaitingResponseSince.IsZero() {
+		h.awaitingResponseSince = now
+	}
+}
+
+// guardianResponded records that a valid response was received from a guardian.
+func (h *ProxyHealth) guardianResponded(now time.Time) {
+	if h == nil {
+		return
+	}
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.lastResponse = now
+	h.awaitingResponseSince = time.Time{}
+}
+
+// queryCompleted records the outcome of a query for each of the chains it covered.
+func (h *ProxyHealth) queryCompleted(queryRequest *query.QueryRequest, success bool) {
+	if h == nil || queryRequest == nil {
+		return
+	}
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	for _, pcq := range queryRequest.PerChainQueries {
+		c, exists := h.chains[pcq.ChainId]
+		if !exists {
+			c = &chainOutcomes{}
+			h.chains[pcq.ChainId] = c
+		}
+		c.add(success)
+	}
+}
+
+// Report returns the current health. The proxy is ready once it has bootstrapped and has enough peers, as long as requests are being
+// answered. If no guardian has responded for guardianResponseTimeout since a request was published, it is not ready.
+func (h *ProxyHealth) Report(now time.Time) *HealthReport {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	report := &HealthReport{
+		Ready:                   true,
+		Bootstrapped:            h.bootstrapped,
+		ConnectedBootstrapPeers: h.connectedBootstrapPeers,
+		Chains:                  make(map[string]ChainHealth, len(h.chains)),
+	}
+	if h.numPeers != nil {
+		report.NumPeers = h.numPeers()
+	}
+	if !h.lastResponse.IsZero() {
+		lastResponse := h.lastResponse
+		since := now.Sub(lastResponse).Seconds()
+		report.LastGuardianResponse = &lastResponse
+		report.SecondsSinceLastGuardianResponse = &since
+	}
+	for chainId, c := range h.chains {
+		successes := c.successes()
+		report.Chains[chainId.String()] = ChainHealth{
+			Successes:   successes,
+			Failures:    c.count - successes,
+			SuccessRate: float64(successes) / float64(c.count),
+		}
+	}
+
+	if !h.bootstrapped {
+		report.NotReadyReasons = append(report.NotReadyReasons, "p2p not bootstrapped")
+	} else if report.NumPeers < h.minPeers {
+		report.NotReadyReasons = append(report.NotReadyReasons, "not enough peers")
+	}
+	if !h.awaitingResponseSince.IsZero() && now.Sub(h.awaitingResponseSince) > guardianResponseTimeout {
+		report.NotReadyReasons = append(report.NotReadyReasons, "no guardian responses")
+	}
+	report.Ready = len(report.NotReadyReasons) == 0
+	return report
+}
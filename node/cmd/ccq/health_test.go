@@ -0,0 +1,135 @@
+package ccq
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+func TestProxyHealthReadiness(t *testing.T) {
+	h := NewProxyHealth(2)
+	now := time.Now()
+
+	report := h.Report(now)
+	assert.False(t, report.Ready)
+	assert.Equal(t, []string{"p2p not bootstrapped"}, report.NotReadyReasons)
+
+	numPeers := 1
+	h.setBootstrapped(3, func() int { return numPeers })
+	report = h.Report(now)
+	assert.False(t, report.Ready)
+	assert.Equal(t, []string{"not enough peers"}, report.NotReadyReasons)
+	assert.Equal(t, 3, report.ConnectedBootstrapPeers)
+
+	numPeers = 2
+	assert.True(t, h.Report(now).Ready)
+
+	// A steady stream of requests without any responses makes us unready.
+	h.requestPublished(now)
+	h.requestPublished(now.Add(20 * time.Second))
+	assert.True(t, h.Report(now.Add(30*time.Second)).Ready)
+	report = h.Report(now.Add(31 * time.Second))
+	assert.False(t, report.Ready)
+	assert.Equal(t, []string{"no guardian responses"}, report.NotReadyReasons)
+	assert.Nil(t, report.LastGuardianResponse)
+
+	h.guardianResponded(now.Add(32 * time.Second))
+	report = h.Report(now.Add(40 * time.Second))
+	assert.True(t, report.Ready)
+	require.NotNil(t, report.SecondsSinceLastGuardianResponse)
+	assert.Equal(t, 8.0, *report.SecondsSinceLastGuardianResponse)
+
+	// Being idle is fine.
+	assert.True(t, h.Report(now.Add(time.Hour)).Ready)
+}
+
+func TestProxyHealthSuccessRates(t *testing.T) {
+	h := NewProxyHealth(1)
+	pr, _ := createJournalTestRequest(t, 1)
+	for i := 0; i < healthOutcomesPerChain; i++ {
+		h.queryCompleted(pr.queryRequest, false)
+	}
+	for i := 0; i < healthOutcomesPerChain*3/4; i++ {
+		h.queryCompleted(pr.queryRequest, true)
+	}
+
+	// Only the most recent outcomes count.
+	assert.Equal(t, ChainHealth{Successes: 75, Failures: 25, SuccessRate: 0.75}, h.Report(time.Now()).Chains[vaa.ChainIDSolana.String()])
+}
+
+func TestPendingResponsesReportUnansweredQueries(t *testing.T) {
+	h := NewProxyHealth(1)
+	p := NewPendingResponses(zap.NewNop(), h)
+
+	answered, _ := createJournalTestRequest(t, 1)
+	unanswered, _ := createJournalTestRequest(t, 2)
+	require.True(t, p.Add(answered))
+	require.True(t, p.Add(unanswered))
+	answered.completed.Store(true)
+	h.queryCompleted(answered.queryRequest, true)
+	p.Remove(answered)
+	p.Remove(unanswered)
+
+	assert.Equal(t, ChainHealth{Successes: 1, Failures: 1, SuccessRate: 0.5}, h.Report(time.Now()).Chains[vaa.ChainIDSolana.String()])
+}
+
+func TestStatusServerReadyz(t *testing.T) {
+	h := NewProxyHealth(1)
+	s := NewStatusServer("", zap.NewNop(), common.GoTest, h)
+	ts := httptest.NewServer(s.httpServer.Handler)
+	defer ts.Close()
+
+	get := func(path string) (int, *HealthReport) {
+		resp, err := http.Get(ts.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var report HealthReport
+		if resp.Header.Get("Content-Type") == "application/json" {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+		}
+		return resp.StatusCode, &report
+	}
+
+	// The proxy is alive while bootstrapping, but not ready.
+	status, report := get("/health")
+	assert.Equal(t, http.StatusOK, status)
+	assert.False(t, report.Bootstrapped)
+	status, _ = get("/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+
+	h.setBootstrapped(1, func() int { return 1 })
+	status, report = get("/readyz")
+	assert.Equal(t, http.StatusOK, status)
+	assert.True(t, report.Ready)
+	assert.Equal(t, 1, report.NumPeers)
+
+	s.disableHealth()
+	status, _ = get("/health")
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	status, _ = get("/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+}
+
+func TestPendingResponseObserveOutcome(t *testing.T) {
+	pr, _ := createJournalTestRequest(t, 1)
+	pr.userName = "Metrics User"
+	pr.queryRequest.PerChainQueries = append(pr.queryRequest.PerChainQueries, pr.queryRequest.PerChainQueries[0])
+	chainName := vaa.ChainIDSolana.String()
+
+	pr.observeOutcome("", pr.created.Add(100*time.Millisecond))
+	pr.observeOutcome("quorum_not_met", time.Now())
+
+	// Each chain is only counted once per query.
+	assert.Equal(t, 1.0, testutil.ToFloat64(successfulQueriesByUserAndChain.WithLabelValues("Metrics User", chainName)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(failedQueriesByUserAndChain.WithLabelValues("Metrics User", chainName, "quorum_not_met")))
+	assert.Equal(t, 1, testutil.CollectAndCount(queryTimeByUserAndChain))
+}
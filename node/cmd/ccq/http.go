@@ -1,9 +1,11 @@
 package ccq
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"sort"
@@ -16,7 +18,6 @@ import (
 	"github.com/gorilla/mux"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"go.uber.org/zap"
-	"google.golang.org/protobuf/proto"
 )
 
 const MAX_BODY_SIZE = 5 * 1024 * 1024
@@ -39,6 +40,39 @@ type httpServer struct {
 	signerKey        *ecdsa.PrivateKey
 	pendingResponses *PendingResponses
 	loggingMap       *LoggingMap
+	admission        *AdmissionControl
+	cache            *ResponseCache
+	jwtAuth          *JWTAuthenticator
+	journal          *RequestJournal
+	selector         *GuardianSelector
+}
+
+// authenticate identifies the user from their API key or, if JWT authentication is enabled and no API key is given, their bearer token.
+// On failure, it returns the HTTP status to respond with and the reason used for the invalid request metric.
+func (s *httpServer) authenticate(ctx context.Context, apiKeys []string, authorization []string) (*permissionEntry, int, string, error) {
+	if s.jwtAuth != nil && len(apiKeys) == 0 && len(authorization) == 1 {
+		if tokenStr, ok := bearerToken(authorization[0]); ok {
+			subject, err := s.jwtAuth.Authenticate(ctx, tokenStr)
+			if err != nil {
+				return nil, http.StatusUnauthorized, "invalid_jwt", fmt.Errorf("invalid bearer token: %w", err)
+			}
+			permEntry, exists := s.permissions.GetUserEntryForJwtSubject(subject)
+			if !exists {
+				return nil, http.StatusForbidden, "unknown_jwt_subject", fmt.Errorf(`no user has JWT subject "%s"`, subject)
+			}
+			return permEntry, http.StatusOK, "", nil
+		}
+	}
+
+	// There should be one and only one API key.
+	if len(apiKeys) != 1 {
+		return nil, http.StatusUnauthorized, "missing_api_key", errors.New("api key is missing")
+	}
+	permEntry, exists := s.permissions.GetUserEntry(strings.ToLower(apiKeys[0]))
+	if !exists {
+		return nil, http.StatusForbidden, "invalid_api_key", errors.New("invalid api key")
+	}
+	return permEntry, http.StatusOK, "", nil
 }
 
 func (s *httpServer) handleQuery(w http.ResponseWriter, r *http.Request) {
@@ -48,7 +82,7 @@ func (s *httpServer) handleQuery(w http.ResponseWriter, r *http.Request) {
 	// Set CORS headers for the preflight request
 	if r.Method == http.MethodOptions {
 		w.Header().Set("Access-Control-Allow-Methods", "PUT, POST")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, X-Api-Key")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, X-Api-Key, Authorization")
 		w.Header().Set("Access-Control-Max-Age", "3600")
 		w.WriteHeader(http.StatusNoContent)
 		return
@@ -69,42 +103,95 @@ func (s *httpServer) handleQuery(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// There should be one and only one API key in the header.
-	apiKeys, exists := r.Header["X-Api-Key"]
-	if !exists || len(apiKeys) != 1 {
-		s.logger.Error("received a request with the wrong number of api keys", zap.Stringer("url", r.URL), zap.Int("numApiKeys", len(apiKeys)))
-		http.Error(w, "api key is missing", http.StatusUnauthorized)
-		invalidQueryRequestReceived.WithLabelValues("missing_api_key").Inc()
+	// Make sure the user is authorized before we go any farther.
+	permEntry, status, reason, err := s.authenticate(r.Context(), r.Header["X-Api-Key"], r.Header["Authorization"])
+	if err != nil {
+		s.logger.Error("failed to authenticate request", zap.Stringer("url", r.URL), zap.String("reason", reason), zap.Error(err))
+		http.Error(w, err.Error(), status)
+		invalidQueryRequestReceived.WithLabelValues(reason).Inc()
 		return
 	}
-	apiKey := strings.ToLower(apiKeys[0])
+	apiKey := permEntry.apiKey
+	totalRequestsByUser.WithLabelValues(permEntry.userName).Inc()
 
-	// Make sure the user is authorized before we go any farther.
-	permEntry, exists := s.permissions.GetUserEntry(apiKey)
-	if !exists {
-		s.logger.Error("invalid api key", zap.String("apiKey", apiKey))
-		http.Error(w, "invalid api key", http.StatusForbidden)
-		invalidQueryRequestReceived.WithLabelValues("invalid_api_key").Inc()
+	release, reason := s.admission.tryAcquire(permEntry.userName)
+	if reason != admissionOK {
+		s.logger.Warn("rejecting request because the server is saturated", zap.String("userId", permEntry.userName), zap.String("reason", reason))
+		w.Header().Set("Retry-After", ADMISSION_RETRY_AFTER_SECS)
+		http.Error(w, "server is busy, please retry later", http.StatusServiceUnavailable)
+		invalidQueryRequestReceived.WithLabelValues("server_saturated").Inc()
+		requestsRejectedByAdmissionControl.WithLabelValues(reason, permEntry.userName).Inc()
 		return
 	}
-	totalRequestsByUser.WithLabelValues(permEntry.userName).Inc()
+	defer release()
+
+	pendingResponse, requestId, status, err := s.submitQuery(r.Context(), permEntry, apiKey, &q, false)
+	if err != nil {
+		var rle *rateLimitError
+		if errors.As(err, &rle) {
+			w.Header().Set("Retry-After", rle.retryAfterSecs())
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	// Wait for the response or timeout
+	select {
+	case <-time.After(query.RequestTimeout + 5*time.Second):
+		s.logger.Info("publishing time out to client", zap.String("userId", permEntry.userName), zap.String("requestId", requestId))
+		http.Error(w, "Timed out waiting for response", http.StatusGatewayTimeout)
+		queryTimeoutsByUser.WithLabelValues(permEntry.userName).Inc()
+		failedQueriesByUser.WithLabelValues(permEntry.userName).Inc()
+	case res := <-pendingResponse.ch:
+		s.logger.Info("publishing response to client", zap.String("userId", permEntry.userName), zap.String("requestId", requestId))
+		resp, err := newQueryResponse(res)
+		if err != nil {
+			s.logger.Error("failed to marshal response", zap.String("userId", permEntry.userName), zap.String("requestId", requestId), zap.Error(err))
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			invalidQueryRequestReceived.WithLabelValues("failed_to_marshal_response").Inc()
+			failedQueriesByUser.WithLabelValues(permEntry.userName).Inc()
+			break
+		}
+		w.Header().Add("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(resp)
+		if err != nil {
+			s.logger.Error("failed to encode response", zap.String("userId", permEntry.userName), zap.String("requestId", requestId), zap.Error(err))
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			invalidQueryRequestReceived.WithLabelValues("failed_to_encode_response").Inc()
+			failedQueriesByUser.WithLabelValues(permEntry.userName).Inc()
+			break
+		}
+		successfulQueriesByUser.WithLabelValues(permEntry.userName).Inc()
+	case errEntry := <-pendingResponse.errCh:
+		s.logger.Info("publishing error response to client", zap.String("userId", permEntry.userName), zap.String("requestId", requestId), zap.Int("status", errEntry.status), zap.Error(errEntry.err))
+		http.Error(w, errEntry.err.Error(), errEntry.status)
+		// Metrics have already been pegged.
+		break
+	}
+
+	totalQueryTime.Observe(float64(time.Since(start).Milliseconds()))
+	validQueryRequestsReceived.Inc()
+	s.pendingResponses.Remove(pendingResponse)
+}
 
+// submitQuery validates a query request from the given user and publishes it to gossip. On success, the returned pending response
+// has been added to s.pendingResponses and the caller is responsible for removing it. If wantProgress is set, progress updates are
+// delivered on the progressCh of the pending response. In the case of an error, it returns the HTTP status.
+func (s *httpServer) submitQuery(ctx context.Context, permEntry *permissionEntry, apiKey string, q *queryRequest, wantProgress bool) (*PendingResponse, string, int, error) {
 	queryRequestBytes, err := hex.DecodeString(q.Bytes)
 	if err != nil {
 		s.logger.Error("failed to decode request bytes", zap.String("userId", permEntry.userName), zap.Error(err))
-		http.Error(w, err.Error(), http.StatusBadRequest)
 		invalidQueryRequestReceived.WithLabelValues("failed_to_decode_request").Inc()
 		invalidRequestsByUser.WithLabelValues(permEntry.userName).Inc()
-		return
+		return nil, "", http.StatusBadRequest, err
 	}
 
 	signature, err := hex.DecodeString(q.Signature)
 	if err != nil {
 		s.logger.Error("failed to decode signature bytes", zap.String("userId", permEntry.userName), zap.Error(err))
-		http.Error(w, err.Error(), http.StatusBadRequest)
 		invalidQueryRequestReceived.WithLabelValues("failed_to_decode_signature").Inc()
 		invalidRequestsByUser.WithLabelValues(permEntry.userName).Inc()
-		return
+		return nil, "", http.StatusBadRequest, err
 	}
 
 	signedQueryRequest := &gossipv1.SignedQueryRequest{
@@ -115,108 +202,89 @@ func (s *httpServer) handleQuery(w http.ResponseWriter, r *http.Request) {
 	status, queryReq, err := validateRequest(s.logger, s.env, s.permissions, s.signerKey, apiKey, signedQueryRequest)
 	if err != nil {
 		s.logger.Error("failed to validate request", zap.String("userId", permEntry.userName), zap.String("requestId", hex.EncodeToString(signedQueryRequest.Signature)), zap.Int("status", status), zap.Error(err))
-		http.Error(w, err.Error(), status)
 		// Error specific metric has already been pegged.
 		invalidRequestsByUser.WithLabelValues(permEntry.userName).Inc()
-		return
+		return nil, "", status, err
 	}
 
 	requestId := hex.EncodeToString(signedQueryRequest.Signature)
 	s.logger.Info("received request from client", zap.String("userId", permEntry.userName), zap.String("requestId", requestId))
 
-	m := gossipv1.GossipMessage{
-		Message: &gossipv1.GossipMessage_SignedQueryRequest{
-			SignedQueryRequest: signedQueryRequest,
-		},
-	}
-
-	b, err := proto.Marshal(&m)
-	if err != nil {
-		s.logger.Error("failed to marshal gossip message", zap.String("userId", permEntry.userName), zap.String("requestId", requestId), zap.Error(err))
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		invalidQueryRequestReceived.WithLabelValues("failed_to_marshal_gossip_msg").Inc()
-		invalidRequestsByUser.WithLabelValues(permEntry.userName).Inc()
-		return
+	if s.cache != nil && !permEntry.bypassCache {
+		if res, exists := s.cache.Get(signedQueryRequest.QueryRequest, time.Now()); exists {
+			s.logger.Info("answering request from the response cache", zap.String("userId", permEntry.userName), zap.String("requestId", requestId))
+			queryCacheHitsByUser.WithLabelValues(permEntry.userName).Inc()
+			// This is never added to s.pendingResponses, so removing it is a no-op.
+			pendingResponse := NewPendingResponse(signedQueryRequest, permEntry.userName, queryReq)
+			pendingResponse.ch = make(chan *SignedResponse, 1)
+			pendingResponse.ch <- res
+			// Journal it anyway, so the client can still fetch it by ID.
+			s.journal.Accept(pendingResponse, time.Now())
+			s.journal.Complete(requestId, res, time.Now())
+			return pendingResponse, requestId, http.StatusOK, nil
+		}
+		queryCacheMissesByUser.WithLabelValues(permEntry.userName).Inc()
 	}
 
 	pendingResponse := NewPendingResponse(signedQueryRequest, permEntry.userName, queryReq)
+	if wantProgress {
+		// The caller may be busy delivering progress when the result arrives, and the p2p handler never blocks on these channels.
+		pendingResponse.progressCh = make(chan *QueryProgress, 1)
+		pendingResponse.ch = make(chan *SignedResponse, 1)
+		pendingResponse.errCh = make(chan *ErrorEntry, 1)
+	}
 	added := s.pendingResponses.Add(pendingResponse)
 	if !added {
 		s.logger.Info("duplicate request", zap.String("userId", permEntry.userName), zap.String("requestId", requestId))
-		http.Error(w, "Duplicate request", http.StatusBadRequest)
 		invalidQueryRequestReceived.WithLabelValues("duplicate_request").Inc()
 		invalidRequestsByUser.WithLabelValues(permEntry.userName).Inc()
-		return
+		return nil, "", http.StatusBadRequest, errors.New("Duplicate request")
 	}
 
 	if permEntry.logResponses {
 		s.loggingMap.AddRequest(requestId)
 	}
 
+	// Journal the request before publishing it, so the outcome can be recorded no matter how quickly it arrives.
+	s.journal.Accept(pendingResponse, time.Now())
+
 	s.logger.Info("posting request to gossip", zap.String("userId", permEntry.userName), zap.String("requestId", requestId))
-	err = s.topic.Publish(r.Context(), b)
+	err = publishQueryRequest(ctx, s.logger, s.topic, s.selector, pendingResponse)
 	if err != nil {
 		s.logger.Error("failed to publish gossip message", zap.String("userId", permEntry.userName), zap.String("requestId", requestId), zap.Error(err))
-		http.Error(w, err.Error(), http.StatusInternalServerError)
 		invalidQueryRequestReceived.WithLabelValues("failed_to_publish_gossip_msg").Inc()
 		invalidRequestsByUser.WithLabelValues(permEntry.userName).Inc()
 		s.pendingResponses.Remove(pendingResponse)
-		return
+		s.journal.Discard(requestId)
+		return nil, "", http.StatusInternalServerError, err
 	}
 
-	// Wait for the response or timeout
-	select {
-	case <-time.After(query.RequestTimeout + 5*time.Second):
-		s.logger.Info("publishing time out to client", zap.String("userId", permEntry.userName), zap.String("requestId", requestId))
-		http.Error(w, "Timed out waiting for response", http.StatusGatewayTimeout)
-		queryTimeoutsByUser.WithLabelValues(permEntry.userName).Inc()
-		failedQueriesByUser.WithLabelValues(permEntry.userName).Inc()
-	case res := <-pendingResponse.ch:
-		s.logger.Info("publishing response to client", zap.String("userId", permEntry.userName), zap.String("requestId", requestId))
-		resBytes, err := res.Response.Marshal()
-		if err != nil {
-			s.logger.Error("failed to marshal response", zap.String("userId", permEntry.userName), zap.String("requestId", requestId), zap.Error(err))
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			invalidQueryRequestReceived.WithLabelValues("failed_to_marshal_response").Inc()
-			failedQueriesByUser.WithLabelValues(permEntry.userName).Inc()
-			break
-		}
-		// Signature indices must be ascending for on-chain verification
-		sort.Slice(res.Signatures, func(i, j int) bool {
-			return res.Signatures[i].Index < res.Signatures[j].Index
-		})
-		signatures := make([]string, 0, len(res.Signatures))
-		for _, s := range res.Signatures {
-			// ECDSA signature + a byte for the index of the guardian in the guardian set
-			signature := fmt.Sprintf("%s%02x", s.Signature, uint8(s.Index))
-			signatures = append(signatures, signature)
-		}
-		w.Header().Add("Content-Type", "application/json")
-		err = json.NewEncoder(w).Encode(&queryResponse{
-			Signatures: signatures,
-			Bytes:      hex.EncodeToString(resBytes),
-		})
-		if err != nil {
-			s.logger.Error("failed to encode response", zap.String("userId", permEntry.userName), zap.String("requestId", requestId), zap.Error(err))
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			invalidQueryRequestReceived.WithLabelValues("failed_to_encode_response").Inc()
-			failedQueriesByUser.WithLabelValues(permEntry.userName).Inc()
-			break
-		}
-		successfulQueriesByUser.WithLabelValues(permEntry.userName).Inc()
-	case errEntry := <-pendingResponse.errCh:
-		s.logger.Info("publishing error response to client", zap.String("userId", permEntry.userName), zap.String("requestId", requestId), zap.Int("status", errEntry.status), zap.Error(errEntry.err))
-		http.Error(w, errEntry.err.Error(), errEntry.status)
-		// Metrics have already been pegged.
-		break
-	}
+	return pendingResponse, requestId, http.StatusOK, nil
+}
 
-	totalQueryTime.Observe(float64(time.Since(start).Milliseconds()))
-	validQueryRequestsReceived.Inc()
-	s.pendingResponses.Remove(pendingResponse)
+// newQueryResponse converts a response which reached quorum into the format returned to clients.
+func newQueryResponse(res *SignedResponse) (*queryResponse, error) {
+	resBytes, err := res.Response.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	// Signature indices must be ascending for on-chain verification
+	sort.Slice(res.Signatures, func(i, j int) bool {
+		return res.Signatures[i].Index < res.Signatures[j].Index
+	})
+	signatures := make([]string, 0, len(res.Signatures))
+	for _, s := range res.Signatures {
+		// ECDSA signature + a byte for the index of the guardian in the guardian set
+		signature := fmt.Sprintf("%s%02x", s.Signature, uint8(s.Index))
+		signatures = append(signatures, signature)
+	}
+	return &queryResponse{
+		Signatures: signatures,
+		Bytes:      hex.EncodeToString(resBytes),
+	}, nil
 }
 
-func NewHTTPServer(addr string, t *pubsub.Topic, permissions *Permissions, signerKey *ecdsa.PrivateKey, p *PendingResponses, logger *zap.Logger, env common.Environment, loggingMap *LoggingMap) *http.Server {
+func NewHTTPServer(addr string, t *pubsub.Topic, permissions *Permissions, signerKey *ecdsa.PrivateKey, p *PendingResponses, logger *zap.Logger, env common.Environment, loggingMap *LoggingMap, admission *AdmissionControl, cache *ResponseCache, jwtAuth *JWTAuthenticator, journal *RequestJournal, selector *GuardianSelector) *http.Server {
 	s := &httpServer{
 		topic:            t,
 		permissions:      permissions,
@@ -225,9 +293,18 @@ func NewHTTPServer(addr string, t *pubsub.Topic, permissions *Permissions, signe
 		logger:           logger,
 		env:              env,
 		loggingMap:       loggingMap,
+		admission:        admission,
+		cache:            cache,
+		jwtAuth:          jwtAuth,
+		journal:          journal,
+		selector:         selector,
 	}
 	r := mux.NewRouter()
 	r.HandleFunc("/v1/query", s.handleQuery).Methods("PUT", "POST", "OPTIONS")
+	r.HandleFunc("/v1/query/ws", s.handleWebSocket).Methods("GET")
+	if journal != nil {
+		r.HandleFunc("/v1/query/{requestId}", s.handleGetQuery).Methods("GET")
+	}
 	return &http.Server{
 		Addr:              addr,
 		Handler:           r,
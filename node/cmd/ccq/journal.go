@@ -0,0 +1,397 @@
+package ccq
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	"github.com/certusone/wormhole/node/pkg/query"
+	"github.com/gorilla/mux"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// journalRequestTimeout is how long after being published a request without an outcome is considered to have timed out.
+// It matches the time the handlers wait for a response before giving up.
+const journalRequestTimeout = query.RequestTimeout + 5*time.Second
+
+// RequestJournal persists the query requests published to the guardians, along with their outcomes. It is used to re-submit requests
+// which were in flight when the proxy restarted, and to let clients fetch the result of a request by its ID. Each request is stored in
+// its own JSON file in the journal directory. A nil journal is valid and disables journaling.
+type RequestJournal struct {
+	dir       string
+	retention time.Duration
+	logger    *zap.Logger
+
+	mutex   sync.Mutex
+	entries map[string]*journalEntry
+}
+
+// journalEntry is the on-disk format of a journaled request. Completed is set once the request has an outcome, which is either a
+// response or an error with the HTTP status returned to the client.
+type journalEntry struct {
+	RequestId    string         `json:"requestId"`
+	UserName     string         `json:"userName"`
+	QueryRequest string         `json:"queryRequest"`
+	Signature    string         `json:"signature"`
+	Accepted     time.Time      `json:"accepted"`
+	Submitted    time.Time      `json:"submitted"`
+	Completed    *time.Time     `json:"completed,omitempty"`
+	Response     *queryResponse `json:"response,omitempty"`
+	Status       int            `json:"status,omitempty"`
+	Error        string         `json:"error,omitempty"`
+}
+
+// NewRequestJournal opens the journal in dir, creating the directory if necessary, and loads the existing entries. It returns nil
+// if dir is blank, which disables journaling. Entries older than the retention period are deleted.
+func NewRequestJournal(dir string, retention time.Duration, logger *zap.Logger) (*RequestJournal, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create journal directory: %w", err)
+	}
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal directory: %w", err)
+	}
+
+	j := &RequestJournal{
+		dir:       dir,
+		retention: retention,
+		logger:    logger,
+		entries:   make(map[string]*journalEntry),
+	}
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(dir, file.Name())
+		// #nosec G304 -- The path is within the journal directory, which is controlled by the operator.
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf(`failed to read journal entry "%s": %w`, path, err)
+		}
+		var entry journalEntry
+		if err := json.Unmarshal(b, &entry); err != nil || entry.RequestId+".json" != file.Name() {
+			// A corrupt entry should not prevent the proxy from starting.
+			logger.Error("ignoring invalid journal entry", zap.String("path", path), zap.Error(err))
+			continue
+		}
+		j.entries[entry.RequestId] = &entry
+	}
+	j.Prune(time.Now())
+	journalEntries.Set(float64(len(j.entries)))
+	return j, nil
+}
+
+// Start starts a go routine to periodically delete entries older than the retention period.
+func (j *RequestJournal) Start(ctx context.Context, errC chan error) {
+	if j == nil {
+		return
+	}
+	common.RunWithScissors(ctx, errC, "journal_cleanup", func(ctx context.Context) error {
+		ticker := time.NewTicker(1 * time.Minute)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+				j.Prune(time.Now())
+			}
+		}
+	})
+}
+
+// Accept records a request which is about to be published to the guardians.
+func (j *RequestJournal) Accept(pr *PendingResponse, now time.Time) {
+	if j == nil {
+		return
+	}
+	entry := &journalEntry{
+		RequestId:    hex.EncodeToString(pr.req.Signature),
+		UserName:     pr.userName,
+		QueryRequest: hex.EncodeToString(pr.req.QueryRequest),
+		Signature:    hex.EncodeToString(pr.req.Signature),
+		Accepted:     now,
+		Submitted:    now,
+	}
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	j.entries[entry.RequestId] = entry
+	j.writeAlreadyLocked(entry)
+	journalEntries.Set(float64(len(j.entries)))
+}
+
+// Discard deletes a request which was never published, so there is nothing to replay or report.
+func (j *RequestJournal) Discard(requestId string) {
+	if j == nil {
+		return
+	}
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	j.deleteAlreadyLocked(requestId)
+	journalEntries.Set(float64(len(j.entries)))
+}
+
+// Complete records the response which reached quorum for a request.
+func (j *RequestJournal) Complete(requestId string, res *SignedResponse, now time.Time) {
+	if j == nil {
+		return
+	}
+	// The signatures get sorted in place when the response is converted, and the caller is still going to publish it.
+	signatures := make([]GuardianSignature, len(res.Signatures))
+	copy(signatures, res.Signatures)
+	resp, err := newQueryResponse(&SignedResponse{Response: res.Response, Signatures: signatures})
+	if err != nil {
+		j.logger.Error("failed to convert response for the journal", zap.String("requestId", requestId), zap.Error(err))
+		journalErrors.WithLabelValues("failed_to_marshal_response").Inc()
+		return
+	}
+	j.resolve(requestId, now, func(entry *journalEntry) {
+		entry.Response = resp
+		entry.Status = http.StatusOK
+	})
+}
+
+// Fail records the error returned for a request.
+func (j *RequestJournal) Fail(requestId string, status int, err error, now time.Time) {
+	if j == nil {
+		return
+	}
+	j.resolve(requestId, now, func(entry *journalEntry) {
+		entry.Status = status
+		entry.Error = err.Error()
+	})
+}
+
+// resolve sets the outcome of a request, unless it already has one.
+func (j *RequestJournal) resolve(requestId string, now time.Time, set func(*journalEntry)) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	entry, exists := j.entries[requestId]
+	if !exists || entry.Completed != nil {
+		return
+	}
+	completed := now
+	entry.Completed = &completed
+	set(entry)
+	j.writeAlreadyLocked(entry)
+}
+
+// Get returns a copy of the entry for a request. A request which has not had an outcome within journalRequestTimeout of being
+// published is reported as timed out, since nobody is waiting for its response any more.
+func (j *RequestJournal) Get(requestId string, now time.Time) (*journalEntry, bool) {
+	if j == nil {
+		return nil, false
+	}
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	entry, exists := j.entries[requestId]
+	if !exists {
+		return nil, false
+	}
+	cpy := *entry
+	if cpy.Completed == nil && now.Sub(cpy.Submitted) > journalRequestTimeout {
+		completed := cpy.Submitted.Add(journalRequestTimeout)
+		cpy.Completed = &completed
+		cpy.Status = http.StatusGatewayTimeout
+		cpy.Error = "Timed out waiting for response"
+	}
+	return &cpy, true
+}
+
+// Prune deletes the entries accepted more than the retention period ago, whether or not they have an outcome.
+func (j *RequestJournal) Prune(now time.Time) {
+	if j == nil {
+		return
+	}
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	for requestId, entry := range j.entries {
+		if now.Sub(entry.Accepted) > j.retention {
+			j.deleteAlreadyLocked(requestId)
+		}
+	}
+	journalEntries.Set(float64(len(j.entries)))
+}
+
+// unresolved returns copies of the entries without an outcome which were published after the cutoff.
+func (j *RequestJournal) unresolved(cutoff time.Time) []*journalEntry {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	ret := []*journalEntry{}
+	for _, entry := range j.entries {
+		if entry.Completed == nil && entry.Submitted.After(cutoff) {
+			cpy := *entry
+			ret = append(ret, &cpy)
+		}
+	}
+	return ret
+}
+
+// markSubmitted records that a request has been published again.
+func (j *RequestJournal) markSubmitted(requestId string, now time.Time) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	if entry, exists := j.entries[requestId]; exists {
+		entry.Submitted = now
+		j.writeAlreadyLocked(entry)
+	}
+}
+
+// writeAlreadyLocked persists an entry. The file is replaced atomically so a crash never leaves a partial entry behind.
+// Failures are logged rather than returned, since the request can still be served without the journal.
+func (j *RequestJournal) writeAlreadyLocked(entry *journalEntry) {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		j.logger.Error("failed to marshal journal entry", zap.String("requestId", entry.RequestId), zap.Error(err))
+		journalErrors.WithLabelValues("failed_to_marshal_entry").Inc()
+		return
+	}
+	path := j.path(entry.RequestId)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0600); err != nil {
+		j.logger.Error("failed to write journal entry", zap.String("path", tmp), zap.Error(err))
+		journalErrors.WithLabelValues("failed_to_write_entry").Inc()
+		return
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		j.logger.Error("failed to rename journal entry", zap.String("path", path), zap.Error(err))
+		journalErrors.WithLabelValues("failed_to_write_entry").Inc()
+	}
+}
+
+func (j *RequestJournal) deleteAlreadyLocked(requestId string) {
+	delete(j.entries, requestId)
+	if err := os.Remove(j.path(requestId)); err != nil && !os.IsNotExist(err) {
+		j.logger.Error("failed to delete journal entry", zap.String("requestId", requestId), zap.Error(err))
+		journalErrors.WithLabelValues("failed_to_delete_entry").Inc()
+	}
+}
+
+func (j *RequestJournal) path(requestId string) string {
+	return filepath.Join(j.dir, requestId+".json")
+}
+
+// replayJournal re-publishes the requests which were in flight when the proxy last stopped, so their responses are journaled and
+// can be fetched by the clients. Requests published more than replayWindow ago are left to time out.
+func replayJournal(ctx context.Context, logger *zap.Logger, j *RequestJournal, topic *pubsub.Topic, pendingResponses *PendingResponses, replayWindow time.Duration) {
+	if j == nil {
+		return
+	}
+	now := time.Now()
+	for _, entry := range j.unresolved(now.Add(-replayWindow)) {
+		if err := replayRequest(ctx, logger, j, topic, pendingResponses, entry); err != nil {
+			logger.Error("failed to replay journaled request", zap.String("userId", entry.UserName), zap.String("requestId", entry.RequestId), zap.Error(err))
+			journalErrors.WithLabelValues("failed_to_replay_request").Inc()
+			continue
+		}
+		journalRequestsReplayed.Inc()
+	}
+}
+
+func replayRequest(ctx context.Context, logger *zap.Logger, j *RequestJournal, topic *pubsub.Topic, pendingResponses *PendingResponses, entry *journalEntry) error {
+	queryRequestBytes, err := hex.DecodeString(entry.QueryRequest)
+	if err != nil {
+		return fmt.Errorf("failed to decode request bytes: %w", err)
+	}
+	signature, err := hex.DecodeString(entry.Signature)
+	if err != nil {
+		return fmt.Errorf("failed to decode signature: %w", err)
+	}
+	var queryReq query.QueryRequest
+	if err := queryReq.Unmarshal(queryRequestBytes); err != nil {
+		return fmt.Errorf("failed to unmarshal request: %w", err)
+	}
+	signedQueryRequest := &gossipv1.SignedQueryRequest{
+		QueryRequest: queryRequestBytes,
+		Signature:    signature,
+	}
+	b, err := proto.Marshal(&gossipv1.GossipMessage{
+		Message: &gossipv1.GossipMessage_SignedQueryRequest{
+			SignedQueryRequest: signedQueryRequest,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal gossip message: %w", err)
+	}
+
+	// Nobody is waiting on the channels, so they are buffered to keep the p2p handler from dropping the outcome, which it journals.
+	pendingResponse := NewPendingResponse(signedQueryRequest, entry.UserName, &queryReq)
+	pendingResponse.ch = make(chan *SignedResponse, 1)
+	pendingResponse.errCh = make(chan *ErrorEntry, 1)
+	if !pendingResponses.Add(pendingResponse) {
+		return nil
+	}
+
+	logger.Info("replaying journaled request", zap.String("userId", entry.UserName), zap.String("requestId", entry.RequestId))
+	if err := topic.Publish(ctx, b); err != nil {
+		pendingResponses.Remove(pendingResponse)
+		return fmt.Errorf("failed to publish gossip message: %w", err)
+	}
+	j.markSubmitted(entry.RequestId, time.Now())
+
+	go func() {
+		defer pendingResponses.Remove(pendingResponse)
+		timeout := time.NewTimer(journalRequestTimeout)
+		defer timeout.Stop()
+		select {
+		case <-ctx.Done():
+		case <-timeout.C:
+			logger.Info("replayed request timed out", zap.String("userId", entry.UserName), zap.String("requestId", entry.RequestId))
+		case <-pendingResponse.ch:
+		case <-pendingResponse.errCh:
+		}
+	}()
+	return nil
+}
+
+// handleGetQuery returns the outcome of a journaled request. Users may only fetch their own requests. While the request is still in
+// flight, it responds with 202 Accepted.
+func (s *httpServer) handleGetQuery(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	permEntry, status, reason, err := s.authenticate(r.Context(), r.Header["X-Api-Key"], r.Header["Authorization"])
+	if err != nil {
+		s.logger.Error("failed to authenticate request", zap.Stringer("url", r.URL), zap.String("reason", reason), zap.Error(err))
+		http.Error(w, err.Error(), status)
+		invalidQueryRequestReceived.WithLabelValues(reason).Inc()
+		return
+	}
+
+	requestId := strings.ToLower(mux.Vars(r)["requestId"])
+	entry, exists := s.journal.Get(requestId, time.Now())
+	if !exists || entry.UserName != permEntry.userName {
+		// Don't reveal the existence of other users' requests.
+		http.Error(w, "request not found", http.StatusNotFound)
+		return
+	}
+
+	if entry.Completed == nil {
+		w.Header().Set("Retry-After", "1")
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	if entry.Response == nil {
+		http.Error(w, entry.Error, entry.Status)
+		return
+	}
+	w.Header().Add("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(entry.Response); err != nil {
+		s.logger.Error("failed to encode response", zap.String("userId", permEntry.userName), zap.String("requestId", requestId), zap.Error(err))
+	}
+}
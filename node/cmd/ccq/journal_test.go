@@ -0,0 +1,200 @@
+package ccq
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	"github.com/certusone/wormhole/node/pkg/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// createJournalTestRequest returns a pending response for a Solana account query, along with a response to it which reached quorum.
+func createJournalTestRequest(t *testing.T, nonce uint32) (*PendingResponse, *SignedResponse) {
+	t.Helper()
+	queryRequest := &query.QueryRequest{
+		Nonce: nonce,
+		PerChainQueries: []*query.PerChainQueryRequest{{
+			ChainId: vaa.ChainIDSolana,
+			Query: &query.SolanaAccountQueryRequest{
+				Commitment: "finalized",
+				Accounts:   [][query.SolanaPublicKeyLength]byte{{1}},
+			},
+		}},
+	}
+	queryRequestBytes, err := queryRequest.Marshal()
+	require.NoError(t, err)
+	signature := make([]byte, 65)
+	signature[0] = byte(nonce)
+	signedQueryRequest := &gossipv1.SignedQueryRequest{QueryRequest: queryRequestBytes, Signature: signature}
+
+	res := &SignedResponse{
+		Response: &query.QueryResponsePublication{
+			Request: signedQueryRequest,
+			PerChainResponses: []*query.PerChainQueryResponse{{
+				ChainId: vaa.ChainIDSolana,
+				Response: &query.SolanaAccountQueryResponse{
+					SlotNumber: 1000,
+					BlockTime:  time.UnixMicro(time.Now().UnixMicro()),
+					Results:    []query.SolanaAccountResult{{Lamports: 2000, Data: []byte("data")}},
+				},
+			}},
+		},
+		Signatures: []GuardianSignature{{Index: 1, Signature: "01"}, {Index: 0, Signature: "00"}},
+	}
+	return NewPendingResponse(signedQueryRequest, "Test User", queryRequest), res
+}
+
+func TestRequestJournalDisabled(t *testing.T) {
+	j, err := NewRequestJournal("", time.Hour, zap.NewNop())
+	require.NoError(t, err)
+	assert.Nil(t, j)
+
+	// A nil journal is safe to use.
+	pr, res := createJournalTestRequest(t, 1)
+	j.Accept(pr, time.Now())
+	j.Complete("id", res, time.Now())
+	_, exists := j.Get("id", time.Now())
+	assert.False(t, exists)
+}
+
+func TestRequestJournalSurvivesRestart(t *testing.T) {
+	dir := t.TempDir()
+	logger := zap.NewNop()
+	now := time.Now()
+
+	j, err := NewRequestJournal(dir, time.Hour, logger)
+	require.NoError(t, err)
+	completed, res := createJournalTestRequest(t, 1)
+	inFlight, _ := createJournalTestRequest(t, 2)
+	failed, _ := createJournalTestRequest(t, 3)
+	for _, pr := range []*PendingResponse{completed, inFlight, failed} {
+		j.Accept(pr, now)
+	}
+	completedId := hex.EncodeToString(completed.req.Signature)
+	inFlightId := hex.EncodeToString(inFlight.req.Signature)
+	failedId := hex.EncodeToString(failed.req.Signature)
+	j.Complete(completedId, res, now)
+	j.Fail(failedId, http.StatusBadRequest, errors.New("quorum not met"), now)
+
+	// The first outcome wins.
+	j.Fail(completedId, http.StatusBadRequest, errors.New("too late"), now)
+
+	// The caller still owns the response, so its signatures must not have been sorted.
+	assert.Equal(t, 1, res.Signatures[0].Index)
+
+	j, err = NewRequestJournal(dir, time.Hour, logger)
+	require.NoError(t, err)
+
+	entry, exists := j.Get(completedId, now)
+	require.True(t, exists)
+	require.NotNil(t, entry.Completed)
+	require.NotNil(t, entry.Response)
+	expected, err := newQueryResponse(res)
+	require.NoError(t, err)
+	assert.Equal(t, expected, entry.Response)
+
+	entry, exists = j.Get(failedId, now)
+	require.True(t, exists)
+	assert.Nil(t, entry.Response)
+	assert.Equal(t, http.StatusBadRequest, entry.Status)
+	assert.Equal(t, "quorum not met", entry.Error)
+
+	unresolved := j.unresolved(now.Add(-time.Minute))
+	require.Len(t, unresolved, 1)
+	assert.Equal(t, inFlightId, unresolved[0].RequestId)
+	assert.Equal(t, hex.EncodeToString(inFlight.req.QueryRequest), unresolved[0].QueryRequest)
+	assert.Empty(t, j.unresolved(now))
+
+	// Nobody is waiting for the request once the handlers would have given up on it.
+	entry, exists = j.Get(inFlightId, now.Add(journalRequestTimeout+time.Second))
+	require.True(t, exists)
+	require.NotNil(t, entry.Completed)
+	assert.Equal(t, http.StatusGatewayTimeout, entry.Status)
+
+	// Unless it gets replayed.
+	j.markSubmitted(inFlightId, now.Add(journalRequestTimeout))
+	entry, exists = j.Get(inFlightId, now.Add(journalRequestTimeout+time.Second))
+	require.True(t, exists)
+	assert.Nil(t, entry.Completed)
+}
+
+func TestRequestJournalPrune(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+
+	j, err := NewRequestJournal(dir, time.Hour, zap.NewNop())
+	require.NoError(t, err)
+	old, _ := createJournalTestRequest(t, 1)
+	recent, _ := createJournalTestRequest(t, 2)
+	j.Accept(old, now.Add(-2*time.Hour))
+	j.Accept(recent, now)
+
+	// Junk in the directory is ignored.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "junk.json"), []byte("not json"), 0600))
+
+	j.Prune(now)
+	_, exists := j.Get(hex.EncodeToString(old.req.Signature), now)
+	assert.False(t, exists)
+	_, exists = j.Get(hex.EncodeToString(recent.req.Signature), now)
+	assert.True(t, exists)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{filepath.Join(dir, hex.EncodeToString(recent.req.Signature)+".json"), filepath.Join(dir, "junk.json")}, files)
+}
+
+func TestHandleGetQuery(t *testing.T) {
+	logger := zap.NewNop()
+	j, err := NewRequestJournal(t.TempDir(), time.Hour, logger)
+	require.NoError(t, err)
+	perms := &Permissions{
+		permMap: PermissionsMap{
+			"my_secret_key":    &permissionEntry{userName: "Test User", apiKey: "my_secret_key"},
+			"other_secret_key": &permissionEntry{userName: "Other User", apiKey: "other_secret_key"},
+		},
+	}
+	s := NewHTTPServer("", nil, perms, nil, NewPendingResponses(logger, nil), logger, common.GoTest, NewLoggingMap(), NewAdmissionControl(0, 0), nil, nil, j, nil)
+	ts := httptest.NewServer(s.Handler)
+	defer ts.Close()
+
+	pr, res := createJournalTestRequest(t, 1)
+	requestId := hex.EncodeToString(pr.req.Signature)
+	j.Accept(pr, time.Now())
+
+	get := func(apiKey string) *http.Response {
+		req, err := http.NewRequest(http.MethodGet, ts.URL+"/v1/query/"+requestId, nil)
+		require.NoError(t, err)
+		if apiKey != "" {
+			req.Header.Set("X-Api-Key", apiKey)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, get("").StatusCode)
+	assert.Equal(t, http.StatusNotFound, get("other_secret_key").StatusCode)
+	assert.Equal(t, http.StatusAccepted, get("my_secret_key").StatusCode)
+
+	j.Complete(requestId, res, time.Now())
+	resp := get("my_secret_key")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var qr queryResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&qr))
+	expected, err := newQueryResponse(res)
+	require.NoError(t, err)
+	assert.Equal(t, *expected, qr)
+}
@@ -0,0 +1,268 @@
+package ccq
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"go.uber.org/zap"
+)
+
+const (
+	// JWKS_REFRESH_INTERVAL is how often the signing keys of the identity provider are refetched.
+	JWKS_REFRESH_INTERVAL = time.Hour
+
+	// JWKS_MIN_REFETCH_INTERVAL limits how often a token signed with an unknown key can trigger a refetch, so that bogus tokens can't be used to hammer the identity provider.
+	JWKS_MIN_REFETCH_INTERVAL = time.Minute
+)
+
+// jwtValidMethods are the signing algorithms accepted in bearer tokens. Symmetric algorithms are not supported since the keys come from a JWKS.
+var jwtValidMethods = []string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}
+
+// JWTAuthenticator validates bearer tokens issued by an external identity provider, as an alternative to static API keys.
+// A valid token is mapped to a user in the permissions file by the value of a configurable claim, which must match the user's "jwtSubject".
+type JWTAuthenticator struct {
+	logger    *zap.Logger
+	issuer    string
+	audience  string
+	userClaim string
+	jwksURL   string
+	client    *http.Client
+	parser    *jwt.Parser
+
+	mutex     sync.Mutex
+	keys      map[string]crypto.PublicKey // Key is the key ID
+	lastFetch time.Time
+}
+
+// NewJWTAuthenticator creates an authenticator for tokens from the given issuer, signed by one of the keys published at jwksURL.
+// If audience is not empty, tokens must also be intended for it. The initial fetch of the keys must succeed.
+func NewJWTAuthenticator(ctx context.Context, logger *zap.Logger, issuer, jwksURL, audience, userClaim string) (*JWTAuthenticator, error) {
+	if issuer == "" || jwksURL == "" {
+		return nil, errors.New("both the issuer and the JWKS URL must be specified")
+	}
+	if userClaim == "" {
+		userClaim = "sub"
+	}
+	a := &JWTAuthenticator{
+		logger:    logger.With(zap.String("component", "jwt")),
+		issuer:    issuer,
+		audience:  audience,
+		userClaim: userClaim,
+		jwksURL:   jwksURL,
+		client:    &http.Client{Timeout: 10 * time.Second},
+		parser:    jwt.NewParser(jwt.WithValidMethods(jwtValidMethods)),
+	}
+	if err := a.refresh(ctx); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Start periodically refreshes the keys, so that keys rotated by the identity provider are picked up.
+func (a *JWTAuthenticator) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(JWKS_REFRESH_INTERVAL)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := a.refresh(ctx); err != nil {
+					a.logger.Error("failed to refresh JWKS, sticking with the old keys", zap.String("url", a.jwksURL), zap.Error(err))
+				}
+			}
+		}
+	}()
+}
+
+// Authenticate validates a token and returns the value of the user claim.
+func (a *JWTAuthenticator) Authenticate(ctx context.Context, tokenStr string) (string, error) {
+	claims := jwt.MapClaims{}
+	_, err := a.parser.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return a.getKey(ctx, kid)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now().Unix()
+	if !claims.VerifyExpiresAt(now, true) {
+		return "", errors.New("token has no expiry or has expired")
+	}
+	if !claims.VerifyIssuer(a.issuer, true) {
+		return "", errors.New("token has the wrong issuer")
+	}
+	if a.audience != "" && !claims.VerifyAudience(a.audience, true) {
+		return "", errors.New("token has the wrong audience")
+	}
+
+	subject, ok := claims[a.userClaim].(string)
+	if !ok || subject == "" {
+		return "", fmt.Errorf(`token does not have a "%s" claim`, a.userClaim)
+	}
+	return subject, nil
+}
+
+// getKey returns the key with the given ID, refetching the keys if it is unknown, since the identity provider may have rotated them.
+func (a *JWTAuthenticator) getKey(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	a.mutex.Lock()
+	key, exists := a.keys[kid]
+	stale := time.Since(a.lastFetch) >= JWKS_MIN_REFETCH_INTERVAL
+	a.mutex.Unlock()
+	if exists {
+		return key, nil
+	}
+	if stale {
+		if err := a.refresh(ctx); err != nil {
+			a.logger.Error("failed to refresh JWKS", zap.String("url", a.jwksURL), zap.Error(err))
+		}
+		a.mutex.Lock()
+		key, exists = a.keys[kid]
+		a.mutex.Unlock()
+		if exists {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf(`unknown key id "%s"`, kid)
+}
+
+// refresh fetches the current keys from the JWKS URL.
+func (a *JWTAuthenticator) refresh(ctx context.Context) error {
+	a.mutex.Lock()
+	a.lastFetch = time.Now()
+	a.mutex.Unlock()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.jwksURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch JWKS: status %d", resp.StatusCode)
+	}
+
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(nil, resp.Body, MAX_BODY_SIZE)).Decode(&jwks); err != nil {
+		return fmt.Errorf("failed to decode JWKS: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey)
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			// Identity providers may publish key types we don't support, which shouldn't prevent us from using the others.
+			a.logger.Warn("skipping JWKS key", zap.String("kid", jwk.Kid), zap.Error(err))
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	if len(keys) == 0 {
+		return errors.New("JWKS does not contain any usable keys")
+	}
+
+	a.mutex.Lock()
+	a.keys = keys
+	a.mutex.Unlock()
+	a.logger.Info("loaded JWKS", zap.String("url", a.jwksURL), zap.Int("numKeys", len(keys)))
+	return nil
+}
+
+// jsonWebKey is the subset of RFC 7517 needed for RSA and EC signature verification keys.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (jwk *jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := decodeJWKInt(jwk.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus: %w", err)
+		}
+		e, err := decodeJWKInt(jwk.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent: %w", err)
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New("exponent is too large")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf(`unsupported curve "%s"`, jwk.Crv)
+		}
+		x, err := decodeJWKInt(jwk.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid x coordinate: %w", err)
+		}
+		y, err := decodeJWKInt(jwk.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid y coordinate: %w", err)
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("point is not on the curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf(`unsupported key type "%s"`, jwk.Kty)
+	}
+}
+
+func decodeJWKInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, errors.New("value is empty")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// bearerToken extracts the token from an "Authorization: Bearer <token>" header value.
+func bearerToken(authorization string) (string, bool) {
+	const prefix = "bearer "
+	if len(authorization) <= len(prefix) || !strings.EqualFold(authorization[:len(prefix)], prefix) {
+		return "", false
+	}
+	return strings.TrimSpace(authorization[len(prefix):]), true
+}
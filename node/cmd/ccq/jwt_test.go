@@ -0,0 +1,142 @@
+package ccq
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const testJwtIssuer = "https://issuer.example.com/"
+
+// newTestJWTAuthenticator starts a JWKS server publishing the public half of key under the given key ID.
+func newTestJWTAuthenticator(t *testing.T, key *rsa.PrivateKey, kid string) *JWTAuthenticator {
+	t.Helper()
+	jwks := map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": kid,
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(jwks)
+	}))
+	t.Cleanup(srv.Close)
+
+	a, err := NewJWTAuthenticator(context.Background(), zap.NewNop(), testJwtIssuer, srv.URL, "ccq", "")
+	require.NoError(t, err)
+	return a
+}
+
+func signTestToken(t *testing.T, key *rsa.PrivateKey, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	tokenStr, err := token.SignedString(key)
+	require.NoError(t, err)
+	return tokenStr
+}
+
+func TestJWTAuthenticate(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	a := newTestJWTAuthenticator(t, key, "key-1")
+	ctx := context.Background()
+	exp := time.Now().Add(time.Hour).Unix()
+
+	subject, err := a.Authenticate(ctx, signTestToken(t, key, "key-1", jwt.MapClaims{"iss": testJwtIssuer, "aud": "ccq", "sub": "client-1", "exp": exp}))
+	require.NoError(t, err)
+	assert.Equal(t, "client-1", subject)
+
+	_, err = a.Authenticate(ctx, signTestToken(t, key, "key-1", jwt.MapClaims{"iss": testJwtIssuer, "aud": "ccq", "sub": "client-1", "exp": time.Now().Add(-time.Minute).Unix()}))
+	assert.Error(t, err, "expired token")
+
+	_, err = a.Authenticate(ctx, signTestToken(t, key, "key-1", jwt.MapClaims{"iss": testJwtIssuer, "aud": "ccq", "sub": "client-1"}))
+	assert.Error(t, err, "token without expiry")
+
+	_, err = a.Authenticate(ctx, signTestToken(t, key, "key-1", jwt.MapClaims{"iss": "https://evil.example.com/", "aud": "ccq", "sub": "client-1", "exp": exp}))
+	assert.Error(t, err, "wrong issuer")
+
+	_, err = a.Authenticate(ctx, signTestToken(t, key, "key-1", jwt.MapClaims{"iss": testJwtIssuer, "aud": "other", "sub": "client-1", "exp": exp}))
+	assert.Error(t, err, "wrong audience")
+
+	_, err = a.Authenticate(ctx, signTestToken(t, key, "key-1", jwt.MapClaims{"iss": testJwtIssuer, "aud": "ccq", "exp": exp}))
+	assert.Error(t, err, "missing subject")
+
+	_, err = a.Authenticate(ctx, signTestToken(t, key, "key-2", jwt.MapClaims{"iss": testJwtIssuer, "aud": "ccq", "sub": "client-1", "exp": exp}))
+	assert.Error(t, err, "unknown key id")
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, err = a.Authenticate(ctx, signTestToken(t, otherKey, "key-1", jwt.MapClaims{"iss": testJwtIssuer, "aud": "ccq", "sub": "client-1", "exp": exp}))
+	assert.Error(t, err, "wrong signing key")
+}
+
+func TestJWTAuthenticateWithApiKeyFallback(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	s := &httpServer{
+		permissions: &Permissions{
+			permMap: PermissionsMap{
+				"my_secret_key": &permissionEntry{userName: "Test User", apiKey: "my_secret_key", jwtSubject: "client-1"},
+			},
+		},
+		jwtAuth: newTestJWTAuthenticator(t, key, "key-1"),
+	}
+	ctx := context.Background()
+	exp := time.Now().Add(time.Hour).Unix()
+
+	permEntry, status, _, err := s.authenticate(ctx, nil, []string{"Bearer " + signTestToken(t, key, "key-1", jwt.MapClaims{"iss": testJwtIssuer, "aud": "ccq", "sub": "client-1", "exp": exp})})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "Test User", permEntry.userName)
+
+	_, status, reason, err := s.authenticate(ctx, nil, []string{"Bearer " + signTestToken(t, key, "key-1", jwt.MapClaims{"iss": testJwtIssuer, "aud": "ccq", "sub": "client-2", "exp": exp})})
+	require.Error(t, err)
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, "unknown_jwt_subject", reason)
+
+	_, status, reason, err = s.authenticate(ctx, nil, []string{"Bearer garbage"})
+	require.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.Equal(t, "invalid_jwt", reason)
+
+	permEntry, _, _, err = s.authenticate(ctx, []string{"My_Secret_Key"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Test User", permEntry.userName)
+
+	_, status, reason, err = s.authenticate(ctx, nil, nil)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.Equal(t, "missing_api_key", reason)
+}
+
+func TestBearerToken(t *testing.T) {
+	token, ok := bearerToken("Bearer abc.def.ghi")
+	assert.True(t, ok)
+	assert.Equal(t, "abc.def.ghi", token)
+
+	token, ok = bearerToken("bearer abc.def.ghi")
+	assert.True(t, ok)
+	assert.Equal(t, "abc.def.ghi", token)
+
+	_, ok = bearerToken("Basic dXNlcjpwYXNz")
+	assert.False(t, ok)
+
+	_, ok = bearerToken("Bearer ")
+	assert.False(t, ok)
+}
@@ -33,6 +33,12 @@ var (
 			Help: "Total number of requested calls by chain",
 		}, []string{"chain_name"})
 
+	totalRequestedCallsByUserAndChain = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ccq_server_total_requested_calls_by_user_and_chain",
+			Help: "Total number of requested calls by user name and chain",
+		}, []string{"user_name", "chain_name"})
+
 	totalRequestsByUser = promauto.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "ccq_server_total_requests_by_user",
@@ -94,6 +100,25 @@ var (
 			Buckets: []float64{10.0, 100.0, 250.0, 500.0, 1000.0, 5000.0, 10000.0, 30000.0},
 		})
 
+	queryTimeByUserAndChain = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "ccq_server_query_time_by_user_and_chain_in_ms",
+			Help:    "Time from request to quorum in ms for successful queries by user name and chain",
+			Buckets: []float64{10.0, 100.0, 250.0, 500.0, 1000.0, 5000.0, 10000.0, 30000.0},
+		}, []string{"user_name", "chain_name"})
+
+	successfulQueriesByUserAndChain = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ccq_server_successful_queries_by_user_and_chain",
+			Help: "Total number of queries that reached quorum by user name and chain",
+		}, []string{"user_name", "chain_name"})
+
+	failedQueriesByUserAndChain = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ccq_server_failed_queries_by_user_and_chain",
+			Help: "Total number of queries that did not reach quorum by user name, chain and reason",
+		}, []string{"user_name", "chain_name", "reason"})
+
 	permissionFileReloadsSuccess = promauto.NewCounter(
 		prometheus.CounterOpts{
 			Name: "ccq_server_perm_file_reload_success",
@@ -118,11 +143,95 @@ var (
 			Help: "Gauge showing the current number of concurrent query requests by chain",
 		}, []string{"chain_name"})
 
+	currentInFlightRequests = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "ccq_server_current_in_flight_requests",
+			Help: "Gauge showing the number of query requests currently admitted for processing",
+		})
+
+	requestsRejectedByAdmissionControl = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ccq_server_requests_rejected_by_admission_control",
+			Help: "Total number of query requests rejected because the server was saturated by reason and user name",
+		}, []string{"reason", "user_name"})
+
+	currentWebSocketConnections = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "ccq_server_current_websocket_connections",
+			Help: "Gauge showing the number of connected WebSocket clients",
+		})
+
+	rateLimitedRequestsByUser = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ccq_server_rate_limited_requests_by_user",
+			Help: "Total number of requests rejected due to rate limiting by user name and reason",
+		}, []string{"user_name", "reason"})
+
+	dailyQuotaUsedByUser = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ccq_server_daily_quota_used_by_user",
+			Help: "Gauge showing the number of requests charged to the daily quota by user name",
+		}, []string{"user_name"})
+
+	dailyQuotaRemainingByUser = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ccq_server_daily_quota_remaining_by_user",
+			Help: "Gauge showing the number of requests remaining in the daily quota by user name, only for users with a quota",
+		}, []string{"user_name"})
+
+	queryCacheHitsByUser = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ccq_server_query_cache_hits_by_user",
+			Help: "Total number of queries answered from the response cache by user name",
+		}, []string{"user_name"})
+
+	queryCacheMissesByUser = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ccq_server_query_cache_misses_by_user",
+			Help: "Total number of queries not found in the response cache by user name",
+		}, []string{"user_name"})
+
+	queryCacheEntries = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "ccq_server_query_cache_entries",
+			Help: "Gauge showing the number of responses in the response cache",
+		})
+
 	maxConcurrentQueriesByChain = promauto.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "ccq_server_max_concurrent_queries_by_chain",
 			Help: "Gauge showing the maximum concurrent query requests by chain",
 		}, []string{"chain_name"})
+
+	queryFailovers = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "ccq_server_query_failovers",
+			Help: "Total number of requests sent to the remaining guardians because the selected ones did not reach quorum in time",
+		})
+
+	guardiansExcludedFromFanOut = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "ccq_server_guardians_excluded_from_fan_out",
+			Help: "Total number of times a guardian was temporarily excluded from request fan-out for not answering",
+		})
+
+	journalEntries = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "ccq_server_journal_entries",
+			Help: "Gauge showing the number of requests in the request journal",
+		})
+
+	journalErrors = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ccq_server_journal_errors",
+			Help: "Total number of errors reading or writing the request journal by reason",
+		}, []string{"reason"})
+
+	journalRequestsReplayed = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "ccq_server_journal_requests_replayed",
+			Help: "Total number of in-flight requests re-submitted from the request journal at startup",
+		})
 )
 
 // getGaugeValue returns the current value of a metric.
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/certusone/wormhole/node/pkg/common"
 	"github.com/certusone/wormhole/node/pkg/p2p"
 	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
 	"github.com/certusone/wormhole/node/pkg/query"
@@ -38,10 +39,12 @@ type P2PSub struct {
 	host       host.Host
 }
 
-func runP2P(ctx context.Context, priv crypto.PrivKey, port uint, networkID, bootstrapPeers, ethRpcUrl, ethCoreAddr string, pendingResponses *PendingResponses, logger *zap.Logger, monitorPeers bool, loggingMap *LoggingMap) (*P2PSub, error) {
+func runP2P(ctx context.Context, priv crypto.PrivKey, port uint, networkID, bootstrapPeers, ethRpcUrl, ethCoreAddr string, pendingResponses *PendingResponses, logger *zap.Logger, monitorPeers bool, loggingMap *LoggingMap, cache *ResponseCache, journal *RequestJournal, health *ProxyHealth, selector *GuardianSelector, ipMode common.IPMode, announceAddrs []string) (*P2PSub, error) {
 	// p2p setup
 	components := p2p.DefaultComponents()
 	components.Port = port
+	components.IPMode = ipMode
+	components.AnnounceAddrs = announceAddrs
 
 	h, err := p2p.NewHost(logger, ctx, networkID, bootstrapPeers, components, priv)
 	if err != nil {
@@ -90,6 +93,7 @@ func runP2P(ctx context.Context, priv crypto.PrivKey, port uint, networkID, boot
 	bootstrappers, _ := p2p.BootstrapAddrs(logger, bootstrapPeers, h.ID())
 	successes := p2p.ConnectToPeers(ctx, logger, h, bootstrappers)
 	logger.Info("Connected to bootstrap peers", zap.Int("num", successes))
+	health.setBootstrapped(successes, func() int { return len(th_req.ListPeers()) })
 
 	// Wait for peers
 	for len(th_req.ListPeers()) < 1 {
@@ -135,6 +139,7 @@ func runP2P(ctx context.Context, priv crypto.PrivKey, port uint, networkID, boot
 		logger.Fatal("Failed to fetch current guardian set", zap.Error(err))
 	}
 	quorum := vaa.CalculateQuorum(len(guardianSet.Keys))
+	selector.setGuardianSet(guardianSet.Keys)
 
 	// Listen to the p2p network for query responses
 	go func() {
@@ -206,6 +211,7 @@ func runP2P(ctx context.Context, priv crypto.PrivKey, port uint, networkID, boot
 				keyIdx, hasKeyIdx := guardianSet.KeyIndex(signerAddress)
 
 				if hasKeyIdx {
+					health.guardianResponded(time.Now())
 					if _, ok := responses[requestSignature]; !ok {
 						responses[requestSignature] = make(map[ethCommon.Hash][]GuardianSignature)
 					}
@@ -220,6 +226,8 @@ func runP2P(ctx context.Context, priv crypto.PrivKey, port uint, networkID, boot
 						// Already handled the response from this guardian
 						continue
 					}
+					selector.guardianAnswered(signerAddress, pendingResponse.guardianAnswered(signerAddress, time.Now()))
+					pendingResponse.recordResponse(digest, &queryResponse)
 					responses[requestSignature][digest] = append(responses[requestSignature][digest], GuardianSignature{
 						Index:     keyIdx,
 						Signature: hex.EncodeToString(m.SignedQueryResponse.Signature),
@@ -232,6 +240,11 @@ func runP2P(ctx context.Context, priv crypto.PrivKey, port uint, networkID, boot
 							Signatures: responses[requestSignature][digest],
 						}
 						delete(responses, requestSignature)
+						cache.Add(pendingResponse.req.QueryRequest, s, time.Now())
+						journal.Complete(requestSignature, s, time.Now())
+						pendingResponse.completed.Store(true)
+						health.queryCompleted(pendingResponse.queryRequest, true)
+						pendingResponse.observeOutcome("", time.Now())
 						select {
 						case pendingResponse.ch <- s:
 							logger.Info("quorum reached, forwarded query response",
@@ -259,8 +272,13 @@ func runP2P(ctx context.Context, priv crypto.PrivKey, port uint, networkID, boot
 							quorumNotMetByUser.WithLabelValues(pendingResponse.userName).Inc()
 							failedQueriesByUser.WithLabelValues(pendingResponse.userName).Inc()
 							delete(responses, requestSignature)
+							errEntry := &ErrorEntry{err: fmt.Errorf("quorum not met"), status: http.StatusBadRequest}
+							journal.Fail(requestSignature, errEntry.status, errEntry.err, time.Now())
+							pendingResponse.completed.Store(true)
+							health.queryCompleted(pendingResponse.queryRequest, false)
+							pendingResponse.observeOutcome("quorum_not_met", time.Now())
 							select {
-							case pendingResponse.errCh <- &ErrorEntry{err: fmt.Errorf("quorum not met"), status: http.StatusBadRequest}:
+							case pendingResponse.errCh <- errEntry:
 								logger.Info("query failed, quorum not met",
 									zap.String("peerId", peerId),
 									zap.String("userId", pendingResponse.userName),
@@ -275,6 +293,12 @@ func runP2P(ctx context.Context, priv crypto.PrivKey, port uint, networkID, boot
 								// Leave the request in the pending map. It will get cleaned up if it times out.
 							}
 						} else {
+							pendingResponse.reportProgress(&QueryProgress{
+								NumSigners:           numSigners,
+								MaxMatchingResponses: maxMatchingResponses,
+								OutstandingResponses: outstandingResponses,
+								Quorum:               quorum,
+							}, responses[requestSignature], len(guardianSet.Keys))
 							logger.Info("waiting for more query responses",
 								zap.String("peerId", peerId),
 								zap.String("userId", pendingResponse.userName),
@@ -347,3 +347,141 @@ func TestParseConfigSuccess(t *testing.T) {
 	_, exists = perm.allowedCalls["solPDA:1:Bridge1p5gheXUvJ6jGWGeCsgPKgnE3YgdGKRVCMY9o"]
 	assert.True(t, exists)
 }
+
+func TestParseConfigWildcardsRequireOptIn(t *testing.T) {
+	str := `
+	{
+  "permissions": [
+    {
+      "userName": "Test User",
+      "apiKey": "my_secret_key",
+      "allowedCalls": [
+        {
+          "solAccount": {
+            "chain": 1,
+            "account": "*"
+          }
+        }
+      ]
+    }
+  ]
+}`
+
+	_, err := parseConfig([]byte(str))
+	require.Error(t, err)
+	assert.Equal(t, `wildcard "*" is not allowed for user "Test User", must set "allowWildcards"`, err.Error())
+}
+
+func TestParseConfigWildcardInvalidPrefix(t *testing.T) {
+	str := `
+	{
+  "permissions": [
+    {
+      "userName": "Test User",
+      "apiKey": "my_secret_key",
+      "allowWildcards": true,
+      "allowedCalls": [
+        {
+          "solPDA": {
+            "chain": 1,
+            "programAddress": "Bridge0*"
+          }
+        }
+      ]
+    }
+  ]
+}`
+
+	_, err := parseConfig([]byte(str))
+	require.Error(t, err)
+	assert.Equal(t, `wildcard "Bridge0*" for user "Test User" is invalid, only a trailing "*" after a base58 prefix is supported`, err.Error())
+}
+
+func TestParseConfigWildcards(t *testing.T) {
+	str := `
+	{
+  "permissions": [
+    {
+      "userName": "Test User",
+      "apiKey": "my_secret_key",
+      "allowWildcards": true,
+      "allowedCalls": [
+        {
+          "solAccount": {
+            "chain": 1,
+            "account": "*"
+          }
+        },
+        {
+          "solPDA": {
+            "chain": 1,
+            "programAddress": "Bridge1*"
+          }
+        },
+        {
+          "solPDA": {
+            "chain": 1,
+            "programAddress": "worm2ZoG2kUd4vFXhvjh93UUH596ayRfgQ2MgjNMTth"
+          }
+        }
+      ]
+    }
+  ]
+}`
+
+	perms, err := parseConfig([]byte(str))
+	require.NoError(t, err)
+
+	perm, exists := perms["my_secret_key"]
+	require.True(t, exists)
+	assert.Equal(t, 3, len(perm.allowedCalls))
+	assert.Equal(t, []string{"solAccount:1:", "solPDA:1:Bridge1"}, perm.wildcardCalls)
+
+	assert.True(t, perm.callAllowed("solAccount:1:BVxyYhm498L79r4HMQ9sxZ5bi41DmJmeWZ7SCS7Cyvna"))
+	assert.False(t, perm.callAllowed("solAccount:2:BVxyYhm498L79r4HMQ9sxZ5bi41DmJmeWZ7SCS7Cyvna"))
+	assert.True(t, perm.callAllowed("solPDA:1:Bridge1p5gheXUvJ6jGWGeCsgPKgnE3YgdGKRVCMY9o"))
+	assert.True(t, perm.callAllowed("solPDA:1:worm2ZoG2kUd4vFXhvjh93UUH596ayRfgQ2MgjNMTth"))
+	assert.False(t, perm.callAllowed("solPDA:1:BVxyYhm498L79r4HMQ9sxZ5bi41DmJmeWZ7SCS7Cyvna"))
+}
+
+func TestParseConfigDuplicateJwtSubject(t *testing.T) {
+	str := `
+	{
+  "permissions": [
+    {
+      "userName": "Test User 1",
+      "apiKey": "my_secret_key",
+      "jwtSubject": "client-1",
+      "allowedCalls": [
+        {
+          "ethCall": {
+            "note:": "Name of WETH on Goerli",
+            "chain": 2,
+            "contractAddress": "B4FBF271143F4FBf7B91A5ded31805e42b2208d6",
+            "call": "0x06fdde03"
+          }
+        }
+      ]
+    },
+    {
+      "userName": "Test User 2",
+      "apiKey": "my_secret_key_2",
+      "jwtSubject": "client-1",
+      "allowedCalls": [
+        {
+          "ethCall": {
+            "note:": "Name of WETH on Goerli",
+            "chain": 2,
+            "contractAddress": "B4FBF271143F4FBf7B91A5ded31805e42b2208d6",
+            "call": "0x06fdde03"
+          }
+        }
+      ]
+    }
+  ]
+}`
+
+	_, err := parseConfig([]byte(str))
+	require.Error(t, err)
+	assert.Equal(t, `JWT subject "client-1" is a duplicate`, err.Error())
+}
@@ -3,9 +3,12 @@ package ccq
 import (
 	"encoding/hex"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
 	"github.com/certusone/wormhole/node/pkg/query"
+	ethCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.uber.org/zap"
 )
@@ -16,6 +19,48 @@ type PendingResponse struct {
 	queryRequest *query.QueryRequest
 	ch           chan *SignedResponse
 	errCh        chan *ErrorEntry
+	// progressCh is optionally used to report responses received before quorum is reached. Writes to it never block.
+	progressCh chan *QueryProgress
+	// completed is set by the p2p handler once the request has reached quorum or can no longer reach it.
+	completed atomic.Bool
+	// created is used to measure the time to quorum.
+	created time.Time
+
+	// When the request is sent to a subset of the guardians, sentAt records when it was sent to each of them, and answered which of them
+	// have answered. Both are nil if the request was sent to all guardians. failedOver is set once it has been sent to the remaining ones.
+	fanOutMu   sync.Mutex
+	sentAt     map[ethCommon.Address]time.Time
+	answered   map[ethCommon.Address]struct{}
+	failedOver bool
+
+	// bodies holds the first response received for each response digest, and lastProgress the last progress reported to the client. Both
+	// are only used if the client asked for progress.
+	progressMu   sync.Mutex
+	bodies       map[ethCommon.Hash]*query.QueryResponsePublication
+	lastProgress *QueryProgress
+}
+
+// QueryProgress describes the responses received so far for a request that has not yet reached quorum.
+type QueryProgress struct {
+	NumSigners           int
+	MaxMatchingResponses int
+	OutstandingResponses int
+	Quorum               int
+	// Chains holds the leading response to each per-chain query, in the order of the request.
+	Chains []ChainProgress
+	// GuardiansQueried is the number of guardians the request has been sent to so far, and FailedOver is set once it has been sent to
+	// the remaining guardians because it did not reach quorum within the failover timeout.
+	GuardiansQueried int
+	FailedOver       bool
+}
+
+// ChainProgress is the response to a per-chain query returned by the most guardians so far. Guardians may disagree on one chain and agree
+// on the others, so each per-chain query has its own leading response.
+type ChainProgress struct {
+	ChainId vaa.ChainID
+	// Response is the serialized chain specific response, or nil if no guardian has answered yet.
+	Response          []byte
+	MatchingResponses int
 }
 
 type ErrorEntry struct {
@@ -30,6 +75,175 @@ func NewPendingResponse(req *gossipv1.SignedQueryRequest, userName string, query
 		queryRequest: queryRequest,
 		ch:           make(chan *SignedResponse),
 		errCh:        make(chan *ErrorEntry),
+		created:      time.Now(),
+	}
+}
+
+// sentTo records that the request was sent to the given guardians.
+func (r *PendingResponse) sentTo(keys []ethCommon.Address, now time.Time) {
+	r.fanOutMu.Lock()
+	defer r.fanOutMu.Unlock()
+	if r.sentAt == nil {
+		r.sentAt = make(map[ethCommon.Address]time.Time, len(keys))
+		r.answered = make(map[ethCommon.Address]struct{}, len(keys))
+	} else {
+		r.failedOver = true
+	}
+	for _, key := range keys {
+		r.sentAt[key] = now
+	}
+}
+
+// guardianAnswered records an answer from a guardian and returns how long it took, measured from when the request was sent to it.
+func (r *PendingResponse) guardianAnswered(key ethCommon.Address, now time.Time) time.Duration {
+	r.fanOutMu.Lock()
+	defer r.fanOutMu.Unlock()
+	sent, exists := r.sentAt[key]
+	if !exists {
+		sent = r.created
+	}
+	if r.answered != nil {
+		r.answered[key] = struct{}{}
+	}
+	return now.Sub(sent)
+}
+
+// unanswered returns the guardians in keys which have not answered yet.
+func (r *PendingResponse) unanswered(keys []ethCommon.Address) []ethCommon.Address {
+	r.fanOutMu.Lock()
+	defer r.fanOutMu.Unlock()
+	var result []ethCommon.Address
+	for _, key := range keys {
+		if _, exists := r.answered[key]; !exists {
+			result = append(result, key)
+		}
+	}
+	return result
+}
+
+// fanOutState returns how many of numGuardians guardians the request has been sent to, and whether it failed over to the remaining ones.
+func (r *PendingResponse) fanOutState(numGuardians int) (int, bool) {
+	r.fanOutMu.Lock()
+	defer r.fanOutMu.Unlock()
+	if r.sentAt == nil {
+		return numGuardians, false
+	}
+	return len(r.sentAt), r.failedOver
+}
+
+// recordResponse keeps the body of a guardian response, so that progress reports can include the per-chain responses. Only the first
+// response with a given digest is kept, since the others are identical.
+func (r *PendingResponse) recordResponse(digest ethCommon.Hash, resp *query.QueryResponsePublication) {
+	if r.progressCh == nil {
+		return
+	}
+	r.progressMu.Lock()
+	defer r.progressMu.Unlock()
+	if r.bodies == nil {
+		r.bodies = make(map[ethCommon.Hash]*query.QueryResponsePublication)
+	}
+	if _, exists := r.bodies[digest]; !exists {
+		r.bodies[digest] = resp
+	}
+}
+
+// reportProgress sends the progress of the request to the client, if it asked for it. signers maps the digest of each response received
+// so far to the guardians that signed it, and is used to find the leading response to each per-chain query.
+func (r *PendingResponse) reportProgress(p *QueryProgress, signers map[ethCommon.Hash][]GuardianSignature, numGuardians int) {
+	if r.progressCh == nil {
+		return
+	}
+	p.GuardiansQueried, p.FailedOver = r.fanOutState(numGuardians)
+	r.progressMu.Lock()
+	p.Chains = r.chainProgress(signers)
+	r.lastProgress = p
+	r.progressMu.Unlock()
+	r.sendProgress(p)
+}
+
+// reportFailover tells the client that the request has been sent to the remaining guardians. The counts are those of the last progress
+// report, if there was one.
+func (r *PendingResponse) reportFailover(numGuardians int, quorum int) {
+	if r.progressCh == nil {
+		return
+	}
+	r.progressMu.Lock()
+	var p QueryProgress
+	if r.lastProgress != nil {
+		p = *r.lastProgress
+	} else {
+		p = QueryProgress{OutstandingResponses: numGuardians, Quorum: quorum, Chains: r.chainProgress(nil)}
+	}
+	p.GuardiansQueried, p.FailedOver = r.fanOutState(numGuardians)
+	r.lastProgress = &p
+	r.progressMu.Unlock()
+	r.sendProgress(&p)
+}
+
+// sendProgress delivers a progress report without blocking. A report the client has not picked up yet is replaced, since it is stale.
+func (r *PendingResponse) sendProgress(p *QueryProgress) {
+	for {
+		select {
+		case r.progressCh <- p:
+			return
+		default:
+		}
+		select {
+		case <-r.progressCh:
+		default:
+		}
+	}
+}
+
+// chainProgress returns the leading response to each per-chain query. r.progressMu must be held.
+func (r *PendingResponse) chainProgress(signers map[ethCommon.Hash][]GuardianSignature) []ChainProgress {
+	if r.queryRequest == nil {
+		return nil
+	}
+	chains := make([]ChainProgress, len(r.queryRequest.PerChainQueries))
+	for idx, pcq := range r.queryRequest.PerChainQueries {
+		chains[idx].ChainId = pcq.ChainId
+		counts := make(map[string]int)
+		for digest, sigs := range signers {
+			body, exists := r.bodies[digest]
+			if !exists || idx >= len(body.PerChainResponses) {
+				continue
+			}
+			resp, err := body.PerChainResponses[idx].Response.Marshal()
+			if err != nil {
+				continue
+			}
+			counts[string(resp)] += len(sigs)
+		}
+		for resp, count := range counts {
+			// Ties are broken by the response bytes, so that the same state is always reported the same way.
+			if count > chains[idx].MatchingResponses || (count == chains[idx].MatchingResponses && resp < string(chains[idx].Response)) {
+				chains[idx].Response = []byte(resp)
+				chains[idx].MatchingResponses = count
+			}
+		}
+	}
+	return chains
+}
+
+// observeOutcome pegs the per user and chain metrics for a query that has completed. An empty reason means it reached quorum.
+func (r *PendingResponse) observeOutcome(reason string, now time.Time) {
+	if r.queryRequest == nil {
+		return
+	}
+	seen := make(map[vaa.ChainID]struct{}, len(r.queryRequest.PerChainQueries))
+	for _, pcq := range r.queryRequest.PerChainQueries {
+		if _, exists := seen[pcq.ChainId]; exists {
+			continue
+		}
+		seen[pcq.ChainId] = struct{}{}
+		chainName := pcq.ChainId.String()
+		if reason == "" {
+			successfulQueriesByUserAndChain.WithLabelValues(r.userName, chainName).Inc()
+			queryTimeByUserAndChain.WithLabelValues(r.userName, chainName).Observe(float64(now.Sub(r.created).Milliseconds()))
+		} else {
+			failedQueriesByUserAndChain.WithLabelValues(r.userName, chainName, reason).Inc()
+		}
 	}
 }
 
@@ -37,13 +251,15 @@ type PendingResponses struct {
 	pendingResponses map[string]*PendingResponse
 	mu               sync.RWMutex
 	logger           *zap.Logger
+	health           *ProxyHealth
 }
 
-func NewPendingResponses(logger *zap.Logger) *PendingResponses {
+func NewPendingResponses(logger *zap.Logger, health *ProxyHealth) *PendingResponses {
 	return &PendingResponses{
 		// Make this channel bigger than the number of responses we ever expect to get for a query.
 		pendingResponses: make(map[string]*PendingResponse, 100),
 		logger:           logger,
+		health:           health,
 	}
 }
 
@@ -58,6 +274,7 @@ func (p *PendingResponses) Add(r *PendingResponse) bool {
 	}
 	p.pendingResponses[signature] = r
 	p.updateMetricsAlreadyLocked()
+	p.health.requestPublished(time.Now())
 	return true
 }
 
@@ -74,7 +291,15 @@ func (p *PendingResponses) Remove(r *PendingResponse) {
 	signature := hex.EncodeToString(r.req.Signature)
 	p.mu.Lock()
 	defer p.mu.Unlock()
-	delete(p.pendingResponses, signature)
+	// Responses served from the cache are never added, so make sure we don't remove somebody else's request with the same signature.
+	if p.pendingResponses[signature] == r {
+		delete(p.pendingResponses, signature)
+		if !r.completed.Load() {
+			// The request timed out or the client went away before the guardians answered.
+			p.health.queryCompleted(r.queryRequest, false)
+			r.observeOutcome("unanswered", time.Now())
+		}
+	}
 }
 
 func (p *PendingResponses) NumPending() int {
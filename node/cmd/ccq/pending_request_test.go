@@ -0,0 +1,100 @@
+package ccq
+
+import (
+	"testing"
+	"time"
+
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	"github.com/certusone/wormhole/node/pkg/query"
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// progressTestResponse returns a response to a request for two Solana accounts, whose per-chain responses are at the given slots.
+func progressTestResponse(req *gossipv1.SignedQueryRequest, slots ...uint64) *query.QueryResponsePublication {
+	resp := &query.QueryResponsePublication{Request: req}
+	for _, slot := range slots {
+		resp.PerChainResponses = append(resp.PerChainResponses, &query.PerChainQueryResponse{
+			ChainId: vaa.ChainIDSolana,
+			Response: &query.SolanaAccountQueryResponse{
+				SlotNumber: slot,
+				BlockTime:  time.UnixMicro(1700000000000000),
+				Results:    []query.SolanaAccountResult{{Lamports: slot, Data: []byte("data")}},
+			},
+		})
+	}
+	return resp
+}
+
+func TestPendingResponseProgress(t *testing.T) {
+	pcq := &query.PerChainQueryRequest{
+		ChainId: vaa.ChainIDSolana,
+		Query:   &query.SolanaAccountQueryRequest{Commitment: "finalized", Accounts: [][query.SolanaPublicKeyLength]byte{{1}}},
+	}
+	queryRequest := &query.QueryRequest{Nonce: 1, PerChainQueries: []*query.PerChainQueryRequest{pcq, pcq}}
+	req := &gossipv1.SignedQueryRequest{Signature: []byte{1}}
+	pr := NewPendingResponse(req, "Test User", queryRequest)
+	pr.progressCh = make(chan *QueryProgress, 1)
+	keys := selectorTestKeys(5)
+	pr.sentTo(keys[:4], time.Now())
+
+	// Two guardians agree on both chains, a third one only on the first chain.
+	digestA, digestB := ethCommon.Hash{1}, ethCommon.Hash{2}
+	pr.recordResponse(digestA, progressTestResponse(req, 10, 20))
+	pr.recordResponse(digestB, progressTestResponse(req, 10, 21))
+	signers := map[ethCommon.Hash][]GuardianSignature{
+		digestA: {{Index: 0}, {Index: 1}},
+		digestB: {{Index: 2}},
+	}
+	pr.reportProgress(&QueryProgress{NumSigners: 1, MaxMatchingResponses: 2, OutstandingResponses: 2, Quorum: 4}, signers, len(keys))
+
+	p := <-pr.progressCh
+	assert.Equal(t, 2, p.MaxMatchingResponses)
+	assert.Equal(t, 4, p.GuardiansQueried)
+	assert.False(t, p.FailedOver)
+	require.Len(t, p.Chains, 2)
+	first, err := progressTestResponse(req, 10).PerChainResponses[0].Response.Marshal()
+	require.NoError(t, err)
+	second, err := progressTestResponse(req, 20).PerChainResponses[0].Response.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, ChainProgress{ChainId: vaa.ChainIDSolana, Response: first, MatchingResponses: 3}, p.Chains[0])
+	assert.Equal(t, ChainProgress{ChainId: vaa.ChainIDSolana, Response: second, MatchingResponses: 2}, p.Chains[1])
+
+	// Failing over reports the last counts with the new fan-out, replacing a report the client has not picked up yet.
+	pr.reportProgress(&QueryProgress{NumSigners: 2, MaxMatchingResponses: 2, OutstandingResponses: 2, Quorum: 4}, signers, len(keys))
+	pr.sentTo(keys[4:], time.Now())
+	pr.reportFailover(len(keys), 4)
+	p = <-pr.progressCh
+	assert.Equal(t, 2, p.NumSigners)
+	assert.Equal(t, 5, p.GuardiansQueried)
+	assert.True(t, p.FailedOver)
+	assert.Equal(t, 3, p.Chains[0].MatchingResponses)
+	assert.Empty(t, pr.progressCh)
+}
+
+func TestPendingResponseFailoverWithoutResponses(t *testing.T) {
+	pr, _ := createJournalTestRequest(t, 1)
+	pr.progressCh = make(chan *QueryProgress, 1)
+	keys := selectorTestKeys(4)
+	pr.sentTo(keys[:3], time.Now())
+	pr.sentTo(keys[3:], time.Now())
+	pr.reportFailover(len(keys), 3)
+
+	p := <-pr.progressCh
+	assert.Equal(t, &QueryProgress{
+		OutstandingResponses: 4,
+		Quorum:               3,
+		Chains:               []ChainProgress{{ChainId: vaa.ChainIDSolana}},
+		GuardiansQueried:     4,
+		FailedOver:           true,
+	}, p)
+
+	// Requests sent to all guardians at once never fail over, and nothing is reported to clients which did not ask for progress.
+	pr, _ = createJournalTestRequest(t, 2)
+	pr.reportFailover(len(keys), 3)
+	queried, failedOver := pr.fanOutState(len(keys))
+	assert.Equal(t, 4, queried)
+	assert.False(t, failedOver)
+}
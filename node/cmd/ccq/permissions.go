@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"strings"
 	"sync"
@@ -25,19 +26,33 @@ type (
 	}
 
 	User struct {
-		UserName      string        `json:"userName"`
-		ApiKey        string        `json:"apiKey"`
-		AllowUnsigned bool          `json:"allowUnsigned"`
-		LogResponses  bool          `json:"logResponses"`
-		AllowedCalls  []AllowedCall `json:"allowedCalls"`
+		UserName      string `json:"userName"`
+		ApiKey        string `json:"apiKey"`
+		AllowUnsigned bool   `json:"allowUnsigned"`
+		LogResponses  bool   `json:"logResponses"`
+		// AllowWildcards must be set for the user's allowed calls to contain wildcards, such as "*" or "Prefix*".
+		AllowWildcards bool `json:"allowWildcards"`
+		// RateLimit is the sustained number of requests per second allowed for this user, zero means unlimited.
+		RateLimit float64 `json:"rateLimit"`
+		// Burst is the number of requests that may be made at once before the rate limit applies. Defaults to the rate limit rounded up.
+		Burst int `json:"burst"`
+		// DailyQuota is the number of requests allowed per UTC day, zero means unlimited.
+		DailyQuota uint64 `json:"dailyQuota"`
+		// JwtSubject is the value of the user claim in bearer tokens issued to this user, if JWT authentication is enabled.
+		JwtSubject string `json:"jwtSubject"`
+		// BypassCache causes this user's queries to always be sent to the guardians, even if the response cache is enabled.
+		BypassCache bool `json:"bypassCache"`
+		// Disabled users stay in the permissions file but their queries are rejected as if their API key was unknown.
+		Disabled     bool          `json:"disabled"`
+		AllowedCalls []AllowedCall `json:"allowedCalls"`
 	}
 
 	AllowedCall struct {
-		EthCall             *EthCall             `json:"ethCall"`
-		EthCallByTimestamp  *EthCallByTimestamp  `json:"ethCallByTimestamp"`
-		EthCallWithFinality *EthCallWithFinality `json:"ethCallWithFinality"`
-		SolanaAccount       *SolanaAccount       `json:"solAccount"`
-		SolanaPda           *SolanaPda           `json:"solPDA"`
+		EthCall             *EthCall             `json:"ethCall,omitempty"`
+		EthCallByTimestamp  *EthCallByTimestamp  `json:"ethCallByTimestamp,omitempty"`
+		EthCallWithFinality *EthCallWithFinality `json:"ethCallWithFinality,omitempty"`
+		SolanaAccount       *SolanaAccount       `json:"solAccount,omitempty"`
+		SolanaPda           *SolanaPda           `json:"solPDA,omitempty"`
 	}
 
 	EthCall struct {
@@ -77,6 +92,12 @@ type (
 		allowUnsigned bool
 		logResponses  bool
 		allowedCalls  allowedCallsForUser // Key is something like "ethCall:2:000000000000000000000000b4fbf271143f4fbf7b91a5ded31805e42b2208d6:06fdde03"
+		wildcardCalls []string            // Call key prefixes, something like "solAccount:1:" to allow all accounts on a chain
+		rateLimit     float64
+		burst         int
+		dailyQuota    uint64
+		bypassCache   bool
+		jwtSubject    string
 	}
 
 	allowedCallsForUser map[string]struct{}
@@ -86,6 +107,10 @@ type (
 		permMap  PermissionsMap
 		fileName string
 		watcher  *fswatch.Watcher
+		usage    map[string]*userUsage // Key is the API key
+
+		// fileLock serializes reloads and updates of the permissions file, so that concurrent updates don't overwrite each other.
+		fileLock sync.Mutex
 	}
 )
 
@@ -99,6 +124,7 @@ func NewPermissions(fileName string) (*Permissions, error) {
 	return &Permissions{
 		permMap:  permMap,
 		fileName: fileName,
+		usage:    make(map[string]*userUsage),
 	}, nil
 }
 
@@ -119,26 +145,90 @@ func (perms *Permissions) StartWatcher(ctx context.Context, logger *zap.Logger,
 				}
 
 				logger.Info("the permissions file has been updated", zap.String("fileName", notif.Path), zap.Int("event", int(notif.Event)))
-				perms.Reload(logger)
+				_ = perms.Reload(logger)
 			}
 		}
 	})
 }
 
-// Reload reloads the permissions file.
-func (perms *Permissions) Reload(logger *zap.Logger) {
+// Reload reloads the permissions file. If the new file is invalid, the existing permissions remain in effect and the error is returned.
+// Queries that are already in flight keep using the permissions entry they were admitted with.
+func (perms *Permissions) Reload(logger *zap.Logger) error {
+	perms.fileLock.Lock()
+	defer perms.fileLock.Unlock()
 	permMap, err := parseConfigFile(perms.fileName)
 	if err != nil {
 		logger.Error("failed to reload the permissions file, sticking with the old one", zap.String("fileName", perms.fileName), zap.Error(err))
 		permissionFileReloadsFailure.Inc()
-		return
+		return err
 	}
 
-	logger.Info("successfully reloaded the permissions file, switching to it", zap.String("fileName", perms.fileName))
+	logger.Info("successfully reloaded the permissions file, switching to it", zap.String("fileName", perms.fileName), zap.Int("numUsers", len(permMap)))
+	perms.swap(permMap)
+	permissionFileReloadsSuccess.Inc()
+	return nil
+}
+
+func (perms *Permissions) swap(permMap PermissionsMap) {
 	perms.lock.Lock()
 	perms.permMap = permMap
+	perms.pruneUsage()
 	perms.lock.Unlock()
-	permissionFileReloadsSuccess.Inc()
+}
+
+// Users returns the users configured in the permissions file, including disabled ones.
+func (perms *Permissions) Users() ([]User, error) {
+	perms.fileLock.Lock()
+	defer perms.fileLock.Unlock()
+	config, err := readConfigFile(perms.fileName)
+	if err != nil {
+		return nil, err
+	}
+	return config.Permissions, nil
+}
+
+// UpdateUsers applies update to the users in the permissions file. If the result is valid, it is written back to the file and
+// takes effect immediately. Otherwise, the file and the active permissions are left untouched and the error is returned.
+func (perms *Permissions) UpdateUsers(logger *zap.Logger, update func(users []User) ([]User, error)) error {
+	perms.fileLock.Lock()
+	defer perms.fileLock.Unlock()
+	config, err := readConfigFile(perms.fileName)
+	if err != nil {
+		return err
+	}
+	config.Permissions, err = update(config.Permissions)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal permissions: %w", err)
+	}
+	permMap, err := parseConfig(b)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file and rename it, so that the file is never seen half written.
+	tmpFileName := perms.fileName + ".tmp"
+	if err := os.WriteFile(tmpFileName, b, 0600); err != nil {
+		return fmt.Errorf(`failed to write permissions file "%s": %w`, tmpFileName, err)
+	}
+	if err := os.Rename(tmpFileName, perms.fileName); err != nil {
+		return fmt.Errorf(`failed to replace permissions file "%s": %w`, perms.fileName, err)
+	}
+
+	logger.Info("updated the permissions file, switching to it", zap.String("fileName", perms.fileName), zap.Int("numUsers", len(permMap)))
+	perms.swap(permMap)
+	return nil
+}
+
+// NumUsers returns the number of users in the currently active permissions.
+func (perms *Permissions) NumUsers() int {
+	perms.lock.Lock()
+	defer perms.lock.Unlock()
+	return len(perms.permMap)
 }
 
 // StopWatcher stops the permissions file watcher.
@@ -156,8 +246,66 @@ func (perms *Permissions) GetUserEntry(apiKey string) (*permissionEntry, bool) {
 	return userEntry, exists
 }
 
+// GetUserEntryForJwtSubject returns the permissions entry of the user with the given JWT subject.
+func (perms *Permissions) GetUserEntryForJwtSubject(subject string) (*permissionEntry, bool) {
+	perms.lock.Lock()
+	defer perms.lock.Unlock()
+	for _, userEntry := range perms.permMap {
+		if userEntry.jwtSubject == subject {
+			return userEntry, true
+		}
+	}
+	return nil, false
+}
+
 const ETH_CALL_SIG_LENGTH = 4
 
+// wildcardSuffix marks an allowed call as matching any call key that starts with the preceding prefix.
+const wildcardSuffix = "*"
+
+// base58Alphabet is the set of characters that may appear in a Solana address prefix.
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// callAllowed returns true if the user is allowed to make the specified call, either explicitly or through a wildcard.
+func (pe *permissionEntry) callAllowed(callKey string) bool {
+	if _, exists := pe.allowedCalls[callKey]; exists {
+		return true
+	}
+	for _, prefix := range pe.wildcardCalls {
+		if strings.HasPrefix(callKey, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseWildcard validates a base58 address wildcard such as "*" or "Prefix*" and returns the prefix.
+func parseWildcard(user User, addr string) (string, error) {
+	if !user.AllowWildcards {
+		return "", fmt.Errorf(`wildcard "%s" is not allowed for user "%s", must set "allowWildcards"`, addr, user.UserName)
+	}
+	prefix := strings.TrimSuffix(addr, wildcardSuffix)
+	for _, c := range prefix {
+		if !strings.ContainsRune(base58Alphabet, c) {
+			return "", fmt.Errorf(`wildcard "%s" for user "%s" is invalid, only a trailing "*" after a base58 prefix is supported`, addr, user.UserName)
+		}
+	}
+	return prefix, nil
+}
+
+// readConfigFile reads the permissions config file without validating it.
+func readConfigFile(fileName string) (*Config, error) {
+	byteValue, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to read permissions file "%s": %w`, fileName, err)
+	}
+	var config Config
+	if err := json.Unmarshal(byteValue, &config); err != nil {
+		return nil, fmt.Errorf(`failed to unmarshal permissions file "%s": %w`, fileName, err)
+	}
+	return &config, nil
+}
+
 // parseConfigFile parses the permissions config file into a map keyed by API key.
 func parseConfigFile(fileName string) (PermissionsMap, error) {
 	jsonFile, err := os.Open(fileName)
@@ -188,6 +336,8 @@ func parseConfig(byteValue []byte) (PermissionsMap, error) {
 
 	ret := make(PermissionsMap)
 	userNames := map[string]struct{}{}
+	apiKeys := map[string]struct{}{}
+	jwtSubjects := map[string]struct{}{}
 	for _, user := range config.Permissions {
 		// Since we log user names in all our error messages, make sure they are unique.
 		if _, exists := userNames[user.UserName]; exists {
@@ -196,12 +346,21 @@ func parseConfig(byteValue []byte) (PermissionsMap, error) {
 		userNames[user.UserName] = struct{}{}
 
 		apiKey := strings.ToLower(user.ApiKey)
-		if _, exists := ret[apiKey]; exists {
+		if _, exists := apiKeys[apiKey]; exists {
 			return nil, fmt.Errorf(`API key "%s" is a duplicate`, apiKey)
 		}
+		apiKeys[apiKey] = struct{}{}
+
+		if user.JwtSubject != "" {
+			if _, exists := jwtSubjects[user.JwtSubject]; exists {
+				return nil, fmt.Errorf(`JWT subject "%s" is a duplicate`, user.JwtSubject)
+			}
+			jwtSubjects[user.JwtSubject] = struct{}{}
+		}
 
 		// Build the list of allowed calls for this API key.
 		allowedCalls := make(allowedCallsForUser)
+		var wildcardCalls []string
 		for _, ac := range user.AllowedCalls {
 			var chain int
 			var callType, contractAddressStr, callStr, callKey string
@@ -224,7 +383,13 @@ func parseConfig(byteValue []byte) (PermissionsMap, error) {
 			} else if ac.SolanaAccount != nil {
 				// We assume the account is base58, but if it starts with "0x" it should be 32 bytes of hex.
 				account := ac.SolanaAccount.Account
-				if strings.HasPrefix(account, "0x") {
+				if strings.HasSuffix(account, "*") {
+					prefix, err := parseWildcard(user, account)
+					if err != nil {
+						return nil, err
+					}
+					callKey = fmt.Sprintf("solAccount:%d:%s%s", ac.SolanaAccount.Chain, prefix, wildcardSuffix)
+				} else if strings.HasPrefix(account, "0x") {
 					buf, err := hex.DecodeString(account[2:])
 					if err != nil {
 						return nil, fmt.Errorf(`invalid solana account hex string "%s" for user "%s": %w`, account, user.UserName, err)
@@ -240,11 +405,19 @@ func parseConfig(byteValue []byte) (PermissionsMap, error) {
 						return nil, fmt.Errorf(`solana account string "%s" for user "%s" is not valid base58: %w`, account, user.UserName, err)
 					}
 				}
-				callKey = fmt.Sprintf("solAccount:%d:%s", ac.SolanaAccount.Chain, account)
+				if callKey == "" {
+					callKey = fmt.Sprintf("solAccount:%d:%s", ac.SolanaAccount.Chain, account)
+				}
 			} else if ac.SolanaPda != nil {
 				// We assume the account is base58, but if it starts with "0x" it should be 32 bytes of hex.
 				pa := ac.SolanaPda.ProgramAddress
-				if strings.HasPrefix(pa, "0x") {
+				if strings.HasSuffix(pa, "*") {
+					prefix, err := parseWildcard(user, pa)
+					if err != nil {
+						return nil, err
+					}
+					callKey = fmt.Sprintf("solPDA:%d:%s%s", ac.SolanaPda.Chain, prefix, wildcardSuffix)
+				} else if strings.HasPrefix(pa, "0x") {
 					buf, err := hex.DecodeString(pa[2:])
 					if err != nil {
 						return nil, fmt.Errorf(`invalid solana program address hex string "%s" for user "%s": %w`, pa, user.UserName, err)
@@ -260,7 +433,9 @@ func parseConfig(byteValue []byte) (PermissionsMap, error) {
 						return nil, fmt.Errorf(`solana program address string "%s" for user "%s" is not valid base58: %w`, pa, user.UserName, err)
 					}
 				}
-				callKey = fmt.Sprintf("solPDA:%d:%s", ac.SolanaPda.Chain, pa)
+				if callKey == "" {
+					callKey = fmt.Sprintf("solPDA:%d:%s", ac.SolanaPda.Chain, pa)
+				}
 			} else {
 				return nil, fmt.Errorf(`unsupported call type for user "%s", must be "ethCall", "ethCallByTimestamp", "ethCallWithFinality", "solAccount" or "solPDA"`, user.UserName)
 			}
@@ -290,6 +465,20 @@ func parseConfig(byteValue []byte) (PermissionsMap, error) {
 			}
 
 			allowedCalls[callKey] = struct{}{}
+			if strings.HasSuffix(callKey, wildcardSuffix) {
+				wildcardCalls = append(wildcardCalls, strings.TrimSuffix(callKey, wildcardSuffix))
+			}
+		}
+
+		if user.RateLimit < 0 {
+			return nil, fmt.Errorf(`rateLimit for user "%s" may not be negative`, user.UserName)
+		}
+		if user.Burst < 0 {
+			return nil, fmt.Errorf(`burst for user "%s" may not be negative`, user.UserName)
+		}
+		burst := user.Burst
+		if user.RateLimit != 0 && burst == 0 {
+			burst = int(math.Ceil(user.RateLimit))
 		}
 
 		pe := &permissionEntry{
@@ -298,8 +487,18 @@ func parseConfig(byteValue []byte) (PermissionsMap, error) {
 			allowUnsigned: user.AllowUnsigned,
 			logResponses:  user.LogResponses,
 			allowedCalls:  allowedCalls,
+			wildcardCalls: wildcardCalls,
+			rateLimit:     user.RateLimit,
+			burst:         burst,
+			dailyQuota:    user.DailyQuota,
+			bypassCache:   user.BypassCache,
+			jwtSubject:    user.JwtSubject,
 		}
 
+		// Disabled users are still validated, so that they can be re-enabled without surprises.
+		if user.Disabled {
+			continue
+		}
 		ret[apiKey] = pe
 	}
 
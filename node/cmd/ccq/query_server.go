@@ -27,24 +27,42 @@ import (
 const CCQ_SERVER_SIGNING_KEY = "CCQ SERVER SIGNING KEY"
 
 var (
-	envStr            *string
-	p2pNetworkID      *string
-	p2pPort           *uint
-	p2pBootstrap      *string
-	listenAddr        *string
-	nodeKeyPath       *string
-	signerKeyPath     *string
-	permFile          *string
-	ethRPC            *string
-	ethContract       *string
-	logLevel          *string
-	telemetryLokiURL  *string
-	telemetryNodeName *string
-	statusAddr        *string
-	promRemoteURL     *string
-	shutdownDelay1    *uint
-	shutdownDelay2    *uint
-	monitorPeers      *bool
+	envStr              *string
+	p2pNetworkID        *string
+	p2pPort             *uint
+	p2pBootstrap        *string
+	listenAddr          *string
+	nodeKeyPath         *string
+	signerKeyPath       *string
+	permFile            *string
+	ethRPC              *string
+	ethContract         *string
+	logLevel            *string
+	telemetryLokiURL    *string
+	telemetryNodeName   *string
+	statusAddr          *string
+	adminListenAddr     *string
+	promRemoteURL       *string
+	shutdownDelay1      *uint
+	shutdownDelay2      *uint
+	monitorPeers        *bool
+	maxInFlight         *int
+	maxInFlightPerUser  *int
+	grpcListenAddr      *string
+	cacheTTL            *time.Duration
+	cacheMaxEntries     *int
+	jwtIssuer           *string
+	jwtJwksURL          *string
+	jwtAudience         *string
+	jwtUserClaim        *string
+	journalDir          *string
+	journalRetention    *time.Duration
+	journalReplayWindow *time.Duration
+	readyMinPeers       *int
+	ipModeStr           *string
+	announceAddrs       *[]string
+	guardianFanOut      *int
+	failoverTimeout     *time.Duration
 )
 
 const DEV_NETWORK_ID = "/wormhole/dev"
@@ -57,6 +75,7 @@ func init() {
 	nodeKeyPath = QueryServerCmd.Flags().String("nodeKey", "", "Path to node key (will be generated if it doesn't exist)")
 	signerKeyPath = QueryServerCmd.Flags().String("signerKey", "", "Path to key used to sign unsigned queries")
 	listenAddr = QueryServerCmd.Flags().String("listenAddr", "[::]:6069", "Listen address for query server (disabled if blank)")
+	grpcListenAddr = QueryServerCmd.Flags().String("grpcListenAddr", "", "Listen address for the gRPC query service (disabled if blank)")
 	permFile = QueryServerCmd.Flags().String("permFile", "", "JSON file containing permissions configuration")
 	ethRPC = QueryServerCmd.Flags().String("ethRPC", "", "Ethereum RPC for fetching current guardian set")
 	ethContract = QueryServerCmd.Flags().String("ethContract", "", "Ethereum core bridge address for fetching current guardian set")
@@ -64,8 +83,25 @@ func init() {
 	telemetryLokiURL = QueryServerCmd.Flags().String("telemetryLokiURL", "", "Loki cloud logging URL")
 	telemetryNodeName = QueryServerCmd.Flags().String("telemetryNodeName", "", "Node name used in telemetry")
 	statusAddr = QueryServerCmd.Flags().String("statusAddr", "[::]:6060", "Listen address for status server (disabled if blank)")
+	ipModeStr = QueryServerCmd.Flags().String("ipMode", "dual", "IP versions to listen on for p2p, the query, gRPC, admin and status servers (dual, ipv4 or ipv6)")
+	announceAddrs = QueryServerCmd.Flags().StringSlice("announceAddrs", nil, "P2P multiaddrs to advertise to peers instead of the listening addresses (comma-separated)")
+	readyMinPeers = QueryServerCmd.Flags().Int("readyMinPeers", 1, "Minimum number of p2p peers required for /readyz to report the server as ready")
+	adminListenAddr = QueryServerCmd.Flags().String("adminListenAddr", "", "Listen address for admin server, either a loopback host:port or unix:/path/to/socket (disabled if blank)")
 	promRemoteURL = QueryServerCmd.Flags().String("promRemoteURL", "", "Prometheus remote write URL (Grafana)")
 	monitorPeers = QueryServerCmd.Flags().Bool("monitorPeers", false, "Should monitor bootstrap peers and attempt to reconnect")
+	maxInFlight = QueryServerCmd.Flags().Int("maxInFlight", 0, "Maximum number of query requests processed concurrently across all users, further requests are rejected with a 503 (zero means unlimited)")
+	maxInFlightPerUser = QueryServerCmd.Flags().Int("maxInFlightPerUser", 0, "Maximum number of query requests processed concurrently for a single user, further requests are rejected with a 503 (zero means unlimited)")
+	cacheTTL = QueryServerCmd.Flags().Duration("cacheTTL", 0, "How long responses are cached and served to identical queries without contacting the guardians (zero disables the cache)")
+	cacheMaxEntries = QueryServerCmd.Flags().Int("cacheMaxEntries", 10000, "Maximum number of responses held in the response cache")
+	jwtIssuer = QueryServerCmd.Flags().String("jwtIssuer", "", "Issuer of JWT bearer tokens accepted in place of an API key (JWT authentication is disabled if blank)")
+	jwtJwksURL = QueryServerCmd.Flags().String("jwtJwksURL", "", "URL of the JWKS used to verify JWT bearer tokens")
+	jwtAudience = QueryServerCmd.Flags().String("jwtAudience", "", "Audience that JWT bearer tokens must be intended for (not checked if blank)")
+	jwtUserClaim = QueryServerCmd.Flags().String("jwtUserClaim", "sub", "JWT claim that is matched against the jwtSubject of the users in the permissions file")
+	journalDir = QueryServerCmd.Flags().String("journalDir", "", "Directory where accepted requests and their outcomes are journaled, so they can be replayed after a restart and fetched by request ID (disabled if blank)")
+	journalRetention = QueryServerCmd.Flags().Duration("journalRetention", 24*time.Hour, "How long requests are kept in the request journal")
+	guardianFanOut = QueryServerCmd.Flags().Int("guardianFanOut", 0, "Number of guardians each request is sent to, preferring the fastest ones (zero sends requests to all guardians, values below quorum are raised to quorum)")
+	failoverTimeout = QueryServerCmd.Flags().Duration("failoverTimeout", 2*time.Second, "How long to wait for the selected guardians to reach quorum before sending a request to the remaining guardians")
+	journalReplayWindow = QueryServerCmd.Flags().Duration("journalReplayWindow", 10*time.Minute, "Requests still in flight at shutdown are re-submitted on startup if they were published less than this long ago")
 
 	// The default health check monitoring is every five seconds, with a five second timeout, and you have to miss two, for 20 seconds total.
 	shutdownDelay1 = QueryServerCmd.Flags().Uint("shutdownDelay1", 25, "Seconds to delay after disabling health check on shutdown")
@@ -127,6 +163,10 @@ func runQueryServer(cmd *cobra.Command, args []string) {
 	}
 
 	// Verify flags
+	ipMode, err := common.ParseIPMode(*ipModeStr)
+	if err != nil {
+		logger.Fatal("Invalid value for --ipMode", zap.Error(err))
+	}
 	if *nodeKeyPath == "" {
 		logger.Fatal("Please specify --nodeKey")
 	}
@@ -149,6 +189,18 @@ func runQueryServer(cmd *cobra.Command, args []string) {
 	}
 
 	loggingMap := NewLoggingMap()
+	admission := NewAdmissionControl(*maxInFlight, *maxInFlightPerUser)
+	cache := NewResponseCache(*cacheTTL, *cacheMaxEntries)
+	if cache != nil {
+		logger.Info("response cache enabled", zap.Duration("ttl", *cacheTTL), zap.Int("maxEntries", *cacheMaxEntries))
+	}
+	journal, err := NewRequestJournal(*journalDir, *journalRetention, logger)
+	if err != nil {
+		logger.Fatal("Failed to open request journal", zap.String("journalDir", *journalDir), zap.Error(err))
+	}
+	if journal != nil {
+		logger.Info("request journal enabled", zap.String("journalDir", *journalDir), zap.Duration("retention", *journalRetention))
+	}
 
 	// Load p2p private key
 	var priv crypto.PrivKey
@@ -170,32 +222,86 @@ func runQueryServer(cmd *cobra.Command, args []string) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	var jwtAuth *JWTAuthenticator
+	if *jwtIssuer != "" {
+		jwtAuth, err = NewJWTAuthenticator(ctx, logger, *jwtIssuer, *jwtJwksURL, *jwtAudience, *jwtUserClaim)
+		if err != nil {
+			logger.Fatal("Failed to initialize JWT authentication", zap.Error(err))
+		}
+		jwtAuth.Start(ctx)
+		logger.Info("JWT authentication enabled", zap.String("issuer", *jwtIssuer), zap.String("jwksURL", *jwtJwksURL), zap.String("userClaim", *jwtUserClaim))
+	}
+
+	// Start the status server before p2p, so it can report that we are still bootstrapping.
+	health := NewProxyHealth(*readyMinPeers)
+	var statServer *statusServer
+	if *statusAddr != "" {
+		statServer = NewStatusServer(*statusAddr, logger, env, health)
+		lis, err := common.ListenTCP(ipMode, *statusAddr)
+		if err != nil {
+			logger.Fatal("Failed to listen on status address", zap.String("statusAddr", *statusAddr), zap.Error(err))
+		}
+		go func() {
+			logger.Sugar().Infof("Status server listening on %s", *statusAddr)
+			err := statServer.httpServer.Serve(lis)
+			if err != nil && err != http.ErrServerClosed {
+				logger.Fatal("Status server closed unexpectedly", zap.Error(err))
+			}
+		}()
+	}
+
+	var selector *GuardianSelector
+	if *guardianFanOut > 0 {
+		selector = NewGuardianSelector(*guardianFanOut, *failoverTimeout)
+		logger.Info("guardian fan-out enabled", zap.Int("fanOut", *guardianFanOut), zap.Duration("failoverTimeout", *failoverTimeout))
+	}
+
 	// Run p2p
-	pendingResponses := NewPendingResponses(logger)
-	p2p, err := runP2P(ctx, priv, *p2pPort, networkID, *p2pBootstrap, *ethRPC, *ethContract, pendingResponses, logger, *monitorPeers, loggingMap)
+	pendingResponses := NewPendingResponses(logger, health)
+	p2p, err := runP2P(ctx, priv, *p2pPort, networkID, *p2pBootstrap, *ethRPC, *ethContract, pendingResponses, logger, *monitorPeers, loggingMap, cache, journal, health, selector, ipMode, *announceAddrs)
 	if err != nil {
 		logger.Fatal("Failed to start p2p", zap.Error(err))
 	}
+	replayJournal(ctx, logger, journal, p2p.topic_req, pendingResponses, *journalReplayWindow)
 
 	// Start the HTTP server
+	lis, err := common.ListenTCP(ipMode, *listenAddr)
+	if err != nil {
+		logger.Fatal("Failed to listen on query server address", zap.String("listenAddr", *listenAddr), zap.Error(err))
+	}
 	go func() {
-		s := NewHTTPServer(*listenAddr, p2p.topic_req, permissions, signerKey, pendingResponses, logger, env, loggingMap)
+		s := NewHTTPServer(*listenAddr, p2p.topic_req, permissions, signerKey, pendingResponses, logger, env, loggingMap, admission, cache, jwtAuth, journal, selector)
 		logger.Sugar().Infof("Server listening on %s", *listenAddr)
-		err := s.ListenAndServe()
+		err := s.Serve(lis)
 		if err != nil && err != http.ErrServerClosed {
 			logger.Fatal("Server closed unexpectedly", zap.Error(err))
 		}
 	}()
 
-	// Start the status server
-	var statServer *statusServer
-	if *statusAddr != "" {
-		statServer = NewStatusServer(*statusAddr, logger, env)
+	// Start the gRPC server
+	if *grpcListenAddr != "" {
+		lis, err := common.ListenTCP(ipMode, *grpcListenAddr)
+		if err != nil {
+			logger.Fatal("Failed to listen on gRPC address", zap.String("grpcListenAddr", *grpcListenAddr), zap.Error(err))
+		}
+		grpcServer := NewGRPCServer(p2p.topic_req, permissions, signerKey, pendingResponses, logger, env, loggingMap, admission, cache, jwtAuth, journal, selector)
 		go func() {
-			logger.Sugar().Infof("Status server listening on %s", *statusAddr)
-			err := statServer.httpServer.ListenAndServe()
+			logger.Sugar().Infof("gRPC server listening on %s", *grpcListenAddr)
+			if err := grpcServer.Serve(lis); err != nil {
+				logger.Fatal("gRPC server closed unexpectedly", zap.Error(err))
+			}
+		}()
+		defer grpcServer.Stop()
+	}
+
+	// Start the admin server
+	if *adminListenAddr != "" {
+		adminServer := NewAdminServer(*adminListenAddr, logger, permissions)
+		go func() {
+			logger.Sugar().Infof("Admin server listening on %s", *adminListenAddr)
+			err := adminServer.Serve(ipMode)
 			if err != nil && err != http.ErrServerClosed {
-				logger.Fatal("Status server closed unexpectedly", zap.Error(err))
+				logger.Fatal("Admin server closed unexpectedly", zap.Error(err))
 			}
 		}()
 	}
@@ -254,6 +360,9 @@ func runQueryServer(cmd *cobra.Command, args []string) {
 	// Star logging cleanup process.
 	loggingMap.Start(ctx, logger, errC)
 
+	// Start pruning the request journal.
+	journal.Start(ctx, errC)
+
 	// Wait for either a shutdown or a fatal error from the permissions watcher.
 	select {
 	case <-ctx.Done():
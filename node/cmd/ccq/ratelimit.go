@@ -0,0 +1,116 @@
+package ccq
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	rateLimitReasonRate  = "rate_limit"
+	rateLimitReasonQuota = "daily_quota"
+)
+
+// rateLimitError is returned by validateRequest when a user has exceeded their configured rate limit or daily quota.
+type rateLimitError struct {
+	reason     string
+	retryAfter time.Duration
+}
+
+func (e *rateLimitError) Error() string {
+	if e.reason == rateLimitReasonQuota {
+		return "daily quota exceeded"
+	}
+	return "rate limit exceeded"
+}
+
+// retryAfterSecs returns the value for the Retry-After header, rounded up to a whole number of seconds.
+func (e *rateLimitError) retryAfterSecs() string {
+	return fmt.Sprintf("%d", int64(math.Ceil(e.retryAfter.Seconds())))
+}
+
+// userUsage tracks the consumption of a single API key. It is kept across reloads of the permissions file so that
+// updating the file does not reset anybody's limits.
+type userUsage struct {
+	limiter *rate.Limiter
+	day     string
+	count   uint64
+}
+
+// consume charges a single request to the user, returning an error if the request should be rejected. A request that is rejected
+// does not count against the user's quota. Must be called with the permissions lock held.
+func (u *userUsage) consume(pe *permissionEntry, now time.Time) *rateLimitError {
+	day := now.UTC().Format(time.DateOnly)
+	if u.day != day {
+		u.day = day
+		u.count = 0
+	}
+
+	if pe.dailyQuota != 0 && u.count >= pe.dailyQuota {
+		y, m, d := now.UTC().Date()
+		midnight := time.Date(y, m, d+1, 0, 0, 0, 0, time.UTC)
+		return &rateLimitError{reason: rateLimitReasonQuota, retryAfter: midnight.Sub(now)}
+	}
+
+	if pe.rateLimit != 0 {
+		if u.limiter == nil {
+			u.limiter = rate.NewLimiter(rate.Limit(pe.rateLimit), pe.burst)
+		} else {
+			if u.limiter.Limit() != rate.Limit(pe.rateLimit) {
+				u.limiter.SetLimitAt(now, rate.Limit(pe.rateLimit))
+			}
+			if u.limiter.Burst() != pe.burst {
+				u.limiter.SetBurstAt(now, pe.burst)
+			}
+		}
+
+		r := u.limiter.ReserveN(now, 1)
+		if delay := r.DelayFrom(now); delay > 0 {
+			r.CancelAt(now)
+			return &rateLimitError{reason: rateLimitReasonRate, retryAfter: delay}
+		}
+	} else {
+		u.limiter = nil
+	}
+
+	u.count++
+	return nil
+}
+
+// ChargeRequest charges a request against the rate limit and daily quota of the user and updates the per user consumption metrics.
+func (perms *Permissions) ChargeRequest(pe *permissionEntry, now time.Time) *rateLimitError {
+	perms.lock.Lock()
+	defer perms.lock.Unlock()
+
+	if perms.usage == nil {
+		perms.usage = make(map[string]*userUsage)
+	}
+	u, exists := perms.usage[pe.apiKey]
+	if !exists {
+		u = &userUsage{}
+		perms.usage[pe.apiKey] = u
+	}
+
+	rle := u.consume(pe, now)
+	if rle != nil {
+		rateLimitedRequestsByUser.WithLabelValues(pe.userName, rle.reason).Inc()
+	}
+
+	dailyQuotaUsedByUser.WithLabelValues(pe.userName).Set(float64(u.count))
+	if pe.dailyQuota != 0 {
+		dailyQuotaRemainingByUser.WithLabelValues(pe.userName).Set(float64(pe.dailyQuota - u.count))
+	}
+
+	return rle
+}
+
+// pruneUsage drops usage tracking for API keys that are no longer configured. Must be called with the permissions lock held.
+func (perms *Permissions) pruneUsage() {
+	for apiKey := range perms.usage {
+		if _, exists := perms.permMap[apiKey]; !exists {
+			delete(perms.usage, apiKey)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package ccq
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConfigRateLimitDefaults(t *testing.T) {
+	str := `
+  {
+    "permissions": [
+      {
+        "userName": "Test User",
+        "apiKey": "my_secret_key",
+        "rateLimit": 2.5,
+        "dailyQuota": 1000,
+        "allowedCalls": []
+      }
+    ]
+  }`
+
+	perms, err := parseConfig([]byte(str))
+	require.NoError(t, err)
+	pe, exists := perms["my_secret_key"]
+	require.True(t, exists)
+	assert.Equal(t, 2.5, pe.rateLimit)
+	assert.Equal(t, 3, pe.burst)
+	assert.Equal(t, uint64(1000), pe.dailyQuota)
+}
+
+func TestParseConfigNegativeRateLimit(t *testing.T) {
+	str := `
+  {
+    "permissions": [
+      {
+        "userName": "Test User",
+        "apiKey": "my_secret_key",
+        "rateLimit": -1,
+        "allowedCalls": []
+      }
+    ]
+  }`
+
+	_, err := parseConfig([]byte(str))
+	assert.EqualError(t, err, `rateLimit for user "Test User" may not be negative`)
+}
+
+func TestChargeRequestRateLimit(t *testing.T) {
+	perms := &Permissions{}
+	pe := &permissionEntry{userName: "alice", apiKey: "alice_key", rateLimit: 1, burst: 2}
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	require.Nil(t, perms.ChargeRequest(pe, now))
+	require.Nil(t, perms.ChargeRequest(pe, now))
+
+	rle := perms.ChargeRequest(pe, now)
+	require.NotNil(t, rle)
+	assert.Equal(t, rateLimitReasonRate, rle.reason)
+	assert.Equal(t, time.Second, rle.retryAfter)
+	assert.Equal(t, "1", rle.retryAfterSecs())
+
+	// A rejected request must not use up a token.
+	assert.Nil(t, perms.ChargeRequest(pe, now.Add(time.Second)))
+}
+
+func TestChargeRequestDailyQuota(t *testing.T) {
+	perms := &Permissions{}
+	pe := &permissionEntry{userName: "alice", apiKey: "alice_key", dailyQuota: 2}
+	now := time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)
+
+	require.Nil(t, perms.ChargeRequest(pe, now))
+	require.Nil(t, perms.ChargeRequest(pe, now))
+
+	rle := perms.ChargeRequest(pe, now)
+	require.NotNil(t, rle)
+	assert.Equal(t, rateLimitReasonQuota, rle.reason)
+	assert.Equal(t, time.Hour, rle.retryAfter)
+
+	// The quota resets at midnight UTC.
+	assert.Nil(t, perms.ChargeRequest(pe, now.Add(time.Hour)))
+}
+
+func TestChargeRequestUsageSurvivesReload(t *testing.T) {
+	perms := &Permissions{}
+	pe := &permissionEntry{userName: "alice", apiKey: "alice_key", dailyQuota: 1}
+	now := time.Now()
+
+	require.Nil(t, perms.ChargeRequest(pe, now))
+
+	// Simulate a reload that keeps alice but drops bob.
+	perms.usage["bob_key"] = &userUsage{}
+	perms.permMap = PermissionsMap{"alice_key": &permissionEntry{userName: "alice", apiKey: "alice_key", dailyQuota: 1}}
+	perms.pruneUsage()
+
+	_, exists := perms.usage["bob_key"]
+	assert.False(t, exists)
+	assert.NotNil(t, perms.ChargeRequest(perms.permMap["alice_key"], now))
+}
@@ -2,7 +2,7 @@ package ccq
 
 import (
 	"context"
-	"fmt"
+	"encoding/json"
 	"net/http"
 	"sync/atomic"
 	"time"
@@ -19,16 +19,19 @@ type statusServer struct {
 	env           common.Environment
 	httpServer    *http.Server
 	healthEnabled atomic.Bool
+	health        *ProxyHealth
 }
 
-func NewStatusServer(addr string, logger *zap.Logger, env common.Environment) *statusServer {
+func NewStatusServer(addr string, logger *zap.Logger, env common.Environment, health *ProxyHealth) *statusServer {
 	s := &statusServer{
 		logger: logger,
 		env:    env,
+		health: health,
 	}
 	s.healthEnabled.Store(true)
 	r := mux.NewRouter()
 	r.HandleFunc("/health", s.handleHealth).Methods("GET")
+	r.HandleFunc("/readyz", s.handleReadyz).Methods("GET")
 	r.Handle("/metrics", promhttp.Handler())
 	s.httpServer = &http.Server{
 		Addr:              addr,
@@ -42,6 +45,8 @@ func (s *statusServer) disableHealth() {
 	s.healthEnabled.Store(false)
 }
 
+// handleHealth reports the health of the proxy. It fails only while shutting down, so that the proxy is not restarted while it is still
+// connecting to the guardians, but the body describes the guardian connectivity.
 func (s *statusServer) handleHealth(w http.ResponseWriter, r *http.Request) {
 	if !s.healthEnabled.Load() {
 		s.logger.Info("ignoring health check")
@@ -49,8 +54,31 @@ func (s *statusServer) handleHealth(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	s.logger.Debug("health check")
-	w.WriteHeader(http.StatusOK)
-	fmt.Fprintf(w, "ok")
+	s.writeReport(w, http.StatusOK, s.health.Report(time.Now()))
+}
+
+// handleReadyz fails unless the proxy is connected to the guardians and they are answering requests, so load balancers only send
+// traffic to proxies that can serve it.
+func (s *statusServer) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	if !s.healthEnabled.Load() {
+		s.logger.Info("ignoring readiness check")
+		http.Error(w, "shutting down", http.StatusServiceUnavailable)
+		return
+	}
+	report := s.health.Report(time.Now())
+	status := http.StatusOK
+	if !report.Ready {
+		status = http.StatusServiceUnavailable
+	}
+	s.writeReport(w, status, report)
+}
+
+func (s *statusServer) writeReport(w http.ResponseWriter, status int, report *HealthReport) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		s.logger.Error("failed to encode health report", zap.Error(err))
+	}
 }
 
 func RunPrometheusScraper(ctx context.Context, logger *zap.Logger, info promremotew.PromTelemetryInfo) error {
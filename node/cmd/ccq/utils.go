@@ -4,6 +4,7 @@ import (
 	"crypto/ecdsa"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/certusone/wormhole/node/pkg/common"
 	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
@@ -29,6 +30,12 @@ func validateRequest(logger *zap.Logger, env common.Environment, perms *Permissi
 		return http.StatusForbidden, nil, fmt.Errorf("invalid api key")
 	}
 
+	if rle := perms.ChargeRequest(permsForUser, time.Now()); rle != nil {
+		logger.Debug("request rejected by rate limiting", zap.String("userName", permsForUser.userName), zap.String("reason", rle.reason), zap.Duration("retryAfter", rle.retryAfter))
+		invalidQueryRequestReceived.WithLabelValues(rle.reason).Inc()
+		return http.StatusTooManyRequests, nil, rle
+	}
+
 	// TODO: Should we verify the signatures?
 
 	if len(qr.Signature) == 0 {
@@ -97,13 +104,14 @@ func validateRequest(logger *zap.Logger, env common.Environment, perms *Permissi
 func validateSolanaAccountQuery(logger *zap.Logger, permsForUser *permissionEntry, callTag string, chainId vaa.ChainID, q *query.SolanaAccountQueryRequest) (int, error) {
 	for _, acct := range q.Accounts {
 		callKey := fmt.Sprintf("%s:%d:%s", callTag, chainId, solana.PublicKey(acct).String())
-		if _, exists := permsForUser.allowedCalls[callKey]; !exists {
+		if !permsForUser.callAllowed(callKey) {
 			logger.Debug("requested call not authorized", zap.String("userName", permsForUser.userName), zap.String("callKey", callKey))
 			invalidQueryRequestReceived.WithLabelValues("call_not_authorized").Inc()
 			return http.StatusForbidden, fmt.Errorf(`call "%s" not authorized`, callKey)
 		}
 
 		totalRequestedCallsByChain.WithLabelValues(chainId.String()).Inc()
+		totalRequestedCallsByUserAndChain.WithLabelValues(permsForUser.userName, chainId.String()).Inc()
 	}
 
 	return http.StatusOK, nil
@@ -113,13 +121,14 @@ func validateSolanaAccountQuery(logger *zap.Logger, permsForUser *permissionEntr
 func validateSolanaPdaQuery(logger *zap.Logger, permsForUser *permissionEntry, callTag string, chainId vaa.ChainID, q *query.SolanaPdaQueryRequest) (int, error) {
 	for _, acct := range q.PDAs {
 		callKey := fmt.Sprintf("%s:%d:%s", callTag, chainId, solana.PublicKey(acct.ProgramAddress).String())
-		if _, exists := permsForUser.allowedCalls[callKey]; !exists {
+		if !permsForUser.callAllowed(callKey) {
 			logger.Debug("requested call not authorized", zap.String("userName", permsForUser.userName), zap.String("callKey", callKey))
 			invalidQueryRequestReceived.WithLabelValues("call_not_authorized").Inc()
 			return http.StatusForbidden, fmt.Errorf(`call "%s" not authorized`, callKey)
 		}
 
 		totalRequestedCallsByChain.WithLabelValues(chainId.String()).Inc()
+		totalRequestedCallsByUserAndChain.WithLabelValues(permsForUser.userName, chainId.String()).Inc()
 	}
 
 	return http.StatusOK, nil
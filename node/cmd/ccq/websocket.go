@@ -0,0 +1,226 @@
+package ccq
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/query"
+	"go.uber.org/zap"
+	"nhooyr.io/websocket"
+	"nhooyr.io/websocket/wsjson"
+)
+
+// WS_WRITE_TIMEOUT is how long we wait for a single message to be written to a WebSocket client.
+const WS_WRITE_TIMEOUT = 10 * time.Second
+
+const (
+	wsMsgAccepted = "accepted"
+	wsMsgProgress = "progress"
+	wsMsgResponse = "response"
+	wsMsgError    = "error"
+)
+
+// wsQueryRequest is a query submitted over the WebSocket API. The ID is chosen by the client and is echoed back in every message about the query.
+type wsQueryRequest struct {
+	ID string `json:"id"`
+	queryRequest
+}
+
+// wsProgress reports the guardian responses received so far for a query that has not yet reached quorum.
+type wsProgress struct {
+	NumResponses         int               `json:"numResponses"`
+	MaxMatchingResponses int               `json:"maxMatchingResponses"`
+	OutstandingResponses int               `json:"outstandingResponses"`
+	Quorum               int               `json:"quorum"`
+	Chains               []wsChainProgress `json:"chains"`
+	GuardiansQueried     int               `json:"guardiansQueried"`
+	FailedOver           bool              `json:"failedOver"`
+}
+
+// wsChainProgress is the leading response to one of the per-chain queries of a query, see ChainProgress.
+type wsChainProgress struct {
+	ChainId uint16 `json:"chainId"`
+	// Response is the hex-encoded chain specific response, or empty if no guardian has answered yet.
+	Response          string `json:"response,omitempty"`
+	MatchingResponses int    `json:"matchingResponses"`
+}
+
+func newWSProgress(p *QueryProgress) *wsProgress {
+	chains := make([]wsChainProgress, len(p.Chains))
+	for i, c := range p.Chains {
+		chains[i] = wsChainProgress{ChainId: uint16(c.ChainId), Response: hex.EncodeToString(c.Response), MatchingResponses: c.MatchingResponses}
+	}
+	return &wsProgress{
+		NumResponses:         p.NumSigners,
+		MaxMatchingResponses: p.MaxMatchingResponses,
+		OutstandingResponses: p.OutstandingResponses,
+		Quorum:               p.Quorum,
+		Chains:               chains,
+		GuardiansQueried:     p.GuardiansQueried,
+		FailedOver:           p.FailedOver,
+	}
+}
+
+// wsMessage is sent from the server to a WebSocket client. A query gets an "accepted" message once it has been published to the guardians,
+// zero or more "progress" messages, and is then completed by exactly one "response" or "error" message. An "error" may also be sent
+// instead of "accepted" if the query is rejected.
+type wsMessage struct {
+	ID        string         `json:"id"`
+	Type      string         `json:"type"`
+	RequestID string         `json:"requestId,omitempty"`
+	Progress  *wsProgress    `json:"progress,omitempty"`
+	Response  *queryResponse `json:"response,omitempty"`
+	// Status is the HTTP status code that would have been returned by the REST API.
+	Status int    `json:"status,omitempty"`
+	Error  string `json:"error,omitempty"`
+	// RetryAfter is the number of seconds to wait before resubmitting a rejected query, if applicable.
+	RetryAfter string `json:"retryAfter,omitempty"`
+}
+
+// handleWebSocket upgrades the connection to a WebSocket on which the client may submit any number of queries. Responses are sent back
+// asynchronously as they become available, so slow queries do not hold up other queries on the same connection.
+func (s *httpServer) handleWebSocket(w http.ResponseWriter, r *http.Request) {
+	// The credentials are checked once when the connection is established, and again for every query, in case the permissions
+	// are reloaded or the bearer token expires.
+	apiKeys, authorization := r.Header["X-Api-Key"], r.Header["Authorization"]
+	permEntry, status, reason, err := s.authenticate(r.Context(), apiKeys, authorization)
+	if err != nil {
+		s.logger.Error("failed to authenticate websocket request", zap.Stringer("url", r.URL), zap.String("reason", reason), zap.Error(err))
+		http.Error(w, err.Error(), status)
+		invalidQueryRequestReceived.WithLabelValues(reason).Inc()
+		return
+	}
+
+	c, err := websocket.Accept(w, r, nil)
+	if err != nil {
+		s.logger.Error("failed to accept websocket connection", zap.String("userId", permEntry.userName), zap.Error(err))
+		return
+	}
+	defer c.Close(websocket.StatusInternalError, "")
+	c.SetReadLimit(MAX_BODY_SIZE)
+
+	s.logger.Info("websocket client connected", zap.String("userId", permEntry.userName), zap.String("remoteAddr", r.RemoteAddr))
+	currentWebSocketConnections.Inc()
+	defer currentWebSocketConnections.Dec()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for {
+		var req wsQueryRequest
+		if err := wsjson.Read(ctx, c, &req); err != nil {
+			status := websocket.CloseStatus(err)
+			if status == websocket.StatusNormalClosure || status == websocket.StatusGoingAway {
+				s.logger.Info("websocket client disconnected", zap.String("userId", permEntry.userName))
+			} else {
+				s.logger.Error("failed to read from websocket", zap.String("userId", permEntry.userName), zap.Error(err))
+			}
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.handleWebSocketQuery(ctx, c, apiKeys, authorization, &req)
+		}()
+	}
+
+	// Abandon any queries that are still waiting for responses, since there is nobody to send them to.
+	cancel()
+	wg.Wait()
+	c.Close(websocket.StatusNormalClosure, "")
+}
+
+// handleWebSocketQuery processes a single query submitted over a WebSocket and sends the results to the client.
+func (s *httpServer) handleWebSocketQuery(ctx context.Context, c *websocket.Conn, apiKeys []string, authorization []string, req *wsQueryRequest) {
+	start := time.Now()
+	allQueryRequestsReceived.Inc()
+
+	permEntry, status, reason, err := s.authenticate(ctx, apiKeys, authorization)
+	if err != nil {
+		s.logger.Error("failed to authenticate websocket query", zap.String("reason", reason), zap.Error(err))
+		invalidQueryRequestReceived.WithLabelValues(reason).Inc()
+		s.writeWebSocketMessage(ctx, c, &wsMessage{ID: req.ID, Type: wsMsgError, Status: status, Error: err.Error()})
+		return
+	}
+	apiKey := permEntry.apiKey
+	totalRequestsByUser.WithLabelValues(permEntry.userName).Inc()
+
+	release, reason := s.admission.tryAcquire(permEntry.userName)
+	if reason != admissionOK {
+		s.logger.Warn("rejecting websocket request because the server is saturated", zap.String("userId", permEntry.userName), zap.String("reason", reason))
+		invalidQueryRequestReceived.WithLabelValues("server_saturated").Inc()
+		requestsRejectedByAdmissionControl.WithLabelValues(reason, permEntry.userName).Inc()
+		s.writeWebSocketMessage(ctx, c, &wsMessage{ID: req.ID, Type: wsMsgError, Status: http.StatusServiceUnavailable, Error: "server is busy, please retry later", RetryAfter: ADMISSION_RETRY_AFTER_SECS})
+		return
+	}
+	defer release()
+
+	pendingResponse, requestId, status, err := s.submitQuery(ctx, permEntry, apiKey, &req.queryRequest, true)
+	if err != nil {
+		msg := &wsMessage{ID: req.ID, Type: wsMsgError, Status: status, Error: err.Error()}
+		var rle *rateLimitError
+		if errors.As(err, &rle) {
+			msg.RetryAfter = rle.retryAfterSecs()
+		}
+		s.writeWebSocketMessage(ctx, c, msg)
+		return
+	}
+	defer s.pendingResponses.Remove(pendingResponse)
+
+	s.writeWebSocketMessage(ctx, c, &wsMessage{ID: req.ID, Type: wsMsgAccepted, RequestID: requestId})
+
+	timeout := time.NewTimer(query.RequestTimeout + 5*time.Second)
+	defer timeout.Stop()
+
+	for done := false; !done; {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("websocket client went away before query completed", zap.String("userId", permEntry.userName), zap.String("requestId", requestId))
+			return
+		case <-timeout.C:
+			s.logger.Info("publishing time out to websocket client", zap.String("userId", permEntry.userName), zap.String("requestId", requestId))
+			s.writeWebSocketMessage(ctx, c, &wsMessage{ID: req.ID, Type: wsMsgError, RequestID: requestId, Status: http.StatusGatewayTimeout, Error: "Timed out waiting for response"})
+			queryTimeoutsByUser.WithLabelValues(permEntry.userName).Inc()
+			failedQueriesByUser.WithLabelValues(permEntry.userName).Inc()
+			done = true
+		case p := <-pendingResponse.progressCh:
+			s.writeWebSocketMessage(ctx, c, &wsMessage{ID: req.ID, Type: wsMsgProgress, RequestID: requestId, Progress: newWSProgress(p)})
+		case res := <-pendingResponse.ch:
+			s.logger.Info("publishing response to websocket client", zap.String("userId", permEntry.userName), zap.String("requestId", requestId))
+			resp, err := newQueryResponse(res)
+			if err != nil {
+				s.logger.Error("failed to marshal response", zap.String("userId", permEntry.userName), zap.String("requestId", requestId), zap.Error(err))
+				s.writeWebSocketMessage(ctx, c, &wsMessage{ID: req.ID, Type: wsMsgError, RequestID: requestId, Status: http.StatusInternalServerError, Error: err.Error()})
+				invalidQueryRequestReceived.WithLabelValues("failed_to_marshal_response").Inc()
+				failedQueriesByUser.WithLabelValues(permEntry.userName).Inc()
+			} else {
+				s.writeWebSocketMessage(ctx, c, &wsMessage{ID: req.ID, Type: wsMsgResponse, RequestID: requestId, Response: resp})
+				successfulQueriesByUser.WithLabelValues(permEntry.userName).Inc()
+			}
+			done = true
+		case errEntry := <-pendingResponse.errCh:
+			s.logger.Info("publishing error response to websocket client", zap.String("userId", permEntry.userName), zap.String("requestId", requestId), zap.Int("status", errEntry.status), zap.Error(errEntry.err))
+			s.writeWebSocketMessage(ctx, c, &wsMessage{ID: req.ID, Type: wsMsgError, RequestID: requestId, Status: errEntry.status, Error: errEntry.err.Error()})
+			// Metrics have already been pegged.
+			done = true
+		}
+	}
+
+	totalQueryTime.Observe(float64(time.Since(start).Milliseconds()))
+	validQueryRequestsReceived.Inc()
+}
+
+// writeWebSocketMessage sends a message to the client. Errors are logged but otherwise ignored, since a broken connection is detected by the read loop.
+func (s *httpServer) writeWebSocketMessage(ctx context.Context, c *websocket.Conn, msg *wsMessage) {
+	ctx, cancel := context.WithTimeout(ctx, WS_WRITE_TIMEOUT)
+	defer cancel()
+	if err := wsjson.Write(ctx, c, msg); err != nil {
+		s.logger.Warn("failed to write to websocket", zap.String("id", msg.ID), zap.String("type", msg.Type), zap.Error(err))
+	}
+}
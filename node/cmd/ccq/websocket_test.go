@@ -0,0 +1,87 @@
+package ccq
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"nhooyr.io/websocket"
+	"nhooyr.io/websocket/wsjson"
+)
+
+func newTestWebSocketServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	perms := &Permissions{
+		permMap: PermissionsMap{
+			"my_secret_key": &permissionEntry{userName: "Test User", apiKey: "my_secret_key"},
+		},
+	}
+	logger := zap.NewNop()
+	s := NewHTTPServer("", nil, perms, nil, NewPendingResponses(logger, nil), logger, common.GoTest, NewLoggingMap(), NewAdmissionControl(0, 0), nil, nil, nil, nil)
+	ts := httptest.NewServer(s.Handler)
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestWebSocketRequiresApiKey(t *testing.T) {
+	ts := newTestWebSocketServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	wsURL := "ws" + strings.TrimPrefix(ts.URL, "http") + "/v1/query/ws"
+
+	_, resp, err := websocket.Dial(ctx, wsURL, nil)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	_, resp, err = websocket.Dial(ctx, wsURL, &websocket.DialOptions{HTTPHeader: http.Header{"X-Api-Key": []string{"bogus"}}})
+	require.Error(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestWebSocketInvalidQuery(t *testing.T) {
+	ts := newTestWebSocketServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	wsURL := "ws" + strings.TrimPrefix(ts.URL, "http") + "/v1/query/ws"
+	c, _, err := websocket.Dial(ctx, wsURL, &websocket.DialOptions{HTTPHeader: http.Header{"X-Api-Key": []string{"My_Secret_Key"}}})
+	require.NoError(t, err)
+	defer c.Close(websocket.StatusNormalClosure, "")
+
+	require.NoError(t, wsjson.Write(ctx, c, &wsQueryRequest{ID: "query-1", queryRequest: queryRequest{Bytes: "not hex"}}))
+
+	var msg wsMessage
+	require.NoError(t, wsjson.Read(ctx, c, &msg))
+	assert.Equal(t, "query-1", msg.ID)
+	assert.Equal(t, wsMsgError, msg.Type)
+	assert.Equal(t, http.StatusBadRequest, msg.Status)
+	assert.NotEmpty(t, msg.Error)
+}
+
+func TestWebSocketProgress(t *testing.T) {
+	p := newWSProgress(&QueryProgress{
+		NumSigners:           2,
+		MaxMatchingResponses: 2,
+		OutstandingResponses: 3,
+		Quorum:               4,
+		Chains:               []ChainProgress{{ChainId: 1, Response: []byte{0xab, 0xcd}, MatchingResponses: 2}, {ChainId: 1}},
+		GuardiansQueried:     5,
+		FailedOver:           true,
+	})
+	b, err := json.Marshal(p)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"numResponses": 2, "maxMatchingResponses": 2, "outstandingResponses": 3, "quorum": 4,
+		"chains": [{"chainId": 1, "response": "abcd", "matchingResponses": 2}, {"chainId": 1, "matchingResponses": 0}],
+		"guardiansQueried": 5, "failedOver": true
+	}`, string(b))
+}
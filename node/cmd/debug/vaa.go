@@ -5,15 +5,28 @@ import (
 	"log"
 	"strings"
 
+	"github.com/certusone/wormhole/node/pkg/payloads"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/spf13/cobra"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 )
 
+var payloadDecoders *string
+
+func init() {
+	payloadDecoders = decodeVaaCmd.Flags().String("payloadDecoders", "", "JSON file registering payload decoders for additional emitters")
+}
+
 var decodeVaaCmd = &cobra.Command{
 	Use:   "decode-vaa [DATA]",
 	Short: "Decode a hex-encoded VAA",
 	Run: func(cmd *cobra.Command, args []string) {
+		if *payloadDecoders != "" {
+			if err := payloads.LoadFile(*payloadDecoders); err != nil {
+				log.Fatal(err)
+			}
+		}
+
 		for _, arg := range args {
 			arg = strings.TrimPrefix(arg, "0x")
 			b, err := hex.DecodeString(arg)
@@ -27,6 +40,13 @@ var decodeVaaCmd = &cobra.Command{
 			}
 
 			spew.Dump(v)
+
+			d, err := payloads.Decode(v.EmitterChain, v.EmitterAddress, v.Payload)
+			if err != nil {
+				log.Printf("failed to decode payload: %v", err)
+			} else if d != nil {
+				log.Printf("Payload: %s", d)
+			}
 		}
 	},
 }
@@ -9,8 +9,10 @@ import (
 	"io"
 	"log"
 	"os"
+	"path"
 	"strconv"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/cosmos/cosmos-sdk/types"
@@ -22,6 +24,7 @@ import (
 	"golang.org/x/crypto/sha3"
 
 	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/payloads"
 	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
 	publicrpcv1 "github.com/certusone/wormhole/node/pkg/proto/publicrpc/v1"
 	"github.com/wormhole-foundation/wormhole/sdk"
@@ -32,6 +35,7 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/encoding/prototext"
 
 	nodev1 "github.com/certusone/wormhole/node/pkg/proto/node/v1"
@@ -41,6 +45,34 @@ var (
 	clientSocketPath *string
 	shouldBackfill   *bool
 	unsafeDevnetMode *bool
+
+	injectValidateOnly *bool
+
+	dumpPayloadDecoders *string
+
+	forceReleaseReason *string
+
+	purgeVAAsReason *string
+	purgeVAAsDryRun *bool
+
+	observationRequestsRate      *float64
+	observationRequestsBatchSize *int
+	observationRequestsSkip      *int
+
+	logLevelComponent *string
+	logLevelDuration  *time.Duration
+
+	resetReleaseTimerChain *string
+	resetReleaseTimerDelay *time.Duration
+
+	tailLogsLevel      *string
+	tailLogsComponents *[]string
+	tailLogsBacklog    *uint32
+
+	restartComponentReason *string
+
+	adminAuditLogLimit *uint32
+	adminAuditLogSince *time.Duration
 )
 
 func init() {
@@ -55,20 +87,85 @@ func init() {
 	shouldBackfill = AdminClientFindMissingMessagesCmd.Flags().Bool(
 		"backfill", false, "backfill missing VAAs from public RPC")
 
+	injectValidateOnly = AdminClientInjectGuardianSetUpdateCmd.Flags().Bool(
+		"validateOnly", false, "only print the digests and decoded payloads the guardian builds from the file, without signing or injecting them")
+
+	dumpPayloadDecoders = DumpVAAByMessageID.Flags().String(
+		"payloadDecoders", "", "JSON file registering payload decoders for additional emitters")
+
 	AdminClientInjectGuardianSetUpdateCmd.Flags().AddFlagSet(pf)
 	AdminClientFindMissingMessagesCmd.Flags().AddFlagSet(pf)
 	AdminClientListNodes.Flags().AddFlagSet(pf)
 	DumpVAAByMessageID.Flags().AddFlagSet(pf)
 	DumpRPCs.Flags().AddFlagSet(pf)
+	DumpConfig.Flags().AddFlagSet(pf)
+	NodeHealth.Flags().AddFlagSet(pf)
+	LogLevel.Flags().AddFlagSet(pf)
+	TailLogs.Flags().AddFlagSet(pf)
+	RestartComponent.Flags().AddFlagSet(pf)
+	AdminAuditLog.Flags().AddFlagSet(pf)
+	DumpPendingObservations.Flags().AddFlagSet(pf)
+	DumpConflictingObservations.Flags().AddFlagSet(pf)
+	AccountantPendingTransfers.Flags().AddFlagSet(pf)
+	AccountantResubmitTransfer.Flags().AddFlagSet(pf)
+	AccountantForceReleaseTransfer.Flags().AddFlagSet(pf)
+	AccountantAuditLog.Flags().AddFlagSet(pf)
+	GatewayRelayerPause.Flags().AddFlagSet(pf)
+	GatewayRelayerResume.Flags().AddFlagSet(pf)
+	GatewayRelayerPendingRelays.Flags().AddFlagSet(pf)
+	GatewayRelayerRetryRelay.Flags().AddFlagSet(pf)
+	GatewayRelayerDropRelay.Flags().AddFlagSet(pf)
+	ClientQueryBudgetUsageCmd.Flags().AddFlagSet(pf)
+	ClientRestoreArchivedVAAsCmd.Flags().AddFlagSet(pf)
+	DumpAggregatedAttestation.Flags().AddFlagSet(pf)
 	SendObservationRequest.Flags().AddFlagSet(pf)
+	SendObservationRequestsFromCSV.Flags().AddFlagSet(pf)
 	ClientChainGovernorStatusCmd.Flags().AddFlagSet(pf)
+	ClientChainGovernorDumpStateCmd.Flags().AddFlagSet(pf)
 	ClientChainGovernorReloadCmd.Flags().AddFlagSet(pf)
 	ClientChainGovernorDropPendingVAACmd.Flags().AddFlagSet(pf)
 	ClientChainGovernorReleasePendingVAACmd.Flags().AddFlagSet(pf)
 	ClientChainGovernorResetReleaseTimerCmd.Flags().AddFlagSet(pf)
+	ClientChainGovernorGetLimitsCmd.Flags().AddFlagSet(pf)
+	ClientChainGovernorSetLimitCmd.Flags().AddFlagSet(pf)
+	ClientChainGovernorResetLimitCmd.Flags().AddFlagSet(pf)
+	ClientChainGovernorAuditLogCmd.Flags().AddFlagSet(pf)
+	ClientChainGovernorExportSnapshotCmd.Flags().AddFlagSet(pf)
+	ClientChainGovernorImportSnapshotCmd.Flags().AddFlagSet(pf)
 	SignExistingVaaCmd.Flags().AddFlagSet(pf)
 	SignExistingVaasFromCSVCmd.Flags().AddFlagSet(pf)
 	GetAndObserveMissingVAAs.Flags().AddFlagSet(pf)
+	AdminClientGovernanceVAAStatusCmd.Flags().AddFlagSet(pf)
+	ClientConfigGetCmd.Flags().AddFlagSet(pf)
+	ClientConfigProposeCmd.Flags().AddFlagSet(pf)
+	ClientConfigListCmd.Flags().AddFlagSet(pf)
+	ClientConfigApplyCmd.Flags().AddFlagSet(pf)
+	ClientConfigDiscardCmd.Flags().AddFlagSet(pf)
+
+	forceReleaseReason = AccountantForceReleaseTransfer.Flags().String("reason", "", "Why the transfer must be released without the accounting contract, recorded in the audit log")
+
+	AdminClientPurgeVAAsCmd.Flags().AddFlagSet(pf)
+	purgeVAAsReason = AdminClientPurgeVAAsCmd.Flags().String("reason", "", "Why the VAAs are purged, recorded in the log of the guardian")
+	purgeVAAsDryRun = AdminClientPurgeVAAsCmd.Flags().Bool("dryRun", false, "Only count the VAAs that would be purged")
+
+	observationRequestsRate = SendObservationRequestsFromCSV.Flags().Float64("rate", 1, "Maximum number of observation requests sent per second. The network drops requests above 1 per second")
+	observationRequestsBatchSize = SendObservationRequestsFromCSV.Flags().Int("batchSize", 10, "Number of observation requests sent per call to the guardian (at most 100)")
+	observationRequestsSkip = SendObservationRequestsFromCSV.Flags().Int("skip", 0, "Number of requests at the start of the file to skip, to resume an interrupted run")
+
+	logLevelComponent = LogLevel.Flags().String("component", "", "Component whose level to change, e.g. solana-finalized_watch (the global level if blank)")
+	logLevelDuration = LogLevel.Flags().Duration("duration", 0, "Revert the level after this long, e.g. 10m (never if zero)")
+
+	resetReleaseTimerChain = ClientChainGovernorResetReleaseTimerCmd.Flags().String("chain", "", "Also reset the timers of all VAAs pending for this chain, by ID or name")
+	resetReleaseTimerDelay = ClientChainGovernorResetReleaseTimerCmd.Flags().Duration("delay", 0, "Release the VAAs this long from now, e.g. 48h, at most 7 days (the configured maximum if zero)")
+
+	tailLogsLevel = TailLogs.Flags().String("level", "", "Only show entries at or above this level, e.g. warn (any level if blank)")
+	tailLogsComponents = TailLogs.Flags().StringSlice("component", nil, "Only show entries of these components, e.g. solana-finalized_watch (every component if blank)")
+	tailLogsBacklog = TailLogs.Flags().Uint32("backlog", 100, "Number of recent entries shown before following new ones")
+
+	restartComponentReason = RestartComponent.Flags().String("reason", "", "Why the runnable is restarted, recorded in the log of the guardian")
+
+	adminAuditLogLimit = AdminAuditLog.Flags().Uint32("limit", 100, "Maximum number of calls listed, the most recent ones")
+	adminAuditLogSince = AdminAuditLog.Flags().Duration("since", 0, "Only list the calls made in this long, e.g. 24h (any time if zero)")
 
 	adminClientSignWormchainAddressFlags := pflag.NewFlagSet("adminClientSignWormchainAddressFlags", pflag.ContinueOnError)
 	unsafeDevnetMode = adminClientSignWormchainAddressFlags.Bool("unsafeDevMode", false, "Run in unsafe devnet mode")
@@ -77,20 +174,55 @@ func init() {
 	AdminCmd.AddCommand(AdminClientInjectGuardianSetUpdateCmd)
 	AdminCmd.AddCommand(AdminClientFindMissingMessagesCmd)
 	AdminCmd.AddCommand(AdminClientGovernanceVAAVerifyCmd)
+	AdminCmd.AddCommand(AdminClientGovernanceVAAStatusCmd)
 	AdminCmd.AddCommand(AdminClientListNodes)
 	AdminCmd.AddCommand(AdminClientSignWormchainAddress)
 	AdminCmd.AddCommand(DumpVAAByMessageID)
 	AdminCmd.AddCommand(DumpRPCs)
+	AdminCmd.AddCommand(DumpConfig)
+	AdminCmd.AddCommand(NodeHealth)
+	AdminCmd.AddCommand(LogLevel)
+	AdminCmd.AddCommand(TailLogs)
+	AdminCmd.AddCommand(RestartComponent)
+	AdminCmd.AddCommand(AdminAuditLog)
+	AdminCmd.AddCommand(DumpPendingObservations)
+	AdminCmd.AddCommand(DumpConflictingObservations)
+	AdminCmd.AddCommand(AccountantPendingTransfers)
+	AdminCmd.AddCommand(AccountantResubmitTransfer)
+	AdminCmd.AddCommand(AccountantForceReleaseTransfer)
+	AdminCmd.AddCommand(AdminClientPurgeVAAsCmd)
+	AdminCmd.AddCommand(AccountantAuditLog)
+	AdminCmd.AddCommand(GatewayRelayerPause)
+	AdminCmd.AddCommand(GatewayRelayerResume)
+	AdminCmd.AddCommand(GatewayRelayerPendingRelays)
+	AdminCmd.AddCommand(GatewayRelayerRetryRelay)
+	AdminCmd.AddCommand(GatewayRelayerDropRelay)
+	AdminCmd.AddCommand(ClientQueryBudgetUsageCmd)
+	AdminCmd.AddCommand(ClientRestoreArchivedVAAsCmd)
+	AdminCmd.AddCommand(DumpAggregatedAttestation)
 	AdminCmd.AddCommand(SendObservationRequest)
+	AdminCmd.AddCommand(SendObservationRequestsFromCSV)
 	AdminCmd.AddCommand(ClientChainGovernorStatusCmd)
+	AdminCmd.AddCommand(ClientChainGovernorDumpStateCmd)
 	AdminCmd.AddCommand(ClientChainGovernorReloadCmd)
 	AdminCmd.AddCommand(ClientChainGovernorDropPendingVAACmd)
 	AdminCmd.AddCommand(ClientChainGovernorReleasePendingVAACmd)
 	AdminCmd.AddCommand(ClientChainGovernorResetReleaseTimerCmd)
+	AdminCmd.AddCommand(ClientChainGovernorGetLimitsCmd)
+	AdminCmd.AddCommand(ClientChainGovernorSetLimitCmd)
+	AdminCmd.AddCommand(ClientChainGovernorResetLimitCmd)
+	AdminCmd.AddCommand(ClientChainGovernorAuditLogCmd)
+	AdminCmd.AddCommand(ClientChainGovernorExportSnapshotCmd)
+	AdminCmd.AddCommand(ClientChainGovernorImportSnapshotCmd)
 	AdminCmd.AddCommand(SignExistingVaaCmd)
 	AdminCmd.AddCommand(SignExistingVaasFromCSVCmd)
 	AdminCmd.AddCommand(Keccak256Hash)
 	AdminCmd.AddCommand(GetAndObserveMissingVAAs)
+	AdminCmd.AddCommand(ClientConfigGetCmd)
+	AdminCmd.AddCommand(ClientConfigProposeCmd)
+	AdminCmd.AddCommand(ClientConfigListCmd)
+	AdminCmd.AddCommand(ClientConfigApplyCmd)
+	AdminCmd.AddCommand(ClientConfigDiscardCmd)
 }
 
 var AdminCmd = &cobra.Command{
@@ -112,6 +244,13 @@ var AdminClientInjectGuardianSetUpdateCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 }
 
+var AdminClientGovernanceVAAStatusCmd = &cobra.Command{
+	Use:   "governance-vaa-status [DIGEST]",
+	Short: "Follow signing progress of a governance VAA injected on this node until it reaches quorum",
+	Run:   runGovernanceVAAStatus,
+	Args:  cobra.ExactArgs(1),
+}
+
 var AdminClientFindMissingMessagesCmd = &cobra.Command{
 	Use:   "find-missing-messages [CHAIN_ID] [EMITTER_ADDRESS_HEX]",
 	Short: "Find sequence number gaps for the given chain ID and emitter address",
@@ -133,6 +272,13 @@ var SendObservationRequest = &cobra.Command{
 	Args:  cobra.ExactArgs(2),
 }
 
+var SendObservationRequestsFromCSV = &cobra.Command{
+	Use:   "send-observation-requests-csv [IN_FILE]",
+	Short: "Broadcast observation requests for a CSV [CHAIN_ID|CHAIN_NAME,TX_HASH] of transactions, e.g. to recover from a long watcher outage",
+	Run:   runSendObservationRequestsFromCSV,
+	Args:  cobra.ExactArgs(1),
+}
+
 var ClientChainGovernorStatusCmd = &cobra.Command{
 	Use:   "governor-status",
 	Short: "Displays the status of the chain governor",
@@ -140,6 +286,13 @@ var ClientChainGovernorStatusCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(0),
 }
 
+var ClientChainGovernorDumpStateCmd = &cobra.Command{
+	Use:   "governor-dump-state",
+	Short: "Displays the complete state of the chain governor as JSON, including its usage per chain and the enqueued VAAs",
+	Run:   runChainGovernorDumpState,
+	Args:  cobra.ExactArgs(0),
+}
+
 var ClientChainGovernorReloadCmd = &cobra.Command{
 	Use:   "governor-reload",
 	Short: "Clears the chain governor history and reloads it from the database",
@@ -156,15 +309,57 @@ var ClientChainGovernorDropPendingVAACmd = &cobra.Command{
 
 var ClientChainGovernorReleasePendingVAACmd = &cobra.Command{
 	Use:   "governor-release-pending-vaa [VAA_ID]",
-	Short: "Releases the specified VAA (chain/emitter/seq) from the chain governor pending list, publishing it immediately",
+	Short: "Releases the specified VAA (chain/emitter/seq) from the chain governor pending list, publishing it immediately. Large VAAs may first need the approvals of several operators, each calling as a different user",
 	Run:   runChainGovernorReleasePendingVAA,
 	Args:  cobra.ExactArgs(1),
 }
 
 var ClientChainGovernorResetReleaseTimerCmd = &cobra.Command{
-	Use:   "governor-reset-release-timer [VAA_ID]",
-	Short: "Resets the release timer for a chain governor pending VAA, extending it to the configured maximum",
+	Use:   "governor-reset-release-timer [VAA_ID...]",
+	Short: "Resets the release timer for chain governor pending VAAs, or all those of a chain with --chain, to the configured maximum or --delay",
 	Run:   runChainGovernorResetReleaseTimer,
+	Args:  cobra.ArbitraryArgs,
+}
+
+var ClientChainGovernorGetLimitsCmd = &cobra.Command{
+	Use:   "governor-get-limits",
+	Short: "Displays the enforced and configured daily limit and big transaction size of each chain governed by the chain governor",
+	Run:   runChainGovernorGetLimits,
+	Args:  cobra.ExactArgs(0),
+}
+
+var ClientChainGovernorSetLimitCmd = &cobra.Command{
+	Use:   "governor-set-limit [CHAIN_ID] [DAILY_LIMIT] [BIG_TRANSACTION_SIZE]",
+	Short: "Changes the limits of a governed chain until they are reset, also across restarts. A big transaction size of zero disables the big transaction check",
+	Run:   runChainGovernorSetLimit,
+	Args:  cobra.ExactArgs(3),
+}
+
+var ClientChainGovernorResetLimitCmd = &cobra.Command{
+	Use:   "governor-reset-limit [CHAIN_ID]",
+	Short: "Goes back to enforcing the configured limits of a governed chain",
+	Run:   runChainGovernorResetLimit,
+	Args:  cobra.ExactArgs(1),
+}
+
+var ClientChainGovernorAuditLogCmd = &cobra.Command{
+	Use:   "governor-audit-log",
+	Short: "Displays the approvals and releases of chain governor pending VAAs, oldest first",
+	Run:   runChainGovernorAuditLog,
+	Args:  cobra.ExactArgs(0),
+}
+
+var ClientChainGovernorExportSnapshotCmd = &cobra.Command{
+	Use:   "governor-export-snapshot [FILE]",
+	Short: "Writes the transfers, pending VAAs and limits of the chain governor as JSON to FILE, or to stdout if no file is given",
+	Run:   runChainGovernorExportSnapshot,
+	Args:  cobra.MaximumNArgs(1),
+}
+
+var ClientChainGovernorImportSnapshotCmd = &cobra.Command{
+	Use:   "governor-import-snapshot [FILE]",
+	Short: "Adds the chain governor state exported by another node with governor-export-snapshot to this node",
+	Run:   runChainGovernorImportSnapshot,
 	Args:  cobra.ExactArgs(1),
 }
 
@@ -189,6 +384,153 @@ var DumpRPCs = &cobra.Command{
 	Args:  cobra.ExactArgs(0),
 }
 
+var DumpConfig = &cobra.Command{
+	Use:   "dump-config",
+	Short: "Displays the effective configuration of the guardian as JSON, with secrets redacted",
+	Run:   runDumpConfig,
+	Args:  cobra.ExactArgs(0),
+}
+
+var LogLevel = &cobra.Command{
+	Use:   "log-level [LEVEL|reset]",
+	Short: "Changes the log level of the guardian, or of a component with --component, without restarting it. Lists the levels if no level is given",
+	Run:   runLogLevel,
+	Args:  cobra.MaximumNArgs(1),
+}
+
+var TailLogs = &cobra.Command{
+	Use:   "logs",
+	Short: "Follows the logs of the guardian as JSON, optionally only those of some components (--component) or levels (--level)",
+	Run:   runTailLogs,
+	Args:  cobra.ExactArgs(0),
+}
+
+var RestartComponent = &cobra.Command{
+	Use:   "restart-component [NAME]",
+	Short: "Restarts a runnable of the guardian, such as a chain watcher (e.g. solana-finalized_watch), without restarting the guardian",
+	Run:   runRestartComponent,
+	Args:  cobra.ExactArgs(1),
+}
+
+var AdminAuditLog = &cobra.Command{
+	Use:   "admin-audit-log",
+	Short: "Lists the most recent calls to the admin service, with who made them, the request and the result",
+	Run:   runAdminAuditLog,
+	Args:  cobra.ExactArgs(0),
+}
+
+var NodeHealth = &cobra.Command{
+	Use:   "node-health",
+	Short: "Displays the readiness, watcher heights, queue depths, p2p peer count and runnable errors of the guardian as JSON",
+	Run:   runNodeHealth,
+	Args:  cobra.ExactArgs(0),
+}
+
+var DumpPendingObservations = &cobra.Command{
+	Use:   "dump-pending-observations",
+	Short: "Displays the observations that have not reached quorum yet, with the confidence our watcher observed them at",
+	Run:   runDumpPendingObservations,
+	Args:  cobra.ExactArgs(0),
+}
+
+var ClientQueryBudgetUsageCmd = &cobra.Command{
+	Use:   "ccq-budget-usage",
+	Short: "Displays how much of their daily cross chain query budget each requester has used today",
+	Run:   runQueryBudgetUsage,
+	Args:  cobra.ExactArgs(0),
+}
+
+var ClientRestoreArchivedVAAsCmd = &cobra.Command{
+	Use:   "vaa-archive-restore [FROM] [TO] [CHAIN_ID] [EMITTER_ADDRESS_HEX]",
+	Short: "Restores the archived VAAs with a timestamp between FROM and TO (RFC3339), optionally only those of a chain or emitter",
+	Run:   runRestoreArchivedVAAs,
+	Args:  cobra.RangeArgs(2, 4),
+}
+
+var DumpConflictingObservations = &cobra.Command{
+	Use:   "dump-conflicting-observations",
+	Short: "Displays the messages for which guardians signed conflicting digests, with the guardians that signed each of them",
+	Run:   runDumpConflictingObservations,
+	Args:  cobra.ExactArgs(0),
+}
+
+var AccountantPendingTransfers = &cobra.Command{
+	Use:   "accountant-pending-transfers",
+	Short: "Displays the transfers the accountants hold until their accounting contract commits them, oldest first",
+	Run:   runAccountantPendingTransfers,
+	Args:  cobra.ExactArgs(0),
+}
+
+var AccountantResubmitTransfer = &cobra.Command{
+	Use:   "accountant-resubmit-transfer [MESSAGE_ID]",
+	Short: "Submits a transfer an accountant is waiting on to its accounting contract again, by message ID (chain/emitter/seq)",
+	Run:   runAccountantResubmitTransfer,
+	Args:  cobra.ExactArgs(1),
+}
+
+var AdminClientPurgeVAAsCmd = &cobra.Command{
+	Use:   "purge-vaas [CHAIN] [EMITTER_ADDRESS] [FROM_SEQUENCE] [TO_SEQUENCE]",
+	Short: "Deletes the signed VAAs of an emitter in a range of sequences from the database of a test network guardian. Without TO_SEQUENCE, all VAAs from FROM_SEQUENCE on are deleted",
+	Run:   runPurgeVAAs,
+	Args:  cobra.RangeArgs(3, 4),
+}
+
+var AccountantForceReleaseTransfer = &cobra.Command{
+	Use:   "accountant-force-release-transfer [MESSAGE_ID]",
+	Short: "Approves publishing a transfer an accountant is waiting on without its accounting contract, for emergencies only",
+	Run:   runAccountantForceReleaseTransfer,
+	Args:  cobra.ExactArgs(1),
+}
+
+var AccountantAuditLog = &cobra.Command{
+	Use:   "accountant-audit-log",
+	Short: "Displays the approvals and forced releases of accountant pending transfers, oldest first",
+	Run:   runAccountantAuditLog,
+	Args:  cobra.ExactArgs(0),
+}
+
+var GatewayRelayerPause = &cobra.Command{
+	Use:   "gateway-relayer-pause",
+	Short: "Stops submitting VAAs to the gateway until gateway-relayer-resume is run, VAAs are still queued in the meantime",
+	Run:   runGatewayRelayerPause,
+	Args:  cobra.ExactArgs(0),
+}
+
+var GatewayRelayerResume = &cobra.Command{
+	Use:   "gateway-relayer-resume",
+	Short: "Resumes submitting VAAs to the gateway",
+	Run:   runGatewayRelayerResume,
+	Args:  cobra.ExactArgs(0),
+}
+
+var GatewayRelayerPendingRelays = &cobra.Command{
+	Use:   "gateway-relayer-pending-relays",
+	Short: "Displays the VAAs waiting to be relayed to the gateway, including the ones the relayer gave up on, oldest first",
+	Run:   runGatewayRelayerPendingRelays,
+	Args:  cobra.ExactArgs(0),
+}
+
+var GatewayRelayerRetryRelay = &cobra.Command{
+	Use:   "gateway-relayer-retry-relay [MESSAGE_ID]",
+	Short: "Submits a pending VAA to the gateway again and restarts its retries, by message ID (chain/emitter/seq)",
+	Run:   runGatewayRelayerRetryRelay,
+	Args:  cobra.ExactArgs(1),
+}
+
+var GatewayRelayerDropRelay = &cobra.Command{
+	Use:   "gateway-relayer-drop-relay [MESSAGE_ID]",
+	Short: "Removes a pending VAA from the gateway relayer, by message ID (chain/emitter/seq)",
+	Run:   runGatewayRelayerDropRelay,
+	Args:  cobra.ExactArgs(1),
+}
+
+var DumpAggregatedAttestation = &cobra.Command{
+	Use:   "dump-aggregated-attestation [MESSAGE_ID]",
+	Short: "Displays the experimental aggregated attestation produced for a VAA, by message ID (chain/emitter/seq)",
+	Run:   runDumpAggregatedAttestation,
+	Args:  cobra.ExactArgs(1),
+}
+
 var GetAndObserveMissingVAAs = &cobra.Command{
 	Use:   "get-and-observe-missing-vaas [URL] [API_KEY]",
 	Short: "Get the list of missing VAAs from a cloud function and try to reobserve them.",
@@ -196,6 +538,41 @@ var GetAndObserveMissingVAAs = &cobra.Command{
 	Args:  cobra.ExactArgs(2),
 }
 
+var ClientConfigGetCmd = &cobra.Command{
+	Use:   "config-get [TARGET]",
+	Short: "Displays the running value of a runtime config target (governor-limits, ccq-allowed-requesters, gateway-relay-policy)",
+	Run:   runConfigGet,
+	Args:  cobra.ExactArgs(1),
+}
+
+var ClientConfigProposeCmd = &cobra.Command{
+	Use:   "config-propose [TARGET] [FILE]",
+	Short: "Stages a change to a runtime config target, read from a file in the format displayed by config-get, and displays the diff",
+	Run:   runConfigPropose,
+	Args:  cobra.ExactArgs(2),
+}
+
+var ClientConfigListCmd = &cobra.Command{
+	Use:   "config-list",
+	Short: "Lists the staged runtime config changes, with a diff against the running config",
+	Run:   runConfigList,
+	Args:  cobra.ExactArgs(0),
+}
+
+var ClientConfigApplyCmd = &cobra.Command{
+	Use:   "config-apply [ID]",
+	Short: "Applies a staged runtime config change",
+	Run:   runConfigApply,
+	Args:  cobra.ExactArgs(1),
+}
+
+var ClientConfigDiscardCmd = &cobra.Command{
+	Use:   "config-discard [ID]",
+	Short: "Discards a staged runtime config change",
+	Run:   runConfigDiscard,
+	Args:  cobra.ExactArgs(1),
+}
+
 var Keccak256Hash = &cobra.Command{
 	Use:   "keccak256",
 	Short: "Compute legacy keccak256 hash",
@@ -271,16 +648,73 @@ func runInjectGovernanceVAA(cmd *cobra.Command, args []string) {
 		log.Fatalf("failed to deserialize: %v", err)
 	}
 
+	msg.ValidateOnly = *injectValidateOnly
 	resp, err := c.InjectGovernanceVAA(ctx, &msg)
 	if err != nil {
 		log.Fatalf("failed to submit governance VAA: %v", err)
 	}
 
+	if msg.ValidateOnly {
+		for i, v := range resp.Validated {
+			fmt.Printf("VAA %s with digest %s\n", v.MessageId, hexutils.BytesToHex(resp.Digests[i]))
+			fmt.Printf("  Serialized: %s\n", hex.EncodeToString(v.Vaa))
+			fmt.Printf("  Payload: %s\n", v.PayloadType)
+			for _, f := range v.PayloadFields {
+				fmt.Printf("    %s: %s\n", f.Name, f.Value)
+			}
+		}
+		return
+	}
+
 	for _, digest := range resp.Digests {
 		log.Printf("VAA successfully injected with digest %s", hexutils.BytesToHex(digest))
 	}
 }
 
+func runGovernanceVAAStatus(cmd *cobra.Command, args []string) {
+	digest, err := hex.DecodeString(strings.TrimPrefix(args[0], "0x"))
+	if err != nil {
+		log.Fatalf("invalid digest: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	stream, err := c.GovernanceVAAStatus(ctx, &nodev1.GovernanceVAAStatusRequest{Digest: digest})
+	if err != nil {
+		log.Fatalf("failed to run GovernanceVAAStatus RPC: %v", err)
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			log.Fatalf("failed to receive governance VAA status: %v", err)
+		}
+
+		log.Printf("%s: %d/%d signatures (quorum %d, guardian set %d)",
+			resp.MessageId, resp.NumSignatures, len(resp.Guardians), resp.Quorum, resp.GuardianSetIndex)
+		for _, g := range resp.Guardians {
+			if g.Signed {
+				fmt.Printf("  [x] %s signed at %s\n", g.GuardianAddress, time.UnixMilli(g.SignedAt).Format(time.RFC3339))
+			} else {
+				fmt.Printf("  [ ] %s\n", g.GuardianAddress)
+			}
+		}
+		if resp.QuorumReached {
+			log.Printf("quorum reached")
+		}
+	}
+}
+
 func runFindMissingMessages(cmd *cobra.Command, args []string) {
 	chainID, err := strconv.Atoi(args[0])
 	if err != nil {
@@ -319,6 +753,12 @@ func runFindMissingMessages(cmd *cobra.Command, args []string) {
 // runDumpVAAByMessageID uses GetSignedVAA to request the given message,
 // then decode and dump the VAA.
 func runDumpVAAByMessageID(cmd *cobra.Command, args []string) {
+	if *dumpPayloadDecoders != "" {
+		if err := payloads.LoadFile(*dumpPayloadDecoders); err != nil {
+			log.Fatalf("failed to load payload decoders: %v", err)
+		}
+	}
+
 	// Parse the {chain,emitter,seq} string.
 	parts := strings.Split(args[0], "/")
 	if len(parts) != 3 {
@@ -361,6 +801,7 @@ func runDumpVAAByMessageID(cmd *cobra.Command, args []string) {
 	}
 
 	log.Printf("VAA with digest %s: %+v\n", v.HexDigest(), spew.Sdump(v))
+	logDecodedPayload(v)
 	fmt.Printf("Bytes:\n%s\n", hex.EncodeToString(resp.VaaBytes))
 }
 
@@ -370,14 +811,9 @@ func runSendObservationRequest(cmd *cobra.Command, args []string) {
 		log.Fatalf("invalid chain ID: %v", err)
 	}
 
-	// Support tx with or without leading 0x so copy / pasta
-	// from monitoring tools is easier.
-	txHash, err := hex.DecodeString(strings.TrimPrefix(args[1], "0x"))
+	txHash, err := parseTxHash(args[1])
 	if err != nil {
-		txHash, err = base58.Decode(args[1])
-		if err != nil {
-			log.Fatalf("invalid transaction hash (neither hex nor base58): %v", err)
-		}
+		log.Fatalf("%v", err)
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -400,36 +836,73 @@ func runSendObservationRequest(cmd *cobra.Command, args []string) {
 	}
 }
 
-func runDumpRPCs(cmd *cobra.Command, args []string) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+// parseTxHash parses a transaction hash in hex or base58.
+func parseTxHash(s string) ([]byte, error) {
+	// Support tx with or without leading 0x so copy / pasta
+	// from monitoring tools is easier.
+	txHash, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
 	if err != nil {
-		log.Fatalf("failed to get admin client: %v", err)
+		txHash, err = base58.Decode(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transaction hash (neither hex nor base58): %v", err)
+		}
 	}
-	defer conn.Close()
+	return txHash, nil
+}
 
-	resp, err := c.DumpRPCs(ctx, &nodev1.DumpRPCsRequest{})
+// readObservationRequestsCSV reads the observation requests from a CSV [CHAIN_ID|CHAIN_NAME,TX_HASH]. Lines starting with # are ignored.
+func readObservationRequestsCSV(path string) ([]*gossipv1.ObservationRequest, error) {
+	f, err := os.Open(path)
 	if err != nil {
-		log.Fatalf("failed to run dump-rpcs: %s", err)
+		return nil, err
 	}
+	defer f.Close()
 
-	for parm, rpc := range resp.Response {
-		fmt.Println(parm, " = [", rpc, "]")
+	r := csv.NewReader(f)
+	r.Comment = '#'
+	r.FieldsPerRecord = 2
+	r.TrimLeadingSpace = true
+
+	var reqs []*gossipv1.ObservationRequest
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := r.FieldPos(0)
+		chainID, err := parseChainID(strings.TrimSpace(row[0]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid chain ID: %w", line, err)
+		}
+		txHash, err := parseTxHash(strings.TrimSpace(row[1]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		reqs = append(reqs, &gossipv1.ObservationRequest{ChainId: uint32(chainID), TxHash: txHash})
 	}
+	return reqs, nil
 }
 
-func runGetAndObserveMissingVAAs(cmd *cobra.Command, args []string) {
-	url := args[0]
-	if !strings.HasPrefix(url, "https://") {
-		log.Fatalf("invalid url: %s", url)
+func runSendObservationRequestsFromCSV(cmd *cobra.Command, args []string) {
+	if *observationRequestsRate <= 0 {
+		log.Fatalf("--rate must be positive")
 	}
-	apiKey := args[1]
-	if len(apiKey) == 0 {
-		log.Fatalf("missing api key")
+	if *observationRequestsBatchSize <= 0 || *observationRequestsBatchSize > 100 {
+		log.Fatalf("--batchSize must be between 1 and 100")
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+
+	reqs, err := readObservationRequestsCSV(args[0])
+	if err != nil {
+		log.Fatalf("failed to read %s: %v", args[0], err)
+	}
+	if *observationRequestsSkip < 0 || *observationRequestsSkip > len(reqs) {
+		log.Fatalf("--skip must be between 0 and the number of requests (%d)", len(reqs))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	conn, c, err := getAdminClient(ctx, *clientSocketPath)
@@ -438,19 +911,42 @@ func runGetAndObserveMissingVAAs(cmd *cobra.Command, args []string) {
 	}
 	defer conn.Close()
 
-	cmdInfo := nodev1.GetAndObserveMissingVAAsRequest{
-		Url:    url,
-		ApiKey: apiKey,
-	}
-	resp, err := c.GetAndObserveMissingVAAs(ctx, &cmdInfo)
-	if err != nil {
-		log.Fatalf("failed to run get-missing-vaas: %s", err)
+	start := time.Now()
+	sent, retries := *observationRequestsSkip, 0
+	for sent < len(reqs) {
+		end := sent + *observationRequestsBatchSize
+		if end > len(reqs) {
+			end = len(reqs)
+		}
+		batch := reqs[sent:end]
+
+		// The guardian paces the batch, so allow for that on top of the usual timeout.
+		callCtx, callCancel := context.WithTimeout(ctx, 5*time.Second+time.Duration(float64(len(batch))/(*observationRequestsRate)*float64(time.Second)))
+		resp, err := c.SendObservationRequest(callCtx, &nodev1.SendObservationRequestRequest{
+			ObservationRequests: batch,
+			Rate:                *observationRequestsRate,
+		})
+		callCancel()
+		if err != nil {
+			if common.GrpcErrorReasonOf(err) != common.ReasonQueueFull {
+				log.Fatalf("failed to send observation requests: %v\n%d of %d requests were sent, resume with --skip %d", err, sent, len(reqs), sent)
+			}
+			resp = &nodev1.SendObservationRequestResponse{}
+		}
+
+		sent += int(resp.Sent)
+		if int(resp.Sent) < len(batch) {
+			// The outbound queue of the guardian is full, give it time to drain.
+			retries++
+			time.Sleep(time.Second)
+		}
+		fmt.Printf("sent %d of %d observation requests\n", sent, len(reqs))
 	}
 
-	fmt.Println(resp.GetResponse())
+	fmt.Printf("Sent %d observation requests in %s (%d retries because the outbound queue was full).\n", sent-*observationRequestsSkip, time.Since(start).Round(time.Second), retries)
 }
 
-func runChainGovernorStatus(cmd *cobra.Command, args []string) {
+func runDumpRPCs(cmd *cobra.Command, args []string) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -460,16 +956,17 @@ func runChainGovernorStatus(cmd *cobra.Command, args []string) {
 	}
 	defer conn.Close()
 
-	msg := nodev1.ChainGovernorStatusRequest{}
-	resp, err := c.ChainGovernorStatus(ctx, &msg)
+	resp, err := c.DumpRPCs(ctx, &nodev1.DumpRPCsRequest{})
 	if err != nil {
-		log.Fatalf("failed to run ChainGovernorStatus RPC: %s", err)
+		log.Fatalf("failed to run dump-rpcs: %s", err)
 	}
 
-	fmt.Println(resp.Response)
+	for parm, rpc := range resp.Response {
+		fmt.Println(parm, " = [", rpc, "]")
+	}
 }
 
-func runChainGovernorReload(cmd *cobra.Command, args []string) {
+func runDumpConfig(cmd *cobra.Command, args []string) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -479,16 +976,15 @@ func runChainGovernorReload(cmd *cobra.Command, args []string) {
 	}
 	defer conn.Close()
 
-	msg := nodev1.ChainGovernorReloadRequest{}
-	resp, err := c.ChainGovernorReload(ctx, &msg)
+	resp, err := c.DumpConfig(ctx, &nodev1.DumpConfigRequest{})
 	if err != nil {
-		log.Fatalf("failed to run ChainGovernorReload RPC: %s", err)
+		log.Fatalf("failed to run dump-config: %s", err)
 	}
 
-	fmt.Println(resp.Response)
+	fmt.Println(resp.Config)
 }
 
-func runChainGovernorDropPendingVAA(cmd *cobra.Command, args []string) {
+func runNodeHealth(cmd *cobra.Command, args []string) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -498,18 +994,31 @@ func runChainGovernorDropPendingVAA(cmd *cobra.Command, args []string) {
 	}
 	defer conn.Close()
 
-	msg := nodev1.ChainGovernorDropPendingVAARequest{
-		VaaId: args[0],
-	}
-	resp, err := c.ChainGovernorDropPendingVAA(ctx, &msg)
+	resp, err := c.GetNodeHealth(ctx, &nodev1.GetNodeHealthRequest{})
 	if err != nil {
-		log.Fatalf("failed to run ChainGovernorDropPendingVAA RPC: %s", err)
+		log.Fatalf("failed to run GetNodeHealth RPC: %s", err)
 	}
 
-	fmt.Println(resp.Response)
+	b, err := protojson.MarshalOptions{Multiline: true, EmitUnpopulated: true}.Marshal(resp)
+	if err != nil {
+		log.Fatalf("failed to marshal the node health: %v", err)
+	}
+	fmt.Println(string(b))
 }
 
-func runChainGovernorReleasePendingVAA(cmd *cobra.Command, args []string) {
+func runLogLevel(cmd *cobra.Command, args []string) {
+	if *logLevelDuration < 0 || *logLevelDuration%time.Second != 0 {
+		log.Fatalf("--duration must be a non-negative whole number of seconds")
+	}
+	req := &nodev1.SetLogLevelRequest{
+		Component:       *logLevelComponent,
+		DurationSeconds: uint32(*logLevelDuration / time.Second),
+		ListOnly:        len(args) == 0,
+	}
+	if len(args) == 1 && args[0] != "reset" {
+		req.Level = args[0]
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -519,19 +1028,29 @@ func runChainGovernorReleasePendingVAA(cmd *cobra.Command, args []string) {
 	}
 	defer conn.Close()
 
-	msg := nodev1.ChainGovernorReleasePendingVAARequest{
-		VaaId: args[0],
-	}
-	resp, err := c.ChainGovernorReleasePendingVAA(ctx, &msg)
+	resp, err := c.SetLogLevel(ctx, req)
 	if err != nil {
-		log.Fatalf("failed to run ChainGovernorReleasePendingVAA RPC: %s", err)
+		log.Fatalf("failed to run SetLogLevel RPC: %s", err)
 	}
 
-	fmt.Println(resp.Response)
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "COMPONENT\tLEVEL\tREVERTS")
+	for _, l := range resp.Levels {
+		component := l.Component
+		if component == "" {
+			component = "(global)"
+		}
+		reverts := "never"
+		if l.Expires != 0 {
+			reverts = time.Unix(l.Expires, 0).Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", component, l.Level, reverts)
+	}
+	w.Flush()
 }
 
-func runChainGovernorResetReleaseTimer(cmd *cobra.Command, args []string) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func runTailLogs(cmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	conn, c, err := getAdminClient(ctx, *clientSocketPath)
@@ -540,14 +1059,748 @@ func runChainGovernorResetReleaseTimer(cmd *cobra.Command, args []string) {
 	}
 	defer conn.Close()
 
-	msg := nodev1.ChainGovernorResetReleaseTimerRequest{
+	stream, err := c.TailLogs(ctx, &nodev1.TailLogsRequest{
+		Level:      *tailLogsLevel,
+		Components: *tailLogsComponents,
+		Backlog:    *tailLogsBacklog,
+	})
+	if err != nil {
+		log.Fatalf("failed to run TailLogs RPC: %v", err)
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			log.Fatalf("failed to receive logs: %v", err)
+		}
+		if resp.Dropped != 0 {
+			log.Printf("%d entries dropped, not keeping up with the guardian", resp.Dropped)
+		}
+		fmt.Println(resp.Line)
+	}
+}
+
+func runRestartComponent(cmd *cobra.Command, args []string) {
+	if *restartComponentReason == "" {
+		log.Fatalf("--reason must be specified")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	resp, err := c.RestartComponent(ctx, &nodev1.RestartComponentRequest{Name: args[0], Reason: *restartComponentReason})
+	if err != nil {
+		log.Fatalf("failed to run RestartComponent RPC: %s", err)
+	}
+	fmt.Printf("Restarted %s\n", resp.Dn)
+}
+
+func runAdminAuditLog(cmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	req := &nodev1.AdminAuditLogRequest{Limit: *adminAuditLogLimit}
+	if *adminAuditLogSince > 0 {
+		req.Since = time.Now().Add(-*adminAuditLogSince).UnixMilli()
+	}
+	resp, err := c.AdminAuditLog(ctx, req)
+	if err != nil {
+		log.Fatalf("failed to run AdminAuditLog RPC: %s", err)
+	}
+
+	for _, e := range resp.Entries {
+		fmt.Printf("%s %s caller=%q result=%q request=%s\n", time.UnixMilli(e.Timestamp).Format(time.RFC3339), path.Base(e.Method), e.Caller, e.Result, e.Request)
+	}
+}
+
+func runDumpPendingObservations(cmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	resp, err := c.DumpPendingObservations(ctx, &nodev1.DumpPendingObservationsRequest{})
+	if err != nil {
+		log.Fatalf("failed to run DumpPendingObservations RPC: %s", err)
+	}
+
+	for _, o := range resp.Observations {
+		messageID := o.MessageId
+		if messageID == "" {
+			messageID = "(not observed by us)"
+		}
+		confidence := o.Confidence
+		if confidence == "" {
+			confidence = "-"
+		}
+		fmt.Printf("%s %s confidence=%s first_observed=%s signatures=%d/%d retries=%d\n",
+			o.Digest, messageID, confidence, time.UnixMilli(o.FirstObserved).Format(time.RFC3339), o.NumSignatures, o.Quorum, o.Retries)
+	}
+}
+
+func runDumpConflictingObservations(cmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	resp, err := c.DumpConflictingObservations(ctx, &nodev1.DumpConflictingObservationsRequest{})
+	if err != nil {
+		log.Fatalf("failed to run DumpConflictingObservations RPC: %s", err)
+	}
+
+	for _, o := range resp.Conflicts {
+		fmt.Printf("%s first_seen=%s last_seen=%s\n",
+			o.MessageId, time.UnixMilli(o.FirstSeen).Format(time.RFC3339), time.UnixMilli(o.LastSeen).Format(time.RFC3339))
+		for _, d := range o.Digests {
+			ours := ""
+			if d.Ours {
+				ours = " (ours)"
+			}
+			fmt.Printf("  %s%s signers=%d %s\n", d.Digest, ours, len(d.Signers), strings.Join(d.Signers, ","))
+		}
+	}
+}
+
+func runAccountantPendingTransfers(cmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	resp, err := c.AccountantPendingTransfers(ctx, &nodev1.AccountantPendingTransfersRequest{})
+	if err != nil {
+		log.Fatalf("failed to run AccountantPendingTransfers RPC: %s", err)
+	}
+
+	fmt.Printf("enforcing=%t ntt_enforcing=%t pending=%d\n", resp.Enforcing, resp.NttEnforcing, len(resp.Transfers))
+	now := time.Now()
+	for _, t := range resp.Transfers {
+		lastSubmitted := "never"
+		if t.LastSubmitted != 0 {
+			lastSubmitted = time.UnixMilli(t.LastSubmitted).Format(time.RFC3339)
+		}
+		fmt.Printf("%s %s accountant=%s emitter=%d/%s observed=%s age=%s last_submitted=%s submit_count=%d\n",
+			t.MessageId, t.Digest, t.Accountant, t.EmitterChain, t.EmitterAddress, time.UnixMilli(t.Observed).Format(time.RFC3339), now.Sub(time.UnixMilli(t.Observed)).Truncate(time.Second), lastSubmitted, t.SubmitCount)
+		fmt.Printf("  %s\n", t.PayloadSummary)
+		if len(t.Approvals) != 0 {
+			fmt.Printf("  force release approved by %s\n", strings.Join(t.Approvals, ", "))
+		}
+	}
+}
+
+func runAccountantResubmitTransfer(cmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := c.AccountantResubmitTransfer(ctx, &nodev1.AccountantResubmitTransferRequest{MessageId: args[0]}); err != nil {
+		log.Fatalf("failed to run AccountantResubmitTransfer RPC: %s", err)
+	}
+	fmt.Printf("resubmitted %s\n", args[0])
+}
+
+func runAccountantForceReleaseTransfer(cmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	resp, err := c.AccountantForceReleaseTransfer(ctx, &nodev1.AccountantForceReleaseTransferRequest{
+		MessageId: args[0],
+		Reason:    *forceReleaseReason,
+	})
+	if err != nil {
+		log.Fatalf("failed to run AccountantForceReleaseTransfer RPC: %s", err)
+	}
+	if resp.Released {
+		fmt.Printf("released %s with %d approvals\n", args[0], resp.Approvals)
+	} else {
+		fmt.Printf("approved releasing %s, %d of %d approvals\n", args[0], resp.Approvals, resp.RequiredApprovals)
+	}
+}
+
+func runPurgeVAAs(cmd *cobra.Command, args []string) {
+	chainID, err := parseChainID(args[0])
+	if err != nil {
+		log.Fatalf("invalid chain ID: %v", err)
+	}
+	fromSequence, err := strconv.ParseUint(args[2], 10, 64)
+	if err != nil {
+		log.Fatalf("invalid first sequence: %v", err)
+	}
+	var toSequence uint64
+	if len(args) == 4 {
+		toSequence, err = strconv.ParseUint(args[3], 10, 64)
+		if err != nil {
+			log.Fatalf("invalid last sequence: %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	resp, err := c.PurgeVAAs(ctx, &nodev1.PurgeVAAsRequest{
+		EmitterChain:   uint32(chainID),
+		EmitterAddress: strings.TrimPrefix(args[1], "0x"),
+		FromSequence:   fromSequence,
+		ToSequence:     toSequence,
+		DryRun:         *purgeVAAsDryRun,
+		Reason:         *purgeVAAsReason,
+	})
+	if err != nil {
+		log.Fatalf("failed to run PurgeVAAs RPC: %s", err)
+	}
+	verb := "purged"
+	if *purgeVAAsDryRun {
+		verb = "would have purged"
+	}
+	if resp.Purged == 0 {
+		fmt.Printf("%s no VAAs\n", verb)
+	} else {
+		fmt.Printf("%s %d VAAs, sequences %d to %d\n", verb, resp.Purged, resp.FirstSequence, resp.LastSequence)
+	}
+}
+
+func runAccountantAuditLog(cmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	resp, err := c.AccountantAuditLog(ctx, &nodev1.AccountantAuditLogRequest{})
+	if err != nil {
+		log.Fatalf("failed to run AccountantAuditLog RPC: %s", err)
+	}
+
+	for _, e := range resp.Entries {
+		fmt.Printf("%s %s accountant=%s msg=%s actor=%q %s\n", time.UnixMilli(e.Timestamp).Format(time.RFC3339), e.Action, e.Accountant, e.MessageId, e.Actor, e.Detail)
+	}
+}
+
+func runGatewayRelayerPause(cmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := c.GatewayRelayerPause(ctx, &nodev1.GatewayRelayerPauseRequest{}); err != nil {
+		log.Fatalf("failed to run GatewayRelayerPause RPC: %s", err)
+	}
+	fmt.Println("paused the gateway relayer")
+}
+
+func runGatewayRelayerResume(cmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := c.GatewayRelayerResume(ctx, &nodev1.GatewayRelayerResumeRequest{}); err != nil {
+		log.Fatalf("failed to run GatewayRelayerResume RPC: %s", err)
+	}
+	fmt.Println("resumed the gateway relayer")
+}
+
+func runGatewayRelayerPendingRelays(cmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	resp, err := c.GatewayRelayerPendingRelays(ctx, &nodev1.GatewayRelayerPendingRelaysRequest{})
+	if err != nil {
+		log.Fatalf("failed to run GatewayRelayerPendingRelays RPC: %s", err)
+	}
+
+	fmt.Printf("paused=%t pending=%d\n", resp.Paused, len(resp.Relays))
+	now := time.Now()
+	for _, r := range resp.Relays {
+		nextAttempt := "never"
+		if r.NextAttempt != 0 {
+			nextAttempt = time.UnixMilli(r.NextAttempt).Format(time.RFC3339)
+		}
+		fmt.Printf("%s status=%s queued=%s age=%s attempts=%d next_attempt=%s last_error=%q\n",
+			r.MessageId, r.Status, time.UnixMilli(r.Queued).Format(time.RFC3339), now.Sub(time.UnixMilli(r.Queued)).Truncate(time.Second), r.Attempts, nextAttempt, r.LastError)
+	}
+}
+
+func runGatewayRelayerRetryRelay(cmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := c.GatewayRelayerRetryRelay(ctx, &nodev1.GatewayRelayerRetryRelayRequest{MessageId: args[0]}); err != nil {
+		log.Fatalf("failed to run GatewayRelayerRetryRelay RPC: %s", err)
+	}
+	fmt.Printf("retrying %s\n", args[0])
+}
+
+func runGatewayRelayerDropRelay(cmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := c.GatewayRelayerDropRelay(ctx, &nodev1.GatewayRelayerDropRelayRequest{MessageId: args[0]}); err != nil {
+		log.Fatalf("failed to run GatewayRelayerDropRelay RPC: %s", err)
+	}
+	fmt.Printf("dropped %s\n", args[0])
+}
+
+func runQueryBudgetUsage(cmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	resp, err := c.GetQueryBudgetUsage(ctx, &nodev1.GetQueryBudgetUsageRequest{})
+	if err != nil {
+		log.Fatalf("failed to run GetQueryBudgetUsage RPC: %s", err)
+	}
+
+	fmt.Printf("day=%s daily_limit=%d\n", time.UnixMilli(resp.Day).UTC().Format("2006-01-02"), resp.DailyLimit)
+	for _, u := range resp.Usage {
+		fmt.Printf("%s used=%d remaining=%d\n", u.Requester, u.Used, resp.DailyLimit-u.Used)
+	}
+}
+
+func runRestoreArchivedVAAs(cmd *cobra.Command, args []string) {
+	from, err := time.Parse(time.RFC3339, args[0])
+	if err != nil {
+		log.Fatalf("invalid FROM: %v", err)
+	}
+	to, err := time.Parse(time.RFC3339, args[1])
+	if err != nil {
+		log.Fatalf("invalid TO: %v", err)
+	}
+	req := &nodev1.RestoreArchivedVAAsRequest{From: from.UnixMilli(), To: to.UnixMilli()}
+	if len(args) > 2 {
+		chainID, err := strconv.ParseUint(args[2], 10, 16)
+		if err != nil {
+			log.Fatalf("invalid chain ID: %v", err)
+		}
+		req.EmitterChain = uint32(chainID)
+	}
+	if len(args) > 3 {
+		req.EmitterAddress = args[3]
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	resp, err := c.RestoreArchivedVAAs(ctx, req)
+	if err != nil {
+		log.Fatalf("failed to run RestoreArchivedVAAs RPC: %s", err)
+	}
+	fmt.Printf("restored %d VAAs\n", resp.NumRestored)
+}
+
+func runDumpAggregatedAttestation(cmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	resp, err := c.GetAggregatedAttestation(ctx, &nodev1.GetAggregatedAttestationRequest{MessageId: args[0]})
+	if err != nil {
+		log.Fatalf("failed to run GetAggregatedAttestation RPC: %s", err)
+	}
+
+	fmt.Printf("attestation: %s\n", hex.EncodeToString(resp.Attestation))
+	fmt.Printf("guardian set index: %d, signers: %d\n", resp.GuardianSetIndex, resp.NumSigners)
+	fmt.Printf("size: %d bytes (classic signatures: %d bytes)\n", len(resp.Attestation), resp.ClassicSignaturesSize)
+}
+
+func runGetAndObserveMissingVAAs(cmd *cobra.Command, args []string) {
+	url := args[0]
+	if !strings.HasPrefix(url, "https://") {
+		log.Fatalf("invalid url: %s", url)
+	}
+	apiKey := args[1]
+	if len(apiKey) == 0 {
+		log.Fatalf("missing api key")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	cmdInfo := nodev1.GetAndObserveMissingVAAsRequest{
+		Url:    url,
+		ApiKey: apiKey,
+	}
+	resp, err := c.GetAndObserveMissingVAAs(ctx, &cmdInfo)
+	if err != nil {
+		log.Fatalf("failed to run get-missing-vaas: %s", err)
+	}
+
+	fmt.Println(resp.GetResponse())
+}
+
+func runChainGovernorStatus(cmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	msg := nodev1.ChainGovernorStatusRequest{}
+	resp, err := c.ChainGovernorStatus(ctx, &msg)
+	if err != nil {
+		log.Fatalf("failed to run ChainGovernorStatus RPC: %s", err)
+	}
+
+	fmt.Println(resp.Response)
+}
+
+func runChainGovernorDumpState(cmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	resp, err := c.ChainGovernorDumpState(ctx, &nodev1.ChainGovernorDumpStateRequest{})
+	if err != nil {
+		log.Fatalf("failed to run ChainGovernorDumpState RPC: %s", err)
+	}
+
+	fmt.Println(resp.State)
+}
+
+func runChainGovernorReload(cmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	msg := nodev1.ChainGovernorReloadRequest{}
+	resp, err := c.ChainGovernorReload(ctx, &msg)
+	if err != nil {
+		log.Fatalf("failed to run ChainGovernorReload RPC: %s", err)
+	}
+
+	fmt.Println(resp.Response)
+}
+
+func runChainGovernorDropPendingVAA(cmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	msg := nodev1.ChainGovernorDropPendingVAARequest{
+		VaaId: args[0],
+	}
+	resp, err := c.ChainGovernorDropPendingVAA(ctx, &msg)
+	if err != nil {
+		log.Fatalf("failed to run ChainGovernorDropPendingVAA RPC: %s", err)
+	}
+
+	fmt.Println(resp.Response)
+}
+
+func runChainGovernorReleasePendingVAA(cmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	msg := nodev1.ChainGovernorReleasePendingVAARequest{
 		VaaId: args[0],
 	}
+	resp, err := c.ChainGovernorReleasePendingVAA(ctx, &msg)
+	if err != nil {
+		log.Fatalf("failed to run ChainGovernorReleasePendingVAA RPC: %s", err)
+	}
+
+	fmt.Println(resp.Response)
+}
+
+func runChainGovernorResetReleaseTimer(cmd *cobra.Command, args []string) {
+	msg := nodev1.ChainGovernorResetReleaseTimerRequest{
+		VaaIds: args,
+	}
+	if *resetReleaseTimerChain != "" {
+		chainID, err := parseChainID(*resetReleaseTimerChain)
+		if err != nil {
+			log.Fatalf("invalid chain ID: %v", err)
+		}
+		msg.ChainId = uint32(chainID)
+	}
+	if len(msg.VaaIds) == 0 && msg.ChainId == 0 {
+		log.Fatalf("the VAA ids or --chain must be specified")
+	}
+	if *resetReleaseTimerDelay < 0 || *resetReleaseTimerDelay%time.Second != 0 {
+		log.Fatalf("--delay must be a non-negative whole number of seconds")
+	}
+	msg.DelaySeconds = uint32(*resetReleaseTimerDelay / time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
 	resp, err := c.ChainGovernorResetReleaseTimer(ctx, &msg)
 	if err != nil {
 		log.Fatalf("failed to run ChainGovernorResetReleaseTimer RPC: %s", err)
 	}
 
+	fmt.Println(resp.Response)
+	for _, vaaId := range resp.VaaIds {
+		fmt.Println(vaaId)
+	}
+}
+
+func runChainGovernorGetLimits(cmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	resp, err := c.ChainGovernorGetLimits(ctx, &nodev1.ChainGovernorGetLimitsRequest{})
+	if err != nil {
+		log.Fatalf("failed to run ChainGovernorGetLimits RPC: %s", err)
+	}
+
+	for _, e := range resp.Entries {
+		fmt.Printf("chain=%d daily_limit=%d big_transaction_size=%d configured_daily_limit=%d configured_big_transaction_size=%d overridden=%t\n",
+			e.ChainId, e.DailyLimit, e.BigTransactionSize, e.ConfiguredDailyLimit, e.ConfiguredBigTransactionSize, e.Overridden)
+	}
+}
+
+func runChainGovernorSetLimit(cmd *cobra.Command, args []string) {
+	chainId, err := strconv.ParseUint(args[0], 10, 16)
+	if err != nil {
+		log.Fatalf("invalid chain ID: %v", err)
+	}
+	dailyLimit, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		log.Fatalf("invalid daily limit: %v", err)
+	}
+	bigTransactionSize, err := strconv.ParseUint(args[2], 10, 64)
+	if err != nil {
+		log.Fatalf("invalid big transaction size: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	msg := nodev1.ChainGovernorSetLimitRequest{
+		ChainId:            uint32(chainId),
+		DailyLimit:         dailyLimit,
+		BigTransactionSize: bigTransactionSize,
+	}
+	if _, err := c.ChainGovernorSetLimit(ctx, &msg); err != nil {
+		log.Fatalf("failed to run ChainGovernorSetLimit RPC: %s", err)
+	}
+	fmt.Printf("chain %d is now limited to %d per day with a big transaction size of %d\n", chainId, dailyLimit, bigTransactionSize)
+}
+
+func runChainGovernorResetLimit(cmd *cobra.Command, args []string) {
+	chainId, err := strconv.ParseUint(args[0], 10, 16)
+	if err != nil {
+		log.Fatalf("invalid chain ID: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := c.ChainGovernorResetLimit(ctx, &nodev1.ChainGovernorResetLimitRequest{ChainId: uint32(chainId)}); err != nil {
+		log.Fatalf("failed to run ChainGovernorResetLimit RPC: %s", err)
+	}
+	fmt.Printf("chain %d is back to its configured limits\n", chainId)
+}
+
+func runChainGovernorAuditLog(cmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	resp, err := c.ChainGovernorAuditLog(ctx, &nodev1.ChainGovernorAuditLogRequest{})
+	if err != nil {
+		log.Fatalf("failed to run ChainGovernorAuditLog RPC: %s", err)
+	}
+
+	for _, e := range resp.Entries {
+		fmt.Printf("%s %s vaa=%s actor=%q %s\n", time.UnixMilli(e.Timestamp).Format(time.RFC3339), e.Action, e.VaaId, e.Actor, e.Detail)
+	}
+}
+
+func runChainGovernorExportSnapshot(cmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	resp, err := c.ChainGovernorExportSnapshot(ctx, &nodev1.ChainGovernorExportSnapshotRequest{})
+	if err != nil {
+		log.Fatalf("failed to run ChainGovernorExportSnapshot RPC: %s", err)
+	}
+
+	if len(args) == 0 {
+		fmt.Println(resp.Snapshot)
+		return
+	}
+	if err := os.WriteFile(args[0], []byte(resp.Snapshot), 0600); err != nil {
+		log.Fatalf("failed to write snapshot: %v", err)
+	}
+	fmt.Printf("governor snapshot written to %s\n", args[0])
+}
+
+func runChainGovernorImportSnapshot(cmd *cobra.Command, args []string) {
+	snapshot, err := os.ReadFile(args[0])
+	if err != nil {
+		log.Fatalf("failed to read snapshot: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	resp, err := c.ChainGovernorImportSnapshot(ctx, &nodev1.ChainGovernorImportSnapshotRequest{Snapshot: string(snapshot)})
+	if err != nil {
+		log.Fatalf("failed to run ChainGovernorImportSnapshot RPC: %s", err)
+	}
+
 	fmt.Println(resp.Response)
 }
 
@@ -704,3 +1957,121 @@ func runKeccak256Hash(cmd *cobra.Command, args []string) {
 	digest := hash.Sum([]byte{})
 	fmt.Printf("%s", hex.EncodeToString(digest))
 }
+
+// logDecodedPayload logs the payload of a VAA, if there is a decoder for its emitter.
+func logDecodedPayload(v *vaa.VAA) {
+	d, err := payloads.Decode(v.EmitterChain, v.EmitterAddress, v.Payload)
+	if err != nil {
+		log.Printf("Failed to decode payload: %v", err)
+	} else if d != nil {
+		log.Printf("Payload: %s", d)
+	}
+}
+
+func runConfigGet(cmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	resp, err := c.GetRuntimeConfig(ctx, &nodev1.GetRuntimeConfigRequest{Target: args[0]})
+	if err != nil {
+		log.Fatalf("failed to run GetRuntimeConfig RPC: %s", err)
+	}
+
+	fmt.Print(resp.Config)
+}
+
+func runConfigPropose(cmd *cobra.Command, args []string) {
+	config, err := os.ReadFile(args[1])
+	if err != nil {
+		log.Fatalf("failed to read config: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	resp, err := c.ProposeConfigChange(ctx, &nodev1.ProposeConfigChangeRequest{Target: args[0], Config: string(config)})
+	if err != nil {
+		log.Fatalf("failed to run ProposeConfigChange RPC: %s", err)
+	}
+
+	printStagedConfig(resp.Staged)
+}
+
+func runConfigList(cmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	resp, err := c.ListStagedConfigs(ctx, &nodev1.ListStagedConfigsRequest{})
+	if err != nil {
+		log.Fatalf("failed to run ListStagedConfigs RPC: %s", err)
+	}
+
+	if len(resp.Staged) == 0 {
+		fmt.Println("no staged config changes")
+	}
+	for _, staged := range resp.Staged {
+		printStagedConfig(staged)
+	}
+}
+
+func runConfigApply(cmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	_, err = c.ApplyStagedConfig(ctx, &nodev1.ApplyStagedConfigRequest{Id: args[0]})
+	if err != nil {
+		log.Fatalf("failed to run ApplyStagedConfig RPC: %s", err)
+	}
+
+	fmt.Printf("applied staged config change %s\n", args[0])
+}
+
+func runConfigDiscard(cmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	_, err = c.DiscardStagedConfig(ctx, &nodev1.DiscardStagedConfigRequest{Id: args[0]})
+	if err != nil {
+		log.Fatalf("failed to run DiscardStagedConfig RPC: %s", err)
+	}
+
+	fmt.Printf("discarded staged config change %s\n", args[0])
+}
+
+func printStagedConfig(staged *nodev1.StagedConfig) {
+	fmt.Printf("id: %s, target: %s, proposer: %s, proposed at: %s\n", staged.Id, staged.Target, staged.Proposer, time.UnixMilli(staged.ProposedAt).Format(time.RFC3339))
+	if staged.Stale {
+		fmt.Println("the running config has changed since this was proposed, it can no longer be applied")
+	}
+	fmt.Println(staged.Diff)
+}
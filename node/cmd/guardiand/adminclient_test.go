@@ -0,0 +1,32 @@
+package guardiand
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestReadObservationRequestsCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "requests.csv")
+	require.NoError(t, os.WriteFile(path, []byte("# chain,tx hash\nsolana, 0x0102\n1,3yZe7d\n"), 0600))
+
+	reqs, err := readObservationRequestsCSV(path)
+	require.NoError(t, err)
+	assert.Equal(t, []*gossipv1.ObservationRequest{
+		{ChainId: uint32(vaa.ChainIDSolana), TxHash: []byte{1, 2}},
+		{ChainId: uint32(vaa.ChainIDSolana), TxHash: []byte("test")},
+	}, reqs)
+
+	require.NoError(t, os.WriteFile(path, []byte("solana,0x0102\nnotachain,0x0304\n"), 0600))
+	_, err = readObservationRequestsCSV(path)
+	require.ErrorContains(t, err, "line 2: invalid chain ID")
+
+	require.NoError(t, os.WriteFile(path, []byte("solana,0x0102,extra\n"), 0600))
+	_, err = readObservationRequestsCSV(path)
+	require.Error(t, err)
+}
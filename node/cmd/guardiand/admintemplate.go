@@ -1,12 +1,17 @@
 package guardiand
 
 import (
+	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"math/big"
+	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/btcsuite/btcutil/bech32"
 	"github.com/ethereum/go-ethereum/common"
@@ -17,17 +22,26 @@ import (
 
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/encoding/prototext"
 
 	"github.com/certusone/wormhole/node/pkg/devnet"
 	nodev1 "github.com/certusone/wormhole/node/pkg/proto/node/v1"
+	publicrpcv1 "github.com/certusone/wormhole/node/pkg/proto/publicrpc/v1"
 )
 
 var setUpdateNumGuardians *int
 var templateGuardianIndex *int
+var templateSocketPath *string
+var templatePublicRpc *string
 var chainID *string
 var address *string
 var module *string
+var dailyLimit *uint64
+var bigTransactionSize *uint64
+var messageFee *string
+var feeAmount *string
+var feeRecipient *string
 
 // TBDel
 var recoverChainIdEvmChainId *string
@@ -41,7 +55,9 @@ func init() {
 	moduleFlagSet := pflag.NewFlagSet("module", pflag.ExitOnError)
 	module = moduleFlagSet.String("module", "", "Module name")
 
-	templateGuardianIndex = TemplateCmd.PersistentFlags().Int("idx", 3, "Default current guardian set index")
+	templateGuardianIndex = TemplateCmd.PersistentFlags().Int("idx", -1, "Current guardian set index (fetched from --socket or --publicRpc if not set)")
+	templateSocketPath = TemplateCmd.PersistentFlags().String("socket", "", "gRPC admin server socket of a node to fetch the current guardian set index from")
+	templatePublicRpc = TemplateCmd.PersistentFlags().String("publicRpc", "", "Public RPC REST endpoint to fetch the current guardian set index from, e.g. https://api.wormholescan.io")
 
 	setUpdateNumGuardians = AdminClientGuardianSetTemplateCmd.Flags().Int("num", 1, "Number of devnet guardians in example file")
 	TemplateCmd.AddCommand(AdminClientGuardianSetTemplateCmd)
@@ -49,6 +65,18 @@ func init() {
 	AdminClientContractUpgradeTemplateCmd.Flags().AddFlagSet(governanceFlagSet)
 	TemplateCmd.AddCommand(AdminClientContractUpgradeTemplateCmd)
 
+	chainIDFlagSet := pflag.NewFlagSet("chain-id", pflag.ExitOnError)
+	chainIDFlagSet.AddFlag(governanceFlagSet.Lookup("chain-id"))
+
+	messageFee = AdminClientSetMessageFeeTemplateCmd.Flags().String("message-fee", "", "New message fee, in the smallest unit of the native token of the chain (decimal uint256)")
+	AdminClientSetMessageFeeTemplateCmd.Flags().AddFlagSet(chainIDFlagSet)
+	TemplateCmd.AddCommand(AdminClientSetMessageFeeTemplateCmd)
+
+	feeAmount = AdminClientTransferFeesTemplateCmd.Flags().String("amount", "", "Amount of fees to transfer, in the smallest unit of the native token of the chain (decimal uint256)")
+	feeRecipient = AdminClientTransferFeesTemplateCmd.Flags().String("recipient", "", "Recipient of the fees (hex, base58 or bech32)")
+	AdminClientTransferFeesTemplateCmd.Flags().AddFlagSet(chainIDFlagSet)
+	TemplateCmd.AddCommand(AdminClientTransferFeesTemplateCmd)
+
 	AdminClientTokenBridgeRegisterChainCmd.Flags().AddFlagSet(governanceFlagSet)
 	AdminClientTokenBridgeRegisterChainCmd.Flags().AddFlagSet(moduleFlagSet)
 	TemplateCmd.AddCommand(AdminClientTokenBridgeRegisterChainCmd)
@@ -60,6 +88,13 @@ func init() {
 	AdminClientWormholeRelayerSetDefaultDeliveryProviderCmd.Flags().AddFlagSet(governanceFlagSet)
 	TemplateCmd.AddCommand(AdminClientWormholeRelayerSetDefaultDeliveryProviderCmd)
 
+	chainGovernorFlagSet := pflag.NewFlagSet("chain-governor", pflag.ExitOnError)
+	dailyLimit = chainGovernorFlagSet.Uint64("daily-limit", 0, "New daily limit of the chain, in USD")
+	bigTransactionSize = chainGovernorFlagSet.Uint64("big-transaction-size", 0, "New big transaction size of the chain, in USD")
+	AdminClientChainGovernorSetChainLimitCmd.Flags().AddFlagSet(governanceFlagSet)
+	AdminClientChainGovernorSetChainLimitCmd.Flags().AddFlagSet(chainGovernorFlagSet)
+	TemplateCmd.AddCommand(AdminClientChainGovernorSetChainLimitCmd)
+
 	// TBDel
 	// flags for the recover-chain-id command
 	recoverChainIdFlagSet := pflag.NewFlagSet("recover-chain-id", pflag.ExitOnError)
@@ -87,6 +122,18 @@ var AdminClientContractUpgradeTemplateCmd = &cobra.Command{
 	Run:   runContractUpgradeTemplate,
 }
 
+var AdminClientSetMessageFeeTemplateCmd = &cobra.Command{
+	Use:   "set-message-fee",
+	Short: "Generate a template to set the message fee of the core contract on the specified chain",
+	Run:   runSetMessageFeeTemplate,
+}
+
+var AdminClientTransferFeesTemplateCmd = &cobra.Command{
+	Use:   "transfer-fees",
+	Short: "Generate a template to transfer the message fees collected by the core contract on the specified chain",
+	Run:   runTransferFeesTemplate,
+}
+
 var AdminClientTokenBridgeRegisterChainCmd = &cobra.Command{
 	Use:   "token-bridge-register-chain",
 	Short: "Generate an empty token bridge chain registration template at specified path",
@@ -111,6 +158,97 @@ var AdminClientWormholeRelayerSetDefaultDeliveryProviderCmd = &cobra.Command{
 	Run:   runWormholeRelayerSetDefaultDeliveryProviderTemplate,
 }
 
+var AdminClientChainGovernorSetChainLimitCmd = &cobra.Command{
+	Use:   "chain-governor-set-chain-limit",
+	Short: "Generate a template to change the limits the chain governor enforces on the specified chain",
+	Run:   runChainGovernorSetChainLimitTemplate,
+}
+
+// templateCurrentSetIndex returns the guardian set index to put in a template. If --idx is not set, it is fetched from the node or
+// public RPC given on the command line. If both are given, they must agree, since a template for the wrong guardian set cannot be
+// injected.
+func templateCurrentSetIndex() uint32 {
+	var fetched *uint32
+	var source string
+	if *templateSocketPath != "" || *templatePublicRpc != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		var idx uint32
+		var err error
+		if *templateSocketPath != "" {
+			source = *templateSocketPath
+			idx, err = fetchGuardianSetIndexFromSocket(ctx, *templateSocketPath)
+		} else {
+			source = *templatePublicRpc
+			idx, err = fetchGuardianSetIndexFromPublicRpc(ctx, *templatePublicRpc)
+		}
+		if err != nil {
+			log.Fatalf("failed to fetch the current guardian set index from %s: %v", source, err)
+		}
+		fetched = &idx
+	}
+
+	if *templateGuardianIndex < 0 {
+		if fetched == nil {
+			log.Fatalf("the current guardian set index is unknown, specify it with --idx or fetch it with --socket or --publicRpc")
+		}
+		log.Printf("using current guardian set index %d from %s", *fetched, source)
+		return *fetched
+	}
+
+	if fetched != nil && *fetched != uint32(*templateGuardianIndex) {
+		log.Fatalf("--idx %d does not match the current guardian set index %d reported by %s", *templateGuardianIndex, *fetched, source)
+	}
+	return uint32(*templateGuardianIndex)
+}
+
+func fetchGuardianSetIndexFromSocket(ctx context.Context, socketPath string) (uint32, error) {
+	conn, c, err := getPublicRPCServiceClient(ctx, socketPath)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	resp, err := c.GetCurrentGuardianSet(ctx, &publicrpcv1.GetCurrentGuardianSetRequest{})
+	if err != nil {
+		return 0, err
+	}
+	if resp.GuardianSet == nil {
+		return 0, errors.New("response does not contain a guardian set")
+	}
+	return resp.GuardianSet.Index, nil
+}
+
+func fetchGuardianSetIndexFromPublicRpc(ctx context.Context, baseURL string) (uint32, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(baseURL, "/")+"/v1/guardianset/current", nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status %s: %s", resp.Status, string(body))
+	}
+
+	var gs publicrpcv1.GetCurrentGuardianSetResponse
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(body, &gs); err != nil {
+		return 0, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if gs.GuardianSet == nil || len(gs.GuardianSet.Addresses) == 0 {
+		return 0, errors.New("response does not contain a guardian set")
+	}
+	return gs.GuardianSet.Index, nil
+}
+
 func runGuardianSetTemplate(cmd *cobra.Command, args []string) {
 	// Use deterministic devnet addresses as examples in the template, such that this doubles as a test fixture.
 	guardians := make([]*nodev1.GuardianSetUpdate_Guardian, *setUpdateNumGuardians)
@@ -123,7 +261,7 @@ func runGuardianSetTemplate(cmd *cobra.Command, args []string) {
 	}
 
 	m := &nodev1.InjectGovernanceVAARequest{
-		CurrentSetIndex: uint32(*templateGuardianIndex),
+		CurrentSetIndex: templateCurrentSetIndex(),
 		Messages: []*nodev1.GovernanceMessage{
 			{
 				Sequence: rand.Uint64(),
@@ -153,7 +291,7 @@ func runContractUpgradeTemplate(cmd *cobra.Command, args []string) {
 	}
 
 	m := &nodev1.InjectGovernanceVAARequest{
-		CurrentSetIndex: uint32(*templateGuardianIndex),
+		CurrentSetIndex: templateCurrentSetIndex(),
 		Messages: []*nodev1.GovernanceMessage{
 			{
 				Sequence: rand.Uint64(),
@@ -174,6 +312,85 @@ func runContractUpgradeTemplate(cmd *cobra.Command, args []string) {
 	}
 	fmt.Print(string(b))
 }
+
+func runSetMessageFeeTemplate(cmd *cobra.Command, args []string) {
+	chainID, err := parseChainID(*chainID)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *messageFee == "" {
+		log.Fatal("--message-fee must be specified.")
+	}
+	if _, err := isValidUint256(*messageFee); err != nil {
+		log.Fatal("failed to parse message fee as uint256:", err)
+	}
+
+	m := &nodev1.InjectGovernanceVAARequest{
+		CurrentSetIndex: templateCurrentSetIndex(),
+		Messages: []*nodev1.GovernanceMessage{
+			{
+				Sequence: rand.Uint64(),
+				Nonce:    rand.Uint32(),
+				Payload: &nodev1.GovernanceMessage_CoreSetMessageFee{
+					CoreSetMessageFee: &nodev1.CoreSetMessageFee{
+						ChainId:    uint32(chainID),
+						MessageFee: *messageFee,
+					},
+				},
+			},
+		},
+	}
+
+	b, err := prototext.MarshalOptions{Multiline: true}.Marshal(m)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Print(string(b))
+}
+
+func runTransferFeesTemplate(cmd *cobra.Command, args []string) {
+	chainID, err := parseChainID(*chainID)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *feeAmount == "" {
+		log.Fatal("--amount must be specified.")
+	}
+	if _, err := isValidUint256(*feeAmount); err != nil {
+		log.Fatal("failed to parse amount as uint256:", err)
+	}
+	if *feeRecipient == "" {
+		log.Fatal("--recipient must be specified.")
+	}
+	recipient, err := parseAddress(*feeRecipient)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	m := &nodev1.InjectGovernanceVAARequest{
+		CurrentSetIndex: templateCurrentSetIndex(),
+		Messages: []*nodev1.GovernanceMessage{
+			{
+				Sequence: rand.Uint64(),
+				Nonce:    rand.Uint32(),
+				Payload: &nodev1.GovernanceMessage_CoreTransferFees{
+					CoreTransferFees: &nodev1.CoreTransferFees{
+						ChainId:   uint32(chainID),
+						Amount:    *feeAmount,
+						Recipient: recipient,
+					},
+				},
+			},
+		},
+	}
+
+	b, err := prototext.MarshalOptions{Multiline: true}.Marshal(m)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Print(string(b))
+}
+
 func runTokenBridgeRegisterChainTemplate(cmd *cobra.Command, args []string) {
 	address, err := parseAddress(*address)
 	if err != nil {
@@ -185,7 +402,7 @@ func runTokenBridgeRegisterChainTemplate(cmd *cobra.Command, args []string) {
 	}
 
 	m := &nodev1.InjectGovernanceVAARequest{
-		CurrentSetIndex: uint32(*templateGuardianIndex),
+		CurrentSetIndex: templateCurrentSetIndex(),
 		Messages: []*nodev1.GovernanceMessage{
 			{
 				Sequence: rand.Uint64(),
@@ -219,7 +436,7 @@ func runTokenBridgeUpgradeContractTemplate(cmd *cobra.Command, args []string) {
 	}
 
 	m := &nodev1.InjectGovernanceVAARequest{
-		CurrentSetIndex: uint32(*templateGuardianIndex),
+		CurrentSetIndex: templateCurrentSetIndex(),
 		Messages: []*nodev1.GovernanceMessage{
 			{
 				Sequence: rand.Uint64(),
@@ -261,7 +478,7 @@ func runRecoverChainIdTemplate(cmd *cobra.Command, args []string) {
 	}
 
 	m := &nodev1.InjectGovernanceVAARequest{
-		CurrentSetIndex: uint32(*templateGuardianIndex),
+		CurrentSetIndex: templateCurrentSetIndex(),
 		Messages: []*nodev1.GovernanceMessage{
 			{
 				Sequence: rand.Uint64(),
@@ -295,7 +512,7 @@ func runWormholeRelayerSetDefaultDeliveryProviderTemplate(cmd *cobra.Command, ar
 	}
 
 	m := &nodev1.InjectGovernanceVAARequest{
-		CurrentSetIndex: uint32(*templateGuardianIndex),
+		CurrentSetIndex: templateCurrentSetIndex(),
 		Messages: []*nodev1.GovernanceMessage{
 			{
 				Sequence: rand.Uint64(),
@@ -317,6 +534,36 @@ func runWormholeRelayerSetDefaultDeliveryProviderTemplate(cmd *cobra.Command, ar
 	fmt.Print(string(b))
 }
 
+func runChainGovernorSetChainLimitTemplate(cmd *cobra.Command, args []string) {
+	chainID, err := parseChainID(*chainID)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	m := &nodev1.InjectGovernanceVAARequest{
+		CurrentSetIndex: templateCurrentSetIndex(),
+		Messages: []*nodev1.GovernanceMessage{
+			{
+				Sequence: rand.Uint64(),
+				Nonce:    rand.Uint32(),
+				Payload: &nodev1.GovernanceMessage_ChainGovernorSetChainLimit{
+					ChainGovernorSetChainLimit: &nodev1.ChainGovernorSetChainLimit{
+						ChainId:            uint32(chainID),
+						DailyLimit:         *dailyLimit,
+						BigTransactionSize: *bigTransactionSize,
+					},
+				},
+			},
+		},
+	}
+
+	b, err := prototext.MarshalOptions{Multiline: true}.Marshal(m)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Print(string(b))
+}
+
 // parseAddress parses either a hex-encoded address and returns
 // a left-padded 32 byte hex string.
 func parseAddress(s string) (string, error) {
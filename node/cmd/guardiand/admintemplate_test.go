@@ -0,0 +1,35 @@
+package guardiand
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchGuardianSetIndexFromPublicRpc(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/guardianset/current":
+			_, _ = w.Write([]byte(`{"guardianSet":{"index":4,"addresses":["0x58CC3AE5C097b213cE3c81979e1B9f9570746AA5"]},"extra":true}`))
+		case "/empty/v1/guardianset/current":
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	idx, err := fetchGuardianSetIndexFromPublicRpc(context.Background(), srv.URL+"/")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(4), idx)
+
+	_, err = fetchGuardianSetIndexFromPublicRpc(context.Background(), srv.URL+"/empty")
+	assert.ErrorContains(t, err, "does not contain a guardian set")
+
+	_, err = fetchGuardianSetIndexFromPublicRpc(context.Background(), srv.URL+"/missing")
+	assert.ErrorContains(t, err, "404")
+}
@@ -56,5 +56,6 @@ func runGovernanceVAAVerify(cmd *cobra.Command, args []string) {
 		log.Printf("Serialized: %v", hex.EncodeToString(b))
 
 		log.Printf("VAA with digest %s: %+v", hexutils.BytesToHex(digest), spew.Sdump(v))
+		logDecodedPayload(v)
 	}
 }
@@ -0,0 +1,364 @@
+package guardiand
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/p2p"
+	nodev1 "github.com/certusone/wormhole/node/pkg/proto/node/v1"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	libp2ptls "github.com/libp2p/go-libp2p/p2p/security/tls"
+	libp2pquic "github.com/libp2p/go-libp2p/p2p/transport/quic"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"golang.org/x/term"
+	"google.golang.org/protobuf/encoding/prototext"
+)
+
+// guardianRegistrationPrefix domain-separates the proof of possession in a registration payload from any other message signed
+// with the guardian key.
+const guardianRegistrationPrefix = "guardian_registration|"
+
+// ceremonyHSMPinEnv is the environment variable the HSM user PIN is read from if no PIN file is given. The PIN is never taken as a flag,
+// since the command line of a process is visible to other users and ends up in the shell history.
+const ceremonyHSMPinEnv = "GUARDIAND_HSM_PIN"
+
+var (
+	ceremonyName           *string
+	ceremonyKeyFile        *string
+	ceremonyNodeKey        *string
+	ceremonyHSMModule      *string
+	ceremonyHSMToken       *string
+	ceremonyHSMPinFile     *string
+	ceremonyHSMKeyLabel    *string
+	ceremonyBootstrap      *string
+	ceremonyConnectTimeout *time.Duration
+	ceremonyRegistration   *string
+	ceremonyReport         *string
+)
+
+func init() {
+	ceremonyName = CeremonyNewGuardianCmd.Flags().String("name", "", "Name of the new guardian, as it will appear in the guardian set")
+	ceremonyKeyFile = CeremonyNewGuardianCmd.Flags().String("keyFile", "", "Path to write the guardian key to (not used with --hsmModule)")
+	ceremonyNodeKey = CeremonyNewGuardianCmd.Flags().String("nodeKey", "", "Path to the P2P node key (will be generated if it doesn't exist)")
+	ceremonyHSMModule = CeremonyNewGuardianCmd.Flags().String("hsmModule", "", "Path to a PKCS#11 module, to generate the guardian key inside an HSM instead of a key file")
+	ceremonyHSMToken = CeremonyNewGuardianCmd.Flags().String("hsmToken", "", "Label of the HSM token to generate the key on")
+	ceremonyHSMPinFile = CeremonyNewGuardianCmd.Flags().String("hsmPinFile", "", "Path to a file holding the user PIN of the HSM token (defaults to $"+ceremonyHSMPinEnv+", or a prompt)")
+	ceremonyHSMKeyLabel = CeremonyNewGuardianCmd.Flags().String("hsmKeyLabel", "guardian", "Label of the generated key on the HSM token")
+	ceremonyBootstrap = CeremonyNewGuardianCmd.Flags().String("bootstrap", "", "P2P bootstrap peers to check connectivity to (comma-separated)")
+	ceremonyConnectTimeout = CeremonyNewGuardianCmd.Flags().Duration("connectTimeout", 30*time.Second, "Timeout for connecting to each bootstrap peer")
+	ceremonyRegistration = CeremonyNewGuardianCmd.Flags().String("registration", "guardian-registration.json", "Path to write the registration payload to")
+	ceremonyReport = CeremonyNewGuardianCmd.Flags().String("report", "", "Path to write the checklist report to as JSON (optional)")
+
+	CeremonyCmd.AddCommand(CeremonyNewGuardianCmd)
+}
+
+var CeremonyCmd = &cobra.Command{
+	Use:   "ceremony",
+	Short: "Key ceremony tools for guardian operators",
+}
+
+var CeremonyNewGuardianCmd = &cobra.Command{
+	Use:   "new-guardian",
+	Short: "Generate the keys of a new guardian and produce its registration payload",
+	Long: "Generates the guardian key, either as a key file or inside an HSM, and the P2P node key. Writes a registration payload " +
+		"with the public guardian address, the P2P peer ID and a proof of possession of the guardian key, checks that the " +
+		"bootstrap peers can be reached and prints a checklist of the remaining onboarding steps.",
+	Run:  runCeremonyNewGuardian,
+	Args: cobra.NoArgs,
+}
+
+// guardianRegistration is the payload a new guardian hands to the existing guardians so that they can add it to the guardian set.
+type guardianRegistration struct {
+	Name            string `json:"name"`
+	GuardianAddress string `json:"guardianAddress"`
+	PublicKey       string `json:"publicKey"`
+	P2PPeerID       string `json:"p2pPeerId"`
+	KeyStorage      string `json:"keyStorage"`
+	// Signature is a signature over registrationDigest by the guardian key, proving that the registrant holds it.
+	Signature string `json:"signature"`
+}
+
+// registrationDigest returns the digest signed by the guardian key. It binds the guardian address to the name and the P2P identity.
+func (r *guardianRegistration) registrationDigest() ethcommon.Hash {
+	return ethcrypto.Keccak256Hash([]byte(guardianRegistrationPrefix + r.Name + "|" + r.GuardianAddress + "|" + r.P2PPeerID))
+}
+
+// verify checks that the signature was made by the key with the registered address.
+func (r *guardianRegistration) verify() error {
+	sig, err := hex.DecodeString(r.Signature)
+	if err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+	digest := r.registrationDigest()
+	pubKey, err := ethcrypto.SigToPub(digest.Bytes(), sig)
+	if err != nil {
+		return fmt.Errorf("failed to recover public key: %w", err)
+	}
+	if addr := ethcrypto.PubkeyToAddress(*pubKey); addr != ethcommon.HexToAddress(r.GuardianAddress) {
+		return fmt.Errorf("signature is by %s, not by %s", addr.Hex(), r.GuardianAddress)
+	}
+	return nil
+}
+
+// checklistItem is one step of the onboarding checklist. Steps which can't be performed by the tool are reported as TODO.
+type checklistItem struct {
+	Step   string `json:"step"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+const (
+	checklistPass = "PASS"
+	checklistFail = "FAIL"
+	checklistTodo = "TODO"
+)
+
+type checklist []checklistItem
+
+func (c *checklist) add(step, status, detail string) {
+	*c = append(*c, checklistItem{Step: step, Status: status, Detail: detail})
+}
+
+func (c *checklist) check(step string, err error, detail string) bool {
+	if err != nil {
+		c.add(step, checklistFail, err.Error())
+		return false
+	}
+	c.add(step, checklistPass, detail)
+	return true
+}
+
+func runCeremonyNewGuardian(cmd *cobra.Command, args []string) {
+	common.LockMemory()
+	common.SetRestrictiveUmask()
+
+	if *ceremonyName == "" {
+		fmt.Println("Please specify --name")
+		os.Exit(1)
+	}
+	if *ceremonyNodeKey == "" {
+		fmt.Println("Please specify --nodeKey")
+		os.Exit(1)
+	}
+	if (*ceremonyKeyFile == "") == (*ceremonyHSMModule == "") {
+		fmt.Println("Please specify exactly one of --keyFile and --hsmModule")
+		os.Exit(1)
+	}
+
+	var hsmPin string
+	if *ceremonyHSMModule != "" {
+		var err error
+		if hsmPin, err = readHSMPin(*ceremonyHSMPinFile); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		fmt.Printf("failed to create logger: %v\n", err)
+		os.Exit(1)
+	}
+
+	var report checklist
+	reg, ok := generateGuardianIdentity(logger, &report, hsmPin)
+	if ok {
+		err := reg.verify()
+		if report.check("Verify registration signature", err, "") {
+			err = writeRegistration(reg, *ceremonyRegistration)
+			report.check("Write registration payload", err, *ceremonyRegistration)
+			if err == nil {
+				printGuardianSetEntry(reg)
+			}
+		}
+	}
+
+	checkBootstrapConnectivity(logger, &report)
+
+	if *ceremonyHSMModule != "" {
+		report.add("Back up the guardian key", checklistTodo, "the key is not extractable, use the HSM's own backup or replication procedure")
+		report.add("Set up signing with the HSM key", checklistTodo, "guardiand only loads key files, so the node needs a signer that can use the HSM key")
+	} else {
+		report.add("Back up the guardian key", checklistTodo, "store an offline backup according to your key management policy")
+	}
+	report.add("Submit the registration payload", checklistTodo, "send it to the existing guardians for inclusion in a guardian set update")
+	report.add("Open the P2P port", checklistTodo, "the guardian P2P port (8999/udp by default) must be reachable from the other guardians")
+
+	failed := false
+	for _, item := range report {
+		fmt.Printf("[%s] %s", item.Status, item.Step)
+		if item.Detail != "" {
+			fmt.Printf(": %s", item.Detail)
+		}
+		fmt.Println()
+		failed = failed || item.Status == checklistFail
+	}
+
+	if *ceremonyReport != "" {
+		b, err := json.MarshalIndent(report, "", "  ")
+		if err == nil {
+			err = os.WriteFile(*ceremonyReport, b, 0600)
+		}
+		if err != nil {
+			fmt.Printf("failed to write report: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// generateGuardianIdentity generates the guardian and node keys and returns the signed registration payload.
+func generateGuardianIdentity(logger *zap.Logger, report *checklist, hsmPin string) (*guardianRegistration, bool) {
+	var pub *ecdsa.PublicKey
+	var sign func(digest []byte) ([]byte, error)
+	var keyStorage string
+
+	if *ceremonyHSMModule != "" {
+		key, err := generateHSMGuardianKey(*ceremonyHSMModule, *ceremonyHSMToken, hsmPin, *ceremonyHSMKeyLabel)
+		if !report.check("Generate guardian key in HSM", err, fmt.Sprintf(`token "%s", key "%s"`, *ceremonyHSMToken, *ceremonyHSMKeyLabel)) {
+			return nil, false
+		}
+		defer key.Close()
+		pub, sign, keyStorage = key.pub, key.Sign, "hsm"
+	} else {
+		gk, err := ecdsa.GenerateKey(ethcrypto.S256(), rand.Reader)
+		if err == nil {
+			err = common.WriteArmoredKey(gk, *ceremonyName, *ceremonyKeyFile, common.GuardianKeyArmoredBlock, false)
+		}
+		if !report.check("Generate guardian key file", err, *ceremonyKeyFile) {
+			return nil, false
+		}
+		pub, keyStorage = &gk.PublicKey, "file"
+		sign = func(digest []byte) ([]byte, error) { return ethcrypto.Sign(digest, gk) }
+	}
+
+	priv, err := common.GetOrCreateNodeKey(logger, *ceremonyNodeKey)
+	if !report.check("Load or generate P2P node key", err, *ceremonyNodeKey) {
+		return nil, false
+	}
+	peerID, err := peer.IDFromPrivateKey(priv)
+	if !report.check("Derive P2P peer ID", err, peerID.String()) {
+		return nil, false
+	}
+
+	reg := &guardianRegistration{
+		Name:            *ceremonyName,
+		GuardianAddress: ethcrypto.PubkeyToAddress(*pub).Hex(),
+		PublicKey:       hex.EncodeToString(ethcrypto.FromECDSAPub(pub)),
+		P2PPeerID:       peerID.String(),
+		KeyStorage:      keyStorage,
+	}
+	digest := reg.registrationDigest()
+	sig, err := sign(digest.Bytes())
+	if !report.check("Sign registration payload", err, reg.GuardianAddress) {
+		return nil, false
+	}
+	reg.Signature = hex.EncodeToString(sig)
+	return reg, true
+}
+
+func writeRegistration(reg *guardianRegistration, path string) error {
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		return errors.New("refusing to overwrite existing registration payload")
+	}
+	b, err := json.MarshalIndent(reg, "", "  ")
+	if err != nil {
+		return err
+	}
+	// The payload is public, but it is only handed out deliberately, so it is not left readable by other users of the machine.
+	return os.WriteFile(path, b, 0600)
+}
+
+// readHSMPin returns the HSM user PIN from pinFile if it is set, from the ceremonyHSMPinEnv environment variable, or else by prompting
+// for it on the terminal.
+func readHSMPin(pinFile string) (string, error) {
+	if pinFile != "" {
+		b, err := os.ReadFile(pinFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read the HSM PIN file: %w", err)
+		}
+		return strings.TrimRight(string(b), "\r\n"), nil
+	}
+	if pin, ok := os.LookupEnv(ceremonyHSMPinEnv); ok {
+		return pin, nil
+	}
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", fmt.Errorf("no HSM PIN given, please specify --hsmPinFile or set %s", ceremonyHSMPinEnv)
+	}
+	fmt.Fprint(os.Stderr, "HSM user PIN: ")
+	pin, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the HSM PIN: %w", err)
+	}
+	return string(pin), nil
+}
+
+// printGuardianSetEntry prints the entry for the new guardian in the format used by the guardian set update template.
+func printGuardianSetEntry(reg *guardianRegistration) {
+	b, err := prototext.MarshalOptions{Multiline: true, EmitASCII: true}.Marshal(&nodev1.GuardianSetUpdate_Guardian{
+		Pubkey: reg.GuardianAddress,
+		Name:   reg.Name,
+	})
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("Guardian set update entry:\nguardians {\n%s}\n\n", b)
+}
+
+// checkBootstrapConnectivity dials each bootstrap peer from a temporary host. A successful connection also proves that the peer
+// holds the key for the peer ID in its multiaddr, since the TLS handshake authenticates it.
+func checkBootstrapConnectivity(logger *zap.Logger, report *checklist) {
+	if *ceremonyBootstrap == "" {
+		report.add("Connect to bootstrap peers", checklistTodo, "no bootstrap peers specified")
+		return
+	}
+
+	// Use a throwaway identity so that the check doesn't depend on the node key being allowed on the network yet.
+	priv, _, err := crypto.GenerateKeyPair(crypto.Ed25519, -1)
+	if err != nil {
+		report.check("Connect to bootstrap peers", err, "")
+		return
+	}
+	h, err := libp2p.New(
+		libp2p.Identity(priv),
+		libp2p.NoListenAddrs,
+		libp2p.Security(libp2ptls.ID, libp2ptls.New),
+		libp2p.Transport(libp2pquic.NewTransport),
+	)
+	if err != nil {
+		report.check("Connect to bootstrap peers", err, "")
+		return
+	}
+	defer h.Close()
+
+	bootstrappers, _ := p2p.BootstrapAddrs(logger, *ceremonyBootstrap, h.ID())
+	if len(bootstrappers) == 0 {
+		report.add("Connect to bootstrap peers", checklistFail, "no valid bootstrap addresses")
+		return
+	}
+	for _, pi := range bootstrappers {
+		ctx, cancel := context.WithTimeout(context.Background(), *ceremonyConnectTimeout)
+		err := h.Connect(ctx, pi)
+		cancel()
+		report.check(fmt.Sprintf("Connect to bootstrap peer %s", pi.ID), err, "")
+	}
+}
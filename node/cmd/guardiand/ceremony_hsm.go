@@ -0,0 +1,176 @@
+package guardiand
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/miekg/pkcs11"
+)
+
+// secp256k1OID is the DER encoded object identifier of the secp256k1 curve (1.3.132.0.10), used as CKA_EC_PARAMS.
+var secp256k1OID = []byte{0x06, 0x05, 0x2b, 0x81, 0x04, 0x00, 0x0a}
+
+// hsmGuardianKey is a guardian key generated inside an HSM via PKCS#11. The private key is marked as sensitive and
+// non-extractable, so it never leaves the device.
+type hsmGuardianKey struct {
+	ctx     *pkcs11.Ctx
+	session pkcs11.SessionHandle
+	priv    pkcs11.ObjectHandle
+	pub     *ecdsa.PublicKey
+}
+
+// generateHSMGuardianKey generates a new secp256k1 key pair labeled keyLabel on the token labeled tokenLabel.
+// The returned key must be closed by the caller.
+func generateHSMGuardianKey(modulePath, tokenLabel, pin, keyLabel string) (*hsmGuardianKey, error) {
+	ctx := pkcs11.New(modulePath)
+	if ctx == nil {
+		return nil, fmt.Errorf(`failed to load PKCS#11 module "%s"`, modulePath)
+	}
+	if err := ctx.Initialize(); err != nil {
+		ctx.Destroy()
+		return nil, fmt.Errorf("failed to initialize PKCS#11 module: %w", err)
+	}
+
+	k := &hsmGuardianKey{ctx: ctx}
+	if err := k.open(tokenLabel, pin); err != nil {
+		k.Close()
+		return nil, err
+	}
+
+	pubTemplate := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PUBLIC_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_EC),
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_VERIFY, true),
+		pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, secp256k1OID),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, keyLabel),
+	}
+	privTemplate := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PRIVATE_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_EC),
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, true),
+		pkcs11.NewAttribute(pkcs11.CKA_SIGN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, true),
+		pkcs11.NewAttribute(pkcs11.CKA_EXTRACTABLE, false),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, keyLabel),
+	}
+	pubHandle, privHandle, err := ctx.GenerateKeyPair(k.session,
+		[]*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_EC_KEY_PAIR_GEN, nil)}, pubTemplate, privTemplate)
+	if err != nil {
+		k.Close()
+		return nil, fmt.Errorf("failed to generate key pair: %w", err)
+	}
+	k.priv = privHandle
+
+	attrs, err := ctx.GetAttributeValue(k.session, pubHandle, []*pkcs11.Attribute{pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil)})
+	if err != nil {
+		k.Close()
+		return nil, fmt.Errorf("failed to read public key: %w", err)
+	}
+	if len(attrs) != 1 {
+		k.Close()
+		return nil, errors.New("failed to read public key: no EC point returned")
+	}
+	k.pub, err = parseECPoint(attrs[0].Value)
+	if err != nil {
+		k.Close()
+		return nil, err
+	}
+	return k, nil
+}
+
+// open logs into the token with the given label.
+func (k *hsmGuardianKey) open(tokenLabel, pin string) error {
+	slots, err := k.ctx.GetSlotList(true)
+	if err != nil {
+		return fmt.Errorf("failed to list HSM slots: %w", err)
+	}
+	for _, slot := range slots {
+		info, err := k.ctx.GetTokenInfo(slot)
+		if err != nil || info.Label != tokenLabel {
+			continue
+		}
+		k.session, err = k.ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
+		if err != nil {
+			return fmt.Errorf("failed to open HSM session: %w", err)
+		}
+		if err := k.ctx.Login(k.session, pkcs11.CKU_USER, pin); err != nil {
+			return fmt.Errorf("failed to log into HSM token: %w", err)
+		}
+		return nil
+	}
+	return fmt.Errorf(`no HSM token with label "%s"`, tokenLabel)
+}
+
+// Sign signs a digest and returns the signature in the 65 byte [R || S || V] format used for guardian signatures.
+func (k *hsmGuardianKey) Sign(digest []byte) ([]byte, error) {
+	if err := k.ctx.SignInit(k.session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_ECDSA, nil)}, k.priv); err != nil {
+		return nil, fmt.Errorf("failed to initialize signing: %w", err)
+	}
+	rs, err := k.ctx.Sign(k.session, digest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign: %w", err)
+	}
+	return recoverableSignature(digest, rs, k.pub)
+}
+
+// Close logs out of the token and unloads the module.
+func (k *hsmGuardianKey) Close() {
+	if k.session != 0 {
+		_ = k.ctx.Logout(k.session)
+		_ = k.ctx.CloseSession(k.session)
+	}
+	_ = k.ctx.Finalize()
+	k.ctx.Destroy()
+}
+
+// parseECPoint decodes the CKA_EC_POINT of a secp256k1 public key. The standard requires the uncompressed point to be
+// wrapped in a DER octet string, but some modules return the raw point, so both are accepted.
+func parseECPoint(b []byte) (*ecdsa.PublicKey, error) {
+	// A raw point also starts with the octet string tag, and may happen to parse as one, so it is recognized by its length.
+	point := b
+	var unwrapped []byte
+	if len(b) != 65 {
+		if rest, err := asn1.Unmarshal(b, &unwrapped); err == nil && len(rest) == 0 {
+			point = unwrapped
+		}
+	}
+	pub, err := ethcrypto.UnmarshalPubkey(point)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key returned by HSM: %w", err)
+	}
+	return pub, nil
+}
+
+// recoverableSignature converts a raw [R || S] ECDSA signature into the [R || S || V] format expected by ecrecover.
+// S is normalized to the lower half of the curve order, since HSMs don't do that, and V is found by trial recovery.
+func recoverableSignature(digest []byte, rs []byte, pub *ecdsa.PublicKey) ([]byte, error) {
+	if len(rs) != 64 {
+		return nil, fmt.Errorf("unexpected signature length %d", len(rs))
+	}
+	n := ethcrypto.S256().Params().N
+	s := new(big.Int).SetBytes(rs[32:])
+	if s.Cmp(new(big.Int).Rsh(n, 1)) > 0 {
+		s.Sub(n, s)
+	}
+
+	sig := make([]byte, 65)
+	copy(sig[:32], rs[:32])
+	s.FillBytes(sig[32:64])
+
+	want := ethcrypto.FromECDSAPub(pub)
+	for v := byte(0); v < 2; v++ {
+		sig[64] = v
+		recovered, err := ethcrypto.Ecrecover(digest, sig)
+		if err == nil && bytes.Equal(recovered, want) {
+			return sig, nil
+		}
+	}
+	return nil, errors.New("signature does not match the public key")
+}
@@ -0,0 +1,118 @@
+package guardiand
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/asn1"
+	"encoding/hex"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoverableSignature(t *testing.T) {
+	gk, err := ecdsa.GenerateKey(ethcrypto.S256(), rand.Reader)
+	require.NoError(t, err)
+	digest := ethcrypto.Keccak256([]byte("hello"))
+	n := ethcrypto.S256().Params().N
+
+	// Simulate an HSM, which returns [R || S] with no guarantee that S is low.
+	sig, err := ethcrypto.Sign(digest, gk)
+	require.NoError(t, err)
+	for _, s := range []*big.Int{new(big.Int).SetBytes(sig[32:64]), new(big.Int).Sub(n, new(big.Int).SetBytes(sig[32:64]))} {
+		rs := make([]byte, 64)
+		copy(rs, sig[:32])
+		s.FillBytes(rs[32:])
+
+		converted, err := recoverableSignature(digest, rs, &gk.PublicKey)
+		require.NoError(t, err)
+		assert.Equal(t, sig, converted)
+	}
+
+	other, err := ecdsa.GenerateKey(ethcrypto.S256(), rand.Reader)
+	require.NoError(t, err)
+	_, err = recoverableSignature(digest, sig[:64], &other.PublicKey)
+	assert.Error(t, err)
+
+	_, err = recoverableSignature(digest, sig, &gk.PublicKey)
+	assert.Error(t, err)
+}
+
+func TestParseECPoint(t *testing.T) {
+	gk, err := ecdsa.GenerateKey(ethcrypto.S256(), rand.Reader)
+	require.NoError(t, err)
+	point := ethcrypto.FromECDSAPub(&gk.PublicKey)
+
+	wrapped, err := asn1.Marshal(point)
+	require.NoError(t, err)
+	for _, b := range [][]byte{point, wrapped} {
+		pub, err := parseECPoint(b)
+		require.NoError(t, err)
+		assert.Equal(t, ethcrypto.PubkeyToAddress(gk.PublicKey), ethcrypto.PubkeyToAddress(*pub))
+	}
+
+	_, err = parseECPoint([]byte{0x04, 0x01})
+	assert.Error(t, err)
+
+	// A raw point whose X coordinate starts with its own remaining length is a valid octet string, but must not be unwrapped.
+	for point[1] != 63 {
+		gk, err = ecdsa.GenerateKey(ethcrypto.S256(), rand.Reader)
+		require.NoError(t, err)
+		point = ethcrypto.FromECDSAPub(&gk.PublicKey)
+	}
+	pub, err := parseECPoint(point)
+	require.NoError(t, err)
+	assert.Equal(t, ethcrypto.PubkeyToAddress(gk.PublicKey), ethcrypto.PubkeyToAddress(*pub))
+}
+
+func TestGuardianRegistrationVerify(t *testing.T) {
+	gk, err := ecdsa.GenerateKey(ethcrypto.S256(), rand.Reader)
+	require.NoError(t, err)
+	reg := &guardianRegistration{
+		Name:            "Test Guardian",
+		GuardianAddress: ethcrypto.PubkeyToAddress(gk.PublicKey).Hex(),
+		P2PPeerID:       "12D3KooWHHzSeKaY8xuZVzkLbKFfvNgPPeKhFBGrMbNzbm5akpqu",
+	}
+	digest := reg.registrationDigest()
+	sig, err := ethcrypto.Sign(digest.Bytes(), gk)
+	require.NoError(t, err)
+	reg.Signature = hex.EncodeToString(sig)
+	require.NoError(t, reg.verify())
+
+	// The signature binds the address to the name and the peer ID.
+	reg.Name = "Someone Else"
+	assert.Error(t, reg.verify())
+}
+
+func TestWriteRegistration(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registration.json")
+	reg := &guardianRegistration{Name: "Test Guardian"}
+	require.NoError(t, writeRegistration(reg, path))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	assert.ErrorContains(t, writeRegistration(reg, path), "refusing to overwrite")
+}
+
+func TestReadHSMPin(t *testing.T) {
+	// The PIN file takes precedence over the environment, and its trailing newline is not part of the PIN.
+	t.Setenv(ceremonyHSMPinEnv, "from-env")
+	pinFile := filepath.Join(t.TempDir(), "pin")
+	require.NoError(t, os.WriteFile(pinFile, []byte("from-file\n"), 0600))
+	pin, err := readHSMPin(pinFile)
+	require.NoError(t, err)
+	assert.Equal(t, "from-file", pin)
+
+	pin, err = readHSMPin("")
+	require.NoError(t, err)
+	assert.Equal(t, "from-env", pin)
+
+	_, err = readHSMPin(filepath.Join(t.TempDir(), "missing"))
+	assert.ErrorContains(t, err, "failed to read the HSM PIN file")
+}
@@ -0,0 +1,393 @@
+package guardiand
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	"github.com/certusone/wormhole/node/pkg/dbbackup"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+var (
+	dbSnapshotDataDir      *string
+	dbSnapshotBackend      *string
+	dbSnapshotStateDb      *bool
+	dbExportOutput         *string
+	dbExportEmitterChain   *uint16
+	dbExportEmitterAddress *string
+	dbExportFrom           *string
+	dbExportTo             *string
+	dbImportInput          *string
+	dbImportVerifyOnly     *bool
+	dbRestoreInput         *string
+	dbRestoreInputAuth     *string
+	dbRestoreInputRegion   *string
+	dbMigrateDryRun        *bool
+	dbVerifyGuardianSets   *string
+	dbVerifyRepair         *bool
+)
+
+func init() {
+	dbFlags := pflag.NewFlagSet("dbFlags", pflag.ContinueOnError)
+	dbSnapshotDataDir = dbFlags.String("dataDir", "", "Data directory of the guardian (required)")
+	dbSnapshotBackend = dbFlags.String("dbBackend", string(db.BackendBadger), fmt.Sprintf("Storage engine of the database, one of %v", db.Backends))
+	DBExportCmd.Flags().AddFlagSet(dbFlags)
+	DBImportCmd.Flags().AddFlagSet(dbFlags)
+
+	// The state database has no VAAs to export or import, but is restored, migrated and verified like the main database.
+	stateDbFlags := pflag.NewFlagSet("stateDbFlags", pflag.ContinueOnError)
+	dbSnapshotStateDb = stateDbFlags.Bool("stateDb", false, "Use the governor and accountant state database instead of the main database")
+	for _, cmd := range []*cobra.Command{DBRestoreCmd, DBMigrateCmd, DBVerifyCmd} {
+		cmd.Flags().AddFlagSet(dbFlags)
+		cmd.Flags().AddFlagSet(stateDbFlags)
+	}
+
+	dbExportOutput = DBExportCmd.Flags().String("output", "", "Path to write the snapshot to (required)")
+	dbExportEmitterChain = DBExportCmd.Flags().Uint16("emitterChain", 0, "Only export VAAs emitted on this chain ID")
+	dbExportEmitterAddress = DBExportCmd.Flags().String("emitterAddress", "", "Only export VAAs from this emitter address (hex), requires --emitterChain")
+	dbExportFrom = DBExportCmd.Flags().String("from", "", "Only export VAAs with a timestamp at or after this time (RFC 3339)")
+	dbExportTo = DBExportCmd.Flags().String("to", "", "Only export VAAs with a timestamp before this time (RFC 3339)")
+	dbImportInput = DBImportCmd.Flags().String("input", "", "Path of the snapshot to import (required)")
+	dbImportVerifyOnly = DBImportCmd.Flags().Bool("verifyOnly", false, "Only check that the snapshot is complete and valid, without importing it")
+	dbMigrateDryRun = DBMigrateCmd.Flags().Bool("dryRun", false, "Only report the pending migrations and how many keys they would write, without applying them")
+	dbVerifyGuardianSets = DBVerifyCmd.Flags().String("guardianSets", "", `JSON file of the guardian sets to verify the signatures of the VAAs against, e.g. [{"index": 0, "keys": ["0x..."]}] (signatures are not verified if blank)`)
+	dbVerifyRepair = DBVerifyCmd.Flags().Bool("repair", false, "Delete corrupt VAAs and VAAs with invalid signatures, move misplaced VAAs and fix the indexes")
+	dbRestoreInput = DBRestoreCmd.Flags().String("input", "", "Path or http(s) URL of the backup to restore (required)")
+	dbRestoreInputAuth = DBRestoreCmd.Flags().String("inputAuth", "", "Authenticate the download of an --input URL for this storage backend, s3 or gcs (optional)")
+	dbRestoreInputRegion = DBRestoreCmd.Flags().String("inputRegion", "", "Region of the S3 bucket the backup is downloaded from, required with --inputAuth s3")
+
+	DBCmd.AddCommand(DBExportCmd)
+	DBCmd.AddCommand(DBImportCmd)
+	DBCmd.AddCommand(DBRestoreCmd)
+	DBCmd.AddCommand(DBMigrateCmd)
+	DBCmd.AddCommand(DBVerifyCmd)
+}
+
+var DBCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Export, import, back up, migrate and verify the database of a stopped guardian",
+}
+
+var DBExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Write all or a filtered range of the signed VAAs to a compressed, checksummed snapshot file",
+	Run:   runDBExport,
+	Args:  cobra.NoArgs,
+}
+
+var DBImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Verify a snapshot file and store the signed VAAs in it that are missing from the database",
+	Run:   runDBImport,
+	Args:  cobra.NoArgs,
+}
+
+var DBRestoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Verify a backup taken with --dbBackupInterval and restore it into an empty database",
+	Run:   runDBRestore,
+	Args:  cobra.NoArgs,
+}
+
+var DBMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Apply the pending schema migrations to the database, which guardiand otherwise does when it starts",
+	Run:   runDBMigrate,
+	Args:  cobra.NoArgs,
+}
+
+var DBVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check the signed VAAs and the indexes of the database, optionally repairing the problems found",
+	Run:   runDBVerify,
+	Args:  cobra.NoArgs,
+}
+
+func parseDBExportFilter() (db.VAAFilter, error) {
+	filter := db.VAAFilter{EmitterChain: vaa.ChainID(*dbExportEmitterChain)}
+	if *dbExportEmitterAddress != "" {
+		if *dbExportEmitterChain == 0 {
+			return filter, fmt.Errorf("--emitterAddress requires --emitterChain")
+		}
+		b, err := hex.DecodeString(*dbExportEmitterAddress)
+		if err != nil || len(b) > len(filter.EmitterAddress) {
+			return filter, fmt.Errorf("invalid --emitterAddress %s", *dbExportEmitterAddress)
+		}
+		copy(filter.EmitterAddress[len(filter.EmitterAddress)-len(b):], b)
+	}
+	var err error
+	if *dbExportFrom != "" {
+		if filter.From, err = time.Parse(time.RFC3339, *dbExportFrom); err != nil {
+			return filter, fmt.Errorf("invalid --from: %w", err)
+		}
+	}
+	if *dbExportTo != "" {
+		if filter.To, err = time.Parse(time.RFC3339, *dbExportTo); err != nil {
+			return filter, fmt.Errorf("invalid --to: %w", err)
+		}
+	}
+	return filter, nil
+}
+
+// openSnapshotDb opens the database of a guardian, which must not be running. Unless skipMigrations is set, the database is
+// migrated like when the guardian starts.
+func openSnapshotDb(skipMigrations bool) *db.Database {
+	if *dbSnapshotDataDir == "" {
+		log.Fatal("--dataDir is required")
+	}
+	if _, err := os.Stat(*dbSnapshotDataDir); err != nil {
+		log.Fatalf("invalid --dataDir: %v", err)
+	}
+	backend, err := db.ParseBackend(*dbSnapshotBackend)
+	if err != nil {
+		log.Fatalf("invalid --dbBackend: %v", err)
+	}
+	// Badger logs its housekeeping at debug and info level, which would drown out the output of the command.
+	cfg := zap.NewDevelopmentConfig()
+	cfg.Level = zap.NewAtomicLevelAt(zap.WarnLevel)
+	logger, err := cfg.Build()
+	if err != nil {
+		log.Fatalf("failed to create logger: %v", err)
+	}
+	opts := db.DefaultOptions()
+	opts.Backend = backend
+	opts.SkipMigrations = skipMigrations
+	if *dbSnapshotStateDb {
+		opts.Name = db.StateDatabase
+	}
+	return db.OpenDb(logger, dbSnapshotDataDir, opts)
+}
+
+func runDBExport(cmd *cobra.Command, args []string) {
+	if *dbExportOutput == "" {
+		log.Fatal("--output is required")
+	}
+	filter, err := parseDBExportFilter()
+	if err != nil {
+		log.Fatal(err)
+	}
+	database := openSnapshotDb(false)
+	defer database.Close()
+
+	// The snapshot is written to a temporary file and only given its name once it is complete, so an interrupted export never
+	// leaves a truncated snapshot behind.
+	f, err := os.CreateTemp(filepath.Dir(*dbExportOutput), ".tmp-"+filepath.Base(*dbExportOutput))
+	if err != nil {
+		log.Fatalf("failed to create snapshot: %v", err)
+	}
+	defer os.Remove(f.Name())
+	bw := bufio.NewWriter(f)
+	count, err := database.ExportVAASnapshot(bw, filter)
+	if err != nil {
+		log.Fatalf("failed to export VAAs: %v", err)
+	}
+	if err := bw.Flush(); err != nil {
+		log.Fatalf("failed to write snapshot: %v", err)
+	}
+	if err := f.Sync(); err != nil {
+		log.Fatalf("failed to write snapshot: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		log.Fatalf("failed to write snapshot: %v", err)
+	}
+	if err := os.Rename(f.Name(), *dbExportOutput); err != nil {
+		log.Fatalf("failed to write snapshot: %v", err)
+	}
+	fmt.Printf("exported %d VAAs to %s\n", count, *dbExportOutput)
+}
+
+func runDBImport(cmd *cobra.Command, args []string) {
+	if *dbImportInput == "" {
+		log.Fatal("--input is required")
+	}
+
+	// The checksum is at the end of the snapshot, so it is read in full before anything is imported.
+	f, err := os.Open(*dbImportInput)
+	if err != nil {
+		log.Fatalf("failed to open snapshot: %v", err)
+	}
+	defer f.Close()
+	count, err := db.VerifyVAASnapshot(f)
+	if err != nil {
+		log.Fatalf("invalid snapshot: %v", err)
+	}
+	fmt.Printf("snapshot contains %d valid VAAs\n", count)
+	if *dbImportVerifyOnly {
+		return
+	}
+
+	if _, err := f.Seek(0, 0); err != nil {
+		log.Fatalf("failed to rewind snapshot: %v", err)
+	}
+	database := openSnapshotDb(false)
+	defer database.Close()
+	res, err := database.ImportVAASnapshot(f)
+	if err != nil {
+		log.Fatalf("failed to import VAAs: %v", err)
+	}
+	fmt.Printf("imported %d VAAs, %d were already in the database\n", res.Imported, res.Existing)
+}
+
+func runDBRestore(cmd *cobra.Command, args []string) {
+	if *dbRestoreInput == "" {
+		log.Fatal("--input is required")
+	}
+
+	path := *dbRestoreInput
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		f, err := os.CreateTemp("", "guardiand-db-backup-")
+		if err != nil {
+			log.Fatalf("failed to create temporary file: %v", err)
+		}
+		defer os.Remove(f.Name())
+		var signer dbbackup.Signer
+		if *dbRestoreInputAuth != "" {
+			signer, err = dbbackup.NewSigner(context.Background(), *dbRestoreInputAuth, *dbRestoreInputRegion)
+			if err != nil {
+				log.Fatalf("invalid --inputAuth: %v", err)
+			}
+		}
+		if err := dbbackup.Download(context.Background(), path, signer, f); err != nil {
+			log.Fatalf("failed to download backup: %v", err)
+		}
+		if err := f.Close(); err != nil {
+			log.Fatalf("failed to download backup: %v", err)
+		}
+		path = f.Name()
+	}
+
+	// A backup which turns out to be corrupted halfway through would leave a partially restored database, so it is read in full
+	// before anything is restored.
+	f, err := os.Open(path)
+	if err != nil {
+		log.Fatalf("failed to open backup: %v", err)
+	}
+	defer f.Close()
+	if err := dbbackup.VerifyBackup(f); err != nil {
+		log.Fatalf("invalid backup: %v", err)
+	}
+	if _, err := f.Seek(0, 0); err != nil {
+		log.Fatalf("failed to rewind backup: %v", err)
+	}
+
+	database := openSnapshotDb(false)
+	defer database.Close()
+	if !database.SupportsBackup() {
+		log.Fatal(db.ErrBackupUnsupported)
+	}
+	if err := dbbackup.RestoreBackup(database, f); err != nil {
+		log.Fatalf("failed to restore backup: %v", err)
+	}
+	fmt.Printf("restored %s into %s\n", *dbRestoreInput, *dbSnapshotDataDir)
+}
+
+func runDBMigrate(cmd *cobra.Command, args []string) {
+	database := openSnapshotDb(true)
+	defer database.Close()
+	version, err := database.SchemaVersion()
+	if err != nil {
+		log.Fatalf("failed to read the schema version: %v", err)
+	}
+	fmt.Printf("database is at schema version %d, the latest is %d\n", version, db.LatestSchemaVersion())
+
+	results, err := database.Migrate(zap.NewNop(), *dbMigrateDryRun)
+	for _, r := range results {
+		if *dbMigrateDryRun {
+			fmt.Printf("would apply migration %d (%s), writing %d keys\n", r.Version, r.Description, r.Writes)
+		} else {
+			fmt.Printf("applied migration %d (%s), writing %d keys\n", r.Version, r.Description, r.Writes)
+		}
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// loadGuardianSets reads a JSON array of guardian sets, each with its index and the hex addresses of its keys.
+func loadGuardianSets(path string) (map[uint32]*common.GuardianSet, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var sets []struct {
+		Index uint32   `json:"index"`
+		Keys  []string `json:"keys"`
+	}
+	if err := json.Unmarshal(b, &sets); err != nil {
+		return nil, err
+	}
+	ret := make(map[uint32]*common.GuardianSet, len(sets))
+	for _, s := range sets {
+		if _, exists := ret[s.Index]; exists {
+			return nil, fmt.Errorf("duplicate guardian set %d", s.Index)
+		}
+		if len(s.Keys) == 0 {
+			return nil, fmt.Errorf("guardian set %d has no keys", s.Index)
+		}
+		gs := &common.GuardianSet{Index: s.Index}
+		for _, key := range s.Keys {
+			if !ethcommon.IsHexAddress(key) {
+				return nil, fmt.Errorf("invalid key %q in guardian set %d", key, s.Index)
+			}
+			gs.Keys = append(gs.Keys, ethcommon.HexToAddress(key))
+		}
+		ret[s.Index] = gs
+	}
+	return ret, nil
+}
+
+func runDBVerify(cmd *cobra.Command, args []string) {
+	opts := db.VerifyOptions{Repair: *dbVerifyRepair}
+	if *dbVerifyGuardianSets != "" {
+		var err error
+		if opts.GuardianSets, err = loadGuardianSets(*dbVerifyGuardianSets); err != nil {
+			log.Fatalf("invalid --guardianSets: %v", err)
+		}
+	}
+	database := openSnapshotDb(false)
+	defer database.Close()
+
+	report, err := database.Verify(opts, func(key []byte, problem db.VerifyProblem, detail string) {
+		if detail != "" {
+			fmt.Printf("%s: %s (%s)\n", key, problem, detail)
+		} else {
+			fmt.Printf("%s: %s\n", key, problem)
+		}
+	})
+	if err != nil {
+		log.Fatalf("failed to verify database: %v", err)
+	}
+
+	fmt.Printf("checked %d VAAs", report.VAAs)
+	if opts.GuardianSets == nil {
+		fmt.Print(", without verifying their signatures")
+	}
+	fmt.Println()
+	problems := 0
+	for _, p := range []db.VerifyProblem{db.ProblemCorruptVAA, db.ProblemMisplacedVAA, db.ProblemInvalidSignatures, db.ProblemUnknownGuardianSet, db.ProblemMissingIndexEntry, db.ProblemDanglingIndexEntry} {
+		if n := report.Problems[p]; n != 0 {
+			fmt.Printf("%s: %d\n", p, n)
+			problems += n
+		}
+	}
+	if *dbVerifyRepair {
+		fmt.Printf("repaired %d of %d problems\n", report.Repaired, problems)
+	}
+	if problems > report.Repaired {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,115 @@
+package guardiand
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/governor"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+)
+
+var (
+	governorConfigGuardianKey   *string
+	governorConfigSignerIndex   *int
+	governorConfigUnsafeDevMode *bool
+	governorConfigVerifySigners *string
+)
+
+func init() {
+	governorConfigGuardianKey = GovernorConfigSignCmd.Flags().String("guardianKey", "", "Path to the key to sign the config with (required)")
+	governorConfigSignerIndex = GovernorConfigSignCmd.Flags().Int("index", -1, "Index of the key in the list of config signers (required)")
+	governorConfigUnsafeDevMode = GovernorConfigSignCmd.Flags().Bool("unsafeDevMode", false, "Accept a devnet guardian key")
+	governorConfigVerifySigners = GovernorConfigVerifyCmd.Flags().String("signers", "", "Comma separated addresses of the config signers (required)")
+
+	GovernorConfigCmd.AddCommand(GovernorConfigSignCmd)
+	GovernorConfigCmd.AddCommand(GovernorConfigVerifyCmd)
+}
+
+var GovernorConfigCmd = &cobra.Command{
+	Use:   "governor-config",
+	Short: "Sign and verify token and chain config files for the chain governor",
+}
+
+var GovernorConfigSignCmd = &cobra.Command{
+	Use:   "sign [FILE]",
+	Short: "Add a signature to a governor config file in place. A file containing just the config is turned into a signed config file.",
+	Run:   runGovernorConfigSign,
+	Args:  cobra.ExactArgs(1),
+}
+
+var GovernorConfigVerifyCmd = &cobra.Command{
+	Use:   "verify [FILE]",
+	Short: "Check that a governor config file is valid and signed by a quorum of the signers",
+	Run:   runGovernorConfigVerify,
+	Args:  cobra.ExactArgs(1),
+}
+
+func runGovernorConfigSign(cmd *cobra.Command, args []string) {
+	if *governorConfigGuardianKey == "" || *governorConfigSignerIndex < 0 {
+		log.Fatal("--guardianKey and --index are required")
+	}
+	gk, err := common.LoadGuardianKey(*governorConfigGuardianKey, *governorConfigUnsafeDevMode)
+	if err != nil {
+		log.Fatalf("failed to load guardian key: %v", err)
+	}
+
+	b, err := os.ReadFile(args[0])
+	if err != nil {
+		log.Fatalf("failed to read config file: %v", err)
+	}
+	f, err := readSignedGovernorConfig(b)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := f.Sign(*governorConfigSignerIndex, gk); err != nil {
+		log.Fatalf("failed to sign config: %v", err)
+	}
+
+	out, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		log.Fatalf("failed to marshal config file: %v", err)
+	}
+	if err := os.WriteFile(args[0], append(out, '\n'), 0644); err != nil { // #nosec G306 the config file is not secret
+		log.Fatalf("failed to write config file: %v", err)
+	}
+	fmt.Printf("signed %s as %s (index %d), it now has %d signatures\n",
+		args[0], ethcrypto.PubkeyToAddress(gk.PublicKey).Hex(), *governorConfigSignerIndex, len(f.Signatures))
+}
+
+// readSignedGovernorConfig parses a signed config file, or wraps a file containing just the config in an unsigned one.
+func readSignedGovernorConfig(b []byte) (*governor.SignedConfigFile, error) {
+	var f governor.SignedConfigFile
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&f); err == nil && len(f.Config) != 0 {
+		return &f, nil
+	}
+
+	var cfg governor.ConfigFile
+	dec = json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("the file is neither a signed config file nor a config: %w", err)
+	}
+	return &governor.SignedConfigFile{Config: b}, nil
+}
+
+func runGovernorConfigVerify(cmd *cobra.Command, args []string) {
+	if *governorConfigVerifySigners == "" {
+		log.Fatal("--signers is required")
+	}
+	signers, err := governor.ParseConfigSigners(*governorConfigVerifySigners)
+	if err != nil {
+		log.Fatal(err)
+	}
+	cfg, err := governor.ReadConfigFile(args[0], signers)
+	if err != nil {
+		log.Fatalf("invalid config file: %v", err)
+	}
+	fmt.Printf("%s is valid and configures %d tokens on %d chains at version %d\n", args[0], len(cfg.Tokens), len(cfg.Chains), cfg.Version)
+}
@@ -2,33 +2,49 @@ package guardiand
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
 	"fmt"
 	"net"
 	_ "net/http/pprof" // #nosec G108 we are using a custom router (`router := mux.NewRouter()`) and thus not automatically expose pprof.
+	"net/url"
 	"os"
 	"os/signal"
 	"path"
 	"runtime"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/certusone/wormhole/node/pkg/watchers"
+	ethcommon "github.com/ethereum/go-ethereum/common"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/certusone/wormhole/node/pkg/watchers/solana"
 
 	"github.com/certusone/wormhole/node/pkg/db"
+	"github.com/certusone/wormhole/node/pkg/dbbackup"
+	"github.com/certusone/wormhole/node/pkg/dbreplication"
 	"github.com/certusone/wormhole/node/pkg/telemetry"
 	"github.com/certusone/wormhole/node/pkg/version"
 	"github.com/gagliardetto/solana-go/rpc"
 	"go.uber.org/zap/zapcore"
 
+	"github.com/certusone/wormhole/node/pkg/accountant"
+	"github.com/certusone/wormhole/node/pkg/adminrpc"
 	"github.com/certusone/wormhole/node/pkg/common"
 	"github.com/certusone/wormhole/node/pkg/devnet"
+	"github.com/certusone/wormhole/node/pkg/governor"
+	"github.com/certusone/wormhole/node/pkg/gwrelayer"
 	"github.com/certusone/wormhole/node/pkg/node"
 	"github.com/certusone/wormhole/node/pkg/p2p"
+	"github.com/certusone/wormhole/node/pkg/payloads"
+	"github.com/certusone/wormhole/node/pkg/processor"
+	"github.com/certusone/wormhole/node/pkg/publicrpc"
 	"github.com/certusone/wormhole/node/pkg/supervisor"
+	"github.com/certusone/wormhole/node/pkg/supportbundle"
 	promremotew "github.com/certusone/wormhole/node/pkg/telemetry/prom_remote_write"
+	"github.com/certusone/wormhole/node/pkg/vaaarchive"
 	libp2p_crypto "github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/spf13/cobra"
@@ -43,19 +59,45 @@ var (
 	p2pPort      *uint
 	p2pBootstrap *string
 
-	nodeKeyPath *string
+	p2pLatencyProbeInterval *time.Duration
+	p2pAnnounceAddrs        *string
+	ipModeStr               *string
 
-	adminSocketPath      *string
-	publicGRPCSocketPath *string
+	nodeKeyPath *string
 
-	dataDir *string
+	adminSocketPath            *string
+	adminRequireSecondApprover *bool
+	adminAllowVAAPurge         *bool
+	adminListenAddr            *string
+	adminTLSCert               *string
+	adminTLSKey                *string
+	adminTLSClients            *string
+	adminAuditLog              *string
+	publicGRPCSocketPath       *string
+
+	dataDir           *string
+	inMemoryDb        *bool
+	dbBackend         *string
+	dbGCInterval      *time.Duration
+	dbGCDiscardRatio  *float64
+	dbNumCompactors   *int
+	dbMetricsInterval *time.Duration
+	dbRetention       *string
+
+	dbReplicationListenAddr   *string
+	dbReplicationCertFile     *string
+	dbReplicationKeyFile      *string
+	dbReplicationClientCAFile *string
 
 	statusAddr *string
 
 	guardianKeyPath *string
 	solanaContract  *string
 
-	solanaRPC *string
+	solanaRPC         *string
+	solanaGeyser      *string
+	solanaGeyserToken *string
+	solanaCommitments *string
 
 	logLevel                *string
 	publicRpcLogDetailStr   *string
@@ -68,6 +110,13 @@ var (
 	publicRPC *string
 	publicWeb *string
 
+	publicRateLimit        *float64
+	publicRateLimitBurst   *int
+	publicRateLimitWeights *map[string]int
+
+	publicRpcSlowRequestThreshold *time.Duration
+	publicRpcReflection           *bool
+
 	tlsHostname *string
 	tlsProdEnv  *bool
 
@@ -81,10 +130,84 @@ var (
 	// Prometheus remote write URL
 	promRemoteURL *string
 
-	chainGovernorEnabled *bool
+	accountantContractURL *string
+	accountantEnforcing   *bool
+
+	nttAccountantContractURL *string
+	nttAccountantEnforcing   *bool
+	nttAccountantEmitters    *string
+
+	accountantAuditInterval    *time.Duration
+	accountantResubmitDelay    *time.Duration
+	accountantResubmitBackoff  *float64
+	accountantResubmitMaxDelay *time.Duration
+	accountantAlarmAge         *time.Duration
+
+	accountantForceReleaseApprovals *int
+
+	gatewayRelayerURL           *string
+	gatewayRelayerContract      *string
+	gatewayRelayerMaxAttempts   *uint
+	gatewayRelayerRetryDelay    *time.Duration
+	gatewayRelayerBackoff       *float64
+	gatewayRelayerMaxRetryDelay *time.Duration
+	gatewayRelayerGasLimit      *uint64
+	gatewayRelayerFee           *uint64
+	gatewayRelayerFeeBump       *float64
+	gatewayRelayerMaxFee        *uint64
+
+	chainGovernorEnabled  *bool
+	governorConfigFile    *string
+	governorConfigSigners *string
+	governorPriceSources  *string
+
+	governorReleaseApprovals         *int
+	governorReleaseApprovalThreshold *uint64
+
+	governorShadowMode *bool
+	governorWebhookURL *string
+
+	observationDelays *string
+	sigVerifyWorkers  *int
+	aggregationShards *int
+
+	experimentalAggregatedSignatures *bool
+
+	replayRecording *string
+
+	vaaRetentionDays      *uint
+	vaaArchiveDir         *string
+	vaaArchiveUploadURL   *string
+	vaaArchiveRestoreHold *time.Duration
+
+	dbBackupInterval  *time.Duration
+	dbBackupDir       *string
+	dbBackupRetention *time.Duration
+	dbBackupUploadURL *string
+
+	dbBackupUploadAuth   *string
+	dbBackupUploadRegion *string
+
+	separateStateDb        *bool
+	stateDbBackupInterval  *time.Duration
+	stateDbBackupDir       *string
+	stateDbBackupRetention *time.Duration
+	stateDbBackupUploadURL *string
+
+	reobservationInitialDelay *time.Duration
+	reobservationBackoff      *float64
+	reobservationMaxAttempts  *uint
+	reobservationOverrides    *string
+
+	nearQuorumAlertThreshold *time.Duration
+	lateObservationWindow    *time.Duration
+	shadowMode               *bool
+
+	payloadDecoders *string
 
 	ccqEnabled           *bool
 	ccqAllowedRequesters *string
+	ccqDailyBudget       *uint64
 	ccqP2pPort           *uint
 	ccqP2pBootstrap      *string
 	ccqAllowedPeers      *string
@@ -95,20 +218,44 @@ func init() {
 	p2pNetworkID = NodeCmd.Flags().String("network", "/wormhole/dev", "P2P network identifier")
 	p2pPort = NodeCmd.Flags().Uint("port", p2p.DefaultPort, "P2P UDP listener port")
 	p2pBootstrap = NodeCmd.Flags().String("bootstrap", "", "P2P bootstrap peers (comma-separated)")
+	p2pLatencyProbeInterval = NodeCmd.Flags().Duration("p2pLatencyProbeInterval", 0, "Interval at which to probe gossip round trip times to other guardians (disabled if zero)")
+	p2pAnnounceAddrs = NodeCmd.Flags().String("p2pAnnounceAddrs", "", "P2P multiaddrs to advertise to peers instead of the listening addresses (comma-separated)")
+	ipModeStr = NodeCmd.Flags().String("ipMode", "dual", "IP versions to listen on for p2p, the status server and the public RPC endpoints (dual, ipv4 or ipv6)")
 
 	statusAddr = NodeCmd.Flags().String("statusAddr", "[::]:6060", "Listen address for status server (disabled if blank)")
 
 	nodeKeyPath = NodeCmd.Flags().String("nodeKey", "", "Path to node key (will be generated if it doesn't exist)")
 
 	adminSocketPath = NodeCmd.Flags().String("adminSocket", "", "Admin gRPC service UNIX domain socket path")
+	adminRequireSecondApprover = NodeCmd.Flags().Bool("adminRequireSecondApprover", false, "Require staged config changes to be applied by someone other than their proposer")
+	adminAllowVAAPurge = NodeCmd.Flags().Bool("adminAllowVAAPurge", false, "Allow signed VAAs to be purged from the database through the admin service, to reset a test network (not allowed on mainnet)")
+	adminListenAddr = NodeCmd.Flags().String("adminListenAddr", "", "Listen address for the admin gRPC service on TCP with mutual TLS (disabled if blank). Requires --adminTLSCert, --adminTLSKey and --adminTLSClients")
+	adminTLSCert = NodeCmd.Flags().String("adminTLSCert", "", "Path to the PEM encoded certificate the admin service presents on --adminListenAddr")
+	adminTLSKey = NodeCmd.Flags().String("adminTLSKey", "", "Path to the PEM encoded key of --adminTLSCert")
+	adminTLSClients = NodeCmd.Flags().String("adminTLSClients", "", "Path to a JSON file listing the client certificate fingerprints allowed on --adminListenAddr and the methods each may call")
+	adminAuditLog = NodeCmd.Flags().String("adminAuditLog", "", "Path to a file every call to the admin service is appended to, in addition to the audit log in the database")
 	publicGRPCSocketPath = NodeCmd.Flags().String("publicGRPCSocket", "", "Public gRPC service UNIX domain socket path")
 
 	dataDir = NodeCmd.Flags().String("dataDir", "", "Data directory")
-
-	guardianKeyPath = NodeCmd.Flags().String("guardianKey", "", "Path to guardian key (required)")
+	dbBackend = NodeCmd.Flags().String("dbBackend", string(db.BackendBadger), fmt.Sprintf("Storage engine of the database, one of %v. Each engine keeps its database in its own directory under --dataDir", db.Backends))
+	inMemoryDb = NodeCmd.Flags().Bool("inMemoryDb", false, "Keep the database in memory instead of in --dataDir. Everything stored in it is lost on shutdown. Only allowed with --unsafeDevMode, --shadowMode or in query-only mode")
+	dbGCInterval = NodeCmd.Flags().Duration("dbGCInterval", db.DefaultBadgerOptions().GCInterval, "How often the Badger value log is garbage collected (disabled if zero)")
+	dbGCDiscardRatio = NodeCmd.Flags().Float64("dbGCDiscardRatio", db.DefaultBadgerOptions().GCDiscardRatio, "Fraction of a Badger value log file which must be garbage for the file to be rewritten by garbage collection")
+	dbNumCompactors = NodeCmd.Flags().Int("dbNumCompactors", db.DefaultBadgerOptions().NumCompactors, "Number of concurrent Badger LSM tree compactions (at least 2)")
+	dbMetricsInterval = NodeCmd.Flags().Duration("dbMetricsInterval", db.DefaultMetricsInterval, "How often the database size and per-keyspace key count metrics are refreshed (disabled if zero). Each refresh reads every key of the database")
+	dbRetention = NodeCmd.Flags().String("dbRetention", "", fmt.Sprintf("Comma separated list of class:duration pairs (e.g. governor_audit:8760h) overriding how long transient records are kept in the database (0 keeps them forever). Classes are %v", db.RecordClasses))
+	dbReplicationListenAddr = NodeCmd.Flags().String("dbReplicationListenAddr", "", "Listen address for read-only database replicas to stream signed VAAs from (disabled if blank). Only replicas should be able to reach it")
+	dbReplicationCertFile = NodeCmd.Flags().String("dbReplicationCertFile", "", "PEM encoded certificate the replication service presents to replicas (required with --dbReplicationListenAddr)")
+	dbReplicationKeyFile = NodeCmd.Flags().String("dbReplicationKeyFile", "", "PEM encoded key of --dbReplicationCertFile (required with --dbReplicationListenAddr)")
+	dbReplicationClientCAFile = NodeCmd.Flags().String("dbReplicationClientCAFile", "", "PEM encoded CAs the client certificates of replicas must be signed by. Replicas need no client certificate if blank")
+
+	guardianKeyPath = NodeCmd.Flags().String("guardianKey", "", "Path to guardian key (required, except for query-node)")
 	solanaContract = NodeCmd.Flags().String("solanaContract", "", "Address of the Solana program (required)")
 
 	solanaRPC = node.RegisterFlagWithValidationOrFail(NodeCmd, "solanaRPC", "Solana RPC URL (required)", "http://solana-devnet:8899", []string{"http", "https"})
+	solanaGeyser = node.RegisterFlagWithValidationOrFail(NodeCmd, "solanaGeyser", "Solana Geyser gRPC URL, streams the message accounts instead of fetching every block if set", "https://solana-geyser:443", []string{"http", "https"})
+	solanaGeyserToken = NodeCmd.Flags().String("solanaGeyserToken", "", "Access token sent to the Solana Geyser gRPC endpoint")
+	solanaCommitments = NodeCmd.Flags().String("solanaCommitments", "confirmed,finalized", "Comma separated list of the commitment levels to run a Solana watcher at (processed, confirmed, finalized). Must include finalized, processed is only allowed with --unsafeDevMode and --solanaGeyser")
 
 	logLevel = NodeCmd.Flags().String("logLevel", "info", "Logging level (debug, info, warn, error, dpanic, panic, fatal)")
 	publicRpcLogDetailStr = NodeCmd.Flags().String("publicRpcLogDetail", "full", "The detail with which public RPC requests shall be logged (none=no logging, minimal=only log gRPC methods, full=log gRPC method, payload (up to 200 bytes) and user agent (up to 200 bytes))")
@@ -121,6 +268,12 @@ func init() {
 	publicRPC = NodeCmd.Flags().String("publicRPC", "", "Listen address for public gRPC interface")
 	publicWeb = NodeCmd.Flags().String("publicWeb", "", "Listen address for public REST and gRPC Web interface")
 
+	publicRateLimit = NodeCmd.Flags().Float64("publicRateLimit", 0, "Requests per second allowed from each client IP on --publicRPC and --publicWeb (0 = unlimited)")
+	publicRateLimitBurst = NodeCmd.Flags().Int("publicRateLimitBurst", 0, "Requests each client IP may make at once on --publicRPC and --publicWeb, --publicRateLimit rounded up if zero")
+	publicRateLimitWeights = NodeCmd.Flags().StringToInt("publicRateLimitWeights", nil, "Number of requests a call to a public RPC method counts as for --publicRateLimit, e.g. GetSignedVAABatch=10,GetSignedVAARange=10")
+	publicRpcSlowRequestThreshold = NodeCmd.Flags().Duration("publicRpcSlowRequestThreshold", 0, "Log the public RPC calls which take longer than this (0 = disabled)")
+	publicRpcReflection = NodeCmd.Flags().Bool("publicRpcReflection", false, "Enable gRPC server reflection on --publicRPC (default true with --testnetMode or --unsafeDevMode)")
+
 	tlsHostname = NodeCmd.Flags().String("tlsHostname", "", "If set, serve publicWeb as TLS with this hostname using Let's Encrypt")
 	tlsProdEnv = NodeCmd.Flags().Bool("tlsProdEnv", false,
 		"Use the production Let's Encrypt environment instead of staging")
@@ -134,14 +287,88 @@ func init() {
 
 	promRemoteURL = NodeCmd.Flags().String("promRemoteURL", "", "Prometheus remote write URL (Grafana)")
 
+	accountantContractURL = NodeCmd.Flags().String("accountantContractURL", "", "URL of the HTTP endpoint of the accounting contract that token bridge transfers are submitted to (empty disables the accountant)")
+	accountantEnforcing = NodeCmd.Flags().Bool("accountantEnforcing", false, "Only sign token bridge transfers once the accounting contract commits them, instead of just submitting them")
+	nttAccountantContractURL = NodeCmd.Flags().String("nttAccountantContractURL", "", "URL of the HTTP endpoint of the NTT accounting contract that native token transfers are submitted to (empty disables the NTT accountant)")
+	nttAccountantEnforcing = NodeCmd.Flags().Bool("nttAccountantEnforcing", false, "Only sign native token transfers once the NTT accounting contract commits them, instead of just submitting them")
+	nttAccountantEmitters = NodeCmd.Flags().String("nttAccountantEmitters", "", "Comma separated list of chain:address NTT transceiver emitters whose transfers are submitted to the NTT accounting contract")
+	accountantAuditInterval = NodeCmd.Flags().Duration("accountantAuditInterval", accountant.DefaultResubmitPolicy().AuditInterval, "How often the accountant queries the status of its pending transfers from the accounting contract")
+	accountantResubmitDelay = NodeCmd.Flags().Duration("accountantResubmitDelay", accountant.DefaultResubmitPolicy().InitialDelay, "Time after a transfer was submitted to the accounting contract before it is submitted again if it is still pending")
+	accountantResubmitBackoff = NodeCmd.Flags().Float64("accountantResubmitBackoff", accountant.DefaultResubmitPolicy().Backoff, "Factor by which the delay between submissions of a pending transfer grows after every submission")
+	accountantResubmitMaxDelay = NodeCmd.Flags().Duration("accountantResubmitMaxDelay", accountant.DefaultResubmitPolicy().MaxDelay, "Maximum delay between submissions of a pending transfer (0 for no limit)")
+	accountantAlarmAge = NodeCmd.Flags().Duration("accountantAlarmAge", accountant.DefaultResubmitPolicy().AlarmAge, "Age from which a transfer waiting on the accounting contract is reported as stuck (0 disables the reports)")
+	accountantForceReleaseApprovals = NodeCmd.Flags().Int("accountantForceReleaseApprovals", 0, "Number of admins who must approve forcing the release of a transfer held by an enforcing accountant (0 disables forced releases)")
+
+	gatewayRelayerURL = NodeCmd.Flags().String("gatewayRelayerURL", "", "URL of the HTTP endpoint the VAAs of transfers to the gateway are submitted to (empty disables the gateway relayer)")
+	gatewayRelayerContract = NodeCmd.Flags().String("gatewayRelayerContract", "", "Bech32 address of the IBC translator contract on the gateway that transfers are relayed to")
+	gatewayRelayerMaxAttempts = NodeCmd.Flags().Uint("gatewayRelayerMaxAttempts", gwrelayer.DefaultRelayPolicy().MaxAttempts, "Number of submissions of a VAA to the gateway before the gateway relayer gives up on it")
+	gatewayRelayerRetryDelay = NodeCmd.Flags().Duration("gatewayRelayerRetryDelay", gwrelayer.DefaultRelayPolicy().InitialDelay, "Time after a failed submission to the gateway before the VAA is submitted again")
+	gatewayRelayerBackoff = NodeCmd.Flags().Float64("gatewayRelayerBackoff", gwrelayer.DefaultRelayPolicy().Backoff, "Factor by which the delay between submissions to the gateway grows after every failed submission")
+	gatewayRelayerMaxRetryDelay = NodeCmd.Flags().Duration("gatewayRelayerMaxRetryDelay", gwrelayer.DefaultRelayPolicy().MaxDelay, "Maximum delay between submissions of a VAA to the gateway (0 for no limit)")
+	gatewayRelayerGasLimit = NodeCmd.Flags().Uint64("gatewayRelayerGasLimit", gwrelayer.DefaultRelayPolicy().GasLimit, "Gas limit of the transactions submitting VAAs to the gateway")
+	gatewayRelayerFee = NodeCmd.Flags().Uint64("gatewayRelayerFee", gwrelayer.DefaultRelayPolicy().InitialFee, "Fee offered for the first submission of a VAA to the gateway, in the fee denomination of the gateway")
+	gatewayRelayerFeeBump = NodeCmd.Flags().Float64("gatewayRelayerFeeBump", gwrelayer.DefaultRelayPolicy().FeeBump, "Factor by which the fee offered for a VAA grows after every failed submission to the gateway")
+	gatewayRelayerMaxFee = NodeCmd.Flags().Uint64("gatewayRelayerMaxFee", gwrelayer.DefaultRelayPolicy().MaxFee, "Maximum fee offered for a submission to the gateway")
+
 	chainGovernorEnabled = NodeCmd.Flags().Bool("chainGovernorEnabled", false, "Run the chain governor")
+	governorConfigFile = NodeCmd.Flags().String("governorConfigFile", "", "Path to a signed token and chain config for the chain governor, which replaces the built-in lists and is reloaded when it changes")
+	governorConfigSigners = NodeCmd.Flags().String("governorConfigSigners", "", "Comma separated addresses of the signers of --governorConfigFile, a quorum of which must sign it")
+	governorPriceSources = NodeCmd.Flags().String("governorPriceSources", "coingecko", "Comma separated sources of token prices for the chain governor: coingecko, and oracle for the oracle accounts in --governorConfigFile")
+	governorReleaseApprovals = NodeCmd.Flags().Int("governorReleaseApprovals", 0, "Number of distinct operators who must approve releasing a chain governor pending VAA worth at least --governorReleaseApprovalThreshold (0 disables approvals)")
+	governorReleaseApprovalThreshold = NodeCmd.Flags().Uint64("governorReleaseApprovalThreshold", 0, "Notional value from which releasing a chain governor pending VAA requires --governorReleaseApprovals approvals")
+
+	governorShadowMode = NodeCmd.Flags().Bool("governorShadowMode", false, "Run the chain governor without holding back any VAA, only recording and reporting what it would have enqueued")
+	governorWebhookURL = NodeCmd.Flags().String("governorWebhookURL", "", "URL the chain governor posts a JSON event to whenever a VAA is enqueued, released or dropped")
+
+	observationDelays = NodeCmd.Flags().String("observationDelays", "", "Comma separated list of chain:duration pairs (e.g. solana:10m), messages from these chains are held for the duration before being signed, except for injected governance messages")
+
+	sigVerifyWorkers = NodeCmd.Flags().Int("sigVerifyWorkers", 4, "Number of goroutines verifying the signatures of observations from other guardians in parallel, each one at a time (0 verifies them on the processor goroutine)")
+	aggregationShards = NodeCmd.Flags().Int("aggregationShards", 4, "Number of shards, split by emitter, aggregating observations in parallel (0 aggregates them on the processor goroutine)")
+
+	vaaRetentionDays = NodeCmd.Flags().Uint("vaaRetentionDays", 0, "Number of days signed VAAs are kept in the database before they are archived (0 keeps them forever)")
+	vaaArchiveDir = NodeCmd.Flags().String("vaaArchiveDir", "", "Directory archived VAAs are written to (defaults to vaa-archive in --dataDir)")
+	vaaArchiveUploadURL = NodeCmd.Flags().String("vaaArchiveUploadURL", "", "URL archive segments are uploaded to with an HTTP PUT, e.g. a presigned object storage prefix (optional)")
+	vaaArchiveRestoreHold = NodeCmd.Flags().Duration("vaaArchiveRestoreHold", 7*24*time.Hour, "Time VAAs restored from the archive are kept in the database before they are pruned again")
+
+	dbBackupInterval = NodeCmd.Flags().Duration("dbBackupInterval", 0, "How often the database is backed up (disabled if zero). Only supported by the badger backend")
+	dbBackupDir = NodeCmd.Flags().String("dbBackupDir", "", "Directory database backups are written to (defaults to db-backups in --dataDir)")
+	dbBackupRetention = NodeCmd.Flags().Duration("dbBackupRetention", 7*24*time.Hour, "How long database backups are kept, locally or in object storage. The latest backup is always kept")
+	dbBackupUploadURL = NodeCmd.Flags().String("dbBackupUploadURL", "", "URL database backups are uploaded to with an HTTP PUT and deleted from with an HTTP DELETE once expired, e.g. an object storage prefix (optional)")
+	dbBackupUploadAuth = NodeCmd.Flags().String("dbBackupUploadAuth", "", "Storage backend the backup uploads are authenticated for, s3 or gcs, with credentials from the environment. Required with --dbBackupUploadURL or --stateDbBackupUploadURL")
+	dbBackupUploadRegion = NodeCmd.Flags().String("dbBackupUploadRegion", "", "Region of the S3 bucket backups are uploaded to, required with --dbBackupUploadAuth s3")
+
+	separateStateDb = NodeCmd.Flags().Bool("separateStateDb", true, "Keep the governor and accountant state in a database of its own next to the main database. If disabled, the state is moved back into the main database")
+	stateDbBackupInterval = NodeCmd.Flags().Duration("stateDbBackupInterval", 0, "How often the governor and accountant state database is backed up (disabled if zero). Only supported by the badger backend")
+	stateDbBackupDir = NodeCmd.Flags().String("stateDbBackupDir", "", "Directory state database backups are written to (defaults to state-db-backups in --dataDir)")
+	stateDbBackupRetention = NodeCmd.Flags().Duration("stateDbBackupRetention", 7*24*time.Hour, "How long state database backups are kept, locally or in object storage. The latest backup is always kept")
+	stateDbBackupUploadURL = NodeCmd.Flags().String("stateDbBackupUploadURL", "", "URL state database backups are uploaded to, like --dbBackupUploadURL, which it must differ from (optional)")
+
+	experimentalAggregatedSignatures = NodeCmd.Flags().Bool("experimentalAggregatedSignatures", false, "Also produce BLS aggregated attestations for VAAs (devnet only)")
+
+	replayRecording = NodeCmd.Flags().String("replayRecording", "", "Path to record the processor's inputs to, for `guardiand replay` (devnet only)")
+
+	reobservationInitialDelay = NodeCmd.Flags().Duration("reobservationInitialDelay", processor.FirstRetryMinWait, "Time after an observation is first seen before re-observation is requested if it has not reached quorum")
+	reobservationBackoff = NodeCmd.Flags().Float64("reobservationBackoff", 2, "Factor by which the delay between re-observation requests grows after every request")
+	reobservationMaxAttempts = NodeCmd.Flags().Uint("reobservationMaxAttempts", 0, "Maximum number of re-observation requests per observation (0 for no limit)")
+	reobservationOverrides = NodeCmd.Flags().String("reobservationOverrides", "", "Comma separated list of per chain re-observation policies of the form chain:initialDelay:backoff:maxAttempts (e.g. solana:1m:2:10)")
+
+	nearQuorumAlertThreshold = NodeCmd.Flags().Duration("nearQuorumAlertThreshold", processor.NearQuorumThresholdDefault, "Time an observation can be one signature short of quorum before the missing guardians are reported (0 to disable)")
+	shadowMode = NodeCmd.Flags().Bool("shadowMode", false, "Run as a non-voting shadow guardian, which observes and checks the quorum reached by the guardian set but never broadcasts its own signatures")
+	lateObservationWindow = NodeCmd.Flags().Duration("lateObservationWindow", 0, "Time after a VAA reached quorum during which signatures arriving late are added to the stored VAA, at most 1h (0 to disable)")
+
+	payloadDecoders = NodeCmd.Flags().String("payloadDecoders", "", "JSON file registering decoders for the payloads of additional emitters, used when logging messages")
 
 	ccqEnabled = NodeCmd.Flags().Bool("ccqEnabled", false, "Enable cross chain query support")
 	ccqAllowedRequesters = NodeCmd.Flags().String("ccqAllowedRequesters", "", "Comma separated list of signers allowed to submit cross chain queries")
+	ccqDailyBudget = NodeCmd.Flags().Uint64("ccqDailyBudget", 0, "Daily budget of each CCQ requester, counted in accounts looked up by archival queries (those with a minimum context slot), or zero for no limit")
 	ccqP2pPort = NodeCmd.Flags().Uint("ccqP2pPort", 8996, "CCQ P2P UDP listener port")
 	ccqP2pBootstrap = NodeCmd.Flags().String("ccqP2pBootstrap", "", "CCQ P2P bootstrap peers (comma-separated)")
 	ccqAllowedPeers = NodeCmd.Flags().String("ccqAllowedPeers", "", "CCQ allowed P2P peers (comma-separated)")
 	ccqBackfillCache = NodeCmd.Flags().Bool("ccqBackfillCache", true, "Should EVM chains backfill CCQ timestamp cache on startup")
+
+	// The query and replica nodes take the same configuration as a full node, so that they can share a config file.
+	QueryNodeCmd.Flags().AddFlagSet(NodeCmd.Flags())
+	ReplicaNodeCmd.Flags().AddFlagSet(NodeCmd.Flags())
 }
 
 var (
@@ -155,6 +382,9 @@ var (
 	envPrefix      = "GUARDIAND"
 )
 
+// supervisorShutdownTimeout bounds how long shutdown waits for the runnables to return before writing the shutdown report.
+const supervisorShutdownTimeout = 30 * time.Second
+
 // "Why would anyone do this?" are famous last words.
 //
 // We already forcibly override RPC URLs and keys in dev mode to prevent security
@@ -176,6 +406,23 @@ var NodeCmd = &cobra.Command{
 	Run:               runNode,
 }
 
+// QueryNodeCmd runs only the cross chain query pipeline, so that queries can be served from separate infrastructure than the node
+// that signs observations. It takes the same flags as NodeCmd, but only the ones relevant to CCQ and the watchers are used.
+var QueryNodeCmd = &cobra.Command{
+	Use:               "query-node",
+	Short:             "Run a guardiand node that only serves cross chain queries",
+	PersistentPreRunE: initConfig,
+	Run:               runQueryNode,
+}
+
+// queryOnly is set when running as QueryNodeCmd.
+var queryOnly bool
+
+func runQueryNode(cmd *cobra.Command, args []string) {
+	queryOnly = true
+	runNode(cmd, args)
+}
+
 // This variable may be overridden by the -X linker flag to "dev" in which case
 // we enforce the --unsafeDevMode flag. Only development binaries/docker images
 // are distributed. Production binaries are required to be built from source by
@@ -223,11 +470,17 @@ func runNode(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	logger := zap.New(zapcore.NewCore(
-		consoleEncoder{zapcore.NewConsoleEncoder(
-			zap.NewDevelopmentEncoderConfig())},
-		zapcore.AddSync(zapcore.Lock(os.Stderr)),
-		zap.NewAtomicLevelAt(zapcore.Level(lvl))))
+	// Recent logs are also kept in memory for support bundles.
+	logBuffer := supportbundle.NewLogBuffer(supportbundle.LogBufferDefaultSize)
+	// The log levels can be changed at runtime through the admin service.
+	logLevels := common.NewLogLevels(zapcore.Level(lvl))
+	logger := zap.New(logLevels.Core(zapcore.NewTee(
+		zapcore.NewCore(
+			consoleEncoder{zapcore.NewConsoleEncoder(
+				zap.NewDevelopmentEncoderConfig())},
+			zapcore.AddSync(zapcore.Lock(os.Stderr)),
+			logLevels),
+		logBuffer.Core(logLevels))))
 
 	if *unsafeDevMode {
 		// Use the hostname as nodeName. For production, we don't want to do this to
@@ -244,6 +497,7 @@ func runNode(cmd *cobra.Command, args []string) {
 
 	// Override the default go-log config, which uses a magic environment variable.
 	ipfslog.SetAllLoggers(lvl)
+	logLevels.OnGlobalChange(func(lvl zapcore.Level) { ipfslog.SetAllLoggers(ipfslog.LogLevel(lvl)) })
 
 	// In devnet mode, we automatically set a number of flags that rely on deterministic keys.
 	if *unsafeDevMode {
@@ -262,7 +516,8 @@ func runNode(cmd *cobra.Command, args []string) {
 	if *nodeKeyPath == "" && !*unsafeDevMode { // In devnet mode, keys are deterministically generated.
 		logger.Fatal("Please specify --nodeKey")
 	}
-	if *guardianKeyPath == "" {
+	// A query-only node only signs query responses, and may do so with a throwaway key (see below).
+	if *guardianKeyPath == "" && !queryOnly {
 		logger.Fatal("Please specify --guardianKey")
 	}
 	if *adminSocketPath == "" {
@@ -271,6 +526,12 @@ func runNode(cmd *cobra.Command, args []string) {
 	if *adminSocketPath == *publicGRPCSocketPath {
 		logger.Fatal("--adminSocket must not equal --publicGRPCSocket")
 	}
+	if *adminListenAddr != "" && (*adminTLSCert == "" || *adminTLSKey == "" || *adminTLSClients == "") {
+		logger.Fatal("If --adminListenAddr is specified, --adminTLSCert, --adminTLSKey and --adminTLSClients must also be specified")
+	}
+	if *adminListenAddr == "" && (*adminTLSCert != "" || *adminTLSKey != "" || *adminTLSClients != "") {
+		logger.Fatal("--adminTLSCert, --adminTLSKey and --adminTLSClients may only be specified with --adminListenAddr")
+	}
 	if (*publicRPC != "" || *publicWeb != "") && *publicGRPCSocketPath == "" {
 		logger.Fatal("If either --publicRPC or --publicWeb is specified, --publicGRPCSocket must also be specified")
 	}
@@ -278,22 +539,251 @@ func runNode(cmd *cobra.Command, args []string) {
 		logger.Fatal("Please specify --dataDir")
 	}
 
-	var publicRpcLogDetail common.GrpcLogDetail
-	switch *publicRpcLogDetailStr {
-	case "none":
-		publicRpcLogDetail = common.GrpcLogDetailNone
-	case "minimal":
-		publicRpcLogDetail = common.GrpcLogDetailMinimal
-	case "full":
-		publicRpcLogDetail = common.GrpcLogDetailFull
-	default:
-		logger.Fatal("--publicRpcLogDetail should be one of (none, minimal, full)")
+	publicRpcLogDetail := parsePublicRpcLogDetail(logger)
+
+	ipMode, err := common.ParseIPMode(*ipModeStr)
+	if err != nil {
+		logger.Fatal("Invalid value for --ipMode", zap.Error(err))
+	}
+
+	var governorSigners []ethcommon.Address
+	if *governorConfigFile != "" {
+		if !*chainGovernorEnabled {
+			logger.Fatal("--governorConfigFile requires --chainGovernorEnabled")
+		}
+		governorSigners, err = governor.ParseConfigSigners(*governorConfigSigners)
+		if err != nil {
+			logger.Fatal("Invalid value for --governorConfigSigners", zap.Error(err))
+		}
+	}
+
+	var governorCoinGecko, governorPriceOracle bool
+	for _, source := range strings.Split(*governorPriceSources, ",") {
+		switch strings.TrimSpace(source) {
+		case "coingecko":
+			governorCoinGecko = true
+		case "oracle":
+			governorPriceOracle = true
+		default:
+			logger.Fatal("Invalid value for --governorPriceSources", zap.String("source", source))
+		}
+	}
+	if governorPriceOracle && *governorConfigFile == "" {
+		logger.Fatal("--governorPriceSources=oracle requires --governorConfigFile, which lists the oracle accounts")
+	}
+	if *governorReleaseApprovals < 0 {
+		logger.Fatal("--governorReleaseApprovals must not be negative")
+	}
+	if *governorReleaseApprovals > 0 && !*chainGovernorEnabled {
+		logger.Fatal("--governorReleaseApprovals requires --chainGovernorEnabled")
+	}
+	if *governorShadowMode && !*chainGovernorEnabled {
+		logger.Fatal("--governorShadowMode requires --chainGovernorEnabled")
+	}
+	if *accountantContractURL != "" {
+		if u, err := url.Parse(*accountantContractURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			logger.Fatal("--accountantContractURL must be an http or https URL")
+		}
+	} else if *accountantEnforcing {
+		logger.Fatal("--accountantEnforcing requires --accountantContractURL")
+	}
+	var nttEmitters []accountant.NTTEmitter
+	if *nttAccountantContractURL != "" {
+		if u, err := url.Parse(*nttAccountantContractURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			logger.Fatal("--nttAccountantContractURL must be an http or https URL")
+		}
+		nttEmitters, err = accountant.ParseNTTEmitters(*nttAccountantEmitters)
+		if err != nil {
+			logger.Fatal("invalid --nttAccountantEmitters", zap.Error(err))
+		}
+		if len(nttEmitters) == 0 {
+			logger.Fatal("--nttAccountantContractURL requires --nttAccountantEmitters")
+		}
+	} else if *nttAccountantEnforcing || *nttAccountantEmitters != "" {
+		logger.Fatal("--nttAccountantEnforcing and --nttAccountantEmitters require --nttAccountantContractURL")
+	}
+	accountantResubmitPolicy := accountant.ResubmitPolicy{
+		AuditInterval: *accountantAuditInterval,
+		InitialDelay:  *accountantResubmitDelay,
+		Backoff:       *accountantResubmitBackoff,
+		MaxDelay:      *accountantResubmitMaxDelay,
+		AlarmAge:      *accountantAlarmAge,
+	}
+	if err := accountantResubmitPolicy.Validate(); err != nil {
+		logger.Fatal("invalid accountant resubmit policy", zap.Error(err))
+	}
+	if *accountantForceReleaseApprovals < 0 {
+		logger.Fatal("--accountantForceReleaseApprovals must not be negative")
+	} else if *accountantForceReleaseApprovals > 0 && *accountantContractURL == "" && *nttAccountantContractURL == "" {
+		logger.Fatal("--accountantForceReleaseApprovals requires --accountantContractURL or --nttAccountantContractURL")
+	}
+	if *gatewayRelayerURL != "" {
+		if u, err := url.Parse(*gatewayRelayerURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			logger.Fatal("--gatewayRelayerURL must be an http or https URL")
+		}
+		if *gatewayRelayerContract == "" {
+			logger.Fatal("--gatewayRelayerURL requires --gatewayRelayerContract")
+		}
+	} else if *gatewayRelayerContract != "" {
+		logger.Fatal("--gatewayRelayerContract requires --gatewayRelayerURL")
+	}
+	gatewayRelayPolicy := gwrelayer.RelayPolicy{
+		MaxAttempts:  *gatewayRelayerMaxAttempts,
+		InitialDelay: *gatewayRelayerRetryDelay,
+		Backoff:      *gatewayRelayerBackoff,
+		MaxDelay:     *gatewayRelayerMaxRetryDelay,
+		GasLimit:     *gatewayRelayerGasLimit,
+		InitialFee:   *gatewayRelayerFee,
+		FeeBump:      *gatewayRelayerFeeBump,
+		MaxFee:       *gatewayRelayerMaxFee,
+	}
+	if err := gatewayRelayPolicy.Validate(); err != nil {
+		logger.Fatal("invalid gateway relay policy", zap.Error(err))
+	}
+	if *governorWebhookURL != "" {
+		if !*chainGovernorEnabled {
+			logger.Fatal("--governorWebhookURL requires --chainGovernorEnabled")
+		}
+		if u, err := url.Parse(*governorWebhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			logger.Fatal("--governorWebhookURL must be an http or https URL")
+		}
+	}
+
+	var announceAddrs []string
+	if *p2pAnnounceAddrs != "" {
+		announceAddrs = strings.Split(*p2pAnnounceAddrs, ",")
 	}
 
-	if *nodeName == "" {
+	// The node name is only announced in heartbeats, which a query-only node does not send.
+	if *nodeName == "" && !queryOnly {
 		logger.Fatal("Please specify --nodeName")
 	}
 
+	observationDelaysByChain, err := processor.ParseObservationDelays(*observationDelays)
+	if err != nil {
+		logger.Fatal("invalid --observationDelays", zap.Error(err))
+	}
+	for chainID, delay := range observationDelaysByChain {
+		logger.Info("delaying observations", zap.Stringer("chain", chainID), zap.Duration("delay", delay))
+	}
+
+	if *sigVerifyWorkers < 0 {
+		logger.Fatal("--sigVerifyWorkers must not be negative")
+	}
+	if *aggregationShards < 0 {
+		logger.Fatal("--aggregationShards must not be negative")
+	}
+
+	if *experimentalAggregatedSignatures && !*unsafeDevMode {
+		logger.Fatal("--experimentalAggregatedSignatures is only allowed with --unsafeDevMode")
+	}
+	if *replayRecording != "" && !*unsafeDevMode {
+		logger.Fatal("--replayRecording is only allowed with --unsafeDevMode")
+	}
+	// A signing guardian which forgets what it signed on restart can sign again for the same messages, and loses its governor state.
+	if *inMemoryDb && !*unsafeDevMode && !queryOnly && !*shadowMode {
+		logger.Fatal("--inMemoryDb is only allowed with --unsafeDevMode, --shadowMode or in query-only mode")
+	}
+
+	reobservationConfig := &processor.ReobservationConfig{
+		Default: processor.ReobservationPolicy{
+			InitialDelay: *reobservationInitialDelay,
+			Backoff:      *reobservationBackoff,
+			MaxAttempts:  *reobservationMaxAttempts,
+		},
+	}
+	if err := reobservationConfig.Default.Validate(); err != nil {
+		logger.Fatal("invalid re-observation policy", zap.Error(err))
+	}
+	reobservationConfig.Chains, err = processor.ParseReobservationOverrides(*reobservationOverrides)
+	if err != nil {
+		logger.Fatal("invalid --reobservationOverrides", zap.Error(err))
+	}
+
+	var vaaArchiveConfig *vaaarchive.Config
+	if *vaaRetentionDays != 0 {
+		dir := *vaaArchiveDir
+		if dir == "" {
+			dir = path.Join(*dataDir, "vaa-archive")
+		}
+		vaaArchiveConfig = &vaaarchive.Config{
+			Dir:         dir,
+			Retention:   time.Duration(*vaaRetentionDays) * 24 * time.Hour,
+			Interval:    time.Hour,
+			RestoreHold: *vaaArchiveRestoreHold,
+			UploadURL:   *vaaArchiveUploadURL,
+		}
+	}
+
+	dbRetentionByClass, err := db.ParseRetention(*dbRetention)
+	if err != nil {
+		logger.Fatal("invalid --dbRetention", zap.Error(err))
+	}
+
+	// Both databases upload their backups with the same credentials.
+	var dbBackupSigner dbbackup.Signer
+	if (*dbBackupInterval != 0 && *dbBackupUploadURL != "") || (*stateDbBackupInterval != 0 && *stateDbBackupUploadURL != "") {
+		if *dbBackupUploadAuth == "" {
+			logger.Fatal("--dbBackupUploadURL and --stateDbBackupUploadURL require --dbBackupUploadAuth")
+		}
+		dbBackupSigner, err = dbbackup.NewSigner(context.Background(), *dbBackupUploadAuth, *dbBackupUploadRegion)
+		if err != nil {
+			logger.Fatal("invalid --dbBackupUploadAuth", zap.Error(err))
+		}
+	}
+
+	var dbBackupConfig *dbbackup.Config
+	if *dbBackupInterval != 0 {
+		dir := *dbBackupDir
+		if dir == "" {
+			if *dataDir == "" {
+				logger.Fatal("--dbBackupInterval requires --dbBackupDir or --dataDir")
+			}
+			dir = path.Join(*dataDir, "db-backups")
+		}
+		dbBackupConfig = &dbbackup.Config{
+			Dir:       dir,
+			Interval:  *dbBackupInterval,
+			Retention: *dbBackupRetention,
+			UploadURL: *dbBackupUploadURL,
+			Signer:    dbBackupSigner,
+		}
+	}
+
+	var stateDbBackupConfig *dbbackup.Config
+	if *stateDbBackupInterval != 0 {
+		if !*separateStateDb {
+			logger.Fatal("--stateDbBackupInterval requires --separateStateDb")
+		}
+		dir := *stateDbBackupDir
+		if dir == "" {
+			if *dataDir == "" {
+				logger.Fatal("--stateDbBackupInterval requires --stateDbBackupDir or --dataDir")
+			}
+			dir = path.Join(*dataDir, "state-db-backups")
+		}
+		stateDbBackupConfig = &dbbackup.Config{
+			Dir:       dir,
+			Interval:  *stateDbBackupInterval,
+			Retention: *stateDbBackupRetention,
+			UploadURL: *stateDbBackupUploadURL,
+			Signer:    dbBackupSigner,
+		}
+		// The backups of both databases have the same names, so they would overwrite and expire each other.
+		if dbBackupConfig != nil && path.Clean(dbBackupConfig.Dir) == path.Clean(stateDbBackupConfig.Dir) {
+			logger.Fatal("--stateDbBackupDir must differ from --dbBackupDir")
+		}
+		if dbBackupConfig != nil && stateDbBackupConfig.UploadURL != "" && strings.TrimSuffix(dbBackupConfig.UploadURL, "/") == strings.TrimSuffix(stateDbBackupConfig.UploadURL, "/") {
+			logger.Fatal("--stateDbBackupUploadURL must differ from --dbBackupUploadURL")
+		}
+	}
+
+	if *payloadDecoders != "" {
+		if err := payloads.LoadFile(*payloadDecoders); err != nil {
+			logger.Fatal("invalid --payloadDecoders", zap.Error(err))
+		}
+	}
+
 	// Solana, Terra Classic, Terra 2, and Algorand are optional in devnet
 	if !*unsafeDevMode {
 
@@ -320,8 +810,12 @@ func runNode(cmd *cobra.Command, args []string) {
 		logger.Fatal("Cannot be in unsafeDevMode and testnetMode at the same time.")
 	}
 
+	if *adminAllowVAAPurge && env == common.MainNet {
+		logger.Fatal("--adminAllowVAAPurge is only allowed with --testnetMode or --unsafeDevMode")
+	}
+
 	// In devnet mode, we generate a deterministic guardian key and write it to disk.
-	if *unsafeDevMode {
+	if *unsafeDevMode && *guardianKeyPath != "" {
 		err := devnet.GenerateAndStoreDevnetGuardianKey(*guardianKeyPath)
 		if err != nil {
 			logger.Fatal("failed to generate devnet guardian key", zap.Error(err))
@@ -329,13 +823,37 @@ func runNode(cmd *cobra.Command, args []string) {
 	}
 
 	// Database
-	db := db.OpenDb(logger, dataDir)
-	defer db.Close()
+	db := openDatabase(logger)
+	stateDb := openStateDatabase(logger, db)
+	// The databases are only closed once the supervision tree has exited, since the processor and the governor write their state to
+	// them on the way out. If it does not exit in time, they are left for the process exit to close rather than closed under them.
+	supervisorExited := true
+	defer func() {
+		if !supervisorExited {
+			logger.Warn("leaving the databases open, since the supervision tree may still be writing to them")
+			return
+		}
+		if stateDb != nil {
+			stateDb.Close()
+		}
+		db.Close()
+	}()
 
 	// Guardian key
-	gk, err := common.LoadGuardianKey(*guardianKeyPath, *unsafeDevMode)
-	if err != nil {
-		logger.Fatal("failed to load guardian key", zap.Error(err))
+	var gk *ecdsa.PrivateKey
+	if *guardianKeyPath == "" {
+		// Only reachable in query-only mode. Clients only count responses signed by a key in the guardian set, so without the
+		// guardian's key the node still runs, but its responses are ignored.
+		gk, err = ecdsa.GenerateKey(ethcrypto.S256(), rand.Reader)
+		if err != nil {
+			logger.Fatal("failed to generate a query response signing key", zap.Error(err))
+		}
+		logger.Warn("no --guardianKey given, signing query responses with an ephemeral key which clients will not accept")
+	} else {
+		gk, err = common.LoadGuardianKey(*guardianKeyPath, *unsafeDevMode)
+		if err != nil {
+			logger.Fatal("failed to load guardian key", zap.Error(err))
+		}
 	}
 
 	logger.Info("Loaded guardian key", zap.String(
@@ -375,17 +893,23 @@ func runNode(cmd *cobra.Command, args []string) {
 
 	rpcMap := make(map[string]string)
 	rpcMap["solanaRPC"] = *solanaRPC
+	rpcMap["solanaGeyser"] = *solanaGeyser
 
 	// Node's main lifecycle context.
 	rootCtx, rootCtxCancel = context.WithCancel(context.Background())
 	defer rootCtxCancel()
 
+	// startTime and sigtermReceived are used for the shutdown report.
+	startTime := time.Now()
+	sigtermReceived := make(chan struct{})
+
 	// Handle SIGTERM
 	sigterm := make(chan os.Signal, 1)
 	signal.Notify(sigterm, syscall.SIGTERM)
 	go func() {
 		<-sigterm
 		logger.Info("Received sigterm. exiting.")
+		close(sigtermReceived)
 		rootCtxCancel()
 	}()
 
@@ -473,30 +997,24 @@ func runNode(cmd *cobra.Command, args []string) {
 	var watcherConfigs = []watchers.WatcherConfig{}
 
 	if shouldStart(solanaRPC) {
-		// confirmed watcher
-		wc := &solana.WatcherConfig{
-			NetworkID:     "solana-confirmed",
-			ChainID:       vaa.ChainIDSolana,
-			Rpc:           *solanaRPC,
-			Websocket:     "",
-			Contract:      *solanaContract,
-			ReceiveObsReq: false,
-			Commitment:    rpc.CommitmentConfirmed,
+		commitments, err := solana.ParseCommitments(*solanaCommitments)
+		if err != nil {
+			logger.Fatal("invalid --solanaCommitments", zap.Error(err))
 		}
-
-		watcherConfigs = append(watcherConfigs, wc)
-
-		// finalized watcher
-		wc = &solana.WatcherConfig{
-			NetworkID:     "solana-finalized",
-			ChainID:       vaa.ChainIDSolana,
-			Rpc:           *solanaRPC,
-			Websocket:     "",
-			Contract:      *solanaContract,
-			ReceiveObsReq: true,
-			Commitment:    rpc.CommitmentFinalized,
+		for _, commitment := range commitments {
+			watcherConfigs = append(watcherConfigs, &solana.WatcherConfig{
+				NetworkID:   watchers.NetworkID("solana-" + string(commitment)),
+				ChainID:     vaa.ChainIDSolana,
+				Rpc:         *solanaRPC,
+				Websocket:   "",
+				Geyser:      *solanaGeyser,
+				GeyserToken: *solanaGeyserToken,
+				Contract:    *solanaContract,
+				// The finalized watcher handles the re-observation requests.
+				ReceiveObsReq: commitment == rpc.CommitmentFinalized,
+				Commitment:    commitment,
+			})
 		}
-		watcherConfigs = append(watcherConfigs, wc)
 	}
 
 	guardianNode := node.NewGuardianNode(
@@ -504,39 +1022,280 @@ func runNode(cmd *cobra.Command, args []string) {
 		gk,
 	)
 
-	guardianOptions := []*node.GuardianOption{
-		node.GuardianOptionDatabase(db),
-		node.GuardianOptionWatchers(watcherConfigs),
-		node.GuardianOptionGovernor(*chainGovernorEnabled),
-		node.GuardianOptionQueryHandler(*ccqEnabled, *ccqAllowedRequesters),
-		node.GuardianOptionAdminService(*adminSocketPath, rpcMap),
-		node.GuardianOptionP2P(p2pKey, *p2pNetworkID, *p2pBootstrap, *nodeName, *disableHeartbeatVerify, *p2pPort, *ccqP2pBootstrap, *ccqP2pPort, *ccqAllowedPeers),
-		node.GuardianOptionStatusServer(*statusAddr),
-		node.GuardianOptionProcessor(),
+	// The state database must be configured before the governor and the accountants.
+	guardianOptions := []*node.GuardianOption{node.GuardianOptionDatabase(db)}
+	if stateDb != nil {
+		guardianOptions = append(guardianOptions, node.GuardianOptionStateDatabase(stateDb))
 	}
+	if queryOnly {
+		logger.Info("running in query-only mode, observations will not be signed")
+		guardianOptions = append(guardianOptions,
+			node.GuardianOptionWatchers(watcherConfigs),
+			node.GuardianOptionQueryHandler(true, *ccqAllowedRequesters, *ccqDailyBudget),
+			node.GuardianOptionQueryP2P(p2pKey, *p2pNetworkID, *ccqP2pBootstrap, *ccqP2pPort, *ccqAllowedPeers, ipMode),
+			node.GuardianOptionStatusServer(*statusAddr, ipMode),
+			node.GuardianOptionDiscardObservations(),
+		)
+	} else {
+		guardianOptions = append(guardianOptions,
+			node.GuardianOptionWatchers(watcherConfigs),
+			node.GuardianOptionAccountant(*accountantContractURL, *accountantEnforcing),
+			node.GuardianOptionNTTAccountant(*nttAccountantContractURL, *nttAccountantEnforcing, nttEmitters),
+			node.GuardianOptionGatewayRelayer(*gatewayRelayerURL, *gatewayRelayerContract, gatewayRelayPolicy),
+			node.GuardianOptionGovernor(*chainGovernorEnabled),
+			node.GuardianOptionQueryHandler(*ccqEnabled, *ccqAllowedRequesters, *ccqDailyBudget),
+		)
+
+		if *accountantContractURL != "" || *nttAccountantContractURL != "" {
+			guardianOptions = append(guardianOptions, node.GuardianOptionAccountantResubmitPolicy(accountantResubmitPolicy))
+		}
+
+		if *accountantForceReleaseApprovals > 0 {
+			guardianOptions = append(guardianOptions, node.GuardianOptionAccountantForceRelease(*accountantForceReleaseApprovals))
+		}
+
+		if *governorConfigFile != "" {
+			guardianOptions = append(guardianOptions, node.GuardianOptionGovernorConfigFile(*governorConfigFile, governorSigners))
+		}
+
+		if governorPriceOracle {
+			guardianOptions = append(guardianOptions, node.GuardianOptionGovernorPriceOracle(governorCoinGecko))
+		}
+
+		if *governorReleaseApprovals > 0 {
+			guardianOptions = append(guardianOptions, node.GuardianOptionGovernorReleaseApprovals(*governorReleaseApprovalThreshold, *governorReleaseApprovals))
+		}
 
-	if shouldStart(publicGRPCSocketPath) {
-		guardianOptions = append(guardianOptions, node.GuardianOptionPublicRpcSocket(*publicGRPCSocketPath, publicRpcLogDetail))
+		if *governorShadowMode {
+			guardianOptions = append(guardianOptions, node.GuardianOptionGovernorShadowMode())
+		}
+
+		if *governorWebhookURL != "" {
+			guardianOptions = append(guardianOptions, node.GuardianOptionGovernorWebhook(*governorWebhookURL))
+		}
+
+		if *experimentalAggregatedSignatures {
+			// Must come before p2p and the processor, which use the key.
+			guardianOptions = append(guardianOptions, node.GuardianOptionAggregatedSignatures())
+		}
+		if *replayRecording != "" {
+			guardianOptions = append(guardianOptions, node.GuardianOptionRecording(*replayRecording))
+		}
+
+		if *dbReplicationListenAddr != "" {
+			tlsConfig, err := dbreplication.ServerTLSConfig(*dbReplicationCertFile, *dbReplicationKeyFile, *dbReplicationClientCAFile)
+			if err != nil {
+				logger.Fatal("invalid --dbReplicationCertFile, --dbReplicationKeyFile or --dbReplicationClientCAFile", zap.Error(err))
+			}
+			guardianOptions = append(guardianOptions, node.GuardianOptionDatabaseReplication(*dbReplicationListenAddr, tlsConfig, ipMode))
+		}
+
+		if vaaArchiveConfig != nil {
+			// Must come before the admin service, so it can restore archived VAAs.
+			guardianOptions = append(guardianOptions, node.GuardianOptionVAAArchive(*vaaArchiveConfig))
+		}
+
+		if *adminAuditLog != "" {
+			// Must come before the admin service, which opens the file.
+			guardianOptions = append(guardianOptions, node.GuardianOptionAdminAuditLog(*adminAuditLog))
+		}
 
-		if shouldStart(publicRPC) {
-			guardianOptions = append(guardianOptions, node.GuardianOptionPublicrpcTcpService(*publicRPC, publicRpcLogDetail))
+		guardianOptions = append(guardianOptions,
+			node.GuardianOptionLogLevels(logLevels),
+			node.GuardianOptionAdminService(*adminSocketPath, rpcMap, *adminRequireSecondApprover, *adminAllowVAAPurge, &supportbundle.Sources{
+				Config: supportbundle.RedactFlags(cmd.Flags()),
+				Logs:   logBuffer,
+			}),
+			node.GuardianOptionP2P(p2pKey, *p2pNetworkID, *p2pBootstrap, *nodeName, *disableHeartbeatVerify, *p2pPort, *ccqP2pBootstrap, *ccqP2pPort, *ccqAllowedPeers, *p2pLatencyProbeInterval, ipMode, announceAddrs),
+			node.GuardianOptionStatusServer(*statusAddr, ipMode),
+			node.GuardianOptionReobservationPolicy(reobservationConfig),
+		)
+
+		if *adminListenAddr != "" {
+			guardianOptions = append(guardianOptions, node.GuardianOptionAdminServiceTCP(adminrpc.TCPConfig{
+				ListenAddr:  *adminListenAddr,
+				CertFile:    *adminTLSCert,
+				KeyFile:     *adminTLSKey,
+				ClientsFile: *adminTLSClients,
+			}, ipMode))
 		}
 
-		if shouldStart(publicWeb) {
-			guardianOptions = append(guardianOptions,
-				node.GuardianOptionPublicWeb(*publicWeb, *publicGRPCSocketPath, *tlsHostname, *tlsProdEnv, path.Join(*dataDir, "autocert")),
-			)
+		if *nearQuorumAlertThreshold > 0 {
+			guardianOptions = append(guardianOptions, node.GuardianOptionNearQuorumAlert(*nearQuorumAlertThreshold))
+		}
+		if *lateObservationWindow > 0 {
+			guardianOptions = append(guardianOptions, node.GuardianOptionLateObservationWindow(*lateObservationWindow))
+		}
+		if *shadowMode {
+			guardianOptions = append(guardianOptions, node.GuardianOptionShadowMode())
+		}
+
+		guardianOptions = append(guardianOptions, node.GuardianOptionProcessor(observationDelaysByChain, *sigVerifyWorkers, *aggregationShards))
+
+		if shouldStart(publicGRPCSocketPath) {
+			guardianOptions = append(guardianOptions, publicRpcOptions()...)
+			guardianOptions = append(guardianOptions, node.GuardianOptionPublicRpcSocket(*publicGRPCSocketPath, publicRpcLogDetail))
+
+			if shouldStart(publicRPC) {
+				guardianOptions = append(guardianOptions, node.GuardianOptionPublicrpcTcpService(*publicRPC, publicRpcLogDetail, ipMode, publicRpcReflectionEnabled(cmd, env)))
+			}
+
+			if shouldStart(publicWeb) {
+				guardianOptions = append(guardianOptions,
+					node.GuardianOptionPublicWeb(*publicWeb, *publicGRPCSocketPath, *tlsHostname, *tlsProdEnv, path.Join(*dataDir, "autocert"), ipMode),
+				)
+			}
 		}
 	}
 
+	if dbBackupConfig != nil {
+		guardianOptions = append(guardianOptions, node.GuardianOptionDatabaseBackup(*dbBackupConfig))
+	}
+	if stateDbBackupConfig != nil {
+		guardianOptions = append(guardianOptions, node.GuardianOptionStateDatabaseBackup(*stateDbBackupConfig))
+	}
+	guardianOptions = append(guardianOptions, node.GuardianOptionDatabaseRetention(dbRetentionByClass))
+	if *dbMetricsInterval > 0 {
+		guardianOptions = append(guardianOptions, node.GuardianOptionDatabaseMetrics(*dbMetricsInterval))
+	}
+
 	// Run supervisor with Guardian Node as root.
-	supervisor.New(rootCtx, logger, guardianNode.Run(rootCtxCancel, guardianOptions...),
+	supervisorExited = false
+	sup := supervisor.New(rootCtx, logger, guardianNode.Run(rootCtxCancel, guardianOptions...),
 		// It's safer to crash and restart the process in case we encounter a panic,
 		// rather than attempting to reschedule the runnable.
 		supervisor.WithPropagatePanic)
 
 	<-rootCtx.Done()
 	logger.Info("root context cancelled, exiting...")
+
+	// Build the shutdown report only once the processor, governor and watchers have returned, so it reflects their final state.
+	select {
+	case <-sup.Done():
+		supervisorExited = true
+	case <-time.After(supervisorShutdownTimeout):
+		logger.Warn("timed out waiting for the supervision tree to exit, the shutdown report may be incomplete",
+			zap.Duration("timeout", supervisorShutdownTimeout))
+	}
+
+	shutdownReason := "root context cancelled"
+	select {
+	case <-sigtermReceived:
+		shutdownReason = "sigterm"
+	default:
+	}
+
+	report := guardianNode.ShutdownReport(shutdownReason, startTime, time.Now())
+	if err := node.WriteShutdownReport(logger, path.Join(*dataDir, node.ShutdownReportFilename), report); err != nil {
+		logger.Error("failed to write shutdown report", zap.Error(err))
+	}
+}
+
+// openDatabase opens the database configured by the --dataDir, --inMemoryDb and --db* flags.
+func openDatabase(logger *zap.Logger) *db.Database {
+	dbDir := dataDir
+	if *inMemoryDb {
+		logger.Warn("using an in-memory database, signed VAAs and governor state will be lost on shutdown")
+		dbDir = nil
+	}
+	dbOpts := databaseOptions(logger)
+	logger.Info("opening database", zap.String("backend", string(dbOpts.Backend)))
+	return db.OpenDb(logger, dbDir, dbOpts)
+}
+
+// openStateDatabase opens the governor and accountant state database next to mainDb and moves the state still kept in mainDb into
+// it. If --separateStateDb is disabled, it moves the state of an existing state database back into mainDb and returns nil.
+func openStateDatabase(logger *zap.Logger, mainDb *db.Database) *db.Database {
+	dbOpts := databaseOptions(logger)
+	dbOpts.Name = db.StateDatabase
+	if !*separateStateDb {
+		if *inMemoryDb || !db.Exists(*dataDir, dbOpts) {
+			return nil
+		}
+		stateDb := db.OpenDb(logger, dataDir, dbOpts)
+		defer stateDb.Close()
+		moved, err := stateDb.MoveState(mainDb)
+		if err != nil {
+			logger.Fatal("failed to move the governor and accountant state back into the main database", zap.Error(err))
+		}
+		logger.Info("moved the governor and accountant state back into the main database", zap.Int("keys", moved))
+		return nil
+	}
+
+	dbDir := dataDir
+	if *inMemoryDb {
+		dbDir = nil
+	}
+	logger.Info("opening state database", zap.String("backend", string(dbOpts.Backend)))
+	stateDb := db.OpenDb(logger, dbDir, dbOpts)
+	moved, err := mainDb.MoveState(stateDb)
+	if err != nil {
+		logger.Fatal("failed to move the governor and accountant state into the state database", zap.Error(err))
+	}
+	if moved != 0 {
+		logger.Info("moved the governor and accountant state into the state database", zap.Int("keys", moved))
+	}
+	return stateDb
+}
+
+// databaseOptions returns the options of the databases configured by the --db* flags.
+func databaseOptions(logger *zap.Logger) db.Options {
+	backend, err := db.ParseBackend(*dbBackend)
+	if err != nil {
+		logger.Fatal("invalid --dbBackend", zap.Error(err))
+	}
+	dbOpts := db.Options{
+		Backend: backend,
+		Badger: db.BadgerOptions{
+			GCInterval:     *dbGCInterval,
+			GCDiscardRatio: *dbGCDiscardRatio,
+			NumCompactors:  *dbNumCompactors,
+		},
+	}
+	if err := dbOpts.Validate(); err != nil {
+		logger.Fatal("invalid database options", zap.Error(err))
+	}
+	return dbOpts
+}
+
+func parsePublicRpcLogDetail(logger *zap.Logger) common.GrpcLogDetail {
+	switch *publicRpcLogDetailStr {
+	case "none":
+		return common.GrpcLogDetailNone
+	case "minimal":
+		return common.GrpcLogDetailMinimal
+	case "full":
+		return common.GrpcLogDetailFull
+	default:
+		logger.Fatal("--publicRpcLogDetail should be one of (none, minimal, full)")
+		return common.GrpcLogDetailNone
+	}
+}
+
+// publicRpcOptions returns the options limiting the rate of the public RPC if --publicRateLimit is set, and logging its slow calls if
+// --publicRpcSlowRequestThreshold is set. They must come before the options of the public RPC services.
+func publicRpcOptions() []*node.GuardianOption {
+	var options []*node.GuardianOption
+	if *publicRateLimit != 0 {
+		options = append(options, node.GuardianOptionPublicRateLimit(publicrpc.RateLimitConfig{
+			Rate:    *publicRateLimit,
+			Burst:   *publicRateLimitBurst,
+			Weights: *publicRateLimitWeights,
+		}))
+	}
+	if *publicRpcSlowRequestThreshold != 0 {
+		options = append(options, node.GuardianOptionPublicSlowRequestLog(*publicRpcSlowRequestThreshold))
+	}
+	return options
+}
+
+// publicRpcReflectionEnabled returns whether server reflection is enabled on the public RPC, which is by default only the case outside
+// of mainnet.
+func publicRpcReflectionEnabled(cmd *cobra.Command, env common.Environment) bool {
+	if cmd.Flags().Changed("publicRpcReflection") {
+		return *publicRpcReflection
+	}
+	return env != common.MainNet
 }
 
 func shouldStart(rpc *string) bool {
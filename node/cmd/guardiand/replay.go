@@ -0,0 +1,168 @@
+package guardiand
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/replay"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"go.uber.org/zap"
+)
+
+var (
+	replayInput         *string
+	replayOutput        *string
+	replayGuardianKey   *string
+	replayGovernor      *bool
+	replayUnsafeDevMode *bool
+	replayOldBinary     *string
+	replayNewBinary     *string
+)
+
+func init() {
+	replayFlags := pflag.NewFlagSet("replayFlags", pflag.ContinueOnError)
+	replayInput = replayFlags.String("input", "", "Recording of watcher and gossip messages to replay (required)")
+	replayGuardianKey = replayFlags.String("guardianKey", "", "Path to the guardian key of the node that made the recording (required)")
+	replayGovernor = replayFlags.Bool("governor", false, "Run the chain governor, using the mainnet token list with its built-in prices")
+	replayUnsafeDevMode = replayFlags.Bool("unsafeDevMode", false, "Accept a devnet guardian key")
+	ReplayRunCmd.Flags().AddFlagSet(replayFlags)
+	ReplayCompareCmd.Flags().AddFlagSet(replayFlags)
+	replayOutput = ReplayRunCmd.Flags().String("output", "", "Path to write the decisions to as JSON (stdout if blank)")
+	replayOldBinary = ReplayCompareCmd.Flags().String("old", "", "Path to the guardiand binary currently deployed (required)")
+	replayNewBinary = ReplayCompareCmd.Flags().String("new", "", "Path to the guardiand binary to be deployed (required)")
+
+	ReplayCmd.AddCommand(ReplayRunCmd)
+	ReplayCmd.AddCommand(ReplayDiffCmd)
+	ReplayCmd.AddCommand(ReplayCompareCmd)
+}
+
+var ReplayCmd = &cobra.Command{
+	Use:   "replay",
+	Short: "Replay recorded messages through the processor to validate upgrades",
+}
+
+var ReplayRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Replay a recording and write out the signing decisions, stored VAAs and governor actions",
+	Run:   runReplayRun,
+	Args:  cobra.NoArgs,
+}
+
+var ReplayDiffCmd = &cobra.Command{
+	Use:   "diff [OLD] [NEW]",
+	Short: "Compare the decisions written by two replays, exiting with an error if they differ",
+	Run:   runReplayDiff,
+	Args:  cobra.ExactArgs(2),
+}
+
+var ReplayCompareCmd = &cobra.Command{
+	Use:   "compare",
+	Short: "Replay a recording with an old and a new guardiand binary and compare their decisions",
+	Run:   runReplayCompare,
+	Args:  cobra.NoArgs,
+}
+
+func runReplayRun(cmd *cobra.Command, args []string) {
+	if *replayInput == "" || *replayGuardianKey == "" {
+		log.Fatal("--input and --guardianKey are required")
+	}
+	gk, err := common.LoadGuardianKey(*replayGuardianKey, *replayUnsafeDevMode)
+	if err != nil {
+		log.Fatalf("failed to load guardian key: %v", err)
+	}
+	f, err := os.Open(*replayInput)
+	if err != nil {
+		log.Fatalf("failed to open recording: %v", err)
+	}
+	records, err := replay.ReadRecords(f)
+	f.Close()
+	if err != nil {
+		log.Fatalf("failed to read recording: %v", err)
+	}
+
+	// The processor logs every message it handles, which is of no use here.
+	res, err := replay.Run(context.Background(), zap.NewNop(), records, replay.Config{GuardianKey: gk, Governor: *replayGovernor})
+	if err != nil {
+		log.Fatalf("replay failed: %v", err)
+	}
+
+	b, err := json.MarshalIndent(res, "", "  ")
+	if err != nil {
+		log.Fatalf("failed to marshal result: %v", err)
+	}
+	if *replayOutput == "" {
+		fmt.Println(string(b))
+		return
+	}
+	if err := os.WriteFile(*replayOutput, b, 0600); err != nil {
+		log.Fatalf("failed to write result: %v", err)
+	}
+	log.Printf("replayed %d records: %d observations signed, %d VAAs stored, %d messages enqueued by the governor",
+		len(records), len(res.Observations), len(res.VAAs), len(res.GovernorEnqueued))
+}
+
+func runReplayDiff(cmd *cobra.Command, args []string) {
+	reportReplayDiff(readReplayResult(args[0]), readReplayResult(args[1]))
+}
+
+func runReplayCompare(cmd *cobra.Command, args []string) {
+	if *replayOldBinary == "" || *replayNewBinary == "" {
+		log.Fatal("--old and --new are required")
+	}
+	dir, err := os.MkdirTemp("", "guardiand-replay")
+	if err != nil {
+		log.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	results := make([]*replay.Result, 2)
+	for i, binary := range []string{*replayOldBinary, *replayNewBinary} {
+		output := filepath.Join(dir, fmt.Sprintf("result-%d.json", i))
+		runArgs := []string{"replay", "run", "--input", *replayInput, "--guardianKey", *replayGuardianKey, "--output", output}
+		if *replayGovernor {
+			runArgs = append(runArgs, "--governor")
+		}
+		if *replayUnsafeDevMode {
+			runArgs = append(runArgs, "--unsafeDevMode")
+		}
+		c := exec.Command(binary, runArgs...) // #nosec G204 -- the binaries are chosen by the operator running the comparison
+		c.Stdout = os.Stdout
+		c.Stderr = os.Stderr
+		if err := c.Run(); err != nil {
+			log.Fatalf("replay with %s failed: %v", binary, err)
+		}
+		results[i] = readReplayResult(output)
+	}
+	reportReplayDiff(results[0], results[1])
+}
+
+func readReplayResult(path string) *replay.Result {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatalf("failed to read result: %v", err)
+	}
+	var res replay.Result
+	if err := json.Unmarshal(b, &res); err != nil {
+		log.Fatalf("failed to parse result %s: %v", path, err)
+	}
+	return &res
+}
+
+func reportReplayDiff(old, new *replay.Result) {
+	diffs := replay.Diff(old, new)
+	if len(diffs) == 0 {
+		fmt.Println("no differences")
+		return
+	}
+	for _, d := range diffs {
+		fmt.Println(d)
+	}
+	log.Fatalf("found %d differences", len(diffs))
+}
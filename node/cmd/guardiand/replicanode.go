@@ -0,0 +1,146 @@
+package guardiand
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path"
+	"syscall"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/dbreplication"
+	"github.com/certusone/wormhole/node/pkg/node"
+	"github.com/certusone/wormhole/node/pkg/supervisor"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	ipfslog "github.com/ipfs/go-log/v2"
+)
+
+var (
+	dbReplicaSource         *string
+	dbReplicaCAFile         *string
+	dbReplicaCertFile       *string
+	dbReplicaKeyFile        *string
+	dbReplicaGuardianSets   *string
+	dbReplicaResyncLookback *time.Duration
+)
+
+func init() {
+	dbReplicaSource = ReplicaNodeCmd.Flags().String("dbReplicaSource", "", "Address of the --dbReplicationListenAddr of the guardian to replicate (required)")
+	dbReplicaCAFile = ReplicaNodeCmd.Flags().String("dbReplicaCAFile", "", "PEM encoded CAs the certificate of the guardian is verified against (the system roots if blank)")
+	dbReplicaCertFile = ReplicaNodeCmd.Flags().String("dbReplicaCertFile", "", "PEM encoded client certificate presented to the guardian, if it requires one")
+	dbReplicaKeyFile = ReplicaNodeCmd.Flags().String("dbReplicaKeyFile", "", "PEM encoded key of --dbReplicaCertFile")
+	dbReplicaGuardianSets = ReplicaNodeCmd.Flags().String("dbReplicaGuardianSets", "", `JSON file of the guardian sets the signatures of the replicated VAAs are verified against, e.g. [{"index": 0, "keys": ["0x..."]}] (required)`)
+	dbReplicaResyncLookback = ReplicaNodeCmd.Flags().Duration("dbReplicaResyncLookback", dbreplication.DefaultResyncLookback,
+		"How far before the last checkpoint to backfill VAAs from when reconnecting to the guardian, to catch VAAs stored long after their timestamp")
+}
+
+// ReplicaNodeCmd serves the public RPC from a read-only replica of the database of a guardian, so that heavy public RPC traffic
+// does not reach the guardian. It takes the same flags as NodeCmd, but only uses the ones for the database, the status server
+// and the public RPC, and needs no keys. It verifies the VAAs it replicates against the guardian sets of --dbReplicaGuardianSets.
+var ReplicaNodeCmd = &cobra.Command{
+	Use:               "replica-node",
+	Short:             "Run a node that serves the public RPC from a read-only replica of the database of a guardian",
+	PersistentPreRunE: initConfig,
+	Run:               runReplicaNode,
+}
+
+func runReplicaNode(cmd *cobra.Command, args []string) {
+	if Build == "dev" && !*unsafeDevMode {
+		fmt.Println("This is a development build. --unsafeDevMode must be enabled.")
+		os.Exit(1)
+	}
+
+	common.SetRestrictiveUmask()
+
+	lvl, err := ipfslog.LevelFromString(*logLevel)
+	if err != nil {
+		fmt.Println("Invalid log level")
+		os.Exit(1)
+	}
+	logger := zap.New(zapcore.NewCore(
+		consoleEncoder{zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig())},
+		zapcore.AddSync(zapcore.Lock(os.Stderr)),
+		zap.NewAtomicLevelAt(zapcore.Level(lvl))))
+
+	if *dbReplicaSource == "" {
+		logger.Fatal("Please specify --dbReplicaSource")
+	}
+	if *dataDir == "" {
+		logger.Fatal("Please specify --dataDir")
+	}
+	if *dbReplicaGuardianSets == "" {
+		logger.Fatal("Please specify --dbReplicaGuardianSets")
+	}
+	guardianSets, err := loadGuardianSets(*dbReplicaGuardianSets)
+	if err != nil {
+		logger.Fatal("invalid --dbReplicaGuardianSets", zap.Error(err))
+	}
+	tlsConfig, err := dbreplication.ClientTLSConfig(*dbReplicaCAFile, *dbReplicaCertFile, *dbReplicaKeyFile)
+	if err != nil {
+		logger.Fatal("invalid --dbReplicaCAFile, --dbReplicaCertFile or --dbReplicaKeyFile", zap.Error(err))
+	}
+	if !shouldStart(publicGRPCSocketPath) {
+		logger.Fatal("Please specify --publicGRPCSocket")
+	}
+	if *unsafeDevMode && *testnetMode {
+		logger.Fatal("Cannot be in unsafeDevMode and testnetMode at the same time.")
+	}
+	publicRpcLogDetail := parsePublicRpcLogDetail(logger)
+	ipMode, err := common.ParseIPMode(*ipModeStr)
+	if err != nil {
+		logger.Fatal("Invalid value for --ipMode", zap.Error(err))
+	}
+
+	env := common.MainNet
+	if *unsafeDevMode {
+		env = common.UnsafeDevNet
+	} else if *testnetMode {
+		env = common.TestNet
+	}
+
+	db := openDatabase(logger)
+	defer db.Close()
+
+	rootCtx, rootCtxCancel = context.WithCancel(context.Background())
+	defer rootCtxCancel()
+
+	sigterm := make(chan os.Signal, 1)
+	signal.Notify(sigterm, syscall.SIGTERM)
+	go func() {
+		<-sigterm
+		logger.Info("Received sigterm. exiting.")
+		rootCtxCancel()
+	}()
+
+	// The replica never signs anything, so it runs without a guardian key.
+	replicaNode := node.NewGuardianNode(env, nil)
+	options := []*node.GuardianOption{
+		node.GuardianOptionDatabase(db),
+		node.GuardianOptionDatabaseReplica(*dbReplicaSource, tlsConfig, guardianSets, *dbReplicaResyncLookback),
+		node.GuardianOptionGovernor(false),
+		node.GuardianOptionStatusServer(*statusAddr, ipMode),
+	}
+	options = append(options, publicRpcOptions()...)
+	options = append(options, node.GuardianOptionPublicRpcSocket(*publicGRPCSocketPath, publicRpcLogDetail))
+	if *dbMetricsInterval > 0 {
+		options = append(options, node.GuardianOptionDatabaseMetrics(*dbMetricsInterval))
+	}
+	if shouldStart(publicRPC) {
+		options = append(options, node.GuardianOptionPublicrpcTcpService(*publicRPC, publicRpcLogDetail, ipMode, publicRpcReflectionEnabled(cmd, env)))
+	}
+	if shouldStart(publicWeb) {
+		options = append(options,
+			node.GuardianOptionPublicWeb(*publicWeb, *publicGRPCSocketPath, *tlsHostname, *tlsProdEnv, path.Join(*dataDir, "autocert"), ipMode),
+		)
+	}
+
+	supervisor.New(rootCtx, logger, replicaNode.Run(rootCtxCancel, options...), supervisor.WithPropagatePanic)
+
+	<-rootCtx.Done()
+	logger.Info("root context cancelled, exiting...")
+}
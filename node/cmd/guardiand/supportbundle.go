@@ -0,0 +1,175 @@
+package guardiand
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	nodev1 "github.com/certusone/wormhole/node/pkg/proto/node/v1"
+	"github.com/certusone/wormhole/node/pkg/supportbundle"
+	"github.com/certusone/wormhole/node/pkg/version"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	supportBundleSocketPath *string
+	supportBundleStatusAddr *string
+	supportBundleOutput     *string
+)
+
+func init() {
+	supportBundleSocketPath = SupportBundleCmd.Flags().String("socket", "", "gRPC admin server socket to connect to")
+	supportBundleStatusAddr = SupportBundleCmd.Flags().String("statusAddr", "localhost:6060", "Address of the node's status server, to collect metrics from (skipped if blank)")
+	supportBundleOutput = SupportBundleCmd.Flags().String("output", "", "Path of the tarball to write (defaults to guardiand-support-<timestamp>.tar.gz)")
+
+	err := SupportBundleCmd.MarkFlagRequired("socket")
+	if err != nil {
+		panic(err)
+	}
+}
+
+var SupportBundleCmd = &cobra.Command{
+	Use:   "support-bundle",
+	Short: "Collect diagnostics from a running node into a tarball to attach to bug reports",
+	Long: "Collect diagnostics from a running node into a tarball to attach to bug reports. It contains the node's " +
+		"configuration, recent logs, a metrics snapshot, the supervision tree, the status of its watchers and the pending " +
+		"observations. Secrets and the paths and queries of URLs are redacted, but review the bundle before sharing it.",
+	Run:  runSupportBundle,
+	Args: cobra.NoArgs,
+}
+
+func runSupportBundle(cmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	output := *supportBundleOutput
+	if output == "" {
+		output = fmt.Sprintf("guardiand-support-%s.tar.gz", now.UTC().Format("20060102T150405Z"))
+	}
+
+	conn, c, err := getAdminClient(ctx, *supportBundleSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	// Collection is best effort: whatever could not be collected is listed in errors.txt instead.
+	files := make(map[string][]byte)
+	var collectErrors []string
+	collect := func(name string, f func() ([]byte, error)) {
+		b, err := f()
+		if err != nil {
+			collectErrors = append(collectErrors, fmt.Sprintf("%s: %v", name, err))
+			return
+		}
+		files[name] = b
+	}
+
+	snapshot, err := c.GetSupportSnapshot(ctx, &nodev1.GetSupportSnapshotRequest{})
+	if err != nil {
+		collectErrors = append(collectErrors, fmt.Sprintf("snapshot: %v", err))
+	} else {
+		collect("config.json", func() ([]byte, error) {
+			return json.MarshalIndent(snapshot.Config, "", "  ")
+		})
+		files["logs.jsonl"] = []byte(strings.Join(snapshot.RecentLogs, "\n") + "\n")
+		var tree strings.Builder
+		for _, r := range snapshot.SupervisorTree {
+			fmt.Fprintf(&tree, "%s %s\n", r.Dn, r.State)
+		}
+		files["supervisor.txt"] = []byte(tree.String())
+		if snapshot.Heartbeat != nil {
+			collect("watchers.json", func() ([]byte, error) {
+				return marshalSupportBundleJSON(snapshot.Heartbeat)
+			})
+		}
+	}
+
+	collect("pending_observations.json", func() ([]byte, error) {
+		resp, err := c.DumpPendingObservations(ctx, &nodev1.DumpPendingObservationsRequest{})
+		if err != nil {
+			return nil, err
+		}
+		return marshalSupportBundleJSON(resp)
+	})
+
+	if *supportBundleStatusAddr != "" {
+		collect("metrics.txt", func() ([]byte, error) {
+			return fetchSupportBundleMetrics(ctx, *supportBundleStatusAddr)
+		})
+	}
+
+	info := fmt.Sprintf("created: %s\nguardiand support-bundle version: %s\n", now.UTC().Format(time.RFC3339), version.Version())
+	if snapshot != nil {
+		info += fmt.Sprintf("guardian address: %s\n", snapshot.GuardianAddress)
+	}
+	files["info.txt"] = []byte(info)
+	if len(collectErrors) != 0 {
+		files["errors.txt"] = []byte(strings.Join(collectErrors, "\n") + "\n")
+	}
+
+	if err := writeSupportBundle(output, now, files); err != nil {
+		log.Fatalf("failed to write support bundle: %v", err)
+	}
+	for _, e := range collectErrors {
+		fmt.Printf("could not collect %s\n", e)
+	}
+	fmt.Printf("wrote support bundle to %s\n", output)
+}
+
+func marshalSupportBundleJSON(m proto.Message) ([]byte, error) {
+	return protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(m)
+}
+
+func fetchSupportBundleMetrics(ctx context.Context, statusAddr string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+statusAddr+"/metrics", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(supportbundle.RedactURLs(string(b))), nil
+}
+
+func writeSupportBundle(path string, now time.Time, files map[string][]byte) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := supportbundle.NewWriter(f, now)
+	// Fixed order, so bundles are easy to compare.
+	for _, name := range []string{"info.txt", "errors.txt", "config.json", "watchers.json", "supervisor.txt", "pending_observations.json", "metrics.txt", "logs.jsonl"} {
+		b, ok := files[name]
+		if !ok {
+			continue
+		}
+		if err := w.AddFile(name, b); err != nil {
+			return err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,134 @@
+package guardiand
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/node"
+	"github.com/certusone/wormhole/node/pkg/watchers"
+	"github.com/certusone/wormhole/node/pkg/watchers/solana"
+	"github.com/spf13/cobra"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+var (
+	validateSolanaRPC          *string
+	validateSolanaContract     *string
+	validateSolanaContractHash *string
+	validateSolanaGeyser       *string
+	validateSolanaCommitments  *string
+	validateUnsafeDevMode      *bool
+	validateTestnetMode        *bool
+	validateTimeout            *time.Duration
+)
+
+func init() {
+	validateSolanaRPC = WatchersValidateCmd.Flags().String("solanaRPC", "", "Solana RPC URL")
+	validateSolanaContract = WatchersValidateCmd.Flags().String("solanaContract", "", "Address of the Solana program")
+	validateSolanaContractHash = WatchersValidateCmd.Flags().String("solanaContractHash", "", "Expected hex SHA-256 hash of the deployed Solana program (optional)")
+	validateSolanaGeyser = WatchersValidateCmd.Flags().String("solanaGeyser", "", "Solana Geyser gRPC URL")
+	validateSolanaCommitments = WatchersValidateCmd.Flags().String("solanaCommitments", "confirmed,finalized", "Comma separated list of the commitment levels of the Solana watchers")
+	validateUnsafeDevMode = WatchersValidateCmd.Flags().Bool("unsafeDevMode", false, "Validate against a devnet")
+	validateTestnetMode = WatchersValidateCmd.Flags().Bool("testnetMode", false, "Validate against testnet")
+	validateTimeout = WatchersValidateCmd.Flags().Duration("timeout", 30*time.Second, "Timeout for the checks of each watcher")
+
+	WatchersCmd.AddCommand(WatchersValidateCmd)
+}
+
+var WatchersCmd = &cobra.Command{
+	Use:   "watchers",
+	Short: "Watcher configuration tools",
+}
+
+var WatchersValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check a watcher config against the live chains before deploying it to a guardian",
+	Long: "Reads the same settings as the node command, either from flags or from the file given by --config, " +
+		"and checks RPC connectivity, chain IDs, contract deployments and finality support for every configured watcher.",
+	PreRunE: initValidateConfig,
+	Run:     runWatchersValidate,
+	Args:    cobra.NoArgs,
+}
+
+// initValidateConfig loads the file passed with --config, if any, using the same precedence rules as the node command.
+func initValidateConfig(cmd *cobra.Command, args []string) error {
+	configFile, err := cmd.Flags().GetString("config")
+	if err != nil || configFile == "" {
+		return nil
+	}
+	if _, err := os.Stat(configFile); err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	return node.InitFileConfig(cmd, node.ConfigOptions{
+		FilePath:  filepath.Dir(configFile),
+		FileName:  strings.TrimSuffix(filepath.Base(configFile), filepath.Ext(configFile)),
+		EnvPrefix: envPrefix,
+	})
+}
+
+func runWatchersValidate(cmd *cobra.Command, args []string) {
+	if *validateUnsafeDevMode && *validateTestnetMode {
+		fmt.Println("Cannot be in unsafeDevMode and testnetMode at the same time.")
+		os.Exit(1)
+	}
+
+	env := common.MainNet
+	if *validateUnsafeDevMode {
+		env = common.UnsafeDevNet
+	} else if *validateTestnetMode {
+		env = common.TestNet
+	}
+
+	var configs []watchers.ValidatableConfig
+	if *validateSolanaRPC != "" {
+		commitments, err := solana.ParseCommitments(*validateSolanaCommitments)
+		if err != nil {
+			fmt.Printf("Invalid --solanaCommitments: %v\n", err)
+			os.Exit(1)
+		}
+		for _, commitment := range commitments {
+			configs = append(configs, &solana.WatcherConfig{
+				NetworkID:            watchers.NetworkID("solana-" + string(commitment)),
+				ChainID:              vaa.ChainIDSolana,
+				Rpc:                  *validateSolanaRPC,
+				Geyser:               *validateSolanaGeyser,
+				Contract:             *validateSolanaContract,
+				Commitment:           commitment,
+				ExpectedContractHash: *validateSolanaContractHash,
+			})
+		}
+	}
+
+	if len(configs) == 0 {
+		fmt.Println("No watchers are configured.")
+		os.Exit(1)
+	}
+
+	fmt.Printf("Validating %d watcher(s) for %s\n", len(configs), env)
+	failed := 0
+	for _, wc := range configs {
+		fmt.Printf("\n%s (chain %s):\n", wc.GetNetworkID(), wc.GetChainID())
+		ctx, cancel := context.WithTimeout(context.Background(), *validateTimeout)
+		results := wc.Validate(ctx, env)
+		cancel()
+		for _, r := range results {
+			status := "PASS"
+			if !r.Passed {
+				status = "FAIL"
+				failed++
+			}
+			fmt.Printf("  [%s] %s: %s\n", status, r.Check, r.Detail)
+		}
+	}
+
+	if failed != 0 {
+		fmt.Printf("\n%d check(s) failed\n", failed)
+		os.Exit(1)
+	}
+	fmt.Println("\nAll checks passed")
+}
@@ -48,11 +48,19 @@ func init() {
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.guardiand.yaml)")
 	rootCmd.AddCommand(guardiand.NodeCmd)
+	rootCmd.AddCommand(guardiand.QueryNodeCmd)
+	rootCmd.AddCommand(guardiand.ReplicaNodeCmd)
 	rootCmd.AddCommand(spy.SpyCmd)
 	rootCmd.AddCommand(ccq.QueryServerCmd)
 	rootCmd.AddCommand(guardiand.KeygenCmd)
 	rootCmd.AddCommand(guardiand.AdminCmd)
 	rootCmd.AddCommand(guardiand.TemplateCmd)
+	rootCmd.AddCommand(guardiand.WatchersCmd)
+	rootCmd.AddCommand(guardiand.CeremonyCmd)
+	rootCmd.AddCommand(guardiand.ReplayCmd)
+	rootCmd.AddCommand(guardiand.SupportBundleCmd)
+	rootCmd.AddCommand(guardiand.GovernorConfigCmd)
+	rootCmd.AddCommand(guardiand.DBCmd)
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(debug.DebugCmd)
 }
@@ -0,0 +1,459 @@
+// The accountant checks token bridge transfers against the accounting contract before they are signed, so that no more wrapped tokens
+// can leave a chain than were sent to it, even if the chain is compromised (see whitepapers/0011_accountant.md).
+//
+// Every token bridge transfer observed by the guardian is submitted to the contract, and held back until the contract commits it.
+// Transfers which are rejected by the contract are never signed. Other messages are not affected.
+//
+// In log-only mode, transfers are submitted to the contract but signed right away, which allows running the accountant before it
+// is enforced.
+
+package accountant
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	"github.com/certusone/wormhole/node/pkg/supervisor"
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	ethCrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/wormhole-foundation/wormhole/sdk"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+const (
+	// The accountant modes, see NewAccountant.
+	EnforcingMode    = true
+	NotEnforcingMode = false
+
+	// maxBatchSize is the maximum number of observations submitted to the contract at once.
+	maxBatchSize = 10
+
+	subChanSize = 1000
+
+	// MsgChannelCapacity is the capacity of the channel the committed transfers are published on.
+	MsgChannelCapacity = 5 * maxBatchSize
+)
+
+// The names of the accountant instances, used in logs, metrics and runnable names.
+const (
+	tokenBridgeName = "token_bridge"
+	nttName         = "ntt"
+)
+
+var (
+	metricTransfersSubmitted = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "guardian_accountant_transfers_submitted_total",
+			Help: "Total number of transfers submitted to the accounting contract, by accountant",
+		}, []string{"accountant"})
+
+	metricTransfersCommitted = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "guardian_accountant_transfers_committed_total",
+			Help: "Total number of transfers committed by the accounting contract, by accountant",
+		}, []string{"accountant"})
+
+	metricTransferErrors = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "guardian_accountant_transfer_errors_total",
+			Help: "Total number of transfers rejected by the accounting contract or committed with a different digest, by accountant and cause",
+		}, []string{"accountant", "cause"})
+
+	metricSubmitFailures = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "guardian_accountant_submit_failures_total",
+			Help: "Total number of failed calls to the accounting contract, by accountant and method",
+		}, []string{"accountant", "method"})
+
+	metricCommitLatency = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "guardian_accountant_commit_latency_seconds",
+			Help:    "Time from observing a transfer until the accounting contract committed it, by accountant",
+			Buckets: []float64{1, 5, 15, 30, 60, 120, 300, 900, 3600},
+		}, []string{"accountant"})
+
+	metricOldestPendingTransferAge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "guardian_accountant_oldest_pending_transfer_age_seconds",
+			Help: "Age of the oldest transfer waiting to be committed by the accounting contract, zero if there is none, by accountant",
+		}, []string{"accountant"})
+
+	metricStuckTransfers = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "guardian_accountant_stuck_transfers",
+			Help: "Current number of transfers which have been waiting on the accounting contract for longer than the alarm age, by accountant",
+		}, []string{"accountant"})
+
+	metricPendingTransfers = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "guardian_accountant_pending_transfers",
+			Help: "Current number of transfers waiting to be committed by the accounting contract, by accountant",
+		}, []string{"accountant"})
+)
+
+// metrics are the metrics of an accountant instance.
+type metrics struct {
+	transfersSubmitted       prometheus.Counter
+	transfersCommitted       prometheus.Counter
+	transferErrors           *prometheus.CounterVec
+	submitFailures           *prometheus.CounterVec
+	commitLatency            prometheus.Observer
+	oldestPendingTransferAge prometheus.Gauge
+	stuckTransfers           prometheus.Gauge
+	pendingTransfers         prometheus.Gauge
+}
+
+func newMetrics(name string) metrics {
+	labels := prometheus.Labels{"accountant": name}
+	return metrics{
+		transfersSubmitted:       metricTransfersSubmitted.With(labels),
+		transfersCommitted:       metricTransfersCommitted.With(labels),
+		transferErrors:           metricTransferErrors.MustCurryWith(labels),
+		submitFailures:           metricSubmitFailures.MustCurryWith(labels),
+		commitLatency:            metricCommitLatency.With(labels),
+		oldestPendingTransferAge: metricOldestPendingTransferAge.With(labels),
+		stuckTransfers:           metricStuckTransfers.With(labels),
+		pendingTransfers:         metricPendingTransfers.With(labels),
+	}
+}
+
+// submitObservationPrefix separates the signatures of observations submitted to the contract from the other messages signed by
+// guardian keys.
+var submitObservationPrefix = []byte("acct_sub_obsfig_000000000000000000|")
+
+type pendingEntry struct {
+	msg    *common.MessagePublication
+	digest string
+	// observeTime is when the transfer was observed, or reloaded from the database after a restart.
+	observeTime time.Time
+	// submitTime is when the transfer was last submitted to the contract, zero if it was not submitted yet.
+	submitTime time.Time
+	// submitCount is the number of times the transfer was submitted to the contract since it was observed or reloaded.
+	submitCount uint
+	// stuck is set once the transfer is older than the alarm age, so that it is only reported once.
+	stuck bool
+	// approvals are the admins who approved releasing the transfer without the contract, see ForceReleaseTransfer.
+	approvals []string
+}
+
+type Accountant struct {
+	// name identifies the accountant instance, see tokenBridgeName and nttName.
+	name         string
+	logger       *zap.Logger
+	db           db.AccountantDB
+	contract     Contract
+	gk           *ecdsa.PrivateKey
+	gst          *common.GuardianSetState
+	guardianAddr ethCommon.Address
+	msgChan      chan<- *common.MessagePublication
+	// isAccountable returns true for the messages this accountant submits to its contract.
+	isAccountable func(msg *common.MessagePublication) bool
+	// summarize describes the payload of a transfer for the admin listing.
+	summarize   func(payload []byte) string
+	enforceFlag bool
+	policy      ResubmitPolicy
+	metrics     metrics
+	// forceReleaseApprovals is the number of approvals needed to force the release of a transfer, zero if it is disabled.
+	forceReleaseApprovals int
+
+	// pendingTransfers are the transfers submitted to the contract which are not committed yet, by message ID.
+	pendingTransfersLock sync.Mutex
+	pendingTransfers     map[string]*pendingEntry // protected by pendingTransfersLock
+
+	// subChan holds the transfers to be submitted by the worker.
+	subChan chan *common.MessagePublication
+}
+
+// NewAccountant returns an accountant which submits the token bridge transfers of env to contract, signed with gk. If enforceFlag is
+// EnforcingMode, transfers are published on msgChan once the contract commits them, otherwise they can be signed right away.
+func NewAccountant(
+	logger *zap.Logger,
+	db db.AccountantDB,
+	contract Contract,
+	gk *ecdsa.PrivateKey,
+	gst *common.GuardianSetState,
+	msgChan chan<- *common.MessagePublication,
+	env common.Environment,
+	enforceFlag bool,
+) *Accountant {
+	emitters := sdk.KnownTokenbridgeEmitters
+	if env == common.TestNet {
+		emitters = sdk.KnownTestnetTokenbridgeEmitters
+	} else if env == common.UnsafeDevNet || env == common.GoTest {
+		emitters = sdk.KnownDevnetTokenbridgeEmitters
+	}
+	tokenBridges := make(map[vaa.ChainID]vaa.Address, len(emitters))
+	for chainId, addr := range emitters {
+		tokenBridges[chainId] = vaa.Address(addr)
+	}
+
+	isTokenBridgeTransfer := func(msg *common.MessagePublication) bool {
+		emitter, exists := tokenBridges[msg.EmitterChain]
+		return exists && emitter == msg.EmitterAddress && vaa.IsTransfer(msg.Payload)
+	}
+	return newAccountant(tokenBridgeName, isTokenBridgeTransfer, summarizeTokenBridgeTransfer, logger, db, contract, gk, gst, msgChan, enforceFlag)
+}
+
+func newAccountant(
+	name string,
+	isAccountable func(msg *common.MessagePublication) bool,
+	summarize func(payload []byte) string,
+	logger *zap.Logger,
+	db db.AccountantDB,
+	contract Contract,
+	gk *ecdsa.PrivateKey,
+	gst *common.GuardianSetState,
+	msgChan chan<- *common.MessagePublication,
+	enforceFlag bool,
+) *Accountant {
+	return &Accountant{
+		name:             name,
+		logger:           logger.With(zap.String("component", "gacct"), zap.String("accountant", name)),
+		db:               db,
+		contract:         contract,
+		gk:               gk,
+		gst:              gst,
+		guardianAddr:     ethCrypto.PubkeyToAddress(gk.PublicKey),
+		msgChan:          msgChan,
+		isAccountable:    isAccountable,
+		summarize:        summarize,
+		enforceFlag:      enforceFlag,
+		policy:           DefaultResubmitPolicy(),
+		metrics:          newMetrics(name),
+		pendingTransfers: make(map[string]*pendingEntry),
+		subChan:          make(chan *common.MessagePublication, subChanSize),
+	}
+}
+
+// Start reloads the pending transfers from the database and starts submitting transfers to the contract.
+func (acct *Accountant) Start(ctx context.Context) error {
+	acct.logger.Info("starting accountant", zap.Bool("enforcing", acct.enforceFlag))
+	if err := acct.loadPendingTransfers(); err != nil {
+		return err
+	}
+	if err := supervisor.Run(ctx, acct.name+"_acctworker", acct.worker); err != nil {
+		return err
+	}
+	return supervisor.Run(ctx, acct.name+"_acctaudit", acct.audit)
+}
+
+// Name returns the name of the accountant instance, "token_bridge" or "ntt".
+func (acct *Accountant) Name() string {
+	return acct.name
+}
+
+// UseResubmitPolicy replaces the default resubmission policy. It must be called before Start.
+func (acct *Accountant) UseResubmitPolicy(policy ResubmitPolicy) {
+	acct.policy = policy
+}
+
+// IsEnforcing returns true if transfers are held back until the contract commits them.
+func (acct *Accountant) IsEnforcing() bool {
+	return acct.enforceFlag
+}
+
+// SubmitObservation submits a message observed by the guardian to the contract, if it is a transfer this accountant is responsible
+// for. It returns true if the message can be signed right away. Otherwise it is published on the message channel once the contract
+// commits it.
+func (acct *Accountant) SubmitObservation(msg *common.MessagePublication) (bool, error) {
+	if !acct.isAccountable(msg) {
+		return true, nil
+	}
+
+	msgId := msg.MessageIDString()
+	digest := msg.CreateDigest()
+
+	acct.pendingTransfersLock.Lock()
+	defer acct.pendingTransfersLock.Unlock()
+
+	if pe, exists := acct.pendingTransfers[msgId]; exists {
+		if pe.digest != digest {
+			acct.metrics.transferErrors.WithLabelValues("conflicting_observation").Inc()
+			return false, fmt.Errorf("transfer %s is already pending with digest %s, observed it again with digest %s", msgId, pe.digest, digest)
+		}
+		acct.logger.Info("transfer is already pending, ignoring it", zap.String("msgID", msgId))
+		return !acct.enforceFlag, nil
+	}
+
+	if err := acct.db.AcctStorePendingTransfer(msg); err != nil {
+		return false, fmt.Errorf("failed to store pending transfer %s: %w", msgId, err)
+	}
+	acct.pendingTransfers[msgId] = &pendingEntry{msg: msg, digest: digest, observeTime: time.Now()}
+	acct.metrics.pendingTransfers.Set(float64(len(acct.pendingTransfers)))
+	acct.logger.Info("submitting transfer to the accounting contract", zap.String("msgID", msgId), zap.String("digest", digest), zap.Bool("enforcing", acct.enforceFlag))
+
+	select {
+	case acct.subChan <- msg:
+	default:
+		// The audit submits it later.
+		acct.logger.Warn("submit channel is full, the transfer will be submitted by the audit", zap.String("msgID", msgId))
+	}
+
+	return !acct.enforceFlag, nil
+}
+
+// loadPendingTransfers reloads the transfers which were pending when the guardian stopped. They are submitted again by the audit.
+func (acct *Accountant) loadPendingTransfers() error {
+	pending, err := acct.db.AcctGetData(acct.logger)
+	if err != nil {
+		return fmt.Errorf("failed to load pending transfers: %w", err)
+	}
+
+	acct.pendingTransfersLock.Lock()
+	defer acct.pendingTransfersLock.Unlock()
+	now := time.Now()
+	for _, msg := range pending {
+		// The pending transfers of all accountant instances are stored together.
+		if !acct.isAccountable(msg) {
+			continue
+		}
+		acct.logger.Info("reloaded pending transfer", zap.String("msgID", msg.MessageIDString()))
+		acct.pendingTransfers[msg.MessageIDString()] = &pendingEntry{msg: msg, digest: msg.CreateDigest(), observeTime: now}
+	}
+	acct.metrics.pendingTransfers.Set(float64(len(acct.pendingTransfers)))
+	return nil
+}
+
+// handleStatus handles the status of a transfer returned by the contract.
+func (acct *Accountant) handleStatus(resp ObservationResponse) {
+	msgId := resp.Key.String()
+	switch resp.Status.Type {
+	case StatusCommitted:
+		acct.handleCommitted(msgId, resp.Status.Digest)
+	case StatusError:
+		acct.logger.Error("transfer was rejected by the accounting contract", zap.String("msgID", msgId), zap.String("error", resp.Status.Error))
+		acct.metrics.transferErrors.WithLabelValues("rejected").Inc()
+		acct.deletePendingTransfer(msgId)
+	case StatusPending, StatusUnknown:
+	default:
+		acct.logger.Error("unexpected transfer status from the accounting contract", zap.String("msgID", msgId), zap.String("status", resp.Status.Type))
+	}
+}
+
+// handleCommitted publishes a pending transfer once the contract committed it, if the contract committed the same transfer.
+func (acct *Accountant) handleCommitted(msgId string, digest string) {
+	acct.pendingTransfersLock.Lock()
+	pe, exists := acct.pendingTransfers[msgId]
+	acct.pendingTransfersLock.Unlock()
+	if !exists {
+		return
+	}
+
+	// SECURITY: the contract must only release the transfer we observed.
+	if pe.digest != digest {
+		acct.logger.Error("transfer was committed with a different digest than observed, not signing it",
+			zap.String("msgID", msgId), zap.String("observedDigest", pe.digest), zap.String("committedDigest", digest))
+		acct.metrics.transferErrors.WithLabelValues("digest_mismatch").Inc()
+		acct.deletePendingTransfer(msgId)
+		return
+	}
+
+	acct.logger.Info("transfer was committed by the accounting contract", zap.String("msgID", msgId), zap.Bool("enforcing", acct.enforceFlag))
+	if !acct.deletePendingTransfer(msgId) {
+		return
+	}
+	acct.metrics.transfersCommitted.Inc()
+	acct.metrics.commitLatency.Observe(time.Since(pe.observeTime).Seconds())
+	if acct.enforceFlag {
+		acct.msgChan <- pe.msg
+	}
+}
+
+// deletePendingTransfer removes a transfer from the pending transfers. It returns false if the transfer was not pending anymore.
+func (acct *Accountant) deletePendingTransfer(msgId string) bool {
+	acct.pendingTransfersLock.Lock()
+	defer acct.pendingTransfersLock.Unlock()
+	if _, exists := acct.pendingTransfers[msgId]; !exists {
+		return false
+	}
+	delete(acct.pendingTransfers, msgId)
+	acct.metrics.pendingTransfers.Set(float64(len(acct.pendingTransfers)))
+	if err := acct.db.AcctDeletePendingTransfer(msgId); err != nil {
+		acct.logger.Error("failed to delete pending transfer from the db", zap.String("msgID", msgId), zap.Error(err))
+	}
+	return true
+}
+
+// PendingTransfer is a transfer waiting to be committed by the accounting contract.
+type PendingTransfer struct {
+	MsgID  string
+	Digest string
+	// ObserveTime is when the transfer was observed, or reloaded from the database after a restart.
+	ObserveTime time.Time
+	// SubmitTime is when the transfer was last submitted to the contract, zero if it was not submitted yet.
+	SubmitTime time.Time
+	// SubmitCount is the number of times the transfer was submitted since it was observed or reloaded.
+	SubmitCount    uint
+	EmitterChain   vaa.ChainID
+	EmitterAddress vaa.Address
+	// PayloadSummary describes the transfer, e.g. its amount and recipient.
+	PayloadSummary string
+	// Approvals are the admins who approved forcing the release of the transfer.
+	Approvals []string
+}
+
+// PendingTransfers returns the transfers waiting to be committed by the accounting contract, oldest first.
+func (acct *Accountant) PendingTransfers() []PendingTransfer {
+	acct.pendingTransfersLock.Lock()
+	defer acct.pendingTransfersLock.Unlock()
+
+	pending := make([]PendingTransfer, 0, len(acct.pendingTransfers))
+	for msgId, pe := range acct.pendingTransfers {
+		pending = append(pending, PendingTransfer{
+			MsgID:          msgId,
+			Digest:         pe.digest,
+			ObserveTime:    pe.observeTime,
+			SubmitTime:     pe.submitTime,
+			SubmitCount:    pe.submitCount,
+			EmitterChain:   pe.msg.EmitterChain,
+			EmitterAddress: pe.msg.EmitterAddress,
+			PayloadSummary: acct.summarize(pe.msg.Payload),
+			Approvals:      append([]string(nil), pe.approvals...),
+		})
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		if !pending[i].ObserveTime.Equal(pending[j].ObserveTime) {
+			return pending[i].ObserveTime.Before(pending[j].ObserveTime)
+		}
+		return pending[i].MsgID < pending[j].MsgID
+	})
+	return pending
+}
+
+var (
+	// ErrTransferNotPending is returned by ResubmitTransfer if the transfer is not waiting to be committed.
+	ErrTransferNotPending = errors.New("transfer is not pending")
+	// ErrSubmitQueueFull is returned by ResubmitTransfer if too many transfers are waiting to be submitted.
+	ErrSubmitQueueFull = errors.New("too many transfers are waiting to be submitted")
+)
+
+// ResubmitTransfer submits a pending transfer to the contract again right away, and restarts its backoff.
+func (acct *Accountant) ResubmitTransfer(msgId string) error {
+	acct.pendingTransfersLock.Lock()
+	pe, exists := acct.pendingTransfers[msgId]
+	if exists {
+		pe.submitCount = 0
+	}
+	acct.pendingTransfersLock.Unlock()
+	if !exists {
+		return ErrTransferNotPending
+	}
+
+	acct.logger.Info("resubmitting transfer on request", zap.String("msgID", msgId))
+	select {
+	case acct.subChan <- pe.msg:
+		return nil
+	default:
+		return ErrSubmitQueueFull
+	}
+}
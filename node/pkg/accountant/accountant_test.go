@@ -0,0 +1,326 @@
+package accountant
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	ethCrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// mockContract records the observations submitted to it and returns the statuses set by the test.
+type mockContract struct {
+	submitted []*SubmitObservationsRequest
+	queried   []TransferKey
+	statuses  map[string]TransferStatus
+	err       error
+}
+
+func (c *mockContract) responses(keys []TransferKey) []ObservationResponse {
+	resps := make([]ObservationResponse, 0, len(keys))
+	for _, key := range keys {
+		status, exists := c.statuses[key.String()]
+		if !exists {
+			status = TransferStatus{Type: StatusPending}
+		}
+		resps = append(resps, ObservationResponse{Key: key, Status: status})
+	}
+	return resps
+}
+
+func (c *mockContract) SubmitObservations(_ context.Context, req *SubmitObservationsRequest) ([]ObservationResponse, error) {
+	c.submitted = append(c.submitted, req)
+	if c.err != nil {
+		return nil, c.err
+	}
+	var obs []Observation
+	if err := json.Unmarshal(req.Observations, &obs); err != nil {
+		return nil, err
+	}
+	keys := make([]TransferKey, 0, len(obs))
+	for _, o := range obs {
+		keys = append(keys, TransferKey{EmitterChain: o.EmitterChain, EmitterAddress: o.EmitterAddress, Sequence: o.Sequence})
+	}
+	return c.responses(keys), nil
+}
+
+func (c *mockContract) TransferStatus(_ context.Context, keys []TransferKey) ([]ObservationResponse, error) {
+	c.queried = append(c.queried, keys...)
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.responses(keys), nil
+}
+
+func newTestAccountant(t *testing.T, database *db.Database, contract Contract, enforceFlag bool) (*Accountant, chan *common.MessagePublication) {
+	t.Helper()
+	gk, err := ethCrypto.GenerateKey()
+	require.NoError(t, err)
+
+	gst := common.NewGuardianSetState(nil)
+	gst.Set(&common.GuardianSet{Keys: []ethCommon.Address{ethCrypto.PubkeyToAddress(gk.PublicKey)}, Index: 3})
+
+	msgChan := make(chan *common.MessagePublication, MsgChannelCapacity)
+	acct := NewAccountant(zap.NewNop(), database, contract, gk, gst, msgChan, common.GoTest, enforceFlag)
+	require.NoError(t, acct.loadPendingTransfers())
+	return acct, msgChan
+}
+
+func newTestDB(t *testing.T) *db.Database {
+	t.Helper()
+	database, err := db.OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	t.Cleanup(func() { database.Close() })
+	return database
+}
+
+func testTransfer(seq uint64) *common.MessagePublication {
+	return &common.MessagePublication{
+		TxHash:           ethCommon.HexToHash("0x06f541f5ecfc43407c31587aa6ac3a689e8960f36dc23c332db5510dfc6a4063"),
+		Timestamp:        time.Unix(1654516425, 0),
+		Nonce:            123456,
+		Sequence:         seq,
+		EmitterChain:     vaa.ChainIDSolana,
+		EmitterAddress:   vaa.Address(sdk.KnownDevnetTokenbridgeEmitters[vaa.ChainIDSolana]),
+		Payload:          []byte{1, 0, 0, 0, 0, 0, 0, 0},
+		ConsistencyLevel: 32,
+	}
+}
+
+func TestSubmitObservationIgnoresOtherMessages(t *testing.T) {
+	contract := &mockContract{}
+	acct, _ := newTestAccountant(t, newTestDB(t), contract, EnforcingMode)
+
+	notTransfer := testTransfer(1)
+	notTransfer.Payload = []byte{2, 0, 0, 0}
+	otherEmitter := testTransfer(2)
+	otherEmitter.EmitterAddress = vaa.Address{1}
+
+	for _, msg := range []*common.MessagePublication{notTransfer, otherEmitter} {
+		shouldPub, err := acct.SubmitObservation(msg)
+		require.NoError(t, err)
+		assert.True(t, shouldPub)
+	}
+	assert.Empty(t, acct.pendingTransfers)
+	assert.Empty(t, acct.subChan)
+}
+
+func TestEnforcingHoldsTransferUntilCommitted(t *testing.T) {
+	contract := &mockContract{statuses: map[string]TransferStatus{}}
+	acct, msgChan := newTestAccountant(t, newTestDB(t), contract, EnforcingMode)
+	msg := testTransfer(1)
+
+	shouldPub, err := acct.SubmitObservation(msg)
+	require.NoError(t, err)
+	assert.False(t, shouldPub)
+	require.Len(t, acct.subChan, 1)
+
+	// The contract has not committed it yet.
+	acct.submitObservationsToContract(context.Background(), []*common.MessagePublication{<-acct.subChan})
+	require.Len(t, contract.submitted, 1)
+	assert.Equal(t, uint32(3), contract.submitted[0].GuardianSetIndex)
+	assert.Equal(t, uint32(0), contract.submitted[0].Signature.Index)
+	assert.Empty(t, msgChan)
+	pending := acct.PendingTransfers()
+	require.Len(t, pending, 1)
+	assert.Equal(t, msg.MessageIDString(), pending[0].MsgID)
+	assert.Equal(t, msg.CreateDigest(), pending[0].Digest)
+	assert.False(t, pending[0].SubmitTime.IsZero())
+
+	// Once it is committed, the audit publishes it.
+	contract.statuses[msg.MessageIDString()] = TransferStatus{Type: StatusCommitted, Digest: msg.CreateDigest()}
+	acct.runAudit(context.Background(), time.Now())
+	require.Len(t, msgChan, 1)
+	assert.Equal(t, msg, <-msgChan)
+	assert.Empty(t, acct.PendingTransfers())
+
+	stored, err := acct.db.AcctGetData(zap.NewNop())
+	require.NoError(t, err)
+	assert.Empty(t, stored)
+}
+
+func TestNotEnforcingPublishesRightAway(t *testing.T) {
+	contract := &mockContract{statuses: map[string]TransferStatus{}}
+	acct, msgChan := newTestAccountant(t, newTestDB(t), contract, NotEnforcingMode)
+	msg := testTransfer(1)
+	contract.statuses[msg.MessageIDString()] = TransferStatus{Type: StatusCommitted, Digest: msg.CreateDigest()}
+
+	shouldPub, err := acct.SubmitObservation(msg)
+	require.NoError(t, err)
+	assert.True(t, shouldPub)
+
+	acct.submitObservationsToContract(context.Background(), []*common.MessagePublication{<-acct.subChan})
+	assert.Empty(t, acct.pendingTransfers)
+	assert.Empty(t, msgChan)
+}
+
+func TestRejectedTransfersAreNotPublished(t *testing.T) {
+	contract := &mockContract{statuses: map[string]TransferStatus{}}
+	acct, msgChan := newTestAccountant(t, newTestDB(t), contract, EnforcingMode)
+	rejected := testTransfer(1)
+	mismatch := testTransfer(2)
+	contract.statuses[rejected.MessageIDString()] = TransferStatus{Type: StatusError, Error: "insufficient balance"}
+	contract.statuses[mismatch.MessageIDString()] = TransferStatus{Type: StatusCommitted, Digest: testTransfer(3).CreateDigest()}
+
+	for _, msg := range []*common.MessagePublication{rejected, mismatch} {
+		_, err := acct.SubmitObservation(msg)
+		require.NoError(t, err)
+	}
+	acct.submitObservationsToContract(context.Background(), []*common.MessagePublication{<-acct.subChan, <-acct.subChan})
+
+	assert.Empty(t, msgChan)
+	assert.Empty(t, acct.pendingTransfers)
+}
+
+func TestConflictingObservation(t *testing.T) {
+	acct, _ := newTestAccountant(t, newTestDB(t), &mockContract{}, EnforcingMode)
+	msg := testTransfer(1)
+	_, err := acct.SubmitObservation(msg)
+	require.NoError(t, err)
+
+	// Observing the same transfer again is fine.
+	dup := *msg
+	_, err = acct.SubmitObservation(&dup)
+	require.NoError(t, err)
+
+	conflicting := *msg
+	conflicting.Payload = []byte{1, 1, 1, 1, 1, 1, 1, 1}
+	_, err = acct.SubmitObservation(&conflicting)
+	require.Error(t, err)
+	assert.Len(t, acct.pendingTransfers, 1)
+}
+
+func TestAuditResubmitsPendingTransfers(t *testing.T) {
+	database := newTestDB(t)
+	contract := &mockContract{err: errors.New("contract unavailable")}
+	acct, _ := newTestAccountant(t, database, contract, EnforcingMode)
+	msg := testTransfer(1)
+	_, err := acct.SubmitObservation(msg)
+	require.NoError(t, err)
+	acct.submitObservationsToContract(context.Background(), []*common.MessagePublication{<-acct.subChan})
+	require.Len(t, contract.submitted, 1)
+
+	// After a restart, the pending transfer is reloaded and submitted again by the audit.
+	contract = &mockContract{statuses: map[string]TransferStatus{msg.MessageIDString(): {Type: StatusCommitted, Digest: msg.CreateDigest()}}}
+	acct, msgChan := newTestAccountant(t, database, contract, EnforcingMode)
+	require.Contains(t, acct.pendingTransfers, msg.MessageIDString())
+
+	acct.runAudit(context.Background(), time.Now())
+	assert.Len(t, contract.submitted, 1)
+	assert.Empty(t, contract.queried)
+	require.Len(t, msgChan, 1)
+	assert.Equal(t, msg, <-msgChan)
+}
+
+func TestAuditQueriesRecentlySubmittedTransfers(t *testing.T) {
+	contract := &mockContract{statuses: map[string]TransferStatus{}}
+	acct, _ := newTestAccountant(t, newTestDB(t), contract, EnforcingMode)
+	acct.UseResubmitPolicy(ResubmitPolicy{AuditInterval: time.Second, InitialDelay: time.Minute, Backoff: 2, MaxDelay: 3 * time.Minute})
+	msg := testTransfer(1)
+	_, err := acct.SubmitObservation(msg)
+	require.NoError(t, err)
+	acct.submitObservationsToContract(context.Background(), []*common.MessagePublication{<-acct.subChan})
+
+	// The submission times are taken from the wall clock, so the audits must leave some slack around the delays.
+	now := time.Now()
+	acct.runAudit(context.Background(), now)
+	assert.Len(t, contract.submitted, 1)
+	assert.Equal(t, []TransferKey{transferKeyFromMsg(msg)}, contract.queried)
+
+	acct.runAudit(context.Background(), now.Add(time.Minute+time.Second))
+	assert.Len(t, contract.submitted, 2)
+
+	// The delay doubles after every submission, up to the max delay.
+	acct.runAudit(context.Background(), now.Add(110*time.Second))
+	assert.Len(t, contract.submitted, 2)
+	acct.runAudit(context.Background(), now.Add(2*time.Minute+time.Second))
+	assert.Len(t, contract.submitted, 3)
+	acct.runAudit(context.Background(), now.Add(170*time.Second))
+	assert.Len(t, contract.submitted, 3)
+	acct.runAudit(context.Background(), now.Add(3*time.Minute+time.Second))
+	assert.Len(t, contract.submitted, 4)
+	assert.Equal(t, uint(4), acct.PendingTransfers()[0].SubmitCount)
+}
+
+func TestResubmitTransfer(t *testing.T) {
+	contract := &mockContract{statuses: map[string]TransferStatus{}}
+	acct, _ := newTestAccountant(t, newTestDB(t), contract, EnforcingMode)
+	msg := testTransfer(1)
+	require.ErrorIs(t, acct.ResubmitTransfer(msg.MessageIDString()), ErrTransferNotPending)
+
+	_, err := acct.SubmitObservation(msg)
+	require.NoError(t, err)
+	acct.submitObservationsToContract(context.Background(), []*common.MessagePublication{<-acct.subChan})
+	acct.submitObservationsToContract(context.Background(), []*common.MessagePublication{msg})
+	assert.Equal(t, uint(2), acct.PendingTransfers()[0].SubmitCount)
+
+	require.NoError(t, acct.ResubmitTransfer(msg.MessageIDString()))
+	require.Len(t, acct.subChan, 1)
+	acct.submitObservationsToContract(context.Background(), []*common.MessagePublication{<-acct.subChan})
+	assert.Len(t, contract.submitted, 3)
+	assert.Equal(t, uint(1), acct.PendingTransfers()[0].SubmitCount)
+}
+
+func TestStuckTransfersAreReportedOnce(t *testing.T) {
+	acct, _ := newTestAccountant(t, newTestDB(t), &mockContract{statuses: map[string]TransferStatus{}}, EnforcingMode)
+	msg := testTransfer(1)
+	_, err := acct.SubmitObservation(msg)
+	require.NoError(t, err)
+
+	now := time.Now()
+	acct.runAudit(context.Background(), now)
+	assert.False(t, acct.pendingTransfers[msg.MessageIDString()].stuck)
+
+	acct.runAudit(context.Background(), now.Add(acct.policy.AlarmAge+time.Second))
+	assert.True(t, acct.pendingTransfers[msg.MessageIDString()].stuck)
+}
+
+func TestResubmitPolicy(t *testing.T) {
+	require.NoError(t, DefaultResubmitPolicy().Validate())
+
+	p := ResubmitPolicy{AuditInterval: time.Second, InitialDelay: time.Minute, Backoff: 3, MaxDelay: 5 * time.Minute}
+	assert.Equal(t, time.Minute, p.resubmitDelay(0))
+	assert.Equal(t, time.Minute, p.resubmitDelay(1))
+	assert.Equal(t, 3*time.Minute, p.resubmitDelay(2))
+	assert.Equal(t, 5*time.Minute, p.resubmitDelay(3))
+	assert.Equal(t, 5*time.Minute, p.resubmitDelay(1000))
+
+	p.MaxDelay = 0
+	assert.Equal(t, time.Duration(math.MaxInt64), p.resubmitDelay(1000))
+
+	for _, invalid := range []ResubmitPolicy{
+		{AuditInterval: 0, InitialDelay: time.Minute, Backoff: 1},
+		{AuditInterval: time.Second, InitialDelay: 0, Backoff: 1},
+		{AuditInterval: time.Second, InitialDelay: time.Minute, Backoff: 0.5},
+		{AuditInterval: time.Second, InitialDelay: time.Minute, Backoff: 1, MaxDelay: time.Second},
+		{AuditInterval: time.Second, InitialDelay: time.Minute, Backoff: 1, AlarmAge: -time.Second},
+	} {
+		assert.Error(t, invalid.Validate(), "%+v", invalid)
+	}
+}
+
+func TestSignObservations(t *testing.T) {
+	gk, err := ethCrypto.GenerateKey()
+	require.NoError(t, err)
+
+	req, err := signObservations(gk, []*common.MessagePublication{testTransfer(1)}, 3, 7)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(3), req.GuardianSetIndex)
+	assert.Equal(t, uint32(7), req.Signature.Index)
+
+	digest := ethCrypto.Keccak256Hash(append(append([]byte{}, submitObservationPrefix...), req.Observations...))
+	pubKey, err := ethCrypto.SigToPub(digest.Bytes(), req.Signature.Signature)
+	require.NoError(t, err)
+	assert.Equal(t, ethCrypto.PubkeyToAddress(gk.PublicKey), ethCrypto.PubkeyToAddress(*pubKey))
+}
@@ -0,0 +1,168 @@
+// This file contains the interface to the accounting contract and its HTTP implementation.
+//
+// The accountant talks to the accounting contract through an HTTP endpoint exposed by the contract's deployment, which accepts JSON:
+//
+//	POST <url>/submit_observations  SubmitObservationsRequest  -> []ObservationResponse
+//	POST <url>/transfer_status      TransferStatusRequest      -> []ObservationResponse
+//
+// Observations are submitted as the JSON encoding of a list of Observation, signed by the guardian (see signObservations). The
+// contract verifies the signature against the guardian set, and commits a transfer once a quorum of guardians observed it, unless
+// that would leave one of its accounts with a negative balance.
+
+package accountant
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+const contractTimeout = 10 * time.Second
+
+// Contract is the accounting contract the accountant submits its observations to.
+type Contract interface {
+	// SubmitObservations submits signed observations and returns the status of their transfers.
+	SubmitObservations(ctx context.Context, req *SubmitObservationsRequest) ([]ObservationResponse, error)
+
+	// TransferStatus returns the status of the specified transfers.
+	TransferStatus(ctx context.Context, keys []TransferKey) ([]ObservationResponse, error)
+}
+
+// Observation is a token bridge transfer as observed by a guardian.
+type Observation struct {
+	TxHash           []byte      `json:"tx_hash"`
+	Timestamp        uint32      `json:"timestamp"`
+	Nonce            uint32      `json:"nonce"`
+	EmitterChain     uint16      `json:"emitter_chain"`
+	EmitterAddress   vaa.Address `json:"emitter_address"`
+	Sequence         uint64      `json:"sequence"`
+	ConsistencyLevel uint8       `json:"consistency_level"`
+	Payload          []byte      `json:"payload"`
+}
+
+func observationFromMsg(msg *common.MessagePublication) Observation {
+	return Observation{
+		TxHash:           msg.TxHash.Bytes(),
+		Timestamp:        uint32(msg.Timestamp.Unix()),
+		Nonce:            msg.Nonce,
+		EmitterChain:     uint16(msg.EmitterChain),
+		EmitterAddress:   msg.EmitterAddress,
+		Sequence:         msg.Sequence,
+		ConsistencyLevel: msg.ConsistencyLevel,
+		Payload:          msg.Payload,
+	}
+}
+
+// SignatureBySetIndex is the signature of a guardian, identified by its index in the guardian set.
+type SignatureBySetIndex struct {
+	Index     uint32 `json:"index"`
+	Signature []byte `json:"signature"`
+}
+
+// SubmitObservationsRequest submits the JSON encoding of a list of observations, signed by a guardian of the guardian set.
+type SubmitObservationsRequest struct {
+	Observations     []byte              `json:"observations"`
+	GuardianSetIndex uint32              `json:"guardian_set_index"`
+	Signature        SignatureBySetIndex `json:"signature"`
+}
+
+// TransferKey identifies a transfer in the contract.
+type TransferKey struct {
+	EmitterChain   uint16      `json:"emitter_chain"`
+	EmitterAddress vaa.Address `json:"emitter_address"`
+	Sequence       uint64      `json:"sequence"`
+}
+
+// String returns the key in the format of common.MessagePublication.MessageIDString.
+func (k TransferKey) String() string {
+	return fmt.Sprintf("%v/%v/%v", k.EmitterChain, k.EmitterAddress, k.Sequence)
+}
+
+func transferKeyFromMsg(msg *common.MessagePublication) TransferKey {
+	return TransferKey{EmitterChain: uint16(msg.EmitterChain), EmitterAddress: msg.EmitterAddress, Sequence: msg.Sequence}
+}
+
+// The types of transfer statuses.
+const (
+	StatusPending   = "pending"
+	StatusCommitted = "committed"
+	StatusError     = "error"
+	StatusUnknown   = "unknown"
+)
+
+// TransferStatus is the status of a transfer in the contract. Digest is the hex-encoded signing digest of the committed transfer,
+// and Error the reason it was rejected.
+type TransferStatus struct {
+	Type   string `json:"type"`
+	Digest string `json:"digest,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ObservationResponse is the status of a transfer.
+type ObservationResponse struct {
+	Key    TransferKey    `json:"key"`
+	Status TransferStatus `json:"status"`
+}
+
+// TransferStatusRequest queries the status of transfers.
+type TransferStatusRequest struct {
+	Keys []TransferKey `json:"keys"`
+}
+
+// httpContract is a Contract reached through its HTTP endpoint.
+type httpContract struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPContract returns a Contract which talks to the accounting contract endpoint at url.
+func NewHTTPContract(url string) Contract {
+	return &httpContract{url: strings.TrimSuffix(url, "/"), client: &http.Client{Timeout: contractTimeout}}
+}
+
+func (c *httpContract) SubmitObservations(ctx context.Context, req *SubmitObservationsRequest) ([]ObservationResponse, error) {
+	var resp []ObservationResponse
+	if err := c.post(ctx, "submit_observations", req, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *httpContract) TransferStatus(ctx context.Context, keys []TransferKey) ([]ObservationResponse, error) {
+	var resp []ObservationResponse
+	if err := c.post(ctx, "transfer_status", &TransferStatusRequest{Keys: keys}, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *httpContract) post(ctx context.Context, method string, body interface{}, result interface{}) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url+"/"+method, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call %s: %w", method, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s returned status %s", method, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode the response of %s: %w", method, err)
+	}
+	return nil
+}
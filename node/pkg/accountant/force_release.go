@@ -0,0 +1,134 @@
+// This file contains the forced release of pending transfers, for emergencies such as the accounting contract being unavailable.
+//
+// Forcing the release of a transfer publishes it as if the contract had committed it, so it is guarded: it must be enabled (see
+// RequireForceReleaseApprovals), the accountant must be enforcing, and the release must be approved by the configured number of
+// distinct admins, each giving a reason. Approvals are kept in memory, so they are lost if the transfer leaves the pending list or
+// the guardian restarts. Every approval and release is recorded in the audit log kept in the database.
+
+package accountant
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/db"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+const (
+	auditActionForceReleaseApprove = "force-release-approve"
+	auditActionForceRelease        = "force-release"
+)
+
+var (
+	// ErrForceReleaseDisabled is returned by ForceReleaseTransfer if forced releases are not enabled, or the accountant is not enforcing.
+	ErrForceReleaseDisabled = errors.New("forced releases are not enabled for this accountant")
+	// ErrApproverRequired is returned by ForceReleaseTransfer if the approver is not known.
+	ErrApproverRequired = errors.New("forcing the release of a transfer requires approvals, which only authenticated callers can give")
+	// ErrReasonRequired is returned by ForceReleaseTransfer if no reason is given.
+	ErrReasonRequired = errors.New("a reason must be given to force the release of a transfer")
+	// ErrDuplicateApproval is returned by ForceReleaseTransfer if an approver approves the release of the same transfer twice.
+	ErrDuplicateApproval = errors.New("the release of this transfer has already been approved by this approver")
+)
+
+// RequireForceReleaseApprovals enables forcing the release of pending transfers once approvals distinct admins have approved it.
+// Zero disables it, which is the default. It must be called before Start.
+func (acct *Accountant) RequireForceReleaseApprovals(approvals int) {
+	acct.forceReleaseApprovals = approvals
+}
+
+// ForceReleaseApprovals returns the number of approvals needed to force the release of a transfer, zero if it is disabled.
+func (acct *Accountant) ForceReleaseApprovals() int {
+	return acct.forceReleaseApprovals
+}
+
+// ForceReleaseTransfer records the approval of approver to publish a pending transfer without waiting for the contract. Once the
+// transfer has enough approvals, it is published and removed from the pending transfers. It returns whether the transfer was
+// released and the number of approvals it has.
+func (acct *Accountant) ForceReleaseTransfer(msgId string, approver string, reason string) (bool, int, error) {
+	if acct.forceReleaseApprovals <= 0 || !acct.enforceFlag {
+		return false, 0, ErrForceReleaseDisabled
+	}
+	if approver == "" {
+		return false, 0, ErrApproverRequired
+	}
+	if strings.TrimSpace(reason) == "" {
+		return false, 0, ErrReasonRequired
+	}
+
+	acct.pendingTransfersLock.Lock()
+	defer acct.pendingTransfersLock.Unlock()
+
+	pe, exists := acct.pendingTransfers[msgId]
+	if !exists {
+		return false, 0, ErrTransferNotPending
+	}
+	for _, a := range pe.approvals {
+		if a == approver {
+			return false, len(pe.approvals), fmt.Errorf("%w: %s", ErrDuplicateApproval, approver)
+		}
+	}
+
+	now := time.Now()
+	if len(pe.approvals)+1 < acct.forceReleaseApprovals {
+		pe.approvals = append(pe.approvals, approver)
+		detail := fmt.Sprintf("%d of %d approvals: %s", len(pe.approvals), acct.forceReleaseApprovals, reason)
+		acct.recordAudit(auditActionForceReleaseApprove, msgId, approver, detail, now)
+		acct.logger.Warn("recorded approval to force the release of a pending transfer",
+			zap.String("msgID", msgId),
+			zap.String("approver", approver),
+			zap.String("reason", reason),
+			zap.Int("approvals", len(pe.approvals)),
+			zap.Int("requiredApprovals", acct.forceReleaseApprovals),
+		)
+		return false, len(pe.approvals), nil
+	}
+
+	// The transfer is only removed once it is published, so that it can still be committed by the contract if the channel is full.
+	select {
+	case acct.msgChan <- pe.msg:
+	default:
+		return false, len(pe.approvals), ErrSubmitQueueFull
+	}
+	pe.approvals = append(pe.approvals, approver)
+	delete(acct.pendingTransfers, msgId)
+	acct.metrics.pendingTransfers.Set(float64(len(acct.pendingTransfers)))
+	if err := acct.db.AcctDeletePendingTransfer(msgId); err != nil {
+		acct.logger.Error("failed to delete pending transfer from the db", zap.String("msgID", msgId), zap.Error(err))
+	}
+
+	detail := fmt.Sprintf("approved by %s: %s", strings.Join(pe.approvals, ", "), reason)
+	acct.recordAudit(auditActionForceRelease, msgId, approver, detail, now)
+	acct.logger.Warn("forced the release of a pending transfer without the accounting contract",
+		zap.String("msgID", msgId),
+		zap.String("digest", pe.digest),
+		zap.Strings("approvers", pe.approvals),
+		zap.String("reason", reason),
+	)
+	return true, len(pe.approvals), nil
+}
+
+// recordAudit stores an entry in the audit log. Failures are logged but do not fail the admin command.
+func (acct *Accountant) recordAudit(action string, msgId string, actor string, detail string, now time.Time) {
+	e := &db.AccountantAuditEntry{Timestamp: now, Accountant: acct.name, Action: action, MsgID: msgId, Actor: actor, Detail: detail}
+	if err := acct.db.AcctStoreAuditEntry(e); err != nil {
+		acct.logger.Error("failed to store accountant audit entry", zap.String("action", action), zap.String("msgID", msgId), zap.Error(err))
+	}
+}
+
+// AuditLog returns the audit log of admin actions on the pending transfers of all accountants, oldest first.
+func (acct *Accountant) AuditLog() ([]*db.AccountantAuditEntry, error) {
+	return acct.db.AcctGetAuditEntries()
+}
+
+// summarizeTokenBridgeTransfer describes a token bridge transfer. Chains are shown by ID, since most have no name in the SDK.
+func summarizeTokenBridgeTransfer(payload []byte) string {
+	hdr, err := vaa.DecodeTransferPayloadHdr(payload)
+	if err != nil {
+		return "token bridge transfer"
+	}
+	return fmt.Sprintf("transfer of %s of token %d/%s to %d/%s", hdr.Amount, hdr.OriginChain, hdr.OriginAddress, hdr.TargetChain, hdr.TargetAddress)
+}
@@ -0,0 +1,133 @@
+package accountant
+
+import (
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+func TestForceReleaseTransfer(t *testing.T) {
+	acct, msgChan := newTestAccountant(t, newTestDB(t), &mockContract{statuses: map[string]TransferStatus{}}, EnforcingMode)
+	msg := testTransfer(1)
+	msgId := msg.MessageIDString()
+	_, err := acct.SubmitObservation(msg)
+	require.NoError(t, err)
+
+	_, _, err = acct.ForceReleaseTransfer(msgId, "alice", "contract is down")
+	require.ErrorIs(t, err, ErrForceReleaseDisabled)
+
+	acct.RequireForceReleaseApprovals(2)
+	_, _, err = acct.ForceReleaseTransfer(msgId, "", "contract is down")
+	require.ErrorIs(t, err, ErrApproverRequired)
+	_, _, err = acct.ForceReleaseTransfer(msgId, "alice", " ")
+	require.ErrorIs(t, err, ErrReasonRequired)
+	_, _, err = acct.ForceReleaseTransfer(testTransfer(2).MessageIDString(), "alice", "contract is down")
+	require.ErrorIs(t, err, ErrTransferNotPending)
+
+	released, approvals, err := acct.ForceReleaseTransfer(msgId, "alice", "contract is down")
+	require.NoError(t, err)
+	assert.False(t, released)
+	assert.Equal(t, 1, approvals)
+	assert.Empty(t, msgChan)
+	assert.Equal(t, []string{"alice"}, acct.PendingTransfers()[0].Approvals)
+
+	_, _, err = acct.ForceReleaseTransfer(msgId, "alice", "contract is down")
+	require.ErrorIs(t, err, ErrDuplicateApproval)
+
+	released, approvals, err = acct.ForceReleaseTransfer(msgId, "bob", "confirmed with the contract admins")
+	require.NoError(t, err)
+	assert.True(t, released)
+	assert.Equal(t, 2, approvals)
+	require.Len(t, msgChan, 1)
+	assert.Equal(t, msg, <-msgChan)
+	assert.Empty(t, acct.PendingTransfers())
+	stored, err := acct.db.AcctGetData(zap.NewNop())
+	require.NoError(t, err)
+	assert.Empty(t, stored)
+
+	// The contract committing it later does not publish it again.
+	acct.handleCommitted(msgId, msg.CreateDigest())
+	assert.Empty(t, msgChan)
+
+	entries, err := acct.AuditLog()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, auditActionForceReleaseApprove, entries[0].Action)
+	assert.Equal(t, "alice", entries[0].Actor)
+	assert.Equal(t, "1 of 2 approvals: contract is down", entries[0].Detail)
+	assert.Equal(t, auditActionForceRelease, entries[1].Action)
+	assert.Equal(t, tokenBridgeName, entries[1].Accountant)
+	assert.Equal(t, msgId, entries[1].MsgID)
+	assert.Equal(t, "approved by alice, bob: confirmed with the contract admins", entries[1].Detail)
+}
+
+func TestForceReleaseRequiresEnforcing(t *testing.T) {
+	acct, _ := newTestAccountant(t, newTestDB(t), &mockContract{statuses: map[string]TransferStatus{}}, NotEnforcingMode)
+	acct.RequireForceReleaseApprovals(1)
+	msg := testTransfer(1)
+	_, err := acct.SubmitObservation(msg)
+	require.NoError(t, err)
+
+	_, _, err = acct.ForceReleaseTransfer(msg.MessageIDString(), "alice", "contract is down")
+	require.ErrorIs(t, err, ErrForceReleaseDisabled)
+}
+
+func TestForceReleaseWithFullChannel(t *testing.T) {
+	acct, msgChan := newTestAccountant(t, newTestDB(t), &mockContract{statuses: map[string]TransferStatus{}}, EnforcingMode)
+	acct.RequireForceReleaseApprovals(1)
+	for len(msgChan) < cap(msgChan) {
+		msgChan <- testTransfer(100)
+	}
+	msg := testTransfer(1)
+	_, err := acct.SubmitObservation(msg)
+	require.NoError(t, err)
+
+	_, _, err = acct.ForceReleaseTransfer(msg.MessageIDString(), "alice", "contract is down")
+	require.ErrorIs(t, err, ErrSubmitQueueFull)
+	pending := acct.PendingTransfers()
+	require.Len(t, pending, 1)
+	assert.Empty(t, pending[0].Approvals)
+}
+
+func TestSummarizeTransfers(t *testing.T) {
+	payload := make([]byte, 133)
+	payload[0] = 1
+	big.NewInt(1000).FillBytes(payload[1:33])
+	payload[64] = 0xaa
+	binary.BigEndian.PutUint16(payload[65:], 2)
+	payload[98] = 0xbb
+	binary.BigEndian.PutUint16(payload[99:], 1)
+	assert.Equal(t,
+		"transfer of 1000 of token 2/00000000000000000000000000000000000000000000000000000000000000aa to "+
+			"1/00000000000000000000000000000000000000000000000000000000000000bb",
+		summarizeTokenBridgeTransfer(payload))
+	assert.Equal(t, "token bridge transfer", summarizeTokenBridgeTransfer(testTransfer(1).Payload))
+
+	transfer := append([]byte{}, nttTransferPrefix...)
+	transfer = append(transfer, 8)
+	transfer = binary.BigEndian.AppendUint64(transfer, 42)
+	transfer = append(transfer, vaa.Address{31: 0xcc}.Bytes()...)
+	transfer = append(transfer, vaa.Address{31: 0xdd}.Bytes()...)
+	transfer = binary.BigEndian.AppendUint16(transfer, 2)
+	assert.Equal(t,
+		"NTT transfer of 42 (8 decimals) of token 00000000000000000000000000000000000000000000000000000000000000cc to "+
+			"2/00000000000000000000000000000000000000000000000000000000000000dd",
+		summarizeNTTTransfer(nttPayload(transfer)))
+	assert.Equal(t, "NTT transfer", summarizeNTTTransfer(testNTTTransfer(1).Payload))
+
+	acct, _ := newTestAccountant(t, newTestDB(t), &mockContract{}, EnforcingMode)
+	msg := testTransfer(1)
+	msg.Payload = payload
+	_, err := acct.SubmitObservation(msg)
+	require.NoError(t, err)
+	pending := acct.PendingTransfers()
+	require.Len(t, pending, 1)
+	assert.Equal(t, vaa.ChainIDSolana, pending[0].EmitterChain)
+	assert.Equal(t, msg.EmitterAddress, pending[0].EmitterAddress)
+	assert.Equal(t, summarizeTokenBridgeTransfer(payload), pending[0].PayloadSummary)
+}
@@ -0,0 +1,160 @@
+// This file contains the NTT accountant, which checks native token transfers (NTT) against a separate accounting contract.
+//
+// NTT managers send their transfers through a Wormhole transceiver, whose messages are laid out as:
+//
+//	prefix                  [4]byte  0x9945FF10
+//	source manager          [32]byte
+//	recipient manager       [32]byte
+//	manager payload length  uint16
+//	manager payload:
+//	  id                    [32]byte
+//	  sender                [32]byte
+//	  payload length        uint16
+//	  payload               starting with 0x994E5454 for transfers
+//
+// Since anyone can deploy a transceiver, only the messages of the configured transceiver emitters are accounted for.
+
+package accountant
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	"github.com/gagliardetto/solana-go"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+var (
+	nttTransceiverPrefix = []byte{0x99, 0x45, 0xFF, 0x10}
+	nttTransferPrefix    = []byte{0x99, 0x4E, 0x54, 0x54}
+)
+
+// NTTEmitter is the transceiver of an NTT manager, whose transfers are accounted for.
+type NTTEmitter struct {
+	Chain   vaa.ChainID
+	Address vaa.Address
+}
+
+// nttTransferOffset is the offset of the NTT transfer prefix in a transceiver message.
+const nttTransferOffset = 4 + 32 + 32 + 2 + 32 + 32 + 2
+
+// isNTTTransfer returns true if a transceiver message carries a native token transfer.
+func isNTTTransfer(payload []byte) bool {
+	if len(payload) < nttTransferOffset+len(nttTransferPrefix) || !bytes.HasPrefix(payload, nttTransceiverPrefix) {
+		return false
+	}
+
+	// The manager payload must contain the transfer.
+	managerPayloadLen := int(binary.BigEndian.Uint16(payload[68:70]))
+	transferLen := int(binary.BigEndian.Uint16(payload[134:136]))
+	if managerPayloadLen < 32+32+2+transferLen || len(payload) < 70+managerPayloadLen || transferLen < len(nttTransferPrefix) {
+		return false
+	}
+	return bytes.HasPrefix(payload[nttTransferOffset:], nttTransferPrefix)
+}
+
+// summarizeNTTTransfer describes the native token transfer carried by a transceiver message. The transfer is laid out as:
+//
+//	prefix          [4]byte  0x994E5454
+//	decimals        uint8
+//	amount          uint64
+//	source token    [32]byte
+//	recipient       [32]byte
+//	recipient chain uint16
+func summarizeNTTTransfer(payload []byte) string {
+	transfer := payload[nttTransferOffset:]
+	if len(transfer) < 4+1+8+32+32+2 {
+		return "NTT transfer"
+	}
+	decimals := transfer[4]
+	amount := binary.BigEndian.Uint64(transfer[5:13])
+	var token, to vaa.Address
+	copy(token[:], transfer[13:45])
+	copy(to[:], transfer[45:77])
+	toChain := vaa.ChainID(binary.BigEndian.Uint16(transfer[77:79]))
+	return fmt.Sprintf("NTT transfer of %d (%d decimals) of token %s to %d/%s", amount, decimals, token, toChain, to)
+}
+
+// NewNTTAccountant returns an accountant which submits the native token transfers sent through emitters to contract, signed with
+// gk. It behaves like the token bridge accountant returned by NewAccountant, and may publish on the same msgChan.
+func NewNTTAccountant(
+	logger *zap.Logger,
+	db db.AccountantDB,
+	contract Contract,
+	gk *ecdsa.PrivateKey,
+	gst *common.GuardianSetState,
+	msgChan chan<- *common.MessagePublication,
+	emitters []NTTEmitter,
+	enforceFlag bool,
+) *Accountant {
+	emitterSet := make(map[NTTEmitter]struct{}, len(emitters))
+	for _, e := range emitters {
+		emitterSet[e] = struct{}{}
+	}
+
+	isNTTMsg := func(msg *common.MessagePublication) bool {
+		_, exists := emitterSet[NTTEmitter{Chain: msg.EmitterChain, Address: msg.EmitterAddress}]
+		return exists && isNTTTransfer(msg.Payload)
+	}
+	return newAccountant(nttName, isNTTMsg, summarizeNTTTransfer, logger, db, contract, gk, gst, msgChan, enforceFlag)
+}
+
+// ParseNTTEmitters parses a comma separated list of NTT transceiver emitters of the form chain:address, such as
+// "solana:ENG1wQ7CQKH8ibAJ1hSLmJgL9Ucg6DRDbj752ZAfidLA,1:B23A1MPD9XmgMzkKy75J3nRdok6jzDo3wZBhyCxVV8S", where the chain may be
+// specified by name or by ID. Addresses are base58, or hex if they start with "0x".
+func ParseNTTEmitters(str string) ([]NTTEmitter, error) {
+	var ret []NTTEmitter
+	if str == "" {
+		return ret, nil
+	}
+
+	seen := make(map[NTTEmitter]struct{})
+	for _, entry := range strings.Split(str, ",") {
+		parts := strings.Split(strings.TrimSpace(entry), ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf(`invalid NTT emitter "%s", must be of the form chain:address`, entry)
+		}
+
+		chainID, err := vaa.ChainIDFromString(parts[0])
+		if err != nil {
+			id, err := strconv.ParseUint(parts[0], 10, 16)
+			if err != nil || id == 0 {
+				return nil, fmt.Errorf(`invalid chain in NTT emitter "%s", must be a chain name or ID`, entry)
+			}
+			chainID = vaa.ChainID(id)
+		}
+
+		addr, err := parseNTTEmitterAddress(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf(`invalid address in NTT emitter "%s": %w`, entry, err)
+		}
+
+		e := NTTEmitter{Chain: chainID, Address: addr}
+		if _, exists := seen[e]; exists {
+			return nil, fmt.Errorf(`duplicate NTT emitter "%s"`, entry)
+		}
+		seen[e] = struct{}{}
+		ret = append(ret, e)
+	}
+
+	return ret, nil
+}
+
+// parseNTTEmitterAddress parses an emitter address. We assume it is base58, but if it starts with "0x" it is hex.
+func parseNTTEmitterAddress(str string) (vaa.Address, error) {
+	if strings.HasPrefix(str, "0x") {
+		return vaa.StringToAddress(str)
+	}
+	pk, err := solana.PublicKeyFromBase58(str)
+	if err != nil {
+		return vaa.Address{}, fmt.Errorf("not valid base58: %w", err)
+	}
+	return vaa.Address(pk), nil
+}
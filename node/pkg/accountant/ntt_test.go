@@ -0,0 +1,116 @@
+package accountant
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	ethCrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+var testNTTEmitter = NTTEmitter{Chain: vaa.ChainIDSolana, Address: vaa.Address{29: 0x4e, 30: 0x54, 31: 0x54}}
+
+// nttPayload returns a transceiver message carrying an NTT manager payload with the specified inner payload.
+func nttPayload(inner []byte) []byte {
+	managerPayload := make([]byte, 32+32+2, 32+32+2+len(inner))
+	binary.BigEndian.PutUint16(managerPayload[64:], uint16(len(inner)))
+	managerPayload = append(managerPayload, inner...)
+
+	payload := append([]byte{}, nttTransceiverPrefix...)
+	payload = append(payload, make([]byte, 64)...)
+	payload = binary.BigEndian.AppendUint16(payload, uint16(len(managerPayload)))
+	return append(payload, managerPayload...)
+}
+
+func testNTTTransfer(seq uint64) *common.MessagePublication {
+	msg := testTransfer(seq)
+	msg.EmitterAddress = testNTTEmitter.Address
+	msg.Payload = nttPayload(append(append([]byte{}, nttTransferPrefix...), 1, 2, 3))
+	return msg
+}
+
+func TestIsNTTTransfer(t *testing.T) {
+	assert.True(t, isNTTTransfer(testNTTTransfer(1).Payload))
+
+	// Other manager messages are not accounted for.
+	assert.False(t, isNTTTransfer(nttPayload([]byte{1, 2, 3, 4, 5})))
+
+	// Neither are truncated or inconsistent messages.
+	payload := testNTTTransfer(1).Payload
+	assert.False(t, isNTTTransfer(payload[:len(payload)-4]))
+	assert.False(t, isNTTTransfer(payload[4:]))
+	inconsistent := append([]byte{}, payload...)
+	binary.BigEndian.PutUint16(inconsistent[134:], 100)
+	assert.False(t, isNTTTransfer(inconsistent))
+	assert.False(t, isNTTTransfer(nil))
+}
+
+func TestNTTAccountant(t *testing.T) {
+	database := newTestDB(t)
+	gk, err := ethCrypto.GenerateKey()
+	require.NoError(t, err)
+	msgChan := make(chan *common.MessagePublication, MsgChannelCapacity)
+	nttAcct := NewNTTAccountant(zap.NewNop(), database, &mockContract{}, gk, common.NewGuardianSetState(nil), msgChan, []NTTEmitter{testNTTEmitter}, EnforcingMode)
+	tbAcct, _ := newTestAccountant(t, database, &mockContract{}, EnforcingMode)
+
+	// Each accountant only holds its own transfers.
+	nttMsg := testNTTTransfer(1)
+	tbMsg := testTransfer(2)
+	otherEmitter := testNTTTransfer(3)
+	otherEmitter.EmitterAddress = vaa.Address{1}
+
+	shouldPub, err := nttAcct.SubmitObservation(nttMsg)
+	require.NoError(t, err)
+	assert.False(t, shouldPub)
+	for _, msg := range []*common.MessagePublication{tbMsg, otherEmitter} {
+		shouldPub, err = nttAcct.SubmitObservation(msg)
+		require.NoError(t, err)
+		assert.True(t, shouldPub)
+	}
+	shouldPub, err = tbAcct.SubmitObservation(nttMsg)
+	require.NoError(t, err)
+	assert.True(t, shouldPub)
+	shouldPub, err = tbAcct.SubmitObservation(tbMsg)
+	require.NoError(t, err)
+	assert.False(t, shouldPub)
+
+	// After a restart, each accountant only reloads its own transfers from the shared database.
+	nttAcct = NewNTTAccountant(zap.NewNop(), database, &mockContract{}, gk, common.NewGuardianSetState(nil), msgChan, []NTTEmitter{testNTTEmitter}, EnforcingMode)
+	require.NoError(t, nttAcct.loadPendingTransfers())
+	require.Len(t, nttAcct.PendingTransfers(), 1)
+	assert.Equal(t, nttMsg.MessageIDString(), nttAcct.PendingTransfers()[0].MsgID)
+	assert.Equal(t, nttName, nttAcct.Name())
+
+	tbAcct, _ = newTestAccountant(t, database, &mockContract{}, EnforcingMode)
+	require.Len(t, tbAcct.PendingTransfers(), 1)
+	assert.Equal(t, tbMsg.MessageIDString(), tbAcct.PendingTransfers()[0].MsgID)
+}
+
+func TestParseNTTEmitters(t *testing.T) {
+	emitters, err := ParseNTTEmitters("")
+	require.NoError(t, err)
+	assert.Empty(t, emitters)
+
+	emitters, err = ParseNTTEmitters("solana:0x4e5454, 1:ENG1wQ7CQKH8ibAJ1hSLmJgL9Ucg6DRDbj752ZAfidLA")
+	require.NoError(t, err)
+	require.Len(t, emitters, 2)
+	assert.Equal(t, testNTTEmitter, emitters[0])
+	assert.Equal(t, vaa.ChainIDSolana, emitters[1].Chain)
+	assert.Equal(t, "c69a1b1a65dd336bf1df6a77afb501fc25db7fc0938cb08595a9ef473265cb4f", emitters[1].Address.String())
+
+	for _, invalid := range []string{
+		"solana",
+		"unknown:0x4e5454",
+		"0:0x4e5454",
+		"solana:xyz",
+		"solana:c69a1b1a65dd336bf1df6a77afb501fc25db7fc0938cb08595a9ef473265cb4f",
+		"solana:0x4e5454,1:0x4e5454",
+	} {
+		_, err := ParseNTTEmitters(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
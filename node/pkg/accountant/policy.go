@@ -0,0 +1,72 @@
+package accountant
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// ResubmitPolicy controls how often the accountant checks on the pending transfers, and when it submits them to the contract again.
+type ResubmitPolicy struct {
+	// AuditInterval is how often the status of the pending transfers is queried from the contract.
+	AuditInterval time.Duration
+	// InitialDelay is the time after a transfer was submitted before it is submitted again, if it is still pending.
+	InitialDelay time.Duration
+	// Backoff is the factor by which the delay grows after every submission.
+	Backoff float64
+	// MaxDelay caps the delay between submissions, or zero for no cap.
+	MaxDelay time.Duration
+	// AlarmAge is the age from which a pending transfer is reported as stuck, or zero to never report them.
+	AlarmAge time.Duration
+}
+
+// DefaultResubmitPolicy returns the policy used when none is configured.
+func DefaultResubmitPolicy() ResubmitPolicy {
+	return ResubmitPolicy{
+		AuditInterval: 30 * time.Second,
+		InitialDelay:  5 * time.Minute,
+		Backoff:       2,
+		MaxDelay:      time.Hour,
+		AlarmAge:      time.Hour,
+	}
+}
+
+// Validate checks that the policy would result in sensible resubmission intervals.
+func (p ResubmitPolicy) Validate() error {
+	if p.AuditInterval <= 0 {
+		return errors.New("audit interval must be positive")
+	}
+	if p.InitialDelay <= 0 {
+		return errors.New("initial delay must be positive")
+	}
+	if p.Backoff < 1 || math.IsInf(p.Backoff, 0) || math.IsNaN(p.Backoff) {
+		return errors.New("backoff must be at least 1")
+	}
+	if p.MaxDelay < 0 {
+		return errors.New("max delay must not be negative")
+	}
+	if p.MaxDelay != 0 && p.MaxDelay < p.InitialDelay {
+		return errors.New("max delay must not be less than the initial delay")
+	}
+	if p.AlarmAge < 0 {
+		return errors.New("alarm age must not be negative")
+	}
+	return nil
+}
+
+// resubmitDelay returns how long to wait before submitting a transfer again, after it was submitted submitCount times.
+func (p ResubmitPolicy) resubmitDelay(submitCount uint) time.Duration {
+	exp := float64(0)
+	if submitCount > 1 {
+		exp = float64(submitCount - 1)
+	}
+	delay := time.Duration(float64(p.InitialDelay) * math.Pow(p.Backoff, exp))
+	if delay <= 0 {
+		// The multiplication overflowed.
+		delay = time.Duration(math.MaxInt64)
+	}
+	if p.MaxDelay != 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return delay
+}
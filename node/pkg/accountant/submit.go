@@ -0,0 +1,171 @@
+// This file contains the worker which submits observations to the accounting contract, and the audit which catches up on the
+// transfers whose status was missed.
+
+package accountant
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	ethCrypto "github.com/ethereum/go-ethereum/crypto"
+	"go.uber.org/zap"
+)
+
+// worker submits the transfers queued by SubmitObservation to the contract, batching the ones which are already queued.
+func (acct *Accountant) worker(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg := <-acct.subChan:
+			msgs := []*common.MessagePublication{msg}
+		batch:
+			for len(msgs) < maxBatchSize {
+				select {
+				case msg := <-acct.subChan:
+					msgs = append(msgs, msg)
+				default:
+					break batch
+				}
+			}
+			acct.submitObservationsToContract(ctx, msgs)
+		}
+	}
+}
+
+// audit periodically queries the status of the pending transfers, and submits again the ones which were not submitted recently.
+func (acct *Accountant) audit(ctx context.Context) error {
+	ticker := time.NewTicker(acct.policy.AuditInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			acct.runAudit(ctx, time.Now())
+		}
+	}
+}
+
+func (acct *Accountant) runAudit(ctx context.Context, now time.Time) {
+	var keys []TransferKey
+	var resubmit []*common.MessagePublication
+	var oldest time.Duration
+	stuck := 0
+	acct.pendingTransfersLock.Lock()
+	for msgId, pe := range acct.pendingTransfers {
+		age := now.Sub(pe.observeTime)
+		if age > oldest {
+			oldest = age
+		}
+		if acct.policy.AlarmAge != 0 && age > acct.policy.AlarmAge {
+			stuck++
+			if !pe.stuck {
+				pe.stuck = true
+				acct.logger.Error("transfer has been waiting on the accounting contract for longer than the alarm age",
+					zap.String("msgID", msgId), zap.Duration("age", age), zap.Uint("submitCount", pe.submitCount))
+			}
+		}
+		if pe.submitTime.IsZero() || now.Sub(pe.submitTime) > acct.policy.resubmitDelay(pe.submitCount) {
+			resubmit = append(resubmit, pe.msg)
+		} else {
+			keys = append(keys, transferKeyFromMsg(pe.msg))
+		}
+	}
+	acct.pendingTransfersLock.Unlock()
+	acct.metrics.oldestPendingTransferAge.Set(oldest.Seconds())
+	acct.metrics.stuckTransfers.Set(float64(stuck))
+
+	if len(keys) != 0 {
+		resps, err := acct.contract.TransferStatus(ctx, keys)
+		if err != nil {
+			acct.metrics.submitFailures.WithLabelValues("transfer_status").Inc()
+			acct.logger.Error("failed to query the status of the pending transfers", zap.Int("numTransfers", len(keys)), zap.Error(err))
+		} else {
+			for _, resp := range resps {
+				acct.handleStatus(resp)
+			}
+		}
+	}
+
+	for len(resubmit) != 0 {
+		n := len(resubmit)
+		if n > maxBatchSize {
+			n = maxBatchSize
+		}
+		acct.logger.Info("resubmitting pending transfers", zap.Int("numTransfers", n))
+		acct.submitObservationsToContract(ctx, resubmit[:n])
+		resubmit = resubmit[n:]
+	}
+}
+
+// submitObservationsToContract signs a batch of observations, submits them to the contract and handles the returned statuses.
+func (acct *Accountant) submitObservationsToContract(ctx context.Context, msgs []*common.MessagePublication) {
+	gs := acct.gst.Get()
+	if gs == nil {
+		acct.logger.Error("unable to submit observations because the guardian set is not known yet", zap.Int("numObs", len(msgs)))
+		return
+	}
+	guardianIndex, ok := gs.KeyIndex(acct.guardianAddr)
+	if !ok {
+		acct.logger.Error("unable to submit observations because we are not in the guardian set", zap.Uint32("gsIndex", gs.Index))
+		return
+	}
+
+	req, err := signObservations(acct.gk, msgs, gs.Index, uint32(guardianIndex))
+	if err != nil {
+		acct.logger.Error("failed to sign observations", zap.Error(err))
+		return
+	}
+
+	now := time.Now()
+	acct.pendingTransfersLock.Lock()
+	for _, msg := range msgs {
+		if pe, exists := acct.pendingTransfers[msg.MessageIDString()]; exists {
+			pe.submitTime = now
+			pe.submitCount++
+		}
+	}
+	acct.pendingTransfersLock.Unlock()
+
+	resps, err := acct.contract.SubmitObservations(ctx, req)
+	if err != nil {
+		// The audit submits them again.
+		acct.metrics.submitFailures.WithLabelValues("submit_observations").Inc()
+		acct.logger.Error("failed to submit observations to the accounting contract", zap.Int("numObs", len(msgs)), zap.Error(err))
+		return
+	}
+
+	acct.metrics.transfersSubmitted.Add(float64(len(msgs)))
+	for _, resp := range resps {
+		acct.handleStatus(resp)
+	}
+}
+
+// signObservations returns the request submitting msgs to the contract, signed by the guardian at guardianIndex in the guardian set.
+func signObservations(gk *ecdsa.PrivateKey, msgs []*common.MessagePublication, gsIndex uint32, guardianIndex uint32) (*SubmitObservationsRequest, error) {
+	obs := make([]Observation, 0, len(msgs))
+	for _, msg := range msgs {
+		obs = append(obs, observationFromMsg(msg))
+	}
+	bytes, err := json.Marshal(obs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal observations: %w", err)
+	}
+
+	digest := ethCrypto.Keccak256Hash(append(append([]byte{}, submitObservationPrefix...), bytes...))
+	sig, err := ethCrypto.Sign(digest.Bytes(), gk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign observations: %w", err)
+	}
+
+	return &SubmitObservationsRequest{
+		Observations:     bytes,
+		GuardianSetIndex: gsIndex,
+		Signature:        SignatureBySetIndex{Index: guardianIndex, Signature: sig},
+	}, nil
+}
@@ -14,6 +14,7 @@ import (
 	"math/big"
 	"math/rand"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -24,15 +25,27 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"golang.org/x/exp/slices"
 
+	"github.com/certusone/wormhole/node/pkg/aggsig"
 	"github.com/certusone/wormhole/node/pkg/db"
 	"github.com/certusone/wormhole/node/pkg/governor"
+	"github.com/certusone/wormhole/node/pkg/gwrelayer"
+	"github.com/certusone/wormhole/node/pkg/p2p"
+	"github.com/certusone/wormhole/node/pkg/payloads"
 	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	"github.com/certusone/wormhole/node/pkg/query"
+	"github.com/certusone/wormhole/node/pkg/readiness"
+	"github.com/certusone/wormhole/node/pkg/supervisor"
+	"github.com/certusone/wormhole/node/pkg/supportbundle"
+	"github.com/certusone/wormhole/node/pkg/vaaarchive"
+	"github.com/certusone/wormhole/node/pkg/watchers"
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/mr-tron/base58"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"golang.org/x/time/rate"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 
+	"github.com/certusone/wormhole/node/pkg/accountant"
 	"github.com/certusone/wormhole/node/pkg/common"
 	nodev1 "github.com/certusone/wormhole/node/pkg/proto/node/v1"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
@@ -54,10 +67,42 @@ type nodePrivilegedService struct {
 	logger          *zap.Logger
 	signedInC       chan<- *gossipv1.SignedVAAWithQuorum
 	governor        *governor.ChainGovernor
+	acct            *accountant.Accountant
+	nttAcct         *accountant.Accountant
+	gwRelayer       *gwrelayer.GatewayRelayer
 	gsCache         sync.Map
 	gk              *ecdsa.PrivateKey
 	guardianAddress ethcommon.Address
 	rpcMap          map[string]string
+	gst             *common.GuardianSetState
+	govStatus       *common.GovernanceStatusTracker
+	conflicts       *common.ObservationConflictTracker
+	stagedConfigs   *stagedConfigs
+	queryBudget     *query.QueryBudget
+	vaaArchiver     *vaaarchive.Archiver
+	support         *supportbundle.Sources
+	allowVAAPurge   bool
+	// supervisorTree is set once the admin service runs under the supervisor.
+	supervisorTree func() []supervisor.RunnableStatus
+	// restartRunnable restarts a runnable of the supervision tree by DN, see SetRunnableRestarter. Runnables in protectedRunnables,
+	// and their children, can't be restarted.
+	restartRunnable    func(dn string) error
+	protectedRunnables []string
+	// effectiveConfig dumps the effective configuration of the node, see SetEffectiveConfig.
+	effectiveConfig func() ([]byte, error)
+	// queueDepths lists the depth of the node's internal queues, see SetQueueDepths.
+	queueDepths func() []QueueDepth
+	// logLevels are the log levels of the node, see SetLogLevels.
+	logLevels *common.LogLevels
+	// auditor records the calls to the service, see SetAuditor.
+	auditor *Auditor
+}
+
+// QueueDepth is the number of messages waiting in one of the node's internal queues.
+type QueueDepth struct {
+	Name     string
+	Length   int
+	Capacity int
 }
 
 func NewPrivService(
@@ -67,10 +112,21 @@ func NewPrivService(
 	logger *zap.Logger,
 	signedInC chan<- *gossipv1.SignedVAAWithQuorum,
 	governor *governor.ChainGovernor,
+	acct *accountant.Accountant,
+	nttAcct *accountant.Accountant,
+	gwRelayer *gwrelayer.GatewayRelayer,
 	gk *ecdsa.PrivateKey,
 	guardianAddress ethcommon.Address,
 	rpcMap map[string]string,
-
+	gst *common.GuardianSetState,
+	govStatus *common.GovernanceStatusTracker,
+	conflicts *common.ObservationConflictTracker,
+	allowedRequesters *query.AllowedRequesters,
+	queryBudget *query.QueryBudget,
+	vaaArchiver *vaaarchive.Archiver,
+	support *supportbundle.Sources,
+	requireSecondApprover bool,
+	allowVAAPurge bool,
 ) *nodePrivilegedService {
 	return &nodePrivilegedService{
 		db:              db,
@@ -79,12 +135,65 @@ func NewPrivService(
 		logger:          logger,
 		signedInC:       signedInC,
 		governor:        governor,
+		acct:            acct,
+		nttAcct:         nttAcct,
+		gwRelayer:       gwRelayer,
 		gk:              gk,
 		guardianAddress: guardianAddress,
 		rpcMap:          rpcMap,
+		gst:             gst,
+		govStatus:       govStatus,
+		conflicts:       conflicts,
+		stagedConfigs:   newStagedConfigs(logger, requireSecondApprover, governor, allowedRequesters, gwRelayer),
+		queryBudget:     queryBudget,
+		vaaArchiver:     vaaArchiver,
+		support:         support,
+		allowVAAPurge:   allowVAAPurge,
 	}
 }
 
+// SetSupervisorTree sets the function used to list the supervision tree in support snapshots. It must be called before the
+// service starts serving.
+func (s *nodePrivilegedService) SetSupervisorTree(tree func() []supervisor.RunnableStatus) {
+	s.supervisorTree = tree
+}
+
+// SetRunnableRestarter sets the function used to restart runnables of the supervision tree by DN. The runnables with a DN in
+// protected, and their children, can't be restarted, e.g. because they serve the admin service. It must be called before the
+// service starts serving.
+func (s *nodePrivilegedService) SetRunnableRestarter(restart func(dn string) error, protected []string) {
+	s.restartRunnable = restart
+	s.protectedRunnables = protected
+}
+
+// SetWatcherConfigs makes the endpoints of the Solana watchers in configs changeable through the staged config workflow. A watcher
+// picks up its new endpoints once it is restarted, see SetRunnableRestarter. It must be called before the service starts serving.
+func (s *nodePrivilegedService) SetWatcherConfigs(configs *watchers.Configs) {
+	s.stagedConfigs.targets[configTargetSolanaEndpoints] = solanaEndpointsTarget{configs}
+}
+
+// SetEffectiveConfig sets the function used to dump the effective configuration of the node, as JSON. It must be called before the
+// service starts serving.
+func (s *nodePrivilegedService) SetEffectiveConfig(effectiveConfig func() ([]byte, error)) {
+	s.effectiveConfig = effectiveConfig
+}
+
+// SetQueueDepths sets the function used to list the depth of the node's internal queues in node health reports. It must be
+// called before the service starts serving.
+func (s *nodePrivilegedService) SetQueueDepths(depths func() []QueueDepth) {
+	s.queueDepths = depths
+}
+
+// SetLogLevels sets the log levels of the node changed by SetLogLevel. It must be called before the service starts serving.
+func (s *nodePrivilegedService) SetLogLevels(levels *common.LogLevels) {
+	s.logLevels = levels
+}
+
+// SetAuditor sets the auditor whose entries are read by AdminAuditLog. It must be called before the service starts serving.
+func (s *nodePrivilegedService) SetAuditor(auditor *Auditor) {
+	s.auditor = auditor
+}
+
 // adminGuardianSetUpdateToVAA converts a nodev1.GuardianSetUpdate message to its canonical VAA representation.
 // Returns an error if the data is invalid.
 func adminGuardianSetUpdateToVAA(req *nodev1.GuardianSetUpdate, timestamp time.Time, guardianSetIndex uint32, nonce uint32, sequence uint64) (*vaa.VAA, error) {
@@ -149,6 +258,74 @@ func adminContractUpgradeToVAA(req *nodev1.ContractUpgrade, timestamp time.Time,
 	return v, nil
 }
 
+// parseGovernanceUint256 parses a decimal formatted uint256 of a governance message.
+func parseGovernanceUint256(s string, name string) (*uint256.Int, error) {
+	b, ok := new(big.Int).SetString(s, 10)
+	if !ok || b.Sign() < 0 {
+		return nil, fmt.Errorf("invalid %s (expected a decimal uint256)", name)
+	}
+	v, overflow := uint256.FromBig(b)
+	if overflow {
+		return nil, fmt.Errorf("%s overflow", name)
+	}
+	return v, nil
+}
+
+// coreSetMessageFee converts a nodev1.CoreSetMessageFee message to its canonical VAA representation.
+// Returns an error if the data is invalid.
+func coreSetMessageFee(req *nodev1.CoreSetMessageFee, timestamp time.Time, guardianSetIndex uint32, nonce uint32, sequence uint64) (*vaa.VAA, error) {
+	if req.ChainId > math.MaxUint16 {
+		return nil, errors.New("invalid chain_id")
+	}
+
+	fee, err := parseGovernanceUint256(req.MessageFee, "message_fee")
+	if err != nil {
+		return nil, err
+	}
+
+	v := vaa.CreateGovernanceVAA(timestamp, nonce, sequence, guardianSetIndex,
+		vaa.BodyCoreSetMessageFee{
+			ChainID:    vaa.ChainID(req.ChainId),
+			MessageFee: fee,
+		}.Serialize())
+
+	return v, nil
+}
+
+// coreTransferFees converts a nodev1.CoreTransferFees message to its canonical VAA representation.
+// Returns an error if the data is invalid.
+func coreTransferFees(req *nodev1.CoreTransferFees, timestamp time.Time, guardianSetIndex uint32, nonce uint32, sequence uint64) (*vaa.VAA, error) {
+	if req.ChainId > math.MaxUint16 {
+		return nil, errors.New("invalid chain_id")
+	}
+
+	amount, err := parseGovernanceUint256(req.Amount, "amount")
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := hex.DecodeString(req.Recipient)
+	if err != nil {
+		return nil, errors.New("invalid recipient address encoding (expected hex)")
+	}
+
+	if len(b) != 32 {
+		return nil, errors.New("invalid recipient address (expected 32 bytes)")
+	}
+
+	recipient := vaa.Address{}
+	copy(recipient[:], b)
+
+	v := vaa.CreateGovernanceVAA(timestamp, nonce, sequence, guardianSetIndex,
+		vaa.BodyCoreTransferFees{
+			ChainID:   vaa.ChainID(req.ChainId),
+			Amount:    amount,
+			Recipient: recipient,
+		}.Serialize())
+
+	return v, nil
+}
+
 // tokenBridgeRegisterChain converts a nodev1.TokenBridgeRegisterChain message to its canonical VAA representation.
 // Returns an error if the data is invalid.
 func tokenBridgeRegisterChain(req *nodev1.BridgeRegisterChain, timestamp time.Time, guardianSetIndex uint32, nonce uint32, sequence uint64) (*vaa.VAA, error) {
@@ -265,6 +442,23 @@ func wormholeRelayerSetDefaultDeliveryProvider(req *nodev1.WormholeRelayerSetDef
 	return v, nil
 }
 
+// chainGovernorSetChainLimit converts a nodev1.ChainGovernorSetChainLimit message to its canonical VAA representation.
+// Returns an error if the data is invalid.
+func chainGovernorSetChainLimit(req *nodev1.ChainGovernorSetChainLimit, timestamp time.Time, guardianSetIndex uint32, nonce uint32, sequence uint64) (*vaa.VAA, error) {
+	if req.ChainId > math.MaxUint16 {
+		return nil, errors.New("invalid chain_id")
+	}
+
+	v := vaa.CreateGovernanceVAA(timestamp, nonce, sequence, guardianSetIndex,
+		vaa.BodyChainGovernorSetChainLimit{
+			EmitterChainID:     vaa.ChainID(req.ChainId),
+			DailyLimit:         req.DailyLimit,
+			BigTransactionSize: req.BigTransactionSize,
+		}.Serialize())
+
+	return v, nil
+}
+
 func GovMsgToVaa(message *nodev1.GovernanceMessage, currentSetIndex uint32, timestamp time.Time) (*vaa.VAA, error) {
 	var (
 		v   *vaa.VAA
@@ -276,6 +470,10 @@ func GovMsgToVaa(message *nodev1.GovernanceMessage, currentSetIndex uint32, time
 		v, err = adminGuardianSetUpdateToVAA(payload.GuardianSet, timestamp, currentSetIndex, message.Nonce, message.Sequence)
 	case *nodev1.GovernanceMessage_ContractUpgrade:
 		v, err = adminContractUpgradeToVAA(payload.ContractUpgrade, timestamp, currentSetIndex, message.Nonce, message.Sequence)
+	case *nodev1.GovernanceMessage_CoreSetMessageFee:
+		v, err = coreSetMessageFee(payload.CoreSetMessageFee, timestamp, currentSetIndex, message.Nonce, message.Sequence)
+	case *nodev1.GovernanceMessage_CoreTransferFees:
+		v, err = coreTransferFees(payload.CoreTransferFees, timestamp, currentSetIndex, message.Nonce, message.Sequence)
 	case *nodev1.GovernanceMessage_BridgeRegisterChain:
 		v, err = tokenBridgeRegisterChain(payload.BridgeRegisterChain, timestamp, currentSetIndex, message.Nonce, message.Sequence)
 	case *nodev1.GovernanceMessage_BridgeContractUpgrade:
@@ -284,6 +482,8 @@ func GovMsgToVaa(message *nodev1.GovernanceMessage, currentSetIndex uint32, time
 		v, err = recoverChainId(payload.RecoverChainId, timestamp, currentSetIndex, message.Nonce, message.Sequence)
 	case *nodev1.GovernanceMessage_WormholeRelayerSetDefaultDeliveryProvider:
 		v, err = wormholeRelayerSetDefaultDeliveryProvider(payload.WormholeRelayerSetDefaultDeliveryProvider, timestamp, currentSetIndex, message.Nonce, message.Sequence)
+	case *nodev1.GovernanceMessage_ChainGovernorSetChainLimit:
+		v, err = chainGovernorSetChainLimit(payload.ChainGovernorSetChainLimit, timestamp, currentSetIndex, message.Nonce, message.Sequence)
 	default:
 		panic(fmt.Sprintf("unsupported VAA type: %T", payload))
 	}
@@ -292,6 +492,10 @@ func GovMsgToVaa(message *nodev1.GovernanceMessage, currentSetIndex uint32, time
 }
 
 func (s *nodePrivilegedService) InjectGovernanceVAA(ctx context.Context, req *nodev1.InjectGovernanceVAARequest) (*nodev1.InjectGovernanceVAAResponse, error) {
+	if req.ValidateOnly {
+		return s.validateGovernanceVAAs(req)
+	}
+
 	s.logger.Info("governance VAA injected via admin socket", zap.String("request", req.String()))
 
 	var (
@@ -307,7 +511,7 @@ func (s *nodePrivilegedService) InjectGovernanceVAA(ctx context.Context, req *no
 		v, err = GovMsgToVaa(message, req.CurrentSetIndex, timestamp)
 
 		if err != nil {
-			return nil, status.Error(codes.InvalidArgument, err.Error())
+			return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidGovernanceMessage, err.Error())
 		}
 
 		// Generate digest of the unsigned VAA.
@@ -316,10 +520,16 @@ func (s *nodePrivilegedService) InjectGovernanceVAA(ctx context.Context, req *no
 		s.logger.Info("governance VAA constructed",
 			zap.Any("vaa", v),
 			zap.String("digest", digest.String()),
+			zap.String("messageId", v.MessageID()),
+			payloads.ZapField(v.EmitterChain, v.EmitterAddress, v.Payload),
 		)
 
 		vaaInjectionsTotal.Inc()
 
+		if s.govStatus != nil {
+			s.govStatus.Track(digest, v.MessageID(), s.currentGuardianSet(req.CurrentSetIndex), time.Now())
+		}
+
 		s.injectC <- &common.MessagePublication{
 			TxHash:           ethcommon.Hash{},
 			Timestamp:        v.Timestamp,
@@ -335,9 +545,57 @@ func (s *nodePrivilegedService) InjectGovernanceVAA(ctx context.Context, req *no
 		digests[i] = digest.Bytes()
 	}
 
+	digestStrs := make([]string, len(digests))
+	for i, d := range digests {
+		digestStrs[i] = hex.EncodeToString(d)
+	}
+	s.logger.Info("governance VAAs queued for signing",
+		zap.Uint32("currentSetIndex", req.CurrentSetIndex),
+		zap.Int("numMessages", len(digests)),
+		zap.Strings("digests", digestStrs),
+	)
+
 	return &nodev1.InjectGovernanceVAAResponse{Digests: digests}, nil
 }
 
+// validateGovernanceVAAs builds the VAAs of the governance messages of req like InjectGovernanceVAA, but returns them instead of
+// signing them.
+func (s *nodePrivilegedService) validateGovernanceVAAs(req *nodev1.InjectGovernanceVAARequest) (*nodev1.InjectGovernanceVAAResponse, error) {
+	timestamp := time.Unix(int64(req.Timestamp), 0)
+	resp := &nodev1.InjectGovernanceVAAResponse{}
+	for _, message := range req.Messages {
+		v, err := GovMsgToVaa(message, req.CurrentSetIndex, timestamp)
+		if err != nil {
+			return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidGovernanceMessage, err.Error())
+		}
+		b, err := v.Marshal()
+		if err != nil {
+			return nil, common.NewGrpcError(codes.Internal, common.ReasonInternal, err.Error())
+		}
+		decoded, err := payloads.DecodeGovernance(v.Payload)
+		if err != nil {
+			return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidGovernanceMessage, fmt.Sprintf("failed to decode payload: %v", err))
+		}
+
+		validated := &nodev1.ValidatedGovernanceVAA{
+			MessageId:   v.MessageID(),
+			Vaa:         b,
+			PayloadType: decoded.Type,
+		}
+		for _, f := range decoded.Fields {
+			validated.PayloadFields = append(validated.PayloadFields, &nodev1.ValidatedGovernanceVAA_Field{Name: f.Name, Value: f.Value})
+		}
+		resp.Digests = append(resp.Digests, v.SigningDigest().Bytes())
+		resp.Validated = append(resp.Validated, validated)
+	}
+
+	s.logger.Info("governance VAAs validated via admin socket",
+		zap.Uint32("currentSetIndex", req.CurrentSetIndex),
+		zap.Int("numMessages", len(resp.Digests)),
+	)
+	return resp, nil
+}
+
 // fetchMissing attempts to backfill a gap by fetching and storing missing signed VAAs from the network.
 // Returns true if the gap was filled, false otherwise.
 func (s *nodePrivilegedService) fetchMissing(
@@ -438,7 +696,7 @@ func (s *nodePrivilegedService) fetchMissing(
 func (s *nodePrivilegedService) FindMissingMessages(ctx context.Context, req *nodev1.FindMissingMessagesRequest) (*nodev1.FindMissingMessagesResponse, error) {
 	b, err := hex.DecodeString(req.EmitterAddress)
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "invalid emitter address encoding: %v", err)
+		return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidEmitterAddress, fmt.Sprintf("invalid emitter address encoding: %v", err))
 	}
 	emitterAddress := vaa.Address{}
 	copy(emitterAddress[:], b)
@@ -448,7 +706,7 @@ func (s *nodePrivilegedService) FindMissingMessages(ctx context.Context, req *no
 		EmitterAddress: emitterAddress,
 	})
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "database operation failed: %v", err)
+		return nil, common.NewGrpcError(codes.Internal, common.ReasonInternal, fmt.Sprintf("database operation failed: %v", err))
 	}
 
 	if req.RpcBackfill {
@@ -456,7 +714,7 @@ func (s *nodePrivilegedService) FindMissingMessages(ctx context.Context, req *no
 		unfilled := make([]uint64, 0, len(ids))
 		for _, id := range ids {
 			if ok, err := s.fetchMissing(ctx, req.BackfillNodes, c, vaa.ChainID(req.EmitterChain), emitterAddress.String(), id); err != nil {
-				return nil, status.Errorf(codes.Internal, "failed to backfill VAA: %v", err)
+				return nil, common.NewRetryableGrpcError(codes.Unavailable, common.ReasonBackfillFailed, fmt.Sprintf("failed to backfill VAA: %v", err), backfillRetryDelay)
 			} else if ok {
 				continue
 			}
@@ -477,17 +735,64 @@ func (s *nodePrivilegedService) FindMissingMessages(ctx context.Context, req *no
 }
 
 func (s *nodePrivilegedService) SendObservationRequest(ctx context.Context, req *nodev1.SendObservationRequestRequest) (*nodev1.SendObservationRequestResponse, error) {
+	if len(req.ObservationRequests) != 0 {
+		if req.ObservationRequest != nil {
+			return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidObsvRequest, "observation_request and observation_requests are mutually exclusive")
+		}
+		return s.sendObservationRequestBatch(ctx, req.ObservationRequests, req.Rate)
+	}
+
 	if err := common.PostObservationRequest(s.obsvReqSendC, req.ObservationRequest); err != nil {
-		return nil, err
+		return nil, common.NewRetryableGrpcError(codes.ResourceExhausted, common.ReasonQueueFull, err.Error(), queueFullRetryDelay)
 	}
 
 	s.logger.Info("sent observation request", zap.Any("request", req.ObservationRequest))
-	return &nodev1.SendObservationRequestResponse{}, nil
+	return &nodev1.SendObservationRequestResponse{Sent: 1}, nil
+}
+
+// sendObservationRequestBatch sends the requests in order, at most rate per second. It stops early if the outbound queue fills up,
+// so the caller can retry the rest later.
+func (s *nodePrivilegedService) sendObservationRequestBatch(ctx context.Context, reqs []*gossipv1.ObservationRequest, r float64) (*nodev1.SendObservationRequestResponse, error) {
+	if len(reqs) > maxObservationRequestBatch {
+		return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonBatchTooLarge, fmt.Sprintf("at most %d observation requests can be sent at once", maxObservationRequestBatch))
+	}
+	if r < 0 || math.IsNaN(r) || math.IsInf(r, 0) {
+		return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidObsvRequest, "rate must be positive")
+	}
+	if r == 0 {
+		r = defaultObservationRequestRate
+	}
+	for i, obsvReq := range reqs {
+		if obsvReq.ChainId == 0 || obsvReq.ChainId > math.MaxUint16 {
+			return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidChainID, fmt.Sprintf("request %d: invalid chain ID %d", i, obsvReq.ChainId))
+		}
+		if len(obsvReq.TxHash) == 0 {
+			return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidObsvRequest, fmt.Sprintf("request %d: missing tx hash", i))
+		}
+	}
+
+	limiter := rate.NewLimiter(rate.Limit(r), 1)
+	sent := uint32(0)
+	for _, obsvReq := range reqs {
+		if err := limiter.Wait(ctx); err != nil {
+			break
+		}
+		if err := common.PostObservationRequest(s.obsvReqSendC, obsvReq); err != nil {
+			if sent == 0 {
+				return nil, common.NewRetryableGrpcError(codes.ResourceExhausted, common.ReasonQueueFull, err.Error(), queueFullRetryDelay)
+			}
+			break
+		}
+		sent++
+	}
+
+	s.logger.Info("sent batch of observation requests", zap.Uint32("sent", sent), zap.Int("requested", len(reqs)), zap.Float64("rate", r))
+	return &nodev1.SendObservationRequestResponse{Sent: sent}, nil
 }
 
 func (s *nodePrivilegedService) ChainGovernorStatus(ctx context.Context, req *nodev1.ChainGovernorStatusRequest) (*nodev1.ChainGovernorStatusResponse, error) {
 	if s.governor == nil {
-		return nil, fmt.Errorf("chain governor is not enabled")
+		return nil, errGovernorDisabled
 	}
 
 	return &nodev1.ChainGovernorStatusResponse{
@@ -495,14 +800,26 @@ func (s *nodePrivilegedService) ChainGovernorStatus(ctx context.Context, req *no
 	}, nil
 }
 
+func (s *nodePrivilegedService) ChainGovernorDumpState(ctx context.Context, req *nodev1.ChainGovernorDumpStateRequest) (*nodev1.ChainGovernorDumpStateResponse, error) {
+	if s.governor == nil {
+		return nil, errGovernorDisabled
+	}
+
+	state, err := json.MarshalIndent(s.governor.DumpState(), "", "  ")
+	if err != nil {
+		return nil, common.NewGrpcError(codes.Internal, common.ReasonInternal, fmt.Sprintf("failed to marshal the governor state: %v", err))
+	}
+	return &nodev1.ChainGovernorDumpStateResponse{State: string(state)}, nil
+}
+
 func (s *nodePrivilegedService) ChainGovernorReload(ctx context.Context, req *nodev1.ChainGovernorReloadRequest) (*nodev1.ChainGovernorReloadResponse, error) {
 	if s.governor == nil {
-		return nil, fmt.Errorf("chain governor is not enabled")
+		return nil, errGovernorDisabled
 	}
 
 	resp, err := s.governor.Reload()
 	if err != nil {
-		return nil, err
+		return nil, common.NewGrpcError(codes.Internal, common.ReasonInternal, err.Error())
 	}
 
 	return &nodev1.ChainGovernorReloadResponse{
@@ -512,16 +829,16 @@ func (s *nodePrivilegedService) ChainGovernorReload(ctx context.Context, req *no
 
 func (s *nodePrivilegedService) ChainGovernorDropPendingVAA(ctx context.Context, req *nodev1.ChainGovernorDropPendingVAARequest) (*nodev1.ChainGovernorDropPendingVAAResponse, error) {
 	if s.governor == nil {
-		return nil, fmt.Errorf("chain governor is not enabled")
+		return nil, errGovernorDisabled
 	}
 
 	if len(req.VaaId) == 0 {
-		return nil, fmt.Errorf("the VAA id must be specified as \"chainId/emitterAddress/seqNum\"")
+		return nil, errMissingVAAID
 	}
 
 	resp, err := s.governor.DropPendingVAA(req.VaaId)
 	if err != nil {
-		return nil, err
+		return nil, governorError(err)
 	}
 
 	return &nodev1.ChainGovernorDropPendingVAAResponse{
@@ -531,16 +848,18 @@ func (s *nodePrivilegedService) ChainGovernorDropPendingVAA(ctx context.Context,
 
 func (s *nodePrivilegedService) ChainGovernorReleasePendingVAA(ctx context.Context, req *nodev1.ChainGovernorReleasePendingVAARequest) (*nodev1.ChainGovernorReleasePendingVAAResponse, error) {
 	if s.governor == nil {
-		return nil, fmt.Errorf("chain governor is not enabled")
+		return nil, errGovernorDisabled
 	}
 
 	if len(req.VaaId) == 0 {
-		return nil, fmt.Errorf("the VAA id must be specified as \"chainId/emitterAddress/seqNum\"")
+		return nil, errMissingVAAID
 	}
 
-	resp, err := s.governor.ReleasePendingVAA(req.VaaId)
+	// Releases which need approvals are refused if the caller is not authenticated.
+	approver, _ := authenticatedCaller(ctx)
+	resp, err := s.governor.ReleasePendingVAA(req.VaaId, approver)
 	if err != nil {
-		return nil, err
+		return nil, governorError(err)
 	}
 
 	return &nodev1.ChainGovernorReleasePendingVAAResponse{
@@ -548,33 +867,156 @@ func (s *nodePrivilegedService) ChainGovernorReleasePendingVAA(ctx context.Conte
 	}, nil
 }
 
+func (s *nodePrivilegedService) ChainGovernorAuditLog(ctx context.Context, req *nodev1.ChainGovernorAuditLogRequest) (*nodev1.ChainGovernorAuditLogResponse, error) {
+	if s.governor == nil {
+		return nil, errGovernorDisabled
+	}
+
+	entries, err := s.governor.AuditLog()
+	if err != nil {
+		return nil, governorError(err)
+	}
+
+	resp := &nodev1.ChainGovernorAuditLogResponse{}
+	for _, e := range entries {
+		resp.Entries = append(resp.Entries, &nodev1.ChainGovernorAuditLogResponse_Entry{
+			Timestamp: e.Timestamp.UnixMilli(),
+			Action:    e.Action,
+			VaaId:     e.MsgID,
+			Actor:     e.Actor,
+			Detail:    e.Detail,
+		})
+	}
+	return resp, nil
+}
+
 func (s *nodePrivilegedService) ChainGovernorResetReleaseTimer(ctx context.Context, req *nodev1.ChainGovernorResetReleaseTimerRequest) (*nodev1.ChainGovernorResetReleaseTimerResponse, error) {
 	if s.governor == nil {
-		return nil, fmt.Errorf("chain governor is not enabled")
+		return nil, errGovernorDisabled
 	}
 
-	if len(req.VaaId) == 0 {
-		return nil, fmt.Errorf("the VAA id must be specified as \"chainId/emitterAddress/seqNum\"")
+	vaaIds := req.VaaIds
+	if len(req.VaaId) != 0 {
+		vaaIds = append([]string{req.VaaId}, vaaIds...)
+	}
+	if len(vaaIds) == 0 && req.ChainId == 0 {
+		return nil, errMissingVAAID
+	}
+	if req.ChainId > math.MaxUint16 {
+		return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidChainID, "invalid chain_id")
 	}
 
-	resp, err := s.governor.ResetReleaseTimer(req.VaaId)
+	reset, releaseTime, err := s.governor.ResetReleaseTimers(vaaIds, vaa.ChainID(req.ChainId), time.Duration(req.DelaySeconds)*time.Second)
 	if err != nil {
-		return nil, err
+		return nil, governorError(err)
 	}
 
 	return &nodev1.ChainGovernorResetReleaseTimerResponse{
-		Response: resp,
+		Response:    fmt.Sprintf("release time on %d pending vaas has been updated to %v", len(reset), releaseTime.String()),
+		VaaIds:      reset,
+		ReleaseTime: releaseTime.Unix(),
 	}, nil
 }
 
+func (s *nodePrivilegedService) ChainGovernorGetLimits(ctx context.Context, req *nodev1.ChainGovernorGetLimitsRequest) (*nodev1.ChainGovernorGetLimitsResponse, error) {
+	if s.governor == nil {
+		return nil, errGovernorDisabled
+	}
+
+	resp := &nodev1.ChainGovernorGetLimitsResponse{}
+	for _, l := range s.governor.ChainLimitStatuses() {
+		resp.Entries = append(resp.Entries, &nodev1.ChainGovernorGetLimitsResponse_Entry{
+			ChainId:                      uint32(l.ChainId),
+			DailyLimit:                   l.DailyLimit,
+			BigTransactionSize:           l.BigTransactionSize,
+			ConfiguredDailyLimit:         l.ConfiguredDailyLimit,
+			ConfiguredBigTransactionSize: l.ConfiguredBigTransactionSize,
+			Overridden:                   l.Overridden,
+		})
+	}
+	return resp, nil
+}
+
+func (s *nodePrivilegedService) ChainGovernorSetLimit(ctx context.Context, req *nodev1.ChainGovernorSetLimitRequest) (*nodev1.ChainGovernorSetLimitResponse, error) {
+	if s.governor == nil {
+		return nil, errGovernorDisabled
+	}
+
+	// Raising a limit must not bypass the second approver required for the same change through the staged config workflow.
+	if s.stagedConfigs.requireSecondApprover {
+		return nil, common.NewGrpcError(codes.PermissionDenied, common.ReasonSecondApproverRequired,
+			fmt.Sprintf("limits must be changed with config-propose %s, which requires a second approver", configTargetGovernorLimits))
+	}
+
+	chainId, err := governedChainID(req.ChainId)
+	if err != nil {
+		return nil, err
+	}
+	limit := governor.ChainLimit{ChainId: chainId, DailyLimit: req.DailyLimit, BigTransactionSize: req.BigTransactionSize}
+	if err := s.governor.SetChainLimits([]governor.ChainLimit{limit}); err != nil {
+		return nil, governorError(err)
+	}
+	s.logger.Info("governor limits changed via admin RPC", zap.Stringer("chain", chainId), zap.Uint64("dailyLimit", req.DailyLimit), zap.Uint64("bigTransactionSize", req.BigTransactionSize))
+	return &nodev1.ChainGovernorSetLimitResponse{}, nil
+}
+
+func (s *nodePrivilegedService) ChainGovernorResetLimit(ctx context.Context, req *nodev1.ChainGovernorResetLimitRequest) (*nodev1.ChainGovernorResetLimitResponse, error) {
+	if s.governor == nil {
+		return nil, errGovernorDisabled
+	}
+
+	chainId, err := governedChainID(req.ChainId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.governor.ResetChainLimits([]vaa.ChainID{chainId}); err != nil {
+		return nil, governorError(err)
+	}
+	s.logger.Info("governor limits reset via admin RPC", zap.Stringer("chain", chainId))
+	return &nodev1.ChainGovernorResetLimitResponse{}, nil
+}
+
+func (s *nodePrivilegedService) ChainGovernorExportSnapshot(ctx context.Context, req *nodev1.ChainGovernorExportSnapshotRequest) (*nodev1.ChainGovernorExportSnapshotResponse, error) {
+	if s.governor == nil {
+		return nil, errGovernorDisabled
+	}
+
+	snapshot, err := s.governor.ExportSnapshot()
+	if err != nil {
+		return nil, governorError(err)
+	}
+	return &nodev1.ChainGovernorExportSnapshotResponse{Snapshot: string(snapshot)}, nil
+}
+
+func (s *nodePrivilegedService) ChainGovernorImportSnapshot(ctx context.Context, req *nodev1.ChainGovernorImportSnapshotRequest) (*nodev1.ChainGovernorImportSnapshotResponse, error) {
+	if s.governor == nil {
+		return nil, errGovernorDisabled
+	}
+
+	resp, err := s.governor.ImportSnapshot([]byte(req.Snapshot))
+	if err != nil {
+		return nil, governorError(err)
+	}
+	s.logger.Info("governor snapshot imported via admin RPC", zap.String("response", resp))
+	return &nodev1.ChainGovernorImportSnapshotResponse{Response: resp}, nil
+}
+
+// governedChainID converts the chain ID of a governor limit request.
+func governedChainID(chainId uint32) (vaa.ChainID, error) {
+	if chainId > math.MaxUint16 {
+		return vaa.ChainIDUnset, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidChainID, fmt.Sprintf("invalid chain ID %d", chainId))
+	}
+	return vaa.ChainID(chainId), nil
+}
+
 func (s *nodePrivilegedService) SignExistingVAA(ctx context.Context, req *nodev1.SignExistingVAARequest) (*nodev1.SignExistingVAAResponse, error) {
 	v, err := vaa.Unmarshal(req.Vaa)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal VAA: %w", err)
+		return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidVAA, fmt.Sprintf("failed to unmarshal VAA: %v", err))
 	}
 
 	if req.NewGuardianSetIndex <= v.GuardianSetIndex {
-		return nil, errors.New("new guardian set index must be higher than provided VAA")
+		return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonGuardianSetIndexTooLow, "new guardian set index must be higher than provided VAA")
 	}
 
 	var gs *common.GuardianSet
@@ -582,7 +1024,7 @@ func (s *nodePrivilegedService) SignExistingVAA(ctx context.Context, req *nodev1
 		var ok bool
 		gs, ok = cachedGs.(*common.GuardianSet)
 		if !ok {
-			return nil, fmt.Errorf("internal error")
+			return nil, common.NewGrpcError(codes.Internal, common.ReasonInternal, "internal error")
 		}
 	} else {
 		/* TBDel
@@ -597,19 +1039,22 @@ func (s *nodePrivilegedService) SignExistingVAA(ctx context.Context, req *nodev1
 		s.gsCache.Store(v.GuardianSetIndex, gs)
 		*/
 	}
+	if gs == nil {
+		return nil, common.NewGrpcError(codes.FailedPrecondition, common.ReasonGuardianSetUnknown, fmt.Sprintf("guardian set %d is not known to this node", v.GuardianSetIndex))
+	}
 
 	if slices.Index(gs.Keys, s.guardianAddress) != -1 {
-		return nil, fmt.Errorf("local guardian is already on the old set")
+		return nil, common.NewGrpcError(codes.FailedPrecondition, common.ReasonAlreadyInGuardianSet, "local guardian is already on the old set")
 	}
 
 	// Verify VAA
 	err = v.Verify(gs.Keys)
 	if err != nil {
-		return nil, fmt.Errorf("failed to verify existing VAA: %w", err)
+		return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidVAA, fmt.Sprintf("failed to verify existing VAA: %v", err))
 	}
 
 	if len(req.NewGuardianAddrs) > 255 {
-		return nil, errors.New("new guardian set has too many guardians")
+		return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidGuardianSet, "new guardian set has too many guardians")
 	}
 	newGS := make([]ethcommon.Address, len(req.NewGuardianAddrs))
 	for i, guardianString := range req.NewGuardianAddrs {
@@ -624,12 +1069,12 @@ func (s *nodePrivilegedService) SignExistingVAA(ctx context.Context, req *nodev1
 	})
 	newGsLen := len(newGSSorted)
 	if len(slices.Compact(newGSSorted)) != newGsLen {
-		return nil, fmt.Errorf("duplicate guardians in the guardian set")
+		return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidGuardianSet, "duplicate guardians in the guardian set")
 	}
 
 	localGuardianIndex := slices.Index(newGS, s.guardianAddress)
 	if localGuardianIndex == -1 {
-		return nil, fmt.Errorf("local guardian is not a member of the new guardian set")
+		return nil, common.NewGrpcError(codes.FailedPrecondition, common.ReasonNotInGuardianSet, "local guardian is not a member of the new guardian set")
 	}
 
 	newVAA := &vaa.VAA{
@@ -663,7 +1108,7 @@ func (s *nodePrivilegedService) SignExistingVAA(ctx context.Context, req *nodev1
 
 	// Add our own signature only if the new guardian set would reach quorum
 	if vaa.CalculateQuorum(len(newGS)) > len(newVAA.Signatures)+1 {
-		return nil, errors.New("cannot reach quorum on new guardian set with the local signature")
+		return nil, common.NewGrpcError(codes.FailedPrecondition, common.ReasonQuorumNotReachable, "cannot reach quorum on new guardian set with the local signature")
 	}
 
 	// Add local signature
@@ -681,7 +1126,7 @@ func (s *nodePrivilegedService) SignExistingVAA(ctx context.Context, req *nodev1
 
 	newVAABytes, err := newVAA.Marshal()
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal new VAA: %w", err)
+		return nil, common.NewGrpcError(codes.Internal, common.ReasonInternal, fmt.Sprintf("failed to marshal new VAA: %v", err))
 	}
 
 	return &nodev1.SignExistingVAAResponse{Vaa: newVAABytes}, nil
@@ -693,6 +1138,733 @@ func (s *nodePrivilegedService) DumpRPCs(ctx context.Context, req *nodev1.DumpRP
 	}, nil
 }
 
+// DumpPendingObservations reads the aggregation state from the database rather than asking the processor, so it lags behind the
+// processor by up to one cleanup interval.
+func (s *nodePrivilegedService) DumpPendingObservations(ctx context.Context, req *nodev1.DumpPendingObservationsRequest) (*nodev1.DumpPendingObservationsResponse, error) {
+	states, err := s.db.GetAggregationStates()
+	if err != nil {
+		return nil, common.NewGrpcError(codes.Internal, common.ReasonInternal, fmt.Sprintf("database operation failed: %v", err))
+	}
+
+	resp := &nodev1.DumpPendingObservationsResponse{}
+	for _, st := range states {
+		o := &nodev1.PendingObservation{
+			Digest:        st.Digest,
+			Confidence:    st.Confidence,
+			FirstObserved: st.FirstObserved.UnixMilli(),
+			NumSignatures: uint32(len(st.Signatures)),
+			Retries:       uint32(st.RetryCtr),
+		}
+		if len(st.OurObservation) != 0 {
+			if v, err := vaa.Unmarshal(st.OurObservation); err == nil {
+				o.MessageId = v.MessageID()
+			}
+			o.Quorum = uint32(vaa.CalculateQuorum(len(st.GuardianSetKeys)))
+		}
+		resp.Observations = append(resp.Observations, o)
+	}
+	sort.Slice(resp.Observations, func(i, j int) bool {
+		return resp.Observations[i].FirstObserved < resp.Observations[j].FirstObserved
+	})
+	return resp, nil
+}
+
+func (s *nodePrivilegedService) DumpConflictingObservations(ctx context.Context, req *nodev1.DumpConflictingObservationsRequest) (*nodev1.DumpConflictingObservationsResponse, error) {
+	if s.conflicts == nil {
+		return nil, common.NewGrpcError(codes.FailedPrecondition, common.ReasonConflictTrackingDisabled, "conflicting observations are not tracked")
+	}
+
+	resp := &nodev1.DumpConflictingObservationsResponse{}
+	for _, c := range s.conflicts.List() {
+		o := &nodev1.ConflictingObservation{
+			MessageId: c.MessageID,
+			FirstSeen: c.FirstSeen.UnixMilli(),
+			LastSeen:  c.LastSeen.UnixMilli(),
+		}
+		for digest, signers := range c.Signers {
+			d := &nodev1.ConflictingDigest{Digest: hex.EncodeToString(digest.Bytes()), Ours: digest == c.Ours}
+			for _, addr := range signers {
+				d.Signers = append(d.Signers, addr.Hex())
+			}
+			o.Digests = append(o.Digests, d)
+		}
+		// Most signed first.
+		sort.Slice(o.Digests, func(i, j int) bool {
+			if len(o.Digests[i].Signers) != len(o.Digests[j].Signers) {
+				return len(o.Digests[i].Signers) > len(o.Digests[j].Signers)
+			}
+			return o.Digests[i].Digest < o.Digests[j].Digest
+		})
+		resp.Conflicts = append(resp.Conflicts, o)
+	}
+	return resp, nil
+}
+
+func (s *nodePrivilegedService) AccountantPendingTransfers(ctx context.Context, req *nodev1.AccountantPendingTransfersRequest) (*nodev1.AccountantPendingTransfersResponse, error) {
+	if s.acct == nil && s.nttAcct == nil {
+		return nil, common.NewGrpcError(codes.FailedPrecondition, common.ReasonAccountantDisabled, "no accountant is enabled")
+	}
+
+	resp := &nodev1.AccountantPendingTransfersResponse{
+		Enforcing:    s.acct != nil && s.acct.IsEnforcing(),
+		NttEnforcing: s.nttAcct != nil && s.nttAcct.IsEnforcing(),
+	}
+	for _, acct := range []*accountant.Accountant{s.acct, s.nttAcct} {
+		if acct == nil {
+			continue
+		}
+		for _, pt := range acct.PendingTransfers() {
+			t := &nodev1.AccountantPendingTransfer{
+				MessageId:      pt.MsgID,
+				Digest:         pt.Digest,
+				Observed:       pt.ObserveTime.UnixMilli(),
+				SubmitCount:    uint32(pt.SubmitCount),
+				Accountant:     acct.Name(),
+				EmitterChain:   uint32(pt.EmitterChain),
+				EmitterAddress: pt.EmitterAddress.String(),
+				PayloadSummary: pt.PayloadSummary,
+				Approvals:      pt.Approvals,
+			}
+			if !pt.SubmitTime.IsZero() {
+				t.LastSubmitted = pt.SubmitTime.UnixMilli()
+			}
+			resp.Transfers = append(resp.Transfers, t)
+		}
+	}
+	return resp, nil
+}
+
+func (s *nodePrivilegedService) AccountantResubmitTransfer(ctx context.Context, req *nodev1.AccountantResubmitTransferRequest) (*nodev1.AccountantResubmitTransferResponse, error) {
+	if s.acct == nil && s.nttAcct == nil {
+		return nil, common.NewGrpcError(codes.FailedPrecondition, common.ReasonAccountantDisabled, "no accountant is enabled")
+	}
+	if req.MessageId == "" {
+		return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonMissingMessageID, "the message ID must be specified")
+	}
+
+	// A transfer is pending in at most one accountant.
+	err := accountant.ErrTransferNotPending
+	for _, acct := range []*accountant.Accountant{s.acct, s.nttAcct} {
+		if acct == nil {
+			continue
+		}
+		if err = acct.ResubmitTransfer(req.MessageId); !errors.Is(err, accountant.ErrTransferNotPending) {
+			break
+		}
+	}
+	if err != nil {
+		if errors.Is(err, accountant.ErrTransferNotPending) {
+			return nil, common.NewGrpcError(codes.NotFound, common.ReasonTransferNotPending, err.Error())
+		}
+		if errors.Is(err, accountant.ErrSubmitQueueFull) {
+			return nil, common.NewRetryableGrpcError(codes.ResourceExhausted, common.ReasonQueueFull, err.Error(), queueFullRetryDelay)
+		}
+		return nil, common.NewGrpcError(codes.Internal, common.ReasonInternal, err.Error())
+	}
+	return &nodev1.AccountantResubmitTransferResponse{}, nil
+}
+
+func (s *nodePrivilegedService) AccountantForceReleaseTransfer(ctx context.Context, req *nodev1.AccountantForceReleaseTransferRequest) (*nodev1.AccountantForceReleaseTransferResponse, error) {
+	if s.acct == nil && s.nttAcct == nil {
+		return nil, common.NewGrpcError(codes.FailedPrecondition, common.ReasonAccountantDisabled, "no accountant is enabled")
+	}
+	if req.MessageId == "" {
+		return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonMissingMessageID, "the message ID must be specified")
+	}
+
+	// A transfer is pending in at most one accountant.
+	approver, _ := authenticatedCaller(ctx)
+	for _, acct := range []*accountant.Accountant{s.acct, s.nttAcct} {
+		if acct == nil {
+			continue
+		}
+		released, approvals, err := acct.ForceReleaseTransfer(req.MessageId, approver, req.Reason)
+		if errors.Is(err, accountant.ErrTransferNotPending) {
+			continue
+		}
+		if err != nil {
+			return nil, accountantForceReleaseError(err)
+		}
+		s.logger.Warn("force release of accountant pending transfer approved via admin RPC",
+			zap.String("msgID", req.MessageId),
+			zap.String("accountant", acct.Name()),
+			zap.String("approver", approver),
+			zap.Bool("released", released),
+		)
+		return &nodev1.AccountantForceReleaseTransferResponse{
+			Released:          released,
+			Approvals:         uint32(approvals),
+			RequiredApprovals: uint32(acct.ForceReleaseApprovals()),
+		}, nil
+	}
+	return nil, accountantForceReleaseError(accountant.ErrTransferNotPending)
+}
+
+// accountantForceReleaseError maps the errors returned by Accountant.ForceReleaseTransfer to gRPC errors.
+func accountantForceReleaseError(err error) error {
+	switch {
+	case errors.Is(err, accountant.ErrTransferNotPending):
+		return common.NewGrpcError(codes.NotFound, common.ReasonTransferNotPending, err.Error())
+	case errors.Is(err, accountant.ErrForceReleaseDisabled):
+		return common.NewGrpcError(codes.FailedPrecondition, common.ReasonForceReleaseDisabled, err.Error())
+	case errors.Is(err, accountant.ErrApproverRequired):
+		return common.NewGrpcError(codes.Unauthenticated, common.ReasonApproverRequired, err.Error())
+	case errors.Is(err, accountant.ErrReasonRequired):
+		return common.NewGrpcError(codes.InvalidArgument, common.ReasonMissingReason, err.Error())
+	case errors.Is(err, accountant.ErrDuplicateApproval):
+		return common.NewGrpcError(codes.AlreadyExists, common.ReasonDuplicateApproval, err.Error())
+	case errors.Is(err, accountant.ErrSubmitQueueFull):
+		return common.NewRetryableGrpcError(codes.ResourceExhausted, common.ReasonQueueFull, err.Error(), queueFullRetryDelay)
+	default:
+		return common.NewGrpcError(codes.Internal, common.ReasonInternal, err.Error())
+	}
+}
+
+func (s *nodePrivilegedService) AccountantAuditLog(ctx context.Context, req *nodev1.AccountantAuditLogRequest) (*nodev1.AccountantAuditLogResponse, error) {
+	// Both accountants share the audit log.
+	acct := s.acct
+	if acct == nil {
+		acct = s.nttAcct
+	}
+	if acct == nil {
+		return nil, common.NewGrpcError(codes.FailedPrecondition, common.ReasonAccountantDisabled, "no accountant is enabled")
+	}
+
+	entries, err := acct.AuditLog()
+	if err != nil {
+		return nil, common.NewGrpcError(codes.Internal, common.ReasonInternal, err.Error())
+	}
+
+	resp := &nodev1.AccountantAuditLogResponse{}
+	for _, e := range entries {
+		resp.Entries = append(resp.Entries, &nodev1.AccountantAuditLogResponse_Entry{
+			Timestamp:  e.Timestamp.UnixMilli(),
+			Accountant: e.Accountant,
+			Action:     e.Action,
+			MessageId:  e.MsgID,
+			Actor:      e.Actor,
+			Detail:     e.Detail,
+		})
+	}
+	return resp, nil
+}
+
+func (s *nodePrivilegedService) GatewayRelayerPause(ctx context.Context, req *nodev1.GatewayRelayerPauseRequest) (*nodev1.GatewayRelayerPauseResponse, error) {
+	if s.gwRelayer == nil {
+		return nil, errGatewayRelayerDisabled
+	}
+	s.gwRelayer.Pause()
+	return &nodev1.GatewayRelayerPauseResponse{}, nil
+}
+
+func (s *nodePrivilegedService) GatewayRelayerResume(ctx context.Context, req *nodev1.GatewayRelayerResumeRequest) (*nodev1.GatewayRelayerResumeResponse, error) {
+	if s.gwRelayer == nil {
+		return nil, errGatewayRelayerDisabled
+	}
+	s.gwRelayer.Resume()
+	return &nodev1.GatewayRelayerResumeResponse{}, nil
+}
+
+func (s *nodePrivilegedService) GatewayRelayerPendingRelays(ctx context.Context, req *nodev1.GatewayRelayerPendingRelaysRequest) (*nodev1.GatewayRelayerPendingRelaysResponse, error) {
+	if s.gwRelayer == nil {
+		return nil, errGatewayRelayerDisabled
+	}
+
+	resp := &nodev1.GatewayRelayerPendingRelaysResponse{Paused: s.gwRelayer.IsPaused()}
+	for _, pr := range s.gwRelayer.PendingRelays() {
+		relay := &nodev1.GatewayRelayerPendingRelay{
+			MessageId: pr.MsgID,
+			Status:    pr.Status,
+			Queued:    pr.QueueTime.UnixMilli(),
+			Attempts:  uint32(pr.Attempts),
+			LastError: pr.LastError,
+		}
+		if !pr.NextAttempt.IsZero() {
+			relay.NextAttempt = pr.NextAttempt.UnixMilli()
+		}
+		resp.Relays = append(resp.Relays, relay)
+	}
+	return resp, nil
+}
+
+func (s *nodePrivilegedService) GatewayRelayerRetryRelay(ctx context.Context, req *nodev1.GatewayRelayerRetryRelayRequest) (*nodev1.GatewayRelayerRetryRelayResponse, error) {
+	if s.gwRelayer == nil {
+		return nil, errGatewayRelayerDisabled
+	}
+	if req.MessageId == "" {
+		return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonMissingMessageID, "the message ID must be specified")
+	}
+	if err := s.gwRelayer.RetryRelay(req.MessageId); err != nil {
+		return nil, gatewayRelayerError(err)
+	}
+	return &nodev1.GatewayRelayerRetryRelayResponse{}, nil
+}
+
+func (s *nodePrivilegedService) GatewayRelayerDropRelay(ctx context.Context, req *nodev1.GatewayRelayerDropRelayRequest) (*nodev1.GatewayRelayerDropRelayResponse, error) {
+	if s.gwRelayer == nil {
+		return nil, errGatewayRelayerDisabled
+	}
+	if req.MessageId == "" {
+		return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonMissingMessageID, "the message ID must be specified")
+	}
+	if err := s.gwRelayer.DropRelay(req.MessageId); err != nil {
+		return nil, gatewayRelayerError(err)
+	}
+	return &nodev1.GatewayRelayerDropRelayResponse{}, nil
+}
+
+// gatewayRelayerError maps the errors of the gateway relayer to gRPC errors.
+func gatewayRelayerError(err error) error {
+	if errors.Is(err, gwrelayer.ErrRelayNotPending) {
+		return common.NewGrpcError(codes.NotFound, common.ReasonRelayNotPending, err.Error())
+	}
+	return common.NewGrpcError(codes.Internal, common.ReasonInternal, err.Error())
+}
+
+func (s *nodePrivilegedService) GetQueryBudgetUsage(ctx context.Context, req *nodev1.GetQueryBudgetUsageRequest) (*nodev1.GetQueryBudgetUsageResponse, error) {
+	if s.queryBudget == nil {
+		return nil, common.NewGrpcError(codes.FailedPrecondition, common.ReasonQueryBudgetDisabled, "cross chain query budgets are not enabled")
+	}
+
+	day, usage := s.queryBudget.Usage(time.Now())
+	resp := &nodev1.GetQueryBudgetUsageResponse{
+		DailyLimit: s.queryBudget.DailyLimit(),
+		Day:        day.UnixMilli(),
+	}
+	for _, u := range usage {
+		resp.Usage = append(resp.Usage, &nodev1.QueryBudgetUsage{Requester: u.Requester.Hex(), Used: u.Used})
+	}
+	return resp, nil
+}
+
+func (s *nodePrivilegedService) RestoreArchivedVAAs(ctx context.Context, req *nodev1.RestoreArchivedVAAsRequest) (*nodev1.RestoreArchivedVAAsResponse, error) {
+	if s.vaaArchiver == nil {
+		return nil, common.NewGrpcError(codes.FailedPrecondition, common.ReasonVAAArchiveDisabled, "the VAA archive is not enabled")
+	}
+	if req.From > req.To {
+		return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidTimeRange, "from must not be after to")
+	}
+	if req.EmitterChain > math.MaxUint16 {
+		return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidEmitterAddress, "invalid emitter chain")
+	}
+
+	var emitter *vaa.Address
+	if req.EmitterAddress != "" {
+		if req.EmitterChain == 0 {
+			return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidEmitterAddress, "an emitter address requires an emitter chain")
+		}
+		addr, err := vaa.StringToAddress(req.EmitterAddress)
+		if err != nil {
+			return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidEmitterAddress, fmt.Sprintf("invalid emitter address: %v", err))
+		}
+		emitter = &addr
+	}
+
+	restored, err := s.vaaArchiver.Restore(time.UnixMilli(req.From), time.UnixMilli(req.To), vaa.ChainID(req.EmitterChain), emitter, time.Now())
+	if err != nil {
+		s.logger.Error("failed to restore archived VAAs", zap.Int("restored", restored), zap.Error(err))
+		return nil, common.NewGrpcError(codes.Internal, common.ReasonInternal, fmt.Sprintf("failed to restore archived VAAs after restoring %d: %v", restored, err))
+	}
+	s.logger.Info("restored archived VAAs",
+		zap.Time("from", time.UnixMilli(req.From)),
+		zap.Time("to", time.UnixMilli(req.To)),
+		zap.Uint32("emitterChain", req.EmitterChain),
+		zap.String("emitterAddress", req.EmitterAddress),
+		zap.Int("restored", restored),
+	)
+	return &nodev1.RestoreArchivedVAAsResponse{NumRestored: uint32(restored)}, nil
+}
+
+func (s *nodePrivilegedService) GetAggregatedAttestation(ctx context.Context, req *nodev1.GetAggregatedAttestationRequest) (*nodev1.GetAggregatedAttestationResponse, error) {
+	id, err := db.VaaIDFromString(req.MessageId)
+	if err != nil {
+		return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidVAAID, fmt.Sprintf("invalid message id: %v", err))
+	}
+
+	b, err := s.db.GetAggregatedAttestation(*id)
+	if err != nil {
+		if errors.Is(err, db.ErrAttestationNotFound) {
+			return nil, common.NewGrpcError(codes.NotFound, common.ReasonAttestationNotFound, err.Error())
+		}
+		return nil, common.NewGrpcError(codes.Internal, common.ReasonInternal, fmt.Sprintf("database operation failed: %v", err))
+	}
+	attestation, err := aggsig.UnmarshalAttestation(b)
+	if err != nil {
+		return nil, common.NewGrpcError(codes.Internal, common.ReasonInternal, fmt.Sprintf("stored attestation is invalid: %v", err))
+	}
+
+	resp := &nodev1.GetAggregatedAttestationResponse{
+		Attestation:      b,
+		GuardianSetIndex: attestation.GuardianSetIndex,
+		NumSigners:       uint32(attestation.NumSigners()),
+	}
+	// The VAA may have been archived.
+	if vb, err := s.db.GetSignedVAABytes(*id); err == nil {
+		if v, err := vaa.Unmarshal(vb); err == nil {
+			resp.ClassicSignaturesSize = uint32(len(v.Signatures) * 66)
+		}
+	}
+	return resp, nil
+}
+
+func (s *nodePrivilegedService) GetSupportSnapshot(ctx context.Context, req *nodev1.GetSupportSnapshotRequest) (*nodev1.GetSupportSnapshotResponse, error) {
+	resp := &nodev1.GetSupportSnapshotResponse{GuardianAddress: s.guardianAddress.Hex()}
+	if s.support != nil {
+		resp.Config = s.support.Config
+		if s.support.Logs != nil {
+			resp.RecentLogs = s.support.Logs.Lines()
+		}
+	}
+	resp.SupervisorTree = s.supervisorRunnables()
+	if s.gst != nil {
+		var latest *gossipv1.Heartbeat
+		for _, hb := range s.gst.LastHeartbeat(s.guardianAddress) {
+			if latest == nil || hb.Timestamp > latest.Timestamp {
+				latest = hb
+			}
+		}
+		resp.Heartbeat = latest
+	}
+	return resp, nil
+}
+
+func (s *nodePrivilegedService) DumpConfig(ctx context.Context, req *nodev1.DumpConfigRequest) (*nodev1.DumpConfigResponse, error) {
+	if s.effectiveConfig == nil {
+		return nil, common.NewGrpcError(codes.Internal, common.ReasonInternal, "the effective configuration is not available")
+	}
+	config, err := s.effectiveConfig()
+	if err != nil {
+		return nil, common.NewGrpcError(codes.Internal, common.ReasonInternal, fmt.Sprintf("failed to collect the effective configuration: %v", err))
+	}
+	return &nodev1.DumpConfigResponse{Config: string(config)}, nil
+}
+
+func (s *nodePrivilegedService) supervisorRunnables() []*nodev1.SupervisorRunnable {
+	if s.supervisorTree == nil {
+		return nil
+	}
+	var runnables []*nodev1.SupervisorRunnable
+	for _, r := range s.supervisorTree() {
+		runnable := &nodev1.SupervisorRunnable{Dn: r.DN, State: r.State}
+		if r.LastError != nil {
+			runnable.LastError = r.LastError.Error()
+			runnable.LastErrorTimestamp = r.LastErrorTime.Unix()
+		}
+		runnables = append(runnables, runnable)
+	}
+	return runnables
+}
+
+func (s *nodePrivilegedService) GetNodeHealth(ctx context.Context, req *nodev1.GetNodeHealthRequest) (*nodev1.GetNodeHealthResponse, error) {
+	resp := &nodev1.GetNodeHealthResponse{
+		Ready:          true,
+		P2PPeerCount:   uint32(p2p.DefaultRegistry.GetPeerCount()),
+		SupervisorTree: s.supervisorRunnables(),
+	}
+
+	components := readiness.Components()
+	names := make([]string, 0, len(components))
+	for name := range components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		resp.Components = append(resp.Components, &nodev1.ComponentReadiness{Component: name, Ready: components[name]})
+		resp.Ready = resp.Ready && components[name]
+	}
+
+	// The lag of a watcher is measured against the highest height any guardian reported for its chain in its latest heartbeat.
+	networkHeights := make(map[vaa.ChainID]int64)
+	if s.gst != nil {
+		for _, heartbeats := range s.gst.GetAll() {
+			for _, hb := range heartbeats {
+				for _, n := range hb.Networks {
+					if n.Height > networkHeights[vaa.ChainID(n.Id)] {
+						networkHeights[vaa.ChainID(n.Id)] = n.Height
+					}
+				}
+			}
+		}
+	}
+	stats := p2p.DefaultRegistry.GetNetworkStats()
+	chains := make([]vaa.ChainID, 0, len(stats))
+	for chain := range stats {
+		chains = append(chains, chain)
+	}
+	sort.Slice(chains, func(i, j int) bool { return chains[i] < chains[j] })
+	for _, chain := range chains {
+		n := stats[chain]
+		networkHeight := networkHeights[chain]
+		if n.Height > networkHeight {
+			networkHeight = n.Height
+		}
+		resp.Watchers = append(resp.Watchers, &nodev1.WatcherHealth{
+			ChainId:         uint32(chain),
+			ChainName:       chain.String(),
+			Height:          n.Height,
+			SafeHeight:      n.SafeHeight,
+			FinalizedHeight: n.FinalizedHeight,
+			NetworkHeight:   networkHeight,
+			Lag:             networkHeight - n.Height,
+			ErrorCount:      p2p.DefaultRegistry.GetErrorCount(chain),
+		})
+	}
+
+	if s.queueDepths != nil {
+		for _, q := range s.queueDepths() {
+			resp.Queues = append(resp.Queues, &nodev1.QueueDepth{
+				Name:     q.Name,
+				Length:   uint32(q.Length),
+				Capacity: uint32(q.Capacity),
+			})
+		}
+	}
+	return resp, nil
+}
+
+func (s *nodePrivilegedService) PurgeVAAs(ctx context.Context, req *nodev1.PurgeVAAsRequest) (*nodev1.PurgeVAAsResponse, error) {
+	if !s.allowVAAPurge {
+		return nil, common.NewGrpcError(codes.FailedPrecondition, common.ReasonVAAPurgeDisabled, "purging VAAs is disabled, see --adminAllowVAAPurge")
+	}
+	approver, ok := authenticatedCaller(ctx)
+	if !ok {
+		return nil, common.NewGrpcError(codes.Unauthenticated, common.ReasonApproverRequired, "only authenticated callers can purge VAAs")
+	}
+	if req.Reason == "" {
+		return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonMissingReason, "the reason for purging the VAAs must be specified")
+	}
+	if req.EmitterChain == 0 || req.EmitterChain > math.MaxUint16 {
+		return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidChainID, "invalid emitter chain")
+	}
+	emitterAddress, err := vaa.StringToAddress(req.EmitterAddress)
+	if err != nil {
+		return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidEmitterAddress, fmt.Sprintf("invalid emitter address: %v", err))
+	}
+	if req.ToSequence != 0 && req.FromSequence > req.ToSequence {
+		return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidSequenceRange, "the first sequence is after the last sequence")
+	}
+
+	q := db.VAAPurgeQuery{
+		EmitterChain:   vaa.ChainID(req.EmitterChain),
+		EmitterAddress: emitterAddress,
+		FromSequence:   req.FromSequence,
+		ToSequence:     req.ToSequence,
+	}
+	fields := []zap.Field{
+		zap.Stringer("emitterChain", q.EmitterChain),
+		zap.Stringer("emitterAddress", q.EmitterAddress),
+		zap.Uint64("fromSequence", q.FromSequence),
+		zap.Uint64("toSequence", q.ToSequence),
+		zap.Bool("dryRun", req.DryRun),
+		zap.String("approver", approver),
+		zap.String("reason", req.Reason),
+	}
+	result, err := s.db.PurgeVAARange(q, req.DryRun)
+	if err != nil {
+		// Part of the range may have been purged already.
+		if result != nil {
+			fields = append(fields, zap.Int("purged", result.Purged))
+		}
+		s.logger.Error("failed to purge VAAs", append(fields, zap.Error(err))...)
+		return nil, common.NewGrpcError(codes.Internal, common.ReasonInternal, fmt.Sprintf("failed to purge VAAs: %v", err))
+	}
+	fields = append(fields,
+		zap.Int("purged", result.Purged),
+		zap.Uint64("firstPurgedSequence", result.FirstSequence),
+		zap.Uint64("lastPurgedSequence", result.LastSequence),
+	)
+	if req.DryRun {
+		s.logger.Info("dry run of purging VAAs through the admin service", fields...)
+	} else {
+		s.logger.Warn("purged VAAs through the admin service", fields...)
+	}
+
+	return &nodev1.PurgeVAAsResponse{
+		Purged:        uint64(result.Purged),
+		FirstSequence: result.FirstSequence,
+		LastSequence:  result.LastSequence,
+	}, nil
+}
+
+func (s *nodePrivilegedService) SetLogLevel(ctx context.Context, req *nodev1.SetLogLevelRequest) (*nodev1.SetLogLevelResponse, error) {
+	if s.logLevels == nil {
+		return nil, common.NewGrpcError(codes.FailedPrecondition, common.ReasonLogLevelsDisabled, "the log levels of this node can't be changed at runtime")
+	}
+
+	if !req.ListOnly {
+		duration := time.Duration(req.DurationSeconds) * time.Second
+		fields := []zap.Field{zap.String("component", req.Component), zap.String("level", req.Level), zap.Duration("duration", duration)}
+		if req.Level == "" {
+			s.logger.Warn("resetting log level through the admin service", fields...)
+			s.logLevels.ResetLevel(req.Component)
+		} else {
+			lvl, err := zapcore.ParseLevel(req.Level)
+			if err != nil {
+				return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidLogLevel, err.Error())
+			}
+			// Logged before the change, so that it is still visible if the level is raised.
+			s.logger.Warn("changing log level through the admin service", fields...)
+			s.logLevels.SetLevel(req.Component, lvl, duration)
+		}
+	}
+
+	resp := &nodev1.SetLogLevelResponse{}
+	for _, l := range s.logLevels.Levels() {
+		level := &nodev1.ComponentLogLevel{Component: l.Component, Level: l.Level.String()}
+		if !l.Expires.IsZero() {
+			level.Expires = l.Expires.Unix()
+		}
+		resp.Levels = append(resp.Levels, level)
+	}
+	return resp, nil
+}
+
+// tailLogsBufferSize is the number of log entries buffered for each TailLogs stream. Entries logged while the buffer is full are
+// dropped.
+const tailLogsBufferSize = 1000
+
+func (s *nodePrivilegedService) TailLogs(req *nodev1.TailLogsRequest, stream nodev1.NodePrivilegedService_TailLogsServer) error {
+	if s.support == nil || s.support.Logs == nil {
+		return common.NewGrpcError(codes.FailedPrecondition, common.ReasonLogTailDisabled, "the logs of this node are not buffered")
+	}
+
+	minLevel := zapcore.DebugLevel
+	if req.Level != "" {
+		lvl, err := zapcore.ParseLevel(req.Level)
+		if err != nil {
+			return common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidLogLevel, err.Error())
+		}
+		minLevel = lvl
+	}
+	match := func(e *supportbundle.LogEntry) bool {
+		if e.Level < minLevel {
+			return false
+		}
+		if len(req.Components) == 0 {
+			return true
+		}
+		for _, c := range req.Components {
+			if common.LoggerInComponent(e.Logger, c) {
+				return true
+			}
+		}
+		return false
+	}
+
+	recent, entryC, stop := s.support.Logs.Tail(match, tailLogsBufferSize)
+	defer stop()
+
+	send := func(e *supportbundle.LogEntry) error {
+		return stream.Send(&nodev1.TailLogsResponse{
+			Line:      e.Line,
+			Level:     e.Level.String(),
+			Logger:    e.Logger,
+			Timestamp: e.Time.UnixMilli(),
+			Dropped:   e.Dropped,
+		})
+	}
+	if len(recent) > int(req.Backlog) {
+		recent = recent[len(recent)-int(req.Backlog):]
+	}
+	for i := range recent {
+		if err := send(&recent[i]); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case e := <-entryC:
+			if err := send(&e); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *nodePrivilegedService) RestartComponent(ctx context.Context, req *nodev1.RestartComponentRequest) (*nodev1.RestartComponentResponse, error) {
+	if s.restartRunnable == nil {
+		return nil, common.NewGrpcError(codes.Internal, common.ReasonInternal, "the supervision tree is not available")
+	}
+	if req.Name == "" {
+		return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonRunnableNotFound, "the name of the runnable must be specified")
+	}
+	if req.Reason == "" {
+		return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonMissingReason, "the reason for restarting the runnable must be specified")
+	}
+
+	dn := req.Name
+	if dn != "root" && !strings.HasPrefix(dn, "root.") {
+		dn = "root." + dn
+	}
+	for _, protected := range s.protectedRunnables {
+		if dn == protected || strings.HasPrefix(dn, protected+".") {
+			return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonRunnableNotRestartable, fmt.Sprintf("%s serves the admin service and can't be restarted through it", dn))
+		}
+	}
+
+	if err := s.restartRunnable(dn); err != nil {
+		switch {
+		case errors.Is(err, supervisor.ErrRunnableNotFound):
+			return nil, common.NewGrpcError(codes.NotFound, common.ReasonRunnableNotFound, err.Error())
+		case errors.Is(err, supervisor.ErrRunnableNotRunning):
+			return nil, common.NewGrpcError(codes.FailedPrecondition, common.ReasonRunnableNotRunning, err.Error())
+		default:
+			return nil, common.NewGrpcError(codes.Internal, common.ReasonInternal, err.Error())
+		}
+	}
+
+	s.logger.Warn("restarted runnable through the admin service", zap.String("dn", dn), zap.String("reason", req.Reason))
+	return &nodev1.RestartComponentResponse{Dn: dn}, nil
+}
+
+const (
+	// defaultAdminAuditLogLimit is the number of entries returned by AdminAuditLog if the request has no limit.
+	defaultAdminAuditLogLimit = 100
+	// maxAdminAuditLogLimit caps the number of entries returned by AdminAuditLog.
+	maxAdminAuditLogLimit = 10000
+)
+
+func (s *nodePrivilegedService) AdminAuditLog(ctx context.Context, req *nodev1.AdminAuditLogRequest) (*nodev1.AdminAuditLogResponse, error) {
+	if s.auditor == nil {
+		return nil, common.NewGrpcError(codes.FailedPrecondition, common.ReasonAuditLogDisabled, "the calls to the admin service are not audited")
+	}
+	if req.Since < 0 {
+		return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidTimeRange, "since must not be negative")
+	}
+
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultAdminAuditLogLimit
+	}
+	if limit > maxAdminAuditLogLimit {
+		limit = maxAdminAuditLogLimit
+	}
+	var since time.Time
+	if req.Since != 0 {
+		since = time.UnixMilli(req.Since)
+	}
+
+	entries, err := s.auditor.Entries(since, limit)
+	if err != nil {
+		return nil, common.NewGrpcError(codes.Internal, common.ReasonInternal, err.Error())
+	}
+
+	resp := &nodev1.AdminAuditLogResponse{}
+	for _, e := range entries {
+		resp.Entries = append(resp.Entries, &nodev1.AdminAuditLogResponse_Entry{
+			Timestamp: e.Timestamp.UnixMilli(),
+			Method:    e.Method,
+			Caller:    e.Caller,
+			Request:   e.Request,
+			Result:    e.Result,
+		})
+	}
+	return resp, nil
+}
+
 func (s *nodePrivilegedService) GetAndObserveMissingVAAs(ctx context.Context, req *nodev1.GetAndObserveMissingVAAsRequest) (*nodev1.GetAndObserveMissingVAAsResponse, error) {
 	// Get URL and API key from the command line
 	url := req.GetUrl()
@@ -809,3 +1981,125 @@ func (s *nodePrivilegedService) GetAndObserveMissingVAAs(ctx context.Context, re
 		Response: response,
 	}, nil
 }
+
+// currentGuardianSet returns the guardian set we currently know about if it matches the given index, or nil otherwise.
+func (s *nodePrivilegedService) currentGuardianSet(index uint32) *common.GuardianSet {
+	if s.gst == nil {
+		return nil
+	}
+	gs := s.gst.Get()
+	if gs == nil || gs.Index != index {
+		return nil
+	}
+	return gs
+}
+
+func governanceStatusToResponse(st *common.GovernanceStatus) *nodev1.GovernanceVAAStatusResponse {
+	resp := &nodev1.GovernanceVAAStatusResponse{
+		Digest:        st.Digest.Bytes(),
+		MessageId:     st.MessageID,
+		Quorum:        uint32(st.Quorum()),
+		NumSignatures: uint32(st.NumSignatures()),
+		QuorumReached: st.QuorumReached(),
+	}
+
+	if st.GuardianSet != nil {
+		resp.GuardianSetIndex = st.GuardianSet.Index
+		resp.Guardians = make([]*nodev1.GovernanceVAAGuardianStatus, 0, len(st.GuardianSet.Keys))
+		for _, k := range st.GuardianSet.Keys {
+			gs := &nodev1.GovernanceVAAGuardianStatus{GuardianAddress: k.Hex()}
+			if signedAt, signed := st.Signed[k]; signed {
+				gs.Signed = true
+				gs.SignedAt = signedAt.UnixMilli()
+			}
+			resp.Guardians = append(resp.Guardians, gs)
+		}
+	}
+
+	return resp
+}
+
+func (s *nodePrivilegedService) GovernanceVAAStatus(req *nodev1.GovernanceVAAStatusRequest, stream nodev1.NodePrivilegedService_GovernanceVAAStatusServer) error {
+	if s.govStatus == nil {
+		return common.NewGrpcError(codes.FailedPrecondition, common.ReasonGovernanceStatusDisabled, "governance status tracking is not enabled")
+	}
+	if len(req.Digest) != 32 {
+		return common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidDigest, "digest must be 32 bytes")
+	}
+	digest := ethcommon.BytesToHash(req.Digest)
+
+	updateC, unsubscribe, exists := s.govStatus.Subscribe(digest)
+	if !exists {
+		return common.NewGrpcError(codes.NotFound, common.ReasonGovernanceVAANotFound, fmt.Sprintf("governance VAA %s was not injected on this node or has expired", digest.Hex()))
+	}
+	defer unsubscribe()
+
+	for {
+		st, exists := s.govStatus.Get(digest)
+		if !exists {
+			return common.NewGrpcError(codes.NotFound, common.ReasonGovernanceVAANotFound, fmt.Sprintf("governance VAA %s is no longer tracked", digest.Hex()))
+		}
+
+		if err := stream.Send(governanceStatusToResponse(&st)); err != nil {
+			return err
+		}
+
+		if st.QuorumReached() {
+			return nil
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case _, ok := <-updateC:
+			if !ok {
+				return common.NewGrpcError(codes.NotFound, common.ReasonGovernanceVAANotFound, fmt.Sprintf("governance VAA %s is no longer tracked", digest.Hex()))
+			}
+		}
+	}
+}
+
+var (
+	errGovernorDisabled = common.NewGrpcError(codes.FailedPrecondition, common.ReasonGovernorDisabled, "chain governor is not enabled")
+	errMissingVAAID     = common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidVAAID, "the VAA id must be specified as \"chainId/emitterAddress/seqNum\"")
+
+	errGatewayRelayerDisabled = common.NewGrpcError(codes.FailedPrecondition, common.ReasonGatewayRelayerDisabled, "the gateway relayer is not enabled")
+)
+
+const (
+	// backfillRetryDelay is how long clients are asked to wait before retrying after the public RPC endpoints failed to serve a backfill.
+	backfillRetryDelay = 10 * time.Second
+
+	// queueFullRetryDelay is how long clients are asked to wait before retrying after an internal queue was full.
+	queueFullRetryDelay = time.Second
+
+	// maxObservationRequestBatch is the maximum number of observation requests that can be sent in one call.
+	maxObservationRequestBatch = 100
+
+	// defaultObservationRequestRate is the number of observation requests of a batch sent per second by default, which is the rate the
+	// network accepts.
+	defaultObservationRequestRate = 1
+)
+
+// governorError converts an error returned by an admin command of the chain governor into a gRPC error.
+func governorError(err error) error {
+	if errors.Is(err, governor.ErrPendingVAANotFound) {
+		return common.NewGrpcError(codes.NotFound, common.ReasonPendingVAANotFound, err.Error())
+	}
+	if errors.Is(err, governor.ErrChainNotGoverned) {
+		return common.NewGrpcError(codes.NotFound, common.ReasonChainNotGoverned, err.Error())
+	}
+	if errors.Is(err, governor.ErrApproverRequired) {
+		return common.NewGrpcError(codes.Unauthenticated, common.ReasonApproverRequired, err.Error())
+	}
+	if errors.Is(err, governor.ErrDuplicateApproval) {
+		return common.NewGrpcError(codes.AlreadyExists, common.ReasonDuplicateApproval, err.Error())
+	}
+	if errors.Is(err, governor.ErrInvalidReleaseDelay) {
+		return common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidReleaseDelay, err.Error())
+	}
+	if errors.Is(err, governor.ErrInvalidSnapshot) {
+		return common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidGovernorSnapshot, err.Error())
+	}
+	return common.NewGrpcError(codes.Internal, common.ReasonInternal, err.Error())
+}
@@ -4,15 +4,44 @@ package adminrpc
 import (
 	"context"
 	"crypto/ecdsa"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/certusone/wormhole/node/pkg/accountant"
+	"github.com/certusone/wormhole/node/pkg/aggsig"
+	nodecommon "github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	"github.com/certusone/wormhole/node/pkg/governor"
+	"github.com/certusone/wormhole/node/pkg/gwrelayer"
+	"github.com/certusone/wormhole/node/pkg/p2p"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
 	nodev1 "github.com/certusone/wormhole/node/pkg/proto/node/v1"
+	"github.com/certusone/wormhole/node/pkg/query"
+	"github.com/certusone/wormhole/node/pkg/readiness"
+	"github.com/certusone/wormhole/node/pkg/supervisor"
+	"github.com/certusone/wormhole/node/pkg/supportbundle"
+	"github.com/certusone/wormhole/node/pkg/vaaarchive"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
 	"github.com/ethereum/go-ethereum/common"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 )
 
 func generateGS(num int) (keys []*ecdsa.PrivateKey, addrs []common.Address) {
@@ -64,7 +93,7 @@ func setupAdminServerForVAASigning(gsIndex uint32, gsAddrs []common.Address) *no
 		panic(err)
 	}
 
-	return &nodePrivilegedService{
+	s := &nodePrivilegedService{
 		db:              nil,
 		injectC:         nil,
 		obsvReqSendC:    nil,
@@ -74,6 +103,8 @@ func setupAdminServerForVAASigning(gsIndex uint32, gsAddrs []common.Address) *no
 		gk:              gk,
 		guardianAddress: ethcrypto.PubkeyToAddress(gk.PublicKey),
 	}
+	s.gsCache.Store(gsIndex, &nodecommon.GuardianSet{Keys: gsAddrs, Index: gsIndex})
+	return s
 }
 
 func TestSignExistingVAA_NoVAA(t *testing.T) {
@@ -139,6 +170,8 @@ func TestSignExistingVAA_AlreadyGuardian(t *testing.T) {
 	v := generateMockVAA(0, append(gsKeys, s.gk))
 
 	gsAddrs = append(gsAddrs, s.guardianAddress)
+	// The local guardian is part of the old set.
+	s.gsCache.Store(uint32(0), &nodecommon.GuardianSet{Keys: gsAddrs, Index: 0})
 	_, err := s.SignExistingVAA(context.Background(), &nodev1.SignExistingVAARequest{
 		Vaa:                 v,
 		NewGuardianAddrs:    addrsToHexStrings(gsAddrs),
@@ -193,3 +226,799 @@ func TestSignExistingVAA_Valid(t *testing.T) {
 	v2 := generateMockVAA(1, append(gsKeys, s.gk))
 	require.Equal(t, v2, res.Vaa)
 }
+
+func TestDumpPendingObservations(t *testing.T) {
+	database, err := db.OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer database.Close()
+
+	_, gsAddrs := generateGS(4)
+	v := vaa.VAA{Version: 1, Timestamp: time.Unix(0, 0), EmitterChain: vaa.ChainIDSolana, Sequence: 5, Payload: []byte("test")}
+	ours, err := v.Marshal()
+	require.NoError(t, err)
+	require.NoError(t, database.StoreAggregationStates([]*db.AggregationState{
+		{
+			Digest:          "aa",
+			FirstObserved:   time.UnixMilli(2000),
+			OurObservation:  ours,
+			Confidence:      nodecommon.ConfidenceReobserved,
+			GuardianSetKeys: gsAddrs,
+			Signatures:      map[common.Address][]byte{gsAddrs[0]: {1}, gsAddrs[1]: {2}},
+			RetryCtr:        1,
+		},
+		{
+			Digest:        "bb",
+			FirstObserved: time.UnixMilli(1000),
+			Signatures:    map[common.Address][]byte{gsAddrs[2]: {3}},
+		},
+	}))
+
+	s := &nodePrivilegedService{db: database, logger: zap.NewNop()}
+	resp, err := s.DumpPendingObservations(context.Background(), &nodev1.DumpPendingObservationsRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Observations, 2)
+
+	// Oldest first; observations by other guardians only have what they sent us.
+	assert.Equal(t, "bb", resp.Observations[0].Digest)
+	assert.Empty(t, resp.Observations[0].MessageId)
+	assert.Equal(t, uint32(1), resp.Observations[0].NumSignatures)
+
+	assert.Equal(t, &nodev1.PendingObservation{
+		Digest:        "aa",
+		MessageId:     v.MessageID(),
+		Confidence:    "reobserved",
+		FirstObserved: 2000,
+		NumSignatures: 2,
+		Quorum:        3,
+		Retries:       1,
+	}, resp.Observations[1])
+}
+
+func TestGetQueryBudgetUsage(t *testing.T) {
+	s := &nodePrivilegedService{logger: zap.NewNop()}
+	_, err := s.GetQueryBudgetUsage(context.Background(), &nodev1.GetQueryBudgetUsageRequest{})
+	assert.Equal(t, nodecommon.ReasonQueryBudgetDisabled, nodecommon.GrpcErrorReasonOf(err))
+
+	requester := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	s.queryBudget = query.NewQueryBudget(10, func(*query.PerChainQueryRequest) uint64 { return 3 })
+	_, ok := s.queryBudget.Charge(requester, &query.QueryRequest{PerChainQueries: []*query.PerChainQueryRequest{{}}}, time.Now())
+	require.True(t, ok)
+
+	resp, err := s.GetQueryBudgetUsage(context.Background(), &nodev1.GetQueryBudgetUsageRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), resp.DailyLimit)
+	assert.Equal(t, []*nodev1.QueryBudgetUsage{{Requester: requester.Hex(), Used: 3}}, resp.Usage)
+}
+
+func TestDumpConflictingObservations(t *testing.T) {
+	s := &nodePrivilegedService{logger: zap.NewNop()}
+	_, err := s.DumpConflictingObservations(context.Background(), &nodev1.DumpConflictingObservationsRequest{})
+	assert.Equal(t, nodecommon.ReasonConflictTrackingDisabled, nodecommon.GrpcErrorReasonOf(err))
+
+	messageID := "1/0000000000000000000000000000000000000000000000000000000000000004/1"
+	ours := common.HexToHash("0x01")
+	theirs := common.HexToHash("0x02")
+	now := time.UnixMilli(1700000000000)
+	s.conflicts = nodecommon.NewObservationConflictTracker()
+	s.conflicts.AddSignature(messageID, ours, common.HexToAddress("0x0a"), true, now)
+	s.conflicts.AddSignature(messageID, theirs, common.HexToAddress("0x0b"), false, now)
+	s.conflicts.AddSignature(messageID, theirs, common.HexToAddress("0x0c"), false, now.Add(time.Second))
+
+	resp, err := s.DumpConflictingObservations(context.Background(), &nodev1.DumpConflictingObservationsRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Conflicts, 1)
+	assert.Equal(t, &nodev1.ConflictingObservation{
+		MessageId: messageID,
+		FirstSeen: now.UnixMilli(),
+		LastSeen:  now.Add(time.Second).UnixMilli(),
+		Digests: []*nodev1.ConflictingDigest{
+			{Digest: hex.EncodeToString(theirs.Bytes()), Signers: []string{common.HexToAddress("0x0b").Hex(), common.HexToAddress("0x0c").Hex()}},
+			{Digest: hex.EncodeToString(ours.Bytes()), Ours: true, Signers: []string{common.HexToAddress("0x0a").Hex()}},
+		},
+	}, resp.Conflicts[0])
+}
+
+func TestAccountantPendingTransfers(t *testing.T) {
+	s := &nodePrivilegedService{logger: zap.NewNop()}
+	_, err := s.AccountantPendingTransfers(context.Background(), &nodev1.AccountantPendingTransfersRequest{})
+	assert.Equal(t, nodecommon.ReasonAccountantDisabled, nodecommon.GrpcErrorReasonOf(err))
+
+	database, err := db.OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer database.Close()
+	keys, _ := generateGS(1)
+	msgC := make(chan *nodecommon.MessagePublication, accountant.MsgChannelCapacity)
+	s.acct = accountant.NewAccountant(zap.NewNop(), database, nil, keys[0], nodecommon.NewGuardianSetState(nil), msgC, nodecommon.GoTest, accountant.EnforcingMode)
+
+	msg := &nodecommon.MessagePublication{
+		Timestamp:      time.Unix(1654516425, 0),
+		Sequence:       1,
+		EmitterChain:   vaa.ChainIDSolana,
+		EmitterAddress: vaa.Address(sdk.KnownDevnetTokenbridgeEmitters[vaa.ChainIDSolana]),
+		Payload:        []byte{1, 0, 0, 0},
+	}
+	shouldPub, err := s.acct.SubmitObservation(msg)
+	require.NoError(t, err)
+	require.False(t, shouldPub)
+
+	resp, err := s.AccountantPendingTransfers(context.Background(), &nodev1.AccountantPendingTransfersRequest{})
+	require.NoError(t, err)
+	assert.True(t, resp.Enforcing)
+	assert.False(t, resp.NttEnforcing)
+	require.Len(t, resp.Transfers, 1)
+	assert.Equal(t, msg.MessageIDString(), resp.Transfers[0].MessageId)
+	assert.Equal(t, "token_bridge", resp.Transfers[0].Accountant)
+	assert.Equal(t, msg.CreateDigest(), resp.Transfers[0].Digest)
+	assert.NotZero(t, resp.Transfers[0].Observed)
+	assert.Zero(t, resp.Transfers[0].LastSubmitted)
+
+	_, err = s.AccountantResubmitTransfer(context.Background(), &nodev1.AccountantResubmitTransferRequest{})
+	assert.Equal(t, nodecommon.ReasonMissingMessageID, nodecommon.GrpcErrorReasonOf(err))
+	_, err = s.AccountantResubmitTransfer(context.Background(), &nodev1.AccountantResubmitTransferRequest{MessageId: "1/00/2"})
+	assert.Equal(t, nodecommon.ReasonTransferNotPending, nodecommon.GrpcErrorReasonOf(err))
+	_, err = s.AccountantResubmitTransfer(context.Background(), &nodev1.AccountantResubmitTransferRequest{MessageId: msg.MessageIDString()})
+	require.NoError(t, err)
+}
+
+func TestAccountantForceReleaseTransfer(t *testing.T) {
+	s := &nodePrivilegedService{logger: zap.NewNop()}
+	_, err := s.AccountantForceReleaseTransfer(context.Background(), &nodev1.AccountantForceReleaseTransferRequest{})
+	assert.Equal(t, nodecommon.ReasonAccountantDisabled, nodecommon.GrpcErrorReasonOf(err))
+	_, err = s.AccountantAuditLog(context.Background(), &nodev1.AccountantAuditLogRequest{})
+	assert.Equal(t, nodecommon.ReasonAccountantDisabled, nodecommon.GrpcErrorReasonOf(err))
+
+	database, err := db.OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer database.Close()
+	keys, _ := generateGS(1)
+	msgC := make(chan *nodecommon.MessagePublication, accountant.MsgChannelCapacity)
+	s.acct = accountant.NewAccountant(zap.NewNop(), database, nil, keys[0], nodecommon.NewGuardianSetState(nil), msgC, nodecommon.GoTest, accountant.EnforcingMode)
+
+	msg := &nodecommon.MessagePublication{
+		Timestamp:      time.Unix(1654516425, 0),
+		Sequence:       1,
+		EmitterChain:   vaa.ChainIDSolana,
+		EmitterAddress: vaa.Address(sdk.KnownDevnetTokenbridgeEmitters[vaa.ChainIDSolana]),
+		Payload:        []byte{1, 0, 0, 0},
+	}
+	_, err = s.acct.SubmitObservation(msg)
+	require.NoError(t, err)
+	msgId := msg.MessageIDString()
+	alice := withCaller(context.Background(), "alice")
+	bob := withCaller(context.Background(), "bob")
+
+	_, err = s.AccountantForceReleaseTransfer(alice, &nodev1.AccountantForceReleaseTransferRequest{MessageId: msgId, Reason: "contract is down"})
+	assert.Equal(t, nodecommon.ReasonForceReleaseDisabled, nodecommon.GrpcErrorReasonOf(err))
+
+	s.acct.RequireForceReleaseApprovals(2)
+	_, err = s.AccountantForceReleaseTransfer(alice, &nodev1.AccountantForceReleaseTransferRequest{Reason: "contract is down"})
+	assert.Equal(t, nodecommon.ReasonMissingMessageID, nodecommon.GrpcErrorReasonOf(err))
+	_, err = s.AccountantForceReleaseTransfer(alice, &nodev1.AccountantForceReleaseTransferRequest{MessageId: "1/00/2", Reason: "contract is down"})
+	assert.Equal(t, nodecommon.ReasonTransferNotPending, nodecommon.GrpcErrorReasonOf(err))
+	_, err = s.AccountantForceReleaseTransfer(context.Background(), &nodev1.AccountantForceReleaseTransferRequest{MessageId: msgId, Reason: "contract is down"})
+	assert.Equal(t, nodecommon.ReasonApproverRequired, nodecommon.GrpcErrorReasonOf(err))
+	_, err = s.AccountantForceReleaseTransfer(alice, &nodev1.AccountantForceReleaseTransferRequest{MessageId: msgId})
+	assert.Equal(t, nodecommon.ReasonMissingReason, nodecommon.GrpcErrorReasonOf(err))
+
+	resp, err := s.AccountantForceReleaseTransfer(alice, &nodev1.AccountantForceReleaseTransferRequest{MessageId: msgId, Reason: "contract is down"})
+	require.NoError(t, err)
+	assert.Equal(t, &nodev1.AccountantForceReleaseTransferResponse{Released: false, Approvals: 1, RequiredApprovals: 2}, resp)
+	_, err = s.AccountantForceReleaseTransfer(alice, &nodev1.AccountantForceReleaseTransferRequest{MessageId: msgId, Reason: "contract is down"})
+	assert.Equal(t, nodecommon.ReasonDuplicateApproval, nodecommon.GrpcErrorReasonOf(err))
+
+	pending, err := s.AccountantPendingTransfers(context.Background(), &nodev1.AccountantPendingTransfersRequest{})
+	require.NoError(t, err)
+	require.Len(t, pending.Transfers, 1)
+	assert.Equal(t, []string{"alice"}, pending.Transfers[0].Approvals)
+	assert.Equal(t, uint32(vaa.ChainIDSolana), pending.Transfers[0].EmitterChain)
+	assert.Equal(t, msg.EmitterAddress.String(), pending.Transfers[0].EmitterAddress)
+	assert.NotEmpty(t, pending.Transfers[0].PayloadSummary)
+
+	resp, err = s.AccountantForceReleaseTransfer(bob, &nodev1.AccountantForceReleaseTransferRequest{MessageId: msgId, Reason: "contract is down"})
+	require.NoError(t, err)
+	assert.True(t, resp.Released)
+	require.Len(t, msgC, 1)
+	assert.Equal(t, msg, <-msgC)
+
+	log, err := s.AccountantAuditLog(context.Background(), &nodev1.AccountantAuditLogRequest{})
+	require.NoError(t, err)
+	require.Len(t, log.Entries, 2)
+	assert.Equal(t, "force-release", log.Entries[1].Action)
+	assert.Equal(t, "token_bridge", log.Entries[1].Accountant)
+	assert.Equal(t, msgId, log.Entries[1].MessageId)
+	assert.Equal(t, "bob", log.Entries[1].Actor)
+}
+
+func TestGatewayRelayerAdmin(t *testing.T) {
+	s := &nodePrivilegedService{logger: zap.NewNop()}
+	_, err := s.GatewayRelayerPendingRelays(context.Background(), &nodev1.GatewayRelayerPendingRelaysRequest{})
+	assert.Equal(t, nodecommon.ReasonGatewayRelayerDisabled, nodecommon.GrpcErrorReasonOf(err))
+	_, err = s.GatewayRelayerPause(context.Background(), &nodev1.GatewayRelayerPauseRequest{})
+	assert.Equal(t, nodecommon.ReasonGatewayRelayerDisabled, nodecommon.GrpcErrorReasonOf(err))
+
+	// The gateway fails every submission.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(&gwrelayer.SubmitVAAResponse{TxHash: "0xtx", Code: 11, RawLog: "out of gas"})
+	}))
+	defer server.Close()
+	contractAddr := vaa.Address{31: 1}
+	contract, err := sdktypes.Bech32ifyAddressBytes("wormhole", contractAddr[:])
+	require.NoError(t, err)
+	s.gwRelayer, err = gwrelayer.NewGatewayRelayer(zap.NewNop(), gwrelayer.NewHTTPSubmitter(server.URL), contract, nodecommon.GoTest, gwrelayer.DefaultRelayPolicy())
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = s.gwRelayer.Run(ctx) }()
+
+	// A transfer with payload to the contract on the gateway.
+	payload := make([]byte, 133)
+	payload[0] = 3
+	copy(payload[67:99], contractAddr[:])
+	binary.BigEndian.PutUint16(payload[99:101], 3104)
+	v := &vaa.VAA{
+		Version:        vaa.SupportedVAAVersion,
+		EmitterChain:   vaa.ChainIDSolana,
+		EmitterAddress: vaa.Address(sdk.KnownDevnetTokenbridgeEmitters[vaa.ChainIDSolana]),
+		Sequence:       1,
+		Payload:        payload,
+	}
+	s.gwRelayer.SubmitVAA(v)
+
+	var resp *nodev1.GatewayRelayerPendingRelaysResponse
+	require.Eventually(t, func() bool {
+		resp, err = s.GatewayRelayerPendingRelays(context.Background(), &nodev1.GatewayRelayerPendingRelaysRequest{})
+		require.NoError(t, err)
+		return len(resp.Relays) == 1 && resp.Relays[0].Attempts == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.False(t, resp.Paused)
+	assert.Equal(t, v.MessageID(), resp.Relays[0].MessageId)
+	assert.Equal(t, gwrelayer.StatusRetrying, resp.Relays[0].Status)
+	assert.NotZero(t, resp.Relays[0].Queued)
+	assert.NotZero(t, resp.Relays[0].NextAttempt)
+	assert.Contains(t, resp.Relays[0].LastError, "out of gas")
+
+	_, err = s.GatewayRelayerPause(context.Background(), &nodev1.GatewayRelayerPauseRequest{})
+	require.NoError(t, err)
+	_, err = s.GatewayRelayerRetryRelay(context.Background(), &nodev1.GatewayRelayerRetryRelayRequest{MessageId: v.MessageID()})
+	require.NoError(t, err)
+	resp, err = s.GatewayRelayerPendingRelays(context.Background(), &nodev1.GatewayRelayerPendingRelaysRequest{})
+	require.NoError(t, err)
+	assert.True(t, resp.Paused)
+	require.Len(t, resp.Relays, 1)
+	assert.Equal(t, gwrelayer.StatusQueued, resp.Relays[0].Status)
+	assert.Zero(t, resp.Relays[0].Attempts)
+
+	_, err = s.GatewayRelayerRetryRelay(context.Background(), &nodev1.GatewayRelayerRetryRelayRequest{})
+	assert.Equal(t, nodecommon.ReasonMissingMessageID, nodecommon.GrpcErrorReasonOf(err))
+	_, err = s.GatewayRelayerDropRelay(context.Background(), &nodev1.GatewayRelayerDropRelayRequest{MessageId: "1/00/2"})
+	assert.Equal(t, nodecommon.ReasonRelayNotPending, nodecommon.GrpcErrorReasonOf(err))
+	_, err = s.GatewayRelayerDropRelay(context.Background(), &nodev1.GatewayRelayerDropRelayRequest{MessageId: v.MessageID()})
+	require.NoError(t, err)
+	resp, err = s.GatewayRelayerPendingRelays(context.Background(), &nodev1.GatewayRelayerPendingRelaysRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Relays)
+
+	_, err = s.GatewayRelayerResume(context.Background(), &nodev1.GatewayRelayerResumeRequest{})
+	require.NoError(t, err)
+}
+
+func TestChainGovernorLimits(t *testing.T) {
+	ctx := context.Background()
+	s := &nodePrivilegedService{logger: zap.NewNop(), stagedConfigs: newStagedConfigs(zap.NewNop(), false, nil, nil, nil)}
+	_, err := s.ChainGovernorGetLimits(ctx, &nodev1.ChainGovernorGetLimitsRequest{})
+	assert.Equal(t, nodecommon.ReasonGovernorDisabled, nodecommon.GrpcErrorReasonOf(err))
+
+	database, err := db.OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer database.Close()
+	s.governor = governor.NewChainGovernor(zap.NewNop(), database, nodecommon.GoTest)
+	require.NoError(t, s.governor.Run(ctx))
+
+	_, err = s.ChainGovernorSetLimit(ctx, &nodev1.ChainGovernorSetLimitRequest{ChainId: 2, DailyLimit: 1000})
+	assert.Equal(t, nodecommon.ReasonChainNotGoverned, nodecommon.GrpcErrorReasonOf(err))
+	_, err = s.ChainGovernorSetLimit(ctx, &nodev1.ChainGovernorSetLimitRequest{ChainId: 1 << 16, DailyLimit: 1000})
+	assert.Equal(t, nodecommon.ReasonInvalidChainID, nodecommon.GrpcErrorReasonOf(err))
+
+	_, err = s.ChainGovernorSetLimit(ctx, &nodev1.ChainGovernorSetLimitRequest{ChainId: 1, DailyLimit: 1000, BigTransactionSize: 100})
+	require.NoError(t, err)
+	resp, err := s.ChainGovernorGetLimits(ctx, &nodev1.ChainGovernorGetLimitsRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Entries, 1)
+	assert.Equal(t, uint64(1000), resp.Entries[0].DailyLimit)
+	assert.Equal(t, uint64(100), resp.Entries[0].BigTransactionSize)
+	assert.NotEqual(t, uint64(1000), resp.Entries[0].ConfiguredDailyLimit)
+	assert.True(t, resp.Entries[0].Overridden)
+
+	_, err = s.ChainGovernorResetLimit(ctx, &nodev1.ChainGovernorResetLimitRequest{ChainId: 1})
+	require.NoError(t, err)
+	resp, err = s.ChainGovernorGetLimits(ctx, &nodev1.ChainGovernorGetLimitsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, resp.Entries[0].ConfiguredDailyLimit, resp.Entries[0].DailyLimit)
+	assert.False(t, resp.Entries[0].Overridden)
+
+	// The staged config workflow must be used when it requires a second approver.
+	s.stagedConfigs.requireSecondApprover = true
+	_, err = s.ChainGovernorSetLimit(ctx, &nodev1.ChainGovernorSetLimitRequest{ChainId: 1, DailyLimit: 1000})
+	assert.Equal(t, nodecommon.ReasonSecondApproverRequired, nodecommon.GrpcErrorReasonOf(err))
+}
+
+func TestGovernorError(t *testing.T) {
+	assert.Equal(t, nodecommon.ReasonPendingVAANotFound, nodecommon.GrpcErrorReasonOf(governorError(governor.ErrPendingVAANotFound)))
+	assert.Equal(t, nodecommon.ReasonApproverRequired, nodecommon.GrpcErrorReasonOf(governorError(governor.ErrApproverRequired)))
+	err := governorError(fmt.Errorf("%w: alice", governor.ErrDuplicateApproval))
+	assert.Equal(t, nodecommon.ReasonDuplicateApproval, nodecommon.GrpcErrorReasonOf(err))
+	err = governorError(fmt.Errorf("%w: unsupported version 2", governor.ErrInvalidSnapshot))
+	assert.Equal(t, nodecommon.ReasonInvalidGovernorSnapshot, nodecommon.GrpcErrorReasonOf(err))
+}
+
+func TestRestoreArchivedVAAs(t *testing.T) {
+	s := &nodePrivilegedService{logger: zap.NewNop()}
+	_, err := s.RestoreArchivedVAAs(context.Background(), &nodev1.RestoreArchivedVAAsRequest{})
+	assert.Equal(t, nodecommon.ReasonVAAArchiveDisabled, nodecommon.GrpcErrorReasonOf(err))
+
+	database, err := db.OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer database.Close()
+	s.vaaArchiver, err = vaaarchive.NewArchiver(zap.NewNop(), database, vaaarchive.Config{
+		Dir:         t.TempDir(),
+		Retention:   time.Hour,
+		Interval:    time.Hour,
+		RestoreHold: time.Hour,
+	})
+	require.NoError(t, err)
+
+	_, err = s.RestoreArchivedVAAs(context.Background(), &nodev1.RestoreArchivedVAAsRequest{From: 2, To: 1})
+	assert.Equal(t, nodecommon.ReasonInvalidTimeRange, nodecommon.GrpcErrorReasonOf(err))
+	_, err = s.RestoreArchivedVAAs(context.Background(), &nodev1.RestoreArchivedVAAsRequest{To: 1, EmitterAddress: "0x01"})
+	assert.Equal(t, nodecommon.ReasonInvalidEmitterAddress, nodecommon.GrpcErrorReasonOf(err))
+
+	resp, err := s.RestoreArchivedVAAs(context.Background(), &nodev1.RestoreArchivedVAAsRequest{To: 1, EmitterChain: 1, EmitterAddress: "0x01"})
+	require.NoError(t, err)
+	assert.Zero(t, resp.NumRestored)
+}
+
+func TestGetAggregatedAttestation(t *testing.T) {
+	database, err := db.OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer database.Close()
+	s := &nodePrivilegedService{logger: zap.NewNop(), db: database}
+
+	_, err = s.GetAggregatedAttestation(context.Background(), &nodev1.GetAggregatedAttestationRequest{MessageId: "invalid"})
+	assert.Equal(t, nodecommon.ReasonInvalidVAAID, nodecommon.GrpcErrorReasonOf(err))
+
+	v := &vaa.VAA{
+		Version:          vaa.SupportedVAAVersion,
+		GuardianSetIndex: 1,
+		Signatures:       []*vaa.Signature{{Index: 0}, {Index: 1}, {Index: 2}},
+		Timestamp:        time.Unix(1700000000, 0),
+		EmitterChain:     vaa.ChainIDSolana,
+		Sequence:         7,
+	}
+	require.NoError(t, database.StoreSignedVAA(v))
+	_, err = s.GetAggregatedAttestation(context.Background(), &nodev1.GetAggregatedAttestationRequest{MessageId: v.MessageID()})
+	assert.Equal(t, nodecommon.ReasonAttestationNotFound, nodecommon.GrpcErrorReasonOf(err))
+
+	gk, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+	attestation := &aggsig.Attestation{GuardianSetIndex: 1, Signers: 0b111, Signature: aggsig.DeriveSecretKey(gk).Sign(v.SigningDigest().Bytes())}
+	require.NoError(t, database.StoreAggregatedAttestation(*db.VaaIDFromVAA(v), v.Timestamp, attestation.Marshal()))
+
+	resp, err := s.GetAggregatedAttestation(context.Background(), &nodev1.GetAggregatedAttestationRequest{MessageId: v.MessageID()})
+	require.NoError(t, err)
+	assert.Equal(t, attestation.Marshal(), resp.Attestation)
+	assert.Equal(t, uint32(1), resp.GuardianSetIndex)
+	assert.Equal(t, uint32(3), resp.NumSigners)
+	assert.Equal(t, uint32(3*66), resp.ClassicSignaturesSize)
+}
+
+func TestGetSupportSnapshot(t *testing.T) {
+	_, addrs := generateGS(1)
+	gst := nodecommon.NewGuardianSetState(nil)
+	logs := supportbundle.NewLogBuffer(10)
+	zap.New(logs.Core(zap.InfoLevel)).Info("hello")
+	s := &nodePrivilegedService{
+		logger:          zap.NewNop(),
+		guardianAddress: addrs[0],
+		gst:             gst,
+		support:         &supportbundle.Sources{Config: map[string]string{"statusAddr": "[::]:6060"}, Logs: logs},
+	}
+
+	resp, err := s.GetSupportSnapshot(context.Background(), &nodev1.GetSupportSnapshotRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, addrs[0].Hex(), resp.GuardianAddress)
+	assert.Equal(t, map[string]string{"statusAddr": "[::]:6060"}, resp.Config)
+	require.Len(t, resp.RecentLogs, 1)
+	assert.Contains(t, resp.RecentLogs[0], "hello")
+	assert.Nil(t, resp.Heartbeat)
+	assert.Empty(t, resp.SupervisorTree)
+
+	for i, ts := range []int64{2, 1} {
+		require.NoError(t, gst.SetHeartbeat(addrs[0], peer.ID(fmt.Sprint(i)), &gossipv1.Heartbeat{
+			Timestamp: ts,
+			Networks:  []*gossipv1.Heartbeat_Network{{Id: uint32(vaa.ChainIDSolana), Height: 100}},
+		}))
+	}
+	s.SetSupervisorTree(func() []supervisor.RunnableStatus {
+		return []supervisor.RunnableStatus{{DN: "root", State: "NODE_STATE_HEALTHY"}}
+	})
+
+	resp, err = s.GetSupportSnapshot(context.Background(), &nodev1.GetSupportSnapshotRequest{})
+	require.NoError(t, err)
+	require.NotNil(t, resp.Heartbeat)
+	assert.Equal(t, int64(2), resp.Heartbeat.Timestamp)
+	require.Len(t, resp.SupervisorTree, 1)
+	assert.Equal(t, "root", resp.SupervisorTree[0].Dn)
+}
+
+func TestDumpConfig(t *testing.T) {
+	s := &nodePrivilegedService{logger: zap.NewNop()}
+	_, err := s.DumpConfig(context.Background(), &nodev1.DumpConfigRequest{})
+	assert.Equal(t, nodecommon.ReasonInternal, nodecommon.GrpcErrorReasonOf(err))
+
+	s.SetEffectiveConfig(func() ([]byte, error) { return []byte(`{"environment":"unsafe-devnet"}`), nil })
+	resp, err := s.DumpConfig(context.Background(), &nodev1.DumpConfigRequest{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"environment":"unsafe-devnet"}`, resp.Config)
+}
+
+func TestGetNodeHealth(t *testing.T) {
+	_, addrs := generateGS(2)
+	gst := nodecommon.NewGuardianSetState(nil)
+	s := &nodePrivilegedService{logger: zap.NewNop(), guardianAddress: addrs[0], gst: gst}
+
+	readiness.RegisterComponent("adminrpcTestHealth")
+	p2p.DefaultRegistry.SetNetworkStats(vaa.ChainIDSolana, &gossipv1.Heartbeat_Network{Height: 90, SafeHeight: 85, FinalizedHeight: 80})
+	p2p.DefaultRegistry.SetPeerCount(3)
+	require.NoError(t, gst.SetHeartbeat(addrs[1], peer.ID("1"), &gossipv1.Heartbeat{
+		Networks: []*gossipv1.Heartbeat_Network{{Id: uint32(vaa.ChainIDSolana), Height: 100}},
+	}))
+	s.SetQueueDepths(func() []QueueDepth { return []QueueDepth{{Name: "observations", Length: 5, Capacity: 50}} })
+	s.SetSupervisorTree(func() []supervisor.RunnableStatus {
+		return []supervisor.RunnableStatus{
+			{DN: "root", State: "NODE_STATE_HEALTHY"},
+			{DN: "root.solana", State: "NODE_STATE_DEAD", LastError: errors.New("connection refused"), LastErrorTime: time.Unix(1700000000, 0)},
+		}
+	})
+
+	resp, err := s.GetNodeHealth(context.Background(), &nodev1.GetNodeHealthRequest{})
+	require.NoError(t, err)
+	assert.False(t, resp.Ready)
+	assert.Contains(t, resp.Components, &nodev1.ComponentReadiness{Component: "adminrpcTestHealth"})
+	require.Len(t, resp.Watchers, 1)
+	assert.Equal(t, &nodev1.WatcherHealth{
+		ChainId:         uint32(vaa.ChainIDSolana),
+		ChainName:       "solana",
+		Height:          90,
+		SafeHeight:      85,
+		FinalizedHeight: 80,
+		NetworkHeight:   100,
+		Lag:             10,
+	}, resp.Watchers[0])
+	assert.Equal(t, []*nodev1.QueueDepth{{Name: "observations", Length: 5, Capacity: 50}}, resp.Queues)
+	assert.Equal(t, uint32(3), resp.P2PPeerCount)
+	require.Len(t, resp.SupervisorTree, 2)
+	assert.Empty(t, resp.SupervisorTree[0].LastError)
+	assert.Equal(t, "connection refused", resp.SupervisorTree[1].LastError)
+	assert.Equal(t, int64(1700000000), resp.SupervisorTree[1].LastErrorTimestamp)
+
+	readiness.SetReady("adminrpcTestHealth")
+	resp, err = s.GetNodeHealth(context.Background(), &nodev1.GetNodeHealthRequest{})
+	require.NoError(t, err)
+	assert.Contains(t, resp.Components, &nodev1.ComponentReadiness{Component: "adminrpcTestHealth", Ready: true})
+}
+
+func TestPurgeVAAs(t *testing.T) {
+	database, err := db.OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer database.Close()
+	s := &nodePrivilegedService{logger: zap.NewNop(), db: database}
+
+	emitter := vaa.Address{1}
+	for seq := uint64(1); seq <= 5; seq++ {
+		require.NoError(t, database.StoreSignedVAA(&vaa.VAA{
+			Version:        vaa.SupportedVAAVersion,
+			Signatures:     []*vaa.Signature{{Index: 0}},
+			Timestamp:      time.Unix(1700000000, 0),
+			EmitterChain:   vaa.ChainIDSolana,
+			EmitterAddress: emitter,
+			Sequence:       seq,
+		}))
+	}
+	req := &nodev1.PurgeVAAsRequest{
+		EmitterChain:   uint32(vaa.ChainIDSolana),
+		EmitterAddress: emitter.String(),
+		FromSequence:   2,
+		ToSequence:     3,
+		Reason:         "testnet reset",
+	}
+
+	alice := withCaller(context.Background(), "alice")
+	_, err = s.PurgeVAAs(alice, req)
+	assert.Equal(t, nodecommon.ReasonVAAPurgeDisabled, nodecommon.GrpcErrorReasonOf(err))
+	s.allowVAAPurge = true
+
+	for _, tc := range []struct {
+		modify func(r *nodev1.PurgeVAAsRequest)
+		reason nodecommon.GrpcErrorReason
+	}{
+		{func(r *nodev1.PurgeVAAsRequest) { r.Reason = "" }, nodecommon.ReasonMissingReason},
+		{func(r *nodev1.PurgeVAAsRequest) { r.EmitterChain = 0 }, nodecommon.ReasonInvalidChainID},
+		{func(r *nodev1.PurgeVAAsRequest) { r.EmitterAddress = "zz" }, nodecommon.ReasonInvalidEmitterAddress},
+		{func(r *nodev1.PurgeVAAsRequest) { r.FromSequence = 4 }, nodecommon.ReasonInvalidSequenceRange},
+	} {
+		invalid := proto.Clone(req).(*nodev1.PurgeVAAsRequest)
+		tc.modify(invalid)
+		_, err = s.PurgeVAAs(alice, invalid)
+		assert.Equal(t, tc.reason, nodecommon.GrpcErrorReasonOf(err))
+	}
+
+	// Unidentified callers can't purge anything.
+	_, err = s.PurgeVAAs(context.Background(), req)
+	assert.Equal(t, nodecommon.ReasonApproverRequired, nodecommon.GrpcErrorReasonOf(err))
+
+	dryRun := proto.Clone(req).(*nodev1.PurgeVAAsRequest)
+	dryRun.DryRun = true
+	resp, err := s.PurgeVAAs(alice, dryRun)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), resp.Purged)
+	has, err := database.HasVAA(db.VAAID{EmitterChain: vaa.ChainIDSolana, EmitterAddress: emitter, Sequence: 2})
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	resp, err = s.PurgeVAAs(alice, req)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), resp.Purged)
+	assert.Equal(t, uint64(2), resp.FirstSequence)
+	assert.Equal(t, uint64(3), resp.LastSequence)
+	has, err = database.HasVAA(db.VAAID{EmitterChain: vaa.ChainIDSolana, EmitterAddress: emitter, Sequence: 2})
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestSendObservationRequestBatch(t *testing.T) {
+	obsvReqSendC := make(chan *gossipv1.ObservationRequest, 2)
+	s := &nodePrivilegedService{logger: zap.NewNop(), obsvReqSendC: obsvReqSendC}
+	newReq := func(txHash byte) *gossipv1.ObservationRequest {
+		return &gossipv1.ObservationRequest{ChainId: uint32(vaa.ChainIDSolana), TxHash: []byte{txHash}}
+	}
+
+	for _, tc := range []struct {
+		req    *nodev1.SendObservationRequestRequest
+		reason nodecommon.GrpcErrorReason
+	}{
+		{&nodev1.SendObservationRequestRequest{ObservationRequest: newReq(1), ObservationRequests: []*gossipv1.ObservationRequest{newReq(2)}}, nodecommon.ReasonInvalidObsvRequest},
+		{&nodev1.SendObservationRequestRequest{ObservationRequests: make([]*gossipv1.ObservationRequest, maxObservationRequestBatch+1)}, nodecommon.ReasonBatchTooLarge},
+		{&nodev1.SendObservationRequestRequest{ObservationRequests: []*gossipv1.ObservationRequest{newReq(1)}, Rate: -1}, nodecommon.ReasonInvalidObsvRequest},
+		{&nodev1.SendObservationRequestRequest{ObservationRequests: []*gossipv1.ObservationRequest{newReq(1), {TxHash: []byte{2}}}}, nodecommon.ReasonInvalidChainID},
+		{&nodev1.SendObservationRequestRequest{ObservationRequests: []*gossipv1.ObservationRequest{newReq(1), {ChainId: uint32(vaa.ChainIDSolana)}}}, nodecommon.ReasonInvalidObsvRequest},
+	} {
+		_, err := s.SendObservationRequest(context.Background(), tc.req)
+		assert.Equal(t, tc.reason, nodecommon.GrpcErrorReasonOf(err))
+	}
+	assert.Empty(t, obsvReqSendC)
+
+	// Requests are sent in order until the queue is full.
+	resp, err := s.SendObservationRequest(context.Background(), &nodev1.SendObservationRequestRequest{
+		ObservationRequests: []*gossipv1.ObservationRequest{newReq(1), newReq(2), newReq(3)},
+		Rate:                1000,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), resp.Sent)
+	assert.Equal(t, []byte{1}, (<-obsvReqSendC).TxHash)
+	assert.Equal(t, []byte{2}, (<-obsvReqSendC).TxHash)
+
+	// The batch is paced at the requested rate.
+	start := time.Now()
+	resp, err = s.SendObservationRequest(context.Background(), &nodev1.SendObservationRequestRequest{
+		ObservationRequests: []*gossipv1.ObservationRequest{newReq(3), newReq(4)},
+		Rate:                10,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), resp.Sent)
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+	<-obsvReqSendC
+	<-obsvReqSendC
+
+	// A full queue is retryable if nothing could be sent.
+	obsvReqSendC <- newReq(5)
+	obsvReqSendC <- newReq(6)
+	_, err = s.SendObservationRequest(context.Background(), &nodev1.SendObservationRequestRequest{
+		ObservationRequests: []*gossipv1.ObservationRequest{newReq(7)},
+	})
+	assert.Equal(t, nodecommon.ReasonQueueFull, nodecommon.GrpcErrorReasonOf(err))
+}
+
+func TestSetLogLevel(t *testing.T) {
+	s := &nodePrivilegedService{logger: zap.NewNop()}
+	_, err := s.SetLogLevel(context.Background(), &nodev1.SetLogLevelRequest{Level: "debug"})
+	assert.Equal(t, nodecommon.ReasonLogLevelsDisabled, nodecommon.GrpcErrorReasonOf(err))
+
+	levels := nodecommon.NewLogLevels(zapcore.InfoLevel)
+	s.SetLogLevels(levels)
+
+	_, err = s.SetLogLevel(context.Background(), &nodev1.SetLogLevelRequest{Level: "loud"})
+	assert.Equal(t, nodecommon.ReasonInvalidLogLevel, nodecommon.GrpcErrorReasonOf(err))
+
+	resp, err := s.SetLogLevel(context.Background(), &nodev1.SetLogLevelRequest{Component: "ethwatch", Level: "debug", DurationSeconds: 600})
+	require.NoError(t, err)
+	require.Len(t, resp.Levels, 2)
+	assert.Equal(t, "", resp.Levels[0].Component)
+	assert.Equal(t, "info", resp.Levels[0].Level)
+	assert.Zero(t, resp.Levels[0].Expires)
+	assert.Equal(t, "ethwatch", resp.Levels[1].Component)
+	assert.Equal(t, "debug", resp.Levels[1].Level)
+	assert.InDelta(t, time.Now().Add(10*time.Minute).Unix(), resp.Levels[1].Expires, 5)
+
+	resp, err = s.SetLogLevel(context.Background(), &nodev1.SetLogLevelRequest{ListOnly: true, Level: "error"})
+	require.NoError(t, err)
+	assert.Len(t, resp.Levels, 2)
+	assert.Equal(t, zapcore.InfoLevel, levels.Levels()[0].Level)
+
+	resp, err = s.SetLogLevel(context.Background(), &nodev1.SetLogLevelRequest{Component: "ethwatch"})
+	require.NoError(t, err)
+	assert.Len(t, resp.Levels, 1)
+}
+
+type tailLogsStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *nodev1.TailLogsResponse
+}
+
+func (s *tailLogsStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *tailLogsStream) Send(resp *nodev1.TailLogsResponse) error {
+	s.sent <- resp
+	return nil
+}
+
+func TestTailLogs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := &tailLogsStream{ctx: ctx, sent: make(chan *nodev1.TailLogsResponse, 10)}
+
+	s := &nodePrivilegedService{logger: zap.NewNop()}
+	err := s.TailLogs(&nodev1.TailLogsRequest{}, stream)
+	assert.Equal(t, nodecommon.ReasonLogTailDisabled, nodecommon.GrpcErrorReasonOf(err))
+
+	logs := supportbundle.NewLogBuffer(10)
+	s.support = &supportbundle.Sources{Logs: logs}
+	err = s.TailLogs(&nodev1.TailLogsRequest{Level: "loud"}, stream)
+	assert.Equal(t, nodecommon.ReasonInvalidLogLevel, nodecommon.GrpcErrorReasonOf(err))
+
+	root := zap.New(logs.Core(zapcore.DebugLevel)).Named("root")
+	eth, sol := root.Named("ethwatch"), root.Named("solwatch")
+	eth.Info("old")
+	eth.Info("recent")
+	sol.Info("recent")
+
+	errC := make(chan error, 1)
+	go func() {
+		errC <- s.TailLogs(&nodev1.TailLogsRequest{Level: "info", Components: []string{"ethwatch"}, Backlog: 1}, stream)
+	}()
+	resp := <-stream.sent
+	assert.Contains(t, resp.Line, `"msg":"recent"`)
+	assert.Equal(t, "root.ethwatch", resp.Logger)
+	assert.Equal(t, "info", resp.Level)
+
+	eth.Debug("filtered")
+	sol.Warn("filtered")
+	eth.Warn("new")
+	resp = <-stream.sent
+	assert.Contains(t, resp.Line, `"msg":"new"`)
+	assert.Equal(t, "warn", resp.Level)
+
+	cancel()
+	assert.ErrorIs(t, <-errC, context.Canceled)
+	assert.Empty(t, stream.sent)
+}
+
+func TestRestartComponent(t *testing.T) {
+	var restarted []string
+	s := &nodePrivilegedService{logger: zap.NewNop()}
+	s.SetRunnableRestarter(func(dn string) error {
+		switch dn {
+		case "root.ethereum_watch":
+			restarted = append(restarted, dn)
+			return nil
+		case "root.done":
+			return fmt.Errorf("%w: %s is NODE_STATE_DONE", supervisor.ErrRunnableNotRunning, dn)
+		default:
+			return fmt.Errorf("%w: %s", supervisor.ErrRunnableNotFound, dn)
+		}
+	}, []string{"root.admin"})
+
+	for _, tc := range []struct {
+		req    *nodev1.RestartComponentRequest
+		reason nodecommon.GrpcErrorReason
+	}{
+		{&nodev1.RestartComponentRequest{Name: "ethereum_watch"}, nodecommon.ReasonMissingReason},
+		{&nodev1.RestartComponentRequest{Reason: "new endpoint"}, nodecommon.ReasonRunnableNotFound},
+		{&nodev1.RestartComponentRequest{Name: "solana_watch", Reason: "new endpoint"}, nodecommon.ReasonRunnableNotFound},
+		{&nodev1.RestartComponentRequest{Name: "done", Reason: "new endpoint"}, nodecommon.ReasonRunnableNotRunning},
+		{&nodev1.RestartComponentRequest{Name: "admin", Reason: "new endpoint"}, nodecommon.ReasonRunnableNotRestartable},
+		{&nodev1.RestartComponentRequest{Name: "root.admin.grpcserver", Reason: "new endpoint"}, nodecommon.ReasonRunnableNotRestartable},
+	} {
+		_, err := s.RestartComponent(context.Background(), tc.req)
+		assert.Equal(t, tc.reason, nodecommon.GrpcErrorReasonOf(err), tc.req.Name)
+	}
+	assert.Empty(t, restarted)
+
+	for _, name := range []string{"ethereum_watch", "root.ethereum_watch"} {
+		resp, err := s.RestartComponent(context.Background(), &nodev1.RestartComponentRequest{Name: name, Reason: "new endpoint"})
+		require.NoError(t, err)
+		assert.Equal(t, "root.ethereum_watch", resp.Dn)
+	}
+	assert.Equal(t, []string{"root.ethereum_watch", "root.ethereum_watch"}, restarted)
+}
+
+func TestGovMsgToVaaFees(t *testing.T) {
+	recipient := hex.EncodeToString(common.LeftPadBytes([]byte{1}, 32))
+	ts := time.Unix(1654516425, 0)
+
+	v, err := GovMsgToVaa(&nodev1.GovernanceMessage{Sequence: 1, Nonce: 2, Payload: &nodev1.GovernanceMessage_CoreSetMessageFee{
+		CoreSetMessageFee: &nodev1.CoreSetMessageFee{ChainId: uint32(vaa.ChainIDSolana), MessageFee: "1000"},
+	}}, 4, ts)
+	require.NoError(t, err)
+	assert.Equal(t, vaa.BodyCoreSetMessageFee{ChainID: vaa.ChainIDSolana, MessageFee: uint256.NewInt(1000)}.Serialize(), v.Payload)
+	assert.Equal(t, uint32(4), v.GuardianSetIndex)
+
+	v, err = GovMsgToVaa(&nodev1.GovernanceMessage{Sequence: 1, Nonce: 2, Payload: &nodev1.GovernanceMessage_CoreTransferFees{
+		CoreTransferFees: &nodev1.CoreTransferFees{ChainId: uint32(vaa.ChainIDSolana), Amount: "5000", Recipient: recipient},
+	}}, 4, ts)
+	require.NoError(t, err)
+	assert.Equal(t, vaa.BodyCoreTransferFees{ChainID: vaa.ChainIDSolana, Amount: uint256.NewInt(5000), Recipient: vaa.Address{31: 1}}.Serialize(), v.Payload)
+
+	for _, tc := range []struct {
+		msg    *nodev1.GovernanceMessage
+		errMsg string
+	}{
+		{&nodev1.GovernanceMessage{Payload: &nodev1.GovernanceMessage_CoreSetMessageFee{CoreSetMessageFee: &nodev1.CoreSetMessageFee{ChainId: 1, MessageFee: "-1"}}}, "invalid message_fee"},
+		{&nodev1.GovernanceMessage{Payload: &nodev1.GovernanceMessage_CoreSetMessageFee{CoreSetMessageFee: &nodev1.CoreSetMessageFee{ChainId: 1, MessageFee: "0x10"}}}, "invalid message_fee"},
+		{&nodev1.GovernanceMessage{Payload: &nodev1.GovernanceMessage_CoreSetMessageFee{CoreSetMessageFee: &nodev1.CoreSetMessageFee{ChainId: 70000, MessageFee: "1"}}}, "invalid chain_id"},
+		{&nodev1.GovernanceMessage{Payload: &nodev1.GovernanceMessage_CoreTransferFees{CoreTransferFees: &nodev1.CoreTransferFees{ChainId: 1, Amount: "1" + strings.Repeat("0", 80), Recipient: recipient}}}, "amount overflow"},
+		{&nodev1.GovernanceMessage{Payload: &nodev1.GovernanceMessage_CoreTransferFees{CoreTransferFees: &nodev1.CoreTransferFees{ChainId: 1, Amount: "1", Recipient: "01"}}}, "expected 32 bytes"},
+	} {
+		_, err := GovMsgToVaa(tc.msg, 4, ts)
+		assert.ErrorContains(t, err, tc.errMsg)
+	}
+}
+
+func TestInjectGovernanceVAAValidateOnly(t *testing.T) {
+	injectC := make(chan *nodecommon.MessagePublication, 1)
+	s := &nodePrivilegedService{logger: zap.NewNop(), injectC: injectC}
+	req := &nodev1.InjectGovernanceVAARequest{
+		CurrentSetIndex: 4,
+		Timestamp:       1654516425,
+		ValidateOnly:    true,
+		Messages: []*nodev1.GovernanceMessage{{Sequence: 1, Nonce: 2, Payload: &nodev1.GovernanceMessage_CoreSetMessageFee{
+			CoreSetMessageFee: &nodev1.CoreSetMessageFee{ChainId: uint32(vaa.ChainIDSolana), MessageFee: "1000"},
+		}}},
+	}
+
+	resp, err := s.InjectGovernanceVAA(context.Background(), req)
+	require.NoError(t, err)
+	assert.Empty(t, injectC, "nothing is injected")
+
+	v, err := GovMsgToVaa(req.Messages[0], 4, time.Unix(1654516425, 0))
+	require.NoError(t, err)
+	require.Len(t, resp.Digests, 1)
+	assert.Equal(t, v.SigningDigest().Bytes(), resp.Digests[0])
+
+	require.Len(t, resp.Validated, 1)
+	validated := resp.Validated[0]
+	assert.Equal(t, v.MessageID(), validated.MessageId)
+	parsed, err := vaa.Unmarshal(validated.Vaa)
+	require.NoError(t, err)
+	assert.Equal(t, v.Payload, parsed.Payload)
+	assert.Empty(t, parsed.Signatures)
+	assert.Equal(t, "SetMessageFee", validated.PayloadType)
+	assert.Contains(t, validated.PayloadFields, &nodev1.ValidatedGovernanceVAA_Field{Name: "messageFee", Value: "1000"})
+
+	// Invalid messages are rejected as when injecting.
+	req.Messages[0].Payload = &nodev1.GovernanceMessage_CoreSetMessageFee{CoreSetMessageFee: &nodev1.CoreSetMessageFee{ChainId: 1, MessageFee: "x"}}
+	_, err = s.InjectGovernanceVAA(context.Background(), req)
+	assert.Equal(t, nodecommon.ReasonInvalidGovernanceMessage, nodecommon.GrpcErrorReasonOf(err))
+}
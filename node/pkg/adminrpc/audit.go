@@ -0,0 +1,193 @@
+package adminrpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/db"
+	"github.com/certusone/wormhole/node/pkg/supportbundle"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Every call to the privileged service is recorded in the audit log, for post-incident forensics: when it was made, by whom, what
+// was asked for and what the result was. Calls rejected before reaching the service, such as those of TCP clients that may not call
+// the method, are recorded too. The audit log is kept in the database and can optionally be appended to a file as well, one JSON
+// object per line, so that it can be shipped off the host.
+
+// CallerLocal is the caller of the calls made over the admin socket, which only local users can reach, if the user cannot be identified.
+// The calls of identified users are recorded as made by "local:<username>".
+const CallerLocal = "local"
+
+// maxAuditRequestLen is the maximum length of the request recorded in an audit entry. Longer requests are truncated.
+const maxAuditRequestLen = 1024
+
+// Auditor records the calls to the privileged service.
+type Auditor struct {
+	logger *zap.Logger
+	db     *db.Database
+
+	// mu serializes the writes to file.
+	mu   sync.Mutex
+	file *os.File
+}
+
+// auditFileEntry is an audit entry as written to the audit file.
+type auditFileEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Method    string    `json:"method"`
+	Caller    string    `json:"caller"`
+	Request   string    `json:"request"`
+	Result    string    `json:"result"`
+}
+
+// NewAuditor returns an auditor storing the audit entries in database. If path is not empty, the entries are also appended to the
+// file at path, which is created if it doesn't exist.
+func NewAuditor(logger *zap.Logger, database *db.Database, path string) (*Auditor, error) {
+	a := &Auditor{logger: logger, db: database}
+	if path != "" {
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open admin audit log: %w", err)
+		}
+		a.file = f
+	}
+	return a, nil
+}
+
+// Close closes the audit file, if any.
+func (a *Auditor) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.file == nil {
+		return nil
+	}
+	err := a.file.Close()
+	a.file = nil
+	return err
+}
+
+// Entries returns the most recent limit audit entries made at or after since, oldest first.
+func (a *Auditor) Entries(since time.Time, limit int) ([]*db.AdminAuditEntry, error) {
+	return a.db.GetAdminAuditEntries(since, limit)
+}
+
+// record stores an audit entry. Failing to record a call is logged, but doesn't fail the call.
+func (a *Auditor) record(e *db.AdminAuditEntry) {
+	if err := a.db.StoreAdminAuditEntry(e); err != nil {
+		a.logger.Error("failed to store admin audit entry", zap.String("method", e.Method), zap.String("caller", e.Caller), zap.Error(err))
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.file == nil {
+		return
+	}
+	line, err := json.Marshal(auditFileEntry(*e))
+	if err != nil {
+		a.logger.Error("failed to marshal admin audit entry", zap.String("method", e.Method), zap.Error(err))
+		return
+	}
+	if _, err := a.file.Write(append(line, '\n')); err != nil {
+		a.logger.Error("failed to write admin audit entry", zap.String("method", e.Method), zap.String("caller", e.Caller), zap.Error(err))
+	}
+}
+
+// auditRequest summarizes a request as JSON, with secrets such as API keys redacted like in support bundles, truncated to
+// maxAuditRequestLen.
+func auditRequest(req interface{}) string {
+	m, ok := req.(proto.Message)
+	if !ok || m == nil {
+		return ""
+	}
+	b, err := protojson.Marshal(m)
+	if err != nil {
+		return fmt.Sprintf("unable to marshal request: %v", err)
+	}
+	redacted, err := supportbundle.RedactJSON(json.RawMessage(b))
+	if err != nil {
+		return fmt.Sprintf("unable to redact request: %v", err)
+	}
+	buf := new(strings.Builder)
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(redacted); err != nil {
+		return fmt.Sprintf("unable to marshal request: %v", err)
+	}
+	s := strings.TrimSuffix(buf.String(), "\n")
+	if len(s) > maxAuditRequestLen {
+		s = s[:maxAuditRequestLen] + "..."
+	}
+	return s
+}
+
+// auditResult summarizes the result of a call.
+func auditResult(err error) string {
+	if err == nil {
+		return "OK"
+	}
+	st := status.Convert(err)
+	return fmt.Sprintf("%s: %s", st.Code(), st.Message())
+}
+
+// UnaryServerInterceptor returns an interceptor recording the unary calls to the privileged service, with caller identifying who
+// makes a call. It must come before any interceptor which may reject calls, so that rejected calls are recorded too.
+func (a *Auditor) UnaryServerInterceptor(caller func(ctx context.Context) string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !strings.HasPrefix(info.FullMethod, adminMethodPrefix) {
+			return handler(ctx, req)
+		}
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		a.record(&db.AdminAuditEntry{
+			Timestamp: start,
+			Method:    info.FullMethod,
+			Caller:    caller(ctx),
+			Request:   auditRequest(req),
+			Result:    auditResult(err),
+		})
+		return resp, err
+	}
+}
+
+// auditedServerStream captures the first message received on a stream, which is the request of server streaming calls.
+type auditedServerStream struct {
+	grpc.ServerStream
+	req interface{}
+}
+
+func (s *auditedServerStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil && s.req == nil {
+		s.req = m
+	}
+	return err
+}
+
+// StreamServerInterceptor is like UnaryServerInterceptor for streaming calls, which are recorded once they end.
+func (a *Auditor) StreamServerInterceptor(caller func(ctx context.Context) string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !strings.HasPrefix(info.FullMethod, adminMethodPrefix) {
+			return handler(srv, stream)
+		}
+		start := time.Now()
+		audited := &auditedServerStream{ServerStream: stream}
+		err := handler(srv, audited)
+		a.record(&db.AdminAuditEntry{
+			Timestamp: start,
+			Method:    info.FullMethod,
+			Caller:    caller(stream.Context()),
+			Request:   auditRequest(audited.req),
+			Result:    auditResult(err),
+		})
+		return err
+	}
+}
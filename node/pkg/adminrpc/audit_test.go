@@ -0,0 +1,105 @@
+package adminrpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	nodev1 "github.com/certusone/wormhole/node/pkg/proto/node/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestAuditor(t *testing.T) {
+	database, err := db.Open(t.TempDir())
+	require.NoError(t, err)
+	defer database.Close()
+
+	logPath := filepath.Join(t.TempDir(), "admin-audit.log")
+	auditor, err := NewAuditor(zap.NewNop(), database, logPath)
+	require.NoError(t, err)
+	defer auditor.Close()
+
+	caller := func(context.Context) string { return CallerLocal }
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(auditor.UnaryServerInterceptor(caller), UnaryServerInterceptor),
+		grpc.ChainStreamInterceptor(auditor.StreamServerInterceptor(caller), StreamServerInterceptor),
+	)
+	nodev1.RegisterNodePrivilegedServiceServer(server, &mtlsTestService{})
+	go func() { _ = server.Serve(l) }()
+	defer server.Stop()
+
+	conn, err := grpc.Dial(l.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	c := nodev1.NewNodePrivilegedServiceClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err = c.GetNodeHealth(ctx, &nodev1.GetNodeHealthRequest{})
+	require.NoError(t, err)
+	_, err = c.PurgeVAAs(ctx, &nodev1.PurgeVAAsRequest{EmitterChain: 1, Reason: "testnet reset"})
+	require.Error(t, err)
+	_, err = c.GetAndObserveMissingVAAs(ctx, &nodev1.GetAndObserveMissingVAAsRequest{Url: "https://example.com", ApiKey: "secret-key"})
+	require.Error(t, err)
+
+	s := &nodePrivilegedService{logger: zap.NewNop()}
+	_, err = s.AdminAuditLog(ctx, &nodev1.AdminAuditLogRequest{})
+	assert.Equal(t, common.ReasonAuditLogDisabled, common.GrpcErrorReasonOf(err))
+	s.SetAuditor(auditor)
+
+	resp, err := s.AdminAuditLog(ctx, &nodev1.AdminAuditLogRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Entries, 3)
+	assert.Equal(t, "/node.v1.NodePrivilegedService/GetNodeHealth", resp.Entries[0].Method)
+	assert.Equal(t, CallerLocal, resp.Entries[0].Caller)
+	assert.Equal(t, "{}", resp.Entries[0].Request)
+	assert.Equal(t, "OK", resp.Entries[0].Result)
+
+	assert.Equal(t, CallerLocal, resp.Entries[1].Caller)
+	assert.Equal(t, `{"emitterChain":1,"reason":"testnet reset"}`, resp.Entries[1].Request)
+	assert.Contains(t, resp.Entries[1].Result, codes.Unimplemented.String()+": ")
+
+	// Secrets are not recorded.
+	assert.NotContains(t, resp.Entries[2].Request, "secret-key")
+	assert.Contains(t, resp.Entries[2].Request, `"apiKey":"<redacted>"`)
+
+	resp, err = s.AdminAuditLog(ctx, &nodev1.AdminAuditLogRequest{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, resp.Entries, 1)
+	assert.Equal(t, "/node.v1.NodePrivilegedService/GetAndObserveMissingVAAs", resp.Entries[0].Method)
+
+	resp, err = s.AdminAuditLog(ctx, &nodev1.AdminAuditLogRequest{Since: time.Now().Add(time.Hour).UnixMilli()})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Entries)
+
+	// The calls are also appended to the file.
+	f, err := os.Open(logPath)
+	require.NoError(t, err)
+	defer f.Close()
+	var lines []auditFileEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e auditFileEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+		lines = append(lines, e)
+	}
+	require.NoError(t, scanner.Err())
+	require.Len(t, lines, 3)
+	assert.Equal(t, "/node.v1.NodePrivilegedService/PurgeVAAs", lines[1].Method)
+	assert.Equal(t, "OK", lines[0].Result)
+}
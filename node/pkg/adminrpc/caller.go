@@ -0,0 +1,131 @@
+package adminrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os/user"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// The actions which need the approval of distinct operators, such as releasing a held transfer or applying a staged config change,
+// take the identity of the approver from the authenticated caller rather than from the request, which anyone could fill in with any
+// name. Callers are authenticated by the unix user connected to the admin socket, or by the name of the pinned client certificate
+// of the admin service over TCP.
+
+type callerKey struct{}
+
+// withCaller returns ctx carrying the authenticated identity of the caller of a call.
+func withCaller(ctx context.Context, caller string) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// authenticatedCaller returns the authenticated identity of the caller of a call, or false if the caller is not authenticated.
+func authenticatedCaller(ctx context.Context) (string, bool) {
+	caller, ok := ctx.Value(callerKey{}).(string)
+	return caller, ok && caller != ""
+}
+
+// callerStream overrides the context of a stream to carry the identity of its caller.
+type callerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *callerStream) Context() context.Context {
+	return s.ctx
+}
+
+// UnixPeerInfo is the auth info of the connections to the admin socket, identifying the local user at the other end.
+type UnixPeerInfo struct {
+	credentials.CommonAuthInfo
+	UID uint32
+}
+
+// AuthType implements credentials.AuthInfo.
+func (UnixPeerInfo) AuthType() string {
+	return "unix-peer"
+}
+
+// unixPeerCredentials are the transport credentials of the admin socket. They do not secure the connection, which only local users
+// can reach, but look up the user at the other end of it.
+type unixPeerCredentials struct{}
+
+// UnixPeerCredentials returns the server transport credentials of the admin socket, which identify the local user of every
+// connection with the peer credentials of the socket.
+func UnixPeerCredentials() credentials.TransportCredentials {
+	return unixPeerCredentials{}
+}
+
+func (unixPeerCredentials) ClientHandshake(context.Context, string, net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return nil, nil, errors.New("unix peer credentials are server side only")
+}
+
+func (unixPeerCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	uc, ok := conn.(*net.UnixConn)
+	if !ok {
+		return nil, nil, fmt.Errorf("unix peer credentials need a unix socket, got %T", conn)
+	}
+	uid, err := peerUID(uc)
+	if err != nil {
+		// The connection is still served, but its caller is not identified and cannot approve anything.
+		return conn, nil, nil
+	}
+	return conn, UnixPeerInfo{CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.NoSecurity}, UID: uid}, nil
+}
+
+func (unixPeerCredentials) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{SecurityProtocol: "unix-peer"}
+}
+
+func (c unixPeerCredentials) Clone() credentials.TransportCredentials {
+	return c
+}
+
+func (unixPeerCredentials) OverrideServerName(string) error {
+	return nil
+}
+
+// LocalCaller returns the identity of the local user calling over the admin socket, "local:<username>", or false if the socket was not
+// served with UnixPeerCredentials.
+func LocalCaller(ctx context.Context) (string, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", false
+	}
+	info, ok := p.AuthInfo.(UnixPeerInfo)
+	if !ok {
+		return "", false
+	}
+	uid := strconv.FormatUint(uint64(info.UID), 10)
+	if u, err := user.LookupId(uid); err == nil {
+		return CallerLocal + ":" + u.Username, true
+	}
+	return CallerLocal + ":uid=" + uid, true
+}
+
+// CallerUnaryServerInterceptor returns an interceptor making the identity returned by identify available to the handlers of unary calls.
+// Calls whose caller cannot be identified are still served, but cannot approve anything.
+func CallerUnaryServerInterceptor(identify func(ctx context.Context) (string, bool)) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if caller, ok := identify(ctx); ok {
+			ctx = withCaller(ctx, caller)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// CallerStreamServerInterceptor is the streaming counterpart of CallerUnaryServerInterceptor.
+func CallerStreamServerInterceptor(identify func(ctx context.Context) (string, bool)) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if caller, ok := identify(stream.Context()); ok {
+			stream = &callerStream{ServerStream: stream, ctx: withCaller(stream.Context(), caller)}
+		}
+		return handler(srv, stream)
+	}
+}
@@ -0,0 +1,69 @@
+package adminrpc
+
+import (
+	"context"
+	"net"
+	"os/user"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	nodev1 "github.com/certusone/wormhole/node/pkg/proto/node/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// callerTestService records the authenticated caller of its calls.
+type callerTestService struct {
+	nodev1.UnimplementedNodePrivilegedServiceServer
+	callers chan string
+}
+
+func (s *callerTestService) GetNodeHealth(ctx context.Context, _ *nodev1.GetNodeHealthRequest) (*nodev1.GetNodeHealthResponse, error) {
+	caller, _ := authenticatedCaller(ctx)
+	s.callers <- caller
+	return &nodev1.GetNodeHealthResponse{}, nil
+}
+
+func TestAuthenticatedCaller(t *testing.T) {
+	_, ok := authenticatedCaller(context.Background())
+	assert.False(t, ok)
+	_, ok = authenticatedCaller(withCaller(context.Background(), ""))
+	assert.False(t, ok)
+	caller, ok := authenticatedCaller(withCaller(context.Background(), "ops"))
+	assert.True(t, ok)
+	assert.Equal(t, "ops", caller)
+}
+
+func TestLocalCaller(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("peer credentials are only read on Linux")
+	}
+	current, err := user.Current()
+	require.NoError(t, err)
+
+	socketPath := filepath.Join(t.TempDir(), "admin.sock")
+	l, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+	service := &callerTestService{callers: make(chan string, 1)}
+	server := grpc.NewServer(
+		grpc.Creds(UnixPeerCredentials()),
+		grpc.ChainUnaryInterceptor(CallerUnaryServerInterceptor(LocalCaller)),
+	)
+	nodev1.RegisterNodePrivilegedServiceServer(server, service)
+	go func() { _ = server.Serve(l) }()
+	defer server.Stop()
+
+	conn, err := grpc.Dial("unix:///"+socketPath, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err = nodev1.NewNodePrivilegedServiceClient(conn).GetNodeHealth(ctx, &nodev1.GetNodeHealthRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, CallerLocal+":"+current.Username, <-service.callers)
+}
@@ -0,0 +1,59 @@
+package adminrpc
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	nodev1 "github.com/certusone/wormhole/node/pkg/proto/node/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	adminRequestsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "wormhole_admin_rpc_requests_total",
+			Help: "Total number of admin RPC calls by method and outcome",
+		}, []string{"method", "outcome"})
+
+	adminRequestDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "wormhole_admin_rpc_duration_seconds",
+			Help:    "Latency of admin RPC calls by method and outcome",
+			Buckets: []float64{0.001, 0.01, 0.1, 0.5, 1, 5, 10, 30, 60, 300},
+		}, []string{"method", "outcome"})
+)
+
+// adminMethodPrefix is the prefix of the full method names of the privileged service. The admin socket also serves the public RPC
+// service, which has its own metrics.
+var adminMethodPrefix = "/" + nodev1.NodePrivilegedService_ServiceDesc.ServiceName + "/"
+
+// observeAdminRequest records the outcome and latency of a call, if it was made to the privileged service.
+func observeAdminRequest(fullMethod string, start time.Time, err error) {
+	method, ok := strings.CutPrefix(fullMethod, adminMethodPrefix)
+	if !ok {
+		return
+	}
+	outcome := status.Code(err).String()
+	adminRequestsTotal.WithLabelValues(method, outcome).Inc()
+	adminRequestDuration.WithLabelValues(method, outcome).Observe(time.Since(start).Seconds())
+}
+
+// UnaryServerInterceptor records metrics for unary calls to the privileged service.
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	observeAdminRequest(info.FullMethod, start, err)
+	return resp, err
+}
+
+// StreamServerInterceptor records metrics for streaming calls to the privileged service. The latency covers the lifetime of the stream.
+func StreamServerInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, stream)
+	observeAdminRequest(info.FullMethod, start, err)
+	return err
+}
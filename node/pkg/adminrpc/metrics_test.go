@@ -0,0 +1,41 @@
+package adminrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptorRecordsOutcome(t *testing.T) {
+	ok := func(ctx context.Context, req interface{}) (interface{}, error) { return "resp", nil }
+	denied := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.InvalidArgument, "bad request")
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: adminMethodPrefix + "TestMethod"}
+
+	before := testutil.ToFloat64(adminRequestsTotal.WithLabelValues("TestMethod", "OK"))
+	resp, err := UnaryServerInterceptor(context.Background(), nil, info, ok)
+	require.NoError(t, err)
+	assert.Equal(t, "resp", resp)
+	assert.Equal(t, before+1, testutil.ToFloat64(adminRequestsTotal.WithLabelValues("TestMethod", "OK")))
+
+	before = testutil.ToFloat64(adminRequestsTotal.WithLabelValues("TestMethod", "InvalidArgument"))
+	_, err = UnaryServerInterceptor(context.Background(), nil, info, denied)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, before+1, testutil.ToFloat64(adminRequestsTotal.WithLabelValues("TestMethod", "InvalidArgument")))
+}
+
+func TestUnaryServerInterceptorIgnoresOtherServices(t *testing.T) {
+	ok := func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil }
+	info := &grpc.UnaryServerInfo{FullMethod: "/publicrpc.v1.PublicRPCService/GetLastHeartbeats"}
+
+	_, err := UnaryServerInterceptor(context.Background(), nil, info, ok)
+	require.NoError(t, err)
+	assert.Equal(t, float64(0), testutil.ToFloat64(adminRequestsTotal.WithLabelValues("GetLastHeartbeats", "OK")))
+}
@@ -0,0 +1,245 @@
+package adminrpc
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	nodev1 "github.com/certusone/wormhole/node/pkg/proto/node/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// The admin service can also be served over TCP, for remote tooling. Connections must use TLS with a client certificate, and only
+// pinned client certificates are accepted: there is no certificate authority, a client is identified by the SHA-256 fingerprint of
+// its certificate. Each client may only call the methods of the privileged service it is allowed to.
+
+// allMethods allows a client to call every method.
+const allMethods = "*"
+
+// TLSClient is a client allowed to connect to the admin service over TCP.
+type TLSClient struct {
+	// Name identifies the client in the logs.
+	Name string `json:"name"`
+	// Fingerprint is the SHA-256 fingerprint of the client certificate, in hex. Colons are ignored, so the output of
+	// `openssl x509 -noout -fingerprint -sha256` can be used as is.
+	Fingerprint string `json:"fingerprint"`
+	// Methods are the names of the methods of the privileged service the client may call, e.g. GetNodeHealth, or "*" for all of them.
+	Methods []string `json:"methods"`
+}
+
+// TLSClients is the content of the file listing the clients allowed to connect over TCP.
+type TLSClients struct {
+	Clients []TLSClient `json:"clients"`
+}
+
+// TCPConfig configures the admin service over TCP.
+type TCPConfig struct {
+	ListenAddr string
+	// CertFile and KeyFile are the PEM encoded certificate and key the admin service presents to clients.
+	CertFile string
+	KeyFile  string
+	// ClientsFile is the JSON file listing the allowed clients, see TLSClients.
+	ClientsFile string
+}
+
+type tlsClientPolicy struct {
+	name    string
+	all     bool
+	methods map[string]bool
+}
+
+// TLSAuthorizer checks that the callers of the admin service over TCP present a pinned client certificate and are allowed to call
+// the method they call.
+type TLSAuthorizer struct {
+	logger *zap.Logger
+	// policies are the allowed clients, by the fingerprint of their certificate.
+	policies map[[sha256.Size]byte]*tlsClientPolicy
+}
+
+// privilegedMethods returns the names of the methods of the privileged service.
+func privilegedMethods() map[string]bool {
+	methods := make(map[string]bool)
+	for _, m := range nodev1.NodePrivilegedService_ServiceDesc.Methods {
+		methods[m.MethodName] = true
+	}
+	for _, s := range nodev1.NodePrivilegedService_ServiceDesc.Streams {
+		methods[s.StreamName] = true
+	}
+	return methods
+}
+
+func parseFingerprint(s string) ([sha256.Size]byte, error) {
+	var fp [sha256.Size]byte
+	b, err := hex.DecodeString(strings.ReplaceAll(s, ":", ""))
+	if err != nil {
+		return fp, fmt.Errorf("invalid fingerprint: %w", err)
+	}
+	if len(b) != sha256.Size {
+		return fp, fmt.Errorf("invalid fingerprint: must be %d bytes, is %d", sha256.Size, len(b))
+	}
+	copy(fp[:], b)
+	return fp, nil
+}
+
+// NewTLSAuthorizer validates the allowed clients.
+func NewTLSAuthorizer(logger *zap.Logger, clients TLSClients) (*TLSAuthorizer, error) {
+	if len(clients.Clients) == 0 {
+		return nil, errors.New("no clients are allowed")
+	}
+
+	known := privilegedMethods()
+	a := &TLSAuthorizer{logger: logger, policies: make(map[[sha256.Size]byte]*tlsClientPolicy)}
+	names := make(map[string]bool)
+	for _, c := range clients.Clients {
+		if c.Name == "" {
+			return nil, errors.New("every client must have a name")
+		}
+		if names[c.Name] {
+			return nil, fmt.Errorf("duplicate client %s", c.Name)
+		}
+		names[c.Name] = true
+
+		fp, err := parseFingerprint(c.Fingerprint)
+		if err != nil {
+			return nil, fmt.Errorf("client %s: %w", c.Name, err)
+		}
+		if _, exists := a.policies[fp]; exists {
+			return nil, fmt.Errorf("client %s: the certificate is already allowed for another client", c.Name)
+		}
+		if len(c.Methods) == 0 {
+			return nil, fmt.Errorf("client %s: no methods are allowed", c.Name)
+		}
+
+		policy := &tlsClientPolicy{name: c.Name, methods: make(map[string]bool)}
+		for _, m := range c.Methods {
+			if m == allMethods {
+				policy.all = true
+				continue
+			}
+			if !known[m] {
+				return nil, fmt.Errorf("client %s: unknown method %s", c.Name, m)
+			}
+			policy.methods[m] = true
+		}
+		a.policies[fp] = policy
+	}
+	return a, nil
+}
+
+// LoadTLSClients reads the allowed clients from a JSON file.
+func LoadTLSClients(path string) (TLSClients, error) {
+	var clients TLSClients
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return clients, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&clients); err != nil {
+		return clients, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return clients, nil
+}
+
+// ServerTLSConfig returns the TLS config of the admin service, which requires a client certificate and only accepts the pinned ones
+// during the handshake. Which methods a client may call is checked by the interceptors.
+func (a *TLSAuthorizer) ServerTLSConfig(cert tls.Certificate) *tls.Config {
+	return &tls.Config{
+		MinVersion:   tls.VersionTLS13,
+		Certificates: []tls.Certificate{cert},
+		// The certificates are pinned rather than verified against a certificate authority.
+		ClientAuth: tls.RequireAnyClientCert,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return errors.New("no client certificate")
+			}
+			fp := sha256.Sum256(rawCerts[0])
+			if _, ok := a.policies[fp]; !ok {
+				a.logger.Warn("rejected admin client with an unknown certificate", zap.String("fingerprint", hex.EncodeToString(fp[:])))
+				return errors.New("client certificate is not allowed")
+			}
+			return nil
+		},
+	}
+}
+
+// policyOf returns the policy of the client making a call, or an error if it is not an allowed client.
+func (a *TLSAuthorizer) policyOf(ctx context.Context) (*tlsClientPolicy, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, common.NewGrpcError(codes.Unauthenticated, common.ReasonMethodNotAllowed, "no peer")
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.PeerCertificates) == 0 {
+		return nil, common.NewGrpcError(codes.Unauthenticated, common.ReasonMethodNotAllowed, "no client certificate")
+	}
+	policy, ok := a.policies[sha256.Sum256(info.State.PeerCertificates[0].Raw)]
+	if !ok {
+		return nil, common.NewGrpcError(codes.Unauthenticated, common.ReasonMethodNotAllowed, "client certificate is not allowed")
+	}
+	return policy, nil
+}
+
+// ClientName returns the name of the client making a call, or "unknown" if it is not an allowed client.
+func (a *TLSAuthorizer) ClientName(ctx context.Context) string {
+	policy, err := a.policyOf(ctx)
+	if err != nil {
+		return "unknown"
+	}
+	return policy.name
+}
+
+// Caller returns the name of the allowed client making a call, or false if it is not an allowed client.
+func (a *TLSAuthorizer) Caller(ctx context.Context) (string, bool) {
+	policy, err := a.policyOf(ctx)
+	if err != nil {
+		return "", false
+	}
+	return policy.name, true
+}
+
+// authorize returns the name of the client calling fullMethod, or an error if it may not call it.
+func (a *TLSAuthorizer) authorize(ctx context.Context, fullMethod string) (string, error) {
+	policy, err := a.policyOf(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	method := path.Base(fullMethod)
+	if !policy.all && !policy.methods[method] {
+		a.logger.Warn("admin client called a method it is not allowed to call", zap.String("client", policy.name), zap.String("method", fullMethod))
+		return policy.name, common.NewGrpcError(codes.PermissionDenied, common.ReasonMethodNotAllowed, fmt.Sprintf("client %s may not call %s", policy.name, method))
+	}
+	a.logger.Debug("admin client call", zap.String("client", policy.name), zap.String("method", fullMethod))
+	return policy.name, nil
+}
+
+// UnaryServerInterceptor rejects unary calls the client is not allowed to make.
+func (a *TLSAuthorizer) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if _, err := a.authorize(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor rejects streaming calls the client is not allowed to make.
+func (a *TLSAuthorizer) StreamServerInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if _, err := a.authorize(stream.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, stream)
+}
@@ -0,0 +1,182 @@
+package adminrpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	nodev1 "github.com/certusone/wormhole/node/pkg/proto/node/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+func generateTestCert(t *testing.T, name string) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func testCertFingerprint(cert tls.Certificate) string {
+	fp := sha256.Sum256(cert.Certificate[0])
+	return hex.EncodeToString(fp[:])
+}
+
+type mtlsTestService struct {
+	nodev1.UnimplementedNodePrivilegedServiceServer
+}
+
+func (s *mtlsTestService) DumpConfig(context.Context, *nodev1.DumpConfigRequest) (*nodev1.DumpConfigResponse, error) {
+	return &nodev1.DumpConfigResponse{}, nil
+}
+
+func (s *mtlsTestService) GetNodeHealth(context.Context, *nodev1.GetNodeHealthRequest) (*nodev1.GetNodeHealthResponse, error) {
+	return &nodev1.GetNodeHealthResponse{}, nil
+}
+
+func TestNewTLSAuthorizer(t *testing.T) {
+	fp := testCertFingerprint(generateTestCert(t, "client"))
+	tests := []struct {
+		name    string
+		clients []TLSClient
+		errMsg  string
+	}{
+		{"no clients", nil, "no clients are allowed"},
+		{"no name", []TLSClient{{Fingerprint: fp, Methods: []string{"*"}}}, "every client must have a name"},
+		{"bad fingerprint", []TLSClient{{Name: "a", Fingerprint: "zz", Methods: []string{"*"}}}, "invalid fingerprint"},
+		{"short fingerprint", []TLSClient{{Name: "a", Fingerprint: "abcd", Methods: []string{"*"}}}, "must be 32 bytes"},
+		{"no methods", []TLSClient{{Name: "a", Fingerprint: fp}}, "no methods are allowed"},
+		{"unknown method", []TLSClient{{Name: "a", Fingerprint: fp, Methods: []string{"DoesNotExist"}}}, "unknown method DoesNotExist"},
+		{"duplicate name", []TLSClient{{Name: "a", Fingerprint: fp, Methods: []string{"*"}}, {Name: "a", Fingerprint: fp, Methods: []string{"*"}}}, "duplicate client a"},
+		{"duplicate fingerprint", []TLSClient{{Name: "a", Fingerprint: fp, Methods: []string{"*"}}, {Name: "b", Fingerprint: fp, Methods: []string{"*"}}}, "already allowed"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewTLSAuthorizer(zap.NewNop(), TLSClients{Clients: tc.clients})
+			require.ErrorContains(t, err, tc.errMsg)
+		})
+	}
+}
+
+func TestLoadTLSClients(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "clients.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"clients":[{"name":"ops","fingerprint":"AB:CD","methods":["GetNodeHealth"]}]}`), 0600))
+	clients, err := LoadTLSClients(path)
+	require.NoError(t, err)
+	assert.Equal(t, TLSClients{Clients: []TLSClient{{Name: "ops", Fingerprint: "AB:CD", Methods: []string{"GetNodeHealth"}}}}, clients)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"clients":[{"name":"ops","fingerprints":"AB:CD"}]}`), 0600))
+	_, err = LoadTLSClients(path)
+	require.ErrorContains(t, err, "unknown field")
+}
+
+func TestTLSAuthorizer(t *testing.T) {
+	serverCert := generateTestCert(t, "guardian")
+	monitorCert := generateTestCert(t, "monitor")
+	opsCert := generateTestCert(t, "ops")
+	unknownCert := generateTestCert(t, "unknown")
+
+	// The fingerprint of the monitor is given in the format printed by openssl.
+	monitorFp := ""
+	for i, b := range sha256.Sum256(monitorCert.Certificate[0]) {
+		if i > 0 {
+			monitorFp += ":"
+		}
+		monitorFp += hex.EncodeToString([]byte{b})
+	}
+
+	auth, err := NewTLSAuthorizer(zap.NewNop(), TLSClients{Clients: []TLSClient{
+		{Name: "monitor", Fingerprint: monitorFp, Methods: []string{"GetNodeHealth"}},
+		{Name: "ops", Fingerprint: testCertFingerprint(opsCert), Methods: []string{"*"}},
+	}})
+	require.NoError(t, err)
+
+	database, err := db.Open(t.TempDir())
+	require.NoError(t, err)
+	defer database.Close()
+	auditor, err := NewAuditor(zap.NewNop(), database, "")
+	require.NoError(t, err)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer(
+		grpc.Creds(credentials.NewTLS(auth.ServerTLSConfig(serverCert))),
+		grpc.ChainUnaryInterceptor(auditor.UnaryServerInterceptor(auth.ClientName), auth.UnaryServerInterceptor),
+	)
+	nodev1.RegisterNodePrivilegedServiceServer(server, &mtlsTestService{})
+	go func() { _ = server.Serve(l) }()
+	defer server.Stop()
+
+	dial := func(cert tls.Certificate) nodev1.NodePrivilegedServiceClient {
+		roots := x509.NewCertPool()
+		parsed, err := x509.ParseCertificate(serverCert.Certificate[0])
+		require.NoError(t, err)
+		roots.AddCert(parsed)
+		conn, err := grpc.Dial(l.Addr().String(), grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+			MinVersion:   tls.VersionTLS13,
+			ServerName:   "guardian",
+			RootCAs:      roots,
+			Certificates: []tls.Certificate{cert},
+		})))
+		require.NoError(t, err)
+		t.Cleanup(func() { conn.Close() })
+		return nodev1.NewNodePrivilegedServiceClient(conn)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	monitor := dial(monitorCert)
+	_, err = monitor.GetNodeHealth(ctx, &nodev1.GetNodeHealthRequest{})
+	require.NoError(t, err)
+	_, err = monitor.DumpConfig(ctx, &nodev1.DumpConfigRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Equal(t, common.ReasonMethodNotAllowed, common.GrpcErrorReasonOf(err))
+
+	ops := dial(opsCert)
+	_, err = ops.GetNodeHealth(ctx, &nodev1.GetNodeHealthRequest{})
+	require.NoError(t, err)
+	_, err = ops.DumpConfig(ctx, &nodev1.DumpConfigRequest{})
+	require.NoError(t, err)
+
+	// Clients with a certificate that is not pinned are rejected during the handshake.
+	_, err = dial(unknownCert).GetNodeHealth(ctx, &nodev1.GetNodeHealthRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	// The calls are audited with the name of the client, including those that were rejected.
+	entries, err := auditor.Entries(time.Time{}, 0)
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+	assert.Equal(t, "monitor", entries[1].Caller)
+	assert.Equal(t, "/node.v1.NodePrivilegedService/DumpConfig", entries[1].Method)
+	assert.Equal(t, "PermissionDenied: client monitor may not call DumpConfig", entries[1].Result)
+	assert.Equal(t, "ops", entries[3].Caller)
+}
@@ -0,0 +1,14 @@
+//go:build !linux
+
+package adminrpc
+
+import (
+	"errors"
+	"net"
+)
+
+// peerUID returns the user ID of the process at the other end of a unix socket connection. Peer credentials are only read on
+// Linux, elsewhere the callers of the admin socket are not identified and cannot approve anything.
+func peerUID(conn *net.UnixConn) (uint32, error) {
+	return 0, errors.New("peer credentials are not supported on this platform")
+}
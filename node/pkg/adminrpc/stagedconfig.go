@@ -0,0 +1,618 @@
+package adminrpc
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/governor"
+	"github.com/certusone/wormhole/node/pkg/gwrelayer"
+	nodev1 "github.com/certusone/wormhole/node/pkg/proto/node/v1"
+	"github.com/certusone/wormhole/node/pkg/query"
+	"github.com/certusone/wormhole/node/pkg/watchers"
+	"github.com/certusone/wormhole/node/pkg/watchers/solana"
+	solana_rpc "github.com/gagliardetto/solana-go/rpc"
+	"github.com/pmezard/go-difflib/difflib"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// stagedConfigExpiry is how long a proposed config change may wait to be applied before it is discarded.
+	stagedConfigExpiry = 24 * time.Hour
+
+	configTargetGovernorLimits       = "governor-limits"
+	configTargetCCQAllowedRequesters = "ccq-allowed-requesters"
+	configTargetGatewayRelayPolicy   = "gateway-relay-policy"
+	configTargetSolanaEndpoints      = "solana-endpoints"
+)
+
+// configTarget is a piece of configuration that can be changed at runtime using the staged config workflow. Configs are exchanged as text
+// in a canonical form, so that a proposed change can be shown as a line diff against the running config.
+type configTarget interface {
+	// current returns the running config.
+	current() (string, error)
+	// canonicalize validates a proposed config and returns it in canonical form.
+	canonicalize(proposed string) (string, error)
+	// apply replaces the running config with a config returned by canonicalize.
+	apply(config string) error
+}
+
+// stagedConfigChange is a config change which has been proposed but not yet applied.
+type stagedConfigChange struct {
+	id         string
+	target     string
+	base       string // The running config at the time of the proposal.
+	proposed   string
+	proposer   string
+	proposedAt time.Time
+}
+
+// stagedConfigs holds the config changes waiting to be applied. Staged changes are only kept in memory, so they are lost on restart
+// and have to be proposed again: a change reviewed before a restart may not match the config the guardian restarted with.
+type stagedConfigs struct {
+	logger                *zap.Logger
+	requireSecondApprover bool
+	targets               map[string]configTarget
+
+	mutex   sync.Mutex
+	changes map[string]*stagedConfigChange
+}
+
+func newStagedConfigs(
+	logger *zap.Logger,
+	requireSecondApprover bool,
+	gov *governor.ChainGovernor,
+	allowedRequesters *query.AllowedRequesters,
+	gwRelayer *gwrelayer.GatewayRelayer,
+) *stagedConfigs {
+	targets := make(map[string]configTarget)
+	if gov != nil {
+		targets[configTargetGovernorLimits] = governorLimitsTarget{gov}
+	}
+	if allowedRequesters != nil {
+		targets[configTargetCCQAllowedRequesters] = ccqAllowedRequestersTarget{allowedRequesters}
+	}
+	if gwRelayer != nil {
+		targets[configTargetGatewayRelayPolicy] = gatewayRelayPolicyTarget{gwRelayer}
+	}
+	return &stagedConfigs{
+		logger:                logger,
+		requireSecondApprover: requireSecondApprover,
+		targets:               targets,
+		changes:               make(map[string]*stagedConfigChange),
+	}
+}
+
+func (sc *stagedConfigs) target(name string) (configTarget, error) {
+	t, exists := sc.targets[name]
+	if !exists {
+		names := make([]string, 0, len(sc.targets))
+		for n := range sc.targets {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonUnknownConfigTarget,
+			fmt.Sprintf(`unknown config target "%s", available targets are: %s`, name, strings.Join(names, ", ")))
+	}
+	return t, nil
+}
+
+// expireAlreadyLocked drops the changes that have been waiting longer than stagedConfigExpiry.
+func (sc *stagedConfigs) expireAlreadyLocked(now time.Time) {
+	for id, c := range sc.changes {
+		if now.Sub(c.proposedAt) > stagedConfigExpiry {
+			sc.logger.Info("staged config change expired", zap.String("id", id), zap.String("target", c.target), zap.String("proposer", c.proposer))
+			delete(sc.changes, id)
+		}
+	}
+}
+
+func (sc *stagedConfigs) propose(targetName string, config string, proposer string, now time.Time) (*nodev1.StagedConfig, error) {
+	if proposer == "" {
+		return nil, common.NewGrpcError(codes.Unauthenticated, common.ReasonCallerNotAuthenticated, "only authenticated callers can propose config changes")
+	}
+
+	t, err := sc.target(targetName)
+	if err != nil {
+		return nil, err
+	}
+
+	proposed, err := t.canonicalize(config)
+	if err != nil {
+		return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidConfig, err.Error())
+	}
+
+	base, err := t.current()
+	if err != nil {
+		return nil, common.NewGrpcError(codes.Internal, common.ReasonInternal, err.Error())
+	}
+
+	if proposed == base {
+		return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidConfig, "the proposed config is the same as the running config")
+	}
+
+	var idBytes [8]byte
+	if _, err := rand.Read(idBytes[:]); err != nil {
+		return nil, common.NewGrpcError(codes.Internal, common.ReasonInternal, err.Error())
+	}
+
+	c := &stagedConfigChange{
+		id:         hex.EncodeToString(idBytes[:]),
+		target:     targetName,
+		base:       base,
+		proposed:   proposed,
+		proposer:   proposer,
+		proposedAt: now,
+	}
+
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	sc.expireAlreadyLocked(now)
+	sc.changes[c.id] = c
+
+	staged := c.toProto(base)
+	sc.logger.Info("staged config change proposed",
+		zap.String("id", c.id),
+		zap.String("target", c.target),
+		zap.String("proposer", c.proposer),
+		zap.String("diff", staged.Diff),
+	)
+	return staged, nil
+}
+
+// list returns the staged changes, oldest first, diffed against the config that is running now.
+func (sc *stagedConfigs) list(now time.Time) ([]*nodev1.StagedConfig, error) {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	sc.expireAlreadyLocked(now)
+
+	changes := make([]*stagedConfigChange, 0, len(sc.changes))
+	for _, c := range sc.changes {
+		changes = append(changes, c)
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].proposedAt.Before(changes[j].proposedAt) })
+
+	staged := make([]*nodev1.StagedConfig, 0, len(changes))
+	for _, c := range changes {
+		current, err := sc.targets[c.target].current()
+		if err != nil {
+			return nil, common.NewGrpcError(codes.Internal, common.ReasonInternal, err.Error())
+		}
+		staged = append(staged, c.toProto(current))
+	}
+	return staged, nil
+}
+
+func (sc *stagedConfigs) apply(id string, approver string, now time.Time) error {
+	if approver == "" {
+		return common.NewGrpcError(codes.Unauthenticated, common.ReasonCallerNotAuthenticated, "only authenticated callers can apply config changes")
+	}
+
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	sc.expireAlreadyLocked(now)
+
+	c, exists := sc.changes[id]
+	if !exists {
+		return common.NewGrpcError(codes.NotFound, common.ReasonStagedConfigNotFound, fmt.Sprintf("no staged config change with id %s, staged changes are discarded after %s or when the guardian restarts", id, stagedConfigExpiry))
+	}
+
+	if sc.requireSecondApprover && approver == c.proposer {
+		return common.NewGrpcError(codes.PermissionDenied, common.ReasonSecondApproverRequired, "the change must be applied by someone other than its proposer")
+	}
+
+	t := sc.targets[c.target]
+	current, err := t.current()
+	if err != nil {
+		return common.NewGrpcError(codes.Internal, common.ReasonInternal, err.Error())
+	}
+
+	// Applying a change on top of a config that was modified after it was reviewed could undo the other modification.
+	if current != c.base {
+		return common.NewGrpcError(codes.FailedPrecondition, common.ReasonStagedConfigStale,
+			"the running config has changed since the change was proposed, discard it and propose it again")
+	}
+
+	if err := t.apply(c.proposed); err != nil {
+		return common.NewGrpcError(codes.Internal, common.ReasonInternal, err.Error())
+	}
+
+	delete(sc.changes, id)
+	sc.logger.Info("staged config change applied",
+		zap.String("id", c.id),
+		zap.String("target", c.target),
+		zap.String("proposer", c.proposer),
+		zap.String("approver", approver),
+	)
+	return nil
+}
+
+func (sc *stagedConfigs) discard(id string) error {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	c, exists := sc.changes[id]
+	if !exists {
+		return common.NewGrpcError(codes.NotFound, common.ReasonStagedConfigNotFound, fmt.Sprintf("no staged config change with id %s, staged changes are discarded after %s or when the guardian restarts", id, stagedConfigExpiry))
+	}
+
+	delete(sc.changes, id)
+	sc.logger.Info("staged config change discarded", zap.String("id", c.id), zap.String("target", c.target), zap.String("proposer", c.proposer))
+	return nil
+}
+
+// toProto converts the change to its protobuf representation, with a diff against the given running config.
+func (c *stagedConfigChange) toProto(current string) *nodev1.StagedConfig {
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(current),
+		B:        splitLines(c.proposed),
+		FromFile: "running",
+		ToFile:   "staged",
+		Context:  3,
+	})
+	if err != nil {
+		// This can only fail if writing to the string builder fails.
+		diff = err.Error()
+	}
+
+	return &nodev1.StagedConfig{
+		Id:         c.id,
+		Target:     c.target,
+		Proposer:   c.proposer,
+		ProposedAt: c.proposedAt.UnixMilli(),
+		Diff:       diff,
+		Stale:      current != c.base,
+	}
+}
+
+// splitLines splits a config into lines for diffing. Unlike difflib.SplitLines, it does not add an empty line after the final newline.
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// governorLimitsTarget exposes the per chain governor limits, as a JSON array of governor.ChainLimit. Chains which are left out of a
+// proposal keep their limits.
+type governorLimitsTarget struct {
+	gov *governor.ChainGovernor
+}
+
+func (t governorLimitsTarget) current() (string, error) {
+	return marshalChainLimits(t.gov.ChainLimits())
+}
+
+func (t governorLimitsTarget) canonicalize(proposed string) (string, error) {
+	var changes []governor.ChainLimit
+	dec := json.NewDecoder(strings.NewReader(proposed))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&changes); err != nil {
+		return "", fmt.Errorf("failed to parse chain limits: %w", err)
+	}
+
+	limits := t.gov.ChainLimits()
+	for _, change := range changes {
+		idx := sort.Search(len(limits), func(i int) bool { return limits[i].ChainId >= change.ChainId })
+		if idx == len(limits) || limits[idx].ChainId != change.ChainId {
+			return "", fmt.Errorf("chain %v is not governed", change.ChainId)
+		}
+		limits[idx] = change
+	}
+	return marshalChainLimits(limits)
+}
+
+func (t governorLimitsTarget) apply(config string) error {
+	var limits []governor.ChainLimit
+	if err := json.Unmarshal([]byte(config), &limits); err != nil {
+		return err
+	}
+	return t.gov.SetChainLimits(limits)
+}
+
+func marshalChainLimits(limits []governor.ChainLimit) (string, error) {
+	b, err := json.MarshalIndent(limits, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b) + "\n", nil
+}
+
+// ccqAllowedRequestersTarget exposes the addresses allowed to submit cross chain queries, one per line.
+type ccqAllowedRequestersTarget struct {
+	allowedRequesters *query.AllowedRequesters
+}
+
+func (t ccqAllowedRequestersTarget) current() (string, error) {
+	return formatAllowedRequesters(t.allowedRequesters), nil
+}
+
+func (t ccqAllowedRequestersTarget) canonicalize(proposed string) (string, error) {
+	var parsed query.AllowedRequesters
+	if err := parsed.Set(joinAllowedRequesters(proposed)); err != nil {
+		return "", err
+	}
+	return formatAllowedRequesters(&parsed), nil
+}
+
+func (t ccqAllowedRequestersTarget) apply(config string) error {
+	return t.allowedRequesters.Set(joinAllowedRequesters(config))
+}
+
+// joinAllowedRequesters converts a list of addresses separated by commas or whitespace to the comma separated format of the
+// `--ccqAllowedRequesters` flag.
+func joinAllowedRequesters(s string) string {
+	return strings.Join(strings.FieldsFunc(s, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }), ",")
+}
+
+func formatAllowedRequesters(a *query.AllowedRequesters) string {
+	var buf bytes.Buffer
+	for _, addr := range a.List() {
+		buf.WriteString(addr.Hex())
+		buf.WriteString("\n")
+	}
+	return buf.String()
+}
+
+// gatewayRelayPolicyTarget exposes the policy of the gateway relayer, as a JSON object of relayPolicyConfig.
+type gatewayRelayPolicyTarget struct {
+	gwRelayer *gwrelayer.GatewayRelayer
+}
+
+// relayPolicyConfig is the JSON representation of gwrelayer.RelayPolicy, with the durations in the format of time.ParseDuration.
+type relayPolicyConfig struct {
+	MaxAttempts  uint    `json:"maxAttempts"`
+	InitialDelay string  `json:"initialDelay"`
+	Backoff      float64 `json:"backoff"`
+	MaxDelay     string  `json:"maxDelay"`
+	GasLimit     uint64  `json:"gasLimit"`
+	InitialFee   uint64  `json:"initialFee"`
+	FeeBump      float64 `json:"feeBump"`
+	MaxFee       uint64  `json:"maxFee"`
+}
+
+func (t gatewayRelayPolicyTarget) current() (string, error) {
+	return marshalRelayPolicy(t.gwRelayer.Policy())
+}
+
+func (t gatewayRelayPolicyTarget) canonicalize(proposed string) (string, error) {
+	policy, err := parseRelayPolicy(proposed)
+	if err != nil {
+		return "", err
+	}
+	return marshalRelayPolicy(policy)
+}
+
+func (t gatewayRelayPolicyTarget) apply(config string) error {
+	policy, err := parseRelayPolicy(config)
+	if err != nil {
+		return err
+	}
+	return t.gwRelayer.SetPolicy(policy)
+}
+
+func parseRelayPolicy(s string) (gwrelayer.RelayPolicy, error) {
+	var cfg relayPolicyConfig
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return gwrelayer.RelayPolicy{}, fmt.Errorf("failed to parse relay policy: %w", err)
+	}
+
+	initialDelay, err := time.ParseDuration(cfg.InitialDelay)
+	if err != nil {
+		return gwrelayer.RelayPolicy{}, fmt.Errorf("invalid initial delay: %w", err)
+	}
+	maxDelay, err := time.ParseDuration(cfg.MaxDelay)
+	if err != nil {
+		return gwrelayer.RelayPolicy{}, fmt.Errorf("invalid max delay: %w", err)
+	}
+
+	policy := gwrelayer.RelayPolicy{
+		MaxAttempts:  cfg.MaxAttempts,
+		InitialDelay: initialDelay,
+		Backoff:      cfg.Backoff,
+		MaxDelay:     maxDelay,
+		GasLimit:     cfg.GasLimit,
+		InitialFee:   cfg.InitialFee,
+		FeeBump:      cfg.FeeBump,
+		MaxFee:       cfg.MaxFee,
+	}
+	if err := policy.Validate(); err != nil {
+		return gwrelayer.RelayPolicy{}, fmt.Errorf("invalid relay policy: %w", err)
+	}
+	return policy, nil
+}
+
+func marshalRelayPolicy(policy gwrelayer.RelayPolicy) (string, error) {
+	b, err := json.MarshalIndent(relayPolicyConfig{
+		MaxAttempts:  policy.MaxAttempts,
+		InitialDelay: policy.InitialDelay.String(),
+		Backoff:      policy.Backoff,
+		MaxDelay:     policy.MaxDelay.String(),
+		GasLimit:     policy.GasLimit,
+		InitialFee:   policy.InitialFee,
+		FeeBump:      policy.FeeBump,
+		MaxFee:       policy.MaxFee,
+	}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b) + "\n", nil
+}
+
+// solanaEndpointsTarget exposes the endpoints the Solana watchers connect to, as a JSON object of solanaEndpointsConfig. The
+// endpoints are shared by the watchers of every commitment level, like the flags they are configured with. A watcher only uses
+// new endpoints once it is restarted.
+type solanaEndpointsTarget struct {
+	configs *watchers.Configs
+}
+
+// solanaEndpointsConfig holds the endpoints of a Solana watcher config. The Geyser token is left out, so that it does not show up in
+// diffs and logs, and is kept as it is.
+type solanaEndpointsConfig struct {
+	Rpc       string `json:"rpc"`
+	Websocket string `json:"websocket"`
+	Geyser    string `json:"geyser"`
+}
+
+// solanaConfigs returns the current configs of the Solana watchers, ordered by NetworkID.
+func (t solanaEndpointsTarget) solanaConfigs() []*solana.WatcherConfig {
+	var configs []*solana.WatcherConfig
+	for _, wc := range t.configs.All() {
+		if sc, ok := wc.(*solana.WatcherConfig); ok {
+			configs = append(configs, sc)
+		}
+	}
+	return configs
+}
+
+func (t solanaEndpointsTarget) current() (string, error) {
+	configs := t.solanaConfigs()
+	if len(configs) == 0 {
+		return "", errors.New("no Solana watcher is configured")
+	}
+	return marshalSolanaEndpoints(solanaEndpointsConfig{Rpc: configs[0].Rpc, Websocket: configs[0].Websocket, Geyser: configs[0].Geyser})
+}
+
+func (t solanaEndpointsTarget) canonicalize(proposed string) (string, error) {
+	endpoints, err := t.parse(proposed)
+	if err != nil {
+		return "", err
+	}
+	return marshalSolanaEndpoints(endpoints)
+}
+
+func (t solanaEndpointsTarget) apply(config string) error {
+	endpoints, err := t.parse(config)
+	if err != nil {
+		return err
+	}
+	for _, wc := range t.solanaConfigs() {
+		// The running watcher may still read its config, so it is replaced by an updated copy.
+		updated := *wc
+		updated.Rpc = endpoints.Rpc
+		updated.Websocket = endpoints.Websocket
+		updated.Geyser = endpoints.Geyser
+		if err := t.configs.Set(&updated); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t solanaEndpointsTarget) parse(s string) (solanaEndpointsConfig, error) {
+	var endpoints solanaEndpointsConfig
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&endpoints); err != nil {
+		return solanaEndpointsConfig{}, fmt.Errorf("failed to parse Solana endpoints: %w", err)
+	}
+	if err := checkEndpointURL(endpoints.Rpc, "http", "https"); err != nil {
+		return solanaEndpointsConfig{}, fmt.Errorf("invalid rpc: %w", err)
+	}
+	if endpoints.Websocket != "" {
+		if err := checkEndpointURL(endpoints.Websocket, "ws", "wss"); err != nil {
+			return solanaEndpointsConfig{}, fmt.Errorf("invalid websocket: %w", err)
+		}
+	}
+	if endpoints.Geyser != "" {
+		if err := checkEndpointURL(endpoints.Geyser, "http", "https"); err != nil {
+			return solanaEndpointsConfig{}, fmt.Errorf("invalid geyser: %w", err)
+		}
+	}
+	for _, wc := range t.solanaConfigs() {
+		if wc.Commitment == solana_rpc.CommitmentProcessed && endpoints.Geyser == "" {
+			return solanaEndpointsConfig{}, fmt.Errorf("the %s watcher requires a Geyser endpoint", wc.NetworkID)
+		}
+	}
+	return endpoints, nil
+}
+
+// checkEndpointURL returns an error unless s is an absolute URL with a host and one of the given schemes.
+func checkEndpointURL(s string, schemes ...string) error {
+	u, err := url.Parse(s)
+	if err != nil {
+		return err
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%q has no host", s)
+	}
+	for _, scheme := range schemes {
+		if u.Scheme == scheme {
+			return nil
+		}
+	}
+	return fmt.Errorf("%q must use one of the schemes %s", s, strings.Join(schemes, ", "))
+}
+
+func marshalSolanaEndpoints(endpoints solanaEndpointsConfig) (string, error) {
+	b, err := json.MarshalIndent(endpoints, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b) + "\n", nil
+}
+
+func (s *nodePrivilegedService) GetRuntimeConfig(ctx context.Context, req *nodev1.GetRuntimeConfigRequest) (*nodev1.GetRuntimeConfigResponse, error) {
+	t, err := s.stagedConfigs.target(req.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := t.current()
+	if err != nil {
+		return nil, common.NewGrpcError(codes.Internal, common.ReasonInternal, err.Error())
+	}
+
+	return &nodev1.GetRuntimeConfigResponse{Config: config}, nil
+}
+
+func (s *nodePrivilegedService) ProposeConfigChange(ctx context.Context, req *nodev1.ProposeConfigChangeRequest) (*nodev1.ProposeConfigChangeResponse, error) {
+	proposer, _ := authenticatedCaller(ctx)
+	staged, err := s.stagedConfigs.propose(req.Target, req.Config, proposer, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return &nodev1.ProposeConfigChangeResponse{Staged: staged}, nil
+}
+
+func (s *nodePrivilegedService) ListStagedConfigs(ctx context.Context, req *nodev1.ListStagedConfigsRequest) (*nodev1.ListStagedConfigsResponse, error) {
+	staged, err := s.stagedConfigs.list(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return &nodev1.ListStagedConfigsResponse{Staged: staged}, nil
+}
+
+func (s *nodePrivilegedService) ApplyStagedConfig(ctx context.Context, req *nodev1.ApplyStagedConfigRequest) (*nodev1.ApplyStagedConfigResponse, error) {
+	approver, _ := authenticatedCaller(ctx)
+	if err := s.stagedConfigs.apply(req.Id, approver, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return &nodev1.ApplyStagedConfigResponse{}, nil
+}
+
+func (s *nodePrivilegedService) DiscardStagedConfig(ctx context.Context, req *nodev1.DiscardStagedConfigRequest) (*nodev1.DiscardStagedConfigResponse, error) {
+	if err := s.stagedConfigs.discard(req.Id); err != nil {
+		return nil, err
+	}
+
+	return &nodev1.DiscardStagedConfigResponse{}, nil
+}
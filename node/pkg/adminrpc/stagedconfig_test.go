@@ -0,0 +1,224 @@
+package adminrpc
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/gwrelayer"
+	nodev1 "github.com/certusone/wormhole/node/pkg/proto/node/v1"
+	"github.com/certusone/wormhole/node/pkg/query"
+	"github.com/certusone/wormhole/node/pkg/watchers"
+	"github.com/certusone/wormhole/node/pkg/watchers/solana"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	solana_rpc "github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+const (
+	testRequester1 = "0xbeFA429d57cD18b7F8A4d91A2da9AB4AF05d0FBe"
+	testRequester2 = "0x88D7D8B32a9105d228100E72dFFe2Fae0705D31c"
+)
+
+func newTestStagedConfigs(t *testing.T, requireSecondApprover bool) (*stagedConfigs, *query.AllowedRequesters) {
+	t.Helper()
+	var allowedRequesters query.AllowedRequesters
+	require.NoError(t, allowedRequesters.Set(testRequester1))
+	return newStagedConfigs(zap.NewNop(), requireSecondApprover, nil, &allowedRequesters, nil), &allowedRequesters
+}
+
+func TestStagedConfigProposeAndApply(t *testing.T) {
+	sc, allowedRequesters := newTestStagedConfigs(t, false)
+	now := time.Now()
+
+	staged, err := sc.propose(configTargetCCQAllowedRequesters, testRequester1+", "+testRequester2, "alice", now)
+	require.NoError(t, err)
+	assert.Equal(t, "--- running\n+++ staged\n@@ -1 +1,2 @@\n+"+testRequester2+"\n "+testRequester1+"\n", staged.Diff)
+	assert.False(t, staged.Stale)
+
+	// Nothing changes until the proposal is applied.
+	assert.Len(t, allowedRequesters.List(), 1)
+
+	list, err := sc.list(now)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, staged.Id, list[0].Id)
+
+	require.NoError(t, sc.apply(staged.Id, "alice", now))
+	assert.Len(t, allowedRequesters.List(), 2)
+
+	err = sc.apply(staged.Id, "alice", now)
+	assert.Equal(t, common.ReasonStagedConfigNotFound, common.GrpcErrorReasonOf(err))
+}
+
+func TestStagedConfigRequiresSecondApprover(t *testing.T) {
+	sc, allowedRequesters := newTestStagedConfigs(t, true)
+	now := time.Now()
+
+	staged, err := sc.propose(configTargetCCQAllowedRequesters, testRequester2, "alice", now)
+	require.NoError(t, err)
+
+	err = sc.apply(staged.Id, "alice", now)
+	assert.Equal(t, common.ReasonSecondApproverRequired, common.GrpcErrorReasonOf(err))
+	require.NoError(t, sc.apply(staged.Id, "bob", now))
+	assert.Equal(t, testRequester2, allowedRequesters.List()[0].Hex())
+}
+
+func TestStagedConfigStale(t *testing.T) {
+	sc, allowedRequesters := newTestStagedConfigs(t, false)
+	now := time.Now()
+
+	staged, err := sc.propose(configTargetCCQAllowedRequesters, testRequester2, "alice", now)
+	require.NoError(t, err)
+
+	// Someone changes the config after the proposal was reviewed.
+	require.NoError(t, allowedRequesters.Set(testRequester1+","+testRequester2))
+
+	list, err := sc.list(now)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.True(t, list[0].Stale)
+
+	err = sc.apply(staged.Id, "bob", now)
+	assert.Equal(t, common.ReasonStagedConfigStale, common.GrpcErrorReasonOf(err))
+	assert.Len(t, allowedRequesters.List(), 2)
+
+	require.NoError(t, sc.discard(staged.Id))
+	list, err = sc.list(now)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+}
+
+func TestStagedConfigInvalidProposals(t *testing.T) {
+	sc, _ := newTestStagedConfigs(t, false)
+	now := time.Now()
+
+	_, err := sc.propose("bogus", testRequester2, "alice", now)
+	assert.Equal(t, common.ReasonUnknownConfigTarget, common.GrpcErrorReasonOf(err))
+
+	// The governor is not enabled.
+	_, err = sc.propose(configTargetGovernorLimits, "[]", "alice", now)
+	assert.Equal(t, common.ReasonUnknownConfigTarget, common.GrpcErrorReasonOf(err))
+
+	for name, config := range map[string]string{
+		"invalid address": "Hello",
+		"empty":           "",
+		"unchanged":       testRequester1,
+	} {
+		_, err = sc.propose(configTargetCCQAllowedRequesters, config, "alice", now)
+		assert.Equal(t, common.ReasonInvalidConfig, common.GrpcErrorReasonOf(err), name)
+	}
+
+	_, err = sc.propose(configTargetCCQAllowedRequesters, testRequester2, "", now)
+	assert.Equal(t, common.ReasonCallerNotAuthenticated, common.GrpcErrorReasonOf(err))
+}
+
+func TestStagedConfigCallers(t *testing.T) {
+	sc, allowedRequesters := newTestStagedConfigs(t, true)
+	s := &nodePrivilegedService{stagedConfigs: sc}
+	alice := withCaller(context.Background(), "local:alice")
+
+	// Unidentified callers can neither propose nor apply changes.
+	_, err := s.ProposeConfigChange(context.Background(), &nodev1.ProposeConfigChangeRequest{Target: configTargetCCQAllowedRequesters, Config: testRequester2})
+	assert.Equal(t, common.ReasonCallerNotAuthenticated, common.GrpcErrorReasonOf(err))
+
+	resp, err := s.ProposeConfigChange(alice, &nodev1.ProposeConfigChangeRequest{Target: configTargetCCQAllowedRequesters, Config: testRequester2})
+	require.NoError(t, err)
+	assert.Equal(t, "local:alice", resp.Staged.Proposer)
+
+	_, err = s.ApplyStagedConfig(context.Background(), &nodev1.ApplyStagedConfigRequest{Id: resp.Staged.Id})
+	assert.Equal(t, common.ReasonCallerNotAuthenticated, common.GrpcErrorReasonOf(err))
+	_, err = s.ApplyStagedConfig(alice, &nodev1.ApplyStagedConfigRequest{Id: resp.Staged.Id})
+	assert.Equal(t, common.ReasonSecondApproverRequired, common.GrpcErrorReasonOf(err))
+	assert.Equal(t, testRequester1, allowedRequesters.List()[0].Hex())
+
+	_, err = s.ApplyStagedConfig(withCaller(context.Background(), "local:bob"), &nodev1.ApplyStagedConfigRequest{Id: resp.Staged.Id})
+	require.NoError(t, err)
+	assert.Equal(t, testRequester2, allowedRequesters.List()[0].Hex())
+}
+
+func TestStagedConfigGatewayRelayPolicy(t *testing.T) {
+	contract, err := sdktypes.Bech32ifyAddressBytes("wormhole", make([]byte, 32))
+	require.NoError(t, err)
+	gr, err := gwrelayer.NewGatewayRelayer(zap.NewNop(), gwrelayer.NewHTTPSubmitter("http://localhost"), contract, common.GoTest, gwrelayer.DefaultRelayPolicy())
+	require.NoError(t, err)
+	sc := newStagedConfigs(zap.NewNop(), false, nil, nil, gr)
+	now := time.Now()
+
+	current, err := sc.targets[configTargetGatewayRelayPolicy].current()
+	require.NoError(t, err)
+	assert.Contains(t, current, `"initialDelay": "5s"`)
+
+	proposed := strings.Replace(current, `"maxAttempts": 10`, `"maxAttempts": 20`, 1)
+	staged, err := sc.propose(configTargetGatewayRelayPolicy, proposed, "alice", now)
+	require.NoError(t, err)
+	assert.Contains(t, staged.Diff, "+  \"maxAttempts\": 20,\n")
+	require.NoError(t, sc.apply(staged.Id, "bob", now))
+	assert.Equal(t, uint(20), gr.Policy().MaxAttempts)
+
+	for name, config := range map[string]string{
+		"invalid json":     "{",
+		"unknown field":    `{"retries": 3}`,
+		"invalid duration": strings.Replace(current, `"5s"`, `"soon"`, 1),
+		"invalid policy":   strings.Replace(current, `"maxAttempts": 10`, `"maxAttempts": 0`, 1),
+	} {
+		_, err = sc.propose(configTargetGatewayRelayPolicy, config, "alice", now)
+		assert.Equal(t, common.ReasonInvalidConfig, common.GrpcErrorReasonOf(err), name)
+	}
+}
+
+func TestStagedConfigSolanaEndpoints(t *testing.T) {
+	finalized := &solana.WatcherConfig{NetworkID: "solana-finalized", ChainID: vaa.ChainIDSolana, Rpc: "http://solana:8899", Commitment: solana_rpc.CommitmentFinalized}
+	confirmed := &solana.WatcherConfig{NetworkID: "solana-confirmed", ChainID: vaa.ChainIDSolana, Rpc: "http://solana:8899", Commitment: solana_rpc.CommitmentConfirmed, GeyserToken: "secret"}
+	configs := watchers.NewConfigs([]watchers.WatcherConfig{finalized, confirmed})
+	sc := newStagedConfigs(zap.NewNop(), false, nil, nil, nil)
+	sc.targets[configTargetSolanaEndpoints] = solanaEndpointsTarget{configs}
+	now := time.Now()
+
+	current, err := sc.targets[configTargetSolanaEndpoints].current()
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"rpc\": \"http://solana:8899\",\n  \"websocket\": \"\",\n  \"geyser\": \"\"\n}\n", current)
+
+	staged, err := sc.propose(configTargetSolanaEndpoints, `{"rpc": "https://backup:8899", "websocket": "", "geyser": "https://geyser:10000"}`, "alice", now)
+	require.NoError(t, err)
+	assert.Contains(t, staged.Diff, "+  \"rpc\": \"https://backup:8899\",\n")
+	require.NoError(t, sc.apply(staged.Id, "bob", now))
+
+	// Every Solana watcher gets the new endpoints in a new config, and keeps the rest of its config.
+	for _, wc := range []*solana.WatcherConfig{finalized, confirmed} {
+		updated := configs.Get(wc.NetworkID).(*solana.WatcherConfig)
+		assert.NotSame(t, wc, updated)
+		assert.Equal(t, "https://backup:8899", updated.Rpc)
+		assert.Equal(t, "https://geyser:10000", updated.Geyser)
+		assert.Equal(t, wc.Commitment, updated.Commitment)
+		assert.Equal(t, wc.GeyserToken, updated.GeyserToken)
+		assert.Equal(t, "http://solana:8899", wc.Rpc)
+	}
+
+	for name, config := range map[string]string{
+		"invalid json":   "{",
+		"unknown field":  `{"rpc": "http://solana:8899", "token": "x"}`,
+		"missing rpc":    `{"websocket": "ws://solana:8900"}`,
+		"invalid scheme": `{"rpc": "ws://solana:8899"}`,
+		"no host":        `{"rpc": "http://"}`,
+	} {
+		_, err = sc.propose(configTargetSolanaEndpoints, config, "alice", now)
+		assert.Equal(t, common.ReasonInvalidConfig, common.GrpcErrorReasonOf(err), name)
+	}
+}
+
+func TestStagedConfigExpiry(t *testing.T) {
+	sc, _ := newTestStagedConfigs(t, false)
+	now := time.Now()
+
+	staged, err := sc.propose(configTargetCCQAllowedRequesters, testRequester2, "alice", now)
+	require.NoError(t, err)
+
+	err = sc.apply(staged.Id, "bob", now.Add(stagedConfigExpiry+time.Second))
+	assert.Equal(t, common.ReasonStagedConfigNotFound, common.GrpcErrorReasonOf(err))
+}
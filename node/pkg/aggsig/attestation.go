@@ -0,0 +1,75 @@
+package aggsig
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// AttestationVersion is the first byte of a marshaled attestation.
+const AttestationVersion = 1
+
+// AttestationLength is the length of a marshaled attestation: version, guardian set index, signer bitmap and signature.
+const AttestationLength = 1 + 4 + 4 + SignatureLength
+
+// Attestation is an aggregated signature by some of the guardians of a guardian set over a VAA signing digest. It takes the place of
+// the signatures of a classic VAA, and its size does not depend on the number of signers.
+type Attestation struct {
+	GuardianSetIndex uint32
+	// Signers has bit i set if the guardian at index i of the guardian set signed.
+	Signers   uint32
+	Signature *Signature
+}
+
+// NumSigners returns the number of guardians that signed.
+func (a *Attestation) NumSigners() int {
+	n := 0
+	for s := a.Signers; s != 0; s &= s - 1 {
+		n++
+	}
+	return n
+}
+
+// Verify checks the attestation against the public keys of the guardian set, indexed like the guardian set.
+func (a *Attestation) Verify(digest []byte, keys []*PublicKey) bool {
+	if len(keys) < 32 && a.Signers>>len(keys) != 0 {
+		return false
+	}
+	var signers []*PublicKey
+	for i, pk := range keys {
+		if a.Signers&(1<<i) != 0 {
+			signers = append(signers, pk)
+		}
+	}
+	return Verify(AggregatePublicKeys(signers), digest, a.Signature)
+}
+
+func (a *Attestation) Marshal() []byte {
+	b := make([]byte, 0, AttestationLength)
+	b = append(b, AttestationVersion)
+	b = binary.BigEndian.AppendUint32(b, a.GuardianSetIndex)
+	b = binary.BigEndian.AppendUint32(b, a.Signers)
+	return append(b, a.Signature.Marshal()...)
+}
+
+func UnmarshalAttestation(b []byte) (*Attestation, error) {
+	if len(b) != AttestationLength {
+		return nil, fmt.Errorf("attestation must be %d bytes, got %d", AttestationLength, len(b))
+	}
+	if b[0] != AttestationVersion {
+		return nil, fmt.Errorf("unsupported attestation version %d", b[0])
+	}
+	sig, err := UnmarshalSignature(b[9:])
+	if err != nil {
+		return nil, err
+	}
+	a := &Attestation{
+		GuardianSetIndex: binary.BigEndian.Uint32(b[1:5]),
+		Signers:          binary.BigEndian.Uint32(b[5:9]),
+		Signature:        sig,
+	}
+	if a.Signers == 0 {
+		return nil, errors.New("attestation has no signers")
+	}
+	return a, nil
+}
@@ -0,0 +1,179 @@
+// Package aggsig implements BLS signatures over the BN254 curve, which can be aggregated into a single signature and verified on
+// EVM chains with the ecPairing precompile. It backs the experimental aggregated attestations and must not be used in production:
+// keys are derived from the guardian key rather than generated independently.
+package aggsig
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+)
+
+const (
+	// SignatureLength is the length of a marshaled signature, an uncompressed G1 point.
+	SignatureLength = 64
+	// PublicKeyLength is the length of a marshaled public key, an uncompressed G2 point.
+	PublicKeyLength = 128
+)
+
+var (
+	// Domain separation tags, so that signatures over VAA digests cannot be confused with proofs of possession.
+	messageDST = []byte("SOL2LINK-AGGSIG-BN254-MSG-V1")
+	popDST     = []byte("SOL2LINK-AGGSIG-BN254-POP-V1")
+
+	g2Generator = new(bn256.G2).ScalarBaseMult(big.NewInt(1))
+	curveB      = big.NewInt(3)
+
+	ErrInvalidSignature = errors.New("invalid signature")
+	ErrInvalidPublicKey = errors.New("invalid public key")
+)
+
+type SecretKey struct {
+	k *big.Int
+}
+
+type PublicKey struct {
+	p *bn256.G2
+}
+
+type Signature struct {
+	p *bn256.G1
+}
+
+// DeriveSecretKey derives a BLS key from a guardian key. This avoids managing a second key while the scheme is evaluated on
+// devnet, but means anyone who learns one of the keys learns the other.
+func DeriveSecretKey(gk *ecdsa.PrivateKey) *SecretKey {
+	h := crypto.Keccak256([]byte("SOL2LINK-AGGSIG-BN254-KEY-V1"), gk.D.Bytes())
+	k := new(big.Int).Mod(new(big.Int).SetBytes(h), bn256.Order)
+	if k.Sign() == 0 {
+		k.SetInt64(1)
+	}
+	return &SecretKey{k: k}
+}
+
+func (sk *SecretKey) PublicKey() *PublicKey {
+	return &PublicKey{p: new(bn256.G2).ScalarBaseMult(sk.k)}
+}
+
+// Sign signs a message, usually a VAA signing digest.
+func (sk *SecretKey) Sign(msg []byte) *Signature {
+	return &Signature{p: new(bn256.G1).ScalarMult(hashToG1(messageDST, msg), sk.k)}
+}
+
+// ProofOfPossession signs the key's own public key. Requiring it before accepting a public key prevents rogue key attacks on
+// aggregated signatures.
+func (sk *SecretKey) ProofOfPossession() *Signature {
+	return &Signature{p: new(bn256.G1).ScalarMult(hashToG1(popDST, sk.PublicKey().Marshal()), sk.k)}
+}
+
+func (pk *PublicKey) Marshal() []byte {
+	return pk.p.Marshal()
+}
+
+func (pk *PublicKey) Equal(other *PublicKey) bool {
+	return bytes.Equal(pk.Marshal(), other.Marshal())
+}
+
+// UnmarshalPublicKey parses a public key and checks that it is in the prime order subgroup.
+func UnmarshalPublicKey(b []byte) (*PublicKey, error) {
+	if len(b) != PublicKeyLength {
+		return nil, ErrInvalidPublicKey
+	}
+	p := new(bn256.G2)
+	if _, err := p.Unmarshal(b); err != nil {
+		return nil, ErrInvalidPublicKey
+	}
+	if isZero(p.Marshal()) || !isZero(new(bn256.G2).ScalarMult(p, bn256.Order).Marshal()) {
+		return nil, ErrInvalidPublicKey
+	}
+	return &PublicKey{p: p}, nil
+}
+
+func (s *Signature) Marshal() []byte {
+	return s.p.Marshal()
+}
+
+func UnmarshalSignature(b []byte) (*Signature, error) {
+	if len(b) != SignatureLength {
+		return nil, ErrInvalidSignature
+	}
+	p := new(bn256.G1)
+	if _, err := p.Unmarshal(b); err != nil {
+		return nil, ErrInvalidSignature
+	}
+	return &Signature{p: p}, nil
+}
+
+// Verify checks a signature, or an aggregated signature against the aggregated public key of its signers.
+func Verify(pk *PublicKey, msg []byte, sig *Signature) bool {
+	return verify(pk, messageDST, msg, sig)
+}
+
+// VerifyProofOfPossession checks that the holder of the public key created the proof.
+func VerifyProofOfPossession(pk *PublicKey, pop *Signature) bool {
+	return verify(pk, popDST, pk.Marshal(), pop)
+}
+
+func verify(pk *PublicKey, dst []byte, msg []byte, sig *Signature) bool {
+	// e(sig, g2) == e(H(msg), pk)
+	h := new(bn256.G1).Neg(hashToG1(dst, msg))
+	return bn256.PairingCheck([]*bn256.G1{sig.p, h}, []*bn256.G2{g2Generator, pk.p})
+}
+
+// AggregateSignatures adds up signatures over the same message.
+func AggregateSignatures(sigs []*Signature) *Signature {
+	agg := new(bn256.G1).ScalarBaseMult(big.NewInt(0))
+	for _, s := range sigs {
+		agg = new(bn256.G1).Add(agg, s.p)
+	}
+	return &Signature{p: agg}
+}
+
+// AggregatePublicKeys adds up the public keys of the signers of an aggregated signature.
+func AggregatePublicKeys(pks []*PublicKey) *PublicKey {
+	agg := new(bn256.G2).ScalarBaseMult(big.NewInt(0))
+	for _, pk := range pks {
+		agg = new(bn256.G2).Add(agg, pk.p)
+	}
+	return &PublicKey{p: agg}
+}
+
+// hashToG1 maps a message to a point with try-and-increment. This is not constant time, which is fine since messages are public,
+// and is simple to reproduce in a contract.
+func hashToG1(dst []byte, msg []byte) *bn256.G1 {
+	for ctr := 0; ; ctr++ {
+		x := new(big.Int).SetBytes(crypto.Keccak256(dst, msg, []byte{byte(ctr >> 8), byte(ctr)}))
+		x.Mod(x, bn256.P)
+
+		// y² = x³ + 3
+		rhs := new(big.Int).Exp(x, big.NewInt(3), bn256.P)
+		rhs.Add(rhs, curveB).Mod(rhs, bn256.P)
+		y := new(big.Int).ModSqrt(rhs, bn256.P)
+		if y == nil {
+			continue
+		}
+
+		b := make([]byte, 64)
+		x.FillBytes(b[:32])
+		y.FillBytes(b[32:])
+		p := new(bn256.G1)
+		if _, err := p.Unmarshal(b); err != nil {
+			// Cannot happen for a point that satisfies the curve equation.
+			panic(err)
+		}
+		return p
+	}
+}
+
+func isZero(b []byte) bool {
+	for _, c := range b {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
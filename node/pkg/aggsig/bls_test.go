@@ -0,0 +1,71 @@
+package aggsig
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testKeys(t *testing.T, n int) []*SecretKey {
+	keys := make([]*SecretKey, n)
+	for i := range keys {
+		gk, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		keys[i] = DeriveSecretKey(gk)
+	}
+	return keys
+}
+
+func TestSignAndVerify(t *testing.T) {
+	sk := testKeys(t, 1)[0]
+	msg := crypto.Keccak256([]byte("digest"))
+	sig := sk.Sign(msg)
+	assert.True(t, Verify(sk.PublicKey(), msg, sig))
+	assert.False(t, Verify(sk.PublicKey(), crypto.Keccak256([]byte("other")), sig))
+
+	pk, err := UnmarshalPublicKey(sk.PublicKey().Marshal())
+	require.NoError(t, err)
+	decoded, err := UnmarshalSignature(sig.Marshal())
+	require.NoError(t, err)
+	assert.True(t, Verify(pk, msg, decoded))
+
+	// A proof of possession is not a valid signature over the public key as a message, and vice versa.
+	pop := sk.ProofOfPossession()
+	assert.True(t, VerifyProofOfPossession(pk, pop))
+	assert.False(t, Verify(pk, pk.Marshal(), pop))
+	assert.False(t, VerifyProofOfPossession(pk, sk.Sign(pk.Marshal())))
+
+	_, err = UnmarshalPublicKey(make([]byte, PublicKeyLength))
+	assert.ErrorIs(t, err, ErrInvalidPublicKey)
+}
+
+func TestAttestation(t *testing.T) {
+	keys := testKeys(t, 4)
+	pks := make([]*PublicKey, len(keys))
+	for i, k := range keys {
+		pks[i] = k.PublicKey()
+	}
+	msg := crypto.Keccak256([]byte("digest"))
+
+	a := &Attestation{
+		GuardianSetIndex: 3,
+		Signers:          0b1011,
+		Signature:        AggregateSignatures([]*Signature{keys[0].Sign(msg), keys[1].Sign(msg), keys[3].Sign(msg)}),
+	}
+	assert.Equal(t, 3, a.NumSigners())
+	assert.True(t, a.Verify(msg, pks))
+
+	b := a.Marshal()
+	assert.Len(t, b, AttestationLength)
+	decoded, err := UnmarshalAttestation(b)
+	require.NoError(t, err)
+	assert.True(t, decoded.Verify(msg, pks))
+
+	// The bitmap must match the signers.
+	decoded.Signers = 0b0111
+	assert.False(t, decoded.Verify(msg, pks))
+	decoded.Signers = 0b11011
+	assert.False(t, decoded.Verify(msg, pks))
+}
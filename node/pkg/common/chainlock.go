@@ -33,8 +33,20 @@ type MessagePublication struct {
 	// Unreliable indicates if this message can be reobserved. If a message is considered unreliable it cannot be
 	// reobserved.
 	Unreliable bool
+
+	// TxID is the ID of the source chain transaction which emitted the message, if the watcher knows it and it differs from TxHash.
+	// On Solana, it is the signature of the transaction, while TxHash is the address of the message account. It is used to look up
+	// the VAAs of a transaction and is not part of the binary serialization, so messages held by the governor across a restart lose it.
+	TxID []byte
+
+	// Confidence is an optional annotation by the watcher describing how final the message was when it was observed, such as the
+	// commitment level it was read at or ConfidenceReobserved. It is only used for diagnostics and is not part of the binary serialization.
+	Confidence string
 }
 
+// ConfidenceReobserved is the MessagePublication.Confidence of a message observed again on request.
+const ConfidenceReobserved = "reobserved"
+
 func (msg *MessagePublication) MessageID() []byte {
 	return []byte(msg.MessageIDString())
 }
@@ -0,0 +1,174 @@
+package common
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// GovernanceStatusRetention is how long an injected governance VAA is tracked before it is forgotten.
+const GovernanceStatusRetention = 24 * time.Hour
+
+// GovernanceStatus is a point in time view of the signing progress of an injected governance VAA.
+type GovernanceStatus struct {
+	Digest     common.Hash
+	MessageID  string
+	InjectedAt time.Time
+	// GuardianSet is the guardian set signatures are counted against. It may be nil if the guardian set was unknown at injection time.
+	GuardianSet *GuardianSet
+	// Signed maps guardians whose signature we have seen to the time we first saw it.
+	Signed map[common.Address]time.Time
+}
+
+// Quorum returns the number of signatures required for quorum, or zero if the guardian set is unknown.
+func (s *GovernanceStatus) Quorum() int {
+	if s.GuardianSet == nil {
+		return 0
+	}
+	return vaa.CalculateQuorum(len(s.GuardianSet.Keys))
+}
+
+// NumSignatures returns the number of guardians in the guardian set whose signature has been seen.
+func (s *GovernanceStatus) NumSignatures() int {
+	if s.GuardianSet == nil {
+		return 0
+	}
+	num := 0
+	for _, k := range s.GuardianSet.Keys {
+		if _, exists := s.Signed[k]; exists {
+			num++
+		}
+	}
+	return num
+}
+
+// QuorumReached returns true once a quorum of the guardian set has signed.
+func (s *GovernanceStatus) QuorumReached() bool {
+	return s.GuardianSet != nil && s.NumSignatures() >= s.Quorum()
+}
+
+type governanceStatusEntry struct {
+	status      GovernanceStatus
+	subscribers map[chan struct{}]struct{}
+}
+
+// GovernanceStatusTracker records which guardians have signed governance VAAs injected on this node. The admin service
+// registers digests as they are injected and the processor reports verified signatures as they arrive over gossip.
+type GovernanceStatusTracker struct {
+	mu      sync.Mutex
+	entries map[common.Hash]*governanceStatusEntry
+}
+
+// NewGovernanceStatusTracker returns a new GovernanceStatusTracker.
+func NewGovernanceStatusTracker() *GovernanceStatusTracker {
+	return &GovernanceStatusTracker{
+		entries: make(map[common.Hash]*governanceStatusEntry),
+	}
+}
+
+// Track starts tracking signatures for the given digest. Tracking a digest that is already tracked resets its status.
+func (t *GovernanceStatusTracker) Track(digest common.Hash, messageID string, gs *GuardianSet, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for d, e := range t.entries {
+		if now.Sub(e.status.InjectedAt) > GovernanceStatusRetention {
+			t.removeLocked(d, e)
+		}
+	}
+
+	e, exists := t.entries[digest]
+	if !exists {
+		e = &governanceStatusEntry{subscribers: make(map[chan struct{}]struct{})}
+		t.entries[digest] = e
+	}
+	e.status = GovernanceStatus{
+		Digest:      digest,
+		MessageID:   messageID,
+		InjectedAt:  now,
+		GuardianSet: gs,
+		Signed:      make(map[common.Address]time.Time),
+	}
+	e.notifyLocked()
+}
+
+// AddSignature records a verified signature by addr over digest. The guardian set the signature was verified against is
+// adopted if none was known yet. Digests which are not tracked are ignored, so this is cheap to call for every observation.
+func (t *GovernanceStatusTracker) AddSignature(digest common.Hash, addr common.Address, gs *GuardianSet, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	e, exists := t.entries[digest]
+	if !exists {
+		return
+	}
+	if _, exists := e.status.Signed[addr]; exists {
+		return
+	}
+	if e.status.GuardianSet == nil {
+		e.status.GuardianSet = gs
+	}
+	e.status.Signed[addr] = now
+	e.notifyLocked()
+}
+
+// Get returns a copy of the current status of the given digest.
+func (t *GovernanceStatusTracker) Get(digest common.Hash) (GovernanceStatus, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	e, exists := t.entries[digest]
+	if !exists {
+		return GovernanceStatus{}, false
+	}
+
+	status := e.status
+	status.Signed = make(map[common.Address]time.Time, len(e.status.Signed))
+	for k, v := range e.status.Signed {
+		status.Signed[k] = v
+	}
+	return status, true
+}
+
+// Subscribe returns a channel which is signaled whenever the status of the given digest changes, along with a function
+// to cancel the subscription. Notifications are coalesced, so callers should call Get after every signal. The channel is
+// closed if the digest stops being tracked.
+func (t *GovernanceStatusTracker) Subscribe(digest common.Hash) (<-chan struct{}, func(), bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	e, exists := t.entries[digest]
+	if !exists {
+		return nil, nil, false
+	}
+
+	c := make(chan struct{}, 1)
+	e.subscribers[c] = struct{}{}
+	return c, func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		if _, exists := e.subscribers[c]; exists {
+			delete(e.subscribers, c)
+			close(c)
+		}
+	}, true
+}
+
+func (t *GovernanceStatusTracker) removeLocked(digest common.Hash, e *governanceStatusEntry) {
+	for c := range e.subscribers {
+		close(c)
+	}
+	e.subscribers = make(map[chan struct{}]struct{})
+	delete(t.entries, digest)
+}
+
+func (e *governanceStatusEntry) notifyLocked() {
+	for c := range e.subscribers {
+		select {
+		case c <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testGovernanceGuardianSet() *GuardianSet {
+	return &GuardianSet{
+		Keys: []common.Address{
+			common.HexToAddress("0x01"),
+			common.HexToAddress("0x02"),
+			common.HexToAddress("0x03"),
+		},
+		Index: 4,
+	}
+}
+
+func TestGovernanceStatusTrackerQuorum(t *testing.T) {
+	tracker := NewGovernanceStatusTracker()
+	gs := testGovernanceGuardianSet()
+	digest := common.HexToHash("0xabcd")
+	now := time.Now()
+
+	tracker.Track(digest, "1/0000000000000000000000000000000000000000000000000000000000000004/1", gs, now)
+
+	st, exists := tracker.Get(digest)
+	require.True(t, exists)
+	assert.Equal(t, 3, st.Quorum())
+	assert.Equal(t, 0, st.NumSignatures())
+	assert.False(t, st.QuorumReached())
+
+	tracker.AddSignature(digest, gs.Keys[0], gs, now)
+	tracker.AddSignature(digest, gs.Keys[1], gs, now)
+	// Duplicate signatures must not be counted twice.
+	tracker.AddSignature(digest, gs.Keys[1], gs, now.Add(time.Second))
+
+	st, _ = tracker.Get(digest)
+	assert.Equal(t, 2, st.NumSignatures())
+	assert.Equal(t, now, st.Signed[gs.Keys[1]])
+	assert.False(t, st.QuorumReached())
+
+	tracker.AddSignature(digest, gs.Keys[2], gs, now)
+	st, _ = tracker.Get(digest)
+	assert.True(t, st.QuorumReached())
+}
+
+func TestGovernanceStatusTrackerIgnoresUntracked(t *testing.T) {
+	tracker := NewGovernanceStatusTracker()
+	gs := testGovernanceGuardianSet()
+	digest := common.HexToHash("0xabcd")
+
+	tracker.AddSignature(digest, gs.Keys[0], gs, time.Now())
+	_, exists := tracker.Get(digest)
+	assert.False(t, exists)
+
+	_, _, exists = tracker.Subscribe(digest)
+	assert.False(t, exists)
+}
+
+func TestGovernanceStatusTrackerAdoptsGuardianSet(t *testing.T) {
+	tracker := NewGovernanceStatusTracker()
+	gs := testGovernanceGuardianSet()
+	digest := common.HexToHash("0xabcd")
+
+	tracker.Track(digest, "", nil, time.Now())
+	st, _ := tracker.Get(digest)
+	assert.Equal(t, 0, st.Quorum())
+	assert.False(t, st.QuorumReached())
+
+	tracker.AddSignature(digest, gs.Keys[0], gs, time.Now())
+	st, _ = tracker.Get(digest)
+	require.NotNil(t, st.GuardianSet)
+	assert.Equal(t, uint32(4), st.GuardianSet.Index)
+	assert.Equal(t, 1, st.NumSignatures())
+}
+
+func TestGovernanceStatusTrackerSubscribe(t *testing.T) {
+	tracker := NewGovernanceStatusTracker()
+	gs := testGovernanceGuardianSet()
+	digest := common.HexToHash("0xabcd")
+	now := time.Now()
+
+	tracker.Track(digest, "", gs, now)
+	updateC, unsubscribe, exists := tracker.Subscribe(digest)
+	require.True(t, exists)
+
+	// Notifications are coalesced, so two signatures result in a single pending signal.
+	tracker.AddSignature(digest, gs.Keys[0], gs, now)
+	tracker.AddSignature(digest, gs.Keys[1], gs, now)
+	<-updateC
+	select {
+	case <-updateC:
+		t.Fatal("expected notifications to be coalesced")
+	default:
+	}
+
+	unsubscribe()
+	unsubscribe()
+	_, ok := <-updateC
+	assert.False(t, ok)
+}
+
+func TestGovernanceStatusTrackerExpiry(t *testing.T) {
+	tracker := NewGovernanceStatusTracker()
+	gs := testGovernanceGuardianSet()
+	old := common.HexToHash("0x01")
+	now := time.Now()
+
+	tracker.Track(old, "", gs, now)
+	updateC, _, exists := tracker.Subscribe(old)
+	require.True(t, exists)
+
+	tracker.Track(common.HexToHash("0x02"), "", gs, now.Add(GovernanceStatusRetention+time.Second))
+
+	_, exists = tracker.Get(old)
+	assert.False(t, exists)
+	_, ok := <-updateC
+	assert.False(t, ok)
+}
@@ -90,7 +90,9 @@ func requestPayloadServerInterceptor(ctx context.Context, req interface{}, info
 	return handler(ctx, req)
 }
 
-func NewInstrumentedGRPCServer(logger *zap.Logger, rpcLogDetail GrpcLogDetail) *grpc.Server {
+// NewInstrumentedGRPCServer creates a gRPC server with the standard logging and metrics interceptors. Additional options, such as
+// interceptors chained with grpc.ChainUnaryInterceptor, may be passed in opts.
+func NewInstrumentedGRPCServer(logger *zap.Logger, rpcLogDetail GrpcLogDetail, opts ...grpc.ServerOption) *grpc.Server {
 	initMutex.Lock()
 	defer initMutex.Unlock()
 
@@ -122,10 +124,10 @@ func NewInstrumentedGRPCServer(logger *zap.Logger, rpcLogDetail GrpcLogDetail) *
 		)
 	}
 
-	server := grpc.NewServer(
+	server := grpc.NewServer(append([]grpc.ServerOption{
 		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(streamInterceptors...)),
 		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(unaryInterceptors...)),
-	)
+	}, opts...)...)
 
 	grpc_prometheus.EnableHandlingTimeHistogram()
 	grpc_prometheus.Register(server)
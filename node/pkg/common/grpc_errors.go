@@ -0,0 +1,147 @@
+package common
+
+import (
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// GrpcErrorDomain is the domain of the ErrorInfo details attached to errors returned by the guardian's gRPC services.
+const GrpcErrorDomain = "guardian.wormhole.com"
+
+// GrpcErrorReason identifies the cause of an error returned by the public or admin RPC services. Unlike the error messages, reasons
+// are stable, so clients should check them (using GrpcErrorReasonOf) rather than matching on the message.
+type GrpcErrorReason string
+
+const (
+	// Errors caused by the request.
+	ReasonMissingMessageID         GrpcErrorReason = "MISSING_MESSAGE_ID"
+	ReasonInvalidEmitterAddress    GrpcErrorReason = "INVALID_EMITTER_ADDRESS"
+	ReasonInvalidTxHash            GrpcErrorReason = "INVALID_TX_HASH"
+	ReasonInvalidVAAID             GrpcErrorReason = "INVALID_VAA_ID"
+	ReasonInvalidVAA               GrpcErrorReason = "INVALID_VAA"
+	ReasonInvalidGovernanceMessage GrpcErrorReason = "INVALID_GOVERNANCE_MESSAGE"
+	ReasonInvalidGuardianSet       GrpcErrorReason = "INVALID_GUARDIAN_SET"
+	ReasonInvalidDigest            GrpcErrorReason = "INVALID_DIGEST"
+	ReasonInvalidConfig            GrpcErrorReason = "INVALID_CONFIG"
+	ReasonUnknownConfigTarget      GrpcErrorReason = "UNKNOWN_CONFIG_TARGET"
+	ReasonInvalidTimeRange         GrpcErrorReason = "INVALID_TIME_RANGE"
+	ReasonInvalidChainID           GrpcErrorReason = "INVALID_CHAIN_ID"
+	ReasonApproverRequired         GrpcErrorReason = "APPROVER_REQUIRED"
+	ReasonDuplicateApproval        GrpcErrorReason = "DUPLICATE_APPROVAL"
+	ReasonInvalidGovernorSnapshot  GrpcErrorReason = "INVALID_GOVERNOR_SNAPSHOT"
+	ReasonMissingReason            GrpcErrorReason = "MISSING_REASON"
+	ReasonInvalidSequenceRange     GrpcErrorReason = "INVALID_SEQUENCE_RANGE"
+	ReasonInvalidObsvRequest       GrpcErrorReason = "INVALID_OBSERVATION_REQUEST"
+	ReasonBatchTooLarge            GrpcErrorReason = "BATCH_TOO_LARGE"
+	ReasonInvalidLogLevel          GrpcErrorReason = "INVALID_LOG_LEVEL"
+	ReasonRunnableNotRestartable   GrpcErrorReason = "RUNNABLE_NOT_RESTARTABLE"
+	ReasonInvalidReleaseDelay      GrpcErrorReason = "INVALID_RELEASE_DELAY"
+	ReasonInvalidContinuationToken GrpcErrorReason = "INVALID_CONTINUATION_TOKEN"
+	ReasonInvalidGuardianAddress   GrpcErrorReason = "INVALID_GUARDIAN_ADDRESS"
+
+	// Errors for things that don't exist.
+	ReasonVAANotFound           GrpcErrorReason = "VAA_NOT_FOUND"
+	ReasonPendingVAANotFound    GrpcErrorReason = "PENDING_VAA_NOT_FOUND"
+	ReasonGovernanceVAANotFound GrpcErrorReason = "GOVERNANCE_VAA_NOT_FOUND"
+	ReasonStagedConfigNotFound  GrpcErrorReason = "STAGED_CONFIG_NOT_FOUND"
+	ReasonAttestationNotFound   GrpcErrorReason = "ATTESTATION_NOT_FOUND"
+	ReasonChainNotGoverned      GrpcErrorReason = "CHAIN_NOT_GOVERNED"
+	ReasonTransferNotPending    GrpcErrorReason = "TRANSFER_NOT_PENDING"
+	ReasonRelayNotPending       GrpcErrorReason = "RELAY_NOT_PENDING"
+	ReasonRunnableNotFound      GrpcErrorReason = "RUNNABLE_NOT_FOUND"
+
+	// Errors where the request is valid but the node is not in a state to serve it.
+	ReasonGovernorDisabled         GrpcErrorReason = "GOVERNOR_DISABLED"
+	ReasonGovernanceStatusDisabled GrpcErrorReason = "GOVERNANCE_STATUS_DISABLED"
+	ReasonConflictTrackingDisabled GrpcErrorReason = "CONFLICT_TRACKING_DISABLED"
+	ReasonQueryBudgetDisabled      GrpcErrorReason = "QUERY_BUDGET_DISABLED"
+	ReasonVAAArchiveDisabled       GrpcErrorReason = "VAA_ARCHIVE_DISABLED"
+	ReasonAccountantDisabled       GrpcErrorReason = "ACCOUNTANT_DISABLED"
+	ReasonGatewayRelayerDisabled   GrpcErrorReason = "GATEWAY_RELAYER_DISABLED"
+	ReasonForceReleaseDisabled     GrpcErrorReason = "FORCE_RELEASE_DISABLED"
+	ReasonVAAPurgeDisabled         GrpcErrorReason = "VAA_PURGE_DISABLED"
+	ReasonLogLevelsDisabled        GrpcErrorReason = "LOG_LEVELS_DISABLED"
+	ReasonAuditLogDisabled         GrpcErrorReason = "AUDIT_LOG_DISABLED"
+	ReasonLogTailDisabled          GrpcErrorReason = "LOG_TAIL_DISABLED"
+	ReasonRunnableNotRunning       GrpcErrorReason = "RUNNABLE_NOT_RUNNING"
+	ReasonGuardianSetUnknown       GrpcErrorReason = "GUARDIAN_SET_UNKNOWN"
+	ReasonGuardianSetIndexTooLow   GrpcErrorReason = "GUARDIAN_SET_INDEX_TOO_LOW"
+	ReasonAlreadyInGuardianSet     GrpcErrorReason = "ALREADY_IN_GUARDIAN_SET"
+	ReasonNotInGuardianSet         GrpcErrorReason = "NOT_IN_GUARDIAN_SET"
+	ReasonQuorumNotReachable       GrpcErrorReason = "QUORUM_NOT_REACHABLE"
+	ReasonStagedConfigStale        GrpcErrorReason = "STAGED_CONFIG_STALE"
+	ReasonSecondApproverRequired   GrpcErrorReason = "SECOND_APPROVER_REQUIRED"
+	ReasonMethodNotAllowed         GrpcErrorReason = "METHOD_NOT_ALLOWED"
+	ReasonCallerNotAuthenticated   GrpcErrorReason = "CALLER_NOT_AUTHENTICATED"
+
+	// Transient errors, which may succeed if retried.
+	ReasonGuardianSetNotReady GrpcErrorReason = "GUARDIAN_SET_NOT_READY"
+	ReasonQueueFull           GrpcErrorReason = "QUEUE_FULL"
+	ReasonBackfillFailed      GrpcErrorReason = "BACKFILL_FAILED"
+	ReasonTooManyStreams      GrpcErrorReason = "TOO_MANY_STREAMS"
+	ReasonRateLimited         GrpcErrorReason = "RATE_LIMITED"
+
+	// Errors internal to the node.
+	ReasonInternal GrpcErrorReason = "INTERNAL"
+)
+
+// NewGrpcError returns a gRPC status error with the given code and message, and an ErrorInfo detail carrying the reason.
+// Use codes.NotFound for missing objects and codes.FailedPrecondition when the node is not in a state to serve the request.
+func NewGrpcError(code codes.Code, reason GrpcErrorReason, msg string) error {
+	st, err := status.New(code, msg).WithDetails(&errdetails.ErrorInfo{Reason: string(reason), Domain: GrpcErrorDomain})
+	if err != nil {
+		// This can only happen if the details can't be marshaled, in which case the plain status is still better than nothing.
+		return status.Error(code, msg)
+	}
+	return st.Err()
+}
+
+// NewRetryableGrpcError is like NewGrpcError, but also attaches a RetryInfo detail which tells the client to retry after retryDelay.
+func NewRetryableGrpcError(code codes.Code, reason GrpcErrorReason, msg string, retryDelay time.Duration) error {
+	st, err := status.New(code, msg).WithDetails(
+		&errdetails.ErrorInfo{Reason: string(reason), Domain: GrpcErrorDomain},
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(retryDelay)},
+	)
+	if err != nil {
+		return status.Error(code, msg)
+	}
+	return st.Err()
+}
+
+// GrpcErrorReasonOf returns the reason attached to an error returned by one of the guardian's gRPC services, or an empty string if there is none.
+func GrpcErrorReasonOf(err error) GrpcErrorReason {
+	st, ok := status.FromError(err)
+	if !ok {
+		return ""
+	}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.Domain == GrpcErrorDomain {
+			return GrpcErrorReason(info.Reason)
+		}
+	}
+	return ""
+}
+
+// IsRetryableGrpcError returns true if the request that failed with err may succeed if it is retried, and the delay the server
+// asked for, if any.
+func IsRetryableGrpcError(err error) (bool, time.Duration) {
+	st, ok := status.FromError(err)
+	if !ok {
+		return false, 0
+	}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			return true, info.RetryDelay.AsDuration()
+		}
+	}
+	switch st.Code() {
+	case codes.Unavailable, codes.ResourceExhausted, codes.Aborted:
+		return true, 0
+	default:
+		return false, 0
+	}
+}
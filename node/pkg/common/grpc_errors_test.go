@@ -0,0 +1,43 @@
+package common
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGrpcErrorReason(t *testing.T) {
+	err := NewGrpcError(codes.NotFound, ReasonVAANotFound, "requested VAA not found in store")
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, "requested VAA not found in store", status.Convert(err).Message())
+	assert.Equal(t, ReasonVAANotFound, GrpcErrorReasonOf(err))
+
+	retryable, _ := IsRetryableGrpcError(err)
+	assert.False(t, retryable)
+
+	// Errors without details, or which aren't gRPC errors at all, have no reason.
+	assert.Equal(t, GrpcErrorReason(""), GrpcErrorReasonOf(status.Error(codes.NotFound, "not found")))
+	assert.Equal(t, GrpcErrorReason(""), GrpcErrorReasonOf(errors.New("not found")))
+}
+
+func TestRetryableGrpcError(t *testing.T) {
+	err := NewRetryableGrpcError(codes.Unavailable, ReasonGuardianSetNotReady, "guardian set not fetched from chain yet", 5*time.Second)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, ReasonGuardianSetNotReady, GrpcErrorReasonOf(err))
+
+	retryable, delay := IsRetryableGrpcError(err)
+	assert.True(t, retryable)
+	assert.Equal(t, 5*time.Second, delay)
+
+	// Transient codes are retryable even without a RetryInfo detail.
+	retryable, delay = IsRetryableGrpcError(status.Error(codes.ResourceExhausted, "slow down"))
+	assert.True(t, retryable)
+	assert.Zero(t, delay)
+
+	retryable, _ = IsRetryableGrpcError(errors.New("boom"))
+	assert.False(t, retryable)
+}
@@ -46,6 +46,10 @@ const MaxNodesPerGuardian = 15
 // from the state by Cleanup().
 const MaxStateAge = 1 * time.Minute
 
+// MaxGovernorStatusAge is the maximum age of the governor statuses kept in the state. It is longer than MaxStateAge since
+// guardians only publish their governor status once a minute.
+const MaxGovernorStatusAge = 3 * time.Minute
+
 type GuardianSet struct {
 	// Guardian's public key hashes truncated by the ETH standard hashing mechanism (20 bytes).
 	Keys []common.Address
@@ -83,6 +87,9 @@ type GuardianSetState struct {
 	// across guardian set updates - these values don't change.
 	lastHeartbeats map[common.Address]map[peer.ID]*gossipv1.Heartbeat
 	updateC        chan *gossipv1.Heartbeat
+
+	// Last governor status received per guardian, see governor.ParseSignedStatus.
+	lastGovernorStatuses map[common.Address]*gossipv1.ChainGovernorStatus
 }
 
 // NewGuardianSetState returns a new GuardianSetState.
@@ -91,8 +98,9 @@ type GuardianSetState struct {
 // but be aware that the channel will block guardian set updates if full.
 func NewGuardianSetState(guardianSetStateUpdateC chan *gossipv1.Heartbeat) *GuardianSetState {
 	return &GuardianSetState{
-		lastHeartbeats: map[common.Address]map[peer.ID]*gossipv1.Heartbeat{},
-		updateC:        guardianSetStateUpdateC,
+		lastHeartbeats:       map[common.Address]map[peer.ID]*gossipv1.Heartbeat{},
+		updateC:              guardianSetStateUpdateC,
+		lastGovernorStatuses: map[common.Address]*gossipv1.ChainGovernorStatus{},
 	}
 }
 
@@ -166,6 +174,30 @@ func (st *GuardianSetState) GetAll() map[common.Address]map[peer.ID]*gossipv1.He
 	return ret
 }
 
+// SetGovernorStatus stores a verified governor status published by a given guardian. It is ignored if a more recent status of the
+// guardian is already stored.
+func (st *GuardianSetState) SetGovernorStatus(addr common.Address, status *gossipv1.ChainGovernorStatus) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if last, ok := st.lastGovernorStatuses[addr]; ok && last.Timestamp >= status.Timestamp {
+		return
+	}
+	st.lastGovernorStatuses[addr] = status
+}
+
+// GetAllGovernorStatuses returns the last governor status stored for each guardian.
+func (st *GuardianSetState) GetAllGovernorStatuses() map[common.Address]*gossipv1.ChainGovernorStatus {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	ret := make(map[common.Address]*gossipv1.ChainGovernorStatus, len(st.lastGovernorStatuses))
+	for addr, status := range st.lastGovernorStatuses {
+		ret[addr] = status
+	}
+	return ret
+}
+
 // Cleanup removes expired entries from the state.
 func (st *GuardianSetState) Cleanup() {
 	st.mu.Lock()
@@ -179,4 +211,10 @@ func (st *GuardianSetState) Cleanup() {
 			}
 		}
 	}
+
+	for addr, status := range st.lastGovernorStatuses {
+		if time.Since(time.Unix(0, status.Timestamp)) > MaxGovernorStatusAge {
+			delete(st.lastGovernorStatuses, addr)
+		}
+	}
 }
@@ -2,9 +2,12 @@ package common
 
 import (
 	"testing"
+	"time"
 
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestKeyIndex(t *testing.T) {
@@ -92,3 +95,23 @@ func TestGet(t *testing.T) {
 	gss.Set(&gs)
 	assert.Equal(t, gss.Get(), &gs)
 }
+
+func TestSetGovernorStatus(t *testing.T) {
+	addr := common.HexToAddress("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed")
+	now := time.Now()
+	gss := NewGuardianSetState(nil)
+
+	gss.SetGovernorStatus(addr, &gossipv1.ChainGovernorStatus{Counter: 2, Timestamp: now.UnixNano()})
+	gss.SetGovernorStatus(addr, &gossipv1.ChainGovernorStatus{Counter: 1, Timestamp: now.Add(-time.Minute).UnixNano()})
+	statuses := gss.GetAllGovernorStatuses()
+	require.Len(t, statuses, 1)
+	assert.Equal(t, int64(2), statuses[addr].Counter)
+
+	// Statuses expire later than heartbeats, since they are published less often.
+	gss.lastGovernorStatuses[addr].Timestamp = now.Add(-MaxStateAge - time.Second).UnixNano()
+	gss.Cleanup()
+	assert.Len(t, gss.GetAllGovernorStatuses(), 1)
+	gss.lastGovernorStatuses[addr].Timestamp = now.Add(-MaxGovernorStatusAge - time.Second).UnixNano()
+	gss.Cleanup()
+	assert.Empty(t, gss.GetAllGovernorStatuses())
+}
@@ -0,0 +1,64 @@
+package common
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// IPMode selects the IP versions the node's listeners accept connections on.
+type IPMode string
+
+const (
+	// IPModeDual listens on both IPv4 and IPv6. A wildcard address such as "[::]:port" or ":port" accepts connections of either version.
+	IPModeDual IPMode = "dual"
+	// IPModeIPv4 only listens on IPv4.
+	IPModeIPv4 IPMode = "ipv4"
+	// IPModeIPv6 only listens on IPv6. Wildcard addresses do not accept IPv4 connections.
+	IPModeIPv6 IPMode = "ipv6"
+)
+
+// ParseIPMode parses the value of an `--ipMode` flag. An empty string is dual stack.
+func ParseIPMode(str string) (IPMode, error) {
+	switch IPMode(strings.ToLower(str)) {
+	case "", IPModeDual:
+		return IPModeDual, nil
+	case IPModeIPv4:
+		return IPModeIPv4, nil
+	case IPModeIPv6:
+		return IPModeIPv6, nil
+	}
+	return IPModeDual, fmt.Errorf(`invalid IP mode "%s", must be "dual", "ipv4" or "ipv6"`, str)
+}
+
+// TCPNetwork returns the network to pass to net.Listen for a TCP listener. Go binds wildcard addresses of the "tcp6" network with
+// IPV6_V6ONLY set, and those of the "tcp" network without it.
+func (m IPMode) TCPNetwork() string {
+	switch m {
+	case IPModeIPv4:
+		return "tcp4"
+	case IPModeIPv6:
+		return "tcp6"
+	}
+	return "tcp"
+}
+
+// IPv4 returns true if listeners should accept IPv4 connections.
+func (m IPMode) IPv4() bool {
+	return m != IPModeIPv6
+}
+
+// IPv6 returns true if listeners should accept IPv6 connections.
+func (m IPMode) IPv6() bool {
+	return m != IPModeIPv4
+}
+
+// ListenTCP listens on a TCP address using the given IP mode. It is an error to pass an address of the wrong IP version,
+// e.g. an IPv6 address in IPv4 only mode.
+func ListenTCP(mode IPMode, addr string) (net.Listener, error) {
+	l, err := net.Listen(mode.TCPNetwork(), addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s in %s mode: %w", addr, mode, err)
+	}
+	return l, nil
+}
@@ -0,0 +1,68 @@
+package common
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIPMode(t *testing.T) {
+	for input, expected := range map[string]IPMode{"": IPModeDual, "dual": IPModeDual, "IPv4": IPModeIPv4, "ipv6": IPModeIPv6} {
+		mode, err := ParseIPMode(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, expected, mode, input)
+	}
+
+	_, err := ParseIPMode("ipv5")
+	assert.Error(t, err)
+}
+
+// ipv6Available returns true if the host can listen on the IPv6 loopback address.
+func ipv6Available() bool {
+	l, err := net.Listen("tcp6", "[::1]:0")
+	if err != nil {
+		return false
+	}
+	l.Close()
+	return true
+}
+
+func TestListenTCP(t *testing.T) {
+	l, err := ListenTCP(IPModeIPv4, "127.0.0.1:0")
+	require.NoError(t, err)
+	l.Close()
+
+	_, err = ListenTCP(IPModeIPv6, "127.0.0.1:0")
+	assert.Error(t, err)
+
+	if !ipv6Available() {
+		t.Skip("IPv6 is not available")
+	}
+
+	_, err = ListenTCP(IPModeIPv4, "[::1]:0")
+	assert.Error(t, err)
+
+	// In IPv6 only mode, a wildcard listener does not accept IPv4 connections.
+	l, err = ListenTCP(IPModeIPv6, "[::]:0")
+	require.NoError(t, err)
+	defer l.Close()
+	port := l.Addr().(*net.TCPAddr).Port
+	conn, err := net.Dial("tcp6", (&net.TCPAddr{IP: net.IPv6loopback, Port: port}).String())
+	require.NoError(t, err)
+	conn.Close()
+	_, err = net.Dial("tcp4", (&net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: port}).String())
+	assert.Error(t, err)
+
+	// In dual stack mode, it accepts both.
+	l, err = ListenTCP(IPModeDual, "[::]:0")
+	require.NoError(t, err)
+	defer l.Close()
+	port = l.Addr().(*net.TCPAddr).Port
+	for _, ip := range []net.IP{net.IPv6loopback, net.IPv4(127, 0, 0, 1)} {
+		conn, err := net.Dial("tcp", (&net.TCPAddr{IP: ip, Port: port}).String())
+		require.NoError(t, err)
+		conn.Close()
+	}
+}
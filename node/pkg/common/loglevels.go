@@ -0,0 +1,213 @@
+package common
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// LogLevel is the log level of a component, see LogLevels.
+type LogLevel struct {
+	// Component is empty for the global level.
+	Component string
+	Level     zapcore.Level
+	// Expires is when the level reverts, or the zero time if it doesn't.
+	Expires time.Time
+}
+
+type logLevelOverride struct {
+	level   zapcore.Level
+	expires time.Time
+	timer   *time.Timer
+}
+
+// LogLevels holds the log levels of the node, which can be changed at runtime. Besides the global level, components can be given
+// their own level. A component is one or more consecutive segments of logger names, e.g. "ethwatch" applies to the loggers named
+// "root.ethwatch" and "root.ethwatch.finalizer". If several components apply to a logger, the longest one wins.
+type LogLevels struct {
+	defaultLevel zapcore.Level
+	onGlobal     func(zapcore.Level)
+
+	mu sync.Mutex
+	// overrides are the levels that were changed, by component. The key of the global level is the empty string.
+	overrides map[string]*logLevelOverride
+	// effective is a snapshot of the levels for the cores, so that logging doesn't take the lock.
+	effective atomic.Pointer[effectiveLogLevels]
+}
+
+type effectiveLogLevels struct {
+	global zapcore.Level
+	// minLevel is the lowest level of any component, so that cores only check the logger name of entries that may be written.
+	minLevel   zapcore.Level
+	components []componentLogLevel
+}
+
+type componentLogLevel struct {
+	segments []string
+	level    zapcore.Level
+}
+
+// NewLogLevels returns log levels with the global level set to defaultLevel.
+func NewLogLevels(defaultLevel zapcore.Level) *LogLevels {
+	l := &LogLevels{defaultLevel: defaultLevel, overrides: make(map[string]*logLevelOverride)}
+	l.updateLocked()
+	return l
+}
+
+// OnGlobalChange registers f to be called with the new global level whenever it changes, e.g. to update loggers that don't use
+// the cores of LogLevels.
+func (l *LogLevels) OnGlobalChange(f func(zapcore.Level)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.onGlobal = f
+}
+
+// Enabled implements zapcore.LevelEnabler. It reports whether lvl is enabled for any component.
+func (l *LogLevels) Enabled(lvl zapcore.Level) bool {
+	return lvl >= l.effective.Load().minLevel
+}
+
+// SetLevel sets the level of component, or the global level if component is empty. If d is non-zero, the level reverts after d.
+func (l *LogLevels) SetLevel(component string, lvl zapcore.Level, d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.stopLocked(component)
+	o := &logLevelOverride{level: lvl}
+	if d > 0 {
+		o.expires = time.Now().Add(d)
+		o.timer = time.AfterFunc(d, func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			// The level may have been changed again in the meantime.
+			if l.overrides[component] == o {
+				delete(l.overrides, component)
+				l.updateLocked()
+			}
+		})
+	}
+	l.overrides[component] = o
+	l.updateLocked()
+}
+
+// ResetLevel reverts the level of component, or the global level if component is empty, to the level it started with.
+func (l *LogLevels) ResetLevel(component string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.stopLocked(component)
+	delete(l.overrides, component)
+	l.updateLocked()
+}
+
+// Levels returns the global level followed by the levels of components, sorted by component.
+func (l *LogLevels) Levels() []LogLevel {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	levels := []LogLevel{{Level: l.defaultLevel}}
+	if o, exists := l.overrides[""]; exists {
+		levels[0] = LogLevel{Level: o.level, Expires: o.expires}
+	}
+	for component, o := range l.overrides {
+		if component != "" {
+			levels = append(levels, LogLevel{Component: component, Level: o.level, Expires: o.expires})
+		}
+	}
+	sort.Slice(levels[1:], func(i, j int) bool { return levels[i+1].Component < levels[j+1].Component })
+	return levels
+}
+
+func (l *LogLevels) stopLocked(component string) {
+	if o, exists := l.overrides[component]; exists && o.timer != nil {
+		o.timer.Stop()
+	}
+}
+
+func (l *LogLevels) globalLocked() zapcore.Level {
+	if o, exists := l.overrides[""]; exists {
+		return o.level
+	}
+	return l.defaultLevel
+}
+
+func (l *LogLevels) updateLocked() {
+	e := &effectiveLogLevels{global: l.globalLocked()}
+	e.minLevel = e.global
+	for component, o := range l.overrides {
+		if component == "" {
+			continue
+		}
+		e.components = append(e.components, componentLogLevel{segments: strings.Split(component, "."), level: o.level})
+		if o.level < e.minLevel {
+			e.minLevel = o.level
+		}
+	}
+	prev := l.effective.Swap(e)
+	if l.onGlobal != nil && prev != nil && prev.global != e.global {
+		l.onGlobal(e.global)
+	}
+}
+
+// levelFor returns the level of the logger named name.
+func (e *effectiveLogLevels) levelFor(name string) zapcore.Level {
+	if len(e.components) == 0 {
+		return e.global
+	}
+	segments := strings.Split(name, ".")
+	lvl, matched := e.global, 0
+	for _, c := range e.components {
+		if len(c.segments) > matched && containsSegments(segments, c.segments) {
+			lvl, matched = c.level, len(c.segments)
+		}
+	}
+	return lvl
+}
+
+// LoggerInComponent reports whether the logger named name is part of component, in the same way as components are matched when
+// setting their level, see LogLevels.
+func LoggerInComponent(name string, component string) bool {
+	return containsSegments(strings.Split(name, "."), strings.Split(component, "."))
+}
+
+func containsSegments(segments []string, sub []string) bool {
+	for i := 0; i+len(sub) <= len(segments); i++ {
+		match := true
+		for j := range sub {
+			if segments[i+j] != sub[j] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+// Core wraps core, which should use l as its level enabler, so that entries are filtered by the level of their logger.
+func (l *LogLevels) Core(core zapcore.Core) zapcore.Core {
+	return &logLevelsCore{Core: core, levels: l}
+}
+
+type logLevelsCore struct {
+	zapcore.Core
+	levels *LogLevels
+}
+
+func (c *logLevelsCore) With(fields []zapcore.Field) zapcore.Core {
+	return &logLevelsCore{Core: c.Core.With(fields), levels: c.levels}
+}
+
+func (c *logLevelsCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	e := c.levels.effective.Load()
+	if ent.Level < e.minLevel || ent.Level < e.levelFor(ent.LoggerName) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
@@ -0,0 +1,74 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLogLevels(t *testing.T) {
+	levels := NewLogLevels(zapcore.InfoLevel)
+	var global []zapcore.Level
+	levels.OnGlobalChange(func(lvl zapcore.Level) { global = append(global, lvl) })
+
+	observedCore, logs := observer.New(levels)
+	root := zap.New(levels.Core(observedCore)).Named("root")
+	eth := root.Named("ethwatch")
+	finalizer := eth.Named("finalizer")
+	sol := root.Named("solwatch")
+
+	logAll := func() []string {
+		logs.TakeAll()
+		for _, l := range []*zap.Logger{root, eth, finalizer, sol} {
+			l.Debug("debug")
+			l.Info("info")
+		}
+		var out []string
+		for _, e := range logs.TakeAll() {
+			out = append(out, e.LoggerName+" "+e.Message)
+		}
+		return out
+	}
+
+	assert.Equal(t, []string{"root info", "root.ethwatch info", "root.ethwatch.finalizer info", "root.solwatch info"}, logAll())
+
+	levels.SetLevel("ethwatch", zapcore.DebugLevel, 0)
+	assert.Equal(t, []string{"root info", "root.ethwatch debug", "root.ethwatch info", "root.ethwatch.finalizer debug", "root.ethwatch.finalizer info", "root.solwatch info"}, logAll())
+
+	// The longest component wins.
+	levels.SetLevel("ethwatch.finalizer", zapcore.WarnLevel, 0)
+	levels.SetLevel("", zapcore.WarnLevel, 0)
+	assert.Equal(t, []string{"root.ethwatch debug", "root.ethwatch info"}, logAll())
+	assert.Equal(t, []LogLevel{
+		{Level: zapcore.WarnLevel},
+		{Component: "ethwatch", Level: zapcore.DebugLevel},
+		{Component: "ethwatch.finalizer", Level: zapcore.WarnLevel},
+	}, levels.Levels())
+
+	levels.ResetLevel("")
+	levels.ResetLevel("ethwatch")
+	levels.ResetLevel("ethwatch.finalizer")
+	assert.Equal(t, []string{"root info", "root.ethwatch info", "root.ethwatch.finalizer info", "root.solwatch info"}, logAll())
+	assert.Equal(t, []zapcore.Level{zapcore.WarnLevel, zapcore.InfoLevel}, global)
+
+	// Levels set for a limited time revert.
+	levels.SetLevel("solwatch", zapcore.DebugLevel, 50*time.Millisecond)
+	lvls := levels.Levels()
+	require.Len(t, lvls, 2)
+	assert.False(t, lvls[1].Expires.IsZero())
+	assert.Contains(t, logAll(), "root.solwatch debug")
+	require.Eventually(t, func() bool { return len(levels.Levels()) == 1 }, time.Second, 10*time.Millisecond)
+	assert.NotContains(t, logAll(), "root.solwatch debug")
+}
+
+func TestLoggerInComponent(t *testing.T) {
+	assert.True(t, LoggerInComponent("root.ethwatch", "ethwatch"))
+	assert.True(t, LoggerInComponent("root.ethwatch.finalizer", "ethwatch.finalizer"))
+	assert.False(t, LoggerInComponent("root.ethwatcher", "ethwatch"))
+	assert.False(t, LoggerInComponent("root.solwatch", "ethwatch"))
+}
@@ -0,0 +1,107 @@
+package common
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// ObservationConflictRetention is how long a conflict is kept after its last signature was recorded.
+	ObservationConflictRetention = 7 * 24 * time.Hour
+	// MaxObservationConflicts caps the number of conflicts kept. Once reached, the conflict updated least recently is forgotten.
+	MaxObservationConflicts = 1000
+)
+
+// ObservationConflict is a point in time view of a message for which guardians signed more than one digest.
+type ObservationConflict struct {
+	MessageID string
+	FirstSeen time.Time
+	LastSeen  time.Time
+	// Signers maps each digest signed for the message to the guardians whose signature on it we have seen.
+	Signers map[common.Hash][]common.Address
+	// Ours is the digest we signed ourselves, or the zero hash if we have not observed the message.
+	Ours common.Hash
+}
+
+// ObservationConflictTracker records conflicting observations detected by the processor, so that they can be inspected via the
+// admin service after the fact.
+type ObservationConflictTracker struct {
+	mu      sync.Mutex
+	entries map[string]*ObservationConflict
+}
+
+// NewObservationConflictTracker returns a new ObservationConflictTracker.
+func NewObservationConflictTracker() *ObservationConflictTracker {
+	return &ObservationConflictTracker{
+		entries: make(map[string]*ObservationConflict),
+	}
+}
+
+// AddSignature records a verified signature by addr over digest, one of several digests signed for messageID. observedByUs is
+// set if digest is the one we signed ourselves.
+func (t *ObservationConflictTracker) AddSignature(messageID string, digest common.Hash, addr common.Address, observedByUs bool, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	e, exists := t.entries[messageID]
+	if !exists {
+		t.pruneLocked(now)
+		e = &ObservationConflict{
+			MessageID: messageID,
+			FirstSeen: now,
+			Signers:   make(map[common.Hash][]common.Address),
+		}
+		t.entries[messageID] = e
+	}
+	e.LastSeen = now
+	if observedByUs {
+		e.Ours = digest
+	}
+	for _, a := range e.Signers[digest] {
+		if a == addr {
+			return
+		}
+	}
+	e.Signers[digest] = append(e.Signers[digest], addr)
+}
+
+// List returns a copy of the conflicts currently known, oldest first.
+func (t *ObservationConflictTracker) List() []ObservationConflict {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	conflicts := make([]ObservationConflict, 0, len(t.entries))
+	for _, e := range t.entries {
+		c := *e
+		c.Signers = make(map[common.Hash][]common.Address, len(e.Signers))
+		for digest, signers := range e.Signers {
+			c.Signers[digest] = append([]common.Address(nil), signers...)
+		}
+		conflicts = append(conflicts, c)
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].FirstSeen.Before(conflicts[j].FirstSeen)
+	})
+	return conflicts
+}
+
+// pruneLocked makes room for a new entry, dropping expired entries and, if the tracker is still full, the one updated least
+// recently.
+func (t *ObservationConflictTracker) pruneLocked(now time.Time) {
+	var oldest *ObservationConflict
+	for id, e := range t.entries {
+		if now.Sub(e.LastSeen) > ObservationConflictRetention {
+			delete(t.entries, id)
+			continue
+		}
+		if oldest == nil || e.LastSeen.Before(oldest.LastSeen) {
+			oldest = e
+		}
+	}
+	if len(t.entries) >= MaxObservationConflicts && oldest != nil {
+		delete(t.entries, oldest.MessageID)
+	}
+}
@@ -0,0 +1,56 @@
+package common
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObservationConflictTracker(t *testing.T) {
+	tracker := NewObservationConflictTracker()
+	messageID := "1/0000000000000000000000000000000000000000000000000000000000000004/1"
+	ours := common.HexToHash("0x01")
+	theirs := common.HexToHash("0x02")
+	now := time.Now()
+
+	tracker.AddSignature(messageID, ours, common.HexToAddress("0x0a"), true, now)
+	tracker.AddSignature(messageID, theirs, common.HexToAddress("0x0b"), false, now)
+	// Duplicate signatures are only recorded once.
+	tracker.AddSignature(messageID, theirs, common.HexToAddress("0x0b"), false, now.Add(time.Second))
+	tracker.AddSignature(messageID, theirs, common.HexToAddress("0x0c"), false, now.Add(time.Second))
+
+	conflicts := tracker.List()
+	require.Len(t, conflicts, 1)
+	c := conflicts[0]
+	assert.Equal(t, messageID, c.MessageID)
+	assert.Equal(t, now, c.FirstSeen)
+	assert.Equal(t, now.Add(time.Second), c.LastSeen)
+	assert.Equal(t, ours, c.Ours)
+	assert.Equal(t, []common.Address{common.HexToAddress("0x0a")}, c.Signers[ours])
+	assert.Equal(t, []common.Address{common.HexToAddress("0x0b"), common.HexToAddress("0x0c")}, c.Signers[theirs])
+
+	// List returns copies.
+	c.Signers[ours][0] = common.HexToAddress("0x0d")
+	assert.Equal(t, common.HexToAddress("0x0a"), tracker.List()[0].Signers[ours][0])
+}
+
+func TestObservationConflictTrackerPruning(t *testing.T) {
+	tracker := NewObservationConflictTracker()
+	now := time.Now()
+
+	tracker.AddSignature("expired", common.HexToHash("0x01"), common.HexToAddress("0x0a"), false, now)
+	later := now.Add(ObservationConflictRetention + time.Second)
+	for i := 0; i < MaxObservationConflicts+1; i++ {
+		tracker.AddSignature(fmt.Sprint(i), common.HexToHash("0x01"), common.HexToAddress("0x0a"), false, later.Add(time.Duration(i)))
+	}
+
+	conflicts := tracker.List()
+	require.Len(t, conflicts, MaxObservationConflicts)
+	// The expired entry went first, then the one updated least recently.
+	assert.Equal(t, "1", conflicts[0].MessageID)
+	assert.Equal(t, fmt.Sprint(MaxObservationConflicts), conflicts[len(conflicts)-1].MessageID)
+}
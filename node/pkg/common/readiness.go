@@ -8,7 +8,7 @@ import (
 )
 
 // MustRegisterReadinessSyncing registers the specified chain for readiness syncing. It panics if the chain ID is invalid so it should only be used during initialization.
-// TODO: Using vaa.ChainID is bad here because there can be multiple watchers for the same chainId, e.g. solana-finalized and solana-confirmed. This is currently handled as a special case for solana in node/node.go, but should really be fixed here.
+// There can be multiple watchers for the same chainId, e.g. solana-finalized and solana-confirmed, which share its component, so it must only be registered once per chain.
 func MustRegisterReadinessSyncing(chainID vaa.ChainID) {
 	readiness.RegisterComponent(MustConvertChainIdToReadinessSyncing(chainID))
 }
@@ -0,0 +1,156 @@
+package db
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+
+	"go.uber.org/zap"
+)
+
+type AccountantDB interface {
+	AcctStorePendingTransfer(msg *common.MessagePublication) error
+	AcctDeletePendingTransfer(msgId string) error
+	AcctGetData(logger *zap.Logger) ([]*common.MessagePublication, error)
+	AcctStoreAuditEntry(e *AccountantAuditEntry) error
+	AcctGetAuditEntries() ([]*AccountantAuditEntry, error)
+}
+
+const acctPendingTransfer = "ACCT:PXFER:"
+
+func acctPendingTransferMsgID(msgId string) []byte {
+	return []byte(acctPendingTransfer + msgId)
+}
+
+// This is called by the accountant to persist a transfer which has been submitted to the accounting contract and is waiting to be
+// committed.
+func (d *Database) AcctStorePendingTransfer(msg *common.MessagePublication) error {
+	b, err := msg.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal accountant pending transfer: %w", err)
+	}
+
+	if err := d.db.Update(func(txn Txn) error {
+		return txn.Set(acctPendingTransferMsgID(msg.MessageIDString()), b)
+	}); err != nil {
+		return fmt.Errorf("failed to commit accountant pending transfer for tx %s: %w", msg.MessageIDString(), err)
+	}
+
+	return nil
+}
+
+// This is called by the accountant to delete a pending transfer once it has been committed or rejected.
+func (d *Database) AcctDeletePendingTransfer(msgId string) error {
+	if err := d.db.Update(func(txn Txn) error {
+		return txn.Delete(acctPendingTransferMsgID(msgId))
+	}); err != nil {
+		return fmt.Errorf("failed to delete accountant pending transfer for tx %s: %w", msgId, err)
+	}
+
+	return nil
+}
+
+// This is called by the accountant on start up to reload the pending transfers.
+func (d *Database) AcctGetData(logger *zap.Logger) (pending []*common.MessagePublication, err error) {
+	err = d.db.View(func(txn Txn) error {
+		return txn.Iterate([]byte(acctPendingTransfer), func(key []byte, val []byte) error {
+			msg, err := common.UnmarshalMessagePublication(val)
+			if err != nil {
+				logger.Error("failed to unmarshal accountant pending transfer, dropping it", zap.String("key", string(key)), zap.Error(err))
+				return nil
+			}
+			pending = append(pending, msg)
+			return nil
+		})
+	})
+	return
+}
+
+// AccountantAuditEntry records an action taken on an accountant by an admin, such as approving the forced release of a transfer.
+type AccountantAuditEntry struct {
+	Timestamp time.Time
+	// Accountant is the name of the accountant instance the action was taken on.
+	Accountant string
+	Action     string
+	MsgID      string
+	Actor      string
+	Detail     string
+}
+
+func (e *AccountantAuditEntry) Marshal() []byte {
+	buf := new(bytes.Buffer)
+	vaa.MustWrite(buf, binary.BigEndian, e.Timestamp.UnixNano())
+	writeLenPrefixed(buf, e.Accountant)
+	writeLenPrefixed(buf, e.Action)
+	writeLenPrefixed(buf, e.MsgID)
+	writeLenPrefixed(buf, e.Actor)
+	writeLenPrefixed(buf, e.Detail)
+	return buf.Bytes()
+}
+
+func UnmarshalAccountantAuditEntry(data []byte) (*AccountantAuditEntry, error) {
+	e := &AccountantAuditEntry{}
+	reader := bytes.NewReader(data)
+	var nanos int64
+	if err := binary.Read(reader, binary.BigEndian, &nanos); err != nil {
+		return nil, fmt.Errorf("failed to read audit entry timestamp: %w", err)
+	}
+	e.Timestamp = time.Unix(0, nanos)
+	for _, f := range []struct {
+		name  string
+		value *string
+	}{
+		{"accountant", &e.Accountant},
+		{"action", &e.Action},
+		{"msg id", &e.MsgID},
+		{"actor", &e.Actor},
+		{"detail", &e.Detail},
+	} {
+		var err error
+		if *f.value, err = readLenPrefixed(reader); err != nil {
+			return nil, fmt.Errorf("failed to read audit entry %s: %w", f.name, err)
+		}
+	}
+	return e, nil
+}
+
+const acctAudit = "ACCT:AUDIT:"
+
+// acctAuditID orders the audit entries by time. The rest of the key keeps apart the entries made at the same time.
+func acctAuditID(e *AccountantAuditEntry) []byte {
+	return []byte(fmt.Sprintf("%v%020d:%s:%s:%s:%s", acctAudit, e.Timestamp.UnixNano(), e.Accountant, e.Action, e.MsgID, e.Actor))
+}
+
+// This is called by the accountant to record an admin action. Audit entries are kept forever, unless the accountant_audit record
+// class has a retention.
+func (d *Database) AcctStoreAuditEntry(e *AccountantAuditEntry) error {
+	if err := d.db.Update(func(txn Txn) error {
+		key := acctAuditID(e)
+		if err := txn.Set(key, e.Marshal()); err != nil {
+			return err
+		}
+		return setTTL(txn, RecordClassAccountantAudit, e.Timestamp, key)
+	}); err != nil {
+		return fmt.Errorf("failed to commit accountant audit entry tx: %w", err)
+	}
+	return nil
+}
+
+// AcctGetAuditEntries returns the audit entries of all accountants, oldest first.
+func (d *Database) AcctGetAuditEntries() (entries []*AccountantAuditEntry, err error) {
+	err = d.db.View(func(txn Txn) error {
+		return txn.Iterate([]byte(acctAudit), func(_ []byte, val []byte) error {
+			e, err := UnmarshalAccountantAuditEntry(val)
+			if err != nil {
+				return err
+			}
+			entries = append(entries, e)
+			return nil
+		})
+	})
+	return
+}
@@ -0,0 +1,75 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	eth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+func TestAcctPendingTransfers(t *testing.T) {
+	db, err := Open(t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+
+	tokenBridgeAddr, err := vaa.StringToAddress("0x0290fb167208af455bb137780163b7b7a9a10c16")
+	require.NoError(t, err)
+
+	msg1 := &common.MessagePublication{
+		TxHash:           eth_common.HexToHash("0x06f541f5ecfc43407c31587aa6ac3a689e8960f36dc23c332db5510dfc6a4063"),
+		Timestamp:        time.Unix(int64(1654516425), 0),
+		Nonce:            123456,
+		Sequence:         789101112131415,
+		EmitterChain:     vaa.ChainIDSolana,
+		EmitterAddress:   tokenBridgeAddr,
+		Payload:          []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		ConsistencyLevel: 16,
+	}
+	msg2 := *msg1
+	msg2.Sequence++
+
+	require.NoError(t, db.AcctStorePendingTransfer(msg1))
+	require.NoError(t, db.AcctStorePendingTransfer(&msg2))
+	assert.Equal(t, "ACCT:PXFER:"+msg1.MessageIDString(), string(acctPendingTransferMsgID(msg1.MessageIDString())))
+
+	pending, err := db.AcctGetData(zap.NewNop())
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, msg1, pending[0])
+
+	require.NoError(t, db.AcctDeletePendingTransfer(msg1.MessageIDString()))
+	pending, err = db.AcctGetData(zap.NewNop())
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, &msg2, pending[0])
+}
+
+func TestAcctAuditEntries(t *testing.T) {
+	db, err := Open(t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+
+	now := time.Unix(1654516425, 0)
+	e1 := &AccountantAuditEntry{Timestamp: now, Accountant: "token_bridge", Action: "force-release-approve", MsgID: "1/00/1", Actor: "alice", Detail: "1 of 2 approvals"}
+	e2 := &AccountantAuditEntry{Timestamp: now, Accountant: "ntt", Action: "force-release", MsgID: "1/00/2", Actor: "bob"}
+	e0 := &AccountantAuditEntry{Timestamp: now.Add(-time.Second), Accountant: "token_bridge", Action: "force-release", MsgID: "1/00/3"}
+	for _, e := range []*AccountantAuditEntry{e1, e2, e0} {
+		require.NoError(t, db.AcctStoreAuditEntry(e))
+	}
+
+	entries, err := db.AcctGetAuditEntries()
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, e0, entries[0])
+	assert.ElementsMatch(t, []*AccountantAuditEntry{e1, e2}, entries[1:])
+
+	// Audit entries are not mistaken for pending transfers.
+	pending, err := db.AcctGetData(zap.NewNop())
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
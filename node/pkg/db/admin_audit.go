@@ -0,0 +1,103 @@
+package db
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// AdminAuditEntry records a call to the admin service.
+type AdminAuditEntry struct {
+	Timestamp time.Time
+	// Method is the full name of the method called, e.g. /node.v1.NodePrivilegedService/InjectGovernanceVAA.
+	Method string
+	// Caller identifies who made the call, e.g. the name of a TLS client.
+	Caller string
+	// Request is a summary of the request.
+	Request string
+	// Result is OK, or the status code and message of the error returned.
+	Result string
+}
+
+func (e *AdminAuditEntry) Marshal() []byte {
+	buf := new(bytes.Buffer)
+	vaa.MustWrite(buf, binary.BigEndian, e.Timestamp.UnixNano())
+	writeLenPrefixed(buf, e.Method)
+	writeLenPrefixed(buf, e.Caller)
+	writeLenPrefixed(buf, e.Request)
+	writeLenPrefixed(buf, e.Result)
+	return buf.Bytes()
+}
+
+func UnmarshalAdminAuditEntry(data []byte) (*AdminAuditEntry, error) {
+	e := &AdminAuditEntry{}
+	reader := bytes.NewReader(data)
+	var nanos int64
+	if err := binary.Read(reader, binary.BigEndian, &nanos); err != nil {
+		return nil, fmt.Errorf("failed to read audit entry timestamp: %w", err)
+	}
+	e.Timestamp = time.Unix(0, nanos)
+	for _, f := range []struct {
+		name  string
+		value *string
+	}{
+		{"method", &e.Method},
+		{"caller", &e.Caller},
+		{"request", &e.Request},
+		{"result", &e.Result},
+	} {
+		var err error
+		if *f.value, err = readLenPrefixed(reader); err != nil {
+			return nil, fmt.Errorf("failed to read audit entry %s: %w", f.name, err)
+		}
+	}
+	return e, nil
+}
+
+const adminAudit = adminPrefix + "AUDIT:"
+
+// adminAuditID orders the audit entries by time. The rest of the key keeps apart the entries made at the same time.
+func adminAuditID(e *AdminAuditEntry) []byte {
+	return []byte(fmt.Sprintf("%v%020d:%s:%s", adminAudit, e.Timestamp.UnixNano(), e.Method, e.Caller))
+}
+
+// StoreAdminAuditEntry records a call to the admin service. Audit entries are kept forever, unless the admin_audit record class has
+// a retention.
+func (d *Database) StoreAdminAuditEntry(e *AdminAuditEntry) error {
+	if err := d.db.Update(func(txn Txn) error {
+		key := adminAuditID(e)
+		if err := txn.Set(key, e.Marshal()); err != nil {
+			return err
+		}
+		return setTTL(txn, RecordClassAdminAudit, e.Timestamp, key)
+	}); err != nil {
+		return fmt.Errorf("failed to commit admin audit entry tx: %w", err)
+	}
+	return nil
+}
+
+// GetAdminAuditEntries returns the most recent limit audit entries made at or after since, oldest first. A limit of 0 returns all
+// of them.
+func (d *Database) GetAdminAuditEntries(since time.Time, limit int) (entries []*AdminAuditEntry, err error) {
+	start := []byte(adminAudit)
+	if since.After(time.Unix(0, 0)) {
+		start = []byte(fmt.Sprintf("%v%020d", adminAudit, since.UnixNano()))
+	}
+	err = d.db.View(func(txn Txn) error {
+		return txn.IterateFrom([]byte(adminAudit), start, func(_ []byte, val []byte) error {
+			e, err := UnmarshalAdminAuditEntry(val)
+			if err != nil {
+				return err
+			}
+			entries = append(entries, e)
+			if limit > 0 && len(entries) > limit {
+				entries = entries[1:]
+			}
+			return nil
+		})
+	})
+	return
+}
@@ -0,0 +1,46 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminAuditEntries(t *testing.T) {
+	db, err := Open(t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+
+	now := time.Unix(1654516425, 0)
+	e0 := &AdminAuditEntry{Timestamp: now.Add(-time.Minute), Method: "/node.v1.NodePrivilegedService/GetNodeHealth", Caller: "local", Request: "{}", Result: "OK"}
+	e1 := &AdminAuditEntry{Timestamp: now, Method: "/node.v1.NodePrivilegedService/InjectGovernanceVAA", Caller: "ops", Request: `{"currentSetIndex":4}`, Result: "InvalidArgument: invalid guardian set index"}
+	e2 := &AdminAuditEntry{Timestamp: now, Method: "/node.v1.NodePrivilegedService/DumpConfig", Caller: "monitor", Request: "{}", Result: "PermissionDenied: client monitor may not call DumpConfig"}
+	e3 := &AdminAuditEntry{Timestamp: now.Add(time.Minute), Method: "/node.v1.NodePrivilegedService/GetNodeHealth", Caller: "local", Request: "{}", Result: "OK"}
+	for _, e := range []*AdminAuditEntry{e3, e1, e0, e2} {
+		require.NoError(t, db.StoreAdminAuditEntry(e))
+	}
+
+	entries, err := db.GetAdminAuditEntries(time.Time{}, 0)
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+	assert.Equal(t, e0, entries[0])
+	assert.ElementsMatch(t, []*AdminAuditEntry{e1, e2}, entries[1:3])
+	assert.Equal(t, e3, entries[3])
+
+	// The limit keeps the most recent entries.
+	entries, err = db.GetAdminAuditEntries(time.Time{}, 2)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, e3, entries[1])
+
+	entries, err = db.GetAdminAuditEntries(now, 0)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, e3, entries[2])
+
+	entries, err = db.GetAdminAuditEntries(now.Add(time.Hour), 0)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
@@ -0,0 +1,122 @@
+package db
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+const aggregationStatePrefix = "AGG1:"
+
+// AggregationState is the processor's view of an observation it is collecting signatures for, stored so that signatures collected
+// before a restart are not lost.
+type AggregationState struct {
+	Digest        string    `json:"digest"`
+	FirstObserved time.Time `json:"firstObserved"`
+	OurObservedAt time.Time `json:"ourObservedAt"`
+	NextRetry     time.Time `json:"nextRetry"`
+	RetryCtr      uint      `json:"retryCtr"`
+	Settled       bool      `json:"settled"`
+	Source        string    `json:"source"`
+	// MessageID is the message ID of the observation, used to restore it to the right aggregation shard.
+	MessageID string `json:"messageId,omitempty"`
+	// OurObservation is our own unsigned VAA, if we made the observation.
+	OurObservation []byte `json:"ourObservation,omitempty"`
+	Unreliable     bool   `json:"unreliable,omitempty"`
+	Reobservation  bool   `json:"reobservation,omitempty"`
+	Confidence     string `json:"confidence,omitempty"`
+	// OurMsg is our signed observation as broadcast, used for retransmissions.
+	OurMsg []byte `json:"ourMsg,omitempty"`
+	TxHash []byte `json:"txHash,omitempty"`
+	// GuardianSetIndex and GuardianSetKeys are the guardian set valid when we made the observation.
+	GuardianSetIndex uint32                       `json:"guardianSetIndex"`
+	GuardianSetKeys  []ethcommon.Address          `json:"guardianSetKeys,omitempty"`
+	Signatures       map[ethcommon.Address][]byte `json:"signatures"`
+}
+
+func aggregationStateKey(digest string) []byte {
+	return []byte(aggregationStatePrefix + digest)
+}
+
+// StoreAggregationStates writes the given aggregation states, replacing any stored under the same digests.
+func (d *Database) StoreAggregationStates(states []*AggregationState) error {
+	if err := d.db.Batch(func(wb Writer) error {
+		for _, s := range states {
+			b, err := json.Marshal(s)
+			if err != nil {
+				return fmt.Errorf("failed to marshal aggregation state %s: %w", s.Digest, err)
+			}
+			key := aggregationStateKey(s.Digest)
+			if err := wb.Set(key, b); err != nil {
+				return fmt.Errorf("failed to write aggregation state %s: %w", s.Digest, err)
+			}
+			if err := setTTL(wb, RecordClassAggregationState, s.FirstObserved, key); err != nil {
+				return fmt.Errorf("failed to write aggregation state %s: %w", s.Digest, err)
+			}
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to commit aggregation states: %w", err)
+	}
+	return nil
+}
+
+// DeleteAggregationStates deletes the aggregation states of the given digests.
+func (d *Database) DeleteAggregationStates(digests []string) error {
+	// The time of the first observation is needed to delete the states from the TTL index.
+	firstObserved := make(map[string]time.Time, len(digests))
+	if err := d.db.View(func(txn Txn) error {
+		for _, digest := range digests {
+			b, err := txn.Get(aggregationStateKey(digest))
+			if errors.Is(err, ErrKeyNotFound) {
+				continue
+			} else if err != nil {
+				return err
+			}
+			var s struct {
+				FirstObserved time.Time `json:"firstObserved"`
+			}
+			if err := json.Unmarshal(b, &s); err != nil {
+				return fmt.Errorf("failed to unmarshal aggregation state %s: %w", digest, err)
+			}
+			firstObserved[digest] = s.FirstObserved
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to read aggregation states: %w", err)
+	}
+
+	if err := d.db.Batch(func(wb Writer) error {
+		for digest, t := range firstObserved {
+			key := aggregationStateKey(digest)
+			if err := wb.Delete(key); err != nil {
+				return fmt.Errorf("failed to delete aggregation state %s: %w", digest, err)
+			}
+			if err := deleteTTL(wb, RecordClassAggregationState, t, key); err != nil {
+				return fmt.Errorf("failed to delete aggregation state %s: %w", digest, err)
+			}
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to commit aggregation state deletions: %w", err)
+	}
+	return nil
+}
+
+// GetAggregationStates returns all stored aggregation states.
+func (d *Database) GetAggregationStates() (states []*AggregationState, err error) {
+	err = d.db.View(func(txn Txn) error {
+		return txn.Iterate([]byte(aggregationStatePrefix), func(key []byte, val []byte) error {
+			var s AggregationState
+			if err := json.Unmarshal(val, &s); err != nil {
+				return fmt.Errorf("failed to unmarshal aggregation state for key %s: %w", key, err)
+			}
+			states = append(states, &s)
+			return nil
+		})
+	})
+	return
+}
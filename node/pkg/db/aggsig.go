@@ -0,0 +1,41 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const aggregatedAttestationPrefix = "aggsig/"
+
+var ErrAttestationNotFound = errors.New("requested aggregated attestation not found in store")
+
+func aggregatedAttestationKey(id *VAAID) []byte {
+	return append([]byte(aggregatedAttestationPrefix), id.Bytes()[len(signedVaaPrefix):]...)
+}
+
+// StoreAggregatedAttestation stores the experimental aggregated attestation of a VAA, replacing any existing one. The attestation
+// expires based on the timestamp of the VAA.
+func (d *Database) StoreAggregatedAttestation(id VAAID, timestamp time.Time, attestation []byte) error {
+	if err := d.db.Update(func(txn Txn) error {
+		key := aggregatedAttestationKey(&id)
+		if err := txn.Set(key, attestation); err != nil {
+			return err
+		}
+		return setTTL(txn, RecordClassAggregatedAttestation, timestamp, key)
+	}); err != nil {
+		return fmt.Errorf("failed to commit tx: %w", err)
+	}
+	return nil
+}
+
+func (d *Database) GetAggregatedAttestation(id VAAID) (b []byte, err error) {
+	err = d.db.View(func(txn Txn) error {
+		b, err = txn.Get(aggregatedAttestationKey(&id))
+		return err
+	})
+	if errors.Is(err, ErrKeyNotFound) {
+		return nil, ErrAttestationNotFound
+	}
+	return b, err
+}
@@ -0,0 +1,150 @@
+package db
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// restoredVaaPrefix marks VAAs that were restored from an archive. The value is the unix time until which the VAA is kept.
+const restoredVaaPrefix = "vaarestored/"
+
+const signedVaaPrefix = "signed/"
+
+// ArchivableVAA is a stored VAA that is old enough to be archived.
+type ArchivableVAA struct {
+	ID        VAAID
+	Timestamp time.Time
+	Bytes     []byte
+	// Restored is set if the VAA was restored from an archive, so it can be pruned again without archiving it a second time.
+	Restored bool
+}
+
+func restoredVaaKey(id *VAAID) []byte {
+	return append([]byte(restoredVaaPrefix), id.Bytes()[len(signedVaaPrefix):]...)
+}
+
+// vaaTimestamp reads the timestamp of a marshaled VAA without unmarshaling the rest of it.
+func vaaTimestamp(b []byte) (time.Time, error) {
+	// Version (1), guardian set index (4), number of signatures (1), signatures (66 each), timestamp (4).
+	if len(b) < 6 {
+		return time.Time{}, errors.New("VAA too short")
+	}
+	offset := 6 + int(b[5])*66
+	if len(b) < offset+4 {
+		return time.Time{}, errors.New("VAA too short")
+	}
+	return time.Unix(int64(binary.BigEndian.Uint32(b[offset:offset+4])), 0), nil
+}
+
+// ScanArchivableVAAs calls fn with batches of up to batchSize VAAs with a timestamp before cutoff. VAAs that were restored from an
+// archive are skipped until their hold expires. Batches are in key order.
+func (d *Database) ScanArchivableVAAs(cutoff time.Time, now time.Time, batchSize int, fn func([]*ArchivableVAA) error) error {
+	return d.db.View(func(txn Txn) error {
+		batch := make([]*ArchivableVAA, 0, batchSize)
+		err := txn.Iterate([]byte(signedVaaPrefix), func(key []byte, val []byte) error {
+			timestamp, err := vaaTimestamp(val)
+			if err != nil {
+				return fmt.Errorf("failed to read timestamp of VAA %s: %w", key, err)
+			}
+			if !timestamp.Before(cutoff) {
+				return nil
+			}
+
+			id, err := VaaIDFromString(strings.TrimPrefix(string(key), signedVaaPrefix))
+			if err != nil {
+				return fmt.Errorf("invalid VAA key %s: %w", key, err)
+			}
+
+			restored := false
+			holdUntil, err := txn.Get(restoredVaaKey(id))
+			if err == nil {
+				if len(holdUntil) == 8 && now.Unix() < int64(binary.BigEndian.Uint64(holdUntil)) {
+					return nil
+				}
+				restored = true
+			} else if !errors.Is(err, ErrKeyNotFound) {
+				return err
+			}
+
+			batch = append(batch, &ArchivableVAA{ID: *id, Timestamp: timestamp, Bytes: append([]byte(nil), val...), Restored: restored})
+			if len(batch) == batchSize {
+				if err := fn(batch); err != nil {
+					return err
+				}
+				batch = make([]*ArchivableVAA, 0, batchSize)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(batch) != 0 {
+			return fn(batch)
+		}
+		return nil
+	})
+}
+
+// PruneVAAs deletes the given VAAs, and whether they were restored from an archive.
+func (d *Database) PruneVAAs(ids []VAAID) error {
+	if err := d.db.Batch(func(wb Writer) error {
+		for i := range ids {
+			if err := wb.Delete(ids[i].Bytes()); err != nil {
+				return fmt.Errorf("failed to delete VAA %s: %w", ids[i].Bytes(), err)
+			}
+			if err := wb.Delete(vaaSequenceKey(&ids[i])); err != nil {
+				return fmt.Errorf("failed to delete VAA %s from the sequence index: %w", ids[i].Bytes(), err)
+			}
+			if err := wb.Delete(restoredVaaKey(&ids[i])); err != nil {
+				return fmt.Errorf("failed to delete restore marker of VAA %s: %w", ids[i].Bytes(), err)
+			}
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to commit VAA deletions: %w", err)
+	}
+	return nil
+}
+
+// RestoreVAAs stores VAAs read from an archive. They are not archived again, and are pruned once holdUntil has passed.
+func (d *Database) RestoreVAAs(vaas []*vaa.VAA, holdUntil time.Time) error {
+	hold := make([]byte, 8)
+	binary.BigEndian.PutUint64(hold, uint64(holdUntil.Unix()))
+	restored := make([][]byte, 0, len(vaas))
+
+	if err := d.db.Batch(func(wb Writer) error {
+		for _, v := range vaas {
+			b, err := v.Marshal()
+			if err != nil {
+				return fmt.Errorf("failed to marshal VAA %s: %w", v.MessageID(), err)
+			}
+			id := VaaIDFromVAA(v)
+			if err := wb.Set(id.Bytes(), b); err != nil {
+				return fmt.Errorf("failed to write VAA %s: %w", v.MessageID(), err)
+			}
+			if err := wb.Set(vaaSequenceKey(id), nil); err != nil {
+				return fmt.Errorf("failed to index VAA %s: %w", v.MessageID(), err)
+			}
+			if err := indexGuardianSetUpdate(wb, v); err != nil {
+				return fmt.Errorf("failed to index VAA %s: %w", v.MessageID(), err)
+			}
+			if err := wb.Set(restoredVaaKey(id), hold); err != nil {
+				return fmt.Errorf("failed to write restore marker of VAA %s: %w", v.MessageID(), err)
+			}
+			restored = append(restored, b)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to commit restored VAAs: %w", err)
+	}
+	for _, b := range restored {
+		d.feed.notify(b)
+	}
+	return nil
+}
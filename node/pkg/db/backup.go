@@ -0,0 +1,58 @@
+package db
+
+import (
+	"errors"
+	"io"
+)
+
+// backupMaxPendingWrites is the number of pending writes Badger allows while loading a backup.
+const backupMaxPendingWrites = 256
+
+// ErrBackupUnsupported is returned when backing up or restoring a database whose backend does not support backups.
+var ErrBackupUnsupported = errors.New("backups are only supported by the badger backend")
+
+// SupportsBackup returns whether the backend of the database supports Backup and LoadBackup.
+func (d *Database) SupportsBackup() bool {
+	_, ok := d.db.(*badgerStore)
+	return ok
+}
+
+// Backup writes a consistent snapshot of the whole database to w, in the format of Badger backups. Writes may continue while the
+// backup is taken, but are not included in it.
+func (d *Database) Backup(w io.Writer) error {
+	s, ok := d.db.(*badgerStore)
+	if !ok {
+		return ErrBackupUnsupported
+	}
+	_, err := s.db.Backup(w, 0)
+	return err
+}
+
+// LoadBackup stores the keys of a backup written by Backup in the database. Keys which are in both are overwritten, so backups
+// should only be loaded into an empty database, see IsEmpty.
+func (d *Database) LoadBackup(r io.Reader) error {
+	s, ok := d.db.(*badgerStore)
+	if !ok {
+		return ErrBackupUnsupported
+	}
+	return s.db.Load(r, backupMaxPendingWrites)
+}
+
+// IsEmpty returns whether the database has no keys, apart from the ones written when any database is opened.
+func (d *Database) IsEmpty() (bool, error) {
+	empty := true
+	err := d.db.View(func(txn Txn) error {
+		err := txn.IterateKeys(nil, func(key []byte, _ int64) error {
+			if string(key) == schemaVersionKey {
+				return nil
+			}
+			empty = false
+			return errStopIteration
+		})
+		if errors.Is(err, errStopIteration) {
+			return nil
+		}
+		return err
+	})
+	return empty, err
+}
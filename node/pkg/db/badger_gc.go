@@ -0,0 +1,125 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dgraph-io/badger/v3"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+var (
+	gcRuns = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "wormhole_db_gc_runs_total",
+			Help: "Total number of value log garbage collections of the database, by result (rewritten, nothing_to_rewrite, rejected or failed)",
+		}, []string{"result"})
+	gcDuration = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "wormhole_db_gc_duration_seconds",
+			Help:    "Duration of the value log garbage collection cycles of the database",
+			Buckets: []float64{0.01, 0.1, 1, 10, 60, 300},
+		})
+)
+
+// BadgerOptions tunes Badger. Badger keeps values in a log which is only shrunk by garbage collection, so without it the log of a
+// busy guardian grows by gigabytes of overwritten and deleted values.
+type BadgerOptions struct {
+	// GCInterval is how often the value log is garbage collected, zero to disable garbage collection.
+	GCInterval time.Duration
+	// GCDiscardRatio is the fraction of a value log file which must be garbage for the file to be rewritten. Lower ratios reclaim
+	// more space at the cost of more rewrites.
+	GCDiscardRatio float64
+	// NumCompactors is the number of concurrent LSM tree compactions.
+	NumCompactors int
+}
+
+// DefaultBadgerOptions returns the settings used unless configured otherwise.
+func DefaultBadgerOptions() BadgerOptions {
+	return BadgerOptions{
+		GCInterval:     5 * time.Minute,
+		GCDiscardRatio: 0.5,
+		NumCompactors:  4,
+	}
+}
+
+// Validate returns an error if the options are invalid.
+func (o BadgerOptions) Validate() error {
+	if o.GCInterval < 0 {
+		return errors.New("the GC interval must not be negative")
+	}
+	if o.GCDiscardRatio <= 0 || o.GCDiscardRatio >= 1 {
+		return fmt.Errorf("the GC discard ratio must be between 0 and 1 exclusive, got %v", o.GCDiscardRatio)
+	}
+	// Badger needs a compactor dedicated to level 0 and at least one for the other levels. Without compactors, writes eventually
+	// block forever.
+	if o.NumCompactors < 2 {
+		return fmt.Errorf("at least 2 compactors are required, got %d", o.NumCompactors)
+	}
+	return nil
+}
+
+// GCRunnable returns a runnable which periodically garbage collects the database, or nil if it does not need garbage collection
+// because of its backend, because it is in memory, or because garbage collection is disabled.
+func (d *Database) GCRunnable() func(ctx context.Context) error {
+	s, ok := d.db.(*badgerStore)
+	if !ok || s.opts.GCInterval == 0 || s.db.Opts().InMemory {
+		return nil
+	}
+	return s.runGC
+}
+
+func (s *badgerStore) runGC(ctx context.Context) error {
+	s.logger.Info("starting database garbage collection", zap.Duration("interval", s.opts.GCInterval), zap.Float64("discardRatio", s.opts.GCDiscardRatio))
+	s.updateSize()
+	ticker := time.NewTicker(s.opts.GCInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			s.gcCycle(ctx)
+		}
+	}
+}
+
+// gcCycle rewrites value log files until one has too little garbage to be worth rewriting.
+func (s *badgerStore) gcCycle(ctx context.Context) {
+	start := time.Now()
+	rewritten := 0
+	for ctx.Err() == nil {
+		err := s.db.RunValueLogGC(s.opts.GCDiscardRatio)
+		if err == nil {
+			gcRuns.WithLabelValues("rewritten").Inc()
+			rewritten++
+			continue
+		}
+		switch {
+		case errors.Is(err, badger.ErrNoRewrite):
+			gcRuns.WithLabelValues("nothing_to_rewrite").Inc()
+		case errors.Is(err, badger.ErrRejected):
+			// Another garbage collection is running, or the database is closing.
+			gcRuns.WithLabelValues("rejected").Inc()
+		default:
+			gcRuns.WithLabelValues("failed").Inc()
+			s.logger.Error("database garbage collection failed", zap.Error(err))
+		}
+		break
+	}
+	gcDuration.Observe(time.Since(start).Seconds())
+	s.updateSize()
+	if rewritten != 0 {
+		s.logger.Info("garbage collected the database", zap.Int("rewrittenFiles", rewritten), zap.Duration("duration", time.Since(start)))
+	}
+}
+
+func (s *badgerStore) updateSize() {
+	lsm, vlog := s.db.Size()
+	dbSize.WithLabelValues(s.name, "lsm").Set(float64(lsm))
+	dbSize.WithLabelValues(s.name, "vlog").Set(float64(vlog))
+}
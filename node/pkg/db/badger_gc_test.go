@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestBadgerOptionsValidate(t *testing.T) {
+	assert.NoError(t, DefaultBadgerOptions().Validate())
+
+	disabled := DefaultBadgerOptions()
+	disabled.GCInterval = 0
+	assert.NoError(t, disabled.Validate())
+
+	for name, modify := range map[string]func(*BadgerOptions){
+		"negative interval":   func(o *BadgerOptions) { o.GCInterval = -time.Second },
+		"zero discard ratio":  func(o *BadgerOptions) { o.GCDiscardRatio = 0 },
+		"whole discard ratio": func(o *BadgerOptions) { o.GCDiscardRatio = 1 },
+		"one compactor":       func(o *BadgerOptions) { o.NumCompactors = 1 },
+	} {
+		t.Run(name, func(t *testing.T) {
+			opts := DefaultBadgerOptions()
+			modify(&opts)
+			assert.Error(t, opts.Validate())
+
+			_, err := OpenWithOptions(zap.NewNop(), "", Options{Backend: BackendBadger, Badger: opts})
+			assert.Error(t, err)
+		})
+	}
+
+	// The Badger options do not apply to the other backends.
+	db, err := OpenWithOptions(zap.NewNop(), "", Options{Backend: BackendLevelDB})
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+}
+
+func TestGCRunnable(t *testing.T) {
+	inMemory, err := OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer inMemory.Close()
+	assert.Nil(t, inMemory.GCRunnable())
+
+	levelDB, err := OpenBackend(zap.NewNop(), BackendLevelDB, t.TempDir())
+	require.NoError(t, err)
+	defer levelDB.Close()
+	assert.Nil(t, levelDB.GCRunnable())
+
+	opts := DefaultOptions()
+	opts.Badger.GCInterval = 0
+	disabled, err := OpenWithOptions(zap.NewNop(), t.TempDir(), opts)
+	require.NoError(t, err)
+	defer disabled.Close()
+	assert.Nil(t, disabled.GCRunnable())
+
+	opts.Badger.GCInterval = 10 * time.Millisecond
+	db, err := OpenWithOptions(zap.NewNop(), t.TempDir(), opts)
+	require.NoError(t, err)
+	defer db.Close()
+	gc := db.GCRunnable()
+	require.NotNil(t, gc)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.NoError(t, gc(ctx))
+}
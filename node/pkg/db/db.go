@@ -10,6 +10,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
 )
 
 var storedVaaTotal = promauto.NewCounter(
@@ -19,7 +20,21 @@ var storedVaaTotal = promauto.NewCounter(
 	})
 
 type Database struct {
-	db *badger.DB
+	db Store
+	// name identifies the database in the metrics, see Options.Name.
+	name string
+	// feed notifies subscribers of the signed VAAs stored in the database.
+	feed signedVAAFeed
+}
+
+// NewDatabase returns the main database, stored in store.
+func NewDatabase(store Store) *Database {
+	return &Database{db: store, name: MainDatabase}
+}
+
+// Name returns the name of the database, MainDatabase or StateDatabase.
+func (d *Database) Name() string {
+	return d.name
 }
 
 type VAAID struct {
@@ -89,9 +104,12 @@ func Open(path string) (*Database, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
-	return &Database{
-		db: db,
-	}, nil
+	d := NewDatabase(&badgerStore{db: db})
+	if _, err := d.Migrate(zap.NewNop(), false); err != nil {
+		d.Close()
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
+	return d, nil
 }
 
 func (d *Database) Close() error {
@@ -99,6 +117,25 @@ func (d *Database) Close() error {
 }
 
 func (d *Database) StoreSignedVAA(v *vaa.VAA) error {
+	b, err := d.storeSignedVAA(v)
+	if err != nil {
+		return err
+	}
+
+	storedVaaTotal.Inc()
+	d.feed.notify(b)
+
+	return nil
+}
+
+// UpdateSignedVAA stores a VAA that was stored before with fewer signatures. Unlike StoreSignedVAA, it does not notify the
+// subscribers of SubscribeSignedVAAs, which have received the VAA already.
+func (d *Database) UpdateSignedVAA(v *vaa.VAA) error {
+	_, err := d.storeSignedVAA(v)
+	return err
+}
+
+func (d *Database) storeSignedVAA(v *vaa.VAA) ([]byte, error) {
 	if len(v.Signatures) == 0 {
 		panic("StoreSignedVAA called for unsigned VAA")
 	}
@@ -112,50 +149,48 @@ func (d *Database) StoreSignedVAA(v *vaa.VAA) error {
 	//
 	// TODO: panic on non-identical signing digest?
 
-	err := d.db.Update(func(txn *badger.Txn) error {
-		if err := txn.Set(VaaIDFromVAA(v).Bytes(), b); err != nil {
+	err := d.db.Update(func(txn Txn) error {
+		id := VaaIDFromVAA(v)
+		if err := txn.Set(id.Bytes(), b); err != nil {
 			return err
 		}
-		return nil
+		if err := txn.Set(vaaSequenceKey(id), nil); err != nil {
+			return err
+		}
+		return indexGuardianSetUpdate(txn, v)
 	})
 
 	if err != nil {
-		return fmt.Errorf("failed to commit tx: %w", err)
+		return nil, fmt.Errorf("failed to commit tx: %w", err)
 	}
 
-	storedVaaTotal.Inc()
-
-	return nil
+	return b, nil
 }
 
 func (d *Database) HasVAA(id VAAID) (bool, error) {
-	err := d.db.View(func(txn *badger.Txn) error {
+	err := d.db.View(func(txn Txn) error {
 		_, err := txn.Get(id.Bytes())
 		return err
 	})
 	if err == nil {
 		return true, nil
 	}
-	if err == badger.ErrKeyNotFound {
+	if err == ErrKeyNotFound {
 		return false, nil
 	}
 	return false, err
 }
 
 func (d *Database) GetSignedVAABytes(id VAAID) (b []byte, err error) {
-	if err := d.db.View(func(txn *badger.Txn) error {
-		item, err := txn.Get(id.Bytes())
+	if err := d.db.View(func(txn Txn) error {
+		val, err := txn.Get(id.Bytes())
 		if err != nil {
 			return err
 		}
-		if val, err := item.ValueCopy(nil); err != nil {
-			return err
-		} else {
-			b = val
-		}
+		b = val
 		return nil
 	}); err != nil {
-		if err == badger.ErrKeyNotFound {
+		if err == ErrKeyNotFound {
 			return nil, ErrVAANotFound
 		}
 		return nil, err
@@ -163,31 +198,44 @@ func (d *Database) GetSignedVAABytes(id VAAID) (b []byte, err error) {
 	return
 }
 
+// GetSignedVAABytesBatch returns the signed VAAs of several messages, read from a single snapshot of the database. The result is in
+// the order of ids, with a nil entry for each VAA that is not found.
+func (d *Database) GetSignedVAABytesBatch(ids []VAAID) ([][]byte, error) {
+	vaas := make([][]byte, len(ids))
+	if err := d.db.View(func(txn Txn) error {
+		for i := range ids {
+			val, err := txn.Get(ids[i].Bytes())
+			if errors.Is(err, ErrKeyNotFound) {
+				continue
+			} else if err != nil {
+				return err
+			}
+			vaas[i] = val
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return vaas, nil
+}
+
 func (d *Database) FindEmitterSequenceGap(prefix VAAID) (resp []uint64, firstSeq uint64, lastSeq uint64, err error) {
 	resp = make([]uint64, 0)
-	if err = d.db.View(func(txn *badger.Txn) error {
-		it := txn.NewIterator(badger.DefaultIteratorOptions)
-		defer it.Close()
-		prefix := prefix.EmitterPrefixBytes()
-
+	if err = d.db.View(func(txn Txn) error {
 		// Find all sequence numbers (the message IDs are ordered lexicographically,
 		// rather than numerically, so we need to sort them in-memory).
 		seqs := make(map[uint64]bool)
-		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
-			item := it.Item()
-			key := item.Key()
-			err := item.Value(func(val []byte) error {
-				v, err := vaa.Unmarshal(val)
-				if err != nil {
-					return fmt.Errorf("failed to unmarshal VAA for %s: %v", string(key), err)
-				}
-
-				seqs[v.Sequence] = true
-				return nil
-			})
+		err := txn.Iterate(prefix.EmitterPrefixBytes(), func(key []byte, val []byte) error {
+			v, err := vaa.Unmarshal(val)
 			if err != nil {
-				return err
+				return fmt.Errorf("failed to unmarshal VAA for %s: %v", string(key), err)
 			}
+
+			seqs[v.Sequence] = true
+			return nil
+		})
+		if err != nil {
+			return err
 		}
 
 		// Find min/max (yay lack of Go generics)
@@ -19,6 +19,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 )
 
 func getVAA() vaa.VAA {
@@ -144,6 +145,59 @@ func TestGetSignedVAABytes(t *testing.T) {
 	assert.Equal(t, testVaaBytes, vaaBytes)
 }
 
+func TestGetSignedVAABytesBatch(t *testing.T) {
+	db, err := OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer db.Close()
+
+	privKey, _ := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	first := getVAA()
+	first.AddSignature(privKey, 0)
+	second := getVAA()
+	second.Sequence++
+	second.AddSignature(privKey, 0)
+	require.NoError(t, db.StoreSignedVAA(&first))
+	require.NoError(t, db.StoreSignedVAA(&second))
+
+	missing := *VaaIDFromVAA(&second)
+	missing.Sequence++
+	vaas, err := db.GetSignedVAABytesBatch([]VAAID{*VaaIDFromVAA(&second), missing, *VaaIDFromVAA(&first)})
+	require.NoError(t, err)
+	require.Len(t, vaas, 3)
+
+	firstBytes, err := first.Marshal()
+	require.NoError(t, err)
+	secondBytes, err := second.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, secondBytes, vaas[0])
+	assert.Nil(t, vaas[1])
+	assert.Equal(t, firstBytes, vaas[2])
+}
+
+func TestOpenInMemory(t *testing.T) {
+	db, err := OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer db.Close()
+
+	testVaa := getVAA()
+	privKey, _ := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	testVaa.AddSignature(privKey, 0)
+	require.NoError(t, db.StoreSignedVAA(&testVaa))
+
+	vaaBytes, err := db.GetSignedVAABytes(*VaaIDFromVAA(&testVaa))
+	require.NoError(t, err)
+	testVaaBytes, err := testVaa.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, testVaaBytes, vaaBytes)
+
+	// A second in-memory database does not share any state with the first.
+	other, err := OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer other.Close()
+	_, err = other.GetSignedVAABytes(*VaaIDFromVAA(&testVaa))
+	assert.ErrorIs(t, err, ErrVAANotFound)
+}
+
 func TestFindEmitterSequenceGap(t *testing.T) {
 	dbPath := t.TempDir()
 	db, err := Open(dbPath)
@@ -185,9 +239,7 @@ func BenchmarkVaaLookup(b *testing.B) {
 	optionsDB.Logger = nil
 	badgerDb, err := badger.Open(optionsDB)
 	require.NoError(b, err)
-	db := &Database{
-		db: badgerDb,
-	}
+	db := NewDatabase(&badgerStore{db: badgerDb})
 
 	if err != nil {
 		b.Error("failed to open database")
@@ -0,0 +1,80 @@
+package db
+
+import (
+	"encoding/binary"
+	"errors"
+	"sync"
+	"time"
+)
+
+// replicationCheckpointKey stores the time up to which a replica is known to have received every VAA stored by its source.
+const replicationCheckpointKey = "replication/checkpoint"
+
+// signedVAAFeed notifies subscribers of newly stored signed VAAs. A subscriber that falls behind is dropped rather than blocking
+// the write path: its channel is closed and it has to catch up from the database.
+type signedVAAFeed struct {
+	mu          sync.Mutex
+	subscribers map[chan []byte]struct{}
+}
+
+func (f *signedVAAFeed) notify(b []byte) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for c := range f.subscribers {
+		select {
+		case c <- b:
+		default:
+			delete(f.subscribers, c)
+			close(c)
+		}
+	}
+}
+
+// SubscribeSignedVAAs returns a channel of the marshaled signed VAAs stored from now on, and a function to unsubscribe. VAAs
+// stored again with UpdateSignedVAA to add late signatures are not sent again. Up to bufferSize VAAs are buffered. If the
+// subscriber falls further behind, the channel is closed and VAAs stored in the meantime have to be read from the database.
+func (d *Database) SubscribeSignedVAAs(bufferSize int) (<-chan []byte, func()) {
+	d.feed.mu.Lock()
+	defer d.feed.mu.Unlock()
+	if d.feed.subscribers == nil {
+		d.feed.subscribers = make(map[chan []byte]struct{})
+	}
+	c := make(chan []byte, bufferSize)
+	d.feed.subscribers[c] = struct{}{}
+	return c, func() {
+		d.feed.mu.Lock()
+		defer d.feed.mu.Unlock()
+		if _, exists := d.feed.subscribers[c]; exists {
+			delete(d.feed.subscribers, c)
+			close(c)
+		}
+	}
+}
+
+// StoreReplicationCheckpoint records that the database has every VAA its replication source stored before t.
+func (d *Database) StoreReplicationCheckpoint(t time.Time) error {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(t.Unix()))
+	return d.db.Update(func(txn Txn) error {
+		return txn.Set([]byte(replicationCheckpointKey), b)
+	})
+}
+
+// GetReplicationCheckpoint returns the time stored by StoreReplicationCheckpoint, or the zero time if there is none.
+func (d *Database) GetReplicationCheckpoint() (time.Time, error) {
+	var t time.Time
+	err := d.db.View(func(txn Txn) error {
+		b, err := txn.Get([]byte(replicationCheckpointKey))
+		if errors.Is(err, ErrKeyNotFound) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if len(b) != 8 {
+			return errors.New("invalid replication checkpoint")
+		}
+		t = time.Unix(int64(binary.BigEndian.Uint64(b)), 0)
+		return nil
+	})
+	return t, err
+}
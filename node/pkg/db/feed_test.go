@@ -0,0 +1,62 @@
+package db
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSubscribeSignedVAAs(t *testing.T) {
+	db, err := OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer db.Close()
+	privKey, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	require.NoError(t, err)
+
+	fastC, unsubscribeFast := db.SubscribeSignedVAAs(2)
+	defer unsubscribeFast()
+	slowC, unsubscribeSlow := db.SubscribeSignedVAAs(1)
+	defer unsubscribeSlow()
+
+	for seq := uint64(1); seq <= 2; seq++ {
+		v := getVAA()
+		v.Sequence = seq
+		v.AddSignature(privKey, 0)
+		require.NoError(t, db.StoreSignedVAA(&v))
+		expected, err := v.Marshal()
+		require.NoError(t, err)
+		assert.Equal(t, expected, <-fastC)
+	}
+
+	// The slow subscriber got the first VAA, and was dropped instead of blocking the second one.
+	_, ok := <-slowC
+	assert.True(t, ok)
+	_, ok = <-slowC
+	assert.False(t, ok)
+
+	unsubscribeFast()
+	_, ok = <-fastC
+	assert.False(t, ok)
+}
+
+func TestReplicationCheckpoint(t *testing.T) {
+	db, err := OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer db.Close()
+
+	checkpoint, err := db.GetReplicationCheckpoint()
+	require.NoError(t, err)
+	assert.True(t, checkpoint.IsZero())
+
+	now := time.Unix(time.Now().Unix(), 0)
+	require.NoError(t, db.StoreReplicationCheckpoint(now))
+	checkpoint, err = db.GetReplicationCheckpoint()
+	require.NoError(t, err)
+	assert.Equal(t, now, checkpoint)
+}
@@ -3,11 +3,11 @@ package db
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/certusone/wormhole/node/pkg/common"
-	"github.com/dgraph-io/badger/v3"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 
 	"go.uber.org/zap"
@@ -19,6 +19,18 @@ type GovernorDB interface {
 	DeleteTransfer(t *Transfer) error
 	DeletePendingMsg(k *PendingTransfer) error
 	GetChainGovernorData(logger *zap.Logger) (transfers []*Transfer, pending []*PendingTransfer, err error)
+	StoreChainLimit(l *ChainLimit) error
+	DeleteChainLimit(emitterChain vaa.ChainID) error
+	GetChainLimits() ([]*ChainLimit, error)
+	StoreChainLimitVAATime(emitterChain vaa.ChainID, timestamp time.Time) error
+	GetChainLimitVAATimes() (map[vaa.ChainID]time.Time, error)
+	StoreReleaseApproval(a *ReleaseApproval) error
+	GetReleaseApprovals(msgID string) ([]*ReleaseApproval, error)
+	DeleteReleaseApprovals(msgID string) error
+	StoreGovernorAuditEntry(e *GovernorAuditEntry) error
+	GetGovernorAuditEntries() ([]*GovernorAuditEntry, error)
+	StoreGovernorConfigVersion(version uint64) error
+	GetGovernorConfigVersion() (uint64, error)
 }
 
 type MockGovernorDB struct {
@@ -44,6 +56,54 @@ func (d *MockGovernorDB) GetChainGovernorData(logger *zap.Logger) (transfers []*
 	return nil, nil, nil
 }
 
+func (d *MockGovernorDB) StoreChainLimit(l *ChainLimit) error {
+	return nil
+}
+
+func (d *MockGovernorDB) DeleteChainLimit(emitterChain vaa.ChainID) error {
+	return nil
+}
+
+func (d *MockGovernorDB) GetChainLimits() ([]*ChainLimit, error) {
+	return nil, nil
+}
+
+func (d *MockGovernorDB) StoreChainLimitVAATime(emitterChain vaa.ChainID, timestamp time.Time) error {
+	return nil
+}
+
+func (d *MockGovernorDB) GetChainLimitVAATimes() (map[vaa.ChainID]time.Time, error) {
+	return nil, nil
+}
+
+func (d *MockGovernorDB) StoreReleaseApproval(a *ReleaseApproval) error {
+	return nil
+}
+
+func (d *MockGovernorDB) GetReleaseApprovals(msgID string) ([]*ReleaseApproval, error) {
+	return nil, nil
+}
+
+func (d *MockGovernorDB) DeleteReleaseApprovals(msgID string) error {
+	return nil
+}
+
+func (d *MockGovernorDB) StoreGovernorAuditEntry(e *GovernorAuditEntry) error {
+	return nil
+}
+
+func (d *MockGovernorDB) GetGovernorAuditEntries() ([]*GovernorAuditEntry, error) {
+	return nil, nil
+}
+
+func (d *MockGovernorDB) StoreGovernorConfigVersion(version uint64) error {
+	return nil
+}
+
+func (d *MockGovernorDB) GetGovernorConfigVersion() (uint64, error) {
+	return 0, nil
+}
+
 type Transfer struct {
 	Timestamp      time.Time
 	Value          uint64
@@ -275,6 +335,162 @@ func UnmarshalPendingTransfer(data []byte, isOld bool) (*PendingTransfer, error)
 	return p, nil
 }
 
+// ChainLimit is a limit of an emitter chain which was changed at runtime, and overrides the configured one until it is deleted.
+type ChainLimit struct {
+	EmitterChain       vaa.ChainID
+	DailyLimit         uint64
+	BigTransactionSize uint64
+}
+
+func (l *ChainLimit) Marshal() []byte {
+	buf := new(bytes.Buffer)
+	vaa.MustWrite(buf, binary.BigEndian, l.EmitterChain)
+	vaa.MustWrite(buf, binary.BigEndian, l.DailyLimit)
+	vaa.MustWrite(buf, binary.BigEndian, l.BigTransactionSize)
+	return buf.Bytes()
+}
+
+func UnmarshalChainLimit(data []byte) (*ChainLimit, error) {
+	l := &ChainLimit{}
+	reader := bytes.NewReader(data)
+	if err := binary.Read(reader, binary.BigEndian, &l.EmitterChain); err != nil {
+		return nil, fmt.Errorf("failed to read chain limit emitter chain: %w", err)
+	}
+	if err := binary.Read(reader, binary.BigEndian, &l.DailyLimit); err != nil {
+		return nil, fmt.Errorf("failed to read chain limit daily limit: %w", err)
+	}
+	if err := binary.Read(reader, binary.BigEndian, &l.BigTransactionSize); err != nil {
+		return nil, fmt.Errorf("failed to read chain limit big transaction size: %w", err)
+	}
+	return l, nil
+}
+
+const chainLimit = "GOV:LIMIT:"
+
+func chainLimitID(emitterChain vaa.ChainID) []byte {
+	return []byte(fmt.Sprintf("%v%d", chainLimit, uint16(emitterChain)))
+}
+
+const chainLimitVAATime = "GOV:LIMITVAA:"
+
+func chainLimitVAATimeID(emitterChain vaa.ChainID) []byte {
+	return []byte(fmt.Sprintf("%v%d", chainLimitVAATime, uint16(emitterChain)))
+}
+
+const governorConfigVersion = "GOV:CONFIGVERSION"
+
+// ReleaseApproval is the approval of an admin to release a pending transfer before its release time.
+type ReleaseApproval struct {
+	MsgID     string
+	Approver  string
+	Timestamp time.Time
+}
+
+func (a *ReleaseApproval) Marshal() []byte {
+	buf := new(bytes.Buffer)
+	writeLenPrefixed(buf, a.MsgID)
+	writeLenPrefixed(buf, a.Approver)
+	vaa.MustWrite(buf, binary.BigEndian, a.Timestamp.UnixNano())
+	return buf.Bytes()
+}
+
+func UnmarshalReleaseApproval(data []byte) (*ReleaseApproval, error) {
+	a := &ReleaseApproval{}
+	reader := bytes.NewReader(data)
+	var err error
+	if a.MsgID, err = readLenPrefixed(reader); err != nil {
+		return nil, fmt.Errorf("failed to read release approval msg id: %w", err)
+	}
+	if a.Approver, err = readLenPrefixed(reader); err != nil {
+		return nil, fmt.Errorf("failed to read release approval approver: %w", err)
+	}
+	var nanos int64
+	if err := binary.Read(reader, binary.BigEndian, &nanos); err != nil {
+		return nil, fmt.Errorf("failed to read release approval timestamp: %w", err)
+	}
+	a.Timestamp = time.Unix(0, nanos)
+	return a, nil
+}
+
+const releaseApproval = "GOV:APPROVAL:"
+
+// releaseApprovalPrefix is the prefix of the keys of all approvals of a message. The message ID ends in the sequence number, so
+// the separator keeps the approvals of one message apart from those of messages whose sequence number starts with the same digits.
+func releaseApprovalPrefix(msgID string) []byte {
+	return []byte(releaseApproval + msgID + "|")
+}
+
+func releaseApprovalID(a *ReleaseApproval) []byte {
+	return append(releaseApprovalPrefix(a.MsgID), []byte(a.Approver)...)
+}
+
+// GovernorAuditEntry records an action taken on the chain governor by an admin, or on their behalf.
+type GovernorAuditEntry struct {
+	Timestamp time.Time
+	Action    string
+	MsgID     string
+	Actor     string
+	Detail    string
+}
+
+func (e *GovernorAuditEntry) Marshal() []byte {
+	buf := new(bytes.Buffer)
+	vaa.MustWrite(buf, binary.BigEndian, e.Timestamp.UnixNano())
+	writeLenPrefixed(buf, e.Action)
+	writeLenPrefixed(buf, e.MsgID)
+	writeLenPrefixed(buf, e.Actor)
+	writeLenPrefixed(buf, e.Detail)
+	return buf.Bytes()
+}
+
+func UnmarshalGovernorAuditEntry(data []byte) (*GovernorAuditEntry, error) {
+	e := &GovernorAuditEntry{}
+	reader := bytes.NewReader(data)
+	var nanos int64
+	if err := binary.Read(reader, binary.BigEndian, &nanos); err != nil {
+		return nil, fmt.Errorf("failed to read audit entry timestamp: %w", err)
+	}
+	e.Timestamp = time.Unix(0, nanos)
+	var err error
+	if e.Action, err = readLenPrefixed(reader); err != nil {
+		return nil, fmt.Errorf("failed to read audit entry action: %w", err)
+	}
+	if e.MsgID, err = readLenPrefixed(reader); err != nil {
+		return nil, fmt.Errorf("failed to read audit entry msg id: %w", err)
+	}
+	if e.Actor, err = readLenPrefixed(reader); err != nil {
+		return nil, fmt.Errorf("failed to read audit entry actor: %w", err)
+	}
+	if e.Detail, err = readLenPrefixed(reader); err != nil {
+		return nil, fmt.Errorf("failed to read audit entry detail: %w", err)
+	}
+	return e, nil
+}
+
+const governorAudit = "GOV:AUDIT:"
+
+// governorAuditID orders the audit entries by time. The rest of the key keeps apart the entries made at the same time.
+func governorAuditID(e *GovernorAuditEntry) []byte {
+	return []byte(fmt.Sprintf("%v%020d:%s:%s:%s", governorAudit, e.Timestamp.UnixNano(), e.Action, e.MsgID, e.Actor))
+}
+
+func writeLenPrefixed(buf *bytes.Buffer, s string) {
+	vaa.MustWrite(buf, binary.BigEndian, uint16(len(s)))
+	buf.WriteString(s)
+}
+
+func readLenPrefixed(reader *bytes.Reader) (string, error) {
+	var l uint16
+	if err := binary.Read(reader, binary.BigEndian, &l); err != nil {
+		return "", err
+	}
+	b := make([]byte, l)
+	if n, err := reader.Read(b); l != 0 && (err != nil || n != int(l)) {
+		return "", fmt.Errorf("read %d of %d bytes: %w", n, l, err)
+	}
+	return string(b), nil
+}
+
 const oldTransfer = "GOV:XFER2:"
 const oldTransferLen = len(oldTransfer)
 
@@ -333,19 +549,8 @@ func (d *Database) GetChainGovernorData(logger *zap.Logger) (transfers []*Transf
 func (d *Database) GetChainGovernorDataForTime(logger *zap.Logger, now time.Time) (transfers []*Transfer, pending []*PendingTransfer, err error) {
 	oldTransfers := []*Transfer{}
 	oldPendingToUpdate := []*PendingTransfer{}
-	err = d.db.View(func(txn *badger.Txn) error {
-		opts := badger.DefaultIteratorOptions
-		opts.PrefetchSize = 10
-		it := txn.NewIterator(opts)
-		defer it.Close()
-		for it.Rewind(); it.Valid(); it.Next() {
-			item := it.Item()
-			key := item.Key()
-			val, err := item.ValueCopy(nil)
-			if err != nil {
-				return err
-			}
-
+	err = d.db.View(func(txn Txn) error {
+		err := txn.Iterate(nil, func(key []byte, val []byte) error {
 			if IsPendingMsg(key) {
 				p, err := UnmarshalPendingTransfer(val, false)
 				if err != nil {
@@ -378,6 +583,10 @@ func (d *Database) GetChainGovernorDataForTime(logger *zap.Logger, now time.Time
 				transfers = append(transfers, v)
 				oldTransfers = append(oldTransfers, v)
 			}
+			return nil
+		})
+		if err != nil {
+			return err
 		}
 
 		if len(oldPendingToUpdate) != 0 {
@@ -389,7 +598,7 @@ func (d *Database) GetChainGovernorDataForTime(logger *zap.Logger, now time.Time
 				}
 
 				key := oldPendingMsgID(&pending.Msg)
-				if err := d.db.Update(func(txn *badger.Txn) error {
+				if err := d.db.Update(func(txn Txn) error {
 					err := txn.Delete(key)
 					return err
 				}); err != nil {
@@ -407,7 +616,7 @@ func (d *Database) GetChainGovernorDataForTime(logger *zap.Logger, now time.Time
 				}
 
 				key := oldTransferMsgID(xfer)
-				if err := d.db.Update(func(txn *badger.Txn) error {
+				if err := d.db.Update(func(txn Txn) error {
 					err := txn.Delete(key)
 					return err
 				}); err != nil {
@@ -426,7 +635,7 @@ func (d *Database) GetChainGovernorDataForTime(logger *zap.Logger, now time.Time
 func (d *Database) StoreTransfer(t *Transfer) error {
 	b, _ := t.Marshal()
 
-	err := d.db.Update(func(txn *badger.Txn) error {
+	err := d.db.Update(func(txn Txn) error {
 		if err := txn.Set(TransferMsgID(t), b); err != nil {
 			return err
 		}
@@ -444,7 +653,7 @@ func (d *Database) StoreTransfer(t *Transfer) error {
 func (d *Database) StorePendingMsg(pending *PendingTransfer) error {
 	b, _ := pending.Marshal()
 
-	err := d.db.Update(func(txn *badger.Txn) error {
+	err := d.db.Update(func(txn Txn) error {
 		if err := txn.Set(PendingMsgID(&pending.Msg), b); err != nil {
 			return err
 		}
@@ -461,7 +670,7 @@ func (d *Database) StorePendingMsg(pending *PendingTransfer) error {
 // This is called by the chain governor to delete a transfer after the time limit has expired.
 func (d *Database) DeleteTransfer(t *Transfer) error {
 	key := TransferMsgID(t)
-	if err := d.db.Update(func(txn *badger.Txn) error {
+	if err := d.db.Update(func(txn Txn) error {
 		err := txn.Delete(key)
 		return err
 	}); err != nil {
@@ -474,7 +683,7 @@ func (d *Database) DeleteTransfer(t *Transfer) error {
 // This is called by the chain governor to delete a pending transfer.
 func (d *Database) DeletePendingMsg(pending *PendingTransfer) error {
 	key := PendingMsgID(&pending.Msg)
-	if err := d.db.Update(func(txn *badger.Txn) error {
+	if err := d.db.Update(func(txn Txn) error {
 		err := txn.Delete(key)
 		return err
 	}); err != nil {
@@ -483,3 +692,181 @@ func (d *Database) DeletePendingMsg(pending *PendingTransfer) error {
 
 	return nil
 }
+
+// This is called by the chain governor to persist a limit changed at runtime.
+func (d *Database) StoreChainLimit(l *ChainLimit) error {
+	if err := d.db.Update(func(txn Txn) error {
+		return txn.Set(chainLimitID(l.EmitterChain), l.Marshal())
+	}); err != nil {
+		return fmt.Errorf("failed to commit chain limit tx: %w", err)
+	}
+	return nil
+}
+
+// This is called by the chain governor when a chain goes back to its configured limits.
+func (d *Database) DeleteChainLimit(emitterChain vaa.ChainID) error {
+	key := chainLimitID(emitterChain)
+	if err := d.db.Update(func(txn Txn) error {
+		return txn.Delete(key)
+	}); err != nil {
+		return fmt.Errorf("failed to delete chain limit for key [%v]: %w", key, err)
+	}
+	return nil
+}
+
+// This is called by the chain governor on start up to reload the limits changed at runtime.
+func (d *Database) GetChainLimits() (limits []*ChainLimit, err error) {
+	err = d.db.View(func(txn Txn) error {
+		return txn.Iterate([]byte(chainLimit), func(_ []byte, val []byte) error {
+			l, err := UnmarshalChainLimit(val)
+			if err != nil {
+				return err
+			}
+			limits = append(limits, l)
+			return nil
+		})
+	})
+	return
+}
+
+// This is called by the chain governor when it applies a governance VAA setting the limits of a chain, so that older VAAs are
+// not applied after it.
+func (d *Database) StoreChainLimitVAATime(emitterChain vaa.ChainID, timestamp time.Time) error {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(timestamp.Unix()))
+	if err := d.db.Update(func(txn Txn) error {
+		return txn.Set(chainLimitVAATimeID(emitterChain), b)
+	}); err != nil {
+		return fmt.Errorf("failed to commit chain limit vaa time tx: %w", err)
+	}
+	return nil
+}
+
+// This is called by the chain governor on start up to reload the timestamps of the last governance VAA applied to each chain.
+func (d *Database) GetChainLimitVAATimes() (times map[vaa.ChainID]time.Time, err error) {
+	times = make(map[vaa.ChainID]time.Time)
+	err = d.db.View(func(txn Txn) error {
+		return txn.Iterate([]byte(chainLimitVAATime), func(key []byte, val []byte) error {
+			var chain uint16
+			if _, err := fmt.Sscanf(string(key[len(chainLimitVAATime):]), "%d", &chain); err != nil {
+				return fmt.Errorf("invalid chain limit vaa time key %q: %w", key, err)
+			}
+			if len(val) != 8 {
+				return fmt.Errorf("invalid chain limit vaa time for chain %d", chain)
+			}
+			times[vaa.ChainID(chain)] = time.Unix(int64(binary.BigEndian.Uint64(val)), 0)
+			return nil
+		})
+	})
+	return
+}
+
+// This is called by the chain governor to persist the version of the config file it applied, so that it never goes back to an older one.
+func (d *Database) StoreGovernorConfigVersion(version uint64) error {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, version)
+	if err := d.db.Update(func(txn Txn) error {
+		return txn.Set([]byte(governorConfigVersion), b)
+	}); err != nil {
+		return fmt.Errorf("failed to commit governor config version tx: %w", err)
+	}
+	return nil
+}
+
+// This is called by the chain governor on start up to reload the highest version of the config file it applied, or zero if it never
+// applied one.
+func (d *Database) GetGovernorConfigVersion() (version uint64, err error) {
+	err = d.db.View(func(txn Txn) error {
+		val, err := txn.Get([]byte(governorConfigVersion))
+		if errors.Is(err, ErrKeyNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if len(val) != 8 {
+			return errors.New("invalid governor config version")
+		}
+		version = binary.BigEndian.Uint64(val)
+		return nil
+	})
+	return
+}
+
+// This is called by the chain governor to persist the approval of an admin to release a pending transfer.
+func (d *Database) StoreReleaseApproval(a *ReleaseApproval) error {
+	if err := d.db.Update(func(txn Txn) error {
+		return txn.Set(releaseApprovalID(a), a.Marshal())
+	}); err != nil {
+		return fmt.Errorf("failed to commit release approval tx: %w", err)
+	}
+	return nil
+}
+
+// This is called by the chain governor to look up the approvals to release a pending transfer.
+func (d *Database) GetReleaseApprovals(msgID string) (approvals []*ReleaseApproval, err error) {
+	err = d.db.View(func(txn Txn) error {
+		return txn.Iterate(releaseApprovalPrefix(msgID), func(_ []byte, val []byte) error {
+			a, err := UnmarshalReleaseApproval(val)
+			if err != nil {
+				return err
+			}
+			approvals = append(approvals, a)
+			return nil
+		})
+	})
+	return
+}
+
+// This is called by the chain governor once a pending transfer is no longer pending.
+func (d *Database) DeleteReleaseApprovals(msgID string) error {
+	prefix := releaseApprovalPrefix(msgID)
+	if err := d.db.Update(func(txn Txn) error {
+		var keys [][]byte
+		if err := txn.Iterate(prefix, func(key []byte, _ []byte) error {
+			keys = append(keys, append([]byte(nil), key...))
+			return nil
+		}); err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if err := txn.Delete(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to delete release approvals for prefix [%v]: %w", string(prefix), err)
+	}
+	return nil
+}
+
+// This is called by the chain governor to record an admin action. Audit entries are kept forever, unless the governor_audit
+// record class has a retention.
+func (d *Database) StoreGovernorAuditEntry(e *GovernorAuditEntry) error {
+	if err := d.db.Update(func(txn Txn) error {
+		key := governorAuditID(e)
+		if err := txn.Set(key, e.Marshal()); err != nil {
+			return err
+		}
+		return setTTL(txn, RecordClassGovernorAudit, e.Timestamp, key)
+	}); err != nil {
+		return fmt.Errorf("failed to commit governor audit entry tx: %w", err)
+	}
+	return nil
+}
+
+// GetGovernorAuditEntries returns all audit entries, oldest first.
+func (d *Database) GetGovernorAuditEntries() (entries []*GovernorAuditEntry, err error) {
+	err = d.db.View(func(txn Txn) error {
+		return txn.Iterate([]byte(governorAudit), func(_ []byte, val []byte) error {
+			e, err := UnmarshalGovernorAuditEntry(val)
+			if err != nil {
+				return err
+			}
+			entries = append(entries, e)
+			return nil
+		})
+	})
+	return
+}
@@ -10,7 +10,6 @@ import (
 	"time"
 
 	"github.com/certusone/wormhole/node/pkg/common"
-	"github.com/dgraph-io/badger/v3"
 	eth_common "github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -19,7 +18,7 @@ import (
 )
 
 func (d *Database) rowExistsInDB(key []byte) error {
-	return d.db.View(func(txn *badger.Txn) error {
+	return d.db.View(func(txn Txn) error {
 		_, err := txn.Get(key)
 		return err
 	})
@@ -213,7 +212,7 @@ func TestDeleteTransfer(t *testing.T) {
 	require.NoError(t, err3)
 
 	// Make sure the xfer is no longer in the db.
-	assert.ErrorIs(t, badger.ErrKeyNotFound, db.rowExistsInDB(TransferMsgID(xfer1)))
+	assert.ErrorIs(t, ErrKeyNotFound, db.rowExistsInDB(TransferMsgID(xfer1)))
 }
 
 func TestStorePendingMsg(t *testing.T) {
@@ -278,7 +277,7 @@ func TestDeletePendingMsg(t *testing.T) {
 	assert.Nil(t, err4)
 
 	// Make sure the pending transfer is no longer in the db.
-	assert.ErrorIs(t, badger.ErrKeyNotFound, db.rowExistsInDB(PendingMsgID(msg)))
+	assert.ErrorIs(t, ErrKeyNotFound, db.rowExistsInDB(PendingMsgID(msg)))
 }
 
 func TestSerializeAndDeserializeOfPendingTransfer(t *testing.T) {
@@ -569,7 +568,7 @@ func (d *Database) storeOldPendingMsg(t *testing.T, p *PendingTransfer) {
 
 	vaa.MustWrite(buf, binary.BigEndian, b)
 
-	err := d.db.Update(func(txn *badger.Txn) error {
+	err := d.db.Update(func(txn Txn) error {
 		if err := txn.Set(oldPendingMsgID(&p.Msg), buf.Bytes()); err != nil {
 			return err
 		}
@@ -782,7 +781,7 @@ func (d *Database) storeOldTransfer(xfer *Transfer) error {
 	key := []byte(fmt.Sprintf("%v%v", oldTransfer, xfer.MsgID))
 	b := marshalOldTransfer(xfer)
 
-	return d.db.Update(func(txn *badger.Txn) error {
+	return d.db.Update(func(txn Txn) error {
 		if err := txn.Set(key, b); err != nil {
 			return err
 		}
@@ -886,7 +885,7 @@ func TestOldTransfersUpdatedWhenReloading(t *testing.T) {
 	assert.Equal(t, xfer2, xfers[1])
 
 	// Make sure the old transfer got dropped from the database and rewritten in the new format.
-	assert.ErrorIs(t, badger.ErrKeyNotFound, db.rowExistsInDB(oldTransferMsgID(xfer1)))
+	assert.ErrorIs(t, ErrKeyNotFound, db.rowExistsInDB(oldTransferMsgID(xfer1)))
 	assert.NoError(t, db.rowExistsInDB(TransferMsgID(xfer1)))
 
 	// And make sure the other transfer is still there.
@@ -907,3 +906,97 @@ func TestOldTransfersUpdatedWhenReloading(t *testing.T) {
 	assert.Equal(t, xfer1, xfers[0])
 	assert.Equal(t, xfer2, xfers[1])
 }
+
+func TestStoreAndDeleteChainLimit(t *testing.T) {
+	dbPath := t.TempDir()
+	db, err := Open(dbPath)
+	require.NoError(t, err)
+	defer db.Close()
+
+	l1 := &ChainLimit{EmitterChain: vaa.ChainIDSolana, DailyLimit: 1000000, BigTransactionSize: 100000}
+	l2 := &ChainLimit{EmitterChain: vaa.ChainID(2), DailyLimit: 500}
+	require.NoError(t, db.StoreChainLimit(l1))
+	require.NoError(t, db.StoreChainLimit(l2))
+
+	// Storing a limit again replaces it.
+	l1.DailyLimit = 2000000
+	require.NoError(t, db.StoreChainLimit(l1))
+
+	limits, err := db.GetChainLimits()
+	require.NoError(t, err)
+	sort.Slice(limits, func(i, j int) bool { return limits[i].EmitterChain < limits[j].EmitterChain })
+	assert.Equal(t, []*ChainLimit{l1, l2}, limits)
+
+	// Limits are not mistaken for transfers or pending messages.
+	transfers, pending, err := db.GetChainGovernorData(zap.NewNop())
+	require.NoError(t, err)
+	assert.Empty(t, transfers)
+	assert.Empty(t, pending)
+
+	require.NoError(t, db.DeleteChainLimit(vaa.ChainIDSolana))
+	limits, err = db.GetChainLimits()
+	require.NoError(t, err)
+	assert.Equal(t, []*ChainLimit{l2}, limits)
+}
+
+func TestStoreChainLimitVAATimes(t *testing.T) {
+	db, err := Open(t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+
+	now := time.Unix(1700000000, 0)
+	require.NoError(t, db.StoreChainLimitVAATime(vaa.ChainIDSolana, now.Add(-time.Hour)))
+	require.NoError(t, db.StoreChainLimitVAATime(vaa.ChainIDSolana, now))
+	require.NoError(t, db.StoreChainLimitVAATime(vaa.ChainID(2), now.Add(time.Hour)))
+	require.NoError(t, db.StoreChainLimit(&ChainLimit{EmitterChain: vaa.ChainIDSolana, DailyLimit: 1000}))
+
+	times, err := db.GetChainLimitVAATimes()
+	require.NoError(t, err)
+	assert.Equal(t, map[vaa.ChainID]time.Time{vaa.ChainIDSolana: now, vaa.ChainID(2): now.Add(time.Hour)}, times)
+
+	// The times are not mistaken for limits.
+	limits, err := db.GetChainLimits()
+	require.NoError(t, err)
+	assert.Len(t, limits, 1)
+}
+
+func TestStoreAndDeleteReleaseApprovals(t *testing.T) {
+	dbPath := t.TempDir()
+	db, err := Open(dbPath)
+	require.NoError(t, err)
+	defer db.Close()
+
+	now := time.Unix(1700000000, 123)
+	a1 := &ReleaseApproval{MsgID: "1/0000000000000000000000000290fb167208af455bb137780163b7b7a9a10c16/3", Approver: "alice", Timestamp: now}
+	a2 := &ReleaseApproval{MsgID: a1.MsgID, Approver: "bob", Timestamp: now.Add(time.Minute)}
+	other := &ReleaseApproval{MsgID: a1.MsgID + "1", Approver: "alice", Timestamp: now}
+	for _, a := range []*ReleaseApproval{a1, a2, other} {
+		require.NoError(t, db.StoreReleaseApproval(a))
+	}
+
+	// The approvals of a message do not include those of a message with a longer sequence number.
+	approvals, err := db.GetReleaseApprovals(a1.MsgID)
+	require.NoError(t, err)
+	assert.Equal(t, []*ReleaseApproval{a1, a2}, approvals)
+
+	require.NoError(t, db.DeleteReleaseApprovals(a1.MsgID))
+	approvals, err = db.GetReleaseApprovals(a1.MsgID)
+	require.NoError(t, err)
+	assert.Empty(t, approvals)
+	approvals, err = db.GetReleaseApprovals(other.MsgID)
+	require.NoError(t, err)
+	assert.Equal(t, []*ReleaseApproval{other}, approvals)
+
+	e1 := &GovernorAuditEntry{Timestamp: now.Add(time.Second), Action: "release", MsgID: a1.MsgID, Actor: "bob", Detail: "approved by alice, bob"}
+	e2 := &GovernorAuditEntry{Timestamp: now, Action: "approve", MsgID: a1.MsgID, Actor: "alice"}
+	require.NoError(t, db.StoreGovernorAuditEntry(e1))
+	require.NoError(t, db.StoreGovernorAuditEntry(e2))
+	entries, err := db.GetGovernorAuditEntries()
+	require.NoError(t, err)
+	assert.Equal(t, []*GovernorAuditEntry{e2, e1}, entries)
+
+	transfers, pending, err := db.GetChainGovernorData(zap.NewNop())
+	require.NoError(t, err)
+	assert.Empty(t, transfers)
+	assert.Empty(t, pending)
+}
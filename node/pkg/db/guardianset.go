@@ -0,0 +1,76 @@
+package db
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// The guardian set update index lists the guardian set update VAAs in the database, so that the guardian set history can be served
+// without reading every governance VAA. Its keys are made of the new guardian set index and the sequence of the VAA in big endian,
+// and have no value. The VAAs are looked up by sequence, since they all have the governance emitter.
+const guardianSetUpdateIndexPrefix = "gsupdate/"
+
+func guardianSetUpdateIndexKey(index uint32, sequence uint64) []byte {
+	b := make([]byte, 0, len(guardianSetUpdateIndexPrefix)+4+8)
+	b = append(b, guardianSetUpdateIndexPrefix...)
+	b = binary.BigEndian.AppendUint32(b, index)
+	return binary.BigEndian.AppendUint64(b, sequence)
+}
+
+// indexGuardianSetUpdate adds v to the guardian set update index if it is a guardian set update VAA.
+func indexGuardianSetUpdate(w Writer, v *vaa.VAA) error {
+	if v.EmitterChain != vaa.GovernanceChain || v.EmitterAddress != vaa.GovernanceEmitter {
+		return nil
+	}
+	var body vaa.BodyGuardianSetUpdate
+	if body.Deserialize(v.Payload) != nil {
+		return nil
+	}
+	return w.Set(guardianSetUpdateIndexKey(body.NewIndex, v.Sequence), nil)
+}
+
+// GetGuardianSetUpdates returns the guardian set update VAAs in the database, ordered by the index of the guardian set they
+// install. If several VAAs install the same guardian set, only the one with the lowest sequence is returned. VAAs which were
+// purged are skipped.
+func (d *Database) GetGuardianSetUpdates() (vaas [][]byte, err error) {
+	seen := make(map[uint32]bool)
+	err = d.db.View(func(txn Txn) error {
+		return txn.IterateKeys([]byte(guardianSetUpdateIndexPrefix), func(key []byte, _ int64) error {
+			if len(key) != len(guardianSetUpdateIndexPrefix)+4+8 {
+				return fmt.Errorf("invalid guardian set update index key %q", key)
+			}
+			index := binary.BigEndian.Uint32(key[len(guardianSetUpdateIndexPrefix):])
+			if seen[index] {
+				return nil
+			}
+			id := VAAID{EmitterChain: vaa.GovernanceChain, EmitterAddress: vaa.GovernanceEmitter, Sequence: binary.BigEndian.Uint64(key[len(key)-8:])}
+			b, err := txn.Get(id.Bytes())
+			if errors.Is(err, ErrKeyNotFound) {
+				return nil
+			} else if err != nil {
+				return err
+			}
+			seen[index] = true
+			vaas = append(vaas, b)
+			return nil
+		})
+	})
+	return
+}
+
+// migrateGuardianSetUpdateIndex indexes the guardian set update VAAs stored before the index existed.
+func migrateGuardianSetUpdateIndex(d *Database, w *migrationWriter) error {
+	id := VAAID{EmitterChain: vaa.GovernanceChain, EmitterAddress: vaa.GovernanceEmitter}
+	return d.db.View(func(txn Txn) error {
+		return txn.Iterate(append(id.EmitterPrefixBytes(), '/'), func(key []byte, val []byte) error {
+			v, err := vaa.Unmarshal(val)
+			if err != nil {
+				return fmt.Errorf("failed to unmarshal governance VAA %s: %w", key, err)
+			}
+			return indexGuardianSetUpdate(w, v)
+		})
+	})
+}
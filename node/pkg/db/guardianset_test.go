@@ -0,0 +1,68 @@
+package db
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+func TestGetGuardianSetUpdates(t *testing.T) {
+	db, err := OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer db.Close()
+	privKey, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	require.NoError(t, err)
+
+	keys := []ethcommon.Address{ethcommon.HexToAddress("0x01"), ethcommon.HexToAddress("0x02")}
+	store := func(sequence uint64, payload []byte) []byte {
+		v := vaa.CreateGovernanceVAA(time.Unix(1700000000, 0), 1, sequence, 0, payload)
+		v.AddSignature(privKey, 0)
+		require.NoError(t, db.StoreSignedVAA(v))
+		b, err := v.Marshal()
+		require.NoError(t, err)
+		return b
+	}
+
+	// Stored out of order, with another governance VAA and a second VAA installing guardian set 1 that comes later.
+	second := store(4, vaa.BodyGuardianSetUpdate{Keys: keys, NewIndex: 2}.Serialize())
+	store(3, vaa.BodyGuardianSetUpdate{Keys: keys[1:], NewIndex: 1}.Serialize())
+	first := store(2, vaa.BodyGuardianSetUpdate{Keys: keys[:1], NewIndex: 1}.Serialize())
+	store(1, vaa.BodyContractUpgrade{ChainID: vaa.ChainIDSolana, NewContract: vaa.Address{1}}.Serialize())
+	other := getVAA()
+	other.EmitterAddress = vaa.Address{5}
+	other.Payload = vaa.BodyGuardianSetUpdate{Keys: keys, NewIndex: 3}.Serialize()
+	other.AddSignature(privKey, 0)
+	require.NoError(t, db.StoreSignedVAA(&other))
+
+	updates, err := db.GetGuardianSetUpdates()
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{first, second}, updates)
+
+	// Databases from before the index are indexed by the migration.
+	require.NoError(t, db.db.Update(func(txn Txn) error {
+		for _, key := range [][]byte{guardianSetUpdateIndexKey(1, 2), guardianSetUpdateIndexKey(1, 3), guardianSetUpdateIndexKey(2, 4)} {
+			if err := txn.Delete(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+	updates, err = db.GetGuardianSetUpdates()
+	require.NoError(t, err)
+	assert.Empty(t, updates)
+
+	require.NoError(t, db.setSchemaVersion(2))
+	_, err = db.Migrate(zap.NewNop(), false)
+	require.NoError(t, err)
+	updates, err = db.GetGuardianSetUpdates()
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{first, second}, updates)
+}
@@ -0,0 +1,136 @@
+package db
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+var (
+	dbSize = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "wormhole_db_size_bytes",
+			Help: "Size of the database on disk, by database and part (lsm and vlog for Badger, sst for LevelDB)",
+		}, []string{"db", "part"})
+	keyspaceKeys = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "wormhole_db_keys",
+			Help: "Number of keys in the database, by database and keyspace",
+		}, []string{"db", "keyspace"})
+	keyspaceBytes = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "wormhole_db_keyspace_bytes",
+			Help: "Estimated size of the keys and values in the database, by database and keyspace",
+		}, []string{"db", "keyspace"})
+	keyspaceScanDuration = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "wormhole_db_keyspace_scan_duration_seconds",
+			Help: "Duration of the last scan of the database keyspaces, by database",
+		}, []string{"db"})
+)
+
+// DefaultMetricsInterval is how often the database metrics are refreshed unless configured otherwise.
+const DefaultMetricsInterval = 10 * time.Minute
+
+// keyspaceOther is the keyspace of the keys that match none of the known prefixes.
+const keyspaceOther = "other"
+
+type keyspace struct {
+	prefix string
+	name   string
+}
+
+// keyspaces maps the key prefixes to the name of their keyspace in the metrics.
+var keyspaces = []keyspace{
+	{signedVaaPrefix, "signed_vaas"},
+	{vaaSequenceIndexPrefix, "vaa_sequence_index"},
+	{txHashIndexPrefix, "tx_hash_index"},
+	{restoredVaaPrefix, "restored_vaas"},
+	{governorPrefix, "governor"},
+	{accountantPrefix, "accountant"},
+	{adminPrefix, "admin"},
+	{aggregationStatePrefix, "aggregation_state"},
+	{aggregatedAttestationPrefix, "aggregated_attestations"},
+	{ttlIndexPrefix, "ttl_index"},
+}
+
+func keyspaceOf(key []byte) string {
+	for _, ks := range keyspaces {
+		if strings.HasPrefix(string(key), ks.prefix) {
+			return ks.name
+		}
+	}
+	return keyspaceOther
+}
+
+// KeyspaceStats is the number of keys and the estimated size of the entries of a keyspace.
+type KeyspaceStats struct {
+	Keys  int64
+	Bytes int64
+}
+
+// KeyspaceStats scans the whole database and returns the stats of each keyspace, by keyspace name.
+func (d *Database) KeyspaceStats() (map[string]KeyspaceStats, error) {
+	stats := make(map[string]KeyspaceStats, len(keyspaces)+1)
+	err := d.db.View(func(txn Txn) error {
+		return txn.IterateKeys(nil, func(key []byte, size int64) error {
+			name := keyspaceOf(key)
+			s := stats[name]
+			s.Keys++
+			s.Bytes += size
+			stats[name] = s
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// UpdateMetrics refreshes the size and keyspace metrics of the database.
+func (d *Database) UpdateMetrics() error {
+	sizes, err := d.db.Sizes()
+	if err != nil {
+		return err
+	}
+	for part, size := range sizes {
+		dbSize.WithLabelValues(d.name, part).Set(float64(size))
+	}
+
+	start := time.Now()
+	stats, err := d.KeyspaceStats()
+	if err != nil {
+		return err
+	}
+	keyspaceScanDuration.WithLabelValues(d.name).Set(time.Since(start).Seconds())
+	// Keyspaces without keys are reported too, so that a keyspace which was emptied does not keep its last value.
+	for _, ks := range append(keyspaces, keyspace{name: keyspaceOther}) {
+		keyspaceKeys.WithLabelValues(d.name, ks.name).Set(float64(stats[ks.name].Keys))
+		keyspaceBytes.WithLabelValues(d.name, ks.name).Set(float64(stats[ks.name].Bytes))
+	}
+	return nil
+}
+
+// MetricsRunnable returns a runnable which refreshes the database metrics every interval. Each refresh reads every key of the
+// database, so the interval should not be too short for large databases.
+func (d *Database) MetricsRunnable(logger *zap.Logger, interval time.Duration) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if err := d.UpdateMetrics(); err != nil {
+				logger.Error("failed to update the database metrics", zap.Error(err))
+			}
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+		}
+	}
+}
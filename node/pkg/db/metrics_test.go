@@ -0,0 +1,52 @@
+package db
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestKeyspaceStats(t *testing.T) {
+	for _, backend := range Backends {
+		t.Run(string(backend), func(t *testing.T) {
+			db, err := OpenBackend(zap.NewNop(), backend, "")
+			require.NoError(t, err)
+			defer db.Close()
+
+			privKey, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+			require.NoError(t, err)
+			for seq := uint64(1); seq <= 3; seq++ {
+				v := getVAA()
+				v.Sequence = seq
+				v.AddSignature(privKey, 0)
+				require.NoError(t, db.StoreSignedVAA(&v))
+			}
+			require.NoError(t, db.db.Update(func(txn Txn) error {
+				if err := txn.Set([]byte("GOV:LIMIT:1"), []byte("limit")); err != nil {
+					return err
+				}
+				return txn.Set([]byte("unknown"), []byte("v"))
+			}))
+
+			stats, err := db.KeyspaceStats()
+			require.NoError(t, err)
+			assert.Equal(t, int64(3), stats["signed_vaas"].Keys)
+			assert.Equal(t, int64(3), stats["vaa_sequence_index"].Keys)
+			assert.Equal(t, int64(1), stats["governor"].Keys)
+			assert.Zero(t, stats["accountant"].Keys)
+			// The other keyspace also has the schema version.
+			assert.Equal(t, int64(2), stats[keyspaceOther].Keys)
+			assert.Greater(t, stats["signed_vaas"].Bytes, stats["governor"].Bytes)
+
+			require.NoError(t, db.UpdateMetrics())
+			assert.Equal(t, float64(3), testutil.ToFloat64(keyspaceKeys.WithLabelValues(MainDatabase, "signed_vaas")))
+			assert.Equal(t, float64(0), testutil.ToFloat64(keyspaceKeys.WithLabelValues(MainDatabase, "accountant")))
+		})
+	}
+}
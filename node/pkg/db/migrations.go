@@ -0,0 +1,208 @@
+package db
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// schemaVersionKey stores the version of the layout of the keys in the database, which is the version of the last migration
+// applied to it. A database without it is at version 0.
+const schemaVersionKey = "schema/version"
+
+// migrationBatchSize is the number of writes of a migration applied at once.
+const migrationBatchSize = 10000
+
+// ErrSchemaTooNew is returned when the database was migrated by a newer release, whose key layout this release does not know.
+var ErrSchemaTooNew = errors.New("the database schema is newer than this release supports")
+
+// A migration changes the layout of the keys in the database. Pending migrations are applied in order when the database is
+// opened. A migration must be idempotent, since one interrupted by a crash is applied again from the start.
+type migration struct {
+	version     uint32
+	description string
+	apply       func(d *Database, w *migrationWriter) error
+}
+
+// migrations are the migrations of the database schema by increasing version. New migrations are only ever appended, with the
+// next version.
+var migrations = []migration{
+	{1, "build the VAA sequence index", migrateVAASequenceIndex},
+	{2, "build the TTL index of transient records", migrateTTLIndex},
+	{3, "build the guardian set update index", migrateGuardianSetUpdateIndex},
+}
+
+// LatestSchemaVersion returns the schema version of a database with every migration applied.
+func LatestSchemaVersion() uint32 {
+	return migrations[len(migrations)-1].version
+}
+
+// MigrationResult describes a migration applied to the database, or which would be applied in a dry run.
+type MigrationResult struct {
+	Version     uint32
+	Description string
+	// Writes is the number of keys the migration set or deleted.
+	Writes int
+}
+
+// SchemaVersion returns the version of the last migration applied to the database.
+func (d *Database) SchemaVersion() (uint32, error) {
+	var version uint32
+	err := d.db.View(func(txn Txn) error {
+		b, err := txn.Get([]byte(schemaVersionKey))
+		if errors.Is(err, ErrKeyNotFound) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if len(b) != 4 {
+			return errors.New("invalid schema version")
+		}
+		version = binary.BigEndian.Uint32(b)
+		return nil
+	})
+	return version, err
+}
+
+func (d *Database) setSchemaVersion(version uint32) error {
+	return d.db.Update(func(txn Txn) error {
+		return txn.Set([]byte(schemaVersionKey), binary.BigEndian.AppendUint32(nil, version))
+	})
+}
+
+// Migrate applies the pending migrations in order and returns the ones it applied. A migration which fails is rolled back, and
+// the migrations after it are not applied.
+//
+// In a dry run nothing is written. Each pending migration runs against the database as it is and reports how many keys it would
+// write, so migrations which depend on an earlier pending one may report fewer writes than they end up making.
+func (d *Database) Migrate(logger *zap.Logger, dryRun bool) ([]MigrationResult, error) {
+	current, err := d.SchemaVersion()
+	if err != nil {
+		return nil, err
+	}
+	if current > LatestSchemaVersion() {
+		return nil, fmt.Errorf("%w: the database is at version %d, the latest known version is %d", ErrSchemaTooNew, current, LatestSchemaVersion())
+	}
+
+	var results []MigrationResult
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+		w := &migrationWriter{store: d.db, dryRun: dryRun}
+		err := m.apply(d, w)
+		if err == nil {
+			err = w.flush()
+		}
+		if err != nil {
+			if rerr := w.rollback(); rerr != nil {
+				return results, fmt.Errorf("migration %d (%s) failed: %w, and rolling it back failed, leaving it partially applied: %v", m.version, m.description, err, rerr)
+			}
+			return results, fmt.Errorf("migration %d (%s) failed and was rolled back: %w", m.version, m.description, err)
+		}
+		if !dryRun {
+			if err := d.setSchemaVersion(m.version); err != nil {
+				return results, fmt.Errorf("failed to record migration %d: %w", m.version, err)
+			}
+			logger.Info("migrated the database", zap.Uint32("version", m.version), zap.String("migration", m.description), zap.Int("writes", w.writes))
+		}
+		results = append(results, MigrationResult{Version: m.version, Description: m.description, Writes: w.writes})
+	}
+	return results, nil
+}
+
+// migrationWrite is a write made by a migration.
+type migrationWrite struct {
+	key   []byte
+	value []byte
+	// delete is set for deletes. In the undo log it is set for keys which did not exist before the migration wrote them.
+	delete bool
+}
+
+// migrationWriter applies the writes of a migration in batches, since a migration may write more keys than fit in one
+// transaction. It keeps the previous value of every key it writes, to roll the migration back if it fails.
+type migrationWriter struct {
+	store   Store
+	dryRun  bool
+	pending []migrationWrite
+	undo    []migrationWrite
+	writes  int
+}
+
+func (w *migrationWriter) Set(key []byte, value []byte) error {
+	return w.add(migrationWrite{key: bytes.Clone(key), value: bytes.Clone(value)})
+}
+
+func (w *migrationWriter) Delete(key []byte) error {
+	return w.add(migrationWrite{key: bytes.Clone(key), delete: true})
+}
+
+func (w *migrationWriter) add(write migrationWrite) error {
+	w.pending = append(w.pending, write)
+	if len(w.pending) == migrationBatchSize {
+		return w.flush()
+	}
+	return nil
+}
+
+// flush applies the pending writes, after recording the previous values of their keys in the undo log.
+func (w *migrationWriter) flush() error {
+	pending := w.pending
+	w.pending = nil
+	w.writes += len(pending)
+	if w.dryRun || len(pending) == 0 {
+		return nil
+	}
+
+	if err := w.store.View(func(txn Txn) error {
+		for _, p := range pending {
+			prev, err := txn.Get(p.key)
+			if errors.Is(err, ErrKeyNotFound) {
+				w.undo = append(w.undo, migrationWrite{key: p.key, delete: true})
+			} else if err != nil {
+				return err
+			} else {
+				w.undo = append(w.undo, migrationWrite{key: p.key, value: prev})
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	return w.store.Batch(func(b Writer) error {
+		return applyMigrationWrites(b, pending)
+	})
+}
+
+// rollback restores the keys written by the migration to their previous values. The undo log is applied in reverse, so that a
+// key written more than once ends up with the value it had before the first write.
+func (w *migrationWriter) rollback() error {
+	if len(w.undo) == 0 {
+		return nil
+	}
+	undo := make([]migrationWrite, 0, len(w.undo))
+	for i := len(w.undo) - 1; i >= 0; i-- {
+		undo = append(undo, w.undo[i])
+	}
+	return w.store.Batch(func(b Writer) error {
+		return applyMigrationWrites(b, undo)
+	})
+}
+
+func applyMigrationWrites(b Writer, writes []migrationWrite) error {
+	for _, write := range writes {
+		var err error
+		if write.delete {
+			err = b.Delete(write.key)
+		} else {
+			err = b.Set(write.key, write.value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
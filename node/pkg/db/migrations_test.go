@@ -0,0 +1,98 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// withTestMigrations replaces the migrations for the duration of a test.
+func withTestMigrations(t *testing.T, m []migration) {
+	t.Helper()
+	saved := migrations
+	migrations = m
+	t.Cleanup(func() { migrations = saved })
+}
+
+func TestMigrate(t *testing.T) {
+	for _, backend := range Backends {
+		t.Run(string(backend), func(t *testing.T) {
+			db, err := OpenBackend(zap.NewNop(), backend, "")
+			require.NoError(t, err)
+			defer db.Close()
+			version, err := db.SchemaVersion()
+			require.NoError(t, err)
+			assert.Equal(t, LatestSchemaVersion(), version)
+
+			require.NoError(t, db.db.Update(func(txn Txn) error {
+				if err := txn.Set([]byte("test/a"), []byte("old")); err != nil {
+					return err
+				}
+				return txn.Set([]byte("test/b"), []byte("b"))
+			}))
+			failed := errors.New("failed")
+			base := LatestSchemaVersion()
+			withTestMigrations(t, append(append([]migration(nil), migrations...),
+				migration{base + 1, "rewrite a", func(d *Database, w *migrationWriter) error {
+					return w.Set([]byte("test/a"), []byte("new"))
+				}},
+				migration{base + 2, "write many keys and fail", func(d *Database, w *migrationWriter) error {
+					// More writes than fit in a batch, so some of them are applied before the failure.
+					for i := 0; i < migrationBatchSize+1; i++ {
+						if err := w.Set([]byte(fmt.Sprintf("test/c/%d", i)), nil); err != nil {
+							return err
+						}
+					}
+					if err := w.Set([]byte("test/a"), []byte("newer")); err != nil {
+						return err
+					}
+					if err := w.Delete([]byte("test/b")); err != nil {
+						return err
+					}
+					return failed
+				}},
+			))
+
+			// A dry run reports the pending migrations without applying them.
+			results, err := db.Migrate(zap.NewNop(), true)
+			assert.ErrorIs(t, err, failed)
+			assert.Equal(t, []MigrationResult{{Version: base + 1, Description: "rewrite a", Writes: 1}}, results)
+			version, err = db.SchemaVersion()
+			require.NoError(t, err)
+			assert.Equal(t, base, version)
+			assertTestKey(t, db, "test/a", "old")
+
+			// The failed migration is rolled back, and the one before it stays applied.
+			results, err = db.Migrate(zap.NewNop(), false)
+			assert.ErrorIs(t, err, failed)
+			assert.Len(t, results, 1)
+			version, err = db.SchemaVersion()
+			require.NoError(t, err)
+			assert.Equal(t, base+1, version)
+			assertTestKey(t, db, "test/a", "new")
+			assertTestKey(t, db, "test/b", "b")
+			stats, err := db.KeyspaceStats()
+			require.NoError(t, err)
+			assert.Equal(t, int64(3), stats[keyspaceOther].Keys, "only test/a, test/b and the schema version are left")
+
+			// A database migrated by a newer release is refused.
+			require.NoError(t, db.setSchemaVersion(base+3))
+			_, err = db.Migrate(zap.NewNop(), false)
+			assert.ErrorIs(t, err, ErrSchemaTooNew)
+		})
+	}
+}
+
+func assertTestKey(t *testing.T, db *Database, key string, expected string) {
+	t.Helper()
+	require.NoError(t, db.db.View(func(txn Txn) error {
+		val, err := txn.Get([]byte(key))
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(val))
+		return nil
+	}))
+}
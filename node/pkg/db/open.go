@@ -6,6 +6,8 @@ import (
 	"path"
 
 	"github.com/dgraph-io/badger/v3"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/storage"
 	"go.uber.org/zap"
 )
 
@@ -29,28 +31,128 @@ func (l badgerZapLogger) Debugf(f string, v ...interface{}) {
 	l.Debug(fmt.Sprintf(f, v...))
 }
 
-func OpenDb(logger *zap.Logger, dataDir *string) *Database {
-	var options badger.Options
+// Options configures how a database is opened.
+type Options struct {
+	Backend Backend
+	// Badger tunes the Badger backend. It is ignored by the other backends.
+	Badger BadgerOptions
+	// Name is the name of the database, MainDatabase if empty. Databases other than the main database are stored in their own
+	// directory, see OpenDb.
+	Name string
+	// SkipMigrations opens the database without applying the pending schema migrations, see Database.Migrate. The database must
+	// then be migrated before it is used.
+	SkipMigrations bool
+}
+
+// DefaultOptions stores the database in Badger with the default settings.
+func DefaultOptions() Options {
+	return Options{Backend: BackendBadger, Badger: DefaultBadgerOptions()}
+}
+
+func (o Options) name() string {
+	if o.Name == "" {
+		return MainDatabase
+	}
+	return o.Name
+}
+
+// dirName is the name of the directory the database is stored in, under the data directory.
+func (o Options) dirName() string {
+	if o.name() == MainDatabase {
+		return o.Backend.dirName()
+	}
+	return o.Backend.dirName() + "-" + o.Name
+}
+
+// Exists returns whether the database has been created under dataDir.
+func Exists(dataDir string, opts Options) bool {
+	_, err := os.Stat(path.Join(dataDir, opts.dirName()))
+	return err == nil
+}
+
+// Validate returns an error if the options are invalid.
+func (o Options) Validate() error {
+	if _, err := ParseBackend(string(o.Backend)); err != nil {
+		return err
+	}
+	if o.Backend == BackendBadger {
+		return o.Badger.Validate()
+	}
+	return nil
+}
 
+// OpenDb opens the database under dataDir, or an in-memory database if dataDir is nil. Any failure is fatal.
+func OpenDb(logger *zap.Logger, dataDir *string, opts Options) *Database {
+	dir := ""
 	if dataDir != nil {
-		dbPath := path.Join(*dataDir, "db")
-		if err := os.MkdirAll(dbPath, 0700); err != nil {
+		dir = path.Join(*dataDir, opts.dirName())
+		if err := os.MkdirAll(dir, 0700); err != nil {
 			logger.Fatal("failed to create database directory", zap.Error(err))
 		}
+	}
 
-		options = badger.DefaultOptions(dbPath)
-	} else {
-		options = badger.DefaultOptions("").WithInMemory(true)
+	db, err := OpenWithOptions(logger, dir, opts)
+	if err != nil {
+		logger.Fatal("failed to open database", zap.Error(err), zap.String("backend", string(opts.Backend)))
 	}
+	return db
+}
 
-	options = options.WithLogger(badgerZapLogger{logger})
+// OpenInMemory opens a database that is never written to disk. Everything stored in it is lost when it is closed, which makes it
+// suitable for tests and for ephemeral nodes that can rebuild their state from the network.
+func OpenInMemory(logger *zap.Logger) (*Database, error) {
+	return OpenBackend(logger, BackendBadger, "")
+}
 
-	db, err := badger.Open(options)
-	if err != nil {
-		logger.Fatal("failed to open database", zap.Error(err))
+// OpenBackend opens the database stored in dir with the given backend and its default settings, or an in-memory database if dir
+// is empty.
+func OpenBackend(logger *zap.Logger, backend Backend, dir string) (*Database, error) {
+	opts := DefaultOptions()
+	opts.Backend = backend
+	return OpenWithOptions(logger, dir, opts)
+}
+
+// OpenWithOptions opens the database stored in dir, or an in-memory database if dir is empty.
+func OpenWithOptions(logger *zap.Logger, dir string, opts Options) (*Database, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
 	}
 
-	return &Database{
-		db: db,
+	var store Store
+	switch opts.Backend {
+	case BackendBadger:
+		options := badger.DefaultOptions(dir).WithLogger(badgerZapLogger{logger}).WithNumCompactors(opts.Badger.NumCompactors)
+		if dir == "" {
+			options = options.WithInMemory(true)
+		}
+		db, err := badger.Open(options)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open badger database: %w", err)
+		}
+		store = &badgerStore{db: db, opts: opts.Badger, logger: logger.With(zap.String("component", "dbgc")), name: opts.name()}
+	case BackendLevelDB:
+		var db *leveldb.DB
+		var err error
+		if dir == "" {
+			db, err = leveldb.Open(storage.NewMemStorage(), nil)
+		} else {
+			db, err = leveldb.OpenFile(dir, nil)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to open leveldb database: %w", err)
+		}
+		store = &levelDBStore{db: db}
+	default:
+		return nil, fmt.Errorf(`unknown database backend "%s"`, opts.Backend)
+	}
+
+	d := NewDatabase(store)
+	d.name = opts.name()
+	if !opts.SkipMigrations {
+		if _, err := d.Migrate(logger, false); err != nil {
+			d.Close()
+			return nil, fmt.Errorf("failed to migrate database: %w", err)
+		}
 	}
+	return d, nil
 }
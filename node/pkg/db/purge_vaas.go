@@ -1,10 +1,11 @@
 package db
 
 import (
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"time"
 
-	"github.com/dgraph-io/badger/v3"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 )
 
@@ -20,41 +21,31 @@ func (d *Database) PurgeVaas(prefix VAAID, oldestTime time.Time, logOnly bool) (
 	numDeleted := 0
 	numKept := 0
 
-	if err := d.db.View(func(txn *badger.Txn) error {
-		it := txn.NewIterator(badger.DefaultIteratorOptions)
-		defer it.Close()
-		prefix := prefix.EmitterPrefixBytes()
-		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
-			item := it.Item()
-			key := item.Key()
-			err := item.Value(func(val []byte) error {
-				v, err := vaa.Unmarshal(val)
-				if err != nil {
-					return fmt.Errorf("failed to unmarshal VAA for %s: %v", string(key), err)
-				}
+	if err := d.db.View(func(txn Txn) error {
+		return txn.Iterate(prefix.EmitterPrefixBytes(), func(key []byte, val []byte) error {
+			v, err := vaa.Unmarshal(val)
+			if err != nil {
+				return fmt.Errorf("failed to unmarshal VAA for %s: %v", string(key), err)
+			}
 
-				if v.Timestamp.Before(oldestTime) {
-					numDeleted++
-					if !logOnly {
-						if err := d.db.Update(func(txn *badger.Txn) error {
-							err := txn.Delete(key)
+			if v.Timestamp.Before(oldestTime) {
+				numDeleted++
+				if !logOnly {
+					if err := d.db.Update(func(txn Txn) error {
+						if err := txn.Delete(key); err != nil {
 							return err
-						}); err != nil {
-							return fmt.Errorf("failed to delete vaa for key [%v]: %w", key, err)
 						}
+						return txn.Delete(vaaSequenceKey(VaaIDFromVAA(v)))
+					}); err != nil {
+						return fmt.Errorf("failed to delete vaa for key [%v]: %w", key, err)
 					}
-				} else {
-					numKept++
 				}
-
-				return nil
-			})
-			if err != nil {
-				return err
+			} else {
+				numKept++
 			}
-		}
 
-		return nil
+			return nil
+		})
 	}); err != nil {
 		return "", err
 	}
@@ -78,3 +69,110 @@ func (d *Database) PurgeVaas(prefix VAAID, oldestTime time.Time, logOnly bool) (
 
 	return ret, nil
 }
+
+// purgeVAARangeBatchSize is the number of VAAs deleted at once by PurgeVAARange.
+const purgeVAARangeBatchSize = 10000
+
+// VAAPurgeQuery selects the signed VAAs of an emitter to purge by sequence.
+type VAAPurgeQuery struct {
+	EmitterChain   vaa.ChainID
+	EmitterAddress vaa.Address
+	// FromSequence is the first sequence of the range.
+	FromSequence uint64
+	// ToSequence is the last sequence of the range, inclusive. Zero means there is no upper bound.
+	ToSequence uint64
+}
+
+// VAAPurgeResult is the outcome of PurgeVAARange.
+type VAAPurgeResult struct {
+	// Purged is the number of VAAs deleted, or that would have been deleted in a dry run.
+	Purged int
+	// FirstSequence and LastSequence are the lowest and highest sequences purged. Both are zero if nothing was purged.
+	FirstSequence uint64
+	LastSequence  uint64
+}
+
+// PurgeVAARange deletes the signed VAAs of an emitter in a range of sequences, with their sequence index entries, restore markers
+// and aggregated attestations. The VAAs are found through the sequence index. If dryRun is set, nothing is deleted and the result
+// is what would have been.
+func (d *Database) PurgeVAARange(q VAAPurgeQuery, dryRun bool) (*VAAPurgeResult, error) {
+	if q.ToSequence != 0 && q.FromSequence > q.ToSequence {
+		return nil, fmt.Errorf("the first sequence %d is after the last sequence %d", q.FromSequence, q.ToSequence)
+	}
+
+	result := &VAAPurgeResult{}
+	prefix := vaaSequenceEmitterPrefix(q.EmitterChain, q.EmitterAddress)
+	from := q.FromSequence
+	for {
+		ids, timestamps, err := d.scanVAARange(prefix, q, from)
+		if err != nil {
+			return result, err
+		}
+		if len(ids) == 0 {
+			return result, nil
+		}
+
+		if !dryRun {
+			if err := d.db.Batch(func(w Writer) error {
+				for i := range ids {
+					id := &ids[i]
+					for _, key := range [][]byte{id.Bytes(), vaaSequenceKey(id), restoredVaaKey(id), aggregatedAttestationKey(id)} {
+						if err := w.Delete(key); err != nil {
+							return err
+						}
+					}
+					if err := deleteTTL(w, RecordClassAggregatedAttestation, timestamps[i], aggregatedAttestationKey(id)); err != nil {
+						return err
+					}
+				}
+				return nil
+			}); err != nil {
+				return result, fmt.Errorf("failed to purge VAAs: %w", err)
+			}
+		}
+
+		if result.Purged == 0 {
+			result.FirstSequence = ids[0].Sequence
+		}
+		result.Purged += len(ids)
+		result.LastSequence = ids[len(ids)-1].Sequence
+		if result.LastSequence == ^uint64(0) {
+			return result, nil
+		}
+		from = result.LastSequence + 1
+	}
+}
+
+// scanVAARange returns the IDs of up to purgeVAARangeBatchSize VAAs of the sequence range of q starting at from, and their
+// timestamps.
+func (d *Database) scanVAARange(prefix []byte, q VAAPurgeQuery, from uint64) ([]VAAID, []time.Time, error) {
+	var ids []VAAID
+	var timestamps []time.Time
+	err := d.db.View(func(txn Txn) error {
+		start := binary.BigEndian.AppendUint64(append([]byte(nil), prefix...), from)
+		return txn.IterateFrom(prefix, start, func(key []byte, _ []byte) error {
+			id := VAAID{EmitterChain: q.EmitterChain, EmitterAddress: q.EmitterAddress, Sequence: binary.BigEndian.Uint64(key[len(key)-8:])}
+			if (q.ToSequence != 0 && id.Sequence > q.ToSequence) || len(ids) == purgeVAARangeBatchSize {
+				return errStopIteration
+			}
+			b, err := txn.Get(id.Bytes())
+			if err != nil && !errors.Is(err, ErrKeyNotFound) {
+				return err
+			}
+			// A dangling index entry is purged as well, its VAA is gone already.
+			var timestamp time.Time
+			if err == nil {
+				if timestamp, err = vaaTimestamp(b); err != nil {
+					return fmt.Errorf("failed to read timestamp of VAA %s: %w", id.Bytes(), err)
+				}
+			}
+			ids = append(ids, id)
+			timestamps = append(timestamps, timestamp)
+			return nil
+		})
+	})
+	if err != nil && !errors.Is(err, errStopIteration) {
+		return nil, nil, err
+	}
+	return ids, timestamps, nil
+}
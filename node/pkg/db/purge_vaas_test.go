@@ -12,9 +12,9 @@ import (
 	"testing"
 	"time"
 
-	"github.com/dgraph-io/badger/v3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 )
 
 func storeVAA(db *Database, v *vaa.VAA) error {
@@ -24,33 +24,21 @@ func storeVAA(db *Database, v *vaa.VAA) error {
 }
 
 func countVAAs(d *Database, chainId vaa.ChainID) (numThisChain int, numOtherChains int, err error) { //nolint:unparam
-	if err = d.db.View(func(txn *badger.Txn) error {
-		opts := badger.DefaultIteratorOptions
-		opts.PrefetchSize = 10
-		it := txn.NewIterator(opts)
-		defer it.Close()
-		for it.Rewind(); it.Valid(); it.Next() {
-			item := it.Item()
-			key := item.Key()
-			err := item.Value(func(val []byte) error {
-				v, err := vaa.Unmarshal(val)
-				if err != nil {
-					return fmt.Errorf("failed to unmarshal VAA for %s: %v", string(key), err)
-				}
-
-				if v.EmitterChain == chainId {
-					numThisChain++
-				} else {
-					numOtherChains++
-				}
-
-				return nil
-			})
+	if err = d.db.View(func(txn Txn) error {
+		return txn.Iterate([]byte(signedVaaPrefix), func(key []byte, val []byte) error {
+			v, err := vaa.Unmarshal(val)
 			if err != nil {
-				return err
+				return fmt.Errorf("failed to unmarshal VAA for %s: %v", string(key), err)
 			}
-		}
-		return nil
+
+			if v.EmitterChain == chainId {
+				numThisChain++
+			} else {
+				numOtherChains++
+			}
+
+			return nil
+		})
 	}); err != nil {
 		return
 	}
@@ -229,3 +217,57 @@ func TestPurgingVAAsForOneEmitterAddress(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, 200, numSolana)
 }
+
+func TestPurgeVAARange(t *testing.T) {
+	db, err := OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer db.Close()
+
+	emitter := getVAA().EmitterAddress
+	for _, seq := range []uint64{1, 2, 3, 10, 11, 100} {
+		v := getVAA()
+		v.Sequence = seq
+		require.NoError(t, storeVAA(db, &v))
+		require.NoError(t, db.StoreAggregatedAttestation(*VaaIDFromVAA(&v), v.Timestamp, []byte{1}))
+	}
+	other := getVAA()
+	other.EmitterAddress = vaa.Address{1}
+	other.Sequence = 2
+	require.NoError(t, storeVAA(db, &other))
+
+	q := VAAPurgeQuery{EmitterChain: vaa.ChainIDSolana, EmitterAddress: emitter, FromSequence: 2, ToSequence: 11}
+	result, err := db.PurgeVAARange(q, true)
+	require.NoError(t, err)
+	assert.Equal(t, &VAAPurgeResult{Purged: 4, FirstSequence: 2, LastSequence: 11}, result)
+	page, err := db.GetSignedVAARange(SignedVAARangeQuery{EmitterChain: vaa.ChainIDSolana, EmitterAddress: emitter})
+	require.NoError(t, err)
+	assert.Len(t, page.VAAs, 6)
+
+	result, err = db.PurgeVAARange(q, false)
+	require.NoError(t, err)
+	assert.Equal(t, &VAAPurgeResult{Purged: 4, FirstSequence: 2, LastSequence: 11}, result)
+	page, err = db.GetSignedVAARange(SignedVAARangeQuery{EmitterChain: vaa.ChainIDSolana, EmitterAddress: emitter})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 100}, rangeSequences(t, page))
+	_, err = db.GetAggregatedAttestation(VAAID{EmitterChain: vaa.ChainIDSolana, EmitterAddress: emitter, Sequence: 2})
+	assert.ErrorIs(t, err, ErrAttestationNotFound)
+	has, err := db.HasVAA(*VaaIDFromVAA(&other))
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	// Nothing is left dangling.
+	report, err := db.Verify(VerifyOptions{}, func([]byte, VerifyProblem, string) {})
+	require.NoError(t, err)
+	assert.Empty(t, report.Problems)
+
+	// Without an upper bound, the rest of the emitter is purged.
+	result, err = db.PurgeVAARange(VAAPurgeQuery{EmitterChain: vaa.ChainIDSolana, EmitterAddress: emitter}, false)
+	require.NoError(t, err)
+	assert.Equal(t, &VAAPurgeResult{Purged: 2, FirstSequence: 1, LastSequence: 100}, result)
+	result, err = db.PurgeVAARange(VAAPurgeQuery{EmitterChain: vaa.ChainIDSolana, EmitterAddress: emitter}, false)
+	require.NoError(t, err)
+	assert.Zero(t, result.Purged)
+
+	_, err = db.PurgeVAARange(VAAPurgeQuery{EmitterChain: vaa.ChainIDSolana, EmitterAddress: emitter, FromSequence: 3, ToSequence: 2}, false)
+	assert.Error(t, err)
+}
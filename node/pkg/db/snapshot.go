@@ -0,0 +1,305 @@
+package db
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// A snapshot is a gzip compressed stream of signed VAAs used to move them between nodes and to back them up. The uncompressed
+// stream is the magic and version, then every VAA preceded by its length as a big endian uint32, then a zero length, the number
+// of VAAs as a big endian uint64, and the SHA-256 of everything before it. The trailer lets imports detect truncated snapshots.
+var snapshotMagic = []byte("WHVAASNAP")
+
+const snapshotVersion = 1
+
+// snapshotImportBatchSize is the number of VAAs written to the database at once by ImportVAASnapshot.
+const snapshotImportBatchSize = 1000
+
+var (
+	ErrInvalidSnapshot  = errors.New("not a VAA snapshot")
+	ErrSnapshotChecksum = errors.New("snapshot checksum mismatch")
+)
+
+// VAAFilter selects signed VAAs, for snapshots and replication. The zero value selects all of them.
+type VAAFilter struct {
+	// EmitterChain restricts the selection to VAAs emitted on this chain, unless it is zero.
+	EmitterChain vaa.ChainID
+	// EmitterAddress restricts the selection to VAAs from this emitter, unless it is zero. It requires EmitterChain.
+	EmitterAddress vaa.Address
+	// From and To restrict the selection to VAAs with a timestamp in [From, To), unless they are zero.
+	From time.Time
+	To   time.Time
+}
+
+func (f *VAAFilter) prefix() ([]byte, error) {
+	if f.EmitterChain == 0 {
+		if f.EmitterAddress != nullAddr {
+			return nil, errors.New("filtering by emitter address requires an emitter chain")
+		}
+		return []byte(signedVaaPrefix), nil
+	}
+	// The trailing separator stops a prefix for chain 1 from matching chain 10.
+	id := VAAID{EmitterChain: f.EmitterChain, EmitterAddress: f.EmitterAddress}
+	return append(id.EmitterPrefixBytes(), '/'), nil
+}
+
+func (f *VAAFilter) matches(timestamp time.Time) bool {
+	if !f.From.IsZero() && timestamp.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !timestamp.Before(f.To) {
+		return false
+	}
+	return true
+}
+
+// ForEachSignedVAA calls fn with every signed VAA selected by filter, in key order, from a single consistent view of the
+// database. The slice passed to fn is only valid until fn returns.
+func (d *Database) ForEachSignedVAA(filter VAAFilter, fn func(b []byte) error) error {
+	prefix, err := filter.prefix()
+	if err != nil {
+		return err
+	}
+	return d.db.View(func(txn Txn) error {
+		return txn.Iterate(prefix, func(key []byte, val []byte) error {
+			if !filter.From.IsZero() || !filter.To.IsZero() {
+				timestamp, err := vaaTimestamp(val)
+				if err != nil {
+					return fmt.Errorf("failed to read timestamp of VAA %s: %w", key, err)
+				}
+				if !filter.matches(timestamp) {
+					return nil
+				}
+			}
+			return fn(val)
+		})
+	})
+}
+
+// SnapshotImportResult counts the VAAs read from a snapshot.
+type SnapshotImportResult struct {
+	Imported int
+	// Existing is the number of VAAs that were already in the database and left untouched.
+	Existing int
+}
+
+// ExportVAASnapshot writes the signed VAAs selected by filter to w as a snapshot, and returns how many were written. The VAAs are
+// read from a single consistent view of the database.
+func (d *Database) ExportVAASnapshot(w io.Writer, filter VAAFilter) (int, error) {
+	if _, err := filter.prefix(); err != nil {
+		return 0, err
+	}
+
+	zw := gzip.NewWriter(w)
+	sum := sha256.New()
+	out := io.MultiWriter(zw, sum)
+	if _, err := out.Write(append(append([]byte(nil), snapshotMagic...), snapshotVersion)); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	var length [4]byte
+	err := d.ForEachSignedVAA(filter, func(b []byte) error {
+		binary.BigEndian.PutUint32(length[:], uint32(len(b)))
+		if _, err := out.Write(length[:]); err != nil {
+			return err
+		}
+		if _, err := out.Write(b); err != nil {
+			return err
+		}
+		count++
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var trailer [12]byte
+	binary.BigEndian.PutUint64(trailer[4:], uint64(count))
+	if _, err := out.Write(trailer[:]); err != nil {
+		return 0, err
+	}
+	if _, err := zw.Write(sum.Sum(nil)); err != nil {
+		return 0, err
+	}
+	if err := zw.Close(); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// snapshotReader reads the VAAs in a snapshot while hashing them.
+type snapshotReader struct {
+	r   io.Reader
+	sum hash.Hash
+}
+
+func newSnapshotReader(r io.Reader) (*snapshotReader, *gzip.Reader, error) {
+	zr, err := gzip.NewReader(bufio.NewReader(r))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidSnapshot, err)
+	}
+	sr := &snapshotReader{sum: sha256.New()}
+	sr.r = io.TeeReader(zr, sr.sum)
+
+	header := make([]byte, len(snapshotMagic)+1)
+	if _, err := io.ReadFull(sr.r, header); err != nil || !bytes.Equal(header[:len(snapshotMagic)], snapshotMagic) {
+		zr.Close()
+		return nil, nil, ErrInvalidSnapshot
+	}
+	if header[len(snapshotMagic)] != snapshotVersion {
+		zr.Close()
+		return nil, nil, fmt.Errorf("unsupported snapshot version %d", header[len(snapshotMagic)])
+	}
+	return sr, zr, nil
+}
+
+// readSnapshot calls fn with every VAA in a snapshot, and returns an error if the snapshot is truncated or corrupted. Since the
+// checksum is at the end, fn may already have been called with VAAs from a corrupted snapshot; use VerifyVAASnapshot first to
+// avoid that.
+func readSnapshot(r io.Reader, fn func(b []byte) error) (int, error) {
+	sr, zr, err := newSnapshotReader(r)
+	if err != nil {
+		return 0, err
+	}
+	defer zr.Close()
+
+	count := 0
+	var length [4]byte
+	for {
+		if _, err := io.ReadFull(sr.r, length[:]); err != nil {
+			return count, fmt.Errorf("truncated snapshot: %w", err)
+		}
+		n := binary.BigEndian.Uint32(length[:])
+		if n == 0 {
+			break
+		}
+		b := make([]byte, n)
+		if _, err := io.ReadFull(sr.r, b); err != nil {
+			return count, fmt.Errorf("truncated snapshot: %w", err)
+		}
+		if err := fn(b); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	var total [8]byte
+	if _, err := io.ReadFull(sr.r, total[:]); err != nil {
+		return count, fmt.Errorf("truncated snapshot: %w", err)
+	}
+	expected := sr.sum.Sum(nil)
+	checksum := make([]byte, sha256.Size)
+	if _, err := io.ReadFull(zr, checksum); err != nil {
+		return count, fmt.Errorf("truncated snapshot: %w", err)
+	}
+	if !bytes.Equal(checksum, expected) {
+		return count, ErrSnapshotChecksum
+	}
+	// Reading to the end also makes gzip check its own checksum.
+	if n, err := io.Copy(io.Discard, zr); err != nil {
+		return count, fmt.Errorf("corrupted snapshot: %w", err)
+	} else if n != 0 {
+		return count, fmt.Errorf("%w: unexpected data after the checksum", ErrInvalidSnapshot)
+	}
+	if binary.BigEndian.Uint64(total[:]) != uint64(count) {
+		return count, fmt.Errorf("snapshot claims %d VAAs but contains %d", binary.BigEndian.Uint64(total[:]), count)
+	}
+	return count, nil
+}
+
+// VerifyVAASnapshot reads a snapshot without importing it, and returns the number of VAAs in it or an error if it is truncated,
+// corrupted or contains an invalid VAA.
+func VerifyVAASnapshot(r io.Reader) (int, error) {
+	return readSnapshot(r, func(b []byte) error {
+		if _, err := vaa.Unmarshal(b); err != nil {
+			return fmt.Errorf("invalid VAA in snapshot: %w", err)
+		}
+		return nil
+	})
+}
+
+// ImportVAASnapshot stores the VAAs in a snapshot. VAAs that are already in the database are left untouched. The VAAs are written
+// in batches as they are read, so a snapshot which turns out to be corrupted is partially imported; verify it with
+// VerifyVAASnapshot first.
+func (d *Database) ImportVAASnapshot(r io.Reader) (*SnapshotImportResult, error) {
+	res := &SnapshotImportResult{}
+	batch := make([]*vaa.VAA, 0, snapshotImportBatchSize)
+	flush := func() error {
+		missing := make([]*vaa.VAA, 0, len(batch))
+		if err := d.db.View(func(txn Txn) error {
+			for _, v := range batch {
+				if _, err := txn.Get(VaaIDFromVAA(v).Bytes()); errors.Is(err, ErrKeyNotFound) {
+					missing = append(missing, v)
+				} else if err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return fmt.Errorf("failed to look up imported VAAs: %w", err)
+		}
+
+		written := make([][]byte, 0, len(missing))
+		if err := d.db.Batch(func(wb Writer) error {
+			for _, v := range missing {
+				b, err := v.Marshal()
+				if err != nil {
+					return fmt.Errorf("failed to marshal VAA %s: %w", v.MessageID(), err)
+				}
+				id := VaaIDFromVAA(v)
+				if err := wb.Set(id.Bytes(), b); err != nil {
+					return fmt.Errorf("failed to write VAA %s: %w", v.MessageID(), err)
+				}
+				if err := wb.Set(vaaSequenceKey(id), nil); err != nil {
+					return fmt.Errorf("failed to index VAA %s: %w", v.MessageID(), err)
+				}
+				if err := indexGuardianSetUpdate(wb, v); err != nil {
+					return fmt.Errorf("failed to index VAA %s: %w", v.MessageID(), err)
+				}
+				written = append(written, b)
+			}
+			return nil
+		}); err != nil {
+			return fmt.Errorf("failed to commit imported VAAs: %w", err)
+		}
+		for _, b := range written {
+			d.feed.notify(b)
+		}
+		res.Imported += len(missing)
+		res.Existing += len(batch) - len(missing)
+		batch = batch[:0]
+		return nil
+	}
+
+	_, err := readSnapshot(r, func(b []byte) error {
+		v, err := vaa.Unmarshal(b)
+		if err != nil {
+			return fmt.Errorf("invalid VAA in snapshot: %w", err)
+		}
+		batch = append(batch, v)
+		if len(batch) == snapshotImportBatchSize {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return res, err
+	}
+	if len(batch) != 0 {
+		if err := flush(); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,124 @@
+package db
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+func storeSnapshotTestVAAs(t *testing.T, db *Database) []*vaa.VAA {
+	t.Helper()
+	privKey, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	require.NoError(t, err)
+
+	var vaas []*vaa.VAA
+	for i, chain := range []vaa.ChainID{1, 1, 10, 10} {
+		v := getVAA()
+		v.EmitterChain = chain
+		v.Sequence = uint64(i)
+		v.Timestamp = time.Unix(int64(1000*(i+1)), 0)
+		v.AddSignature(privKey, 0)
+		require.NoError(t, db.StoreSignedVAA(&v))
+		vaas = append(vaas, &v)
+	}
+	return vaas
+}
+
+func TestVAASnapshotRoundTrip(t *testing.T) {
+	src, err := OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer src.Close()
+	vaas := storeSnapshotTestVAAs(t, src)
+
+	var snapshot bytes.Buffer
+	count, err := src.ExportVAASnapshot(&snapshot, VAAFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, len(vaas), count)
+
+	count, err = VerifyVAASnapshot(bytes.NewReader(snapshot.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, len(vaas), count)
+
+	dst, err := OpenBackend(zap.NewNop(), BackendLevelDB, "")
+	require.NoError(t, err)
+	defer dst.Close()
+	res, err := dst.ImportVAASnapshot(bytes.NewReader(snapshot.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, SnapshotImportResult{Imported: len(vaas)}, *res)
+	for _, v := range vaas {
+		expected, err := v.Marshal()
+		require.NoError(t, err)
+		b, err := dst.GetSignedVAABytes(*VaaIDFromVAA(v))
+		require.NoError(t, err)
+		assert.Equal(t, expected, b)
+	}
+
+	// Importing the same snapshot again leaves the VAAs alone.
+	res, err = dst.ImportVAASnapshot(bytes.NewReader(snapshot.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, SnapshotImportResult{Existing: len(vaas)}, *res)
+}
+
+func TestVAAVAAFilter(t *testing.T) {
+	db, err := OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer db.Close()
+	vaas := storeSnapshotTestVAAs(t, db)
+
+	tests := map[string]struct {
+		filter   VAAFilter
+		expected int
+	}{
+		"chain":          {VAAFilter{EmitterChain: 1}, 2},
+		"emitter":        {VAAFilter{EmitterChain: 10, EmitterAddress: vaas[0].EmitterAddress}, 2},
+		"other emitter":  {VAAFilter{EmitterChain: 10, EmitterAddress: vaa.Address{1}}, 0},
+		"from":           {VAAFilter{From: time.Unix(2000, 0)}, 3},
+		"to":             {VAAFilter{To: time.Unix(2000, 0)}, 1},
+		"chain and time": {VAAFilter{EmitterChain: 10, From: time.Unix(3000, 0), To: time.Unix(4000, 0)}, 1},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var snapshot bytes.Buffer
+			count, err := db.ExportVAASnapshot(&snapshot, tc.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, count)
+			count, err = VerifyVAASnapshot(&snapshot)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, count)
+		})
+	}
+
+	_, err = db.ExportVAASnapshot(&bytes.Buffer{}, VAAFilter{EmitterAddress: vaas[0].EmitterAddress})
+	assert.Error(t, err)
+}
+
+func TestVAASnapshotCorruption(t *testing.T) {
+	db, err := OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer db.Close()
+	storeSnapshotTestVAAs(t, db)
+
+	var snapshot bytes.Buffer
+	_, err = db.ExportVAASnapshot(&snapshot, VAAFilter{})
+	require.NoError(t, err)
+	b := snapshot.Bytes()
+
+	_, err = VerifyVAASnapshot(bytes.NewReader(b[:len(b)/2]))
+	assert.Error(t, err)
+
+	_, err = VerifyVAASnapshot(bytes.NewReader([]byte("not a snapshot")))
+	assert.ErrorIs(t, err, ErrInvalidSnapshot)
+
+	corrupted := append([]byte(nil), b...)
+	corrupted[len(corrupted)/2] ^= 0xff
+	_, err = VerifyVAASnapshot(bytes.NewReader(corrupted))
+	assert.Error(t, err)
+}
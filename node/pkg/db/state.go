@@ -0,0 +1,94 @@
+package db
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+const (
+	// MainDatabase is the name of the database of the signed VAAs and everything else not kept in the state database.
+	MainDatabase = "main"
+	// StateDatabase is the name of the database of the governor and accountant state. Keeping it apart from the main database means
+	// pruning, purging, archiving or restoring VAA history cannot touch the state the governor and accountants need to account for
+	// the last 24 hours, and lets it be backed up on its own schedule.
+	StateDatabase = "state"
+)
+
+// The governor and accountant keys, and the admin audit log, all start with these prefixes.
+const (
+	governorPrefix   = "GOV:"
+	accountantPrefix = "ACCT:"
+	adminPrefix      = "ADMIN:"
+)
+
+// moveStateBatchSize is the number of keys moved at once by MoveState.
+const moveStateBatchSize = 10000
+
+// statePrefixes are the prefixes of the keys kept in the state database, including the TTL index entries of its records.
+func statePrefixes() [][]byte {
+	return [][]byte{
+		[]byte(governorPrefix),
+		[]byte(accountantPrefix),
+		[]byte(adminPrefix),
+		ttlClassPrefix(RecordClassGovernorAudit),
+		ttlClassPrefix(RecordClassAccountantAudit),
+		ttlClassPrefix(RecordClassAdminAudit),
+	}
+}
+
+// MoveState moves the governor and accountant state, and the TTL index entries of its records, from d to dst, and returns the
+// number of keys moved. Keys are written to dst before being deleted from d, so a move interrupted by a crash is completed by
+// moving again. The main database is moved to the state database when the state database is first used, and back when a node
+// goes back to a single database.
+func (d *Database) MoveState(dst *Database) (int, error) {
+	if d == dst {
+		return 0, errors.New("cannot move the state of a database to itself")
+	}
+
+	moved := 0
+	for _, prefix := range statePrefixes() {
+		for {
+			var keys, values [][]byte
+			err := d.db.View(func(txn Txn) error {
+				return txn.Iterate(prefix, func(key []byte, val []byte) error {
+					if len(keys) == moveStateBatchSize {
+						return errStopIteration
+					}
+					keys = append(keys, bytes.Clone(key))
+					values = append(values, bytes.Clone(val))
+					return nil
+				})
+			})
+			if err != nil && !errors.Is(err, errStopIteration) {
+				return moved, err
+			}
+			if len(keys) == 0 {
+				break
+			}
+
+			if err := dst.db.Batch(func(w Writer) error {
+				for i, key := range keys {
+					if err := w.Set(key, values[i]); err != nil {
+						return err
+					}
+				}
+				return nil
+			}); err != nil {
+				return moved, fmt.Errorf("failed to write the state to the %s database: %w", dst.name, err)
+			}
+			if err := d.db.Batch(func(w Writer) error {
+				for _, key := range keys {
+					if err := w.Delete(key); err != nil {
+						return err
+					}
+				}
+				return nil
+			}); err != nil {
+				return moved, fmt.Errorf("failed to delete the moved state from the %s database: %w", d.name, err)
+			}
+			moved += len(keys)
+		}
+	}
+	return moved, nil
+}
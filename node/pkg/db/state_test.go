@@ -0,0 +1,82 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+func TestMoveState(t *testing.T) {
+	mainDb, err := OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer mainDb.Close()
+	opts := DefaultOptions()
+	opts.Name = StateDatabase
+	stateDb, err := OpenWithOptions(zap.NewNop(), "", opts)
+	require.NoError(t, err)
+	defer stateDb.Close()
+	assert.Equal(t, MainDatabase, mainDb.Name())
+	assert.Equal(t, StateDatabase, stateDb.Name())
+
+	now := time.Unix(1700000000, 0)
+	require.NoError(t, mainDb.StoreSignedVAA(&vaa.VAA{
+		Version:        vaa.SupportedVAAVersion,
+		Signatures:     []*vaa.Signature{{Index: 0}},
+		Timestamp:      now,
+		EmitterChain:   vaa.ChainIDSolana,
+		EmitterAddress: vaa.Address{1},
+		Sequence:       1,
+	}))
+	require.NoError(t, mainDb.StoreAggregationStates([]*AggregationState{{Digest: "digest", FirstObserved: now}}))
+	require.NoError(t, mainDb.StoreGovernorAuditEntry(&GovernorAuditEntry{Timestamp: now, Action: "release", MsgID: "1/00/1"}))
+	require.NoError(t, mainDb.AcctStoreAuditEntry(&AccountantAuditEntry{Timestamp: now, Accountant: "ntt", Action: "force-release", MsgID: "1/00/2"}))
+	require.NoError(t, mainDb.db.Update(func(txn Txn) error {
+		return txn.Set([]byte(chainLimit+"1"), []byte("limit"))
+	}))
+
+	// Three records and the index entries of the two audit entries.
+	moved, err := mainDb.MoveState(stateDb)
+	require.NoError(t, err)
+	assert.Equal(t, 5, moved)
+
+	stats, err := mainDb.KeyspaceStats()
+	require.NoError(t, err)
+	assert.Zero(t, stats["governor"].Keys)
+	assert.Zero(t, stats["accountant"].Keys)
+	assert.Equal(t, int64(1), stats["signed_vaas"].Keys)
+	// Only the index entry of the aggregation state is left in the main database.
+	assert.Equal(t, int64(1), stats["ttl_index"].Keys)
+	states, err := mainDb.GetAggregationStates()
+	require.NoError(t, err)
+	assert.Len(t, states, 1)
+
+	govEntries, err := stateDb.GetGovernorAuditEntries()
+	require.NoError(t, err)
+	assert.Len(t, govEntries, 1)
+	acctEntries, err := stateDb.AcctGetAuditEntries()
+	require.NoError(t, err)
+	assert.Len(t, acctEntries, 1)
+
+	// The audit entries expire from the state database.
+	deleted, err := stateDb.DeleteExpired(RecordClassGovernorAudit, now.Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+
+	// Moving again has nothing left to move, and the state can be moved back.
+	moved, err = mainDb.MoveState(stateDb)
+	require.NoError(t, err)
+	assert.Zero(t, moved)
+	moved, err = stateDb.MoveState(mainDb)
+	require.NoError(t, err)
+	assert.Equal(t, 3, moved)
+	acctEntries, err = mainDb.AcctGetAuditEntries()
+	require.NoError(t, err)
+	assert.Len(t, acctEntries, 1)
+
+	_, err = mainDb.MoveState(mainDb)
+	assert.Error(t, err)
+}
@@ -0,0 +1,78 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrKeyNotFound is returned by Txn.Get if the key is not in the store.
+var ErrKeyNotFound = errors.New("key not found")
+
+// Store is the key-value storage engine under a Database. Keys are ordered bytewise.
+type Store interface {
+	// View calls fn with a read-only transaction, which sees a consistent snapshot of the store.
+	View(fn func(txn Txn) error) error
+	// Update calls fn with a read-write transaction, whose writes are committed if fn returns nil. Updates are serialized, but reads
+	// in a transaction are not guaranteed to see the writes made by the same transaction.
+	Update(fn func(txn Txn) error) error
+	// Batch calls fn to write many keys at once, more efficiently than Update. The writes are committed if fn returns nil.
+	Batch(fn func(w Writer) error) error
+	// Sizes returns the size on disk of the parts of the store, by part name.
+	Sizes() (map[string]int64, error)
+	Close() error
+}
+
+// Writer writes keys to a Store.
+type Writer interface {
+	Set(key []byte, value []byte) error
+	Delete(key []byte) error
+}
+
+// Txn is a transaction on a Store.
+type Txn interface {
+	Writer
+	// Get returns a copy of the value of key, or ErrKeyNotFound.
+	Get(key []byte) ([]byte, error)
+	// Iterate calls fn with each key starting with prefix and its value, in key order, until fn returns an error. The key and value
+	// are only valid during the call, and must be copied to be retained.
+	Iterate(prefix []byte, fn func(key []byte, value []byte) error) error
+	// IterateFrom is like Iterate, but starts at the first key starting with prefix which is not before start.
+	IterateFrom(prefix []byte, start []byte, fn func(key []byte, value []byte) error) error
+	// IterateKeys calls fn with each key starting with prefix and the estimated size of its entry, in key order, until fn returns an
+	// error. It avoids reading the values where the engine allows it. The key is only valid during the call.
+	IterateKeys(prefix []byte, fn func(key []byte, size int64) error) error
+}
+
+// Backend selects the storage engine of a Database.
+type Backend string
+
+const (
+	// BackendBadger stores the database in Badger, an LSM tree which keeps values in a separate log. It is the default.
+	BackendBadger Backend = "badger"
+	// BackendLevelDB stores the database in LevelDB, an LSM tree which keeps values with their keys. It uses less disk on workloads
+	// with many overwrites and deletes, since space is reclaimed by its compactions rather than by value log garbage collection.
+	BackendLevelDB Backend = "leveldb"
+)
+
+// Backends are the supported storage engines.
+var Backends = []Backend{BackendBadger, BackendLevelDB}
+
+// ParseBackend parses the name of a storage engine.
+func ParseBackend(s string) (Backend, error) {
+	for _, b := range Backends {
+		if string(b) == strings.ToLower(s) {
+			return b, nil
+		}
+	}
+	return "", fmt.Errorf(`unknown database backend "%s", must be one of %v`, s, Backends)
+}
+
+// dirName is the name of the directory the backend stores the database in, under the data directory. The backends use separate
+// directories, so that switching backends never opens the files of the other one.
+func (b Backend) dirName() string {
+	if b == BackendBadger {
+		return "db"
+	}
+	return "db-" + string(b)
+}
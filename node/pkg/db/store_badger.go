@@ -0,0 +1,108 @@
+package db
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/dgraph-io/badger/v3"
+	"go.uber.org/zap"
+)
+
+// badgerStore is a Store backed by Badger.
+type badgerStore struct {
+	db     *badger.DB
+	opts   BadgerOptions
+	logger *zap.Logger
+	// name is the name of the database, which labels its size metrics.
+	name string
+}
+
+func (s *badgerStore) View(fn func(txn Txn) error) error {
+	return s.db.View(func(txn *badger.Txn) error {
+		return fn(badgerTxn{txn})
+	})
+}
+
+func (s *badgerStore) Update(fn func(txn Txn) error) error {
+	return s.db.Update(func(txn *badger.Txn) error {
+		return fn(badgerTxn{txn})
+	})
+}
+
+func (s *badgerStore) Batch(fn func(w Writer) error) error {
+	wb := s.db.NewWriteBatch()
+	defer wb.Cancel()
+	if err := fn(wb); err != nil {
+		return err
+	}
+	return wb.Flush()
+}
+
+func (s *badgerStore) Sizes() (map[string]int64, error) {
+	lsm, vlog := s.db.Size()
+	return map[string]int64{"lsm": lsm, "vlog": vlog}, nil
+}
+
+func (s *badgerStore) Close() error {
+	return s.db.Close()
+}
+
+type badgerTxn struct {
+	txn *badger.Txn
+}
+
+func (t badgerTxn) Get(key []byte) ([]byte, error) {
+	item, err := t.txn.Get(key)
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return nil, ErrKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return item.ValueCopy(nil)
+}
+
+func (t badgerTxn) Set(key []byte, value []byte) error {
+	return t.txn.Set(key, value)
+}
+
+func (t badgerTxn) Delete(key []byte) error {
+	return t.txn.Delete(key)
+}
+
+func (t badgerTxn) Iterate(prefix []byte, fn func(key []byte, value []byte) error) error {
+	return t.IterateFrom(prefix, prefix, fn)
+}
+
+func (t badgerTxn) IterateFrom(prefix []byte, start []byte, fn func(key []byte, value []byte) error) error {
+	if bytes.Compare(start, prefix) < 0 {
+		start = prefix
+	}
+	opts := badger.DefaultIteratorOptions
+	opts.Prefix = prefix
+	it := t.txn.NewIterator(opts)
+	defer it.Close()
+	for it.Seek(start); it.Valid(); it.Next() {
+		item := it.Item()
+		if err := item.Value(func(val []byte) error {
+			return fn(item.Key(), val)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t badgerTxn) IterateKeys(prefix []byte, fn func(key []byte, size int64) error) error {
+	opts := badger.DefaultIteratorOptions
+	opts.Prefix = prefix
+	opts.PrefetchValues = false
+	it := t.txn.NewIterator(opts)
+	defer it.Close()
+	for it.Rewind(); it.Valid(); it.Next() {
+		if err := fn(it.Item().Key(), it.Item().EstimatedSize()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
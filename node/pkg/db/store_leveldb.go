@@ -0,0 +1,139 @@
+package db
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+var errReadOnlyTxn = errors.New("cannot write in a read-only transaction")
+
+// levelDBStore is a Store backed by LevelDB. LevelDB has no transactions, so reads are made on a snapshot, writes are collected
+// in a batch, and updates are serialized by a lock.
+type levelDBStore struct {
+	db *leveldb.DB
+	// writeLock serializes updates and batches.
+	writeLock sync.Mutex
+}
+
+func (s *levelDBStore) View(fn func(txn Txn) error) error {
+	snap, err := s.db.GetSnapshot()
+	if err != nil {
+		return err
+	}
+	defer snap.Release()
+	return fn(&levelDBTxn{reader: snap})
+}
+
+func (s *levelDBStore) Update(fn func(txn Txn) error) error {
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+
+	snap, err := s.db.GetSnapshot()
+	if err != nil {
+		return err
+	}
+	defer snap.Release()
+	txn := &levelDBTxn{reader: snap, batch: new(leveldb.Batch)}
+	if err := fn(txn); err != nil {
+		return err
+	}
+	return s.db.Write(txn.batch, nil)
+}
+
+func (s *levelDBStore) Batch(fn func(w Writer) error) error {
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+
+	txn := &levelDBTxn{batch: new(leveldb.Batch)}
+	if err := fn(txn); err != nil {
+		return err
+	}
+	return s.db.Write(txn.batch, nil)
+}
+
+func (s *levelDBStore) Sizes() (map[string]int64, error) {
+	var stats leveldb.DBStats
+	if err := s.db.Stats(&stats); err != nil {
+		return nil, err
+	}
+	return map[string]int64{"sst": stats.LevelSizes.Sum()}, nil
+}
+
+func (s *levelDBStore) Close() error {
+	return s.db.Close()
+}
+
+// levelDBReader is implemented by leveldb.DB and leveldb.Snapshot.
+type levelDBReader interface {
+	Get(key []byte, ro *opt.ReadOptions) ([]byte, error)
+	NewIterator(slice *util.Range, ro *opt.ReadOptions) iterator.Iterator
+}
+
+type levelDBTxn struct {
+	reader levelDBReader
+	// batch collects the writes of the transaction, nil if it is read-only.
+	batch *leveldb.Batch
+}
+
+func (t *levelDBTxn) Get(key []byte) ([]byte, error) {
+	val, err := t.reader.Get(key, nil)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return nil, ErrKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(nil), val...), nil
+}
+
+func (t *levelDBTxn) Set(key []byte, value []byte) error {
+	if t.batch == nil {
+		return errReadOnlyTxn
+	}
+	t.batch.Put(key, value)
+	return nil
+}
+
+func (t *levelDBTxn) Delete(key []byte) error {
+	if t.batch == nil {
+		return errReadOnlyTxn
+	}
+	t.batch.Delete(key)
+	return nil
+}
+
+func (t *levelDBTxn) Iterate(prefix []byte, fn func(key []byte, value []byte) error) error {
+	return t.IterateFrom(prefix, prefix, fn)
+}
+
+func (t *levelDBTxn) IterateFrom(prefix []byte, start []byte, fn func(key []byte, value []byte) error) error {
+	r := util.BytesPrefix(prefix)
+	if bytes.Compare(start, r.Start) > 0 {
+		r.Start = start
+	}
+	it := t.reader.NewIterator(r, nil)
+	defer it.Release()
+	for it.Next() {
+		if err := fn(it.Key(), it.Value()); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+func (t *levelDBTxn) IterateKeys(prefix []byte, fn func(key []byte, size int64) error) error {
+	it := t.reader.NewIterator(util.BytesPrefix(prefix), nil)
+	defer it.Release()
+	for it.Next() {
+		if err := fn(it.Key(), int64(len(it.Key())+len(it.Value()))); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
@@ -0,0 +1,124 @@
+package db
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestStoreBackends(t *testing.T) {
+	for _, backend := range Backends {
+		t.Run(string(backend), func(t *testing.T) {
+			db, err := OpenBackend(zap.NewNop(), backend, "")
+			require.NoError(t, err)
+			defer db.Close()
+
+			require.NoError(t, db.db.Update(func(txn Txn) error {
+				for _, key := range []string{"a/2", "a/1", "b/1", "a"} {
+					if err := txn.Set([]byte(key), []byte("v"+key)); err != nil {
+						return err
+					}
+				}
+				return nil
+			}))
+			require.NoError(t, db.db.Batch(func(w Writer) error {
+				if err := w.Set([]byte("a/3"), []byte("va/3")); err != nil {
+					return err
+				}
+				return w.Delete([]byte("a/1"))
+			}))
+
+			// A failed update is not committed.
+			failed := errors.New("failed")
+			require.ErrorIs(t, db.db.Update(func(txn Txn) error {
+				if err := txn.Set([]byte("a/4"), []byte("va/4")); err != nil {
+					return err
+				}
+				return failed
+			}), failed)
+
+			require.NoError(t, db.db.View(func(txn Txn) error {
+				val, err := txn.Get([]byte("b/1"))
+				require.NoError(t, err)
+				assert.Equal(t, []byte("vb/1"), val)
+				_, err = txn.Get([]byte("a/1"))
+				assert.ErrorIs(t, err, ErrKeyNotFound)
+				assert.Error(t, txn.Set([]byte("c"), nil))
+
+				var keys, vals []string
+				require.NoError(t, txn.Iterate([]byte("a/"), func(key []byte, val []byte) error {
+					keys = append(keys, string(key))
+					vals = append(vals, string(val))
+					return nil
+				}))
+				assert.Equal(t, []string{"a/2", "a/3"}, keys)
+				assert.Equal(t, []string{"va/2", "va/3"}, vals)
+
+				for start, expected := range map[string][]string{"": {"a/2", "a/3"}, "a/3": {"a/3"}, "a/4": nil} {
+					keys = nil
+					require.NoError(t, txn.IterateFrom([]byte("a/"), []byte(start), func(key []byte, val []byte) error {
+						keys = append(keys, string(key))
+						return nil
+					}))
+					assert.Equal(t, expected, keys, "start %q", start)
+				}
+
+				// Errors stop the iteration.
+				n := 0
+				assert.ErrorIs(t, txn.Iterate(nil, func([]byte, []byte) error {
+					n++
+					return failed
+				}), failed)
+				assert.Equal(t, 1, n)
+				return nil
+			}))
+		})
+	}
+}
+
+func TestOpenDbLevelDB(t *testing.T) {
+	dataDir := t.TempDir()
+	opts := DefaultOptions()
+	opts.Backend = BackendLevelDB
+	db := OpenDb(zap.NewNop(), &dataDir, opts)
+
+	testVaa := getVAA()
+	privKey, _ := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	testVaa.AddSignature(privKey, 0)
+	require.NoError(t, db.StoreSignedVAA(&testVaa))
+	require.NoError(t, db.Close())
+
+	// The VAA survives reopening the database.
+	db = OpenDb(zap.NewNop(), &dataDir, opts)
+	defer db.Close()
+	vaaBytes, err := db.GetSignedVAABytes(*VaaIDFromVAA(&testVaa))
+	require.NoError(t, err)
+	testVaaBytes, err := testVaa.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, testVaaBytes, vaaBytes)
+
+	missing, _, lastSeq, err := db.FindEmitterSequenceGap(*VaaIDFromVAA(&testVaa))
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{0}, missing)
+	assert.Equal(t, uint64(1), lastSeq)
+}
+
+func TestParseBackend(t *testing.T) {
+	b, err := ParseBackend("LevelDB")
+	require.NoError(t, err)
+	assert.Equal(t, BackendLevelDB, b)
+	b, err = ParseBackend("badger")
+	require.NoError(t, err)
+	assert.Equal(t, BackendBadger, b)
+	_, err = ParseBackend("pebble")
+	assert.Error(t, err)
+
+	assert.Equal(t, "db", BackendBadger.dirName())
+	assert.Equal(t, "db-leveldb", BackendLevelDB.dirName())
+}
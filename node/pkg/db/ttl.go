@@ -0,0 +1,262 @@
+package db
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// RecordClass is a class of transient records, which can be deleted once they are older than the retention of their class.
+type RecordClass string
+
+const (
+	// RecordClassAggregationState are the observations waiting for quorum, persisted by the processor. Their age is the time of
+	// their first observation.
+	RecordClassAggregationState RecordClass = "aggregation_state"
+	// RecordClassGovernorAudit are the audit entries of the chain governor.
+	RecordClassGovernorAudit RecordClass = "governor_audit"
+	// RecordClassAccountantAudit are the audit entries of the accountants.
+	RecordClassAccountantAudit RecordClass = "accountant_audit"
+	// RecordClassAdminAudit are the audit entries of the calls to the admin service.
+	RecordClassAdminAudit RecordClass = "admin_audit"
+	// RecordClassAggregatedAttestation are the experimental aggregated attestations of VAAs. Their age is the timestamp of their VAA.
+	RecordClassAggregatedAttestation RecordClass = "aggregated_attestation"
+	// RecordClassTxHashIndex are the entries of the index of the messages by transaction. Their age is the timestamp of their message.
+	RecordClassTxHashIndex RecordClass = "tx_hash_index"
+)
+
+var RecordClasses = []RecordClass{
+	RecordClassAggregationState,
+	RecordClassGovernorAudit,
+	RecordClassAccountantAudit,
+	RecordClassAdminAudit,
+	RecordClassAggregatedAttestation,
+	RecordClassTxHashIndex,
+}
+
+// Each transient record has a key in the TTL index, made of its class, the time its age is counted from in big endian and its own
+// key. The index sorts the records of a class by age, so the sweeper only reads the records it deletes. The keys have no value.
+const ttlIndexPrefix = "ttl/"
+
+const (
+	// DefaultSweepInterval is how often expired records are deleted.
+	DefaultSweepInterval = time.Hour
+	// sweepBatchSize is the number of records deleted at once.
+	sweepBatchSize = 10000
+)
+
+var recordsExpired = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "wormhole_db_expired_records_deleted_total",
+		Help: "Total number of transient records deleted from the database because they were older than their retention, by class",
+	}, []string{"class"})
+
+// DefaultRetention returns the retention of each record class unless configured otherwise. Records of classes without a
+// retention are kept forever.
+func DefaultRetention() map[RecordClass]time.Duration {
+	return map[RecordClass]time.Duration{
+		// The processor gives up on observations long before this, but does not delete them if it is not running.
+		RecordClassAggregationState: 7 * 24 * time.Hour,
+	}
+}
+
+// ParseRetention parses a comma separated list of class:duration pairs, e.g. governor_audit:8760h, and returns the default
+// retention with the listed classes overridden. A duration of 0 keeps the records of the class forever.
+func ParseRetention(str string) (map[RecordClass]time.Duration, error) {
+	ret := DefaultRetention()
+	if str == "" {
+		return ret, nil
+	}
+
+	seen := make(map[RecordClass]struct{})
+	for _, entry := range strings.Split(str, ",") {
+		parts := strings.Split(strings.TrimSpace(entry), ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf(`invalid retention "%s", must be of the form class:duration`, entry)
+		}
+		class := RecordClass(parts[0])
+		known := false
+		for _, c := range RecordClasses {
+			known = known || c == class
+		}
+		if !known {
+			return nil, fmt.Errorf(`unknown record class "%s", must be one of %v`, parts[0], RecordClasses)
+		}
+		if _, exists := seen[class]; exists {
+			return nil, fmt.Errorf("duplicate retention for %s", class)
+		}
+		seen[class] = struct{}{}
+
+		retention, err := time.ParseDuration(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf(`invalid duration in retention "%s": %w`, entry, err)
+		}
+		if retention < 0 {
+			return nil, fmt.Errorf(`retention "%s" must not be negative`, entry)
+		}
+		if retention == 0 {
+			delete(ret, class)
+		} else {
+			ret[class] = retention
+		}
+	}
+	return ret, nil
+}
+
+func ttlClassPrefix(class RecordClass) []byte {
+	return []byte(ttlIndexPrefix + string(class) + "/")
+}
+
+func ttlIndexKey(class RecordClass, t time.Time, key []byte) []byte {
+	// Times before 1970, such as the zero time, expire first.
+	ns := uint64(0)
+	if t.After(time.Unix(0, 0)) {
+		ns = uint64(t.UnixNano())
+	}
+	b := binary.BigEndian.AppendUint64(ttlClassPrefix(class), ns)
+	return append(b, key...)
+}
+
+// setTTL adds a record to the TTL index. Its age is counted from t.
+func setTTL(w Writer, class RecordClass, t time.Time, key []byte) error {
+	return w.Set(ttlIndexKey(class, t, key), nil)
+}
+
+// deleteTTL removes a record added with setTTL from the TTL index.
+func deleteTTL(w Writer, class RecordClass, t time.Time, key []byte) error {
+	return w.Delete(ttlIndexKey(class, t, key))
+}
+
+// DeleteExpired deletes the records of a class whose age is counted from before cutoff, and returns how many it deleted.
+func (d *Database) DeleteExpired(class RecordClass, cutoff time.Time) (int, error) {
+	prefix := ttlClassPrefix(class)
+	deleted := 0
+	for {
+		var expired [][]byte
+		err := d.db.View(func(txn Txn) error {
+			return txn.IterateKeys(prefix, func(key []byte, _ int64) error {
+				if len(key) < len(prefix)+8 {
+					return fmt.Errorf("invalid TTL index key %q", key)
+				}
+				ns := binary.BigEndian.Uint64(key[len(prefix):])
+				if ns >= uint64(cutoff.UnixNano()) || len(expired) == sweepBatchSize {
+					return errStopIteration
+				}
+				expired = append(expired, append([]byte(nil), key...))
+				return nil
+			})
+		})
+		if err != nil && !errors.Is(err, errStopIteration) {
+			return deleted, err
+		}
+		if len(expired) == 0 {
+			return deleted, nil
+		}
+
+		if err := d.db.Batch(func(w Writer) error {
+			for _, key := range expired {
+				if err := w.Delete(key[len(prefix)+8:]); err != nil {
+					return err
+				}
+				if err := w.Delete(key); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return deleted, fmt.Errorf("failed to delete expired records: %w", err)
+		}
+		deleted += len(expired)
+		recordsExpired.WithLabelValues(string(class)).Add(float64(len(expired)))
+	}
+}
+
+// SweeperRunnable returns a runnable which deletes the records older than the retention of their class every interval, or nil if
+// no class has a retention.
+func (d *Database) SweeperRunnable(logger *zap.Logger, retention map[RecordClass]time.Duration, interval time.Duration) func(ctx context.Context) error {
+	if len(retention) == 0 {
+		return nil
+	}
+	return func(ctx context.Context) error {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			now := time.Now()
+			for _, class := range RecordClasses {
+				r, ok := retention[class]
+				if !ok {
+					continue
+				}
+				deleted, err := d.DeleteExpired(class, now.Add(-r))
+				if err != nil {
+					logger.Error("failed to delete expired records", zap.String("class", string(class)), zap.Error(err))
+				} else if deleted != 0 {
+					logger.Info("deleted expired records", zap.String("class", string(class)), zap.Int("deleted", deleted))
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+		}
+	}
+}
+
+// migrateTTLIndex adds the transient records stored before the TTL index existed to the index. Audit entries are aged from the
+// time in their key, and aggregated attestations from the timestamp of their VAA, or from the migration if the VAA is gone.
+func migrateTTLIndex(d *Database, w *migrationWriter) error {
+	now := time.Now()
+	return d.db.View(func(txn Txn) error {
+		if err := txn.Iterate([]byte(aggregationStatePrefix), func(key []byte, val []byte) error {
+			var s struct {
+				FirstObserved time.Time `json:"firstObserved"`
+			}
+			if err := json.Unmarshal(val, &s); err != nil {
+				return fmt.Errorf("failed to unmarshal aggregation state %s: %w", key, err)
+			}
+			return setTTL(w, RecordClassAggregationState, s.FirstObserved, key)
+		}); err != nil {
+			return err
+		}
+		for prefix, class := range map[string]RecordClass{governorAudit: RecordClassGovernorAudit, acctAudit: RecordClassAccountantAudit} {
+			if err := txn.IterateKeys([]byte(prefix), func(key []byte, _ int64) error {
+				ts := strings.TrimPrefix(string(key), prefix)
+				if len(ts) < 20 {
+					return fmt.Errorf("invalid audit entry key %s", key)
+				}
+				ns, err := strconv.ParseInt(ts[:20], 10, 64)
+				if err != nil {
+					return fmt.Errorf("invalid audit entry key %s: %w", key, err)
+				}
+				return setTTL(w, class, time.Unix(0, ns), key)
+			}); err != nil {
+				return err
+			}
+		}
+		return txn.IterateKeys([]byte(aggregatedAttestationPrefix), func(key []byte, _ int64) error {
+			t := now
+			b, err := txn.Get([]byte(signedVaaPrefix + strings.TrimPrefix(string(key), aggregatedAttestationPrefix)))
+			if err == nil {
+				v, err := vaa.Unmarshal(b)
+				if err != nil {
+					return fmt.Errorf("failed to unmarshal the VAA of aggregated attestation %s: %w", key, err)
+				}
+				t = v.Timestamp
+			} else if !errors.Is(err, ErrKeyNotFound) {
+				return err
+			}
+			return setTTL(w, RecordClassAggregatedAttestation, t, key)
+		})
+	})
+}
@@ -0,0 +1,105 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestDeleteExpired(t *testing.T) {
+	db, err := OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer db.Close()
+
+	now := time.Unix(1700000000, 0)
+	require.NoError(t, db.StoreAggregationStates([]*AggregationState{
+		{Digest: "old", FirstObserved: now.Add(-8 * 24 * time.Hour)},
+		{Digest: "new", FirstObserved: now.Add(-time.Hour)},
+		{Digest: "deleted", FirstObserved: now.Add(-8 * 24 * time.Hour)},
+	}))
+	// Deleting a state also deletes it from the index, so it is not counted as expired later.
+	require.NoError(t, db.DeleteAggregationStates([]string{"deleted", "missing"}))
+	require.NoError(t, db.StoreGovernorAuditEntry(&GovernorAuditEntry{Timestamp: now.Add(-400 * 24 * time.Hour), Action: "release", MsgID: "1/00/1"}))
+	require.NoError(t, db.StoreGovernorAuditEntry(&GovernorAuditEntry{Timestamp: now, Action: "release", MsgID: "1/00/2"}))
+
+	deleted, err := db.DeleteExpired(RecordClassAggregationState, now.Add(-7*24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	states, err := db.GetAggregationStates()
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+	assert.Equal(t, "new", states[0].Digest)
+
+	deleted, err = db.DeleteExpired(RecordClassGovernorAudit, now.Add(-365*24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	entries, err := db.GetGovernorAuditEntries()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "1/00/2", entries[0].MsgID)
+
+	// Only the index keys of the records left remain.
+	stats, err := db.KeyspaceStats()
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), stats["ttl_index"].Keys)
+}
+
+func TestMigrateTTLIndex(t *testing.T) {
+	db, err := OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer db.Close()
+
+	now := time.Unix(1700000000, 0)
+	require.NoError(t, db.StoreAggregationStates([]*AggregationState{{Digest: "old", FirstObserved: now.Add(-8 * 24 * time.Hour)}}))
+	require.NoError(t, db.AcctStoreAuditEntry(&AccountantAuditEntry{Timestamp: now.Add(-time.Hour), Accountant: "ntt", Action: "force-release", MsgID: "1/00/1"}))
+	// Simulate records stored before the TTL index existed.
+	require.NoError(t, db.db.Update(func(txn Txn) error {
+		return txn.Set([]byte(schemaVersionKey), []byte{0, 0, 0, 1})
+	}))
+	var indexKeys [][]byte
+	require.NoError(t, db.db.View(func(txn Txn) error {
+		return txn.IterateKeys([]byte(ttlIndexPrefix), func(key []byte, _ int64) error {
+			indexKeys = append(indexKeys, append([]byte(nil), key...))
+			return nil
+		})
+	}))
+	require.NoError(t, db.db.Batch(func(w Writer) error {
+		for _, key := range indexKeys {
+			if err := w.Delete(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+
+	results, err := db.Migrate(zap.NewNop(), false)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, uint32(2), results[0].Version)
+	assert.Equal(t, 2, results[0].Writes)
+
+	deleted, err := db.DeleteExpired(RecordClassAggregationState, now.Add(-7*24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	deleted, err = db.DeleteExpired(RecordClassAccountantAudit, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+}
+
+func TestParseRetention(t *testing.T) {
+	r, err := ParseRetention("")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultRetention(), r)
+
+	r, err = ParseRetention("aggregation_state:0, governor_audit:8760h")
+	require.NoError(t, err)
+	assert.Equal(t, map[RecordClass]time.Duration{RecordClassGovernorAudit: 8760 * time.Hour}, r)
+
+	for _, invalid := range []string{"governor_audit", "pending:1h", "governor_audit:1x", "governor_audit:-1h", "governor_audit:1h,governor_audit:2h"} {
+		_, err := ParseRetention(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
@@ -0,0 +1,72 @@
+package db
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// The transaction index maps the source chain transactions of the messages observed by the guardian to their message IDs, so that
+// the signed VAAs of a transaction can be found. Its keys are made of the emitter chain in big endian, the transaction ID and the
+// emitter address and sequence in big endian of a message. The keys have no value, the VAAs are looked up by message ID. On Solana,
+// messages are indexed both by the 64-byte signature of their transaction and by the 32-byte address of their message account.
+// Since the length of the keys tells the two apart, a lookup by one never returns entries of the other.
+const txHashIndexPrefix = "signedtx/"
+
+func txHashIndexTxPrefix(chain vaa.ChainID, txID []byte) []byte {
+	b := make([]byte, 0, len(txHashIndexPrefix)+2+len(txID)+32+8)
+	b = append(b, txHashIndexPrefix...)
+	b = binary.BigEndian.AppendUint16(b, uint16(chain))
+	return append(b, txID...)
+}
+
+func txHashIndexKey(txID []byte, id *VAAID) []byte {
+	b := append(txHashIndexTxPrefix(id.EmitterChain, txID), id.EmitterAddress[:]...)
+	return binary.BigEndian.AppendUint64(b, id.Sequence)
+}
+
+// StoreTxHash records that the message id was emitted by the transaction txID on its emitter chain. The entry expires based on
+// timestamp, the time of the message, if the tx_hash_index record class has a retention.
+func (d *Database) StoreTxHash(txID []byte, id VAAID, timestamp time.Time) error {
+	if len(txID) == 0 {
+		return errors.New("empty transaction ID")
+	}
+	if err := d.db.Update(func(txn Txn) error {
+		key := txHashIndexKey(txID, &id)
+		if err := txn.Set(key, nil); err != nil {
+			return err
+		}
+		return setTTL(txn, RecordClassTxHashIndex, timestamp, key)
+	}); err != nil {
+		return fmt.Errorf("failed to commit tx hash index tx: %w", err)
+	}
+	return nil
+}
+
+// GetSignedVAAsByTxHash returns the signed VAAs of the messages emitted by the transaction txID on chain, ordered by emitter and
+// sequence. Messages which have no signed VAA yet, or whose VAA was purged, are skipped.
+func (d *Database) GetSignedVAAsByTxHash(chain vaa.ChainID, txID []byte) (vaas [][]byte, err error) {
+	prefix := txHashIndexTxPrefix(chain, txID)
+	err = d.db.View(func(txn Txn) error {
+		return txn.IterateKeys(prefix, func(key []byte, _ int64) error {
+			// Keys of transaction IDs of another length which happen to start with prefix are skipped.
+			if len(key) != len(prefix)+32+8 {
+				return nil
+			}
+			id := VAAID{EmitterChain: chain, Sequence: binary.BigEndian.Uint64(key[len(prefix)+32:])}
+			copy(id.EmitterAddress[:], key[len(prefix):])
+			b, err := txn.Get(id.Bytes())
+			if errors.Is(err, ErrKeyNotFound) {
+				return nil
+			} else if err != nil {
+				return err
+			}
+			vaas = append(vaas, b)
+			return nil
+		})
+	})
+	return
+}
@@ -0,0 +1,70 @@
+package db
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+func TestGetSignedVAAsByTxHash(t *testing.T) {
+	db, err := OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer db.Close()
+	privKey, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	require.NoError(t, err)
+
+	txHash, otherTxHash := []byte{31: 1}, []byte{31: 2}
+	var expected [][]byte
+	for seq := uint64(1); seq <= 3; seq++ {
+		v := getVAA()
+		v.Sequence = seq
+		v.AddSignature(privKey, 0)
+		// The third message has no signed VAA yet.
+		if seq < 3 {
+			require.NoError(t, db.StoreSignedVAA(&v))
+			b, err := v.Marshal()
+			require.NoError(t, err)
+			expected = append(expected, b)
+		}
+		require.NoError(t, db.StoreTxHash(txHash, *VaaIDFromVAA(&v), v.Timestamp))
+	}
+	other := getVAA()
+	other.Sequence = 4
+	other.AddSignature(privKey, 0)
+	require.NoError(t, db.StoreSignedVAA(&other))
+	require.NoError(t, db.StoreTxHash(otherTxHash, *VaaIDFromVAA(&other), other.Timestamp))
+
+	// The keys of a 64-byte signature made of txHash and the emitter of the messages start with the prefix of txHash, and the keys
+	// of txHash start with the prefix of the signature.
+	signature := append(append([]byte(nil), txHash...), other.EmitterAddress[:]...)
+	require.NoError(t, db.StoreTxHash(signature, *VaaIDFromVAA(&other), other.Timestamp))
+	assert.Error(t, db.StoreTxHash(nil, *VaaIDFromVAA(&other), other.Timestamp))
+
+	vaas, err := db.GetSignedVAAsByTxHash(vaa.ChainIDSolana, txHash)
+	require.NoError(t, err)
+	assert.Equal(t, expected, vaas)
+	otherVAA, err := other.Marshal()
+	require.NoError(t, err)
+	vaas, err = db.GetSignedVAAsByTxHash(vaa.ChainIDSolana, signature)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{otherVAA}, vaas)
+
+	vaas, err = db.GetSignedVAAsByTxHash(vaa.ChainID(2), txHash)
+	require.NoError(t, err)
+	assert.Empty(t, vaas)
+
+	// The index expires with the tx_hash_index retention.
+	deleted, err := db.DeleteExpired(RecordClassTxHashIndex, time.Unix(1, 0))
+	require.NoError(t, err)
+	assert.Equal(t, 5, deleted)
+	vaas, err = db.GetSignedVAAsByTxHash(vaa.ChainIDSolana, txHash)
+	require.NoError(t, err)
+	assert.Empty(t, vaas)
+}
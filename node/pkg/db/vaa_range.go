@@ -0,0 +1,168 @@
+package db
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// Signed VAAs are keyed by their message ID, whose sequence is in decimal, so their keys are not in sequence order. The sequence
+// index has a key for each signed VAA, made of its emitter chain and sequence in big endian and its emitter address, which sorts
+// the VAAs of an emitter by sequence. The keys have no value, the VAAs are looked up by message ID.
+const vaaSequenceIndexPrefix = "signedseq/"
+
+// vaaSequenceIndexBuiltKey was set by releases from before schema migrations once they had built the sequence index.
+const vaaSequenceIndexBuiltKey = "signedseq-built"
+
+const (
+	// DefaultSignedVAARangeLimit is the number of VAAs returned by GetSignedVAARange if the query has no limit.
+	DefaultSignedVAARangeLimit = 100
+	// MaxSignedVAARangeLimit is the maximum number of VAAs returned by GetSignedVAARange.
+	MaxSignedVAARangeLimit = 1000
+)
+
+var ErrInvalidContinuationToken = errors.New("invalid continuation token")
+
+// errStopIteration ends an iteration early without failing it.
+var errStopIteration = errors.New("stop iteration")
+
+func vaaSequenceEmitterPrefix(chain vaa.ChainID, emitter vaa.Address) []byte {
+	b := make([]byte, 0, len(vaaSequenceIndexPrefix)+2+32+8)
+	b = append(b, vaaSequenceIndexPrefix...)
+	b = binary.BigEndian.AppendUint16(b, uint16(chain))
+	return append(b, emitter[:]...)
+}
+
+func vaaSequenceKey(id *VAAID) []byte {
+	return binary.BigEndian.AppendUint64(vaaSequenceEmitterPrefix(id.EmitterChain, id.EmitterAddress), id.Sequence)
+}
+
+// SignedVAARangeQuery selects the signed VAAs of an emitter in a range of sequences.
+type SignedVAARangeQuery struct {
+	EmitterChain   vaa.ChainID
+	EmitterAddress vaa.Address
+	// FromSequence is the first sequence of the range.
+	FromSequence uint64
+	// ToSequence is the last sequence of the range, inclusive. Zero means there is no upper bound.
+	ToSequence uint64
+	// Limit is the maximum number of VAAs to return, DefaultSignedVAARangeLimit if zero. It is capped at MaxSignedVAARangeLimit.
+	Limit int
+	// ContinuationToken continues a previous query from where it stopped. The other fields must be the same as in that query.
+	ContinuationToken string
+}
+
+// SignedVAAPage is a page of the VAAs selected by a SignedVAARangeQuery.
+type SignedVAAPage struct {
+	// VAAs are the serialized signed VAAs in sequence order. The sequences may have gaps.
+	VAAs [][]byte
+	// ContinuationToken returns the next page when set in the query, empty if this is the last page.
+	ContinuationToken string
+}
+
+func encodeContinuationToken(key []byte) string {
+	return base64.RawURLEncoding.EncodeToString(key[len(vaaSequenceIndexPrefix):])
+}
+
+// decodeContinuationToken returns the sequence at which a query continues. The token must belong to the emitter of the query.
+func decodeContinuationToken(token string, prefix []byte) (uint64, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(b) != len(prefix)-len(vaaSequenceIndexPrefix)+8 || string(b[:len(b)-8]) != string(prefix[len(vaaSequenceIndexPrefix):]) {
+		return 0, ErrInvalidContinuationToken
+	}
+	return binary.BigEndian.Uint64(b[len(b)-8:]), nil
+}
+
+// GetSignedVAARange returns the signed VAAs of an emitter in a range of sequences, a page at a time.
+func (d *Database) GetSignedVAARange(q SignedVAARangeQuery) (*SignedVAAPage, error) {
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultSignedVAARangeLimit
+	} else if limit > MaxSignedVAARangeLimit {
+		limit = MaxSignedVAARangeLimit
+	}
+
+	prefix := vaaSequenceEmitterPrefix(q.EmitterChain, q.EmitterAddress)
+	from := q.FromSequence
+	if q.ContinuationToken != "" {
+		next, err := decodeContinuationToken(q.ContinuationToken, prefix)
+		if err != nil {
+			return nil, err
+		}
+		if next < from {
+			return nil, ErrInvalidContinuationToken
+		}
+		from = next
+	}
+	if q.ToSequence != 0 && from > q.ToSequence {
+		return &SignedVAAPage{}, nil
+	}
+
+	page := &SignedVAAPage{}
+	err := d.db.View(func(txn Txn) error {
+		start := binary.BigEndian.AppendUint64(append([]byte(nil), prefix...), from)
+		err := txn.IterateFrom(prefix, start, func(key []byte, _ []byte) error {
+			id := VAAID{
+				EmitterChain:   q.EmitterChain,
+				EmitterAddress: q.EmitterAddress,
+				Sequence:       binary.BigEndian.Uint64(key[len(key)-8:]),
+			}
+			if q.ToSequence != 0 && id.Sequence > q.ToSequence {
+				return errStopIteration
+			}
+			if len(page.VAAs) == limit {
+				page.ContinuationToken = encodeContinuationToken(key)
+				return errStopIteration
+			}
+			b, err := txn.Get(id.Bytes())
+			if err != nil {
+				return fmt.Errorf("failed to read VAA %s from the sequence index: %w", id.Bytes(), err)
+			}
+			page.VAAs = append(page.VAAs, b)
+			return nil
+		})
+		if errors.Is(err, errStopIteration) {
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// migrateVAASequenceIndex adds the VAAs stored before the sequence index existed to the index. Releases from before schema
+// migrations built the index themselves and marked it as built, in which case only the marker is left to delete.
+func migrateVAASequenceIndex(d *Database, w *migrationWriter) error {
+	built := false
+	if err := d.db.View(func(txn Txn) error {
+		_, err := txn.Get([]byte(vaaSequenceIndexBuiltKey))
+		if err == nil {
+			built = true
+			return nil
+		}
+		if errors.Is(err, ErrKeyNotFound) {
+			return nil
+		}
+		return err
+	}); err != nil {
+		return err
+	}
+	if built {
+		return w.Delete([]byte(vaaSequenceIndexBuiltKey))
+	}
+
+	return d.db.View(func(txn Txn) error {
+		return txn.IterateKeys([]byte(signedVaaPrefix), func(key []byte, _ int64) error {
+			id, err := VaaIDFromString(strings.TrimPrefix(string(key), signedVaaPrefix))
+			if err != nil {
+				return fmt.Errorf("invalid VAA key %s: %w", key, err)
+			}
+			return w.Set(vaaSequenceKey(id), nil)
+		})
+	})
+}
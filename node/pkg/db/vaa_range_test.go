@@ -0,0 +1,138 @@
+package db
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+func rangeSequences(t *testing.T, page *SignedVAAPage) []uint64 {
+	t.Helper()
+	var seqs []uint64
+	for _, b := range page.VAAs {
+		v, err := vaa.Unmarshal(b)
+		require.NoError(t, err)
+		seqs = append(seqs, v.Sequence)
+	}
+	return seqs
+}
+
+func TestGetSignedVAARange(t *testing.T) {
+	for _, backend := range Backends {
+		t.Run(string(backend), func(t *testing.T) {
+			db, err := OpenBackend(zap.NewNop(), backend, "")
+			require.NoError(t, err)
+			defer db.Close()
+			privKey, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+			require.NoError(t, err)
+
+			// Sequence 10 sorts before 2 in the VAA keys, but not in the index.
+			emitter := getVAA().EmitterAddress
+			for _, seq := range []uint64{1, 2, 3, 10, 11, 100} {
+				v := getVAA()
+				v.Sequence = seq
+				v.AddSignature(privKey, 0)
+				require.NoError(t, db.StoreSignedVAA(&v))
+			}
+			other := getVAA()
+			other.EmitterAddress = vaa.Address{1}
+			other.AddSignature(privKey, 0)
+			require.NoError(t, db.StoreSignedVAA(&other))
+
+			page, err := db.GetSignedVAARange(SignedVAARangeQuery{EmitterChain: vaa.ChainIDSolana, EmitterAddress: emitter})
+			require.NoError(t, err)
+			assert.Equal(t, []uint64{1, 2, 3, 10, 11, 100}, rangeSequences(t, page))
+			assert.Empty(t, page.ContinuationToken)
+
+			page, err = db.GetSignedVAARange(SignedVAARangeQuery{EmitterChain: vaa.ChainIDSolana, EmitterAddress: emitter, FromSequence: 3, ToSequence: 11})
+			require.NoError(t, err)
+			assert.Equal(t, []uint64{3, 10, 11}, rangeSequences(t, page))
+
+			// Paging through the range with continuation tokens.
+			q := SignedVAARangeQuery{EmitterChain: vaa.ChainIDSolana, EmitterAddress: emitter, FromSequence: 2, Limit: 2}
+			var pages [][]uint64
+			for {
+				page, err := db.GetSignedVAARange(q)
+				require.NoError(t, err)
+				pages = append(pages, rangeSequences(t, page))
+				if page.ContinuationToken == "" {
+					break
+				}
+				q.ContinuationToken = page.ContinuationToken
+			}
+			assert.Equal(t, [][]uint64{{2, 3}, {10, 11}, {100}}, pages)
+
+			// A token only continues queries for the same emitter.
+			page, err = db.GetSignedVAARange(SignedVAARangeQuery{EmitterChain: vaa.ChainIDSolana, EmitterAddress: emitter, Limit: 1})
+			require.NoError(t, err)
+			_, err = db.GetSignedVAARange(SignedVAARangeQuery{EmitterChain: vaa.ChainIDSolana, EmitterAddress: other.EmitterAddress, ContinuationToken: page.ContinuationToken})
+			assert.ErrorIs(t, err, ErrInvalidContinuationToken)
+			_, err = db.GetSignedVAARange(SignedVAARangeQuery{EmitterChain: vaa.ChainIDSolana, EmitterAddress: emitter, ContinuationToken: "garbage"})
+			assert.ErrorIs(t, err, ErrInvalidContinuationToken)
+
+			// Pruned VAAs are removed from the index.
+			require.NoError(t, db.PruneVAAs([]VAAID{{EmitterChain: vaa.ChainIDSolana, EmitterAddress: emitter, Sequence: 10}}))
+			page, err = db.GetSignedVAARange(SignedVAARangeQuery{EmitterChain: vaa.ChainIDSolana, EmitterAddress: emitter, FromSequence: 3, ToSequence: 11})
+			require.NoError(t, err)
+			assert.Equal(t, []uint64{3, 11}, rangeSequences(t, page))
+		})
+	}
+}
+
+func TestMigrateVAASequenceIndex(t *testing.T) {
+	db, err := OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer db.Close()
+	privKey, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	require.NoError(t, err)
+
+	// Simulate VAAs stored before the index existed.
+	v := getVAA()
+	v.AddSignature(privKey, 0)
+	b, err := v.Marshal()
+	require.NoError(t, err)
+	require.NoError(t, db.db.Update(func(txn Txn) error {
+		if err := txn.Set(VaaIDFromVAA(&v).Bytes(), b); err != nil {
+			return err
+		}
+		return txn.Delete([]byte(schemaVersionKey))
+	}))
+	q := SignedVAARangeQuery{EmitterChain: v.EmitterChain, EmitterAddress: v.EmitterAddress}
+	page, err := db.GetSignedVAARange(q)
+	require.NoError(t, err)
+	assert.Empty(t, page.VAAs)
+
+	results, err := db.Migrate(zap.NewNop(), false)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), results[0].Version)
+	assert.Equal(t, 1, results[0].Writes)
+	page, err = db.GetSignedVAARange(q)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{b}, page.VAAs)
+
+	// An index built before migrations existed is kept, and only its marker is deleted.
+	require.NoError(t, db.db.Update(func(txn Txn) error {
+		if err := txn.Set([]byte(vaaSequenceIndexBuiltKey), []byte{1}); err != nil {
+			return err
+		}
+		return txn.Delete([]byte(schemaVersionKey))
+	}))
+	results, err = db.Migrate(zap.NewNop(), false)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), results[0].Version)
+	assert.Equal(t, 1, results[0].Writes)
+	page, err = db.GetSignedVAARange(q)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{b}, page.VAAs)
+	require.NoError(t, db.db.View(func(txn Txn) error {
+		_, err := txn.Get([]byte(vaaSequenceIndexBuiltKey))
+		assert.ErrorIs(t, err, ErrKeyNotFound)
+		return nil
+	}))
+}
@@ -0,0 +1,183 @@
+package db
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// verifyBatchSize is the number of repairs written at once.
+const verifyBatchSize = 10000
+
+// VerifyProblem is a kind of inconsistency found by Verify.
+type VerifyProblem string
+
+const (
+	// ProblemCorruptVAA is a signed VAA which does not unmarshal.
+	ProblemCorruptVAA VerifyProblem = "corrupt_vaa"
+	// ProblemMisplacedVAA is a signed VAA stored under the key of another message ID.
+	ProblemMisplacedVAA VerifyProblem = "misplaced_vaa"
+	// ProblemInvalidSignatures is a signed VAA without a quorum of valid signatures of its guardian set.
+	ProblemInvalidSignatures VerifyProblem = "invalid_signatures"
+	// ProblemUnknownGuardianSet is a signed VAA whose guardian set is not known, so its signatures could not be verified.
+	ProblemUnknownGuardianSet VerifyProblem = "unknown_guardian_set"
+	// ProblemMissingIndexEntry is a signed VAA missing from the sequence index.
+	ProblemMissingIndexEntry VerifyProblem = "missing_index_entry"
+	// ProblemDanglingIndexEntry is an entry of the sequence index or of the TTL index without a record.
+	ProblemDanglingIndexEntry VerifyProblem = "dangling_index_entry"
+)
+
+// VerifyOptions configures Verify.
+type VerifyOptions struct {
+	// GuardianSets are the guardian sets the signatures of the VAAs are verified against, by index. If nil, signatures are not
+	// verified.
+	GuardianSets map[uint32]*common.GuardianSet
+	// Repair fixes the problems found. Corrupt VAAs and VAAs with invalid signatures are deleted, misplaced VAAs are moved to
+	// their key, and the indexes are brought in line with the records. VAAs of unknown guardian sets are left alone.
+	Repair bool
+}
+
+// VerifyReport summarizes the problems found by Verify.
+type VerifyReport struct {
+	VAAs     int
+	Problems map[VerifyProblem]int
+	// Repaired is the number of problems fixed.
+	Repaired int
+}
+
+// Verify checks every signed VAA and the consistency of the indexes, and calls onProblem for each problem found with the key
+// concerned. The database should not be written to while it is being verified, since a record written in the meantime can be
+// reported as inconsistent.
+func (d *Database) Verify(opts VerifyOptions, onProblem func(key []byte, problem VerifyProblem, detail string)) (*VerifyReport, error) {
+	report := &VerifyReport{Problems: make(map[VerifyProblem]int)}
+	var repairs []migrationWrite
+	flush := func() error {
+		if len(repairs) == 0 {
+			return nil
+		}
+		if err := d.db.Batch(func(w Writer) error {
+			return applyMigrationWrites(w, repairs)
+		}); err != nil {
+			return fmt.Errorf("failed to write repairs: %w", err)
+		}
+		repairs = repairs[:0]
+		return nil
+	}
+	problem := func(key []byte, p VerifyProblem, detail string, fix ...migrationWrite) error {
+		report.Problems[p]++
+		onProblem(key, p, detail)
+		if !opts.Repair || len(fix) == 0 {
+			return nil
+		}
+		report.Repaired++
+		repairs = append(repairs, fix...)
+		if len(repairs) >= verifyBatchSize {
+			return flush()
+		}
+		return nil
+	}
+	del := func(key []byte) migrationWrite {
+		return migrationWrite{key: append([]byte(nil), key...), delete: true}
+	}
+
+	err := d.db.View(func(txn Txn) error {
+		exists := func(key []byte) (bool, error) {
+			_, err := txn.Get(key)
+			if errors.Is(err, ErrKeyNotFound) {
+				return false, nil
+			}
+			return err == nil, err
+		}
+
+		if err := txn.Iterate([]byte(signedVaaPrefix), func(key []byte, val []byte) error {
+			report.VAAs++
+			id, idErr := VaaIDFromString(strings.TrimPrefix(string(key), signedVaaPrefix))
+			v, err := vaa.Unmarshal(val)
+			if err != nil {
+				fix := []migrationWrite{del(key)}
+				if idErr == nil {
+					fix = append(fix, del(vaaSequenceKey(id)))
+				}
+				return problem(key, ProblemCorruptVAA, err.Error(), fix...)
+			}
+			actual := VaaIDFromVAA(v)
+			if idErr != nil || string(actual.Bytes()) != string(key) {
+				fix := []migrationWrite{del(key)}
+				if idErr == nil {
+					fix = append(fix, del(vaaSequenceKey(id)))
+				}
+				// The VAA is only moved if its own key is free, since a VAA stored there is the one that belongs there.
+				if taken, err := exists(actual.Bytes()); err != nil {
+					return err
+				} else if !taken {
+					fix = append(fix,
+						migrationWrite{key: actual.Bytes(), value: append([]byte(nil), val...)},
+						migrationWrite{key: vaaSequenceKey(actual)},
+					)
+				}
+				return problem(key, ProblemMisplacedVAA, fmt.Sprintf("contains %s", actual.Bytes()), fix...)
+			}
+
+			if opts.GuardianSets != nil {
+				gs, ok := opts.GuardianSets[v.GuardianSetIndex]
+				if !ok {
+					if err := problem(key, ProblemUnknownGuardianSet, fmt.Sprintf("guardian set %d", v.GuardianSetIndex)); err != nil {
+						return err
+					}
+				} else if err := v.Verify(gs.Keys); err != nil {
+					return problem(key, ProblemInvalidSignatures, err.Error(), del(key), del(vaaSequenceKey(id)))
+				}
+			}
+
+			if ok, err := exists(vaaSequenceKey(id)); err != nil {
+				return err
+			} else if !ok {
+				return problem(key, ProblemMissingIndexEntry, "", migrationWrite{key: vaaSequenceKey(id)})
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		if err := txn.IterateKeys([]byte(vaaSequenceIndexPrefix), func(key []byte, _ int64) error {
+			if len(key) != len(vaaSequenceIndexPrefix)+2+32+8 {
+				return problem(key, ProblemDanglingIndexEntry, "invalid sequence index key", del(key))
+			}
+			b := key[len(vaaSequenceIndexPrefix):]
+			id := VAAID{EmitterChain: vaa.ChainID(binary.BigEndian.Uint16(b)), Sequence: binary.BigEndian.Uint64(b[34:])}
+			copy(id.EmitterAddress[:], b[2:34])
+			if ok, err := exists(id.Bytes()); err != nil {
+				return err
+			} else if !ok {
+				return problem(key, ProblemDanglingIndexEntry, fmt.Sprintf("%s is missing", id.Bytes()), del(key))
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		return txn.IterateKeys([]byte(ttlIndexPrefix), func(key []byte, _ int64) error {
+			// The class is followed by a slash and the time, which is 8 bytes long.
+			rest := key[len(ttlIndexPrefix):]
+			slash := strings.IndexByte(string(rest), '/')
+			if slash < 0 || len(rest) < slash+1+8 {
+				return problem(key, ProblemDanglingIndexEntry, "invalid TTL index key", del(key))
+			}
+			record := rest[slash+1+8:]
+			if ok, err := exists(record); err != nil {
+				return err
+			} else if !ok {
+				return problem(key, ProblemDanglingIndexEntry, fmt.Sprintf("%s is missing", record), del(key))
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return report, err
+	}
+	return report, flush()
+}
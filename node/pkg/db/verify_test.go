@@ -0,0 +1,98 @@
+package db
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"testing"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+func TestVerify(t *testing.T) {
+	db, err := OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer db.Close()
+
+	guardian, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	require.NoError(t, err)
+	other, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	require.NoError(t, err)
+	gs := &common.GuardianSet{Index: 1, Keys: []ethcommon.Address{crypto.PubkeyToAddress(guardian.PublicKey)}}
+
+	signed := func(seq uint64, key *ecdsa.PrivateKey, gsIndex uint32) *vaa.VAA {
+		v := getVAA()
+		v.Sequence = seq
+		v.GuardianSetIndex = gsIndex
+		v.AddSignature(key, 0)
+		return &v
+	}
+	valid := signed(1, guardian, 1)
+	forged := signed(2, other, 1)
+	unknownSet := signed(3, guardian, 2)
+	misplaced := signed(4, guardian, 1)
+	noIndex := signed(5, guardian, 1)
+	for _, v := range []*vaa.VAA{valid, forged, unknownSet, noIndex} {
+		require.NoError(t, db.StoreSignedVAA(v))
+	}
+	misplacedBytes, err := misplaced.Marshal()
+	require.NoError(t, err)
+	corruptID := VAAID{EmitterChain: valid.EmitterChain, EmitterAddress: valid.EmitterAddress, Sequence: 6}
+	require.NoError(t, db.db.Update(func(txn Txn) error {
+		if err := txn.Set([]byte("signed/1/0000000000000000000000000000000000000000000000000000000000000004/99"), misplacedBytes); err != nil {
+			return err
+		}
+		if err := txn.Set(corruptID.Bytes(), []byte{1, 2, 3}); err != nil {
+			return err
+		}
+		if err := txn.Delete(vaaSequenceKey(VaaIDFromVAA(noIndex))); err != nil {
+			return err
+		}
+		return txn.Set(vaaSequenceKey(&VAAID{EmitterChain: valid.EmitterChain, EmitterAddress: valid.EmitterAddress, Sequence: 7}), nil)
+	}))
+
+	opts := VerifyOptions{GuardianSets: map[uint32]*common.GuardianSet{1: gs}}
+	expected := map[VerifyProblem]int{
+		ProblemCorruptVAA:         1,
+		ProblemMisplacedVAA:       1,
+		ProblemInvalidSignatures:  1,
+		ProblemUnknownGuardianSet: 1,
+		ProblemMissingIndexEntry:  1,
+		ProblemDanglingIndexEntry: 1,
+	}
+	found := 0
+	report, err := db.Verify(opts, func([]byte, VerifyProblem, string) { found++ })
+	require.NoError(t, err)
+	assert.Equal(t, 6, report.VAAs)
+	assert.Equal(t, expected, report.Problems)
+	assert.Equal(t, 6, found)
+	assert.Zero(t, report.Repaired)
+
+	opts.Repair = true
+	report, err = db.Verify(opts, func([]byte, VerifyProblem, string) {})
+	require.NoError(t, err)
+	assert.Equal(t, expected, report.Problems)
+	assert.Equal(t, 5, report.Repaired)
+
+	// Only the VAA of the unknown guardian set is still reported.
+	report, err = db.Verify(opts, func([]byte, VerifyProblem, string) {})
+	require.NoError(t, err)
+	assert.Equal(t, 4, report.VAAs)
+	assert.Equal(t, map[VerifyProblem]int{ProblemUnknownGuardianSet: 1}, report.Problems)
+	for _, v := range []*vaa.VAA{valid, unknownSet, misplaced, noIndex} {
+		ok, err := db.HasVAA(*VaaIDFromVAA(v))
+		require.NoError(t, err)
+		assert.True(t, ok, "sequence %d", v.Sequence)
+	}
+	ok, err := db.HasVAA(*VaaIDFromVAA(forged))
+	require.NoError(t, err)
+	assert.False(t, ok)
+	page, err := db.GetSignedVAARange(SignedVAARangeQuery{EmitterChain: valid.EmitterChain, EmitterAddress: valid.EmitterAddress})
+	require.NoError(t, err)
+	assert.Len(t, page.VAAs, 4)
+}
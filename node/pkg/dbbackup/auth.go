@@ -0,0 +1,85 @@
+package dbbackup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/defaults"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+// The storage backends requests to the upload URL can be authenticated for.
+const (
+	AuthS3  = "s3"
+	AuthGCS = "gcs"
+)
+
+// gcsScope is the OAuth scope needed to upload and delete objects in a GCS bucket.
+const gcsScope = "https://www.googleapis.com/auth/devstorage.read_write"
+
+// Signer authenticates a request to object storage before it is sent. body is the request body, or nil if it has none.
+type Signer interface {
+	Sign(req *http.Request, body io.ReadSeeker) error
+}
+
+// NewSigner returns a signer for the given backend which takes its credentials from the environment: the default AWS credential
+// chain (environment, shared credentials file or instance role) for S3, and the application default credentials for GCS. region is
+// the bucket's region and is only used for S3.
+func NewSigner(ctx context.Context, backend string, region string) (Signer, error) {
+	switch backend {
+	case AuthS3:
+		if region == "" {
+			return nil, fmt.Errorf("a region is needed to sign requests for %s", AuthS3)
+		}
+		return NewS3Signer(defaults.CredChain(defaults.Config(), defaults.Handlers()), region), nil
+	case AuthGCS:
+		ts, err := google.DefaultTokenSource(ctx, gcsScope)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the application default credentials: %w", err)
+		}
+		return NewGCSSigner(ts), nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q, must be %s or %s", backend, AuthS3, AuthGCS)
+	}
+}
+
+// s3Signer signs requests with AWS Signature Version 4.
+type s3Signer struct {
+	signer *v4.Signer
+	region string
+}
+
+func NewS3Signer(creds *credentials.Credentials, region string) Signer {
+	return &s3Signer{signer: v4.NewSigner(creds), region: region}
+}
+
+func (s *s3Signer) Sign(req *http.Request, body io.ReadSeeker) error {
+	// The signer hashes the body into the signature, and rewinds it once done so it can still be sent.
+	_, err := s.signer.Sign(req, body, "s3", s.region, time.Now())
+	return err
+}
+
+// gcsSigner authenticates requests with an OAuth access token.
+type gcsSigner struct {
+	ts oauth2.TokenSource
+}
+
+func NewGCSSigner(ts oauth2.TokenSource) Signer {
+	// The token is cached until it is about to expire, rather than fetched for each request.
+	return &gcsSigner{ts: oauth2.ReuseTokenSource(nil, ts)}
+}
+
+func (s *gcsSigner) Sign(req *http.Request, _ io.ReadSeeker) error {
+	token, err := s.ts.Token()
+	if err != nil {
+		return fmt.Errorf("failed to get an access token: %w", err)
+	}
+	token.SetAuthHeader(req)
+	return nil
+}
@@ -0,0 +1,421 @@
+// Package dbbackup takes consistent backups of the database on a schedule, optionally uploads them to object storage, and
+// restores them into an empty database.
+package dbbackup
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/db"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+// A backup is a gzip compressed Badger backup of the whole database. Its name records when it was taken, which is all retention
+// needs to know about it.
+const (
+	backupPrefix = "backup-"
+	backupSuffix = ".badger.gz"
+	// uploadedSuffix is appended to the name of a backup to mark it as uploaded. The backup itself is deleted once uploaded.
+	uploadedSuffix = ".uploaded"
+)
+
+var (
+	backupRuns = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "wormhole_db_backups_total",
+			Help: "Total number of database backups taken, by database and result (success or failed)",
+		}, []string{"db", "result"})
+	backupUploadFailures = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "wormhole_db_backup_upload_failures_total",
+			Help: "Total number of database backup uploads that failed, by database",
+		}, []string{"db"})
+	backupSize = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "wormhole_db_backup_size_bytes",
+			Help: "Size of the last database backup, by database",
+		}, []string{"db"})
+	lastBackup = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "wormhole_db_backup_last_success_timestamp_seconds",
+			Help: "Time of the last successful database backup, by database",
+		}, []string{"db"})
+)
+
+type Config struct {
+	// Dir is the directory backups are written to.
+	Dir string
+	// Interval is the time between backups.
+	Interval time.Duration
+	// Retention is how long backups are kept, locally or in object storage. The latest backup is always kept.
+	Retention time.Duration
+	// UploadURL is optional. If set, each backup is uploaded with an HTTP PUT to UploadURL/<backup name> and deleted locally, and
+	// deleted with an HTTP DELETE once it is older than the retention.
+	UploadURL string
+	// Signer authenticates the requests to UploadURL. It must be set if UploadURL is.
+	Signer Signer
+}
+
+type Scheduler struct {
+	logger     *zap.Logger
+	db         *db.Database
+	cfg        Config
+	httpClient *http.Client
+}
+
+func NewScheduler(logger *zap.Logger, database *db.Database, cfg Config) (*Scheduler, error) {
+	if !database.SupportsBackup() {
+		return nil, db.ErrBackupUnsupported
+	}
+	if cfg.Dir == "" {
+		return nil, errors.New("backup directory must be set")
+	}
+	if cfg.Interval <= 0 {
+		return nil, errors.New("interval must be positive")
+	}
+	if cfg.Retention < cfg.Interval {
+		return nil, errors.New("retention must be at least the interval")
+	}
+	if cfg.UploadURL != "" && cfg.Signer == nil {
+		return nil, errors.New("uploads must be authenticated with a signer")
+	}
+	if err := os.MkdirAll(cfg.Dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	return &Scheduler{
+		logger:     logger,
+		db:         database,
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: 30 * time.Minute},
+	}, nil
+}
+
+// Run takes a backup every interval until the context is cancelled. The first backup is only taken once the latest backup is
+// older than the interval, so restarting the guardian does not take a backup each time. Failures are logged and retried on the
+// next run.
+func (s *Scheduler) Run(ctx context.Context) error {
+	wait := time.Duration(0)
+	if backups, err := listBackups(s.cfg.Dir); err != nil {
+		s.logger.Error("failed to list backups", zap.Error(err))
+	} else if len(backups) != 0 {
+		wait = time.Until(backups[len(backups)-1].taken.Add(s.cfg.Interval))
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-timer.C:
+		}
+
+		now := time.Now()
+		if name, size, err := s.Backup(now); err != nil {
+			backupRuns.WithLabelValues(s.db.Name(), "failed").Inc()
+			s.logger.Error("failed to back up the database", zap.Error(err))
+		} else {
+			backupRuns.WithLabelValues(s.db.Name(), "success").Inc()
+			backupSize.WithLabelValues(s.db.Name()).Set(float64(size))
+			lastBackup.WithLabelValues(s.db.Name()).Set(float64(now.Unix()))
+			s.logger.Info("backed up the database", zap.String("backup", name), zap.Int64("size", size), zap.Duration("duration", time.Since(now)))
+		}
+		if s.cfg.UploadURL != "" {
+			s.uploadBackups(ctx)
+		}
+		s.expireBackups(ctx, now)
+
+		timer.Reset(s.cfg.Interval)
+	}
+}
+
+// Backup writes a backup of the database to the backup directory and returns its name and size.
+func (s *Scheduler) Backup(now time.Time) (string, int64, error) {
+	name := backupName(now)
+	// The backup is only given its name once it is complete, so a failed backup is never mistaken for a usable one.
+	f, err := os.CreateTemp(s.cfg.Dir, ".tmp-"+name)
+	if err != nil {
+		return "", 0, err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	bw := bufio.NewWriter(f)
+	if err := WriteBackup(s.db, bw); err != nil {
+		return "", 0, err
+	}
+	if err := bw.Flush(); err != nil {
+		return "", 0, err
+	}
+	if err := f.Sync(); err != nil {
+		return "", 0, err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return "", 0, err
+	}
+	if err := f.Close(); err != nil {
+		return "", 0, err
+	}
+	if err := os.Rename(f.Name(), filepath.Join(s.cfg.Dir, name)); err != nil {
+		return "", 0, err
+	}
+	return name, fi.Size(), nil
+}
+
+// WriteBackup writes a compressed backup of the database to w.
+func WriteBackup(database *db.Database, w io.Writer) error {
+	gw := gzip.NewWriter(w)
+	if err := database.Backup(gw); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+// VerifyBackup reads a backup written by WriteBackup to the end, which checks it is complete and was not corrupted.
+func VerifyBackup(r io.Reader) error {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(io.Discard, gr); err != nil {
+		return err
+	}
+	return gr.Close()
+}
+
+// RestoreBackup loads a backup written by WriteBackup into the database, which must be empty. The backup should be verified
+// with VerifyBackup first, since a backup which turns out to be corrupted leaves the database partially restored.
+func RestoreBackup(database *db.Database, r io.Reader) error {
+	empty, err := database.IsEmpty()
+	if err != nil {
+		return err
+	}
+	if !empty {
+		return errors.New("backups can only be restored into an empty database")
+	}
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	if err := database.LoadBackup(gr); err != nil {
+		return err
+	}
+	return gr.Close()
+}
+
+// Download writes the backup at url to w. signer authenticates the request, and may be nil if the backup is public.
+func Download(ctx context.Context, url string, signer Signer, w io.Writer) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	if signer != nil {
+		if err := signer.Sign(req, nil); err != nil {
+			return err
+		}
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil {
+		return err
+	}
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+// uploadBackups uploads the backups that have not been uploaded yet, and replaces each uploaded backup with a marker file.
+func (s *Scheduler) uploadBackups(ctx context.Context) {
+	backups, err := listBackups(s.cfg.Dir)
+	if err != nil {
+		s.logger.Error("failed to list backups", zap.Error(err))
+		return
+	}
+
+	for _, b := range backups {
+		if b.uploaded {
+			continue
+		}
+		if err := s.upload(ctx, b.name); err != nil {
+			backupUploadFailures.WithLabelValues(s.db.Name()).Inc()
+			s.logger.Error("failed to upload backup", zap.String("backup", b.name), zap.Error(err))
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(s.cfg.Dir, b.name+uploadedSuffix), nil, 0600); err != nil {
+			s.logger.Error("failed to mark backup as uploaded", zap.String("backup", b.name), zap.Error(err))
+			continue
+		}
+		if err := os.Remove(filepath.Join(s.cfg.Dir, b.name)); err != nil {
+			s.logger.Error("failed to delete uploaded backup", zap.String("backup", b.name), zap.Error(err))
+		}
+		s.logger.Info("uploaded backup", zap.String("backup", b.name))
+	}
+}
+
+// expireBackups deletes the backups older than the retention, apart from the latest one.
+func (s *Scheduler) expireBackups(ctx context.Context, now time.Time) {
+	backups, err := listBackups(s.cfg.Dir)
+	if err != nil {
+		s.logger.Error("failed to list backups", zap.Error(err))
+		return
+	}
+
+	for i, b := range backups {
+		if i == len(backups)-1 || !b.taken.Before(now.Add(-s.cfg.Retention)) {
+			continue
+		}
+		path := filepath.Join(s.cfg.Dir, b.name)
+		if b.uploaded {
+			if s.cfg.UploadURL == "" {
+				// Without an upload URL, there is no way to delete the uploaded copy, so it is left to the storage.
+				continue
+			}
+			if err := s.delete(ctx, b.name); err != nil {
+				s.logger.Error("failed to delete expired backup from storage", zap.String("backup", b.name), zap.Error(err))
+				continue
+			}
+			// The local copy is normally deleted on upload, but may have been left behind.
+			if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				s.logger.Error("failed to delete expired backup", zap.String("backup", b.name), zap.Error(err))
+				continue
+			}
+			path += uploadedSuffix
+		}
+		if err := os.Remove(path); err != nil {
+			s.logger.Error("failed to delete expired backup", zap.String("backup", b.name), zap.Error(err))
+			continue
+		}
+		s.logger.Info("deleted expired backup", zap.String("backup", b.name))
+	}
+}
+
+func (s *Scheduler) backupURL(name string) string {
+	return strings.TrimSuffix(s.cfg.UploadURL, "/") + "/" + name
+}
+
+func (s *Scheduler) upload(ctx context.Context, name string) error {
+	f, err := os.Open(filepath.Join(s.cfg.Dir, name))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.backupURL(name), f)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = fi.Size()
+	req.Header.Set("Content-Type", "application/gzip")
+	if err := s.cfg.Signer.Sign(req, f); err != nil {
+		return err
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return checkResponse(resp)
+}
+
+func (s *Scheduler) delete(ctx context.Context, name string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.backupURL(name), nil)
+	if err != nil {
+		return err
+	}
+	if err := s.cfg.Signer.Sign(req, nil); err != nil {
+		return err
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// A backup which is already gone needs no deleting.
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return checkResponse(resp)
+}
+
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected status %s: %s", resp.Status, string(body))
+	}
+	return nil
+}
+
+// backup describes a backup in the backup directory.
+type backup struct {
+	name  string
+	taken time.Time
+	// uploaded is set if only the marker of the backup is left, because it was uploaded.
+	uploaded bool
+}
+
+func backupName(taken time.Time) string {
+	return fmt.Sprintf("%s%d%s", backupPrefix, taken.UnixNano(), backupSuffix)
+}
+
+func parseBackupName(name string) (*backup, bool) {
+	uploaded := strings.HasSuffix(name, uploadedSuffix)
+	name = strings.TrimSuffix(name, uploadedSuffix)
+	if !strings.HasPrefix(name, backupPrefix) || !strings.HasSuffix(name, backupSuffix) {
+		return nil, false
+	}
+	taken, err := strconv.ParseInt(strings.TrimSuffix(strings.TrimPrefix(name, backupPrefix), backupSuffix), 10, 64)
+	if err != nil {
+		return nil, false
+	}
+	return &backup{name: name, taken: time.Unix(0, taken), uploaded: uploaded}, true
+}
+
+// listBackups returns the backups in dir, oldest first. A backup which was uploaded but whose local copy could not be deleted is
+// only listed once, as uploaded.
+func listBackups(dir string) ([]*backup, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*backup)
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		b, ok := parseBackupName(e.Name())
+		if !ok {
+			continue
+		}
+		if _, exists := byName[b.name]; !exists || b.uploaded {
+			byName[b.name] = b
+		}
+	}
+	backups := make([]*backup, 0, len(byName))
+	for _, b := range byName {
+		backups = append(backups, b)
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].taken.Before(backups[j].taken) })
+	return backups, nil
+}
@@ -0,0 +1,271 @@
+package dbbackup
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+	"github.com/certusone/wormhole/node/pkg/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+)
+
+var testEmitter = vaa.Address{1, 2, 3}
+
+func openTestDb(t *testing.T) *db.Database {
+	t.Helper()
+	database, err := db.OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	t.Cleanup(func() { database.Close() })
+	return database
+}
+
+func storeTestVAA(t *testing.T, database *db.Database, sequence uint64) {
+	t.Helper()
+	require.NoError(t, database.StoreSignedVAA(&vaa.VAA{
+		Version:          vaa.SupportedVAAVersion,
+		GuardianSetIndex: 1,
+		Signatures:       []*vaa.Signature{{Index: 0}},
+		Timestamp:        time.Unix(int64(sequence), 0),
+		EmitterChain:     vaa.ChainIDSolana,
+		EmitterAddress:   testEmitter,
+		Sequence:         sequence,
+		Payload:          []byte{byte(sequence)},
+	}))
+}
+
+func TestBackupAndRestore(t *testing.T) {
+	source := openTestDb(t)
+	for seq := uint64(1); seq <= 10; seq++ {
+		storeTestVAA(t, source, seq)
+	}
+	var buf bytes.Buffer
+	require.NoError(t, WriteBackup(source, &buf))
+	require.NoError(t, VerifyBackup(bytes.NewReader(buf.Bytes())))
+
+	target := openTestDb(t)
+	require.NoError(t, RestoreBackup(target, bytes.NewReader(buf.Bytes())))
+	for seq := uint64(1); seq <= 10; seq++ {
+		ok, err := target.HasVAA(db.VAAID{EmitterChain: vaa.ChainIDSolana, EmitterAddress: testEmitter, Sequence: seq})
+		require.NoError(t, err)
+		assert.True(t, ok, "sequence %d", seq)
+	}
+	page, err := target.GetSignedVAARange(db.SignedVAARangeQuery{EmitterChain: vaa.ChainIDSolana, EmitterAddress: testEmitter})
+	require.NoError(t, err)
+	assert.Len(t, page.VAAs, 10)
+
+	// The target is no longer empty.
+	assert.Error(t, RestoreBackup(target, bytes.NewReader(buf.Bytes())))
+
+	// A truncated backup fails verification.
+	assert.Error(t, VerifyBackup(bytes.NewReader(buf.Bytes()[:buf.Len()/2])))
+}
+
+func TestSchedulerUnsupportedBackend(t *testing.T) {
+	database, err := db.OpenBackend(zap.NewNop(), db.BackendLevelDB, "")
+	require.NoError(t, err)
+	defer database.Close()
+	_, err = NewScheduler(zap.NewNop(), database, Config{Dir: t.TempDir(), Interval: time.Hour, Retention: time.Hour})
+	assert.ErrorIs(t, err, db.ErrBackupUnsupported)
+}
+
+// storageStub is an object storage server which only accepts requests that authorized accepts.
+type storageStub struct {
+	mu         sync.Mutex
+	stored     map[string][]byte
+	authorized func(r *http.Request, body []byte) bool
+}
+
+func newStorageStub(t *testing.T, authorized func(r *http.Request, body []byte) bool) (*storageStub, *httptest.Server) {
+	t.Helper()
+	stub := &storageStub{stored: make(map[string][]byte), authorized: authorized}
+	server := httptest.NewServer(stub)
+	t.Cleanup(server.Close)
+	return stub, server
+}
+
+func (s *storageStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if !s.authorized(r, body) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		s.stored[r.URL.Path] = body
+	case http.MethodGet:
+		b, ok := s.stored[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(b)
+	case http.MethodDelete:
+		if _, ok := s.stored[r.URL.Path]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(s.stored, r.URL.Path)
+	}
+}
+
+// s3Authorized checks the request was signed with AWS Signature Version 4 by creds, by signing the signed headers of the request again.
+func s3Authorized(creds *credentials.Credentials, region string) func(r *http.Request, body []byte) bool {
+	return func(r *http.Request, body []byte) bool {
+		auth := r.Header.Get("Authorization")
+		_, signed, ok := strings.Cut(auth, "SignedHeaders=")
+		if !ok {
+			return false
+		}
+		signed, _, _ = strings.Cut(signed, ",")
+		date, err := time.Parse("20060102T150405Z", r.Header.Get("X-Amz-Date"))
+		if err != nil {
+			return false
+		}
+		// The signature covers the hash of the body, so the hash has to match the body.
+		hash := sha256.Sum256(body)
+		if r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(hash[:]) {
+			return false
+		}
+
+		req, err := http.NewRequest(r.Method, "http://"+r.Host+r.URL.RequestURI(), nil)
+		if err != nil {
+			return false
+		}
+		req.ContentLength = r.ContentLength
+		for _, h := range strings.Split(signed, ";") {
+			if h != "host" && h != "content-length" {
+				req.Header[http.CanonicalHeaderKey(h)] = r.Header.Values(h)
+			}
+		}
+		if _, err := v4.NewSigner(creds).Sign(req, bytes.NewReader(body), "s3", region, date); err != nil {
+			return false
+		}
+		return req.Header.Get("Authorization") == auth
+	}
+}
+
+func testUploadAndExpire(t *testing.T, stub *storageStub, server *httptest.Server, signer Signer) {
+	database := openTestDb(t)
+	storeTestVAA(t, database, 1)
+	dir := t.TempDir()
+	s, err := NewScheduler(zap.NewNop(), database, Config{Dir: dir, Interval: time.Hour, Retention: 48 * time.Hour, UploadURL: server.URL + "/backups/", Signer: signer})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	start := time.Unix(1700000000, 0)
+	var names []string
+	for i := 0; i < 4; i++ {
+		now := start.Add(time.Duration(i) * 24 * time.Hour)
+		name, size, err := s.Backup(now)
+		require.NoError(t, err)
+		assert.NotZero(t, size)
+		names = append(names, name)
+		s.uploadBackups(ctx)
+		s.expireBackups(ctx, now)
+	}
+
+	// Only the backups within the retention are left in storage, and none is left locally apart from the markers.
+	stub.mu.Lock()
+	assert.Len(t, stub.stored, 3)
+	assert.NotContains(t, stub.stored, "/backups/"+names[0])
+	stub.mu.Unlock()
+	backups, err := listBackups(dir)
+	require.NoError(t, err)
+	require.Len(t, backups, 3)
+	for _, b := range backups {
+		assert.True(t, b.uploaded)
+		_, err := os.Stat(filepath.Join(dir, b.name))
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	}
+
+	// The uploaded backup can be downloaded and restored.
+	var buf bytes.Buffer
+	require.NoError(t, Download(ctx, server.URL+"/backups/"+names[3], signer, &buf))
+	target := openTestDb(t)
+	require.NoError(t, RestoreBackup(target, &buf))
+	ok, err := target.HasVAA(db.VAAID{EmitterChain: vaa.ChainIDSolana, EmitterAddress: testEmitter, Sequence: 1})
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestSchedulerUploadAndExpireS3(t *testing.T) {
+	creds := credentials.NewStaticCredentials("AKIDEXAMPLE", "secret", "")
+	stub, server := newStorageStub(t, s3Authorized(creds, "us-east-1"))
+	testUploadAndExpire(t, stub, server, NewS3Signer(creds, "us-east-1"))
+}
+
+func TestSchedulerUploadAndExpireGCS(t *testing.T) {
+	stub, server := newStorageStub(t, func(r *http.Request, _ []byte) bool {
+		return r.Header.Get("Authorization") == "Bearer test-token"
+	})
+	testUploadAndExpire(t, stub, server, NewGCSSigner(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"})))
+}
+
+func TestSchedulerRejectedUpload(t *testing.T) {
+	stub, server := newStorageStub(t, s3Authorized(credentials.NewStaticCredentials("AKIDEXAMPLE", "secret", ""), "us-east-1"))
+
+	database := openTestDb(t)
+	dir := t.TempDir()
+
+	// Uploads without a signer are refused outright.
+	_, err := NewScheduler(zap.NewNop(), database, Config{Dir: dir, Interval: time.Hour, Retention: time.Hour, UploadURL: server.URL})
+	assert.Error(t, err)
+
+	// A backup signed with the wrong secret is rejected by the storage, and kept locally to be uploaded again.
+	signer := NewS3Signer(credentials.NewStaticCredentials("AKIDEXAMPLE", "wrong", ""), "us-east-1")
+	s, err := NewScheduler(zap.NewNop(), database, Config{Dir: dir, Interval: time.Hour, Retention: time.Hour, UploadURL: server.URL, Signer: signer})
+	require.NoError(t, err)
+	name, _, err := s.Backup(time.Unix(1700000000, 0))
+	require.NoError(t, err)
+	s.uploadBackups(context.Background())
+
+	stub.mu.Lock()
+	assert.Empty(t, stub.stored)
+	stub.mu.Unlock()
+	_, err = os.Stat(filepath.Join(dir, name))
+	assert.NoError(t, err)
+	assert.Error(t, s.delete(context.Background(), name))
+}
+
+func TestExpireKeepsLatestBackup(t *testing.T) {
+	database := openTestDb(t)
+	dir := t.TempDir()
+	s, err := NewScheduler(zap.NewNop(), database, Config{Dir: dir, Interval: time.Hour, Retention: time.Hour})
+	require.NoError(t, err)
+
+	taken := time.Unix(1700000000, 0)
+	_, _, err = s.Backup(taken.Add(-time.Hour))
+	require.NoError(t, err)
+	name, _, err := s.Backup(taken)
+	require.NoError(t, err)
+
+	// Both backups are expired, but the latest one is kept since it is the only one left.
+	s.expireBackups(context.Background(), taken.Add(30*24*time.Hour))
+	backups, err := listBackups(dir)
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	assert.Equal(t, name, backups[0].name)
+	assert.False(t, backups[0].uploaded)
+}
@@ -0,0 +1,142 @@
+package dbreplication
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	nodev1 "github.com/certusone/wormhole/node/pkg/proto/node/v1"
+	"github.com/certusone/wormhole/node/pkg/readiness"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// ReadinessComponent is ready once a replica has caught up with its source for the first time.
+const ReadinessComponent readiness.Component = "dbReplicaCaughtUp"
+
+// DefaultResyncLookback is how far before its checkpoint a replica asks its source to backfill from when it reconnects.
+const DefaultResyncLookback = 24 * time.Hour
+
+var (
+	replicaReceivedVAAs = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "wormhole_db_replica_received_vaas_total",
+			Help: "Total number of signed VAAs received from the replication source, by whether they were stored, already present or rejected",
+		}, []string{"result"})
+	replicaCheckpoint = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "wormhole_db_replica_checkpoint_timestamp_seconds",
+			Help: "UNIX time up to which the replica has every VAA stored by its source",
+		})
+)
+
+// Replica copies the signed VAAs stored by a guardian into a local database.
+type Replica struct {
+	logger *zap.Logger
+	db     *db.Database
+	source string
+	tls    *tls.Config
+	// guardianSets are the guardian sets the VAAs received are verified against, by index.
+	guardianSets map[uint32]*common.GuardianSet
+	// resyncLookback is subtracted from the checkpoint when reconnecting, because a VAA can be stored long after its timestamp,
+	// for instance when a missed message is re-observed.
+	resyncLookback time.Duration
+}
+
+// NewReplica returns a replica of the database of the guardian whose DatabaseReplicationService listens on source. It connects with
+// tlsConfig, see ClientTLSConfig, and only stores the VAAs signed by a quorum of their guardian set in guardianSets.
+func NewReplica(logger *zap.Logger, database *db.Database, source string, tlsConfig *tls.Config, guardianSets map[uint32]*common.GuardianSet, resyncLookback time.Duration) *Replica {
+	return &Replica{logger: logger, db: database, source: source, tls: tlsConfig, guardianSets: guardianSets, resyncLookback: resyncLookback}
+}
+
+// Run streams VAAs from the source until ctx is cancelled. It returns an error when the stream breaks, so that the supervisor
+// reconnects after a backoff.
+func (r *Replica) Run(ctx context.Context) error {
+	checkpoint, err := r.db.GetReplicationCheckpoint()
+	if err != nil {
+		return fmt.Errorf("failed to read replication checkpoint: %w", err)
+	}
+	req := &nodev1.StreamSignedVAAsRequest{}
+	if !checkpoint.IsZero() {
+		req.Since = checkpoint.Add(-r.resyncLookback).Unix()
+		replicaCheckpoint.Set(float64(checkpoint.Unix()))
+	}
+
+	conn, err := grpc.DialContext(ctx, r.source, grpc.WithTransportCredentials(credentials.NewTLS(r.tls)))
+	if err != nil {
+		return fmt.Errorf("failed to connect to replication source %s: %w", r.source, err)
+	}
+	defer conn.Close()
+
+	stream, err := nodev1.NewDatabaseReplicationServiceClient(conn).StreamSignedVAAs(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to start replication: %w", err)
+	}
+	r.logger.Info("replicating signed VAAs", zap.String("source", r.source), zap.Time("checkpoint", checkpoint), zap.Int64("since", req.Since))
+
+	caughtUp := false
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("replication stream failed: %w", err)
+		}
+		switch m := resp.Message.(type) {
+		case *nodev1.StreamSignedVAAsResponse_Vaa:
+			if err := r.store(m.Vaa); err != nil {
+				return err
+			}
+		case *nodev1.StreamSignedVAAsResponse_CaughtUp:
+			t := time.Unix(m.CaughtUp, 0)
+			if err := r.db.StoreReplicationCheckpoint(t); err != nil {
+				return fmt.Errorf("failed to store replication checkpoint: %w", err)
+			}
+			replicaCheckpoint.Set(float64(m.CaughtUp))
+			if !caughtUp {
+				r.logger.Info("caught up with replication source", zap.Time("checkpoint", t))
+				readiness.SetReady(ReadinessComponent)
+				caughtUp = true
+			}
+		}
+	}
+}
+
+// store stores a VAA unless it is already present. A VAA which is not signed by a quorum of its guardian set fails the stream rather
+// than being skipped, so that the checkpoint does not move past it.
+func (r *Replica) store(b []byte) error {
+	v, err := vaa.Unmarshal(b)
+	if err != nil {
+		return fmt.Errorf("received invalid VAA from replication source: %w", err)
+	}
+	gs, ok := r.guardianSets[v.GuardianSetIndex]
+	if !ok {
+		replicaReceivedVAAs.WithLabelValues("rejected").Inc()
+		return fmt.Errorf("received VAA %s of unknown guardian set %d from replication source", v.MessageID(), v.GuardianSetIndex)
+	}
+	if err := v.Verify(gs.Keys); err != nil {
+		replicaReceivedVAAs.WithLabelValues("rejected").Inc()
+		return fmt.Errorf("received VAA %s with invalid signatures from replication source: %w", v.MessageID(), err)
+	}
+	exists, err := r.db.HasVAA(*db.VaaIDFromVAA(v))
+	if err != nil {
+		return fmt.Errorf("failed to look up VAA %s: %w", v.MessageID(), err)
+	}
+	if exists {
+		replicaReceivedVAAs.WithLabelValues("existing").Inc()
+		return nil
+	}
+	if err := r.db.StoreSignedVAA(v); err != nil {
+		return fmt.Errorf("failed to store VAA %s: %w", v.MessageID(), err)
+	}
+	replicaReceivedVAAs.WithLabelValues("stored").Inc()
+	return nil
+}
@@ -0,0 +1,191 @@
+package dbreplication
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	nodev1 "github.com/certusone/wormhole/node/pkg/proto/node/v1"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+func storeTestVAA(t *testing.T, database *db.Database, key *ecdsa.PrivateKey, seq uint64) *vaa.VAA {
+	t.Helper()
+	v := &vaa.VAA{
+		Version:          vaa.SupportedVAAVersion,
+		GuardianSetIndex: 0,
+		Timestamp:        time.Unix(int64(1000+seq), 0),
+		Nonce:            1,
+		Sequence:         seq,
+		ConsistencyLevel: 32,
+		EmitterChain:     vaa.ChainIDSolana,
+		EmitterAddress:   vaa.Address{4},
+		Payload:          []byte{1, 2, 3},
+	}
+	v.AddSignature(key, 0)
+	require.NoError(t, database.StoreSignedVAA(v))
+	return v
+}
+
+// writeTestCert writes a self-signed certificate for 127.0.0.1 and its key to dir, and returns their paths.
+func writeTestCert(t *testing.T, dir string, name string) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, name+".crt")
+	keyFile := filepath.Join(dir, name+".key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+	return certFile, keyFile
+}
+
+// startTestServer serves the replication service of source over TLS, requiring the client certificates signed by clientCAFile.
+func startTestServer(t *testing.T, source *db.Database, certFile, keyFile, clientCAFile string) string {
+	t.Helper()
+	tlsConfig, err := ServerTLSConfig(certFile, keyFile, clientCAFile)
+	require.NoError(t, err)
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsConfig)))
+	nodev1.RegisterDatabaseReplicationServiceServer(server, NewServer(zap.NewNop(), source))
+	go func() { _ = server.Serve(l) }()
+	t.Cleanup(server.Stop)
+	return l.Addr().String()
+}
+
+func TestReplica(t *testing.T) {
+	key, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	require.NoError(t, err)
+	guardianSets := map[uint32]*common.GuardianSet{0: {Index: 0, Keys: []ethcommon.Address{crypto.PubkeyToAddress(key.PublicKey)}}}
+
+	source, err := db.OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer source.Close()
+	backfilled := storeTestVAA(t, source, key, 1)
+
+	dir := t.TempDir()
+	serverCert, serverKey := writeTestCert(t, dir, "server")
+	clientCert, clientKey := writeTestCert(t, dir, "client")
+	addr := startTestServer(t, source, serverCert, serverKey, clientCert)
+	tlsConfig, err := ClientTLSConfig(serverCert, clientCert, clientKey)
+	require.NoError(t, err)
+
+	replicaDb, err := db.OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer replicaDb.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	errC := make(chan error, 1)
+	go func() {
+		errC <- NewReplica(zap.NewNop(), replicaDb, addr, tlsConfig, guardianSets, DefaultResyncLookback).Run(ctx)
+	}()
+
+	hasVAA := func(v *vaa.VAA) func() bool {
+		return func() bool {
+			exists, err := replicaDb.HasVAA(*db.VaaIDFromVAA(v))
+			require.NoError(t, err)
+			return exists
+		}
+	}
+
+	// The replica backfills the stored VAAs, records its checkpoint, then receives new VAAs as they are stored.
+	require.Eventually(t, hasVAA(backfilled), 5*time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool {
+		checkpoint, err := replicaDb.GetReplicationCheckpoint()
+		require.NoError(t, err)
+		return !checkpoint.IsZero()
+	}, 5*time.Second, 10*time.Millisecond)
+	live := storeTestVAA(t, source, key, 2)
+	require.Eventually(t, hasVAA(live), 5*time.Second, 10*time.Millisecond)
+
+	expected, err := live.Marshal()
+	require.NoError(t, err)
+	b, err := replicaDb.GetSignedVAABytes(*db.VaaIDFromVAA(live))
+	require.NoError(t, err)
+	assert.Equal(t, expected, b)
+
+	cancel()
+	assert.NoError(t, <-errC)
+}
+
+func TestReplicaRejects(t *testing.T) {
+	key, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	serverCert, serverKey := writeTestCert(t, dir, "server")
+	clientCert, clientKey := writeTestCert(t, dir, "client")
+	strangerCert, strangerKey := writeTestCert(t, dir, "stranger")
+
+	tests := []struct {
+		name         string
+		signer       *ecdsa.PrivateKey
+		guardianSets map[uint32]*common.GuardianSet
+		certFile     string
+		keyFile      string
+		errMsg       string
+	}{
+		{"invalid signature", otherKey, map[uint32]*common.GuardianSet{0: {Keys: []ethcommon.Address{crypto.PubkeyToAddress(key.PublicKey)}}}, clientCert, clientKey, "invalid signatures"},
+		{"unknown guardian set", key, map[uint32]*common.GuardianSet{1: {Index: 1, Keys: []ethcommon.Address{crypto.PubkeyToAddress(key.PublicKey)}}}, clientCert, clientKey, "unknown guardian set 0"},
+		{"unknown client", key, map[uint32]*common.GuardianSet{0: {Keys: []ethcommon.Address{crypto.PubkeyToAddress(key.PublicKey)}}}, strangerCert, strangerKey, "replication"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			source, err := db.OpenInMemory(zap.NewNop())
+			require.NoError(t, err)
+			defer source.Close()
+			v := storeTestVAA(t, source, tc.signer, 1)
+			addr := startTestServer(t, source, serverCert, serverKey, clientCert)
+			tlsConfig, err := ClientTLSConfig(serverCert, tc.certFile, tc.keyFile)
+			require.NoError(t, err)
+
+			replicaDb, err := db.OpenInMemory(zap.NewNop())
+			require.NoError(t, err)
+			defer replicaDb.Close()
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			err = NewReplica(zap.NewNop(), replicaDb, addr, tlsConfig, tc.guardianSets, DefaultResyncLookback).Run(ctx)
+			assert.ErrorContains(t, err, tc.errMsg)
+
+			exists, err := replicaDb.HasVAA(*db.VaaIDFromVAA(v))
+			require.NoError(t, err)
+			assert.False(t, exists)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Package dbreplication keeps read-only replicas of a guardian's database of signed VAAs. A guardian streams the VAAs it stores to
+// its replicas, which store them in their own database and serve them to public RPC clients, so that heavy GetSignedVAA traffic
+// never reaches the signing guardian's database.
+package dbreplication
+
+import (
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	nodev1 "github.com/certusone/wormhole/node/pkg/proto/node/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// StreamBufferSize is the number of newly stored VAAs buffered for each replica. A replica which falls further behind, for
+	// instance because the backfill takes too long on a busy guardian, is disconnected and has to reconnect.
+	StreamBufferSize = 10000
+
+	// checkpointInterval is how often a replica that is caught up is told the time up to which it has every VAA.
+	checkpointInterval = time.Minute
+)
+
+var (
+	replicationStreams = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "wormhole_db_replication_streams",
+			Help: "Current number of replicas streaming signed VAAs from this guardian",
+		})
+	replicationSentVAAs = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "wormhole_db_replication_sent_vaas_total",
+			Help: "Total number of signed VAAs sent to replicas",
+		})
+	replicationDroppedStreams = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "wormhole_db_replication_dropped_streams_total",
+			Help: "Total number of replica streams closed because the replica fell behind",
+		})
+)
+
+// Server implements the DatabaseReplicationService.
+type Server struct {
+	nodev1.UnsafeDatabaseReplicationServiceServer
+	logger *zap.Logger
+	db     *db.Database
+}
+
+func NewServer(logger *zap.Logger, database *db.Database) *Server {
+	return &Server{logger: logger, db: database}
+}
+
+func (s *Server) StreamSignedVAAs(req *nodev1.StreamSignedVAAsRequest, stream nodev1.DatabaseReplicationService_StreamSignedVAAsServer) error {
+	// Subscribing before the backfill means that every VAA stored from now on is either in the backfill or in the feed, or in
+	// both, which the replica handles.
+	start := time.Now()
+	updateC, unsubscribe := s.db.SubscribeSignedVAAs(StreamBufferSize)
+	defer unsubscribe()
+
+	replicationStreams.Inc()
+	defer replicationStreams.Dec()
+
+	filter := db.VAAFilter{}
+	if req.Since > 0 {
+		filter.From = time.Unix(req.Since, 0)
+	}
+	backfilled := 0
+	if err := s.db.ForEachSignedVAA(filter, func(b []byte) error {
+		if err := sendVAA(stream, b); err != nil {
+			return err
+		}
+		backfilled++
+		return nil
+	}); err != nil {
+		return err
+	}
+	s.logger.Info("backfilled replica", zap.Int("vaas", backfilled), zap.Time("since", filter.From), zap.Duration("took", time.Since(start)))
+	if err := sendCheckpoint(stream, start); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(checkpointInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case b, ok := <-updateC:
+			if !ok {
+				replicationDroppedStreams.Inc()
+				return common.NewGrpcError(codes.ResourceExhausted, common.ReasonQueueFull, "the replica fell behind the VAAs being stored")
+			}
+			if err := sendVAA(stream, b); err != nil {
+				return err
+			}
+		case <-ticker.C:
+			// VAAs are sent in the order they are stored, so the replica has every VAA stored before the ones still queued.
+			if len(updateC) == 0 {
+				if err := sendCheckpoint(stream, time.Now()); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+func sendVAA(stream nodev1.DatabaseReplicationService_StreamSignedVAAsServer, b []byte) error {
+	if err := stream.Send(&nodev1.StreamSignedVAAsResponse{Message: &nodev1.StreamSignedVAAsResponse_Vaa{Vaa: b}}); err != nil {
+		return err
+	}
+	replicationSentVAAs.Inc()
+	return nil
+}
+
+func sendCheckpoint(stream nodev1.DatabaseReplicationService_StreamSignedVAAsServer, t time.Time) error {
+	return stream.Send(&nodev1.StreamSignedVAAsResponse{Message: &nodev1.StreamSignedVAAsResponse_CaughtUp{CaughtUp: t.Unix()}})
+}
@@ -2,7 +2,8 @@
 // It works by tracking transfers (types one and three) for a configured set of tokens from a configured set of emitters (chains).
 //
 // To compute the notional value of a transfer, the governor uses the amount from the transfer multiplied by the maximum of
-// a hard coded price and the latest price pulled from CoinkGecko (every five minutes). Once a transfer is published,
+// a hard coded price and the latest price pulled from CoinkGecko (every five minutes), or the median of that and the prices read
+// from on chain oracle accounts if those are configured (see governor_oracle.go). Once a transfer is published,
 // its value (as factored into the daily total) is fixed. However the value of pending transfers is computed using the latest price each interval.
 //
 // The governor maintains a rolling 24 hour window of transfers that have been received from a configured chain (emitter)
@@ -15,12 +16,16 @@
 //
 // All completed transfers from the last 24 hours and all pending transfers are stored in the Badger DB, and reloaded on start up.
 //
-// The chain governor supports admin client commands as documented in governor_cmd.go.
+// The chain governor supports admin client commands as documented in governor_monitoring.go. Releasing large pending transfers
+// can be made to require the approvals of several admins (see governor_approvals.go).
 //
 // The set of tokens to be monitored is specified in tokens.go, which can be auto generated using the tool in node/hack/governor. See the README there.
 //
 // The set of chains to be monitored is specified in chains.go, which can be edited by hand.
 //
+// Instead of the compiled in lists, the tokens and chains can be loaded from a signed config file, which is reloaded when it changes
+// (see governor_config_file.go).
+//
 // To enable the chain governor, you must specified the --chainGovernorEnabled guardiand command line argument.
 
 package governor
@@ -36,6 +41,8 @@ import (
 
 	"github.com/certusone/wormhole/node/pkg/common"
 	"github.com/certusone/wormhole/node/pkg/db"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/gagliardetto/solana-go"
 	"github.com/wormhole-foundation/wormhole/sdk"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 
@@ -83,6 +90,7 @@ type (
 		cfgPrice       *big.Float
 		coinGeckoPrice *big.Float
 		priceTime      time.Time
+		oraclePrices   []*big.Float // The fresh prices read from the oracle accounts of the token on the last oracle query.
 	}
 
 	// Payload for each enqueued transfer
@@ -91,6 +99,10 @@ type (
 		amount *big.Int
 		hash   string
 		dbData db.PendingTransfer // This info gets persisted in the DB.
+
+		approvals []*db.ReleaseApproval // The approvals to release it early, see governor_approvals.go.
+		shadow    bool                  // Set if it was published already and is only tracked in shadow mode, see governor_shadow.go.
+		reason    string                // Why it was enqueued, e.g. "daily_limit". Not persisted, see enqueueReasonAlreadyLocked.
 	}
 
 	// Payload of the map of chains being monitored
@@ -101,6 +113,10 @@ type (
 		bigTransactionSize      uint64
 		checkForBigTransactions bool
 
+		// The limits from the config, which are enforced unless they were changed at runtime.
+		cfgDailyLimit         uint64
+		cfgBigTransactionSize uint64
+
 		transfers []*db.Transfer
 		pending   []*pendingEntry
 	}
@@ -110,6 +126,12 @@ func (ce *chainEntry) isBigTransfer(value uint64) bool {
 	return value >= ce.bigTransactionSize && ce.checkForBigTransactions
 }
 
+func (ce *chainEntry) setLimits(dailyLimit uint64, bigTransactionSize uint64) {
+	ce.dailyLimit = dailyLimit
+	ce.bigTransactionSize = bigTransactionSize
+	ce.checkForBigTransactions = bigTransactionSize != 0
+}
+
 type ChainGovernor struct {
 	db                    db.GovernorDB // protected by `mutex`
 	logger                *zap.Logger
@@ -126,6 +148,41 @@ type ChainGovernor struct {
 	nextConfigPublishTime time.Time
 	statusPublishCounter  int64
 	configPublishCounter  int64
+
+	// configFile is the signed token and chain config file, if one was configured with UseConfigFile.
+	configFile    string
+	configSigners []ethcommon.Address
+
+	// priceOracle reads the oracle accounts in priceOracles, if it was configured with UsePriceOracle.
+	priceOracle       PriceOracleQuerier
+	priceOracles      map[string][]solana.PublicKey // protected by `mutex`
+	coinGeckoDisabled bool
+
+	// limitOverrides are the limits changed at runtime, which are persisted and take precedence over the config.
+	limitOverrides map[vaa.ChainID]db.ChainLimit // protected by `mutex`
+	// limitVAATimes are the timestamps of the last governance VAA applied to each chain, used to reject replayed VAAs.
+	limitVAATimes map[vaa.ChainID]time.Time // protected by `mutex`
+
+	// Releasing a pending transfer worth at least releaseApprovalThreshold requires releaseApprovals approvers, if set with
+	// RequireReleaseApprovals.
+	releaseApprovalThreshold uint64
+	releaseApprovals         int
+
+	// shadow is set with UseShadowMode, in which case transfers are never held back.
+	shadow bool
+
+	// denylist holds the routes whose transfers are always enqueued for manual review.
+	denylist map[denylistKey]bool // protected by `mutex`
+
+	// configVersion is the Version of the config file the governor enforces, or empty if it uses the built-in config.
+	configVersion string // protected by `mutex`
+
+	// configFileVersion is the highest Version field of a config file the governor applied, which is persisted so that an older
+	// config file is never applied again.
+	configFileVersion uint64 // protected by `mutex`
+
+	// notifier posts the governor events to a webhook, if one was configured with UseWebhook.
+	notifier *webhookNotifier
 }
 
 func NewChainGovernor(
@@ -156,11 +213,25 @@ func (gov *ChainGovernor) Run(ctx context.Context) error {
 			return err
 		}
 
-		if err := gov.initCoinGecko(ctx, true); err != nil {
+		if !gov.coinGeckoDisabled {
+			if err := gov.initCoinGecko(ctx, true); err != nil {
+				return err
+			}
+		}
+
+		if err := gov.startPriceOracle(ctx); err != nil {
 			return err
 		}
 	}
 
+	if err := gov.startConfigWatcher(ctx); err != nil {
+		return err
+	}
+
+	if err := gov.startNotifier(ctx); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -178,10 +249,57 @@ func (gov *ChainGovernor) initConfig() error {
 		configTokens, configChains = gov.initTestnetConfig()
 	}
 
+	var denylist map[denylistKey]bool
+	var configFileVersion uint64
+	if gov.configFile != "" {
+		cfg, version, err := readConfigFile(gov.configFile, gov.configSigners)
+		if err != nil {
+			return err
+		}
+		if gov.db != nil {
+			if gov.configFileVersion, err = gov.db.GetGovernorConfigVersion(); err != nil {
+				return fmt.Errorf("failed to load the config version: %w", err)
+			}
+		}
+		if err := gov.checkConfigVersion(cfg.Version); err != nil {
+			return err
+		}
+		configTokens, configChains = cfg.entries()
+		gov.priceOracles = cfg.oracleAccounts()
+		denylist = cfg.denylistEntries()
+		gov.configVersion = version
+		configFileVersion = cfg.Version
+		gov.logger.Info("using the token and chain config from the config file", zap.String("configFile", gov.configFile),
+			zap.Uint64("version", cfg.Version))
+	}
+
+	tokens, tokensByCoinGeckoId, chains, err := gov.buildConfig(configTokens, configChains)
+	if err != nil {
+		return err
+	}
+	if err := checkDenylist(denylist, tokens); err != nil {
+		return err
+	}
+	if err := gov.storeConfigVersion(configFileVersion); err != nil {
+		return err
+	}
+	gov.denylist = denylist
+	gov.tokens = tokens
+	gov.tokensByCoinGeckoId = tokensByCoinGeckoId
+	gov.chains = chains
+	return nil
+}
+
+// buildConfig creates the token and chain entries for a config, without touching the running config.
+func (gov *ChainGovernor) buildConfig(configTokens []tokenConfigEntry, configChains []chainConfigEntry) (map[tokenKey]*tokenEntry, map[string][]*tokenEntry, map[vaa.ChainID]*chainEntry, error) {
+	tokens := make(map[tokenKey]*tokenEntry)
+	tokensByCoinGeckoId := make(map[string][]*tokenEntry)
+	chains := make(map[vaa.ChainID]*chainEntry)
+
 	for _, ct := range configTokens {
 		addr, err := vaa.StringToAddress(ct.addr)
 		if err != nil {
-			return fmt.Errorf("invalid address: %s", ct.addr)
+			return nil, nil, nil, fmt.Errorf("invalid address: %s", ct.addr)
 		}
 
 		cfgPrice := big.NewFloat(ct.price)
@@ -207,16 +325,10 @@ func (gov *ChainGovernor) initConfig() error {
 		te := &tokenEntry{cfgPrice: cfgPrice, price: initialPrice, decimals: decimals, symbol: symbol, coinGeckoId: ct.coinGeckoId, token: key}
 		te.updatePrice()
 
-		gov.tokens[key] = te
+		tokens[key] = te
 
 		// Multiple tokens can share a CoinGecko price, so we keep an array of tokens per CoinGecko ID.
-		cge, cgExists := gov.tokensByCoinGeckoId[te.coinGeckoId]
-		if !cgExists {
-			gov.tokensByCoinGeckoId[te.coinGeckoId] = []*tokenEntry{te}
-		} else {
-			cge = append(cge, te)
-			gov.tokensByCoinGeckoId[te.coinGeckoId] = cge
-		}
+		tokensByCoinGeckoId[te.coinGeckoId] = append(tokensByCoinGeckoId[te.coinGeckoId], te)
 
 		if gov.env != common.GoTest {
 			gov.logger.Info("will monitor token:", zap.Stringer("chain", key.chain),
@@ -230,8 +342,8 @@ func (gov *ChainGovernor) initConfig() error {
 		}
 	}
 
-	if len(gov.tokens) == 0 {
-		return fmt.Errorf("no tokens are configured")
+	if len(tokens) == 0 {
+		return nil, nil, nil, fmt.Errorf("no tokens are configured")
 	}
 
 	emitterMap := &sdk.KnownTokenbridgeEmitters
@@ -247,12 +359,12 @@ func (gov *ChainGovernor) initConfig() error {
 
 		emitterAddrBytes, exists := (*emitterMap)[cc.emitterChainID]
 		if !exists {
-			return fmt.Errorf("failed to look up token bridge emitter address for chain: %v", cc.emitterChainID)
+			return nil, nil, nil, fmt.Errorf("failed to look up token bridge emitter address for chain: %v", cc.emitterChainID)
 		}
 
 		emitterAddr, err = vaa.BytesToAddress(emitterAddrBytes)
 		if err != nil {
-			return fmt.Errorf("failed to convert emitter address for chain: %v", cc.emitterChainID)
+			return nil, nil, nil, fmt.Errorf("failed to convert emitter address for chain: %v", cc.emitterChainID)
 		}
 
 		ce := &chainEntry{
@@ -261,6 +373,8 @@ func (gov *ChainGovernor) initConfig() error {
 			dailyLimit:              cc.dailyLimit,
 			bigTransactionSize:      cc.bigTransactionSize,
 			checkForBigTransactions: cc.bigTransactionSize != 0,
+			cfgDailyLimit:           cc.dailyLimit,
+			cfgBigTransactionSize:   cc.bigTransactionSize,
 		}
 
 		if gov.env != common.GoTest {
@@ -272,14 +386,14 @@ func (gov *ChainGovernor) initConfig() error {
 			)
 		}
 
-		gov.chains[cc.emitterChainID] = ce
+		chains[cc.emitterChainID] = ce
 	}
 
-	if len(gov.chains) == 0 {
-		return fmt.Errorf("no chains are configured")
+	if len(chains) == 0 {
+		return nil, nil, nil, fmt.Errorf("no chains are configured")
 	}
 
-	return nil
+	return tokens, tokensByCoinGeckoId, chains, nil
 }
 
 // Returns true if the message can be published, false if it has been added to the pending list.
@@ -313,6 +427,15 @@ func (gov *ChainGovernor) ProcessMsgForTime(msg *common.MessagePublication, now
 	hash := gov.HashFromMsg(msg)
 	xferComplete, alreadySeen := gov.msgsSeen[hash]
 	if alreadySeen {
+		if !xferComplete && gov.shadow {
+			gov.logger.Info("allowing duplicate vaa to be published again because the governor is in shadow mode",
+				zap.String("msgID", msg.MessageIDString()),
+				zap.String("hash", hash),
+				zap.Stringer("txHash", msg.TxHash),
+			)
+			return true, nil
+		}
+
 		if !xferComplete {
 			gov.logger.Info("ignoring duplicate vaa because it is enqueued",
 				zap.String("msgID", msg.MessageIDString()),
@@ -367,8 +490,21 @@ func (gov *ChainGovernor) ProcessMsgForTime(msg *common.MessagePublication, now
 
 	enqueueIt := false
 	var releaseTime time.Time
-	if ce.isBigTransfer(value) {
+	var enqueueReason string
+	if gov.isDeniedAlreadyLocked(token.token, payload.TargetChain) {
+		enqueueIt = true
+		enqueueReason = "denylist"
+		releaseTime = now.Add(maxEnqueuedTime)
+		gov.logger.Error("enqueuing vaa for manual review because its route is denylisted",
+			zap.Uint64("value", value),
+			zap.String("msgID", msg.MessageIDString()),
+			zap.Stringer("targetChain", payload.TargetChain),
+			zap.String("hash", hash),
+			zap.Stringer("txHash", msg.TxHash),
+		)
+	} else if ce.isBigTransfer(value) {
 		enqueueIt = true
+		enqueueReason = "big_transaction"
 		releaseTime = now.Add(maxEnqueuedTime)
 		gov.logger.Error("enqueuing vaa because it is a big transaction",
 			zap.Uint64("value", value),
@@ -382,6 +518,7 @@ func (gov *ChainGovernor) ProcessMsgForTime(msg *common.MessagePublication, now
 		)
 	} else if newTotalValue > ce.dailyLimit {
 		enqueueIt = true
+		enqueueReason = "daily_limit"
 		releaseTime = now.Add(maxEnqueuedTime)
 		gov.logger.Error("enqueuing vaa because it would exceed the daily limit",
 			zap.Uint64("value", value),
@@ -394,6 +531,19 @@ func (gov *ChainGovernor) ProcessMsgForTime(msg *common.MessagePublication, now
 		)
 	}
 
+	if enqueueIt && gov.shadow {
+		pe := &pendingEntry{token: token, amount: payload.Amount, hash: hash, dbData: db.PendingTransfer{ReleaseTime: releaseTime, Msg: *msg}, reason: enqueueReason}
+		gov.shadowEnqueueAlreadyLocked(ce, pe, enqueueReason)
+		gov.notify(EventEnqueued, pe, enqueueReason, "", now)
+		gov.msgsSeen[hash] = transferEnqueued
+		gov.logger.Warn("publishing vaa the governor would have enqueued because it is in shadow mode",
+			zap.String("msgID", msg.MessageIDString()),
+			zap.String("hash", hash),
+			zap.String("reason", enqueueReason),
+		)
+		return true, nil
+	}
+
 	if enqueueIt {
 		dbData := db.PendingTransfer{ReleaseTime: releaseTime, Msg: *msg}
 		err = gov.db.StorePendingMsg(&dbData)
@@ -407,8 +557,10 @@ func (gov *ChainGovernor) ProcessMsgForTime(msg *common.MessagePublication, now
 			return false, err
 		}
 
-		ce.pending = append(ce.pending, &pendingEntry{token: token, amount: payload.Amount, hash: hash, dbData: dbData})
+		pe := &pendingEntry{token: token, amount: payload.Amount, hash: hash, dbData: dbData, reason: enqueueReason}
+		ce.pending = append(ce.pending, pe)
 		gov.msgsSeen[hash] = transferEnqueued
+		gov.notify(EventEnqueued, pe, enqueueReason, "", now)
 		return false, nil
 	}
 
@@ -524,6 +676,10 @@ func (gov *ChainGovernor) CheckPendingForTime(now time.Time) ([]*common.MessageP
 
 			// Keep going until we find something that fits or hit the end.
 			for idx, pe := range ce.pending {
+				if gov.heldForReviewAlreadyLocked(pe) {
+					continue // Only an admin can release it.
+				}
+
 				value, err := computeValue(pe.amount, pe.token)
 				if err != nil {
 					gov.logger.Error("failed to compute value for pending vaa",
@@ -538,6 +694,7 @@ func (gov *ChainGovernor) CheckPendingForTime(now time.Time) ([]*common.MessageP
 				}
 
 				countsTowardsTransfers := true
+				releaseReason := "release_time"
 				if ce.isBigTransfer(value) {
 					if now.Before(pe.dbData.ReleaseTime) {
 						continue // Keep waiting for the timer to expire.
@@ -569,6 +726,7 @@ func (gov *ChainGovernor) CheckPendingForTime(now time.Time) ([]*common.MessageP
 						// This one won't fit. Keep checking other enqueued ones.
 						continue
 					}
+					releaseReason = "limit_available"
 
 					gov.logger.Info("posting pending vaa",
 						zap.Stringer("amount", pe.amount),
@@ -587,9 +745,16 @@ func (gov *ChainGovernor) CheckPendingForTime(now time.Time) ([]*common.MessageP
 						zap.Error(err),
 					)
 					delete(gov.msgsSeen, pe.hash) // Rest of the clean up happens below.
+					gov.notify(EventDropped, pe, "invalid_payload", "", now)
 				} else {
-					// If we get here, publish it and remove it from the pending list.
-					msgsToPublish = append(msgsToPublish, &pe.dbData.Msg)
+					gov.notify(EventReleased, pe, releaseReason, "", now)
+
+					// If we get here, publish it and remove it from the pending list. Shadow entries were published already.
+					if pe.shadow {
+						metricShadowReleased.Inc()
+					} else {
+						msgsToPublish = append(msgsToPublish, &pe.dbData.Msg)
+					}
 
 					if countsTowardsTransfers {
 						xfer := db.Transfer{Timestamp: now,
@@ -616,11 +781,13 @@ func (gov *ChainGovernor) CheckPendingForTime(now time.Time) ([]*common.MessageP
 					}
 				}
 
-				if err := gov.db.DeletePendingMsg(&pe.dbData); err != nil {
+				if err := gov.deletePendingAlreadyLocked(pe); err != nil {
 					gov.msgsToPublish = msgsToPublish
 					return nil, err
 				}
 
+				gov.discardApprovalsAlreadyLocked(pe, "released by the governor", now)
+
 				ce.pending = append(ce.pending[:idx], ce.pending[idx+1:]...)
 				foundOne = true
 				break // We messed up our loop indexing, so we have to break out and start over.
@@ -0,0 +1,116 @@
+// This file contains the approval workflow for releasing pending transfers.
+//
+// If release approvals are required (see RequireReleaseApprovals), releasing a pending transfer whose notional value is at least
+// the threshold only records the approval of the admin asking for it. The transfer is released once the required number of distinct
+// approvers have approved it. Approvals are stored in the database, so they survive restarts, and are dropped once the transfer
+// leaves the pending list for any reason. Every approval and release, and every dropped set of approvals, is recorded as an entry
+// in the audit log, which is also kept in the database.
+
+package governor
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/db"
+	"go.uber.org/zap"
+)
+
+const (
+	auditActionApprove          = "approve"
+	auditActionRelease          = "release"
+	auditActionDiscardApprovals = "discard-approvals"
+)
+
+// ErrApproverRequired is returned when releasing a transfer which requires approvals without an authenticated approver.
+var ErrApproverRequired = errors.New("releasing this vaa requires approvals, which only authenticated callers can give")
+
+// ErrDuplicateApproval is returned when an approver approves the release of the same transfer twice.
+var ErrDuplicateApproval = errors.New("the release of this vaa has already been approved by this approver")
+
+// RequireReleaseApprovals makes releasing a pending transfer worth at least threshold require the approvals of approvals distinct
+// approvers. It must be called before Run.
+func (gov *ChainGovernor) RequireReleaseApprovals(threshold uint64, approvals int) {
+	gov.releaseApprovalThreshold = threshold
+	gov.releaseApprovals = approvals
+}
+
+// ReleaseApprovals returns the settings given to RequireReleaseApprovals. Releases need no approvals if approvals is zero.
+func (gov *ChainGovernor) ReleaseApprovals() (threshold uint64, approvals int) {
+	return gov.releaseApprovalThreshold, gov.releaseApprovals
+}
+
+// needsApprovals returns true if releasing a transfer of the given value has to be approved.
+func (gov *ChainGovernor) needsApprovals(value uint64) bool {
+	return gov.releaseApprovals > 0 && value >= gov.releaseApprovalThreshold
+}
+
+// approveReleaseAlreadyLocked records the approval of approver to release a pending transfer. approver must be the authenticated identity
+// of the admin, as approvals only count once per approver. It returns true if the transfer has
+// enough approvals to be released. It assumes the caller holds the lock.
+func (gov *ChainGovernor) approveReleaseAlreadyLocked(pe *pendingEntry, approver string, now time.Time) (bool, error) {
+	msgId := pe.dbData.Msg.MessageIDString()
+	if approver == "" {
+		return false, ErrApproverRequired
+	}
+	for _, a := range pe.approvals {
+		if a.Approver == approver {
+			return false, fmt.Errorf("%w: %s", ErrDuplicateApproval, approver)
+		}
+	}
+
+	a := &db.ReleaseApproval{MsgID: msgId, Approver: approver, Timestamp: now}
+	if err := gov.db.StoreReleaseApproval(a); err != nil {
+		return false, err
+	}
+	pe.approvals = append(pe.approvals, a)
+
+	detail := fmt.Sprintf("%d of %d approvals", len(pe.approvals), gov.releaseApprovals)
+	gov.audit(auditActionApprove, msgId, approver, detail, now)
+	gov.logger.Info("recorded approval to release pending vaa",
+		zap.String("msgId", msgId),
+		zap.String("approver", approver),
+		zap.Int("approvals", len(pe.approvals)),
+		zap.Int("requiredApprovals", gov.releaseApprovals),
+	)
+	return len(pe.approvals) >= gov.releaseApprovals, nil
+}
+
+// discardApprovalsAlreadyLocked drops the approvals of a transfer which is leaving the pending list. Failing to delete them only
+// leaves unused records behind, so errors are logged rather than returned. It assumes the caller holds the lock.
+func (gov *ChainGovernor) discardApprovalsAlreadyLocked(pe *pendingEntry, reason string, now time.Time) {
+	if len(pe.approvals) == 0 {
+		return
+	}
+	msgId := pe.dbData.Msg.MessageIDString()
+	if err := gov.db.DeleteReleaseApprovals(msgId); err != nil {
+		gov.logger.Error("failed to delete release approvals", zap.String("msgId", msgId), zap.Error(err))
+	}
+	gov.audit(auditActionDiscardApprovals, msgId, "", fmt.Sprintf("%s, approved by %s", reason, approvers(pe.approvals)), now)
+	pe.approvals = nil
+}
+
+// audit stores an entry in the audit log. Like discarded approvals, failures are logged but do not fail the admin command.
+func (gov *ChainGovernor) audit(action string, msgId string, actor string, detail string, now time.Time) {
+	e := &db.GovernorAuditEntry{Timestamp: now, Action: action, MsgID: msgId, Actor: actor, Detail: detail}
+	if err := gov.db.StoreGovernorAuditEntry(e); err != nil {
+		gov.logger.Error("failed to store governor audit entry", zap.String("action", action), zap.String("msgId", msgId), zap.Error(err))
+	}
+}
+
+// AuditLog returns the audit log of admin actions on pending transfers, oldest first.
+func (gov *ChainGovernor) AuditLog() ([]*db.GovernorAuditEntry, error) {
+	gov.mutex.Lock()
+	defer gov.mutex.Unlock()
+	return gov.db.GetGovernorAuditEntries()
+}
+
+func approvers(approvals []*db.ReleaseApproval) string {
+	names := make([]string, len(approvals))
+	for i, a := range approvals {
+		names[i] = a.Approver
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,89 @@
+package governor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+func TestReleaseApprovals(t *testing.T) {
+	database, err := db.OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer database.Close()
+
+	keys, signers := testConfigSigners(t, 1)
+	path := filepath.Join(t.TempDir(), "governor.json")
+	writeTestConfigFile(t, path, testSignedConfig(t, testConfig(100), keys))
+	newGov := func() *ChainGovernor {
+		gov := NewChainGovernor(zap.NewNop(), database, common.GoTest)
+		gov.UseConfigFile(path, signers)
+		gov.RequireReleaseApprovals(100, 2)
+		require.NoError(t, gov.initConfig())
+		return gov
+	}
+	gov := newGov()
+
+	// Three SOL are worth 104, which is a big transaction and needs approvals. A transfer worth 69 that exceeds the daily limit does not.
+	now := time.Now()
+	big := testSolTransfer(t, gov, 1, vaa.ChainIDSolana, 2, 3, now)
+	for seq, sol := range []int64{3, 2, 2} {
+		_, err := gov.ProcessMsgForTime(testSolTransfer(t, gov, uint64(seq+1), vaa.ChainIDSolana, 2, sol, now), now)
+		require.NoError(t, err)
+	}
+	require.Len(t, gov.chains[vaa.ChainIDSolana].pending, 2)
+
+	_, err = gov.releasePendingVAAForTime(testSolTransfer(t, gov, 3, vaa.ChainIDSolana, 2, 2, now).MessageIDString(), "", now)
+	require.NoError(t, err)
+
+	_, err = gov.releasePendingVAAForTime(big.MessageIDString(), "", now)
+	assert.ErrorIs(t, err, ErrApproverRequired)
+	now = time.Now()
+	resp, err := gov.releasePendingVAAForTime(big.MessageIDString(), "alice", now)
+	require.NoError(t, err)
+	assert.Contains(t, resp, "it has 1 of the 2 required approvals")
+	_, err = gov.releasePendingVAAForTime(big.MessageIDString(), "alice", now)
+	assert.ErrorIs(t, err, ErrDuplicateApproval)
+
+	// Approvals survive a restart.
+	gov = newGov()
+	require.NoError(t, gov.loadFromDB())
+	require.Len(t, gov.chains[vaa.ChainIDSolana].pending, 1)
+	now = time.Now()
+	resp, err = gov.releasePendingVAAForTime(big.MessageIDString(), "bob", now)
+	require.NoError(t, err)
+	assert.Contains(t, resp, "has been released")
+	assert.Len(t, gov.msgsToPublish, 1)
+	approvals, err := database.GetReleaseApprovals(big.MessageIDString())
+	require.NoError(t, err)
+	assert.Empty(t, approvals)
+
+	// Approvals of a transfer that is dropped are discarded.
+	other := testSolTransfer(t, gov, 4, vaa.ChainIDSolana, 2, 3, now)
+	_, err = gov.ProcessMsgForTime(other, now)
+	require.NoError(t, err)
+	now = time.Now()
+	_, err = gov.releasePendingVAAForTime(other.MessageIDString(), "alice", now)
+	require.NoError(t, err)
+	_, err = gov.DropPendingVAA(other.MessageIDString())
+	require.NoError(t, err)
+	approvals, err = database.GetReleaseApprovals(other.MessageIDString())
+	require.NoError(t, err)
+	assert.Empty(t, approvals)
+
+	entries, err := gov.AuditLog()
+	require.NoError(t, err)
+	var actions []string
+	for _, e := range entries {
+		actions = append(actions, e.Action+" "+e.Actor)
+	}
+	assert.Equal(t, []string{"release ", "approve alice", "approve bob", "release bob", "approve alice", "discard-approvals "}, actions)
+	assert.Equal(t, "value 104, approved by alice, bob", entries[3].Detail)
+	assert.Equal(t, "dropped, approved by alice", entries[5].Detail)
+}
@@ -0,0 +1,461 @@
+// This file contains the code to load the token and chain config of the chain governor from a signed config file, instead of the
+// lists compiled into the binary, and to reload it while the guardian is running.
+//
+// A config file is a JSON document of the following form:
+//
+//	{
+//	  "config": {
+//	    "version": 7,
+//	    "tokens": [{"chain": 1, "addr": "069b8857...", "symbol": "SOL", "coinGeckoId": "wrapped-solana", "decimals": 8, "price": 34.94}],
+//	    "chains": [{"emitterChainId": 1, "dailyLimit": 25000000, "bigTransactionSize": 2500000}],
+//	    "priceOracles": [{"coinGeckoId": "wrapped-solana", "accounts": ["7UVimffx..."]}],
+//	    "denylist": [{"chain": 1, "addr": "069b8857...", "targetChain": 2}]
+//	  },
+//	  "signatures": [{"index": 0, "signature": "..."}]
+//	}
+//
+// The config is only accepted if it is signed by a quorum of the configured signers, each signature identifying its signer by its
+// index in the list of signers. Signatures are over ConfigDigest of the "config" value, which ignores whitespace, so that the file
+// can be reformatted without invalidating them.
+//
+// When the file changes, the new config is validated and applied as a whole. If it is invalid, the running config is kept.
+//
+// Each config carries a version which must be raised for every new config. The highest version applied is persisted, and a config
+// with a lower version is rejected, so that an older config which is still validly signed cannot be put back in place.
+
+package governor
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/supervisor"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/gagliardetto/solana-go"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+	"gopkg.in/godo.v2/watcher/fswatch"
+)
+
+// configDigestPrefix separates the digest of a config file from the other messages signed by guardian keys.
+var configDigestPrefix = []byte("governor_config|")
+
+var metricConfigReloads = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "guardian_governor_config_reloads_total",
+		Help: "Total number of reloads of the chain governor config file, by whether the new config was applied",
+	}, []string{"result"})
+
+type (
+	// ConfigFile is the token and chain config of the governor.
+	ConfigFile struct {
+		Version      uint64              `json:"version"`
+		Tokens       []TokenConfig       `json:"tokens"`
+		Chains       []ChainConfig       `json:"chains"`
+		PriceOracles []PriceOracleConfig `json:"priceOracles,omitempty"`
+		Denylist     []DenylistConfig    `json:"denylist,omitempty"`
+	}
+
+	// TokenConfig is a token monitored by the governor.
+	TokenConfig struct {
+		Chain       uint16  `json:"chain"`
+		Addr        string  `json:"addr"`
+		Symbol      string  `json:"symbol"`
+		CoinGeckoId string  `json:"coinGeckoId"`
+		Decimals    int64   `json:"decimals"`
+		Price       float64 `json:"price"`
+	}
+
+	// ChainConfig is a chain monitored by the governor.
+	ChainConfig struct {
+		EmitterChainID     uint16 `json:"emitterChainId"`
+		DailyLimit         uint64 `json:"dailyLimit"`
+		BigTransactionSize uint64 `json:"bigTransactionSize"`
+	}
+
+	// PriceOracleConfig lists the Solana oracle accounts the price of the tokens with a CoinGecko ID is read from, see governor_oracle.go.
+	PriceOracleConfig struct {
+		CoinGeckoId string   `json:"coinGeckoId"`
+		Accounts    []string `json:"accounts"`
+	}
+
+	// DenylistConfig is a route whose transfers are always enqueued for manual review, see governor_denylist.go.
+	DenylistConfig struct {
+		Chain       uint16 `json:"chain"`
+		Addr        string `json:"addr"`
+		TargetChain uint16 `json:"targetChain"`
+	}
+
+	// SignedConfigFile is the format of the config file on disk.
+	SignedConfigFile struct {
+		Config     json.RawMessage   `json:"config"`
+		Signatures []ConfigSignature `json:"signatures"`
+	}
+
+	// ConfigSignature is the signature of a config by the signer at Index in the list of signers.
+	ConfigSignature struct {
+		Index     int    `json:"index"`
+		Signature string `json:"signature"`
+	}
+)
+
+// ConfigDigest returns the digest signed by the signers of a config.
+func ConfigDigest(config []byte) (ethcommon.Hash, error) {
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, config); err != nil {
+		return ethcommon.Hash{}, fmt.Errorf("invalid config: %w", err)
+	}
+	return ethcrypto.Keccak256Hash(configDigestPrefix, compact.Bytes()), nil
+}
+
+// Sign adds the signature of the signer at index to the file, replacing any signature it made before.
+func (f *SignedConfigFile) Sign(index int, key *ecdsa.PrivateKey) error {
+	digest, err := ConfigDigest(f.Config)
+	if err != nil {
+		return err
+	}
+	sig, err := ethcrypto.Sign(digest.Bytes(), key)
+	if err != nil {
+		return err
+	}
+	signatures := []ConfigSignature{{Index: index, Signature: hex.EncodeToString(sig)}}
+	for _, s := range f.Signatures {
+		if s.Index != index {
+			signatures = append(signatures, s)
+		}
+	}
+	f.Signatures = signatures
+	return nil
+}
+
+// Verify checks that the config is signed by a quorum of signers and returns it.
+func (f *SignedConfigFile) Verify(signers []ethcommon.Address) (*ConfigFile, error) {
+	if len(signers) == 0 {
+		return nil, errors.New("no config signers are configured")
+	}
+
+	digest, err := ConfigDigest(f.Config)
+	if err != nil {
+		return nil, err
+	}
+	signed := make(map[int]bool)
+	for _, s := range f.Signatures {
+		if s.Index < 0 || s.Index >= len(signers) {
+			return nil, fmt.Errorf("signature index %d is out of range", s.Index)
+		}
+		if signed[s.Index] {
+			return nil, fmt.Errorf("duplicate signature for index %d", s.Index)
+		}
+		sig, err := hex.DecodeString(s.Signature)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature for index %d: %w", s.Index, err)
+		}
+		pubKey, err := ethcrypto.SigToPub(digest.Bytes(), sig)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature for index %d: %w", s.Index, err)
+		}
+		if ethcrypto.PubkeyToAddress(*pubKey) != signers[s.Index] {
+			return nil, fmt.Errorf("signature for index %d is not by %s", s.Index, signers[s.Index].Hex())
+		}
+		signed[s.Index] = true
+	}
+	if quorum := vaa.CalculateQuorum(len(signers)); len(signed) < quorum {
+		return nil, fmt.Errorf("config is signed by %d signers, %d are required", len(signed), quorum)
+	}
+
+	var cfg ConfigFile
+	dec := json.NewDecoder(bytes.NewReader(f.Config))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// ParseConfigSigners parses a comma separated list of signer addresses.
+func ParseConfigSigners(s string) ([]ethcommon.Address, error) {
+	var signers []ethcommon.Address
+	for _, addr := range strings.Split(s, ",") {
+		addr = strings.TrimSpace(addr)
+		if !ethcommon.IsHexAddress(addr) {
+			return nil, fmt.Errorf("invalid signer address %q", addr)
+		}
+		signers = append(signers, ethcommon.HexToAddress(addr))
+	}
+	return signers, nil
+}
+
+// ReadConfigFile reads a config file and checks that it is signed by a quorum of signers.
+func ReadConfigFile(path string, signers []ethcommon.Address) (*ConfigFile, error) {
+	cfg, _, err := readConfigFile(path, signers)
+	return cfg, err
+}
+
+// readConfigFile is ReadConfigFile, which also returns the version of the config. The version is the hex-encoded ConfigDigest of
+// the config, so it is the same on every guardian enforcing it.
+func readConfigFile(path string, signers []ethcommon.Address) (*ConfigFile, string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read config file: %w", err)
+	}
+	var f SignedConfigFile
+	if err := json.Unmarshal(b, &f); err != nil {
+		return nil, "", fmt.Errorf("failed to parse config file: %w", err)
+	}
+	cfg, err := f.Verify(signers)
+	if err != nil {
+		return nil, "", err
+	}
+	digest, err := ConfigDigest(f.Config)
+	if err != nil {
+		return nil, "", err
+	}
+	return cfg, digest.Hex(), nil
+}
+
+// validate checks the parts of the config which the governor does not check itself when applying it.
+func (cfg *ConfigFile) validate() error {
+	if cfg.Version == 0 {
+		return errors.New("config has no version")
+	}
+	tokens := make(map[string]bool)
+	for _, t := range cfg.Tokens {
+		key := fmt.Sprintf("%d:%s", t.Chain, t.Addr)
+		if tokens[key] {
+			return fmt.Errorf("duplicate token %s", key)
+		}
+		tokens[key] = true
+		if t.Decimals < 0 {
+			return fmt.Errorf("token %s has negative decimals", key)
+		}
+		if t.Price < 0 {
+			return fmt.Errorf("token %s has a negative price", key)
+		}
+	}
+	chains := make(map[uint16]bool)
+	for _, c := range cfg.Chains {
+		if chains[c.EmitterChainID] {
+			return fmt.Errorf("duplicate chain %d", c.EmitterChainID)
+		}
+		chains[c.EmitterChainID] = true
+	}
+	oracles := make(map[string]bool)
+	for _, o := range cfg.PriceOracles {
+		if oracles[o.CoinGeckoId] {
+			return fmt.Errorf("duplicate price oracles for %s", o.CoinGeckoId)
+		}
+		oracles[o.CoinGeckoId] = true
+		if len(o.Accounts) == 0 {
+			return fmt.Errorf("no price oracle accounts for %s", o.CoinGeckoId)
+		}
+		for _, acct := range o.Accounts {
+			if _, err := solana.PublicKeyFromBase58(acct); err != nil {
+				return fmt.Errorf("invalid price oracle account %q for %s: %w", acct, o.CoinGeckoId, err)
+			}
+		}
+	}
+	routes := make(map[string]bool)
+	for _, d := range cfg.Denylist {
+		key := fmt.Sprintf("%d:%s:%d", d.Chain, d.Addr, d.TargetChain)
+		if routes[key] {
+			return fmt.Errorf("duplicate denylist entry %s", key)
+		}
+		routes[key] = true
+		if _, err := vaa.StringToAddress(d.Addr); err != nil {
+			return fmt.Errorf("invalid denylist token address %q: %w", d.Addr, err)
+		}
+	}
+	return nil
+}
+
+// denylistEntries returns the denylisted routes. The config must have been validated.
+func (cfg *ConfigFile) denylistEntries() map[denylistKey]bool {
+	denylist := make(map[denylistKey]bool, len(cfg.Denylist))
+	for _, d := range cfg.Denylist {
+		addr, _ := vaa.StringToAddress(d.Addr)
+		denylist[denylistKey{token: tokenKey{chain: vaa.ChainID(d.Chain), addr: addr}, targetChain: vaa.ChainID(d.TargetChain)}] = true
+	}
+	return denylist
+}
+
+// oracleAccounts returns the price oracle accounts by CoinGecko ID. The config must have been validated.
+func (cfg *ConfigFile) oracleAccounts() map[string][]solana.PublicKey {
+	oracles := make(map[string][]solana.PublicKey, len(cfg.PriceOracles))
+	for _, o := range cfg.PriceOracles {
+		for _, acct := range o.Accounts {
+			oracles[o.CoinGeckoId] = append(oracles[o.CoinGeckoId], solana.MustPublicKeyFromBase58(acct))
+		}
+	}
+	return oracles
+}
+
+func (cfg *ConfigFile) entries() ([]tokenConfigEntry, []chainConfigEntry) {
+	tokens := make([]tokenConfigEntry, len(cfg.Tokens))
+	for i, t := range cfg.Tokens {
+		tokens[i] = tokenConfigEntry{chain: t.Chain, addr: t.Addr, symbol: t.Symbol, coinGeckoId: t.CoinGeckoId, decimals: t.Decimals, price: t.Price}
+	}
+	chains := make([]chainConfigEntry, len(cfg.Chains))
+	for i, c := range cfg.Chains {
+		chains[i] = chainConfigEntry{emitterChainID: vaa.ChainID(c.EmitterChainID), dailyLimit: c.DailyLimit, bigTransactionSize: c.BigTransactionSize}
+	}
+	return tokens, chains
+}
+
+// UseConfigFile makes the governor load its token and chain config from a config file signed by a quorum of signers, and reload it
+// whenever it changes. It must be called before Run.
+func (gov *ChainGovernor) UseConfigFile(path string, signers []ethcommon.Address) {
+	gov.configFile = path
+	gov.configSigners = signers
+}
+
+// watchConfigFile reloads the config file whenever it changes.
+func (gov *ChainGovernor) watchConfigFile(ctx context.Context) error {
+	watcher := fswatch.NewWatcher(gov.configFile)
+	fsChan := watcher.Start()
+	defer watcher.Stop()
+
+	supervisor.Signal(ctx, supervisor.SignalHealthy)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case notif := <-fsChan:
+			if notif.Path != gov.configFile {
+				return fmt.Errorf("config watcher received an update for an unexpected file: %s", notif.Path)
+			}
+			gov.logger.Info("the config file has been updated", zap.String("configFile", notif.Path), zap.Int("event", int(notif.Event)))
+			_ = gov.ReloadConfigFile()
+		}
+	}
+}
+
+// ReloadConfigFile reads the config file again and applies it. If the file is invalid, the running config is kept and the error is
+// returned.
+func (gov *ChainGovernor) ReloadConfigFile() error {
+	cfg, version, err := readConfigFile(gov.configFile, gov.configSigners)
+	if err == nil {
+		tokens, chains := cfg.entries()
+		err = gov.applyConfig(cfg.Version, tokens, chains, cfg.oracleAccounts(), cfg.denylistEntries())
+	}
+	if err != nil {
+		gov.logger.Error("failed to reload the config file, sticking with the old config", zap.String("configFile", gov.configFile), zap.Error(err))
+		metricConfigReloads.WithLabelValues("failure").Inc()
+		return err
+	}
+	gov.mutex.Lock()
+	gov.configVersion = version
+	gov.mutex.Unlock()
+	gov.logger.Info("successfully reloaded the config file, switching to it", zap.String("configFile", gov.configFile),
+		zap.Uint64("version", cfg.Version), zap.Int("numTokens", len(cfg.Tokens)), zap.Int("numChains", len(cfg.Chains)))
+	metricConfigReloads.WithLabelValues("success").Inc()
+	return nil
+}
+
+// applyConfig replaces the running token and chain config. The transfers and pending transfers of chains that remain governed are
+// kept, as are the latest CoinGecko and oracle prices of tokens that remain monitored, and limits changed at runtime. Removing a chain with pending transfers is refused, since
+// they could not be released anymore, and so is a config whose version is lower than the highest one applied.
+func (gov *ChainGovernor) applyConfig(version uint64, configTokens []tokenConfigEntry, configChains []chainConfigEntry, oracles map[string][]solana.PublicKey, denylist map[denylistKey]bool) error {
+	gov.mutex.Lock()
+	defer gov.mutex.Unlock()
+
+	if err := gov.checkConfigVersion(version); err != nil {
+		return err
+	}
+	tokens, tokensByCoinGeckoId, chains, err := gov.buildConfig(configTokens, configChains)
+	if err != nil {
+		return err
+	}
+	if err := checkDenylist(denylist, tokens); err != nil {
+		return err
+	}
+
+	for chainId, ce := range gov.chains {
+		if _, exists := chains[chainId]; !exists && len(ce.pending) != 0 {
+			return fmt.Errorf("chain %v has %d pending transfers and cannot be removed", chainId, len(ce.pending))
+		}
+	}
+	if err := gov.storeConfigVersion(version); err != nil {
+		return err
+	}
+
+	for key, te := range tokens {
+		if old, exists := gov.tokens[key]; exists && old.coinGeckoId == te.coinGeckoId {
+			// Oracle prices are kept even if the accounts of the token changed, they are replaced on the next oracle query.
+			te.coinGeckoPrice = old.coinGeckoPrice
+			te.priceTime = old.priceTime
+			te.oraclePrices = old.oraclePrices
+			te.updatePrice()
+		}
+	}
+
+	for chainId, ce := range chains {
+		if l, exists := gov.limitOverrides[chainId]; exists {
+			ce.setLimits(l.DailyLimit, l.BigTransactionSize)
+		}
+		old, exists := gov.chains[chainId]
+		if !exists {
+			continue
+		}
+		ce.transfers = old.transfers
+		ce.pending = old.pending
+		// Pending transfers are valued with the price of their token each time the governor checks them, so they follow the new
+		// config of their token. Tokens which are no longer monitored keep their last price.
+		for _, pe := range ce.pending {
+			if te, exists := tokens[pe.token.token]; exists {
+				pe.token = te
+			}
+		}
+	}
+
+	gov.tokens = tokens
+	gov.tokensByCoinGeckoId = tokensByCoinGeckoId
+	gov.chains = chains
+	gov.priceOracles = oracles
+	gov.denylist = denylist
+	gov.coinGeckoQueries = createCoinGeckoQueries(coinGeckoIds(tokensByCoinGeckoId), tokensPerCoinGeckoQuery)
+	return nil
+}
+
+// checkConfigVersion checks that a config file is not older than the newest one applied. gov.mutex must be held.
+func (gov *ChainGovernor) checkConfigVersion(version uint64) error {
+	if version < gov.configFileVersion {
+		return fmt.Errorf("config version %d is lower than version %d, which was applied before", version, gov.configFileVersion)
+	}
+	return nil
+}
+
+// storeConfigVersion records the version of a config file that is being applied, if it is the newest one. gov.mutex must be held.
+func (gov *ChainGovernor) storeConfigVersion(version uint64) error {
+	if version <= gov.configFileVersion {
+		return nil
+	}
+	if gov.db != nil {
+		if err := gov.db.StoreGovernorConfigVersion(version); err != nil {
+			return fmt.Errorf("failed to store the config version: %w", err)
+		}
+	}
+	gov.configFileVersion = version
+	return nil
+}
+
+// startConfigWatcher starts reloading the config file on changes, if one is configured.
+func (gov *ChainGovernor) startConfigWatcher(ctx context.Context) error {
+	if gov.configFile == "" || gov.env == common.GoTest {
+		return nil
+	}
+	return supervisor.Run(ctx, "govconfig", gov.watchConfigFile)
+}
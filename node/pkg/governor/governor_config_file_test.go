@@ -0,0 +1,227 @@
+package governor
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+const testConfigSolAddr = "069b8857feab8184fb687f634618c035dac439dc1aeb3b5598a0f00000000001"
+
+func testConfigSigners(t *testing.T, n int) ([]*ecdsa.PrivateKey, []ethcommon.Address) {
+	t.Helper()
+	keys := make([]*ecdsa.PrivateKey, n)
+	addrs := make([]ethcommon.Address, n)
+	for i := range keys {
+		var err error
+		keys[i], err = ecdsa.GenerateKey(ethcrypto.S256(), rand.Reader)
+		require.NoError(t, err)
+		addrs[i] = ethcrypto.PubkeyToAddress(keys[i].PublicKey)
+	}
+	return keys, addrs
+}
+
+func testSignedConfig(t *testing.T, cfg ConfigFile, keys []*ecdsa.PrivateKey) *SignedConfigFile {
+	t.Helper()
+	b, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	f := &SignedConfigFile{Config: b}
+	for i, key := range keys {
+		require.NoError(t, f.Sign(i, key))
+	}
+	return f
+}
+
+func writeTestConfigFile(t *testing.T, path string, f *SignedConfigFile) {
+	t.Helper()
+	b, err := json.Marshal(f)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, b, 0600))
+}
+
+func testConfig(dailyLimit uint64) ConfigFile {
+	return ConfigFile{
+		Version: 1,
+		Tokens:  []TokenConfig{{Chain: 1, Addr: testConfigSolAddr, Symbol: "SOL", CoinGeckoId: "wrapped-solana", Decimals: 8, Price: 34.94}},
+		Chains:  []ChainConfig{{EmitterChainID: uint16(vaa.ChainIDSolana), DailyLimit: dailyLimit, BigTransactionSize: 75}},
+	}
+}
+
+// testSolTransfer returns a transfer of whole SOL from the governed emitter of emitterChain to targetChain.
+func testSolTransfer(t *testing.T, gov *ChainGovernor, seq uint64, emitterChain vaa.ChainID, targetChain vaa.ChainID, sol int64, now time.Time) *common.MessagePublication {
+	t.Helper()
+	tokenAddr, err := vaa.StringToAddress(testConfigSolAddr)
+	require.NoError(t, err)
+
+	payload := make([]byte, 101)
+	payload[0] = 1
+	new(big.Int).Mul(big.NewInt(sol), big.NewInt(100000000)).FillBytes(payload[1:33])
+	copy(payload[33:65], tokenAddr.Bytes())
+	binary.BigEndian.PutUint16(payload[65:67], uint16(vaa.ChainIDSolana))
+	binary.BigEndian.PutUint16(payload[99:101], uint16(targetChain))
+
+	return &common.MessagePublication{
+		Timestamp:      now,
+		Sequence:       seq,
+		EmitterChain:   emitterChain,
+		EmitterAddress: gov.chains[emitterChain].emitterAddr,
+		Payload:        payload,
+	}
+}
+
+func TestSignedConfigFileVerify(t *testing.T) {
+	keys, signers := testConfigSigners(t, 4)
+
+	// Three of four signers are a quorum.
+	f := testSignedConfig(t, testConfig(100), keys[:3])
+	cfg, err := f.Verify(signers)
+	require.NoError(t, err)
+	assert.Equal(t, testConfig(100), *cfg)
+
+	_, err = testSignedConfig(t, testConfig(100), keys[:2]).Verify(signers)
+	assert.ErrorContains(t, err, "config is signed by 2 signers, 3 are required")
+
+	// Signing again replaces the previous signature instead of counting twice.
+	require.NoError(t, f.Sign(0, keys[0]))
+	assert.Len(t, f.Signatures, 3)
+
+	duplicate := *f
+	duplicate.Signatures = append(duplicate.Signatures, f.Signatures[0])
+	_, err = duplicate.Verify(signers)
+	assert.ErrorContains(t, err, "duplicate signature")
+
+	wrongIndex := testSignedConfig(t, testConfig(100), keys[:3])
+	wrongIndex.Signatures[0].Index = 3
+	_, err = wrongIndex.Verify(signers)
+	assert.ErrorContains(t, err, "is not by")
+
+	// Whitespace is not part of the digest, but everything else is.
+	reformatted := *f
+	reformatted.Config = []byte(" " + string(f.Config) + "\n")
+	_, err = reformatted.Verify(signers)
+	assert.NoError(t, err)
+
+	tampered := *f
+	tampered.Config = bytes.Replace(f.Config, []byte(`"dailyLimit":100`), []byte(`"dailyLimit":1000`), 1)
+	require.NotEqual(t, f.Config, tampered.Config)
+	_, err = tampered.Verify(signers)
+	assert.ErrorContains(t, err, "is not by")
+
+	invalid := testConfig(100)
+	invalid.Chains = append(invalid.Chains, invalid.Chains[0])
+	_, err = testSignedConfig(t, invalid, keys).Verify(signers)
+	assert.ErrorContains(t, err, "duplicate chain 1")
+
+	badOracle := testConfig(100)
+	badOracle.PriceOracles = []PriceOracleConfig{{CoinGeckoId: "wrapped-solana", Accounts: []string{"not-an-account"}}}
+	_, err = testSignedConfig(t, badOracle, keys).Verify(signers)
+	assert.ErrorContains(t, err, "invalid price oracle account")
+
+	unversioned := testConfig(100)
+	unversioned.Version = 0
+	_, err = testSignedConfig(t, unversioned, keys).Verify(signers)
+	assert.ErrorContains(t, err, "config has no version")
+}
+
+func TestReloadConfigFile(t *testing.T) {
+	keys, signers := testConfigSigners(t, 1)
+	path := filepath.Join(t.TempDir(), "governor.json")
+	write := func(f *SignedConfigFile) { writeTestConfigFile(t, path, f) }
+	write(testSignedConfig(t, testConfig(100), keys))
+
+	gov := NewChainGovernor(zap.NewNop(), nil, common.GoTest)
+	gov.UseConfigFile(path, signers)
+	require.NoError(t, gov.initConfig())
+	require.Len(t, gov.chains, 1)
+	assert.Equal(t, uint64(100), gov.chains[vaa.ChainIDSolana].dailyLimit)
+
+	// Pending transfers survive a reload and are valued with the new config of their token.
+	addr, err := vaa.StringToAddress(testConfigSolAddr)
+	require.NoError(t, err)
+	te := gov.tokens[tokenKey{chain: vaa.ChainIDSolana, addr: addr}]
+	require.NotNil(t, te)
+	ce := gov.chains[vaa.ChainIDSolana]
+	ce.pending = append(ce.pending, &pendingEntry{token: te, amount: big.NewInt(1)})
+
+	updated := testConfig(200)
+	updated.Version = 2
+	updated.Tokens[0].Price = 50
+	write(testSignedConfig(t, updated, keys))
+	require.NoError(t, gov.ReloadConfigFile())
+	ce = gov.chains[vaa.ChainIDSolana]
+	assert.Equal(t, uint64(200), ce.dailyLimit)
+	require.Len(t, ce.pending, 1)
+	assert.Equal(t, "50", ce.pending[0].token.price.String())
+
+	// A config that is not signed by the signers is not applied.
+	others, _ := testConfigSigners(t, 1)
+	write(testSignedConfig(t, testConfig(300), others))
+	assert.Error(t, gov.ReloadConfigFile())
+	assert.Equal(t, uint64(200), gov.chains[vaa.ChainIDSolana].dailyLimit)
+
+	// Neither is one that drops a chain with pending transfers.
+	gov.chains[vaa.ChainID(2)] = &chainEntry{emitterChainId: vaa.ChainID(2), pending: []*pendingEntry{{token: te, amount: big.NewInt(1)}}}
+	dropped := testConfig(300)
+	dropped.Version = 3
+	write(testSignedConfig(t, dropped, keys))
+	assert.ErrorContains(t, gov.ReloadConfigFile(), "has 1 pending transfers and cannot be removed")
+	assert.Equal(t, uint64(200), gov.chains[vaa.ChainIDSolana].dailyLimit)
+}
+
+func TestConfigFileVersion(t *testing.T) {
+	database, err := db.OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer database.Close()
+
+	keys, signers := testConfigSigners(t, 1)
+	path := filepath.Join(t.TempDir(), "governor.json")
+	write := func(version uint64, dailyLimit uint64) {
+		cfg := testConfig(dailyLimit)
+		cfg.Version = version
+		writeTestConfigFile(t, path, testSignedConfig(t, cfg, keys))
+	}
+	newGov := func() (*ChainGovernor, error) {
+		gov := NewChainGovernor(zap.NewNop(), database, common.GoTest)
+		gov.UseConfigFile(path, signers)
+		return gov, gov.initConfig()
+	}
+
+	write(2, 100)
+	gov, err := newGov()
+	require.NoError(t, err)
+	version, err := database.GetGovernorConfigVersion()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), version)
+
+	// The same version can be reloaded, a newer one replaces it, and an older one is rejected even though it is validly signed.
+	write(2, 200)
+	require.NoError(t, gov.ReloadConfigFile())
+	write(3, 300)
+	require.NoError(t, gov.ReloadConfigFile())
+	write(2, 400)
+	assert.ErrorContains(t, gov.ReloadConfigFile(), "config version 2 is lower than version 3")
+	assert.Equal(t, uint64(300), gov.chains[vaa.ChainIDSolana].dailyLimit)
+
+	// The highest version survives a restart.
+	_, err = newGov()
+	assert.ErrorContains(t, err, "config version 2 is lower than version 3")
+	write(3, 300)
+	_, err = newGov()
+	require.NoError(t, err)
+}
@@ -19,6 +19,11 @@ func (gov *ChainGovernor) loadFromDB() error {
 }
 
 func (gov *ChainGovernor) loadFromDBAlreadyLocked() error {
+	if err := gov.loadChainLimits(); err != nil {
+		gov.logger.Error("failed to reload chain limits from db", zap.Error(err))
+		return err
+	}
+
 	xfers, pending, err := gov.db.GetChainGovernorData(gov.logger)
 	if err != nil {
 		gov.logger.Error("failed to reload transactions from db", zap.Error(err))
@@ -56,6 +61,40 @@ func (gov *ChainGovernor) loadFromDBAlreadyLocked() error {
 	return nil
 }
 
+// loadChainLimits enforces the limits which were changed at runtime instead of the configured ones. Limits of chains which are not
+// governed are kept, in case the chain is added again by a config file.
+func (gov *ChainGovernor) loadChainLimits() error {
+	limits, err := gov.db.GetChainLimits()
+	if err != nil {
+		return err
+	}
+
+	gov.limitOverrides = make(map[vaa.ChainID]db.ChainLimit, len(limits))
+	for _, l := range limits {
+		gov.limitOverrides[l.EmitterChain] = *l
+		ce, exists := gov.chains[l.EmitterChain]
+		if !exists {
+			gov.logger.Warn("ignoring limits changed at runtime for a chain which is not governed", zap.Stringer("emitterChainId", l.EmitterChain))
+			continue
+		}
+		gov.logger.Info("enforcing limits changed at runtime instead of the configured limits",
+			zap.Stringer("emitterChainId", l.EmitterChain),
+			zap.Uint64("dailyLimit", l.DailyLimit),
+			zap.Uint64("cfgDailyLimit", ce.cfgDailyLimit),
+			zap.Uint64("bigTransactionSize", l.BigTransactionSize),
+			zap.Uint64("cfgBigTransactionSize", ce.cfgBigTransactionSize),
+		)
+		ce.setLimits(l.DailyLimit, l.BigTransactionSize)
+	}
+
+	times, err := gov.db.GetChainLimitVAATimes()
+	if err != nil {
+		return err
+	}
+	gov.limitVAATimes = times
+	return nil
+}
+
 func (gov *ChainGovernor) reloadPendingTransfer(pending *db.PendingTransfer) {
 	msg := &pending.Msg
 	ce, exists := gov.chains[msg.EmitterChain]
@@ -152,10 +191,30 @@ func (gov *ChainGovernor) reloadPendingTransfer(pending *db.PendingTransfer) {
 		zap.String("Hash", hash),
 	)
 
-	ce.pending = append(ce.pending, &pendingEntry{token: token, amount: payload.Amount, hash: hash, dbData: *pending})
+	approvals, err := gov.db.GetReleaseApprovals(msg.MessageIDString())
+	if err != nil {
+		// The transfer is still governed, it just needs to be approved again.
+		gov.logger.Error("failed to reload release approvals of pending transfer", zap.String("MsgID", msg.MessageIDString()), zap.Error(err))
+	}
+
+	pe := &pendingEntry{token: token, amount: payload.Amount, hash: hash, dbData: *pending, approvals: approvals}
+	pe.reason = gov.enqueueReasonAlreadyLocked(ce, pe)
+	ce.pending = append(ce.pending, pe)
 	gov.msgsSeen[hash] = transferEnqueued
 }
 
+// enqueueReasonAlreadyLocked returns why a pending transfer reloaded from the database was enqueued, since the reason is not
+// persisted. It is derived from the current denylist and limits, which may have changed since. It assumes the caller holds the lock.
+func (gov *ChainGovernor) enqueueReasonAlreadyLocked(ce *chainEntry, pe *pendingEntry) string {
+	if gov.heldForReviewAlreadyLocked(pe) {
+		return "denylist"
+	}
+	if value, err := computeValue(pe.amount, pe.token); err == nil && ce.isBigTransfer(value) {
+		return "big_transaction"
+	}
+	return "daily_limit"
+}
+
 func (gov *ChainGovernor) reloadTransfer(xfer *db.Transfer) {
 	ce, exists := gov.chains[xfer.EmitterChain]
 	if !exists {
@@ -0,0 +1,49 @@
+// This file contains the denylist of the chain governor.
+//
+// The denylist is a list of routes, each a token and a target chain, which comes from the "denylist" of the config file. Transfers
+// of a denylisted token to a denylisted target chain are always enqueued, regardless of their value, and stay in the pending list
+// until they are released or dropped through the admin service, even after their release time. This allows to stop a route which is
+// under attack while the rest of the token's transfers flow as usual. Once a route is removed from the denylist, its pending transfers
+// are released by the governor like any other.
+
+package governor
+
+import (
+	"fmt"
+
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+type denylistKey struct {
+	token       tokenKey
+	targetChain vaa.ChainID
+}
+
+// isDeniedAlreadyLocked returns true if transfers of a token to a target chain are denylisted. It assumes the caller holds the lock.
+func (gov *ChainGovernor) isDeniedAlreadyLocked(token tokenKey, targetChain vaa.ChainID) bool {
+	return gov.denylist[denylistKey{token: token, targetChain: targetChain}]
+}
+
+// heldForReviewAlreadyLocked returns true if a pending transfer is on a denylisted route, and so may only be released by an admin.
+// It assumes the caller holds the lock.
+func (gov *ChainGovernor) heldForReviewAlreadyLocked(pe *pendingEntry) bool {
+	if len(gov.denylist) == 0 {
+		return false
+	}
+	payload, err := vaa.DecodeTransferPayloadHdr(pe.dbData.Msg.Payload)
+	if err != nil {
+		// The transfer is dropped when it is checked.
+		return false
+	}
+	return gov.isDeniedAlreadyLocked(pe.token.token, payload.TargetChain)
+}
+
+// checkDenylist makes sure that the tokens of a denylist are monitored, since the transfers of other tokens are not governed.
+func checkDenylist(denylist map[denylistKey]bool, tokens map[tokenKey]*tokenEntry) error {
+	for key := range denylist {
+		if _, exists := tokens[key.token]; !exists {
+			return fmt.Errorf("denylisted token %v:%v is not monitored by the governor", key.token.chain, key.token.addr)
+		}
+	}
+	return nil
+}
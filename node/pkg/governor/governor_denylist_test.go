@@ -0,0 +1,60 @@
+package governor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+func TestDenylist(t *testing.T) {
+	keys, signers := testConfigSigners(t, 1)
+	path := filepath.Join(t.TempDir(), "governor.json")
+	cfg := testConfig(1000)
+	cfg.Denylist = []DenylistConfig{{Chain: uint16(vaa.ChainIDSolana), Addr: testConfigSolAddr, TargetChain: 2}}
+	writeTestConfigFile(t, path, testSignedConfig(t, cfg, keys))
+
+	gov := NewChainGovernor(zap.NewNop(), &db.MockGovernorDB{}, common.GoTest)
+	gov.UseConfigFile(path, signers)
+	require.NoError(t, gov.initConfig())
+
+	// Small transfers on the denylisted route are enqueued, the ones to other chains are not.
+	now := time.Now()
+	denied := testSolTransfer(t, gov, 1, vaa.ChainIDSolana, 2, 1, now)
+	publish, err := gov.ProcessMsgForTime(denied, now)
+	require.NoError(t, err)
+	assert.False(t, publish)
+	publish, err = gov.ProcessMsgForTime(testSolTransfer(t, gov, 2, vaa.ChainIDSolana, 3, 1, now), now)
+	require.NoError(t, err)
+	assert.True(t, publish)
+
+	// They are not released, even after their release time.
+	released, err := gov.CheckPendingForTime(now.Add(maxEnqueuedTime + time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, released)
+	require.Len(t, gov.chains[vaa.ChainIDSolana].pending, 1)
+
+	// Once the route is removed from the denylist, the governor releases them as usual.
+	cfg.Denylist = nil
+	writeTestConfigFile(t, path, testSignedConfig(t, cfg, keys))
+	require.NoError(t, gov.ReloadConfigFile())
+	released, err = gov.CheckPendingForTime(now)
+	require.NoError(t, err)
+	require.Len(t, released, 1)
+	assert.Equal(t, denied.MessageIDString(), released[0].MessageIDString())
+
+	// Denylisted tokens must be monitored.
+	cfg.Denylist = []DenylistConfig{{Chain: 2, Addr: testConfigSolAddr, TargetChain: 1}}
+	writeTestConfigFile(t, path, testSignedConfig(t, cfg, keys))
+	assert.ErrorContains(t, gov.ReloadConfigFile(), "is not monitored by the governor")
+
+	cfg.Denylist = []DenylistConfig{{Chain: 1, Addr: "xyz", TargetChain: 2}}
+	writeTestConfigFile(t, path, testSignedConfig(t, cfg, keys))
+	assert.ErrorContains(t, gov.ReloadConfigFile(), "invalid denylist token address")
+}
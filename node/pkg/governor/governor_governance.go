@@ -0,0 +1,83 @@
+// This file contains the handling of governance VAAs by the chain governor.
+//
+// A ChainGovernor SetChainLimit governance VAA changes the daily limit and big transaction size of a chain on every guardian, like
+// the chain-governor-set-chain-limits admin command does on a single one. The processor passes every governance VAA to the governor
+// once it reaches quorum, and the governor applies those of the ChainGovernor module and ignores the others. The timestamp of the last
+// VAA applied to each chain is kept in the database, and only VAAs with a later timestamp are applied, so an old VAA which is
+// replayed cannot undo a newer one.
+
+package governor
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+const auditActionGovernanceVAA = "governance-vaa"
+
+var metricGovernanceVAAs = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "guardian_governor_governance_vaas_total",
+		Help: "Total number of chain governor governance VAAs handled, by result",
+	}, []string{"result"})
+
+// HandleGovernanceVAA applies a ChainGovernor governance VAA which has reached quorum. Governance VAAs of other modules are ignored.
+// Errors are logged rather than returned, since they must not stop the processor.
+func (gov *ChainGovernor) HandleGovernanceVAA(v *vaa.VAA) {
+	if v.EmitterChain != vaa.GovernanceChain || v.EmitterAddress != vaa.GovernanceEmitter {
+		return
+	}
+	module := vaa.LeftPadBytes(vaa.ChainGovernorModule, 32).Bytes()
+	if len(v.Payload) < len(module) || !bytes.Equal(v.Payload[:len(module)], module) {
+		return
+	}
+
+	if err := gov.applyGovernanceVAA(v); err != nil {
+		metricGovernanceVAAs.WithLabelValues("rejected").Inc()
+		gov.logger.Error("failed to apply governance vaa", zap.String("msgID", v.MessageID()), zap.Error(err))
+		return
+	}
+	metricGovernanceVAAs.WithLabelValues("applied").Inc()
+}
+
+func (gov *ChainGovernor) applyGovernanceVAA(v *vaa.VAA) error {
+	var body vaa.BodyChainGovernorSetChainLimit
+	if err := body.Deserialize(v.Payload); err != nil {
+		return err
+	}
+
+	gov.mutex.Lock()
+	defer gov.mutex.Unlock()
+
+	if last, exists := gov.limitVAATimes[body.EmitterChainID]; exists && !v.Timestamp.After(last) {
+		return fmt.Errorf("vaa is not newer than the last one applied to chain %v at %v", body.EmitterChainID, last)
+	}
+
+	limits := []ChainLimit{{ChainId: body.EmitterChainID, DailyLimit: body.DailyLimit, BigTransactionSize: body.BigTransactionSize}}
+	if err := gov.setChainLimitsAlreadyLocked(limits); err != nil {
+		return err
+	}
+	if err := gov.db.StoreChainLimitVAATime(body.EmitterChainID, v.Timestamp); err != nil {
+		return fmt.Errorf("failed to store vaa time of chain %v: %w", body.EmitterChainID, err)
+	}
+	if gov.limitVAATimes == nil {
+		gov.limitVAATimes = make(map[vaa.ChainID]time.Time)
+	}
+	gov.limitVAATimes[body.EmitterChainID] = v.Timestamp
+
+	detail := fmt.Sprintf("set limits of chain %v to daily limit %d and big transaction size %d", body.EmitterChainID, body.DailyLimit, body.BigTransactionSize)
+	gov.audit(auditActionGovernanceVAA, v.MessageID(), "", detail, time.Now())
+	gov.logger.Info("applied governance vaa",
+		zap.String("msgID", v.MessageID()),
+		zap.Stringer("emitterChainId", body.EmitterChainID),
+		zap.Uint64("dailyLimit", body.DailyLimit),
+		zap.Uint64("bigTransactionSize", body.BigTransactionSize),
+	)
+	return nil
+}
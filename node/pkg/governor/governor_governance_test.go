@@ -0,0 +1,69 @@
+package governor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+func TestHandleGovernanceVAA(t *testing.T) {
+	keys, signers := testConfigSigners(t, 1)
+	path := filepath.Join(t.TempDir(), "governor.json")
+	writeTestConfigFile(t, path, testSignedConfig(t, testConfig(1000), keys))
+	database, err := db.OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer database.Close()
+	newGov := func() *ChainGovernor {
+		gov := NewChainGovernor(zap.NewNop(), database, common.GoTest)
+		gov.UseConfigFile(path, signers)
+		require.NoError(t, gov.initConfig())
+		require.NoError(t, gov.loadFromDB())
+		return gov
+	}
+	setLimit := func(timestamp time.Time, seq uint64, chain vaa.ChainID, dailyLimit uint64) *vaa.VAA {
+		body := vaa.BodyChainGovernorSetChainLimit{EmitterChainID: chain, DailyLimit: dailyLimit, BigTransactionSize: 75}
+		return vaa.CreateGovernanceVAA(timestamp, 0, seq, 0, body.Serialize())
+	}
+
+	gov := newGov()
+	ce := gov.chains[vaa.ChainIDSolana]
+	now := time.Unix(1700000000, 0)
+	gov.HandleGovernanceVAA(setLimit(now, 1, vaa.ChainIDSolana, 2000))
+	assert.Equal(t, uint64(2000), ce.dailyLimit)
+
+	// An older VAA, or the same one replayed, does not undo it.
+	gov.HandleGovernanceVAA(setLimit(now.Add(-time.Hour), 2, vaa.ChainIDSolana, 500))
+	gov.HandleGovernanceVAA(setLimit(now, 1, vaa.ChainIDSolana, 500))
+	assert.Equal(t, uint64(2000), ce.dailyLimit)
+
+	// VAAs for chains which are not governed, from other emitters or for other modules are ignored.
+	gov.HandleGovernanceVAA(setLimit(now.Add(time.Hour), 3, vaa.ChainID(2), 500))
+	notGovernance := setLimit(now.Add(time.Hour), 4, vaa.ChainIDSolana, 500)
+	notGovernance.EmitterAddress = vaa.Address{1}
+	gov.HandleGovernanceVAA(notGovernance)
+	gov.HandleGovernanceVAA(vaa.CreateGovernanceVAA(now.Add(time.Hour), 0, 5, 0, vaa.BodyContractUpgrade{ChainID: vaa.ChainIDSolana}.Serialize()))
+	assert.Equal(t, uint64(2000), ce.dailyLimit)
+
+	// The limits and the time of the last VAA survive a restart.
+	gov = newGov()
+	ce = gov.chains[vaa.ChainIDSolana]
+	assert.Equal(t, uint64(2000), ce.dailyLimit)
+	gov.HandleGovernanceVAA(setLimit(now, 1, vaa.ChainIDSolana, 500))
+	assert.Equal(t, uint64(2000), ce.dailyLimit)
+	gov.HandleGovernanceVAA(setLimit(now.Add(time.Minute), 6, vaa.ChainIDSolana, 1000))
+	assert.Equal(t, uint64(1000), ce.dailyLimit)
+	_, overridden := gov.limitOverrides[vaa.ChainIDSolana]
+	assert.False(t, overridden)
+
+	entries, err := database.GetGovernorAuditEntries()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, auditActionGovernanceVAA, entries[0].Action)
+}
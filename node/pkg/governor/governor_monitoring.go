@@ -5,10 +5,17 @@
 
 // The chain governor supports the following admin client commands:
 //   - governor-status - displays the status of the chain governor to the log file.
+//   - governor-dump-state - returns the complete state of the chain governor as JSON, for automation (see DumpState).
 //   - governor-reload - reloads the state of the chain governor from the database.
 //   - governor-drop-pending-vaa [VAA_ID] - removes the specified transfer from the pending list and discards it.
 //   - governor-release-pending-vaa [VAA_ID] - removes the specified transfer from the pending list and publishes it, without regard to the threshold.
-//   - governor-reset-release-timer - resets the release timer for the specified VAA to the configured maximum.
+//     Large transfers may require the approvals of several admins first (see governor_approvals.go).
+//   - governor-audit-log - lists the approvals and releases of pending transfers.
+//   - governor-reset-release-timer [VAA_ID...] - resets the release timer for the specified VAAs, or all those pending for a chain,
+//     to the configured maximum or to the specified delay.
+//
+// The per chain limits can also be changed at runtime using the staged config admin commands (see ChainLimits and SetChainLimits),
+// or directly with governor-set-limit and governor-reset-limit. Such changes are persisted (see ChainLimitStatuses).
 //
 // The VAA_ID is of the form "2/0000000000000000000000000290fb167208af455bb137780163b7b7a9a10c16/3", which is "emitter chain / emitter address / sequence number".
 
@@ -44,6 +51,13 @@
 //	{"originChainId":4, "originAddress":"0x00000000000000000000000086812b970bbdce75b4590243ba2cbff671d0b754"},
 //	{"originChainId":1, "originAddress":"0xc6fa7af3bedbad3a3d65f36aabc97431b1bbe4c2d2f6e0e47ca60203452f5d61"},
 //	]}
+//
+// Query: http://localhost:7071/v1/governor/network_status
+//
+// Returns the last status message received from each guardian (see SignedChainGovernorStatus below):
+// {"entries":[
+//	{"verifiedGuardianAddr":"0xbeFA429d57cD18b7F8A4d91A2da9AB4AF05d0FBe", "status":{"nodeName":"guardian-0", "counter":"42", "timestamp":"1662057609000000000", "chains":[...], "configVersion":"0x..."}}
+//	]}
 
 // The chain governor also supports the following Prometheus metrics:
 //
@@ -59,6 +73,20 @@
 // guardian_governor_total_enqueued_vaas 1
 // - This is a single metric that indicates the total number of enqueued VAAs across all chains. This provides a quick check if
 //   anything is currently being limited.
+//
+// guardian_governor_headroom_ratio{chain_id="1",chain_name="solana"} 0.304
+// - This metric is the available notional value of a governed chain as a fraction of its daily limit, so a single alert threshold
+//   works for chains with different limits.
+//
+// guardian_governor_pending_notional{chain_id="1",chain_name="solana"} 5000
+// - This metric is the total notional value of the VAAs currently enqueued for a governed chain.
+//
+// guardian_governor_oldest_enqueued_vaa_age_seconds{chain_id="1",chain_name="solana"} 3600
+// - This metric is the age of the oldest VAA currently enqueued for a governed chain, based on the message timestamp. It is zero
+//   if nothing is enqueued.
+//
+// guardian_governor_oldest_enqueued_vaa_age_seconds_total 3600
+// - This is the age of the oldest VAA currently enqueued across all chains.
 
 // The chain governor also publishes the following messages to the gossip network
 //
@@ -70,21 +98,27 @@
 //   - Published once a minute.
 //   - Contains a list of configured chains along with their remaining available notional value, the number of enqueued VAAs
 //     and information on zero or more enqueued VAAs.
-//   - Only the first 20 enqueued VAAs are include, to constrain the message size.
+//   - Also contains the digest of each listed enqueued VAA and the version of the config file the governor enforces.
+//   - Only the first 20 enqueued VAAs are include, to constrain the message size, and fewer if the message would still exceed
+//     maxStatusSize.
+//   - The last status received from each guardian is available through the public RPC (see ParseSignedStatus).
 
 package governor
 
 import (
 	"crypto/ecdsa"
+	"errors"
 	"fmt"
 	"sort"
 	"time"
 
+	"github.com/certusone/wormhole/node/pkg/common"
 	"github.com/certusone/wormhole/node/pkg/db"
 	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
 	publicrpcv1 "github.com/certusone/wormhole/node/pkg/proto/publicrpc/v1"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
 
 	ethCommon "github.com/ethereum/go-ethereum/common"
 	ethCrypto "github.com/ethereum/go-ethereum/crypto"
@@ -102,6 +136,9 @@ func (gov *ChainGovernor) Status() string {
 
 	startTime := time.Now().Add(-time.Minute * time.Duration(gov.dayLengthInMinutes))
 	var resp string
+	if gov.shadow {
+		resp = "governor is in shadow mode, pending vaas have been published already\n"
+	}
 	for _, ce := range gov.chains {
 		valueTrans := sumValue(ce.transfers, startTime)
 		s1 := fmt.Sprintf("chain: %v, dailyLimit: %v, total: %v, numPending: %v", ce.emitterChainId, ce.dailyLimit, valueTrans, len(ce.pending))
@@ -121,6 +158,267 @@ func (gov *ChainGovernor) Status() string {
 	return resp
 }
 
+// StateDump is the complete state of the chain governor, as returned by DumpState.
+type StateDump struct {
+	Timestamp time.Time `json:"timestamp"`
+	// ConfigVersion is the version of the config file being enforced, or empty if the built-in config is used.
+	ConfigVersion            string           `json:"configVersion"`
+	Shadow                   bool             `json:"shadow"`
+	DayLengthMinutes         int              `json:"dayLengthMinutes"`
+	ReleaseApprovalThreshold uint64           `json:"releaseApprovalThreshold"`
+	ReleaseApprovals         int              `json:"releaseApprovals"`
+	Chains                   []StateDumpChain `json:"chains"`
+}
+
+// StateDumpChain is the limits, usage and enqueued transfers of a governed chain. The notional values are in USD.
+type StateDumpChain struct {
+	ChainId                      vaa.ChainID `json:"chainId"`
+	ChainName                    string      `json:"chainName"`
+	EmitterAddress               string      `json:"emitterAddress"`
+	DailyLimit                   uint64      `json:"dailyLimit"`
+	BigTransactionSize           uint64      `json:"bigTransactionSize"`
+	ConfiguredDailyLimit         uint64      `json:"configuredDailyLimit"`
+	ConfiguredBigTransactionSize uint64      `json:"configuredBigTransactionSize"`
+	Overridden                   bool        `json:"overridden"`
+	Transfers                    int         `json:"transfers"`
+	// UsedNotional is the notional of the transfers of the last 24 hours, which is counted against the daily limit.
+	UsedNotional               uint64           `json:"usedNotional"`
+	RemainingAvailableNotional uint64           `json:"remainingAvailableNotional"`
+	PendingNotional            uint64           `json:"pendingNotional"`
+	Enqueued                   []StateDumpEntry `json:"enqueued"`
+}
+
+// StateDumpEntry is an enqueued transfer.
+type StateDumpEntry struct {
+	MsgID         string    `json:"msgId"`
+	TxHash        string    `json:"txHash"`
+	Timestamp     time.Time `json:"timestamp"`
+	AgeSeconds    int64     `json:"ageSeconds"`
+	ReleaseTime   time.Time `json:"releaseTime"`
+	NotionalValue uint64    `json:"notionalValue"`
+	// Reason is why the transfer was enqueued, one of "daily_limit", "big_transaction" or "denylist".
+	Reason string `json:"reason"`
+	// HeldForReview is set if the transfer is on a denylisted route, and so is only released by an admin.
+	HeldForReview bool `json:"heldForReview"`
+	Approvals     int  `json:"approvals"`
+	// Shadow is set if the transfer was published already and is only tracked in shadow mode.
+	Shadow bool `json:"shadow"`
+}
+
+// DumpState returns the complete state of the governor. Unlike Status, it is meant to be processed by tools. Chains are sorted by
+// chain ID and their enqueued transfers by message timestamp.
+func (gov *ChainGovernor) DumpState() *StateDump {
+	return gov.dumpStateForTime(time.Now())
+}
+
+func (gov *ChainGovernor) dumpStateForTime(now time.Time) *StateDump {
+	gov.mutex.Lock()
+	defer gov.mutex.Unlock()
+
+	startTime := now.Add(-time.Minute * time.Duration(gov.dayLengthInMinutes))
+	dump := &StateDump{
+		Timestamp:                now,
+		ConfigVersion:            gov.configVersion,
+		Shadow:                   gov.shadow,
+		DayLengthMinutes:         gov.dayLengthInMinutes,
+		ReleaseApprovalThreshold: gov.releaseApprovalThreshold,
+		ReleaseApprovals:         gov.releaseApprovals,
+		Chains:                   make([]StateDumpChain, 0, len(gov.chains)),
+	}
+	for _, ce := range gov.chains {
+		_, overridden := gov.limitOverrides[ce.emitterChainId]
+		used := sumValue(ce.transfers, startTime)
+		chain := StateDumpChain{
+			ChainId:                      ce.emitterChainId,
+			ChainName:                    ce.emitterChainId.String(),
+			EmitterAddress:               "0x" + ce.emitterAddr.String(),
+			DailyLimit:                   ce.dailyLimit,
+			BigTransactionSize:           ce.bigTransactionSize,
+			ConfiguredDailyLimit:         ce.cfgDailyLimit,
+			ConfiguredBigTransactionSize: ce.cfgBigTransactionSize,
+			Overridden:                   overridden,
+			Transfers:                    len(ce.transfers),
+			UsedNotional:                 used,
+			Enqueued:                     make([]StateDumpEntry, 0, len(ce.pending)),
+		}
+		if used < ce.dailyLimit {
+			chain.RemainingAvailableNotional = ce.dailyLimit - used
+		}
+
+		for _, pe := range ce.pending {
+			value, err := computeValue(pe.amount, pe.token)
+			if err != nil {
+				gov.logger.Error("failed to compute value of pending transfer", zap.String("msgID", pe.dbData.Msg.MessageIDString()), zap.Error(err))
+				value = 0
+			}
+			chain.PendingNotional += value
+			chain.Enqueued = append(chain.Enqueued, StateDumpEntry{
+				MsgID:         pe.dbData.Msg.MessageIDString(),
+				TxHash:        pe.dbData.Msg.TxHash.String(),
+				Timestamp:     pe.dbData.Msg.Timestamp,
+				AgeSeconds:    int64(now.Sub(pe.dbData.Msg.Timestamp).Seconds()),
+				ReleaseTime:   pe.dbData.ReleaseTime,
+				NotionalValue: value,
+				Reason:        pe.reason,
+				HeldForReview: gov.heldForReviewAlreadyLocked(pe),
+				Approvals:     len(pe.approvals),
+				Shadow:        pe.shadow,
+			})
+		}
+		sort.SliceStable(chain.Enqueued, func(i, j int) bool { return chain.Enqueued[i].Timestamp.Before(chain.Enqueued[j].Timestamp) })
+		dump.Chains = append(dump.Chains, chain)
+	}
+	sort.Slice(dump.Chains, func(i, j int) bool { return dump.Chains[i].ChainId < dump.Chains[j].ChainId })
+	return dump
+}
+
+// ChainLimit is the governor configuration of a single chain, as returned by ChainLimits and accepted by SetChainLimits.
+type ChainLimit struct {
+	ChainId            vaa.ChainID `json:"chainId"`
+	DailyLimit         uint64      `json:"dailyLimit"`
+	BigTransactionSize uint64      `json:"bigTransactionSize"`
+}
+
+// ChainLimits returns the limits currently being enforced for each governed chain, sorted by chain ID.
+func (gov *ChainGovernor) ChainLimits() []ChainLimit {
+	gov.mutex.Lock()
+	defer gov.mutex.Unlock()
+
+	limits := make([]ChainLimit, 0, len(gov.chains))
+	for _, ce := range gov.chains {
+		limits = append(limits, ChainLimit{ChainId: ce.emitterChainId, DailyLimit: ce.dailyLimit, BigTransactionSize: ce.bigTransactionSize})
+	}
+	sort.Slice(limits, func(i, j int) bool { return limits[i].ChainId < limits[j].ChainId })
+	return limits
+}
+
+// ChainLimitStatus is the limits being enforced for a chain together with its configured limits.
+type ChainLimitStatus struct {
+	ChainLimit
+	ConfiguredDailyLimit         uint64
+	ConfiguredBigTransactionSize uint64
+	// Overridden is set if the limits were changed at runtime, in which case they are enforced instead of the configured limits.
+	Overridden bool
+}
+
+// ChainLimitStatuses returns the enforced and configured limits of each governed chain, sorted by chain ID.
+func (gov *ChainGovernor) ChainLimitStatuses() []ChainLimitStatus {
+	gov.mutex.Lock()
+	defer gov.mutex.Unlock()
+
+	statuses := make([]ChainLimitStatus, 0, len(gov.chains))
+	for _, ce := range gov.chains {
+		_, overridden := gov.limitOverrides[ce.emitterChainId]
+		statuses = append(statuses, ChainLimitStatus{
+			ChainLimit:                   ChainLimit{ChainId: ce.emitterChainId, DailyLimit: ce.dailyLimit, BigTransactionSize: ce.bigTransactionSize},
+			ConfiguredDailyLimit:         ce.cfgDailyLimit,
+			ConfiguredBigTransactionSize: ce.cfgBigTransactionSize,
+			Overridden:                   overridden,
+		})
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].ChainId < statuses[j].ChainId })
+	return statuses
+}
+
+// SetChainLimits replaces the limits of the specified chains. Chains that are not listed keep their current limits. Chains cannot be
+// added to or removed from the governor at runtime, so it is an error to specify a chain that is not governed. Transfers which are
+// already pending are not re-evaluated, but are released under the new limits as usual.
+//
+// The new limits are stored in the database and enforced instead of the configured limits, also after a restart or a reload of the
+// config file, until they are set back to the configured limits or reset with ResetChainLimits.
+func (gov *ChainGovernor) SetChainLimits(limits []ChainLimit) error {
+	gov.mutex.Lock()
+	defer gov.mutex.Unlock()
+	return gov.setChainLimitsAlreadyLocked(limits)
+}
+
+// setChainLimitsAlreadyLocked is SetChainLimits for callers which already hold the lock.
+func (gov *ChainGovernor) setChainLimitsAlreadyLocked(limits []ChainLimit) error {
+	for _, l := range limits {
+		if _, exists := gov.chains[l.ChainId]; !exists {
+			return fmt.Errorf("%w: %v", ErrChainNotGoverned, l.ChainId)
+		}
+	}
+
+	for _, l := range limits {
+		ce := gov.chains[l.ChainId]
+		if ce.dailyLimit == l.DailyLimit && ce.bigTransactionSize == l.BigTransactionSize {
+			continue
+		}
+		if l.DailyLimit == ce.cfgDailyLimit && l.BigTransactionSize == ce.cfgBigTransactionSize {
+			if err := gov.deleteLimitOverride(ce.emitterChainId); err != nil {
+				return err
+			}
+		} else {
+			override := db.ChainLimit{EmitterChain: ce.emitterChainId, DailyLimit: l.DailyLimit, BigTransactionSize: l.BigTransactionSize}
+			if err := gov.db.StoreChainLimit(&override); err != nil {
+				return fmt.Errorf("failed to store limits of chain %v: %w", ce.emitterChainId, err)
+			}
+			if gov.limitOverrides == nil {
+				gov.limitOverrides = make(map[vaa.ChainID]db.ChainLimit)
+			}
+			gov.limitOverrides[ce.emitterChainId] = override
+		}
+		gov.logger.Info("changing chain limits",
+			zap.Stringer("emitterChainId", ce.emitterChainId),
+			zap.Uint64("oldDailyLimit", ce.dailyLimit),
+			zap.Uint64("newDailyLimit", l.DailyLimit),
+			zap.Uint64("oldBigTransactionSize", ce.bigTransactionSize),
+			zap.Uint64("newBigTransactionSize", l.BigTransactionSize),
+		)
+		ce.setLimits(l.DailyLimit, l.BigTransactionSize)
+	}
+
+	return nil
+}
+
+// ResetChainLimits goes back to enforcing the configured limits of the specified chains, which must be governed.
+func (gov *ChainGovernor) ResetChainLimits(chains []vaa.ChainID) error {
+	gov.mutex.Lock()
+	defer gov.mutex.Unlock()
+
+	for _, chainId := range chains {
+		if _, exists := gov.chains[chainId]; !exists {
+			return fmt.Errorf("%w: %v", ErrChainNotGoverned, chainId)
+		}
+	}
+
+	for _, chainId := range chains {
+		if _, exists := gov.limitOverrides[chainId]; !exists {
+			continue
+		}
+		if err := gov.deleteLimitOverride(chainId); err != nil {
+			return err
+		}
+		ce := gov.chains[chainId]
+		gov.logger.Info("resetting chain limits to the configured limits",
+			zap.Stringer("emitterChainId", chainId),
+			zap.Uint64("oldDailyLimit", ce.dailyLimit),
+			zap.Uint64("newDailyLimit", ce.cfgDailyLimit),
+			zap.Uint64("oldBigTransactionSize", ce.bigTransactionSize),
+			zap.Uint64("newBigTransactionSize", ce.cfgBigTransactionSize),
+		)
+		ce.setLimits(ce.cfgDailyLimit, ce.cfgBigTransactionSize)
+	}
+
+	return nil
+}
+
+// deleteLimitOverride removes the limits of a chain changed at runtime from the database. It must be called with the mutex held.
+func (gov *ChainGovernor) deleteLimitOverride(chainId vaa.ChainID) error {
+	if err := gov.db.DeleteChainLimit(chainId); err != nil {
+		return fmt.Errorf("failed to delete limits of chain %v: %w", chainId, err)
+	}
+	delete(gov.limitOverrides, chainId)
+	return nil
+}
+
+// ErrChainNotGoverned is returned when changing the limits of a chain that is not governed.
+var ErrChainNotGoverned = errors.New("chain is not governed")
+
+// ErrPendingVAANotFound is returned by the admin commands that operate on a pending VAA if there is no such VAA.
+var ErrPendingVAANotFound = errors.New("vaa not found in the pending list")
+
 // Admin command to reload the governor state from the database.
 func (gov *ChainGovernor) Reload() (string, error) {
 	gov.mutex.Lock()
@@ -159,10 +457,12 @@ func (gov *ChainGovernor) DropPendingVAA(vaaId string) (string, error) {
 					zap.Stringer("timeStamp", pe.dbData.Msg.Timestamp),
 				)
 
-				if err := gov.db.DeletePendingMsg(&pe.dbData); err != nil {
+				if err := gov.deletePendingAlreadyLocked(pe); err != nil {
 					return "", err
 				}
 
+				gov.discardApprovalsAlreadyLocked(pe, "dropped", time.Now())
+				gov.notify(EventDropped, pe, "admin", "", time.Now())
 				ce.pending = append(ce.pending[:idx], ce.pending[idx+1:]...)
 				str := fmt.Sprintf("vaa \"%v\" has been dropped from the pending list", msgId)
 				return str, nil
@@ -170,11 +470,16 @@ func (gov *ChainGovernor) DropPendingVAA(vaaId string) (string, error) {
 		}
 	}
 
-	return "", fmt.Errorf("vaa not found in the pending list")
+	return "", ErrPendingVAANotFound
+}
+
+// Admin command to remove a VAA from the pending list and publish it without regard to (or impact on) the daily limit. If the VAA
+// requires release approvals, the approval of approver is recorded instead, and the VAA is only released once it has enough of them.
+func (gov *ChainGovernor) ReleasePendingVAA(vaaId string, approver string) (string, error) {
+	return gov.releasePendingVAAForTime(vaaId, approver, time.Now())
 }
 
-// Admin command to remove a VAA from the pending list and publish it without regard to (or impact on) the daily limit.
-func (gov *ChainGovernor) ReleasePendingVAA(vaaId string) (string, error) {
+func (gov *ChainGovernor) releasePendingVAAForTime(vaaId string, approver string, now time.Time) (string, error) {
 	gov.mutex.Lock()
 	defer gov.mutex.Unlock()
 
@@ -183,21 +488,47 @@ func (gov *ChainGovernor) ReleasePendingVAA(vaaId string) (string, error) {
 			msgId := pe.dbData.Msg.MessageIDString()
 			if msgId == vaaId {
 				value, _ := computeValue(pe.amount, pe.token)
+				// Shadow entries are only tracked in memory, so they are released without approvals.
+				if !pe.shadow && gov.needsApprovals(value) {
+					approved, err := gov.approveReleaseAlreadyLocked(pe, approver, now)
+					if err != nil {
+						return "", err
+					}
+					if !approved {
+						str := fmt.Sprintf("approval of \"%v\" to release pending vaa \"%v\" has been recorded, it has %d of the %d required approvals",
+							approver, msgId, len(pe.approvals), gov.releaseApprovals)
+						return str, nil
+					}
+				}
+
 				gov.logger.Info("releasing pending vaa, should be published soon",
 					zap.String("msgId", msgId),
 					zap.Uint64("value", value),
 					zap.Stringer("timeStamp", pe.dbData.Msg.Timestamp),
+					zap.String("approver", approver),
 				)
 
-				gov.msgsToPublish = append(gov.msgsToPublish, &pe.dbData.Msg)
+				if !pe.shadow {
+					gov.msgsToPublish = append(gov.msgsToPublish, &pe.dbData.Msg)
+				}
 
 				// We delete the pending message from the database, but we don't add it to the transfers
 				// because released messages do not apply to the limit.
 
-				if err := gov.db.DeletePendingMsg(&pe.dbData); err != nil {
+				if err := gov.deletePendingAlreadyLocked(pe); err != nil {
 					return "", err
 				}
 
+				detail := fmt.Sprintf("value %d", value)
+				if len(pe.approvals) != 0 {
+					detail += ", approved by " + approvers(pe.approvals)
+					if err := gov.db.DeleteReleaseApprovals(msgId); err != nil {
+						gov.logger.Error("failed to delete release approvals", zap.String("msgId", msgId), zap.Error(err))
+					}
+				}
+				gov.audit(auditActionRelease, msgId, approver, detail, now)
+				gov.notify(EventReleased, pe, "admin", approver, now)
+
 				ce.pending = append(ce.pending[:idx], ce.pending[idx+1:]...)
 				str := fmt.Sprintf("pending vaa \"%v\" has been released and will be published soon", msgId)
 				return str, nil
@@ -205,41 +536,90 @@ func (gov *ChainGovernor) ReleasePendingVAA(vaaId string) (string, error) {
 		}
 	}
 
-	return "", fmt.Errorf("vaa not found in the pending list")
+	return "", ErrPendingVAANotFound
 }
 
+// maxReleaseTimerDelay is the longest delay the release timer of a pending VAA can be reset to.
+const maxReleaseTimerDelay = 7 * 24 * time.Hour
+
+// ErrInvalidReleaseDelay is returned when resetting release timers to a delay longer than maxReleaseTimerDelay.
+var ErrInvalidReleaseDelay = fmt.Errorf("the release delay must be positive and at most %v", maxReleaseTimerDelay)
+
 // Admin command to reset the release timer for a pending VAA, extending it to the configured limit.
 func (gov *ChainGovernor) ResetReleaseTimer(vaaId string) (string, error) {
 	return gov.resetReleaseTimerForTime(vaaId, time.Now())
 }
 
 func (gov *ChainGovernor) resetReleaseTimerForTime(vaaId string, now time.Time) (string, error) {
+	_, releaseTime, err := gov.resetReleaseTimersForTime([]string{vaaId}, vaa.ChainIDUnset, 0, now)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("release time on pending vaa \"%v\" has been updated to %v", vaaId, releaseTime.String()), nil
+}
+
+// Admin command to reset the release timers of several pending VAAs at once: those in vaaIds, and every VAA pending for chain unless
+// it is vaa.ChainIDUnset. The timers are reset to delay from now, or to the configured limit if delay is zero. If one of vaaIds is
+// not pending, no timer is reset. Returns the ids of the VAAs whose timers were reset and their new release time.
+func (gov *ChainGovernor) ResetReleaseTimers(vaaIds []string, chain vaa.ChainID, delay time.Duration) ([]string, time.Time, error) {
+	return gov.resetReleaseTimersForTime(vaaIds, chain, delay, time.Now())
+}
+
+func (gov *ChainGovernor) resetReleaseTimersForTime(vaaIds []string, chain vaa.ChainID, delay time.Duration, now time.Time) ([]string, time.Time, error) {
+	if delay < 0 || delay > maxReleaseTimerDelay {
+		return nil, time.Time{}, ErrInvalidReleaseDelay
+	}
+	if delay == 0 {
+		delay = maxEnqueuedTime
+	}
+
 	gov.mutex.Lock()
 	defer gov.mutex.Unlock()
 
-	for _, ce := range gov.chains {
-		for _, pe := range ce.pending {
-			msgId := pe.dbData.Msg.MessageIDString()
-			if msgId == vaaId {
-				pe.dbData.ReleaseTime = now.Add(maxEnqueuedTime)
-				gov.logger.Info("updating the release time due to admin command",
-					zap.String("msgId", msgId),
-					zap.Stringer("timeStamp", pe.dbData.Msg.Timestamp),
-					zap.Stringer("newReleaseTime", pe.dbData.ReleaseTime),
-				)
-
-				if err := gov.db.StorePendingMsg(&pe.dbData); err != nil {
-					gov.logger.Error("failed to store updated pending vaa", zap.String("msgID", msgId), zap.Error(err))
-					return "", err
+	var selected []*pendingEntry
+	if chain != vaa.ChainIDUnset {
+		ce, exists := gov.chains[chain]
+		if !exists {
+			return nil, time.Time{}, ErrChainNotGoverned
+		}
+		selected = append(selected, ce.pending...)
+	}
+	for _, vaaId := range vaaIds {
+		var found *pendingEntry
+		for _, ce := range gov.chains {
+			for _, pe := range ce.pending {
+				if pe.dbData.Msg.MessageIDString() == vaaId {
+					found = pe
 				}
-
-				str := fmt.Sprintf("release time on pending vaa \"%v\" has been updated to %v", msgId, pe.dbData.ReleaseTime.String())
-				return str, nil
 			}
 		}
+		if found == nil {
+			return nil, time.Time{}, fmt.Errorf("%w: %s", ErrPendingVAANotFound, vaaId)
+		}
+		if !slices.Contains(selected, found) {
+			selected = append(selected, found)
+		}
 	}
 
-	return "", fmt.Errorf("vaa not found in the pending list")
+	releaseTime := now.Add(delay)
+	msgIds := make([]string, 0, len(selected))
+	for _, pe := range selected {
+		msgId := pe.dbData.Msg.MessageIDString()
+		pe.dbData.ReleaseTime = releaseTime
+		gov.logger.Info("updating the release time due to admin command",
+			zap.String("msgId", msgId),
+			zap.Stringer("timeStamp", pe.dbData.Msg.Timestamp),
+			zap.Stringer("newReleaseTime", pe.dbData.ReleaseTime),
+		)
+
+		if err := gov.storePendingAlreadyLocked(pe); err != nil {
+			gov.logger.Error("failed to store updated pending vaa", zap.String("msgID", msgId), zap.Error(err))
+			return nil, time.Time{}, err
+		}
+		msgIds = append(msgIds, msgId)
+	}
+
+	return msgIds, releaseTime, nil
 }
 
 func sumValue(transfers []*db.Transfer, startTime time.Time) uint64 {
@@ -345,6 +725,104 @@ func (gov *ChainGovernor) IsVAAEnqueued(msgId *publicrpcv1.MessageID) (bool, err
 	return false, nil
 }
 
+// GetQueuePosition returns where the enqueued transfer with the message ID msgId, in the format of MessageIDString, or with the hex
+// encoded signing digest digest stands in the queue of its chain. Only one of msgId and digest is used, the other must be empty.
+func (gov *ChainGovernor) GetQueuePosition(msgId string, digest string) *publicrpcv1.GovernorGetQueuePositionResponse {
+	return gov.getQueuePositionForTime(msgId, digest, time.Now())
+}
+
+func (gov *ChainGovernor) getQueuePositionForTime(msgId string, digest string, now time.Time) *publicrpcv1.GovernorGetQueuePositionResponse {
+	gov.mutex.Lock()
+	defer gov.mutex.Unlock()
+
+	for _, ce := range gov.chains {
+		for idx, pe := range ce.pending {
+			if (msgId == "" || pe.dbData.Msg.MessageIDString() != msgId) && (digest == "" || pe.hash != digest) {
+				continue
+			}
+			value, err := computeValue(pe.amount, pe.token)
+			if err != nil {
+				gov.logger.Error("failed to compute value of pending transfer", zap.String("msgID", pe.dbData.Msg.MessageIDString()), zap.Error(err))
+				value = 0
+			}
+			resp := &publicrpcv1.GovernorGetQueuePositionResponse{
+				IsEnqueued: true,
+				MessageId: &publicrpcv1.MessageID{
+					EmitterChain:   publicrpcv1.ChainID(pe.dbData.Msg.EmitterChain),
+					EmitterAddress: pe.dbData.Msg.EmitterAddress.String(),
+					Sequence:       pe.dbData.Msg.Sequence,
+				},
+				Position:      uint32(idx + 1),
+				QueueLength:   uint32(len(ce.pending)),
+				Reason:        pe.reason,
+				NotionalValue: value,
+				ReleaseTime:   uint32(pe.dbData.ReleaseTime.Unix()),
+			}
+			if wait := now.Sub(pe.dbData.Msg.Timestamp); wait > 0 {
+				resp.WaitSeconds = uint64(wait.Seconds())
+			}
+			if !gov.heldForReviewAlreadyLocked(pe) {
+				resp.EstimatedReleaseTime = uint32(gov.estimateReleaseTimeAlreadyLocked(ce, idx, value, now).Unix())
+			}
+			return resp
+		}
+	}
+
+	return &publicrpcv1.GovernorGetQueuePositionResponse{}
+}
+
+// estimateReleaseTimeAlreadyLocked returns when the transfer at idx in the queue of ce, worth value, would be released if no other
+// transfer were made on the chain: once the transfers of the last day have aged out enough for it and for the transfers ahead of it
+// to fit in the daily limit, or at its release time if that comes first. It assumes the caller holds the lock.
+func (gov *ChainGovernor) estimateReleaseTimeAlreadyLocked(ce *chainEntry, idx int, value uint64, now time.Time) time.Time {
+	pe := ce.pending[idx]
+	releaseTime := pe.dbData.ReleaseTime
+	if ce.isBigTransfer(value) {
+		return releaseTime
+	}
+
+	// The transfers ahead in the queue are released first, unless they are held for review or wait for their release time.
+	needed := value
+	for _, ahead := range ce.pending[:idx] {
+		if gov.heldForReviewAlreadyLocked(ahead) {
+			continue
+		}
+		if v, err := computeValue(ahead.amount, ahead.token); err == nil && !ce.isBigTransfer(v) {
+			needed += v
+		}
+	}
+	if needed > ce.dailyLimit {
+		return releaseTime
+	}
+
+	dayLength := time.Minute * time.Duration(gov.dayLengthInMinutes)
+	startTime := now.Add(-dayLength)
+	used := sumValue(ce.transfers, startTime)
+	if used+needed <= ce.dailyLimit {
+		return now
+	}
+
+	// Replay the transfers of the last day aging out, until enough of the limit is available.
+	window := make([]*db.Transfer, 0, len(ce.transfers))
+	for _, t := range ce.transfers {
+		if !t.Timestamp.Before(startTime) {
+			window = append(window, t)
+		}
+	}
+	sort.Slice(window, func(i, j int) bool { return window[i].Timestamp.Before(window[j].Timestamp) })
+	for _, t := range window {
+		expiry := t.Timestamp.Add(dayLength)
+		if !expiry.Before(releaseTime) {
+			break
+		}
+		used -= t.Value
+		if used+needed <= ce.dailyLimit {
+			return expiry
+		}
+	}
+	return releaseTime
+}
+
 // REST query to get the list of tokens being monitored by the governor.
 func (gov *ChainGovernor) GetTokenList() []*publicrpcv1.GovernorGetTokenListResponse_Entry {
 	gov.mutex.Lock()
@@ -396,13 +874,42 @@ var (
 			Name: "guardian_governor_total_enqueued_vaas",
 			Help: "Chain governor total number of VAAs enqueued due to limiting across all chains",
 		})
+
+	// guardian_governor_headroom_ratio{chain_id="1",chain_name="solana"} 0.5
+	metricHeadroomRatio = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "guardian_governor_headroom_ratio",
+			Help: "Chain governor remaining available notional value as a fraction of the daily limit per governed chain",
+		}, []string{"chain_id", "chain_name"})
+
+	// guardian_governor_pending_notional{chain_id="1",chain_name="solana"} 5000
+	metricPendingNotional = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "guardian_governor_pending_notional",
+			Help: "Chain governor total notional value of the VAAs enqueued per governed chain",
+		}, []string{"chain_id", "chain_name"})
+
+	// guardian_governor_oldest_enqueued_vaa_age_seconds{chain_id="1",chain_name="solana"} 3600
+	metricOldestEnqueuedAge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "guardian_governor_oldest_enqueued_vaa_age_seconds",
+			Help: "Chain governor age of the oldest VAA enqueued per governed chain",
+		}, []string{"chain_id", "chain_name"})
+
+	// guardian_governor_oldest_enqueued_vaa_age_seconds_total 3600
+	metricTotalOldestEnqueuedAge = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "guardian_governor_oldest_enqueued_vaa_age_seconds_total",
+			Help: "Chain governor age of the oldest VAA enqueued across all chains",
+		})
 )
 
 func (gov *ChainGovernor) CollectMetrics(hb *gossipv1.Heartbeat, sendC chan<- []byte, gk *ecdsa.PrivateKey, ourAddr ethCommon.Address) {
 	gov.mutex.Lock()
 	defer gov.mutex.Unlock()
 
-	startTime := time.Now().Add(-time.Minute * time.Duration(gov.dayLengthInMinutes))
+	now := time.Now()
+	startTime := now.Add(-time.Minute * time.Duration(gov.dayLengthInMinutes))
 	totalPending := 0
 	for _, n := range hb.Networks {
 		if n == nil {
@@ -451,6 +958,7 @@ func (gov *ChainGovernor) CollectMetrics(hb *gossipv1.Heartbeat, sendC chan<- []
 	}
 
 	metricTotalEnqueuedVAAs.Set(float64(totalPending))
+	gov.collectQueueMetricsAlreadyLocked(now, startTime)
 
 	if startTime.After(gov.nextConfigPublishTime) {
 		gov.publishConfig(hb, sendC, gk, ourAddr)
@@ -463,9 +971,77 @@ func (gov *ChainGovernor) CollectMetrics(hb *gossipv1.Heartbeat, sendC chan<- []
 	}
 }
 
+// collectQueueMetricsAlreadyLocked updates the headroom, pending notional and queue age metrics of the governed chains. It assumes the
+// caller holds the lock.
+func (gov *ChainGovernor) collectQueueMetricsAlreadyLocked(now time.Time, startTime time.Time) {
+	var oldestOverall time.Duration
+	for _, ce := range gov.chains {
+		chainId := fmt.Sprint(uint16(ce.emitterChainId))
+		chainName := ce.emitterChainId.String()
+
+		headroom := 0.0
+		if value := sumValue(ce.transfers, startTime); ce.dailyLimit != 0 && value < ce.dailyLimit {
+			headroom = float64(ce.dailyLimit-value) / float64(ce.dailyLimit)
+		}
+		metricHeadroomRatio.WithLabelValues(chainId, chainName).Set(headroom)
+
+		var pendingValue uint64
+		var oldest time.Duration
+		for _, pe := range ce.pending {
+			if value, err := computeValue(pe.amount, pe.token); err == nil {
+				pendingValue += value
+			}
+			if age := now.Sub(pe.dbData.Msg.Timestamp); age > oldest {
+				oldest = age
+			}
+		}
+		metricPendingNotional.WithLabelValues(chainId, chainName).Set(float64(pendingValue))
+		metricOldestEnqueuedAge.WithLabelValues(chainId, chainName).Set(oldest.Seconds())
+		if oldest > oldestOverall {
+			oldestOverall = oldest
+		}
+	}
+	metricTotalOldestEnqueuedAge.Set(oldestOverall.Seconds())
+}
+
 var governorMessagePrefixConfig = []byte("governor_config_000000000000000000|")
 var governorMessagePrefixStatus = []byte("governor_status_000000000000000000|")
 
+const (
+	// maxStatusEnqueuedVAAs is the maximum number of enqueued VAAs listed in a status message, across all chains.
+	maxStatusEnqueuedVAAs = 20
+
+	// maxStatusSize caps the size of a serialized status message. Larger statuses received from other guardians are rejected.
+	maxStatusSize = 16 * 1024
+)
+
+// ParseSignedStatus checks that a status message received from the gossip network is signed by a guardian of the guardian set,
+// and returns its content and signer.
+func ParseSignedStatus(s *gossipv1.SignedChainGovernorStatus, gs *common.GuardianSet) (*gossipv1.ChainGovernorStatus, ethCommon.Address, error) {
+	addr := ethCommon.BytesToAddress(s.GuardianAddr)
+	if _, ok := gs.KeyIndex(addr); !ok {
+		return nil, addr, fmt.Errorf("%s is not in the guardian set", addr)
+	}
+	if len(s.Status) > maxStatusSize {
+		return nil, addr, fmt.Errorf("status is %d bytes, which exceeds the maximum of %d", len(s.Status), maxStatusSize)
+	}
+
+	digest := ethCrypto.Keccak256Hash(append(governorMessagePrefixStatus, s.Status...))
+	pubKey, err := ethCrypto.SigToPub(digest.Bytes(), s.Signature)
+	if err != nil {
+		return nil, addr, fmt.Errorf("invalid signature: %w", err)
+	}
+	if signer := ethCrypto.PubkeyToAddress(*pubKey); signer != addr {
+		return nil, addr, fmt.Errorf("status of %s is signed by %s", addr, signer)
+	}
+
+	var status gossipv1.ChainGovernorStatus
+	if err := proto.Unmarshal(s.Status, &status); err != nil {
+		return nil, addr, fmt.Errorf("failed to unmarshal status: %w", err)
+	}
+	return &status, addr, nil
+}
+
 func (gov *ChainGovernor) publishConfig(hb *gossipv1.Heartbeat, sendC chan<- []byte, gk *ecdsa.PrivateKey, ourAddr ethCommon.Address) {
 	chains := make([]*gossipv1.ChainGovernorConfig_Chain, 0)
 	for _, ce := range gov.chains {
@@ -523,7 +1099,8 @@ func (gov *ChainGovernor) publishConfig(hb *gossipv1.Heartbeat, sendC chan<- []b
 	sendC <- b
 }
 
-func (gov *ChainGovernor) publishStatus(hb *gossipv1.Heartbeat, sendC chan<- []byte, startTime time.Time, gk *ecdsa.PrivateKey, ourAddr ethCommon.Address) {
+// statusAlreadyLocked builds the status message published to the gossip network. It assumes the caller holds the lock.
+func (gov *ChainGovernor) statusAlreadyLocked(nodeName string, timestamp int64, startTime time.Time) *gossipv1.ChainGovernorStatus {
 	chains := make([]*gossipv1.ChainGovernorStatus_Chain, 0)
 	numEnqueued := 0
 	for _, ce := range gov.chains {
@@ -542,13 +1119,14 @@ func (gov *ChainGovernor) publishStatus(hb *gossipv1.Heartbeat, sendC chan<- []b
 				value = 0
 			}
 
-			if numEnqueued < 20 {
+			if numEnqueued < maxStatusEnqueuedVAAs {
 				numEnqueued = numEnqueued + 1
 				enqueuedVaas = append(enqueuedVaas, &gossipv1.ChainGovernorStatus_EnqueuedVAA{
 					Sequence:      pe.dbData.Msg.Sequence,
 					ReleaseTime:   uint32(pe.dbData.ReleaseTime.Unix()),
 					NotionalValue: value,
 					TxHash:        pe.dbData.Msg.TxHash.String(),
+					Digest:        pe.dbData.Msg.CreateVAA(0).SigningDigest().Bytes(),
 				})
 			}
 		}
@@ -566,15 +1144,45 @@ func (gov *ChainGovernor) publishStatus(hb *gossipv1.Heartbeat, sendC chan<- []b
 		})
 	}
 
-	gov.statusPublishCounter += 1
-	payload := &gossipv1.ChainGovernorStatus{
-		NodeName:  hb.NodeName,
-		Counter:   gov.statusPublishCounter,
-		Timestamp: hb.Timestamp,
-		Chains:    chains,
+	return &gossipv1.ChainGovernorStatus{
+		NodeName:      nodeName,
+		Counter:       gov.statusPublishCounter,
+		Timestamp:     timestamp,
+		Chains:        chains,
+		Shadow:        gov.shadow,
+		ConfigVersion: gov.configVersion,
 	}
+}
 
-	b, err := proto.Marshal(payload)
+// marshalStatus serializes a status message. If it exceeds maxStatusSize, the enqueued VAAs are left out one by one, starting with
+// the last, until it fits. The totals of enqueued VAAs are always included.
+func marshalStatus(payload *gossipv1.ChainGovernorStatus) ([]byte, error) {
+	for {
+		b, err := proto.Marshal(payload)
+		if err != nil || len(b) <= maxStatusSize {
+			return b, err
+		}
+		trimmed := false
+		for i := len(payload.Chains) - 1; i >= 0 && !trimmed; i-- {
+			for _, emitter := range payload.Chains[i].Emitters {
+				if n := len(emitter.EnqueuedVaas); n != 0 {
+					emitter.EnqueuedVaas = emitter.EnqueuedVaas[:n-1]
+					trimmed = true
+					break
+				}
+			}
+		}
+		if !trimmed {
+			return nil, fmt.Errorf("status message is %d bytes, which exceeds the maximum of %d", len(b), maxStatusSize)
+		}
+	}
+}
+
+func (gov *ChainGovernor) publishStatus(hb *gossipv1.Heartbeat, sendC chan<- []byte, startTime time.Time, gk *ecdsa.PrivateKey, ourAddr ethCommon.Address) {
+	gov.statusPublishCounter += 1
+	payload := gov.statusAlreadyLocked(hb.NodeName, hb.Timestamp, startTime)
+
+	b, err := marshalStatus(payload)
 	if err != nil {
 		gov.logger.Error("failed to marshal status message", zap.Error(err))
 		return
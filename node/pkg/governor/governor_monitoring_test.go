@@ -1,11 +1,20 @@
 package governor
 
 import (
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	ethCrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.uber.org/zap"
 )
 
@@ -16,3 +25,256 @@ func TestIsVAAEnqueuedNilMessageID(t *testing.T) {
 	require.EqualError(t, err, "no message ID specified")
 	assert.Equal(t, false, enqueued)
 }
+
+func TestChainLimitsArePersisted(t *testing.T) {
+	database, err := db.OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer database.Close()
+
+	gov := NewChainGovernor(zap.NewNop(), database, common.GoTest)
+	require.NoError(t, gov.initConfig())
+	ce := gov.chains[vaa.ChainIDSolana]
+	configured := ChainLimit{ChainId: vaa.ChainIDSolana, DailyLimit: ce.cfgDailyLimit, BigTransactionSize: ce.cfgBigTransactionSize}
+
+	require.NoError(t, gov.SetChainLimits([]ChainLimit{{ChainId: vaa.ChainIDSolana, DailyLimit: 1000}}))
+	assert.False(t, ce.checkForBigTransactions)
+	assert.ErrorIs(t, gov.SetChainLimits([]ChainLimit{{ChainId: vaa.ChainID(2)}}), ErrChainNotGoverned)
+
+	// A restarted governor enforces the changed limits instead of the configured ones.
+	restarted := NewChainGovernor(zap.NewNop(), database, common.GoTest)
+	require.NoError(t, restarted.initConfig())
+	require.NoError(t, restarted.loadFromDB())
+	assert.Equal(t, []ChainLimitStatus{{
+		ChainLimit:                   ChainLimit{ChainId: vaa.ChainIDSolana, DailyLimit: 1000},
+		ConfiguredDailyLimit:         configured.DailyLimit,
+		ConfiguredBigTransactionSize: configured.BigTransactionSize,
+		Overridden:                   true,
+	}}, restarted.ChainLimitStatuses())
+
+	// Setting the configured limits again is the same as resetting them.
+	require.NoError(t, restarted.SetChainLimits([]ChainLimit{configured}))
+	limits, err := database.GetChainLimits()
+	require.NoError(t, err)
+	assert.Empty(t, limits)
+	assert.False(t, restarted.ChainLimitStatuses()[0].Overridden)
+
+	require.NoError(t, restarted.SetChainLimits([]ChainLimit{{ChainId: vaa.ChainIDSolana, DailyLimit: 1000}}))
+	require.NoError(t, restarted.ResetChainLimits([]vaa.ChainID{vaa.ChainIDSolana}))
+	assert.Equal(t, []ChainLimit{configured}, restarted.ChainLimits())
+	limits, err = database.GetChainLimits()
+	require.NoError(t, err)
+	assert.Empty(t, limits)
+}
+
+func TestQueueMetrics(t *testing.T) {
+	keys, signers := testConfigSigners(t, 1)
+	path := filepath.Join(t.TempDir(), "governor.json")
+	writeTestConfigFile(t, path, testSignedConfig(t, testConfig(100), keys))
+	gov := NewChainGovernor(zap.NewNop(), &db.MockGovernorDB{}, common.GoTest)
+	gov.UseConfigFile(path, signers)
+	require.NoError(t, gov.initConfig())
+
+	// Two SOL are worth 69, so the second transfer exceeds the daily limit and is enqueued.
+	now := time.Now()
+	for seq := uint64(1); seq <= 2; seq++ {
+		_, err := gov.ProcessMsgForTime(testSolTransfer(t, gov, seq, vaa.ChainIDSolana, 2, 2, now.Add(-time.Hour)), now)
+		require.NoError(t, err)
+	}
+
+	gov.collectQueueMetricsAlreadyLocked(now, now.Add(-24*time.Hour))
+	assert.InDelta(t, 0.31, testutil.ToFloat64(metricHeadroomRatio.WithLabelValues("1", "solana")), 0.001)
+	assert.Equal(t, 69.0, testutil.ToFloat64(metricPendingNotional.WithLabelValues("1", "solana")))
+	assert.Equal(t, 3600.0, testutil.ToFloat64(metricOldestEnqueuedAge.WithLabelValues("1", "solana")))
+	assert.Equal(t, 3600.0, testutil.ToFloat64(metricTotalOldestEnqueuedAge))
+}
+
+func TestStatusMessage(t *testing.T) {
+	keys, signers := testConfigSigners(t, 1)
+	path := filepath.Join(t.TempDir(), "governor.json")
+	cfg := testSignedConfig(t, testConfig(100), keys)
+	writeTestConfigFile(t, path, cfg)
+	gov := NewChainGovernor(zap.NewNop(), &db.MockGovernorDB{}, common.GoTest)
+	gov.UseConfigFile(path, signers)
+	require.NoError(t, gov.initConfig())
+
+	// Two SOL are worth 69, so the second transfer exceeds the daily limit and is enqueued.
+	now := time.Now()
+	var enqueued *common.MessagePublication
+	for seq := uint64(1); seq <= 2; seq++ {
+		enqueued = testSolTransfer(t, gov, seq, vaa.ChainIDSolana, 2, 2, now)
+		_, err := gov.ProcessMsgForTime(enqueued, now)
+		require.NoError(t, err)
+	}
+
+	status := gov.statusAlreadyLocked("guardian", now.UnixNano(), now.Add(-24*time.Hour))
+	digest, err := ConfigDigest(cfg.Config)
+	require.NoError(t, err)
+	assert.Equal(t, digest.Hex(), status.ConfigVersion)
+	require.Len(t, status.Chains, 1)
+	assert.Equal(t, uint64(31), status.Chains[0].RemainingAvailableNotional)
+	vaas := status.Chains[0].Emitters[0].EnqueuedVaas
+	require.Len(t, vaas, 1)
+	assert.Equal(t, enqueued.CreateVAA(0).SigningDigest().Bytes(), vaas[0].Digest)
+
+	// The status is signed, and verified against the guardian set by the receiving guardians.
+	b, err := marshalStatus(status)
+	require.NoError(t, err)
+	sig, err := ethCrypto.Sign(ethCrypto.Keccak256Hash(append(governorMessagePrefixStatus, b...)).Bytes(), keys[0])
+	require.NoError(t, err)
+	signed := &gossipv1.SignedChainGovernorStatus{Status: b, Signature: sig, GuardianAddr: signers[0].Bytes()}
+	parsed, signer, err := ParseSignedStatus(signed, &common.GuardianSet{Keys: signers})
+	require.NoError(t, err)
+	assert.Equal(t, signers[0], signer)
+	assert.Equal(t, status.ConfigVersion, parsed.ConfigVersion)
+	_, _, err = ParseSignedStatus(signed, &common.GuardianSet{Keys: []ethCommon.Address{{1}}})
+	assert.ErrorContains(t, err, "is not in the guardian set")
+	signed.Status = append(signed.Status, 0)
+	_, _, err = ParseSignedStatus(signed, &common.GuardianSet{Keys: signers})
+	assert.ErrorContains(t, err, "is signed by")
+
+	// Statuses above the size cap leave out enqueued VAAs until they fit.
+	status.Chains[0].Emitters[0].EnqueuedVaas = make([]*gossipv1.ChainGovernorStatus_EnqueuedVAA, maxStatusEnqueuedVAAs)
+	for i := range status.Chains[0].Emitters[0].EnqueuedVaas {
+		status.Chains[0].Emitters[0].EnqueuedVaas[i] = &gossipv1.ChainGovernorStatus_EnqueuedVAA{TxHash: strings.Repeat("0", 1024)}
+	}
+	b, err = marshalStatus(status)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(b), maxStatusSize)
+	assert.Less(t, len(status.Chains[0].Emitters[0].EnqueuedVaas), maxStatusEnqueuedVAAs)
+	assert.NotEmpty(t, status.Chains[0].Emitters[0].EnqueuedVaas)
+}
+
+func TestDumpState(t *testing.T) {
+	keys, signers := testConfigSigners(t, 1)
+	path := filepath.Join(t.TempDir(), "governor.json")
+	cfg := testSignedConfig(t, testConfig(100), keys)
+	writeTestConfigFile(t, path, cfg)
+	gov := NewChainGovernor(zap.NewNop(), &db.MockGovernorDB{}, common.GoTest)
+	gov.UseConfigFile(path, signers)
+	require.NoError(t, gov.initConfig())
+
+	// Two SOL are worth 69, so the second transfer exceeds the daily limit and is enqueued.
+	now := time.Now()
+	for seq := uint64(1); seq <= 2; seq++ {
+		_, err := gov.ProcessMsgForTime(testSolTransfer(t, gov, seq, vaa.ChainIDSolana, 2, 2, now.Add(-time.Hour)), now)
+		require.NoError(t, err)
+	}
+
+	dump := gov.dumpStateForTime(now)
+	digest, err := ConfigDigest(cfg.Config)
+	require.NoError(t, err)
+	assert.Equal(t, digest.Hex(), dump.ConfigVersion)
+	require.Len(t, dump.Chains, 1)
+	chain := dump.Chains[0]
+	assert.Equal(t, vaa.ChainIDSolana, chain.ChainId)
+	assert.Equal(t, uint64(100), chain.DailyLimit)
+	assert.Equal(t, 1, chain.Transfers)
+	assert.Equal(t, uint64(69), chain.UsedNotional)
+	assert.Equal(t, uint64(31), chain.RemainingAvailableNotional)
+	assert.Equal(t, uint64(69), chain.PendingNotional)
+	require.Len(t, chain.Enqueued, 1)
+	assert.Equal(t, "daily_limit", chain.Enqueued[0].Reason)
+	assert.Equal(t, int64(3600), chain.Enqueued[0].AgeSeconds)
+	assert.False(t, chain.Enqueued[0].HeldForReview)
+
+	// The reason is not persisted, and is derived again for the transfers reloaded from the database.
+	ce := gov.chains[vaa.ChainIDSolana]
+	assert.Equal(t, "daily_limit", gov.enqueueReasonAlreadyLocked(ce, ce.pending[0]))
+}
+
+func TestResetReleaseTimers(t *testing.T) {
+	keys, signers := testConfigSigners(t, 1)
+	path := filepath.Join(t.TempDir(), "governor.json")
+	writeTestConfigFile(t, path, testSignedConfig(t, testConfig(100), keys))
+	gov := NewChainGovernor(zap.NewNop(), &db.MockGovernorDB{}, common.GoTest)
+	gov.UseConfigFile(path, signers)
+	require.NoError(t, gov.initConfig())
+
+	// Two SOL are worth 69, so the second and third transfers exceed the daily limit and are enqueued.
+	now := time.Now()
+	var enqueued []string
+	for seq := uint64(1); seq <= 3; seq++ {
+		msg := testSolTransfer(t, gov, seq, vaa.ChainIDSolana, 2, 2, now)
+		_, err := gov.ProcessMsgForTime(msg, now)
+		require.NoError(t, err)
+		if seq > 1 {
+			enqueued = append(enqueued, msg.MessageIDString())
+		}
+	}
+	pending := gov.chains[vaa.ChainIDSolana].pending
+	require.Len(t, pending, 2)
+
+	// Nothing is reset if one of the VAAs is not pending.
+	later := now.Add(time.Hour)
+	_, _, err := gov.resetReleaseTimersForTime([]string{enqueued[0], "1/0000/1"}, vaa.ChainIDUnset, 0, later)
+	assert.ErrorIs(t, err, ErrPendingVAANotFound)
+	assert.Equal(t, now.Add(maxEnqueuedTime), pending[0].dbData.ReleaseTime)
+
+	_, _, err = gov.resetReleaseTimersForTime(enqueued, vaa.ChainIDUnset, 8*24*time.Hour, later)
+	assert.ErrorIs(t, err, ErrInvalidReleaseDelay)
+	_, _, err = gov.resetReleaseTimersForTime(nil, vaa.ChainID(2), 0, later)
+	assert.ErrorIs(t, err, ErrChainNotGoverned)
+
+	msgIds, releaseTime, err := gov.resetReleaseTimersForTime([]string{enqueued[1]}, vaa.ChainIDUnset, 48*time.Hour, later)
+	require.NoError(t, err)
+	assert.Equal(t, []string{enqueued[1]}, msgIds)
+	assert.Equal(t, later.Add(48*time.Hour), releaseTime)
+	assert.Equal(t, now.Add(maxEnqueuedTime), pending[0].dbData.ReleaseTime)
+	assert.Equal(t, releaseTime, pending[1].dbData.ReleaseTime)
+
+	// Selecting a VAA both by id and by chain resets it once.
+	msgIds, releaseTime, err = gov.resetReleaseTimersForTime([]string{enqueued[1]}, vaa.ChainIDSolana, 0, later)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, enqueued, msgIds)
+	assert.Equal(t, later.Add(maxEnqueuedTime), releaseTime)
+	for _, pe := range pending {
+		assert.Equal(t, releaseTime, pe.dbData.ReleaseTime)
+	}
+}
+
+func TestGetQueuePosition(t *testing.T) {
+	keys, signers := testConfigSigners(t, 1)
+	path := filepath.Join(t.TempDir(), "governor.json")
+	writeTestConfigFile(t, path, testSignedConfig(t, testConfig(100), keys))
+	gov := NewChainGovernor(zap.NewNop(), &db.MockGovernorDB{}, common.GoTest)
+	gov.UseConfigFile(path, signers)
+	require.NoError(t, gov.initConfig())
+
+	// Two SOL are worth 69, so the transfers after the first one exceed the daily limit and are enqueued.
+	start := time.Unix(1700000000, 0)
+	now := start.Add(time.Hour)
+	first := testSolTransfer(t, gov, 1, vaa.ChainIDSolana, 2, 2, start)
+	_, err := gov.ProcessMsgForTime(first, start)
+	require.NoError(t, err)
+	var enqueued []*common.MessagePublication
+	for seq := uint64(2); seq <= 3; seq++ {
+		msg := testSolTransfer(t, gov, seq, vaa.ChainIDSolana, 2, 2, now)
+		_, err := gov.ProcessMsgForTime(msg, now)
+		require.NoError(t, err)
+		enqueued = append(enqueued, msg)
+	}
+
+	later := now.Add(10 * time.Minute)
+	resp := gov.getQueuePositionForTime(enqueued[0].MessageIDString(), "", later)
+	assert.True(t, resp.IsEnqueued)
+	assert.Equal(t, enqueued[0].Sequence, resp.MessageId.Sequence)
+	assert.Equal(t, uint32(1), resp.Position)
+	assert.Equal(t, uint32(2), resp.QueueLength)
+	assert.Equal(t, uint64(600), resp.WaitSeconds)
+	assert.Equal(t, "daily_limit", resp.Reason)
+	assert.Equal(t, uint64(69), resp.NotionalValue)
+	assert.Equal(t, uint32(now.Add(maxEnqueuedTime).Unix()), resp.ReleaseTime)
+	// The first transfer fits in the limit again once the transfer made an hour before it has aged out.
+	assert.Equal(t, uint32(start.Add(24*time.Hour).Unix()), resp.EstimatedReleaseTime)
+
+	// The second transfer only fits once the first one is released, which leaves no room for it before its own release time.
+	resp = gov.getQueuePositionForTime("", gov.HashFromMsg(enqueued[1]), later)
+	assert.True(t, resp.IsEnqueued)
+	assert.Equal(t, enqueued[1].Sequence, resp.MessageId.Sequence)
+	assert.Equal(t, uint32(2), resp.Position)
+	assert.Equal(t, resp.ReleaseTime, resp.EstimatedReleaseTime)
+
+	resp = gov.getQueuePositionForTime(first.MessageIDString(), "", later)
+	assert.False(t, resp.IsEnqueued)
+	assert.Nil(t, resp.MessageId)
+}
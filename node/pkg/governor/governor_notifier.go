@@ -0,0 +1,154 @@
+// This file contains the webhook notifier of the chain governor.
+//
+// If a webhook is configured with UseWebhook, an Event is posted to it as JSON whenever a transfer is enqueued, released or dropped.
+// Events are queued and posted by a separate routine, so a slow or unreachable webhook never holds up the governor. If the queue is
+// full, or posting an event fails, the event is logged and counted in the metrics, but not retried.
+
+package governor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/supervisor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+const (
+	// The types of governor events.
+	EventEnqueued = "enqueued"
+	EventReleased = "released"
+	EventDropped  = "dropped"
+
+	webhookQueueSize = 1000
+	webhookTimeout   = 10 * time.Second
+)
+
+var (
+	metricWebhookEvents = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "guardian_governor_webhook_events_total",
+			Help: "Total number of chain governor events posted to the webhook, by type",
+		}, []string{"type"})
+
+	metricWebhookFailures = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "guardian_governor_webhook_failures_total",
+			Help: "Total number of chain governor events that could not be posted to the webhook, by cause",
+		}, []string{"cause"})
+)
+
+// Event is the body posted to the webhook.
+type Event struct {
+	Type             string      `json:"type"`
+	Timestamp        time.Time   `json:"timestamp"`
+	MsgID            string      `json:"msgId"`
+	TxHash           string      `json:"txHash"`
+	EmitterChain     vaa.ChainID `json:"emitterChain"`
+	EmitterChainName string      `json:"emitterChainName"`
+	Value            uint64      `json:"value"`
+	ReleaseTime      time.Time   `json:"releaseTime"`
+	// Reason is why the transfer was enqueued, released or dropped, for example "daily_limit", "release_time" or "admin".
+	Reason string `json:"reason"`
+	// Actor is the approver who released the transfer through the admin service, if any.
+	Actor string `json:"actor,omitempty"`
+	// Shadow is set if the governor runs in shadow mode, so the transfer was not actually held back.
+	Shadow bool `json:"shadow,omitempty"`
+}
+
+type webhookNotifier struct {
+	url    string
+	client *http.Client
+	events chan *Event
+}
+
+// UseWebhook makes the governor post its events to url. It must be called before Run.
+func (gov *ChainGovernor) UseWebhook(url string) {
+	gov.notifier = &webhookNotifier{
+		url:    url,
+		client: &http.Client{Timeout: webhookTimeout},
+		events: make(chan *Event, webhookQueueSize),
+	}
+}
+
+func (gov *ChainGovernor) startNotifier(ctx context.Context) error {
+	if gov.notifier == nil {
+		return nil
+	}
+	return supervisor.Run(ctx, "govwebhook", gov.runNotifier)
+}
+
+// runNotifier is the routine that posts the queued events to the webhook.
+func (gov *ChainGovernor) runNotifier(ctx context.Context) error {
+	supervisor.Signal(ctx, supervisor.SignalHealthy)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e := <-gov.notifier.events:
+			if err := gov.notifier.post(ctx, e); err != nil {
+				metricWebhookFailures.WithLabelValues("post").Inc()
+				gov.logger.Error("failed to post governor event to the webhook", zap.String("type", e.Type), zap.String("msgID", e.MsgID), zap.Error(err))
+				continue
+			}
+			metricWebhookEvents.WithLabelValues(e.Type).Inc()
+		}
+	}
+}
+
+func (n *webhookNotifier) post(ctx context.Context, e *Event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %s", resp.Status)
+	}
+	return nil
+}
+
+// notify queues an event about a pending transfer for the webhook, if one is configured. It never blocks, so it can be called with
+// the lock held.
+func (gov *ChainGovernor) notify(eventType string, pe *pendingEntry, reason string, actor string, now time.Time) {
+	if gov.notifier == nil {
+		return
+	}
+	value, _ := computeValue(pe.amount, pe.token)
+	msg := &pe.dbData.Msg
+	e := &Event{
+		Type:             eventType,
+		Timestamp:        now,
+		MsgID:            msg.MessageIDString(),
+		TxHash:           msg.TxHash.String(),
+		EmitterChain:     msg.EmitterChain,
+		EmitterChainName: msg.EmitterChain.String(),
+		Value:            value,
+		ReleaseTime:      pe.dbData.ReleaseTime,
+		Reason:           reason,
+		Actor:            actor,
+		Shadow:           pe.shadow,
+	}
+	select {
+	case gov.notifier.events <- e:
+	default:
+		metricWebhookFailures.WithLabelValues("queue_full").Inc()
+		gov.logger.Error("dropping governor event because the webhook queue is full", zap.String("type", eventType), zap.String("msgID", e.MsgID))
+	}
+}
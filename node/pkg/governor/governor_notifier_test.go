@@ -0,0 +1,75 @@
+package governor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+	var received []Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var e Event
+		if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&e)) {
+			return
+		}
+		received = append(received, e)
+		if e.Type == EventDropped {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	keys, signers := testConfigSigners(t, 1)
+	path := filepath.Join(t.TempDir(), "governor.json")
+	writeTestConfigFile(t, path, testSignedConfig(t, testConfig(100), keys))
+	gov := NewChainGovernor(zap.NewNop(), &db.MockGovernorDB{}, common.GoTest)
+	gov.UseConfigFile(path, signers)
+	gov.UseWebhook(server.URL)
+	require.NoError(t, gov.initConfig())
+
+	// Three SOL are worth 104, which is a big transaction. Two SOL are worth 69, so the second one exceeds the daily limit.
+	now := time.Now()
+	for seq, sol := range []int64{3, 2, 2} {
+		_, err := gov.ProcessMsgForTime(testSolTransfer(t, gov, uint64(seq+1), vaa.ChainIDSolana, 2, sol, now), now)
+		require.NoError(t, err)
+	}
+	_, err := gov.ReleasePendingVAA(testSolTransfer(t, gov, 3, vaa.ChainIDSolana, 2, 2, now).MessageIDString(), "alice")
+	require.NoError(t, err)
+	_, err = gov.DropPendingVAA(testSolTransfer(t, gov, 1, vaa.ChainIDSolana, 2, 3, now).MessageIDString())
+	require.NoError(t, err)
+
+	require.Len(t, gov.notifier.events, 4)
+	for len(gov.notifier.events) != 0 {
+		e := <-gov.notifier.events
+		err := gov.notifier.post(context.Background(), e)
+		if e.Type == EventDropped {
+			assert.ErrorContains(t, err, "500")
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+
+	require.Len(t, received, 4)
+	assert.Equal(t, EventEnqueued, received[0].Type)
+	assert.Equal(t, "big_transaction", received[0].Reason)
+	assert.Equal(t, uint64(104), received[0].Value)
+	assert.Equal(t, vaa.ChainIDSolana, received[0].EmitterChain)
+	assert.Equal(t, "daily_limit", received[1].Reason)
+	assert.Equal(t, EventReleased, received[2].Type)
+	assert.Equal(t, "alice", received[2].Actor)
+	assert.Equal(t, EventDropped, received[3].Type)
+	assert.Equal(t, "admin", received[3].Reason)
+}
@@ -0,0 +1,260 @@
+// This file contains the code to read token prices from on chain oracle accounts for the chain governor.
+//
+// The oracle accounts of each CoinGecko ID are listed in the signed config file (see governor_config_file.go). They are Pyth price
+// update accounts on Solana, which are read through the guardian's own Solana watcher using cross chain queries, so the prices do not
+// depend on a third party API. Prices which were published more than oraclePriceMaxAge ago are ignored.
+//
+// The market price of a token is the median of its CoinGecko price, unless CoinGecko is disabled, and the fresh prices of its oracle
+// accounts. As with CoinGecko alone, the governor uses the maximum of that and the configured price. The accounts are read every
+// oracleQueryInterval.
+
+package governor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/query"
+	"github.com/certusone/wormhole/node/pkg/supervisor"
+	"github.com/gagliardetto/solana-go"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+const (
+	// oracleQueryInterval specifies how often the oracle accounts are read.
+	oracleQueryInterval = time.Minute
+
+	// oraclePriceMaxAge is how old the price in an oracle account may be for it to be used.
+	oraclePriceMaxAge = 5 * time.Minute
+
+	// oracleQueryTimeout bounds a single query, the watcher gives up on retries well before it.
+	oracleQueryTimeout = 30 * time.Second
+)
+
+// pythReceiverProgram is the owner of the Pyth price update accounts on Solana.
+var pythReceiverProgram = solana.MustPublicKeyFromBase58("rec5EKMGg6MxZYaMdyBfgwp4d5rB9T1VQH5pJv5LtFJ")
+
+// pythPriceUpdateDiscriminator is the Anchor discriminator of PriceUpdateV2 accounts.
+var pythPriceUpdateDiscriminator = func() []byte {
+	h := sha256.Sum256([]byte("account:PriceUpdateV2"))
+	return h[:8]
+}()
+
+var metricOraclePrices = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "guardian_governor_oracle_prices_total",
+		Help: "Total number of prices read from oracle accounts by the chain governor, by whether they were used",
+	}, []string{"result"})
+
+var errStaleOraclePrice = errors.New("price is stale")
+
+// PriceOracleQuerier runs queries against the guardian's own watchers. It is implemented by query.LocalQuerier.
+type PriceOracleQuerier interface {
+	Query(ctx context.Context, chainID vaa.ChainID, q query.ChainSpecificQuery) (query.ChainSpecificResponse, error)
+}
+
+// UsePriceOracle makes the governor read prices from the oracle accounts in its config file through querier. If coinGecko is false,
+// CoinGecko is no longer queried and the market price of a token only comes from its oracle accounts. It must be called before Run.
+func (gov *ChainGovernor) UsePriceOracle(querier PriceOracleQuerier, coinGecko bool) {
+	gov.priceOracle = querier
+	gov.coinGeckoDisabled = !coinGecko
+}
+
+// startPriceOracle starts reading the oracle accounts, if a price oracle is configured.
+func (gov *ChainGovernor) startPriceOracle(ctx context.Context) error {
+	if gov.priceOracle == nil {
+		return nil
+	}
+	return supervisor.Run(ctx, "govoracle", gov.OracleQuery)
+}
+
+// OracleQuery is the entry point for the routine that periodically reads the oracle accounts.
+func (gov *ChainGovernor) OracleQuery(ctx context.Context) error {
+	supervisor.Signal(ctx, supervisor.SignalHealthy)
+
+	// Errors are logged and leave the affected tokens without oracle prices until the next interval.
+	_ = gov.queryOracles(ctx)
+
+	ticker := time.NewTicker(oracleQueryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			_ = gov.queryOracles(ctx)
+		}
+	}
+}
+
+// queryOracles reads all oracle accounts and replaces the oracle prices of every token with the fresh ones. Accounts which could not
+// be read, or whose price is stale or invalid, do not contribute to the price of their token.
+func (gov *ChainGovernor) queryOracles(ctx context.Context) error {
+	// The map is replaced rather than modified when the config is reloaded.
+	gov.mutex.Lock()
+	oracles := gov.priceOracles
+	gov.mutex.Unlock()
+
+	var accounts [][query.SolanaPublicKeyLength]byte
+	seen := make(map[solana.PublicKey]bool)
+	for _, accts := range oracles {
+		for _, acct := range accts {
+			if !seen[acct] {
+				seen[acct] = true
+				accounts = append(accounts, acct)
+			}
+		}
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		return solana.PublicKey(accounts[i]).String() < solana.PublicKey(accounts[j]).String()
+	})
+
+	var queryErr error
+	prices := make(map[solana.PublicKey]*big.Float)
+	for start := 0; start < len(accounts); start += query.SolanaMaxAccountsPerQuery {
+		end := start + query.SolanaMaxAccountsPerQuery
+		if end > len(accounts) {
+			end = len(accounts)
+		}
+		chunk := accounts[start:end]
+		results, err := gov.readOracleAccounts(ctx, chunk)
+		if err != nil {
+			gov.logger.Error("failed to read price oracle accounts", zap.Int("numAccounts", len(chunk)), zap.Error(err))
+			metricOraclePrices.WithLabelValues("failed").Add(float64(len(chunk)))
+			queryErr = err
+			continue
+		}
+
+		now := time.Now()
+		for i, res := range results {
+			acct := solana.PublicKey(chunk[i])
+			price, err := parseOraclePrice(res, now)
+			if errors.Is(err, errStaleOraclePrice) {
+				gov.logger.Warn("ignoring stale oracle price", zap.Stringer("account", acct), zap.Error(err))
+				metricOraclePrices.WithLabelValues("stale").Inc()
+				continue
+			}
+			if err != nil {
+				gov.logger.Error("ignoring invalid oracle account", zap.Stringer("account", acct), zap.Error(err))
+				metricOraclePrices.WithLabelValues("invalid").Inc()
+				continue
+			}
+			prices[acct] = price
+			metricOraclePrices.WithLabelValues("fresh").Inc()
+		}
+	}
+
+	gov.mutex.Lock()
+	defer gov.mutex.Unlock()
+	for coinGeckoId, cge := range gov.tokensByCoinGeckoId {
+		var fresh []*big.Float
+		for _, acct := range oracles[coinGeckoId] {
+			if price, exists := prices[acct]; exists {
+				fresh = append(fresh, price)
+			}
+		}
+		for _, te := range cge {
+			te.oraclePrices = fresh
+			te.updatePrice()
+		}
+	}
+
+	return queryErr
+}
+
+// readOracleAccounts reads a chunk of oracle accounts in a single query and returns their results in the same order.
+func (gov *ChainGovernor) readOracleAccounts(ctx context.Context, accounts [][query.SolanaPublicKeyLength]byte) ([]query.SolanaAccountResult, error) {
+	qCtx, cancel := context.WithTimeout(ctx, oracleQueryTimeout)
+	defer cancel()
+
+	resp, err := gov.priceOracle.Query(qCtx, vaa.ChainIDSolana, &query.SolanaAccountQueryRequest{Commitment: "finalized", Accounts: accounts})
+	if err != nil {
+		return nil, err
+	}
+	r, ok := resp.(*query.SolanaAccountQueryResponse)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response type %T", resp)
+	}
+	if len(r.Results) != len(accounts) {
+		return nil, fmt.Errorf("received %d results for %d accounts", len(r.Results), len(accounts))
+	}
+	return r.Results, nil
+}
+
+// parseOraclePrice returns the price in a Pyth PriceUpdateV2 account, if it is fully verified and was published no more than
+// oraclePriceMaxAge before now. The account data is laid out as follows, in little endian:
+//
+//	discriminator [8], write authority [32], verification level (1 for full, or 0 followed by the number of signatures),
+//	feed id [32], price i64, confidence u64, exponent i32, publish time i64, ...
+func parseOraclePrice(res query.SolanaAccountResult, now time.Time) (*big.Float, error) {
+	if solana.PublicKey(res.Owner) != pythReceiverProgram {
+		return nil, fmt.Errorf("account is owned by %s, not the Pyth receiver", solana.PublicKey(res.Owner))
+	}
+	data := res.Data
+	if len(data) < 41 || string(data[:8]) != string(pythPriceUpdateDiscriminator) {
+		return nil, errors.New("account is not a price update")
+	}
+	if data[40] != 1 {
+		return nil, errors.New("price update is not fully verified")
+	}
+	data = data[41:]
+	if len(data) < 64 {
+		return nil, errors.New("price update is truncated")
+	}
+
+	// The price, exponent and publish time are signed integers.
+	price := int64(binary.LittleEndian.Uint64(data[32:40]))
+	exponent := int32(binary.LittleEndian.Uint32(data[48:52]))
+	publishTime := time.Unix(int64(binary.LittleEndian.Uint64(data[52:60])), 0)
+
+	if age := now.Sub(publishTime); age > oraclePriceMaxAge {
+		return nil, fmt.Errorf("%w: published %s ago", errStaleOraclePrice, age.Truncate(time.Second))
+	}
+	if price <= 0 {
+		return nil, fmt.Errorf("price %d is not positive", price)
+	}
+	if exponent < -18 || exponent > 18 {
+		return nil, fmt.Errorf("exponent %d is out of range", exponent)
+	}
+
+	scale := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(exponent))), nil))
+	value := new(big.Float).SetInt64(price)
+	if exponent < 0 {
+		return value.Quo(value, scale), nil
+	}
+	return value.Mul(value, scale), nil
+}
+
+func abs(x int32) int32 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// medianPrice returns the median of prices, or nil if there are none. The slice is not modified.
+func medianPrice(prices []*big.Float) *big.Float {
+	if len(prices) == 0 {
+		return nil
+	}
+	sorted := make([]*big.Float, len(prices))
+	copy(sorted, prices)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Cmp(sorted[j]) < 0 })
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+	median := new(big.Float).Add(sorted[mid-1], sorted[mid])
+	return median.Quo(median, big.NewFloat(2))
+}
@@ -0,0 +1,122 @@
+package governor
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/query"
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// testPriceUpdate returns the result of reading a fully verified Pyth price update account.
+func testPriceUpdate(price int64, exponent int32, publishTime time.Time) query.SolanaAccountResult {
+	data := make([]byte, 41, 133)
+	copy(data, pythPriceUpdateDiscriminator)
+	data[40] = 1
+	data = append(data, make([]byte, 32)...) // feed id
+	data = binary.LittleEndian.AppendUint64(data, uint64(price))
+	data = binary.LittleEndian.AppendUint64(data, 0) // confidence
+	data = binary.LittleEndian.AppendUint32(data, uint32(exponent))
+	data = binary.LittleEndian.AppendUint64(data, uint64(publishTime.Unix()))
+	data = append(data, make([]byte, 32)...) // previous publish time, ema price and confidence, posted slot
+	return query.SolanaAccountResult{Owner: pythReceiverProgram, Data: data}
+}
+
+func TestParseOraclePrice(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	price, err := parseOraclePrice(testPriceUpdate(3494000000, -8, now.Add(-time.Minute)), now)
+	require.NoError(t, err)
+	assert.Equal(t, "34.94", price.Text('f', 2))
+
+	_, err = parseOraclePrice(testPriceUpdate(3494000000, -8, now.Add(-oraclePriceMaxAge-time.Second)), now)
+	assert.ErrorIs(t, err, errStaleOraclePrice)
+
+	_, err = parseOraclePrice(testPriceUpdate(-1, -8, now), now)
+	assert.ErrorContains(t, err, "is not positive")
+
+	partial := testPriceUpdate(3494000000, -8, now)
+	partial.Data[40] = 0
+	_, err = parseOraclePrice(partial, now)
+	assert.ErrorContains(t, err, "not fully verified")
+
+	spoofed := testPriceUpdate(3494000000, -8, now)
+	spoofed.Owner = solana.SystemProgramID
+	_, err = parseOraclePrice(spoofed, now)
+	assert.ErrorContains(t, err, "not the Pyth receiver")
+}
+
+func TestMedianPrice(t *testing.T) {
+	assert.Nil(t, medianPrice(nil))
+	prices := []*big.Float{big.NewFloat(3), big.NewFloat(1), big.NewFloat(2)}
+	assert.Equal(t, "2", medianPrice(prices).String())
+	assert.Equal(t, "3", prices[0].String())
+	assert.Equal(t, "2.5", medianPrice(append(prices, big.NewFloat(10))).String())
+}
+
+type fakePriceOracle struct {
+	results map[solana.PublicKey]query.SolanaAccountResult
+	err     error
+}
+
+func (o *fakePriceOracle) Query(_ context.Context, chainID vaa.ChainID, q query.ChainSpecificQuery) (query.ChainSpecificResponse, error) {
+	if o.err != nil {
+		return nil, o.err
+	}
+	resp := &query.SolanaAccountQueryResponse{}
+	for _, acct := range q.(*query.SolanaAccountQueryRequest).Accounts {
+		resp.Results = append(resp.Results, o.results[acct])
+	}
+	return resp, nil
+}
+
+func TestQueryOracles(t *testing.T) {
+	keys, signers := testConfigSigners(t, 1)
+	accounts := []solana.PublicKey{solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()}
+	cfg := testConfig(100)
+	cfg.PriceOracles = []PriceOracleConfig{{CoinGeckoId: "wrapped-solana", Accounts: []string{accounts[0].String(), accounts[1].String(), accounts[2].String()}}}
+	path := filepath.Join(t.TempDir(), "governor.json")
+	writeTestConfigFile(t, path, testSignedConfig(t, cfg, keys))
+
+	now := time.Now()
+	oracle := &fakePriceOracle{results: map[solana.PublicKey]query.SolanaAccountResult{
+		accounts[0]: testPriceUpdate(40, 0, now),
+		accounts[1]: testPriceUpdate(60, 0, now),
+		accounts[2]: testPriceUpdate(1000, 0, now.Add(-time.Hour)),
+	}}
+	gov := NewChainGovernor(zap.NewNop(), nil, common.GoTest)
+	gov.UseConfigFile(path, signers)
+	gov.UsePriceOracle(oracle, true)
+	require.NoError(t, gov.initConfig())
+	addr, err := vaa.StringToAddress(testConfigSolAddr)
+	require.NoError(t, err)
+	te := gov.tokens[tokenKey{chain: vaa.ChainIDSolana, addr: addr}]
+
+	// The stale price is ignored and the median of the other two is used, as it is above the configured price.
+	require.NoError(t, gov.queryOracles(context.Background()))
+	assert.Len(t, te.oraclePrices, 2)
+	assert.Equal(t, "50", te.price.String())
+
+	// The CoinGecko price is one more source.
+	te.coinGeckoPrice = big.NewFloat(45)
+	te.updatePrice()
+	assert.Equal(t, "45", te.price.String())
+
+	// Without any fresh source, the configured price is used again.
+	oracle.err = errors.New("watcher is down")
+	assert.Error(t, gov.queryOracles(context.Background()))
+	gov.revertAllPrices()
+	assert.Empty(t, te.oraclePrices)
+	assert.Equal(t, "34.94", te.price.Text('f', 2))
+	assert.Equal(t, "34.94", te.cfgPrice.Text('f', 2))
+}
@@ -3,6 +3,8 @@
 // The initial prices are read from the static config (tokens.go). After that, prices are
 // queried from CoinGecko. The chain governor then uses the maximum of the static price and
 // the latest CoinGecko price. The CoinGecko poll interval is specified by coinGeckoQueryIntervalInMins.
+// If price oracles are configured, the median of the CoinGecko price and the oracle prices is used
+// instead of the CoinGecko price alone (see governor_oracle.go).
 
 package governor
 
@@ -37,19 +39,16 @@ const tokensPerCoinGeckoQuery = 200
 
 // initCoinGecko builds the set of CoinGecko queries that will be used to update prices. It also starts a go routine to periodically do the queries.
 func (gov *ChainGovernor) initCoinGecko(ctx context.Context, run bool) error {
-	// Create a slice of all the CoinGecko IDs so we can create the corresponding queries.
-	ids := make([]string, 0, len(gov.tokensByCoinGeckoId))
-	for id := range gov.tokensByCoinGeckoId {
-		ids = append(ids, id)
-	}
-
 	// Create the set of queries, breaking the IDs into the appropriate size chunks.
-	gov.coinGeckoQueries = createCoinGeckoQueries(ids, tokensPerCoinGeckoQuery)
-	for queryIdx, query := range gov.coinGeckoQueries {
+	gov.mutex.Lock()
+	gov.coinGeckoQueries = createCoinGeckoQueries(coinGeckoIds(gov.tokensByCoinGeckoId), tokensPerCoinGeckoQuery)
+	queries := gov.coinGeckoQueries
+	gov.mutex.Unlock()
+	for queryIdx, query := range queries {
 		gov.logger.Info("coingecko query: ", zap.Int("queryIdx", queryIdx), zap.String("query", query))
 	}
 
-	if len(gov.coinGeckoQueries) == 0 {
+	if len(queries) == 0 {
 		gov.logger.Info("did not find any tokens, nothing to do!")
 		return nil
 	}
@@ -63,6 +62,15 @@ func (gov *ChainGovernor) initCoinGecko(ctx context.Context, run bool) error {
 	return nil
 }
 
+// coinGeckoIds returns a slice of all the CoinGecko IDs so we can create the corresponding queries.
+func coinGeckoIds(tokensByCoinGeckoId map[string][]*tokenEntry) []string {
+	ids := make([]string, 0, len(tokensByCoinGeckoId))
+	for id := range tokensByCoinGeckoId {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
 // createCoinGeckoQueries creates the set of CoinGecko queries, breaking the set of IDs into the appropriate size chunks.
 func createCoinGeckoQueries(idList []string, tokensPerQuery int) []string {
 	var queries []string
@@ -135,7 +143,12 @@ func (gov *ChainGovernor) queryCoinGecko() error {
 	params := url.Values{}
 	params.Add("bust", strconv.Itoa(int(time.Now().Unix()))+strconv.Itoa(rand.Int())) // #nosec G404
 
-	for queryIdx, query := range gov.coinGeckoQueries {
+	// The queries change if the config is reloaded.
+	gov.mutex.Lock()
+	queries := gov.coinGeckoQueries
+	gov.mutex.Unlock()
+
+	for queryIdx, query := range queries {
 		query := query + "&" + params.Encode()
 		thisResult, err := gov.queryCoinGeckoChunk(query)
 		if err != nil {
@@ -203,14 +216,15 @@ func (gov *ChainGovernor) queryCoinGecko() error {
 	if len(localTokenMap) != 0 {
 		for _, lcge := range localTokenMap {
 			for _, te := range lcge {
-				gov.logger.Error("did not receive a CoinGecko response for symbol, reverting to configured price",
+				gov.logger.Error("did not receive a CoinGecko response for symbol, dropping its CoinGecko price",
 					zap.String("symbol", te.symbol),
 					zap.String("coinGeckoId",
 						te.coinGeckoId),
 					zap.Stringer("cfgPrice", te.cfgPrice),
 				)
 
-				te.price = te.cfgPrice
+				te.coinGeckoPrice = nil
+				te.updatePrice()
 				// Don't update the timestamp so we'll know when we last received an update from CoinGecko.
 			}
 		}
@@ -255,32 +269,45 @@ func (gov *ChainGovernor) queryCoinGeckoChunk(query string) (map[string]interfac
 	return result, nil
 }
 
-// revertAllPrices reverts the price of all tokens to the configured prices. It is used when a CoinGecko query fails.
+// revertAllPrices drops the CoinGecko price of all tokens, which reverts them to the configured prices unless they have oracle prices.
+// It is used when a CoinGecko query fails.
 func (gov *ChainGovernor) revertAllPrices() {
 	gov.mutex.Lock()
 	defer gov.mutex.Unlock()
 
 	for _, cge := range gov.tokensByCoinGeckoId {
 		for _, te := range cge {
-			gov.logger.Info("reverting to configured price",
+			gov.logger.Info("dropping CoinGecko price",
 				zap.String("symbol", te.symbol),
 				zap.String("coinGeckoId", te.coinGeckoId),
 				zap.Stringer("cfgPrice", te.cfgPrice),
+				zap.Int("oraclePrices", len(te.oraclePrices)),
 			)
 
-			te.price = te.cfgPrice
+			te.coinGeckoPrice = nil
+			te.updatePrice()
 			// Don't update the timestamp so we'll know when we last received an update from CoinGecko.
 		}
 	}
 }
 
-// updatePrice updates the price of a single token. We should use the max(coinGeckoPrice, configuredPrice) as our price for computing notional value.
+// updatePrice updates the price of a single token. We should use the max(marketPrice, configuredPrice) as our price for computing notional value.
 func (te tokenEntry) updatePrice() {
-	if (te.coinGeckoPrice == nil) || (te.coinGeckoPrice.Cmp(te.cfgPrice) < 0) {
+	marketPrice := te.marketPrice()
+	if (marketPrice == nil) || (marketPrice.Cmp(te.cfgPrice) < 0) {
 		te.price.Set(te.cfgPrice)
 	} else {
-		te.price.Set(te.coinGeckoPrice)
+		te.price.Set(marketPrice)
+	}
+}
+
+// marketPrice returns the median of the CoinGecko price and the oracle prices of a token, or nil if it has neither.
+func (te tokenEntry) marketPrice() *big.Float {
+	prices := te.oraclePrices
+	if te.coinGeckoPrice != nil {
+		prices = append([]*big.Float{te.coinGeckoPrice}, prices...)
 	}
+	return medianPrice(prices)
 }
 
 // CheckQuery is a free function used to test that the CoinGecko query still works after the mainnet token list has been updated.
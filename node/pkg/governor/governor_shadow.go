@@ -0,0 +1,64 @@
+// This file contains the shadow mode of the chain governor.
+//
+// In shadow mode, the governor evaluates every transfer as usual, but never holds one back. A transfer that would have been enqueued
+// is published right away, and is also added to the pending list as a shadow entry, so that the status, REST queries and metrics
+// show what enforcing the limits would look like. Shadow entries leave the pending list when they would have been released, but
+// are not published again. They are only kept in memory, since the transfers were published already.
+//
+// Transfers which were enqueued for real before the guardian was switched to shadow mode are still released as usual.
+
+package governor
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	metricShadowEnqueued = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "guardian_governor_shadow_enqueued_vaas_total",
+			Help: "Total number of VAAs the chain governor would have enqueued in shadow mode, by chain and reason",
+		}, []string{"chain_name", "reason"})
+
+	metricShadowReleased = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "guardian_governor_shadow_released_vaas_total",
+			Help: "Total number of VAAs the chain governor would have released in shadow mode",
+		})
+)
+
+// UseShadowMode makes the governor record what it would have enqueued instead of enqueuing it. It must be called before Run.
+func (gov *ChainGovernor) UseShadowMode() {
+	gov.shadow = true
+}
+
+// IsShadowMode returns true if the governor runs in shadow mode.
+func (gov *ChainGovernor) IsShadowMode() bool {
+	return gov.shadow
+}
+
+// storePendingAlreadyLocked persists a pending transfer, unless it is a shadow entry. It assumes the caller holds the lock.
+func (gov *ChainGovernor) storePendingAlreadyLocked(pe *pendingEntry) error {
+	if pe.shadow {
+		return nil
+	}
+	return gov.db.StorePendingMsg(&pe.dbData)
+}
+
+// deletePendingAlreadyLocked deletes a pending transfer from the database, unless it is a shadow entry. It assumes the caller holds
+// the lock.
+func (gov *ChainGovernor) deletePendingAlreadyLocked(pe *pendingEntry) error {
+	if pe.shadow {
+		return nil
+	}
+	return gov.db.DeletePendingMsg(&pe.dbData)
+}
+
+// shadowEnqueueAlreadyLocked adds a transfer that would have been enqueued to the pending list as a shadow entry. It assumes the
+// caller holds the lock.
+func (gov *ChainGovernor) shadowEnqueueAlreadyLocked(ce *chainEntry, pe *pendingEntry, reason string) {
+	pe.shadow = true
+	ce.pending = append(ce.pending, pe)
+	metricShadowEnqueued.WithLabelValues(ce.emitterChainId.String(), reason).Inc()
+}
@@ -0,0 +1,72 @@
+package governor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+func TestShadowMode(t *testing.T) {
+	database, err := db.OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer database.Close()
+
+	keys, signers := testConfigSigners(t, 1)
+	path := filepath.Join(t.TempDir(), "governor.json")
+	writeTestConfigFile(t, path, testSignedConfig(t, testConfig(100), keys))
+	gov := NewChainGovernor(zap.NewNop(), database, common.GoTest)
+	gov.UseConfigFile(path, signers)
+	require.NoError(t, gov.initConfig())
+
+	// A transfer enqueued before switching to shadow mode is still held back.
+	now := time.Now()
+	enqueued := testSolTransfer(t, gov, 1, vaa.ChainIDSolana, 2, 3, now)
+	publish, err := gov.ProcessMsgForTime(enqueued, now)
+	require.NoError(t, err)
+	assert.False(t, publish)
+
+	gov.UseShadowMode()
+	require.True(t, gov.IsShadowMode())
+
+	// Three SOL are worth 104, which is a big transaction, and the second transfer of two SOL exceeds the daily limit. All of them
+	// are published, but tracked as pending.
+	for seq, sol := range []int64{3, 2, 2} {
+		publish, err := gov.ProcessMsgForTime(testSolTransfer(t, gov, uint64(seq+2), vaa.ChainIDSolana, 2, sol, now), now)
+		require.NoError(t, err)
+		assert.True(t, publish)
+	}
+	ce := gov.chains[vaa.ChainIDSolana]
+	require.Len(t, ce.pending, 3)
+	assert.Equal(t, uint64(69), sumValue(ce.transfers, now.Add(-time.Hour)))
+	assert.Contains(t, gov.Status(), "shadow mode")
+
+	// Duplicates of shadow entries are published again.
+	publish, err = gov.ProcessMsgForTime(testSolTransfer(t, gov, 4, vaa.ChainIDSolana, 2, 2, now), now)
+	require.NoError(t, err)
+	assert.True(t, publish)
+
+	// Only the transfer enqueued for real is in the database.
+	_, pending, err := database.GetChainGovernorData(zap.NewNop())
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, enqueued.MessageIDString(), pending[0].Msg.MessageIDString())
+
+	// Shadow entries are not published again when released, but the transfer enqueued for real is.
+	resp, err := gov.ReleasePendingVAA(testSolTransfer(t, gov, 4, vaa.ChainIDSolana, 2, 2, now).MessageIDString(), "")
+	require.NoError(t, err)
+	assert.Contains(t, resp, "has been released")
+	assert.Empty(t, gov.msgsToPublish)
+
+	released, err := gov.CheckPendingForTime(now.Add(maxEnqueuedTime + time.Minute))
+	require.NoError(t, err)
+	require.Len(t, released, 1)
+	assert.Equal(t, enqueued.MessageIDString(), released[0].MessageIDString())
+	assert.Empty(t, ce.pending)
+}
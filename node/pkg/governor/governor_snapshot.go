@@ -0,0 +1,227 @@
+// This file contains the export and import of governor snapshots, which carry the state of the governor over to another node.
+//
+// A snapshot holds the transfers of the current 24 hour window, the pending transfers and the limits of each chain. The transfers are
+// encoded the same way as in the database, so nothing is lost on the way. The readable fields next to them are only there for the
+// operators looking at the file and are ignored on import.
+//
+// Importing a snapshot adds the transfers to the database and reloads the governor state from it, so the daily usage of the exporting
+// node carries over. Transfers which this node has already seen, which have left the 24 hour window, or whose emitter chain is not
+// governed here are skipped, which makes importing the same snapshot twice harmless. Limits changed at runtime are imported as well.
+// The configured limits come from the config of the importing node, and are only compared.
+//
+// Pending transfers are exported for the operators, but never imported: the governor releases its pending transfers for signing,
+// and a snapshot comes from the admin client, so importing them would let whoever can call the admin service have messages this
+// node never observed signed. A transfer which is pending on the exporting node is governed here once this node observes it.
+//
+// Release approvals and shadow entries are not part of a snapshot.
+
+package governor
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/db"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+const snapshotVersion = 1
+
+// ErrInvalidSnapshot is returned when importing a snapshot that cannot be decoded.
+var ErrInvalidSnapshot = errors.New("invalid governor snapshot")
+
+// Snapshot is the state of the governor as exported by ExportSnapshot.
+type Snapshot struct {
+	Version    int                `json:"version"`
+	ExportedAt time.Time          `json:"exportedAt"`
+	Chains     []SnapshotChain    `json:"chains"`
+	Transfers  []SnapshotTransfer `json:"transfers"`
+	Pending    []SnapshotPending  `json:"pending"`
+}
+
+// SnapshotChain is the limits of a governed chain.
+type SnapshotChain struct {
+	ChainId                      vaa.ChainID `json:"chainId"`
+	DailyLimit                   uint64      `json:"dailyLimit"`
+	BigTransactionSize           uint64      `json:"bigTransactionSize"`
+	ConfiguredDailyLimit         uint64      `json:"configuredDailyLimit"`
+	ConfiguredBigTransactionSize uint64      `json:"configuredBigTransactionSize"`
+	Overridden                   bool        `json:"overridden"`
+}
+
+// SnapshotTransfer is a transfer counted against the daily limit. Data is the hex encoded database record.
+type SnapshotTransfer struct {
+	MsgID     string    `json:"msgId"`
+	Timestamp time.Time `json:"timestamp"`
+	Value     uint64    `json:"value"`
+	Data      string    `json:"data"`
+}
+
+// SnapshotPending is a pending transfer. Data is the hex encoded database record.
+type SnapshotPending struct {
+	MsgID       string    `json:"msgId"`
+	ReleaseTime time.Time `json:"releaseTime"`
+	Value       uint64    `json:"value"`
+	Data        string    `json:"data"`
+}
+
+// ExportSnapshot returns the current state of the governor as JSON.
+func (gov *ChainGovernor) ExportSnapshot() ([]byte, error) {
+	return gov.exportSnapshotForTime(time.Now())
+}
+
+func (gov *ChainGovernor) exportSnapshotForTime(now time.Time) ([]byte, error) {
+	gov.mutex.Lock()
+	defer gov.mutex.Unlock()
+
+	s := Snapshot{Version: snapshotVersion, ExportedAt: now, Chains: []SnapshotChain{}, Transfers: []SnapshotTransfer{}, Pending: []SnapshotPending{}}
+	startTime := now.Add(-time.Minute * time.Duration(gov.dayLengthInMinutes))
+	for _, ce := range gov.chains {
+		_, overridden := gov.limitOverrides[ce.emitterChainId]
+		s.Chains = append(s.Chains, SnapshotChain{
+			ChainId:                      ce.emitterChainId,
+			DailyLimit:                   ce.dailyLimit,
+			BigTransactionSize:           ce.bigTransactionSize,
+			ConfiguredDailyLimit:         ce.cfgDailyLimit,
+			ConfiguredBigTransactionSize: ce.cfgBigTransactionSize,
+			Overridden:                   overridden,
+		})
+
+		for _, xfer := range ce.transfers {
+			if xfer.Timestamp.Before(startTime) {
+				continue
+			}
+			b, err := xfer.Marshal()
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal transfer %s: %w", xfer.MsgID, err)
+			}
+			s.Transfers = append(s.Transfers, SnapshotTransfer{MsgID: xfer.MsgID, Timestamp: xfer.Timestamp, Value: xfer.Value, Data: hex.EncodeToString(b)})
+		}
+
+		for _, pe := range ce.pending {
+			if pe.shadow {
+				continue
+			}
+			b, err := pe.dbData.Marshal()
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal pending transfer %s: %w", pe.dbData.Msg.MessageIDString(), err)
+			}
+			value, _ := computeValue(pe.amount, pe.token)
+			s.Pending = append(s.Pending, SnapshotPending{
+				MsgID:       pe.dbData.Msg.MessageIDString(),
+				ReleaseTime: pe.dbData.ReleaseTime,
+				Value:       value,
+				Data:        hex.EncodeToString(b),
+			})
+		}
+	}
+
+	sort.Slice(s.Chains, func(i, j int) bool { return s.Chains[i].ChainId < s.Chains[j].ChainId })
+	sort.SliceStable(s.Transfers, func(i, j int) bool { return s.Transfers[i].Timestamp.Before(s.Transfers[j].Timestamp) })
+	sort.SliceStable(s.Pending, func(i, j int) bool { return s.Pending[i].ReleaseTime.Before(s.Pending[j].ReleaseTime) })
+
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// ImportSnapshot adds the state exported by another node with ExportSnapshot to this one, and returns a summary of what was imported.
+func (gov *ChainGovernor) ImportSnapshot(data []byte) (string, error) {
+	return gov.importSnapshotForTime(data, time.Now())
+}
+
+func (gov *ChainGovernor) importSnapshotForTime(data []byte, now time.Time) (string, error) {
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidSnapshot, err)
+	}
+	if s.Version != snapshotVersion {
+		return "", fmt.Errorf("%w: unsupported version %d", ErrInvalidSnapshot, s.Version)
+	}
+
+	// Decode everything before touching the database, so a bad snapshot is not imported halfway.
+	xfers := make([]*db.Transfer, 0, len(s.Transfers))
+	for _, t := range s.Transfers {
+		b, err := hex.DecodeString(t.Data)
+		if err != nil {
+			return "", fmt.Errorf("%w: transfer %s: %v", ErrInvalidSnapshot, t.MsgID, err)
+		}
+		xfer, err := db.UnmarshalTransfer(b)
+		if err != nil {
+			return "", fmt.Errorf("%w: transfer %s: %v", ErrInvalidSnapshot, t.MsgID, err)
+		}
+		xfers = append(xfers, xfer)
+	}
+
+	gov.mutex.Lock()
+	defer gov.mutex.Unlock()
+
+	startTime := now.Add(-time.Minute * time.Duration(gov.dayLengthInMinutes))
+	numXfers, numLimits, numSkipped := 0, 0, 0
+	for _, xfer := range xfers {
+		if _, exists := gov.chains[xfer.EmitterChain]; !exists || xfer.Timestamp.Before(startTime) {
+			numSkipped++
+			continue
+		}
+		if _, alreadySeen := gov.msgsSeen[xfer.Hash]; alreadySeen {
+			numSkipped++
+			continue
+		}
+		if err := gov.db.StoreTransfer(xfer); err != nil {
+			return "", fmt.Errorf("failed to store transfer %s: %w", xfer.MsgID, err)
+		}
+		numXfers++
+	}
+
+	for _, p := range s.Pending {
+		gov.logger.Info("not importing pending transfer from a snapshot, it is governed once this node observes it", zap.String("msgID", p.MsgID))
+	}
+
+	for _, c := range s.Chains {
+		ce, exists := gov.chains[c.ChainId]
+		if !exists {
+			gov.logger.Warn("ignoring the limits of a chain which is not governed from a snapshot", zap.Stringer("emitterChainId", c.ChainId))
+			continue
+		}
+		if c.ConfiguredDailyLimit != ce.cfgDailyLimit || c.ConfiguredBigTransactionSize != ce.cfgBigTransactionSize {
+			gov.logger.Warn("the configured limits of a chain differ from the ones in the snapshot",
+				zap.Stringer("emitterChainId", c.ChainId),
+				zap.Uint64("cfgDailyLimit", ce.cfgDailyLimit),
+				zap.Uint64("snapshotCfgDailyLimit", c.ConfiguredDailyLimit),
+				zap.Uint64("cfgBigTransactionSize", ce.cfgBigTransactionSize),
+				zap.Uint64("snapshotCfgBigTransactionSize", c.ConfiguredBigTransactionSize),
+			)
+		}
+		if !c.Overridden {
+			continue
+		}
+		override := db.ChainLimit{EmitterChain: c.ChainId, DailyLimit: c.DailyLimit, BigTransactionSize: c.BigTransactionSize}
+		if err := gov.db.StoreChainLimit(&override); err != nil {
+			return "", fmt.Errorf("failed to store limits of chain %v: %w", c.ChainId, err)
+		}
+		numLimits++
+	}
+
+	// Everything that was seen is in the database, and would be taken for a duplicate when reloading it if it was left in msgsSeen.
+	gov.msgsSeen = make(map[string]bool)
+	for _, ce := range gov.chains {
+		ce.transfers = nil
+		ce.pending = nil
+	}
+	if err := gov.loadFromDBAlreadyLocked(); err != nil {
+		gov.logger.Error("failed to load from the database after importing a snapshot", zap.Error(err))
+		return "", err
+	}
+
+	gov.logger.Info("imported governor snapshot",
+		zap.Stringer("exportedAt", s.ExportedAt),
+		zap.Int("transfers", numXfers),
+		zap.Int("ignoredPending", len(s.Pending)),
+		zap.Int("limits", numLimits),
+		zap.Int("skipped", numSkipped),
+	)
+	return fmt.Sprintf("imported %d transfers and %d changed chain limits, skipped %d transfers, ignored %d pending vaas", numXfers, numLimits, numSkipped, len(s.Pending)), nil
+}
@@ -0,0 +1,84 @@
+package governor
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+func TestSnapshotExportImport(t *testing.T) {
+	keys, signers := testConfigSigners(t, 1)
+	path := filepath.Join(t.TempDir(), "governor.json")
+	writeTestConfigFile(t, path, testSignedConfig(t, testConfig(100), keys))
+	newGov := func() (*ChainGovernor, *db.Database) {
+		database, err := db.OpenInMemory(zap.NewNop())
+		require.NoError(t, err)
+		t.Cleanup(func() { database.Close() })
+		gov := NewChainGovernor(zap.NewNop(), database, common.GoTest)
+		gov.UseConfigFile(path, signers)
+		require.NoError(t, gov.initConfig())
+		require.NoError(t, gov.loadFromDB())
+		return gov, database
+	}
+
+	// Two SOL are worth 69, so the second transfer exceeds the daily limit and is enqueued.
+	now := time.Now()
+	src, _ := newGov()
+	for seq := uint64(1); seq <= 2; seq++ {
+		_, err := src.ProcessMsgForTime(testSolTransfer(t, src, seq, vaa.ChainIDSolana, 2, 2, now), now)
+		require.NoError(t, err)
+	}
+	require.NoError(t, src.SetChainLimits([]ChainLimit{{ChainId: vaa.ChainIDSolana, DailyLimit: 120, BigTransactionSize: 75}}))
+
+	snapshot, err := src.exportSnapshotForTime(now)
+	require.NoError(t, err)
+	var s Snapshot
+	require.NoError(t, json.Unmarshal(snapshot, &s))
+	require.Len(t, s.Transfers, 1)
+	assert.Equal(t, uint64(69), s.Transfers[0].Value)
+	require.Len(t, s.Pending, 1)
+	require.Len(t, s.Chains, 1)
+	assert.True(t, s.Chains[0].Overridden)
+
+	dst, database := newGov()
+	resp, err := dst.importSnapshotForTime(snapshot, now)
+	require.NoError(t, err)
+	assert.Equal(t, "imported 1 transfers and 1 changed chain limits, skipped 0 transfers, ignored 1 pending vaas", resp)
+
+	// Pending transfers are not imported, they could be released for signing without this node having observed them.
+	ce := dst.chains[vaa.ChainIDSolana]
+	assert.Equal(t, uint64(69), sumValue(ce.transfers, now.Add(-time.Hour)))
+	assert.Empty(t, ce.pending)
+	assert.Equal(t, uint64(120), ce.dailyLimit)
+
+	// The imported state is in the database, and importing it again changes nothing.
+	xfers, pending, err := database.GetChainGovernorData(zap.NewNop())
+	require.NoError(t, err)
+	assert.Len(t, xfers, 1)
+	assert.Empty(t, pending)
+	resp, err = dst.importSnapshotForTime(snapshot, now)
+	require.NoError(t, err)
+	assert.Contains(t, resp, "imported 0 transfers")
+	assert.Empty(t, ce.pending)
+
+	// Snapshots that cannot be decoded are rejected before anything is imported.
+	s.Version = 2
+	b, err := json.Marshal(s)
+	require.NoError(t, err)
+	_, err = dst.ImportSnapshot(b)
+	assert.ErrorIs(t, err, ErrInvalidSnapshot)
+	s.Version = snapshotVersion
+	s.Transfers[0].Data = "zz"
+	b, err = json.Marshal(s)
+	require.NoError(t, err)
+	_, err = dst.ImportSnapshot(b)
+	assert.ErrorIs(t, err, ErrInvalidSnapshot)
+}
@@ -0,0 +1,466 @@
+// The gateway relayer completes the token bridge transfers sent to the gateway (Wormchain), by submitting their signed VAAs to the
+// IBC translator contract on it. The contract then forwards the tokens to their destination over IBC.
+//
+// Every guardian running the relayer submits the VAAs it stores. Only the first submission of a VAA is executed, the others fail
+// with ErrAlreadyRelayed, which the relayer counts as done. Failed submissions are retried with a growing delay and fee, as
+// configured by the RelayPolicy, until the relayer gives up. VAAs the relayer gave up on are kept as failed, until an operator retries
+// or drops them through the admin service, or the node restarts.
+
+package gwrelayer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/wormhole-foundation/wormhole/sdk"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+const (
+	// gatewayChainID is the Wormhole chain ID of the gateway.
+	gatewayChainID = vaa.ChainID(3104)
+
+	// gatewayAddressPrefix is the bech32 prefix of addresses on the gateway.
+	gatewayAddressPrefix = "wormhole"
+
+	// transferWithPayloadType is the payload type of the token bridge transfers carrying a payload for the recipient contract.
+	transferWithPayloadType = 3
+
+	subChanSize = 1000
+
+	// retryCheckInterval is how often the relayer checks for submissions which are due to be retried.
+	retryCheckInterval = time.Second
+)
+
+var (
+	metricSubmissions = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "guardian_gateway_relayer_submissions_total",
+			Help: "Total number of VAA submissions to the gateway, by outcome (success, already_relayed or failed)",
+		}, []string{"outcome"})
+
+	metricRelaysGivenUp = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "guardian_gateway_relayer_relays_given_up_total",
+			Help: "Total number of VAAs the gateway relayer gave up on after exhausting its attempts",
+		})
+
+	metricRelaysDropped = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "guardian_gateway_relayer_relays_dropped_total",
+			Help: "Total number of VAAs which were not relayed because the gateway relayer queue was full",
+		})
+
+	metricFeesPaid = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "guardian_gateway_relayer_fees_paid_total",
+			Help: "Total fees offered for successful submissions to the gateway, in the fee denomination of the gateway",
+		})
+
+	metricRelayLatency = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "guardian_gateway_relayer_relay_latency_seconds",
+			Help:    "Time from queueing a VAA until it was executed on the gateway",
+			Buckets: []float64{1, 5, 15, 30, 60, 300, 900, 3600},
+		})
+
+	metricPendingRelays = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "guardian_gateway_relayer_pending_relays",
+			Help: "Current number of VAAs waiting to be relayed to the gateway, including the failed ones",
+		})
+
+	metricFailedRelays = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "guardian_gateway_relayer_failed_relays",
+			Help: "Current number of VAAs the gateway relayer gave up on, which were not retried or dropped yet",
+		})
+
+	metricPaused = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "guardian_gateway_relayer_paused",
+			Help: "Whether submissions to the gateway are paused (1) or not (0)",
+		})
+)
+
+// ErrRelayNotPending is returned when an operation refers to a VAA which is not pending in the relayer.
+var ErrRelayNotPending = errors.New("VAA is not pending in the gateway relayer")
+
+// The statuses of a pending relay.
+const (
+	// StatusQueued is the status of a VAA which was not submitted yet.
+	StatusQueued = "queued"
+	// StatusRetrying is the status of a VAA whose submissions failed, which will be submitted again.
+	StatusRetrying = "retrying"
+	// StatusFailed is the status of a VAA the relayer gave up on.
+	StatusFailed = "failed"
+)
+
+type pendingRelay struct {
+	v        *vaa.VAA
+	vaaBytes []byte
+	// queueTime is when the VAA was queued for relaying.
+	queueTime time.Time
+	// attempts is the number of failed submissions.
+	attempts uint
+	// nextAttempt is when the VAA is due to be submitted.
+	nextAttempt time.Time
+	lastError   string
+	// failed is set once the relayer gave up on the VAA.
+	failed bool
+}
+
+func (pr *pendingRelay) status() string {
+	if pr.failed {
+		return StatusFailed
+	}
+	if pr.attempts != 0 {
+		return StatusRetrying
+	}
+	return StatusQueued
+}
+
+// PendingRelay is a VAA waiting to be relayed to the gateway.
+type PendingRelay struct {
+	MsgID string
+	// Status is StatusQueued, StatusRetrying or StatusFailed.
+	Status string
+	// QueueTime is when the VAA was queued for relaying.
+	QueueTime time.Time
+	// Attempts is the number of failed submissions.
+	Attempts uint
+	// NextAttempt is when the VAA is due to be submitted, zero if it failed.
+	NextAttempt time.Time
+	// LastError is the error of the last failed submission, if any.
+	LastError string
+}
+
+// GatewayRelayer submits the signed VAAs of token bridge transfers destined for the gateway to its IBC translator contract.
+type GatewayRelayer struct {
+	logger       *zap.Logger
+	submitter    Submitter
+	contract     string
+	targetAddr   vaa.Address
+	tokenBridges map[vaa.ChainID]vaa.Address
+	policy       RelayPolicy
+	subChan      chan *vaa.VAA
+
+	pendingLock sync.Mutex
+	pending     map[string]*pendingRelay
+	// paused stops the submissions to the gateway, VAAs are still queued.
+	paused bool
+}
+
+// NewGatewayRelayer returns a relayer which submits the transfers to contract, the bech32 address of the IBC translator contract on
+// the gateway, through submitter.
+func NewGatewayRelayer(logger *zap.Logger, submitter Submitter, contract string, env common.Environment, policy RelayPolicy) (*GatewayRelayer, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid relay policy: %w", err)
+	}
+	targetAddr, err := convertBech32AddressToWormhole(contract)
+	if err != nil {
+		return nil, err
+	}
+
+	emitters := sdk.KnownTokenbridgeEmitters
+	if env == common.TestNet {
+		emitters = sdk.KnownTestnetTokenbridgeEmitters
+	} else if env == common.UnsafeDevNet || env == common.GoTest {
+		emitters = sdk.KnownDevnetTokenbridgeEmitters
+	}
+	tokenBridges := make(map[vaa.ChainID]vaa.Address, len(emitters))
+	for chainId, addr := range emitters {
+		tokenBridges[chainId] = vaa.Address(addr)
+	}
+
+	return &GatewayRelayer{
+		logger:       logger.With(zap.String("component", "gwrelayer")),
+		submitter:    submitter,
+		contract:     contract,
+		targetAddr:   targetAddr,
+		tokenBridges: tokenBridges,
+		policy:       policy,
+		subChan:      make(chan *vaa.VAA, subChanSize),
+		pending:      make(map[string]*pendingRelay),
+	}, nil
+}
+
+// convertBech32AddressToWormhole returns the 32 byte address of a contract on the gateway, as used in token bridge payloads.
+func convertBech32AddressToWormhole(contract string) (vaa.Address, error) {
+	addr, err := sdktypes.GetFromBech32(contract, gatewayAddressPrefix)
+	if err != nil {
+		return vaa.Address{}, fmt.Errorf(`invalid gateway contract address "%s": %w`, contract, err)
+	}
+	if len(addr) != 32 {
+		return vaa.Address{}, fmt.Errorf(`invalid gateway contract address "%s": must be 32 bytes long, not %d`, contract, len(addr))
+	}
+	var ret vaa.Address
+	copy(ret[:], addr)
+	return ret, nil
+}
+
+// shouldRelay returns true if a VAA is a token bridge transfer to the IBC translator contract.
+func (gr *GatewayRelayer) shouldRelay(v *vaa.VAA) bool {
+	emitter, exists := gr.tokenBridges[v.EmitterChain]
+	if !exists || emitter != v.EmitterAddress || len(v.Payload) == 0 || v.Payload[0] != transferWithPayloadType {
+		return false
+	}
+	hdr, err := vaa.DecodeTransferPayloadHdr(v.Payload)
+	if err != nil {
+		return false
+	}
+	return hdr.TargetChain == gatewayChainID && hdr.TargetAddress == gr.targetAddr
+}
+
+// SubmitVAA queues a signed VAA for relaying, if it is a transfer to the IBC translator contract. It does not block.
+func (gr *GatewayRelayer) SubmitVAA(v *vaa.VAA) {
+	if !gr.shouldRelay(v) {
+		return
+	}
+	select {
+	case gr.subChan <- v:
+	default:
+		metricRelaysDropped.Inc()
+		gr.logger.Error("gateway relayer queue is full, dropping VAA", zap.String("msgID", v.MessageID()))
+	}
+}
+
+// Run submits the queued VAAs to the gateway, and retries the failed submissions.
+func (gr *GatewayRelayer) Run(ctx context.Context) error {
+	gr.logger.Info("starting gateway relayer", zap.String("contract", gr.contract))
+	ticker := time.NewTicker(retryCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case v := <-gr.subChan:
+			gr.queueRelay(v, time.Now())
+			gr.relayDue(ctx, time.Now())
+		case <-ticker.C:
+			gr.relayDue(ctx, time.Now())
+		}
+	}
+}
+
+// queueRelay adds a VAA to the pending relays, unless it is already pending.
+func (gr *GatewayRelayer) queueRelay(v *vaa.VAA, now time.Time) {
+	msgId := v.MessageID()
+	vaaBytes, err := v.Marshal()
+	if err != nil {
+		gr.logger.Error("failed to marshal VAA", zap.String("msgID", msgId), zap.Error(err))
+		return
+	}
+
+	gr.pendingLock.Lock()
+	defer gr.pendingLock.Unlock()
+	if _, exists := gr.pending[msgId]; exists {
+		return
+	}
+	gr.pending[msgId] = &pendingRelay{v: v, vaaBytes: vaaBytes, queueTime: now, nextAttempt: now}
+	gr.updateGauges()
+}
+
+// relayDue submits the pending VAAs which are due, unless the relayer is paused.
+func (gr *GatewayRelayer) relayDue(ctx context.Context, now time.Time) {
+	var due []*pendingRelay
+	gr.pendingLock.Lock()
+	if gr.paused {
+		gr.pendingLock.Unlock()
+		return
+	}
+	for _, pr := range gr.pending {
+		if !pr.failed && !now.Before(pr.nextAttempt) {
+			due = append(due, pr)
+		}
+	}
+	gr.pendingLock.Unlock()
+
+	for _, pr := range due {
+		if ctx.Err() != nil {
+			return
+		}
+		gr.submit(ctx, pr, now)
+	}
+}
+
+// submit submits a pending VAA, and removes it from the pending relays if it was executed. It is marked as failed if the relayer gives
+// up on it.
+func (gr *GatewayRelayer) submit(ctx context.Context, pr *pendingRelay, now time.Time) {
+	msgId := pr.v.MessageID()
+	gr.pendingLock.Lock()
+	fee := Fee{GasLimit: gr.policy.GasLimit, Amount: gr.policy.fee(pr.attempts)}
+	gr.pendingLock.Unlock()
+
+	txHash, err := gr.submitter.SubmitVAA(ctx, gr.contract, pr.vaaBytes, fee)
+
+	gr.pendingLock.Lock()
+	defer gr.pendingLock.Unlock()
+	switch {
+	case err == nil:
+		metricSubmissions.WithLabelValues("success").Inc()
+		metricFeesPaid.Add(float64(fee.Amount))
+		metricRelayLatency.Observe(now.Sub(pr.queueTime).Seconds())
+		gr.logger.Info("relayed VAA to the gateway", zap.String("msgID", msgId), zap.String("txHash", txHash), zap.Uint64("fee", fee.Amount))
+	case errors.Is(err, ErrAlreadyRelayed):
+		metricSubmissions.WithLabelValues("already_relayed").Inc()
+		gr.logger.Debug("VAA was already relayed to the gateway", zap.String("msgID", msgId))
+	default:
+		metricSubmissions.WithLabelValues("failed").Inc()
+		if gr.pending[msgId] != pr {
+			// The VAA was dropped during the submission.
+			return
+		}
+		pr.attempts++
+		pr.lastError = err.Error()
+		if pr.attempts < gr.policy.MaxAttempts {
+			pr.nextAttempt = now.Add(gr.policy.retryDelay(pr.attempts))
+			gr.logger.Warn("failed to relay VAA to the gateway, will retry", zap.String("msgID", msgId), zap.Uint("attempts", pr.attempts),
+				zap.Time("nextAttempt", pr.nextAttempt), zap.Uint64("fee", fee.Amount), zap.Error(err))
+			return
+		}
+		pr.failed = true
+		metricRelaysGivenUp.Inc()
+		gr.updateGauges()
+		gr.logger.Error("failed to relay VAA to the gateway, giving up", zap.String("msgID", msgId), zap.Uint("attempts", pr.attempts),
+			zap.Uint64("fee", fee.Amount), zap.Error(err))
+		return
+	}
+
+	if gr.pending[msgId] == pr {
+		delete(gr.pending, msgId)
+		gr.updateGauges()
+	}
+}
+
+// updateGauges updates the metrics derived from the pending relays. The caller must hold pendingLock.
+func (gr *GatewayRelayer) updateGauges() {
+	failed := 0
+	for _, pr := range gr.pending {
+		if pr.failed {
+			failed++
+		}
+	}
+	metricPendingRelays.Set(float64(len(gr.pending)))
+	metricFailedRelays.Set(float64(failed))
+}
+
+// Pause stops submitting VAAs to the gateway until Resume is called. VAAs which reach quorum in the meantime are still queued.
+func (gr *GatewayRelayer) Pause() {
+	gr.pendingLock.Lock()
+	defer gr.pendingLock.Unlock()
+	gr.paused = true
+	metricPaused.Set(1)
+	gr.logger.Info("paused submissions to the gateway")
+}
+
+// Resume resumes submitting VAAs to the gateway, starting with the ones which became due while the relayer was paused.
+func (gr *GatewayRelayer) Resume() {
+	gr.pendingLock.Lock()
+	defer gr.pendingLock.Unlock()
+	gr.paused = false
+	metricPaused.Set(0)
+	gr.logger.Info("resumed submissions to the gateway")
+}
+
+// IsPaused returns true if submitting VAAs to the gateway is paused.
+func (gr *GatewayRelayer) IsPaused() bool {
+	gr.pendingLock.Lock()
+	defer gr.pendingLock.Unlock()
+	return gr.paused
+}
+
+// Policy returns the relay policy in use.
+func (gr *GatewayRelayer) Policy() RelayPolicy {
+	gr.pendingLock.Lock()
+	defer gr.pendingLock.Unlock()
+	return gr.policy
+}
+
+// SetPolicy replaces the relay policy. The retries of the VAAs which already failed keep their scheduled time, the new policy applies
+// from their next submission.
+func (gr *GatewayRelayer) SetPolicy(policy RelayPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return fmt.Errorf("invalid relay policy: %w", err)
+	}
+	gr.pendingLock.Lock()
+	defer gr.pendingLock.Unlock()
+	gr.policy = policy
+	gr.logger.Info("updated relay policy",
+		zap.Uint("maxAttempts", policy.MaxAttempts),
+		zap.Duration("initialDelay", policy.InitialDelay),
+		zap.Float64("backoff", policy.Backoff),
+		zap.Duration("maxDelay", policy.MaxDelay),
+		zap.Uint64("gasLimit", policy.GasLimit),
+		zap.Uint64("initialFee", policy.InitialFee),
+		zap.Float64("feeBump", policy.FeeBump),
+		zap.Uint64("maxFee", policy.MaxFee),
+	)
+	return nil
+}
+
+// PendingRelays returns the VAAs waiting to be relayed, including the failed ones, sorted by the time they were queued.
+func (gr *GatewayRelayer) PendingRelays() []PendingRelay {
+	gr.pendingLock.Lock()
+	ret := make([]PendingRelay, 0, len(gr.pending))
+	for msgId, pr := range gr.pending {
+		relay := PendingRelay{
+			MsgID:     msgId,
+			Status:    pr.status(),
+			QueueTime: pr.queueTime,
+			Attempts:  pr.attempts,
+			LastError: pr.lastError,
+		}
+		if !pr.failed {
+			relay.NextAttempt = pr.nextAttempt
+		}
+		ret = append(ret, relay)
+	}
+	gr.pendingLock.Unlock()
+
+	sort.Slice(ret, func(i, j int) bool {
+		if !ret[i].QueueTime.Equal(ret[j].QueueTime) {
+			return ret[i].QueueTime.Before(ret[j].QueueTime)
+		}
+		return ret[i].MsgID < ret[j].MsgID
+	})
+	return ret
+}
+
+// RetryRelay submits a pending VAA again on the next check, even if it failed, and restarts its retries and fee escalation.
+func (gr *GatewayRelayer) RetryRelay(msgId string) error {
+	gr.pendingLock.Lock()
+	defer gr.pendingLock.Unlock()
+	pr, exists := gr.pending[msgId]
+	if !exists {
+		return ErrRelayNotPending
+	}
+	pr.attempts = 0
+	pr.failed = false
+	pr.nextAttempt = time.Time{}
+	gr.updateGauges()
+	gr.logger.Info("retrying VAA on request of an operator", zap.String("msgID", msgId))
+	return nil
+}
+
+// DropRelay removes a VAA from the pending relays, so it is never submitted again unless it reaches the relayer again.
+func (gr *GatewayRelayer) DropRelay(msgId string) error {
+	gr.pendingLock.Lock()
+	defer gr.pendingLock.Unlock()
+	if _, exists := gr.pending[msgId]; !exists {
+		return ErrRelayNotPending
+	}
+	delete(gr.pending, msgId)
+	gr.updateGauges()
+	gr.logger.Info("dropped VAA on request of an operator", zap.String("msgID", msgId))
+	return nil
+}
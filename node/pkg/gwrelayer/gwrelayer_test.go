@@ -0,0 +1,297 @@
+package gwrelayer
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+var testContractAddr = vaa.Address{0x11, 0x22, 0x33, 31: 0x44}
+
+func testContract(t *testing.T) string {
+	contract, err := sdktypes.Bech32ifyAddressBytes(gatewayAddressPrefix, testContractAddr[:])
+	require.NoError(t, err)
+	return contract
+}
+
+// testTransfer returns a token bridge transfer with payload to targetAddr on targetChain.
+func testTransfer(seq uint64, targetChain vaa.ChainID, targetAddr vaa.Address) *vaa.VAA {
+	payload := make([]byte, 133)
+	payload[0] = transferWithPayloadType
+	payload[32] = 100
+	copy(payload[67:99], targetAddr[:])
+	binary.BigEndian.PutUint16(payload[99:101], uint16(targetChain))
+	return &vaa.VAA{
+		Version:        vaa.SupportedVAAVersion,
+		Timestamp:      time.Unix(1654516425, 0),
+		EmitterChain:   vaa.ChainIDSolana,
+		EmitterAddress: vaa.Address(sdk.KnownDevnetTokenbridgeEmitters[vaa.ChainIDSolana]),
+		Sequence:       seq,
+		Payload:        payload,
+	}
+}
+
+type submission struct {
+	msgId string
+	fee   Fee
+}
+
+// mockSubmitter fails the submissions of a VAA with the error queued for it, if any.
+type mockSubmitter struct {
+	errs        map[string][]error
+	submissions []submission
+}
+
+func (s *mockSubmitter) SubmitVAA(ctx context.Context, contract string, vaaBytes []byte, fee Fee) (string, error) {
+	v, err := vaa.Unmarshal(vaaBytes)
+	if err != nil {
+		return "", err
+	}
+	msgId := v.MessageID()
+	s.submissions = append(s.submissions, submission{msgId: msgId, fee: fee})
+	if errs := s.errs[msgId]; len(errs) != 0 {
+		s.errs[msgId] = errs[1:]
+		return "", errs[0]
+	}
+	return "0xtx", nil
+}
+
+func newTestRelayer(t *testing.T, submitter Submitter, policy RelayPolicy) *GatewayRelayer {
+	gr, err := NewGatewayRelayer(zap.NewNop(), submitter, testContract(t), common.GoTest, policy)
+	require.NoError(t, err)
+	return gr
+}
+
+func TestNewGatewayRelayer(t *testing.T) {
+	gr := newTestRelayer(t, &mockSubmitter{}, DefaultRelayPolicy())
+	assert.Equal(t, testContractAddr, gr.targetAddr)
+
+	_, err := NewGatewayRelayer(zap.NewNop(), &mockSubmitter{}, "wormhole1invalid", common.GoTest, DefaultRelayPolicy())
+	assert.Error(t, err)
+
+	// Contract addresses are 32 bytes long.
+	contract, err := sdktypes.Bech32ifyAddressBytes(gatewayAddressPrefix, make([]byte, 20))
+	require.NoError(t, err)
+	_, err = NewGatewayRelayer(zap.NewNop(), &mockSubmitter{}, contract, common.GoTest, DefaultRelayPolicy())
+	assert.Error(t, err)
+
+	policy := DefaultRelayPolicy()
+	policy.MaxAttempts = 0
+	_, err = NewGatewayRelayer(zap.NewNop(), &mockSubmitter{}, testContract(t), common.GoTest, policy)
+	assert.Error(t, err)
+}
+
+func TestShouldRelay(t *testing.T) {
+	gr := newTestRelayer(t, &mockSubmitter{}, DefaultRelayPolicy())
+	assert.True(t, gr.shouldRelay(testTransfer(1, gatewayChainID, testContractAddr)))
+
+	// Transfers to other chains or contracts are not relayed.
+	assert.False(t, gr.shouldRelay(testTransfer(1, vaa.ChainIDSolana, testContractAddr)))
+	assert.False(t, gr.shouldRelay(testTransfer(1, gatewayChainID, vaa.Address{1})))
+
+	// Neither are transfers without payload, or messages from other emitters.
+	v := testTransfer(1, gatewayChainID, testContractAddr)
+	v.Payload[0] = 1
+	assert.False(t, gr.shouldRelay(v))
+	v = testTransfer(1, gatewayChainID, testContractAddr)
+	v.EmitterAddress = vaa.Address{1}
+	assert.False(t, gr.shouldRelay(v))
+	v = testTransfer(1, gatewayChainID, testContractAddr)
+	v.Payload = v.Payload[:100]
+	assert.False(t, gr.shouldRelay(v))
+}
+
+func TestRelayRetries(t *testing.T) {
+	policy := DefaultRelayPolicy()
+	policy.MaxAttempts = 3
+	policy.InitialFee = 100
+	policy.FeeBump = 2
+	policy.MaxFee = 300
+	v := testTransfer(1, gatewayChainID, testContractAddr)
+	submitter := &mockSubmitter{errs: map[string][]error{v.MessageID(): {errors.New("out of gas"), errors.New("out of gas")}}}
+	gr := newTestRelayer(t, submitter, policy)
+	ctx := context.Background()
+	now := time.Now()
+
+	gr.SubmitVAA(v)
+	gr.SubmitVAA(testTransfer(2, vaa.ChainIDSolana, testContractAddr))
+	require.Len(t, gr.subChan, 1)
+	gr.queueRelay(<-gr.subChan, now)
+	gr.queueRelay(v, now)
+	require.Len(t, gr.pending, 1)
+
+	gr.relayDue(ctx, now)
+	require.Len(t, submitter.submissions, 1)
+	assert.Equal(t, uint(1), gr.pending[v.MessageID()].attempts)
+	assert.Equal(t, "out of gas", gr.pending[v.MessageID()].lastError)
+
+	// The VAA is submitted again once the delay elapsed, offering a higher fee.
+	gr.relayDue(ctx, now.Add(policy.InitialDelay-time.Millisecond))
+	require.Len(t, submitter.submissions, 1)
+	gr.relayDue(ctx, now.Add(policy.InitialDelay))
+	require.Len(t, submitter.submissions, 2)
+	now = now.Add(policy.InitialDelay)
+	gr.relayDue(ctx, now.Add(2*policy.InitialDelay))
+	require.Len(t, submitter.submissions, 3)
+
+	assert.Equal(t, Fee{GasLimit: policy.GasLimit, Amount: 100}, submitter.submissions[0].fee)
+	assert.Equal(t, Fee{GasLimit: policy.GasLimit, Amount: 200}, submitter.submissions[1].fee)
+	assert.Equal(t, Fee{GasLimit: policy.GasLimit, Amount: 300}, submitter.submissions[2].fee)
+	assert.Empty(t, gr.pending)
+}
+
+func TestRelayGivesUp(t *testing.T) {
+	policy := DefaultRelayPolicy()
+	policy.MaxAttempts = 2
+	v := testTransfer(1, gatewayChainID, testContractAddr)
+	submitter := &mockSubmitter{errs: map[string][]error{v.MessageID(): {errors.New("failed"), errors.New("failed"), errors.New("failed")}}}
+	gr := newTestRelayer(t, submitter, policy)
+	ctx := context.Background()
+	now := time.Now()
+
+	gr.queueRelay(v, now)
+	gr.relayDue(ctx, now)
+	require.Equal(t, StatusRetrying, gr.PendingRelays()[0].Status)
+	gr.relayDue(ctx, now.Add(time.Hour))
+	assert.Len(t, submitter.submissions, 2)
+
+	// Failed VAAs are kept, but not submitted again.
+	relays := gr.PendingRelays()
+	require.Len(t, relays, 1)
+	assert.Equal(t, PendingRelay{MsgID: v.MessageID(), Status: StatusFailed, QueueTime: now, Attempts: 2, LastError: "failed"}, relays[0])
+	gr.relayDue(ctx, now.Add(24*time.Hour))
+	assert.Len(t, submitter.submissions, 2)
+
+	// Until they are retried, which restarts their attempts.
+	require.NoError(t, gr.RetryRelay(v.MessageID()))
+	assert.Equal(t, StatusQueued, gr.PendingRelays()[0].Status)
+	gr.relayDue(ctx, now)
+	assert.Len(t, submitter.submissions, 3)
+	assert.Equal(t, StatusRetrying, gr.PendingRelays()[0].Status)
+
+	// VAAs which were already executed are done.
+	submitter.errs[v.MessageID()] = []error{ErrAlreadyRelayed}
+	gr.relayDue(ctx, now.Add(time.Hour))
+	assert.Empty(t, gr.PendingRelays())
+	assert.ErrorIs(t, gr.RetryRelay(v.MessageID()), ErrRelayNotPending)
+}
+
+func TestRelayPauseAndDrop(t *testing.T) {
+	submitter := &mockSubmitter{}
+	gr := newTestRelayer(t, submitter, DefaultRelayPolicy())
+	ctx := context.Background()
+	now := time.Now()
+	v1 := testTransfer(1, gatewayChainID, testContractAddr)
+	v2 := testTransfer(2, gatewayChainID, testContractAddr)
+
+	// VAAs are queued but not submitted while the relayer is paused.
+	gr.Pause()
+	assert.True(t, gr.IsPaused())
+	gr.queueRelay(v2, now.Add(time.Second))
+	gr.queueRelay(v1, now)
+	gr.relayDue(ctx, now.Add(time.Minute))
+	assert.Empty(t, submitter.submissions)
+
+	relays := gr.PendingRelays()
+	require.Len(t, relays, 2)
+	assert.Equal(t, v1.MessageID(), relays[0].MsgID)
+	assert.Equal(t, StatusQueued, relays[0].Status)
+	assert.Equal(t, v2.MessageID(), relays[1].MsgID)
+
+	require.NoError(t, gr.DropRelay(v1.MessageID()))
+	assert.ErrorIs(t, gr.DropRelay(v1.MessageID()), ErrRelayNotPending)
+
+	gr.Resume()
+	assert.False(t, gr.IsPaused())
+	gr.relayDue(ctx, now.Add(time.Minute))
+	require.Len(t, submitter.submissions, 1)
+	assert.Equal(t, v2.MessageID(), submitter.submissions[0].msgId)
+	assert.Empty(t, gr.PendingRelays())
+}
+
+func TestRelayPolicy(t *testing.T) {
+	policy := DefaultRelayPolicy()
+	require.NoError(t, policy.Validate())
+
+	for _, invalid := range []func(p *RelayPolicy){
+		func(p *RelayPolicy) { p.MaxAttempts = 0 },
+		func(p *RelayPolicy) { p.InitialDelay = 0 },
+		func(p *RelayPolicy) { p.Backoff = 0.5 },
+		func(p *RelayPolicy) { p.MaxDelay = time.Second },
+		func(p *RelayPolicy) { p.GasLimit = 0 },
+		func(p *RelayPolicy) { p.FeeBump = 0 },
+		func(p *RelayPolicy) { p.InitialFee = 10 },
+	} {
+		p := DefaultRelayPolicy()
+		invalid(&p)
+		assert.Error(t, p.Validate())
+	}
+
+	assert.Equal(t, 5*time.Second, policy.retryDelay(1))
+	assert.Equal(t, 20*time.Second, policy.retryDelay(3))
+	assert.Equal(t, 5*time.Minute, policy.retryDelay(100))
+	assert.Equal(t, uint64(0), policy.fee(5))
+
+	policy.InitialFee = 1000
+	policy.FeeBump = 1.5
+	policy.MaxFee = 2000
+	assert.Equal(t, uint64(1000), policy.fee(0))
+	assert.Equal(t, uint64(1500), policy.fee(1))
+	assert.Equal(t, uint64(2000), policy.fee(2))
+}
+
+func TestSetRelayPolicy(t *testing.T) {
+	gr := newTestRelayer(t, &mockSubmitter{}, DefaultRelayPolicy())
+
+	invalid := DefaultRelayPolicy()
+	invalid.MaxAttempts = 0
+	assert.Error(t, gr.SetPolicy(invalid))
+	assert.Equal(t, DefaultRelayPolicy(), gr.Policy())
+
+	policy := DefaultRelayPolicy()
+	policy.InitialFee = 100
+	policy.MaxFee = 1000
+	require.NoError(t, gr.SetPolicy(policy))
+	assert.Equal(t, policy, gr.Policy())
+}
+
+func TestHTTPSubmitter(t *testing.T) {
+	var resp SubmitVAAResponse
+	var req SubmitVAARequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/submit_vaa", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.NoError(t, json.NewEncoder(w).Encode(&resp))
+	}))
+	defer server.Close()
+	s := NewHTTPSubmitter(server.URL + "/")
+	ctx := context.Background()
+
+	resp = SubmitVAAResponse{TxHash: "0xtx"}
+	txHash, err := s.SubmitVAA(ctx, "wormhole1contract", []byte{1, 2}, Fee{GasLimit: 10, Amount: 5})
+	require.NoError(t, err)
+	assert.Equal(t, "0xtx", txHash)
+	assert.Equal(t, SubmitVAARequest{Contract: "wormhole1contract", VAA: []byte{1, 2}, Fee: Fee{GasLimit: 10, Amount: 5}}, req)
+
+	resp = SubmitVAAResponse{TxHash: "0xtx", Code: 5, RawLog: "failed to execute message: VaaAlreadyExecuted"}
+	_, err = s.SubmitVAA(ctx, "wormhole1contract", []byte{1, 2}, Fee{})
+	assert.ErrorIs(t, err, ErrAlreadyRelayed)
+
+	resp = SubmitVAAResponse{TxHash: "0xtx", Code: 11, RawLog: "out of gas"}
+	_, err = s.SubmitVAA(ctx, "wormhole1contract", []byte{1, 2}, Fee{})
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrAlreadyRelayed)
+}
@@ -0,0 +1,97 @@
+package gwrelayer
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// RelayPolicy controls how often the gateway relayer retries a failed submission, and the fee it offers for each attempt.
+type RelayPolicy struct {
+	// MaxAttempts is the number of submissions of a VAA before the relayer gives up on it.
+	MaxAttempts uint
+	// InitialDelay is the time after the first failed submission before the VAA is submitted again.
+	InitialDelay time.Duration
+	// Backoff is the factor by which the delay grows after every failed submission.
+	Backoff float64
+	// MaxDelay caps the delay between submissions, or zero for no cap.
+	MaxDelay time.Duration
+
+	// GasLimit is the gas limit of the submission transactions.
+	GasLimit uint64
+	// InitialFee is the fee offered for the first submission of a VAA, in the fee denomination of the gateway.
+	InitialFee uint64
+	// FeeBump is the factor by which the fee grows after every failed submission.
+	FeeBump float64
+	// MaxFee caps the fee offered for a submission.
+	MaxFee uint64
+}
+
+// DefaultRelayPolicy returns the policy used when none is configured. The gateway does not charge fees by default.
+func DefaultRelayPolicy() RelayPolicy {
+	return RelayPolicy{
+		MaxAttempts:  10,
+		InitialDelay: 5 * time.Second,
+		Backoff:      2,
+		MaxDelay:     5 * time.Minute,
+		GasLimit:     2000000,
+		InitialFee:   0,
+		FeeBump:      1,
+		MaxFee:       0,
+	}
+}
+
+// Validate checks that the policy would result in sensible retries and fees.
+func (p RelayPolicy) Validate() error {
+	if p.MaxAttempts == 0 {
+		return errors.New("max attempts must be positive")
+	}
+	if p.InitialDelay <= 0 {
+		return errors.New("initial delay must be positive")
+	}
+	if p.Backoff < 1 || math.IsInf(p.Backoff, 0) || math.IsNaN(p.Backoff) {
+		return errors.New("backoff must be at least 1")
+	}
+	if p.MaxDelay < 0 {
+		return errors.New("max delay must not be negative")
+	}
+	if p.MaxDelay != 0 && p.MaxDelay < p.InitialDelay {
+		return errors.New("max delay must not be less than the initial delay")
+	}
+	if p.GasLimit == 0 {
+		return errors.New("gas limit must be positive")
+	}
+	if p.FeeBump < 1 || math.IsInf(p.FeeBump, 0) || math.IsNaN(p.FeeBump) {
+		return errors.New("fee bump must be at least 1")
+	}
+	if p.MaxFee < p.InitialFee {
+		return errors.New("max fee must not be less than the initial fee")
+	}
+	return nil
+}
+
+// retryDelay returns how long to wait before submitting a VAA again, after attempts failed submissions.
+func (p RelayPolicy) retryDelay(attempts uint) time.Duration {
+	exp := float64(0)
+	if attempts > 1 {
+		exp = float64(attempts - 1)
+	}
+	delay := time.Duration(float64(p.InitialDelay) * math.Pow(p.Backoff, exp))
+	if delay <= 0 {
+		// The multiplication overflowed.
+		delay = time.Duration(math.MaxInt64)
+	}
+	if p.MaxDelay != 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return delay
+}
+
+// fee returns the fee offered for a submission of a VAA, after attempts failed submissions.
+func (p RelayPolicy) fee(attempts uint) uint64 {
+	fee := float64(p.InitialFee) * math.Pow(p.FeeBump, float64(attempts))
+	if fee >= float64(p.MaxFee) {
+		return p.MaxFee
+	}
+	return uint64(fee)
+}
@@ -0,0 +1,96 @@
+// This file contains the interface used to submit VAAs to the gateway and its HTTP implementation.
+//
+// The relayer submits VAAs through an HTTP endpoint exposed by the gateway deployment, which accepts JSON:
+//
+//	POST <url>/submit_vaa  SubmitVAARequest  -> SubmitVAAResponse
+//
+// The endpoint broadcasts a transaction executing the VAA on the contract, and returns its result. A transaction failing because the
+// VAA was already executed, e.g. by another guardian, is reported in its raw log.
+
+package gwrelayer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const submitTimeout = 10 * time.Second
+
+// ErrAlreadyRelayed is returned by a Submitter when the VAA was already executed on the gateway.
+var ErrAlreadyRelayed = errors.New("VAA was already executed")
+
+// Submitter submits VAAs to the contract on the gateway.
+type Submitter interface {
+	// SubmitVAA executes a VAA on contract, paying up to fee. It returns the hash of the transaction.
+	SubmitVAA(ctx context.Context, contract string, vaaBytes []byte, fee Fee) (string, error)
+}
+
+// Fee is the fee offered for a submission, in the fee denomination of the gateway.
+type Fee struct {
+	GasLimit uint64 `json:"gas_limit"`
+	Amount   uint64 `json:"amount"`
+}
+
+// SubmitVAARequest executes a VAA on a contract.
+type SubmitVAARequest struct {
+	Contract string `json:"contract"`
+	VAA      []byte `json:"vaa"`
+	Fee      Fee    `json:"fee"`
+}
+
+// SubmitVAAResponse is the result of the transaction executing a VAA. A non-zero Code means the transaction failed, in which case
+// RawLog contains the reason.
+type SubmitVAAResponse struct {
+	TxHash string `json:"tx_hash"`
+	Code   uint32 `json:"code"`
+	RawLog string `json:"raw_log"`
+}
+
+// httpSubmitter is a Submitter reaching the gateway through its HTTP endpoint.
+type httpSubmitter struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPSubmitter returns a Submitter which submits VAAs to the gateway endpoint at url.
+func NewHTTPSubmitter(url string) Submitter {
+	return &httpSubmitter{url: strings.TrimSuffix(url, "/"), client: &http.Client{Timeout: submitTimeout}}
+}
+
+func (s *httpSubmitter) SubmitVAA(ctx context.Context, contract string, vaaBytes []byte, fee Fee) (string, error) {
+	b, err := json.Marshal(&SubmitVAARequest{Contract: contract, VAA: vaaBytes, Fee: fee})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url+"/submit_vaa", bytes.NewReader(b))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to call submit_vaa: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("submit_vaa returned status %s", resp.Status)
+	}
+
+	var result SubmitVAAResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode the response of submit_vaa: %w", err)
+	}
+	if result.Code != 0 {
+		if strings.Contains(result.RawLog, "VaaAlreadyExecuted") {
+			return result.TxHash, ErrAlreadyRelayed
+		}
+		return result.TxHash, fmt.Errorf("transaction %s failed with code %d: %s", result.TxHash, result.Code, result.RawLog)
+	}
+	return result.TxHash, nil
+}
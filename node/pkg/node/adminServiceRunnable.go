@@ -1,21 +1,31 @@
 package node
 
 import (
+	"context"
 	"crypto/ecdsa"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"os"
 
+	"github.com/certusone/wormhole/node/pkg/accountant"
 	"github.com/certusone/wormhole/node/pkg/adminrpc"
 	"github.com/certusone/wormhole/node/pkg/common"
 	"github.com/certusone/wormhole/node/pkg/db"
 	"github.com/certusone/wormhole/node/pkg/governor"
+	"github.com/certusone/wormhole/node/pkg/gwrelayer"
 	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
 	nodev1 "github.com/certusone/wormhole/node/pkg/proto/node/v1"
 	publicrpcv1 "github.com/certusone/wormhole/node/pkg/proto/publicrpc/v1"
 	"github.com/certusone/wormhole/node/pkg/publicrpc"
+	"github.com/certusone/wormhole/node/pkg/query"
 	"github.com/certusone/wormhole/node/pkg/supervisor"
+	"github.com/certusone/wormhole/node/pkg/supportbundle"
+	"github.com/certusone/wormhole/node/pkg/vaaarchive"
+	"github.com/certusone/wormhole/node/pkg/watchers"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 )
@@ -29,9 +39,25 @@ func adminServiceRunnable(
 	db *db.Database,
 	gst *common.GuardianSetState,
 	gov *governor.ChainGovernor,
+	acct *accountant.Accountant,
+	nttAcct *accountant.Accountant,
+	gatewayRelayer *gwrelayer.GatewayRelayer,
 	gk *ecdsa.PrivateKey,
 	rpcMap map[string]string,
-) (supervisor.Runnable, error) {
+	govStatus *common.GovernanceStatusTracker,
+	conflicts *common.ObservationConflictTracker,
+	allowedRequesters *query.AllowedRequesters,
+	queryBudget *query.QueryBudget,
+	vaaArchiver *vaaarchive.Archiver,
+	support *supportbundle.Sources,
+	requireSecondApprover bool,
+	allowVAAPurge bool,
+	effectiveConfig func() ([]byte, error),
+	queueDepths func() []adminrpc.QueueDepth,
+	logLevels *common.LogLevels,
+	watcherConfigs *watchers.Configs,
+	auditor *adminrpc.Auditor,
+) (supervisor.Runnable, nodev1.NodePrivilegedServiceServer, error) {
 	// Delete existing UNIX socket, if present.
 	fi, err := os.Stat(socketPath)
 	if err == nil {
@@ -39,10 +65,10 @@ func adminServiceRunnable(
 		if fmode&os.ModeType == os.ModeSocket {
 			err = os.Remove(socketPath)
 			if err != nil {
-				return nil, fmt.Errorf("failed to remove existing socket at %s: %w", socketPath, err)
+				return nil, nil, fmt.Errorf("failed to remove existing socket at %s: %w", socketPath, err)
 			}
 		} else {
-			return nil, fmt.Errorf("%s is not a UNIX socket", socketPath)
+			return nil, nil, fmt.Errorf("%s is not a UNIX socket", socketPath)
 		}
 	}
 
@@ -54,11 +80,11 @@ func adminServiceRunnable(
 
 	laddr, err := net.ResolveUnixAddr("unix", socketPath)
 	if err != nil {
-		return nil, fmt.Errorf("invalid listen address: %v", err)
+		return nil, nil, fmt.Errorf("invalid listen address: %v", err)
 	}
 	l, err := net.ListenUnix("unix", laddr)
 	if err != nil {
-		return nil, fmt.Errorf("failed to listen on %s: %w", socketPath, err)
+		return nil, nil, fmt.Errorf("failed to listen on %s: %w", socketPath, err)
 	}
 
 	logger.Info("admin server listening on", zap.String("path", socketPath))
@@ -70,15 +96,83 @@ func adminServiceRunnable(
 		logger.Named("adminservice"),
 		signedInC,
 		gov,
+		acct,
+		nttAcct,
+		gatewayRelayer,
 		gk,
 		ethcrypto.PubkeyToAddress(gk.PublicKey),
 		rpcMap,
+		gst,
+		govStatus,
+		conflicts,
+		allowedRequesters,
+		queryBudget,
+		vaaArchiver,
+		support,
+		requireSecondApprover,
+		allowVAAPurge,
 	)
 
+	nodeService.SetEffectiveConfig(effectiveConfig)
+	nodeService.SetQueueDepths(queueDepths)
+	if logLevels != nil {
+		nodeService.SetLogLevels(logLevels)
+	}
+	if watcherConfigs != nil {
+		nodeService.SetWatcherConfigs(watcherConfigs)
+	}
+	nodeService.SetAuditor(auditor)
+
 	publicrpcService := publicrpc.NewPublicrpcServer(logger, db, gst, gov)
 
-	grpcServer := common.NewInstrumentedGRPCServer(logger, common.GrpcLogDetailMinimal)
+	// Only local users can reach the socket. They are identified by the peer credentials of their connection.
+	caller := func(ctx context.Context) string {
+		if c, ok := adminrpc.LocalCaller(ctx); ok {
+			return c
+		}
+		return adminrpc.CallerLocal
+	}
+	grpcServer := common.NewInstrumentedGRPCServer(logger, common.GrpcLogDetailMinimal,
+		grpc.Creds(adminrpc.UnixPeerCredentials()),
+		grpc.ChainUnaryInterceptor(auditor.UnaryServerInterceptor(caller), adminrpc.CallerUnaryServerInterceptor(adminrpc.LocalCaller), adminrpc.UnaryServerInterceptor),
+		grpc.ChainStreamInterceptor(auditor.StreamServerInterceptor(caller), adminrpc.CallerStreamServerInterceptor(adminrpc.LocalCaller), adminrpc.StreamServerInterceptor),
+	)
 	nodev1.RegisterNodePrivilegedServiceServer(grpcServer, nodeService)
 	publicrpcv1.RegisterPublicRPCServiceServer(grpcServer, publicrpcService)
-	return supervisor.GRPCServer(grpcServer, l, false), nil
+	serve := supervisor.GRPCServer(grpcServer, l, false)
+	return func(ctx context.Context) error {
+		nodeService.SetSupervisorTree(func() []supervisor.RunnableStatus { return supervisor.Tree(ctx) })
+		// The runnables serving the admin service, see GuardianOptionAdminService and GuardianOptionAdminServiceTCP.
+		nodeService.SetRunnableRestarter(func(dn string) error { return supervisor.Restart(ctx, dn) }, []string{"root.admin", "root.admin-tcp"})
+		return serve(ctx)
+	}, nodeService, nil
+}
+
+// adminServiceTCPRunnable serves the admin service on TCP to the clients allowed by auth. Unlike the unix socket, the public
+// service is not served alongside it.
+func adminServiceTCPRunnable(logger *zap.Logger, listenAddr string, ipMode common.IPMode, tlsConfig *tls.Config, auth *adminrpc.TLSAuthorizer, auditor *adminrpc.Auditor, nodeService nodev1.NodePrivilegedServiceServer) supervisor.Runnable {
+	return func(ctx context.Context) error {
+		l, err := common.ListenTCP(ipMode, listenAddr)
+		if err != nil {
+			return fmt.Errorf("failed to listen: %w", err)
+		}
+
+		logger.Info("admin server listening on TCP", zap.String("addr", l.Addr().String()))
+
+		// NewInstrumentedGRPCServer runs its metrics and logging interceptors before the ones passed in, so calls from clients that are
+		// not authorized are still counted per method by the gRPC metrics, and are audited before they are rejected.
+		grpcServer := common.NewInstrumentedGRPCServer(logger, common.GrpcLogDetailMinimal,
+			grpc.Creds(credentials.NewTLS(tlsConfig)),
+			grpc.ChainUnaryInterceptor(auditor.UnaryServerInterceptor(auth.ClientName), auth.UnaryServerInterceptor, adminrpc.CallerUnaryServerInterceptor(auth.Caller), adminrpc.UnaryServerInterceptor),
+			grpc.ChainStreamInterceptor(auditor.StreamServerInterceptor(auth.ClientName), auth.StreamServerInterceptor, adminrpc.CallerStreamServerInterceptor(auth.Caller), adminrpc.StreamServerInterceptor),
+		)
+		nodev1.RegisterNodePrivilegedServiceServer(grpcServer, nodeService)
+
+		if err := supervisor.Run(ctx, "grpcserver", supervisor.GRPCServer(grpcServer, l, false)); err != nil {
+			return err
+		}
+
+		<-ctx.Done()
+		return nil
+	}
 }
@@ -0,0 +1,37 @@
+package node
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	"github.com/certusone/wormhole/node/pkg/dbreplication"
+	nodev1 "github.com/certusone/wormhole/node/pkg/proto/node/v1"
+	"github.com/certusone/wormhole/node/pkg/supervisor"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+func dbReplicationServiceRunnable(logger *zap.Logger, listenAddr string, ipMode common.IPMode, tlsConfig *tls.Config, db *db.Database) supervisor.Runnable {
+	return func(ctx context.Context) error {
+		l, err := common.ListenTCP(ipMode, listenAddr)
+		if err != nil {
+			return fmt.Errorf("failed to listen: %w", err)
+		}
+
+		logger.Info("database replication server listening", zap.String("addr", l.Addr().String()))
+
+		grpcServer := common.NewInstrumentedGRPCServer(logger, common.GrpcLogDetailMinimal, grpc.Creds(credentials.NewTLS(tlsConfig)))
+		nodev1.RegisterDatabaseReplicationServiceServer(grpcServer, dbreplication.NewServer(logger, db))
+
+		if err := supervisor.Run(ctx, "grpcserver", supervisor.GRPCServer(grpcServer, l, false)); err != nil {
+			return err
+		}
+
+		<-ctx.Done()
+		return nil
+	}
+}
@@ -0,0 +1,138 @@
+package node
+
+import (
+	"runtime"
+	"runtime/debug"
+
+	"github.com/certusone/wormhole/node/pkg/accountant"
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/governor"
+	"github.com/certusone/wormhole/node/pkg/supportbundle"
+	"github.com/certusone/wormhole/node/pkg/version"
+	"github.com/certusone/wormhole/node/pkg/watchers"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// EffectiveConfig is the fully resolved configuration of a running node, with secrets redacted. It is meant to be diffed across
+// guardians, so it only holds configuration and no state.
+type EffectiveConfig struct {
+	Build           EffectiveConfigBuild `json:"build"`
+	Environment     common.Environment   `json:"environment"`
+	GuardianAddress string               `json:"guardianAddress,omitempty"`
+	// Options are the names of the options the node was configured with, in order. A component can be configured but disabled, in
+	// which case its section below is null.
+	Options []string `json:"options"`
+	// Flags are the command line flags of guardiand, unset ones with their default values.
+	Flags    map[string]string        `json:"flags,omitempty"`
+	Shadow   bool                     `json:"shadow"`
+	Watchers []EffectiveConfigWatcher `json:"watchers"`
+
+	Governor      *EffectiveConfigGovernor   `json:"governor"`
+	Accountant    *EffectiveConfigAccountant `json:"accountant"`
+	NTTAccountant *EffectiveConfigAccountant `json:"nttAccountant"`
+}
+
+// EffectiveConfigBuild identifies the binary the node runs.
+type EffectiveConfigBuild struct {
+	Version   string `json:"version"`
+	GoVersion string `json:"goVersion"`
+	// Revision is the commit the binary was built from, if it was built from a git checkout. Modified is set if the checkout had
+	// uncommitted changes.
+	Revision string `json:"revision,omitempty"`
+	Modified bool   `json:"modified,omitempty"`
+}
+
+// EffectiveConfigWatcher is the config of a watcher.
+type EffectiveConfigWatcher struct {
+	NetworkID watchers.NetworkID `json:"networkId"`
+	ChainID   vaa.ChainID        `json:"chainId"`
+	ChainName string             `json:"chainName"`
+	// Config is the config the watcher was created with, with RPC URLs and secrets redacted.
+	Config interface{} `json:"config"`
+}
+
+// EffectiveConfigGovernor is the config of the chain governor, including the limits it currently enforces.
+type EffectiveConfigGovernor struct {
+	ShadowMode               bool                  `json:"shadowMode"`
+	ReleaseApprovalThreshold uint64                `json:"releaseApprovalThreshold"`
+	ReleaseApprovals         int                   `json:"releaseApprovals"`
+	ChainLimits              []governor.ChainLimit `json:"chainLimits"`
+}
+
+// EffectiveConfigAccountant is the config of an accountant.
+type EffectiveConfigAccountant struct {
+	Enforcing             bool `json:"enforcing"`
+	ForceReleaseApprovals int  `json:"forceReleaseApprovals"`
+}
+
+// EffectiveConfig collects the effective configuration of the node. flags are the command line flags, which must already be
+// redacted, see supportbundle.RedactFlags.
+func (g *G) EffectiveConfig(flags map[string]string) (*EffectiveConfig, error) {
+	cfg := &EffectiveConfig{
+		Build:       buildInfo(),
+		Environment: g.env,
+		Options:     append([]string(nil), g.configuredOptions...),
+		Flags:       flags,
+		Shadow:      g.shadow,
+		Watchers:    []EffectiveConfigWatcher{},
+	}
+	if g.gk != nil {
+		cfg.GuardianAddress = ethcrypto.PubkeyToAddress(g.gk.PublicKey).Hex()
+	}
+
+	var watcherConfigs []watchers.WatcherConfig
+	if g.watcherConfigs != nil {
+		watcherConfigs = g.watcherConfigs.All()
+	}
+	for _, wc := range watcherConfigs {
+		redacted, err := supportbundle.RedactJSON(wc)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Watchers = append(cfg.Watchers, EffectiveConfigWatcher{
+			NetworkID: wc.GetNetworkID(),
+			ChainID:   wc.GetChainID(),
+			ChainName: wc.GetChainID().String(),
+			Config:    redacted,
+		})
+	}
+
+	if g.gov != nil {
+		threshold, approvals := g.gov.ReleaseApprovals()
+		cfg.Governor = &EffectiveConfigGovernor{
+			ShadowMode:               g.gov.IsShadowMode(),
+			ReleaseApprovalThreshold: threshold,
+			ReleaseApprovals:         approvals,
+			ChainLimits:              g.gov.ChainLimits(),
+		}
+	}
+	cfg.Accountant = effectiveAccountantConfig(g.acct)
+	cfg.NTTAccountant = effectiveAccountantConfig(g.nttAcct)
+	return cfg, nil
+}
+
+func effectiveAccountantConfig(acct *accountant.Accountant) *EffectiveConfigAccountant {
+	if acct == nil {
+		return nil
+	}
+	return &EffectiveConfigAccountant{
+		Enforcing:             acct.IsEnforcing(),
+		ForceReleaseApprovals: acct.ForceReleaseApprovals(),
+	}
+}
+
+func buildInfo() EffectiveConfigBuild {
+	b := EffectiveConfigBuild{Version: version.Version(), GoVersion: runtime.Version()}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, s := range info.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				b.Revision = s.Value
+			case "vcs.modified":
+				b.Modified = s.Value == "true"
+			}
+		}
+	}
+	return b
+}
@@ -4,13 +4,23 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"fmt"
+	"time"
 
+	"github.com/certusone/wormhole/node/pkg/accountant"
+	"github.com/certusone/wormhole/node/pkg/adminrpc"
+	"github.com/certusone/wormhole/node/pkg/aggsig"
 	"github.com/certusone/wormhole/node/pkg/common"
 	"github.com/certusone/wormhole/node/pkg/db"
 	"github.com/certusone/wormhole/node/pkg/governor"
+	"github.com/certusone/wormhole/node/pkg/gwrelayer"
+	"github.com/certusone/wormhole/node/pkg/processor"
 	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	nodev1 "github.com/certusone/wormhole/node/pkg/proto/node/v1"
+	"github.com/certusone/wormhole/node/pkg/publicrpc"
 	"github.com/certusone/wormhole/node/pkg/query"
 	"github.com/certusone/wormhole/node/pkg/supervisor"
+	"github.com/certusone/wormhole/node/pkg/vaaarchive"
+	"github.com/certusone/wormhole/node/pkg/watchers"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 
 	"go.uber.org/zap"
@@ -55,10 +65,44 @@ type G struct {
 
 	// components
 	db              *db.Database
+	stateDb         *db.Database
 	gst             *common.GuardianSetState
+	govStatus       *common.GovernanceStatusTracker
+	conflicts       *common.ObservationConflictTracker
 	gov             *governor.ChainGovernor
+	acct            *accountant.Accountant
+	nttAcct         *accountant.Accountant
+	gatewayRelayer  *gwrelayer.GatewayRelayer
+	processor       *processor.Processor
+	reobservation   *processor.ReobservationConfig
+	aggSigKey       *aggsig.SecretKey
 	queryHandler    *query.QueryHandler
+	localQuerier    *query.LocalQuerier
+	vaaArchiver     *vaaarchive.Archiver
 	publicrpcServer *grpc.Server
+	adminService    nodev1.NodePrivilegedServiceServer
+	adminAuditor    *adminrpc.Auditor
+	logLevels       *common.LogLevels
+	// adminAuditLogPath is the file the calls to the admin service are appended to, if any, in addition to the database.
+	adminAuditLogPath string
+	// publicRateLimiter limits the rate of the public RPC calls of each client IP. Nil unless rate limiting is enabled.
+	publicRateLimiter *publicrpc.RateLimiter
+	// publicSlowRequestThreshold is zero unless the public RPC calls slower than it are logged.
+	publicSlowRequestThreshold time.Duration
+
+	// nearQuorumThreshold is zero unless the near quorum alert is configured.
+	nearQuorumThreshold time.Duration
+	// lateObservationWindow is zero unless late observations are accepted.
+	lateObservationWindow time.Duration
+	// shadow is set if the node runs as a shadow guardian.
+	shadow bool
+	// recorder records the inputs of the processor for replay. Nil unless recording is enabled.
+	recorder processor.Recorder
+	// watcherConfigs are the current configs of the watchers, initially those given to GuardianOptionWatchers. Nil unless watchers
+	// are configured.
+	watcherConfigs *watchers.Configs
+	// configuredOptions are the names of the options applied to the node, in order.
+	configuredOptions []string
 
 	// runnables
 	runnablesWithScissors map[string]supervisor.Runnable
@@ -73,6 +117,8 @@ type G struct {
 	msgC channelPair[*common.MessagePublication]
 	// Ethereum incoming guardian set updates
 	setC channelPair[*common.GuardianSet]
+	// Transfers committed by the accounting contracts
+	acctC channelPair[*common.MessagePublication]
 	// Inbound signed VAAs
 	signedInC channelPair[*gossipv1.SignedVAAWithQuorum]
 	// Inbound observation requests from the p2p service (for all chains)
@@ -107,11 +153,13 @@ func (g *G) initializeBasic(rootCtxCancel context.CancelFunc) {
 	g.obsvC = make(chan *common.MsgWithTimeStamp[gossipv1.SignedObservation], inboundObservationBufferSize)
 	g.msgC = makeChannelPair[*common.MessagePublication](0)
 	g.setC = makeChannelPair[*common.GuardianSet](1) // This needs to be a buffered channel because of a circular dependency between processor and accountant during startup.
+	g.acctC = makeChannelPair[*common.MessagePublication](accountant.MsgChannelCapacity)
 	g.signedInC = makeChannelPair[*gossipv1.SignedVAAWithQuorum](inboundSignedVaaBufferSize)
 	g.obsvReqC = makeChannelPair[*gossipv1.ObservationRequest](observationRequestInboundBufferSize)
 	g.obsvReqSendC = makeChannelPair[*gossipv1.ObservationRequest](observationRequestOutboundBufferSize)
 	// Cross Chain Query Handler channels
 	g.chainQueryReqC = make(map[vaa.ChainID]chan *query.PerChainQueryInternal)
+	g.localQuerier = query.NewLocalQuerier(g.chainQueryReqC)
 	g.signedQueryReqC = makeChannelPair[*gossipv1.SignedQueryRequest](query.SignedQueryRequestChannelSize)
 	g.queryResponseC = makeChannelPair[*query.PerChainQueryResponseInternal](0)
 	g.queryResponsePublicationC = makeChannelPair[*query.QueryResponsePublication](0)
@@ -119,11 +167,26 @@ func (g *G) initializeBasic(rootCtxCancel context.CancelFunc) {
 	// Guardian set state managed by processor
 	g.gst = common.NewGuardianSetState(nil)
 
+	// Signing progress of governance VAAs injected via the admin service, fed by the processor
+	g.govStatus = common.NewGovernanceStatusTracker()
+
+	// Conflicting observations detected by the processor, inspected via the admin service
+	g.conflicts = common.NewObservationConflictTracker()
+
 	// allocate maps
 	g.runnablesWithScissors = make(map[string]supervisor.Runnable)
 	g.runnables = make(map[string]supervisor.Runnable)
 }
 
+// stateDatabase returns the database of the governor and accountant state, which is the main database unless a state database is
+// configured.
+func (g *G) stateDatabase() *db.Database {
+	if g.stateDb != nil {
+		return g.stateDb
+	}
+	return g.db
+}
+
 // applyOptions applies `options` to the GuardianNode.
 // Each option must have a unique option.name.
 // If an option has `dependencies`, they must be defined before that option.
@@ -151,6 +214,7 @@ func (g *G) applyOptions(ctx context.Context, logger *zap.Logger, options []*Gua
 
 		// mark the component as configured
 		configuredComponents[option.name] = struct{}{}
+		g.configuredOptions = append(g.configuredOptions, option.name)
 	}
 
 	return nil
@@ -174,8 +238,22 @@ func (g *G) Run(rootCtxCancel context.CancelFunc, options ...*GuardianOption) su
 			}
 		}
 
-		// TODO there is an opportunity to refactor the startup of the governor:
-		// Ideally they should just register a g.runnables["governor"] instead of being treated as special cases.
+		// TODO there is an opportunity to refactor the startup of the accountant and governor:
+		// Ideally they should just register a g.runnables["governor"] and g.runnables["accountant"] instead of being treated as special cases.
+
+		if g.acct != nil {
+			logger.Info("Starting accountant")
+			if err := g.acct.Start(ctx); err != nil {
+				logger.Fatal("failed to start accountant", zap.Error(err))
+			}
+		}
+
+		if g.nttAcct != nil {
+			logger.Info("Starting NTT accountant")
+			if err := g.nttAcct.Start(ctx); err != nil {
+				logger.Fatal("failed to start NTT accountant", zap.Error(err))
+			}
+		}
 
 		if g.gov != nil {
 			logger.Info("Starting governor")
@@ -207,6 +285,21 @@ func (g *G) Run(rootCtxCancel context.CancelFunc, options ...*GuardianOption) su
 	}
 }
 
+// queueDepths returns the depth of the buffered channels between the components of the node. A queue at its capacity means its
+// reader does not keep up.
+func (g *G) queueDepths() []adminrpc.QueueDepth {
+	return []adminrpc.QueueDepth{
+		{Name: "gossip_send", Length: len(g.gossipSendC), Capacity: cap(g.gossipSendC)},
+		{Name: "observations", Length: len(g.obsvC), Capacity: cap(g.obsvC)},
+		{Name: "guardian_sets", Length: len(g.setC.readC), Capacity: cap(g.setC.readC)},
+		{Name: "accountant_committed", Length: len(g.acctC.readC), Capacity: cap(g.acctC.readC)},
+		{Name: "signed_vaas_in", Length: len(g.signedInC.readC), Capacity: cap(g.signedInC.readC)},
+		{Name: "observation_requests_in", Length: len(g.obsvReqC.readC), Capacity: cap(g.obsvReqC.readC)},
+		{Name: "observation_requests_out", Length: len(g.obsvReqSendC.readC), Capacity: cap(g.obsvReqSendC.readC)},
+		{Name: "signed_query_requests", Length: len(g.signedQueryReqC.readC), Capacity: cap(g.signedQueryReqC.readC)},
+	}
+}
+
 type channelPair[T any] struct {
 	readC  <-chan T
 	writeC chan<- T
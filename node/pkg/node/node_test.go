@@ -26,6 +26,7 @@ import (
 	"github.com/certusone/wormhole/node/pkg/common"
 	"github.com/certusone/wormhole/node/pkg/db"
 	"github.com/certusone/wormhole/node/pkg/devnet"
+	"github.com/certusone/wormhole/node/pkg/gwrelayer"
 	"github.com/certusone/wormhole/node/pkg/processor"
 	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
 	publicrpcv1 "github.com/certusone/wormhole/node/pkg/proto/publicrpc/v1"
@@ -148,7 +149,7 @@ func mockGuardianRunnable(t testing.TB, gs []*mockGuardian, mockGuardianIndex ui
 		logger := supervisor.Logger(ctx)
 
 		// setup db
-		db := db.OpenDb(logger, nil)
+		db := db.OpenDb(logger, nil, db.DefaultOptions())
 		defer db.Close()
 		gs[mockGuardianIndex].db = db
 
@@ -187,14 +188,17 @@ func mockGuardianRunnable(t testing.TB, gs []*mockGuardian, mockGuardianIndex ui
 		guardianOptions := []*GuardianOption{
 			GuardianOptionDatabase(db),
 			GuardianOptionWatchers(watcherConfigs),
+			GuardianOptionAccountant("", false),
+			GuardianOptionNTTAccountant("", false, nil),
+			GuardianOptionGatewayRelayer("", "", gwrelayer.DefaultRelayPolicy()),
 			GuardianOptionGovernor(true),
-			GuardianOptionP2P(gs[mockGuardianIndex].p2pKey, networkID, bootstrapPeers, nodeName, false, cfg.p2pPort, "", 0, ""),
+			GuardianOptionP2P(gs[mockGuardianIndex].p2pKey, networkID, bootstrapPeers, nodeName, false, cfg.p2pPort, "", 0, "", 0, common.IPModeDual, nil),
 			GuardianOptionPublicRpcSocket(cfg.publicSocket, publicRpcLogDetail),
-			GuardianOptionPublicrpcTcpService(cfg.publicRpc, publicRpcLogDetail),
-			GuardianOptionPublicWeb(cfg.publicWeb, cfg.publicSocket, "", false, ""),
-			GuardianOptionAdminService(cfg.adminSocket, rpcMap),
-			GuardianOptionStatusServer(fmt.Sprintf("[::]:%d", cfg.statusPort)),
-			GuardianOptionProcessor(),
+			GuardianOptionPublicrpcTcpService(cfg.publicRpc, publicRpcLogDetail, common.IPModeDual, true),
+			GuardianOptionPublicWeb(cfg.publicWeb, cfg.publicSocket, "", false, "", common.IPModeDual),
+			GuardianOptionAdminService(cfg.adminSocket, rpcMap, false, false, nil),
+			GuardianOptionStatusServer(fmt.Sprintf("[::]:%d", cfg.statusPort), common.IPModeDual),
+			GuardianOptionProcessor(nil, 0, 0),
 		}
 
 		guardianNode := NewGuardianNode(
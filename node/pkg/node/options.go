@@ -2,22 +2,39 @@ package node
 
 import (
 	"context"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"os"
+	"sync/atomic"
 	"time"
 
 	"github.com/benbjohnson/clock"
+	"github.com/certusone/wormhole/node/pkg/accountant"
+	"github.com/certusone/wormhole/node/pkg/adminrpc"
+	"github.com/certusone/wormhole/node/pkg/aggsig"
 	"github.com/certusone/wormhole/node/pkg/common"
 	"github.com/certusone/wormhole/node/pkg/db"
+	"github.com/certusone/wormhole/node/pkg/dbbackup"
+	"github.com/certusone/wormhole/node/pkg/dbreplication"
 	"github.com/certusone/wormhole/node/pkg/governor"
+	"github.com/certusone/wormhole/node/pkg/gwrelayer"
 	"github.com/certusone/wormhole/node/pkg/p2p"
 	"github.com/certusone/wormhole/node/pkg/processor"
 	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	"github.com/certusone/wormhole/node/pkg/publicrpc"
 	"github.com/certusone/wormhole/node/pkg/query"
 	"github.com/certusone/wormhole/node/pkg/readiness"
+	"github.com/certusone/wormhole/node/pkg/replay"
 	"github.com/certusone/wormhole/node/pkg/supervisor"
+	"github.com/certusone/wormhole/node/pkg/supportbundle"
+	"github.com/certusone/wormhole/node/pkg/vaaarchive"
 	"github.com/certusone/wormhole/node/pkg/watchers"
 	"github.com/certusone/wormhole/node/pkg/watchers/interfaces"
+	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/gorilla/mux"
 	libp2p_crypto "github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -32,15 +49,24 @@ type GuardianOption struct {
 	f            func(context.Context, *zap.Logger, *G) error // Function that is run by the constructor to initialize this component.
 }
 
-// GuardianOptionP2P configures p2p networking.
-// Dependencies: Accountant, Governor
-func GuardianOptionP2P(p2pKey libp2p_crypto.PrivKey, networkId string, bootstrapPeers string, nodeName string, disableHeartbeatVerify bool, port uint, ccqBootstrapPeers string, ccqPort uint, ccqAllowedPeers string) *GuardianOption {
+// GuardianOptionP2P configures p2p networking. A non-zero latencyProbeInterval enables gossip latency probing. The ipMode applies to
+// both the gossip and the CCQ network, while announceAddrs, if not empty, replaces the addresses advertised on the gossip network.
+// Dependencies: Accountant, Governor, Gateway Relayer
+func GuardianOptionP2P(p2pKey libp2p_crypto.PrivKey, networkId string, bootstrapPeers string, nodeName string, disableHeartbeatVerify bool, port uint, ccqBootstrapPeers string, ccqPort uint, ccqAllowedPeers string, latencyProbeInterval time.Duration, ipMode common.IPMode, announceAddrs []string) *GuardianOption {
 	return &GuardianOption{
 		name:         "p2p",
 		dependencies: []string{"accountant", "governor", "gateway-relayer"},
 		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
 			components := p2p.DefaultComponents()
 			components.Port = port
+			components.LatencyProbeInterval = latencyProbeInterval
+			components.IPMode = ipMode
+			components.AnnounceAddrs = announceAddrs
+			components.GatewayRelayerEnabled = g.gatewayRelayer != nil
+			if g.aggSigKey != nil {
+				components.AggSigPublicKey = g.aggSigKey.PublicKey().Marshal()
+				components.AggSigProofOfPossession = g.aggSigKey.ProofOfPossession().Marshal()
+			}
 
 			if g.env == common.GoTest {
 				components.WarnChannelOverflow = true
@@ -77,8 +103,9 @@ func GuardianOptionP2P(p2pKey libp2p_crypto.PrivKey, networkId string, bootstrap
 		}}
 }
 
-// GuardianOptionQueryHandler configures the Cross Chain Query module.
-func GuardianOptionQueryHandler(ccqEnabled bool, allowedRequesters string) *GuardianOption {
+// GuardianOptionQueryHandler configures the Cross Chain Query module. If dailyBudget is non-zero, each requester may only cause that
+// much archival RPC work per day, as measured by query.DefaultQueryCost.
+func GuardianOptionQueryHandler(ccqEnabled bool, allowedRequesters string, dailyBudget uint64) *GuardianOption {
 	return &GuardianOption{
 		name: "query",
 		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
@@ -87,6 +114,11 @@ func GuardianOptionQueryHandler(ccqEnabled bool, allowedRequesters string) *Guar
 				return nil
 			}
 
+			var budget *query.QueryBudget
+			if dailyBudget != 0 {
+				budget = query.NewQueryBudget(dailyBudget, nil)
+			}
+
 			g.queryHandler = query.NewQueryHandler(
 				logger,
 				g.env,
@@ -95,13 +127,161 @@ func GuardianOptionQueryHandler(ccqEnabled bool, allowedRequesters string) *Guar
 				g.chainQueryReqC,
 				g.queryResponseC.readC,
 				g.queryResponsePublicationC.writeC,
+				budget,
+			)
+
+			return nil
+		}}
+}
+
+// GuardianOptionAccountant configures the accountant, which submits token bridge transfers to the accounting contract reachable at
+// contractURL. If enforcing is set, transfers are only signed once the contract commits them, otherwise they are signed right away
+// and the contract is only consulted for monitoring. The accountant is disabled if contractURL is empty. Like the governor, it keeps
+// its state in the state database if one is configured.
+// Dependencies: db
+func GuardianOptionAccountant(contractURL string, enforcing bool) *GuardianOption {
+	return &GuardianOption{
+		name:         "accountant",
+		dependencies: []string{"db"},
+		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
+			if contractURL == "" {
+				logger.Info("acct: accountant is disabled", zap.String("component", "gacct"))
+				return nil
+			}
+
+			if enforcing {
+				logger.Info("acct: accountant is enabled and will be enforced", zap.String("component", "gacct"))
+			} else {
+				logger.Info("acct: accountant is enabled but will not be enforced", zap.String("component", "gacct"))
+			}
+
+			g.acct = accountant.NewAccountant(
+				logger,
+				g.stateDatabase(),
+				accountant.NewHTTPContract(contractURL),
+				g.gk,
+				g.gst,
+				g.acctC.writeC,
+				g.env,
+				enforcing,
+			)
+			return nil
+		}}
+}
+
+// GuardianOptionNTTAccountant configures the NTT accountant, which submits the native token transfers sent through emitters to the
+// NTT accounting contract reachable at contractURL. It runs alongside the token bridge accountant, with its own enforcing flag. The
+// NTT accountant is disabled if contractURL is empty.
+// Dependencies: db
+func GuardianOptionNTTAccountant(contractURL string, enforcing bool, emitters []accountant.NTTEmitter) *GuardianOption {
+	return &GuardianOption{
+		name:         "ntt-accountant",
+		dependencies: []string{"db"},
+		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
+			if contractURL == "" {
+				logger.Info("acct: NTT accountant is disabled", zap.String("component", "gacct"))
+				return nil
+			}
+			if len(emitters) == 0 {
+				return errors.New("the NTT accountant requires at least one NTT emitter")
+			}
+
+			logger.Info("acct: NTT accountant is enabled", zap.String("component", "gacct"), zap.Bool("enforcing", enforcing), zap.Int("numEmitters", len(emitters)))
+			g.nttAcct = accountant.NewNTTAccountant(
+				logger,
+				g.stateDatabase(),
+				accountant.NewHTTPContract(contractURL),
+				g.gk,
+				g.gst,
+				g.acctC.writeC,
+				emitters,
+				enforcing,
+			)
+			return nil
+		}}
+}
+
+// GuardianOptionGatewayRelayer configures the gateway relayer, which submits the VAAs of token bridge transfers to the IBC translator
+// contract on the gateway, through the endpoint at submitURL. The contract is given by its bech32 address. Submissions are retried and
+// paid for according to policy. The gateway relayer is disabled if submitURL is empty.
+// Dependencies: none
+func GuardianOptionGatewayRelayer(submitURL string, contract string, policy gwrelayer.RelayPolicy) *GuardianOption {
+	return &GuardianOption{
+		name: "gateway-relayer",
+		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
+			if submitURL == "" {
+				logger.Info("gwrelayer: gateway relayer is disabled", zap.String("component", "gwrelayer"))
+				return nil
+			}
+
+			var err error
+			g.gatewayRelayer, err = gwrelayer.NewGatewayRelayer(logger, gwrelayer.NewHTTPSubmitter(submitURL), contract, g.env, policy)
+			if err != nil {
+				return fmt.Errorf("failed to create gateway relayer: %w", err)
+			}
+			logger.Info("gwrelayer: gateway relayer is enabled", zap.String("component", "gwrelayer"), zap.String("contract", contract))
+			g.runnables["gwrelayer"] = g.gatewayRelayer.Run
+			return nil
+		}}
+}
+
+// GuardianOptionAccountantResubmitPolicy configures how often the accountants check on their pending transfers, when they submit them
+// to their accounting contract again, and after how long they are reported as stuck. Without this option, the accountants use
+// accountant.DefaultResubmitPolicy.
+// Dependencies: accountant, ntt-accountant
+func GuardianOptionAccountantResubmitPolicy(policy accountant.ResubmitPolicy) *GuardianOption {
+	return &GuardianOption{
+		name:         "accountant-resubmit-policy",
+		dependencies: []string{"accountant", "ntt-accountant"},
+		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
+			if g.acct == nil && g.nttAcct == nil {
+				return errors.New("a resubmit policy requires an accountant to be enabled")
+			}
+			if err := policy.Validate(); err != nil {
+				return fmt.Errorf("invalid accountant resubmit policy: %w", err)
+			}
+			logger.Info("acct: using resubmit policy", zap.String("component", "gacct"),
+				zap.Duration("auditInterval", policy.AuditInterval),
+				zap.Duration("initialDelay", policy.InitialDelay),
+				zap.Float64("backoff", policy.Backoff),
+				zap.Duration("maxDelay", policy.MaxDelay),
+				zap.Duration("alarmAge", policy.AlarmAge),
 			)
+			for _, acct := range []*accountant.Accountant{g.acct, g.nttAcct} {
+				if acct != nil {
+					acct.UseResubmitPolicy(policy)
+				}
+			}
+			return nil
+		}}
+}
 
+// GuardianOptionAccountantForceRelease allows admins to force the release of transfers the enforcing accountants hold, once approvals
+// distinct admins approved it. This bypasses the accounting contract, so it is only meant for emergencies.
+// Dependencies: accountant, ntt-accountant
+func GuardianOptionAccountantForceRelease(approvals int) *GuardianOption {
+	return &GuardianOption{
+		name:         "accountant-force-release",
+		dependencies: []string{"accountant", "ntt-accountant"},
+		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
+			if g.acct == nil && g.nttAcct == nil {
+				return errors.New("forced releases require an accountant to be enabled")
+			}
+			if approvals <= 0 {
+				return errors.New("forced releases require at least one approval")
+			}
+			logger.Warn("acct: forced releases of pending transfers are enabled", zap.String("component", "gacct"), zap.Int("approvals", approvals))
+			for _, acct := range []*accountant.Accountant{g.acct, g.nttAcct} {
+				if acct != nil {
+					acct.RequireForceReleaseApprovals(approvals)
+				}
+			}
 			return nil
 		}}
 }
 
-// GuardianOptionGovernor enables or disables the governor.
+// GuardianOptionGovernor enables or disables the governor. The governor keeps its state in the state database if one is configured,
+// see GuardianOptionStateDatabase.
 // Dependencies: db
 func GuardianOptionGovernor(governorEnabled bool) *GuardianOption {
 	return &GuardianOption{
@@ -110,7 +290,7 @@ func GuardianOptionGovernor(governorEnabled bool) *GuardianOption {
 		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
 			if governorEnabled {
 				logger.Info("chain governor is enabled")
-				g.gov = governor.NewChainGovernor(logger, g.db, g.env)
+				g.gov = governor.NewChainGovernor(logger, g.stateDatabase(), g.env)
 			} else {
 				logger.Info("chain governor is disabled")
 			}
@@ -118,10 +298,98 @@ func GuardianOptionGovernor(governorEnabled bool) *GuardianOption {
 		}}
 }
 
+// GuardianOptionGovernorConfigFile makes the governor load its token and chain config from a config file signed by a quorum of signers
+// instead of using the compiled in lists, and reload it whenever it changes.
+// Dependencies: Governor
+func GuardianOptionGovernorConfigFile(path string, signers []ethcommon.Address) *GuardianOption {
+	return &GuardianOption{
+		name:         "governor-config-file",
+		dependencies: []string{"governor"},
+		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
+			if g.gov == nil {
+				return errors.New("a governor config file requires the governor to be enabled")
+			}
+			g.gov.UseConfigFile(path, signers)
+			return nil
+		}}
+}
+
+// GuardianOptionGovernorPriceOracle makes the governor read token prices from the oracle accounts in its config file, using queries
+// against the guardian's own Solana watcher. If coinGecko is false, the governor stops querying CoinGecko.
+// Dependencies: watchers, governor
+func GuardianOptionGovernorPriceOracle(coinGecko bool) *GuardianOption {
+	return &GuardianOption{
+		name:         "governor-price-oracle",
+		dependencies: []string{"watchers", "governor"},
+		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
+			if g.gov == nil {
+				return errors.New("the price oracle requires the governor to be enabled")
+			}
+			if !g.localQuerier.Supported(vaa.ChainIDSolana) {
+				return errors.New("the price oracle requires a finalized Solana watcher")
+			}
+			logger.Info("governor price oracle is enabled", zap.Bool("coinGecko", coinGecko))
+			g.gov.UsePriceOracle(g.localQuerier, coinGecko)
+			return nil
+		}}
+}
+
+// GuardianOptionGovernorReleaseApprovals makes releasing a pending transfer worth at least threshold through the admin service require
+// the approvals of approvals distinct operators.
+// Dependencies: governor
+func GuardianOptionGovernorReleaseApprovals(threshold uint64, approvals int) *GuardianOption {
+	return &GuardianOption{
+		name:         "governor-release-approvals",
+		dependencies: []string{"governor"},
+		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
+			if g.gov == nil {
+				return errors.New("release approvals require the governor to be enabled")
+			}
+			if approvals < 1 {
+				return fmt.Errorf("at least one release approval must be required, not %d", approvals)
+			}
+			logger.Info("releasing large pending transfers requires approvals", zap.Uint64("threshold", threshold), zap.Int("approvals", approvals))
+			g.gov.RequireReleaseApprovals(threshold, approvals)
+			return nil
+		}}
+}
+
+// GuardianOptionGovernorShadowMode makes the governor publish every VAA right away, while still recording and reporting the VAAs
+// it would have enqueued.
+// Dependencies: governor
+func GuardianOptionGovernorShadowMode() *GuardianOption {
+	return &GuardianOption{
+		name:         "governor-shadow-mode",
+		dependencies: []string{"governor"},
+		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
+			if g.gov == nil {
+				return errors.New("shadow mode requires the governor to be enabled")
+			}
+			logger.Warn("the governor runs in shadow mode and will not hold back any VAAs")
+			g.gov.UseShadowMode()
+			return nil
+		}}
+}
+
+// GuardianOptionGovernorWebhook makes the governor post a JSON event to url whenever a transfer is enqueued, released or dropped.
+// Dependencies: governor
+func GuardianOptionGovernorWebhook(url string) *GuardianOption {
+	return &GuardianOption{
+		name:         "governor-webhook",
+		dependencies: []string{"governor"},
+		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
+			if g.gov == nil {
+				return errors.New("the governor webhook requires the governor to be enabled")
+			}
+			g.gov.UseWebhook(url)
+			return nil
+		}}
+}
+
 // GuardianOptionStatusServer configures the status server, including /readyz and /metrics.
 // If g.env == common.UnsafeDevNet || g.env == common.GoTest, pprof will be enabled under /debug/pprof/
 // Dependencies: none
-func GuardianOptionStatusServer(statusAddr string) *GuardianOption {
+func GuardianOptionStatusServer(statusAddr string, ipMode common.IPMode) *GuardianOption {
 	return &GuardianOption{
 		name: "status-server",
 		f: func(_ context.Context, _ *zap.Logger, g *G) error {
@@ -155,8 +423,12 @@ func GuardianOptionStatusServer(statusAddr string) *GuardianOption {
 
 				g.runnables["status-server"] = func(ctx context.Context) error {
 					logger := supervisor.Logger(ctx)
+					l, err := common.ListenTCP(ipMode, statusAddr)
+					if err != nil {
+						return err
+					}
 					go func() {
-						if err := server.ListenAndServe(); err != http.ErrServerClosed {
+						if err := server.Serve(l); err != http.ErrServerClosed {
 							logger.Error("status server crashed", zap.Error(err))
 						}
 					}()
@@ -180,6 +452,7 @@ func GuardianOptionWatchers(watcherConfigs []watchers.WatcherConfig) *GuardianOp
 	return &GuardianOption{
 		name: "watchers",
 		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
+			g.watcherConfigs = watchers.NewConfigs(watcherConfigs)
 
 			chainObsvReqC := make(map[vaa.ChainID]chan *gossipv1.ObservationRequest)
 
@@ -259,7 +532,10 @@ func GuardianOptionWatchers(watcherConfigs []watchers.WatcherConfig) *GuardianOp
 									zap.Stringer("watcherChainId", chainId),
 								)
 							}
-							g.queryResponseC.writeC <- response
+							// Responses to the guardian's own queries go back to it instead of to the query handler.
+							if !g.localQuerier.Deliver(response) {
+								g.queryResponseC.writeC <- response
+							}
 						}
 					}
 				}(chainQueryResponseC[chainId], chainId)
@@ -275,8 +551,8 @@ func GuardianOptionWatchers(watcherConfigs []watchers.WatcherConfig) *GuardianOp
 				watcherName := string(wc.GetNetworkID()) + "_watch"
 				logger.Debug("Setting up watcher: " + watcherName)
 
-				if wc.GetNetworkID() != "solana-confirmed" { // TODO this should not be a special case, see comment in common/readiness.go
-					// TBDel
+				// Watchers of the same chain at different commitment levels share its readiness component and channels.
+				if _, ok := chainObsvReqC[wc.GetChainID()]; !ok {
 					common.MustRegisterReadinessSyncing(wc.GetChainID())
 					chainObsvReqC[wc.GetChainID()] = make(chan *gossipv1.ObservationRequest, observationRequestPerChainBufferSize)
 					g.chainQueryReqC[wc.GetChainID()] = make(chan *query.PerChainQueryInternal, query.QueryRequestBufferSize)
@@ -292,13 +568,17 @@ func GuardianOptionWatchers(watcherConfigs []watchers.WatcherConfig) *GuardianOp
 					wc.SetL1Finalizer(l1watcher)
 				}
 
-				l1finalizer, runnable, err := wc.Create(chainMsgC[wc.GetChainID()], chainObsvReqC[wc.GetChainID()], g.chainQueryReqC[wc.GetChainID()], chainQueryResponseC[wc.GetChainID()], g.setC.writeC, g.env)
+				networkID, chainID := wc.GetNetworkID(), wc.GetChainID()
+				create := func() (interfaces.L1Finalizer, supervisor.Runnable, error) {
+					return g.watcherConfigs.Get(networkID).Create(chainMsgC[chainID], chainObsvReqC[chainID], g.chainQueryReqC[chainID], chainQueryResponseC[chainID], g.setC.writeC, g.env)
+				}
+				l1finalizer, runnable, err := create()
 
 				if err != nil {
 					return fmt.Errorf("error creating watcher: %w", err)
 				}
 
-				g.runnablesWithScissors[watcherName] = runnable
+				g.runnablesWithScissors[watcherName] = rebuildOnRestart(runnable, create)
 				watchers[wc.GetNetworkID()] = l1finalizer
 			}
 
@@ -308,14 +588,77 @@ func GuardianOptionWatchers(watcherConfigs []watchers.WatcherConfig) *GuardianOp
 		}}
 }
 
-// GuardianOptionAdminService enables the admin rpc service on a unix socket.
-// Dependencies: db, governor
-func GuardianOptionAdminService(socketPath string, rpcMap map[string]string) *GuardianOption {
+// rebuildOnRestart returns a runnable which runs first the first time it is started, and a watcher newly built by create from its
+// current config each time it is restarted, so that config changes are picked up without restarting the guardian. Watchers which use
+// this one as their L1 finalizer keep the one built at startup.
+func rebuildOnRestart(first supervisor.Runnable, create func() (interfaces.L1Finalizer, supervisor.Runnable, error)) supervisor.Runnable {
+	var started atomic.Bool
+	return func(ctx context.Context) error {
+		if !started.Swap(true) {
+			return first(ctx)
+		}
+		_, runnable, err := create()
+		if err != nil {
+			return fmt.Errorf("error creating watcher: %w", err)
+		}
+		return runnable(ctx)
+	}
+}
+
+// GuardianOptionLogLevels makes the log levels of the node changeable at runtime through the admin service, which must be configured
+// after this option.
+// Dependencies: none
+func GuardianOptionLogLevels(levels *common.LogLevels) *GuardianOption {
+	return &GuardianOption{
+		name: "log-levels",
+		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
+			g.logLevels = levels
+			return nil
+		}}
+}
+
+// GuardianOptionAdminAuditLog appends every call to the admin service to the file at path, one JSON object per line, in addition
+// to the audit log kept in the database. The admin service must be configured after this option.
+// Dependencies: none
+func GuardianOptionAdminAuditLog(path string) *GuardianOption {
+	return &GuardianOption{
+		name: "admin-audit-log",
+		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
+			g.adminAuditLogPath = path
+			return nil
+		}}
+}
+
+// GuardianOptionAdminService enables the admin rpc service on a unix socket. If the query handler is configured before this option,
+// its allowed requesters can be changed through the staged config workflow, as can the Solana endpoints if the watchers are, and if the VAA archive is, archived VAAs can be restored. If requireSecondApprover is set, staged config changes
+// must be applied by someone other than their proposer. If allowVAAPurge is set, signed VAAs can be purged from the database with
+// PurgeVAAs. If the log levels are configured before this option, they can be changed with SetLogLevel. Every call is recorded in
+// the audit log of the state database, and in the file of GuardianOptionAdminAuditLog if it is configured first. support is optional and
+// provides the config and logs for support bundles and TailLogs,
+// and the flags of the effective configuration dumped by DumpConfig.
+// Dependencies: db, governor, accountant, ntt-accountant, gateway-relayer
+func GuardianOptionAdminService(socketPath string, rpcMap map[string]string, requireSecondApprover bool, allowVAAPurge bool, support *supportbundle.Sources) *GuardianOption {
 	return &GuardianOption{
 		name:         "admin-service",
-		dependencies: []string{"governor", "db"},
+		dependencies: []string{"governor", "db", "accountant", "ntt-accountant", "gateway-relayer"},
 		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
-			adminService, err := adminServiceRunnable(
+			var allowedRequesters *query.AllowedRequesters
+			var queryBudget *query.QueryBudget
+			if g.queryHandler != nil {
+				allowedRequesters = g.queryHandler.AllowedRequesters()
+				queryBudget = g.queryHandler.Budget()
+			}
+
+			auditor, err := adminrpc.NewAuditor(logger.Named("adminaudit"), g.stateDatabase(), g.adminAuditLogPath)
+			if err != nil {
+				return err
+			}
+			go func() {
+				<-ctx.Done()
+				_ = auditor.Close()
+			}()
+
+			adminService, nodeService, err := adminServiceRunnable(
 				logger,
 				socketPath,
 				g.msgC.writeC,
@@ -324,14 +667,68 @@ func GuardianOptionAdminService(socketPath string, rpcMap map[string]string) *Gu
 				g.db,
 				g.gst,
 				g.gov,
+				g.acct,
+				g.nttAcct,
+				g.gatewayRelayer,
 				g.gk,
 				rpcMap,
+				g.govStatus,
+				g.conflicts,
+				allowedRequesters,
+				queryBudget,
+				g.vaaArchiver,
+				support,
+				requireSecondApprover,
+				allowVAAPurge,
+				func() ([]byte, error) {
+					var flags map[string]string
+					if support != nil {
+						flags = support.Config
+					}
+					cfg, err := g.EffectiveConfig(flags)
+					if err != nil {
+						return nil, err
+					}
+					return json.MarshalIndent(cfg, "", "  ")
+				},
+				g.queueDepths,
+				g.logLevels,
+				g.watcherConfigs,
+				auditor,
 			)
 			if err != nil {
 				return fmt.Errorf("failed to create admin service: %w", err)
 			}
 			g.runnables["admin"] = adminService
+			g.adminService = nodeService
+			g.adminAuditor = auditor
+
+			return nil
+		}}
+}
+
+// GuardianOptionAdminServiceTCP additionally serves the admin service on TCP, for remote tooling. Clients must present one of the
+// client certificates pinned in cfg.ClientsFile and may only call the methods allowed for it, see adminrpc.TLSClients.
+// Dependencies: admin-service
+func GuardianOptionAdminServiceTCP(cfg adminrpc.TCPConfig, ipMode common.IPMode) *GuardianOption {
+	return &GuardianOption{
+		name:         "admin-service-tcp",
+		dependencies: []string{"admin-service"},
+		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
+			cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+			if err != nil {
+				return fmt.Errorf("failed to load admin service certificate: %w", err)
+			}
+			clients, err := adminrpc.LoadTLSClients(cfg.ClientsFile)
+			if err != nil {
+				return fmt.Errorf("failed to load admin service clients: %w", err)
+			}
+			auth, err := adminrpc.NewTLSAuthorizer(logger.Named("adminservice"), clients)
+			if err != nil {
+				return fmt.Errorf("invalid admin service clients: %w", err)
+			}
 
+			g.runnables["admin-tcp"] = adminServiceTCPRunnable(logger, cfg.ListenAddr, ipMode, auth.ServerTLSConfig(cert), auth, g.adminAuditor, g.adminService)
 			return nil
 		}}
 }
@@ -344,7 +741,7 @@ func GuardianOptionPublicRpcSocket(publicGRPCSocketPath string, publicRpcLogDeta
 		dependencies: []string{"db", "governor"},
 		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
 			// local public grpc service socket
-			publicrpcUnixService, publicrpcServer, err := publicrpcUnixServiceRunnable(logger, publicGRPCSocketPath, publicRpcLogDetail, g.db, g.gst, g.gov)
+			publicrpcUnixService, publicrpcServer, err := publicrpcUnixServiceRunnable(logger, publicGRPCSocketPath, publicRpcLogDetail, g.db, g.gst, g.gov, g.publicRateLimiter, g.publicSlowRequestThreshold)
 			if err != nil {
 				return fmt.Errorf("failed to create publicrpc service: %w", err)
 			}
@@ -355,14 +752,52 @@ func GuardianOptionPublicRpcSocket(publicGRPCSocketPath string, publicRpcLogDeta
 		}}
 }
 
-// GuardianOptionPublicrpcTcpService enables the public gRPC service on TCP.
+// GuardianOptionPublicRateLimit limits the rate at which each client IP may call the public RPC on TCP and on publicweb, see
+// publicrpc.RateLimiter. It must be configured before the public RPC services.
+// Dependencies: none
+func GuardianOptionPublicRateLimit(cfg publicrpc.RateLimitConfig) *GuardianOption {
+	return &GuardianOption{
+		name: "public-rate-limit",
+		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
+			if g.publicrpcServer != nil {
+				return errors.New("the public rate limit must be configured before the public RPC services")
+			}
+			limiter, err := publicrpc.NewRateLimiter(cfg)
+			if err != nil {
+				return fmt.Errorf("invalid public rate limit: %w", err)
+			}
+			g.publicRateLimiter = limiter
+			return nil
+		}}
+}
+
+// GuardianOptionPublicSlowRequestLog logs the unary public RPC calls which take longer than threshold, on TCP and on the unix socket.
+// It must be configured before the public RPC services.
+// Dependencies: none
+func GuardianOptionPublicSlowRequestLog(threshold time.Duration) *GuardianOption {
+	return &GuardianOption{
+		name: "public-slow-request-log",
+		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
+			if g.publicrpcServer != nil {
+				return errors.New("the public slow request log must be configured before the public RPC services")
+			}
+			if threshold <= 0 {
+				return fmt.Errorf("invalid slow request threshold %s, must be positive", threshold)
+			}
+			g.publicSlowRequestThreshold = threshold
+			return nil
+		}}
+}
+
+// GuardianOptionPublicrpcTcpService enables the public gRPC service on TCP, together with the gRPC health service and, if reflection
+// is set, server reflection.
 // Dependencies: db, governor, publicrpcsocket
-func GuardianOptionPublicrpcTcpService(publicRpc string, publicRpcLogDetail common.GrpcLogDetail) *GuardianOption {
+func GuardianOptionPublicrpcTcpService(publicRpc string, publicRpcLogDetail common.GrpcLogDetail, ipMode common.IPMode, reflection bool) *GuardianOption {
 	return &GuardianOption{
 		name:         "publicrpc",
 		dependencies: []string{"db", "governor", "publicrpcsocket"},
 		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
-			publicrpcService := publicrpcTcpServiceRunnable(logger, publicRpc, publicRpcLogDetail, g.db, g.gst, g.gov)
+			publicrpcService := publicrpcTcpServiceRunnable(logger, publicRpc, ipMode, publicRpcLogDetail, g.db, g.gst, g.gov, g.publicRateLimiter, g.publicSlowRequestThreshold, reflection)
 			g.runnables["publicrpc"] = publicrpcService
 			return nil
 		}}
@@ -370,40 +805,345 @@ func GuardianOptionPublicrpcTcpService(publicRpc string, publicRpcLogDetail comm
 
 // GuardianOptionPublicWeb enables the public rpc service on http, i.e. gRPC-web and JSON-web.
 // Dependencies: db, governor, publicrpcsocket
-func GuardianOptionPublicWeb(listenAddr string, publicGRPCSocketPath string, tlsHostname string, tlsProdEnv bool, tlsCacheDir string) *GuardianOption {
+func GuardianOptionPublicWeb(listenAddr string, publicGRPCSocketPath string, tlsHostname string, tlsProdEnv bool, tlsCacheDir string, ipMode common.IPMode) *GuardianOption {
 	return &GuardianOption{
 		name:         "publicweb",
 		dependencies: []string{"db", "governor", "publicrpcsocket"},
 		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
-			publicwebService := publicwebServiceRunnable(logger, listenAddr, publicGRPCSocketPath, g.publicrpcServer,
-				tlsHostname, tlsProdEnv, tlsCacheDir)
+			publicwebService := publicwebServiceRunnable(logger, listenAddr, ipMode, publicGRPCSocketPath, g.publicrpcServer,
+				tlsHostname, tlsProdEnv, tlsCacheDir, g.publicRateLimiter)
 			g.runnables["publicweb"] = publicwebService
 			return nil
 		}}
 }
 
-// GuardianOptionDatabase configures the main database to be used for this guardian node.
+// GuardianOptionDatabase configures the main database to be used for this guardian node. The database may be stored in any of
+// the db.Backends, see db.OpenDb. Badger databases on disk are also garbage collected periodically, see db.BadgerOptions.
 // Dependencies: none
 func GuardianOptionDatabase(db *db.Database) *GuardianOption {
 	return &GuardianOption{
 		name: "db",
 		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
 			g.db = db
+			if db == nil {
+				return nil
+			}
+			if gc := db.GCRunnable(); gc != nil {
+				g.runnables["dbgc"] = gc
+			}
+			return nil
+		}}
+}
+
+// GuardianOptionStateDatabase keeps the governor and accountant state in stateDb instead of the main database, see db.StateDatabase.
+// The state must have been moved to stateDb already, see db.Database.MoveState. It must be configured before the governor and the
+// accountants.
+// Dependencies: db
+func GuardianOptionStateDatabase(stateDb *db.Database) *GuardianOption {
+	return &GuardianOption{
+		name:         "state-db",
+		dependencies: []string{"db"},
+		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
+			if g.gov != nil || g.acct != nil || g.nttAcct != nil {
+				return errors.New("the state database must be configured before the governor and the accountants")
+			}
+			if stateDb == g.db {
+				return errors.New("the state database must not be the main database")
+			}
+			g.stateDb = stateDb
+			if gc := stateDb.GCRunnable(); gc != nil {
+				g.runnables["state-dbgc"] = gc
+			}
+			return nil
+		}}
+}
+
+// GuardianOptionDatabaseMetrics refreshes the database size and keyspace metrics every interval, see db.Database.UpdateMetrics.
+// Dependencies: db
+func GuardianOptionDatabaseMetrics(interval time.Duration) *GuardianOption {
+	return &GuardianOption{
+		name:         "db-metrics",
+		dependencies: []string{"db"},
+		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
+			g.runnables["db-metrics"] = g.db.MetricsRunnable(logger.Named("dbmetrics"), interval)
+			if g.stateDb != nil {
+				g.runnables["state-db-metrics"] = g.stateDb.MetricsRunnable(logger.Named("statedbmetrics"), interval)
+			}
+			return nil
+		}}
+}
+
+// GuardianOptionDatabaseRetention deletes the transient records of each class once they are older than the retention of their
+// class, see db.RecordClass. Records of classes without a retention are kept forever. The records of the state database are swept
+// separately, so the sweeper of the main database never reads the governor and accountant state.
+// Dependencies: db
+func GuardianOptionDatabaseRetention(retention map[db.RecordClass]time.Duration) *GuardianOption {
+	return &GuardianOption{
+		name:         "db-retention",
+		dependencies: []string{"db"},
+		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
+			if sweeper := g.db.SweeperRunnable(logger.Named("dbsweeper"), retention, db.DefaultSweepInterval); sweeper != nil {
+				g.runnables["db-sweeper"] = sweeper
+			}
+			if g.stateDb != nil {
+				if sweeper := g.stateDb.SweeperRunnable(logger.Named("statedbsweeper"), retention, db.DefaultSweepInterval); sweeper != nil {
+					g.runnables["state-db-sweeper"] = sweeper
+				}
+			}
+			return nil
+		}}
+}
+
+// GuardianOptionDatabaseReplication streams the signed VAAs stored in the database to read-only replicas connecting to listenAddr
+// over TLS with tlsConfig, see dbreplication.ServerTLSConfig and dbreplication.Replica. Unless tlsConfig requires client certificates,
+// anyone who can connect can read every signed VAA, so the address should only be reachable by the replicas.
+// Dependencies: db
+func GuardianOptionDatabaseReplication(listenAddr string, tlsConfig *tls.Config, ipMode common.IPMode) *GuardianOption {
+	return &GuardianOption{
+		name:         "db-replication",
+		dependencies: []string{"db"},
+		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
+			g.runnables["db-replication"] = dbReplicationServiceRunnable(logger.Named("dbreplication"), listenAddr, ipMode, tlsConfig, g.db)
+			return nil
+		}}
+}
+
+// GuardianOptionDatabaseReplica makes the database a read-only replica of the database of the guardian whose replication service
+// listens on source, which it connects to with tlsConfig. Only the VAAs signed by a quorum of their guardian set in guardianSets are
+// stored. The node becomes ready once the replica has caught up with the source.
+// Dependencies: db
+func GuardianOptionDatabaseReplica(source string, tlsConfig *tls.Config, guardianSets map[uint32]*common.GuardianSet, resyncLookback time.Duration) *GuardianOption {
+	return &GuardianOption{
+		name:         "db-replica",
+		dependencies: []string{"db"},
+		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
+			if g.db == nil {
+				return errors.New("a database replica requires a database")
+			}
+			readiness.RegisterComponent(dbreplication.ReadinessComponent)
+			g.runnables["db-replica"] = dbreplication.NewReplica(logger.Named("dbreplica"), g.db, source, tlsConfig, guardianSets, resyncLookback).Run
+			return nil
+		}}
+}
+
+// GuardianOptionVAAArchive moves signed VAAs older than the retention period out of the database into archive segments.
+// Dependencies: db
+func GuardianOptionVAAArchive(cfg vaaarchive.Config) *GuardianOption {
+	return &GuardianOption{
+		name:         "vaa-archive",
+		dependencies: []string{"db"},
+		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
+			archiver, err := vaaarchive.NewArchiver(logger.Named("vaaarchive"), g.db, cfg)
+			if err != nil {
+				return fmt.Errorf("failed to create VAA archiver: %w", err)
+			}
+			logger.Info("archiving VAAs",
+				zap.String("dir", cfg.Dir),
+				zap.Duration("retention", cfg.Retention),
+				zap.Bool("upload", cfg.UploadURL != ""),
+			)
+			g.vaaArchiver = archiver
+			g.runnables["vaa-archive"] = archiver.Run
+			return nil
+		}}
+}
+
+// GuardianOptionDatabaseBackup backs up the database every cfg.Interval, optionally uploading the backups to object storage. Only
+// Badger databases can be backed up.
+// Dependencies: db
+func GuardianOptionDatabaseBackup(cfg dbbackup.Config) *GuardianOption {
+	return &GuardianOption{
+		name:         "db-backup",
+		dependencies: []string{"db"},
+		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
+			run, err := databaseBackupRunnable(logger.Named("dbbackup"), g.db, cfg)
+			if err != nil {
+				return err
+			}
+			g.runnables["db-backup"] = run
+			return nil
+		}}
+}
+
+// GuardianOptionStateDatabaseBackup backs up the state database on its own schedule, see GuardianOptionDatabaseBackup. cfg must not
+// share its directory or upload URL with the backups of the main database.
+// Dependencies: state-db
+func GuardianOptionStateDatabaseBackup(cfg dbbackup.Config) *GuardianOption {
+	return &GuardianOption{
+		name:         "state-db-backup",
+		dependencies: []string{"state-db"},
+		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
+			run, err := databaseBackupRunnable(logger.Named("statedbbackup"), g.stateDb, cfg)
+			if err != nil {
+				return err
+			}
+			g.runnables["state-db-backup"] = run
+			return nil
+		}}
+}
+
+func databaseBackupRunnable(logger *zap.Logger, database *db.Database, cfg dbbackup.Config) (supervisor.Runnable, error) {
+	scheduler, err := dbbackup.NewScheduler(logger, database, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s database backup scheduler: %w", database.Name(), err)
+	}
+	logger.Info("backing up the database",
+		zap.String("db", database.Name()),
+		zap.String("dir", cfg.Dir),
+		zap.Duration("interval", cfg.Interval),
+		zap.Duration("retention", cfg.Retention),
+		zap.Bool("upload", cfg.UploadURL != ""),
+	)
+	return scheduler.Run, nil
+}
+
+// GuardianOptionAggregatedSignatures makes the processor produce experimental BLS aggregated attestations alongside VAAs, using a
+// BLS key derived from the guardian key. It must be configured before p2p and the processor, and is only allowed on devnet.
+func GuardianOptionAggregatedSignatures() *GuardianOption {
+	return &GuardianOption{
+		name: "aggregated-signatures",
+		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
+			if g.env != common.UnsafeDevNet && g.env != common.GoTest {
+				return errors.New("aggregated signatures are experimental and only allowed on devnet")
+			}
+			if g.processor != nil || g.runnables["p2p"] != nil {
+				return errors.New("aggregated signatures must be configured before p2p and the processor")
+			}
+			g.aggSigKey = aggsig.DeriveSecretKey(g.gk)
+			logger.Warn("experimental aggregated signatures enabled",
+				zap.String("publicKey", hex.EncodeToString(g.aggSigKey.PublicKey().Marshal())))
+			return nil
+		}}
+}
+
+// GuardianOptionRecording makes the processor record the guardian set updates, watcher messages and gossip it handles to a file
+// at path, which `guardiand replay` can feed through a processor again. The file is appended to if it exists. It must be configured
+// before the processor, and is only allowed on devnet.
+func GuardianOptionRecording(path string) *GuardianOption {
+	return &GuardianOption{
+		name: "recording",
+		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
+			if g.env != common.UnsafeDevNet && g.env != common.GoTest {
+				return errors.New("recording is only allowed on devnet")
+			}
+			if g.processor != nil {
+				return errors.New("recording must be configured before the processor")
+			}
+			f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+			if err != nil {
+				return fmt.Errorf("failed to open recording: %w", err)
+			}
+			go func() {
+				<-ctx.Done()
+				f.Close()
+			}()
+			g.recorder = replay.NewWriter(f)
+			logger.Info("recording processor inputs", zap.String("path", path))
+			return nil
+		}}
+}
+
+// GuardianOptionNearQuorumAlert makes the processor report observations that have been one signature short of quorum for longer
+// than threshold, along with the guardians whose signatures are missing.
+// It must be applied before GuardianOptionProcessor.
+// Dependencies: none
+func GuardianOptionNearQuorumAlert(threshold time.Duration) *GuardianOption {
+	return &GuardianOption{
+		name: "near-quorum-alert",
+		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
+			if g.processor != nil {
+				return errors.New("the near quorum alert must be configured before the processor")
+			}
+			if threshold <= 0 {
+				return errors.New("the near quorum alert threshold must be positive")
+			}
+			g.nearQuorumThreshold = threshold
+			return nil
+		}}
+}
+
+// GuardianOptionLateObservationWindow makes the processor add signatures that arrive within window after a VAA reached quorum to
+// the stored VAA, which publicrpc then serves with the additional signatures.
+// It must be applied before GuardianOptionProcessor.
+// Dependencies: none
+func GuardianOptionLateObservationWindow(window time.Duration) *GuardianOption {
+	return &GuardianOption{
+		name: "late-observation-window",
+		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
+			if g.processor != nil {
+				return errors.New("the late observation window must be configured before the processor")
+			}
+			if window <= 0 || window > processor.LateObservationWindowMax {
+				return fmt.Errorf("the late observation window must be positive and at most %s", processor.LateObservationWindowMax)
+			}
+			g.lateObservationWindow = window
+			return nil
+		}}
+}
+
+// GuardianOptionShadowMode runs the node as a shadow guardian. Its watchers and processor run as usual and it aggregates the
+// signatures of the guardian set, but it never broadcasts its own signatures, VAAs or re-observation requests. This lets a
+// prospective guardian, or an operator monitoring the network, check that their setup observes the same messages as the
+// guardian set without taking part in consensus.
+// It must be applied before GuardianOptionProcessor.
+// Dependencies: none
+func GuardianOptionShadowMode() *GuardianOption {
+	return &GuardianOption{
+		name: "shadow-mode",
+		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
+			if g.processor != nil {
+				return errors.New("shadow mode must be configured before the processor")
+			}
+			g.shadow = true
+			return nil
+		}}
+}
+
+// GuardianOptionReobservationPolicy configures when the processor requests re-observation of messages that do not reach quorum,
+// with per chain overrides in cfg.Chains. Without this option, the processor uses processor.DefaultReobservationPolicy for all chains.
+// It must be applied before GuardianOptionProcessor.
+// Dependencies: none
+func GuardianOptionReobservationPolicy(cfg *processor.ReobservationConfig) *GuardianOption {
+	return &GuardianOption{
+		name: "reobservation-policy",
+		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
+			if g.processor != nil {
+				return errors.New("the re-observation policy must be configured before the processor")
+			}
+			if err := cfg.Default.Validate(); err != nil {
+				return fmt.Errorf("invalid default re-observation policy: %w", err)
+			}
+			for chainID, policy := range cfg.Chains {
+				if err := policy.Validate(); err != nil {
+					return fmt.Errorf("invalid re-observation policy for chain %s: %w", chainID, err)
+				}
+				logger.Info("using chain specific re-observation policy",
+					zap.Stringer("chain", chainID),
+					zap.Duration("initialDelay", policy.InitialDelay),
+					zap.Float64("backoff", policy.Backoff),
+					zap.Uint("maxAttempts", policy.MaxAttempts),
+				)
+			}
+			g.reobservation = cfg
 			return nil
 		}}
 }
 
 // GuardianOptionProcessor enables the default processor, which is required to make consensus on messages.
-// Dependencies: db, governor, accountant
-func GuardianOptionProcessor() *GuardianOption {
+// Messages from chains in observationDelays are held for the given duration before they are signed. Signatures on observations from
+// other guardians are verified by sigVerifyWorkers goroutines in parallel, or on the processor goroutine if it is zero. Observations
+// are then aggregated by aggregationShards goroutines, each owning the observations of a share of the emitters, or on the processor
+// goroutine if it is zero.
+// Dependencies: db, governor, accountant, ntt-accountant, gateway-relayer
+func GuardianOptionProcessor(observationDelays map[vaa.ChainID]time.Duration, sigVerifyWorkers int, aggregationShards int) *GuardianOption {
 	return &GuardianOption{
 		name: "processor",
-		// governor and accountant may be set to nil, but that choice needs to be made before the processor is configured
-		dependencies: []string{"db", "governor", "accountant", "gateway-relayer"},
+		// governor, accountants and gateway relayer may be set to nil, but that choice needs to be made before the processor is configured
+		dependencies: []string{"db", "governor", "accountant", "ntt-accountant", "gateway-relayer"},
 
 		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
 
-			g.runnables["processor"] = processor.NewProcessor(ctx,
+			g.processor = processor.NewProcessor(ctx,
 				g.db,
 				g.msgC.readC,
 				g.setC.readC,
@@ -414,8 +1154,78 @@ func GuardianOptionProcessor() *GuardianOption {
 				g.gk,
 				g.gst,
 				g.gov,
-			).Run
+				g.acct,
+				g.nttAcct,
+				g.acctC.readC,
+				g.gatewayRelayer,
+				g.govStatus,
+				g.conflicts,
+				observationDelays,
+				sigVerifyWorkers,
+				aggregationShards,
+				g.reobservation,
+				g.nearQuorumThreshold,
+				g.lateObservationWindow,
+				g.shadow,
+				clock.New(),
+				g.recorder,
+				g.aggSigKey,
+			)
+			g.runnables["processor"] = g.processor.Run
+
+			return nil
+		}}
+}
 
+// GuardianOptionQueryP2P joins only the CCQ p2p network, for nodes which serve cross chain queries without taking part in consensus.
+// It must not be combined with GuardianOptionP2P, which joins the CCQ network itself when the query handler is enabled.
+// Dependencies: query
+func GuardianOptionQueryP2P(p2pKey libp2p_crypto.PrivKey, networkId string, ccqBootstrapPeers string, ccqPort uint, ccqAllowedPeers string, ipMode common.IPMode) *GuardianOption {
+	return &GuardianOption{
+		name:         "query-p2p",
+		dependencies: []string{"query"},
+		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
+			if g.queryHandler == nil {
+				return fmt.Errorf("the query handler must be enabled to join the CCQ network")
+			}
+
+			g.runnables["ccqp2p"] = p2p.RunCcq(
+				p2pKey,
+				g.gk,
+				networkId,
+				ccqBootstrapPeers,
+				ccqPort,
+				ccqAllowedPeers,
+				ipMode,
+				g.signedQueryReqC.writeC,
+				g.queryResponsePublicationC.readC,
+			)
+
+			return nil
+		}}
+}
+
+// GuardianOptionDiscardObservations drains the messages and guardian set updates produced by the watchers, which would otherwise
+// block them. It takes the place of the processor on nodes that only serve cross chain queries, and is registered under the same
+// name so that the two cannot be configured together.
+// Dependencies: none
+func GuardianOptionDiscardObservations() *GuardianOption {
+	return &GuardianOption{
+		name: "processor",
+		f: func(ctx context.Context, logger *zap.Logger, g *G) error {
+			g.runnables["observation-sink"] = func(ctx context.Context) error {
+				supervisor.Signal(ctx, supervisor.SignalHealthy)
+				for {
+					select {
+					case <-ctx.Done():
+						return nil
+					case msg := <-g.msgC.readC:
+						logger.Debug("discarding observation", zap.String("msgId", msg.MessageIDString()))
+					case gs := <-g.setC.readC:
+						logger.Debug("discarding guardian set update", zap.Uint32("index", gs.Index))
+					}
+				}
+			}
 			return nil
 		}}
 }
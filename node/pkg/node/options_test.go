@@ -0,0 +1,206 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	"github.com/certusone/wormhole/node/pkg/processor"
+	"github.com/certusone/wormhole/node/pkg/supervisor"
+	"github.com/certusone/wormhole/node/pkg/watchers"
+	"github.com/certusone/wormhole/node/pkg/watchers/interfaces"
+	"github.com/certusone/wormhole/node/pkg/watchers/solana"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+func TestQueryP2PRequiresQueryHandler(t *testing.T) {
+	g := NewGuardianNode(common.GoTest, nil)
+	g.initializeBasic(func() {})
+
+	err := g.applyOptions(context.Background(), zap.NewNop(), []*GuardianOption{
+		GuardianOptionQueryHandler(false, "", 0),
+		GuardianOptionQueryP2P(nil, "/wormhole/test", "", 0, "", common.IPModeDual),
+	})
+	assert.ErrorContains(t, err, "query handler must be enabled")
+}
+
+func TestDiscardObservationsExcludesProcessor(t *testing.T) {
+	g := NewGuardianNode(common.GoTest, nil)
+	g.initializeBasic(func() {})
+
+	err := g.applyOptions(context.Background(), zap.NewNop(), []*GuardianOption{
+		GuardianOptionDiscardObservations(),
+		GuardianOptionProcessor(nil, 0, 0),
+	})
+	require.Error(t, err)
+	assert.Contains(t, g.runnables, "observation-sink")
+	assert.NotContains(t, g.runnables, "processor")
+}
+
+func TestReobservationPolicyOption(t *testing.T) {
+	cfg := &processor.ReobservationConfig{Default: processor.DefaultReobservationPolicy()}
+
+	g := NewGuardianNode(common.GoTest, nil)
+	g.initializeBasic(func() {})
+	err := g.applyOptions(context.Background(), zap.NewNop(), []*GuardianOption{
+		GuardianOptionReobservationPolicy(cfg),
+	})
+	require.NoError(t, err)
+	assert.Same(t, cfg, g.reobservation)
+
+	invalid := &processor.ReobservationConfig{
+		Default: processor.DefaultReobservationPolicy(),
+		Chains:  map[vaa.ChainID]processor.ReobservationPolicy{vaa.ChainIDSolana: {InitialDelay: time.Minute}},
+	}
+	g = NewGuardianNode(common.GoTest, nil)
+	g.initializeBasic(func() {})
+	err = g.applyOptions(context.Background(), zap.NewNop(), []*GuardianOption{
+		GuardianOptionReobservationPolicy(invalid),
+	})
+	assert.ErrorContains(t, err, "invalid re-observation policy for chain solana")
+}
+
+func TestLateObservationWindowOption(t *testing.T) {
+	g := NewGuardianNode(common.GoTest, nil)
+	g.initializeBasic(func() {})
+	err := g.applyOptions(context.Background(), zap.NewNop(), []*GuardianOption{
+		GuardianOptionLateObservationWindow(time.Minute),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, g.lateObservationWindow)
+
+	g = NewGuardianNode(common.GoTest, nil)
+	g.initializeBasic(func() {})
+	err = g.applyOptions(context.Background(), zap.NewNop(), []*GuardianOption{
+		GuardianOptionLateObservationWindow(2 * processor.LateObservationWindowMax),
+	})
+	assert.ErrorContains(t, err, "late observation window must be positive")
+}
+
+func TestRecordingOption(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "recording.jsonl")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	g := NewGuardianNode(common.GoTest, nil)
+	g.initializeBasic(func() {})
+	err := g.applyOptions(ctx, zap.NewNop(), []*GuardianOption{
+		GuardianOptionRecording(path),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, g.recorder)
+	assert.FileExists(t, path)
+
+	g = NewGuardianNode(common.MainNet, nil)
+	g.initializeBasic(func() {})
+	err = g.applyOptions(ctx, zap.NewNop(), []*GuardianOption{
+		GuardianOptionRecording(path),
+	})
+	assert.ErrorContains(t, err, "recording is only allowed on devnet")
+}
+
+func TestGovernorConfigFileOption(t *testing.T) {
+	g := NewGuardianNode(common.GoTest, nil)
+	g.initializeBasic(func() {})
+	err := g.applyOptions(context.Background(), zap.NewNop(), []*GuardianOption{
+		GuardianOptionDatabase(nil),
+		GuardianOptionGovernor(false),
+		GuardianOptionGovernorConfigFile("governor.json", nil),
+	})
+	assert.ErrorContains(t, err, "a governor config file requires the governor to be enabled")
+}
+
+func TestGovernorPriceOracleOption(t *testing.T) {
+	g := NewGuardianNode(common.GoTest, nil)
+	g.initializeBasic(func() {})
+	err := g.applyOptions(context.Background(), zap.NewNop(), []*GuardianOption{
+		GuardianOptionDatabase(nil),
+		GuardianOptionWatchers(nil),
+		GuardianOptionGovernor(true),
+		GuardianOptionGovernorPriceOracle(false),
+	})
+	assert.ErrorContains(t, err, "the price oracle requires a finalized Solana watcher")
+}
+
+func TestShadowModeOption(t *testing.T) {
+	g := NewGuardianNode(common.GoTest, nil)
+	g.initializeBasic(func() {})
+	err := g.applyOptions(context.Background(), zap.NewNop(), []*GuardianOption{
+		GuardianOptionShadowMode(),
+	})
+	require.NoError(t, err)
+	assert.True(t, g.shadow)
+}
+
+func TestStateDatabaseOption(t *testing.T) {
+	stateDb, err := db.OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer stateDb.Close()
+
+	g := NewGuardianNode(common.GoTest, nil)
+	g.initializeBasic(func() {})
+	err = g.applyOptions(context.Background(), zap.NewNop(), []*GuardianOption{
+		GuardianOptionDatabase(nil),
+		GuardianOptionGovernor(true),
+		GuardianOptionStateDatabase(stateDb),
+	})
+	assert.ErrorContains(t, err, "the state database must be configured before the governor and the accountants")
+}
+
+func TestEffectiveConfig(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	g := NewGuardianNode(common.GoTest, nil)
+	g.initializeBasic(func() {})
+	require.NoError(t, g.applyOptions(ctx, zap.NewNop(), []*GuardianOption{
+		GuardianOptionDatabase(nil),
+		GuardianOptionWatchers([]watchers.WatcherConfig{&solana.WatcherConfig{
+			NetworkID:  "solana-finalized",
+			ChainID:    vaa.ChainIDSolana,
+			Rpc:        "https://solana.example.com/?api-key=secret",
+			Contract:   "worm2ZoG2kUd4vFXhvjh93UUH596ayRfgQ2MgjNMTth",
+			Commitment: rpc.CommitmentFinalized,
+		}}),
+		GuardianOptionGovernor(false),
+	}))
+
+	cfg, err := g.EffectiveConfig(map[string]string{"statusAddr": "[::]:6060"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"db", "watchers", "governor"}, cfg.Options)
+	assert.Equal(t, "[::]:6060", cfg.Flags["statusAddr"])
+	assert.Nil(t, cfg.Governor)
+	assert.Nil(t, cfg.Accountant)
+	require.Len(t, cfg.Watchers, 1)
+	assert.Equal(t, vaa.ChainIDSolana, cfg.Watchers[0].ChainID)
+	assert.Equal(t, "https://solana.example.com/<redacted>", cfg.Watchers[0].Config.(map[string]interface{})["Rpc"])
+	assert.NotEmpty(t, cfg.Build.Version)
+}
+
+func TestRebuildOnRestart(t *testing.T) {
+	var ran []string
+	newRunnable := func(name string) supervisor.Runnable {
+		return func(ctx context.Context) error {
+			ran = append(ran, name)
+			return nil
+		}
+	}
+	builds := 0
+	runnable := rebuildOnRestart(newRunnable("startup"), func() (interfaces.L1Finalizer, supervisor.Runnable, error) {
+		builds++
+		return nil, newRunnable(fmt.Sprintf("rebuilt %d", builds)), nil
+	})
+
+	// The watcher built at startup runs first, and a new one is built from the current config on every restart.
+	for i := 0; i < 3; i++ {
+		require.NoError(t, runnable(context.Background()))
+	}
+	assert.Equal(t, []string{"startup", "rebuilt 1", "rebuilt 2"}, ran)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"time"
 
 	"github.com/certusone/wormhole/node/pkg/common"
 	"github.com/certusone/wormhole/node/pkg/db"
@@ -14,11 +15,14 @@ import (
 	"github.com/certusone/wormhole/node/pkg/supervisor"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	grpcreflection "google.golang.org/grpc/reflection"
 )
 
-func publicrpcTcpServiceRunnable(logger *zap.Logger, listenAddr string, publicRpcLogDetail common.GrpcLogDetail, db *db.Database, gst *common.GuardianSetState, gov *governor.ChainGovernor) supervisor.Runnable {
+func publicrpcTcpServiceRunnable(logger *zap.Logger, listenAddr string, ipMode common.IPMode, publicRpcLogDetail common.GrpcLogDetail, db *db.Database, gst *common.GuardianSetState, gov *governor.ChainGovernor, limiter *publicrpc.RateLimiter, slowRequestThreshold time.Duration, reflection bool) supervisor.Runnable {
 	return func(ctx context.Context) error {
-		l, err := net.Listen("tcp", listenAddr)
+		l, err := common.ListenTCP(ipMode, listenAddr)
 
 		if err != nil {
 			return fmt.Errorf("failed to listen: %w", err)
@@ -27,20 +31,55 @@ func publicrpcTcpServiceRunnable(logger *zap.Logger, listenAddr string, publicRp
 		logger.Info("publicrpc server listening", zap.String("addr", l.Addr().String()))
 
 		rpcServer := publicrpc.NewPublicrpcServer(logger, db, gst, gov)
-		grpcServer := common.NewInstrumentedGRPCServer(logger, publicRpcLogDetail)
+		opts := publicrpc.MetricsServerOptions(logger, publicrpc.ListenerTCP, slowRequestThreshold)
+		if limiter != nil {
+			opts = append(opts, limiter.ServerOptions()...)
+		}
+		grpcServer := common.NewInstrumentedGRPCServer(logger, publicRpcLogDetail, opts...)
 
 		publicrpcv1.RegisterPublicRPCServiceServer(grpcServer, rpcServer)
+		healthServer := health.NewServer()
+		healthpb.RegisterHealthServer(grpcServer, healthServer)
+		if reflection {
+			grpcreflection.Register(grpcServer)
+		}
 
 		if err := supervisor.Run(ctx, "grpcserver", supervisor.GRPCServer(grpcServer, l, false)); err != nil {
 			return err
 		}
 
-		<-ctx.Done()
+		watchPublicrpcHealth(ctx, gst, healthServer)
 		return nil
 	}
 }
 
-func publicrpcUnixServiceRunnable(logger *zap.Logger, socketPath string, publicRpcLogDetail common.GrpcLogDetail, db *db.Database, gst *common.GuardianSetState, gov *governor.ChainGovernor) (supervisor.Runnable, *grpc.Server, error) {
+// publicrpcHealthCheckInterval is how often the health of the public RPC is checked until it is serving.
+const publicrpcHealthCheckInterval = time.Second
+
+// watchPublicrpcHealth reports the public RPC as not serving to gRPC health checks until the guardian set is known, since most of its
+// methods fail before, and as serving from then on until ctx is done.
+func watchPublicrpcHealth(ctx context.Context, gst *common.GuardianSetState, healthServer *health.Server) {
+	setStatus := func(status healthpb.HealthCheckResponse_ServingStatus) {
+		healthServer.SetServingStatus("", status)
+		healthServer.SetServingStatus(publicrpcv1.PublicRPCService_ServiceDesc.ServiceName, status)
+	}
+	setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	defer healthServer.Shutdown()
+
+	ticker := time.NewTicker(publicrpcHealthCheckInterval)
+	defer ticker.Stop()
+	for gst.Get() == nil {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+	setStatus(healthpb.HealthCheckResponse_SERVING)
+	<-ctx.Done()
+}
+
+func publicrpcUnixServiceRunnable(logger *zap.Logger, socketPath string, publicRpcLogDetail common.GrpcLogDetail, db *db.Database, gst *common.GuardianSetState, gov *governor.ChainGovernor, limiter *publicrpc.RateLimiter, slowRequestThreshold time.Duration) (supervisor.Runnable, *grpc.Server, error) {
 	// Delete existing UNIX socket, if present.
 	fi, err := os.Stat(socketPath)
 	if err == nil {
@@ -74,7 +113,12 @@ func publicrpcUnixServiceRunnable(logger *zap.Logger, socketPath string, publicR
 
 	publicrpcService := publicrpc.NewPublicrpcServer(logger, db, gst, gov)
 
-	grpcServer := common.NewInstrumentedGRPCServer(logger, publicRpcLogDetail)
+	// Local callers of the unix socket are not rate limited, but grpc-web calls from publicweb, which this server handles, are.
+	opts := publicrpc.MetricsServerOptions(logger, publicrpc.ListenerSocket, slowRequestThreshold)
+	if limiter != nil {
+		opts = append(opts, limiter.ServerOptions()...)
+	}
+	grpcServer := common.NewInstrumentedGRPCServer(logger, publicRpcLogDetail, opts...)
 	publicrpcv1.RegisterPublicRPCServiceServer(grpcServer, publicrpcService)
 	return supervisor.GRPCServer(grpcServer, l, false), grpcServer, nil
 }
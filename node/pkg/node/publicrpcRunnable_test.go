@@ -0,0 +1,47 @@
+package node
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	publicrpcv1 "github.com/certusone/wormhole/node/pkg/proto/publicrpc/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestWatchPublicrpcHealth(t *testing.T) {
+	gst := common.NewGuardianSetState(nil)
+	healthServer := health.NewServer()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		watchPublicrpcHealth(ctx, gst, healthServer)
+		close(done)
+	}()
+
+	status := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := healthServer.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		return resp.Status
+	}
+
+	// The public RPC is not serving until the guardian set is known.
+	require.Eventually(t, func() bool {
+		return status(publicrpcv1.PublicRPCService_ServiceDesc.ServiceName) == healthpb.HealthCheckResponse_NOT_SERVING
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, status(""))
+
+	gst.Set(&common.GuardianSet{Index: 0})
+	require.Eventually(t, func() bool {
+		return status("") == healthpb.HealthCheckResponse_SERVING
+	}, 3*publicrpcHealthCheckInterval, 10*time.Millisecond)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, status(publicrpcv1.PublicRPCService_ServiceDesc.ServiceName))
+
+	cancel()
+	<-done
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, status(""))
+}
@@ -3,12 +3,16 @@ package node
 import (
 	"context"
 	"fmt"
+	"math"
 	"net"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/certusone/wormhole/node/pkg/common"
 	publicrpcv1 "github.com/certusone/wormhole/node/pkg/proto/publicrpc/v1"
+	"github.com/certusone/wormhole/node/pkg/publicrpc"
 	"github.com/certusone/wormhole/node/pkg/supervisor"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/improbable-eng/grpc-web/go/grpcweb"
@@ -47,32 +51,60 @@ func corsPreflightHandler(w http.ResponseWriter) {
 	w.Header().Set("Access-Control-Allow-Methods", strings.Join(methods, ","))
 }
 
+// retryAfterErrorHandler is the default error handler of the gateway, which answers rate limited calls with 429 Too Many Requests,
+// but also tells clients when to retry with a Retry-After header.
+func retryAfterErrorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	if retryable, delay := common.IsRetryableGrpcError(err); retryable && delay > 0 {
+		w.Header().Set("Retry-After", strconv.FormatInt(int64(math.Ceil(delay.Seconds())), 10))
+	}
+	runtime.DefaultHTTPErrorHandler(ctx, mux, marshaler, w, r, err)
+}
+
+// openAPISpecPath is where publicweb serves the OpenAPI specification of its REST API.
+const openAPISpecPath = "/v1/openapi.json"
+
+func serveOpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(publicrpc.OpenAPISpec)
+}
+
 func publicwebServiceRunnable(
 	logger *zap.Logger,
 	listenAddr string,
+	ipMode common.IPMode,
 	upstreamAddr string,
 	grpcServer *grpc.Server,
 	tlsHostname string,
 	tlsProd bool,
 	tlsCacheDir string,
+	limiter *publicrpc.RateLimiter,
 ) supervisor.Runnable {
 	return func(ctx context.Context) error {
-		conn, err := grpc.DialContext(
-			ctx,
-			fmt.Sprintf("unix:///%s", upstreamAddr),
+		dialOpts := []grpc.DialOption{
 			grpc.WithBlock(),
-			grpc.WithTransportCredentials(insecure.NewCredentials()))
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		}
+		if limiter != nil {
+			dialOpts = append(dialOpts, limiter.DialOptions()...)
+		}
+		conn, err := grpc.DialContext(ctx, fmt.Sprintf("unix:///%s", upstreamAddr), dialOpts...)
 		if err != nil {
 			return fmt.Errorf("failed to dial upstream: %s", err)
 		}
 
-		gwmux := runtime.NewServeMux()
+		gwmux := runtime.NewServeMux(runtime.WithErrorHandler(retryAfterErrorHandler))
 		err = publicrpcv1.RegisterPublicRPCServiceHandler(ctx, gwmux, conn)
 		if err != nil {
 			panic(err)
 		}
 
 		mux := http.NewServeMux()
+		mux.Handle(openAPISpecPath, allowCORSWrapper(http.HandlerFunc(serveOpenAPISpec)))
 		grpcWebServer := grpcweb.WrapServer(grpcServer)
 		mux.Handle("/", allowCORSWrapper(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
 			if grpcWebServer.IsGrpcWebRequest(req) {
@@ -82,8 +114,13 @@ func publicwebServiceRunnable(
 			}
 		})))
 
+		var handler http.Handler = mux
+		if limiter != nil {
+			handler = publicrpc.ClientIPHandler(mux)
+		}
+
 		srv := &http.Server{
-			Handler:           mux,
+			Handler:           handler,
 			ReadHeaderTimeout: 3 * time.Second,
 		}
 
@@ -136,7 +173,7 @@ func publicwebServiceRunnable(
 				return fmt.Errorf("no valid systemd listeners, got: %s", strings.Join(all, ","))
 			}
 		} else {
-			listener, err = net.Listen("tcp", listenAddr)
+			listener, err = common.ListenTCP(ipMode, listenAddr)
 			if err != nil {
 				return fmt.Errorf("failed to listen: %v", err)
 			}
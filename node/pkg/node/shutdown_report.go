@@ -0,0 +1,106 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/p2p"
+	publicrpcv1 "github.com/certusone/wormhole/node/pkg/proto/publicrpc/v1"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// ShutdownReportFilename is the name of the file the shutdown report is written to inside the data directory.
+const ShutdownReportFilename = "shutdown_report.json"
+
+// ShutdownReport is a snapshot of the node state taken when the guardian exits.
+// It is written to disk so operators investigating a restart can see what the node was doing at the time.
+type ShutdownReport struct {
+	Reason       string    `json:"reason"`
+	StartTime    time.Time `json:"startTime"`
+	ShutdownTime time.Time `json:"shutdownTime"`
+	Uptime       string    `json:"uptime"`
+
+	// PendingObservations is the number of entries in the processor aggregation state, or -1 if the processor was not configured.
+	PendingObservations int `json:"pendingObservations"`
+
+	// GovernorEnabled is false if the node was running without the chain governor.
+	GovernorEnabled bool `json:"governorEnabled"`
+	// GovernorEnqueuedVAAs lists the transfers that were still held by the governor. They are persisted in the database
+	// and will be reloaded on startup, but are included here so they can be inspected without the node running.
+	GovernorEnqueuedVAAs []*publicrpcv1.GovernorGetEnqueuedVAAsResponse_Entry `json:"governorEnqueuedVAAs"`
+
+	Watchers []ShutdownReportWatcher `json:"watchers"`
+}
+
+// ShutdownReportWatcher is the last block height reported by a chain's watcher.
+type ShutdownReportWatcher struct {
+	ChainID         vaa.ChainID `json:"chainId"`
+	ChainName       string      `json:"chainName"`
+	Height          int64       `json:"height"`
+	SafeHeight      int64       `json:"safeHeight"`
+	FinalizedHeight int64       `json:"finalizedHeight"`
+}
+
+// ShutdownReport collects a shutdown report for the guardian node. It may be called after the root context has been cancelled.
+func (g *G) ShutdownReport(reason string, startTime time.Time, now time.Time) *ShutdownReport {
+	report := &ShutdownReport{
+		Reason:              reason,
+		StartTime:           startTime,
+		ShutdownTime:        now,
+		Uptime:              now.Sub(startTime).Round(time.Second).String(),
+		PendingObservations: -1,
+		Watchers:            make([]ShutdownReportWatcher, 0),
+	}
+
+	if g.processor != nil {
+		report.PendingObservations = g.processor.PendingObservations()
+	}
+
+	if g.gov != nil {
+		report.GovernorEnabled = true
+		report.GovernorEnqueuedVAAs = g.gov.GetEnqueuedVAAs()
+	}
+
+	for chainID, stats := range p2p.DefaultRegistry.GetNetworkStats() {
+		report.Watchers = append(report.Watchers, ShutdownReportWatcher{
+			ChainID:         chainID,
+			ChainName:       chainID.String(),
+			Height:          stats.Height,
+			SafeHeight:      stats.SafeHeight,
+			FinalizedHeight: stats.FinalizedHeight,
+		})
+	}
+
+	sort.SliceStable(report.Watchers, func(i, j int) bool {
+		return report.Watchers[i].ChainID < report.Watchers[j].ChainID
+	})
+
+	return report
+}
+
+// WriteShutdownReport logs the report and writes it as JSON to the specified path, replacing any previous report.
+func WriteShutdownReport(logger *zap.Logger, path string, report *ShutdownReport) error {
+	logger.Info("shutdown report",
+		zap.String("reason", report.Reason),
+		zap.String("uptime", report.Uptime),
+		zap.Int("pendingObservations", report.PendingObservations),
+		zap.Bool("governorEnabled", report.GovernorEnabled),
+		zap.Int("governorEnqueuedVAAs", len(report.GovernorEnqueuedVAAs)),
+		zap.Any("watchers", report.Watchers),
+	)
+
+	b, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal shutdown report: %w", err)
+	}
+
+	if err := os.WriteFile(path, b, 0600); err != nil {
+		return fmt.Errorf("failed to write shutdown report to %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package node
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestShutdownReportWithoutComponents(t *testing.T) {
+	g := NewGuardianNode(common.GoTest, nil)
+
+	start := time.Unix(1700000000, 0)
+	now := start.Add(90 * time.Minute)
+	report := g.ShutdownReport("sigterm", start, now)
+
+	assert.Equal(t, "sigterm", report.Reason)
+	assert.Equal(t, "1h30m0s", report.Uptime)
+	assert.Equal(t, -1, report.PendingObservations)
+	assert.False(t, report.GovernorEnabled)
+	assert.Nil(t, report.GovernorEnqueuedVAAs)
+	assert.NotNil(t, report.Watchers)
+}
+
+func TestWriteShutdownReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ShutdownReportFilename)
+
+	start := time.Unix(1700000000, 0).UTC()
+	report := &ShutdownReport{
+		Reason:              "root context cancelled",
+		StartTime:           start,
+		ShutdownTime:        start.Add(time.Minute),
+		Uptime:              "1m0s",
+		PendingObservations: 7,
+		Watchers: []ShutdownReportWatcher{
+			{ChainID: 1, ChainName: "solana", Height: 100, FinalizedHeight: 68},
+		},
+	}
+
+	require.NoError(t, WriteShutdownReport(zap.NewNop(), path, report))
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var readBack ShutdownReport
+	require.NoError(t, json.Unmarshal(b, &readBack))
+	assert.Equal(t, *report, readBack)
+}
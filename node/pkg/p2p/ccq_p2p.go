@@ -9,6 +9,8 @@ import (
 
 	"github.com/certusone/wormhole/node/pkg/common"
 	"github.com/certusone/wormhole/node/pkg/query"
+	"github.com/certusone/wormhole/node/pkg/supervisor"
+	ethcommon "github.com/ethereum/go-ethereum/common"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -45,6 +47,7 @@ type ccqP2p struct {
 	sub           *pubsub.Subscription
 	allowedPeers  map[string]struct{}
 	p2pComponents *Components
+	guardianAddr  ethcommon.Address
 }
 
 func newCcqRunP2p(
@@ -87,6 +90,8 @@ func (ccq *ccqP2p) run(
 		return fmt.Errorf("components is not initialized")
 	}
 	components.Port = port
+	components.IPMode = ccq.p2pComponents.IPMode
+	ccq.guardianAddr = ethcrypto.PubkeyToAddress(gk.PublicKey)
 
 	ccq.h, err = NewHost(ccq.logger, ctx, networkID, bootstrapPeers, components, priv)
 	if err != nil {
@@ -175,6 +180,42 @@ func (ccq *ccqP2p) run(
 	return nil
 }
 
+// RunCcq returns a runnable which joins only the CCQ network, without the main gossip network. It is used by nodes that serve
+// cross chain queries but do not take part in consensus. Since no heartbeats are received, only the peers in allowedPeers and the
+// bootstrap peers may subscribe to the responses.
+func RunCcq(
+	priv crypto.PrivKey,
+	gk *ecdsa.PrivateKey,
+	networkID string,
+	bootstrapPeers string,
+	port uint,
+	allowedPeers string,
+	ipMode common.IPMode,
+	signedQueryReqC chan<- *gossipv1.SignedQueryRequest,
+	queryResponseReadC <-chan *query.QueryResponsePublication,
+) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		logger := supervisor.Logger(ctx)
+		errC := make(chan error)
+		components := DefaultComponents()
+		components.IPMode = ipMode
+		ccq := newCcqRunP2p(logger, allowedPeers, components)
+		if err := ccq.run(ctx, priv, gk, networkID, bootstrapPeers, port, signedQueryReqC, queryResponseReadC, errC); err != nil {
+			return fmt.Errorf("failed to start p2p for CCQ: %w", err)
+		}
+		defer ccq.close()
+
+		supervisor.Signal(ctx, supervisor.SignalHealthy)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errC:
+			return fmt.Errorf("ccqp2p returned an error: %w", err)
+		}
+	}
+}
+
 func (ccq *ccqP2p) close() {
 	ccq.logger.Info("entering close")
 
@@ -216,6 +257,11 @@ func (ccq *ccqP2p) listener(ctx context.Context, signedQueryReqC chan<- *gossipv
 
 		switch m := msg.Message.(type) {
 		case *gossipv1.GossipMessage_SignedQueryRequest:
+			if !query.IsTargetedAt(m.SignedQueryRequest, ccq.guardianAddr) {
+				// The proxy sent the request to a subset of the guardians that does not include us.
+				ccqP2pMessagesReceived.WithLabelValues("not_targeted").Inc()
+				continue
+			}
 			if err := query.PostSignedQueryRequest(signedQueryReqC, m.SignedQueryRequest); err != nil {
 				ccq.logger.Warn("failed to handle query request", zap.Error(err))
 			}
@@ -0,0 +1,221 @@
+package p2p
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	eth_common "github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/time/rate"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	latencyProbeRtt = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "wormhole_p2p_latency_probe_rtt_ms",
+			Help:    "Round trip time of gossip latency probes by responding guardian",
+			Buckets: []float64{10.0, 25.0, 50.0, 100.0, 250.0, 500.0, 1000.0, 2500.0, 5000.0, 10000.0},
+		}, []string{"guardian_addr"})
+	latencyProbeLastRtt = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "wormhole_p2p_latency_probe_last_rtt_ms",
+			Help: "Most recent round trip time of a gossip latency probe by responding guardian",
+		}, []string{"guardian_addr"})
+	latencyProbeRepliesRateLimited = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "wormhole_p2p_latency_probe_replies_rate_limited_total",
+			Help: "Total number of latency probes left unanswered because of the overall reply rate limit",
+		})
+)
+
+var latencyProbePrefix = []byte("latency_probe|")
+
+// latencyProbeMinReplyInterval limits how often we answer probes from any single guardian.
+const latencyProbeMinReplyInterval = time.Second
+
+// Replies are broadcast on the gossip topic like the probes, so if every guardian answered every probe the traffic would grow with the
+// square of the guardian set size. latencyProbeMaxReplyRate and latencyProbeMaxReplyBurst limit the replies we send to all guardians
+// together, which keeps the total linear in the number of guardians. Probes that go unanswered just yield fewer samples.
+const (
+	latencyProbeMaxReplyRate  = rate.Limit(2)
+	latencyProbeMaxReplyBurst = 5
+)
+
+// latencyProbeMaxAge is how long we wait for replies to one of our probes before forgetting it.
+const latencyProbeMaxAge = time.Minute
+
+func latencyProbeDigest(b []byte) eth_common.Hash {
+	return ethcrypto.Keccak256Hash(append(latencyProbePrefix, b...))
+}
+
+// latencyProber sends periodic probes over gossip and measures how long it takes each guardian to answer them.
+type latencyProber struct {
+	gk      *ecdsa.PrivateKey
+	ourAddr eth_common.Address
+	session []byte
+
+	mu sync.Mutex
+	// seq is the sequence number of the most recent probe we sent.
+	seq uint64
+	// sent maps outstanding probe sequence numbers to the time they were sent.
+	sent map[uint64]time.Time
+	// lastReply is the time we last replied to a probe from a given guardian.
+	lastReply map[eth_common.Address]time.Time
+	// replyLimiter limits the rate of our replies to all guardians.
+	replyLimiter *rate.Limiter
+}
+
+func newLatencyProber(gk *ecdsa.PrivateKey) (*latencyProber, error) {
+	session := make([]byte, 16)
+	if _, err := rand.Read(session); err != nil {
+		return nil, fmt.Errorf("failed to generate latency probe session: %w", err)
+	}
+
+	return &latencyProber{
+		gk:           gk,
+		ourAddr:      ethcrypto.PubkeyToAddress(gk.PublicKey),
+		session:      session,
+		sent:         make(map[uint64]time.Time),
+		lastReply:    make(map[eth_common.Address]time.Time),
+		replyLimiter: rate.NewLimiter(latencyProbeMaxReplyRate, latencyProbeMaxReplyBurst),
+	}, nil
+}
+
+// newProbe creates the next outbound probe and records when it was sent.
+func (lp *latencyProber) newProbe(now time.Time) []byte {
+	lp.mu.Lock()
+	defer lp.mu.Unlock()
+
+	for seq, sentAt := range lp.sent {
+		if now.Sub(sentAt) > latencyProbeMaxAge {
+			delete(lp.sent, seq)
+		}
+	}
+
+	lp.seq++
+	lp.sent[lp.seq] = now
+
+	return lp.marshal(&gossipv1.LatencyProbe{
+		Sequence:  lp.seq,
+		Timestamp: now.UnixNano(),
+		Session:   lp.session,
+	})
+}
+
+// handle processes a verified probe from another guardian. If the probe needs to be answered, the serialized reply is returned.
+func (lp *latencyProber) handle(signer eth_common.Address, probe *gossipv1.LatencyProbe, now time.Time) []byte {
+	if len(probe.ReplyTo) == 0 {
+		lp.mu.Lock()
+		defer lp.mu.Unlock()
+
+		if now.Sub(lp.lastReply[signer]) < latencyProbeMinReplyInterval {
+			return nil
+		}
+		if !lp.replyLimiter.AllowN(now, 1) {
+			latencyProbeRepliesRateLimited.Inc()
+			return nil
+		}
+		lp.lastReply[signer] = now
+
+		return lp.marshal(&gossipv1.LatencyProbe{
+			Sequence:  probe.Sequence,
+			Timestamp: now.UnixNano(),
+			ReplyTo:   signer.Bytes(),
+			Session:   probe.Session,
+		})
+	}
+
+	if eth_common.BytesToAddress(probe.ReplyTo) != lp.ourAddr || !bytes.Equal(probe.Session, lp.session) {
+		// A reply to somebody else's probe, or to one we sent before restarting.
+		return nil
+	}
+
+	lp.mu.Lock()
+	sentAt, exists := lp.sent[probe.Sequence]
+	lp.mu.Unlock()
+	if !exists {
+		return nil
+	}
+
+	rtt := float64(now.Sub(sentAt).Microseconds()) / 1000.0
+	latencyProbeRtt.WithLabelValues(signer.Hex()).Observe(rtt)
+	latencyProbeLastRtt.WithLabelValues(signer.Hex()).Set(rtt)
+	return nil
+}
+
+// marshal signs a probe and wraps it in a gossip message.
+func (lp *latencyProber) marshal(probe *gossipv1.LatencyProbe) []byte {
+	b, err := proto.Marshal(probe)
+	if err != nil {
+		panic(err)
+	}
+
+	digest := latencyProbeDigest(b)
+	sig, err := ethcrypto.Sign(digest.Bytes(), lp.gk)
+	if err != nil {
+		panic(err)
+	}
+
+	msg := gossipv1.GossipMessage{
+		Message: &gossipv1.GossipMessage_SignedLatencyProbe{
+			SignedLatencyProbe: &gossipv1.SignedLatencyProbe{
+				Probe:        b,
+				Signature:    sig,
+				GuardianAddr: lp.ourAddr.Bytes(),
+			},
+		},
+	}
+
+	b, err = proto.Marshal(&msg)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func processSignedLatencyProbe(s *gossipv1.SignedLatencyProbe, gs *common.GuardianSet) (eth_common.Address, *gossipv1.LatencyProbe, error) {
+	envelopeAddr := eth_common.BytesToAddress(s.GuardianAddr)
+	idx, ok := gs.KeyIndex(envelopeAddr)
+	if !ok {
+		return eth_common.Address{}, nil, fmt.Errorf("invalid message: %s not in guardian set", envelopeAddr)
+	}
+	pk := gs.Keys[idx]
+
+	// SECURITY: see whitepapers/0009_guardian_key.md
+	if len(latencyProbePrefix)+len(s.Probe) < 34 {
+		return eth_common.Address{}, nil, fmt.Errorf("invalid latency probe: too short")
+	}
+
+	digest := latencyProbeDigest(s.Probe)
+
+	pubKey, err := ethcrypto.Ecrecover(digest.Bytes(), s.Signature)
+	if err != nil {
+		return eth_common.Address{}, nil, errors.New("failed to recover public key")
+	}
+
+	signerAddr := eth_common.BytesToAddress(ethcrypto.Keccak256(pubKey[1:])[12:])
+	if pk != signerAddr {
+		return eth_common.Address{}, nil, fmt.Errorf("invalid signer: %v", signerAddr)
+	}
+
+	var p gossipv1.LatencyProbe
+	if err := proto.Unmarshal(s.Probe, &p); err != nil {
+		return eth_common.Address{}, nil, fmt.Errorf("failed to unmarshal latency probe: %w", err)
+	}
+
+	if time.Until(time.Unix(0, p.Timestamp)).Abs() > heartbeatMaxTimeDifference {
+		return eth_common.Address{}, nil, fmt.Errorf("latency probe is too old or too far into the future")
+	}
+
+	return signerAddr, &p, nil
+}
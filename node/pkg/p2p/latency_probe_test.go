@@ -0,0 +1,140 @@
+package p2p
+
+import (
+	"crypto/ecdsa"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	eth_common "github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func newTestProber(t *testing.T) (*latencyProber, *ecdsa.PrivateKey) {
+	t.Helper()
+	gk, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+	lp, err := newLatencyProber(gk)
+	require.NoError(t, err)
+	return lp, gk
+}
+
+// decodeProbe unwraps a gossip message produced by the prober and verifies it against the guardian set.
+func decodeProbe(t *testing.T, b []byte, gs *common.GuardianSet) (eth_common.Address, *gossipv1.LatencyProbe) {
+	t.Helper()
+	var msg gossipv1.GossipMessage
+	require.NoError(t, proto.Unmarshal(b, &msg))
+	m, ok := msg.Message.(*gossipv1.GossipMessage_SignedLatencyProbe)
+	require.True(t, ok)
+	signer, probe, err := processSignedLatencyProbe(m.SignedLatencyProbe, gs)
+	require.NoError(t, err)
+	return signer, probe
+}
+
+func TestLatencyProbeRoundTrip(t *testing.T) {
+	alice, aliceKey := newTestProber(t)
+	bob, bobKey := newTestProber(t)
+	aliceAddr := ethcrypto.PubkeyToAddress(aliceKey.PublicKey)
+	bobAddr := ethcrypto.PubkeyToAddress(bobKey.PublicKey)
+	gs := &common.GuardianSet{Keys: []eth_common.Address{aliceAddr, bobAddr}}
+
+	now := time.Now()
+	signer, probe := decodeProbe(t, alice.newProbe(now), gs)
+	assert.Equal(t, aliceAddr, signer)
+	assert.Equal(t, uint64(1), probe.Sequence)
+	assert.Empty(t, probe.ReplyTo)
+
+	reply := bob.handle(signer, probe, now.Add(10*time.Millisecond))
+	require.NotNil(t, reply)
+
+	signer, replyProbe := decodeProbe(t, reply, gs)
+	assert.Equal(t, bobAddr, signer)
+	assert.Equal(t, aliceAddr.Bytes(), replyProbe.ReplyTo)
+	assert.Equal(t, probe.Session, replyProbe.Session)
+
+	assert.Nil(t, alice.handle(signer, replyProbe, now.Add(250*time.Millisecond)))
+	assert.Equal(t, 250.0, testutil.ToFloat64(latencyProbeLastRtt.WithLabelValues(bobAddr.Hex())))
+
+	// A third guardian seeing the reply must not record anything or respond.
+	carol, _ := newTestProber(t)
+	assert.Nil(t, carol.handle(signer, replyProbe, now))
+}
+
+func TestLatencyProbeReplyRateLimited(t *testing.T) {
+	alice, aliceKey := newTestProber(t)
+	bob, _ := newTestProber(t)
+	aliceAddr := ethcrypto.PubkeyToAddress(aliceKey.PublicKey)
+	gs := &common.GuardianSet{Keys: []eth_common.Address{aliceAddr}}
+
+	now := time.Now()
+	_, probe := decodeProbe(t, alice.newProbe(now), gs)
+	assert.NotNil(t, bob.handle(aliceAddr, probe, now))
+	assert.Nil(t, bob.handle(aliceAddr, probe, now.Add(latencyProbeMinReplyInterval/2)))
+	assert.NotNil(t, bob.handle(aliceAddr, probe, now.Add(latencyProbeMinReplyInterval)))
+}
+
+func TestLatencyProbeRepliesRateLimitedOverall(t *testing.T) {
+	alice, aliceKey := newTestProber(t)
+	bob, _ := newTestProber(t)
+	aliceAddr := ethcrypto.PubkeyToAddress(aliceKey.PublicKey)
+	gs := &common.GuardianSet{Keys: []eth_common.Address{aliceAddr}}
+
+	// Probes from many guardians at once are only answered up to the burst, even though each of them is within its own limit.
+	now := time.Now()
+	_, probe := decodeProbe(t, alice.newProbe(now), gs)
+	for i := 0; i < latencyProbeMaxReplyBurst; i++ {
+		assert.NotNil(t, bob.handle(eth_common.BytesToAddress([]byte{byte(i + 1)}), probe, now))
+	}
+	other := eth_common.BytesToAddress([]byte{0xff})
+	before := testutil.ToFloat64(latencyProbeRepliesRateLimited)
+	assert.Nil(t, bob.handle(other, probe, now))
+	assert.Equal(t, before+1, testutil.ToFloat64(latencyProbeRepliesRateLimited))
+
+	// A guardian left unanswered is not held to the per-guardian interval, and gets a reply once the limit allows it.
+	assert.NotNil(t, bob.handle(other, probe, now.Add(time.Duration(float64(time.Second)/float64(latencyProbeMaxReplyRate)))))
+}
+
+func TestLatencyProbeIgnoresReplyFromOtherSession(t *testing.T) {
+	alice, aliceKey := newTestProber(t)
+	aliceAddr := ethcrypto.PubkeyToAddress(aliceKey.PublicKey)
+	_, bobKey := newTestProber(t)
+	bobAddr := ethcrypto.PubkeyToAddress(bobKey.PublicKey)
+
+	now := time.Now()
+	alice.newProbe(now)
+
+	stale := &gossipv1.LatencyProbe{
+		Sequence:  1,
+		Timestamp: now.UnixNano(),
+		ReplyTo:   aliceAddr.Bytes(),
+		Session:   []byte("previous session"),
+	}
+	assert.Nil(t, alice.handle(bobAddr, stale, now))
+	assert.Equal(t, 0.0, testutil.ToFloat64(latencyProbeLastRtt.WithLabelValues(bobAddr.Hex())))
+}
+
+func TestLatencyProbeExpiresOldProbes(t *testing.T) {
+	alice, _ := newTestProber(t)
+
+	now := time.Now()
+	alice.newProbe(now)
+	alice.newProbe(now.Add(latencyProbeMaxAge + time.Second))
+	assert.Equal(t, 1, len(alice.sent))
+	_, exists := alice.sent[2]
+	assert.True(t, exists)
+}
+
+func TestLatencyProbeRejectsNonGuardian(t *testing.T) {
+	alice, _ := newTestProber(t)
+	gs := &common.GuardianSet{Keys: []eth_common.Address{}}
+
+	var msg gossipv1.GossipMessage
+	require.NoError(t, proto.Unmarshal(alice.newProbe(time.Now()), &msg))
+	_, _, err := processSignedLatencyProbe(msg.GetSignedLatencyProbe(), gs)
+	assert.ErrorContains(t, err, "not in guardian set")
+}
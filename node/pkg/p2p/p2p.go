@@ -106,11 +106,31 @@ type Components struct {
 	SignedHeartbeatLogLevel zapcore.Level
 	// GossipParams is used to configure the GossipSub instance used by the Guardian.
 	GossipParams pubsub.GossipSubParams
+	// LatencyProbeInterval is the interval at which latency probes are sent to the other guardians. Zero disables probing.
+	// Probes from other guardians are only answered if probing is enabled.
+	LatencyProbeInterval time.Duration
+	// IPMode selects which of the ListeningAddressesPatterns are used. The zero value listens on both IPv4 and IPv6.
+	IPMode common.IPMode
+	// AnnounceAddrs, if set, replaces the addresses the host advertises to its peers. This is needed if the host is reachable on
+	// other addresses than the ones it listens on, e.g. behind NAT or a load balancer.
+	AnnounceAddrs []string
+	// AggSigPublicKey and AggSigProofOfPossession are advertised in heartbeats if set, so other guardians can verify our shares of
+	// experimental aggregated signatures.
+	AggSigPublicKey         []byte
+	AggSigProofOfPossession []byte
+	// GatewayRelayerEnabled advertises the gateway relayer in heartbeats.
+	GatewayRelayerEnabled bool
+	// SignedVAAReplayWindow is the number of recently received SignedVAAWithQuorum messages that are remembered, so they are
+	// dropped if received again before being handed to the processor. Zero disables the window.
+	SignedVAAReplayWindow int
 }
 
 func (f *Components) ListeningAddresses() []string {
 	la := make([]string, 0, len(f.ListeningAddressesPatterns))
 	for _, pattern := range f.ListeningAddressesPatterns {
+		if (strings.HasPrefix(pattern, "/ip4/") && !f.IPMode.IPv4()) || (strings.HasPrefix(pattern, "/ip6/") && !f.IPMode.IPv6()) {
+			continue
+		}
 		pattern = cutOverAddressPattern(pattern)
 		la = append(la, fmt.Sprintf(pattern, f.Port))
 	}
@@ -136,6 +156,7 @@ func DefaultComponents() *Components {
 		ProtectedHostByGuardianKey: make(map[eth_common.Address]peer.ID),
 		SignedHeartbeatLogLevel:    zapcore.DebugLevel,
 		GossipParams:               pubsub.DefaultGossipSubParams(),
+		SignedVAAReplayWindow:      SignedVAAReplayWindowDefault,
 	}
 }
 
@@ -194,7 +215,32 @@ func ConnectToPeers(ctx context.Context, logger *zap.Logger, h host.Host, peers
 	return successes
 }
 
+// parseAnnounceAddrs parses the AnnounceAddrs of the components.
+func (f *Components) parseAnnounceAddrs() ([]multiaddr.Multiaddr, error) {
+	addrs := make([]multiaddr.Multiaddr, 0, len(f.AnnounceAddrs))
+	for _, str := range f.AnnounceAddrs {
+		addr, err := multiaddr.NewMultiaddr(cutOverAddressPattern(str))
+		if err != nil {
+			return nil, fmt.Errorf("invalid announce address %s: %w", str, err)
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
+
 func NewHost(logger *zap.Logger, ctx context.Context, networkID string, bootstrapPeers string, components *Components, priv crypto.PrivKey) (host.Host, error) {
+	announceAddrs, err := components.parseAnnounceAddrs()
+	if err != nil {
+		return nil, err
+	}
+
+	addrsFactory := func(addrs []multiaddr.Multiaddr) []multiaddr.Multiaddr {
+		if len(announceAddrs) != 0 {
+			return announceAddrs
+		}
+		return addrs
+	}
+
 	h, err := libp2p.New(
 		// Use the keypair we generated
 		libp2p.Identity(priv),
@@ -204,6 +250,9 @@ func NewHost(logger *zap.Logger, ctx context.Context, networkID string, bootstra
 			components.ListeningAddresses()...,
 		),
 
+		// Advertise the announce addresses instead of the listening addresses, if configured.
+		libp2p.AddrsFactory(addrsFactory),
+
 		// Enable TLS security as the only security protocol.
 		libp2p.Security(libp2ptls.ID, libp2ptls.New),
 
@@ -271,6 +320,16 @@ func Run(
 
 		logger := supervisor.Logger(ctx)
 
+		var prober *latencyProber
+		if components.LatencyProbeInterval > 0 && nodeName != "" {
+			var err error
+			prober, err = newLatencyProber(gk)
+			if err != nil {
+				return err
+			}
+			logger.Info("latency probing is enabled", zap.Duration("interval", components.LatencyProbeInterval))
+		}
+
 		defer func() {
 			// TODO: Right now we're canceling the root context because it used to be the case that libp2p cannot be cleanly restarted.
 			// But that seems to no longer be the case. We may want to revisit this. See (https://github.com/libp2p/go-libp2p/issues/992) for background.
@@ -406,6 +465,8 @@ func Run(
 				case <-timer.C:
 					timer.Reset(15 * time.Second)
 
+					DefaultRegistry.SetPeerCount(len(h.Network().Peers()))
+
 					// create a heartbeat
 					b := func() []byte {
 						DefaultRegistry.mu.Lock()
@@ -424,6 +485,15 @@ func Run(
 						if ccqEnabled {
 							features = append(features, "ccq")
 						}
+						if prober != nil {
+							features = append(features, "latency_probe")
+						}
+						if len(components.AggSigPublicKey) != 0 {
+							features = append(features, "aggsig")
+						}
+						if components.GatewayRelayerEnabled {
+							features = append(features, "gwrelayer")
+						}
 
 						heartbeat := &gossipv1.Heartbeat{
 							NodeName:      nodeName,
@@ -439,6 +509,8 @@ func Run(
 						if components.P2PIDInHeartbeat {
 							heartbeat.P2PNodeId = nodeIdBytes
 						}
+						heartbeat.AggsigPublicKey = components.AggSigPublicKey
+						heartbeat.AggsigProofOfPossession = components.AggSigProofOfPossession
 
 						if err := gst.SetHeartbeat(ourAddr, h.ID(), heartbeat); err != nil {
 							panic(err)
@@ -473,6 +545,25 @@ func Run(
 			}
 		}()
 
+		if prober != nil {
+			go func() {
+				ticker := time.NewTicker(components.LatencyProbeInterval)
+				defer ticker.Stop()
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+						if err := th.Publish(ctx, prober.newProbe(time.Now())); err != nil {
+							logger.Warn("failed to publish latency probe", zap.Error(err))
+						} else {
+							p2pMessagesSent.Inc()
+						}
+					}
+				}
+			}()
+		}
+
 		go func() {
 			for {
 				select {
@@ -526,6 +617,11 @@ func Run(
 			}
 		}()
 
+		var replayWindow *signedVAAReplayWindow
+		if components.SignedVAAReplayWindow > 0 {
+			replayWindow = newSignedVAAReplayWindow(components.SignedVAAReplayWindow)
+		}
+
 		for {
 			envelope, err := sub.Next(ctx) // Note: sub.Next(ctx) will return an error once ctx is canceled
 			if err != nil {
@@ -628,9 +724,20 @@ func Run(
 					p2pReceiveChannelOverflow.WithLabelValues("observation").Inc()
 				}
 			case *gossipv1.GossipMessage_SignedVaaWithQuorum:
+				var replayKey eth_common.Hash
+				if replayWindow != nil {
+					replayKey = signedVAAReplayKey(m.SignedVaaWithQuorum.Vaa)
+					if replayWindow.isReplay(replayKey, envelope.GetFrom()) {
+						p2pMessagesReceived.WithLabelValues("signed_vaa_with_quorum_replay").Inc()
+						break
+					}
+				}
 				select {
 				case signedInC <- m.SignedVaaWithQuorum:
 					p2pMessagesReceived.WithLabelValues("signed_vaa_with_quorum").Inc()
+					if replayWindow != nil {
+						replayWindow.add(replayKey)
+					}
 				default:
 					if components.WarnChannelOverflow {
 						// TODO do not log this in production
@@ -677,9 +784,45 @@ func Run(
 					signedGovCfg <- m.SignedChainGovernorConfig
 				}
 			case *gossipv1.GossipMessage_SignedChainGovernorStatus:
+				if gs := gst.Get(); gs != nil {
+					status, signer, err := governor.ParseSignedStatus(m.SignedChainGovernorStatus, gs)
+					if err != nil {
+						p2pMessagesReceived.WithLabelValues("invalid_governor_status").Inc()
+						logger.Debug("invalid governor status received",
+							zap.Error(err),
+							zap.String("from", envelope.GetFrom().String()))
+					} else {
+						p2pMessagesReceived.WithLabelValues("valid_governor_status").Inc()
+						gst.SetGovernorStatus(signer, status)
+					}
+				}
 				if signedGovSt != nil {
 					signedGovSt <- m.SignedChainGovernorStatus
 				}
+			case *gossipv1.GossipMessage_SignedLatencyProbe:
+				if prober == nil {
+					break
+				}
+				gs := gst.Get()
+				if gs == nil {
+					break
+				}
+				signer, probe, err := processSignedLatencyProbe(m.SignedLatencyProbe, gs)
+				if err != nil {
+					p2pMessagesReceived.WithLabelValues("invalid_latency_probe").Inc()
+					logger.Debug("invalid latency probe received",
+						zap.Error(err),
+						zap.String("from", envelope.GetFrom().String()))
+					break
+				}
+				p2pMessagesReceived.WithLabelValues("latency_probe").Inc()
+				if reply := prober.handle(signer, probe, time.Now()); reply != nil {
+					select {
+					case gossipSendC <- reply:
+					default:
+						p2pReceiveChannelOverflow.WithLabelValues("latency_probe_reply").Inc()
+					}
+				}
 			default:
 				p2pMessagesReceived.WithLabelValues("unknown").Inc()
 				logger.Warn("received unknown message type (running outdated software?)",
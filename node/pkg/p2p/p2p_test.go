@@ -1,12 +1,16 @@
 package p2p
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"crypto/rand"
 	"testing"
 	"time"
 
+	libp2p_crypto "github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 
 	node_common "github.com/certusone/wormhole/node/pkg/common"
 	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
@@ -103,3 +107,40 @@ func TestSignedHeartbeat(t *testing.T) {
 		testFunc(t, tc)
 	}
 }
+
+func TestListeningAddressesIPMode(t *testing.T) {
+	components := DefaultComponents()
+	components.Port = 8999
+	assert.Equal(t, []string{"/ip4/0.0.0.0/udp/8999/quic-v1", "/ip6/::/udp/8999/quic-v1"}, components.ListeningAddresses())
+
+	components.IPMode = node_common.IPModeIPv4
+	assert.Equal(t, []string{"/ip4/0.0.0.0/udp/8999/quic-v1"}, components.ListeningAddresses())
+
+	components.IPMode = node_common.IPModeIPv6
+	assert.Equal(t, []string{"/ip6/::/udp/8999/quic-v1"}, components.ListeningAddresses())
+}
+
+func TestNewHostAnnounceAddrs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	priv, _, err := libp2p_crypto.GenerateKeyPair(libp2p_crypto.Ed25519, -1)
+	require.NoError(t, err)
+
+	components := DefaultComponents()
+	components.Port = 0
+	components.IPMode = node_common.IPModeIPv4
+	components.AnnounceAddrs = []string{"/ip6/2001:db8::1/udp/8999/quic", "/dns4/guardian.example.com/udp/8999/quic-v1"}
+	h, err := NewHost(zap.NewNop(), ctx, "/wormhole/test", "", components, priv)
+	require.NoError(t, err)
+	defer h.Close()
+
+	addrs := make([]string, 0, len(h.Addrs()))
+	for _, addr := range h.Addrs() {
+		addrs = append(addrs, addr.String())
+	}
+	assert.Equal(t, []string{"/ip6/2001:db8::1/udp/8999/quic-v1", "/dns4/guardian.example.com/udp/8999/quic-v1"}, addrs)
+
+	components.AnnounceAddrs = []string{"not a multiaddr"}
+	_, err = NewHost(zap.NewNop(), ctx, "/wormhole/test", "", components, priv)
+	assert.Error(t, err)
+}
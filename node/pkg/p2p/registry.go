@@ -2,6 +2,7 @@ package p2p
 
 import (
 	"sync"
+	"sync/atomic"
 
 	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
@@ -18,6 +19,9 @@ type registry struct {
 	// Per-chain error counters
 	errorCounters  map[vaa.ChainID]uint64
 	errorCounterMu sync.Mutex
+
+	// Number of peers the host is connected to, as of the last heartbeat.
+	peerCount atomic.Int64
 }
 
 func NewRegistry() *registry {
@@ -51,3 +55,24 @@ func (r *registry) GetErrorCount(chain vaa.ChainID) uint64 {
 	defer r.errorCounterMu.Unlock()
 	return r.errorCounters[chain]
 }
+
+// GetNetworkStats returns a snapshot of the most recent network status reported by each chain's watcher.
+func (r *registry) GetNetworkStats() map[vaa.ChainID]*gossipv1.Heartbeat_Network {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stats := make(map[vaa.ChainID]*gossipv1.Heartbeat_Network, len(r.networkStats))
+	for chain, data := range r.networkStats {
+		stats[chain] = data
+	}
+	return stats
+}
+
+// SetPeerCount sets the number of peers the host is connected to.
+func (r *registry) SetPeerCount(count int) {
+	r.peerCount.Store(int64(count))
+}
+
+// GetPeerCount returns the number of peers the host was connected to at the last heartbeat.
+func (r *registry) GetPeerCount() int {
+	return int(r.peerCount.Load())
+}
@@ -28,9 +28,9 @@ func TestSetNetworkStats(t *testing.T) {
 	}
 
 	expect := make(map[vaa.ChainID]*gossipv1.Heartbeat_Network)
-	expect[vaa.ChainIDEthereum] = heartBeat
+	expect[vaa.ChainIDSolana] = heartBeat
 
-	registry.SetNetworkStats(vaa.ChainIDEthereum, heartBeat)
+	registry.SetNetworkStats(vaa.ChainIDSolana, heartBeat)
 	assert.Equal(t, expect, registry.networkStats)
 }
 
@@ -38,18 +38,37 @@ func TestAddErrorCount(t *testing.T) {
 	registry := NewRegistry()
 
 	expect := make(map[vaa.ChainID]uint64)
-	expect[vaa.ChainIDEthereum] = 1
+	expect[vaa.ChainIDSolana] = 1
 
-	registry.AddErrorCount(vaa.ChainIDEthereum, uint64(1))
+	registry.AddErrorCount(vaa.ChainIDSolana, uint64(1))
 	assert.Equal(t, expect, registry.errorCounters)
 }
 
 func TestGetErrorCount(t *testing.T) {
+	// Solana is the only named chain, so the errors of another chain are counted under its raw ID.
+	otherChain := vaa.ChainID(2)
 	registry := NewRegistry()
-	assert.Equal(t, uint64(0), registry.GetErrorCount(vaa.ChainIDEthereum))
+	assert.Equal(t, uint64(0), registry.GetErrorCount(otherChain))
 	assert.Equal(t, uint64(0), registry.GetErrorCount(vaa.ChainIDSolana))
 
-	registry.AddErrorCount(vaa.ChainIDEthereum, uint64(1))
-	assert.Equal(t, uint64(1), registry.GetErrorCount(vaa.ChainIDEthereum))
+	registry.AddErrorCount(otherChain, uint64(1))
+	assert.Equal(t, uint64(1), registry.GetErrorCount(otherChain))
 	assert.Equal(t, uint64(0), registry.GetErrorCount(vaa.ChainIDSolana))
 }
+
+func TestGetNetworkStats(t *testing.T) {
+	registry := NewRegistry()
+	assert.Equal(t, 0, len(registry.GetNetworkStats()))
+
+	heartBeat := &gossipv1.Heartbeat_Network{Height: 42}
+	registry.SetNetworkStats(vaa.ChainIDSolana, heartBeat)
+
+	stats := registry.GetNetworkStats()
+	assert.Equal(t, 1, len(stats))
+	assert.Equal(t, int64(42), stats[vaa.ChainIDSolana].Height)
+	assert.Equal(t, uint32(vaa.ChainIDSolana), stats[vaa.ChainIDSolana].Id)
+
+	// Modifying the snapshot must not affect the registry.
+	delete(stats, vaa.ChainIDSolana)
+	assert.Equal(t, 1, len(registry.GetNetworkStats()))
+}
@@ -0,0 +1,87 @@
+package p2p
+
+import (
+	eth_common "github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	signedVAAReplaysDropped = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "wormhole_p2p_signed_vaa_replays_dropped_total",
+			Help: "Total number of SignedVAAWithQuorum messages dropped because they were received recently, by publishing peer",
+		}, []string{"peer"})
+)
+
+// SignedVAAReplayWindowDefault is the default number of SignedVAAWithQuorum messages remembered by the replay window.
+const SignedVAAReplayWindowDefault = 50000
+
+// replayWindowMaxPeerLabels caps the number of peers with their own label in the replay metric. Peer IDs are free to create, so
+// the rest share the "other" label.
+const replayWindowMaxPeerLabels = 64
+
+// signedVAAReplayWindow remembers the most recently received SignedVAAWithQuorum messages, so a peer re-gossiping old VAAs en
+// masse costs us a hash rather than a database lookup per VAA. It is only used by the receive loop and is not safe for concurrent
+// use.
+//
+// Messages are identified by the hash of the whole VAA rather than its signing digest. Otherwise, anyone could get a VAA dropped
+// by gossiping it first with invalid signatures. A VAA gossiped with another set of signatures is forwarded again, which is fine
+// since the processor drops VAAs it already stored.
+type signedVAAReplayWindow struct {
+	seen map[eth_common.Hash]struct{}
+	// ring holds the hashes in seen in the order they were added, so the oldest is evicted once the window is full.
+	ring []eth_common.Hash
+	next int
+	// peerLabels are the peers that have their own label in the replay metric.
+	peerLabels map[peer.ID]struct{}
+}
+
+func newSignedVAAReplayWindow(size int) *signedVAAReplayWindow {
+	return &signedVAAReplayWindow{
+		seen:       make(map[eth_common.Hash]struct{}, size),
+		ring:       make([]eth_common.Hash, 0, size),
+		peerLabels: make(map[peer.ID]struct{}),
+	}
+}
+
+func signedVAAReplayKey(vaaBytes []byte) eth_common.Hash {
+	return ethcrypto.Keccak256Hash(vaaBytes)
+}
+
+// isReplay reports whether the message is in the window, and if so counts it against the peer that published it.
+func (w *signedVAAReplayWindow) isReplay(key eth_common.Hash, from peer.ID) bool {
+	if _, ok := w.seen[key]; !ok {
+		return false
+	}
+	signedVAAReplaysDropped.WithLabelValues(w.peerLabel(from)).Inc()
+	return true
+}
+
+// add adds a message to the window. It is called once the message was handed to the processor, so a message we dropped because
+// the processor was busy is accepted again.
+func (w *signedVAAReplayWindow) add(key eth_common.Hash) {
+	if _, ok := w.seen[key]; ok {
+		return
+	}
+	if len(w.ring) < cap(w.ring) {
+		w.ring = append(w.ring, key)
+	} else {
+		delete(w.seen, w.ring[w.next])
+		w.ring[w.next] = key
+		w.next = (w.next + 1) % len(w.ring)
+	}
+	w.seen[key] = struct{}{}
+}
+
+func (w *signedVAAReplayWindow) peerLabel(id peer.ID) string {
+	if _, ok := w.peerLabels[id]; !ok {
+		if len(w.peerLabels) >= replayWindowMaxPeerLabels {
+			return "other"
+		}
+		w.peerLabels[id] = struct{}{}
+	}
+	return id.String()
+}
@@ -0,0 +1,44 @@
+package p2p
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignedVAAReplayWindow(t *testing.T) {
+	w := newSignedVAAReplayWindow(2)
+	from := peer.ID("replaying-peer")
+	a, b, c := signedVAAReplayKey([]byte{1}), signedVAAReplayKey([]byte{2}), signedVAAReplayKey([]byte{3})
+
+	assert.False(t, w.isReplay(a, from))
+	w.add(a)
+	w.add(b)
+	assert.True(t, w.isReplay(a, from))
+	assert.True(t, w.isReplay(b, from))
+	assert.Equal(t, 2.0, testutil.ToFloat64(signedVAAReplaysDropped.WithLabelValues(from.String())))
+
+	// Adding a third message evicts the oldest.
+	w.add(c)
+	assert.False(t, w.isReplay(a, from))
+	assert.True(t, w.isReplay(b, from))
+	assert.True(t, w.isReplay(c, from))
+	assert.Len(t, w.seen, 2)
+
+	// Messages already in the window do not take another slot.
+	w.add(c)
+	assert.True(t, w.isReplay(b, from))
+}
+
+func TestSignedVAAReplayWindowPeerLabels(t *testing.T) {
+	w := newSignedVAAReplayWindow(1)
+	for i := 0; i < replayWindowMaxPeerLabels; i++ {
+		id := peer.ID(fmt.Sprint(i))
+		assert.Equal(t, id.String(), w.peerLabel(id))
+	}
+	assert.Equal(t, "other", w.peerLabel(peer.ID("one too many")))
+	assert.Equal(t, peer.ID("0").String(), w.peerLabel(peer.ID("0")))
+}
@@ -11,7 +11,6 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
-	"github.com/certusone/wormhole/node/pkg/accountant"
 	node_common "github.com/certusone/wormhole/node/pkg/common"
 	"github.com/certusone/wormhole/node/pkg/governor"
 	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
@@ -41,7 +40,6 @@ type G struct {
 	disableHeartbeatVerify bool
 	rootCtxCancel          context.CancelFunc
 	gov                    *governor.ChainGovernor
-	acct                   *accountant.Accountant
 	signedGovCfg           chan *gossipv1.SignedChainGovernorConfig
 	signedGovSt            chan *gossipv1.SignedChainGovernorStatus
 	components             *Components
@@ -178,13 +176,10 @@ func startGuardian(t *testing.T, ctx context.Context, g *G) {
 			g.nodeName,
 			g.disableHeartbeatVerify,
 			g.rootCtxCancel,
-			g.acct,
 			g.gov,
 			g.signedGovCfg,
 			g.signedGovSt,
 			g.components,
-			nil,   // ibc feature string
-			false, // gateway relayer enabled
 			false, // ccqEnabled
 			nil,   // signed query request channel
 			nil,   // query response channel
@@ -0,0 +1,266 @@
+package payloads
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// Names of the built in decoders.
+const (
+	TokenBridgeDecoder     = "token-bridge"
+	NFTBridgeDecoder       = "nft-bridge"
+	WormholeRelayerDecoder = "wormhole-relayer"
+	GovernanceDecoder      = "governance"
+)
+
+// builtinDecoders maps the names of the built in decoders to their implementations, so they can be referenced from a decoder file.
+var builtinDecoders = map[string]DecodeFunc{
+	TokenBridgeDecoder:     DecodeTokenBridge,
+	NFTBridgeDecoder:       DecodeNFTBridge,
+	WormholeRelayerDecoder: DecodeWormholeRelayer,
+	GovernanceDecoder:      DecodeGovernance,
+}
+
+var errPayloadTooShort = errors.New("payload too short")
+
+// reader reads big endian fields from a payload. The first error is sticky, so callers only need to check it once at the end.
+type reader struct {
+	buf []byte
+	err error
+}
+
+func (r *reader) next(n int) []byte {
+	if r.err != nil {
+		return make([]byte, n)
+	}
+	if len(r.buf) < n {
+		r.err = errPayloadTooShort
+		return make([]byte, n)
+	}
+	b := r.buf[:n]
+	r.buf = r.buf[n:]
+	return b
+}
+
+func (r *reader) uint8() uint8   { return r.next(1)[0] }
+func (r *reader) uint16() uint16 { return binary.BigEndian.Uint16(r.next(2)) }
+func (r *reader) uint32() uint32 { return binary.BigEndian.Uint32(r.next(4)) }
+func (r *reader) uint64() uint64 { return binary.BigEndian.Uint64(r.next(8)) }
+
+func (r *reader) uint256() string { return new(big.Int).SetBytes(r.next(32)).String() }
+func (r *reader) chain() string   { return vaa.ChainID(r.uint16()).String() }
+func (r *reader) address() string { return hex.EncodeToString(r.next(32)) }
+
+// string32 reads a fixed size string, which is right padded with zeroes on the token bridge and left padded in governance modules.
+func (r *reader) string32() string {
+	return string(bytes.Trim(r.next(32), "\x00"))
+}
+
+func (r *reader) rest() string {
+	if r.err != nil {
+		return ""
+	}
+	b := r.buf
+	r.buf = nil
+	return hex.EncodeToString(b)
+}
+
+// done returns the first error encountered, or an error if there are unread bytes.
+func (r *reader) done() error {
+	if r.err != nil {
+		return r.err
+	}
+	if len(r.buf) != 0 {
+		return fmt.Errorf("%d unexpected trailing bytes", len(r.buf))
+	}
+	return nil
+}
+
+func uintString[T uint8 | uint16 | uint32 | uint64](v T) string {
+	return strconv.FormatUint(uint64(v), 10)
+}
+
+// DecodeTokenBridge decodes token bridge transfers and asset attestations.
+func DecodeTokenBridge(payload []byte) (*Decoded, error) {
+	r := &reader{buf: payload}
+	d := &Decoded{}
+	switch payloadType := r.uint8(); payloadType {
+	case 1, 3:
+		d.Type = "Transfer"
+		d.Fields = []Field{
+			{"amount", r.uint256()},
+			{"tokenAddress", r.address()},
+			{"tokenChain", r.chain()},
+			{"to", r.address()},
+			{"toChain", r.chain()},
+		}
+		if payloadType == 1 {
+			d.Fields = append(d.Fields, Field{"fee", r.uint256()})
+		} else {
+			d.Type = "TransferWithPayload"
+			d.Fields = append(d.Fields, Field{"fromAddress", r.address()}, Field{"payload", r.rest()})
+		}
+	case 2:
+		d.Type = "AssetMeta"
+		d.Fields = []Field{
+			{"tokenAddress", r.address()},
+			{"tokenChain", r.chain()},
+			{"decimals", uintString(r.uint8())},
+			{"symbol", r.string32()},
+			{"name", r.string32()},
+		}
+	default:
+		return nil, fmt.Errorf("unknown payload type %d", payloadType)
+	}
+	if err := r.done(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// DecodeNFTBridge decodes NFT bridge transfers.
+func DecodeNFTBridge(payload []byte) (*Decoded, error) {
+	r := &reader{buf: payload}
+	if payloadType := r.uint8(); payloadType != 1 {
+		return nil, fmt.Errorf("unknown payload type %d", payloadType)
+	}
+	d := &Decoded{Type: "Transfer"}
+	d.Fields = []Field{
+		{"nftAddress", r.address()},
+		{"nftChain", r.chain()},
+		{"symbol", r.string32()},
+		{"name", r.string32()},
+		{"tokenId", r.uint256()},
+	}
+	uri := r.next(int(r.uint8()))
+	d.Fields = append(d.Fields,
+		Field{"uri", string(uri)},
+		Field{"to", r.address()},
+		Field{"toChain", r.chain()},
+	)
+	if err := r.done(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// DecodeWormholeRelayer decodes the fixed part of Wormhole relayer delivery instructions. The message keys which follow the sender
+// are only counted, since they reference other VAAs.
+func DecodeWormholeRelayer(payload []byte) (*Decoded, error) {
+	r := &reader{buf: payload}
+	if payloadId := r.uint8(); payloadId != 1 {
+		return nil, fmt.Errorf("unsupported payload ID %d", payloadId)
+	}
+	d := &Decoded{Type: "DeliveryInstruction"}
+	d.Fields = []Field{
+		{"targetChain", r.chain()},
+		{"targetAddress", r.address()},
+		{"payload", hex.EncodeToString(r.next(int(r.uint32())))},
+		{"requestedReceiverValue", r.uint256()},
+		{"extraReceiverValue", r.uint256()},
+		{"executionInfo", hex.EncodeToString(r.next(int(r.uint32())))},
+		{"refundChain", r.chain()},
+		{"refundAddress", r.address()},
+		{"refundDeliveryProvider", r.address()},
+		{"sourceDeliveryProvider", r.address()},
+		{"senderAddress", r.address()},
+		{"numMessageKeys", uintString(r.uint8())},
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return d, nil
+}
+
+// DecodeGovernance decodes governance messages for the core, token bridge, NFT bridge, Wormhole relayer and chain governor modules. The body of
+// actions without a dedicated layout is rendered as hex.
+func DecodeGovernance(payload []byte) (*Decoded, error) {
+	r := &reader{buf: payload}
+	module := r.string32()
+	action := vaa.GovernanceAction(r.uint8())
+	d := &Decoded{Type: governanceActionName(module, action)}
+	d.Fields = []Field{
+		{"module", module},
+		{"action", uintString(uint8(action))},
+		{"chain", r.chain()},
+	}
+	switch {
+	case module == "Core" && action == vaa.ActionContractUpgrade:
+		d.Fields = append(d.Fields, Field{"newContract", r.address()})
+	case module == "Core" && action == vaa.ActionCoreSetMessageFee:
+		d.Fields = append(d.Fields, Field{"messageFee", r.uint256()})
+	case module == "Core" && action == vaa.ActionCoreTransferFees:
+		d.Fields = append(d.Fields, Field{"amount", r.uint256()}, Field{"recipient", r.address()})
+	case module == "Core" && action == vaa.ActionGuardianSetUpdate:
+		newIndex := r.uint32()
+		numKeys := int(r.uint8())
+		d.Fields = append(d.Fields, Field{"newIndex", uintString(newIndex)})
+		for i := 0; i < numKeys; i++ {
+			d.Fields = append(d.Fields, Field{fmt.Sprintf("key%d", i), common.BytesToAddress(r.next(common.AddressLength)).Hex()})
+		}
+	case (module == "TokenBridge" || module == "NFTBridge") && action == vaa.ActionRegisterChain:
+		d.Fields = append(d.Fields, Field{"emitterChain", r.chain()}, Field{"emitterAddress", r.address()})
+	case (module == "TokenBridge" || module == "NFTBridge") && action == vaa.ActionUpgradeTokenBridge:
+		d.Fields = append(d.Fields, Field{"newContract", r.address()})
+	case module == "WormholeRelayer" && action == vaa.WormholeRelayerSetDefaultDeliveryProvider:
+		d.Fields = append(d.Fields, Field{"newDefaultDeliveryProvider", r.address()})
+	case module == vaa.ChainGovernorModule && action == vaa.ActionChainGovernorSetChainLimit:
+		d.Fields = append(d.Fields,
+			Field{"emitterChain", r.chain()},
+			Field{"dailyLimit", uintString(r.uint64())},
+			Field{"bigTransactionSize", uintString(r.uint64())},
+		)
+	default:
+		if body := r.rest(); body != "" {
+			d.Fields = append(d.Fields, Field{"body", body})
+		}
+	}
+	if err := r.done(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func governanceActionName(module string, action vaa.GovernanceAction) string {
+	switch module {
+	case "Core":
+		switch action {
+		case vaa.ActionContractUpgrade:
+			return "ContractUpgrade"
+		case vaa.ActionGuardianSetUpdate:
+			return "GuardianSetUpdate"
+		case vaa.ActionCoreSetMessageFee:
+			return "SetMessageFee"
+		case vaa.ActionCoreTransferFees:
+			return "TransferFees"
+		case vaa.ActionCoreRecoverChainId:
+			return "RecoverChainId"
+		}
+	case "TokenBridge", "NFTBridge":
+		switch action {
+		case vaa.ActionRegisterChain:
+			return "RegisterChain"
+		case vaa.ActionUpgradeTokenBridge:
+			return "UpgradeContract"
+		case vaa.ActionTokenBridgeRecoverChainId:
+			return "RecoverChainId"
+		}
+	case "WormholeRelayer":
+		if action == vaa.WormholeRelayerSetDefaultDeliveryProvider {
+			return "SetDefaultDeliveryProvider"
+		}
+	case vaa.ChainGovernorModule:
+		if action == vaa.ActionChainGovernorSetChainLimit {
+			return "SetChainLimit"
+		}
+	}
+	return fmt.Sprintf("%sAction%d", module, action)
+}
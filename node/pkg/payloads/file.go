@@ -0,0 +1,145 @@
+package payloads
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// decoderFile is the format of a file registering decoders for additional emitters. Each emitter either references one of the built in
+// decoders, for instance to decode the payloads of a Wormhole relayer, or describes the layout of its payload field by field.
+type decoderFile struct {
+	Decoders []decoderConfig `json:"decoders"`
+}
+
+type decoderConfig struct {
+	Name           string        `json:"name"`
+	EmitterChain   vaa.ChainID   `json:"emitterChain"`
+	EmitterAddress string        `json:"emitterAddress"`
+	Builtin        string        `json:"builtin"`
+	Type           string        `json:"type"`
+	Fields         []layoutField `json:"fields"`
+}
+
+// layoutField is one field of a payload layout. Type is one of uint8, uint16, uint32, uint64, uint256, chain, address, string32, or
+// bytes, which consumes the rest of the payload and so may only be used for the last field.
+type layoutField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// LoadFile registers the decoders described in a JSON file with the default registry.
+func LoadFile(path string) error {
+	return Default.LoadFile(path)
+}
+
+// LoadFile registers the decoders described in a JSON file. Nothing is registered if any entry is invalid.
+func (r *Registry) LoadFile(path string) error {
+	// #nosec G304 -- The path is provided by the operator.
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read payload decoder file: %w", err)
+	}
+	var file decoderFile
+	if err := json.Unmarshal(b, &file); err != nil {
+		return fmt.Errorf("failed to parse payload decoder file: %w", err)
+	}
+
+	type pending struct {
+		emitter vaa.Address
+		decode  DecodeFunc
+	}
+	decoders := make([]pending, 0, len(file.Decoders))
+	for i, cfg := range file.Decoders {
+		if cfg.Name == "" {
+			return fmt.Errorf("decoder %d has no name", i)
+		}
+		emitter, err := vaa.StringToAddress(cfg.EmitterAddress)
+		if err != nil {
+			return fmt.Errorf(`decoder "%s" has an invalid emitter address: %w`, cfg.Name, err)
+		}
+		decode, err := cfg.decodeFunc()
+		if err != nil {
+			return fmt.Errorf(`decoder "%s" is invalid: %w`, cfg.Name, err)
+		}
+		decoders = append(decoders, pending{emitter, decode})
+	}
+
+	for i, cfg := range file.Decoders {
+		if err := r.Register(cfg.EmitterChain, decoders[i].emitter, cfg.Name, decoders[i].decode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (cfg *decoderConfig) decodeFunc() (DecodeFunc, error) {
+	if cfg.Builtin != "" {
+		if len(cfg.Fields) != 0 {
+			return nil, errors.New("may not specify both builtin and fields")
+		}
+		decode, exists := builtinDecoders[cfg.Builtin]
+		if !exists {
+			return nil, fmt.Errorf(`unknown builtin decoder "%s"`, cfg.Builtin)
+		}
+		return decode, nil
+	}
+
+	if len(cfg.Fields) == 0 {
+		return nil, errors.New("must specify either builtin or fields")
+	}
+	for i, f := range cfg.Fields {
+		if f.Name == "" {
+			return nil, fmt.Errorf("field %d has no name", i)
+		}
+		switch f.Type {
+		case "uint8", "uint16", "uint32", "uint64", "uint256", "chain", "address", "string32":
+		case "bytes":
+			if i != len(cfg.Fields)-1 {
+				return nil, fmt.Errorf(`field "%s" of type bytes must be the last field`, f.Name)
+			}
+		default:
+			return nil, fmt.Errorf(`field "%s" has unknown type "%s"`, f.Name, f.Type)
+		}
+	}
+	payloadType := cfg.Type
+	if payloadType == "" {
+		payloadType = "Payload"
+	}
+	fields := cfg.Fields
+	return func(payload []byte) (*Decoded, error) {
+		r := &reader{buf: payload}
+		d := &Decoded{Type: payloadType, Fields: make([]Field, 0, len(fields))}
+		for _, f := range fields {
+			var value string
+			switch f.Type {
+			case "uint8":
+				value = uintString(r.uint8())
+			case "uint16":
+				value = uintString(r.uint16())
+			case "uint32":
+				value = uintString(r.uint32())
+			case "uint64":
+				value = uintString(r.uint64())
+			case "uint256":
+				value = r.uint256()
+			case "chain":
+				value = r.chain()
+			case "address":
+				value = r.address()
+			case "string32":
+				value = r.string32()
+			case "bytes":
+				value = r.rest()
+			}
+			d.Fields = append(d.Fields, Field{f.Name, value})
+		}
+		if err := r.done(); err != nil {
+			return nil, err
+		}
+		return d, nil
+	}, nil
+}
@@ -0,0 +1,177 @@
+// Package payloads decodes the payloads of VAAs and message publications into named fields, so that logs and operator tooling can show
+// what a message contains rather than a hex blob. Decoders are looked up by emitter. The token bridge, NFT bridge and governance
+// emitters known to the SDK are registered by default, and further emitters can be registered at startup, either in code or from a
+// JSON file describing their payload layout.
+package payloads
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/wormhole-foundation/wormhole/sdk"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Field is a single decoded value. Values are rendered as strings so that decoded payloads print the same in logs and on the command line.
+type Field struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Decoded is a decoded payload.
+type Decoded struct {
+	// Decoder is the name of the decoder which produced this, e.g. "token-bridge".
+	Decoder string `json:"decoder"`
+	// Type is the payload type within that decoder, e.g. "Transfer".
+	Type   string  `json:"type"`
+	Fields []Field `json:"fields"`
+}
+
+// DecodeFunc decodes a payload. It should return an error if the payload does not have the expected format.
+type DecodeFunc func(payload []byte) (*Decoded, error)
+
+// String renders the payload on a single line.
+func (d *Decoded) String() string {
+	var sb strings.Builder
+	sb.WriteString(d.Decoder)
+	sb.WriteString("/")
+	sb.WriteString(d.Type)
+	for _, f := range d.Fields {
+		fmt.Fprintf(&sb, " %s=%s", f.Name, f.Value)
+	}
+	return sb.String()
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler.
+func (d *Decoded) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("decoder", d.Decoder)
+	enc.AddString("type", d.Type)
+	for _, f := range d.Fields {
+		enc.AddString(f.Name, f.Value)
+	}
+	return nil
+}
+
+type emitterKey struct {
+	chain   vaa.ChainID
+	emitter vaa.Address
+}
+
+type registration struct {
+	name   string
+	decode DecodeFunc
+}
+
+// Registry maps emitters to the decoders for their payloads. It is safe for concurrent use.
+type Registry struct {
+	mu       sync.RWMutex
+	decoders map[emitterKey]registration
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{decoders: make(map[emitterKey]registration)}
+}
+
+// Register adds a decoder for the payloads emitted by an emitter. Registering a different decoder for an emitter that already has one
+// is an error. Registering the same decoder again is a no-op, since the known emitters of some networks overlap.
+func (r *Registry) Register(chain vaa.ChainID, emitter vaa.Address, name string, decode DecodeFunc) error {
+	key := emitterKey{chain, emitter}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, exists := r.decoders[key]; exists {
+		if existing.name == name {
+			return nil
+		}
+		return fmt.Errorf(`emitter %v/%v already has decoder "%s"`, uint16(chain), emitter, existing.name)
+	}
+	r.decoders[key] = registration{name: name, decode: decode}
+	return nil
+}
+
+// Decode decodes a payload from the given emitter. It returns nil and no error if there is no decoder registered for the emitter.
+func (r *Registry) Decode(chain vaa.ChainID, emitter vaa.Address, payload []byte) (*Decoded, error) {
+	r.mu.RLock()
+	reg, exists := r.decoders[emitterKey{chain, emitter}]
+	r.mu.RUnlock()
+	if !exists {
+		return nil, nil
+	}
+	d, err := reg.decode(payload)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", reg.name, err)
+	}
+	d.Decoder = reg.name
+	return d, nil
+}
+
+// ZapField returns a field that logs the decoded payload. If there is no decoder for the emitter, the field is skipped,
+// and if decoding fails, the error is logged instead.
+func (r *Registry) ZapField(chain vaa.ChainID, emitter vaa.Address, payload []byte) zap.Field {
+	d, err := r.Decode(chain, emitter, payload)
+	if err != nil {
+		return zap.String("payload_decode_error", err.Error())
+	}
+	if d == nil {
+		return zap.Skip()
+	}
+	return zap.Object("payload", d)
+}
+
+// registerKnownEmitters registers the governance emitter and the token and NFT bridge emitters the SDK knows about for every network.
+// The addresses are unique per network, so there is no need to know which network we are on.
+func (r *Registry) registerKnownEmitters() {
+	mustRegister := func(chain vaa.ChainID, emitter vaa.Address, name string, decode DecodeFunc) {
+		if err := r.Register(chain, emitter, name, decode); err != nil {
+			panic(err)
+		}
+	}
+	mustRegister(vaa.GovernanceChain, vaa.GovernanceEmitter, GovernanceDecoder, DecodeGovernance)
+	for _, emitters := range []map[vaa.ChainID][]byte{sdk.KnownTokenbridgeEmitters, sdk.KnownTestnetTokenbridgeEmitters, sdk.KnownDevnetTokenbridgeEmitters} {
+		for chain, emitter := range emitters {
+			mustRegister(chain, addressFromBytes(emitter), TokenBridgeDecoder, DecodeTokenBridge)
+		}
+	}
+	for _, emitters := range []map[vaa.ChainID][]byte{sdk.KnownNFTBridgeEmitters, sdk.KnownTestnetNFTBridgeEmitters, sdk.KnownDevnetNFTBridgeEmitters} {
+		for chain, emitter := range emitters {
+			mustRegister(chain, addressFromBytes(emitter), NFTBridgeDecoder, DecodeNFTBridge)
+		}
+	}
+}
+
+func addressFromBytes(b []byte) vaa.Address {
+	var addr vaa.Address
+	copy(addr[32-len(b):], b)
+	return addr
+}
+
+// Default is the registry used by the guardian and its command line tools. It starts out with the known emitters registered.
+var Default = func() *Registry {
+	r := NewRegistry()
+	r.registerKnownEmitters()
+	return r
+}()
+
+// Register adds a decoder to the default registry. It should be called at startup.
+func Register(chain vaa.ChainID, emitter vaa.Address, name string, decode DecodeFunc) error {
+	return Default.Register(chain, emitter, name, decode)
+}
+
+// Decode decodes a payload using the default registry.
+func Decode(chain vaa.ChainID, emitter vaa.Address, payload []byte) (*Decoded, error) {
+	return Default.Decode(chain, emitter, payload)
+}
+
+// ZapField returns a field that logs the payload decoded using the default registry.
+func ZapField(chain vaa.ChainID, emitter vaa.Address, payload []byte) zap.Field {
+	return Default.ZapField(chain, emitter, payload)
+}
+
+// ZapFieldForMessage is a convenience wrapper around ZapField for message publications.
+func ZapFieldForMessage(msg *common.MessagePublication) zap.Field {
+	return Default.ZapField(msg.EmitterChain, msg.EmitterAddress, msg.Payload)
+}
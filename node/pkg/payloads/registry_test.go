@@ -0,0 +1,161 @@
+package payloads
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func fieldValue(t *testing.T, d *Decoded, name string) string {
+	t.Helper()
+	for _, f := range d.Fields {
+		if f.Name == name {
+			return f.Value
+		}
+	}
+	t.Fatalf("no field %s in %s", name, d)
+	return ""
+}
+
+func TestDecodeTokenBridgeTransfer(t *testing.T) {
+	payload, err := hex.DecodeString(
+		"01" +
+			"00000000000000000000000000000000000000000000000000000000000f4240" + // amount
+			"ec7372995d5cc8732397fb0ad35c0121e0eaa90d26f828a534cab54391b3a4f5" + // token address
+			"0001" + // token chain
+			"0000000000000000000000000290fb167208af455bb137780163b7b7a9a10c16" + // to
+			"0002" + // to chain
+			"0000000000000000000000000000000000000000000000000000000000000000") // fee
+	require.NoError(t, err)
+
+	emitter := addressFromBytes(sdk.KnownTokenbridgeEmitters[vaa.ChainIDSolana])
+	d, err := Decode(vaa.ChainIDSolana, emitter, payload)
+	require.NoError(t, err)
+	require.NotNil(t, d)
+	assert.Equal(t, TokenBridgeDecoder, d.Decoder)
+	assert.Equal(t, "Transfer", d.Type)
+	assert.Equal(t, "1000000", fieldValue(t, d, "amount"))
+	assert.Equal(t, "solana", fieldValue(t, d, "tokenChain"))
+	assert.Equal(t, vaa.ChainID(2).String(), fieldValue(t, d, "toChain"))
+	assert.Equal(t, "0", fieldValue(t, d, "fee"))
+
+	// Truncated and unknown payloads are errors.
+	_, err = Decode(vaa.ChainIDSolana, emitter, payload[:50])
+	assert.ErrorContains(t, err, "payload too short")
+	_, err = Decode(vaa.ChainIDSolana, emitter, []byte{9})
+	assert.ErrorContains(t, err, "unknown payload type 9")
+
+	// There is nothing to decode for an unknown emitter.
+	d, err = Decode(vaa.ChainIDSolana, vaa.Address{1}, payload)
+	require.NoError(t, err)
+	assert.Nil(t, d)
+}
+
+func TestDecodeGovernance(t *testing.T) {
+	keys := []common.Address{common.HexToAddress("0xbeFA429d57cD18b7F8A4d91A2da9AB4AF05d0FBe"), common.HexToAddress("0x88D7D8B32a9105d228100E72dFFe2Fae0705D31c")}
+	payload := vaa.BodyGuardianSetUpdate{Keys: keys, NewIndex: 3}.Serialize()
+
+	d, err := Decode(vaa.GovernanceChain, vaa.GovernanceEmitter, payload)
+	require.NoError(t, err)
+	require.NotNil(t, d)
+	assert.Equal(t, "GuardianSetUpdate", d.Type)
+	assert.Equal(t, "Core", fieldValue(t, d, "module"))
+	assert.Equal(t, "3", fieldValue(t, d, "newIndex"))
+	assert.Equal(t, keys[0].Hex(), fieldValue(t, d, "key0"))
+	assert.Equal(t, keys[1].Hex(), fieldValue(t, d, "key1"))
+
+	payload = vaa.BodyTokenBridgeRegisterChain{Module: "TokenBridge", ChainID: vaa.ChainIDSolana, EmitterAddress: vaa.Address{1}}.Serialize()
+	d, err = Decode(vaa.GovernanceChain, vaa.GovernanceEmitter, payload)
+	require.NoError(t, err)
+	assert.Equal(t, "RegisterChain", d.Type)
+	assert.Equal(t, "solana", fieldValue(t, d, "emitterChain"))
+
+	payload = vaa.BodyCoreTransferFees{ChainID: vaa.ChainIDSolana, Amount: uint256.NewInt(5000), Recipient: vaa.Address{1}}.Serialize()
+	d, err = Decode(vaa.GovernanceChain, vaa.GovernanceEmitter, payload)
+	require.NoError(t, err)
+	assert.Equal(t, "TransferFees", d.Type)
+	assert.Equal(t, "solana", fieldValue(t, d, "chain"))
+	assert.Equal(t, "5000", fieldValue(t, d, "amount"))
+	assert.Equal(t, "01"+strings.Repeat("00", 31), fieldValue(t, d, "recipient"))
+
+	payload = vaa.BodyChainGovernorSetChainLimit{EmitterChainID: vaa.ChainIDSolana, DailyLimit: 1000, BigTransactionSize: 100}.Serialize()
+	d, err = Decode(vaa.GovernanceChain, vaa.GovernanceEmitter, payload)
+	require.NoError(t, err)
+	assert.Equal(t, "SetChainLimit", d.Type)
+	assert.Equal(t, "solana", fieldValue(t, d, "emitterChain"))
+	assert.Equal(t, "1000", fieldValue(t, d, "dailyLimit"))
+	assert.Equal(t, "100", fieldValue(t, d, "bigTransactionSize"))
+}
+
+func TestRegistryRegister(t *testing.T) {
+	r := NewRegistry()
+	require.NoError(t, r.Register(vaa.ChainIDSolana, vaa.Address{1}, TokenBridgeDecoder, DecodeTokenBridge))
+	require.NoError(t, r.Register(vaa.ChainIDSolana, vaa.Address{1}, TokenBridgeDecoder, DecodeTokenBridge))
+	assert.Error(t, r.Register(vaa.ChainIDSolana, vaa.Address{1}, NFTBridgeDecoder, DecodeNFTBridge))
+}
+
+func TestRegistryZapField(t *testing.T) {
+	r := NewRegistry()
+	require.NoError(t, r.LoadFile(writeDecoderFile(t, `{"decoders": [{"name": "my-app", "emitterChain": 1, "emitterAddress": "0x01", "type": "Ping", "fields": [{"name": "count", "type": "uint16"}]}]}`)))
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := zap.New(core)
+	emitter := addressFromBytes([]byte{1})
+	logger.Info("decodable", r.ZapField(vaa.ChainIDSolana, emitter, []byte{0, 7}))
+	logger.Info("undecodable", r.ZapField(vaa.ChainIDSolana, emitter, []byte{0}))
+	logger.Info("unknown", r.ZapField(vaa.ChainIDSolana, vaa.Address{2}, []byte{0}))
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 3)
+	assert.Equal(t, map[string]interface{}{"decoder": "my-app", "type": "Ping", "count": "7"}, entries[0].ContextMap()["payload"])
+	assert.Equal(t, "my-app: payload too short", entries[1].ContextMap()["payload_decode_error"])
+	assert.Empty(t, entries[2].ContextMap())
+}
+
+func writeDecoderFile(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "decoders.json")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+	return path
+}
+
+func TestLoadFile(t *testing.T) {
+	r := NewRegistry()
+	require.NoError(t, r.LoadFile(writeDecoderFile(t, `{"decoders": [
+		{"name": "relayer", "emitterChain": 1, "emitterAddress": "0x27428dd2d3dd32a4d7f7c497eaaa23130d894911", "builtin": "wormhole-relayer"},
+		{"name": "my-app", "emitterChain": 1, "emitterAddress": "0x01", "fields": [
+			{"name": "kind", "type": "uint8"},
+			{"name": "recipient", "type": "address"},
+			{"name": "memo", "type": "bytes"}
+		]}
+	]}`)))
+
+	d, err := r.Decode(vaa.ChainIDSolana, addressFromBytes([]byte{1}), append([]byte{2}, append(make([]byte, 32), 0xab, 0xcd)...))
+	require.NoError(t, err)
+	require.NotNil(t, d)
+	assert.Equal(t, "my-app/Payload kind=2 recipient="+hex.EncodeToString(make([]byte, 32))+" memo=abcd", d.String())
+
+	for name, contents := range map[string]string{
+		"no name":          `{"decoders": [{"emitterChain": 1, "emitterAddress": "0x01", "builtin": "token-bridge"}]}`,
+		"unknown builtin":  `{"decoders": [{"name": "x", "emitterChain": 1, "emitterAddress": "0x01", "builtin": "bogus"}]}`,
+		"no layout":        `{"decoders": [{"name": "x", "emitterChain": 1, "emitterAddress": "0x01"}]}`,
+		"unknown type":     `{"decoders": [{"name": "x", "emitterChain": 1, "emitterAddress": "0x01", "fields": [{"name": "a", "type": "int8"}]}]}`,
+		"bytes not last":   `{"decoders": [{"name": "x", "emitterChain": 1, "emitterAddress": "0x01", "fields": [{"name": "a", "type": "bytes"}, {"name": "b", "type": "uint8"}]}]}`,
+		"bad emitter":      `{"decoders": [{"name": "x", "emitterChain": 1, "emitterAddress": "zz", "builtin": "token-bridge"}]}`,
+		"conflicting name": `{"decoders": [{"name": "y", "emitterChain": 1, "emitterAddress": "0x27428dd2d3dd32a4d7f7c497eaaa23130d894911", "builtin": "token-bridge"}]}`,
+	} {
+		assert.Error(t, r.LoadFile(writeDecoderFile(t, contents)), name)
+	}
+}
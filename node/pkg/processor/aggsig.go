@@ -0,0 +1,156 @@
+package processor
+
+import (
+	"bytes"
+	"encoding/hex"
+
+	"github.com/certusone/wormhole/node/pkg/aggsig"
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+var aggregatedAttestationsTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "wormhole_aggregated_attestations_total",
+		Help: "Total number of experimental aggregated attestations attempted for VAAs that reached quorum, by result",
+	}, []string{"result"})
+
+// aggSigPeerKey is a guardian's BLS public key as advertised in its heartbeat.
+type aggSigPeerKey struct {
+	raw []byte
+	pk  *aggsig.PublicKey
+}
+
+// guardianAggSigKey returns the BLS public key of a guardian, or nil if it has not advertised a valid one.
+func (p *Processor) guardianAggSigKey(addr ethcommon.Address) *aggsig.PublicKey {
+	// Aggregation shards look keys up concurrently.
+	p.aggSigKeysMu.Lock()
+	defer p.aggSigKeysMu.Unlock()
+
+	cached := p.aggSigKeys[addr]
+	if addr == p.ourAddr {
+		if cached == nil {
+			pk := p.aggSigKey.PublicKey()
+			cached = &aggSigPeerKey{raw: pk.Marshal(), pk: pk}
+			p.aggSigKeys[addr] = cached
+		}
+		return cached.pk
+	}
+	if p.gst == nil {
+		return nil
+	}
+
+	for _, hb := range p.gst.LastHeartbeat(addr) {
+		if len(hb.AggsigPublicKey) == 0 {
+			continue
+		}
+		if cached != nil && bytes.Equal(cached.raw, hb.AggsigPublicKey) {
+			return cached.pk
+		}
+
+		pk, err := aggsig.UnmarshalPublicKey(hb.AggsigPublicKey)
+		if err != nil {
+			p.logger.Warn("guardian advertised an invalid aggsig public key", zap.Stringer("guardian", addr), zap.Error(err))
+			continue
+		}
+		pop, err := aggsig.UnmarshalSignature(hb.AggsigProofOfPossession)
+		if err != nil || !aggsig.VerifyProofOfPossession(pk, pop) {
+			p.logger.Warn("guardian advertised an aggsig public key without a valid proof of possession", zap.Stringer("guardian", addr))
+			continue
+		}
+		p.aggSigKeys[addr] = &aggSigPeerKey{raw: hb.AggsigPublicKey, pk: pk}
+		return pk
+	}
+	return nil
+}
+
+// attestAggregated aggregates the BLS signature shares collected for a VAA that reached quorum and stores the result next to the
+// VAA. Shares from guardians without a known public key and invalid shares are left out. If that leaves fewer signers than a
+// quorum, no attestation is produced.
+func (p *Processor) attestAggregated(s *state, gs *common.GuardianSet, digest []byte) {
+	v, ok := s.ourObservation.(*VAA)
+	if !ok {
+		return
+	}
+	quorum := vaa.CalculateQuorum(len(gs.Keys))
+
+	type share struct {
+		index int
+		sig   *aggsig.Signature
+		pk    *aggsig.PublicKey
+	}
+	var shares []share
+	for i, addr := range gs.Keys {
+		b, ok := s.aggSigShares[addr]
+		if !ok {
+			continue
+		}
+		pk := p.guardianAggSigKey(addr)
+		if pk == nil {
+			continue
+		}
+		sig, err := aggsig.UnmarshalSignature(b)
+		if err != nil {
+			continue
+		}
+		shares = append(shares, share{index: i, sig: sig, pk: pk})
+	}
+
+	aggregate := func() (*aggsig.Signature, *aggsig.PublicKey, uint32) {
+		sigs := make([]*aggsig.Signature, len(shares))
+		pks := make([]*aggsig.PublicKey, len(shares))
+		var signers uint32
+		for i, sh := range shares {
+			sigs[i] = sh.sig
+			pks[i] = sh.pk
+			signers |= 1 << sh.index
+		}
+		return aggsig.AggregateSignatures(sigs), aggsig.AggregatePublicKeys(pks), signers
+	}
+
+	hash := hex.EncodeToString(digest)
+	if len(shares) < quorum {
+		aggregatedAttestationsTotal.WithLabelValues("insufficient_shares").Inc()
+		p.logger.Debug("not enough aggsig shares for an aggregated attestation",
+			zap.String("digest", hash), zap.Int("shares", len(shares)), zap.Int("quorum", quorum))
+		return
+	}
+
+	sig, pk, signers := aggregate()
+	if !aggsig.Verify(pk, digest, sig) {
+		// At least one share is invalid. Checking them individually costs a pairing each, so it is only done when needed.
+		valid := shares[:0]
+		for _, sh := range shares {
+			if aggsig.Verify(sh.pk, digest, sh.sig) {
+				valid = append(valid, sh)
+			} else {
+				p.logger.Warn("invalid aggsig share", zap.String("digest", hash), zap.Stringer("guardian", gs.Keys[sh.index]))
+			}
+		}
+		shares = valid
+		if len(shares) < quorum {
+			aggregatedAttestationsTotal.WithLabelValues("invalid_shares").Inc()
+			return
+		}
+		sig, _, signers = aggregate()
+	}
+
+	attestation := &aggsig.Attestation{GuardianSetIndex: gs.Index, Signers: signers, Signature: sig}
+	if err := p.db.StoreAggregatedAttestation(*db.VaaIDFromVAA(&v.VAA), v.Timestamp, attestation.Marshal()); err != nil {
+		aggregatedAttestationsTotal.WithLabelValues("store_failed").Inc()
+		p.logger.Error("failed to store aggregated attestation", zap.String("digest", hash), zap.Error(err))
+		return
+	}
+	aggregatedAttestationsTotal.WithLabelValues("produced").Inc()
+	p.logger.Info("produced aggregated attestation",
+		zap.String("digest", hash),
+		zap.String("message_id", v.MessageID()),
+		zap.Int("signers", len(shares)),
+		zap.Int("size", aggsig.AttestationLength),
+	)
+}
@@ -0,0 +1,86 @@
+package processor
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/benbjohnson/clock"
+	"github.com/certusone/wormhole/node/pkg/aggsig"
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestAttestAggregated(t *testing.T) {
+	database, err := db.OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer database.Close()
+
+	gs := &common.GuardianSet{Index: 1}
+	keys := make([]*aggsig.SecretKey, 4)
+	for i := range keys {
+		gk, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+		require.NoError(t, err)
+		keys[i] = aggsig.DeriveSecretKey(gk)
+		gs.Keys = append(gs.Keys, crypto.PubkeyToAddress(gk.PublicKey))
+	}
+
+	p := &Processor{
+		clock:      clock.New(),
+		logger:     zap.NewNop(),
+		db:         database,
+		gst:        common.NewGuardianSetState(nil),
+		ourAddr:    gs.Keys[0],
+		aggSigKey:  keys[0],
+		aggSigKeys: make(map[ethcommon.Address]*aggSigPeerKey),
+	}
+	// The other guardians advertise their keys in heartbeats. The last one's proof of possession is for a different key.
+	for i := 1; i < len(keys); i++ {
+		pop := keys[i].ProofOfPossession()
+		if i == len(keys)-1 {
+			pop = keys[0].ProofOfPossession()
+		}
+		require.NoError(t, p.gst.SetHeartbeat(gs.Keys[i], peer.ID(fmt.Sprint(i)), &gossipv1.Heartbeat{
+			GuardianAddr:            gs.Keys[i].Hex(),
+			AggsigPublicKey:         keys[i].PublicKey().Marshal(),
+			AggsigProofOfPossession: pop.Marshal(),
+		}))
+	}
+
+	v := getVAA()
+	digest := v.SigningDigest().Bytes()
+	id := db.VaaIDFromVAA(&v)
+	s := &state{ourObservation: &VAA{VAA: v}, aggSigShares: make(map[ethcommon.Address][]byte)}
+
+	// Two valid shares, an invalid one and one from a key without a valid proof of possession are not enough for a quorum of three.
+	s.aggSigShares[gs.Keys[0]] = keys[0].Sign(digest).Marshal()
+	s.aggSigShares[gs.Keys[1]] = keys[1].Sign(digest).Marshal()
+	s.aggSigShares[gs.Keys[2]] = keys[2].Sign([]byte("something else")).Marshal()
+	s.aggSigShares[gs.Keys[3]] = keys[3].Sign(digest).Marshal()
+	p.attestAggregated(s, gs, digest)
+	_, err = database.GetAggregatedAttestation(*id)
+	assert.ErrorIs(t, err, db.ErrAttestationNotFound)
+
+	s.aggSigShares[gs.Keys[2]] = keys[2].Sign(digest).Marshal()
+	p.attestAggregated(s, gs, digest)
+	b, err := database.GetAggregatedAttestation(*id)
+	require.NoError(t, err)
+	attestation, err := aggsig.UnmarshalAttestation(b)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), attestation.GuardianSetIndex)
+	assert.Equal(t, uint32(0b0111), attestation.Signers)
+
+	pks := make([]*aggsig.PublicKey, len(keys))
+	for i := range keys {
+		pks[i] = keys[i].PublicKey()
+	}
+	assert.True(t, attestation.Verify(digest, pks))
+}
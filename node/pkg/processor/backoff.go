@@ -1,13 +1,121 @@
 package processor
 
 import (
+	"errors"
+	"fmt"
+	"math"
 	mathrand "math/rand"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 )
 
-func nextRetryDuration(ctr uint) time.Duration {
-	m := 1 << ctr
-	wait := FirstRetryMinWait * time.Duration(m)
+// ReobservationPolicy controls when the processor asks the network to re-observe a message it signed that has not reached quorum.
+type ReobservationPolicy struct {
+	// InitialDelay is the time after a message was first seen before re-observation is requested.
+	InitialDelay time.Duration
+	// Backoff is the factor by which the delay grows after every request. A random jitter of up to the delay is added to it.
+	Backoff float64
+	// MaxAttempts is the maximum number of re-observation requests for a message, or zero for no limit. The processor keeps
+	// waiting for signatures after the last attempt until the observation expires.
+	MaxAttempts uint
+}
+
+// ReobservationConfig is the re-observation policy for all chains, with optional per chain overrides.
+type ReobservationConfig struct {
+	Default ReobservationPolicy
+	Chains  map[vaa.ChainID]ReobservationPolicy
+}
+
+// DefaultReobservationPolicy returns the policy used when none is configured: wait FirstRetryMinWait, then double the delay
+// after every attempt, for as long as the observation is kept.
+func DefaultReobservationPolicy() ReobservationPolicy {
+	return ReobservationPolicy{
+		InitialDelay: FirstRetryMinWait,
+		Backoff:      2,
+	}
+}
+
+// Validate checks that the policy would result in sensible retry intervals.
+func (p ReobservationPolicy) Validate() error {
+	if p.InitialDelay <= 0 {
+		return errors.New("initial delay must be positive")
+	}
+	if p.Backoff < 1 || math.IsInf(p.Backoff, 0) || math.IsNaN(p.Backoff) {
+		return errors.New("backoff must be at least 1")
+	}
+	return nil
+}
+
+// policy returns the policy for a chain, falling back to the default policy. It may be called on a nil config.
+func (c *ReobservationConfig) policy(chainID vaa.ChainID) ReobservationPolicy {
+	if c == nil {
+		return DefaultReobservationPolicy()
+	}
+	if p, ok := c.Chains[chainID]; ok {
+		return p
+	}
+	return c.Default
+}
+
+// nextRetryDuration returns how long to wait before the next re-observation request, after ctr requests have been made.
+func (p ReobservationPolicy) nextRetryDuration(ctr uint) time.Duration {
+	wait := time.Duration(float64(p.InitialDelay) * math.Pow(p.Backoff, float64(ctr)))
+	if wait <= 0 {
+		// The multiplication overflowed.
+		wait = time.Duration(math.MaxInt64 / 2)
+	}
 	jitter := time.Duration(mathrand.Int63n(int64(wait))) // nolint:gosec
 	return wait + jitter
 }
+
+// attemptsExhausted returns true if no more re-observation requests may be made after ctr requests.
+func (p ReobservationPolicy) attemptsExhausted(ctr uint) bool {
+	return p.MaxAttempts != 0 && ctr >= p.MaxAttempts
+}
+
+// ParseReobservationOverrides parses a comma separated list of per chain re-observation policies of the form
+// chain:initialDelay:backoff:maxAttempts, such as "solana:1m:2:10,21:1h:1.5:0", where the chain may be specified by name or by ID.
+func ParseReobservationOverrides(str string) (map[vaa.ChainID]ReobservationPolicy, error) {
+	ret := make(map[vaa.ChainID]ReobservationPolicy)
+	if str == "" {
+		return ret, nil
+	}
+
+	for _, entry := range strings.Split(str, ",") {
+		parts := strings.Split(strings.TrimSpace(entry), ":")
+		if len(parts) != 4 {
+			return nil, fmt.Errorf(`invalid re-observation policy "%s", must be of the form chain:initialDelay:backoff:maxAttempts`, entry)
+		}
+
+		chainID, err := parseChain(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf(`invalid chain in re-observation policy "%s": %w`, entry, err)
+		}
+
+		var policy ReobservationPolicy
+		if policy.InitialDelay, err = time.ParseDuration(parts[1]); err != nil {
+			return nil, fmt.Errorf(`invalid initial delay in re-observation policy "%s": %w`, entry, err)
+		}
+		if policy.Backoff, err = strconv.ParseFloat(parts[2], 64); err != nil {
+			return nil, fmt.Errorf(`invalid backoff in re-observation policy "%s": %w`, entry, err)
+		}
+		maxAttempts, err := strconv.ParseUint(parts[3], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf(`invalid max attempts in re-observation policy "%s": %w`, entry, err)
+		}
+		policy.MaxAttempts = uint(maxAttempts)
+		if err := policy.Validate(); err != nil {
+			return nil, fmt.Errorf(`invalid re-observation policy "%s": %w`, entry, err)
+		}
+
+		if _, exists := ret[chainID]; exists {
+			return nil, fmt.Errorf(`duplicate re-observation policy for chain %s`, chainID)
+		}
+		ret[chainID] = policy
+	}
+
+	return ret, nil
+}
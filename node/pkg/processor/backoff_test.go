@@ -5,9 +5,12 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 )
 
 func TestBackoff(t *testing.T) {
+	nextRetryDuration := DefaultReobservationPolicy().nextRetryDuration
 	for i := 0; i < 10; i++ {
 		assert.Greater(t, FirstRetryMinWait*1*2+time.Second, nextRetryDuration(0))
 		assert.Less(t, FirstRetryMinWait*1-time.Second, nextRetryDuration(0))
@@ -25,3 +28,47 @@ func TestBackoff(t *testing.T) {
 		assert.Less(t, FirstRetryMinWait*1024-time.Second, nextRetryDuration(10))
 	}
 }
+
+func TestReobservationPolicy(t *testing.T) {
+	policy := ReobservationPolicy{InitialDelay: time.Minute, Backoff: 1.5, MaxAttempts: 3}
+	for i := 0; i < 10; i++ {
+		assert.LessOrEqual(t, time.Minute, policy.nextRetryDuration(0))
+		assert.Greater(t, 2*time.Minute, policy.nextRetryDuration(0))
+
+		assert.LessOrEqual(t, 135*time.Second, policy.nextRetryDuration(2))
+		assert.Greater(t, 270*time.Second, policy.nextRetryDuration(2))
+	}
+	assert.Positive(t, policy.nextRetryDuration(1000))
+
+	assert.False(t, policy.attemptsExhausted(2))
+	assert.True(t, policy.attemptsExhausted(3))
+	assert.False(t, DefaultReobservationPolicy().attemptsExhausted(1000))
+
+	cfg := &ReobservationConfig{
+		Default: policy,
+		Chains:  map[vaa.ChainID]ReobservationPolicy{vaa.ChainID(42): {InitialDelay: time.Hour, Backoff: 1}},
+	}
+	assert.Equal(t, policy, cfg.policy(vaa.ChainIDSolana))
+	assert.Equal(t, time.Hour, cfg.policy(vaa.ChainID(42)).InitialDelay)
+
+	var unset *ReobservationConfig
+	assert.Equal(t, DefaultReobservationPolicy(), unset.policy(vaa.ChainIDSolana))
+}
+
+func TestParseReobservationOverrides(t *testing.T) {
+	overrides, err := ParseReobservationOverrides("")
+	require.NoError(t, err)
+	assert.Empty(t, overrides)
+
+	overrides, err = ParseReobservationOverrides("solana:1m:2:10, 42:1h:1.5:0")
+	require.NoError(t, err)
+	assert.Equal(t, map[vaa.ChainID]ReobservationPolicy{
+		vaa.ChainIDSolana: {InitialDelay: time.Minute, Backoff: 2, MaxAttempts: 10},
+		vaa.ChainID(42):   {InitialDelay: time.Hour, Backoff: 1.5},
+	}, overrides)
+
+	for _, str := range []string{"solana:1m:2", "bogus:1m:2:1", "solana:soon:2:1", "solana:0s:2:1", "solana:1m:0.5:1", "solana:1m:2:-1", "solana:1m:2:1,1:1m:2:1"} {
+		_, err := ParseReobservationOverrides(str)
+		assert.Error(t, err, str)
+	}
+}
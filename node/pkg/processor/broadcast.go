@@ -2,7 +2,6 @@ package processor
 
 import (
 	"encoding/hex"
-	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -37,6 +36,9 @@ func (p *Processor) broadcastSignature(
 		TxHash:    txhash,
 		MessageId: o.MessageID(),
 	}
+	if p.aggSigKey != nil {
+		obsv.AggsigSignature = p.aggSigKey.Sign(digest.Bytes()).Marshal()
+	}
 
 	w := gossipv1.GossipMessage{Message: &gossipv1.GossipMessage_SignedObservation{SignedObservation: &obsv}}
 
@@ -45,25 +47,51 @@ func (p *Processor) broadcastSignature(
 		panic(err)
 	}
 
-	p.gossipSendC <- msg
+	if !p.shadow {
+		p.gossipSendC <- msg
+	}
 
 	// Store our VAA in case we're going to submit it to Solana
 	hash := hex.EncodeToString(digest.Bytes())
 
-	if p.state.signatures[hash] == nil {
-		p.state.signatures[hash] = &state{
-			firstObserved: time.Now(),
-			nextRetry:     time.Now().Add(nextRetryDuration(0)),
+	sh := p.state.shardFor(o.MessageID())
+	sh.mu.Lock()
+	s := sh.signatures[hash]
+	conflict := false
+	if s == nil {
+		s = &state{
+			firstObserved: p.clock.Now(),
+			nextRetry:     p.clock.Now().Add(p.reobservation.policy(o.GetEmitterChain()).nextRetryDuration(0)),
 			signatures:    map[ethcommon.Address][]byte{},
 			source:        "loopback",
+			messageID:     o.MessageID(),
 		}
+		conflict = sh.add(hash, s)
+	} else if s.messageID != o.MessageID() {
+		// The entry was created for an observation by another guardian that claimed a different message ID.
+		sh.unindex(hash, s.messageID)
+		s.messageID = o.MessageID()
+		conflict = sh.index(hash, s.messageID)
 	}
 
-	p.state.signatures[hash].ourObservation = o
-	p.state.signatures[hash].ourMsg = msg
-	p.state.signatures[hash].txHash = txhash
-	p.state.signatures[hash].source = o.GetEmitterChain().String()
-	p.state.signatures[hash].gs = p.gs // guaranteed to match ourObservation - there's no concurrent access to p.gs
+	if s.ourObservation == nil {
+		s.ourObservedAt = p.clock.Now()
+	}
+	s.ourObservation = o
+	s.ourMsg = msg
+	s.txHash = txhash
+	s.source = o.GetEmitterChain().String()
+	s.gs = p.gs // guaranteed to match ourObservation - broadcastSignature is only called from the processor goroutine
+	s.dirty = true
+	if conflict {
+		p.reportConflict(sh, s.messageID)
+	}
+	sh.mu.Unlock()
+
+	if p.shadow {
+		// Our signature does not count towards quorum, so there is no point aggregating it.
+		return
+	}
 
 	// Fast path for our own signature
 	// send to obsvC directly if there is capacity, otherwise do it in a go routine.
@@ -79,6 +107,10 @@ func (p *Processor) broadcastSignature(
 }
 
 func (p *Processor) broadcastSignedVAA(v *vaa.VAA) {
+	if p.shadow {
+		return
+	}
+
 	b, err := v.Marshal()
 	if err != nil {
 		panic(err)
@@ -4,6 +4,7 @@ package processor
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"time"
 
@@ -67,13 +68,35 @@ var (
 	FirstRetryMinWait = time.Minute * 5
 )
 
+// errHashMismatch is returned by signedVaaAlreadyInDB if the stored VAA has a different digest than our observation.
+var errHashMismatch = errors.New("hash mismatch")
+
 // handleCleanup handles periodic retransmissions and cleanup of observations
 func (p *Processor) handleCleanup(ctx context.Context) {
-	p.logger.Info("aggregation state summary", zap.Int("cached", len(p.state.signatures)))
-	aggregationStateEntries.Set(float64(len(p.state.signatures)))
+	size := p.state.size()
+	p.logger.Info("aggregation state summary", zap.Int("cached", size))
+	aggregationStateEntries.Set(float64(size))
+	p.pendingObservations.Store(int64(size))
+
+	nearQuorum := 0
+	for _, sh := range p.state.shards {
+		nearQuorum += p.cleanupShard(sh)
+	}
+	nearQuorumObservations.Set(float64(nearQuorum))
+}
+
+// cleanupShard runs the periodic cleanup of a single shard with its lock held, and returns the number of its observations that
+// are near quorum.
+func (p *Processor) cleanupShard(sh *stateShard) (nearQuorum int) {
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
 
-	for hash, s := range p.state.signatures {
-		delta := time.Since(s.firstObserved)
+	for hash, s := range sh.signatures {
+		delta := p.clock.Since(s.firstObserved)
+
+		if p.checkNearQuorum(hash, s, delta) {
+			nearQuorum++
+		}
 
 		if !s.submitted && s.ourObservation != nil && delta > settlementTime {
 			// Expire pending VAAs post settlement time if we have a stored quorum VAA.
@@ -87,8 +110,9 @@ func (p *Processor) handleCleanup(ctx context.Context) {
 					// This is a rare case, and we can safely expire the state, since we
 					// have a quorum VAA.
 					p.logger.Info("Expiring late VAA", zap.String("digest", hash), zap.Duration("delta", delta))
+					p.checkShadowVAA(hash, s)
 					aggregationStateLate.Inc()
-					delete(p.state.signatures, hash)
+					sh.remove(hash)
 					continue
 				}
 			}
@@ -100,6 +124,7 @@ func (p *Processor) handleCleanup(ctx context.Context) {
 			// arrive, barring special circumstances. This is a better time to count misses than submission,
 			// because we submit right when we quorum rather than waiting for all observations to arrive.
 			s.settled = true
+			s.dirty = true
 
 			// Use either the most recent (in case of a observation we haven't seen) or stored gs, if available.
 			var gs *common.GuardianSet
@@ -140,14 +165,15 @@ func (p *Processor) handleCleanup(ctx context.Context) {
 			// If a very late observation arrives after cleanup, a nil aggregation state will be created
 			// and then expired after a while (as noted in observation.go, this can be abused by a byzantine guardian).
 			p.logger.Debug("expiring submitted observation", zap.String("digest", hash), zap.Duration("delta", delta))
-			delete(p.state.signatures, hash)
+			sh.remove(hash)
 			aggregationStateExpiration.Inc()
 		case !s.submitted && ((s.ourMsg != nil && delta > retryLimitOurs) || (s.ourMsg == nil && delta > retryLimitNotOurs)):
 			// Clearly, this horse is dead and continued beatings won't bring it closer to quorum.
 			p.logger.Info("expiring unsubmitted observation after exhausting retries", zap.String("digest", hash), zap.Duration("delta", delta), zap.Bool("weObserved", s.ourMsg != nil))
-			delete(p.state.signatures, hash)
+			p.recordShadowResult(s, shadowUnconfirmed)
+			sh.remove(hash)
 			aggregationStateTimeout.Inc()
-		case !s.submitted && delta >= FirstRetryMinWait && time.Since(s.nextRetry) >= 0:
+		case !s.submitted && delta >= p.reobservationPolicy(s).InitialDelay && p.clock.Since(s.nextRetry) >= 0:
 			// Poor observation has been unsubmitted for five minutes - clearly, something went wrong.
 			// If we have previously submitted an observation, and it was reliable, we can make another attempt to get
 			// it over the finish line by sending a re-observation request to the network and rebroadcasting our
@@ -158,7 +184,8 @@ func (p *Processor) handleCleanup(ctx context.Context) {
 				// Unreliable observations cannot be resubmitted and can be considered failed after 5 minutes
 				if !s.ourObservation.IsReliable() {
 					p.logger.Info("expiring unsubmitted unreliable observation", zap.String("digest", hash), zap.Duration("delta", delta))
-					delete(p.state.signatures, hash)
+					p.recordShadowResult(s, shadowUnconfirmed)
+					sh.remove(hash)
 					aggregationStateTimeout.Inc()
 					break
 				}
@@ -169,6 +196,17 @@ func (p *Processor) handleCleanup(ctx context.Context) {
 					break
 				}
 
+				// Shadow guardians do not take part in consensus, so they do not ask for re-observations either.
+				if p.shadow {
+					break
+				}
+
+				// Likewise once the chain's policy allows no more attempts.
+				if p.reobservationPolicy(s).attemptsExhausted(s.retryCtr) {
+					p.logger.Debug("not submitting reobservation request after exhausting attempts", zap.String("digest", hash), zap.Uint("attempts", s.retryCtr))
+					break
+				}
+
 				// If we have already stored this VAA, there is no reason for us to request reobservation.
 				alreadyInDB, err := p.signedVaaAlreadyInDB(hash, s)
 				if err != nil {
@@ -192,7 +230,8 @@ func (p *Processor) handleCleanup(ctx context.Context) {
 					}
 					p.gossipSendC <- s.ourMsg
 					s.retryCtr++
-					s.nextRetry = time.Now().Add(nextRetryDuration(s.retryCtr))
+					s.nextRetry = p.clock.Now().Add(p.reobservationPolicy(s).nextRetryDuration(s.retryCtr))
+					s.dirty = true
 					aggregationStateRetries.Inc()
 				}
 			} else {
@@ -209,11 +248,21 @@ func (p *Processor) handleCleanup(ctx context.Context) {
 					zap.Int("required_sigs", wantSigs),
 					zap.Bool("quorum", hasSigs >= wantSigs),
 				)
-				delete(p.state.signatures, hash)
+				sh.remove(hash)
 				aggregationStateUnobserved.Inc()
 			}
 		}
 	}
+	return nearQuorum
+}
+
+// reobservationPolicy returns the re-observation policy for the chain of an observation. Observations we have not made
+// ourselves use the default policy.
+func (p *Processor) reobservationPolicy(s *state) ReobservationPolicy {
+	if s.ourObservation == nil {
+		return p.reobservation.policy(vaa.ChainIDUnset)
+	}
+	return p.reobservation.policy(s.ourObservation.GetEmitterChain())
 }
 
 // signedVaaAlreadyInDB checks if the VAA is already in the DB. If it is, it makes sure the hash matches.
@@ -246,7 +295,7 @@ func (p *Processor) signedVaaAlreadyInDB(hash string, s *state) (bool, error) {
 	oldHash := hex.EncodeToString(v.SigningDigest().Bytes())
 	if hash != oldHash {
 		p.logger.Debug("VAA already in DB but hash is different", zap.String("old_hash", oldHash), zap.String("new_hash", hash))
-		return false, fmt.Errorf("hash mismatch in_db: %s, new: %s", oldHash, hash)
+		return false, fmt.Errorf("%w in_db: %s, new: %s", errHashMismatch, oldHash, hash)
 	}
 
 	return true, nil
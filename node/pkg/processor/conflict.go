@@ -0,0 +1,63 @@
+package processor
+
+import (
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+var (
+	conflictingObservationsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "wormhole_conflicting_observations_total",
+			Help: "Total number of digests signed by guardians for a message another digest was already signed for, by whether one of the digests is our own observation (ours) or not (peers)",
+		}, []string{"kind"})
+)
+
+// reportConflict is called with the shard lock held when an entry was added for a message ID that other entries of the shard
+// already have. Guardians signing different digests for the same message means that some of them observed a fork of the chain,
+// were fed bad data by their RPC node, or were compromised.
+//
+// Message IDs are not covered by the signature of an observation, so a conflict between digests we have not observed ourselves
+// may also be the work of a peer rewriting the message IDs of observations it relays. Only conflicts with our own observation are
+// reported as security critical, the others are logged as a warning. If we observe the message later, the conflict is reported
+// again then.
+func (p *Processor) reportConflict(sh *stateShard, messageID string) {
+	signers := make(map[string][]string)
+	ours := ""
+	for _, hash := range sh.digests[messageID] {
+		s := sh.signatures[hash]
+		if s.ourObservation != nil {
+			ours = hash
+		}
+		for addr := range s.signatures {
+			signers[hash] = append(signers[hash], addr.Hex())
+			p.recordConflictingSignature(messageID, hash, s, addr)
+		}
+	}
+
+	if ours == "" {
+		conflictingObservationsTotal.WithLabelValues("peers").Inc()
+		p.logger.Warn("guardians signed conflicting observations for a message we have not observed",
+			zap.String("message_id", messageID),
+			zap.Any("signers", signers),
+		)
+		return
+	}
+
+	conflictingObservationsTotal.WithLabelValues("ours").Inc()
+	p.logger.Error("SECURITY CRITICAL: guardians signed conflicting observations for the same message",
+		zap.String("message_id", messageID),
+		zap.String("our_digest", ours),
+		zap.Any("signers", signers),
+	)
+}
+
+// recordConflictingSignature records a signature on a digest of a conflicting message for inspection via the admin service.
+func (p *Processor) recordConflictingSignature(messageID string, hash string, s *state, addr ethcommon.Address) {
+	if p.conflicts == nil {
+		return
+	}
+	p.conflicts.AddSignature(messageID, ethcommon.HexToHash(hash), addr, s.ourObservation != nil, p.clock.Now())
+}
@@ -0,0 +1,104 @@
+package processor
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/benbjohnson/clock"
+	"github.com/certusone/wormhole/node/pkg/common"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestConflictingObservations(t *testing.T) {
+	gs := &common.GuardianSet{Index: 1}
+	gks := make([]*ecdsa.PrivateKey, 3)
+	for i := range gks {
+		var err error
+		gks[i], err = ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+		require.NoError(t, err)
+		gs.Keys = append(gs.Keys, crypto.PubkeyToAddress(gks[i].PublicKey))
+	}
+
+	core, logs := observer.New(zap.WarnLevel)
+	p := &Processor{
+		clock:       clock.New(),
+		logger:      zap.New(core),
+		gk:          gks[0],
+		gs:          gs,
+		ourAddr:     gs.Keys[0],
+		state:       newAggregationState(4),
+		conflicts:   common.NewObservationConflictTracker(),
+		gossipSendC: make(chan []byte, 10),
+		obsvC:       make(chan *common.MsgWithTimeStamp[gossipv1.SignedObservation], 10),
+	}
+	observe := func(gk *ecdsa.PrivateKey, digest []byte, messageID string) {
+		sig, err := crypto.Sign(digest, gk)
+		require.NoError(t, err)
+		p.handleVerifiedObservation(context.Background(), common.CreateMsgWithTimestamp[gossipv1.SignedObservation](&gossipv1.SignedObservation{
+			Addr:      crypto.PubkeyToAddress(gk.PublicKey).Bytes(),
+			Hash:      digest,
+			Signature: sig,
+			MessageId: messageID,
+		}), crypto.PubkeyToAddress(gk.PublicKey), gs)
+	}
+
+	v := getVAA()
+	ours := v.SigningDigest()
+	sig, err := crypto.Sign(ours.Bytes(), gks[0])
+	require.NoError(t, err)
+	p.broadcastSignature(&VAA{VAA: v}, sig, nil)
+	p.handleVerifiedObservation(context.Background(), <-p.obsvC, gs.Keys[0], gs)
+	assert.Empty(t, p.conflicts.List())
+
+	// Another guardian signs a different payload for the same message.
+	before := testutil.ToFloat64(conflictingObservationsTotal.WithLabelValues("ours"))
+	v.Payload = []byte("forked")
+	theirs := v.SigningDigest()
+	observe(gks[1], theirs.Bytes(), v.MessageID())
+	assert.Equal(t, before+1, testutil.ToFloat64(conflictingObservationsTotal.WithLabelValues("ours")))
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, zap.ErrorLevel, logs.TakeAll()[0].Level)
+
+	// Signatures arriving after the conflict was detected are recorded too.
+	observe(gks[2], theirs.Bytes(), v.MessageID())
+	observe(gks[2], ours.Bytes(), v.MessageID())
+
+	conflicts := p.conflicts.List()
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, v.MessageID(), conflicts[0].MessageID)
+	assert.Equal(t, ours, conflicts[0].Ours)
+	assert.ElementsMatch(t, []ethcommon.Address{gs.Keys[0], gs.Keys[2]}, conflicts[0].Signers[ours])
+	assert.ElementsMatch(t, []ethcommon.Address{gs.Keys[1], gs.Keys[2]}, conflicts[0].Signers[theirs])
+
+	// Observations of other messages are not affected.
+	other := getVAA()
+	other.Sequence = 2
+	observe(gks[1], other.SigningDigest().Bytes(), other.MessageID())
+	assert.Len(t, p.conflicts.List(), 1)
+	assert.Equal(t, before+1, testutil.ToFloat64(conflictingObservationsTotal.WithLabelValues("ours")))
+
+	// A conflict between other guardians over a message we have not observed is only a warning.
+	logs.TakeAll()
+	beforePeers := testutil.ToFloat64(conflictingObservationsTotal.WithLabelValues("peers"))
+	other.Payload = []byte("forked")
+	observe(gks[2], other.SigningDigest().Bytes(), other.MessageID())
+	assert.Equal(t, beforePeers+1, testutil.ToFloat64(conflictingObservationsTotal.WithLabelValues("peers")))
+	assert.Equal(t, before+1, testutil.ToFloat64(conflictingObservationsTotal.WithLabelValues("ours")))
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, zap.WarnLevel, logs.TakeAll()[0].Level)
+
+	// Removing an entry drops it from the index.
+	sh := p.state.shardFor(v.MessageID())
+	sh.remove(hex.EncodeToString(theirs.Bytes()))
+	assert.Equal(t, []string{hex.EncodeToString(ours.Bytes())}, sh.digests[v.MessageID()])
+}
@@ -0,0 +1,152 @@
+package processor
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// DelayBufferCheckInterval is how often the processor checks for delayed messages that are ready to be signed.
+const DelayBufferCheckInterval = time.Second
+
+var (
+	delayedMessagesBuffered = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "wormhole_processor_delayed_messages_buffered",
+			Help: "Current number of messages held in the observation delay buffer, by chain",
+		}, []string{"emitter_chain"})
+	delayedMessagesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "wormhole_processor_delayed_messages_total",
+			Help: "Total number of messages placed in the observation delay buffer, by chain",
+		}, []string{"emitter_chain"})
+)
+
+type delayedMessage struct {
+	msg       *common.MessagePublication
+	releaseAt time.Time
+}
+
+// observationDelayBuffer holds messages from selected chains for a fixed amount of time before they are signed. This gives
+// external monitoring a window to halt a chain (for example a newly integrated one) before the guardian commits to an observation.
+//
+// The buffer is only accessed from the processor goroutine and lives in memory, so messages that are buffered when the guardian
+// restarts are dropped and must be recovered through re-observation.
+type observationDelayBuffer struct {
+	delays map[vaa.ChainID]time.Duration
+	// pending holds buffered messages per chain. Since the delay is constant per chain, each queue is ordered by release time.
+	pending map[vaa.ChainID][]delayedMessage
+}
+
+func newObservationDelayBuffer(delays map[vaa.ChainID]time.Duration) *observationDelayBuffer {
+	if len(delays) == 0 {
+		return nil
+	}
+
+	return &observationDelayBuffer{
+		delays:  delays,
+		pending: make(map[vaa.ChainID][]delayedMessage),
+	}
+}
+
+// add buffers the message if its chain is configured with a delay. Returns false if the message should be processed immediately.
+func (b *observationDelayBuffer) add(k *common.MessagePublication, now time.Time) bool {
+	// Governance messages are injected by the guardian operators through the admin service rather than observed on chain, since the
+	// watchers drop messages from the governance emitter. There is nothing for a delay to guard against, and holding them back would
+	// only slow down a coordinated governance action.
+	if k.EmitterChain == vaa.GovernanceChain && k.EmitterAddress == vaa.GovernanceEmitter {
+		return false
+	}
+
+	delay, exists := b.delays[k.EmitterChain]
+	if !exists {
+		return false
+	}
+
+	b.pending[k.EmitterChain] = append(b.pending[k.EmitterChain], delayedMessage{msg: k, releaseAt: now.Add(delay)})
+	delayedMessagesTotal.WithLabelValues(k.EmitterChain.String()).Inc()
+	delayedMessagesBuffered.WithLabelValues(k.EmitterChain.String()).Set(float64(len(b.pending[k.EmitterChain])))
+	return true
+}
+
+// release removes and returns all messages whose delay has expired.
+func (b *observationDelayBuffer) release(now time.Time) []*common.MessagePublication {
+	var ret []*common.MessagePublication
+	for chainID, queue := range b.pending {
+		n := 0
+		for n < len(queue) && !queue[n].releaseAt.After(now) {
+			ret = append(ret, queue[n].msg)
+			n++
+		}
+		if n == 0 {
+			continue
+		}
+
+		if n == len(queue) {
+			delete(b.pending, chainID)
+		} else {
+			b.pending[chainID] = queue[n:]
+		}
+		delayedMessagesBuffered.WithLabelValues(chainID.String()).Set(float64(len(queue) - n))
+	}
+	return ret
+}
+
+// ParseObservationDelays parses a comma separated list of chain:duration pairs, such as "solana:10m,21:1h", where the chain
+// may be specified by name or by ID.
+func ParseObservationDelays(str string) (map[vaa.ChainID]time.Duration, error) {
+	ret := make(map[vaa.ChainID]time.Duration)
+	if str == "" {
+		return ret, nil
+	}
+
+	for _, entry := range strings.Split(str, ",") {
+		parts := strings.Split(strings.TrimSpace(entry), ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf(`invalid observation delay "%s", must be of the form chain:duration`, entry)
+		}
+
+		chainID, err := parseChain(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf(`invalid chain in observation delay "%s": %w`, entry, err)
+		}
+
+		delay, err := time.ParseDuration(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf(`invalid duration in observation delay "%s": %w`, entry, err)
+		}
+		if delay <= 0 {
+			return nil, fmt.Errorf(`observation delay "%s" must be positive`, entry)
+		}
+
+		if _, exists := ret[chainID]; exists {
+			return nil, fmt.Errorf(`duplicate observation delay for chain %s`, chainID)
+		}
+		ret[chainID] = delay
+	}
+
+	return ret, nil
+}
+
+// parseChain parses a chain name or ID.
+func parseChain(str string) (vaa.ChainID, error) {
+	chainID, err := vaa.ChainIDFromString(str)
+	if err != nil {
+		id, err := strconv.ParseUint(str, 10, 16)
+		if err != nil {
+			return vaa.ChainIDUnset, errors.New("must be a chain name or ID")
+		}
+		chainID = vaa.ChainID(id)
+	}
+	if chainID == vaa.ChainIDUnset {
+		return vaa.ChainIDUnset, errors.New("chain ID 0 is not valid")
+	}
+	return chainID, nil
+}
@@ -0,0 +1,72 @@
+package processor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestObservationDelayBufferDisabled(t *testing.T) {
+	assert.Nil(t, newObservationDelayBuffer(nil))
+	assert.Nil(t, newObservationDelayBuffer(map[vaa.ChainID]time.Duration{}))
+}
+
+func TestObservationDelayBuffer(t *testing.T) {
+	b := newObservationDelayBuffer(map[vaa.ChainID]time.Duration{vaa.ChainIDSolana: time.Minute})
+	require.NotNil(t, b)
+
+	now := time.Now()
+	msg1 := &common.MessagePublication{EmitterChain: vaa.ChainIDSolana, Sequence: 1}
+	msg2 := &common.MessagePublication{EmitterChain: vaa.ChainIDSolana, Sequence: 2}
+	undelayed := &common.MessagePublication{EmitterChain: vaa.ChainID(42), Sequence: 3}
+
+	assert.True(t, b.add(msg1, now))
+	assert.True(t, b.add(msg2, now.Add(30*time.Second)))
+	assert.False(t, b.add(undelayed, now))
+
+	assert.Empty(t, b.release(now.Add(59*time.Second)))
+
+	released := b.release(now.Add(time.Minute))
+	require.Equal(t, 1, len(released))
+	assert.Equal(t, uint64(1), released[0].Sequence)
+
+	released = b.release(now.Add(2 * time.Minute))
+	require.Equal(t, 1, len(released))
+	assert.Equal(t, uint64(2), released[0].Sequence)
+	assert.Empty(t, b.pending)
+}
+
+func TestObservationDelayBufferSkipsGovernance(t *testing.T) {
+	b := newObservationDelayBuffer(map[vaa.ChainID]time.Duration{vaa.GovernanceChain: time.Minute})
+	require.NotNil(t, b)
+
+	now := time.Now()
+	governance := &common.MessagePublication{EmitterChain: vaa.GovernanceChain, EmitterAddress: vaa.GovernanceEmitter, Sequence: 1}
+	other := &common.MessagePublication{EmitterChain: vaa.GovernanceChain, EmitterAddress: vaa.Address{1}, Sequence: 2}
+
+	assert.False(t, b.add(governance, now))
+	assert.True(t, b.add(other, now))
+	assert.Len(t, b.pending[vaa.GovernanceChain], 1)
+}
+
+func TestParseObservationDelays(t *testing.T) {
+	delays, err := ParseObservationDelays("")
+	require.NoError(t, err)
+	assert.Empty(t, delays)
+
+	delays, err = ParseObservationDelays("solana:10m, 42:1h")
+	require.NoError(t, err)
+	assert.Equal(t, map[vaa.ChainID]time.Duration{
+		vaa.ChainIDSolana: 10 * time.Minute,
+		vaa.ChainID(42):   time.Hour,
+	}, delays)
+
+	for _, str := range []string{"solana", "bogus:10m", "0:10m", "solana:soon", "solana:0s", "solana:1m,1:2m"} {
+		_, err := ParseObservationDelays(str)
+		assert.Error(t, err, str)
+	}
+}
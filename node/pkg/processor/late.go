@@ -0,0 +1,63 @@
+package processor
+
+import (
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/db"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+var (
+	lateObservationsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "wormhole_late_observations_total",
+			Help: "Total number of observations received for VAAs that already reached quorum, by whether their signature was added to the stored VAA",
+		}, []string{"result"})
+)
+
+// LateObservationWindowMax is the longest late observation window. Submitted observations are expired an hour after they were
+// first seen, after which late signatures have nothing to be added to.
+const LateObservationWindowMax = time.Hour
+
+// acceptsLateObservation returns true if signatures for a submitted observation are still added to its VAA. It must be called
+// with the lock of the observation's shard held.
+func (p *Processor) acceptsLateObservation(s *state) bool {
+	return p.lateObservationWindow > 0 && p.clock.Since(s.submittedAt) < p.lateObservationWindow
+}
+
+// storeLateSignature stores the VAA of a submitted observation again after a signature by signer arrived for it, so that it is
+// served with the signatures of as many guardians as possible. Only the stored copy changes: the VAA is not broadcast again, and
+// the governor, the gateway relayer and the subscribers of the database, which were given the VAA when it reached quorum, don't see
+// it again. It must be called with the lock of the observation's shard held.
+func (p *Processor) storeLateSignature(s *state, hash string, sigs []*vaa.Signature, signer ethcommon.Address) {
+	ourVAA, ok := s.ourObservation.(*VAA)
+	if !ok {
+		return
+	}
+	signed := ourVAA.signed(sigs)
+
+	// The stored VAA may have come from gossip with signatures we have not seen ourselves. Never replace it with one that has fewer.
+	if b, err := p.db.GetSignedVAABytes(*db.VaaIDFromVAA(signed)); err == nil {
+		if stored, err := vaa.Unmarshal(b); err == nil && len(stored.Signatures) >= len(sigs) {
+			lateObservationsTotal.WithLabelValues("not_stored").Inc()
+			return
+		}
+	}
+
+	if err := p.db.UpdateSignedVAA(signed); err != nil {
+		p.logger.Error("failed to store VAA with late signature", zap.String("digest", hash), zap.Error(err))
+		lateObservationsTotal.WithLabelValues("not_stored").Inc()
+		return
+	}
+	p.logger.Debug("added late signature to VAA",
+		zap.String("digest", hash),
+		zap.String("message_id", signed.MessageID()),
+		zap.Stringer("signer", signer),
+		zap.Int("signatures", len(sigs)),
+	)
+	lateObservationsTotal.WithLabelValues("stored").Inc()
+}
@@ -0,0 +1,120 @@
+package processor
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+func TestLateObservations(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		window time.Duration
+		want   int
+	}{
+		{name: "disabled", window: 0, want: 3},
+		{name: "within window", window: time.Minute, want: 4},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			database, err := db.OpenInMemory(zap.NewNop())
+			require.NoError(t, err)
+			defer database.Close()
+
+			gs := &common.GuardianSet{Index: 1}
+			gks := make([]*ecdsa.PrivateKey, 4)
+			for i := range gks {
+				gks[i], err = ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+				require.NoError(t, err)
+				gs.Keys = append(gs.Keys, crypto.PubkeyToAddress(gks[i].PublicKey))
+			}
+			p := &Processor{
+				clock:                 clock.New(),
+				logger:                zap.NewNop(),
+				db:                    database,
+				gk:                    gks[0],
+				gs:                    gs,
+				ourAddr:               gs.Keys[0],
+				state:                 newAggregationState(1),
+				lateObservationWindow: tc.window,
+				gossipSendC:           make(chan []byte, 10),
+				obsvC:                 make(chan *common.MsgWithTimeStamp[gossipv1.SignedObservation], 10),
+			}
+
+			feedC, unsubscribe := database.SubscribeSignedVAAs(10)
+			defer unsubscribe()
+
+			v := getVAA()
+			digest := v.SigningDigest().Bytes()
+			observe := func(gk *ecdsa.PrivateKey) {
+				sig, err := crypto.Sign(digest, gk)
+				require.NoError(t, err)
+				p.handleObservation(context.Background(), common.CreateMsgWithTimestamp[gossipv1.SignedObservation](&gossipv1.SignedObservation{
+					Addr:      crypto.PubkeyToAddress(gk.PublicKey).Bytes(),
+					Hash:      digest,
+					Signature: sig,
+					MessageId: v.MessageID(),
+				}), gs)
+			}
+			storedSignatures := func() int {
+				b, err := database.GetSignedVAABytes(*db.VaaIDFromVAA(&v))
+				require.NoError(t, err)
+				stored, err := vaa.Unmarshal(b)
+				require.NoError(t, err)
+				return len(stored.Signatures)
+			}
+
+			sig, err := crypto.Sign(digest, gks[0])
+			require.NoError(t, err)
+			p.broadcastSignature(&VAA{VAA: v}, sig, nil)
+			p.handleObservation(context.Background(), <-p.obsvC, gs)
+			observe(gks[1])
+			observe(gks[2])
+			require.Equal(t, 3, storedSignatures())
+
+			observe(gks[3])
+			assert.Equal(t, tc.want, storedSignatures())
+
+			// Subscribers get the VAA once when it reaches quorum, and not again for the late signature.
+			assert.Len(t, feedC, 1)
+		})
+	}
+}
+
+func TestStoreLateSignatureKeepsBetterVAA(t *testing.T) {
+	database, err := db.OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer database.Close()
+
+	p := &Processor{clock: clock.New(), logger: zap.NewNop(), db: database}
+	v := getVAA()
+	s := &state{ourObservation: &VAA{VAA: v}}
+
+	// A VAA received via gossip with more signatures than we have seen is kept.
+	stored := v
+	stored.Signatures = []*vaa.Signature{{Index: 0}, {Index: 1}, {Index: 2}}
+	require.NoError(t, database.StoreSignedVAA(&stored))
+
+	before := testutil.ToFloat64(lateObservationsTotal.WithLabelValues("not_stored"))
+	p.storeLateSignature(s, "digest", []*vaa.Signature{{Index: 0}, {Index: 3}}, ethcommon.Address{})
+	assert.Equal(t, before+1, testutil.ToFloat64(lateObservationsTotal.WithLabelValues("not_stored")))
+
+	b, err := database.GetSignedVAABytes(*db.VaaIDFromVAA(&v))
+	require.NoError(t, err)
+	got, err := vaa.Unmarshal(b)
+	require.NoError(t, err)
+	assert.Len(t, got.Signatures, 3)
+}
@@ -0,0 +1,41 @@
+package processor
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+var (
+	timeToQuorum = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "wormhole_time_to_quorum_seconds",
+			Help: "Time from our observation of a message to its VAA reaching quorum, by emitter chain",
+			// 100ms to ~14m
+			Buckets: prometheus.ExponentialBuckets(0.1, 2, 14),
+		}, []string{"emitter_chain"})
+	publicationToVAA = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "wormhole_publication_to_vaa_seconds",
+			Help: "Time from the publication of a message, as per the timestamp of its VAA, to the VAA being stored, by emitter chain",
+			// 1s to ~2h15m, since this includes waiting for finality.
+			Buckets: prometheus.ExponentialBuckets(1, 2, 14),
+		}, []string{"emitter_chain"})
+)
+
+// observeQuorum records the latency of an observation we made that just reached quorum. Observations restored from before a
+// restart are only recorded if we know when we made them.
+func observeQuorum(s *state, now time.Time) {
+	if s.ourObservation == nil || s.ourObservedAt.IsZero() {
+		return
+	}
+	timeToQuorum.WithLabelValues(s.ourObservation.GetEmitterChain().String()).Observe(now.Sub(s.ourObservedAt).Seconds())
+}
+
+// observeVAAStored records the latency from publication of a message to its VAA being stored, whether it reached quorum here or
+// was received via gossip.
+func observeVAAStored(v *vaa.VAA, now time.Time) {
+	publicationToVAA.WithLabelValues(v.EmitterChain.String()).Observe(now.Sub(v.Timestamp).Seconds())
+}
@@ -0,0 +1,48 @@
+package processor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func histogramSample(t *testing.T, h *prometheus.HistogramVec, label string) (uint64, float64) {
+	t.Helper()
+	m := &dto.Metric{}
+	require.NoError(t, h.WithLabelValues(label).(prometheus.Metric).Write(m))
+	return m.GetHistogram().GetSampleCount(), m.GetHistogram().GetSampleSum()
+}
+
+func TestObserveQuorum(t *testing.T) {
+	chain := vaa.ChainIDSolana.String()
+	count, sum := histogramSample(t, timeToQuorum, chain)
+
+	now := time.Now()
+	v := getVAA()
+	// Observations we have not made, or restored without knowing when we made them, are not recorded.
+	observeQuorum(&state{firstObserved: now.Add(-time.Hour)}, now)
+	observeQuorum(&state{ourObservation: &VAA{VAA: v}}, now)
+	observeQuorum(&state{ourObservation: &VAA{VAA: v}, ourObservedAt: now.Add(-3 * time.Second)}, now)
+
+	newCount, newSum := histogramSample(t, timeToQuorum, chain)
+	assert.Equal(t, count+1, newCount)
+	assert.InDelta(t, sum+3, newSum, 0.001)
+}
+
+func TestObserveVAAStored(t *testing.T) {
+	v := getVAA()
+	v.Timestamp = time.Unix(1700000000, 0)
+	chain := v.EmitterChain.String()
+	count, sum := histogramSample(t, publicationToVAA, chain)
+
+	observeVAAStored(&v, v.Timestamp.Add(90*time.Second))
+
+	newCount, newSum := histogramSample(t, publicationToVAA, chain)
+	assert.Equal(t, count+1, newCount)
+	assert.InDelta(t, sum+90, newSum, 0.001)
+}
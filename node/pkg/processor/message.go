@@ -12,6 +12,8 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	"github.com/certusone/wormhole/node/pkg/payloads"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 )
 
@@ -78,6 +80,7 @@ func (p *Processor) handleMessage(k *common.MessagePublication) {
 		},
 		Unreliable:    k.Unreliable,
 		Reobservation: k.IsReobservation,
+		Confidence:    k.Confidence,
 	}
 
 	// Generate digest of the unsigned VAA.
@@ -103,10 +106,31 @@ func (p *Processor) handleMessage(k *common.MessagePublication) {
 		zap.String("message_id", v.MessageID()),
 		zap.String("signature", hex.EncodeToString(s)),
 		zap.Bool("isReobservation", k.IsReobservation),
+		zap.String("confidence", k.Confidence),
+		payloads.ZapFieldForMessage(k),
 	)
 
 	messagesSignedTotal.With(prometheus.Labels{
 		"emitter_chain": k.EmitterChain.String()}).Add(1)
 
+	p.indexTxHash(k)
 	p.broadcastSignature(v, s, k.TxHash.Bytes())
 }
+
+// indexTxHash records the transaction of a message we observed, so that its VAA can be looked up by transaction hash. Only our own
+// observations are indexed, since the VAAs received from the network don't carry the transaction. The message is indexed by its
+// TxHash, and by its TxID too when the watcher reported one.
+func (p *Processor) indexTxHash(k *common.MessagePublication) {
+	if p.db == nil {
+		return
+	}
+	id := db.VAAID{EmitterChain: k.EmitterChain, EmitterAddress: k.EmitterAddress, Sequence: k.Sequence}
+	if err := p.db.StoreTxHash(k.TxHash.Bytes(), id, k.Timestamp); err != nil {
+		p.logger.Error("failed to index message by transaction", zap.String("message_id", k.MessageIDString()), zap.Stringer("txhash", k.TxHash), zap.Error(err))
+	}
+	if len(k.TxID) != 0 {
+		if err := p.db.StoreTxHash(k.TxID, id, k.Timestamp); err != nil {
+			p.logger.Error("failed to index message by transaction", zap.String("message_id", k.MessageIDString()), zap.Binary("txid", k.TxID), zap.Error(err))
+		}
+	}
+}
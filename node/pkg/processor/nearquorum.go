@@ -0,0 +1,83 @@
+package processor
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// NearQuorumThresholdDefault is how long an observation can be one signature short of quorum before it is reported.
+const NearQuorumThresholdDefault = 2 * time.Minute
+
+var (
+	nearQuorumObservations = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "wormhole_near_quorum_observations",
+			Help: "Current number of observations that have been one signature short of quorum for longer than the threshold",
+		})
+	nearQuorumMissing = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "wormhole_near_quorum_missing_signatures_total",
+			Help: "Total number of observations stuck one signature short of quorum, by guardian whose signature was missing",
+		}, []string{"guardian_index", "guardian_addr"})
+)
+
+// checkNearQuorum reports whether an unsubmitted observation has been one signature short of quorum for longer than the
+// threshold. The first time it is, the missing guardians are logged and counted, so that guardians which are often the
+// ones holding up quorum stand out.
+func (p *Processor) checkNearQuorum(hash string, s *state, delta time.Duration) bool {
+	if p.nearQuorumThreshold <= 0 || s.submitted || delta < p.nearQuorumThreshold {
+		return false
+	}
+	gs := s.gs
+	if gs == nil {
+		gs = p.gs
+	}
+	if gs == nil || len(gs.Keys) == 0 {
+		return false
+	}
+
+	// Signatures by guardians of other guardian sets do not count towards quorum.
+	var missing []int
+	for i, k := range gs.Keys {
+		if _, ok := s.signatures[k]; !ok {
+			missing = append(missing, i)
+		}
+	}
+	quorum := vaa.CalculateQuorum(len(gs.Keys))
+	if len(gs.Keys)-len(missing) != quorum-1 {
+		return false
+	}
+	if s.nearQuorumReported {
+		return true
+	}
+	s.nearQuorumReported = true
+
+	missingAddrs := make([]string, len(missing))
+	for i, idx := range missing {
+		missingAddrs[i] = gs.Keys[idx].Hex()
+		nearQuorumMissing.WithLabelValues(strconv.Itoa(idx), missingAddrs[i]).Inc()
+	}
+
+	fields := []zap.Field{
+		zap.String("digest", hash),
+		zap.Duration("delta", delta),
+		zap.Uint32("guardian_set_index", gs.Index),
+		zap.Int("have_sigs", quorum-1),
+		zap.Int("required_sigs", quorum),
+		zap.Ints("missing_guardian_indexes", missing),
+		zap.Strings("missing_guardians", missingAddrs),
+	}
+	if s.ourObservation != nil {
+		fields = append(fields,
+			zap.String("message_id", s.ourObservation.MessageID()),
+			zap.Stringer("emitter_chain", s.ourObservation.GetEmitterChain()),
+		)
+	}
+	p.logger.Warn("observation is one signature short of quorum", fields...)
+	return true
+}
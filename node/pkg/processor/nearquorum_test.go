@@ -0,0 +1,53 @@
+package processor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/certusone/wormhole/node/pkg/common"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestCheckNearQuorum(t *testing.T) {
+	observedCore, observedLogs := observer.New(zap.WarnLevel)
+	gs := &common.GuardianSet{Index: 3}
+	for i := 0; i < 4; i++ {
+		gs.Keys = append(gs.Keys, ethcommon.BytesToAddress([]byte{byte(i + 1)}))
+	}
+	p := &Processor{clock: clock.New(), logger: zap.New(observedCore), gs: gs, nearQuorumThreshold: time.Minute}
+
+	// Quorum of four guardians is three. A signature by a guardian of another set does not count.
+	s := &state{signatures: map[ethcommon.Address][]byte{
+		gs.Keys[0]:                           {},
+		gs.Keys[2]:                           {},
+		ethcommon.BytesToAddress([]byte{42}): {},
+	}}
+	assert.False(t, p.checkNearQuorum("digest", s, 30*time.Second))
+	assert.Zero(t, observedLogs.Len())
+
+	assert.True(t, p.checkNearQuorum("digest", s, 2*time.Minute))
+	require.Equal(t, 1, observedLogs.Len())
+	fields := observedLogs.All()[0].ContextMap()
+	assert.Equal(t, []interface{}{1, 3}, fields["missing_guardian_indexes"])
+	assert.Equal(t, 1.0, testutil.ToFloat64(nearQuorumMissing.WithLabelValues("1", gs.Keys[1].Hex())))
+	assert.Equal(t, 1.0, testutil.ToFloat64(nearQuorumMissing.WithLabelValues("3", gs.Keys[3].Hex())))
+
+	// Only reported once, but still counted as near quorum.
+	assert.True(t, p.checkNearQuorum("digest", s, 3*time.Minute))
+	assert.Equal(t, 1, observedLogs.Len())
+	assert.Equal(t, 1.0, testutil.ToFloat64(nearQuorumMissing.WithLabelValues("1", gs.Keys[1].Hex())))
+
+	// Observations further from quorum, submitted ones and any with the alert disabled are not near quorum.
+	assert.False(t, p.checkNearQuorum("other", &state{signatures: map[ethcommon.Address][]byte{gs.Keys[0]: {}}}, 2*time.Minute))
+	s.submitted = true
+	assert.False(t, p.checkNearQuorum("digest", s, 3*time.Minute))
+	s.submitted = false
+	p.nearQuorumThreshold = 0
+	assert.False(t, p.checkNearQuorum("digest", s, 3*time.Minute))
+}
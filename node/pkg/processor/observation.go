@@ -14,7 +14,6 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
@@ -73,18 +72,48 @@ func signaturesToVaaFormat(signatures map[common.Address][]byte, gsKeys []common
 }
 
 // handleObservation processes a remote VAA observation, verifies it, checks whether the VAA has met quorum,
-// and assembles and submits a valid VAA if possible.
-func (p *Processor) handleObservation(ctx context.Context, obs *node_common.MsgWithTimeStamp[gossipv1.SignedObservation]) {
+// and assembles and submits a valid VAA if possible. gs is the current guardian set.
+func (p *Processor) handleObservation(ctx context.Context, obs *node_common.MsgWithTimeStamp[gossipv1.SignedObservation], gs *node_common.GuardianSet) {
 	// SECURITY: at this point, observations received from the p2p network are fully untrusted (all fields!)
 	//
 	// Note that observations are never tied to the (verified) p2p identity key - the p2p network
 	// identity is completely decoupled from the guardian identity, p2p is just transport.
 
+	sh := p.state.shardFor(obs.Msg.MessageId)
+	sh.mu.Lock()
+	s := sh.signatures[hex.EncodeToString(obs.Msg.Hash)]
+	drop := s != nil && s.submitted && !p.acceptsLateObservation(s)
+	sh.mu.Unlock()
+	if drop {
+		// already submitted; no need to verify additional signatures for it.
+		lateObservationsTotal.WithLabelValues("dropped").Inc()
+		return
+	}
+
+	observationsReceivedTotal.Inc()
+
+	// Verify the Guardian's signature. This verifies that m.Signature matches m.Hash and recovers
+	// the public key that was used to sign the payload, which must match m.Addr.
+	their_addr, ok := verifyObservation(p.logger, obs.Msg)
+	if !ok {
+		return
+	}
+
+	p.handleVerifiedObservation(ctx, obs, their_addr, gs)
+}
+
+// handleVerifiedObservation is handleObservation for an observation whose signature has already been verified to be made by their_addr.
+// It holds the lock of the observation's shard throughout.
+func (p *Processor) handleVerifiedObservation(ctx context.Context, obs *node_common.MsgWithTimeStamp[gossipv1.SignedObservation], their_addr common.Address, currentGS *node_common.GuardianSet) {
 	m := obs.Msg
 	hash := hex.EncodeToString(m.Hash)
-	s := p.state.signatures[hash]
-	if s != nil && s.submitted {
+	sh := p.state.shardFor(m.MessageId)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	s := sh.signatures[hash]
+	if s != nil && s.submitted && !p.acceptsLateObservation(s) {
 		// already submitted; ignoring additional signatures for it.
+		lateObservationsTotal.WithLabelValues("dropped").Inc()
 		return
 	}
 
@@ -99,35 +128,6 @@ func (p *Processor) handleObservation(ctx context.Context, obs *node_common.MsgW
 		)
 	}
 
-	observationsReceivedTotal.Inc()
-
-	// Verify the Guardian's signature. This verifies that m.Signature matches m.Hash and recovers
-	// the public key that was used to sign the payload.
-	pk, err := crypto.Ecrecover(m.Hash, m.Signature)
-	if err != nil {
-		p.logger.Warn("failed to verify signature on observation",
-			zap.String("digest", hash),
-			zap.String("signature", hex.EncodeToString(m.Signature)),
-			zap.String("addr", hex.EncodeToString(m.Addr)),
-			zap.Error(err))
-		observationsFailedTotal.WithLabelValues("invalid_signature").Inc()
-		return
-	}
-
-	// Verify that m.Addr matches the public key that signed m.Hash.
-	their_addr := common.BytesToAddress(m.Addr)
-	signer_pk := common.BytesToAddress(crypto.Keccak256(pk[1:])[12:])
-
-	if their_addr != signer_pk {
-		p.logger.Info("invalid observation - address does not match pubkey",
-			zap.String("digest", hash),
-			zap.String("signature", hex.EncodeToString(m.Signature)),
-			zap.String("addr", hex.EncodeToString(m.Addr)),
-			zap.String("pk", signer_pk.Hex()))
-		observationsFailedTotal.WithLabelValues("pubkey_mismatch").Inc()
-		return
-	}
-
 	// Determine which guardian set to use. The following cases are possible:
 	//
 	//  - We have already seen the message and generated ourObservation. In this case, use the guardian set valid at the time,
@@ -148,7 +148,7 @@ func (p *Processor) handleObservation(ctx context.Context, obs *node_common.MsgW
 	if s != nil && s.gs != nil {
 		gs = s.gs
 	} else {
-		gs = p.gs
+		gs = currentGS
 	}
 
 	// We haven't yet observed the trusted guardian set on Ethereum, and therefore, it's impossible to verify it.
@@ -184,6 +184,7 @@ func (p *Processor) handleObservation(ctx context.Context, obs *node_common.MsgW
 	observationsReceivedByGuardianAddressTotal.WithLabelValues(their_addr.Hex()).Inc()
 
 	// []byte isn't hashable in a map. Paying a small extra cost for encoding for easier debugging.
+	conflict := false
 	if s == nil {
 		// We haven't yet seen this event ourselves, and therefore do not know what the VAA looks like.
 		// However, we have established that a valid guardian has signed it, and therefore we can
@@ -196,16 +197,34 @@ func (p *Processor) handleObservation(ctx context.Context, obs *node_common.MsgW
 		observationsUnknownTotal.Inc()
 
 		s = &state{
-			firstObserved: time.Now(),
-			nextRetry:     time.Now().Add(nextRetryDuration(0)),
+			firstObserved: p.clock.Now(),
+			nextRetry:     p.clock.Now().Add(p.reobservation.policy(vaa.ChainIDUnset).nextRetryDuration(0)),
 			signatures:    map[common.Address][]byte{},
 			source:        "unknown",
+			messageID:     m.MessageId,
 		}
 
-		p.state.signatures[hash] = s
+		conflict = sh.add(hash, s)
 	}
 
+	_, hadSignature := s.signatures[their_addr]
 	s.signatures[their_addr] = m.Signature
+	s.dirty = true
+	if conflict {
+		p.reportConflict(sh, s.messageID)
+	} else if len(sh.digests[s.messageID]) > 1 {
+		p.recordConflictingSignature(s.messageID, hash, s, their_addr)
+	}
+	if p.aggSigKey != nil && len(m.AggsigSignature) != 0 {
+		if s.aggSigShares == nil {
+			s.aggSigShares = make(map[common.Address][]byte)
+		}
+		s.aggSigShares[their_addr] = m.AggsigSignature
+	}
+
+	if p.govStatus != nil {
+		p.govStatus.AddSignature(common.BytesToHash(m.Hash), their_addr, gs, p.clock.Now())
+	}
 
 	if s.ourObservation != nil {
 		// We have made this observation on chain!
@@ -245,6 +264,15 @@ func (p *Processor) handleObservation(ctx context.Context, obs *node_common.MsgW
 		if len(sigsVaaFormat) >= quorum && !s.submitted {
 			// we have reached quorum *with the active guardian set*
 			s.ourObservation.HandleQuorum(sigsVaaFormat, hash, p)
+			s.submitted = true
+			s.submittedAt = p.clock.Now()
+			observeQuorum(s, s.submittedAt)
+			p.recordShadowResult(s, shadowMatched)
+			if p.aggSigKey != nil {
+				p.attestAggregated(s, gs, m.Hash)
+			}
+		} else if s.submitted && !hadSignature {
+			p.storeLateSignature(s, hash, sigsVaaFormat, their_addr)
 		} else {
 			p.logger.Debug("quorum not met or already submitted, doing nothing", // 1.2M out of 3M info messages / hour / guardian
 				zap.String("digest", hash))
@@ -316,4 +344,5 @@ func (p *Processor) handleInboundSignedVAAWithQuorum(ctx context.Context, m *gos
 		p.logger.Error("failed to store signed VAA", zap.Error(err))
 		return
 	}
+	observeVAAStored(v, p.clock.Now())
 }
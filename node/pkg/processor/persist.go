@@ -0,0 +1,206 @@
+package processor
+
+import (
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+var (
+	aggregationStateRestored = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "wormhole_aggregation_state_restored_total",
+			Help: "Total number of aggregation states restored from the database on startup",
+		})
+	aggregationStatePersistFailures = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "wormhole_aggregation_state_persist_failures_total",
+			Help: "Total number of failures to write the aggregation state to the database",
+		})
+)
+
+// restoreAggregationState loads the aggregation state persisted before the last shutdown, so that signatures collected for
+// observations that had not reached quorum yet do not have to be collected again. Entries already in memory are kept, which
+// happens when the processor is restarted by the supervisor rather than the node being restarted.
+func (p *Processor) restoreAggregationState() {
+	if p.db == nil {
+		return
+	}
+	if p.persisted == nil {
+		p.persisted = make(map[string]struct{})
+	}
+
+	stored, err := p.db.GetAggregationStates()
+	if err != nil {
+		p.logger.Error("failed to load aggregation state from database", zap.Error(err))
+		return
+	}
+
+	restored := 0
+	for _, ds := range stored {
+		p.persisted[ds.Digest] = struct{}{}
+		s, err := stateFromDb(ds)
+		if err != nil {
+			p.logger.Error("failed to restore aggregation state", zap.String("digest", ds.Digest), zap.Error(err))
+			continue
+		}
+		if p.restoreState(ds.Digest, s) {
+			restored++
+		}
+	}
+
+	if restored != 0 {
+		p.logger.Info("restored aggregation state from database", zap.Int("entries", restored))
+		aggregationStateRestored.Add(float64(restored))
+	}
+}
+
+// restoreState adds a restored entry to its shard unless the shard already has one for the digest.
+func (p *Processor) restoreState(hash string, s *state) bool {
+	if s.ourObservation != nil {
+		s.messageID = s.ourObservation.MessageID()
+	}
+	sh := p.state.shardFor(s.messageID)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	if _, exists := sh.signatures[hash]; exists {
+		return false
+	}
+	if sh.add(hash, s) {
+		p.reportConflict(sh, s.messageID)
+	}
+	return true
+}
+
+// persistAggregationState writes the entries of the aggregation state that changed since the last call to the database, and
+// deletes the ones that were submitted or expired. Submitted entries are not persisted, since their VAA is already stored.
+func (p *Processor) persistAggregationState() {
+	if p.db == nil {
+		return
+	}
+	if p.persisted == nil {
+		p.persisted = make(map[string]struct{})
+	}
+
+	// Entries are copied and marked clean with the lock of their shard held, so that the database is written without holding up
+	// aggregation. They are marked dirty again if the write fails.
+	var toStore []*db.AggregationState
+	var stored []*state
+	pending := make(map[string]struct{})
+	for _, sh := range p.state.shards {
+		sh.mu.Lock()
+		for hash, s := range sh.signatures {
+			if s.submitted {
+				continue
+			}
+			pending[hash] = struct{}{}
+			if !s.dirty {
+				continue
+			}
+			toStore = append(toStore, stateToDb(hash, s))
+			stored = append(stored, s)
+			s.dirty = false
+		}
+		sh.mu.Unlock()
+	}
+
+	var toDelete []string
+	for hash := range p.persisted {
+		if _, exists := pending[hash]; !exists {
+			toDelete = append(toDelete, hash)
+		}
+	}
+
+	if len(toStore) != 0 {
+		if err := p.db.StoreAggregationStates(toStore); err != nil {
+			p.logger.Error("failed to persist aggregation state", zap.Error(err))
+			aggregationStatePersistFailures.Inc()
+			for i, ds := range toStore {
+				sh := p.state.shardFor(ds.MessageID)
+				sh.mu.Lock()
+				stored[i].dirty = true
+				sh.mu.Unlock()
+			}
+			return
+		}
+		for _, ds := range toStore {
+			p.persisted[ds.Digest] = struct{}{}
+		}
+	}
+
+	if len(toDelete) != 0 {
+		if err := p.db.DeleteAggregationStates(toDelete); err != nil {
+			p.logger.Error("failed to delete aggregation state", zap.Error(err))
+			aggregationStatePersistFailures.Inc()
+			return
+		}
+		for _, hash := range toDelete {
+			delete(p.persisted, hash)
+		}
+	}
+}
+
+func stateToDb(hash string, s *state) *db.AggregationState {
+	ds := &db.AggregationState{
+		Digest:        hash,
+		FirstObserved: s.firstObserved,
+		OurObservedAt: s.ourObservedAt,
+		NextRetry:     s.nextRetry,
+		RetryCtr:      s.retryCtr,
+		Settled:       s.settled,
+		Source:        s.source,
+		MessageID:     s.messageID,
+		OurMsg:        s.ourMsg,
+		TxHash:        s.txHash,
+		Signatures:    make(map[ethcommon.Address][]byte, len(s.signatures)),
+	}
+	// Copied, since the entry keeps being aggregated while the copy is written.
+	for addr, sig := range s.signatures {
+		ds.Signatures[addr] = sig
+	}
+	if v, ok := s.ourObservation.(*VAA); ok {
+		// An unsigned VAA always marshals.
+		ds.OurObservation, _ = v.VAA.Marshal()
+		ds.Unreliable = v.Unreliable
+		ds.Reobservation = v.Reobservation
+		ds.Confidence = v.Confidence
+	}
+	if s.gs != nil {
+		ds.GuardianSetIndex = s.gs.Index
+		ds.GuardianSetKeys = s.gs.Keys
+	}
+	return ds
+}
+
+func stateFromDb(ds *db.AggregationState) (*state, error) {
+	s := &state{
+		firstObserved: ds.FirstObserved,
+		ourObservedAt: ds.OurObservedAt,
+		nextRetry:     ds.NextRetry,
+		retryCtr:      ds.RetryCtr,
+		settled:       ds.Settled,
+		source:        ds.Source,
+		messageID:     ds.MessageID,
+		ourMsg:        ds.OurMsg,
+		txHash:        ds.TxHash,
+		signatures:    ds.Signatures,
+	}
+	if s.signatures == nil {
+		s.signatures = make(map[ethcommon.Address][]byte)
+	}
+	if len(ds.OurObservation) != 0 {
+		v, err := vaa.Unmarshal(ds.OurObservation)
+		if err != nil {
+			return nil, err
+		}
+		s.ourObservation = &VAA{VAA: *v, Unreliable: ds.Unreliable, Reobservation: ds.Reobservation, Confidence: ds.Confidence}
+	}
+	if len(ds.GuardianSetKeys) != 0 {
+		s.gs = &common.GuardianSet{Keys: ds.GuardianSetKeys, Index: ds.GuardianSetIndex}
+	}
+	return s, nil
+}
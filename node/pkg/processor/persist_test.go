@@ -0,0 +1,112 @@
+package processor
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPersistAggregationState(t *testing.T) {
+	database, err := db.OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer database.Close()
+
+	guardian := ethcommon.HexToAddress("0xbeFA429d57cD18b7F8A4d91A2da9AB4AF05d0FBe")
+	v := getVAA()
+	ours := hex.EncodeToString(v.SigningDigest().Bytes())
+	firstObserved := time.Unix(1700000000, 0).UTC()
+
+	p := &Processor{db: database, logger: zap.NewNop(), state: newAggregationState(1)}
+	signatures := p.state.shards[0].signatures
+	signatures[ours] = &state{
+		firstObserved:  firstObserved,
+		ourObservedAt:  firstObserved.Add(time.Second),
+		nextRetry:      firstObserved.Add(time.Minute),
+		retryCtr:       2,
+		ourObservation: &VAA{VAA: v, Reobservation: true, Confidence: common.ConfidenceReobserved},
+		signatures:     map[ethcommon.Address][]byte{guardian: {1, 2, 3}},
+		source:         "solana",
+		ourMsg:         []byte{4, 5, 6},
+		txHash:         []byte{7, 8, 9},
+		gs:             &common.GuardianSet{Keys: []ethcommon.Address{guardian}, Index: 1},
+		dirty:          true,
+	}
+	signatures["unknown"] = &state{
+		firstObserved: firstObserved,
+		signatures:    map[ethcommon.Address][]byte{guardian: {1}},
+		source:        "unknown",
+		messageID:     "2/0000000000000000000000000000000000000000000000000000000000000004/1",
+		dirty:         true,
+	}
+	p.persistAggregationState()
+	assert.False(t, signatures[ours].dirty)
+
+	// A restarted processor picks up where the old one left off, with each entry in the shard of its emitter.
+	restarted := &Processor{db: database, logger: zap.NewNop(), state: newAggregationState(4)}
+	restarted.restoreAggregationState()
+	require.Equal(t, 2, restarted.state.size())
+	s := restarted.state.shardFor(v.MessageID()).signatures[ours]
+	require.NotNil(t, s)
+	assert.True(t, firstObserved.Equal(s.firstObserved))
+	assert.True(t, firstObserved.Add(time.Second).Equal(s.ourObservedAt))
+	assert.Equal(t, uint(2), s.retryCtr)
+	assert.Equal(t, []byte{1, 2, 3}, s.signatures[guardian])
+	assert.Equal(t, []byte{4, 5, 6}, s.ourMsg)
+	assert.Equal(t, signatures[ours].gs, s.gs)
+	require.NotNil(t, s.ourObservation)
+	assert.Equal(t, v.SigningDigest(), s.ourObservation.SigningDigest())
+	assert.True(t, s.ourObservation.IsReobservation())
+	assert.Equal(t, common.ConfidenceReobserved, s.ourObservation.(*VAA).Confidence)
+	unknown := restarted.state.shardFor(signatures["unknown"].messageID).signatures["unknown"]
+	require.NotNil(t, unknown)
+	assert.Nil(t, unknown.ourObservation)
+
+	// Submitted and expired entries are removed from the database.
+	s.submitted = true
+	delete(restarted.state.shardFor(unknown.messageID).signatures, "unknown")
+	restarted.persistAggregationState()
+	stored, err := database.GetAggregationStates()
+	require.NoError(t, err)
+	assert.Empty(t, stored)
+}
+
+func TestRunPersistsAggregationStateOnExit(t *testing.T) {
+	database, err := db.OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer database.Close()
+
+	// The processor fails when the accountant releases a message while it is disabled, which is an exit path other than the
+	// context being cancelled.
+	acctReadC := make(chan *common.MessagePublication, 1)
+	acctReadC <- &common.MessagePublication{}
+	p := &Processor{
+		db:                database,
+		logger:            zap.NewNop(),
+		clock:             clock.New(),
+		state:             newAggregationState(4),
+		aggregationShards: 4,
+		acctReadC:         acctReadC,
+	}
+	messageID := "2/0000000000000000000000000000000000000000000000000000000000000004/1"
+	p.state.shardFor(messageID).signatures["pending"] = &state{
+		firstObserved: time.Now(),
+		signatures:    map[ethcommon.Address][]byte{{1}: {1}},
+		messageID:     messageID,
+		dirty:         true,
+	}
+
+	assert.ErrorContains(t, p.Run(context.Background()), "while it is disabled")
+	stored, err := database.GetAggregationStates()
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	assert.Equal(t, "pending", stored[0].Digest)
+}
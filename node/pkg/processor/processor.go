@@ -4,10 +4,16 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/benbjohnson/clock"
+	"github.com/certusone/wormhole/node/pkg/accountant"
+	"github.com/certusone/wormhole/node/pkg/aggsig"
 	"github.com/certusone/wormhole/node/pkg/db"
 	"github.com/certusone/wormhole/node/pkg/governor"
+	"github.com/certusone/wormhole/node/pkg/gwrelayer"
 
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -49,6 +55,8 @@ type (
 	state struct {
 		// First time this digest was seen (possibly even before we observed it ourselves).
 		firstObserved time.Time
+		// Time at which we observed the message ourselves, zero if we have not.
+		ourObservedAt time.Time
 		// A re-observation request shall not be sent before this time.
 		nextRetry time.Time
 		// Number of times we sent a re-observation request
@@ -60,6 +68,8 @@ type (
 		signatures map[ethcommon.Address][]byte
 		// Flag set after reaching quorum and submitting the VAA.
 		submitted bool
+		// Time at which the VAA was submitted, from which late signatures are accepted for the late observation window.
+		submittedAt time.Time
 		// Flag set by the cleanup service after the settlement timeout has expired and misses were counted.
 		settled bool
 		// Human-readable description of the VAA's source, used for metrics.
@@ -70,13 +80,23 @@ type (
 		txHash []byte
 		// Copy of the guardian set valid at observation/injection time.
 		gs *common.GuardianSet
+		// Flag set when the state changed since it was last written to the database.
+		dirty bool
+		// Message ID of the observation, used to find its shard on restore. Taken from the first observation we saw, so it is
+		// untrusted unless we made the observation ourselves.
+		messageID string
+		// Flag set once the observation was reported as being one signature short of quorum for too long.
+		nearQuorumReported bool
+		// BLS signature shares by guardian, only collected if aggregated signatures are enabled. They are not persisted.
+		aggSigShares map[ethcommon.Address][]byte
 	}
 
 	observationMap map[string]*state
 
-	// aggregationState represents the node's aggregation of guardian signatures.
+	// aggregationState represents the node's aggregation of guardian signatures. It is split into shards by emitter, which are
+	// locked independently so that observations of different emitters can be aggregated in parallel.
 	aggregationState struct {
-		signatures observationMap
+		shards []*stateShard
 	}
 )
 
@@ -111,12 +131,65 @@ type Processor struct {
 	// guardian set by other components.
 	gst *common.GuardianSetState
 
-	// state is the current runtime VAA view
+	// state is the current runtime VAA view. Entries must only be accessed with the lock of their shard held.
 	state *aggregationState
 	// gk pk as eth address
 	ourAddr ethcommon.Address
 
 	governor *governor.ChainGovernor
+	// acct checks token bridge transfers against the accounting contract. Nil if the accountant is disabled.
+	acct *accountant.Accountant
+	// nttAcct checks native token transfers against the NTT accounting contract. Nil if the NTT accountant is disabled.
+	nttAcct *accountant.Accountant
+	// acctReadC receives the transfers committed by either accounting contract, which can then be signed.
+	acctReadC <-chan *common.MessagePublication
+	// gatewayRelayer relays the VAAs of transfers to the gateway once they reach quorum. Nil if the gateway relayer is disabled.
+	gatewayRelayer *gwrelayer.GatewayRelayer
+
+	// delayBuffer holds messages from chains configured with an observation delay. Nil if no delays are configured.
+	delayBuffer *observationDelayBuffer
+
+	// govStatus tracks signatures on governance VAAs injected via the admin service. May be nil.
+	govStatus *common.GovernanceStatusTracker
+	// conflicts records guardians signing different digests for the same message. May be nil.
+	conflicts *common.ObservationConflictTracker
+
+	// pendingObservations mirrors len(state.signatures) so it can be read outside of the processor goroutine.
+	pendingObservations atomic.Int64
+
+	// persisted holds the digests of the aggregation states currently stored in the database.
+	persisted map[string]struct{}
+
+	// reobservation is the re-observation policy. If nil, the default policy is used for all chains.
+	reobservation *ReobservationConfig
+	// nearQuorumThreshold is how long an observation can be one signature short of quorum before it is reported. Zero disables
+	// the reports.
+	nearQuorumThreshold time.Duration
+	// lateObservationWindow is how long after a VAA was submitted signatures arriving late are still added to the stored VAA.
+	// Zero disables it.
+	lateObservationWindow time.Duration
+
+	// shadow is set if the node is a shadow guardian, which observes and aggregates like a guardian but never broadcasts its
+	// signatures or anything else. It is used to validate the setup of a prospective guardian before it joins the guardian set.
+	shadow bool
+
+	// clock is the source of time for the aggregation state. Replays use a mock clock to run deterministically.
+	clock clock.Clock
+	// recorder, if set, records the inputs of the processor so that they can be replayed.
+	recorder Recorder
+
+	// sigVerifyWorkers is the number of goroutines verifying observation signatures in parallel, each of them one at a time. If zero,
+	// they are verified on the processor goroutine.
+	sigVerifyWorkers int
+	// aggregationShards is the number of shards the aggregation state is split into, each with a goroutine aggregating its
+	// observations. If zero, the state is not split and observations are aggregated on the processor goroutine.
+	aggregationShards int
+
+	// aggSigKey is our BLS key for experimental aggregated signatures. If nil, they are disabled.
+	aggSigKey *aggsig.SecretKey
+	// aggSigKeys caches the BLS public keys of guardians whose proof of possession has been verified.
+	aggSigKeys   map[ethcommon.Address]*aggSigPeerKey
+	aggSigKeysMu sync.Mutex
 }
 
 var (
@@ -147,6 +220,22 @@ func NewProcessor(
 	gk *ecdsa.PrivateKey,
 	gst *common.GuardianSetState,
 	g *governor.ChainGovernor,
+	acct *accountant.Accountant,
+	nttAcct *accountant.Accountant,
+	acctReadC <-chan *common.MessagePublication,
+	gatewayRelayer *gwrelayer.GatewayRelayer,
+	govStatus *common.GovernanceStatusTracker,
+	conflicts *common.ObservationConflictTracker,
+	observationDelays map[vaa.ChainID]time.Duration,
+	sigVerifyWorkers int,
+	aggregationShards int,
+	reobservation *ReobservationConfig,
+	nearQuorumThreshold time.Duration,
+	lateObservationWindow time.Duration,
+	shadow bool,
+	clk clock.Clock,
+	recorder Recorder,
+	aggSigKey *aggsig.SecretKey,
 ) *Processor {
 
 	return &Processor{
@@ -160,22 +249,79 @@ func NewProcessor(
 		gst:          gst,
 		db:           db,
 
-		logger:   supervisor.Logger(ctx),
-		state:    &aggregationState{observationMap{}},
-		ourAddr:  crypto.PubkeyToAddress(gk.PublicKey),
-		governor: g,
+		logger:    supervisor.Logger(ctx),
+		state:     newAggregationState(aggregationShards),
+		ourAddr:   crypto.PubkeyToAddress(gk.PublicKey),
+		governor:  g,
+		acct:      acct,
+		nttAcct:   nttAcct,
+		acctReadC: acctReadC,
+		govStatus: govStatus,
+		conflicts: conflicts,
+
+		gatewayRelayer: gatewayRelayer,
+
+		delayBuffer: newObservationDelayBuffer(observationDelays),
+
+		sigVerifyWorkers:  sigVerifyWorkers,
+		aggregationShards: aggregationShards,
+		reobservation:     reobservation,
+
+		nearQuorumThreshold:   nearQuorumThreshold,
+		lateObservationWindow: lateObservationWindow,
+
+		shadow: shadow,
+
+		clock:    clk,
+		recorder: recorder,
+
+		aggSigKey:  aggSigKey,
+		aggSigKeys: make(map[ethcommon.Address]*aggSigPeerKey),
 	}
 }
 
 func (p *Processor) Run(ctx context.Context) error {
-	cleanup := time.NewTicker(CleanupInterval)
+	cleanup := p.clock.Ticker(CleanupInterval)
 
 	// Always initialize the timer so don't have a nil pointer in the case below. It won't get rearmed after that.
-	govTimer := time.NewTimer(GovInterval)
+	govTimer := p.clock.Timer(GovInterval)
+
+	// The delay buffer check only runs if any observation delays are configured. A nil channel is never selected.
+	var delayC <-chan time.Time
+	if p.delayBuffer != nil {
+		delayTicker := p.clock.Ticker(DelayBufferCheckInterval)
+		defer delayTicker.Stop()
+		delayC = delayTicker.C
+	}
+
+	// With verification workers, observations reach the processor through verifiedC instead of obsvC. A nil channel is never selected.
+	obsvC := p.obsvC
+	var verifiedC chan []verifiedObservation
+	if p.sigVerifyWorkers > 0 {
+		verifiedC = make(chan []verifiedObservation, p.sigVerifyWorkers)
+		for i := 0; i < p.sigVerifyWorkers; i++ {
+			go runSigVerifier(ctx, p.logger, p.obsvC, verifiedC)
+		}
+		obsvC = nil
+	}
+
+	p.restoreAggregationState()
+	// The aggregation state is persisted on every exit, once the shard workers have stopped aggregating, so that the database gets the
+	// final state. The node closes the database only after the processor has returned.
+	workerCtx, cancelWorkers := context.WithCancel(ctx)
+	var workers sync.WaitGroup
+	defer func() {
+		cancelWorkers()
+		workers.Wait()
+		p.persistAggregationState()
+	}()
+	shardQueues := p.startShardWorkers(workerCtx, &workers)
 
 	for {
 		select {
 		case <-ctx.Done():
+			p.pendingObservations.Store(int64(p.state.size()))
+
 			// Log these as warnings so they show up in the benchmark logs.
 			metric := &dto.Metric{}
 			_ = observationChanDelay.Write(metric)
@@ -187,24 +333,42 @@ func (p *Processor) Run(ctx context.Context) error {
 
 			return ctx.Err()
 		case p.gs = <-p.setC:
+			p.recordGuardianSet(p.gs)
 			p.logger.Info("guardian set updated",
 				zap.Strings("set", p.gs.KeysAsHexStrings()),
 				zap.Uint32("index", p.gs.Index))
 			p.gst.Set(p.gs)
 		case k := <-p.msgC:
-			if p.governor != nil {
-				if !p.governor.ProcessMsg(k) {
-					continue
-				}
+			p.recordMessage(k)
+			if p.delayBuffer != nil && p.delayBuffer.add(k, p.clock.Now()) {
+				continue
 			}
-			p.handleMessage(k)
-		case m := <-p.obsvC:
+			p.processMessage(k)
+		case <-delayC:
+			for _, k := range p.delayBuffer.release(p.clock.Now()) {
+				p.processMessage(k)
+			}
+		case m := <-obsvC:
 			observationChanDelay.Observe(float64(time.Since(m.Timestamp).Microseconds()))
-			p.handleObservation(ctx, m)
+			p.recordObservation(m.Msg)
+			p.dispatchObservation(ctx, shardQueues, shardWork{obs: m})
+		case verified := <-verifiedC:
+			for i := range verified {
+				p.recordObservation(verified[i].obs.Msg)
+				p.dispatchObservation(ctx, shardQueues, shardWork{obs: verified[i].obs, signer: &verified[i].signer})
+			}
+		case k := <-p.acctReadC:
+			// SECURITY defense-in-depth: Make sure the accountant did not release an unexpected message.
+			if p.acct == nil && p.nttAcct == nil {
+				return fmt.Errorf("received a message from the accountant while it is disabled: `%s`", k.MessageIDString())
+			}
+			p.handleMessage(k)
 		case m := <-p.signedInC:
+			p.recordSignedVAA(m)
 			p.handleInboundSignedVAAWithQuorum(ctx, m)
 		case <-cleanup.C:
 			p.handleCleanup(ctx)
+			p.persistAggregationState()
 		case <-govTimer.C:
 			if p.governor != nil {
 				toBePublished, err := p.governor.CheckPending()
@@ -219,7 +383,7 @@ func (p *Processor) Run(ctx context.Context) error {
 						} else if !msgIsGoverned {
 							return fmt.Errorf("governor published a message that should not be governed: `%s`", k.MessageIDString())
 						}
-						p.handleMessage(k)
+						p.processAcctObservation(k)
 					}
 				}
 			}
@@ -230,8 +394,56 @@ func (p *Processor) Run(ctx context.Context) error {
 	}
 }
 
+// processMessage passes a message observed by one of our watchers through the governor and the accountant (if enabled) and signs it.
+func (p *Processor) processMessage(k *common.MessagePublication) {
+	if p.governor != nil {
+		if !p.governor.ProcessMsg(k) {
+			return
+		}
+	}
+	p.processAcctObservation(k)
+}
+
+// processAcctObservation submits a message to the accountants (if enabled) and signs it, unless an accountant holds it until its
+// accounting contract commits it. Held messages are signed once they are received on acctReadC. A message is accounted for by at
+// most one accountant, the others let it through.
+func (p *Processor) processAcctObservation(k *common.MessagePublication) {
+	for _, acct := range []*accountant.Accountant{p.acct, p.nttAcct} {
+		if acct == nil {
+			continue
+		}
+		shouldPub, err := acct.SubmitObservation(k)
+		if err != nil {
+			p.logger.Error("failed to submit message to the accountant", zap.String("msgID", k.MessageIDString()), zap.Error(err))
+			return
+		}
+		if !shouldPub {
+			return
+		}
+	}
+	p.handleMessage(k)
+}
+
+// PendingObservations returns the number of observations currently held in the aggregation state.
+// It is safe to call from any goroutine.
+func (p *Processor) PendingObservations() int {
+	return int(p.pendingObservations.Load())
+}
+
 func (p *Processor) storeSignedVAA(v *vaa.VAA) error {
-	return p.db.StoreSignedVAA(v)
+	if err := p.db.StoreSignedVAA(v); err != nil {
+		return err
+	}
+
+	// Governance VAAs which change the governor's configuration take effect once they reach quorum.
+	if p.governor != nil && v.EmitterChain == vaa.GovernanceChain && v.EmitterAddress == vaa.GovernanceEmitter {
+		p.governor.HandleGovernanceVAA(v)
+	}
+
+	if p.gatewayRelayer != nil {
+		p.gatewayRelayer.SubmitVAA(v)
+	}
+	return nil
 }
 
 // haveSignedVAA returns true if we already have a VAA for the given VAAID
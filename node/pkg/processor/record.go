@@ -0,0 +1,64 @@
+package processor
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// Recorder records the inputs of the processor in the order it handles them. It is implemented by the replay package's Writer,
+// whose recordings can be fed through a processor again to reproduce its decisions.
+type Recorder interface {
+	WriteGuardianSet(gs *common.GuardianSet, t time.Time) error
+	WriteMessage(msg *common.MessagePublication, t time.Time) error
+	WriteGossip(b []byte, t time.Time) error
+}
+
+func (p *Processor) recordGuardianSet(gs *common.GuardianSet) {
+	if p.recorder == nil {
+		return
+	}
+	if err := p.recorder.WriteGuardianSet(gs, p.clock.Now()); err != nil {
+		p.logger.Error("failed to record guardian set", zap.Error(err))
+	}
+}
+
+func (p *Processor) recordMessage(k *common.MessagePublication) {
+	if p.recorder == nil {
+		return
+	}
+	if err := p.recorder.WriteMessage(k, p.clock.Now()); err != nil {
+		p.logger.Error("failed to record message publication", zap.String("message_id", k.MessageIDString()), zap.Error(err))
+	}
+}
+
+// recordObservation records an observation received from p2p. Our own observations are not recorded, since a replay signs them
+// again when it is fed the messages they were made for.
+func (p *Processor) recordObservation(o *gossipv1.SignedObservation) {
+	if p.recorder == nil || bytes.Equal(o.Addr, p.ourAddr.Bytes()) {
+		return
+	}
+	p.recordGossip(&gossipv1.GossipMessage{Message: &gossipv1.GossipMessage_SignedObservation{SignedObservation: o}})
+}
+
+func (p *Processor) recordSignedVAA(m *gossipv1.SignedVAAWithQuorum) {
+	if p.recorder == nil {
+		return
+	}
+	p.recordGossip(&gossipv1.GossipMessage{Message: &gossipv1.GossipMessage_SignedVaaWithQuorum{SignedVaaWithQuorum: m}})
+}
+
+func (p *Processor) recordGossip(msg *gossipv1.GossipMessage) {
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		p.logger.Error("failed to marshal gossip message for recording", zap.Error(err))
+		return
+	}
+	if err := p.recorder.WriteGossip(b, p.clock.Now()); err != nil {
+		p.logger.Error("failed to record gossip message", zap.Error(err))
+	}
+}
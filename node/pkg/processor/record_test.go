@@ -0,0 +1,57 @@
+package processor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/certusone/wormhole/node/pkg/common"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+type testRecorder struct {
+	gossip [][]byte
+	times  []time.Time
+}
+
+func (r *testRecorder) WriteGuardianSet(gs *common.GuardianSet, t time.Time) error {
+	r.times = append(r.times, t)
+	return nil
+}
+
+func (r *testRecorder) WriteMessage(msg *common.MessagePublication, t time.Time) error {
+	r.times = append(r.times, t)
+	return nil
+}
+
+func (r *testRecorder) WriteGossip(b []byte, t time.Time) error {
+	r.gossip = append(r.gossip, b)
+	r.times = append(r.times, t)
+	return nil
+}
+
+func TestRecordObservation(t *testing.T) {
+	clk := clock.NewMock()
+	recorder := &testRecorder{}
+	p := &Processor{clock: clk, logger: zap.NewNop(), ourAddr: ethcommon.Address{1}, recorder: recorder}
+
+	// Our own observations are signed again on replay, so they are not recorded.
+	p.recordObservation(&gossipv1.SignedObservation{Addr: ethcommon.Address{1}.Bytes(), MessageId: "ours"})
+	p.recordObservation(&gossipv1.SignedObservation{Addr: ethcommon.Address{2}.Bytes(), MessageId: "theirs"})
+	require.Len(t, recorder.gossip, 1)
+	assert.Equal(t, []time.Time{clk.Now()}, recorder.times)
+
+	var msg gossipv1.GossipMessage
+	require.NoError(t, proto.Unmarshal(recorder.gossip[0], &msg))
+	assert.Equal(t, "theirs", msg.GetSignedObservation().MessageId)
+
+	// Without a recorder, nothing happens.
+	p.recorder = nil
+	p.recordObservation(&gossipv1.SignedObservation{Addr: ethcommon.Address{2}.Bytes()})
+	p.recordSignedVAA(&gossipv1.SignedVAAWithQuorum{})
+}
@@ -0,0 +1,56 @@
+package processor
+
+import (
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+var (
+	shadowObservations = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "wormhole_shadow_observations_total",
+			Help: "Total number of observations made in shadow mode, by emitter chain and whether the guardian set signed the same digest",
+		}, []string{"emitter_chain", "result"})
+)
+
+const (
+	// shadowMatched means the guardian set reached quorum on the digest we observed.
+	shadowMatched = "matched"
+	// shadowMismatched means the guardian set reached quorum on a different digest for the message.
+	shadowMismatched = "mismatched"
+	// shadowUnconfirmed means our observation expired without the guardian set reaching quorum on the message.
+	shadowUnconfirmed = "unconfirmed"
+)
+
+// recordShadowResult counts the outcome of an observation we made in shadow mode.
+func (p *Processor) recordShadowResult(s *state, result string) {
+	if !p.shadow || s.ourObservation == nil {
+		return
+	}
+	shadowObservations.WithLabelValues(s.ourObservation.GetEmitterChain().String(), result).Inc()
+}
+
+// checkShadowVAA compares an observation we made in shadow mode with the VAA the guardian set signed for the message, which we
+// received via gossip rather than aggregated ourselves.
+func (p *Processor) checkShadowVAA(hash string, s *state) {
+	if !p.shadow {
+		return
+	}
+	match, err := p.signedVaaAlreadyInDB(hash, s)
+	switch {
+	case match:
+		p.recordShadowResult(s, shadowMatched)
+	case errors.Is(err, errHashMismatch):
+		p.logger.Warn("our observation does not match the VAA signed by the guardian set",
+			zap.String("digest", hash),
+			zap.String("message_id", s.ourObservation.MessageID()),
+			zap.Error(err),
+		)
+		p.recordShadowResult(s, shadowMismatched)
+	case err != nil:
+		p.logger.Error("failed to compare our observation with the VAA signed by the guardian set", zap.String("digest", hash), zap.Error(err))
+	}
+}
@@ -0,0 +1,110 @@
+package processor
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/benbjohnson/clock"
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+func TestShadowMode(t *testing.T) {
+	database, err := db.OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer database.Close()
+
+	// The shadow guardian is not part of the guardian set.
+	gk, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	require.NoError(t, err)
+	gs := &common.GuardianSet{Index: 1}
+	gks := make([]*ecdsa.PrivateKey, 3)
+	for i := range gks {
+		gks[i], err = ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+		require.NoError(t, err)
+		gs.Keys = append(gs.Keys, crypto.PubkeyToAddress(gks[i].PublicKey))
+	}
+	gossipSendC := make(chan []byte, 10)
+	p := &Processor{
+		clock:       clock.New(),
+		logger:      zap.NewNop(),
+		db:          database,
+		gk:          gk,
+		gs:          gs,
+		ourAddr:     crypto.PubkeyToAddress(gk.PublicKey),
+		state:       newAggregationState(1),
+		shadow:      true,
+		gossipSendC: gossipSendC,
+		obsvC:       make(chan *common.MsgWithTimeStamp[gossipv1.SignedObservation], 10),
+	}
+
+	v := getVAA()
+	digest := v.SigningDigest().Bytes()
+	hash := hex.EncodeToString(digest)
+	chain := v.EmitterChain.String()
+	sig, err := crypto.Sign(digest, gk)
+	require.NoError(t, err)
+	p.broadcastSignature(&VAA{VAA: v}, sig, nil)
+
+	// Nothing is gossiped, and our own signature does not count towards quorum.
+	assert.Empty(t, gossipSendC)
+	assert.Empty(t, p.obsvC)
+	s := p.state.shardFor(v.MessageID()).signatures[hash]
+	require.NotNil(t, s)
+	assert.Empty(t, s.signatures)
+
+	before := testutil.ToFloat64(shadowObservations.WithLabelValues(chain, shadowMatched))
+	for _, k := range gks {
+		sig, err := crypto.Sign(digest, k)
+		require.NoError(t, err)
+		p.handleObservation(context.Background(), common.CreateMsgWithTimestamp[gossipv1.SignedObservation](&gossipv1.SignedObservation{
+			Addr:      crypto.PubkeyToAddress(k.PublicKey).Bytes(),
+			Hash:      digest,
+			Signature: sig,
+			MessageId: v.MessageID(),
+		}), gs)
+	}
+	assert.True(t, s.submitted)
+	assert.Equal(t, before+1, testutil.ToFloat64(shadowObservations.WithLabelValues(chain, shadowMatched)))
+	assert.Empty(t, gossipSendC)
+	_, err = database.GetSignedVAABytes(*db.VaaIDFromVAA(&v))
+	assert.NoError(t, err)
+}
+
+func TestCheckShadowVAA(t *testing.T) {
+	database, err := db.OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer database.Close()
+
+	p := &Processor{clock: clock.New(), logger: zap.NewNop(), db: database, shadow: true}
+	v := getVAA()
+	s := &state{ourObservation: &VAA{VAA: v}}
+	hash := hex.EncodeToString(v.SigningDigest().Bytes())
+	chain := v.EmitterChain.String()
+
+	// The guardian set signed a different payload for the message we observed.
+	forked := v
+	forked.Payload = []byte("forked")
+	forked.Signatures = []*vaa.Signature{{Index: 0}}
+	require.NoError(t, database.StoreSignedVAA(&forked))
+
+	before := testutil.ToFloat64(shadowObservations.WithLabelValues(chain, shadowMismatched))
+	p.checkShadowVAA(hash, s)
+	assert.Equal(t, before+1, testutil.ToFloat64(shadowObservations.WithLabelValues(chain, shadowMismatched)))
+
+	v.Signatures = forked.Signatures
+	require.NoError(t, database.StoreSignedVAA(&v))
+	before = testutil.ToFloat64(shadowObservations.WithLabelValues(chain, shadowMatched))
+	p.checkShadowVAA(hash, s)
+	assert.Equal(t, before+1, testutil.ToFloat64(shadowObservations.WithLabelValues(chain, shadowMatched)))
+}
@@ -0,0 +1,160 @@
+package processor
+
+import (
+	"context"
+	"hash/fnv"
+	"strings"
+	"sync"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// shardQueueSize is the number of observations that can be queued for each aggregation shard before dispatching blocks.
+const shardQueueSize = 1024
+
+// stateShard holds the aggregation state of the emitters assigned to it. Its lock is held while an observation is aggregated, so
+// shards can be worked on in parallel while observations of a single emitter are handled one at a time.
+type stateShard struct {
+	mu         sync.Mutex
+	signatures observationMap
+	// digests indexes the entries by message ID, to detect guardians signing different digests for the same message. Since all
+	// messages of an emitter are in the same shard, so are all the digests signed for a message.
+	digests map[string][]string
+}
+
+func newAggregationState(shards int) *aggregationState {
+	if shards < 1 {
+		shards = 1
+	}
+	a := &aggregationState{shards: make([]*stateShard, shards)}
+	for i := range a.shards {
+		a.shards[i] = &stateShard{signatures: observationMap{}, digests: make(map[string][]string)}
+	}
+	return a
+}
+
+// shardIndex returns the shard of the emitter of a message ID (emitter_chain/emitter_address/sequence). It only looks at the
+// emitter part of the ID, and does not validate it: an observation with a malformed ID merely ends up in a shard of its own
+// rather than with the other signatures for its digest, which only hurts the guardian that sent it.
+func (a *aggregationState) shardIndex(messageID string) int {
+	if len(a.shards) == 1 {
+		return 0
+	}
+	emitter := messageID
+	if i := strings.LastIndexByte(messageID, '/'); i >= 0 {
+		emitter = messageID[:i]
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(emitter))
+	return int(h.Sum32() % uint32(len(a.shards)))
+}
+
+func (a *aggregationState) shardFor(messageID string) *stateShard {
+	return a.shards[a.shardIndex(messageID)]
+}
+
+// add adds a new entry to the shard. It returns true if another digest is known for the message ID of the entry.
+func (sh *stateShard) add(hash string, s *state) bool {
+	sh.signatures[hash] = s
+	return sh.index(hash, s.messageID)
+}
+
+// remove deletes an entry from the shard.
+func (sh *stateShard) remove(hash string) {
+	if s, exists := sh.signatures[hash]; exists {
+		sh.unindex(hash, s.messageID)
+		delete(sh.signatures, hash)
+	}
+}
+
+func (sh *stateShard) index(hash string, messageID string) bool {
+	if messageID == "" {
+		return false
+	}
+	sh.digests[messageID] = append(sh.digests[messageID], hash)
+	return len(sh.digests[messageID]) > 1
+}
+
+func (sh *stateShard) unindex(hash string, messageID string) {
+	digests := sh.digests[messageID]
+	for i, d := range digests {
+		if d == hash {
+			digests = append(digests[:i], digests[i+1:]...)
+			break
+		}
+	}
+	if len(digests) == 0 {
+		delete(sh.digests, messageID)
+	} else {
+		sh.digests[messageID] = digests
+	}
+}
+
+// size returns the number of entries across all shards.
+func (a *aggregationState) size() int {
+	n := 0
+	for _, sh := range a.shards {
+		sh.mu.Lock()
+		n += len(sh.signatures)
+		sh.mu.Unlock()
+	}
+	return n
+}
+
+// shardWork is an observation queued for an aggregation shard.
+type shardWork struct {
+	obs *common.MsgWithTimeStamp[gossipv1.SignedObservation]
+	// signer is set if the signature of the observation was already verified.
+	signer *ethcommon.Address
+	// gs is the current guardian set when the observation was dispatched, since only the processor goroutine may read p.gs.
+	gs *common.GuardianSet
+}
+
+// startShardWorkers starts a goroutine per shard that aggregates the observations dispatched to it, which is added to workers until
+// it exits. It returns nil if shards are disabled, in which case observations are aggregated on the processor goroutine.
+func (p *Processor) startShardWorkers(ctx context.Context, workers *sync.WaitGroup) []chan shardWork {
+	if p.aggregationShards <= 0 {
+		return nil
+	}
+	queues := make([]chan shardWork, len(p.state.shards))
+	for i := range queues {
+		queues[i] = make(chan shardWork, shardQueueSize)
+		workers.Add(1)
+		go func(queue <-chan shardWork) {
+			defer workers.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case w := <-queue:
+					p.aggregateObservation(ctx, w)
+				}
+			}
+		}(queues[i])
+	}
+	return queues
+}
+
+// dispatchObservation passes an observation to the shard of its emitter, or aggregates it right away if shards are disabled. It
+// must be called from the processor goroutine.
+func (p *Processor) dispatchObservation(ctx context.Context, queues []chan shardWork, w shardWork) {
+	w.gs = p.gs
+	if queues == nil {
+		p.aggregateObservation(ctx, w)
+		return
+	}
+	select {
+	case <-ctx.Done():
+	case queues[p.state.shardIndex(w.obs.Msg.MessageId)] <- w:
+	}
+}
+
+func (p *Processor) aggregateObservation(ctx context.Context, w shardWork) {
+	if w.signer == nil {
+		p.handleObservation(ctx, w.obs, w.gs)
+	} else {
+		p.handleVerifiedObservation(ctx, w.obs, *w.signer, w.gs)
+	}
+}
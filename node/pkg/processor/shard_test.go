@@ -0,0 +1,140 @@
+package processor
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+func TestShardIndex(t *testing.T) {
+	a := newAggregationState(4)
+	shards := make(map[int]struct{})
+	for emitter := 0; emitter < 16; emitter++ {
+		prefix := fmt.Sprintf("1/%064x/", emitter)
+		idx := a.shardIndex(prefix + "1")
+		for seq := 2; seq < 10; seq++ {
+			assert.Equal(t, idx, a.shardIndex(fmt.Sprint(prefix, seq)))
+		}
+		shards[idx] = struct{}{}
+	}
+	assert.Greater(t, len(shards), 1)
+
+	assert.Equal(t, 0, newAggregationState(1).shardIndex("1/0000/1"))
+	assert.Equal(t, 0, newAggregationState(0).shardIndex("1/0000/1"))
+}
+
+// TestShardedAggregation aggregates observations of several emitters across shards while cleanup and persistence run concurrently.
+// Run with -race.
+func TestShardedAggregation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	database, err := db.OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer database.Close()
+
+	gs := &common.GuardianSet{Index: 1}
+	gks := make([]*ecdsa.PrivateKey, 4)
+	for i := range gks {
+		gks[i], err = ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+		require.NoError(t, err)
+		gs.Keys = append(gs.Keys, crypto.PubkeyToAddress(gks[i].PublicKey))
+	}
+
+	gossipSendC := make(chan []byte, 100)
+	p := &Processor{
+		clock:             clock.New(),
+		logger:            zap.NewNop(),
+		db:                database,
+		gk:                gks[0],
+		gs:                gs,
+		ourAddr:           gs.Keys[0],
+		state:             newAggregationState(4),
+		aggregationShards: 4,
+		gossipSendC:       gossipSendC,
+		obsvC:             make(chan *common.MsgWithTimeStamp[gossipv1.SignedObservation], 100),
+	}
+	var workers sync.WaitGroup
+	queues := p.startShardWorkers(ctx, &workers)
+	require.Len(t, queues, 4)
+
+	// Drain gossip, which receives our observations and the signed VAAs.
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-gossipSendC:
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				p.handleCleanup(ctx)
+				p.persistAggregationState()
+			}
+		}
+	}()
+
+	var vaas []vaa.VAA
+	for i := 0; i < 8; i++ {
+		v := getVAA()
+		v.EmitterChain = vaa.ChainID(1 + i%2)
+		v.EmitterAddress = vaa.Address{31: byte(i)}
+		v.Sequence = uint64(i)
+		vaas = append(vaas, v)
+
+		digest := v.SigningDigest().Bytes()
+		sig, err := crypto.Sign(digest, gks[0])
+		require.NoError(t, err)
+		p.broadcastSignature(&VAA{VAA: v}, sig, nil)
+		p.dispatchObservation(ctx, queues, shardWork{obs: <-p.obsvC})
+
+		for _, gk := range gks[1:3] {
+			sig, err := crypto.Sign(digest, gk)
+			require.NoError(t, err)
+			p.dispatchObservation(ctx, queues, shardWork{obs: common.CreateMsgWithTimestamp[gossipv1.SignedObservation](&gossipv1.SignedObservation{
+				Addr:      crypto.PubkeyToAddress(gk.PublicKey).Bytes(),
+				Hash:      digest,
+				Signature: sig,
+				MessageId: v.MessageID(),
+			})})
+		}
+	}
+
+	require.Eventually(t, func() bool {
+		for i := range vaas {
+			if ok, err := database.HasVAA(*db.VaaIDFromVAA(&vaas[i])); err != nil || !ok {
+				return false
+			}
+		}
+		return true
+	}, 10*time.Second, 10*time.Millisecond)
+
+	close(done)
+	wg.Wait()
+	assert.Equal(t, len(vaas), p.state.size())
+}
@@ -9,22 +9,12 @@ type VAA struct {
 	vaa.VAA
 	Unreliable    bool
 	Reobservation bool
+	// Confidence is the watcher's annotation of how final the message was when we observed it.
+	Confidence string
 }
 
 func (v *VAA) HandleQuorum(sigs []*vaa.Signature, hash string, p *Processor) {
-	// Deep copy the observation and add signatures
-	signed := &vaa.VAA{
-		Version:          v.Version,
-		GuardianSetIndex: v.GuardianSetIndex,
-		Signatures:       sigs,
-		Timestamp:        v.Timestamp,
-		Nonce:            v.Nonce,
-		Sequence:         v.Sequence,
-		EmitterChain:     v.EmitterChain,
-		EmitterAddress:   v.EmitterAddress,
-		Payload:          v.Payload,
-		ConsistencyLevel: v.ConsistencyLevel,
-	}
+	signed := v.signed(sigs)
 
 	// Store signed VAA in database.
 	p.logger.Info("signed VAA with quorum",
@@ -33,10 +23,27 @@ func (v *VAA) HandleQuorum(sigs []*vaa.Signature, hash string, p *Processor) {
 
 	if err := p.storeSignedVAA(signed); err != nil {
 		p.logger.Error("failed to store signed VAA", zap.Error(err))
+	} else {
+		observeVAAStored(signed, p.clock.Now())
 	}
 
 	p.broadcastSignedVAA(signed)
-	p.state.signatures[hash].submitted = true
+}
+
+// signed returns a deep copy of the observation with the given signatures.
+func (v *VAA) signed(sigs []*vaa.Signature) *vaa.VAA {
+	return &vaa.VAA{
+		Version:          v.Version,
+		GuardianSetIndex: v.GuardianSetIndex,
+		Signatures:       sigs,
+		Timestamp:        v.Timestamp,
+		Nonce:            v.Nonce,
+		Sequence:         v.Sequence,
+		EmitterChain:     v.EmitterChain,
+		EmitterAddress:   v.EmitterAddress,
+		Payload:          v.Payload,
+		ConsistencyLevel: v.ConsistencyLevel,
+	}
 }
 
 func (v *VAA) IsReliable() bool {
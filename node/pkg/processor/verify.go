@@ -0,0 +1,114 @@
+package processor
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	node_common "github.com/certusone/wormhole/node/pkg/common"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"go.uber.org/zap"
+)
+
+// sigVerifyMaxHandoff is the maximum number of observations a verification worker takes off the channel at once and hands to the
+// processor goroutine together. This amortizes the hand-off during bursts, while a lone observation is still passed on right away.
+// The signatures are still verified one by one.
+const sigVerifyMaxHandoff = 64
+
+var errSignerMismatch = errors.New("address does not match pubkey")
+
+// verifiedObservation is an observation whose signature has been checked, along with the guardian that signed it.
+type verifiedObservation struct {
+	obs    *node_common.MsgWithTimeStamp[gossipv1.SignedObservation]
+	signer common.Address
+}
+
+// recoverObservationSigner verifies that m.Signature matches m.Hash and was made by the key of m.Addr, and returns that address.
+func recoverObservationSigner(m *gossipv1.SignedObservation) (common.Address, error) {
+	pk, err := crypto.Ecrecover(m.Hash, m.Signature)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	theirAddr := common.BytesToAddress(m.Addr)
+	if signer := common.BytesToAddress(crypto.Keccak256(pk[1:])[12:]); theirAddr != signer {
+		return signer, errSignerMismatch
+	}
+	return theirAddr, nil
+}
+
+// verifyObservation checks the signature of an observation, logging and counting it if it is invalid.
+func verifyObservation(logger *zap.Logger, m *gossipv1.SignedObservation) (common.Address, bool) {
+	signer, err := recoverObservationSigner(m)
+	if errors.Is(err, errSignerMismatch) {
+		logger.Info("invalid observation - address does not match pubkey",
+			zap.String("digest", hex.EncodeToString(m.Hash)),
+			zap.String("signature", hex.EncodeToString(m.Signature)),
+			zap.String("addr", hex.EncodeToString(m.Addr)),
+			zap.String("pk", signer.Hex()))
+		observationsFailedTotal.WithLabelValues("pubkey_mismatch").Inc()
+		return common.Address{}, false
+	} else if err != nil {
+		logger.Warn("failed to verify signature on observation",
+			zap.String("digest", hex.EncodeToString(m.Hash)),
+			zap.String("signature", hex.EncodeToString(m.Signature)),
+			zap.String("addr", hex.EncodeToString(m.Addr)),
+			zap.Error(err))
+		observationsFailedTotal.WithLabelValues("invalid_signature").Inc()
+		return common.Address{}, false
+	}
+	return signer, true
+}
+
+// runSigVerifier verifies the signatures of observations from obsvC and passes the valid ones to verifiedC. Several of them run in
+// parallel so that signature recovery, which dominates the cost of handling an observation, does not have to happen on the
+// processor goroutine. This only adds parallelism: each signature is recovered with its own ecrecover, since the signer of an
+// observation is identified by recovering its key and there is no batch verification for that. Observations may be passed on in a
+// different order than they were received, which the aggregation state does not depend on.
+func runSigVerifier(
+	ctx context.Context,
+	logger *zap.Logger,
+	obsvC <-chan *node_common.MsgWithTimeStamp[gossipv1.SignedObservation],
+	verifiedC chan<- []verifiedObservation,
+) {
+	received := make([]*node_common.MsgWithTimeStamp[gossipv1.SignedObservation], 0, sigVerifyMaxHandoff)
+	for {
+		received = received[:0]
+		select {
+		case <-ctx.Done():
+			return
+		case m := <-obsvC:
+			received = append(received, m)
+		}
+	drain:
+		for len(received) < sigVerifyMaxHandoff {
+			select {
+			case m := <-obsvC:
+				received = append(received, m)
+			default:
+				break drain
+			}
+		}
+
+		verified := make([]verifiedObservation, 0, len(received))
+		for _, m := range received {
+			observationChanDelay.Observe(float64(time.Since(m.Timestamp).Microseconds()))
+			observationsReceivedTotal.Inc()
+			if signer, ok := verifyObservation(logger, m.Msg); ok {
+				verified = append(verified, verifiedObservation{obs: m, signer: signer})
+			}
+		}
+		if len(verified) == 0 {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case verifiedC <- verified:
+		}
+	}
+}
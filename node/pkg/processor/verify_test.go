@@ -0,0 +1,124 @@
+package processor
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func signedObservation(t testing.TB, gk *ecdsa.PrivateKey, sequence uint64) *common.MsgWithTimeStamp[gossipv1.SignedObservation] {
+	t.Helper()
+	hash := crypto.Keccak256([]byte(fmt.Sprintf("observation %d", sequence)))
+	sig, err := crypto.Sign(hash, gk)
+	require.NoError(t, err)
+	return common.CreateMsgWithTimestamp[gossipv1.SignedObservation](&gossipv1.SignedObservation{
+		Addr:      crypto.PubkeyToAddress(gk.PublicKey).Bytes(),
+		Hash:      hash,
+		Signature: sig,
+		MessageId: fmt.Sprintf("1/0000000000000000000000000000000000000000000000000000000000000001/%d", sequence),
+	})
+}
+
+func TestRecoverObservationSigner(t *testing.T) {
+	gk, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	require.NoError(t, err)
+	other, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	require.NoError(t, err)
+
+	m := signedObservation(t, gk, 1).Msg
+	signer, err := recoverObservationSigner(m)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(gk.PublicKey), signer)
+
+	m.Addr = crypto.PubkeyToAddress(other.PublicKey).Bytes()
+	_, err = recoverObservationSigner(m)
+	assert.ErrorIs(t, err, errSignerMismatch)
+
+	m.Signature = m.Signature[:64]
+	_, err = recoverObservationSigner(m)
+	assert.Error(t, err)
+}
+
+func TestRunSigVerifier(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	gk, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	require.NoError(t, err)
+
+	obsvC := make(chan *common.MsgWithTimeStamp[gossipv1.SignedObservation], 10)
+	verifiedC := make(chan []verifiedObservation)
+	for i := 0; i < 2; i++ {
+		go runSigVerifier(ctx, zap.NewNop(), obsvC, verifiedC)
+	}
+
+	bad := signedObservation(t, gk, 2)
+	bad.Msg.Signature[0] ^= 0xff
+	obsvC <- signedObservation(t, gk, 1)
+	obsvC <- bad
+	obsvC <- signedObservation(t, gk, 3)
+
+	// The invalid observation is dropped, the others are passed on with their signer in some order.
+	var got []string
+	for len(got) < 2 {
+		for _, v := range <-verifiedC {
+			assert.Equal(t, crypto.PubkeyToAddress(gk.PublicKey), v.signer)
+			got = append(got, v.obs.Msg.MessageId)
+		}
+	}
+	assert.ElementsMatch(t, []string{
+		"1/0000000000000000000000000000000000000000000000000000000000000001/1",
+		"1/0000000000000000000000000000000000000000000000000000000000000001/3",
+	}, got)
+	assert.Empty(t, verifiedC)
+}
+
+// BenchmarkObservationVerification measures how many observations per second the processor can verify, either on its own
+// goroutine or with a pool of workers.
+func BenchmarkObservationVerification(b *testing.B) {
+	gk, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	require.NoError(b, err)
+	observations := make([]*common.MsgWithTimeStamp[gossipv1.SignedObservation], 1000)
+	for i := range observations {
+		observations[i] = signedObservation(b, gk, uint64(i))
+	}
+
+	b.Run("inline", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, ok := verifyObservation(zap.NewNop(), observations[i%len(observations)].Msg); !ok {
+				b.Fatal("verification failed")
+			}
+		}
+	})
+
+	for _, workers := range []int{2, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			obsvC := make(chan *common.MsgWithTimeStamp[gossipv1.SignedObservation], 1000)
+			verifiedC := make(chan []verifiedObservation, workers)
+			for i := 0; i < workers; i++ {
+				go runSigVerifier(ctx, zap.NewNop(), obsvC, verifiedC)
+			}
+
+			b.ResetTimer()
+			go func() {
+				for i := 0; i < b.N; i++ {
+					obsvC <- observations[i%len(observations)]
+				}
+			}()
+			for verified := 0; verified < b.N; {
+				verified += len(<-verifiedC)
+			}
+		})
+	}
+}
@@ -0,0 +1,670 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        (unknown)
+// source: ccq/v1/ccq.proto
+
+package ccqv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SubmitQueryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Serialized query request.
+	QueryRequest []byte `protobuf:"bytes,1,opt,name=query_request,json=queryRequest,proto3" json:"query_request,omitempty"`
+	// Signature of the query request. May be empty if the API key allows the proxy to sign on the caller's behalf.
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *SubmitQueryRequest) Reset() {
+	*x = SubmitQueryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ccq_v1_ccq_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubmitQueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitQueryRequest) ProtoMessage() {}
+
+func (x *SubmitQueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ccq_v1_ccq_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitQueryRequest.ProtoReflect.Descriptor instead.
+func (*SubmitQueryRequest) Descriptor() ([]byte, []int) {
+	return file_ccq_v1_ccq_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SubmitQueryRequest) GetQueryRequest() []byte {
+	if x != nil {
+		return x.QueryRequest
+	}
+	return nil
+}
+
+func (x *SubmitQueryRequest) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type SubmitQueryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Hex-encoded request signature, which identifies the request in the proxy logs.
+	RequestId string `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// Serialized query response.
+	QueryResponse []byte `protobuf:"bytes,2,opt,name=query_response,json=queryResponse,proto3" json:"query_response,omitempty"`
+	// Guardian signatures over the response, in ascending order of guardian index. Each one is a 65 byte
+	// ECDSA signature followed by a byte holding the index of the guardian in the guardian set.
+	Signatures [][]byte `protobuf:"bytes,3,rep,name=signatures,proto3" json:"signatures,omitempty"`
+}
+
+func (x *SubmitQueryResponse) Reset() {
+	*x = SubmitQueryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ccq_v1_ccq_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubmitQueryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitQueryResponse) ProtoMessage() {}
+
+func (x *SubmitQueryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ccq_v1_ccq_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitQueryResponse.ProtoReflect.Descriptor instead.
+func (*SubmitQueryResponse) Descriptor() ([]byte, []int) {
+	return file_ccq_v1_ccq_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SubmitQueryResponse) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *SubmitQueryResponse) GetQueryResponse() []byte {
+	if x != nil {
+		return x.QueryResponse
+	}
+	return nil
+}
+
+func (x *SubmitQueryResponse) GetSignatures() [][]byte {
+	if x != nil {
+		return x.Signatures
+	}
+	return nil
+}
+
+// QueryAccepted is sent once the query has been published to the guardians.
+type QueryAccepted struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RequestId string `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+}
+
+func (x *QueryAccepted) Reset() {
+	*x = QueryAccepted{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ccq_v1_ccq_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryAccepted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryAccepted) ProtoMessage() {}
+
+func (x *QueryAccepted) ProtoReflect() protoreflect.Message {
+	mi := &file_ccq_v1_ccq_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryAccepted.ProtoReflect.Descriptor instead.
+func (*QueryAccepted) Descriptor() ([]byte, []int) {
+	return file_ccq_v1_ccq_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *QueryAccepted) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+// QueryProgress reports the guardian responses received so far for a query that has not reached quorum.
+type QueryProgress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NumResponses         uint32 `protobuf:"varint,1,opt,name=num_responses,json=numResponses,proto3" json:"num_responses,omitempty"`
+	MaxMatchingResponses uint32 `protobuf:"varint,2,opt,name=max_matching_responses,json=maxMatchingResponses,proto3" json:"max_matching_responses,omitempty"`
+	OutstandingResponses uint32 `protobuf:"varint,3,opt,name=outstanding_responses,json=outstandingResponses,proto3" json:"outstanding_responses,omitempty"`
+	Quorum               uint32 `protobuf:"varint,4,opt,name=quorum,proto3" json:"quorum,omitempty"`
+	// The leading response to each per-chain query, in the order of the request.
+	Chains []*ChainProgress `protobuf:"bytes,5,rep,name=chains,proto3" json:"chains,omitempty"`
+	// Number of guardians the query has been sent to so far.
+	GuardiansQueried uint32 `protobuf:"varint,6,opt,name=guardians_queried,json=guardiansQueried,proto3" json:"guardians_queried,omitempty"`
+	// Set once the query has been sent to the remaining guardians because it did not reach quorum in time.
+	FailedOver bool `protobuf:"varint,7,opt,name=failed_over,json=failedOver,proto3" json:"failed_over,omitempty"`
+}
+
+func (x *QueryProgress) Reset() {
+	*x = QueryProgress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ccq_v1_ccq_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryProgress) ProtoMessage() {}
+
+func (x *QueryProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_ccq_v1_ccq_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryProgress.ProtoReflect.Descriptor instead.
+func (*QueryProgress) Descriptor() ([]byte, []int) {
+	return file_ccq_v1_ccq_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *QueryProgress) GetNumResponses() uint32 {
+	if x != nil {
+		return x.NumResponses
+	}
+	return 0
+}
+
+func (x *QueryProgress) GetMaxMatchingResponses() uint32 {
+	if x != nil {
+		return x.MaxMatchingResponses
+	}
+	return 0
+}
+
+func (x *QueryProgress) GetOutstandingResponses() uint32 {
+	if x != nil {
+		return x.OutstandingResponses
+	}
+	return 0
+}
+
+func (x *QueryProgress) GetQuorum() uint32 {
+	if x != nil {
+		return x.Quorum
+	}
+	return 0
+}
+
+func (x *QueryProgress) GetChains() []*ChainProgress {
+	if x != nil {
+		return x.Chains
+	}
+	return nil
+}
+
+func (x *QueryProgress) GetGuardiansQueried() uint32 {
+	if x != nil {
+		return x.GuardiansQueried
+	}
+	return 0
+}
+
+func (x *QueryProgress) GetFailedOver() bool {
+	if x != nil {
+		return x.FailedOver
+	}
+	return false
+}
+
+// ChainProgress is the response to a per-chain query returned by the most guardians so far.
+type ChainProgress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ChainId uint32 `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	// Serialized chain specific response, empty if no guardian has answered yet.
+	Response          []byte `protobuf:"bytes,2,opt,name=response,proto3" json:"response,omitempty"`
+	MatchingResponses uint32 `protobuf:"varint,3,opt,name=matching_responses,json=matchingResponses,proto3" json:"matching_responses,omitempty"`
+}
+
+func (x *ChainProgress) Reset() {
+	*x = ChainProgress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ccq_v1_ccq_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChainProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChainProgress) ProtoMessage() {}
+
+func (x *ChainProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_ccq_v1_ccq_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChainProgress.ProtoReflect.Descriptor instead.
+func (*ChainProgress) Descriptor() ([]byte, []int) {
+	return file_ccq_v1_ccq_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ChainProgress) GetChainId() uint32 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *ChainProgress) GetResponse() []byte {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *ChainProgress) GetMatchingResponses() uint32 {
+	if x != nil {
+		return x.MatchingResponses
+	}
+	return 0
+}
+
+type StreamQueryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Message:
+	//
+	//	*StreamQueryResponse_Accepted
+	//	*StreamQueryResponse_Progress
+	//	*StreamQueryResponse_Response
+	Message isStreamQueryResponse_Message `protobuf_oneof:"message"`
+}
+
+func (x *StreamQueryResponse) Reset() {
+	*x = StreamQueryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ccq_v1_ccq_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamQueryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamQueryResponse) ProtoMessage() {}
+
+func (x *StreamQueryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ccq_v1_ccq_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamQueryResponse.ProtoReflect.Descriptor instead.
+func (*StreamQueryResponse) Descriptor() ([]byte, []int) {
+	return file_ccq_v1_ccq_proto_rawDescGZIP(), []int{5}
+}
+
+func (m *StreamQueryResponse) GetMessage() isStreamQueryResponse_Message {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func (x *StreamQueryResponse) GetAccepted() *QueryAccepted {
+	if x, ok := x.GetMessage().(*StreamQueryResponse_Accepted); ok {
+		return x.Accepted
+	}
+	return nil
+}
+
+func (x *StreamQueryResponse) GetProgress() *QueryProgress {
+	if x, ok := x.GetMessage().(*StreamQueryResponse_Progress); ok {
+		return x.Progress
+	}
+	return nil
+}
+
+func (x *StreamQueryResponse) GetResponse() *SubmitQueryResponse {
+	if x, ok := x.GetMessage().(*StreamQueryResponse_Response); ok {
+		return x.Response
+	}
+	return nil
+}
+
+type isStreamQueryResponse_Message interface {
+	isStreamQueryResponse_Message()
+}
+
+type StreamQueryResponse_Accepted struct {
+	Accepted *QueryAccepted `protobuf:"bytes,1,opt,name=accepted,proto3,oneof"`
+}
+
+type StreamQueryResponse_Progress struct {
+	Progress *QueryProgress `protobuf:"bytes,2,opt,name=progress,proto3,oneof"`
+}
+
+type StreamQueryResponse_Response struct {
+	Response *SubmitQueryResponse `protobuf:"bytes,3,opt,name=response,proto3,oneof"`
+}
+
+func (*StreamQueryResponse_Accepted) isStreamQueryResponse_Message() {}
+
+func (*StreamQueryResponse_Progress) isStreamQueryResponse_Message() {}
+
+func (*StreamQueryResponse_Response) isStreamQueryResponse_Message() {}
+
+var File_ccq_v1_ccq_proto protoreflect.FileDescriptor
+
+var file_ccq_v1_ccq_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x63, 0x63, 0x71, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x63, 0x71, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x06, 0x63, 0x63, 0x71, 0x2e, 0x76, 0x31, 0x22, 0x57, 0x0a, 0x12, 0x53, 0x75,
+	0x62, 0x6d, 0x69, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x23, 0x0a, 0x0d, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x71, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x22, 0x7b, 0x0a, 0x13, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x71, 0x75, 0x65,
+	0x72, 0x79, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0d, 0x71, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0c, 0x52, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73,
+	0x22, 0x2e, 0x0a, 0x0d, 0x51, 0x75, 0x65, 0x72, 0x79, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65,
+	0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64,
+	0x22, 0xb4, 0x02, 0x0a, 0x0d, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65,
+	0x73, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x6e, 0x75, 0x6d, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x6e, 0x75, 0x6d, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x12, 0x34, 0x0a, 0x16, 0x6d, 0x61, 0x78, 0x5f, 0x6d,
+	0x61, 0x74, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x6d, 0x61, 0x78, 0x4d, 0x61, 0x74, 0x63,
+	0x68, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x12, 0x33, 0x0a,
+	0x15, 0x6f, 0x75, 0x74, 0x73, 0x74, 0x61, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x72, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x6f, 0x75,
+	0x74, 0x73, 0x74, 0x61, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x06, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x12, 0x2d, 0x0a, 0x06, 0x63, 0x68,
+	0x61, 0x69, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x63, 0x71,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73,
+	0x73, 0x52, 0x06, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x67, 0x75, 0x61,
+	0x72, 0x64, 0x69, 0x61, 0x6e, 0x73, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x64, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x10, 0x67, 0x75, 0x61, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x73, 0x51,
+	0x75, 0x65, 0x72, 0x69, 0x65, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64,
+	0x5f, 0x6f, 0x76, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x66, 0x61, 0x69,
+	0x6c, 0x65, 0x64, 0x4f, 0x76, 0x65, 0x72, 0x22, 0x75, 0x0a, 0x0d, 0x43, 0x68, 0x61, 0x69, 0x6e,
+	0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x69,
+	0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x63, 0x68, 0x61, 0x69,
+	0x6e, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x2d, 0x0a, 0x12, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x5f, 0x72, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x22, 0xc5,
+	0x01, 0x0a, 0x13, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74,
+	0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x63, 0x71, 0x2e, 0x76,
+	0x31, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x48,
+	0x00, 0x52, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x12, 0x33, 0x0a, 0x08, 0x70,
+	0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x63, 0x63, 0x71, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x72, 0x6f, 0x67,
+	0x72, 0x65, 0x73, 0x73, 0x48, 0x00, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73,
+	0x12, 0x39, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x63, 0x71, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x62, 0x6d,
+	0x69, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48,
+	0x00, 0x52, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x09, 0x0a, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x32, 0xa0, 0x01, 0x0a, 0x0c, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x46, 0x0a, 0x0b, 0x53, 0x75, 0x62, 0x6d, 0x69,
+	0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x1a, 0x2e, 0x63, 0x63, 0x71, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x63, 0x63, 0x71, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x62, 0x6d,
+	0x69, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x48, 0x0a, 0x0b, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x1a,
+	0x2e, 0x63, 0x63, 0x71, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x63, 0x63, 0x71,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x42, 0x3b, 0x5a, 0x39, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x65, 0x72, 0x74, 0x75, 0x73, 0x6f, 0x6e,
+	0x65, 0x2f, 0x77, 0x6f, 0x72, 0x6d, 0x68, 0x6f, 0x6c, 0x65, 0x2f, 0x6e, 0x6f, 0x64, 0x65, 0x2f,
+	0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x63, 0x71, 0x2f, 0x76, 0x31,
+	0x3b, 0x63, 0x63, 0x71, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_ccq_v1_ccq_proto_rawDescOnce sync.Once
+	file_ccq_v1_ccq_proto_rawDescData = file_ccq_v1_ccq_proto_rawDesc
+)
+
+func file_ccq_v1_ccq_proto_rawDescGZIP() []byte {
+	file_ccq_v1_ccq_proto_rawDescOnce.Do(func() {
+		file_ccq_v1_ccq_proto_rawDescData = protoimpl.X.CompressGZIP(file_ccq_v1_ccq_proto_rawDescData)
+	})
+	return file_ccq_v1_ccq_proto_rawDescData
+}
+
+var file_ccq_v1_ccq_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_ccq_v1_ccq_proto_goTypes = []interface{}{
+	(*SubmitQueryRequest)(nil),  // 0: ccq.v1.SubmitQueryRequest
+	(*SubmitQueryResponse)(nil), // 1: ccq.v1.SubmitQueryResponse
+	(*QueryAccepted)(nil),       // 2: ccq.v1.QueryAccepted
+	(*QueryProgress)(nil),       // 3: ccq.v1.QueryProgress
+	(*ChainProgress)(nil),       // 4: ccq.v1.ChainProgress
+	(*StreamQueryResponse)(nil), // 5: ccq.v1.StreamQueryResponse
+}
+var file_ccq_v1_ccq_proto_depIdxs = []int32{
+	4, // 0: ccq.v1.QueryProgress.chains:type_name -> ccq.v1.ChainProgress
+	2, // 1: ccq.v1.StreamQueryResponse.accepted:type_name -> ccq.v1.QueryAccepted
+	3, // 2: ccq.v1.StreamQueryResponse.progress:type_name -> ccq.v1.QueryProgress
+	1, // 3: ccq.v1.StreamQueryResponse.response:type_name -> ccq.v1.SubmitQueryResponse
+	0, // 4: ccq.v1.QueryService.SubmitQuery:input_type -> ccq.v1.SubmitQueryRequest
+	0, // 5: ccq.v1.QueryService.StreamQuery:input_type -> ccq.v1.SubmitQueryRequest
+	1, // 6: ccq.v1.QueryService.SubmitQuery:output_type -> ccq.v1.SubmitQueryResponse
+	5, // 7: ccq.v1.QueryService.StreamQuery:output_type -> ccq.v1.StreamQueryResponse
+	6, // [6:8] is the sub-list for method output_type
+	4, // [4:6] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_ccq_v1_ccq_proto_init() }
+func file_ccq_v1_ccq_proto_init() {
+	if File_ccq_v1_ccq_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_ccq_v1_ccq_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubmitQueryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ccq_v1_ccq_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubmitQueryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ccq_v1_ccq_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryAccepted); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ccq_v1_ccq_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryProgress); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ccq_v1_ccq_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChainProgress); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ccq_v1_ccq_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamQueryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_ccq_v1_ccq_proto_msgTypes[5].OneofWrappers = []interface{}{
+		(*StreamQueryResponse_Accepted)(nil),
+		(*StreamQueryResponse_Progress)(nil),
+		(*StreamQueryResponse_Response)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_ccq_v1_ccq_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_ccq_v1_ccq_proto_goTypes,
+		DependencyIndexes: file_ccq_v1_ccq_proto_depIdxs,
+		MessageInfos:      file_ccq_v1_ccq_proto_msgTypes,
+	}.Build()
+	File_ccq_v1_ccq_proto = out.File
+	file_ccq_v1_ccq_proto_rawDesc = nil
+	file_ccq_v1_ccq_proto_goTypes = nil
+	file_ccq_v1_ccq_proto_depIdxs = nil
+}
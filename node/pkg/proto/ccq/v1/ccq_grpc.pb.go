@@ -0,0 +1,171 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package ccqv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// QueryServiceClient is the client API for QueryService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type QueryServiceClient interface {
+	// SubmitQuery publishes a query to the guardians and returns once a quorum of them have responded.
+	SubmitQuery(ctx context.Context, in *SubmitQueryRequest, opts ...grpc.CallOption) (*SubmitQueryResponse, error)
+	// StreamQuery publishes a query to the guardians and reports its progress. The stream ends with
+	// the response once a quorum of guardians have responded.
+	StreamQuery(ctx context.Context, in *SubmitQueryRequest, opts ...grpc.CallOption) (QueryService_StreamQueryClient, error)
+}
+
+type queryServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewQueryServiceClient(cc grpc.ClientConnInterface) QueryServiceClient {
+	return &queryServiceClient{cc}
+}
+
+func (c *queryServiceClient) SubmitQuery(ctx context.Context, in *SubmitQueryRequest, opts ...grpc.CallOption) (*SubmitQueryResponse, error) {
+	out := new(SubmitQueryResponse)
+	err := c.cc.Invoke(ctx, "/ccq.v1.QueryService/SubmitQuery", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryServiceClient) StreamQuery(ctx context.Context, in *SubmitQueryRequest, opts ...grpc.CallOption) (QueryService_StreamQueryClient, error) {
+	stream, err := c.cc.NewStream(ctx, &QueryService_ServiceDesc.Streams[0], "/ccq.v1.QueryService/StreamQuery", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &queryServiceStreamQueryClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type QueryService_StreamQueryClient interface {
+	Recv() (*StreamQueryResponse, error)
+	grpc.ClientStream
+}
+
+type queryServiceStreamQueryClient struct {
+	grpc.ClientStream
+}
+
+func (x *queryServiceStreamQueryClient) Recv() (*StreamQueryResponse, error) {
+	m := new(StreamQueryResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// QueryServiceServer is the server API for QueryService service.
+// All implementations must embed UnimplementedQueryServiceServer
+// for forward compatibility
+type QueryServiceServer interface {
+	// SubmitQuery publishes a query to the guardians and returns once a quorum of them have responded.
+	SubmitQuery(context.Context, *SubmitQueryRequest) (*SubmitQueryResponse, error)
+	// StreamQuery publishes a query to the guardians and reports its progress. The stream ends with
+	// the response once a quorum of guardians have responded.
+	StreamQuery(*SubmitQueryRequest, QueryService_StreamQueryServer) error
+	mustEmbedUnimplementedQueryServiceServer()
+}
+
+// UnimplementedQueryServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedQueryServiceServer struct {
+}
+
+func (UnimplementedQueryServiceServer) SubmitQuery(context.Context, *SubmitQueryRequest) (*SubmitQueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitQuery not implemented")
+}
+func (UnimplementedQueryServiceServer) StreamQuery(*SubmitQueryRequest, QueryService_StreamQueryServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamQuery not implemented")
+}
+func (UnimplementedQueryServiceServer) mustEmbedUnimplementedQueryServiceServer() {}
+
+// UnsafeQueryServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to QueryServiceServer will
+// result in compilation errors.
+type UnsafeQueryServiceServer interface {
+	mustEmbedUnimplementedQueryServiceServer()
+}
+
+func RegisterQueryServiceServer(s grpc.ServiceRegistrar, srv QueryServiceServer) {
+	s.RegisterService(&QueryService_ServiceDesc, srv)
+}
+
+func _QueryService_SubmitQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServiceServer).SubmitQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ccq.v1.QueryService/SubmitQuery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServiceServer).SubmitQuery(ctx, req.(*SubmitQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueryService_StreamQuery_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubmitQueryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QueryServiceServer).StreamQuery(m, &queryServiceStreamQueryServer{stream})
+}
+
+type QueryService_StreamQueryServer interface {
+	Send(*StreamQueryResponse) error
+	grpc.ServerStream
+}
+
+type queryServiceStreamQueryServer struct {
+	grpc.ServerStream
+}
+
+func (x *queryServiceStreamQueryServer) Send(m *StreamQueryResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// QueryService_ServiceDesc is the grpc.ServiceDesc for QueryService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var QueryService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ccq.v1.QueryService",
+	HandlerType: (*QueryServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SubmitQuery",
+			Handler:    _QueryService_SubmitQuery_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamQuery",
+			Handler:       _QueryService_StreamQuery_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "ccq/v1/ccq.proto",
+}
@@ -0,0 +1,1056 @@
+// Subset of the Geyser gRPC API of Yellowstone (https://github.com/rpcpool/yellowstone-grpc), used by the Solana watcher to stream
+// the accounts of the core bridge program. The package, service, message and field numbers are those of yellowstone-grpc's
+// geyser.proto so that the messages are wire compatible. Fields the watcher does not use are left out, and skipped as unknown
+// fields when received.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        (unknown)
+// source: geyser/geyser.proto
+
+package geyser
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CommitmentLevel int32
+
+const (
+	CommitmentLevel_PROCESSED CommitmentLevel = 0
+	CommitmentLevel_CONFIRMED CommitmentLevel = 1
+	CommitmentLevel_FINALIZED CommitmentLevel = 2
+)
+
+// Enum value maps for CommitmentLevel.
+var (
+	CommitmentLevel_name = map[int32]string{
+		0: "PROCESSED",
+		1: "CONFIRMED",
+		2: "FINALIZED",
+	}
+	CommitmentLevel_value = map[string]int32{
+		"PROCESSED": 0,
+		"CONFIRMED": 1,
+		"FINALIZED": 2,
+	}
+)
+
+func (x CommitmentLevel) Enum() *CommitmentLevel {
+	p := new(CommitmentLevel)
+	*p = x
+	return p
+}
+
+func (x CommitmentLevel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CommitmentLevel) Descriptor() protoreflect.EnumDescriptor {
+	return file_geyser_geyser_proto_enumTypes[0].Descriptor()
+}
+
+func (CommitmentLevel) Type() protoreflect.EnumType {
+	return &file_geyser_geyser_proto_enumTypes[0]
+}
+
+func (x CommitmentLevel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CommitmentLevel.Descriptor instead.
+func (CommitmentLevel) EnumDescriptor() ([]byte, []int) {
+	return file_geyser_geyser_proto_rawDescGZIP(), []int{0}
+}
+
+type SubscribeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Accounts   map[string]*SubscribeRequestFilterAccounts `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Slots      map[string]*SubscribeRequestFilterSlots    `protobuf:"bytes,2,rep,name=slots,proto3" json:"slots,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Commitment *CommitmentLevel                           `protobuf:"varint,6,opt,name=commitment,proto3,enum=geyser.CommitmentLevel,oneof" json:"commitment,omitempty"`
+	Ping       *SubscribeRequestPing                      `protobuf:"bytes,9,opt,name=ping,proto3,oneof" json:"ping,omitempty"`
+}
+
+func (x *SubscribeRequest) Reset() {
+	*x = SubscribeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_geyser_geyser_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRequest) ProtoMessage() {}
+
+func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_geyser_geyser_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_geyser_geyser_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SubscribeRequest) GetAccounts() map[string]*SubscribeRequestFilterAccounts {
+	if x != nil {
+		return x.Accounts
+	}
+	return nil
+}
+
+func (x *SubscribeRequest) GetSlots() map[string]*SubscribeRequestFilterSlots {
+	if x != nil {
+		return x.Slots
+	}
+	return nil
+}
+
+func (x *SubscribeRequest) GetCommitment() CommitmentLevel {
+	if x != nil && x.Commitment != nil {
+		return *x.Commitment
+	}
+	return CommitmentLevel_PROCESSED
+}
+
+func (x *SubscribeRequest) GetPing() *SubscribeRequestPing {
+	if x != nil {
+		return x.Ping
+	}
+	return nil
+}
+
+type SubscribeRequestFilterAccounts struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Account []string `protobuf:"bytes,2,rep,name=account,proto3" json:"account,omitempty"`
+	Owner   []string `protobuf:"bytes,3,rep,name=owner,proto3" json:"owner,omitempty"`
+}
+
+func (x *SubscribeRequestFilterAccounts) Reset() {
+	*x = SubscribeRequestFilterAccounts{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_geyser_geyser_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeRequestFilterAccounts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRequestFilterAccounts) ProtoMessage() {}
+
+func (x *SubscribeRequestFilterAccounts) ProtoReflect() protoreflect.Message {
+	mi := &file_geyser_geyser_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRequestFilterAccounts.ProtoReflect.Descriptor instead.
+func (*SubscribeRequestFilterAccounts) Descriptor() ([]byte, []int) {
+	return file_geyser_geyser_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SubscribeRequestFilterAccounts) GetAccount() []string {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+func (x *SubscribeRequestFilterAccounts) GetOwner() []string {
+	if x != nil {
+		return x.Owner
+	}
+	return nil
+}
+
+type SubscribeRequestFilterSlots struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FilterByCommitment *bool `protobuf:"varint,1,opt,name=filter_by_commitment,json=filterByCommitment,proto3,oneof" json:"filter_by_commitment,omitempty"`
+}
+
+func (x *SubscribeRequestFilterSlots) Reset() {
+	*x = SubscribeRequestFilterSlots{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_geyser_geyser_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeRequestFilterSlots) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRequestFilterSlots) ProtoMessage() {}
+
+func (x *SubscribeRequestFilterSlots) ProtoReflect() protoreflect.Message {
+	mi := &file_geyser_geyser_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRequestFilterSlots.ProtoReflect.Descriptor instead.
+func (*SubscribeRequestFilterSlots) Descriptor() ([]byte, []int) {
+	return file_geyser_geyser_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SubscribeRequestFilterSlots) GetFilterByCommitment() bool {
+	if x != nil && x.FilterByCommitment != nil {
+		return *x.FilterByCommitment
+	}
+	return false
+}
+
+type SubscribeRequestPing struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *SubscribeRequestPing) Reset() {
+	*x = SubscribeRequestPing{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_geyser_geyser_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeRequestPing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRequestPing) ProtoMessage() {}
+
+func (x *SubscribeRequestPing) ProtoReflect() protoreflect.Message {
+	mi := &file_geyser_geyser_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRequestPing.ProtoReflect.Descriptor instead.
+func (*SubscribeRequestPing) Descriptor() ([]byte, []int) {
+	return file_geyser_geyser_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SubscribeRequestPing) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type SubscribeUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Filters []string `protobuf:"bytes,1,rep,name=filters,proto3" json:"filters,omitempty"`
+	// Types that are assignable to UpdateOneof:
+	//
+	//	*SubscribeUpdate_Account
+	//	*SubscribeUpdate_Slot
+	//	*SubscribeUpdate_Ping
+	//	*SubscribeUpdate_Pong
+	UpdateOneof isSubscribeUpdate_UpdateOneof `protobuf_oneof:"update_oneof"`
+}
+
+func (x *SubscribeUpdate) Reset() {
+	*x = SubscribeUpdate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_geyser_geyser_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeUpdate) ProtoMessage() {}
+
+func (x *SubscribeUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_geyser_geyser_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeUpdate.ProtoReflect.Descriptor instead.
+func (*SubscribeUpdate) Descriptor() ([]byte, []int) {
+	return file_geyser_geyser_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SubscribeUpdate) GetFilters() []string {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+func (m *SubscribeUpdate) GetUpdateOneof() isSubscribeUpdate_UpdateOneof {
+	if m != nil {
+		return m.UpdateOneof
+	}
+	return nil
+}
+
+func (x *SubscribeUpdate) GetAccount() *SubscribeUpdateAccount {
+	if x, ok := x.GetUpdateOneof().(*SubscribeUpdate_Account); ok {
+		return x.Account
+	}
+	return nil
+}
+
+func (x *SubscribeUpdate) GetSlot() *SubscribeUpdateSlot {
+	if x, ok := x.GetUpdateOneof().(*SubscribeUpdate_Slot); ok {
+		return x.Slot
+	}
+	return nil
+}
+
+func (x *SubscribeUpdate) GetPing() *SubscribeUpdatePing {
+	if x, ok := x.GetUpdateOneof().(*SubscribeUpdate_Ping); ok {
+		return x.Ping
+	}
+	return nil
+}
+
+func (x *SubscribeUpdate) GetPong() *SubscribeUpdatePong {
+	if x, ok := x.GetUpdateOneof().(*SubscribeUpdate_Pong); ok {
+		return x.Pong
+	}
+	return nil
+}
+
+type isSubscribeUpdate_UpdateOneof interface {
+	isSubscribeUpdate_UpdateOneof()
+}
+
+type SubscribeUpdate_Account struct {
+	Account *SubscribeUpdateAccount `protobuf:"bytes,2,opt,name=account,proto3,oneof"`
+}
+
+type SubscribeUpdate_Slot struct {
+	Slot *SubscribeUpdateSlot `protobuf:"bytes,3,opt,name=slot,proto3,oneof"`
+}
+
+type SubscribeUpdate_Ping struct {
+	Ping *SubscribeUpdatePing `protobuf:"bytes,6,opt,name=ping,proto3,oneof"`
+}
+
+type SubscribeUpdate_Pong struct {
+	Pong *SubscribeUpdatePong `protobuf:"bytes,9,opt,name=pong,proto3,oneof"`
+}
+
+func (*SubscribeUpdate_Account) isSubscribeUpdate_UpdateOneof() {}
+
+func (*SubscribeUpdate_Slot) isSubscribeUpdate_UpdateOneof() {}
+
+func (*SubscribeUpdate_Ping) isSubscribeUpdate_UpdateOneof() {}
+
+func (*SubscribeUpdate_Pong) isSubscribeUpdate_UpdateOneof() {}
+
+type SubscribeUpdateAccount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Account   *SubscribeUpdateAccountInfo `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Slot      uint64                      `protobuf:"varint,2,opt,name=slot,proto3" json:"slot,omitempty"`
+	IsStartup bool                        `protobuf:"varint,3,opt,name=is_startup,json=isStartup,proto3" json:"is_startup,omitempty"`
+}
+
+func (x *SubscribeUpdateAccount) Reset() {
+	*x = SubscribeUpdateAccount{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_geyser_geyser_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeUpdateAccount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeUpdateAccount) ProtoMessage() {}
+
+func (x *SubscribeUpdateAccount) ProtoReflect() protoreflect.Message {
+	mi := &file_geyser_geyser_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeUpdateAccount.ProtoReflect.Descriptor instead.
+func (*SubscribeUpdateAccount) Descriptor() ([]byte, []int) {
+	return file_geyser_geyser_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SubscribeUpdateAccount) GetAccount() *SubscribeUpdateAccountInfo {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+func (x *SubscribeUpdateAccount) GetSlot() uint64 {
+	if x != nil {
+		return x.Slot
+	}
+	return 0
+}
+
+func (x *SubscribeUpdateAccount) GetIsStartup() bool {
+	if x != nil {
+		return x.IsStartup
+	}
+	return false
+}
+
+type SubscribeUpdateAccountInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pubkey       []byte `protobuf:"bytes,1,opt,name=pubkey,proto3" json:"pubkey,omitempty"`
+	Lamports     uint64 `protobuf:"varint,2,opt,name=lamports,proto3" json:"lamports,omitempty"`
+	Owner        []byte `protobuf:"bytes,3,opt,name=owner,proto3" json:"owner,omitempty"`
+	Executable   bool   `protobuf:"varint,4,opt,name=executable,proto3" json:"executable,omitempty"`
+	RentEpoch    uint64 `protobuf:"varint,5,opt,name=rent_epoch,json=rentEpoch,proto3" json:"rent_epoch,omitempty"`
+	Data         []byte `protobuf:"bytes,6,opt,name=data,proto3" json:"data,omitempty"`
+	WriteVersion uint64 `protobuf:"varint,7,opt,name=write_version,json=writeVersion,proto3" json:"write_version,omitempty"`
+	TxnSignature []byte `protobuf:"bytes,8,opt,name=txn_signature,json=txnSignature,proto3,oneof" json:"txn_signature,omitempty"`
+}
+
+func (x *SubscribeUpdateAccountInfo) Reset() {
+	*x = SubscribeUpdateAccountInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_geyser_geyser_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeUpdateAccountInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeUpdateAccountInfo) ProtoMessage() {}
+
+func (x *SubscribeUpdateAccountInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_geyser_geyser_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeUpdateAccountInfo.ProtoReflect.Descriptor instead.
+func (*SubscribeUpdateAccountInfo) Descriptor() ([]byte, []int) {
+	return file_geyser_geyser_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SubscribeUpdateAccountInfo) GetPubkey() []byte {
+	if x != nil {
+		return x.Pubkey
+	}
+	return nil
+}
+
+func (x *SubscribeUpdateAccountInfo) GetLamports() uint64 {
+	if x != nil {
+		return x.Lamports
+	}
+	return 0
+}
+
+func (x *SubscribeUpdateAccountInfo) GetOwner() []byte {
+	if x != nil {
+		return x.Owner
+	}
+	return nil
+}
+
+func (x *SubscribeUpdateAccountInfo) GetExecutable() bool {
+	if x != nil {
+		return x.Executable
+	}
+	return false
+}
+
+func (x *SubscribeUpdateAccountInfo) GetRentEpoch() uint64 {
+	if x != nil {
+		return x.RentEpoch
+	}
+	return 0
+}
+
+func (x *SubscribeUpdateAccountInfo) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *SubscribeUpdateAccountInfo) GetWriteVersion() uint64 {
+	if x != nil {
+		return x.WriteVersion
+	}
+	return 0
+}
+
+func (x *SubscribeUpdateAccountInfo) GetTxnSignature() []byte {
+	if x != nil {
+		return x.TxnSignature
+	}
+	return nil
+}
+
+type SubscribeUpdateSlot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Slot   uint64          `protobuf:"varint,1,opt,name=slot,proto3" json:"slot,omitempty"`
+	Parent *uint64         `protobuf:"varint,2,opt,name=parent,proto3,oneof" json:"parent,omitempty"`
+	Status CommitmentLevel `protobuf:"varint,3,opt,name=status,proto3,enum=geyser.CommitmentLevel" json:"status,omitempty"`
+}
+
+func (x *SubscribeUpdateSlot) Reset() {
+	*x = SubscribeUpdateSlot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_geyser_geyser_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeUpdateSlot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeUpdateSlot) ProtoMessage() {}
+
+func (x *SubscribeUpdateSlot) ProtoReflect() protoreflect.Message {
+	mi := &file_geyser_geyser_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeUpdateSlot.ProtoReflect.Descriptor instead.
+func (*SubscribeUpdateSlot) Descriptor() ([]byte, []int) {
+	return file_geyser_geyser_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SubscribeUpdateSlot) GetSlot() uint64 {
+	if x != nil {
+		return x.Slot
+	}
+	return 0
+}
+
+func (x *SubscribeUpdateSlot) GetParent() uint64 {
+	if x != nil && x.Parent != nil {
+		return *x.Parent
+	}
+	return 0
+}
+
+func (x *SubscribeUpdateSlot) GetStatus() CommitmentLevel {
+	if x != nil {
+		return x.Status
+	}
+	return CommitmentLevel_PROCESSED
+}
+
+type SubscribeUpdatePing struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SubscribeUpdatePing) Reset() {
+	*x = SubscribeUpdatePing{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_geyser_geyser_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeUpdatePing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeUpdatePing) ProtoMessage() {}
+
+func (x *SubscribeUpdatePing) ProtoReflect() protoreflect.Message {
+	mi := &file_geyser_geyser_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeUpdatePing.ProtoReflect.Descriptor instead.
+func (*SubscribeUpdatePing) Descriptor() ([]byte, []int) {
+	return file_geyser_geyser_proto_rawDescGZIP(), []int{8}
+}
+
+type SubscribeUpdatePong struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *SubscribeUpdatePong) Reset() {
+	*x = SubscribeUpdatePong{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_geyser_geyser_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeUpdatePong) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeUpdatePong) ProtoMessage() {}
+
+func (x *SubscribeUpdatePong) ProtoReflect() protoreflect.Message {
+	mi := &file_geyser_geyser_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeUpdatePong.ProtoReflect.Descriptor instead.
+func (*SubscribeUpdatePong) Descriptor() ([]byte, []int) {
+	return file_geyser_geyser_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SubscribeUpdatePong) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+var File_geyser_geyser_proto protoreflect.FileDescriptor
+
+var file_geyser_geyser_proto_rawDesc = []byte{
+	0x0a, 0x13, 0x67, 0x65, 0x79, 0x73, 0x65, 0x72, 0x2f, 0x67, 0x65, 0x79, 0x73, 0x65, 0x72, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x67, 0x65, 0x79, 0x73, 0x65, 0x72, 0x22, 0xe2, 0x03,
+	0x0a, 0x10, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x42, 0x0a, 0x08, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x67, 0x65, 0x79, 0x73, 0x65, 0x72, 0x2e, 0x53, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x41,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x61, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x39, 0x0a, 0x05, 0x73, 0x6c, 0x6f, 0x74, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x67, 0x65, 0x79, 0x73, 0x65, 0x72, 0x2e, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
+	0x53, 0x6c, 0x6f, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x73, 0x6c, 0x6f, 0x74,
+	0x73, 0x12, 0x3c, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x67, 0x65, 0x79, 0x73, 0x65, 0x72, 0x2e, 0x43,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x48, 0x00,
+	0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x12,
+	0x35, 0x0a, 0x04, 0x70, 0x69, 0x6e, 0x67, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
+	0x67, 0x65, 0x79, 0x73, 0x65, 0x72, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x50, 0x69, 0x6e, 0x67, 0x48, 0x01, 0x52, 0x04, 0x70,
+	0x69, 0x6e, 0x67, 0x88, 0x01, 0x01, 0x1a, 0x63, 0x0a, 0x0d, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x3c, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x67, 0x65, 0x79, 0x73, 0x65,
+	0x72, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x5d, 0x0a, 0x0a, 0x53,
+	0x6c, 0x6f, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x39, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x67, 0x65, 0x79,
+	0x73, 0x65, 0x72, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x53, 0x6c, 0x6f, 0x74, 0x73, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x63,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x70, 0x69,
+	0x6e, 0x67, 0x22, 0x50, 0x0a, 0x1e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x41, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6f,
+	0x77, 0x6e, 0x65, 0x72, 0x22, 0x6d, 0x0a, 0x1b, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x53, 0x6c,
+	0x6f, 0x74, 0x73, 0x12, 0x35, 0x0a, 0x14, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x5f, 0x62, 0x79,
+	0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x08, 0x48, 0x00, 0x52, 0x12, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x42, 0x79, 0x43, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x42, 0x17, 0x0a, 0x15, 0x5f, 0x66,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x5f, 0x62, 0x79, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d,
+	0x65, 0x6e, 0x74, 0x22, 0x26, 0x0a, 0x14, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x50, 0x69, 0x6e, 0x67, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x22, 0x90, 0x02, 0x0a, 0x0f,
+	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x3a, 0x0a, 0x07, 0x61, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x67, 0x65, 0x79,
+	0x73, 0x65, 0x72, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x07, 0x61, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x31, 0x0a, 0x04, 0x73, 0x6c, 0x6f, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x65, 0x79, 0x73, 0x65, 0x72, 0x2e, 0x53, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x6c, 0x6f, 0x74,
+	0x48, 0x00, 0x52, 0x04, 0x73, 0x6c, 0x6f, 0x74, 0x12, 0x31, 0x0a, 0x04, 0x70, 0x69, 0x6e, 0x67,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x65, 0x79, 0x73, 0x65, 0x72, 0x2e,
+	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50,
+	0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x04, 0x70, 0x69, 0x6e, 0x67, 0x12, 0x31, 0x0a, 0x04, 0x70,
+	0x6f, 0x6e, 0x67, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x65, 0x79, 0x73,
+	0x65, 0x72, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x50, 0x6f, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x04, 0x70, 0x6f, 0x6e, 0x67, 0x42, 0x0e,
+	0x0a, 0x0c, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x6f, 0x6e, 0x65, 0x6f, 0x66, 0x22, 0x89,
+	0x01, 0x0a, 0x16, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x3c, 0x0a, 0x07, 0x61, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x67, 0x65, 0x79,
+	0x73, 0x65, 0x72, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07,
+	0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6c, 0x6f, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x6c, 0x6f, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x69,
+	0x73, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x75, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x09, 0x69, 0x73, 0x53, 0x74, 0x61, 0x72, 0x74, 0x75, 0x70, 0x22, 0x9a, 0x02, 0x0a, 0x1a, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x75, 0x62,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x75, 0x62, 0x6b, 0x65,
+	0x79, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x08, 0x6c, 0x61, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x6f, 0x77,
+	0x6e, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x61, 0x62, 0x6c,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x61,
+	0x62, 0x6c, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x65, 0x70, 0x6f, 0x63,
+	0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x72, 0x65, 0x6e, 0x74, 0x45, 0x70, 0x6f,
+	0x63, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x23, 0x0a, 0x0d, 0x77, 0x72, 0x69, 0x74, 0x65, 0x5f,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x77,
+	0x72, 0x69, 0x74, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x0d, 0x74,
+	0x78, 0x6e, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0c, 0x48, 0x00, 0x52, 0x0c, 0x74, 0x78, 0x6e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x88, 0x01, 0x01, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x74, 0x78, 0x6e, 0x5f, 0x73, 0x69,
+	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x82, 0x01, 0x0a, 0x13, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x62, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x6c, 0x6f, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x73, 0x6c, 0x6f, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73,
+	0x6c, 0x6f, 0x74, 0x12, 0x1b, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x88, 0x01, 0x01,
+	0x12, 0x2f, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x17, 0x2e, 0x67, 0x65, 0x79, 0x73, 0x65, 0x72, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x22, 0x15, 0x0a, 0x13,
+	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50,
+	0x69, 0x6e, 0x67, 0x22, 0x25, 0x0a, 0x13, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x6e, 0x67, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x2a, 0x3e, 0x0a, 0x0f, 0x43, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x0d, 0x0a,
+	0x09, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09,
+	0x43, 0x4f, 0x4e, 0x46, 0x49, 0x52, 0x4d, 0x45, 0x44, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x46,
+	0x49, 0x4e, 0x41, 0x4c, 0x49, 0x5a, 0x45, 0x44, 0x10, 0x02, 0x32, 0x4e, 0x0a, 0x06, 0x47, 0x65,
+	0x79, 0x73, 0x65, 0x72, 0x12, 0x44, 0x0a, 0x09, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62,
+	0x65, 0x12, 0x18, 0x2e, 0x67, 0x65, 0x79, 0x73, 0x65, 0x72, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67, 0x65,
+	0x79, 0x73, 0x65, 0x72, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x42, 0x3c, 0x5a, 0x3a, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x65, 0x72, 0x74, 0x75, 0x73, 0x6f,
+	0x6e, 0x65, 0x2f, 0x77, 0x6f, 0x72, 0x6d, 0x68, 0x6f, 0x6c, 0x65, 0x2f, 0x6e, 0x6f, 0x64, 0x65,
+	0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x79, 0x73, 0x65,
+	0x72, 0x3b, 0x67, 0x65, 0x79, 0x73, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_geyser_geyser_proto_rawDescOnce sync.Once
+	file_geyser_geyser_proto_rawDescData = file_geyser_geyser_proto_rawDesc
+)
+
+func file_geyser_geyser_proto_rawDescGZIP() []byte {
+	file_geyser_geyser_proto_rawDescOnce.Do(func() {
+		file_geyser_geyser_proto_rawDescData = protoimpl.X.CompressGZIP(file_geyser_geyser_proto_rawDescData)
+	})
+	return file_geyser_geyser_proto_rawDescData
+}
+
+var file_geyser_geyser_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_geyser_geyser_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_geyser_geyser_proto_goTypes = []interface{}{
+	(CommitmentLevel)(0),                   // 0: geyser.CommitmentLevel
+	(*SubscribeRequest)(nil),               // 1: geyser.SubscribeRequest
+	(*SubscribeRequestFilterAccounts)(nil), // 2: geyser.SubscribeRequestFilterAccounts
+	(*SubscribeRequestFilterSlots)(nil),    // 3: geyser.SubscribeRequestFilterSlots
+	(*SubscribeRequestPing)(nil),           // 4: geyser.SubscribeRequestPing
+	(*SubscribeUpdate)(nil),                // 5: geyser.SubscribeUpdate
+	(*SubscribeUpdateAccount)(nil),         // 6: geyser.SubscribeUpdateAccount
+	(*SubscribeUpdateAccountInfo)(nil),     // 7: geyser.SubscribeUpdateAccountInfo
+	(*SubscribeUpdateSlot)(nil),            // 8: geyser.SubscribeUpdateSlot
+	(*SubscribeUpdatePing)(nil),            // 9: geyser.SubscribeUpdatePing
+	(*SubscribeUpdatePong)(nil),            // 10: geyser.SubscribeUpdatePong
+	nil,                                    // 11: geyser.SubscribeRequest.AccountsEntry
+	nil,                                    // 12: geyser.SubscribeRequest.SlotsEntry
+}
+var file_geyser_geyser_proto_depIdxs = []int32{
+	11, // 0: geyser.SubscribeRequest.accounts:type_name -> geyser.SubscribeRequest.AccountsEntry
+	12, // 1: geyser.SubscribeRequest.slots:type_name -> geyser.SubscribeRequest.SlotsEntry
+	0,  // 2: geyser.SubscribeRequest.commitment:type_name -> geyser.CommitmentLevel
+	4,  // 3: geyser.SubscribeRequest.ping:type_name -> geyser.SubscribeRequestPing
+	6,  // 4: geyser.SubscribeUpdate.account:type_name -> geyser.SubscribeUpdateAccount
+	8,  // 5: geyser.SubscribeUpdate.slot:type_name -> geyser.SubscribeUpdateSlot
+	9,  // 6: geyser.SubscribeUpdate.ping:type_name -> geyser.SubscribeUpdatePing
+	10, // 7: geyser.SubscribeUpdate.pong:type_name -> geyser.SubscribeUpdatePong
+	7,  // 8: geyser.SubscribeUpdateAccount.account:type_name -> geyser.SubscribeUpdateAccountInfo
+	0,  // 9: geyser.SubscribeUpdateSlot.status:type_name -> geyser.CommitmentLevel
+	2,  // 10: geyser.SubscribeRequest.AccountsEntry.value:type_name -> geyser.SubscribeRequestFilterAccounts
+	3,  // 11: geyser.SubscribeRequest.SlotsEntry.value:type_name -> geyser.SubscribeRequestFilterSlots
+	1,  // 12: geyser.Geyser.Subscribe:input_type -> geyser.SubscribeRequest
+	5,  // 13: geyser.Geyser.Subscribe:output_type -> geyser.SubscribeUpdate
+	13, // [13:14] is the sub-list for method output_type
+	12, // [12:13] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_geyser_geyser_proto_init() }
+func file_geyser_geyser_proto_init() {
+	if File_geyser_geyser_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_geyser_geyser_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_geyser_geyser_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeRequestFilterAccounts); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_geyser_geyser_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeRequestFilterSlots); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_geyser_geyser_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeRequestPing); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_geyser_geyser_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeUpdate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_geyser_geyser_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeUpdateAccount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_geyser_geyser_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeUpdateAccountInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_geyser_geyser_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeUpdateSlot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_geyser_geyser_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeUpdatePing); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_geyser_geyser_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeUpdatePong); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_geyser_geyser_proto_msgTypes[0].OneofWrappers = []interface{}{}
+	file_geyser_geyser_proto_msgTypes[2].OneofWrappers = []interface{}{}
+	file_geyser_geyser_proto_msgTypes[4].OneofWrappers = []interface{}{
+		(*SubscribeUpdate_Account)(nil),
+		(*SubscribeUpdate_Slot)(nil),
+		(*SubscribeUpdate_Ping)(nil),
+		(*SubscribeUpdate_Pong)(nil),
+	}
+	file_geyser_geyser_proto_msgTypes[6].OneofWrappers = []interface{}{}
+	file_geyser_geyser_proto_msgTypes[7].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_geyser_geyser_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_geyser_geyser_proto_goTypes,
+		DependencyIndexes: file_geyser_geyser_proto_depIdxs,
+		EnumInfos:         file_geyser_geyser_proto_enumTypes,
+		MessageInfos:      file_geyser_geyser_proto_msgTypes,
+	}.Build()
+	File_geyser_geyser_proto = out.File
+	file_geyser_geyser_proto_rawDesc = nil
+	file_geyser_geyser_proto_goTypes = nil
+	file_geyser_geyser_proto_depIdxs = nil
+}
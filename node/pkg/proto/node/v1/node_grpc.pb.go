@@ -23,6 +23,9 @@ type NodePrivilegedServiceClient interface {
 	//
 	// A consensus majority of nodes on the network will have to inject the VAA within the
 	// VAA timeout window for it to reach consensus.
+	//
+	// With validate_only, the node only builds the VAAs and returns them, so that the guardians of a signing ceremony
+	// can check they agree on the digests before injecting.
 	InjectGovernanceVAA(ctx context.Context, in *InjectGovernanceVAARequest, opts ...grpc.CallOption) (*InjectGovernanceVAAResponse, error)
 	// FindMissingMessages will detect message sequence gaps in the local VAA store for a
 	// specific emitter chain and address. Start and end slots are the lowest and highest
@@ -32,24 +35,120 @@ type NodePrivilegedServiceClient interface {
 	FindMissingMessages(ctx context.Context, in *FindMissingMessagesRequest, opts ...grpc.CallOption) (*FindMissingMessagesResponse, error)
 	// SendObservationRequest broadcasts a signed observation request to the gossip network
 	// using the node's guardian key. The network rate limits these requests to one per second.
-	// Requests at higher rates will fail silently.
+	// Requests at higher rates will fail silently. A batch of requests is sent paced at the
+	// requested rate, which defaults to that limit.
 	SendObservationRequest(ctx context.Context, in *SendObservationRequestRequest, opts ...grpc.CallOption) (*SendObservationRequestResponse, error)
 	// ChainGovernorStatus displays the status of the chain governor.
 	ChainGovernorStatus(ctx context.Context, in *ChainGovernorStatusRequest, opts ...grpc.CallOption) (*ChainGovernorStatusResponse, error)
+	// ChainGovernorDumpState returns the complete state of the chain governor as JSON: its config version, the limits and usage
+	// of each governed chain, and the enqueued VAAs with their ages and the reasons they were enqueued.
+	ChainGovernorDumpState(ctx context.Context, in *ChainGovernorDumpStateRequest, opts ...grpc.CallOption) (*ChainGovernorDumpStateResponse, error)
 	// ChainGovernorReload clears the chain governor history and reloads it from the database.
 	ChainGovernorReload(ctx context.Context, in *ChainGovernorReloadRequest, opts ...grpc.CallOption) (*ChainGovernorReloadResponse, error)
 	// ChainGovernorDropPendingVAA drops a VAA from the chain governor pending list.
 	ChainGovernorDropPendingVAA(ctx context.Context, in *ChainGovernorDropPendingVAARequest, opts ...grpc.CallOption) (*ChainGovernorDropPendingVAAResponse, error)
 	// ChainGovernorReleasePendingVAA release a VAA from the chain governor pending list, publishing it immediately.
+	// If releasing the VAA requires approvals, the approval of the caller is recorded instead, and the VAA is
+	// released once it has been approved by enough distinct callers. Only authenticated callers can approve: the
+	// local user of the admin socket, or the client of the admin service over TCP.
 	ChainGovernorReleasePendingVAA(ctx context.Context, in *ChainGovernorReleasePendingVAARequest, opts ...grpc.CallOption) (*ChainGovernorReleasePendingVAAResponse, error)
-	// ChainGovernorResetReleaseTimer resets the release timer for a chain governor pending VAA to the configured maximum.
+	// ChainGovernorAuditLog returns the approvals and releases of chain governor pending VAAs.
+	ChainGovernorAuditLog(ctx context.Context, in *ChainGovernorAuditLogRequest, opts ...grpc.CallOption) (*ChainGovernorAuditLogResponse, error)
+	// ChainGovernorResetReleaseTimer resets the release timer of chain governor pending VAAs, either to the configured maximum or
+	// to an explicit delay. The VAAs are given by id, or as all those pending for a chain.
 	ChainGovernorResetReleaseTimer(ctx context.Context, in *ChainGovernorResetReleaseTimerRequest, opts ...grpc.CallOption) (*ChainGovernorResetReleaseTimerResponse, error)
+	// ChainGovernorGetLimits returns the enforced and configured limits of each chain governed by the chain governor.
+	ChainGovernorGetLimits(ctx context.Context, in *ChainGovernorGetLimitsRequest, opts ...grpc.CallOption) (*ChainGovernorGetLimitsResponse, error)
+	// ChainGovernorSetLimit changes the limits of a governed chain. The change is persisted and takes precedence over the configured limits.
+	ChainGovernorSetLimit(ctx context.Context, in *ChainGovernorSetLimitRequest, opts ...grpc.CallOption) (*ChainGovernorSetLimitResponse, error)
+	// ChainGovernorResetLimit goes back to enforcing the configured limits of a governed chain.
+	ChainGovernorResetLimit(ctx context.Context, in *ChainGovernorResetLimitRequest, opts ...grpc.CallOption) (*ChainGovernorResetLimitResponse, error)
+	// ChainGovernorExportSnapshot returns the transfers, pending VAAs and limits of the chain governor as JSON.
+	ChainGovernorExportSnapshot(ctx context.Context, in *ChainGovernorExportSnapshotRequest, opts ...grpc.CallOption) (*ChainGovernorExportSnapshotResponse, error)
+	// ChainGovernorImportSnapshot adds the transfers and limits exported by another node with ChainGovernorExportSnapshot to the
+	// chain governor. Pending VAAs are not imported.
+	ChainGovernorImportSnapshot(ctx context.Context, in *ChainGovernorImportSnapshotRequest, opts ...grpc.CallOption) (*ChainGovernorImportSnapshotResponse, error)
 	// SignExistingVAA signs an existing VAA for a new guardian set using the local guardian key.
 	SignExistingVAA(ctx context.Context, in *SignExistingVAARequest, opts ...grpc.CallOption) (*SignExistingVAAResponse, error)
 	// DumpRPCs returns the RPCs being used by the guardian
 	DumpRPCs(ctx context.Context, in *DumpRPCsRequest, opts ...grpc.CallOption) (*DumpRPCsResponse, error)
 	// GetMissingVAAs returns the VAAs from a cloud function that need to be reobserved.
 	GetAndObserveMissingVAAs(ctx context.Context, in *GetAndObserveMissingVAAsRequest, opts ...grpc.CallOption) (*GetAndObserveMissingVAAsResponse, error)
+	// GovernanceVAAStatus streams the signing progress of a governance VAA previously injected on this node.
+	// A new status is sent whenever another guardian's signature is observed. The stream ends once quorum is reached.
+	GovernanceVAAStatus(ctx context.Context, in *GovernanceVAAStatusRequest, opts ...grpc.CallOption) (NodePrivilegedService_GovernanceVAAStatusClient, error)
+	// GetRuntimeConfig returns the running value of a piece of runtime configuration, in the format accepted by ProposeConfigChange.
+	GetRuntimeConfig(ctx context.Context, in *GetRuntimeConfigRequest, opts ...grpc.CallOption) (*GetRuntimeConfigResponse, error)
+	// ProposeConfigChange stages a change to a piece of runtime configuration. The change does not take effect until
+	// it is applied using ApplyStagedConfig. The proposer is the authenticated caller. Staged changes are only kept in
+	// memory, they are discarded after 24 hours or when the node restarts.
+	ProposeConfigChange(ctx context.Context, in *ProposeConfigChangeRequest, opts ...grpc.CallOption) (*ProposeConfigChangeResponse, error)
+	// ListStagedConfigs lists the staged config changes, with a diff against the running config.
+	ListStagedConfigs(ctx context.Context, in *ListStagedConfigsRequest, opts ...grpc.CallOption) (*ListStagedConfigsResponse, error)
+	// ApplyStagedConfig applies a staged config change. The approver is the authenticated caller. If the node requires
+	// a second approver, the change must be applied by someone other than its proposer.
+	ApplyStagedConfig(ctx context.Context, in *ApplyStagedConfigRequest, opts ...grpc.CallOption) (*ApplyStagedConfigResponse, error)
+	// DiscardStagedConfig discards a staged config change without applying it.
+	DiscardStagedConfig(ctx context.Context, in *DiscardStagedConfigRequest, opts ...grpc.CallOption) (*DiscardStagedConfigResponse, error)
+	// DumpPendingObservations lists the observations that have not reached quorum yet, as last persisted by the processor.
+	DumpPendingObservations(ctx context.Context, in *DumpPendingObservationsRequest, opts ...grpc.CallOption) (*DumpPendingObservationsResponse, error)
+	// GetQueryBudgetUsage returns how much of their daily cross chain query budget each requester has used today.
+	GetQueryBudgetUsage(ctx context.Context, in *GetQueryBudgetUsageRequest, opts ...grpc.CallOption) (*GetQueryBudgetUsageResponse, error)
+	// RestoreArchivedVAAs stores archived VAAs back into the database, where they are kept for the restore hold before
+	// being pruned again.
+	RestoreArchivedVAAs(ctx context.Context, in *RestoreArchivedVAAsRequest, opts ...grpc.CallOption) (*RestoreArchivedVAAsResponse, error)
+	// GetAggregatedAttestation returns the experimental aggregated signature produced for a VAA, if any.
+	GetAggregatedAttestation(ctx context.Context, in *GetAggregatedAttestationRequest, opts ...grpc.CallOption) (*GetAggregatedAttestationResponse, error)
+	// GetSupportSnapshot returns the node's diagnostics for `guardiand support-bundle`, with secrets redacted.
+	GetSupportSnapshot(ctx context.Context, in *GetSupportSnapshotRequest, opts ...grpc.CallOption) (*GetSupportSnapshotResponse, error)
+	// DumpConfig returns the node's fully resolved configuration as JSON, with secrets redacted, so it can be diffed across
+	// guardians.
+	DumpConfig(ctx context.Context, in *DumpConfigRequest, opts ...grpc.CallOption) (*DumpConfigResponse, error)
+	// GetNodeHealth returns the readiness of the node's components, the height and lag of each watcher, the depth of the node's
+	// internal queues, the number of p2p peers and the last error of each runnable, so tooling can poll a single endpoint.
+	GetNodeHealth(ctx context.Context, in *GetNodeHealthRequest, opts ...grpc.CallOption) (*GetNodeHealthResponse, error)
+	// PurgeVAAs deletes the signed VAAs of an emitter in a range of sequences from the database, e.g. to reset a test network. It
+	// is refused unless the node was started with --adminAllowVAAPurge, and every call is recorded in the log with the
+	// authenticated caller.
+	PurgeVAAs(ctx context.Context, in *PurgeVAAsRequest, opts ...grpc.CallOption) (*PurgeVAAsResponse, error)
+	// SetLogLevel changes the log level of the node, or of one of its components, without restarting it. The change can be
+	// limited in time, e.g. to enable debug logging for a few minutes. It returns the resulting levels.
+	SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error)
+	// TailLogs streams the most recent log entries of the node, then every entry logged afterwards until the stream is
+	// cancelled, optionally filtered by level and component. Entries are dropped if the client doesn't keep up.
+	TailLogs(ctx context.Context, in *TailLogsRequest, opts ...grpc.CallOption) (NodePrivilegedService_TailLogsClient, error)
+	// RestartComponent tears down a runnable of the supervision tree, such as a chain watcher, along with its children and
+	// lets the supervisor start it again, without restarting the node. A restarted watcher is built again from its current
+	// config. Every restart is recorded in the log.
+	RestartComponent(ctx context.Context, in *RestartComponentRequest, opts ...grpc.CallOption) (*RestartComponentResponse, error)
+	// AdminAuditLog returns the most recent calls to the admin service, with who made them, what they asked for and the
+	// result. Every call is recorded, including those that were rejected.
+	AdminAuditLog(ctx context.Context, in *AdminAuditLogRequest, opts ...grpc.CallOption) (*AdminAuditLogResponse, error)
+	// DumpConflictingObservations lists the messages for which guardians signed more than one digest, with the guardians
+	// that signed each of them.
+	DumpConflictingObservations(ctx context.Context, in *DumpConflictingObservationsRequest, opts ...grpc.CallOption) (*DumpConflictingObservationsResponse, error)
+	// AccountantPendingTransfers lists the transfers the token bridge and NTT accountants hold until their accounting contract
+	// commits them.
+	AccountantPendingTransfers(ctx context.Context, in *AccountantPendingTransfersRequest, opts ...grpc.CallOption) (*AccountantPendingTransfersResponse, error)
+	// AccountantResubmitTransfer submits a pending transfer to the accounting contract again right away.
+	AccountantResubmitTransfer(ctx context.Context, in *AccountantResubmitTransferRequest, opts ...grpc.CallOption) (*AccountantResubmitTransferResponse, error)
+	// AccountantForceReleaseTransfer approves publishing a pending transfer without waiting for the accounting contract. The
+	// approver is the authenticated caller, and the transfer is published once enough distinct admins approved it. Only meant
+	// for emergencies, such as the contract being unavailable.
+	AccountantForceReleaseTransfer(ctx context.Context, in *AccountantForceReleaseTransferRequest, opts ...grpc.CallOption) (*AccountantForceReleaseTransferResponse, error)
+	// AccountantAuditLog returns the approvals and forced releases of accountant pending transfers.
+	AccountantAuditLog(ctx context.Context, in *AccountantAuditLogRequest, opts ...grpc.CallOption) (*AccountantAuditLogResponse, error)
+	// GatewayRelayerPause stops submitting VAAs to the gateway until GatewayRelayerResume is called. VAAs which reach quorum
+	// in the meantime are still queued.
+	GatewayRelayerPause(ctx context.Context, in *GatewayRelayerPauseRequest, opts ...grpc.CallOption) (*GatewayRelayerPauseResponse, error)
+	// GatewayRelayerResume resumes submitting VAAs to the gateway.
+	GatewayRelayerResume(ctx context.Context, in *GatewayRelayerResumeRequest, opts ...grpc.CallOption) (*GatewayRelayerResumeResponse, error)
+	// GatewayRelayerPendingRelays lists the VAAs waiting to be relayed to the gateway, including the ones it gave up on.
+	GatewayRelayerPendingRelays(ctx context.Context, in *GatewayRelayerPendingRelaysRequest, opts ...grpc.CallOption) (*GatewayRelayerPendingRelaysResponse, error)
+	// GatewayRelayerRetryRelay submits a pending VAA to the gateway again, restarting its retries.
+	GatewayRelayerRetryRelay(ctx context.Context, in *GatewayRelayerRetryRelayRequest, opts ...grpc.CallOption) (*GatewayRelayerRetryRelayResponse, error)
+	// GatewayRelayerDropRelay removes a VAA from the gateway relayer, so it is no longer submitted.
+	GatewayRelayerDropRelay(ctx context.Context, in *GatewayRelayerDropRelayRequest, opts ...grpc.CallOption) (*GatewayRelayerDropRelayResponse, error)
 }
 
 type nodePrivilegedServiceClient struct {
@@ -96,6 +195,15 @@ func (c *nodePrivilegedServiceClient) ChainGovernorStatus(ctx context.Context, i
 	return out, nil
 }
 
+func (c *nodePrivilegedServiceClient) ChainGovernorDumpState(ctx context.Context, in *ChainGovernorDumpStateRequest, opts ...grpc.CallOption) (*ChainGovernorDumpStateResponse, error) {
+	out := new(ChainGovernorDumpStateResponse)
+	err := c.cc.Invoke(ctx, "/node.v1.NodePrivilegedService/ChainGovernorDumpState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *nodePrivilegedServiceClient) ChainGovernorReload(ctx context.Context, in *ChainGovernorReloadRequest, opts ...grpc.CallOption) (*ChainGovernorReloadResponse, error) {
 	out := new(ChainGovernorReloadResponse)
 	err := c.cc.Invoke(ctx, "/node.v1.NodePrivilegedService/ChainGovernorReload", in, out, opts...)
@@ -123,6 +231,15 @@ func (c *nodePrivilegedServiceClient) ChainGovernorReleasePendingVAA(ctx context
 	return out, nil
 }
 
+func (c *nodePrivilegedServiceClient) ChainGovernorAuditLog(ctx context.Context, in *ChainGovernorAuditLogRequest, opts ...grpc.CallOption) (*ChainGovernorAuditLogResponse, error) {
+	out := new(ChainGovernorAuditLogResponse)
+	err := c.cc.Invoke(ctx, "/node.v1.NodePrivilegedService/ChainGovernorAuditLog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *nodePrivilegedServiceClient) ChainGovernorResetReleaseTimer(ctx context.Context, in *ChainGovernorResetReleaseTimerRequest, opts ...grpc.CallOption) (*ChainGovernorResetReleaseTimerResponse, error) {
 	out := new(ChainGovernorResetReleaseTimerResponse)
 	err := c.cc.Invoke(ctx, "/node.v1.NodePrivilegedService/ChainGovernorResetReleaseTimer", in, out, opts...)
@@ -132,6 +249,51 @@ func (c *nodePrivilegedServiceClient) ChainGovernorResetReleaseTimer(ctx context
 	return out, nil
 }
 
+func (c *nodePrivilegedServiceClient) ChainGovernorGetLimits(ctx context.Context, in *ChainGovernorGetLimitsRequest, opts ...grpc.CallOption) (*ChainGovernorGetLimitsResponse, error) {
+	out := new(ChainGovernorGetLimitsResponse)
+	err := c.cc.Invoke(ctx, "/node.v1.NodePrivilegedService/ChainGovernorGetLimits", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePrivilegedServiceClient) ChainGovernorSetLimit(ctx context.Context, in *ChainGovernorSetLimitRequest, opts ...grpc.CallOption) (*ChainGovernorSetLimitResponse, error) {
+	out := new(ChainGovernorSetLimitResponse)
+	err := c.cc.Invoke(ctx, "/node.v1.NodePrivilegedService/ChainGovernorSetLimit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePrivilegedServiceClient) ChainGovernorResetLimit(ctx context.Context, in *ChainGovernorResetLimitRequest, opts ...grpc.CallOption) (*ChainGovernorResetLimitResponse, error) {
+	out := new(ChainGovernorResetLimitResponse)
+	err := c.cc.Invoke(ctx, "/node.v1.NodePrivilegedService/ChainGovernorResetLimit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePrivilegedServiceClient) ChainGovernorExportSnapshot(ctx context.Context, in *ChainGovernorExportSnapshotRequest, opts ...grpc.CallOption) (*ChainGovernorExportSnapshotResponse, error) {
+	out := new(ChainGovernorExportSnapshotResponse)
+	err := c.cc.Invoke(ctx, "/node.v1.NodePrivilegedService/ChainGovernorExportSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePrivilegedServiceClient) ChainGovernorImportSnapshot(ctx context.Context, in *ChainGovernorImportSnapshotRequest, opts ...grpc.CallOption) (*ChainGovernorImportSnapshotResponse, error) {
+	out := new(ChainGovernorImportSnapshotResponse)
+	err := c.cc.Invoke(ctx, "/node.v1.NodePrivilegedService/ChainGovernorImportSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *nodePrivilegedServiceClient) SignExistingVAA(ctx context.Context, in *SignExistingVAARequest, opts ...grpc.CallOption) (*SignExistingVAAResponse, error) {
 	out := new(SignExistingVAAResponse)
 	err := c.cc.Invoke(ctx, "/node.v1.NodePrivilegedService/SignExistingVAA", in, out, opts...)
@@ -159,6 +321,304 @@ func (c *nodePrivilegedServiceClient) GetAndObserveMissingVAAs(ctx context.Conte
 	return out, nil
 }
 
+func (c *nodePrivilegedServiceClient) GovernanceVAAStatus(ctx context.Context, in *GovernanceVAAStatusRequest, opts ...grpc.CallOption) (NodePrivilegedService_GovernanceVAAStatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &NodePrivilegedService_ServiceDesc.Streams[0], "/node.v1.NodePrivilegedService/GovernanceVAAStatus", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &nodePrivilegedServiceGovernanceVAAStatusClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type NodePrivilegedService_GovernanceVAAStatusClient interface {
+	Recv() (*GovernanceVAAStatusResponse, error)
+	grpc.ClientStream
+}
+
+type nodePrivilegedServiceGovernanceVAAStatusClient struct {
+	grpc.ClientStream
+}
+
+func (x *nodePrivilegedServiceGovernanceVAAStatusClient) Recv() (*GovernanceVAAStatusResponse, error) {
+	m := new(GovernanceVAAStatusResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *nodePrivilegedServiceClient) GetRuntimeConfig(ctx context.Context, in *GetRuntimeConfigRequest, opts ...grpc.CallOption) (*GetRuntimeConfigResponse, error) {
+	out := new(GetRuntimeConfigResponse)
+	err := c.cc.Invoke(ctx, "/node.v1.NodePrivilegedService/GetRuntimeConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePrivilegedServiceClient) ProposeConfigChange(ctx context.Context, in *ProposeConfigChangeRequest, opts ...grpc.CallOption) (*ProposeConfigChangeResponse, error) {
+	out := new(ProposeConfigChangeResponse)
+	err := c.cc.Invoke(ctx, "/node.v1.NodePrivilegedService/ProposeConfigChange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePrivilegedServiceClient) ListStagedConfigs(ctx context.Context, in *ListStagedConfigsRequest, opts ...grpc.CallOption) (*ListStagedConfigsResponse, error) {
+	out := new(ListStagedConfigsResponse)
+	err := c.cc.Invoke(ctx, "/node.v1.NodePrivilegedService/ListStagedConfigs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePrivilegedServiceClient) ApplyStagedConfig(ctx context.Context, in *ApplyStagedConfigRequest, opts ...grpc.CallOption) (*ApplyStagedConfigResponse, error) {
+	out := new(ApplyStagedConfigResponse)
+	err := c.cc.Invoke(ctx, "/node.v1.NodePrivilegedService/ApplyStagedConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePrivilegedServiceClient) DiscardStagedConfig(ctx context.Context, in *DiscardStagedConfigRequest, opts ...grpc.CallOption) (*DiscardStagedConfigResponse, error) {
+	out := new(DiscardStagedConfigResponse)
+	err := c.cc.Invoke(ctx, "/node.v1.NodePrivilegedService/DiscardStagedConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePrivilegedServiceClient) DumpPendingObservations(ctx context.Context, in *DumpPendingObservationsRequest, opts ...grpc.CallOption) (*DumpPendingObservationsResponse, error) {
+	out := new(DumpPendingObservationsResponse)
+	err := c.cc.Invoke(ctx, "/node.v1.NodePrivilegedService/DumpPendingObservations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePrivilegedServiceClient) GetQueryBudgetUsage(ctx context.Context, in *GetQueryBudgetUsageRequest, opts ...grpc.CallOption) (*GetQueryBudgetUsageResponse, error) {
+	out := new(GetQueryBudgetUsageResponse)
+	err := c.cc.Invoke(ctx, "/node.v1.NodePrivilegedService/GetQueryBudgetUsage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePrivilegedServiceClient) RestoreArchivedVAAs(ctx context.Context, in *RestoreArchivedVAAsRequest, opts ...grpc.CallOption) (*RestoreArchivedVAAsResponse, error) {
+	out := new(RestoreArchivedVAAsResponse)
+	err := c.cc.Invoke(ctx, "/node.v1.NodePrivilegedService/RestoreArchivedVAAs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePrivilegedServiceClient) GetAggregatedAttestation(ctx context.Context, in *GetAggregatedAttestationRequest, opts ...grpc.CallOption) (*GetAggregatedAttestationResponse, error) {
+	out := new(GetAggregatedAttestationResponse)
+	err := c.cc.Invoke(ctx, "/node.v1.NodePrivilegedService/GetAggregatedAttestation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePrivilegedServiceClient) GetSupportSnapshot(ctx context.Context, in *GetSupportSnapshotRequest, opts ...grpc.CallOption) (*GetSupportSnapshotResponse, error) {
+	out := new(GetSupportSnapshotResponse)
+	err := c.cc.Invoke(ctx, "/node.v1.NodePrivilegedService/GetSupportSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePrivilegedServiceClient) DumpConfig(ctx context.Context, in *DumpConfigRequest, opts ...grpc.CallOption) (*DumpConfigResponse, error) {
+	out := new(DumpConfigResponse)
+	err := c.cc.Invoke(ctx, "/node.v1.NodePrivilegedService/DumpConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePrivilegedServiceClient) GetNodeHealth(ctx context.Context, in *GetNodeHealthRequest, opts ...grpc.CallOption) (*GetNodeHealthResponse, error) {
+	out := new(GetNodeHealthResponse)
+	err := c.cc.Invoke(ctx, "/node.v1.NodePrivilegedService/GetNodeHealth", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePrivilegedServiceClient) PurgeVAAs(ctx context.Context, in *PurgeVAAsRequest, opts ...grpc.CallOption) (*PurgeVAAsResponse, error) {
+	out := new(PurgeVAAsResponse)
+	err := c.cc.Invoke(ctx, "/node.v1.NodePrivilegedService/PurgeVAAs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePrivilegedServiceClient) SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error) {
+	out := new(SetLogLevelResponse)
+	err := c.cc.Invoke(ctx, "/node.v1.NodePrivilegedService/SetLogLevel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePrivilegedServiceClient) TailLogs(ctx context.Context, in *TailLogsRequest, opts ...grpc.CallOption) (NodePrivilegedService_TailLogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &NodePrivilegedService_ServiceDesc.Streams[1], "/node.v1.NodePrivilegedService/TailLogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &nodePrivilegedServiceTailLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type NodePrivilegedService_TailLogsClient interface {
+	Recv() (*TailLogsResponse, error)
+	grpc.ClientStream
+}
+
+type nodePrivilegedServiceTailLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *nodePrivilegedServiceTailLogsClient) Recv() (*TailLogsResponse, error) {
+	m := new(TailLogsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *nodePrivilegedServiceClient) RestartComponent(ctx context.Context, in *RestartComponentRequest, opts ...grpc.CallOption) (*RestartComponentResponse, error) {
+	out := new(RestartComponentResponse)
+	err := c.cc.Invoke(ctx, "/node.v1.NodePrivilegedService/RestartComponent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePrivilegedServiceClient) AdminAuditLog(ctx context.Context, in *AdminAuditLogRequest, opts ...grpc.CallOption) (*AdminAuditLogResponse, error) {
+	out := new(AdminAuditLogResponse)
+	err := c.cc.Invoke(ctx, "/node.v1.NodePrivilegedService/AdminAuditLog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePrivilegedServiceClient) DumpConflictingObservations(ctx context.Context, in *DumpConflictingObservationsRequest, opts ...grpc.CallOption) (*DumpConflictingObservationsResponse, error) {
+	out := new(DumpConflictingObservationsResponse)
+	err := c.cc.Invoke(ctx, "/node.v1.NodePrivilegedService/DumpConflictingObservations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePrivilegedServiceClient) AccountantPendingTransfers(ctx context.Context, in *AccountantPendingTransfersRequest, opts ...grpc.CallOption) (*AccountantPendingTransfersResponse, error) {
+	out := new(AccountantPendingTransfersResponse)
+	err := c.cc.Invoke(ctx, "/node.v1.NodePrivilegedService/AccountantPendingTransfers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePrivilegedServiceClient) AccountantResubmitTransfer(ctx context.Context, in *AccountantResubmitTransferRequest, opts ...grpc.CallOption) (*AccountantResubmitTransferResponse, error) {
+	out := new(AccountantResubmitTransferResponse)
+	err := c.cc.Invoke(ctx, "/node.v1.NodePrivilegedService/AccountantResubmitTransfer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePrivilegedServiceClient) AccountantForceReleaseTransfer(ctx context.Context, in *AccountantForceReleaseTransferRequest, opts ...grpc.CallOption) (*AccountantForceReleaseTransferResponse, error) {
+	out := new(AccountantForceReleaseTransferResponse)
+	err := c.cc.Invoke(ctx, "/node.v1.NodePrivilegedService/AccountantForceReleaseTransfer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePrivilegedServiceClient) AccountantAuditLog(ctx context.Context, in *AccountantAuditLogRequest, opts ...grpc.CallOption) (*AccountantAuditLogResponse, error) {
+	out := new(AccountantAuditLogResponse)
+	err := c.cc.Invoke(ctx, "/node.v1.NodePrivilegedService/AccountantAuditLog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePrivilegedServiceClient) GatewayRelayerPause(ctx context.Context, in *GatewayRelayerPauseRequest, opts ...grpc.CallOption) (*GatewayRelayerPauseResponse, error) {
+	out := new(GatewayRelayerPauseResponse)
+	err := c.cc.Invoke(ctx, "/node.v1.NodePrivilegedService/GatewayRelayerPause", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePrivilegedServiceClient) GatewayRelayerResume(ctx context.Context, in *GatewayRelayerResumeRequest, opts ...grpc.CallOption) (*GatewayRelayerResumeResponse, error) {
+	out := new(GatewayRelayerResumeResponse)
+	err := c.cc.Invoke(ctx, "/node.v1.NodePrivilegedService/GatewayRelayerResume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePrivilegedServiceClient) GatewayRelayerPendingRelays(ctx context.Context, in *GatewayRelayerPendingRelaysRequest, opts ...grpc.CallOption) (*GatewayRelayerPendingRelaysResponse, error) {
+	out := new(GatewayRelayerPendingRelaysResponse)
+	err := c.cc.Invoke(ctx, "/node.v1.NodePrivilegedService/GatewayRelayerPendingRelays", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePrivilegedServiceClient) GatewayRelayerRetryRelay(ctx context.Context, in *GatewayRelayerRetryRelayRequest, opts ...grpc.CallOption) (*GatewayRelayerRetryRelayResponse, error) {
+	out := new(GatewayRelayerRetryRelayResponse)
+	err := c.cc.Invoke(ctx, "/node.v1.NodePrivilegedService/GatewayRelayerRetryRelay", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePrivilegedServiceClient) GatewayRelayerDropRelay(ctx context.Context, in *GatewayRelayerDropRelayRequest, opts ...grpc.CallOption) (*GatewayRelayerDropRelayResponse, error) {
+	out := new(GatewayRelayerDropRelayResponse)
+	err := c.cc.Invoke(ctx, "/node.v1.NodePrivilegedService/GatewayRelayerDropRelay", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NodePrivilegedServiceServer is the server API for NodePrivilegedService service.
 // All implementations must embed UnimplementedNodePrivilegedServiceServer
 // for forward compatibility
@@ -168,6 +628,9 @@ type NodePrivilegedServiceServer interface {
 	//
 	// A consensus majority of nodes on the network will have to inject the VAA within the
 	// VAA timeout window for it to reach consensus.
+	//
+	// With validate_only, the node only builds the VAAs and returns them, so that the guardians of a signing ceremony
+	// can check they agree on the digests before injecting.
 	InjectGovernanceVAA(context.Context, *InjectGovernanceVAARequest) (*InjectGovernanceVAAResponse, error)
 	// FindMissingMessages will detect message sequence gaps in the local VAA store for a
 	// specific emitter chain and address. Start and end slots are the lowest and highest
@@ -177,24 +640,120 @@ type NodePrivilegedServiceServer interface {
 	FindMissingMessages(context.Context, *FindMissingMessagesRequest) (*FindMissingMessagesResponse, error)
 	// SendObservationRequest broadcasts a signed observation request to the gossip network
 	// using the node's guardian key. The network rate limits these requests to one per second.
-	// Requests at higher rates will fail silently.
+	// Requests at higher rates will fail silently. A batch of requests is sent paced at the
+	// requested rate, which defaults to that limit.
 	SendObservationRequest(context.Context, *SendObservationRequestRequest) (*SendObservationRequestResponse, error)
 	// ChainGovernorStatus displays the status of the chain governor.
 	ChainGovernorStatus(context.Context, *ChainGovernorStatusRequest) (*ChainGovernorStatusResponse, error)
+	// ChainGovernorDumpState returns the complete state of the chain governor as JSON: its config version, the limits and usage
+	// of each governed chain, and the enqueued VAAs with their ages and the reasons they were enqueued.
+	ChainGovernorDumpState(context.Context, *ChainGovernorDumpStateRequest) (*ChainGovernorDumpStateResponse, error)
 	// ChainGovernorReload clears the chain governor history and reloads it from the database.
 	ChainGovernorReload(context.Context, *ChainGovernorReloadRequest) (*ChainGovernorReloadResponse, error)
 	// ChainGovernorDropPendingVAA drops a VAA from the chain governor pending list.
 	ChainGovernorDropPendingVAA(context.Context, *ChainGovernorDropPendingVAARequest) (*ChainGovernorDropPendingVAAResponse, error)
 	// ChainGovernorReleasePendingVAA release a VAA from the chain governor pending list, publishing it immediately.
+	// If releasing the VAA requires approvals, the approval of the caller is recorded instead, and the VAA is
+	// released once it has been approved by enough distinct callers. Only authenticated callers can approve: the
+	// local user of the admin socket, or the client of the admin service over TCP.
 	ChainGovernorReleasePendingVAA(context.Context, *ChainGovernorReleasePendingVAARequest) (*ChainGovernorReleasePendingVAAResponse, error)
-	// ChainGovernorResetReleaseTimer resets the release timer for a chain governor pending VAA to the configured maximum.
+	// ChainGovernorAuditLog returns the approvals and releases of chain governor pending VAAs.
+	ChainGovernorAuditLog(context.Context, *ChainGovernorAuditLogRequest) (*ChainGovernorAuditLogResponse, error)
+	// ChainGovernorResetReleaseTimer resets the release timer of chain governor pending VAAs, either to the configured maximum or
+	// to an explicit delay. The VAAs are given by id, or as all those pending for a chain.
 	ChainGovernorResetReleaseTimer(context.Context, *ChainGovernorResetReleaseTimerRequest) (*ChainGovernorResetReleaseTimerResponse, error)
+	// ChainGovernorGetLimits returns the enforced and configured limits of each chain governed by the chain governor.
+	ChainGovernorGetLimits(context.Context, *ChainGovernorGetLimitsRequest) (*ChainGovernorGetLimitsResponse, error)
+	// ChainGovernorSetLimit changes the limits of a governed chain. The change is persisted and takes precedence over the configured limits.
+	ChainGovernorSetLimit(context.Context, *ChainGovernorSetLimitRequest) (*ChainGovernorSetLimitResponse, error)
+	// ChainGovernorResetLimit goes back to enforcing the configured limits of a governed chain.
+	ChainGovernorResetLimit(context.Context, *ChainGovernorResetLimitRequest) (*ChainGovernorResetLimitResponse, error)
+	// ChainGovernorExportSnapshot returns the transfers, pending VAAs and limits of the chain governor as JSON.
+	ChainGovernorExportSnapshot(context.Context, *ChainGovernorExportSnapshotRequest) (*ChainGovernorExportSnapshotResponse, error)
+	// ChainGovernorImportSnapshot adds the transfers and limits exported by another node with ChainGovernorExportSnapshot to the
+	// chain governor. Pending VAAs are not imported.
+	ChainGovernorImportSnapshot(context.Context, *ChainGovernorImportSnapshotRequest) (*ChainGovernorImportSnapshotResponse, error)
 	// SignExistingVAA signs an existing VAA for a new guardian set using the local guardian key.
 	SignExistingVAA(context.Context, *SignExistingVAARequest) (*SignExistingVAAResponse, error)
 	// DumpRPCs returns the RPCs being used by the guardian
 	DumpRPCs(context.Context, *DumpRPCsRequest) (*DumpRPCsResponse, error)
 	// GetMissingVAAs returns the VAAs from a cloud function that need to be reobserved.
 	GetAndObserveMissingVAAs(context.Context, *GetAndObserveMissingVAAsRequest) (*GetAndObserveMissingVAAsResponse, error)
+	// GovernanceVAAStatus streams the signing progress of a governance VAA previously injected on this node.
+	// A new status is sent whenever another guardian's signature is observed. The stream ends once quorum is reached.
+	GovernanceVAAStatus(*GovernanceVAAStatusRequest, NodePrivilegedService_GovernanceVAAStatusServer) error
+	// GetRuntimeConfig returns the running value of a piece of runtime configuration, in the format accepted by ProposeConfigChange.
+	GetRuntimeConfig(context.Context, *GetRuntimeConfigRequest) (*GetRuntimeConfigResponse, error)
+	// ProposeConfigChange stages a change to a piece of runtime configuration. The change does not take effect until
+	// it is applied using ApplyStagedConfig. The proposer is the authenticated caller. Staged changes are only kept in
+	// memory, they are discarded after 24 hours or when the node restarts.
+	ProposeConfigChange(context.Context, *ProposeConfigChangeRequest) (*ProposeConfigChangeResponse, error)
+	// ListStagedConfigs lists the staged config changes, with a diff against the running config.
+	ListStagedConfigs(context.Context, *ListStagedConfigsRequest) (*ListStagedConfigsResponse, error)
+	// ApplyStagedConfig applies a staged config change. The approver is the authenticated caller. If the node requires
+	// a second approver, the change must be applied by someone other than its proposer.
+	ApplyStagedConfig(context.Context, *ApplyStagedConfigRequest) (*ApplyStagedConfigResponse, error)
+	// DiscardStagedConfig discards a staged config change without applying it.
+	DiscardStagedConfig(context.Context, *DiscardStagedConfigRequest) (*DiscardStagedConfigResponse, error)
+	// DumpPendingObservations lists the observations that have not reached quorum yet, as last persisted by the processor.
+	DumpPendingObservations(context.Context, *DumpPendingObservationsRequest) (*DumpPendingObservationsResponse, error)
+	// GetQueryBudgetUsage returns how much of their daily cross chain query budget each requester has used today.
+	GetQueryBudgetUsage(context.Context, *GetQueryBudgetUsageRequest) (*GetQueryBudgetUsageResponse, error)
+	// RestoreArchivedVAAs stores archived VAAs back into the database, where they are kept for the restore hold before
+	// being pruned again.
+	RestoreArchivedVAAs(context.Context, *RestoreArchivedVAAsRequest) (*RestoreArchivedVAAsResponse, error)
+	// GetAggregatedAttestation returns the experimental aggregated signature produced for a VAA, if any.
+	GetAggregatedAttestation(context.Context, *GetAggregatedAttestationRequest) (*GetAggregatedAttestationResponse, error)
+	// GetSupportSnapshot returns the node's diagnostics for `guardiand support-bundle`, with secrets redacted.
+	GetSupportSnapshot(context.Context, *GetSupportSnapshotRequest) (*GetSupportSnapshotResponse, error)
+	// DumpConfig returns the node's fully resolved configuration as JSON, with secrets redacted, so it can be diffed across
+	// guardians.
+	DumpConfig(context.Context, *DumpConfigRequest) (*DumpConfigResponse, error)
+	// GetNodeHealth returns the readiness of the node's components, the height and lag of each watcher, the depth of the node's
+	// internal queues, the number of p2p peers and the last error of each runnable, so tooling can poll a single endpoint.
+	GetNodeHealth(context.Context, *GetNodeHealthRequest) (*GetNodeHealthResponse, error)
+	// PurgeVAAs deletes the signed VAAs of an emitter in a range of sequences from the database, e.g. to reset a test network. It
+	// is refused unless the node was started with --adminAllowVAAPurge, and every call is recorded in the log with the
+	// authenticated caller.
+	PurgeVAAs(context.Context, *PurgeVAAsRequest) (*PurgeVAAsResponse, error)
+	// SetLogLevel changes the log level of the node, or of one of its components, without restarting it. The change can be
+	// limited in time, e.g. to enable debug logging for a few minutes. It returns the resulting levels.
+	SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error)
+	// TailLogs streams the most recent log entries of the node, then every entry logged afterwards until the stream is
+	// cancelled, optionally filtered by level and component. Entries are dropped if the client doesn't keep up.
+	TailLogs(*TailLogsRequest, NodePrivilegedService_TailLogsServer) error
+	// RestartComponent tears down a runnable of the supervision tree, such as a chain watcher, along with its children and
+	// lets the supervisor start it again, without restarting the node. A restarted watcher is built again from its current
+	// config. Every restart is recorded in the log.
+	RestartComponent(context.Context, *RestartComponentRequest) (*RestartComponentResponse, error)
+	// AdminAuditLog returns the most recent calls to the admin service, with who made them, what they asked for and the
+	// result. Every call is recorded, including those that were rejected.
+	AdminAuditLog(context.Context, *AdminAuditLogRequest) (*AdminAuditLogResponse, error)
+	// DumpConflictingObservations lists the messages for which guardians signed more than one digest, with the guardians
+	// that signed each of them.
+	DumpConflictingObservations(context.Context, *DumpConflictingObservationsRequest) (*DumpConflictingObservationsResponse, error)
+	// AccountantPendingTransfers lists the transfers the token bridge and NTT accountants hold until their accounting contract
+	// commits them.
+	AccountantPendingTransfers(context.Context, *AccountantPendingTransfersRequest) (*AccountantPendingTransfersResponse, error)
+	// AccountantResubmitTransfer submits a pending transfer to the accounting contract again right away.
+	AccountantResubmitTransfer(context.Context, *AccountantResubmitTransferRequest) (*AccountantResubmitTransferResponse, error)
+	// AccountantForceReleaseTransfer approves publishing a pending transfer without waiting for the accounting contract. The
+	// approver is the authenticated caller, and the transfer is published once enough distinct admins approved it. Only meant
+	// for emergencies, such as the contract being unavailable.
+	AccountantForceReleaseTransfer(context.Context, *AccountantForceReleaseTransferRequest) (*AccountantForceReleaseTransferResponse, error)
+	// AccountantAuditLog returns the approvals and forced releases of accountant pending transfers.
+	AccountantAuditLog(context.Context, *AccountantAuditLogRequest) (*AccountantAuditLogResponse, error)
+	// GatewayRelayerPause stops submitting VAAs to the gateway until GatewayRelayerResume is called. VAAs which reach quorum
+	// in the meantime are still queued.
+	GatewayRelayerPause(context.Context, *GatewayRelayerPauseRequest) (*GatewayRelayerPauseResponse, error)
+	// GatewayRelayerResume resumes submitting VAAs to the gateway.
+	GatewayRelayerResume(context.Context, *GatewayRelayerResumeRequest) (*GatewayRelayerResumeResponse, error)
+	// GatewayRelayerPendingRelays lists the VAAs waiting to be relayed to the gateway, including the ones it gave up on.
+	GatewayRelayerPendingRelays(context.Context, *GatewayRelayerPendingRelaysRequest) (*GatewayRelayerPendingRelaysResponse, error)
+	// GatewayRelayerRetryRelay submits a pending VAA to the gateway again, restarting its retries.
+	GatewayRelayerRetryRelay(context.Context, *GatewayRelayerRetryRelayRequest) (*GatewayRelayerRetryRelayResponse, error)
+	// GatewayRelayerDropRelay removes a VAA from the gateway relayer, so it is no longer submitted.
+	GatewayRelayerDropRelay(context.Context, *GatewayRelayerDropRelayRequest) (*GatewayRelayerDropRelayResponse, error)
 	mustEmbedUnimplementedNodePrivilegedServiceServer()
 }
 
@@ -214,6 +773,9 @@ func (UnimplementedNodePrivilegedServiceServer) SendObservationRequest(context.C
 func (UnimplementedNodePrivilegedServiceServer) ChainGovernorStatus(context.Context, *ChainGovernorStatusRequest) (*ChainGovernorStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ChainGovernorStatus not implemented")
 }
+func (UnimplementedNodePrivilegedServiceServer) ChainGovernorDumpState(context.Context, *ChainGovernorDumpStateRequest) (*ChainGovernorDumpStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChainGovernorDumpState not implemented")
+}
 func (UnimplementedNodePrivilegedServiceServer) ChainGovernorReload(context.Context, *ChainGovernorReloadRequest) (*ChainGovernorReloadResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ChainGovernorReload not implemented")
 }
@@ -223,9 +785,27 @@ func (UnimplementedNodePrivilegedServiceServer) ChainGovernorDropPendingVAA(cont
 func (UnimplementedNodePrivilegedServiceServer) ChainGovernorReleasePendingVAA(context.Context, *ChainGovernorReleasePendingVAARequest) (*ChainGovernorReleasePendingVAAResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ChainGovernorReleasePendingVAA not implemented")
 }
+func (UnimplementedNodePrivilegedServiceServer) ChainGovernorAuditLog(context.Context, *ChainGovernorAuditLogRequest) (*ChainGovernorAuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChainGovernorAuditLog not implemented")
+}
 func (UnimplementedNodePrivilegedServiceServer) ChainGovernorResetReleaseTimer(context.Context, *ChainGovernorResetReleaseTimerRequest) (*ChainGovernorResetReleaseTimerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ChainGovernorResetReleaseTimer not implemented")
 }
+func (UnimplementedNodePrivilegedServiceServer) ChainGovernorGetLimits(context.Context, *ChainGovernorGetLimitsRequest) (*ChainGovernorGetLimitsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChainGovernorGetLimits not implemented")
+}
+func (UnimplementedNodePrivilegedServiceServer) ChainGovernorSetLimit(context.Context, *ChainGovernorSetLimitRequest) (*ChainGovernorSetLimitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChainGovernorSetLimit not implemented")
+}
+func (UnimplementedNodePrivilegedServiceServer) ChainGovernorResetLimit(context.Context, *ChainGovernorResetLimitRequest) (*ChainGovernorResetLimitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChainGovernorResetLimit not implemented")
+}
+func (UnimplementedNodePrivilegedServiceServer) ChainGovernorExportSnapshot(context.Context, *ChainGovernorExportSnapshotRequest) (*ChainGovernorExportSnapshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChainGovernorExportSnapshot not implemented")
+}
+func (UnimplementedNodePrivilegedServiceServer) ChainGovernorImportSnapshot(context.Context, *ChainGovernorImportSnapshotRequest) (*ChainGovernorImportSnapshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChainGovernorImportSnapshot not implemented")
+}
 func (UnimplementedNodePrivilegedServiceServer) SignExistingVAA(context.Context, *SignExistingVAARequest) (*SignExistingVAAResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SignExistingVAA not implemented")
 }
@@ -235,23 +815,107 @@ func (UnimplementedNodePrivilegedServiceServer) DumpRPCs(context.Context, *DumpR
 func (UnimplementedNodePrivilegedServiceServer) GetAndObserveMissingVAAs(context.Context, *GetAndObserveMissingVAAsRequest) (*GetAndObserveMissingVAAsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAndObserveMissingVAAs not implemented")
 }
-func (UnimplementedNodePrivilegedServiceServer) mustEmbedUnimplementedNodePrivilegedServiceServer() {}
-
-// UnsafeNodePrivilegedServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to NodePrivilegedServiceServer will
-// result in compilation errors.
-type UnsafeNodePrivilegedServiceServer interface {
-	mustEmbedUnimplementedNodePrivilegedServiceServer()
+func (UnimplementedNodePrivilegedServiceServer) GovernanceVAAStatus(*GovernanceVAAStatusRequest, NodePrivilegedService_GovernanceVAAStatusServer) error {
+	return status.Errorf(codes.Unimplemented, "method GovernanceVAAStatus not implemented")
 }
-
-func RegisterNodePrivilegedServiceServer(s grpc.ServiceRegistrar, srv NodePrivilegedServiceServer) {
-	s.RegisterService(&NodePrivilegedService_ServiceDesc, srv)
+func (UnimplementedNodePrivilegedServiceServer) GetRuntimeConfig(context.Context, *GetRuntimeConfigRequest) (*GetRuntimeConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRuntimeConfig not implemented")
 }
-
-func _NodePrivilegedService_InjectGovernanceVAA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(InjectGovernanceVAARequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (UnimplementedNodePrivilegedServiceServer) ProposeConfigChange(context.Context, *ProposeConfigChangeRequest) (*ProposeConfigChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProposeConfigChange not implemented")
+}
+func (UnimplementedNodePrivilegedServiceServer) ListStagedConfigs(context.Context, *ListStagedConfigsRequest) (*ListStagedConfigsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStagedConfigs not implemented")
+}
+func (UnimplementedNodePrivilegedServiceServer) ApplyStagedConfig(context.Context, *ApplyStagedConfigRequest) (*ApplyStagedConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyStagedConfig not implemented")
+}
+func (UnimplementedNodePrivilegedServiceServer) DiscardStagedConfig(context.Context, *DiscardStagedConfigRequest) (*DiscardStagedConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiscardStagedConfig not implemented")
+}
+func (UnimplementedNodePrivilegedServiceServer) DumpPendingObservations(context.Context, *DumpPendingObservationsRequest) (*DumpPendingObservationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DumpPendingObservations not implemented")
+}
+func (UnimplementedNodePrivilegedServiceServer) GetQueryBudgetUsage(context.Context, *GetQueryBudgetUsageRequest) (*GetQueryBudgetUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQueryBudgetUsage not implemented")
+}
+func (UnimplementedNodePrivilegedServiceServer) RestoreArchivedVAAs(context.Context, *RestoreArchivedVAAsRequest) (*RestoreArchivedVAAsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreArchivedVAAs not implemented")
+}
+func (UnimplementedNodePrivilegedServiceServer) GetAggregatedAttestation(context.Context, *GetAggregatedAttestationRequest) (*GetAggregatedAttestationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAggregatedAttestation not implemented")
+}
+func (UnimplementedNodePrivilegedServiceServer) GetSupportSnapshot(context.Context, *GetSupportSnapshotRequest) (*GetSupportSnapshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSupportSnapshot not implemented")
+}
+func (UnimplementedNodePrivilegedServiceServer) DumpConfig(context.Context, *DumpConfigRequest) (*DumpConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DumpConfig not implemented")
+}
+func (UnimplementedNodePrivilegedServiceServer) GetNodeHealth(context.Context, *GetNodeHealthRequest) (*GetNodeHealthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNodeHealth not implemented")
+}
+func (UnimplementedNodePrivilegedServiceServer) PurgeVAAs(context.Context, *PurgeVAAsRequest) (*PurgeVAAsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeVAAs not implemented")
+}
+func (UnimplementedNodePrivilegedServiceServer) SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLogLevel not implemented")
+}
+func (UnimplementedNodePrivilegedServiceServer) TailLogs(*TailLogsRequest, NodePrivilegedService_TailLogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method TailLogs not implemented")
+}
+func (UnimplementedNodePrivilegedServiceServer) RestartComponent(context.Context, *RestartComponentRequest) (*RestartComponentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestartComponent not implemented")
+}
+func (UnimplementedNodePrivilegedServiceServer) AdminAuditLog(context.Context, *AdminAuditLogRequest) (*AdminAuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminAuditLog not implemented")
+}
+func (UnimplementedNodePrivilegedServiceServer) DumpConflictingObservations(context.Context, *DumpConflictingObservationsRequest) (*DumpConflictingObservationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DumpConflictingObservations not implemented")
+}
+func (UnimplementedNodePrivilegedServiceServer) AccountantPendingTransfers(context.Context, *AccountantPendingTransfersRequest) (*AccountantPendingTransfersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountantPendingTransfers not implemented")
+}
+func (UnimplementedNodePrivilegedServiceServer) AccountantResubmitTransfer(context.Context, *AccountantResubmitTransferRequest) (*AccountantResubmitTransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountantResubmitTransfer not implemented")
+}
+func (UnimplementedNodePrivilegedServiceServer) AccountantForceReleaseTransfer(context.Context, *AccountantForceReleaseTransferRequest) (*AccountantForceReleaseTransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountantForceReleaseTransfer not implemented")
+}
+func (UnimplementedNodePrivilegedServiceServer) AccountantAuditLog(context.Context, *AccountantAuditLogRequest) (*AccountantAuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountantAuditLog not implemented")
+}
+func (UnimplementedNodePrivilegedServiceServer) GatewayRelayerPause(context.Context, *GatewayRelayerPauseRequest) (*GatewayRelayerPauseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GatewayRelayerPause not implemented")
+}
+func (UnimplementedNodePrivilegedServiceServer) GatewayRelayerResume(context.Context, *GatewayRelayerResumeRequest) (*GatewayRelayerResumeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GatewayRelayerResume not implemented")
+}
+func (UnimplementedNodePrivilegedServiceServer) GatewayRelayerPendingRelays(context.Context, *GatewayRelayerPendingRelaysRequest) (*GatewayRelayerPendingRelaysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GatewayRelayerPendingRelays not implemented")
+}
+func (UnimplementedNodePrivilegedServiceServer) GatewayRelayerRetryRelay(context.Context, *GatewayRelayerRetryRelayRequest) (*GatewayRelayerRetryRelayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GatewayRelayerRetryRelay not implemented")
+}
+func (UnimplementedNodePrivilegedServiceServer) GatewayRelayerDropRelay(context.Context, *GatewayRelayerDropRelayRequest) (*GatewayRelayerDropRelayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GatewayRelayerDropRelay not implemented")
+}
+func (UnimplementedNodePrivilegedServiceServer) mustEmbedUnimplementedNodePrivilegedServiceServer() {}
+
+// UnsafeNodePrivilegedServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to NodePrivilegedServiceServer will
+// result in compilation errors.
+type UnsafeNodePrivilegedServiceServer interface {
+	mustEmbedUnimplementedNodePrivilegedServiceServer()
+}
+
+func RegisterNodePrivilegedServiceServer(s grpc.ServiceRegistrar, srv NodePrivilegedServiceServer) {
+	s.RegisterService(&NodePrivilegedService_ServiceDesc, srv)
+}
+
+func _NodePrivilegedService_InjectGovernanceVAA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InjectGovernanceVAARequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
 	if interceptor == nil {
 		return srv.(NodePrivilegedServiceServer).InjectGovernanceVAA(ctx, in)
@@ -320,6 +984,24 @@ func _NodePrivilegedService_ChainGovernorStatus_Handler(srv interface{}, ctx con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NodePrivilegedService_ChainGovernorDumpState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChainGovernorDumpStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePrivilegedServiceServer).ChainGovernorDumpState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/node.v1.NodePrivilegedService/ChainGovernorDumpState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePrivilegedServiceServer).ChainGovernorDumpState(ctx, req.(*ChainGovernorDumpStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NodePrivilegedService_ChainGovernorReload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ChainGovernorReloadRequest)
 	if err := dec(in); err != nil {
@@ -374,6 +1056,24 @@ func _NodePrivilegedService_ChainGovernorReleasePendingVAA_Handler(srv interface
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NodePrivilegedService_ChainGovernorAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChainGovernorAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePrivilegedServiceServer).ChainGovernorAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/node.v1.NodePrivilegedService/ChainGovernorAuditLog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePrivilegedServiceServer).ChainGovernorAuditLog(ctx, req.(*ChainGovernorAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NodePrivilegedService_ChainGovernorResetReleaseTimer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ChainGovernorResetReleaseTimerRequest)
 	if err := dec(in); err != nil {
@@ -392,6 +1092,96 @@ func _NodePrivilegedService_ChainGovernorResetReleaseTimer_Handler(srv interface
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NodePrivilegedService_ChainGovernorGetLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChainGovernorGetLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePrivilegedServiceServer).ChainGovernorGetLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/node.v1.NodePrivilegedService/ChainGovernorGetLimits",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePrivilegedServiceServer).ChainGovernorGetLimits(ctx, req.(*ChainGovernorGetLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePrivilegedService_ChainGovernorSetLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChainGovernorSetLimitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePrivilegedServiceServer).ChainGovernorSetLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/node.v1.NodePrivilegedService/ChainGovernorSetLimit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePrivilegedServiceServer).ChainGovernorSetLimit(ctx, req.(*ChainGovernorSetLimitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePrivilegedService_ChainGovernorResetLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChainGovernorResetLimitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePrivilegedServiceServer).ChainGovernorResetLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/node.v1.NodePrivilegedService/ChainGovernorResetLimit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePrivilegedServiceServer).ChainGovernorResetLimit(ctx, req.(*ChainGovernorResetLimitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePrivilegedService_ChainGovernorExportSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChainGovernorExportSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePrivilegedServiceServer).ChainGovernorExportSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/node.v1.NodePrivilegedService/ChainGovernorExportSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePrivilegedServiceServer).ChainGovernorExportSnapshot(ctx, req.(*ChainGovernorExportSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePrivilegedService_ChainGovernorImportSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChainGovernorImportSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePrivilegedServiceServer).ChainGovernorImportSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/node.v1.NodePrivilegedService/ChainGovernorImportSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePrivilegedServiceServer).ChainGovernorImportSnapshot(ctx, req.(*ChainGovernorImportSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NodePrivilegedService_SignExistingVAA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SignExistingVAARequest)
 	if err := dec(in); err != nil {
@@ -446,58 +1236,829 @@ func _NodePrivilegedService_GetAndObserveMissingVAAs_Handler(srv interface{}, ct
 	return interceptor(ctx, in, info, handler)
 }
 
-// NodePrivilegedService_ServiceDesc is the grpc.ServiceDesc for NodePrivilegedService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var NodePrivilegedService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "node.v1.NodePrivilegedService",
-	HandlerType: (*NodePrivilegedServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "InjectGovernanceVAA",
-			Handler:    _NodePrivilegedService_InjectGovernanceVAA_Handler,
-		},
-		{
-			MethodName: "FindMissingMessages",
-			Handler:    _NodePrivilegedService_FindMissingMessages_Handler,
-		},
-		{
-			MethodName: "SendObservationRequest",
-			Handler:    _NodePrivilegedService_SendObservationRequest_Handler,
-		},
-		{
-			MethodName: "ChainGovernorStatus",
-			Handler:    _NodePrivilegedService_ChainGovernorStatus_Handler,
-		},
-		{
-			MethodName: "ChainGovernorReload",
-			Handler:    _NodePrivilegedService_ChainGovernorReload_Handler,
-		},
-		{
-			MethodName: "ChainGovernorDropPendingVAA",
-			Handler:    _NodePrivilegedService_ChainGovernorDropPendingVAA_Handler,
-		},
-		{
-			MethodName: "ChainGovernorReleasePendingVAA",
-			Handler:    _NodePrivilegedService_ChainGovernorReleasePendingVAA_Handler,
-		},
-		{
-			MethodName: "ChainGovernorResetReleaseTimer",
-			Handler:    _NodePrivilegedService_ChainGovernorResetReleaseTimer_Handler,
-		},
-		{
-			MethodName: "SignExistingVAA",
-			Handler:    _NodePrivilegedService_SignExistingVAA_Handler,
-		},
-		{
-			MethodName: "DumpRPCs",
-			Handler:    _NodePrivilegedService_DumpRPCs_Handler,
-		},
-		{
-			MethodName: "GetAndObserveMissingVAAs",
-			Handler:    _NodePrivilegedService_GetAndObserveMissingVAAs_Handler,
+func _NodePrivilegedService_GovernanceVAAStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GovernanceVAAStatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NodePrivilegedServiceServer).GovernanceVAAStatus(m, &nodePrivilegedServiceGovernanceVAAStatusServer{stream})
+}
+
+type NodePrivilegedService_GovernanceVAAStatusServer interface {
+	Send(*GovernanceVAAStatusResponse) error
+	grpc.ServerStream
+}
+
+type nodePrivilegedServiceGovernanceVAAStatusServer struct {
+	grpc.ServerStream
+}
+
+func (x *nodePrivilegedServiceGovernanceVAAStatusServer) Send(m *GovernanceVAAStatusResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _NodePrivilegedService_GetRuntimeConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRuntimeConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePrivilegedServiceServer).GetRuntimeConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/node.v1.NodePrivilegedService/GetRuntimeConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePrivilegedServiceServer).GetRuntimeConfig(ctx, req.(*GetRuntimeConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePrivilegedService_ProposeConfigChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProposeConfigChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePrivilegedServiceServer).ProposeConfigChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/node.v1.NodePrivilegedService/ProposeConfigChange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePrivilegedServiceServer).ProposeConfigChange(ctx, req.(*ProposeConfigChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePrivilegedService_ListStagedConfigs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStagedConfigsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePrivilegedServiceServer).ListStagedConfigs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/node.v1.NodePrivilegedService/ListStagedConfigs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePrivilegedServiceServer).ListStagedConfigs(ctx, req.(*ListStagedConfigsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePrivilegedService_ApplyStagedConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyStagedConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePrivilegedServiceServer).ApplyStagedConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/node.v1.NodePrivilegedService/ApplyStagedConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePrivilegedServiceServer).ApplyStagedConfig(ctx, req.(*ApplyStagedConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePrivilegedService_DiscardStagedConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiscardStagedConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePrivilegedServiceServer).DiscardStagedConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/node.v1.NodePrivilegedService/DiscardStagedConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePrivilegedServiceServer).DiscardStagedConfig(ctx, req.(*DiscardStagedConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePrivilegedService_DumpPendingObservations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DumpPendingObservationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePrivilegedServiceServer).DumpPendingObservations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/node.v1.NodePrivilegedService/DumpPendingObservations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePrivilegedServiceServer).DumpPendingObservations(ctx, req.(*DumpPendingObservationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePrivilegedService_GetQueryBudgetUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQueryBudgetUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePrivilegedServiceServer).GetQueryBudgetUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/node.v1.NodePrivilegedService/GetQueryBudgetUsage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePrivilegedServiceServer).GetQueryBudgetUsage(ctx, req.(*GetQueryBudgetUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePrivilegedService_RestoreArchivedVAAs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreArchivedVAAsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePrivilegedServiceServer).RestoreArchivedVAAs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/node.v1.NodePrivilegedService/RestoreArchivedVAAs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePrivilegedServiceServer).RestoreArchivedVAAs(ctx, req.(*RestoreArchivedVAAsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePrivilegedService_GetAggregatedAttestation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAggregatedAttestationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePrivilegedServiceServer).GetAggregatedAttestation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/node.v1.NodePrivilegedService/GetAggregatedAttestation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePrivilegedServiceServer).GetAggregatedAttestation(ctx, req.(*GetAggregatedAttestationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePrivilegedService_GetSupportSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSupportSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePrivilegedServiceServer).GetSupportSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/node.v1.NodePrivilegedService/GetSupportSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePrivilegedServiceServer).GetSupportSnapshot(ctx, req.(*GetSupportSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePrivilegedService_DumpConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DumpConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePrivilegedServiceServer).DumpConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/node.v1.NodePrivilegedService/DumpConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePrivilegedServiceServer).DumpConfig(ctx, req.(*DumpConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePrivilegedService_GetNodeHealth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNodeHealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePrivilegedServiceServer).GetNodeHealth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/node.v1.NodePrivilegedService/GetNodeHealth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePrivilegedServiceServer).GetNodeHealth(ctx, req.(*GetNodeHealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePrivilegedService_PurgeVAAs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeVAAsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePrivilegedServiceServer).PurgeVAAs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/node.v1.NodePrivilegedService/PurgeVAAs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePrivilegedServiceServer).PurgeVAAs(ctx, req.(*PurgeVAAsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePrivilegedService_SetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLogLevelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePrivilegedServiceServer).SetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/node.v1.NodePrivilegedService/SetLogLevel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePrivilegedServiceServer).SetLogLevel(ctx, req.(*SetLogLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePrivilegedService_TailLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TailLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NodePrivilegedServiceServer).TailLogs(m, &nodePrivilegedServiceTailLogsServer{stream})
+}
+
+type NodePrivilegedService_TailLogsServer interface {
+	Send(*TailLogsResponse) error
+	grpc.ServerStream
+}
+
+type nodePrivilegedServiceTailLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *nodePrivilegedServiceTailLogsServer) Send(m *TailLogsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _NodePrivilegedService_RestartComponent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestartComponentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePrivilegedServiceServer).RestartComponent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/node.v1.NodePrivilegedService/RestartComponent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePrivilegedServiceServer).RestartComponent(ctx, req.(*RestartComponentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePrivilegedService_AdminAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePrivilegedServiceServer).AdminAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/node.v1.NodePrivilegedService/AdminAuditLog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePrivilegedServiceServer).AdminAuditLog(ctx, req.(*AdminAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePrivilegedService_DumpConflictingObservations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DumpConflictingObservationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePrivilegedServiceServer).DumpConflictingObservations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/node.v1.NodePrivilegedService/DumpConflictingObservations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePrivilegedServiceServer).DumpConflictingObservations(ctx, req.(*DumpConflictingObservationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePrivilegedService_AccountantPendingTransfers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountantPendingTransfersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePrivilegedServiceServer).AccountantPendingTransfers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/node.v1.NodePrivilegedService/AccountantPendingTransfers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePrivilegedServiceServer).AccountantPendingTransfers(ctx, req.(*AccountantPendingTransfersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePrivilegedService_AccountantResubmitTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountantResubmitTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePrivilegedServiceServer).AccountantResubmitTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/node.v1.NodePrivilegedService/AccountantResubmitTransfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePrivilegedServiceServer).AccountantResubmitTransfer(ctx, req.(*AccountantResubmitTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePrivilegedService_AccountantForceReleaseTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountantForceReleaseTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePrivilegedServiceServer).AccountantForceReleaseTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/node.v1.NodePrivilegedService/AccountantForceReleaseTransfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePrivilegedServiceServer).AccountantForceReleaseTransfer(ctx, req.(*AccountantForceReleaseTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePrivilegedService_AccountantAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountantAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePrivilegedServiceServer).AccountantAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/node.v1.NodePrivilegedService/AccountantAuditLog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePrivilegedServiceServer).AccountantAuditLog(ctx, req.(*AccountantAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePrivilegedService_GatewayRelayerPause_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GatewayRelayerPauseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePrivilegedServiceServer).GatewayRelayerPause(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/node.v1.NodePrivilegedService/GatewayRelayerPause",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePrivilegedServiceServer).GatewayRelayerPause(ctx, req.(*GatewayRelayerPauseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePrivilegedService_GatewayRelayerResume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GatewayRelayerResumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePrivilegedServiceServer).GatewayRelayerResume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/node.v1.NodePrivilegedService/GatewayRelayerResume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePrivilegedServiceServer).GatewayRelayerResume(ctx, req.(*GatewayRelayerResumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePrivilegedService_GatewayRelayerPendingRelays_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GatewayRelayerPendingRelaysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePrivilegedServiceServer).GatewayRelayerPendingRelays(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/node.v1.NodePrivilegedService/GatewayRelayerPendingRelays",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePrivilegedServiceServer).GatewayRelayerPendingRelays(ctx, req.(*GatewayRelayerPendingRelaysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePrivilegedService_GatewayRelayerRetryRelay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GatewayRelayerRetryRelayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePrivilegedServiceServer).GatewayRelayerRetryRelay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/node.v1.NodePrivilegedService/GatewayRelayerRetryRelay",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePrivilegedServiceServer).GatewayRelayerRetryRelay(ctx, req.(*GatewayRelayerRetryRelayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePrivilegedService_GatewayRelayerDropRelay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GatewayRelayerDropRelayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePrivilegedServiceServer).GatewayRelayerDropRelay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/node.v1.NodePrivilegedService/GatewayRelayerDropRelay",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePrivilegedServiceServer).GatewayRelayerDropRelay(ctx, req.(*GatewayRelayerDropRelayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// NodePrivilegedService_ServiceDesc is the grpc.ServiceDesc for NodePrivilegedService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var NodePrivilegedService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "node.v1.NodePrivilegedService",
+	HandlerType: (*NodePrivilegedServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "InjectGovernanceVAA",
+			Handler:    _NodePrivilegedService_InjectGovernanceVAA_Handler,
+		},
+		{
+			MethodName: "FindMissingMessages",
+			Handler:    _NodePrivilegedService_FindMissingMessages_Handler,
+		},
+		{
+			MethodName: "SendObservationRequest",
+			Handler:    _NodePrivilegedService_SendObservationRequest_Handler,
+		},
+		{
+			MethodName: "ChainGovernorStatus",
+			Handler:    _NodePrivilegedService_ChainGovernorStatus_Handler,
+		},
+		{
+			MethodName: "ChainGovernorDumpState",
+			Handler:    _NodePrivilegedService_ChainGovernorDumpState_Handler,
+		},
+		{
+			MethodName: "ChainGovernorReload",
+			Handler:    _NodePrivilegedService_ChainGovernorReload_Handler,
+		},
+		{
+			MethodName: "ChainGovernorDropPendingVAA",
+			Handler:    _NodePrivilegedService_ChainGovernorDropPendingVAA_Handler,
+		},
+		{
+			MethodName: "ChainGovernorReleasePendingVAA",
+			Handler:    _NodePrivilegedService_ChainGovernorReleasePendingVAA_Handler,
+		},
+		{
+			MethodName: "ChainGovernorAuditLog",
+			Handler:    _NodePrivilegedService_ChainGovernorAuditLog_Handler,
+		},
+		{
+			MethodName: "ChainGovernorResetReleaseTimer",
+			Handler:    _NodePrivilegedService_ChainGovernorResetReleaseTimer_Handler,
+		},
+		{
+			MethodName: "ChainGovernorGetLimits",
+			Handler:    _NodePrivilegedService_ChainGovernorGetLimits_Handler,
+		},
+		{
+			MethodName: "ChainGovernorSetLimit",
+			Handler:    _NodePrivilegedService_ChainGovernorSetLimit_Handler,
+		},
+		{
+			MethodName: "ChainGovernorResetLimit",
+			Handler:    _NodePrivilegedService_ChainGovernorResetLimit_Handler,
+		},
+		{
+			MethodName: "ChainGovernorExportSnapshot",
+			Handler:    _NodePrivilegedService_ChainGovernorExportSnapshot_Handler,
+		},
+		{
+			MethodName: "ChainGovernorImportSnapshot",
+			Handler:    _NodePrivilegedService_ChainGovernorImportSnapshot_Handler,
+		},
+		{
+			MethodName: "SignExistingVAA",
+			Handler:    _NodePrivilegedService_SignExistingVAA_Handler,
+		},
+		{
+			MethodName: "DumpRPCs",
+			Handler:    _NodePrivilegedService_DumpRPCs_Handler,
+		},
+		{
+			MethodName: "GetAndObserveMissingVAAs",
+			Handler:    _NodePrivilegedService_GetAndObserveMissingVAAs_Handler,
+		},
+		{
+			MethodName: "GetRuntimeConfig",
+			Handler:    _NodePrivilegedService_GetRuntimeConfig_Handler,
+		},
+		{
+			MethodName: "ProposeConfigChange",
+			Handler:    _NodePrivilegedService_ProposeConfigChange_Handler,
+		},
+		{
+			MethodName: "ListStagedConfigs",
+			Handler:    _NodePrivilegedService_ListStagedConfigs_Handler,
+		},
+		{
+			MethodName: "ApplyStagedConfig",
+			Handler:    _NodePrivilegedService_ApplyStagedConfig_Handler,
+		},
+		{
+			MethodName: "DiscardStagedConfig",
+			Handler:    _NodePrivilegedService_DiscardStagedConfig_Handler,
+		},
+		{
+			MethodName: "DumpPendingObservations",
+			Handler:    _NodePrivilegedService_DumpPendingObservations_Handler,
+		},
+		{
+			MethodName: "GetQueryBudgetUsage",
+			Handler:    _NodePrivilegedService_GetQueryBudgetUsage_Handler,
+		},
+		{
+			MethodName: "RestoreArchivedVAAs",
+			Handler:    _NodePrivilegedService_RestoreArchivedVAAs_Handler,
+		},
+		{
+			MethodName: "GetAggregatedAttestation",
+			Handler:    _NodePrivilegedService_GetAggregatedAttestation_Handler,
+		},
+		{
+			MethodName: "GetSupportSnapshot",
+			Handler:    _NodePrivilegedService_GetSupportSnapshot_Handler,
+		},
+		{
+			MethodName: "DumpConfig",
+			Handler:    _NodePrivilegedService_DumpConfig_Handler,
+		},
+		{
+			MethodName: "GetNodeHealth",
+			Handler:    _NodePrivilegedService_GetNodeHealth_Handler,
+		},
+		{
+			MethodName: "PurgeVAAs",
+			Handler:    _NodePrivilegedService_PurgeVAAs_Handler,
+		},
+		{
+			MethodName: "SetLogLevel",
+			Handler:    _NodePrivilegedService_SetLogLevel_Handler,
+		},
+		{
+			MethodName: "RestartComponent",
+			Handler:    _NodePrivilegedService_RestartComponent_Handler,
+		},
+		{
+			MethodName: "AdminAuditLog",
+			Handler:    _NodePrivilegedService_AdminAuditLog_Handler,
+		},
+		{
+			MethodName: "DumpConflictingObservations",
+			Handler:    _NodePrivilegedService_DumpConflictingObservations_Handler,
+		},
+		{
+			MethodName: "AccountantPendingTransfers",
+			Handler:    _NodePrivilegedService_AccountantPendingTransfers_Handler,
+		},
+		{
+			MethodName: "AccountantResubmitTransfer",
+			Handler:    _NodePrivilegedService_AccountantResubmitTransfer_Handler,
+		},
+		{
+			MethodName: "AccountantForceReleaseTransfer",
+			Handler:    _NodePrivilegedService_AccountantForceReleaseTransfer_Handler,
+		},
+		{
+			MethodName: "AccountantAuditLog",
+			Handler:    _NodePrivilegedService_AccountantAuditLog_Handler,
+		},
+		{
+			MethodName: "GatewayRelayerPause",
+			Handler:    _NodePrivilegedService_GatewayRelayerPause_Handler,
+		},
+		{
+			MethodName: "GatewayRelayerResume",
+			Handler:    _NodePrivilegedService_GatewayRelayerResume_Handler,
+		},
+		{
+			MethodName: "GatewayRelayerPendingRelays",
+			Handler:    _NodePrivilegedService_GatewayRelayerPendingRelays_Handler,
+		},
+		{
+			MethodName: "GatewayRelayerRetryRelay",
+			Handler:    _NodePrivilegedService_GatewayRelayerRetryRelay_Handler,
+		},
+		{
+			MethodName: "GatewayRelayerDropRelay",
+			Handler:    _NodePrivilegedService_GatewayRelayerDropRelay_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GovernanceVAAStatus",
+			Handler:       _NodePrivilegedService_GovernanceVAAStatus_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "TailLogs",
+			Handler:       _NodePrivilegedService_TailLogs_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "node/v1/node.proto",
+}
+
+// DatabaseReplicationServiceClient is the client API for DatabaseReplicationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DatabaseReplicationServiceClient interface {
+	// StreamSignedVAAs sends the stored signed VAAs, then every signed VAA stored afterwards until the stream is cancelled. The
+	// stream fails if the replica does not keep up with the VAAs being stored.
+	StreamSignedVAAs(ctx context.Context, in *StreamSignedVAAsRequest, opts ...grpc.CallOption) (DatabaseReplicationService_StreamSignedVAAsClient, error)
+}
+
+type databaseReplicationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDatabaseReplicationServiceClient(cc grpc.ClientConnInterface) DatabaseReplicationServiceClient {
+	return &databaseReplicationServiceClient{cc}
+}
+
+func (c *databaseReplicationServiceClient) StreamSignedVAAs(ctx context.Context, in *StreamSignedVAAsRequest, opts ...grpc.CallOption) (DatabaseReplicationService_StreamSignedVAAsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &DatabaseReplicationService_ServiceDesc.Streams[0], "/node.v1.DatabaseReplicationService/StreamSignedVAAs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &databaseReplicationServiceStreamSignedVAAsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DatabaseReplicationService_StreamSignedVAAsClient interface {
+	Recv() (*StreamSignedVAAsResponse, error)
+	grpc.ClientStream
+}
+
+type databaseReplicationServiceStreamSignedVAAsClient struct {
+	grpc.ClientStream
+}
+
+func (x *databaseReplicationServiceStreamSignedVAAsClient) Recv() (*StreamSignedVAAsResponse, error) {
+	m := new(StreamSignedVAAsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// DatabaseReplicationServiceServer is the server API for DatabaseReplicationService service.
+// All implementations must embed UnimplementedDatabaseReplicationServiceServer
+// for forward compatibility
+type DatabaseReplicationServiceServer interface {
+	// StreamSignedVAAs sends the stored signed VAAs, then every signed VAA stored afterwards until the stream is cancelled. The
+	// stream fails if the replica does not keep up with the VAAs being stored.
+	StreamSignedVAAs(*StreamSignedVAAsRequest, DatabaseReplicationService_StreamSignedVAAsServer) error
+	mustEmbedUnimplementedDatabaseReplicationServiceServer()
+}
+
+// UnimplementedDatabaseReplicationServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedDatabaseReplicationServiceServer struct {
+}
+
+func (UnimplementedDatabaseReplicationServiceServer) StreamSignedVAAs(*StreamSignedVAAsRequest, DatabaseReplicationService_StreamSignedVAAsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamSignedVAAs not implemented")
+}
+func (UnimplementedDatabaseReplicationServiceServer) mustEmbedUnimplementedDatabaseReplicationServiceServer() {
+}
+
+// UnsafeDatabaseReplicationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DatabaseReplicationServiceServer will
+// result in compilation errors.
+type UnsafeDatabaseReplicationServiceServer interface {
+	mustEmbedUnimplementedDatabaseReplicationServiceServer()
+}
+
+func RegisterDatabaseReplicationServiceServer(s grpc.ServiceRegistrar, srv DatabaseReplicationServiceServer) {
+	s.RegisterService(&DatabaseReplicationService_ServiceDesc, srv)
+}
+
+func _DatabaseReplicationService_StreamSignedVAAs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamSignedVAAsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DatabaseReplicationServiceServer).StreamSignedVAAs(m, &databaseReplicationServiceStreamSignedVAAsServer{stream})
+}
+
+type DatabaseReplicationService_StreamSignedVAAsServer interface {
+	Send(*StreamSignedVAAsResponse) error
+	grpc.ServerStream
+}
+
+type databaseReplicationServiceStreamSignedVAAsServer struct {
+	grpc.ServerStream
+}
+
+func (x *databaseReplicationServiceStreamSignedVAAsServer) Send(m *StreamSignedVAAsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// DatabaseReplicationService_ServiceDesc is the grpc.ServiceDesc for DatabaseReplicationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DatabaseReplicationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "node.v1.DatabaseReplicationService",
+	HandlerType: (*DatabaseReplicationServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamSignedVAAs",
+			Handler:       _DatabaseReplicationService_StreamSignedVAAs_Handler,
+			ServerStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "node/v1/node.proto",
 }
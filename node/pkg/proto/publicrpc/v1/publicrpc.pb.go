@@ -8,6 +8,7 @@ package publicrpcv1
 
 import (
 	v1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
@@ -229,14 +230,20 @@ func (x *GetSignedVAAResponse) GetVaaBytes() []byte {
 	return nil
 }
 
-type GetLastHeartbeatsRequest struct {
+type GetSignedVAAByTxHashRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// Chain of the transaction, which is the emitter chain of its messages.
+	EmitterChain ChainID `protobuf:"varint,1,opt,name=emitter_chain,json=emitterChain,proto3,enum=publicrpc.v1.ChainID" json:"emitter_chain,omitempty"`
+	// Signature of the Solana transaction, base58-encoded as shown by explorers or hex-encoded. The address of the message account
+	// of a message is accepted as well, in the same encodings, and returns the VAA of that message.
+	TxHash string `protobuf:"bytes,2,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
 }
 
-func (x *GetLastHeartbeatsRequest) Reset() {
-	*x = GetLastHeartbeatsRequest{}
+func (x *GetSignedVAAByTxHashRequest) Reset() {
+	*x = GetSignedVAAByTxHashRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -244,13 +251,13 @@ func (x *GetLastHeartbeatsRequest) Reset() {
 	}
 }
 
-func (x *GetLastHeartbeatsRequest) String() string {
+func (x *GetSignedVAAByTxHashRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetLastHeartbeatsRequest) ProtoMessage() {}
+func (*GetSignedVAAByTxHashRequest) ProtoMessage() {}
 
-func (x *GetLastHeartbeatsRequest) ProtoReflect() protoreflect.Message {
+func (x *GetSignedVAAByTxHashRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -262,21 +269,36 @@ func (x *GetLastHeartbeatsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetLastHeartbeatsRequest.ProtoReflect.Descriptor instead.
-func (*GetLastHeartbeatsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetSignedVAAByTxHashRequest.ProtoReflect.Descriptor instead.
+func (*GetSignedVAAByTxHashRequest) Descriptor() ([]byte, []int) {
 	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{3}
 }
 
-type GetLastHeartbeatsResponse struct {
+func (x *GetSignedVAAByTxHashRequest) GetEmitterChain() ChainID {
+	if x != nil {
+		return x.EmitterChain
+	}
+	return ChainID_CHAIN_ID_UNSPECIFIED
+}
+
+func (x *GetSignedVAAByTxHashRequest) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+type GetSignedVAAByTxHashResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Entries []*GetLastHeartbeatsResponse_Entry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	// The signed VAAs of the messages of the transaction, ordered by emitter and sequence.
+	VaaBytes [][]byte `protobuf:"bytes,1,rep,name=vaa_bytes,json=vaaBytes,proto3" json:"vaa_bytes,omitempty"`
 }
 
-func (x *GetLastHeartbeatsResponse) Reset() {
-	*x = GetLastHeartbeatsResponse{}
+func (x *GetSignedVAAByTxHashResponse) Reset() {
+	*x = GetSignedVAAByTxHashResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -284,13 +306,13 @@ func (x *GetLastHeartbeatsResponse) Reset() {
 	}
 }
 
-func (x *GetLastHeartbeatsResponse) String() string {
+func (x *GetSignedVAAByTxHashResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetLastHeartbeatsResponse) ProtoMessage() {}
+func (*GetSignedVAAByTxHashResponse) ProtoMessage() {}
 
-func (x *GetLastHeartbeatsResponse) ProtoReflect() protoreflect.Message {
+func (x *GetSignedVAAByTxHashResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -302,26 +324,29 @@ func (x *GetLastHeartbeatsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetLastHeartbeatsResponse.ProtoReflect.Descriptor instead.
-func (*GetLastHeartbeatsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetSignedVAAByTxHashResponse.ProtoReflect.Descriptor instead.
+func (*GetSignedVAAByTxHashResponse) Descriptor() ([]byte, []int) {
 	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *GetLastHeartbeatsResponse) GetEntries() []*GetLastHeartbeatsResponse_Entry {
+func (x *GetSignedVAAByTxHashResponse) GetVaaBytes() [][]byte {
 	if x != nil {
-		return x.Entries
+		return x.VaaBytes
 	}
 	return nil
 }
 
-type GetCurrentGuardianSetRequest struct {
+type GetSignedVAABatchRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// At most 1000 message IDs.
+	MessageIds []*MessageID `protobuf:"bytes,1,rep,name=message_ids,json=messageIds,proto3" json:"message_ids,omitempty"`
 }
 
-func (x *GetCurrentGuardianSetRequest) Reset() {
-	*x = GetCurrentGuardianSetRequest{}
+func (x *GetSignedVAABatchRequest) Reset() {
+	*x = GetSignedVAABatchRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -329,13 +354,13 @@ func (x *GetCurrentGuardianSetRequest) Reset() {
 	}
 }
 
-func (x *GetCurrentGuardianSetRequest) String() string {
+func (x *GetSignedVAABatchRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCurrentGuardianSetRequest) ProtoMessage() {}
+func (*GetSignedVAABatchRequest) ProtoMessage() {}
 
-func (x *GetCurrentGuardianSetRequest) ProtoReflect() protoreflect.Message {
+func (x *GetSignedVAABatchRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -347,21 +372,29 @@ func (x *GetCurrentGuardianSetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCurrentGuardianSetRequest.ProtoReflect.Descriptor instead.
-func (*GetCurrentGuardianSetRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetSignedVAABatchRequest.ProtoReflect.Descriptor instead.
+func (*GetSignedVAABatchRequest) Descriptor() ([]byte, []int) {
 	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{5}
 }
 
-type GetCurrentGuardianSetResponse struct {
+func (x *GetSignedVAABatchRequest) GetMessageIds() []*MessageID {
+	if x != nil {
+		return x.MessageIds
+	}
+	return nil
+}
+
+type GetSignedVAABatchResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	GuardianSet *GuardianSet `protobuf:"bytes,1,opt,name=guardian_set,json=guardianSet,proto3" json:"guardian_set,omitempty"`
+	// The signed VAAs in the order of message_ids, empty for each VAA that is not found.
+	VaaBytes [][]byte `protobuf:"bytes,1,rep,name=vaa_bytes,json=vaaBytes,proto3" json:"vaa_bytes,omitempty"`
 }
 
-func (x *GetCurrentGuardianSetResponse) Reset() {
-	*x = GetCurrentGuardianSetResponse{}
+func (x *GetSignedVAABatchResponse) Reset() {
+	*x = GetSignedVAABatchResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -369,13 +402,13 @@ func (x *GetCurrentGuardianSetResponse) Reset() {
 	}
 }
 
-func (x *GetCurrentGuardianSetResponse) String() string {
+func (x *GetSignedVAABatchResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCurrentGuardianSetResponse) ProtoMessage() {}
+func (*GetSignedVAABatchResponse) ProtoMessage() {}
 
-func (x *GetCurrentGuardianSetResponse) ProtoReflect() protoreflect.Message {
+func (x *GetSignedVAABatchResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -387,31 +420,39 @@ func (x *GetCurrentGuardianSetResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCurrentGuardianSetResponse.ProtoReflect.Descriptor instead.
-func (*GetCurrentGuardianSetResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetSignedVAABatchResponse.ProtoReflect.Descriptor instead.
+func (*GetSignedVAABatchResponse) Descriptor() ([]byte, []int) {
 	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *GetCurrentGuardianSetResponse) GetGuardianSet() *GuardianSet {
+func (x *GetSignedVAABatchResponse) GetVaaBytes() [][]byte {
 	if x != nil {
-		return x.GuardianSet
+		return x.VaaBytes
 	}
 	return nil
 }
 
-type GuardianSet struct {
+type GetSignedVAARangeRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Guardian set index
-	Index uint32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
-	// List of guardian addresses as human-readable hex-encoded (leading 0x) addresses.
-	Addresses []string `protobuf:"bytes,2,rep,name=addresses,proto3" json:"addresses,omitempty"`
-}
-
-func (x *GuardianSet) Reset() {
-	*x = GuardianSet{}
+	// Emitter chain ID.
+	EmitterChain ChainID `protobuf:"varint,1,opt,name=emitter_chain,json=emitterChain,proto3,enum=publicrpc.v1.ChainID" json:"emitter_chain,omitempty"`
+	// Hex-encoded (without leading 0x) emitter address.
+	EmitterAddress string `protobuf:"bytes,2,opt,name=emitter_address,json=emitterAddress,proto3" json:"emitter_address,omitempty"`
+	// First sequence of the range.
+	FromSequence uint64 `protobuf:"varint,3,opt,name=from_sequence,json=fromSequence,proto3" json:"from_sequence,omitempty"`
+	// Last sequence of the range, inclusive. Zero means there is no upper bound.
+	ToSequence uint64 `protobuf:"varint,4,opt,name=to_sequence,json=toSequence,proto3" json:"to_sequence,omitempty"`
+	// Maximum number of VAAs to return, 100 if zero. It is capped at 1000.
+	Limit uint32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	// continuation_token of the previous page, empty for the first page. The other fields must be the same as for that page.
+	ContinuationToken string `protobuf:"bytes,6,opt,name=continuation_token,json=continuationToken,proto3" json:"continuation_token,omitempty"`
+}
+
+func (x *GetSignedVAARangeRequest) Reset() {
+	*x = GetSignedVAARangeRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -419,13 +460,13 @@ func (x *GuardianSet) Reset() {
 	}
 }
 
-func (x *GuardianSet) String() string {
+func (x *GetSignedVAARangeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GuardianSet) ProtoMessage() {}
+func (*GetSignedVAARangeRequest) ProtoMessage() {}
 
-func (x *GuardianSet) ProtoReflect() protoreflect.Message {
+func (x *GetSignedVAARangeRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -437,33 +478,66 @@ func (x *GuardianSet) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GuardianSet.ProtoReflect.Descriptor instead.
-func (*GuardianSet) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetSignedVAARangeRequest.ProtoReflect.Descriptor instead.
+func (*GetSignedVAARangeRequest) Descriptor() ([]byte, []int) {
 	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *GuardianSet) GetIndex() uint32 {
+func (x *GetSignedVAARangeRequest) GetEmitterChain() ChainID {
 	if x != nil {
-		return x.Index
+		return x.EmitterChain
+	}
+	return ChainID_CHAIN_ID_UNSPECIFIED
+}
+
+func (x *GetSignedVAARangeRequest) GetEmitterAddress() string {
+	if x != nil {
+		return x.EmitterAddress
+	}
+	return ""
+}
+
+func (x *GetSignedVAARangeRequest) GetFromSequence() uint64 {
+	if x != nil {
+		return x.FromSequence
 	}
 	return 0
 }
 
-func (x *GuardianSet) GetAddresses() []string {
+func (x *GetSignedVAARangeRequest) GetToSequence() uint64 {
 	if x != nil {
-		return x.Addresses
+		return x.ToSequence
 	}
-	return nil
+	return 0
 }
 
-type GovernorGetAvailableNotionalByChainRequest struct {
+func (x *GetSignedVAARangeRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetSignedVAARangeRequest) GetContinuationToken() string {
+	if x != nil {
+		return x.ContinuationToken
+	}
+	return ""
+}
+
+type GetSignedVAARangeResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// The signed VAAs in sequence order. The sequences may have gaps, for VAAs the node doesn't have.
+	VaaBytes [][]byte `protobuf:"bytes,1,rep,name=vaa_bytes,json=vaaBytes,proto3" json:"vaa_bytes,omitempty"`
+	// Returns the next page when set in the request, empty if this is the last page.
+	ContinuationToken string `protobuf:"bytes,2,opt,name=continuation_token,json=continuationToken,proto3" json:"continuation_token,omitempty"`
 }
 
-func (x *GovernorGetAvailableNotionalByChainRequest) Reset() {
-	*x = GovernorGetAvailableNotionalByChainRequest{}
+func (x *GetSignedVAARangeResponse) Reset() {
+	*x = GetSignedVAARangeResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -471,13 +545,13 @@ func (x *GovernorGetAvailableNotionalByChainRequest) Reset() {
 	}
 }
 
-func (x *GovernorGetAvailableNotionalByChainRequest) String() string {
+func (x *GetSignedVAARangeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GovernorGetAvailableNotionalByChainRequest) ProtoMessage() {}
+func (*GetSignedVAARangeResponse) ProtoMessage() {}
 
-func (x *GovernorGetAvailableNotionalByChainRequest) ProtoReflect() protoreflect.Message {
+func (x *GetSignedVAARangeResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -489,23 +563,37 @@ func (x *GovernorGetAvailableNotionalByChainRequest) ProtoReflect() protoreflect
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GovernorGetAvailableNotionalByChainRequest.ProtoReflect.Descriptor instead.
-func (*GovernorGetAvailableNotionalByChainRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetSignedVAARangeResponse.ProtoReflect.Descriptor instead.
+func (*GetSignedVAARangeResponse) Descriptor() ([]byte, []int) {
 	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{8}
 }
 
-type GovernorGetAvailableNotionalByChainResponse struct {
+func (x *GetSignedVAARangeResponse) GetVaaBytes() [][]byte {
+	if x != nil {
+		return x.VaaBytes
+	}
+	return nil
+}
+
+func (x *GetSignedVAARangeResponse) GetContinuationToken() string {
+	if x != nil {
+		return x.ContinuationToken
+	}
+	return ""
+}
+
+type GetLastHeartbeatsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// There is an entry for each chain that is being governed.
-	// Chains that are not being governed are not listed, and assumed to be unlimited.
-	Entries []*GovernorGetAvailableNotionalByChainResponse_Entry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	// Hex-encoded (leading 0x optional) addresses of the guardians whose heartbeats are returned. All heartbeats are returned
+	// if empty.
+	GuardianAddrs []string `protobuf:"bytes,1,rep,name=guardian_addrs,json=guardianAddrs,proto3" json:"guardian_addrs,omitempty"`
 }
 
-func (x *GovernorGetAvailableNotionalByChainResponse) Reset() {
-	*x = GovernorGetAvailableNotionalByChainResponse{}
+func (x *GetLastHeartbeatsRequest) Reset() {
+	*x = GetLastHeartbeatsRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -513,13 +601,13 @@ func (x *GovernorGetAvailableNotionalByChainResponse) Reset() {
 	}
 }
 
-func (x *GovernorGetAvailableNotionalByChainResponse) String() string {
+func (x *GetLastHeartbeatsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GovernorGetAvailableNotionalByChainResponse) ProtoMessage() {}
+func (*GetLastHeartbeatsRequest) ProtoMessage() {}
 
-func (x *GovernorGetAvailableNotionalByChainResponse) ProtoReflect() protoreflect.Message {
+func (x *GetLastHeartbeatsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -531,26 +619,28 @@ func (x *GovernorGetAvailableNotionalByChainResponse) ProtoReflect() protoreflec
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GovernorGetAvailableNotionalByChainResponse.ProtoReflect.Descriptor instead.
-func (*GovernorGetAvailableNotionalByChainResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetLastHeartbeatsRequest.ProtoReflect.Descriptor instead.
+func (*GetLastHeartbeatsRequest) Descriptor() ([]byte, []int) {
 	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *GovernorGetAvailableNotionalByChainResponse) GetEntries() []*GovernorGetAvailableNotionalByChainResponse_Entry {
+func (x *GetLastHeartbeatsRequest) GetGuardianAddrs() []string {
 	if x != nil {
-		return x.Entries
+		return x.GuardianAddrs
 	}
 	return nil
 }
 
-type GovernorGetEnqueuedVAAsRequest struct {
+type GetLastHeartbeatsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Entries []*GetLastHeartbeatsResponse_Entry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
 }
 
-func (x *GovernorGetEnqueuedVAAsRequest) Reset() {
-	*x = GovernorGetEnqueuedVAAsRequest{}
+func (x *GetLastHeartbeatsResponse) Reset() {
+	*x = GetLastHeartbeatsResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -558,13 +648,13 @@ func (x *GovernorGetEnqueuedVAAsRequest) Reset() {
 	}
 }
 
-func (x *GovernorGetEnqueuedVAAsRequest) String() string {
+func (x *GetLastHeartbeatsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GovernorGetEnqueuedVAAsRequest) ProtoMessage() {}
+func (*GetLastHeartbeatsResponse) ProtoMessage() {}
 
-func (x *GovernorGetEnqueuedVAAsRequest) ProtoReflect() protoreflect.Message {
+func (x *GetLastHeartbeatsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -576,22 +666,26 @@ func (x *GovernorGetEnqueuedVAAsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GovernorGetEnqueuedVAAsRequest.ProtoReflect.Descriptor instead.
-func (*GovernorGetEnqueuedVAAsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetLastHeartbeatsResponse.ProtoReflect.Descriptor instead.
+func (*GetLastHeartbeatsResponse) Descriptor() ([]byte, []int) {
 	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{10}
 }
 
-type GovernorGetEnqueuedVAAsResponse struct {
+func (x *GetLastHeartbeatsResponse) GetEntries() []*GetLastHeartbeatsResponse_Entry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type GetCurrentGuardianSetRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	// There is an entry for each enqueued vaa.
-	Entries []*GovernorGetEnqueuedVAAsResponse_Entry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
 }
 
-func (x *GovernorGetEnqueuedVAAsResponse) Reset() {
-	*x = GovernorGetEnqueuedVAAsResponse{}
+func (x *GetCurrentGuardianSetRequest) Reset() {
+	*x = GetCurrentGuardianSetRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -599,13 +693,13 @@ func (x *GovernorGetEnqueuedVAAsResponse) Reset() {
 	}
 }
 
-func (x *GovernorGetEnqueuedVAAsResponse) String() string {
+func (x *GetCurrentGuardianSetRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GovernorGetEnqueuedVAAsResponse) ProtoMessage() {}
+func (*GetCurrentGuardianSetRequest) ProtoMessage() {}
 
-func (x *GovernorGetEnqueuedVAAsResponse) ProtoReflect() protoreflect.Message {
+func (x *GetCurrentGuardianSetRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -617,28 +711,21 @@ func (x *GovernorGetEnqueuedVAAsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GovernorGetEnqueuedVAAsResponse.ProtoReflect.Descriptor instead.
-func (*GovernorGetEnqueuedVAAsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetCurrentGuardianSetRequest.ProtoReflect.Descriptor instead.
+func (*GetCurrentGuardianSetRequest) Descriptor() ([]byte, []int) {
 	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *GovernorGetEnqueuedVAAsResponse) GetEntries() []*GovernorGetEnqueuedVAAsResponse_Entry {
-	if x != nil {
-		return x.Entries
-	}
-	return nil
-}
-
-type GovernorIsVAAEnqueuedRequest struct {
+type GetCurrentGuardianSetResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	MessageId *MessageID `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	GuardianSet *GuardianSet `protobuf:"bytes,1,opt,name=guardian_set,json=guardianSet,proto3" json:"guardian_set,omitempty"`
 }
 
-func (x *GovernorIsVAAEnqueuedRequest) Reset() {
-	*x = GovernorIsVAAEnqueuedRequest{}
+func (x *GetCurrentGuardianSetResponse) Reset() {
+	*x = GetCurrentGuardianSetResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -646,13 +733,13 @@ func (x *GovernorIsVAAEnqueuedRequest) Reset() {
 	}
 }
 
-func (x *GovernorIsVAAEnqueuedRequest) String() string {
+func (x *GetCurrentGuardianSetResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GovernorIsVAAEnqueuedRequest) ProtoMessage() {}
+func (*GetCurrentGuardianSetResponse) ProtoMessage() {}
 
-func (x *GovernorIsVAAEnqueuedRequest) ProtoReflect() protoreflect.Message {
+func (x *GetCurrentGuardianSetResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -664,28 +751,31 @@ func (x *GovernorIsVAAEnqueuedRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GovernorIsVAAEnqueuedRequest.ProtoReflect.Descriptor instead.
-func (*GovernorIsVAAEnqueuedRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetCurrentGuardianSetResponse.ProtoReflect.Descriptor instead.
+func (*GetCurrentGuardianSetResponse) Descriptor() ([]byte, []int) {
 	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *GovernorIsVAAEnqueuedRequest) GetMessageId() *MessageID {
+func (x *GetCurrentGuardianSetResponse) GetGuardianSet() *GuardianSet {
 	if x != nil {
-		return x.MessageId
+		return x.GuardianSet
 	}
 	return nil
 }
 
-type GovernorIsVAAEnqueuedResponse struct {
+type GuardianSet struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	IsEnqueued bool `protobuf:"varint,1,opt,name=is_enqueued,json=isEnqueued,proto3" json:"is_enqueued,omitempty"`
+	// Guardian set index
+	Index uint32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// List of guardian addresses as human-readable hex-encoded (leading 0x) addresses.
+	Addresses []string `protobuf:"bytes,2,rep,name=addresses,proto3" json:"addresses,omitempty"`
 }
 
-func (x *GovernorIsVAAEnqueuedResponse) Reset() {
-	*x = GovernorIsVAAEnqueuedResponse{}
+func (x *GuardianSet) Reset() {
+	*x = GuardianSet{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -693,13 +783,13 @@ func (x *GovernorIsVAAEnqueuedResponse) Reset() {
 	}
 }
 
-func (x *GovernorIsVAAEnqueuedResponse) String() string {
+func (x *GuardianSet) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GovernorIsVAAEnqueuedResponse) ProtoMessage() {}
+func (*GuardianSet) ProtoMessage() {}
 
-func (x *GovernorIsVAAEnqueuedResponse) ProtoReflect() protoreflect.Message {
+func (x *GuardianSet) ProtoReflect() protoreflect.Message {
 	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -711,41 +801,752 @@ func (x *GovernorIsVAAEnqueuedResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GovernorIsVAAEnqueuedResponse.ProtoReflect.Descriptor instead.
-func (*GovernorIsVAAEnqueuedResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GuardianSet.ProtoReflect.Descriptor instead.
+func (*GuardianSet) Descriptor() ([]byte, []int) {
 	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *GovernorIsVAAEnqueuedResponse) GetIsEnqueued() bool {
+func (x *GuardianSet) GetIndex() uint32 {
 	if x != nil {
-		return x.IsEnqueued
+		return x.Index
 	}
-	return false
+	return 0
 }
 
-type GovernorGetTokenListRequest struct {
+func (x *GuardianSet) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+type GetGuardianSetHistoryRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *GovernorGetTokenListRequest) Reset() {
+func (x *GetGuardianSetHistoryRequest) Reset() {
+	*x = GetGuardianSetHistoryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetGuardianSetHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGuardianSetHistoryRequest) ProtoMessage() {}
+
+func (x *GetGuardianSetHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGuardianSetHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetGuardianSetHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{14}
+}
+
+type GetGuardianSetHistoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Entries []*GetGuardianSetHistoryResponse_Entry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+}
+
+func (x *GetGuardianSetHistoryResponse) Reset() {
+	*x = GetGuardianSetHistoryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetGuardianSetHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGuardianSetHistoryResponse) ProtoMessage() {}
+
+func (x *GetGuardianSetHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGuardianSetHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetGuardianSetHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetGuardianSetHistoryResponse) GetEntries() []*GetGuardianSetHistoryResponse_Entry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type GovernorGetAvailableNotionalByChainRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GovernorGetAvailableNotionalByChainRequest) Reset() {
+	*x = GovernorGetAvailableNotionalByChainRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GovernorGetAvailableNotionalByChainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GovernorGetAvailableNotionalByChainRequest) ProtoMessage() {}
+
+func (x *GovernorGetAvailableNotionalByChainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GovernorGetAvailableNotionalByChainRequest.ProtoReflect.Descriptor instead.
+func (*GovernorGetAvailableNotionalByChainRequest) Descriptor() ([]byte, []int) {
+	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{16}
+}
+
+type GovernorGetAvailableNotionalByChainResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// There is an entry for each chain that is being governed.
+	// Chains that are not being governed are not listed, and assumed to be unlimited.
+	Entries []*GovernorGetAvailableNotionalByChainResponse_Entry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+}
+
+func (x *GovernorGetAvailableNotionalByChainResponse) Reset() {
+	*x = GovernorGetAvailableNotionalByChainResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GovernorGetAvailableNotionalByChainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GovernorGetAvailableNotionalByChainResponse) ProtoMessage() {}
+
+func (x *GovernorGetAvailableNotionalByChainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GovernorGetAvailableNotionalByChainResponse.ProtoReflect.Descriptor instead.
+func (*GovernorGetAvailableNotionalByChainResponse) Descriptor() ([]byte, []int) {
+	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GovernorGetAvailableNotionalByChainResponse) GetEntries() []*GovernorGetAvailableNotionalByChainResponse_Entry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type GovernorGetEnqueuedVAAsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GovernorGetEnqueuedVAAsRequest) Reset() {
+	*x = GovernorGetEnqueuedVAAsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GovernorGetEnqueuedVAAsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GovernorGetEnqueuedVAAsRequest) ProtoMessage() {}
+
+func (x *GovernorGetEnqueuedVAAsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GovernorGetEnqueuedVAAsRequest.ProtoReflect.Descriptor instead.
+func (*GovernorGetEnqueuedVAAsRequest) Descriptor() ([]byte, []int) {
+	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{18}
+}
+
+type GovernorGetEnqueuedVAAsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// There is an entry for each enqueued vaa.
+	Entries []*GovernorGetEnqueuedVAAsResponse_Entry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+}
+
+func (x *GovernorGetEnqueuedVAAsResponse) Reset() {
+	*x = GovernorGetEnqueuedVAAsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GovernorGetEnqueuedVAAsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GovernorGetEnqueuedVAAsResponse) ProtoMessage() {}
+
+func (x *GovernorGetEnqueuedVAAsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GovernorGetEnqueuedVAAsResponse.ProtoReflect.Descriptor instead.
+func (*GovernorGetEnqueuedVAAsResponse) Descriptor() ([]byte, []int) {
+	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GovernorGetEnqueuedVAAsResponse) GetEntries() []*GovernorGetEnqueuedVAAsResponse_Entry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type GovernorIsVAAEnqueuedRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MessageId *MessageID `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+}
+
+func (x *GovernorIsVAAEnqueuedRequest) Reset() {
+	*x = GovernorIsVAAEnqueuedRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GovernorIsVAAEnqueuedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GovernorIsVAAEnqueuedRequest) ProtoMessage() {}
+
+func (x *GovernorIsVAAEnqueuedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GovernorIsVAAEnqueuedRequest.ProtoReflect.Descriptor instead.
+func (*GovernorIsVAAEnqueuedRequest) Descriptor() ([]byte, []int) {
+	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GovernorIsVAAEnqueuedRequest) GetMessageId() *MessageID {
+	if x != nil {
+		return x.MessageId
+	}
+	return nil
+}
+
+type GovernorIsVAAEnqueuedResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IsEnqueued bool `protobuf:"varint,1,opt,name=is_enqueued,json=isEnqueued,proto3" json:"is_enqueued,omitempty"`
+}
+
+func (x *GovernorIsVAAEnqueuedResponse) Reset() {
+	*x = GovernorIsVAAEnqueuedResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GovernorIsVAAEnqueuedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GovernorIsVAAEnqueuedResponse) ProtoMessage() {}
+
+func (x *GovernorIsVAAEnqueuedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GovernorIsVAAEnqueuedResponse.ProtoReflect.Descriptor instead.
+func (*GovernorIsVAAEnqueuedResponse) Descriptor() ([]byte, []int) {
+	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GovernorIsVAAEnqueuedResponse) GetIsEnqueued() bool {
+	if x != nil {
+		return x.IsEnqueued
+	}
+	return false
+}
+
+type GovernorGetQueuePositionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The transfer is given by either its message ID or its digest.
+	MessageId *MessageID `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	// Hex-encoded (without leading 0x) signing digest of the VAA of the transfer.
+	Digest string `protobuf:"bytes,2,opt,name=digest,proto3" json:"digest,omitempty"`
+}
+
+func (x *GovernorGetQueuePositionRequest) Reset() {
+	*x = GovernorGetQueuePositionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GovernorGetQueuePositionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GovernorGetQueuePositionRequest) ProtoMessage() {}
+
+func (x *GovernorGetQueuePositionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GovernorGetQueuePositionRequest.ProtoReflect.Descriptor instead.
+func (*GovernorGetQueuePositionRequest) Descriptor() ([]byte, []int) {
+	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GovernorGetQueuePositionRequest) GetMessageId() *MessageID {
+	if x != nil {
+		return x.MessageId
+	}
+	return nil
+}
+
+func (x *GovernorGetQueuePositionRequest) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+type GovernorGetQueuePositionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IsEnqueued bool `protobuf:"varint,1,opt,name=is_enqueued,json=isEnqueued,proto3" json:"is_enqueued,omitempty"`
+	// The other fields are only set if the transfer is enqueued.
+	MessageId *MessageID `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	// Position of the transfer in the queue of its emitter chain, starting at 1. Transfers are released in queue order when the
+	// daily limit of the chain allows.
+	Position uint32 `protobuf:"varint,3,opt,name=position,proto3" json:"position,omitempty"`
+	// Number of transfers in the queue of the emitter chain.
+	QueueLength uint32 `protobuf:"varint,4,opt,name=queue_length,json=queueLength,proto3" json:"queue_length,omitempty"`
+	// Number of seconds since the message of the transfer was observed.
+	WaitSeconds uint64 `protobuf:"varint,5,opt,name=wait_seconds,json=waitSeconds,proto3" json:"wait_seconds,omitempty"`
+	// Why the transfer was enqueued, one of "daily_limit", "big_transaction" or "denylist".
+	Reason        string `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	NotionalValue uint64 `protobuf:"varint,7,opt,name=notional_value,json=notionalValue,proto3" json:"notional_value,omitempty"`
+	// Time (unix seconds) at which the transfer is released regardless of the daily limit.
+	ReleaseTime uint32 `protobuf:"varint,8,opt,name=release_time,json=releaseTime,proto3" json:"release_time,omitempty"`
+	// Time (unix seconds) at which the transfer is expected to be released if no other transfers are made on its chain meanwhile.
+	// Zero if the transfer is held for review, in which case only the guardians can release it.
+	EstimatedReleaseTime uint32 `protobuf:"varint,9,opt,name=estimated_release_time,json=estimatedReleaseTime,proto3" json:"estimated_release_time,omitempty"`
+}
+
+func (x *GovernorGetQueuePositionResponse) Reset() {
+	*x = GovernorGetQueuePositionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GovernorGetQueuePositionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GovernorGetQueuePositionResponse) ProtoMessage() {}
+
+func (x *GovernorGetQueuePositionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GovernorGetQueuePositionResponse.ProtoReflect.Descriptor instead.
+func (*GovernorGetQueuePositionResponse) Descriptor() ([]byte, []int) {
+	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GovernorGetQueuePositionResponse) GetIsEnqueued() bool {
+	if x != nil {
+		return x.IsEnqueued
+	}
+	return false
+}
+
+func (x *GovernorGetQueuePositionResponse) GetMessageId() *MessageID {
+	if x != nil {
+		return x.MessageId
+	}
+	return nil
+}
+
+func (x *GovernorGetQueuePositionResponse) GetPosition() uint32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *GovernorGetQueuePositionResponse) GetQueueLength() uint32 {
+	if x != nil {
+		return x.QueueLength
+	}
+	return 0
+}
+
+func (x *GovernorGetQueuePositionResponse) GetWaitSeconds() uint64 {
+	if x != nil {
+		return x.WaitSeconds
+	}
+	return 0
+}
+
+func (x *GovernorGetQueuePositionResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *GovernorGetQueuePositionResponse) GetNotionalValue() uint64 {
+	if x != nil {
+		return x.NotionalValue
+	}
+	return 0
+}
+
+func (x *GovernorGetQueuePositionResponse) GetReleaseTime() uint32 {
+	if x != nil {
+		return x.ReleaseTime
+	}
+	return 0
+}
+
+func (x *GovernorGetQueuePositionResponse) GetEstimatedReleaseTime() uint32 {
+	if x != nil {
+		return x.EstimatedReleaseTime
+	}
+	return 0
+}
+
+type GovernorGetTokenListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GovernorGetTokenListRequest) Reset() {
 	*x = GovernorGetTokenListRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[14]
+		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GovernorGetTokenListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GovernorGetTokenListRequest) ProtoMessage() {}
+
+func (x *GovernorGetTokenListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GovernorGetTokenListRequest.ProtoReflect.Descriptor instead.
+func (*GovernorGetTokenListRequest) Descriptor() ([]byte, []int) {
+	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{24}
+}
+
+type GovernorGetTokenListResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// There is an entry for each token that applies to the notional TVL calcuation.
+	Entries []*GovernorGetTokenListResponse_Entry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+}
+
+func (x *GovernorGetTokenListResponse) Reset() {
+	*x = GovernorGetTokenListResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GovernorGetTokenListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GovernorGetTokenListResponse) ProtoMessage() {}
+
+func (x *GovernorGetTokenListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GovernorGetTokenListResponse.ProtoReflect.Descriptor instead.
+func (*GovernorGetTokenListResponse) Descriptor() ([]byte, []int) {
+	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GovernorGetTokenListResponse) GetEntries() []*GovernorGetTokenListResponse_Entry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type GovernorGetNetworkStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GovernorGetNetworkStatusRequest) Reset() {
+	*x = GovernorGetNetworkStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GovernorGetNetworkStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GovernorGetNetworkStatusRequest) ProtoMessage() {}
+
+func (x *GovernorGetNetworkStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GovernorGetNetworkStatusRequest.ProtoReflect.Descriptor instead.
+func (*GovernorGetNetworkStatusRequest) Descriptor() ([]byte, []int) {
+	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{26}
+}
+
+type GovernorGetNetworkStatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// There is an entry for each guardian a status was received from recently.
+	Entries []*GovernorGetNetworkStatusResponse_Entry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+}
+
+func (x *GovernorGetNetworkStatusResponse) Reset() {
+	*x = GovernorGetNetworkStatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GovernorGetNetworkStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GovernorGetNetworkStatusResponse) ProtoMessage() {}
+
+func (x *GovernorGetNetworkStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GovernorGetNetworkStatusResponse.ProtoReflect.Descriptor instead.
+func (*GovernorGetNetworkStatusResponse) Descriptor() ([]byte, []int) {
+	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GovernorGetNetworkStatusResponse) GetEntries() []*GovernorGetNetworkStatusResponse_Entry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type SubscribeSignedVAARequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The VAAs matching any of the filters are streamed. If empty, all VAAs are streamed.
+	Filters []*SubscribeSignedVAARequest_Filter `protobuf:"bytes,1,rep,name=filters,proto3" json:"filters,omitempty"`
+}
+
+func (x *SubscribeSignedVAARequest) Reset() {
+	*x = SubscribeSignedVAARequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GovernorGetTokenListRequest) String() string {
+func (x *SubscribeSignedVAARequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GovernorGetTokenListRequest) ProtoMessage() {}
+func (*SubscribeSignedVAARequest) ProtoMessage() {}
 
-func (x *GovernorGetTokenListRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[14]
+func (x *SubscribeSignedVAARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -756,37 +1557,43 @@ func (x *GovernorGetTokenListRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GovernorGetTokenListRequest.ProtoReflect.Descriptor instead.
-func (*GovernorGetTokenListRequest) Descriptor() ([]byte, []int) {
-	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use SubscribeSignedVAARequest.ProtoReflect.Descriptor instead.
+func (*SubscribeSignedVAARequest) Descriptor() ([]byte, []int) {
+	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{28}
 }
 
-type GovernorGetTokenListResponse struct {
+func (x *SubscribeSignedVAARequest) GetFilters() []*SubscribeSignedVAARequest_Filter {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+type SubscribeSignedVAAResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// There is an entry for each token that applies to the notional TVL calcuation.
-	Entries []*GovernorGetTokenListResponse_Entry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	VaaBytes []byte `protobuf:"bytes,1,opt,name=vaa_bytes,json=vaaBytes,proto3" json:"vaa_bytes,omitempty"`
 }
 
-func (x *GovernorGetTokenListResponse) Reset() {
-	*x = GovernorGetTokenListResponse{}
+func (x *SubscribeSignedVAAResponse) Reset() {
+	*x = SubscribeSignedVAAResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[15]
+		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GovernorGetTokenListResponse) String() string {
+func (x *SubscribeSignedVAAResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GovernorGetTokenListResponse) ProtoMessage() {}
+func (*SubscribeSignedVAAResponse) ProtoMessage() {}
 
-func (x *GovernorGetTokenListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[15]
+func (x *SubscribeSignedVAAResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -797,14 +1604,14 @@ func (x *GovernorGetTokenListResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GovernorGetTokenListResponse.ProtoReflect.Descriptor instead.
-func (*GovernorGetTokenListResponse) Descriptor() ([]byte, []int) {
-	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use SubscribeSignedVAAResponse.ProtoReflect.Descriptor instead.
+func (*SubscribeSignedVAAResponse) Descriptor() ([]byte, []int) {
+	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *GovernorGetTokenListResponse) GetEntries() []*GovernorGetTokenListResponse_Entry {
+func (x *SubscribeSignedVAAResponse) GetVaaBytes() []byte {
 	if x != nil {
-		return x.Entries
+		return x.VaaBytes
 	}
 	return nil
 }
@@ -824,12 +1631,14 @@ type GetLastHeartbeatsResponse_Entry struct {
 	// Raw heartbeat received from the network. Data is only as trusted
 	// as the guardian node that sent it - none of the fields are verified.
 	RawHeartbeat *v1.Heartbeat `protobuf:"bytes,3,opt,name=raw_heartbeat,json=rawHeartbeat,proto3" json:"raw_heartbeat,omitempty"`
+	// Status of each network watched by the node, taken from the heartbeat.
+	Networks []*GetLastHeartbeatsResponse_Network `protobuf:"bytes,4,rep,name=networks,proto3" json:"networks,omitempty"`
 }
 
 func (x *GetLastHeartbeatsResponse_Entry) Reset() {
 	*x = GetLastHeartbeatsResponse_Entry{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[16]
+		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -842,7 +1651,7 @@ func (x *GetLastHeartbeatsResponse_Entry) String() string {
 func (*GetLastHeartbeatsResponse_Entry) ProtoMessage() {}
 
 func (x *GetLastHeartbeatsResponse_Entry) ProtoReflect() protoreflect.Message {
-	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[16]
+	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -855,7 +1664,7 @@ func (x *GetLastHeartbeatsResponse_Entry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetLastHeartbeatsResponse_Entry.ProtoReflect.Descriptor instead.
 func (*GetLastHeartbeatsResponse_Entry) Descriptor() ([]byte, []int) {
-	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{4, 0}
+	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{10, 0}
 }
 
 func (x *GetLastHeartbeatsResponse_Entry) GetVerifiedGuardianAddr() string {
@@ -879,6 +1688,192 @@ func (x *GetLastHeartbeatsResponse_Entry) GetRawHeartbeat() *v1.Heartbeat {
 	return nil
 }
 
+func (x *GetLastHeartbeatsResponse_Entry) GetNetworks() []*GetLastHeartbeatsResponse_Network {
+	if x != nil {
+		return x.Networks
+	}
+	return nil
+}
+
+type GetLastHeartbeatsResponse_Network struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ChainId ChainID `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3,enum=publicrpc.v1.ChainID" json:"chain_id,omitempty"`
+	// Consensus height of the node.
+	Height int64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	// Safe block height of the node, zero if not supported.
+	SafeHeight int64 `protobuf:"varint,3,opt,name=safe_height,json=safeHeight,proto3" json:"safe_height,omitempty"`
+	// Finalized block height of the node, zero if not supported.
+	FinalizedHeight int64 `protobuf:"varint,4,opt,name=finalized_height,json=finalizedHeight,proto3" json:"finalized_height,omitempty"`
+	// Number of errors encountered by the watcher.
+	ErrorCount uint64 `protobuf:"varint,5,opt,name=error_count,json=errorCount,proto3" json:"error_count,omitempty"`
+	// How many blocks height is behind the highest height reported for the network by any node, zero for the highest.
+	HeightLag int64 `protobuf:"varint,6,opt,name=height_lag,json=heightLag,proto3" json:"height_lag,omitempty"`
+	// How many blocks finalized_height is behind the highest finalized height reported for the network by any node, zero if the
+	// node doesn't report a finalized height.
+	FinalizedHeightLag int64 `protobuf:"varint,7,opt,name=finalized_height_lag,json=finalizedHeightLag,proto3" json:"finalized_height_lag,omitempty"`
+}
+
+func (x *GetLastHeartbeatsResponse_Network) Reset() {
+	*x = GetLastHeartbeatsResponse_Network{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLastHeartbeatsResponse_Network) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLastHeartbeatsResponse_Network) ProtoMessage() {}
+
+func (x *GetLastHeartbeatsResponse_Network) ProtoReflect() protoreflect.Message {
+	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLastHeartbeatsResponse_Network.ProtoReflect.Descriptor instead.
+func (*GetLastHeartbeatsResponse_Network) Descriptor() ([]byte, []int) {
+	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{10, 1}
+}
+
+func (x *GetLastHeartbeatsResponse_Network) GetChainId() ChainID {
+	if x != nil {
+		return x.ChainId
+	}
+	return ChainID_CHAIN_ID_UNSPECIFIED
+}
+
+func (x *GetLastHeartbeatsResponse_Network) GetHeight() int64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *GetLastHeartbeatsResponse_Network) GetSafeHeight() int64 {
+	if x != nil {
+		return x.SafeHeight
+	}
+	return 0
+}
+
+func (x *GetLastHeartbeatsResponse_Network) GetFinalizedHeight() int64 {
+	if x != nil {
+		return x.FinalizedHeight
+	}
+	return 0
+}
+
+func (x *GetLastHeartbeatsResponse_Network) GetErrorCount() uint64 {
+	if x != nil {
+		return x.ErrorCount
+	}
+	return 0
+}
+
+func (x *GetLastHeartbeatsResponse_Network) GetHeightLag() int64 {
+	if x != nil {
+		return x.HeightLag
+	}
+	return 0
+}
+
+func (x *GetLastHeartbeatsResponse_Network) GetFinalizedHeightLag() int64 {
+	if x != nil {
+		return x.FinalizedHeightLag
+	}
+	return 0
+}
+
+type GetGuardianSetHistoryResponse_Entry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GuardianSet *GuardianSet `protobuf:"bytes,1,opt,name=guardian_set,json=guardianSet,proto3" json:"guardian_set,omitempty"`
+	// Timestamp (unix seconds) of the guardian set update VAA which installed the guardian set, zero if it is not known. This is
+	// when governance signed the upgrade, not when it was submitted to the core contract, which is not recorded in the VAA.
+	UpdateVaaTimestamp uint32 `protobuf:"varint,2,opt,name=update_vaa_timestamp,json=updateVaaTimestamp,proto3" json:"update_vaa_timestamp,omitempty"`
+	// Earliest time (unix seconds) after which the core contracts may stop accepting VAAs signed by the guardian set: 24 hours
+	// after the timestamp of the next update VAA. The core contract starts the 24 hours when the next set is installed, which
+	// can only be later. Zero for the current guardian set and when the next update VAA is not known.
+	ExpirationTime uint32 `protobuf:"varint,3,opt,name=expiration_time,json=expirationTime,proto3" json:"expiration_time,omitempty"`
+	// Serialized guardian set update VAA which installed the guardian set, empty if it is not known.
+	UpdateVaa []byte `protobuf:"bytes,4,opt,name=update_vaa,json=updateVaa,proto3" json:"update_vaa,omitempty"`
+}
+
+func (x *GetGuardianSetHistoryResponse_Entry) Reset() {
+	*x = GetGuardianSetHistoryResponse_Entry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetGuardianSetHistoryResponse_Entry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGuardianSetHistoryResponse_Entry) ProtoMessage() {}
+
+func (x *GetGuardianSetHistoryResponse_Entry) ProtoReflect() protoreflect.Message {
+	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGuardianSetHistoryResponse_Entry.ProtoReflect.Descriptor instead.
+func (*GetGuardianSetHistoryResponse_Entry) Descriptor() ([]byte, []int) {
+	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{15, 0}
+}
+
+func (x *GetGuardianSetHistoryResponse_Entry) GetGuardianSet() *GuardianSet {
+	if x != nil {
+		return x.GuardianSet
+	}
+	return nil
+}
+
+func (x *GetGuardianSetHistoryResponse_Entry) GetUpdateVaaTimestamp() uint32 {
+	if x != nil {
+		return x.UpdateVaaTimestamp
+	}
+	return 0
+}
+
+func (x *GetGuardianSetHistoryResponse_Entry) GetExpirationTime() uint32 {
+	if x != nil {
+		return x.ExpirationTime
+	}
+	return 0
+}
+
+func (x *GetGuardianSetHistoryResponse_Entry) GetUpdateVaa() []byte {
+	if x != nil {
+		return x.UpdateVaa
+	}
+	return nil
+}
+
 type GovernorGetAvailableNotionalByChainResponse_Entry struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -893,7 +1888,7 @@ type GovernorGetAvailableNotionalByChainResponse_Entry struct {
 func (x *GovernorGetAvailableNotionalByChainResponse_Entry) Reset() {
 	*x = GovernorGetAvailableNotionalByChainResponse_Entry{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[17]
+		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -906,7 +1901,7 @@ func (x *GovernorGetAvailableNotionalByChainResponse_Entry) String() string {
 func (*GovernorGetAvailableNotionalByChainResponse_Entry) ProtoMessage() {}
 
 func (x *GovernorGetAvailableNotionalByChainResponse_Entry) ProtoReflect() protoreflect.Message {
-	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[17]
+	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -919,7 +1914,7 @@ func (x *GovernorGetAvailableNotionalByChainResponse_Entry) ProtoReflect() proto
 
 // Deprecated: Use GovernorGetAvailableNotionalByChainResponse_Entry.ProtoReflect.Descriptor instead.
 func (*GovernorGetAvailableNotionalByChainResponse_Entry) Descriptor() ([]byte, []int) {
-	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{9, 0}
+	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{17, 0}
 }
 
 func (x *GovernorGetAvailableNotionalByChainResponse_Entry) GetChainId() uint32 {
@@ -966,7 +1961,7 @@ type GovernorGetEnqueuedVAAsResponse_Entry struct {
 func (x *GovernorGetEnqueuedVAAsResponse_Entry) Reset() {
 	*x = GovernorGetEnqueuedVAAsResponse_Entry{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[18]
+		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -979,7 +1974,7 @@ func (x *GovernorGetEnqueuedVAAsResponse_Entry) String() string {
 func (*GovernorGetEnqueuedVAAsResponse_Entry) ProtoMessage() {}
 
 func (x *GovernorGetEnqueuedVAAsResponse_Entry) ProtoReflect() protoreflect.Message {
-	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[18]
+	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -992,7 +1987,7 @@ func (x *GovernorGetEnqueuedVAAsResponse_Entry) ProtoReflect() protoreflect.Mess
 
 // Deprecated: Use GovernorGetEnqueuedVAAsResponse_Entry.ProtoReflect.Descriptor instead.
 func (*GovernorGetEnqueuedVAAsResponse_Entry) Descriptor() ([]byte, []int) {
-	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{11, 0}
+	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{19, 0}
 }
 
 func (x *GovernorGetEnqueuedVAAsResponse_Entry) GetEmitterChain() uint32 {
@@ -1037,33 +2032,154 @@ func (x *GovernorGetEnqueuedVAAsResponse_Entry) GetTxHash() string {
 	return ""
 }
 
-type GovernorGetTokenListResponse_Entry struct {
+type GovernorGetTokenListResponse_Entry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OriginChainId uint32  `protobuf:"varint,1,opt,name=origin_chain_id,json=originChainId,proto3" json:"origin_chain_id,omitempty"`
+	OriginAddress string  `protobuf:"bytes,2,opt,name=origin_address,json=originAddress,proto3" json:"origin_address,omitempty"` // human-readable hex-encoded (leading 0x)
+	Price         float32 `protobuf:"fixed32,3,opt,name=price,proto3" json:"price,omitempty"`
+}
+
+func (x *GovernorGetTokenListResponse_Entry) Reset() {
+	*x = GovernorGetTokenListResponse_Entry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GovernorGetTokenListResponse_Entry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GovernorGetTokenListResponse_Entry) ProtoMessage() {}
+
+func (x *GovernorGetTokenListResponse_Entry) ProtoReflect() protoreflect.Message {
+	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GovernorGetTokenListResponse_Entry.ProtoReflect.Descriptor instead.
+func (*GovernorGetTokenListResponse_Entry) Descriptor() ([]byte, []int) {
+	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{25, 0}
+}
+
+func (x *GovernorGetTokenListResponse_Entry) GetOriginChainId() uint32 {
+	if x != nil {
+		return x.OriginChainId
+	}
+	return 0
+}
+
+func (x *GovernorGetTokenListResponse_Entry) GetOriginAddress() string {
+	if x != nil {
+		return x.OriginAddress
+	}
+	return ""
+}
+
+func (x *GovernorGetTokenListResponse_Entry) GetPrice() float32 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+type GovernorGetNetworkStatusResponse_Entry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Verified, hex-encoded (with leading 0x) guardian address which signed this status.
+	VerifiedGuardianAddr string `protobuf:"bytes,1,opt,name=verified_guardian_addr,json=verifiedGuardianAddr,proto3" json:"verified_guardian_addr,omitempty"`
+	// Status received from the network. Only the signature is verified, the content is as trusted as the guardian that sent it.
+	Status *v1.ChainGovernorStatus `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *GovernorGetNetworkStatusResponse_Entry) Reset() {
+	*x = GovernorGetNetworkStatusResponse_Entry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GovernorGetNetworkStatusResponse_Entry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GovernorGetNetworkStatusResponse_Entry) ProtoMessage() {}
+
+func (x *GovernorGetNetworkStatusResponse_Entry) ProtoReflect() protoreflect.Message {
+	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GovernorGetNetworkStatusResponse_Entry.ProtoReflect.Descriptor instead.
+func (*GovernorGetNetworkStatusResponse_Entry) Descriptor() ([]byte, []int) {
+	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{27, 0}
+}
+
+func (x *GovernorGetNetworkStatusResponse_Entry) GetVerifiedGuardianAddr() string {
+	if x != nil {
+		return x.VerifiedGuardianAddr
+	}
+	return ""
+}
+
+func (x *GovernorGetNetworkStatusResponse_Entry) GetStatus() *v1.ChainGovernorStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type SubscribeSignedVAARequest_Filter struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	OriginChainId uint32  `protobuf:"varint,1,opt,name=origin_chain_id,json=originChainId,proto3" json:"origin_chain_id,omitempty"`
-	OriginAddress string  `protobuf:"bytes,2,opt,name=origin_address,json=originAddress,proto3" json:"origin_address,omitempty"` // human-readable hex-encoded (leading 0x)
-	Price         float32 `protobuf:"fixed32,3,opt,name=price,proto3" json:"price,omitempty"`
+	// Emitter chain ID.
+	EmitterChain ChainID `protobuf:"varint,1,opt,name=emitter_chain,json=emitterChain,proto3,enum=publicrpc.v1.ChainID" json:"emitter_chain,omitempty"`
+	// Hex-encoded (without leading 0x) emitter address. Empty matches every emitter on emitter_chain.
+	EmitterAddress string `protobuf:"bytes,2,opt,name=emitter_address,json=emitterAddress,proto3" json:"emitter_address,omitempty"`
 }
 
-func (x *GovernorGetTokenListResponse_Entry) Reset() {
-	*x = GovernorGetTokenListResponse_Entry{}
+func (x *SubscribeSignedVAARequest_Filter) Reset() {
+	*x = SubscribeSignedVAARequest_Filter{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[19]
+		mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GovernorGetTokenListResponse_Entry) String() string {
+func (x *SubscribeSignedVAARequest_Filter) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GovernorGetTokenListResponse_Entry) ProtoMessage() {}
+func (*SubscribeSignedVAARequest_Filter) ProtoMessage() {}
 
-func (x *GovernorGetTokenListResponse_Entry) ProtoReflect() protoreflect.Message {
-	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[19]
+func (x *SubscribeSignedVAARequest_Filter) ProtoReflect() protoreflect.Message {
+	mi := &file_publicrpc_v1_publicrpc_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1074,32 +2190,25 @@ func (x *GovernorGetTokenListResponse_Entry) ProtoReflect() protoreflect.Message
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GovernorGetTokenListResponse_Entry.ProtoReflect.Descriptor instead.
-func (*GovernorGetTokenListResponse_Entry) Descriptor() ([]byte, []int) {
-	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{15, 0}
+// Deprecated: Use SubscribeSignedVAARequest_Filter.ProtoReflect.Descriptor instead.
+func (*SubscribeSignedVAARequest_Filter) Descriptor() ([]byte, []int) {
+	return file_publicrpc_v1_publicrpc_proto_rawDescGZIP(), []int{28, 0}
 }
 
-func (x *GovernorGetTokenListResponse_Entry) GetOriginChainId() uint32 {
+func (x *SubscribeSignedVAARequest_Filter) GetEmitterChain() ChainID {
 	if x != nil {
-		return x.OriginChainId
+		return x.EmitterChain
 	}
-	return 0
+	return ChainID_CHAIN_ID_UNSPECIFIED
 }
 
-func (x *GovernorGetTokenListResponse_Entry) GetOriginAddress() string {
+func (x *SubscribeSignedVAARequest_Filter) GetEmitterAddress() string {
 	if x != nil {
-		return x.OriginAddress
+		return x.EmitterAddress
 	}
 	return ""
 }
 
-func (x *GovernorGetTokenListResponse_Entry) GetPrice() float32 {
-	if x != nil {
-		return x.Price
-	}
-	return 0
-}
-
 var File_publicrpc_v1_publicrpc_proto protoreflect.FileDescriptor
 
 var file_publicrpc_v1_publicrpc_proto_rawDesc = []byte{
@@ -1109,6 +2218,9 @@ var file_publicrpc_v1_publicrpc_proto_rawDesc = []byte{
 	0x73, 0x73, 0x69, 0x70, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x2e, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69,
 	0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x6f,
+	0x70, 0x65, 0x6e, 0x61, 0x70, 0x69, 0x76, 0x32, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x22, 0x8c, 0x01, 0x0a, 0x09, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x44,
 	0x12, 0x3a, 0x0a, 0x0d, 0x65, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x72, 0x5f, 0x63, 0x68, 0x61, 0x69,
 	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63,
@@ -1126,37 +2238,126 @@ var file_publicrpc_v1_publicrpc_proto_rawDesc = []byte{
 	0x22, 0x33, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x56, 0x41, 0x41,
 	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x76, 0x61, 0x61, 0x5f,
 	0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x76, 0x61, 0x61,
-	0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0x1a, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x73, 0x74,
-	0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x22, 0x83, 0x02, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x73, 0x74, 0x48, 0x65, 0x61,
-	0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x47, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x2d, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0x72, 0x0a, 0x1b, 0x47, 0x65, 0x74, 0x53, 0x69, 0x67, 0x6e,
+	0x65, 0x64, 0x56, 0x41, 0x41, 0x42, 0x79, 0x54, 0x78, 0x48, 0x61, 0x73, 0x68, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x3a, 0x0a, 0x0d, 0x65, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x72, 0x5f,
+	0x63, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e,
+	0x49, 0x44, 0x52, 0x0c, 0x65, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x72, 0x43, 0x68, 0x61, 0x69, 0x6e,
+	0x12, 0x17, 0x0a, 0x07, 0x74, 0x78, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x74, 0x78, 0x48, 0x61, 0x73, 0x68, 0x22, 0x3b, 0x0a, 0x1c, 0x47, 0x65, 0x74,
+	0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x56, 0x41, 0x41, 0x42, 0x79, 0x54, 0x78, 0x48, 0x61, 0x73,
+	0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x76, 0x61, 0x61,
+	0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x08, 0x76, 0x61,
+	0x61, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0x54, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x53, 0x69, 0x67,
+	0x6e, 0x65, 0x64, 0x56, 0x41, 0x41, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x38, 0x0a, 0x0b, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x64,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63,
+	0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x44,
+	0x52, 0x0a, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x64, 0x73, 0x22, 0x38, 0x0a, 0x19,
+	0x47, 0x65, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x56, 0x41, 0x41, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x76, 0x61, 0x61,
+	0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x08, 0x76, 0x61,
+	0x61, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0x8a, 0x02, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x53, 0x69,
+	0x67, 0x6e, 0x65, 0x64, 0x56, 0x41, 0x41, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x3a, 0x0a, 0x0d, 0x65, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x72, 0x5f, 0x63,
+	0x68, 0x61, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x70, 0x75, 0x62,
+	0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x49,
+	0x44, 0x52, 0x0c, 0x65, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x72, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x12,
+	0x27, 0x0a, 0x0f, 0x65, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x72, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x65, 0x6d, 0x69, 0x74, 0x74, 0x65,
+	0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x72, 0x6f, 0x6d,
+	0x5f, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0c, 0x66, 0x72, 0x6f, 0x6d, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x1f, 0x0a,
+	0x0b, 0x74, 0x6f, 0x5f, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0a, 0x74, 0x6f, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x12, 0x2d, 0x0a, 0x12, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x11, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x22, 0x67, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64,
+	0x56, 0x41, 0x41, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x1b, 0x0a, 0x09, 0x76, 0x61, 0x61, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0c, 0x52, 0x08, 0x76, 0x61, 0x61, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x2d, 0x0a,
+	0x12, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x63, 0x6f, 0x6e, 0x74, 0x69,
+	0x6e, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x41, 0x0a, 0x18,
 	0x47, 0x65, 0x74, 0x4c, 0x61, 0x73, 0x74, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
-	0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x1a, 0x9c, 0x01, 0x0a, 0x05, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x12, 0x34, 0x0a, 0x16, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x5f, 0x67,
-	0x75, 0x61, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x14, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x47, 0x75, 0x61, 0x72,
-	0x64, 0x69, 0x61, 0x6e, 0x41, 0x64, 0x64, 0x72, 0x12, 0x22, 0x0a, 0x0d, 0x70, 0x32, 0x70, 0x5f,
-	0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0b, 0x70, 0x32, 0x70, 0x4e, 0x6f, 0x64, 0x65, 0x41, 0x64, 0x64, 0x72, 0x12, 0x39, 0x0a, 0x0d,
-	0x72, 0x61, 0x77, 0x5f, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x2e, 0x76, 0x31, 0x2e,
-	0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x0c, 0x72, 0x61, 0x77, 0x48, 0x65,
-	0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x22, 0x1e, 0x0a, 0x1c, 0x47, 0x65, 0x74, 0x43, 0x75,
-	0x72, 0x72, 0x65, 0x6e, 0x74, 0x47, 0x75, 0x61, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x53, 0x65, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x5d, 0x0a, 0x1d, 0x47, 0x65, 0x74, 0x43, 0x75,
-	0x72, 0x72, 0x65, 0x6e, 0x74, 0x47, 0x75, 0x61, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x53, 0x65, 0x74,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0c, 0x67, 0x75, 0x61, 0x72,
-	0x64, 0x69, 0x61, 0x6e, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
-	0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x75,
-	0x61, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x53, 0x65, 0x74, 0x52, 0x0b, 0x67, 0x75, 0x61, 0x72, 0x64,
-	0x69, 0x61, 0x6e, 0x53, 0x65, 0x74, 0x22, 0x41, 0x0a, 0x0b, 0x47, 0x75, 0x61, 0x72, 0x64, 0x69,
-	0x61, 0x6e, 0x53, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1c, 0x0a, 0x09, 0x61,
-	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09,
-	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x22, 0x2c, 0x0a, 0x2a, 0x47, 0x6f, 0x76,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x67, 0x75, 0x61, 0x72,
+	0x64, 0x69, 0x61, 0x6e, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0d, 0x67, 0x75, 0x61, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x41, 0x64, 0x64, 0x72, 0x73, 0x22,
+	0xe4, 0x04, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x73, 0x74, 0x48, 0x65, 0x61, 0x72, 0x74,
+	0x62, 0x65, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a,
+	0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d,
+	0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x4c, 0x61, 0x73, 0x74, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65,
+	0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x1a, 0xe9, 0x01, 0x0a, 0x05, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x34, 0x0a, 0x16, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x5f, 0x67, 0x75, 0x61,
+	0x72, 0x64, 0x69, 0x61, 0x6e, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x14, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x47, 0x75, 0x61, 0x72, 0x64, 0x69,
+	0x61, 0x6e, 0x41, 0x64, 0x64, 0x72, 0x12, 0x22, 0x0a, 0x0d, 0x70, 0x32, 0x70, 0x5f, 0x6e, 0x6f,
+	0x64, 0x65, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70,
+	0x32, 0x70, 0x4e, 0x6f, 0x64, 0x65, 0x41, 0x64, 0x64, 0x72, 0x12, 0x39, 0x0a, 0x0d, 0x72, 0x61,
+	0x77, 0x5f, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65,
+	0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x0c, 0x72, 0x61, 0x77, 0x48, 0x65, 0x61, 0x72,
+	0x74, 0x62, 0x65, 0x61, 0x74, 0x12, 0x4b, 0x0a, 0x08, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63,
+	0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x73, 0x74, 0x48, 0x65,
+	0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52, 0x08, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72,
+	0x6b, 0x73, 0x1a, 0x91, 0x02, 0x0a, 0x07, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x30,
+	0x0a, 0x08, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x15, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x44, 0x52, 0x07, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x61, 0x66, 0x65,
+	0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x73,
+	0x61, 0x66, 0x65, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x66, 0x69, 0x6e,
+	0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0f, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x48, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x5f,
+	0x6c, 0x61, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x68, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x4c, 0x61, 0x67, 0x12, 0x30, 0x0a, 0x14, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65,
+	0x64, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x6c, 0x61, 0x67, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x12, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x48, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x4c, 0x61, 0x67, 0x22, 0x1e, 0x0a, 0x1c, 0x47, 0x65, 0x74, 0x43, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x74, 0x47, 0x75, 0x61, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x53, 0x65, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x5d, 0x0a, 0x1d, 0x47, 0x65, 0x74, 0x43, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x74, 0x47, 0x75, 0x61, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x53, 0x65, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0c, 0x67, 0x75, 0x61, 0x72, 0x64,
+	0x69, 0x61, 0x6e, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x75, 0x61,
+	0x72, 0x64, 0x69, 0x61, 0x6e, 0x53, 0x65, 0x74, 0x52, 0x0b, 0x67, 0x75, 0x61, 0x72, 0x64, 0x69,
+	0x61, 0x6e, 0x53, 0x65, 0x74, 0x22, 0x41, 0x0a, 0x0b, 0x47, 0x75, 0x61, 0x72, 0x64, 0x69, 0x61,
+	0x6e, 0x53, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x22, 0x1e, 0x0a, 0x1c, 0x47, 0x65, 0x74, 0x47,
+	0x75, 0x61, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x53, 0x65, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72,
+	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xae, 0x02, 0x0a, 0x1d, 0x47, 0x65, 0x74,
+	0x47, 0x75, 0x61, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x53, 0x65, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4b, 0x0a, 0x07, 0x65, 0x6e,
+	0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x47, 0x75,
+	0x61, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x53, 0x65, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07,
+	0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x1a, 0xbf, 0x01, 0x0a, 0x05, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x3c, 0x0a, 0x0c, 0x67, 0x75, 0x61, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x5f, 0x73, 0x65,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63,
+	0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x75, 0x61, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x53,
+	0x65, 0x74, 0x52, 0x0b, 0x67, 0x75, 0x61, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x53, 0x65, 0x74, 0x12,
+	0x30, 0x0a, 0x14, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x76, 0x61, 0x61, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x75,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x56, 0x61, 0x61, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x5f, 0x76, 0x61, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x56, 0x61, 0x61, 0x22, 0x2c, 0x0a, 0x2a, 0x47, 0x6f, 0x76,
 	0x65, 0x72, 0x6e, 0x6f, 0x72, 0x47, 0x65, 0x74, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c,
 	0x65, 0x4e, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x42, 0x79, 0x43, 0x68, 0x61, 0x69, 0x6e,
 	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xc8, 0x02, 0x0a, 0x2b, 0x47, 0x6f, 0x76, 0x65,
@@ -1212,108 +2413,251 @@ var file_publicrpc_v1_publicrpc_proto_rawDesc = []byte{
 	0x6f, 0x72, 0x49, 0x73, 0x56, 0x41, 0x41, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x52,
 	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x73, 0x5f, 0x65, 0x6e,
 	0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x73,
-	0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x22, 0x1d, 0x0a, 0x1b, 0x47, 0x6f, 0x76, 0x65,
-	0x72, 0x6e, 0x6f, 0x72, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x4c, 0x69, 0x73, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xd8, 0x01, 0x0a, 0x1c, 0x47, 0x6f, 0x76, 0x65,
-	0x72, 0x6e, 0x6f, 0x72, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x4c, 0x69, 0x73, 0x74,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4a, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72,
-	0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x70, 0x75, 0x62, 0x6c,
-	0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f,
-	0x72, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74,
-	0x72, 0x69, 0x65, 0x73, 0x1a, 0x6c, 0x0a, 0x05, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x26, 0x0a,
-	0x0f, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x5f, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x43, 0x68,
-	0x61, 0x69, 0x6e, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x5f,
-	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6f,
-	0x72, 0x69, 0x67, 0x69, 0x6e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x14, 0x0a, 0x05,
-	0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05, 0x70, 0x72, 0x69,
-	0x63, 0x65, 0x2a, 0x38, 0x0a, 0x07, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x44, 0x12, 0x18, 0x0a,
-	0x14, 0x43, 0x48, 0x41, 0x49, 0x4e, 0x5f, 0x49, 0x44, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
-	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x48, 0x41, 0x49, 0x4e,
-	0x5f, 0x49, 0x44, 0x5f, 0x53, 0x4f, 0x4c, 0x41, 0x4e, 0x41, 0x10, 0x01, 0x32, 0xc6, 0x09, 0x0a,
-	0x10, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x52, 0x50, 0x43, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x12, 0x7c, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x73, 0x74, 0x48, 0x65, 0x61, 0x72,
-	0x74, 0x62, 0x65, 0x61, 0x74, 0x73, 0x12, 0x26, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72,
-	0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x73, 0x74, 0x48, 0x65, 0x61,
-	0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27,
-	0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
-	0x74, 0x4c, 0x61, 0x73, 0x74, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x16, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x10, 0x12,
-	0x0e, 0x2f, 0x76, 0x31, 0x2f, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x73, 0x12,
-	0xbb, 0x01, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x56, 0x41, 0x41,
-	0x12, 0x21, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e,
-	0x47, 0x65, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x56, 0x41, 0x41, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e,
+	0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x22, 0x71, 0x0a, 0x1f, 0x47, 0x6f, 0x76, 0x65,
+	0x72, 0x6e, 0x6f, 0x72, 0x47, 0x65, 0x74, 0x51, 0x75, 0x65, 0x75, 0x65, 0x50, 0x6f, 0x73, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x36, 0x0a, 0x0a, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x44, 0x52, 0x09, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0xf5, 0x02, 0x0a, 0x20,
+	0x47, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f, 0x72, 0x47, 0x65, 0x74, 0x51, 0x75, 0x65, 0x75, 0x65,
+	0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x73, 0x5f, 0x65, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x73, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65,
+	0x64, 0x12, 0x36, 0x0a, 0x0a, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70,
+	0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x44, 0x52, 0x09,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x6c,
+	0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x71, 0x75, 0x65,
+	0x75, 0x65, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x21, 0x0a, 0x0c, 0x77, 0x61, 0x69, 0x74,
+	0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b,
+	0x77, 0x61, 0x69, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x6e, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x6e, 0x6f, 0x74,
+	0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65,
+	0x6c, 0x65, 0x61, 0x73, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0b, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x34, 0x0a,
+	0x16, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x6c, 0x65, 0x61,
+	0x73, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x65,
+	0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x54,
+	0x69, 0x6d, 0x65, 0x22, 0x1d, 0x0a, 0x1b, 0x47, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f, 0x72, 0x47,
+	0x65, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x22, 0xd8, 0x01, 0x0a, 0x1c, 0x47, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f, 0x72, 0x47,
+	0x65, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x4a, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f, 0x72, 0x47, 0x65, 0x74, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x2e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x1a,
+	0x6c, 0x0a, 0x05, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x26, 0x0a, 0x0f, 0x6f, 0x72, 0x69, 0x67,
+	0x69, 0x6e, 0x5f, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0d, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x64,
+	0x12, 0x25, 0x0a, 0x0e, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e,
+	0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x22, 0x21, 0x0a,
+	0x1f, 0x47, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f, 0x72, 0x47, 0x65, 0x74, 0x4e, 0x65, 0x74, 0x77,
+	0x6f, 0x72, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0xe9, 0x01, 0x0a, 0x20, 0x47, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f, 0x72, 0x47, 0x65, 0x74,
+	0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72,
+	0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f, 0x72, 0x47, 0x65,
+	0x74, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e,
+	0x74, 0x72, 0x69, 0x65, 0x73, 0x1a, 0x75, 0x0a, 0x05, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x34,
+	0x0a, 0x16, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x5f, 0x67, 0x75, 0x61, 0x72, 0x64,
+	0x69, 0x61, 0x6e, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x14,
+	0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x47, 0x75, 0x61, 0x72, 0x64, 0x69, 0x61, 0x6e,
+	0x41, 0x64, 0x64, 0x72, 0x12, 0x36, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x67, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x47, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f, 0x72, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xd4, 0x01, 0x0a,
+	0x19, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64,
+	0x56, 0x41, 0x41, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x48, 0x0a, 0x07, 0x66, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x62, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x56, 0x41, 0x41, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x07, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x73, 0x1a, 0x6d, 0x0a, 0x06, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x3a,
+	0x0a, 0x0d, 0x65, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x72, 0x5f, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70,
+	0x63, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x44, 0x52, 0x0c, 0x65, 0x6d,
+	0x69, 0x74, 0x74, 0x65, 0x72, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x6d,
+	0x69, 0x74, 0x74, 0x65, 0x72, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x65, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x22, 0x39, 0x0a, 0x1a, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65,
+	0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x56, 0x41, 0x41, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x1b, 0x0a, 0x09, 0x76, 0x61, 0x61, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x76, 0x61, 0x61, 0x42, 0x79, 0x74, 0x65, 0x73, 0x2a, 0x38,
+	0x0a, 0x07, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x14, 0x43, 0x48, 0x41,
+	0x49, 0x4e, 0x5f, 0x49, 0x44, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x48, 0x41, 0x49, 0x4e, 0x5f, 0x49, 0x44, 0x5f,
+	0x53, 0x4f, 0x4c, 0x41, 0x4e, 0x41, 0x10, 0x01, 0x32, 0x8a, 0x13, 0x0a, 0x10, 0x50, 0x75, 0x62,
+	0x6c, 0x69, 0x63, 0x52, 0x50, 0x43, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x7c, 0x0a,
+	0x11, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x73, 0x74, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61,
+	0x74, 0x73, 0x12, 0x26, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76,
+	0x31, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x73, 0x74, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65,
+	0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x70, 0x75, 0x62,
+	0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x73,
+	0x74, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x16, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x10, 0x12, 0x0e, 0x2f, 0x76, 0x31,
+	0x2f, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x73, 0x12, 0xbb, 0x01, 0x0a, 0x0c,
+	0x47, 0x65, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x56, 0x41, 0x41, 0x12, 0x21, 0x2e, 0x70,
+	0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53,
+	0x69, 0x67, 0x6e, 0x65, 0x64, 0x56, 0x41, 0x41, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x22, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x56, 0x41, 0x41, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x64, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x5e, 0x12, 0x5c, 0x2f, 0x76, 0x31,
+	0x2f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x76, 0x61, 0x61, 0x2f, 0x7b, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x2e, 0x65, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x72, 0x5f,
+	0x63, 0x68, 0x61, 0x69, 0x6e, 0x7d, 0x2f, 0x7b, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f,
+	0x69, 0x64, 0x2e, 0x65, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x72, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x7d, 0x2f, 0x7b, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x2e,
+	0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x7d, 0x12, 0xa5, 0x01, 0x0a, 0x14, 0x47, 0x65,
+	0x74, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x56, 0x41, 0x41, 0x42, 0x79, 0x54, 0x78, 0x48, 0x61,
+	0x73, 0x68, 0x12, 0x29, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76,
+	0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x56, 0x41, 0x41, 0x42, 0x79,
+	0x54, 0x78, 0x48, 0x61, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e,
+	0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
+	0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x56, 0x41, 0x41, 0x42, 0x79, 0x54, 0x78, 0x48, 0x61, 0x73,
+	0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x36, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x30, 0x12, 0x2e, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x76, 0x61,
+	0x61, 0x5f, 0x62, 0x79, 0x5f, 0x74, 0x78, 0x2f, 0x7b, 0x65, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x72,
+	0x5f, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x7d, 0x2f, 0x7b, 0x74, 0x78, 0x5f, 0x68, 0x61, 0x73, 0x68,
+	0x7d, 0x12, 0x89, 0x01, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x56,
+	0x41, 0x41, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x26, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63,
+	0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64,
+	0x56, 0x41, 0x41, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x27, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x56, 0x41, 0x41, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x23, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1d,
+	0x22, 0x18, 0x2f, 0x76, 0x31, 0x3a, 0x67, 0x65, 0x74, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64,
+	0x5f, 0x76, 0x61, 0x61, 0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x3a, 0x01, 0x2a, 0x12, 0xa4, 0x01,
+	0x0a, 0x11, 0x47, 0x65, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x56, 0x41, 0x41, 0x52, 0x61,
+	0x6e, 0x67, 0x65, 0x12, 0x26, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e,
 	0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x56, 0x41, 0x41, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x64, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x5e, 0x12,
-	0x5c, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x76, 0x61, 0x61, 0x2f,
-	0x7b, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x2e, 0x65, 0x6d, 0x69, 0x74,
-	0x74, 0x65, 0x72, 0x5f, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x7d, 0x2f, 0x7b, 0x6d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x2e, 0x65, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x72, 0x5f, 0x61,
-	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x7d, 0x2f, 0x7b, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x5f, 0x69, 0x64, 0x2e, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x7d, 0x12, 0x91, 0x01,
-	0x0a, 0x15, 0x47, 0x65, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x47, 0x75, 0x61, 0x72,
-	0x64, 0x69, 0x61, 0x6e, 0x53, 0x65, 0x74, 0x12, 0x2a, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63,
-	0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e,
-	0x74, 0x47, 0x75, 0x61, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e,
-	0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x47, 0x75, 0x61,
-	0x72, 0x64, 0x69, 0x61, 0x6e, 0x53, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x1f, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x19, 0x12, 0x17, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x75,
-	0x61, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x73, 0x65, 0x74, 0x2f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
-	0x74, 0x12, 0xcc, 0x01, 0x0a, 0x23, 0x47, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f, 0x72, 0x47, 0x65,
-	0x74, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x4e, 0x6f, 0x74, 0x69, 0x6f, 0x6e,
-	0x61, 0x6c, 0x42, 0x79, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x12, 0x38, 0x2e, 0x70, 0x75, 0x62, 0x6c,
-	0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f,
-	0x72, 0x47, 0x65, 0x74, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x4e, 0x6f, 0x74,
-	0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x42, 0x79, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x39, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e,
-	0x76, 0x31, 0x2e, 0x47, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f, 0x72, 0x47, 0x65, 0x74, 0x41, 0x76,
-	0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x4e, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x42,
-	0x79, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x30,
-	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x2a, 0x12, 0x28, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x6f, 0x76, 0x65,
-	0x72, 0x6e, 0x6f, 0x72, 0x2f, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6e,
-	0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x62, 0x79, 0x5f, 0x63, 0x68, 0x61, 0x69, 0x6e,
-	0x12, 0x9a, 0x01, 0x0a, 0x17, 0x47, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f, 0x72, 0x47, 0x65, 0x74,
-	0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x56, 0x41, 0x41, 0x73, 0x12, 0x2c, 0x2e, 0x70,
+	0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x69,
+	0x67, 0x6e, 0x65, 0x64, 0x56, 0x41, 0x41, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x3e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x38, 0x12, 0x36, 0x2f, 0x76,
+	0x31, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x76, 0x61, 0x61, 0x5f, 0x72, 0x61, 0x6e,
+	0x67, 0x65, 0x2f, 0x7b, 0x65, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x72, 0x5f, 0x63, 0x68, 0x61, 0x69,
+	0x6e, 0x7d, 0x2f, 0x7b, 0x65, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x72, 0x5f, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x7d, 0x12, 0x91, 0x01, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x43, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x74, 0x47, 0x75, 0x61, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x53, 0x65, 0x74, 0x12, 0x2a,
+	0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x47, 0x75, 0x61, 0x72, 0x64, 0x69, 0x61, 0x6e,
+	0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x70, 0x75, 0x62,
+	0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x74, 0x47, 0x75, 0x61, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x53, 0x65, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1f, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x19, 0x12,
+	0x17, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x75, 0x61, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x73, 0x65, 0x74,
+	0x2f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x91, 0x01, 0x0a, 0x15, 0x47, 0x65, 0x74,
+	0x47, 0x75, 0x61, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x53, 0x65, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x12, 0x2a, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76,
+	0x31, 0x2e, 0x47, 0x65, 0x74, 0x47, 0x75, 0x61, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x53, 0x65, 0x74,
+	0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b,
+	0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x47, 0x75, 0x61, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x53, 0x65, 0x74, 0x48, 0x69, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1f, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x19, 0x12, 0x17, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x75, 0x61, 0x72, 0x64, 0x69, 0x61,
+	0x6e, 0x73, 0x65, 0x74, 0x2f, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0xcc, 0x01, 0x0a,
+	0x23, 0x47, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f, 0x72, 0x47, 0x65, 0x74, 0x41, 0x76, 0x61, 0x69,
+	0x6c, 0x61, 0x62, 0x6c, 0x65, 0x4e, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x42, 0x79, 0x43,
+	0x68, 0x61, 0x69, 0x6e, 0x12, 0x38, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f, 0x72, 0x47, 0x65, 0x74, 0x41,
+	0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x4e, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c,
+	0x42, 0x79, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x39,
+	0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x6f,
+	0x76, 0x65, 0x72, 0x6e, 0x6f, 0x72, 0x47, 0x65, 0x74, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62,
+	0x6c, 0x65, 0x4e, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x42, 0x79, 0x43, 0x68, 0x61, 0x69,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x30, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x2a, 0x12, 0x28, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f, 0x72, 0x2f,
+	0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6e, 0x6f, 0x74, 0x69, 0x6f, 0x6e,
+	0x61, 0x6c, 0x5f, 0x62, 0x79, 0x5f, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x12, 0x9a, 0x01, 0x0a, 0x17,
+	0x47, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f, 0x72, 0x47, 0x65, 0x74, 0x45, 0x6e, 0x71, 0x75, 0x65,
+	0x75, 0x65, 0x64, 0x56, 0x41, 0x41, 0x73, 0x12, 0x2c, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63,
+	0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f, 0x72, 0x47,
+	0x65, 0x74, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x56, 0x41, 0x41, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70,
+	0x63, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f, 0x72, 0x47, 0x65, 0x74,
+	0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x56, 0x41, 0x41, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x22, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1c, 0x12, 0x1a, 0x2f, 0x76,
+	0x31, 0x2f, 0x67, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f, 0x72, 0x2f, 0x65, 0x6e, 0x71, 0x75, 0x65,
+	0x75, 0x65, 0x64, 0x5f, 0x76, 0x61, 0x61, 0x73, 0x12, 0xe4, 0x01, 0x0a, 0x15, 0x47, 0x6f, 0x76,
+	0x65, 0x72, 0x6e, 0x6f, 0x72, 0x49, 0x73, 0x56, 0x41, 0x41, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75,
+	0x65, 0x64, 0x12, 0x2a, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76,
+	0x31, 0x2e, 0x47, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f, 0x72, 0x49, 0x73, 0x56, 0x41, 0x41, 0x45,
+	0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b,
+	0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x6f,
+	0x76, 0x65, 0x72, 0x6e, 0x6f, 0x72, 0x49, 0x73, 0x56, 0x41, 0x41, 0x45, 0x6e, 0x71, 0x75, 0x65,
+	0x75, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x72, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x6c, 0x12, 0x6a, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f,
+	0x72, 0x2f, 0x69, 0x73, 0x5f, 0x76, 0x61, 0x61, 0x5f, 0x65, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65,
+	0x64, 0x2f, 0x7b, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x2e, 0x65, 0x6d,
+	0x69, 0x74, 0x74, 0x65, 0x72, 0x5f, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x7d, 0x2f, 0x7b, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x2e, 0x65, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x72,
+	0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x7d, 0x2f, 0x7b, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x5f, 0x69, 0x64, 0x2e, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x7d, 0x12,
+	0xa0, 0x02, 0x0a, 0x18, 0x47, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f, 0x72, 0x47, 0x65, 0x74, 0x51,
+	0x75, 0x65, 0x75, 0x65, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2d, 0x2e, 0x70,
 	0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x6f, 0x76, 0x65,
-	0x72, 0x6e, 0x6f, 0x72, 0x47, 0x65, 0x74, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x56,
-	0x41, 0x41, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x70, 0x75, 0x62,
-	0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x6f, 0x76, 0x65, 0x72, 0x6e,
-	0x6f, 0x72, 0x47, 0x65, 0x74, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x56, 0x41, 0x41,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x22, 0x82, 0xd3, 0xe4, 0x93, 0x02,
-	0x1c, 0x12, 0x1a, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f, 0x72, 0x2f,
-	0x65, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x5f, 0x76, 0x61, 0x61, 0x73, 0x12, 0xe4, 0x01,
-	0x0a, 0x15, 0x47, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f, 0x72, 0x49, 0x73, 0x56, 0x41, 0x41, 0x45,
-	0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x12, 0x2a, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63,
-	0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f, 0x72, 0x49,
-	0x73, 0x56, 0x41, 0x41, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e,
-	0x76, 0x31, 0x2e, 0x47, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f, 0x72, 0x49, 0x73, 0x56, 0x41, 0x41,
-	0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x72, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x6c, 0x12, 0x6a, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x6f,
-	0x76, 0x65, 0x72, 0x6e, 0x6f, 0x72, 0x2f, 0x69, 0x73, 0x5f, 0x76, 0x61, 0x61, 0x5f, 0x65, 0x6e,
-	0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x2f, 0x7b, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f,
-	0x69, 0x64, 0x2e, 0x65, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x72, 0x5f, 0x63, 0x68, 0x61, 0x69, 0x6e,
-	0x7d, 0x2f, 0x7b, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x2e, 0x65, 0x6d,
-	0x69, 0x74, 0x74, 0x65, 0x72, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x7d, 0x2f, 0x7b,
-	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x2e, 0x73, 0x65, 0x71, 0x75, 0x65,
-	0x6e, 0x63, 0x65, 0x7d, 0x12, 0x8e, 0x01, 0x0a, 0x14, 0x47, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f,
-	0x72, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x29, 0x2e,
-	0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x6f, 0x76,
-	0x65, 0x72, 0x6e, 0x6f, 0x72, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x4c, 0x69, 0x73,
-	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69,
-	0x63, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f, 0x72,
-	0x47, 0x65, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1f, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x19, 0x12, 0x17, 0x2f, 0x76,
-	0x31, 0x2f, 0x67, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f, 0x72, 0x2f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
-	0x5f, 0x6c, 0x69, 0x73, 0x74, 0x42, 0x47, 0x5a, 0x45, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x72, 0x6e, 0x6f, 0x72, 0x47, 0x65, 0x74, 0x51, 0x75, 0x65, 0x75, 0x65, 0x50, 0x6f, 0x73, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x6f, 0x76, 0x65, 0x72,
+	0x6e, 0x6f, 0x72, 0x47, 0x65, 0x74, 0x51, 0x75, 0x65, 0x75, 0x65, 0x50, 0x6f, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xa4, 0x01, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x9d, 0x01, 0x12, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x6f, 0x76, 0x65, 0x72,
+	0x6e, 0x6f, 0x72, 0x2f, 0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x2f, 0x7b, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x2e, 0x65,
+	0x6d, 0x69, 0x74, 0x74, 0x65, 0x72, 0x5f, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x7d, 0x2f, 0x7b, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x2e, 0x65, 0x6d, 0x69, 0x74, 0x74, 0x65,
+	0x72, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x7d, 0x2f, 0x7b, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x2e, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x7d,
+	0x5a, 0x30, 0x12, 0x2e, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f, 0x72,
+	0x2f, 0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x62, 0x79, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x2f, 0x7b, 0x64, 0x69, 0x67, 0x65, 0x73,
+	0x74, 0x7d, 0x12, 0x8e, 0x01, 0x0a, 0x14, 0x47, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f, 0x72, 0x47,
+	0x65, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x29, 0x2e, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x6f, 0x76, 0x65, 0x72,
+	0x6e, 0x6f, 0x72, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72,
+	0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f, 0x72, 0x47, 0x65,
+	0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x1f, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x19, 0x12, 0x17, 0x2f, 0x76, 0x31, 0x2f,
+	0x67, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f, 0x72, 0x2f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x6c,
+	0x69, 0x73, 0x74, 0x12, 0x9e, 0x01, 0x0a, 0x18, 0x47, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f, 0x72,
+	0x47, 0x65, 0x74, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x2d, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f, 0x72, 0x47, 0x65, 0x74, 0x4e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x2e, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x6f, 0x76, 0x65, 0x72, 0x6e, 0x6f, 0x72, 0x47, 0x65, 0x74, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72,
+	0x6b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x23, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1d, 0x12, 0x1b, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x6f, 0x76,
+	0x65, 0x72, 0x6e, 0x6f, 0x72, 0x2f, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x8e, 0x01, 0x0a, 0x12, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x62, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x56, 0x41, 0x41, 0x12, 0x27, 0x2e, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x62, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x56, 0x41, 0x41, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x53, 0x69, 0x67,
+	0x6e, 0x65, 0x64, 0x56, 0x41, 0x41, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x23,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1d, 0x22, 0x18, 0x2f, 0x76, 0x31, 0x3a, 0x73, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x62, 0x65, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x76, 0x61, 0x61,
+	0x3a, 0x01, 0x2a, 0x30, 0x01, 0x42, 0x65, 0x5a, 0x45, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
 	0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x65, 0x72, 0x74, 0x75, 0x73, 0x6f, 0x6e, 0x65, 0x2f, 0x77, 0x6f,
 	0x72, 0x6d, 0x68, 0x6f, 0x6c, 0x65, 0x2f, 0x6e, 0x6f, 0x64, 0x65, 0x2f, 0x70, 0x6b, 0x67, 0x2f,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2f,
-	0x76, 0x31, 0x3b, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x76, 0x31, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x76, 0x31, 0x3b, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x76, 0x31, 0x92, 0x41,
+	0x1b, 0x12, 0x19, 0x0a, 0x13, 0x47, 0x75, 0x61, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x20, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x20, 0x52, 0x50, 0x43, 0x32, 0x02, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1329,60 +2673,106 @@ func file_publicrpc_v1_publicrpc_proto_rawDescGZIP() []byte {
 }
 
 var file_publicrpc_v1_publicrpc_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_publicrpc_v1_publicrpc_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_publicrpc_v1_publicrpc_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
 var file_publicrpc_v1_publicrpc_proto_goTypes = []interface{}{
 	(ChainID)(0),                                              // 0: publicrpc.v1.ChainID
 	(*MessageID)(nil),                                         // 1: publicrpc.v1.MessageID
 	(*GetSignedVAARequest)(nil),                               // 2: publicrpc.v1.GetSignedVAARequest
 	(*GetSignedVAAResponse)(nil),                              // 3: publicrpc.v1.GetSignedVAAResponse
-	(*GetLastHeartbeatsRequest)(nil),                          // 4: publicrpc.v1.GetLastHeartbeatsRequest
-	(*GetLastHeartbeatsResponse)(nil),                         // 5: publicrpc.v1.GetLastHeartbeatsResponse
-	(*GetCurrentGuardianSetRequest)(nil),                      // 6: publicrpc.v1.GetCurrentGuardianSetRequest
-	(*GetCurrentGuardianSetResponse)(nil),                     // 7: publicrpc.v1.GetCurrentGuardianSetResponse
-	(*GuardianSet)(nil),                                       // 8: publicrpc.v1.GuardianSet
-	(*GovernorGetAvailableNotionalByChainRequest)(nil),        // 9: publicrpc.v1.GovernorGetAvailableNotionalByChainRequest
-	(*GovernorGetAvailableNotionalByChainResponse)(nil),       // 10: publicrpc.v1.GovernorGetAvailableNotionalByChainResponse
-	(*GovernorGetEnqueuedVAAsRequest)(nil),                    // 11: publicrpc.v1.GovernorGetEnqueuedVAAsRequest
-	(*GovernorGetEnqueuedVAAsResponse)(nil),                   // 12: publicrpc.v1.GovernorGetEnqueuedVAAsResponse
-	(*GovernorIsVAAEnqueuedRequest)(nil),                      // 13: publicrpc.v1.GovernorIsVAAEnqueuedRequest
-	(*GovernorIsVAAEnqueuedResponse)(nil),                     // 14: publicrpc.v1.GovernorIsVAAEnqueuedResponse
-	(*GovernorGetTokenListRequest)(nil),                       // 15: publicrpc.v1.GovernorGetTokenListRequest
-	(*GovernorGetTokenListResponse)(nil),                      // 16: publicrpc.v1.GovernorGetTokenListResponse
-	(*GetLastHeartbeatsResponse_Entry)(nil),                   // 17: publicrpc.v1.GetLastHeartbeatsResponse.Entry
-	(*GovernorGetAvailableNotionalByChainResponse_Entry)(nil), // 18: publicrpc.v1.GovernorGetAvailableNotionalByChainResponse.Entry
-	(*GovernorGetEnqueuedVAAsResponse_Entry)(nil),             // 19: publicrpc.v1.GovernorGetEnqueuedVAAsResponse.Entry
-	(*GovernorGetTokenListResponse_Entry)(nil),                // 20: publicrpc.v1.GovernorGetTokenListResponse.Entry
-	(*v1.Heartbeat)(nil),                                      // 21: gossip.v1.Heartbeat
+	(*GetSignedVAAByTxHashRequest)(nil),                       // 4: publicrpc.v1.GetSignedVAAByTxHashRequest
+	(*GetSignedVAAByTxHashResponse)(nil),                      // 5: publicrpc.v1.GetSignedVAAByTxHashResponse
+	(*GetSignedVAABatchRequest)(nil),                          // 6: publicrpc.v1.GetSignedVAABatchRequest
+	(*GetSignedVAABatchResponse)(nil),                         // 7: publicrpc.v1.GetSignedVAABatchResponse
+	(*GetSignedVAARangeRequest)(nil),                          // 8: publicrpc.v1.GetSignedVAARangeRequest
+	(*GetSignedVAARangeResponse)(nil),                         // 9: publicrpc.v1.GetSignedVAARangeResponse
+	(*GetLastHeartbeatsRequest)(nil),                          // 10: publicrpc.v1.GetLastHeartbeatsRequest
+	(*GetLastHeartbeatsResponse)(nil),                         // 11: publicrpc.v1.GetLastHeartbeatsResponse
+	(*GetCurrentGuardianSetRequest)(nil),                      // 12: publicrpc.v1.GetCurrentGuardianSetRequest
+	(*GetCurrentGuardianSetResponse)(nil),                     // 13: publicrpc.v1.GetCurrentGuardianSetResponse
+	(*GuardianSet)(nil),                                       // 14: publicrpc.v1.GuardianSet
+	(*GetGuardianSetHistoryRequest)(nil),                      // 15: publicrpc.v1.GetGuardianSetHistoryRequest
+	(*GetGuardianSetHistoryResponse)(nil),                     // 16: publicrpc.v1.GetGuardianSetHistoryResponse
+	(*GovernorGetAvailableNotionalByChainRequest)(nil),        // 17: publicrpc.v1.GovernorGetAvailableNotionalByChainRequest
+	(*GovernorGetAvailableNotionalByChainResponse)(nil),       // 18: publicrpc.v1.GovernorGetAvailableNotionalByChainResponse
+	(*GovernorGetEnqueuedVAAsRequest)(nil),                    // 19: publicrpc.v1.GovernorGetEnqueuedVAAsRequest
+	(*GovernorGetEnqueuedVAAsResponse)(nil),                   // 20: publicrpc.v1.GovernorGetEnqueuedVAAsResponse
+	(*GovernorIsVAAEnqueuedRequest)(nil),                      // 21: publicrpc.v1.GovernorIsVAAEnqueuedRequest
+	(*GovernorIsVAAEnqueuedResponse)(nil),                     // 22: publicrpc.v1.GovernorIsVAAEnqueuedResponse
+	(*GovernorGetQueuePositionRequest)(nil),                   // 23: publicrpc.v1.GovernorGetQueuePositionRequest
+	(*GovernorGetQueuePositionResponse)(nil),                  // 24: publicrpc.v1.GovernorGetQueuePositionResponse
+	(*GovernorGetTokenListRequest)(nil),                       // 25: publicrpc.v1.GovernorGetTokenListRequest
+	(*GovernorGetTokenListResponse)(nil),                      // 26: publicrpc.v1.GovernorGetTokenListResponse
+	(*GovernorGetNetworkStatusRequest)(nil),                   // 27: publicrpc.v1.GovernorGetNetworkStatusRequest
+	(*GovernorGetNetworkStatusResponse)(nil),                  // 28: publicrpc.v1.GovernorGetNetworkStatusResponse
+	(*SubscribeSignedVAARequest)(nil),                         // 29: publicrpc.v1.SubscribeSignedVAARequest
+	(*SubscribeSignedVAAResponse)(nil),                        // 30: publicrpc.v1.SubscribeSignedVAAResponse
+	(*GetLastHeartbeatsResponse_Entry)(nil),                   // 31: publicrpc.v1.GetLastHeartbeatsResponse.Entry
+	(*GetLastHeartbeatsResponse_Network)(nil),                 // 32: publicrpc.v1.GetLastHeartbeatsResponse.Network
+	(*GetGuardianSetHistoryResponse_Entry)(nil),               // 33: publicrpc.v1.GetGuardianSetHistoryResponse.Entry
+	(*GovernorGetAvailableNotionalByChainResponse_Entry)(nil), // 34: publicrpc.v1.GovernorGetAvailableNotionalByChainResponse.Entry
+	(*GovernorGetEnqueuedVAAsResponse_Entry)(nil),             // 35: publicrpc.v1.GovernorGetEnqueuedVAAsResponse.Entry
+	(*GovernorGetTokenListResponse_Entry)(nil),                // 36: publicrpc.v1.GovernorGetTokenListResponse.Entry
+	(*GovernorGetNetworkStatusResponse_Entry)(nil),            // 37: publicrpc.v1.GovernorGetNetworkStatusResponse.Entry
+	(*SubscribeSignedVAARequest_Filter)(nil),                  // 38: publicrpc.v1.SubscribeSignedVAARequest.Filter
+	(*v1.Heartbeat)(nil),                                      // 39: gossip.v1.Heartbeat
+	(*v1.ChainGovernorStatus)(nil),                            // 40: gossip.v1.ChainGovernorStatus
 }
 var file_publicrpc_v1_publicrpc_proto_depIdxs = []int32{
 	0,  // 0: publicrpc.v1.MessageID.emitter_chain:type_name -> publicrpc.v1.ChainID
 	1,  // 1: publicrpc.v1.GetSignedVAARequest.message_id:type_name -> publicrpc.v1.MessageID
-	17, // 2: publicrpc.v1.GetLastHeartbeatsResponse.entries:type_name -> publicrpc.v1.GetLastHeartbeatsResponse.Entry
-	8,  // 3: publicrpc.v1.GetCurrentGuardianSetResponse.guardian_set:type_name -> publicrpc.v1.GuardianSet
-	18, // 4: publicrpc.v1.GovernorGetAvailableNotionalByChainResponse.entries:type_name -> publicrpc.v1.GovernorGetAvailableNotionalByChainResponse.Entry
-	19, // 5: publicrpc.v1.GovernorGetEnqueuedVAAsResponse.entries:type_name -> publicrpc.v1.GovernorGetEnqueuedVAAsResponse.Entry
-	1,  // 6: publicrpc.v1.GovernorIsVAAEnqueuedRequest.message_id:type_name -> publicrpc.v1.MessageID
-	20, // 7: publicrpc.v1.GovernorGetTokenListResponse.entries:type_name -> publicrpc.v1.GovernorGetTokenListResponse.Entry
-	21, // 8: publicrpc.v1.GetLastHeartbeatsResponse.Entry.raw_heartbeat:type_name -> gossip.v1.Heartbeat
-	4,  // 9: publicrpc.v1.PublicRPCService.GetLastHeartbeats:input_type -> publicrpc.v1.GetLastHeartbeatsRequest
-	2,  // 10: publicrpc.v1.PublicRPCService.GetSignedVAA:input_type -> publicrpc.v1.GetSignedVAARequest
-	6,  // 11: publicrpc.v1.PublicRPCService.GetCurrentGuardianSet:input_type -> publicrpc.v1.GetCurrentGuardianSetRequest
-	9,  // 12: publicrpc.v1.PublicRPCService.GovernorGetAvailableNotionalByChain:input_type -> publicrpc.v1.GovernorGetAvailableNotionalByChainRequest
-	11, // 13: publicrpc.v1.PublicRPCService.GovernorGetEnqueuedVAAs:input_type -> publicrpc.v1.GovernorGetEnqueuedVAAsRequest
-	13, // 14: publicrpc.v1.PublicRPCService.GovernorIsVAAEnqueued:input_type -> publicrpc.v1.GovernorIsVAAEnqueuedRequest
-	15, // 15: publicrpc.v1.PublicRPCService.GovernorGetTokenList:input_type -> publicrpc.v1.GovernorGetTokenListRequest
-	5,  // 16: publicrpc.v1.PublicRPCService.GetLastHeartbeats:output_type -> publicrpc.v1.GetLastHeartbeatsResponse
-	3,  // 17: publicrpc.v1.PublicRPCService.GetSignedVAA:output_type -> publicrpc.v1.GetSignedVAAResponse
-	7,  // 18: publicrpc.v1.PublicRPCService.GetCurrentGuardianSet:output_type -> publicrpc.v1.GetCurrentGuardianSetResponse
-	10, // 19: publicrpc.v1.PublicRPCService.GovernorGetAvailableNotionalByChain:output_type -> publicrpc.v1.GovernorGetAvailableNotionalByChainResponse
-	12, // 20: publicrpc.v1.PublicRPCService.GovernorGetEnqueuedVAAs:output_type -> publicrpc.v1.GovernorGetEnqueuedVAAsResponse
-	14, // 21: publicrpc.v1.PublicRPCService.GovernorIsVAAEnqueued:output_type -> publicrpc.v1.GovernorIsVAAEnqueuedResponse
-	16, // 22: publicrpc.v1.PublicRPCService.GovernorGetTokenList:output_type -> publicrpc.v1.GovernorGetTokenListResponse
-	16, // [16:23] is the sub-list for method output_type
-	9,  // [9:16] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	0,  // 2: publicrpc.v1.GetSignedVAAByTxHashRequest.emitter_chain:type_name -> publicrpc.v1.ChainID
+	1,  // 3: publicrpc.v1.GetSignedVAABatchRequest.message_ids:type_name -> publicrpc.v1.MessageID
+	0,  // 4: publicrpc.v1.GetSignedVAARangeRequest.emitter_chain:type_name -> publicrpc.v1.ChainID
+	31, // 5: publicrpc.v1.GetLastHeartbeatsResponse.entries:type_name -> publicrpc.v1.GetLastHeartbeatsResponse.Entry
+	14, // 6: publicrpc.v1.GetCurrentGuardianSetResponse.guardian_set:type_name -> publicrpc.v1.GuardianSet
+	33, // 7: publicrpc.v1.GetGuardianSetHistoryResponse.entries:type_name -> publicrpc.v1.GetGuardianSetHistoryResponse.Entry
+	34, // 8: publicrpc.v1.GovernorGetAvailableNotionalByChainResponse.entries:type_name -> publicrpc.v1.GovernorGetAvailableNotionalByChainResponse.Entry
+	35, // 9: publicrpc.v1.GovernorGetEnqueuedVAAsResponse.entries:type_name -> publicrpc.v1.GovernorGetEnqueuedVAAsResponse.Entry
+	1,  // 10: publicrpc.v1.GovernorIsVAAEnqueuedRequest.message_id:type_name -> publicrpc.v1.MessageID
+	1,  // 11: publicrpc.v1.GovernorGetQueuePositionRequest.message_id:type_name -> publicrpc.v1.MessageID
+	1,  // 12: publicrpc.v1.GovernorGetQueuePositionResponse.message_id:type_name -> publicrpc.v1.MessageID
+	36, // 13: publicrpc.v1.GovernorGetTokenListResponse.entries:type_name -> publicrpc.v1.GovernorGetTokenListResponse.Entry
+	37, // 14: publicrpc.v1.GovernorGetNetworkStatusResponse.entries:type_name -> publicrpc.v1.GovernorGetNetworkStatusResponse.Entry
+	38, // 15: publicrpc.v1.SubscribeSignedVAARequest.filters:type_name -> publicrpc.v1.SubscribeSignedVAARequest.Filter
+	39, // 16: publicrpc.v1.GetLastHeartbeatsResponse.Entry.raw_heartbeat:type_name -> gossip.v1.Heartbeat
+	32, // 17: publicrpc.v1.GetLastHeartbeatsResponse.Entry.networks:type_name -> publicrpc.v1.GetLastHeartbeatsResponse.Network
+	0,  // 18: publicrpc.v1.GetLastHeartbeatsResponse.Network.chain_id:type_name -> publicrpc.v1.ChainID
+	14, // 19: publicrpc.v1.GetGuardianSetHistoryResponse.Entry.guardian_set:type_name -> publicrpc.v1.GuardianSet
+	40, // 20: publicrpc.v1.GovernorGetNetworkStatusResponse.Entry.status:type_name -> gossip.v1.ChainGovernorStatus
+	0,  // 21: publicrpc.v1.SubscribeSignedVAARequest.Filter.emitter_chain:type_name -> publicrpc.v1.ChainID
+	10, // 22: publicrpc.v1.PublicRPCService.GetLastHeartbeats:input_type -> publicrpc.v1.GetLastHeartbeatsRequest
+	2,  // 23: publicrpc.v1.PublicRPCService.GetSignedVAA:input_type -> publicrpc.v1.GetSignedVAARequest
+	4,  // 24: publicrpc.v1.PublicRPCService.GetSignedVAAByTxHash:input_type -> publicrpc.v1.GetSignedVAAByTxHashRequest
+	6,  // 25: publicrpc.v1.PublicRPCService.GetSignedVAABatch:input_type -> publicrpc.v1.GetSignedVAABatchRequest
+	8,  // 26: publicrpc.v1.PublicRPCService.GetSignedVAARange:input_type -> publicrpc.v1.GetSignedVAARangeRequest
+	12, // 27: publicrpc.v1.PublicRPCService.GetCurrentGuardianSet:input_type -> publicrpc.v1.GetCurrentGuardianSetRequest
+	15, // 28: publicrpc.v1.PublicRPCService.GetGuardianSetHistory:input_type -> publicrpc.v1.GetGuardianSetHistoryRequest
+	17, // 29: publicrpc.v1.PublicRPCService.GovernorGetAvailableNotionalByChain:input_type -> publicrpc.v1.GovernorGetAvailableNotionalByChainRequest
+	19, // 30: publicrpc.v1.PublicRPCService.GovernorGetEnqueuedVAAs:input_type -> publicrpc.v1.GovernorGetEnqueuedVAAsRequest
+	21, // 31: publicrpc.v1.PublicRPCService.GovernorIsVAAEnqueued:input_type -> publicrpc.v1.GovernorIsVAAEnqueuedRequest
+	23, // 32: publicrpc.v1.PublicRPCService.GovernorGetQueuePosition:input_type -> publicrpc.v1.GovernorGetQueuePositionRequest
+	25, // 33: publicrpc.v1.PublicRPCService.GovernorGetTokenList:input_type -> publicrpc.v1.GovernorGetTokenListRequest
+	27, // 34: publicrpc.v1.PublicRPCService.GovernorGetNetworkStatus:input_type -> publicrpc.v1.GovernorGetNetworkStatusRequest
+	29, // 35: publicrpc.v1.PublicRPCService.SubscribeSignedVAA:input_type -> publicrpc.v1.SubscribeSignedVAARequest
+	11, // 36: publicrpc.v1.PublicRPCService.GetLastHeartbeats:output_type -> publicrpc.v1.GetLastHeartbeatsResponse
+	3,  // 37: publicrpc.v1.PublicRPCService.GetSignedVAA:output_type -> publicrpc.v1.GetSignedVAAResponse
+	5,  // 38: publicrpc.v1.PublicRPCService.GetSignedVAAByTxHash:output_type -> publicrpc.v1.GetSignedVAAByTxHashResponse
+	7,  // 39: publicrpc.v1.PublicRPCService.GetSignedVAABatch:output_type -> publicrpc.v1.GetSignedVAABatchResponse
+	9,  // 40: publicrpc.v1.PublicRPCService.GetSignedVAARange:output_type -> publicrpc.v1.GetSignedVAARangeResponse
+	13, // 41: publicrpc.v1.PublicRPCService.GetCurrentGuardianSet:output_type -> publicrpc.v1.GetCurrentGuardianSetResponse
+	16, // 42: publicrpc.v1.PublicRPCService.GetGuardianSetHistory:output_type -> publicrpc.v1.GetGuardianSetHistoryResponse
+	18, // 43: publicrpc.v1.PublicRPCService.GovernorGetAvailableNotionalByChain:output_type -> publicrpc.v1.GovernorGetAvailableNotionalByChainResponse
+	20, // 44: publicrpc.v1.PublicRPCService.GovernorGetEnqueuedVAAs:output_type -> publicrpc.v1.GovernorGetEnqueuedVAAsResponse
+	22, // 45: publicrpc.v1.PublicRPCService.GovernorIsVAAEnqueued:output_type -> publicrpc.v1.GovernorIsVAAEnqueuedResponse
+	24, // 46: publicrpc.v1.PublicRPCService.GovernorGetQueuePosition:output_type -> publicrpc.v1.GovernorGetQueuePositionResponse
+	26, // 47: publicrpc.v1.PublicRPCService.GovernorGetTokenList:output_type -> publicrpc.v1.GovernorGetTokenListResponse
+	28, // 48: publicrpc.v1.PublicRPCService.GovernorGetNetworkStatus:output_type -> publicrpc.v1.GovernorGetNetworkStatusResponse
+	30, // 49: publicrpc.v1.PublicRPCService.SubscribeSignedVAA:output_type -> publicrpc.v1.SubscribeSignedVAAResponse
+	36, // [36:50] is the sub-list for method output_type
+	22, // [22:36] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
 }
 
 func init() { file_publicrpc_v1_publicrpc_proto_init() }
@@ -1428,7 +2818,7 @@ func file_publicrpc_v1_publicrpc_proto_init() {
 			}
 		}
 		file_publicrpc_v1_publicrpc_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetLastHeartbeatsRequest); i {
+			switch v := v.(*GetSignedVAAByTxHashRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1440,7 +2830,7 @@ func file_publicrpc_v1_publicrpc_proto_init() {
 			}
 		}
 		file_publicrpc_v1_publicrpc_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetLastHeartbeatsResponse); i {
+			switch v := v.(*GetSignedVAAByTxHashResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1452,7 +2842,7 @@ func file_publicrpc_v1_publicrpc_proto_init() {
 			}
 		}
 		file_publicrpc_v1_publicrpc_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetCurrentGuardianSetRequest); i {
+			switch v := v.(*GetSignedVAABatchRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1464,7 +2854,7 @@ func file_publicrpc_v1_publicrpc_proto_init() {
 			}
 		}
 		file_publicrpc_v1_publicrpc_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetCurrentGuardianSetResponse); i {
+			switch v := v.(*GetSignedVAABatchResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1476,7 +2866,7 @@ func file_publicrpc_v1_publicrpc_proto_init() {
 			}
 		}
 		file_publicrpc_v1_publicrpc_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GuardianSet); i {
+			switch v := v.(*GetSignedVAARangeRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1488,7 +2878,7 @@ func file_publicrpc_v1_publicrpc_proto_init() {
 			}
 		}
 		file_publicrpc_v1_publicrpc_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GovernorGetAvailableNotionalByChainRequest); i {
+			switch v := v.(*GetSignedVAARangeResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1500,7 +2890,7 @@ func file_publicrpc_v1_publicrpc_proto_init() {
 			}
 		}
 		file_publicrpc_v1_publicrpc_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GovernorGetAvailableNotionalByChainResponse); i {
+			switch v := v.(*GetLastHeartbeatsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1512,7 +2902,7 @@ func file_publicrpc_v1_publicrpc_proto_init() {
 			}
 		}
 		file_publicrpc_v1_publicrpc_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GovernorGetEnqueuedVAAsRequest); i {
+			switch v := v.(*GetLastHeartbeatsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1524,7 +2914,7 @@ func file_publicrpc_v1_publicrpc_proto_init() {
 			}
 		}
 		file_publicrpc_v1_publicrpc_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GovernorGetEnqueuedVAAsResponse); i {
+			switch v := v.(*GetCurrentGuardianSetRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1536,7 +2926,7 @@ func file_publicrpc_v1_publicrpc_proto_init() {
 			}
 		}
 		file_publicrpc_v1_publicrpc_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GovernorIsVAAEnqueuedRequest); i {
+			switch v := v.(*GetCurrentGuardianSetResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1548,7 +2938,7 @@ func file_publicrpc_v1_publicrpc_proto_init() {
 			}
 		}
 		file_publicrpc_v1_publicrpc_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GovernorIsVAAEnqueuedResponse); i {
+			switch v := v.(*GuardianSet); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1560,7 +2950,7 @@ func file_publicrpc_v1_publicrpc_proto_init() {
 			}
 		}
 		file_publicrpc_v1_publicrpc_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GovernorGetTokenListRequest); i {
+			switch v := v.(*GetGuardianSetHistoryRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1572,7 +2962,7 @@ func file_publicrpc_v1_publicrpc_proto_init() {
 			}
 		}
 		file_publicrpc_v1_publicrpc_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GovernorGetTokenListResponse); i {
+			switch v := v.(*GetGuardianSetHistoryResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1584,7 +2974,7 @@ func file_publicrpc_v1_publicrpc_proto_init() {
 			}
 		}
 		file_publicrpc_v1_publicrpc_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetLastHeartbeatsResponse_Entry); i {
+			switch v := v.(*GovernorGetAvailableNotionalByChainRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1596,7 +2986,7 @@ func file_publicrpc_v1_publicrpc_proto_init() {
 			}
 		}
 		file_publicrpc_v1_publicrpc_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GovernorGetAvailableNotionalByChainResponse_Entry); i {
+			switch v := v.(*GovernorGetAvailableNotionalByChainResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1608,7 +2998,7 @@ func file_publicrpc_v1_publicrpc_proto_init() {
 			}
 		}
 		file_publicrpc_v1_publicrpc_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GovernorGetEnqueuedVAAsResponse_Entry); i {
+			switch v := v.(*GovernorGetEnqueuedVAAsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1620,6 +3010,198 @@ func file_publicrpc_v1_publicrpc_proto_init() {
 			}
 		}
 		file_publicrpc_v1_publicrpc_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GovernorGetEnqueuedVAAsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_publicrpc_v1_publicrpc_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GovernorIsVAAEnqueuedRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_publicrpc_v1_publicrpc_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GovernorIsVAAEnqueuedResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_publicrpc_v1_publicrpc_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GovernorGetQueuePositionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_publicrpc_v1_publicrpc_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GovernorGetQueuePositionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_publicrpc_v1_publicrpc_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GovernorGetTokenListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_publicrpc_v1_publicrpc_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GovernorGetTokenListResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_publicrpc_v1_publicrpc_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GovernorGetNetworkStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_publicrpc_v1_publicrpc_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GovernorGetNetworkStatusResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_publicrpc_v1_publicrpc_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeSignedVAARequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_publicrpc_v1_publicrpc_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeSignedVAAResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_publicrpc_v1_publicrpc_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetLastHeartbeatsResponse_Entry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_publicrpc_v1_publicrpc_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetLastHeartbeatsResponse_Network); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_publicrpc_v1_publicrpc_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetGuardianSetHistoryResponse_Entry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_publicrpc_v1_publicrpc_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GovernorGetAvailableNotionalByChainResponse_Entry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_publicrpc_v1_publicrpc_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GovernorGetEnqueuedVAAsResponse_Entry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_publicrpc_v1_publicrpc_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GovernorGetTokenListResponse_Entry); i {
 			case 0:
 				return &v.state
@@ -1631,6 +3213,30 @@ func file_publicrpc_v1_publicrpc_proto_init() {
 				return nil
 			}
 		}
+		file_publicrpc_v1_publicrpc_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GovernorGetNetworkStatusResponse_Entry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_publicrpc_v1_publicrpc_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeSignedVAARequest_Filter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1638,7 +3244,7 @@ func file_publicrpc_v1_publicrpc_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_publicrpc_v1_publicrpc_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   20,
+			NumMessages:   38,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
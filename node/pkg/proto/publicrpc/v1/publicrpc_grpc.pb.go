@@ -20,14 +20,34 @@ const _ = grpc.SupportPackageIsVersion7
 type PublicRPCServiceClient interface {
 	// GetLastHeartbeats returns the last heartbeat received for each guardian node in the
 	// node's active guardian set. Heartbeats received by nodes not in the guardian set are ignored.
-	// The heartbeat value is null if no heartbeat has yet been received.
+	// The heartbeat value is null if no heartbeat has yet been received. The heartbeats can be filtered by guardian, and the status
+	// of the networks of each node is also returned in structured form, with how far it is behind the other nodes.
 	GetLastHeartbeats(ctx context.Context, in *GetLastHeartbeatsRequest, opts ...grpc.CallOption) (*GetLastHeartbeatsResponse, error)
 	GetSignedVAA(ctx context.Context, in *GetSignedVAARequest, opts ...grpc.CallOption) (*GetSignedVAAResponse, error)
+	// GetSignedVAAByTxHash returns the signed VAAs of the messages emitted by a source chain transaction. Only the messages
+	// this node observed itself are known, so VAAs it only received from the network are not returned.
+	GetSignedVAAByTxHash(ctx context.Context, in *GetSignedVAAByTxHashRequest, opts ...grpc.CallOption) (*GetSignedVAAByTxHashResponse, error)
+	// GetSignedVAABatch returns the signed VAAs of several messages at once.
+	GetSignedVAABatch(ctx context.Context, in *GetSignedVAABatchRequest, opts ...grpc.CallOption) (*GetSignedVAABatchResponse, error)
+	// GetSignedVAARange returns the signed VAAs of an emitter in a range of sequences, a page at a time, in sequence order.
+	GetSignedVAARange(ctx context.Context, in *GetSignedVAARangeRequest, opts ...grpc.CallOption) (*GetSignedVAARangeResponse, error)
 	GetCurrentGuardianSet(ctx context.Context, in *GetCurrentGuardianSetRequest, opts ...grpc.CallOption) (*GetCurrentGuardianSetResponse, error)
+	// GetGuardianSetHistory returns the guardian sets installed by the guardian set update VAAs this guardian has stored, together
+	// with the current guardian set, ordered by index.
+	GetGuardianSetHistory(ctx context.Context, in *GetGuardianSetHistoryRequest, opts ...grpc.CallOption) (*GetGuardianSetHistoryResponse, error)
 	GovernorGetAvailableNotionalByChain(ctx context.Context, in *GovernorGetAvailableNotionalByChainRequest, opts ...grpc.CallOption) (*GovernorGetAvailableNotionalByChainResponse, error)
 	GovernorGetEnqueuedVAAs(ctx context.Context, in *GovernorGetEnqueuedVAAsRequest, opts ...grpc.CallOption) (*GovernorGetEnqueuedVAAsResponse, error)
 	GovernorIsVAAEnqueued(ctx context.Context, in *GovernorIsVAAEnqueuedRequest, opts ...grpc.CallOption) (*GovernorIsVAAEnqueuedResponse, error)
+	// GovernorGetQueuePosition reports whether a transfer, given by message ID or by digest, is delayed by the governor and, if it is,
+	// where it stands in the queue of its chain and when it is expected to be released.
+	GovernorGetQueuePosition(ctx context.Context, in *GovernorGetQueuePositionRequest, opts ...grpc.CallOption) (*GovernorGetQueuePositionResponse, error)
 	GovernorGetTokenList(ctx context.Context, in *GovernorGetTokenListRequest, opts ...grpc.CallOption) (*GovernorGetTokenListResponse, error)
+	// GovernorGetNetworkStatus returns the last governor status received from each guardian, so that the governors of the whole
+	// network can be compared.
+	GovernorGetNetworkStatus(ctx context.Context, in *GovernorGetNetworkStatusRequest, opts ...grpc.CallOption) (*GovernorGetNetworkStatusResponse, error)
+	// SubscribeSignedVAA streams the signed VAAs stored by the node from now on, optionally only those of some chains or emitters.
+	// The stream fails if the client falls behind, in which case the VAAs it missed can be fetched with GetSignedVAA.
+	SubscribeSignedVAA(ctx context.Context, in *SubscribeSignedVAARequest, opts ...grpc.CallOption) (PublicRPCService_SubscribeSignedVAAClient, error)
 }
 
 type publicRPCServiceClient struct {
@@ -56,6 +76,33 @@ func (c *publicRPCServiceClient) GetSignedVAA(ctx context.Context, in *GetSigned
 	return out, nil
 }
 
+func (c *publicRPCServiceClient) GetSignedVAAByTxHash(ctx context.Context, in *GetSignedVAAByTxHashRequest, opts ...grpc.CallOption) (*GetSignedVAAByTxHashResponse, error) {
+	out := new(GetSignedVAAByTxHashResponse)
+	err := c.cc.Invoke(ctx, "/publicrpc.v1.PublicRPCService/GetSignedVAAByTxHash", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *publicRPCServiceClient) GetSignedVAABatch(ctx context.Context, in *GetSignedVAABatchRequest, opts ...grpc.CallOption) (*GetSignedVAABatchResponse, error) {
+	out := new(GetSignedVAABatchResponse)
+	err := c.cc.Invoke(ctx, "/publicrpc.v1.PublicRPCService/GetSignedVAABatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *publicRPCServiceClient) GetSignedVAARange(ctx context.Context, in *GetSignedVAARangeRequest, opts ...grpc.CallOption) (*GetSignedVAARangeResponse, error) {
+	out := new(GetSignedVAARangeResponse)
+	err := c.cc.Invoke(ctx, "/publicrpc.v1.PublicRPCService/GetSignedVAARange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *publicRPCServiceClient) GetCurrentGuardianSet(ctx context.Context, in *GetCurrentGuardianSetRequest, opts ...grpc.CallOption) (*GetCurrentGuardianSetResponse, error) {
 	out := new(GetCurrentGuardianSetResponse)
 	err := c.cc.Invoke(ctx, "/publicrpc.v1.PublicRPCService/GetCurrentGuardianSet", in, out, opts...)
@@ -65,6 +112,15 @@ func (c *publicRPCServiceClient) GetCurrentGuardianSet(ctx context.Context, in *
 	return out, nil
 }
 
+func (c *publicRPCServiceClient) GetGuardianSetHistory(ctx context.Context, in *GetGuardianSetHistoryRequest, opts ...grpc.CallOption) (*GetGuardianSetHistoryResponse, error) {
+	out := new(GetGuardianSetHistoryResponse)
+	err := c.cc.Invoke(ctx, "/publicrpc.v1.PublicRPCService/GetGuardianSetHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *publicRPCServiceClient) GovernorGetAvailableNotionalByChain(ctx context.Context, in *GovernorGetAvailableNotionalByChainRequest, opts ...grpc.CallOption) (*GovernorGetAvailableNotionalByChainResponse, error) {
 	out := new(GovernorGetAvailableNotionalByChainResponse)
 	err := c.cc.Invoke(ctx, "/publicrpc.v1.PublicRPCService/GovernorGetAvailableNotionalByChain", in, out, opts...)
@@ -92,6 +148,15 @@ func (c *publicRPCServiceClient) GovernorIsVAAEnqueued(ctx context.Context, in *
 	return out, nil
 }
 
+func (c *publicRPCServiceClient) GovernorGetQueuePosition(ctx context.Context, in *GovernorGetQueuePositionRequest, opts ...grpc.CallOption) (*GovernorGetQueuePositionResponse, error) {
+	out := new(GovernorGetQueuePositionResponse)
+	err := c.cc.Invoke(ctx, "/publicrpc.v1.PublicRPCService/GovernorGetQueuePosition", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *publicRPCServiceClient) GovernorGetTokenList(ctx context.Context, in *GovernorGetTokenListRequest, opts ...grpc.CallOption) (*GovernorGetTokenListResponse, error) {
 	out := new(GovernorGetTokenListResponse)
 	err := c.cc.Invoke(ctx, "/publicrpc.v1.PublicRPCService/GovernorGetTokenList", in, out, opts...)
@@ -101,20 +166,81 @@ func (c *publicRPCServiceClient) GovernorGetTokenList(ctx context.Context, in *G
 	return out, nil
 }
 
+func (c *publicRPCServiceClient) GovernorGetNetworkStatus(ctx context.Context, in *GovernorGetNetworkStatusRequest, opts ...grpc.CallOption) (*GovernorGetNetworkStatusResponse, error) {
+	out := new(GovernorGetNetworkStatusResponse)
+	err := c.cc.Invoke(ctx, "/publicrpc.v1.PublicRPCService/GovernorGetNetworkStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *publicRPCServiceClient) SubscribeSignedVAA(ctx context.Context, in *SubscribeSignedVAARequest, opts ...grpc.CallOption) (PublicRPCService_SubscribeSignedVAAClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PublicRPCService_ServiceDesc.Streams[0], "/publicrpc.v1.PublicRPCService/SubscribeSignedVAA", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &publicRPCServiceSubscribeSignedVAAClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PublicRPCService_SubscribeSignedVAAClient interface {
+	Recv() (*SubscribeSignedVAAResponse, error)
+	grpc.ClientStream
+}
+
+type publicRPCServiceSubscribeSignedVAAClient struct {
+	grpc.ClientStream
+}
+
+func (x *publicRPCServiceSubscribeSignedVAAClient) Recv() (*SubscribeSignedVAAResponse, error) {
+	m := new(SubscribeSignedVAAResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // PublicRPCServiceServer is the server API for PublicRPCService service.
 // All implementations must embed UnimplementedPublicRPCServiceServer
 // for forward compatibility
 type PublicRPCServiceServer interface {
 	// GetLastHeartbeats returns the last heartbeat received for each guardian node in the
 	// node's active guardian set. Heartbeats received by nodes not in the guardian set are ignored.
-	// The heartbeat value is null if no heartbeat has yet been received.
+	// The heartbeat value is null if no heartbeat has yet been received. The heartbeats can be filtered by guardian, and the status
+	// of the networks of each node is also returned in structured form, with how far it is behind the other nodes.
 	GetLastHeartbeats(context.Context, *GetLastHeartbeatsRequest) (*GetLastHeartbeatsResponse, error)
 	GetSignedVAA(context.Context, *GetSignedVAARequest) (*GetSignedVAAResponse, error)
+	// GetSignedVAAByTxHash returns the signed VAAs of the messages emitted by a source chain transaction. Only the messages
+	// this node observed itself are known, so VAAs it only received from the network are not returned.
+	GetSignedVAAByTxHash(context.Context, *GetSignedVAAByTxHashRequest) (*GetSignedVAAByTxHashResponse, error)
+	// GetSignedVAABatch returns the signed VAAs of several messages at once.
+	GetSignedVAABatch(context.Context, *GetSignedVAABatchRequest) (*GetSignedVAABatchResponse, error)
+	// GetSignedVAARange returns the signed VAAs of an emitter in a range of sequences, a page at a time, in sequence order.
+	GetSignedVAARange(context.Context, *GetSignedVAARangeRequest) (*GetSignedVAARangeResponse, error)
 	GetCurrentGuardianSet(context.Context, *GetCurrentGuardianSetRequest) (*GetCurrentGuardianSetResponse, error)
+	// GetGuardianSetHistory returns the guardian sets installed by the guardian set update VAAs this guardian has stored, together
+	// with the current guardian set, ordered by index.
+	GetGuardianSetHistory(context.Context, *GetGuardianSetHistoryRequest) (*GetGuardianSetHistoryResponse, error)
 	GovernorGetAvailableNotionalByChain(context.Context, *GovernorGetAvailableNotionalByChainRequest) (*GovernorGetAvailableNotionalByChainResponse, error)
 	GovernorGetEnqueuedVAAs(context.Context, *GovernorGetEnqueuedVAAsRequest) (*GovernorGetEnqueuedVAAsResponse, error)
 	GovernorIsVAAEnqueued(context.Context, *GovernorIsVAAEnqueuedRequest) (*GovernorIsVAAEnqueuedResponse, error)
+	// GovernorGetQueuePosition reports whether a transfer, given by message ID or by digest, is delayed by the governor and, if it is,
+	// where it stands in the queue of its chain and when it is expected to be released.
+	GovernorGetQueuePosition(context.Context, *GovernorGetQueuePositionRequest) (*GovernorGetQueuePositionResponse, error)
 	GovernorGetTokenList(context.Context, *GovernorGetTokenListRequest) (*GovernorGetTokenListResponse, error)
+	// GovernorGetNetworkStatus returns the last governor status received from each guardian, so that the governors of the whole
+	// network can be compared.
+	GovernorGetNetworkStatus(context.Context, *GovernorGetNetworkStatusRequest) (*GovernorGetNetworkStatusResponse, error)
+	// SubscribeSignedVAA streams the signed VAAs stored by the node from now on, optionally only those of some chains or emitters.
+	// The stream fails if the client falls behind, in which case the VAAs it missed can be fetched with GetSignedVAA.
+	SubscribeSignedVAA(*SubscribeSignedVAARequest, PublicRPCService_SubscribeSignedVAAServer) error
 	mustEmbedUnimplementedPublicRPCServiceServer()
 }
 
@@ -128,9 +254,21 @@ func (UnimplementedPublicRPCServiceServer) GetLastHeartbeats(context.Context, *G
 func (UnimplementedPublicRPCServiceServer) GetSignedVAA(context.Context, *GetSignedVAARequest) (*GetSignedVAAResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetSignedVAA not implemented")
 }
+func (UnimplementedPublicRPCServiceServer) GetSignedVAAByTxHash(context.Context, *GetSignedVAAByTxHashRequest) (*GetSignedVAAByTxHashResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSignedVAAByTxHash not implemented")
+}
+func (UnimplementedPublicRPCServiceServer) GetSignedVAABatch(context.Context, *GetSignedVAABatchRequest) (*GetSignedVAABatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSignedVAABatch not implemented")
+}
+func (UnimplementedPublicRPCServiceServer) GetSignedVAARange(context.Context, *GetSignedVAARangeRequest) (*GetSignedVAARangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSignedVAARange not implemented")
+}
 func (UnimplementedPublicRPCServiceServer) GetCurrentGuardianSet(context.Context, *GetCurrentGuardianSetRequest) (*GetCurrentGuardianSetResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCurrentGuardianSet not implemented")
 }
+func (UnimplementedPublicRPCServiceServer) GetGuardianSetHistory(context.Context, *GetGuardianSetHistoryRequest) (*GetGuardianSetHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGuardianSetHistory not implemented")
+}
 func (UnimplementedPublicRPCServiceServer) GovernorGetAvailableNotionalByChain(context.Context, *GovernorGetAvailableNotionalByChainRequest) (*GovernorGetAvailableNotionalByChainResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GovernorGetAvailableNotionalByChain not implemented")
 }
@@ -140,9 +278,18 @@ func (UnimplementedPublicRPCServiceServer) GovernorGetEnqueuedVAAs(context.Conte
 func (UnimplementedPublicRPCServiceServer) GovernorIsVAAEnqueued(context.Context, *GovernorIsVAAEnqueuedRequest) (*GovernorIsVAAEnqueuedResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GovernorIsVAAEnqueued not implemented")
 }
+func (UnimplementedPublicRPCServiceServer) GovernorGetQueuePosition(context.Context, *GovernorGetQueuePositionRequest) (*GovernorGetQueuePositionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GovernorGetQueuePosition not implemented")
+}
 func (UnimplementedPublicRPCServiceServer) GovernorGetTokenList(context.Context, *GovernorGetTokenListRequest) (*GovernorGetTokenListResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GovernorGetTokenList not implemented")
 }
+func (UnimplementedPublicRPCServiceServer) GovernorGetNetworkStatus(context.Context, *GovernorGetNetworkStatusRequest) (*GovernorGetNetworkStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GovernorGetNetworkStatus not implemented")
+}
+func (UnimplementedPublicRPCServiceServer) SubscribeSignedVAA(*SubscribeSignedVAARequest, PublicRPCService_SubscribeSignedVAAServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeSignedVAA not implemented")
+}
 func (UnimplementedPublicRPCServiceServer) mustEmbedUnimplementedPublicRPCServiceServer() {}
 
 // UnsafePublicRPCServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -192,6 +339,60 @@ func _PublicRPCService_GetSignedVAA_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PublicRPCService_GetSignedVAAByTxHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSignedVAAByTxHashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicRPCServiceServer).GetSignedVAAByTxHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/publicrpc.v1.PublicRPCService/GetSignedVAAByTxHash",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicRPCServiceServer).GetSignedVAAByTxHash(ctx, req.(*GetSignedVAAByTxHashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PublicRPCService_GetSignedVAABatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSignedVAABatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicRPCServiceServer).GetSignedVAABatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/publicrpc.v1.PublicRPCService/GetSignedVAABatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicRPCServiceServer).GetSignedVAABatch(ctx, req.(*GetSignedVAABatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PublicRPCService_GetSignedVAARange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSignedVAARangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicRPCServiceServer).GetSignedVAARange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/publicrpc.v1.PublicRPCService/GetSignedVAARange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicRPCServiceServer).GetSignedVAARange(ctx, req.(*GetSignedVAARangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PublicRPCService_GetCurrentGuardianSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetCurrentGuardianSetRequest)
 	if err := dec(in); err != nil {
@@ -210,6 +411,24 @@ func _PublicRPCService_GetCurrentGuardianSet_Handler(srv interface{}, ctx contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PublicRPCService_GetGuardianSetHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGuardianSetHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicRPCServiceServer).GetGuardianSetHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/publicrpc.v1.PublicRPCService/GetGuardianSetHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicRPCServiceServer).GetGuardianSetHistory(ctx, req.(*GetGuardianSetHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PublicRPCService_GovernorGetAvailableNotionalByChain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GovernorGetAvailableNotionalByChainRequest)
 	if err := dec(in); err != nil {
@@ -264,6 +483,24 @@ func _PublicRPCService_GovernorIsVAAEnqueued_Handler(srv interface{}, ctx contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PublicRPCService_GovernorGetQueuePosition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GovernorGetQueuePositionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicRPCServiceServer).GovernorGetQueuePosition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/publicrpc.v1.PublicRPCService/GovernorGetQueuePosition",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicRPCServiceServer).GovernorGetQueuePosition(ctx, req.(*GovernorGetQueuePositionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PublicRPCService_GovernorGetTokenList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GovernorGetTokenListRequest)
 	if err := dec(in); err != nil {
@@ -282,6 +519,45 @@ func _PublicRPCService_GovernorGetTokenList_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PublicRPCService_GovernorGetNetworkStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GovernorGetNetworkStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicRPCServiceServer).GovernorGetNetworkStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/publicrpc.v1.PublicRPCService/GovernorGetNetworkStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicRPCServiceServer).GovernorGetNetworkStatus(ctx, req.(*GovernorGetNetworkStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PublicRPCService_SubscribeSignedVAA_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeSignedVAARequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PublicRPCServiceServer).SubscribeSignedVAA(m, &publicRPCServiceSubscribeSignedVAAServer{stream})
+}
+
+type PublicRPCService_SubscribeSignedVAAServer interface {
+	Send(*SubscribeSignedVAAResponse) error
+	grpc.ServerStream
+}
+
+type publicRPCServiceSubscribeSignedVAAServer struct {
+	grpc.ServerStream
+}
+
+func (x *publicRPCServiceSubscribeSignedVAAServer) Send(m *SubscribeSignedVAAResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // PublicRPCService_ServiceDesc is the grpc.ServiceDesc for PublicRPCService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -297,10 +573,26 @@ var PublicRPCService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetSignedVAA",
 			Handler:    _PublicRPCService_GetSignedVAA_Handler,
 		},
+		{
+			MethodName: "GetSignedVAAByTxHash",
+			Handler:    _PublicRPCService_GetSignedVAAByTxHash_Handler,
+		},
+		{
+			MethodName: "GetSignedVAABatch",
+			Handler:    _PublicRPCService_GetSignedVAABatch_Handler,
+		},
+		{
+			MethodName: "GetSignedVAARange",
+			Handler:    _PublicRPCService_GetSignedVAARange_Handler,
+		},
 		{
 			MethodName: "GetCurrentGuardianSet",
 			Handler:    _PublicRPCService_GetCurrentGuardianSet_Handler,
 		},
+		{
+			MethodName: "GetGuardianSetHistory",
+			Handler:    _PublicRPCService_GetGuardianSetHistory_Handler,
+		},
 		{
 			MethodName: "GovernorGetAvailableNotionalByChain",
 			Handler:    _PublicRPCService_GovernorGetAvailableNotionalByChain_Handler,
@@ -313,11 +605,25 @@ var PublicRPCService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GovernorIsVAAEnqueued",
 			Handler:    _PublicRPCService_GovernorIsVAAEnqueued_Handler,
 		},
+		{
+			MethodName: "GovernorGetQueuePosition",
+			Handler:    _PublicRPCService_GovernorGetQueuePosition_Handler,
+		},
 		{
 			MethodName: "GovernorGetTokenList",
 			Handler:    _PublicRPCService_GovernorGetTokenList_Handler,
 		},
+		{
+			MethodName: "GovernorGetNetworkStatus",
+			Handler:    _PublicRPCService_GovernorGetNetworkStatus_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeSignedVAA",
+			Handler:       _PublicRPCService_SubscribeSignedVAA_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "publicrpc/v1/publicrpc.proto",
 }
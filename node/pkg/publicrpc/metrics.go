@@ -0,0 +1,117 @@
+package publicrpc
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	publicrpcv1 "github.com/certusone/wormhole/node/pkg/proto/publicrpc/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	requestsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "wormhole_publicrpc_requests_total",
+			Help: "Total number of public RPC calls by method, listener (tcp or socket) and status code",
+		}, []string{"method", "listener", "code"})
+	requestDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "wormhole_publicrpc_request_duration_seconds",
+			Help:    "Latency of unary public RPC calls by method and listener (tcp or socket)",
+			Buckets: []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30},
+		}, []string{"method", "listener"})
+	responseSize = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "wormhole_publicrpc_response_size_bytes",
+			Help:    "Size of the public RPC responses, and of each message of streaming calls, in protobuf encoding by method and listener (tcp or socket)",
+			Buckets: prometheus.ExponentialBuckets(256, 4, 8),
+		}, []string{"method", "listener"})
+)
+
+// Listeners of the public RPC, as reported in the metrics. publicweb forwards its calls to the unix socket.
+const (
+	ListenerTCP    = "tcp"
+	ListenerSocket = "socket"
+)
+
+// MetricsServerOptions returns the options that make a gRPC server serving the public RPC on listener record per method metrics
+// for its calls. If slowRequestThreshold is not zero, the unary calls which take longer than it are logged.
+func MetricsServerOptions(logger *zap.Logger, listener string, slowRequestThreshold time.Duration) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(metricsUnaryInterceptor(logger, listener, slowRequestThreshold)),
+		grpc.ChainStreamInterceptor(metricsStreamInterceptor(listener)),
+	}
+}
+
+func metricsUnaryInterceptor(logger *zap.Logger, listener string, slowRequestThreshold time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		method, ok := publicMethodName(info.FullMethod)
+		if !ok {
+			return handler(ctx, req)
+		}
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		elapsed := time.Since(start)
+
+		code := status.Code(err).String()
+		size := 0
+		if m, ok := resp.(proto.Message); ok && err == nil {
+			size = proto.Size(m)
+			responseSize.WithLabelValues(method, listener).Observe(float64(size))
+		}
+		requestsTotal.WithLabelValues(method, listener, code).Inc()
+		requestDuration.WithLabelValues(method, listener).Observe(elapsed.Seconds())
+
+		if slowRequestThreshold != 0 && elapsed > slowRequestThreshold {
+			logger.Warn("slow public RPC request",
+				zap.String("method", method),
+				zap.String("listener", listener),
+				zap.String("code", code),
+				zap.Duration("duration", elapsed),
+				zap.Int("responseBytes", size),
+			)
+		}
+		return resp, err
+	}
+}
+
+func metricsStreamInterceptor(listener string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		method, ok := publicMethodName(info.FullMethod)
+		if !ok {
+			return handler(srv, stream)
+		}
+		err := handler(srv, &sizeRecordingStream{ServerStream: stream, size: responseSize.WithLabelValues(method, listener)})
+		requestsTotal.WithLabelValues(method, listener, status.Code(err).String()).Inc()
+		return err
+	}
+}
+
+// publicMethodPrefix is the prefix of the full method names of the public RPC service. The servers of the public RPC also serve the
+// gRPC health and reflection services, which are neither measured nor rate limited.
+var publicMethodPrefix = "/" + publicrpcv1.PublicRPCService_ServiceDesc.ServiceName + "/"
+
+// publicMethodName returns the name of the public RPC method of fullMethod, or false if it is not a method of the public RPC service.
+func publicMethodName(fullMethod string) (string, bool) {
+	return strings.CutPrefix(fullMethod, publicMethodPrefix)
+}
+
+// sizeRecordingStream records the size of the messages sent on a stream. Streams are long lived, so their latency is not recorded.
+type sizeRecordingStream struct {
+	grpc.ServerStream
+	size prometheus.Observer
+}
+
+func (s *sizeRecordingStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if msg, ok := m.(proto.Message); ok && err == nil {
+		s.size.Observe(float64(proto.Size(msg)))
+	}
+	return err
+}
@@ -0,0 +1,43 @@
+package publicrpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	publicrpcv1 "github.com/certusone/wormhole/node/pkg/proto/publicrpc/v1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMetricsUnaryInterceptor(t *testing.T) {
+	core, logs := observer.New(zapcore.WarnLevel)
+	interceptor := metricsUnaryInterceptor(zap.New(core), "test", 10*time.Millisecond)
+	info := &grpc.UnaryServerInfo{FullMethod: "/publicrpc.v1.PublicRPCService/GetSignedVAA"}
+
+	_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &publicrpcv1.GetSignedVAAResponse{VaaBytes: make([]byte, 100)}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, float64(1), testutil.ToFloat64(requestsTotal.WithLabelValues("GetSignedVAA", "test", "OK")))
+	assert.Equal(t, 1, testutil.CollectAndCount(responseSize, "wormhole_publicrpc_response_size_bytes"))
+	assert.Empty(t, logs.All())
+
+	// Calls slower than the threshold are logged.
+	_, err = interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		time.Sleep(20 * time.Millisecond)
+		return nil, status.Error(codes.NotFound, "requested VAA not found in store")
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, float64(1), testutil.ToFloat64(requestsTotal.WithLabelValues("GetSignedVAA", "test", "NotFound")))
+	require.Len(t, logs.All(), 1)
+	assert.Equal(t, "slow public RPC request", logs.All()[0].Message)
+	assert.Equal(t, "NotFound", logs.All()[0].ContextMap()["code"])
+}
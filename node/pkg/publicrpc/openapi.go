@@ -0,0 +1,9 @@
+package publicrpc
+
+import _ "embed"
+
+// OpenAPISpec is the OpenAPI (Swagger 2.0) specification of the REST API of the public RPC service, which publicweb serves next to
+// the API itself. It is generated from the service definition with buf.gen.openapi.yaml.
+//
+//go:embed publicrpc.swagger.json
+var OpenAPISpec []byte
@@ -0,0 +1,31 @@
+package publicrpc
+
+import (
+	"encoding/json"
+	"testing"
+
+	publicrpcv1 "github.com/certusone/wormhole/node/pkg/proto/publicrpc/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOpenAPISpec checks that the spec was regenerated along with the service, so that it documents every method.
+func TestOpenAPISpec(t *testing.T) {
+	var spec struct {
+		Paths map[string]map[string]struct {
+			OperationID string `json:"operationId"`
+		} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(OpenAPISpec, &spec))
+
+	operations := map[string]bool{}
+	for _, methods := range spec.Paths {
+		for _, op := range methods {
+			operations[op.OperationID] = true
+		}
+	}
+	methods := publicrpcv1.File_publicrpc_v1_publicrpc_proto.Services().ByName("PublicRPCService").Methods()
+	for i := 0; i < methods.Len(); i++ {
+		assert.True(t, operations["PublicRPCService_"+string(methods.Get(i).Name())], "method %s is missing from the spec", methods.Get(i).Name())
+	}
+}
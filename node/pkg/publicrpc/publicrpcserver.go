@@ -3,16 +3,65 @@ package publicrpc
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/certusone/wormhole/node/pkg/common"
 	"github.com/certusone/wormhole/node/pkg/db"
 	"github.com/certusone/wormhole/node/pkg/governor"
 	publicrpcv1 "github.com/certusone/wormhole/node/pkg/proto/publicrpc/v1"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/gagliardetto/solana-go"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/mr-tron/base58"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+)
+
+// guardianSetRetryDelay is how long clients are asked to wait before retrying a request that needs the guardian set before it is known.
+const guardianSetRetryDelay = 5 * time.Second
+
+// signedVAACacheSize is the number of signed VAAs served by GetSignedVAA which are kept in memory, so that the fresh VAAs which many
+// relayers poll for are read from the database only once.
+const signedVAACacheSize = 4096
+
+// guardianSetExpiry is how long the core contracts keep accepting VAAs signed by a guardian set after the next one was installed.
+const guardianSetExpiry = 24 * time.Hour
+
+// maxSignedVAABatchSize is the maximum number of message IDs of a GetSignedVAABatch request.
+const maxSignedVAABatchSize = 1000
+
+const (
+	// maxSignedVAASubscriptions is the maximum number of concurrent SubscribeSignedVAA streams of a server.
+	maxSignedVAASubscriptions = 100
+
+	// signedVAASubscriptionBufferSize is the number of newly stored VAAs buffered for each SubscribeSignedVAA stream. A client which
+	// falls further behind is disconnected.
+	signedVAASubscriptionBufferSize = 1000
+
+	// subscriptionRetryDelay is how long clients are asked to wait before subscribing again when there are too many subscriptions.
+	subscriptionRetryDelay = 30 * time.Second
+)
+
+var (
+	signedVAASubscriptions = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "wormhole_publicrpc_signed_vaa_subscriptions",
+			Help: "Current number of SubscribeSignedVAA streams",
+		})
+	signedVAACacheLookups = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "wormhole_publicrpc_signed_vaa_cache_lookups_total",
+			Help: "Total number of GetSignedVAA lookups in the signed VAA cache, by result (hit or miss)",
+		}, []string{"result"})
 )
 
 // PublicrpcServer implements the publicrpc gRPC service.
@@ -22,6 +71,14 @@ type PublicrpcServer struct {
 	db     *db.Database
 	gst    *common.GuardianSetState
 	gov    *governor.ChainGovernor
+
+	// vaaCache maps the IDs of recently served signed VAAs to their bytes. A stored VAA may be replaced by the same VAA with another
+	// set of signatures, or purged, so the cached VAA may differ from the database for a while, but it is always validly signed.
+	// Nil if there is no cache.
+	vaaCache *lru.Cache
+
+	// subscriptions is the number of SubscribeSignedVAA streams.
+	subscriptions atomic.Int32
 }
 
 func NewPublicrpcServer(
@@ -30,18 +87,34 @@ func NewPublicrpcServer(
 	gst *common.GuardianSetState,
 	gov *governor.ChainGovernor,
 ) *PublicrpcServer {
+	vaaCache, err := lru.New(signedVAACacheSize)
+	if err != nil {
+		panic(err) // Only fails if the size isn't positive.
+	}
 	return &PublicrpcServer{
-		logger: logger.Named("publicrpcserver"),
-		db:     db,
-		gst:    gst,
-		gov:    gov,
+		logger:   logger.Named("publicrpcserver"),
+		db:       db,
+		gst:      gst,
+		gov:      gov,
+		vaaCache: vaaCache,
 	}
 }
 
 func (s *PublicrpcServer) GetLastHeartbeats(ctx context.Context, req *publicrpcv1.GetLastHeartbeatsRequest) (*publicrpcv1.GetLastHeartbeatsResponse, error) {
 	gs := s.gst.Get()
 	if gs == nil {
-		return nil, status.Error(codes.Unavailable, "guardian set not fetched from chain yet")
+		return nil, common.NewRetryableGrpcError(codes.Unavailable, common.ReasonGuardianSetNotReady, "guardian set not fetched from chain yet", guardianSetRetryDelay)
+	}
+
+	var guardians map[ethcommon.Address]bool
+	if len(req.GuardianAddrs) > 0 {
+		guardians = make(map[ethcommon.Address]bool, len(req.GuardianAddrs))
+		for _, addr := range req.GuardianAddrs {
+			if !ethcommon.IsHexAddress(addr) {
+				return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidGuardianAddress, fmt.Sprintf("invalid guardian address %q", addr))
+			}
+			guardians[ethcommon.HexToAddress(addr)] = true
+		}
 	}
 
 	resp := &publicrpcv1.GetLastHeartbeatsResponse{
@@ -50,12 +123,51 @@ func (s *PublicrpcServer) GetLastHeartbeats(ctx context.Context, req *publicrpcv
 
 	// Fetch all heartbeats (including from nodes not in the guardian set - which
 	// can happen either with --disableHeartbeatVerify or when the guardian set changes)
-	for addr, v := range s.gst.GetAll() {
+	all := s.gst.GetAll()
+
+	// The lag of a node is measured against the highest heights reported by all nodes, not only the selected ones.
+	type heights struct{ height, finalizedHeight int64 }
+	highest := map[uint32]heights{}
+	for _, v := range all {
+		for _, hb := range v {
+			for _, n := range hb.GetNetworks() {
+				h := highest[n.Id]
+				if n.Height > h.height {
+					h.height = n.Height
+				}
+				if n.FinalizedHeight > h.finalizedHeight {
+					h.finalizedHeight = n.FinalizedHeight
+				}
+				highest[n.Id] = h
+			}
+		}
+	}
+
+	for addr, v := range all {
+		if guardians != nil && !guardians[addr] {
+			continue
+		}
 		for peerId, hb := range v {
+			networks := make([]*publicrpcv1.GetLastHeartbeatsResponse_Network, 0, len(hb.GetNetworks()))
+			for _, n := range hb.GetNetworks() {
+				network := &publicrpcv1.GetLastHeartbeatsResponse_Network{
+					ChainId:         publicrpcv1.ChainID(n.Id),
+					Height:          n.Height,
+					SafeHeight:      n.SafeHeight,
+					FinalizedHeight: n.FinalizedHeight,
+					ErrorCount:      n.ErrorCount,
+					HeightLag:       highest[n.Id].height - n.Height,
+				}
+				if n.FinalizedHeight != 0 {
+					network.FinalizedHeightLag = highest[n.Id].finalizedHeight - n.FinalizedHeight
+				}
+				networks = append(networks, network)
+			}
 			resp.Entries = append(resp.Entries, &publicrpcv1.GetLastHeartbeatsResponse_Entry{
 				VerifiedGuardianAddr: addr.Hex(),
 				P2PNodeAddr:          peerId.String(),
 				RawHeartbeat:         hb,
+				Networks:             networks,
 			})
 		}
 	}
@@ -64,46 +176,170 @@ func (s *PublicrpcServer) GetLastHeartbeats(ctx context.Context, req *publicrpcv
 }
 
 func (s *PublicrpcServer) GetSignedVAA(ctx context.Context, req *publicrpcv1.GetSignedVAARequest) (*publicrpcv1.GetSignedVAAResponse, error) {
-	if req.MessageId == nil {
-		return nil, status.Error(codes.InvalidArgument, "no message ID specified")
+	id, err := parseMessageID(req.MessageId)
+	if err != nil {
+		return nil, err
 	}
 
-	chainID := vaa.ChainID(req.MessageId.EmitterChain.Number())
+	if s.vaaCache != nil {
+		if b, ok := s.vaaCache.Get(id); ok {
+			signedVAACacheLookups.WithLabelValues("hit").Inc()
+			return &publicrpcv1.GetSignedVAAResponse{
+				VaaBytes: b.([]byte),
+			}, nil
+		}
+		signedVAACacheLookups.WithLabelValues("miss").Inc()
+	}
+
+	b, err := s.db.GetSignedVAABytes(id)
 
-	address, err := hex.DecodeString(req.MessageId.EmitterAddress)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("failed to decode address: %v", err))
+		if err == db.ErrVAANotFound {
+			return nil, common.NewGrpcError(codes.NotFound, common.ReasonVAANotFound, err.Error())
+		}
+		s.logger.Error("failed to fetch VAA", zap.Error(err), zap.Any("request", req))
+		return nil, common.NewGrpcError(codes.Internal, common.ReasonInternal, "internal server error")
 	}
-	if len(address) != 32 {
-		return nil, status.Error(codes.InvalidArgument, "address must be 32 bytes")
+
+	if s.vaaCache != nil {
+		s.vaaCache.Add(id, b)
 	}
 
+	return &publicrpcv1.GetSignedVAAResponse{
+		VaaBytes: b,
+	}, nil
+}
+
+// parseEmitterAddress parses a hex-encoded emitter address of a request.
+func parseEmitterAddress(s string) (vaa.Address, error) {
+	address, err := hex.DecodeString(s)
+	if err != nil {
+		return vaa.Address{}, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidEmitterAddress, fmt.Sprintf("failed to decode address: %v", err))
+	}
+	if len(address) != 32 {
+		return vaa.Address{}, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidEmitterAddress, "address must be 32 bytes")
+	}
 	addr := vaa.Address{}
 	copy(addr[:], address)
+	return addr, nil
+}
 
-	b, err := s.db.GetSignedVAABytes(db.VAAID{
-		EmitterChain:   chainID,
+// parseMessageID parses the message ID of a request.
+func parseMessageID(id *publicrpcv1.MessageID) (db.VAAID, error) {
+	if id == nil {
+		return db.VAAID{}, common.NewGrpcError(codes.InvalidArgument, common.ReasonMissingMessageID, "no message ID specified")
+	}
+	addr, err := parseEmitterAddress(id.EmitterAddress)
+	if err != nil {
+		return db.VAAID{}, err
+	}
+	return db.VAAID{
+		EmitterChain:   vaa.ChainID(id.EmitterChain.Number()),
 		EmitterAddress: addr,
-		Sequence:       req.MessageId.Sequence,
-	})
+		Sequence:       id.Sequence,
+	}, nil
+}
 
+func (s *PublicrpcServer) GetSignedVAABatch(ctx context.Context, req *publicrpcv1.GetSignedVAABatchRequest) (*publicrpcv1.GetSignedVAABatchResponse, error) {
+	if len(req.MessageIds) > maxSignedVAABatchSize {
+		return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonBatchTooLarge, fmt.Sprintf("at most %d message IDs can be requested at once", maxSignedVAABatchSize))
+	}
+	ids := make([]db.VAAID, 0, len(req.MessageIds))
+	for _, m := range req.MessageIds {
+		id, err := parseMessageID(m)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	vaas, err := s.db.GetSignedVAABytesBatch(ids)
 	if err != nil {
-		if err == db.ErrVAANotFound {
-			return nil, status.Error(codes.NotFound, err.Error())
+		s.logger.Error("failed to fetch VAAs", zap.Error(err), zap.Int("count", len(ids)))
+		return nil, common.NewGrpcError(codes.Internal, common.ReasonInternal, "internal server error")
+	}
+
+	return &publicrpcv1.GetSignedVAABatchResponse{
+		VaaBytes: vaas,
+	}, nil
+}
+
+func (s *PublicrpcServer) GetSignedVAARange(ctx context.Context, req *publicrpcv1.GetSignedVAARangeRequest) (*publicrpcv1.GetSignedVAARangeResponse, error) {
+	if req.EmitterChain.Number() == 0 || req.EmitterChain.Number() > 0xffff {
+		return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidChainID, fmt.Sprintf("invalid emitter chain %d", req.EmitterChain.Number()))
+	}
+	addr, err := parseEmitterAddress(req.EmitterAddress)
+	if err != nil {
+		return nil, err
+	}
+	if req.ToSequence != 0 && req.ToSequence < req.FromSequence {
+		return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidSequenceRange, "to_sequence must not be before from_sequence")
+	}
+
+	page, err := s.db.GetSignedVAARange(db.SignedVAARangeQuery{
+		EmitterChain:      vaa.ChainID(req.EmitterChain.Number()),
+		EmitterAddress:    addr,
+		FromSequence:      req.FromSequence,
+		ToSequence:        req.ToSequence,
+		Limit:             int(req.Limit),
+		ContinuationToken: req.ContinuationToken,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrInvalidContinuationToken) {
+			return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidContinuationToken, err.Error())
 		}
-		s.logger.Error("failed to fetch VAA", zap.Error(err), zap.Any("request", req))
-		return nil, status.Error(codes.Internal, "internal server error")
+		s.logger.Error("failed to fetch VAA range", zap.Error(err), zap.Any("request", req))
+		return nil, common.NewGrpcError(codes.Internal, common.ReasonInternal, "internal server error")
 	}
 
-	return &publicrpcv1.GetSignedVAAResponse{
-		VaaBytes: b,
+	return &publicrpcv1.GetSignedVAARangeResponse{
+		VaaBytes:          page.VAAs,
+		ContinuationToken: page.ContinuationToken,
 	}, nil
 }
 
+func (s *PublicrpcServer) GetSignedVAAByTxHash(ctx context.Context, req *publicrpcv1.GetSignedVAAByTxHashRequest) (*publicrpcv1.GetSignedVAAByTxHashResponse, error) {
+	if req.EmitterChain.Number() == 0 || req.EmitterChain.Number() > 0xffff {
+		return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidChainID, fmt.Sprintf("invalid emitter chain %d", req.EmitterChain.Number()))
+	}
+	txID, err := parseTxID(req.TxHash)
+	if err != nil {
+		return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidTxHash, err.Error())
+	}
+
+	vaas, err := s.db.GetSignedVAAsByTxHash(vaa.ChainID(req.EmitterChain.Number()), txID)
+	if err != nil {
+		s.logger.Error("failed to fetch VAAs by tx hash", zap.Error(err), zap.Any("request", req))
+		return nil, common.NewGrpcError(codes.Internal, common.ReasonInternal, "internal server error")
+	}
+	if len(vaas) == 0 {
+		return nil, common.NewGrpcError(codes.NotFound, common.ReasonVAANotFound, "no signed VAA found for this transaction")
+	}
+
+	return &publicrpcv1.GetSignedVAAByTxHashResponse{
+		VaaBytes: vaas,
+	}, nil
+}
+
+// parseTxID decodes the transaction of a GetSignedVAAByTxHash request. It is a Solana transaction signature, or the address of a
+// message account, either hex-encoded or base58-encoded as Solana explorers show them.
+func parseTxID(s string) ([]byte, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		if b, err = base58.Decode(s); err != nil {
+			return nil, errors.New("tx hash must be hex or base58 encoded")
+		}
+	}
+	if len(b) != solana.SignatureLength && len(b) != solana.PublicKeyLength {
+		return nil, fmt.Errorf("tx hash must be a %d-byte transaction signature or a %d-byte message account", solana.SignatureLength, solana.PublicKeyLength)
+	}
+	return b, nil
+}
+
 func (s *PublicrpcServer) GetCurrentGuardianSet(ctx context.Context, req *publicrpcv1.GetCurrentGuardianSetRequest) (*publicrpcv1.GetCurrentGuardianSetResponse, error) {
 	gs := s.gst.Get()
 	if gs == nil {
-		return nil, status.Error(codes.Unavailable, "guardian set not fetched from chain yet")
+		return nil, common.NewRetryableGrpcError(codes.Unavailable, common.ReasonGuardianSetNotReady, "guardian set not fetched from chain yet", guardianSetRetryDelay)
 	}
 
 	resp := &publicrpcv1.GetCurrentGuardianSetResponse{
@@ -120,6 +356,63 @@ func (s *PublicrpcServer) GetCurrentGuardianSet(ctx context.Context, req *public
 	return resp, nil
 }
 
+func (s *PublicrpcServer) GetGuardianSetHistory(ctx context.Context, req *publicrpcv1.GetGuardianSetHistoryRequest) (*publicrpcv1.GetGuardianSetHistoryResponse, error) {
+	gs := s.gst.Get()
+	if gs == nil {
+		return nil, common.NewRetryableGrpcError(codes.Unavailable, common.ReasonGuardianSetNotReady, "guardian set not fetched from chain yet", guardianSetRetryDelay)
+	}
+
+	entries, err := s.guardianSetUpdates()
+	if err != nil {
+		s.logger.Error("failed to fetch guardian set updates", zap.Error(err))
+		return nil, common.NewGrpcError(codes.Internal, common.ReasonInternal, "internal server error")
+	}
+
+	// The initial guardian set, and the current one on a guardian which has not stored the VAA that installed it, are only
+	// known from the chain.
+	if !slices.ContainsFunc(entries, func(e *publicrpcv1.GetGuardianSetHistoryResponse_Entry) bool { return e.GuardianSet.Index == gs.Index }) {
+		entries = append(entries, &publicrpcv1.GetGuardianSetHistoryResponse_Entry{
+			GuardianSet: &publicrpcv1.GuardianSet{Index: gs.Index, Addresses: gs.KeysAsHexStrings()},
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].GuardianSet.Index < entries[j].GuardianSet.Index })
+
+	for i, e := range entries[:len(entries)-1] {
+		if next := entries[i+1]; next.UpdateVaaTimestamp != 0 {
+			e.ExpirationTime = uint32(time.Unix(int64(next.UpdateVaaTimestamp), 0).Add(guardianSetExpiry).Unix())
+		}
+	}
+
+	return &publicrpcv1.GetGuardianSetHistoryResponse{Entries: entries}, nil
+}
+
+// guardianSetUpdates returns the guardian sets installed by the guardian set update VAAs in the database, which were verified
+// when they were stored.
+func (s *PublicrpcServer) guardianSetUpdates() ([]*publicrpcv1.GetGuardianSetHistoryResponse_Entry, error) {
+	updates, err := s.db.GetGuardianSetUpdates()
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*publicrpcv1.GetGuardianSetHistoryResponse_Entry, 0, len(updates))
+	for _, b := range updates {
+		v, err := vaa.Unmarshal(b)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal guardian set update VAA: %w", err)
+		}
+		var body vaa.BodyGuardianSetUpdate
+		if err := body.Deserialize(v.Payload); err != nil {
+			return nil, fmt.Errorf("failed to deserialize guardian set update VAA: %w", err)
+		}
+		gs := common.GuardianSet{Keys: body.Keys, Index: body.NewIndex}
+		entries = append(entries, &publicrpcv1.GetGuardianSetHistoryResponse_Entry{
+			GuardianSet:        &publicrpcv1.GuardianSet{Index: gs.Index, Addresses: gs.KeysAsHexStrings()},
+			UpdateVaaTimestamp: uint32(v.Timestamp.Unix()),
+			UpdateVaa:          b,
+		})
+	}
+	return entries, nil
+}
+
 func (s *PublicrpcServer) GovernorGetAvailableNotionalByChain(ctx context.Context, req *publicrpcv1.GovernorGetAvailableNotionalByChainRequest) (*publicrpcv1.GovernorGetAvailableNotionalByChainResponse, error) {
 	resp := &publicrpcv1.GovernorGetAvailableNotionalByChainResponse{}
 
@@ -148,14 +441,14 @@ func (s *PublicrpcServer) GovernorIsVAAEnqueued(ctx context.Context, req *public
 	resp := &publicrpcv1.GovernorIsVAAEnqueuedResponse{}
 
 	if req.MessageId == nil {
-		return nil, status.Error(codes.InvalidArgument, "no message ID specified")
+		return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonMissingMessageID, "no message ID specified")
 	}
 
 	if s.gov != nil {
 		var err error
 		resp.IsEnqueued, err = s.gov.IsVAAEnqueued(req.MessageId)
 		if err != nil {
-			return resp, err
+			return resp, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidEmitterAddress, err.Error())
 		}
 	} else {
 		resp.IsEnqueued = false
@@ -164,6 +457,33 @@ func (s *PublicrpcServer) GovernorIsVAAEnqueued(ctx context.Context, req *public
 	return resp, nil
 }
 
+func (s *PublicrpcServer) GovernorGetQueuePosition(ctx context.Context, req *publicrpcv1.GovernorGetQueuePositionRequest) (*publicrpcv1.GovernorGetQueuePositionResponse, error) {
+	var msgId, digest string
+	if req.MessageId != nil {
+		id, err := parseMessageID(req.MessageId)
+		if err != nil {
+			return nil, err
+		}
+		msgId = fmt.Sprintf("%d/%s/%d", id.EmitterChain, id.EmitterAddress, id.Sequence)
+	} else if req.Digest != "" {
+		b, err := hex.DecodeString(strings.TrimPrefix(req.Digest, "0x"))
+		if err != nil {
+			return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidDigest, fmt.Sprintf("failed to decode digest: %v", err))
+		}
+		if len(b) != 32 {
+			return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidDigest, "digest must be 32 bytes")
+		}
+		digest = hex.EncodeToString(b)
+	} else {
+		return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonMissingMessageID, "no message ID or digest specified")
+	}
+
+	if s.gov == nil {
+		return &publicrpcv1.GovernorGetQueuePositionResponse{}, nil
+	}
+	return s.gov.GetQueuePosition(msgId, digest), nil
+}
+
 func (s *PublicrpcServer) GovernorGetTokenList(ctx context.Context, req *publicrpcv1.GovernorGetTokenListRequest) (*publicrpcv1.GovernorGetTokenListResponse, error) {
 	resp := &publicrpcv1.GovernorGetTokenListResponse{}
 
@@ -175,3 +495,91 @@ func (s *PublicrpcServer) GovernorGetTokenList(ctx context.Context, req *publicr
 
 	return resp, nil
 }
+
+func (s *PublicrpcServer) GovernorGetNetworkStatus(ctx context.Context, req *publicrpcv1.GovernorGetNetworkStatusRequest) (*publicrpcv1.GovernorGetNetworkStatusResponse, error) {
+	resp := &publicrpcv1.GovernorGetNetworkStatusResponse{
+		Entries: make([]*publicrpcv1.GovernorGetNetworkStatusResponse_Entry, 0),
+	}
+
+	for addr, status := range s.gst.GetAllGovernorStatuses() {
+		resp.Entries = append(resp.Entries, &publicrpcv1.GovernorGetNetworkStatusResponse_Entry{
+			VerifiedGuardianAddr: addr.Hex(),
+			Status:               status,
+		})
+	}
+	sort.Slice(resp.Entries, func(i, j int) bool {
+		return resp.Entries[i].VerifiedGuardianAddr < resp.Entries[j].VerifiedGuardianAddr
+	})
+
+	return resp, nil
+}
+
+// signedVAAFilter is a parsed SubscribeSignedVAARequest_Filter. The zero emitter address matches every emitter.
+type signedVAAFilter struct {
+	chain   vaa.ChainID
+	emitter vaa.Address
+}
+
+func parseSignedVAAFilters(filters []*publicrpcv1.SubscribeSignedVAARequest_Filter) ([]signedVAAFilter, error) {
+	parsed := make([]signedVAAFilter, 0, len(filters))
+	for _, f := range filters {
+		if f.EmitterChain.Number() == 0 || f.EmitterChain.Number() > 0xffff {
+			return nil, common.NewGrpcError(codes.InvalidArgument, common.ReasonInvalidChainID, fmt.Sprintf("invalid emitter chain %d", f.EmitterChain.Number()))
+		}
+		pf := signedVAAFilter{chain: vaa.ChainID(f.EmitterChain.Number())}
+		if f.EmitterAddress != "" {
+			var err error
+			if pf.emitter, err = parseEmitterAddress(f.EmitterAddress); err != nil {
+				return nil, err
+			}
+		}
+		parsed = append(parsed, pf)
+	}
+	return parsed, nil
+}
+
+func (f signedVAAFilter) matches(v *vaa.VAA) bool {
+	return v.EmitterChain == f.chain && (f.emitter == vaa.Address{} || v.EmitterAddress == f.emitter)
+}
+
+func (s *PublicrpcServer) SubscribeSignedVAA(req *publicrpcv1.SubscribeSignedVAARequest, stream publicrpcv1.PublicRPCService_SubscribeSignedVAAServer) error {
+	filters, err := parseSignedVAAFilters(req.Filters)
+	if err != nil {
+		return err
+	}
+
+	if s.subscriptions.Add(1) > maxSignedVAASubscriptions {
+		s.subscriptions.Add(-1)
+		return common.NewRetryableGrpcError(codes.ResourceExhausted, common.ReasonTooManyStreams, "too many signed VAA subscriptions", subscriptionRetryDelay)
+	}
+	defer s.subscriptions.Add(-1)
+	signedVAASubscriptions.Inc()
+	defer signedVAASubscriptions.Dec()
+
+	updateC, unsubscribe := s.db.SubscribeSignedVAAs(signedVAASubscriptionBufferSize)
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case b, ok := <-updateC:
+			if !ok {
+				return common.NewGrpcError(codes.ResourceExhausted, common.ReasonQueueFull, "the client fell behind the VAAs being stored")
+			}
+			if len(filters) != 0 {
+				v, err := vaa.Unmarshal(b)
+				if err != nil {
+					s.logger.Error("failed to unmarshal stored VAA", zap.Error(err))
+					continue
+				}
+				if !slices.ContainsFunc(filters, func(f signedVAAFilter) bool { return f.matches(v) }) {
+					continue
+				}
+			}
+			if err := stream.Send(&publicrpcv1.SubscribeSignedVAAResponse{VaaBytes: b}); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -2,17 +2,77 @@ package publicrpc
 
 import (
 	"context"
+	"encoding/hex"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
 	"github.com/certusone/wormhole/node/pkg/governor"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
 	publicrpcv1 "github.com/certusone/wormhole/node/pkg/proto/publicrpc/v1"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/gagliardetto/solana-go"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+func assertGrpcError(t *testing.T, err error, code codes.Code, reason common.GrpcErrorReason, msg string) {
+	t.Helper()
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, code, st.Code())
+	assert.Equal(t, msg, st.Message())
+	assert.Equal(t, reason, common.GrpcErrorReasonOf(err))
+}
+
+func TestGetLastHeartbeats(t *testing.T) {
+	gst := common.NewGuardianSetState(nil)
+	server := &PublicrpcServer{logger: zap.NewNop(), gst: gst}
+	guardians := []ethcommon.Address{ethcommon.HexToAddress("0x01"), ethcommon.HexToAddress("0x02")}
+	gst.Set(&common.GuardianSet{Keys: guardians})
+	require.NoError(t, gst.SetHeartbeat(guardians[0], peer.ID("a"), &gossipv1.Heartbeat{NodeName: "a", Networks: []*gossipv1.Heartbeat_Network{
+		{Id: uint32(vaa.ChainIDSolana), Height: 100, FinalizedHeight: 90},
+		{Id: 2, Height: 7},
+	}}))
+	require.NoError(t, gst.SetHeartbeat(guardians[1], peer.ID("b"), &gossipv1.Heartbeat{NodeName: "b", Networks: []*gossipv1.Heartbeat_Network{
+		{Id: uint32(vaa.ChainIDSolana), Height: 110, SafeHeight: 105, FinalizedHeight: 95, ErrorCount: 3},
+	}}))
+
+	resp, err := server.GetLastHeartbeats(context.Background(), &publicrpcv1.GetLastHeartbeatsRequest{})
+	require.NoError(t, err)
+	assert.Len(t, resp.Entries, 2)
+
+	// The lag is measured against all nodes, even if they are filtered out.
+	resp, err = server.GetLastHeartbeats(context.Background(), &publicrpcv1.GetLastHeartbeatsRequest{GuardianAddrs: []string{strings.TrimPrefix(guardians[0].Hex(), "0x")}})
+	require.NoError(t, err)
+	require.Len(t, resp.Entries, 1)
+	assert.Equal(t, guardians[0].Hex(), resp.Entries[0].VerifiedGuardianAddr)
+	assert.Equal(t, "a", resp.Entries[0].RawHeartbeat.NodeName)
+	assert.Equal(t, []*publicrpcv1.GetLastHeartbeatsResponse_Network{
+		{ChainId: publicrpcv1.ChainID_CHAIN_ID_SOLANA, Height: 100, FinalizedHeight: 90, HeightLag: 10, FinalizedHeightLag: 5},
+		{ChainId: publicrpcv1.ChainID(2), Height: 7},
+	}, resp.Entries[0].Networks)
+
+	resp, err = server.GetLastHeartbeats(context.Background(), &publicrpcv1.GetLastHeartbeatsRequest{GuardianAddrs: []string{guardians[1].Hex()}})
+	require.NoError(t, err)
+	require.Len(t, resp.Entries, 1)
+	assert.Equal(t, []*publicrpcv1.GetLastHeartbeatsResponse_Network{
+		{ChainId: publicrpcv1.ChainID_CHAIN_ID_SOLANA, Height: 110, SafeHeight: 105, FinalizedHeight: 95, ErrorCount: 3},
+	}, resp.Entries[0].Networks)
+
+	_, err = server.GetLastHeartbeats(context.Background(), &publicrpcv1.GetLastHeartbeatsRequest{GuardianAddrs: []string{"0x1234"}})
+	assertGrpcError(t, err, codes.InvalidArgument, common.ReasonInvalidGuardianAddress, `invalid guardian address "0x1234"`)
+}
+
 func TestGetSignedVAANoMessage(t *testing.T) {
 	msg := publicrpcv1.GetSignedVAARequest{}
 	ctx := context.Background()
@@ -23,8 +83,7 @@ func TestGetSignedVAANoMessage(t *testing.T) {
 	resp, err := server.GetSignedVAA(ctx, &msg)
 	assert.Nil(t, resp)
 
-	expected_err := status.Error(codes.InvalidArgument, "no message ID specified")
-	assert.Equal(t, expected_err, err)
+	assertGrpcError(t, err, codes.InvalidArgument, common.ReasonMissingMessageID, "no message ID specified")
 }
 
 func TestGetSignedVAANoAddress(t *testing.T) {
@@ -37,8 +96,45 @@ func TestGetSignedVAANoAddress(t *testing.T) {
 	resp, err := server.GetSignedVAA(ctx, &msg)
 	assert.Nil(t, resp)
 
-	expected_err := status.Error(codes.InvalidArgument, "address must be 32 bytes")
-	assert.Equal(t, expected_err, err)
+	assertGrpcError(t, err, codes.InvalidArgument, common.ReasonInvalidEmitterAddress, "address must be 32 bytes")
+}
+
+func TestGetSignedVAACache(t *testing.T) {
+	database, err := db.OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer database.Close()
+	server := NewPublicrpcServer(zap.NewNop(), database, nil, nil)
+	req := &publicrpcv1.GetSignedVAARequest{MessageId: &publicrpcv1.MessageID{
+		EmitterChain:   publicrpcv1.ChainID_CHAIN_ID_SOLANA,
+		EmitterAddress: hex.EncodeToString(make([]byte, 32)),
+		Sequence:       1,
+	}}
+
+	// VAAs which are not found are not cached, since they may be stored later.
+	_, err = server.GetSignedVAA(context.Background(), req)
+	assertGrpcError(t, err, codes.NotFound, common.ReasonVAANotFound, db.ErrVAANotFound.Error())
+	assert.Equal(t, 0, server.vaaCache.Len())
+
+	v := &vaa.VAA{Version: vaa.SupportedVAAVersion, Timestamp: time.Unix(1700000000, 0), EmitterChain: vaa.ChainIDSolana, Sequence: 1}
+	key, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+	v.AddSignature(key, 0)
+	require.NoError(t, database.StoreSignedVAA(v))
+	expected, err := v.Marshal()
+	require.NoError(t, err)
+
+	resp, err := server.GetSignedVAA(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, expected, resp.VaaBytes)
+
+	// Replacing the stored VAA with one signed by another guardian set doesn't change the cached VAA.
+	v.Signatures = nil
+	v.GuardianSetIndex = 1
+	v.AddSignature(key, 0)
+	require.NoError(t, database.StoreSignedVAA(v))
+	resp, err = server.GetSignedVAA(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, expected, resp.VaaBytes)
 }
 
 func TestGetSignedVAABadAddress(t *testing.T) {
@@ -62,8 +158,143 @@ func TestGetSignedVAABadAddress(t *testing.T) {
 	resp, err := server.GetSignedVAA(ctx, &msg)
 	assert.Nil(t, resp)
 
-	expected_err := status.Error(codes.InvalidArgument, "address must be 32 bytes")
-	assert.Equal(t, expected_err, err)
+	assertGrpcError(t, err, codes.InvalidArgument, common.ReasonInvalidEmitterAddress, "address must be 32 bytes")
+}
+
+func TestGetSignedVAAByTxHash(t *testing.T) {
+	database, err := db.OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer database.Close()
+	key, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+	server := &PublicrpcServer{logger: zap.NewNop(), db: database}
+	signature := solana.Signature{1, 2, 3}
+	account := solana.PublicKey{4, 5, 6}
+
+	_, err = server.GetSignedVAAByTxHash(context.Background(), &publicrpcv1.GetSignedVAAByTxHashRequest{EmitterChain: publicrpcv1.ChainID_CHAIN_ID_SOLANA, TxHash: "0102"})
+	assertGrpcError(t, err, codes.InvalidArgument, common.ReasonInvalidTxHash, "tx hash must be a 64-byte transaction signature or a 32-byte message account")
+	_, err = server.GetSignedVAAByTxHash(context.Background(), &publicrpcv1.GetSignedVAAByTxHashRequest{EmitterChain: publicrpcv1.ChainID_CHAIN_ID_SOLANA, TxHash: "not base58!"})
+	assertGrpcError(t, err, codes.InvalidArgument, common.ReasonInvalidTxHash, "tx hash must be hex or base58 encoded")
+	_, err = server.GetSignedVAAByTxHash(context.Background(), &publicrpcv1.GetSignedVAAByTxHashRequest{TxHash: signature.String()})
+	assertGrpcError(t, err, codes.InvalidArgument, common.ReasonInvalidChainID, "invalid emitter chain 0")
+	_, err = server.GetSignedVAAByTxHash(context.Background(), &publicrpcv1.GetSignedVAAByTxHashRequest{EmitterChain: publicrpcv1.ChainID_CHAIN_ID_SOLANA, TxHash: signature.String()})
+	assertGrpcError(t, err, codes.NotFound, common.ReasonVAANotFound, "no signed VAA found for this transaction")
+
+	v := &vaa.VAA{Version: vaa.SupportedVAAVersion, Timestamp: time.Unix(1700000000, 0), EmitterChain: vaa.ChainIDSolana, Sequence: 1}
+	v.AddSignature(key, 0)
+	require.NoError(t, database.StoreSignedVAA(v))
+	require.NoError(t, database.StoreTxHash(signature[:], *db.VaaIDFromVAA(v), v.Timestamp))
+	require.NoError(t, database.StoreTxHash(account[:], *db.VaaIDFromVAA(v), v.Timestamp))
+	expected, err := v.Marshal()
+	require.NoError(t, err)
+
+	// The transaction signature is accepted as shown by Solana explorers or hex-encoded, and the message account as well.
+	for _, txHash := range []string{signature.String(), hex.EncodeToString(signature[:]), "0x" + hex.EncodeToString(signature[:]), account.String(), hex.EncodeToString(account[:])} {
+		resp, err := server.GetSignedVAAByTxHash(context.Background(), &publicrpcv1.GetSignedVAAByTxHashRequest{EmitterChain: publicrpcv1.ChainID_CHAIN_ID_SOLANA, TxHash: txHash})
+		require.NoError(t, err, txHash)
+		assert.Equal(t, [][]byte{expected}, resp.VaaBytes, txHash)
+	}
+}
+
+func TestGetSignedVAABatchAndRange(t *testing.T) {
+	database, err := db.OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer database.Close()
+	key, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+	server := &PublicrpcServer{logger: zap.NewNop(), db: database}
+	emitter := vaa.Address{4}
+
+	expected := make([][]byte, 0, 3)
+	for seq := uint64(1); seq <= 3; seq++ {
+		v := &vaa.VAA{Version: vaa.SupportedVAAVersion, Timestamp: time.Unix(1700000000, 0), EmitterChain: vaa.ChainIDSolana, EmitterAddress: emitter, Sequence: seq}
+		v.AddSignature(key, 0)
+		require.NoError(t, database.StoreSignedVAA(v))
+		b, err := v.Marshal()
+		require.NoError(t, err)
+		expected = append(expected, b)
+	}
+	messageID := func(seq uint64) *publicrpcv1.MessageID {
+		return &publicrpcv1.MessageID{EmitterChain: publicrpcv1.ChainID_CHAIN_ID_SOLANA, EmitterAddress: hex.EncodeToString(emitter[:]), Sequence: seq}
+	}
+
+	batch, err := server.GetSignedVAABatch(context.Background(), &publicrpcv1.GetSignedVAABatchRequest{MessageIds: []*publicrpcv1.MessageID{messageID(3), messageID(4), messageID(1)}})
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{expected[2], nil, expected[0]}, batch.VaaBytes)
+
+	_, err = server.GetSignedVAABatch(context.Background(), &publicrpcv1.GetSignedVAABatchRequest{MessageIds: []*publicrpcv1.MessageID{messageID(1), nil}})
+	assertGrpcError(t, err, codes.InvalidArgument, common.ReasonMissingMessageID, "no message ID specified")
+	_, err = server.GetSignedVAABatch(context.Background(), &publicrpcv1.GetSignedVAABatchRequest{MessageIds: make([]*publicrpcv1.MessageID, maxSignedVAABatchSize+1)})
+	assertGrpcError(t, err, codes.InvalidArgument, common.ReasonBatchTooLarge, "at most 1000 message IDs can be requested at once")
+
+	req := &publicrpcv1.GetSignedVAARangeRequest{EmitterChain: publicrpcv1.ChainID_CHAIN_ID_SOLANA, EmitterAddress: hex.EncodeToString(emitter[:]), FromSequence: 2, Limit: 1}
+	page, err := server.GetSignedVAARange(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{expected[1]}, page.VaaBytes)
+	require.NotEmpty(t, page.ContinuationToken)
+	req.ContinuationToken = page.ContinuationToken
+	page, err = server.GetSignedVAARange(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{expected[2]}, page.VaaBytes)
+	assert.Empty(t, page.ContinuationToken)
+
+	req.ContinuationToken = "invalid"
+	_, err = server.GetSignedVAARange(context.Background(), req)
+	assertGrpcError(t, err, codes.InvalidArgument, common.ReasonInvalidContinuationToken, "invalid continuation token")
+	_, err = server.GetSignedVAARange(context.Background(), &publicrpcv1.GetSignedVAARangeRequest{EmitterChain: publicrpcv1.ChainID_CHAIN_ID_SOLANA, EmitterAddress: hex.EncodeToString(emitter[:]), FromSequence: 3, ToSequence: 2})
+	assertGrpcError(t, err, codes.InvalidArgument, common.ReasonInvalidSequenceRange, "to_sequence must not be before from_sequence")
+	_, err = server.GetSignedVAARange(context.Background(), &publicrpcv1.GetSignedVAARangeRequest{EmitterChain: publicrpcv1.ChainID_CHAIN_ID_SOLANA, EmitterAddress: "AAAA"})
+	assertGrpcError(t, err, codes.InvalidArgument, common.ReasonInvalidEmitterAddress, "address must be 32 bytes")
+}
+
+func TestGetGuardianSetHistory(t *testing.T) {
+	database, err := db.OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer database.Close()
+	key, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+	gst := common.NewGuardianSetState(nil)
+	server := &PublicrpcServer{logger: zap.NewNop(), db: database, gst: gst}
+
+	_, err = server.GetGuardianSetHistory(context.Background(), &publicrpcv1.GetGuardianSetHistoryRequest{})
+	assertGrpcError(t, err, codes.Unavailable, common.ReasonGuardianSetNotReady, "guardian set not fetched from chain yet")
+
+	keys := []ethcommon.Address{ethcommon.HexToAddress("0x01"), ethcommon.HexToAddress("0x02"), ethcommon.HexToAddress("0x03")}
+	activation := time.Unix(1700000000, 0)
+	payloads := [][]byte{
+		vaa.BodyGuardianSetUpdate{Keys: keys[1:2], NewIndex: 1}.Serialize(),
+		vaa.BodyContractUpgrade{ChainID: vaa.ChainIDSolana, NewContract: vaa.Address{1}}.Serialize(),
+		vaa.BodyGuardianSetUpdate{Keys: keys[1:], NewIndex: 2}.Serialize(),
+	}
+	var updates [][]byte
+	for i, payload := range payloads {
+		v := vaa.CreateGovernanceVAA(activation.Add(time.Duration(i)*time.Hour), uint32(i), uint64(i+1), uint32(i), payload)
+		v.AddSignature(key, 0)
+		require.NoError(t, database.StoreSignedVAA(v))
+		b, err := v.Marshal()
+		require.NoError(t, err)
+		updates = append(updates, b)
+	}
+
+	// The initial guardian set is only known from the chain while it is current.
+	gst.Set(&common.GuardianSet{Keys: keys[:1], Index: 0})
+	resp, err := server.GetGuardianSetHistory(context.Background(), &publicrpcv1.GetGuardianSetHistoryRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Entries, 3)
+	assert.Equal(t, &publicrpcv1.GuardianSet{Index: 0, Addresses: []string{keys[0].Hex()}}, resp.Entries[0].GuardianSet)
+	assert.Zero(t, resp.Entries[0].UpdateVaaTimestamp)
+	assert.Equal(t, uint32(activation.Add(24*time.Hour).Unix()), resp.Entries[0].ExpirationTime)
+	assert.Empty(t, resp.Entries[0].UpdateVaa)
+
+	assert.Equal(t, &publicrpcv1.GuardianSet{Index: 1, Addresses: []string{keys[1].Hex()}}, resp.Entries[1].GuardianSet)
+	assert.Equal(t, uint32(activation.Unix()), resp.Entries[1].UpdateVaaTimestamp)
+	assert.Equal(t, uint32(activation.Add(26*time.Hour).Unix()), resp.Entries[1].ExpirationTime)
+	assert.Equal(t, updates[0], resp.Entries[1].UpdateVaa)
+
+	assert.Equal(t, &publicrpcv1.GuardianSet{Index: 2, Addresses: []string{keys[1].Hex(), keys[2].Hex()}}, resp.Entries[2].GuardianSet)
+	assert.Equal(t, uint32(activation.Add(2*time.Hour).Unix()), resp.Entries[2].UpdateVaaTimestamp)
+	assert.Zero(t, resp.Entries[2].ExpirationTime)
+	assert.Equal(t, updates[2], resp.Entries[2].UpdateVaa)
 }
 
 func TestGovernorIsVAAEnqueuedNoMessage(t *testing.T) {
@@ -77,7 +308,107 @@ func TestGovernorIsVAAEnqueuedNoMessage(t *testing.T) {
 	assert.NotPanics(t, func() {
 		_, err := server.GovernorIsVAAEnqueued(ctx, &msg)
 		assert.Error(t, err)
-		expected_err := status.Error(codes.InvalidArgument, "no message ID specified")
-		assert.Equal(t, expected_err, err)
+		assertGrpcError(t, err, codes.InvalidArgument, common.ReasonMissingMessageID, "no message ID specified")
+	})
+}
+
+func TestGovernorGetQueuePosition(t *testing.T) {
+	ctx := context.Background()
+	logger := zap.NewNop()
+	server := &PublicrpcServer{logger: logger, gov: governor.NewChainGovernor(logger, nil, common.GoTest)}
+
+	_, err := server.GovernorGetQueuePosition(ctx, &publicrpcv1.GovernorGetQueuePositionRequest{})
+	assertGrpcError(t, err, codes.InvalidArgument, common.ReasonMissingMessageID, "no message ID or digest specified")
+	_, err = server.GovernorGetQueuePosition(ctx, &publicrpcv1.GovernorGetQueuePositionRequest{MessageId: &publicrpcv1.MessageID{EmitterAddress: "00"}})
+	assertGrpcError(t, err, codes.InvalidArgument, common.ReasonInvalidEmitterAddress, "address must be 32 bytes")
+	_, err = server.GovernorGetQueuePosition(ctx, &publicrpcv1.GovernorGetQueuePositionRequest{Digest: "0x1234"})
+	assertGrpcError(t, err, codes.InvalidArgument, common.ReasonInvalidDigest, "digest must be 32 bytes")
+
+	resp, err := server.GovernorGetQueuePosition(ctx, &publicrpcv1.GovernorGetQueuePositionRequest{Digest: "0x" + strings.Repeat("AB", 32)})
+	require.NoError(t, err)
+	assert.False(t, resp.IsEnqueued)
+
+	// Without a governor nothing is ever enqueued.
+	server.gov = nil
+	resp, err = server.GovernorGetQueuePosition(ctx, &publicrpcv1.GovernorGetQueuePositionRequest{
+		MessageId: &publicrpcv1.MessageID{EmitterChain: publicrpcv1.ChainID_CHAIN_ID_SOLANA, EmitterAddress: strings.Repeat("00", 32), Sequence: 1},
 	})
+	require.NoError(t, err)
+	assert.False(t, resp.IsEnqueued)
+}
+
+func TestGovernorGetNetworkStatus(t *testing.T) {
+	gst := common.NewGuardianSetState(nil)
+	server := &PublicrpcServer{logger: zap.NewNop(), gst: gst}
+
+	resp, err := server.GovernorGetNetworkStatus(context.Background(), &publicrpcv1.GovernorGetNetworkStatusRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Entries)
+
+	now := time.Now().UnixNano()
+	gst.SetGovernorStatus(ethcommon.HexToAddress("0x02"), &gossipv1.ChainGovernorStatus{NodeName: "b", Timestamp: now, ConfigVersion: "0x1234"})
+	gst.SetGovernorStatus(ethcommon.HexToAddress("0x01"), &gossipv1.ChainGovernorStatus{NodeName: "a", Timestamp: now})
+	resp, err = server.GovernorGetNetworkStatus(context.Background(), &publicrpcv1.GovernorGetNetworkStatusRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Entries, 2)
+	assert.Equal(t, ethcommon.HexToAddress("0x01").Hex(), resp.Entries[0].VerifiedGuardianAddr)
+	assert.Equal(t, "a", resp.Entries[0].Status.NodeName)
+	assert.Equal(t, "0x1234", resp.Entries[1].Status.ConfigVersion)
+}
+
+type signedVAAStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan []byte
+}
+
+func (s *signedVAAStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *signedVAAStream) Send(resp *publicrpcv1.SubscribeSignedVAAResponse) error {
+	s.sent <- resp.VaaBytes
+	return nil
+}
+
+func TestSubscribeSignedVAA(t *testing.T) {
+	database, err := db.OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	defer database.Close()
+	key, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+	server := &PublicrpcServer{logger: zap.NewNop(), db: database}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := &signedVAAStream{ctx: ctx, sent: make(chan []byte, 10)}
+
+	err = server.SubscribeSignedVAA(&publicrpcv1.SubscribeSignedVAARequest{
+		Filters: []*publicrpcv1.SubscribeSignedVAARequest_Filter{{EmitterChain: publicrpcv1.ChainID_CHAIN_ID_SOLANA, EmitterAddress: "0102"}},
+	}, stream)
+	assertGrpcError(t, err, codes.InvalidArgument, common.ReasonInvalidEmitterAddress, "address must be 32 bytes")
+
+	emitter := vaa.Address{1}
+	errC := make(chan error, 1)
+	go func() {
+		errC <- server.SubscribeSignedVAA(&publicrpcv1.SubscribeSignedVAARequest{
+			Filters: []*publicrpcv1.SubscribeSignedVAARequest_Filter{{EmitterChain: publicrpcv1.ChainID_CHAIN_ID_SOLANA, EmitterAddress: emitter.String()}},
+		}, stream)
+	}()
+	require.Eventually(t, func() bool { return server.subscriptions.Load() == 1 }, time.Second, time.Millisecond)
+
+	var expected []byte
+	for _, emitter := range []vaa.Address{{2}, {1}} {
+		v := &vaa.VAA{Version: vaa.SupportedVAAVersion, Timestamp: time.Unix(1700000000, 0), EmitterChain: vaa.ChainIDSolana, EmitterAddress: emitter, Sequence: 1}
+		v.AddSignature(key, 0)
+		require.NoError(t, database.StoreSignedVAA(v))
+		expected, err = v.Marshal()
+		require.NoError(t, err)
+	}
+	assert.Equal(t, expected, <-stream.sent)
+
+	cancel()
+	assert.ErrorIs(t, <-errC, context.Canceled)
+	assert.Empty(t, stream.sent)
+	assert.Zero(t, server.subscriptions.Load())
 }
@@ -0,0 +1,216 @@
+package publicrpc
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"net/netip"
+	"sync"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	publicrpcv1 "github.com/certusone/wormhole/node/pkg/proto/publicrpc/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// rateLimiterPruneInterval is how often the rate limiter forgets the clients which have not made any call for long enough to be
+// back to their full burst.
+const rateLimiterPruneInterval = time.Minute
+
+var (
+	rateLimitedCalls = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "wormhole_publicrpc_rate_limited_calls_total",
+			Help: "Total number of public RPC calls rejected by the per client IP rate limiter",
+		}, []string{"method"})
+	rateLimiterClients = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "wormhole_publicrpc_rate_limiter_clients",
+			Help: "Current number of client IPs tracked by the public RPC rate limiter",
+		})
+)
+
+// RateLimitConfig configures the per client IP rate limiting of the public RPC, see NewRateLimiter.
+type RateLimitConfig struct {
+	// Rate is the number of requests per second allowed from each client IP.
+	Rate float64
+	// Burst is the number of requests a client IP may make at once. It is Rate rounded up if zero.
+	Burst int
+	// Weights are the number of requests a call to a method counts as, by method name, e.g. GetSignedVAABatch. Calls to the other
+	// methods count as one request.
+	Weights map[string]int
+}
+
+// RateLimiter limits the rate at which each client IP may call the public RPC. Clients are identified by the IP of their TCP
+// connection, or by the one recorded by ClientIPHandler for publicweb requests. IPv6 clients are identified by their /64 network,
+// since a single host usually has a whole one. Calls made over the unix socket are not limited.
+type RateLimiter struct {
+	limit   rate.Limit
+	burst   int
+	weights map[string]int
+
+	mu        sync.Mutex
+	clients   map[netip.Addr]*rate.Limiter
+	lastPrune time.Time
+}
+
+// NewRateLimiter returns a rate limiter enforcing cfg.
+func NewRateLimiter(cfg RateLimitConfig) (*RateLimiter, error) {
+	if !(cfg.Rate > 0) || math.IsInf(cfg.Rate, 0) {
+		return nil, fmt.Errorf("invalid rate %v, must be positive", cfg.Rate)
+	}
+	burst := cfg.Burst
+	if burst < 0 {
+		return nil, fmt.Errorf("invalid burst %d, must not be negative", burst)
+	}
+	if burst == 0 {
+		burst = int(math.Ceil(cfg.Rate))
+	}
+	methods := publicrpcv1.File_publicrpc_v1_publicrpc_proto.Services().ByName("PublicRPCService").Methods()
+	for method, weight := range cfg.Weights {
+		if methods.ByName(protoreflect.Name(method)) == nil {
+			return nil, fmt.Errorf("unknown public RPC method %q", method)
+		}
+		if weight < 1 || weight > burst {
+			return nil, fmt.Errorf("invalid weight %d for %s, must be between 1 and the burst of %d", weight, method, burst)
+		}
+	}
+	return &RateLimiter{
+		limit:   rate.Limit(cfg.Rate),
+		burst:   burst,
+		weights: cfg.Weights,
+		clients: make(map[netip.Addr]*rate.Limiter),
+	}, nil
+}
+
+// allow charges a call to method to the client ip. If the client is over its limit, the call is not charged and allow returns how
+// long the client should wait before retrying.
+func (l *RateLimiter) allow(ip netip.Addr, method string, now time.Time) (time.Duration, bool) {
+	weight := 1
+	if w, ok := l.weights[method]; ok {
+		weight = w
+	}
+	if ip.Is6() {
+		ip = netip.PrefixFrom(ip, 64).Masked().Addr()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if now.Sub(l.lastPrune) >= rateLimiterPruneInterval {
+		l.pruneLocked(now)
+	}
+	limiter, ok := l.clients[ip]
+	if !ok {
+		limiter = rate.NewLimiter(l.limit, l.burst)
+		l.clients[ip] = limiter
+		rateLimiterClients.Set(float64(len(l.clients)))
+	}
+	r := limiter.ReserveN(now, weight)
+	if delay := r.DelayFrom(now); delay > 0 {
+		r.CancelAt(now)
+		return delay, false
+	}
+	return 0, true
+}
+
+// pruneLocked forgets the clients whose limiter is full, which are the same as new ones. Must be called with the lock held.
+func (l *RateLimiter) pruneLocked(now time.Time) {
+	l.lastPrune = now
+	for ip, limiter := range l.clients {
+		if limiter.TokensAt(now) >= float64(l.burst) {
+			delete(l.clients, ip)
+		}
+	}
+	rateLimiterClients.Set(float64(len(l.clients)))
+}
+
+// check charges a call to fullMethod to the client of ctx, if it has one, and returns an error if the client is over its limit.
+func (l *RateLimiter) check(ctx context.Context, fullMethod string) error {
+	method, ok := publicMethodName(fullMethod)
+	if !ok {
+		return nil
+	}
+	ip, ok := clientIP(ctx)
+	if !ok {
+		return nil
+	}
+	if retryAfter, ok := l.allow(ip, method, time.Now()); !ok {
+		rateLimitedCalls.WithLabelValues(method).Inc()
+		return common.NewRetryableGrpcError(codes.ResourceExhausted, common.ReasonRateLimited, "rate limit exceeded", retryAfter)
+	}
+	return nil
+}
+
+// ServerOptions returns the options that make a gRPC server serving the public RPC enforce the rate limits.
+func (l *RateLimiter) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			if err := l.check(ctx, info.FullMethod); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if err := l.check(stream.Context(), info.FullMethod); err != nil {
+				return err
+			}
+			return handler(srv, stream)
+		}),
+	}
+}
+
+// DialOptions returns the options that make the publicweb gateway enforce the rate limits on the calls it forwards to the public RPC,
+// which reaches it over the unix socket.
+func (l *RateLimiter) DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			if err := l.check(ctx, method); err != nil {
+				return err
+			}
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}),
+		grpc.WithChainStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			if err := l.check(ctx, method); err != nil {
+				return nil, err
+			}
+			return streamer(ctx, desc, cc, method, opts...)
+		}),
+	}
+}
+
+type clientIPKey struct{}
+
+// ClientIPHandler records the IP of the clients of publicweb in the context of their requests, for the rate limiter. The IP is the
+// remote address of the connection, so when publicweb is behind a reverse proxy, the proxy should limit the rate instead.
+func ClientIPHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if addr, err := netip.ParseAddrPort(r.RemoteAddr); err == nil {
+			r = r.WithContext(context.WithValue(r.Context(), clientIPKey{}, addr.Addr().Unmap()))
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// clientIP returns the IP of the client of a call, if it was made over TCP.
+func clientIP(ctx context.Context) (netip.Addr, bool) {
+	if ip, ok := ctx.Value(clientIPKey{}).(netip.Addr); ok {
+		return ip, true
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return netip.Addr{}, false
+	}
+	addr, ok := p.Addr.(*net.TCPAddr)
+	if !ok {
+		return netip.Addr{}, false
+	}
+	return addr.AddrPort().Addr().Unmap(), true
+}
@@ -0,0 +1,103 @@
+package publicrpc
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+)
+
+func TestNewRateLimiter(t *testing.T) {
+	_, err := NewRateLimiter(RateLimitConfig{Rate: 0})
+	assert.EqualError(t, err, "invalid rate 0, must be positive")
+	_, err = NewRateLimiter(RateLimitConfig{Rate: 1, Burst: -1})
+	assert.EqualError(t, err, "invalid burst -1, must not be negative")
+	_, err = NewRateLimiter(RateLimitConfig{Rate: 1, Weights: map[string]int{"GetSignedVAAs": 2}})
+	assert.EqualError(t, err, `unknown public RPC method "GetSignedVAAs"`)
+	_, err = NewRateLimiter(RateLimitConfig{Rate: 2.5, Weights: map[string]int{"GetSignedVAABatch": 4}})
+	assert.EqualError(t, err, "invalid weight 4 for GetSignedVAABatch, must be between 1 and the burst of 3")
+
+	l, err := NewRateLimiter(RateLimitConfig{Rate: 2.5, Weights: map[string]int{"GetSignedVAABatch": 3}})
+	require.NoError(t, err)
+	assert.Equal(t, 3, l.burst)
+}
+
+func TestRateLimiterAllow(t *testing.T) {
+	l, err := NewRateLimiter(RateLimitConfig{Rate: 1, Burst: 3, Weights: map[string]int{"GetSignedVAABatch": 2}})
+	require.NoError(t, err)
+	now := time.Unix(1700000000, 0)
+	client := netip.MustParseAddr("192.0.2.1")
+
+	_, ok := l.allow(client, "GetSignedVAABatch", now)
+	assert.True(t, ok)
+	_, ok = l.allow(client, "GetSignedVAA", now)
+	assert.True(t, ok)
+	retryAfter, ok := l.allow(client, "GetSignedVAA", now)
+	assert.False(t, ok)
+	assert.Equal(t, time.Second, retryAfter)
+
+	// Other clients have their own limit.
+	_, ok = l.allow(netip.MustParseAddr("192.0.2.2"), "GetSignedVAABatch", now)
+	assert.True(t, ok)
+
+	// A rejected call is not charged, so the client can make a call again once a request worth of tokens is back.
+	now = now.Add(time.Second)
+	_, ok = l.allow(client, "GetSignedVAA", now)
+	assert.True(t, ok)
+	_, ok = l.allow(client, "GetSignedVAABatch", now)
+	assert.False(t, ok)
+
+	// The addresses of an IPv6 /64 network share a limit.
+	_, ok = l.allow(netip.MustParseAddr("2001:db8::1"), "GetSignedVAABatch", now)
+	assert.True(t, ok)
+	_, ok = l.allow(netip.MustParseAddr("2001:db8::2"), "GetSignedVAABatch", now)
+	assert.False(t, ok)
+	_, ok = l.allow(netip.MustParseAddr("2001:db8:0:1::1"), "GetSignedVAABatch", now)
+	assert.True(t, ok)
+
+	// Clients which are back to their full burst are forgotten.
+	now = now.Add(rateLimiterPruneInterval)
+	_, ok = l.allow(client, "GetSignedVAA", now)
+	assert.True(t, ok)
+	assert.Len(t, l.clients, 1)
+}
+
+func TestRateLimiterCheck(t *testing.T) {
+	l, err := NewRateLimiter(RateLimitConfig{Rate: 1})
+	require.NoError(t, err)
+	const method = "/publicrpc.v1.PublicRPCService/GetSignedVAA"
+
+	tcpCtx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 1234}})
+	require.NoError(t, l.check(tcpCtx, method))
+	err = l.check(tcpCtx, method)
+	assertGrpcError(t, err, codes.ResourceExhausted, common.ReasonRateLimited, "rate limit exceeded")
+	retryable, delay := common.IsRetryableGrpcError(err)
+	assert.True(t, retryable)
+	assert.Greater(t, delay, time.Duration(0))
+
+	// Calls over the unix socket are not limited.
+	unixCtx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.UnixAddr{Name: "/run/publicrpc.socket", Net: "unix"}})
+	for i := 0; i < 3; i++ {
+		require.NoError(t, l.check(unixCtx, method))
+	}
+
+	// Unless they are forwarded by publicweb, which records the IP of its clients.
+	var webCtx context.Context
+	req := httptest.NewRequest(http.MethodGet, "/v1/heartbeats", nil)
+	req.RemoteAddr = "192.0.2.2:4321"
+	ClientIPHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		webCtx = r.Context()
+	})).ServeHTTP(httptest.NewRecorder(), req)
+	webCtx = peer.NewContext(webCtx, &peer.Peer{Addr: &net.UnixAddr{Name: "/run/publicrpc.socket", Net: "unix"}})
+	require.NoError(t, l.check(webCtx, method))
+	assert.Error(t, l.check(webCtx, method))
+}
@@ -0,0 +1,53 @@
+package query
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	ethCommon "github.com/ethereum/go-ethereum/common"
+)
+
+// AllowedRequesters is the set of addresses whose query requests are served. It is safe for concurrent use, and can be replaced at
+// runtime through the admin service.
+type AllowedRequesters struct {
+	mutex sync.RWMutex
+	addrs map[ethCommon.Address]struct{}
+}
+
+func newAllowedRequesters(addrs map[ethCommon.Address]struct{}) *AllowedRequesters {
+	return &AllowedRequesters{addrs: addrs}
+}
+
+// Contains returns true if the address is allowed to submit queries.
+func (a *AllowedRequesters) Contains(addr ethCommon.Address) bool {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+	_, exists := a.addrs[addr]
+	return exists
+}
+
+// List returns the allowed addresses in sorted order.
+func (a *AllowedRequesters) List() []ethCommon.Address {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+	list := make([]ethCommon.Address, 0, len(a.addrs))
+	for addr := range a.addrs {
+		list = append(list, addr)
+	}
+	sort.Slice(list, func(i, j int) bool { return bytes.Compare(list[i][:], list[j][:]) < 0 })
+	return list
+}
+
+// Set replaces the allowed addresses with a comma separated list, in the format of the `--ccqAllowedRequesters` flag.
+// The current list is left unchanged if the new one is invalid.
+func (a *AllowedRequesters) Set(ccqAllowedRequesters string) error {
+	addrs, err := parseAllowedRequesters(ccqAllowedRequesters)
+	if err != nil {
+		return err
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.addrs = addrs
+	return nil
+}
@@ -0,0 +1,122 @@
+package query
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+	"time"
+
+	ethCommon "github.com/ethereum/go-ethereum/common"
+)
+
+// QueryCostFunc returns the cost of serving a per chain query, in whatever unit the budget is expressed in. Queries which cost
+// nothing are not counted against the budget.
+type QueryCostFunc func(pcq *PerChainQueryRequest) uint64
+
+// IsArchivalQuery returns true if the query is tied to a slot by MinContextSlot rather than evaluated at the latest one, which the
+// RPC node may have to serve from its archive. These are the queries which are expensive to serve; queries of the latest state are not.
+func IsArchivalQuery(pcq *PerChainQueryRequest) bool {
+	switch q := pcq.Query.(type) {
+	case *SolanaAccountQueryRequest:
+		return q.MinContextSlot != 0
+	case *SolanaPdaQueryRequest:
+		return q.MinContextSlot != 0
+	default:
+		return false
+	}
+}
+
+// DefaultQueryCost charges archival queries one unit per account looked up, which is what the RPC node is billed for. Other
+// queries are free.
+func DefaultQueryCost(pcq *PerChainQueryRequest) uint64 {
+	if !IsArchivalQuery(pcq) {
+		return 0
+	}
+	switch q := pcq.Query.(type) {
+	case *SolanaAccountQueryRequest:
+		return uint64(len(q.Accounts))
+	case *SolanaPdaQueryRequest:
+		return uint64(len(q.PDAs))
+	default:
+		return 1
+	}
+}
+
+// QueryBudget limits the archival RPC work each requester can cause per day, independently of any rate limits. Days start at midnight UTC.
+// It is safe for concurrent use.
+type QueryBudget struct {
+	dailyLimit uint64
+	cost       QueryCostFunc
+
+	mutex sync.Mutex
+	day   time.Time
+	usage map[ethCommon.Address]uint64
+}
+
+// QueryBudgetUsage is the budget used by a requester on the current day.
+type QueryBudgetUsage struct {
+	Requester ethCommon.Address
+	Used      uint64
+}
+
+// NewQueryBudget creates a budget of dailyLimit per requester per day. If cost is nil, DefaultQueryCost is used.
+func NewQueryBudget(dailyLimit uint64, cost QueryCostFunc) *QueryBudget {
+	if cost == nil {
+		cost = DefaultQueryCost
+	}
+	return &QueryBudget{
+		dailyLimit: dailyLimit,
+		cost:       cost,
+		usage:      make(map[ethCommon.Address]uint64),
+	}
+}
+
+// DailyLimit returns the budget of each requester per day.
+func (b *QueryBudget) DailyLimit() uint64 {
+	return b.dailyLimit
+}
+
+// Charge deducts the cost of a request from the requester's budget for the day. If the request would exceed the budget, nothing
+// is deducted and false is returned.
+func (b *QueryBudget) Charge(requester ethCommon.Address, req *QueryRequest, now time.Time) (cost uint64, ok bool) {
+	for _, pcq := range req.PerChainQueries {
+		cost += b.cost(pcq)
+	}
+	if cost == 0 {
+		return 0, true
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.rollOver(now)
+
+	used := b.usage[requester]
+	if used+cost > b.dailyLimit || used+cost < used {
+		return cost, false
+	}
+	b.usage[requester] = used + cost
+	return cost, true
+}
+
+// Usage returns the day the usage is counted for and the budget used by every requester that made a request on it, sorted by requester.
+func (b *QueryBudget) Usage(now time.Time) (time.Time, []QueryBudgetUsage) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.rollOver(now)
+
+	usage := make([]QueryBudgetUsage, 0, len(b.usage))
+	for requester, used := range b.usage {
+		usage = append(usage, QueryBudgetUsage{Requester: requester, Used: used})
+	}
+	sort.Slice(usage, func(i, j int) bool { return bytes.Compare(usage[i].Requester[:], usage[j].Requester[:]) < 0 })
+	return b.day, usage
+}
+
+// rollOver resets the usage when a new day starts. It must be called with the mutex held.
+func (b *QueryBudget) rollOver(now time.Time) {
+	day := now.UTC().Truncate(24 * time.Hour)
+	if !day.Equal(b.day) {
+		b.day = day
+		b.usage = make(map[ethCommon.Address]uint64)
+	}
+}
@@ -0,0 +1,81 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// archivalQueryRequestForTesting returns a copy of req whose per chain queries are tied to a historical slot.
+func archivalQueryRequestForTesting(t *testing.T, req *QueryRequest) *QueryRequest {
+	t.Helper()
+	archival := &QueryRequest{Nonce: req.Nonce}
+	for _, pcq := range req.PerChainQueries {
+		switch q := pcq.Query.(type) {
+		case *SolanaAccountQueryRequest:
+			q2 := *q
+			q2.MinContextSlot = 1000
+			archival.PerChainQueries = append(archival.PerChainQueries, &PerChainQueryRequest{ChainId: pcq.ChainId, Query: &q2})
+		case *SolanaPdaQueryRequest:
+			q2 := *q
+			q2.MinContextSlot = 1000
+			archival.PerChainQueries = append(archival.PerChainQueries, &PerChainQueryRequest{ChainId: pcq.ChainId, Query: &q2})
+		default:
+			t.Fatalf("unexpected query type %T", q)
+		}
+	}
+	return archival
+}
+
+func TestDefaultQueryCost(t *testing.T) {
+	accountReq := createSolanaAccountQueryRequestForTesting(t)
+	pdaReq := createSolanaPdaQueryRequestForTesting(t)
+	assert.Equal(t, uint64(2), DefaultQueryCost(archivalQueryRequestForTesting(t, accountReq).PerChainQueries[0]))
+	assert.Equal(t, uint64(1), DefaultQueryCost(archivalQueryRequestForTesting(t, pdaReq).PerChainQueries[0]))
+
+	// Queries of the latest state are free.
+	assert.False(t, IsArchivalQuery(accountReq.PerChainQueries[0]))
+	assert.Equal(t, uint64(0), DefaultQueryCost(accountReq.PerChainQueries[0]))
+	assert.Equal(t, uint64(0), DefaultQueryCost(pdaReq.PerChainQueries[0]))
+}
+
+func TestQueryBudget(t *testing.T) {
+	requester1 := ethCommon.HexToAddress("0x1111111111111111111111111111111111111111")
+	requester2 := ethCommon.HexToAddress("0x2222222222222222222222222222222222222222")
+	req := archivalQueryRequestForTesting(t, createSolanaAccountQueryRequestForTesting(t)) // Costs 2.
+	now := time.Date(2026, 10, 16, 23, 0, 0, 0, time.UTC)
+
+	budget := NewQueryBudget(5, nil)
+	for i := 0; i < 2; i++ {
+		cost, ok := budget.Charge(requester1, req, now)
+		assert.True(t, ok)
+		assert.Equal(t, uint64(2), cost)
+	}
+
+	// A request that does not fit is rejected without using up what is left.
+	_, ok := budget.Charge(requester1, req, now)
+	assert.False(t, ok)
+	_, ok = budget.Charge(requester2, req, now)
+	assert.True(t, ok)
+
+	// Queries of the latest state are not counted, even once the budget is used up.
+	cost, ok := budget.Charge(requester1, createSolanaAccountQueryRequestForTesting(t), now)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(0), cost)
+	_, ok = budget.Charge(ethCommon.HexToAddress("0x3333333333333333333333333333333333333333"), createSolanaAccountQueryRequestForTesting(t), now)
+	assert.True(t, ok)
+
+	day, usage := budget.Usage(now)
+	assert.Equal(t, time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), day)
+	assert.Equal(t, []QueryBudgetUsage{{requester1, 4}, {requester2, 2}}, usage)
+
+	// The budget is restored at midnight UTC.
+	tomorrow := now.Add(time.Hour)
+	_, ok = budget.Charge(requester1, req, tomorrow)
+	assert.True(t, ok)
+	day, usage = budget.Usage(tomorrow)
+	assert.Equal(t, time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC), day)
+	assert.Equal(t, []QueryBudgetUsage{{requester1, 2}}, usage)
+}
@@ -0,0 +1,107 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// localRequestIDPrefix marks the request IDs of queries made by the guardian itself, so their responses can be told apart from
+// those of queries received over the CCQ network.
+const localRequestIDPrefix = "local:"
+
+var errQueryNotSupported = errors.New("queries are not supported on this chain")
+
+// LocalQuerier runs per chain queries against the guardian's own watchers for other components of the guardian. Unlike queries
+// received over the CCQ network, they are not signed, checked against the allowed requesters or budgeted, and the responses are
+// not published.
+type LocalQuerier struct {
+	chainQueryReqC map[vaa.ChainID]chan *PerChainQueryInternal
+
+	mutex   sync.Mutex
+	nextID  uint64
+	pending map[string]chan *PerChainQueryResponseInternal
+}
+
+// NewLocalQuerier creates a LocalQuerier which sends its queries on chainQueryReqC. Chains that are added to the map later can
+// be queried as well.
+func NewLocalQuerier(chainQueryReqC map[vaa.ChainID]chan *PerChainQueryInternal) *LocalQuerier {
+	return &LocalQuerier{
+		chainQueryReqC: chainQueryReqC,
+		pending:        make(map[string]chan *PerChainQueryResponseInternal),
+	}
+}
+
+// Supported returns true if queries can be sent to the watcher of the chain.
+func (lq *LocalQuerier) Supported(chainID vaa.ChainID) bool {
+	_, exists := lq.chainQueryReqC[chainID]
+	return exists && GetPerChainConfig(chainID).QueriesSupported()
+}
+
+// Query sends a query to the watcher of the chain and waits for its response, or until ctx is done. A response that asks for a retry
+// is returned as an error, the caller is expected to query again later.
+func (lq *LocalQuerier) Query(ctx context.Context, chainID vaa.ChainID, q ChainSpecificQuery) (ChainSpecificResponse, error) {
+	if !lq.Supported(chainID) {
+		return nil, fmt.Errorf("%w: %s", errQueryNotSupported, chainID)
+	}
+	req := &PerChainQueryRequest{ChainId: chainID, Query: q}
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid query: %w", err)
+	}
+
+	// The watcher drops responses it cannot hand off immediately, so there must always be room for ours.
+	respC := make(chan *PerChainQueryResponseInternal, 1)
+	lq.mutex.Lock()
+	lq.nextID++
+	requestID := fmt.Sprintf("%s%d", localRequestIDPrefix, lq.nextID)
+	lq.pending[requestID] = respC
+	lq.mutex.Unlock()
+
+	defer func() {
+		lq.mutex.Lock()
+		delete(lq.pending, requestID)
+		lq.mutex.Unlock()
+	}()
+
+	select {
+	case lq.chainQueryReqC[chainID] <- &PerChainQueryInternal{RequestID: requestID, Request: req}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	select {
+	case resp := <-respC:
+		switch resp.Status {
+		case QuerySuccess:
+			return resp.Response, nil
+		case QueryRetryNeeded:
+			return nil, fmt.Errorf("query on %s failed, a retry may succeed", chainID)
+		default:
+			return nil, fmt.Errorf("query on %s failed", chainID)
+		}
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Deliver hands a response from a watcher to the local query waiting for it. It returns false if the response is not for a local query,
+// in which case it should be passed on to the query handler. Responses to local queries that are no longer waiting are dropped.
+func (lq *LocalQuerier) Deliver(resp *PerChainQueryResponseInternal) bool {
+	if !strings.HasPrefix(resp.RequestID, localRequestIDPrefix) {
+		return false
+	}
+	lq.mutex.Lock()
+	respC, exists := lq.pending[resp.RequestID]
+	lq.mutex.Unlock()
+	if exists {
+		select {
+		case respC <- resp:
+		default:
+		}
+	}
+	return true
+}
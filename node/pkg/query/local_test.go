@@ -0,0 +1,40 @@
+package query
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestLocalQuerier(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	reqC := make(chan *PerChainQueryInternal, 1)
+	lq := NewLocalQuerier(map[vaa.ChainID]chan *PerChainQueryInternal{vaa.ChainIDSolana: reqC})
+	q := createSolanaAccountQueryRequestForTesting(t).PerChainQueries[0].Query
+
+	_, err := lq.Query(ctx, vaa.ChainID(2), q)
+	assert.ErrorIs(t, err, errQueryNotSupported)
+
+	// Answer the first query with a retry and the second one successfully, like the watcher does.
+	expected := &SolanaAccountQueryResponse{SlotNumber: 42}
+	go func() {
+		req := <-reqC
+		assert.False(t, lq.Deliver(CreatePerChainQueryResponseInternal("123", 0, vaa.ChainIDSolana, QuerySuccess, expected)))
+		assert.True(t, lq.Deliver(CreatePerChainQueryResponseInternal(req.RequestID, req.RequestIdx, vaa.ChainIDSolana, QueryRetryNeeded, nil)))
+		req = <-reqC
+		assert.True(t, lq.Deliver(CreatePerChainQueryResponseInternal(req.RequestID, req.RequestIdx, vaa.ChainIDSolana, QuerySuccess, expected)))
+	}()
+
+	_, err = lq.Query(ctx, vaa.ChainIDSolana, q)
+	assert.ErrorContains(t, err, "a retry may succeed")
+	resp, err := lq.Query(ctx, vaa.ChainIDSolana, q)
+	require.NoError(t, err)
+	assert.Equal(t, expected, resp)
+	assert.Empty(t, lq.pending)
+}
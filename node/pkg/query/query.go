@@ -43,15 +43,18 @@ func NewQueryHandler(
 	chainQueryReqC map[vaa.ChainID]chan *PerChainQueryInternal,
 	queryResponseReadC <-chan *PerChainQueryResponseInternal,
 	queryResponseWriteC chan<- *QueryResponsePublication,
+	budget *QueryBudget,
 ) *QueryHandler {
 	return &QueryHandler{
 		logger:               logger.With(zap.String("component", "ccq")),
 		env:                  env,
 		allowedRequestorsStr: allowedRequestorsStr,
+		allowedRequestors:    newAllowedRequesters(nil),
 		signedQueryReqC:      signedQueryReqC,
 		chainQueryReqC:       chainQueryReqC,
 		queryResponseReadC:   queryResponseReadC,
 		queryResponseWriteC:  queryResponseWriteC,
+		budget:               budget,
 	}
 }
 
@@ -70,7 +73,8 @@ type (
 		chainQueryReqC       map[vaa.ChainID]chan *PerChainQueryInternal
 		queryResponseReadC   <-chan *PerChainQueryResponseInternal
 		queryResponseWriteC  chan<- *QueryResponsePublication
-		allowedRequestors    map[ethCommon.Address]struct{}
+		allowedRequestors    *AllowedRequesters
+		budget               *QueryBudget
 	}
 
 	// pendingQuery is the cache entry for a given query.
@@ -123,9 +127,7 @@ func (config PerChainConfig) QueriesSupported() bool {
 func (qh *QueryHandler) Start(ctx context.Context) error {
 	qh.logger.Debug("entering Start", zap.String("enforceFlag", qh.allowedRequestorsStr))
 
-	var err error
-	qh.allowedRequestors, err = parseAllowedRequesters(qh.allowedRequestorsStr)
-	if err != nil {
+	if err := qh.allowedRequestors.Set(qh.allowedRequestorsStr); err != nil {
 		return fmt.Errorf("failed to parse allowed requesters: %w", err)
 	}
 
@@ -136,9 +138,19 @@ func (qh *QueryHandler) Start(ctx context.Context) error {
 	return nil
 }
 
+// AllowedRequesters returns the set of addresses whose requests are served. It is populated from the `--ccqAllowedRequesters` flag on Start.
+func (qh *QueryHandler) AllowedRequesters() *AllowedRequesters {
+	return qh.allowedRequestors
+}
+
+// Budget returns the daily budget of each requester, or nil if requests are not budgeted.
+func (qh *QueryHandler) Budget() *QueryBudget {
+	return qh.budget
+}
+
 // handleQueryRequests multiplexes observation requests to the appropriate chain
 func (qh *QueryHandler) handleQueryRequests(ctx context.Context) error {
-	return handleQueryRequestsImpl(ctx, qh.logger, qh.signedQueryReqC, qh.chainQueryReqC, qh.allowedRequestors, qh.queryResponseReadC, qh.queryResponseWriteC, qh.env, RequestTimeout, RetryInterval, AuditInterval)
+	return handleQueryRequestsImpl(ctx, qh.logger, qh.signedQueryReqC, qh.chainQueryReqC, qh.allowedRequestors, qh.budget, qh.queryResponseReadC, qh.queryResponseWriteC, qh.env, RequestTimeout, RetryInterval, AuditInterval)
 }
 
 // handleQueryRequestsImpl allows instantiating the handler in the test environment with shorter timeout and retry parameters.
@@ -147,7 +159,8 @@ func handleQueryRequestsImpl(
 	logger *zap.Logger,
 	signedQueryReqC <-chan *gossipv1.SignedQueryRequest,
 	chainQueryReqC map[vaa.ChainID]chan *PerChainQueryInternal,
-	allowedRequestors map[ethCommon.Address]struct{},
+	allowedRequestors *AllowedRequesters,
+	budget *QueryBudget,
 	queryResponseReadC <-chan *PerChainQueryResponseInternal,
 	queryResponseWriteC chan<- *QueryResponsePublication,
 	env common.Environment,
@@ -156,7 +169,7 @@ func handleQueryRequestsImpl(
 	auditIntervalImpl time.Duration,
 ) error {
 	qLogger := logger.With(zap.String("component", "ccqhandler"))
-	qLogger.Info("cross chain queries are enabled", zap.Any("allowedRequestors", allowedRequestors), zap.String("env", string(env)))
+	qLogger.Info("cross chain queries are enabled", zap.Any("allowedRequestors", allowedRequestors.List()), zap.String("env", string(env)))
 
 	pendingQueries := make(map[string]*pendingQuery) // Key is requestID.
 
@@ -210,7 +223,7 @@ func handleQueryRequestsImpl(
 
 			signerAddress := ethCommon.BytesToAddress(ethCrypto.Keccak256(signerBytes[1:])[12:])
 
-			if _, exists := allowedRequestors[signerAddress]; !exists {
+			if !allowedRequestors.Contains(signerAddress) {
 				qLogger.Debug("invalid requestor", zap.String("requestor", signerAddress.Hex()), zap.String("requestID", requestID))
 				invalidQueryRequestReceived.WithLabelValues("invalid_requestor").Inc()
 				continue
@@ -274,6 +287,14 @@ func handleQueryRequestsImpl(
 				continue
 			}
 
+			if budget != nil {
+				if cost, ok := budget.Charge(signerAddress, &queryRequest, receiveTime); !ok {
+					qLogger.Warn("dropping query request that exceeds the requester's daily budget", zap.String("requestor", signerAddress.Hex()), zap.String("requestID", requestID), zap.Uint64("cost", cost))
+					invalidQueryRequestReceived.WithLabelValues("budget_exceeded").Inc()
+					continue
+				}
+			}
+
 			validQueryRequestsReceived.Inc()
 
 			// Create the pending query and add it to the cache.
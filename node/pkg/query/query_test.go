@@ -131,6 +131,20 @@ func TestParseAllowedRequestersFailsIfInvalidParameter(t *testing.T) {
 	require.Nil(t, ccqAllowedRequestersList)
 }
 
+func TestAllowedRequestersSet(t *testing.T) {
+	allowedRequesters := newAllowedRequesters(nil)
+	require.NoError(t, allowedRequesters.Set("beFA429d57cD18b7F8A4d91A2da9AB4AF05d0FBf,"+testSigner))
+	require.Equal(t, []ethCommon.Address{
+		ethCommon.HexToAddress("beFA429d57cD18b7F8A4d91A2da9AB4AF05d0FBe"),
+		ethCommon.HexToAddress("beFA429d57cD18b7F8A4d91A2da9AB4AF05d0FBf"),
+	}, allowedRequesters.List())
+
+	// An invalid list leaves the current one in place.
+	require.Error(t, allowedRequesters.Set("Hello"))
+	require.True(t, allowedRequesters.Contains(ethCommon.HexToAddress(testSigner)))
+	require.Len(t, allowedRequesters.List(), 2)
+}
+
 // mockData is the data structure used to mock up the query handler environment.
 type mockData struct {
 	sk *ecdsa.PrivateKey
@@ -271,7 +285,7 @@ func createQueryHandlerForTestWithoutPublisher(t *testing.T, ctx context.Context
 	md.resetState()
 
 	go func() {
-		err := handleQueryRequestsImpl(ctx, logger, md.signedQueryReqReadC, md.chainQueryReqC, ccqAllowedRequestersList,
+		err := handleQueryRequestsImpl(ctx, logger, md.signedQueryReqReadC, md.chainQueryReqC, newAllowedRequesters(ccqAllowedRequestersList), nil,
 			md.queryResponseReadC, md.queryResponsePublicationWriteC, common.GoTest, requestTimeoutForTest, retryIntervalForTest, auditIntervalForTest)
 		assert.NoError(t, err)
 	}()
@@ -165,6 +165,20 @@ func PostSignedQueryRequest(signedQueryReqSendC chan<- *gossipv1.SignedQueryRequ
 	}
 }
 
+// IsTargetedAt returns true if the guardian with the given address should answer the request, which is the case if the request does not
+// target specific guardians.
+func IsTargetedAt(req *gossipv1.SignedQueryRequest, guardianAddr ethCommon.Address) bool {
+	if len(req.TargetGuardians) == 0 {
+		return true
+	}
+	for _, target := range req.TargetGuardians {
+		if bytes.Equal(target, guardianAddr.Bytes()) {
+			return true
+		}
+	}
+	return false
+}
+
 func SignedQueryRequestEqual(left *gossipv1.SignedQueryRequest, right *gossipv1.SignedQueryRequest) bool {
 	if !bytes.Equal(left.QueryRequest, right.QueryRequest) {
 		return false
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 
 	"github.com/stretchr/testify/assert"
@@ -139,3 +140,17 @@ func TestSolanaPdaQueryUnmarshalFromSDK(t *testing.T) {
 }
 
 ///////////// End of Solana PDA Query tests ///////////////////////////
+
+func TestIsTargetedAt(t *testing.T) {
+	guardian := ethCommon.HexToAddress("0xbeFA429d57cD18b7F8A4d91A2da9AB4AF05d0FBe")
+	other := ethCommon.HexToAddress("0x88D7D8B32a9105d228100E72dFFe2Fae0705D31c")
+
+	req := &gossipv1.SignedQueryRequest{}
+	assert.True(t, IsTargetedAt(req, guardian))
+
+	req.TargetGuardians = [][]byte{other.Bytes()}
+	assert.False(t, IsTargetedAt(req, guardian))
+
+	req.TargetGuardians = append(req.TargetGuardians, guardian.Bytes())
+	assert.True(t, IsTargetedAt(req, guardian))
+}
@@ -43,6 +43,17 @@ func SetReady(component Component) {
 	}
 }
 
+// Components returns the state of every registered component.
+func Components() map[string]bool {
+	mu.Lock()
+	defer mu.Unlock()
+	components := make(map[string]bool, len(registry))
+	for k, v := range registry {
+		components[k] = v
+	}
+	return components
+}
+
 // Handler returns a net/http handler for the readiness check. It returns 200 OK if all components are ready,
 // or 412 Precondition Failed otherwise. For operator convenience, a list of components and their states
 // is returned as plain text (not meant for machine consumption!).
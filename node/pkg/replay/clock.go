@@ -0,0 +1,71 @@
+package replay
+
+import (
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+)
+
+// mockClock is the clock of the processor during a replay. It is advanced to the time of each record before the record is fed,
+// and keeps track of the tickers and timers created from it, so that the replay can wait for the processor to handle their ticks.
+type mockClock struct {
+	*clock.Mock
+
+	mu sync.Mutex
+	// chans are the channels of the tickers and timers created from the clock.
+	chans []<-chan time.Time
+	// step is the shortest interval of the tickers and timers, which the clock is advanced by at a time so that none of them
+	// skips a tick.
+	step time.Duration
+}
+
+func newMockClock(start time.Time) *mockClock {
+	c := &mockClock{Mock: clock.NewMock()}
+	c.Set(start)
+	return c
+}
+
+func (c *mockClock) Ticker(d time.Duration) *clock.Ticker {
+	t := c.Mock.Ticker(d)
+	c.track(t.C, d)
+	return t
+}
+
+func (c *mockClock) Timer(d time.Duration) *clock.Timer {
+	t := c.Mock.Timer(d)
+	c.track(t.C, d)
+	return t
+}
+
+func (c *mockClock) track(ch <-chan time.Time, d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.chans = append(c.chans, ch)
+	if c.step == 0 || d < c.step {
+		c.step = d
+	}
+}
+
+// nextStep returns the time the clock should be advanced to next on its way to t.
+func (c *mockClock) nextStep(t time.Time) time.Time {
+	c.mu.Lock()
+	step := c.step
+	c.mu.Unlock()
+	if next := c.Now().Add(step); step > 0 && next.Before(t) {
+		return next
+	}
+	return t
+}
+
+// pending returns true if a tick has not been received by the processor yet.
+func (c *mockClock) pending() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, ch := range c.chans {
+		if len(ch) > 0 {
+			return true
+		}
+	}
+	return false
+}
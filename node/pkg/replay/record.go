@@ -0,0 +1,115 @@
+// Package replay feeds a recorded stream of watcher and gossip messages through the processor and reports the decisions it made, so
+// the behavior of two node versions can be compared before an upgrade.
+package replay
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// RecordType identifies what a record contains.
+type RecordType string
+
+const (
+	// RecordGuardianSet sets the guardian set, as the guardian set watcher would.
+	RecordGuardianSet RecordType = "guardianSet"
+	// RecordMessage is a message publication observed by one of our watchers, serialized with MessagePublication.Marshal.
+	RecordMessage RecordType = "message"
+	// RecordGossip is a serialized GossipMessage received from p2p.
+	RecordGossip RecordType = "gossip"
+)
+
+// Record is a single entry of a recording. Recordings are stored as one JSON encoded record per line.
+type Record struct {
+	Time        time.Time          `json:"time"`
+	Type        RecordType         `json:"type"`
+	GuardianSet *GuardianSetRecord `json:"guardianSet,omitempty"`
+	Data        []byte             `json:"data,omitempty"`
+}
+
+// GuardianSetRecord is the JSON representation of a guardian set.
+type GuardianSetRecord struct {
+	Index uint32   `json:"index"`
+	Keys  []string `json:"keys"`
+}
+
+// guardianSet converts the record back into a guardian set.
+func (r *GuardianSetRecord) guardianSet() (*common.GuardianSet, error) {
+	gs := &common.GuardianSet{Index: r.Index}
+	for _, key := range r.Keys {
+		if !ethcommon.IsHexAddress(key) {
+			return nil, fmt.Errorf("invalid guardian key %q", key)
+		}
+		gs.Keys = append(gs.Keys, ethcommon.HexToAddress(key))
+	}
+	return gs, nil
+}
+
+// Writer appends records to a recording.
+type Writer struct {
+	enc *json.Encoder
+}
+
+// NewWriter creates a writer which writes the recording to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{enc: json.NewEncoder(w)}
+}
+
+// WriteGuardianSet records a guardian set update.
+func (w *Writer) WriteGuardianSet(gs *common.GuardianSet, t time.Time) error {
+	return w.enc.Encode(&Record{
+		Time:        t,
+		Type:        RecordGuardianSet,
+		GuardianSet: &GuardianSetRecord{Index: gs.Index, Keys: gs.KeysAsHexStrings()},
+	})
+}
+
+// WriteMessage records a message publication observed by a watcher.
+func (w *Writer) WriteMessage(msg *common.MessagePublication, t time.Time) error {
+	b, err := msg.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal message publication: %w", err)
+	}
+	return w.enc.Encode(&Record{Time: t, Type: RecordMessage, Data: b})
+}
+
+// WriteGossip records a serialized gossip message.
+func (w *Writer) WriteGossip(b []byte, t time.Time) error {
+	return w.enc.Encode(&Record{Time: t, Type: RecordGossip, Data: b})
+}
+
+// ReadRecords reads a whole recording.
+func ReadRecords(r io.Reader) ([]*Record, error) {
+	var records []*Record
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		switch record.Type {
+		case RecordGuardianSet:
+			if record.GuardianSet == nil {
+				return nil, fmt.Errorf("line %d: guardian set record without a guardian set", line)
+			}
+		case RecordMessage, RecordGossip:
+		default:
+			return nil, fmt.Errorf("line %d: unknown record type %q", line, record.Type)
+		}
+		records = append(records, &record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
@@ -0,0 +1,152 @@
+package replay
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+func testMessage(sequence uint64, payload []byte) *common.MessagePublication {
+	return &common.MessagePublication{
+		TxHash:           ethcommon.HexToHash("0x06f541f5ecfc43407c31587aa6ac3a689e8960f36dc23c332db5510dfc6a4063"),
+		Timestamp:        time.Unix(1654516425, 0),
+		Nonce:            123456,
+		Sequence:         sequence,
+		EmitterChain:     vaa.ChainIDSolana,
+		EmitterAddress:   vaa.Address{1},
+		ConsistencyLevel: 32,
+		Payload:          payload,
+	}
+}
+
+// testRecording returns a recording in which a single guardian observes two messages.
+func testRecording(t *testing.T, gk *ecdsa.PrivateKey) []*Record {
+	t.Helper()
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	now := time.Now()
+	require.NoError(t, w.WriteGuardianSet(&common.GuardianSet{Keys: []ethcommon.Address{crypto.PubkeyToAddress(gk.PublicKey)}}, now))
+	require.NoError(t, w.WriteMessage(testMessage(1, []byte("payload one")), now))
+	require.NoError(t, w.WriteMessage(testMessage(2, []byte("payload two")), now))
+
+	records, err := ReadRecords(&buf)
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	return records
+}
+
+func TestReadRecordsRejectsUnknownTypes(t *testing.T) {
+	_, err := ReadRecords(bytes.NewBufferString(`{"type": "bogus"}`))
+	assert.ErrorContains(t, err, `line 1: unknown record type "bogus"`)
+
+	_, err = ReadRecords(bytes.NewBufferString("\n" + `{"type": "guardianSet"}`))
+	assert.ErrorContains(t, err, "line 2: guardian set record without a guardian set")
+}
+
+func TestRun(t *testing.T) {
+	gk, err := ecdsa.GenerateKey(crypto.S256(), bytes.NewReader(bytes.Repeat([]byte{1}, 64)))
+	require.NoError(t, err)
+	records := testRecording(t, gk)
+
+	res, err := Run(context.Background(), zap.NewNop(), records, Config{GuardianKey: gk})
+	require.NoError(t, err)
+	require.Len(t, res.Observations, 2)
+	require.Len(t, res.VAAs, 2)
+	assert.Equal(t, testMessage(1, nil).MessageIDString(), res.Observations[0].MessageID)
+
+	// With a guardian set of one, every observation reaches quorum.
+	for i, o := range res.Observations {
+		assert.Equal(t, o.MessageID, res.VAAs[i].MessageID)
+		assert.Equal(t, o.Digest, res.VAAs[i].Digest)
+	}
+
+	// Replaying the same recording gives the same decisions.
+	again, err := Run(context.Background(), zap.NewNop(), records, Config{GuardianKey: gk})
+	require.NoError(t, err)
+	assert.Empty(t, Diff(res, again))
+
+	// The messages are not token bridge transfers, so the governor lets them through.
+	governed, err := Run(context.Background(), zap.NewNop(), records, Config{GuardianKey: gk, Governor: true})
+	require.NoError(t, err)
+	assert.Empty(t, Diff(res, governed))
+}
+
+func TestRunAdvancesClock(t *testing.T) {
+	gk, err := ecdsa.GenerateKey(crypto.S256(), bytes.NewReader(bytes.Repeat([]byte{1}, 64)))
+	require.NoError(t, err)
+	other, err := ecdsa.GenerateKey(crypto.S256(), bytes.NewReader(bytes.Repeat([]byte{2}, 64)))
+	require.NoError(t, err)
+
+	msg := testMessage(1, []byte("payload"))
+	digest := msg.CreateVAA(0).SigningDigest()
+	sig, err := crypto.Sign(digest.Bytes(), other)
+	require.NoError(t, err)
+	obs, err := proto.Marshal(&gossipv1.GossipMessage{Message: &gossipv1.GossipMessage_SignedObservation{SignedObservation: &gossipv1.SignedObservation{
+		Addr:      crypto.PubkeyToAddress(other.PublicKey).Bytes(),
+		Hash:      digest.Bytes(),
+		Signature: sig,
+		TxHash:    msg.TxHash.Bytes(),
+		MessageId: msg.MessageIDString(),
+	}}})
+	require.NoError(t, err)
+
+	// The other guardian's observation arrives before we observe the message. Quorum is only reached if we observe it before the
+	// processor gives up on the other guardian's signature, which depends on how much time passed on the mock clock.
+	for _, tc := range []struct {
+		delay time.Duration
+		vaas  int
+	}{
+		{delay: time.Minute, vaas: 1},
+		{delay: time.Hour, vaas: 0},
+	} {
+		var buf bytes.Buffer
+		w := NewWriter(&buf)
+		start := time.Unix(1700000000, 0)
+		require.NoError(t, w.WriteGuardianSet(&common.GuardianSet{Keys: []ethcommon.Address{
+			crypto.PubkeyToAddress(gk.PublicKey),
+			crypto.PubkeyToAddress(other.PublicKey),
+		}}, start))
+		require.NoError(t, w.WriteGossip(obs, start))
+		require.NoError(t, w.WriteMessage(msg, start.Add(tc.delay)))
+		records, err := ReadRecords(&buf)
+		require.NoError(t, err)
+
+		res, err := Run(context.Background(), zap.NewNop(), records, Config{GuardianKey: gk})
+		require.NoError(t, err)
+		assert.Len(t, res.Observations, 1, tc.delay)
+		assert.Len(t, res.VAAs, tc.vaas, tc.delay)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	old := &Result{
+		Observations:     []Observation{{MessageID: "1/01/1", Digest: "aa"}, {MessageID: "1/01/2", Digest: "bb"}},
+		VAAs:             []StoredVAA{{MessageID: "1/01/1", Digest: "aa"}, {MessageID: "1/01/2", Digest: "bb"}},
+		GovernorEnqueued: []string{"1/01/3"},
+	}
+	new := &Result{
+		Observations: []Observation{{MessageID: "1/01/1", Digest: "aa"}, {MessageID: "1/01/2", Digest: "cc"}},
+		VAAs:         []StoredVAA{{MessageID: "1/01/1", Digest: "aa"}, {MessageID: "1/01/2", Digest: "cc"}, {MessageID: "1/01/3", Digest: "dd"}},
+	}
+
+	assert.Equal(t, []string{
+		"observation 1/01/2 digest bb: old only",
+		"observation 1/01/2 digest cc: new only",
+		"VAA 1/01/2: digest bb in old, cc in new",
+		"VAA 1/01/3: stored by new only",
+		"governor enqueued 1/01/3: old only",
+	}, Diff(old, new))
+	assert.Empty(t, Diff(old, old))
+}
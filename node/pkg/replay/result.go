@@ -0,0 +1,105 @@
+package replay
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Result is what the processor decided during a replay.
+type Result struct {
+	// Observations are the observations the processor signed and broadcast.
+	Observations []Observation `json:"observations"`
+	// VAAs are the signed VAAs in the database at the end of the replay.
+	VAAs []StoredVAA `json:"vaas"`
+	// GovernorEnqueued are the IDs of the messages the governor was holding back at the end of the replay.
+	GovernorEnqueued []string `json:"governorEnqueued"`
+}
+
+// Observation is a signing decision.
+type Observation struct {
+	MessageID string `json:"messageId"`
+	Digest    string `json:"digest"`
+}
+
+// StoredVAA is a VAA that reached quorum and was stored.
+type StoredVAA struct {
+	MessageID string `json:"messageId"`
+	Digest    string `json:"digest"`
+}
+
+func (r *Result) sort() {
+	sort.Slice(r.Observations, func(i, j int) bool {
+		if r.Observations[i].MessageID != r.Observations[j].MessageID {
+			return r.Observations[i].MessageID < r.Observations[j].MessageID
+		}
+		return r.Observations[i].Digest < r.Observations[j].Digest
+	})
+	sort.Slice(r.VAAs, func(i, j int) bool {
+		return r.VAAs[i].MessageID < r.VAAs[j].MessageID
+	})
+	sort.Strings(r.GovernorEnqueued)
+}
+
+// Diff returns a description of every decision that differs between two results, or nil if they made the same decisions.
+func Diff(old, new *Result) []string {
+	var diffs []string
+
+	diffs = append(diffs, diffSets("observation", observationKeys(old.Observations), observationKeys(new.Observations))...)
+
+	oldVAAs := make(map[string]string, len(old.VAAs))
+	for _, v := range old.VAAs {
+		oldVAAs[v.MessageID] = v.Digest
+	}
+	newVAAs := make(map[string]string, len(new.VAAs))
+	for _, v := range new.VAAs {
+		newVAAs[v.MessageID] = v.Digest
+	}
+	for _, v := range old.VAAs {
+		digest, exists := newVAAs[v.MessageID]
+		if !exists {
+			diffs = append(diffs, fmt.Sprintf("VAA %s: stored by old only", v.MessageID))
+		} else if digest != v.Digest {
+			diffs = append(diffs, fmt.Sprintf("VAA %s: digest %s in old, %s in new", v.MessageID, v.Digest, digest))
+		}
+	}
+	for _, v := range new.VAAs {
+		if _, exists := oldVAAs[v.MessageID]; !exists {
+			diffs = append(diffs, fmt.Sprintf("VAA %s: stored by new only", v.MessageID))
+		}
+	}
+
+	diffs = append(diffs, diffSets("governor enqueued", old.GovernorEnqueued, new.GovernorEnqueued)...)
+	return diffs
+}
+
+func observationKeys(observations []Observation) []string {
+	keys := make([]string, len(observations))
+	for i, o := range observations {
+		keys[i] = o.MessageID + " digest " + o.Digest
+	}
+	return keys
+}
+
+// diffSets reports the entries that appear in only one of old and new.
+func diffSets(kind string, old, new []string) []string {
+	inOld := make(map[string]struct{}, len(old))
+	for _, s := range old {
+		inOld[s] = struct{}{}
+	}
+	inNew := make(map[string]struct{}, len(new))
+	for _, s := range new {
+		inNew[s] = struct{}{}
+	}
+	var diffs []string
+	for _, s := range old {
+		if _, exists := inNew[s]; !exists {
+			diffs = append(diffs, fmt.Sprintf("%s %s: old only", kind, s))
+		}
+	}
+	for _, s := range new {
+		if _, exists := inOld[s]; !exists {
+			diffs = append(diffs, fmt.Sprintf("%s %s: new only", kind, s))
+		}
+	}
+	return diffs
+}
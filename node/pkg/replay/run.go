@@ -0,0 +1,289 @@
+package replay
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	"github.com/certusone/wormhole/node/pkg/governor"
+	"github.com/certusone/wormhole/node/pkg/processor"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	"github.com/certusone/wormhole/node/pkg/supervisor"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// Config controls how a recording is replayed.
+type Config struct {
+	// GuardianKey is the key the processor signs with. To reproduce the recorded node's decisions, it must be the key of that node.
+	GuardianKey *ecdsa.PrivateKey
+	// Governor enables the chain governor, using the mainnet token and chain lists with their built-in prices.
+	Governor bool
+}
+
+// Run replays the records in order through a processor backed by an in-memory database and returns the decisions it made. The
+// processor runs on a mock clock, which starts at the time of the first record and is advanced to the time of each record before
+// it is fed, so that re-observation requests, retransmissions and expiry happen at the same points of the replay every time.
+func Run(ctx context.Context, logger *zap.Logger, records []*Record, cfg Config) (*Result, error) {
+	if cfg.GuardianKey == nil {
+		return nil, errors.New("a guardian key is required")
+	}
+
+	database, err := db.OpenInMemory(logger)
+	if err != nil {
+		return nil, err
+	}
+	defer database.Close()
+
+	var gov *governor.ChainGovernor
+	if cfg.Governor {
+		// GoTest keeps the governor from loading state from the database and fetching prices, so the replay is repeatable.
+		gov = governor.NewChainGovernor(logger, database, common.GoTest)
+		if err := gov.Run(ctx); err != nil {
+			return nil, fmt.Errorf("failed to start governor: %w", err)
+		}
+	}
+
+	start := time.Unix(0, 0)
+	if len(records) != 0 {
+		start = records[0].Time
+	}
+
+	r := &runner{
+		clock:        newMockClock(start),
+		msgC:         make(chan *common.MessagePublication),
+		setC:         make(chan *common.GuardianSet),
+		gossipSendC:  make(chan []byte, 100),
+		obsvC:        make(chan *common.MsgWithTimeStamp[gossipv1.SignedObservation], 100),
+		obsvReqSendC: make(chan *gossipv1.ObservationRequest, 100),
+		signedInC:    make(chan *gossipv1.SignedVAAWithQuorum),
+		messageIDs:   make(map[string]struct{}),
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Collect what the processor broadcasts for as long as it runs.
+	broadcastsDone := make(chan struct{})
+	go func() {
+		defer close(broadcastsDone)
+		r.collectBroadcasts(ctx, logger)
+	}()
+
+	supervisor.New(ctx, logger, func(ctx context.Context) error {
+		// Observations are verified and aggregated on the processor goroutine, which settle relies on.
+		p := processor.NewProcessor(ctx, database, r.msgC, r.setC, r.gossipSendC, r.obsvC, r.obsvReqSendC, r.signedInC, cfg.GuardianKey, common.NewGuardianSetState(nil), gov, nil, nil, nil, nil, nil, nil, nil, 0, 0, nil, 0, 0, false, r.clock, nil, nil)
+		if err := supervisor.Run(ctx, "processor", p.Run); err != nil {
+			return err
+		}
+		supervisor.Signal(ctx, supervisor.SignalHealthy)
+		<-ctx.Done()
+		return nil
+	})
+
+	for i, record := range records {
+		if err := r.advance(ctx, record.Time); err != nil {
+			return nil, fmt.Errorf("record %d: %w", i+1, err)
+		}
+		if err := r.feed(ctx, record); err != nil {
+			return nil, fmt.Errorf("record %d: %w", i+1, err)
+		}
+	}
+	if err := r.settle(ctx); err != nil {
+		return nil, err
+	}
+	cancel()
+	<-broadcastsDone
+
+	return r.result(database, gov)
+}
+
+// runner holds the processor's channels and what was learned from the replay.
+type runner struct {
+	msgC         chan *common.MessagePublication
+	setC         chan *common.GuardianSet
+	gossipSendC  chan []byte
+	obsvC        chan *common.MsgWithTimeStamp[gossipv1.SignedObservation]
+	obsvReqSendC chan *gossipv1.ObservationRequest
+	signedInC    chan *gossipv1.SignedVAAWithQuorum
+	clock        *mockClock
+
+	// gs is the last guardian set fed to the processor.
+	gs *common.GuardianSet
+	// messageIDs are all the messages seen in the recording or broadcast by the processor, to look up stored VAAs.
+	messageIDs   map[string]struct{}
+	observations []Observation
+}
+
+// feed passes a single record to the processor and waits until it has been handled.
+func (r *runner) feed(ctx context.Context, record *Record) error {
+	switch record.Type {
+	case RecordGuardianSet:
+		gs, err := record.GuardianSet.guardianSet()
+		if err != nil {
+			return err
+		}
+		r.gs = gs
+		return send(ctx, r.setC, gs)
+	case RecordMessage:
+		msg, err := common.UnmarshalMessagePublication(record.Data)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal message publication: %w", err)
+		}
+		r.messageIDs[msg.MessageIDString()] = struct{}{}
+		if err := send(ctx, r.msgC, msg); err != nil {
+			return err
+		}
+	case RecordGossip:
+		var msg gossipv1.GossipMessage
+		if err := proto.Unmarshal(record.Data, &msg); err != nil {
+			return fmt.Errorf("failed to unmarshal gossip message: %w", err)
+		}
+		switch m := msg.Message.(type) {
+		case *gossipv1.GossipMessage_SignedObservation:
+			r.messageIDs[m.SignedObservation.MessageId] = struct{}{}
+			if err := send(ctx, r.obsvC, common.CreateMsgWithTimestamp[gossipv1.SignedObservation](m.SignedObservation)); err != nil {
+				return err
+			}
+		case *gossipv1.GossipMessage_SignedVaaWithQuorum:
+			if v, err := vaa.Unmarshal(m.SignedVaaWithQuorum.Vaa); err == nil {
+				r.messageIDs[v.MessageID()] = struct{}{}
+			}
+			if err := send(ctx, r.signedInC, m.SignedVaaWithQuorum); err != nil {
+				return err
+			}
+		default:
+			// Heartbeats and the like do not influence the processor.
+			return nil
+		}
+	}
+	return r.settle(ctx)
+}
+
+// advance moves the clock forward to t, letting the processor handle the ticks of its tickers and timers on the way. The clock never
+// goes back, so records that are out of order are fed at the current time.
+func (r *runner) advance(ctx context.Context, t time.Time) error {
+	if r.gs == nil {
+		// The processor may not have created its tickers yet, which would then start at a different time on every run. It drops
+		// everything without a guardian set anyway.
+		return nil
+	}
+	for r.clock.Now().Before(t) {
+		r.clock.Set(r.clock.nextStep(t))
+		if err := r.settle(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// settle waits until the processor has handled everything it was sent, including its own observations that it loops back and the
+// ticks of its clock.
+func (r *runner) settle(ctx context.Context) error {
+	for {
+		if r.gs == nil {
+			// Without a guardian set, there is nothing to use as a barrier, but the processor drops everything anyway.
+			return nil
+		}
+		// The processor handles one channel at a time, so once it has accepted the guardian set, it is done with whatever came before.
+		if err := send(ctx, r.setC, r.gs); err != nil {
+			return err
+		}
+		if len(r.obsvC) == 0 && !r.clock.pending() {
+			return nil
+		}
+	}
+}
+
+func send[T any](ctx context.Context, c chan<- T, v T) error {
+	select {
+	case c <- v:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// collectBroadcasts records the observations the processor signs and drains its other outputs. Retransmissions of an observation are
+// only recorded once.
+func (r *runner) collectBroadcasts(ctx context.Context, logger *zap.Logger) {
+	seen := make(map[Observation]struct{})
+	collect := func(b []byte) {
+		var msg gossipv1.GossipMessage
+		if err := proto.Unmarshal(b, &msg); err != nil {
+			logger.Error("processor broadcast an invalid gossip message", zap.Error(err))
+			return
+		}
+		if m, ok := msg.Message.(*gossipv1.GossipMessage_SignedObservation); ok {
+			o := Observation{
+				MessageID: m.SignedObservation.MessageId,
+				Digest:    hex.EncodeToString(m.SignedObservation.Hash),
+			}
+			if _, exists := seen[o]; !exists {
+				seen[o] = struct{}{}
+				r.observations = append(r.observations, o)
+			}
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			for {
+				select {
+				case b := <-r.gossipSendC:
+					collect(b)
+				default:
+					return
+				}
+			}
+		case <-r.obsvReqSendC:
+		case b := <-r.gossipSendC:
+			collect(b)
+		}
+	}
+}
+
+// result gathers the decisions made during the replay in a stable order.
+func (r *runner) result(database *db.Database, gov *governor.ChainGovernor) (*Result, error) {
+	res := &Result{Observations: r.observations}
+	for _, o := range r.observations {
+		r.messageIDs[o.MessageID] = struct{}{}
+	}
+
+	for msgID := range r.messageIDs {
+		id, err := db.VaaIDFromString(msgID)
+		if err != nil {
+			continue
+		}
+		b, err := database.GetSignedVAABytes(*id)
+		if errors.Is(err, db.ErrVAANotFound) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to look up VAA %s: %w", msgID, err)
+		}
+		v, err := vaa.Unmarshal(b)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal stored VAA %s: %w", msgID, err)
+		}
+		res.VAAs = append(res.VAAs, StoredVAA{
+			MessageID: v.MessageID(),
+			Digest:    hex.EncodeToString(v.SigningDigest().Bytes()),
+		})
+	}
+
+	if gov != nil {
+		for _, e := range gov.GetEnqueuedVAAs() {
+			res.GovernorEnqueued = append(res.GovernorEnqueued, fmt.Sprintf("%d/%s/%d", e.EmitterChain, e.EmitterAddress, e.Sequence))
+		}
+	}
+
+	res.sort()
+	return res, nil
+}
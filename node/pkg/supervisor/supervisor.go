@@ -6,7 +6,11 @@ package supervisor
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 )
@@ -62,6 +66,78 @@ func Logger(ctx context.Context) *zap.Logger {
 	return node.getLogger()
 }
 
+// RunnableStatus describes a runnable in the supervision tree.
+type RunnableStatus struct {
+	// DN is the distinguished name of the runnable, eg. 'root.bar.foo'.
+	DN    string
+	State string
+	// LastError is the error the runnable last died with, and LastErrorTime when. LastError is nil if it never died.
+	LastError     error
+	LastErrorTime time.Time
+}
+
+// Tree returns the status of every runnable in the supervision tree that the given runnable context belongs to, parents
+// before their children and siblings ordered by name. The context may already be canceled.
+func Tree(ctx context.Context) []RunnableStatus {
+	sup, ok := ctx.Value(supervisorKey).(*supervisor)
+	if !ok {
+		panic("supervisor function called from non-runnable context")
+	}
+	sup.mu.RLock()
+	defer sup.mu.RUnlock()
+
+	var tree []RunnableStatus
+	var walk func(n *node)
+	walk = func(n *node) {
+		status := RunnableStatus{DN: n.dn(), State: n.state.String()}
+		if e, ok := sup.lastErrors[status.DN]; ok {
+			status.LastError = e.err
+			status.LastErrorTime = e.time
+		}
+		tree = append(tree, status)
+		names := make([]string, 0, len(n.children))
+		for name := range n.children {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			walk(n.children[name])
+		}
+	}
+	walk(sup.root)
+	return tree
+}
+
+// ErrRunnableNotFound is returned by Restart if there is no runnable with the given DN.
+var ErrRunnableNotFound = errors.New("runnable not found")
+
+// ErrRunnableNotRunning is returned by Restart if the runnable is not running, e.g. because it is done or waiting to be restarted.
+var ErrRunnableNotRunning = errors.New("runnable is not running")
+
+// Restart cancels the runnable with the given DN in the supervision tree that the given runnable context belongs to, along with its
+// children, so that the supervisor restarts it without backoff. Other runnables, including the members of its group, keep running.
+// The root runnable can't be restarted.
+func Restart(ctx context.Context, dn string) error {
+	sup, ok := ctx.Value(supervisorKey).(*supervisor)
+	if !ok {
+		panic("supervisor function called from non-runnable context")
+	}
+	sup.mu.Lock()
+	defer sup.mu.Unlock()
+
+	n := sup.findNode(dn)
+	if n == nil || n.parent == nil {
+		return fmt.Errorf("%w: %s", ErrRunnableNotFound, dn)
+	}
+	if n.state != nodeStateNew && n.state != nodeStateHealthy {
+		return fmt.Errorf("%w: %s is %s", ErrRunnableNotRunning, dn, n.state)
+	}
+
+	sup.ilogger.Info("restarting runnable on request", zap.String("dn", dn))
+	n.ctxC()
+	return nil
+}
+
 // supervisor represents and instance of the supervision system. It keeps track of a supervision tree and a request
 // channel to its internal processor goroutine.
 type supervisor struct {
@@ -80,6 +156,20 @@ type supervisor struct {
 
 	// propagate panics, ie. don't catch them.
 	propagatePanic bool
+
+	// lastErrors are the errors runnables last died with, by DN. They are kept by DN since the nodes of a subtree are recreated
+	// when it is restarted.
+	lastErrors map[string]runnableError
+
+	// running counts the runnable goroutines that have not returned yet.
+	running sync.WaitGroup
+	// done is closed once the processor has exited and every runnable goroutine has returned.
+	done chan struct{}
+}
+
+type runnableError struct {
+	err  error
+	time time.Time
 }
 
 // SupervisorOpt are runtime configurable options for the supervisor.
@@ -100,6 +190,9 @@ func New(ctx context.Context, logger *zap.Logger, rootRunnable Runnable, opts ..
 		logger:  logger,
 		ilogger: logger.Named("supervisor"),
 		pReq:    make(chan *processorRequest),
+		done:    make(chan struct{}),
+
+		lastErrors: make(map[string]runnableError),
 	}
 
 	for _, o := range opts {
@@ -116,3 +209,9 @@ func New(ctx context.Context, logger *zap.Logger, rootRunnable Runnable, opts ..
 
 	return sup
 }
+
+// Done returns a channel that is closed once the context given to New has been canceled and every runnable in the
+// supervision tree has returned.
+func (s *supervisor) Done() <-chan struct{} {
+	return s.done
+}
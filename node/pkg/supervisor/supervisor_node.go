@@ -199,6 +199,23 @@ func (s *supervisor) nodeByDN(dn string) *node {
 	}
 }
 
+// findNode returns the node with the given DN, or nil if there is none.
+func (s *supervisor) findNode(dn string) *node {
+	parts := strings.Split(dn, ".")
+	if parts[0] != "root" {
+		return nil
+	}
+	cur := s.root
+	for _, part := range parts[1:] {
+		next, ok := cur.children[part]
+		if !ok {
+			return nil
+		}
+		cur = next
+	}
+	return cur
+}
+
 // reNodeName validates a node name against constraints.
 var reNodeName = regexp.MustCompile(`[a-z90-9_]{1,64}`)
 
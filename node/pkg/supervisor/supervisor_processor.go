@@ -62,7 +62,9 @@ func (s *supervisor) processor(ctx context.Context) {
 		case <-ctx.Done():
 			s.ilogger.Info("supervisor processor exiting...", zap.Error(ctx.Err()))
 			s.processKill()
+			s.running.Wait()
 			s.ilogger.Info("supervisor exited")
+			close(s.done)
 			return
 		case <-gc.C:
 			if !clean {
@@ -130,10 +132,12 @@ func (s *supervisor) processSchedule(r *processorRequestSchedule) {
 	defer s.mu.Unlock()
 
 	n := s.nodeByDN(r.dn)
+	s.running.Add(1)
 	go func() {
 		if !s.propagatePanic {
 			defer func() {
 				if rec := recover(); rec != nil {
+					s.running.Done()
 					s.pReq <- &processorRequest{
 						died: &processorRequestDied{
 							dn:  r.dn,
@@ -145,6 +149,8 @@ func (s *supervisor) processSchedule(r *processorRequestSchedule) {
 		}
 
 		res := n.runnable(n.ctx)
+		// Mark the runnable as returned before reporting it, the processor no longer reads requests once it exits.
+		s.running.Done()
 
 		s.pReq <- &processorRequest{
 			died: &processorRequestDied{
@@ -198,6 +204,7 @@ func (s *supervisor) processDied(r *processorRequestDied) {
 	}
 
 	s.ilogger.Error("Runnable died", zap.String("dn", n.dn()), zap.Error(err))
+	s.lastErrors[r.dn] = runnableError{err: err, time: time.Now()}
 	// Mark as dead.
 	n.state = nodeStateDead
 
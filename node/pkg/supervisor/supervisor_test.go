@@ -0,0 +1,111 @@
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRestart(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var watcherStarts, siblingStarts atomic.Int32
+	runCtxC := make(chan context.Context, 1)
+	s := New(ctx, zap.NewNop(), func(ctx context.Context) error {
+		if err := RunGroup(ctx, map[string]Runnable{
+			"watcher": func(ctx context.Context) error {
+				watcherStarts.Add(1)
+				Signal(ctx, SignalHealthy)
+				<-ctx.Done()
+				return ctx.Err()
+			},
+			"sibling": func(ctx context.Context) error {
+				siblingStarts.Add(1)
+				Signal(ctx, SignalHealthy)
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		}); err != nil {
+			return err
+		}
+		if err := Run(ctx, "done", func(ctx context.Context) error {
+			Signal(ctx, SignalHealthy)
+			Signal(ctx, SignalDone)
+			return nil
+		}); err != nil {
+			return err
+		}
+		runCtxC <- ctx
+		Signal(ctx, SignalHealthy)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	s.waitSettleError(ctx, t)
+	rootCtx := <-runCtxC
+
+	watcherState := func() string {
+		for _, r := range Tree(rootCtx) {
+			if r.DN == "root.watcher" {
+				assert.Nil(t, r.LastError)
+				return r.State
+			}
+		}
+		return ""
+	}
+
+	require.NoError(t, Restart(rootCtx, "root.watcher"))
+	require.Eventually(t, func() bool { return watcherStarts.Load() == 2 && watcherState() == "NODE_STATE_HEALTHY" }, 5*time.Second, time.Millisecond)
+	s.waitSettleError(ctx, t)
+	assert.Equal(t, int32(1), siblingStarts.Load())
+
+	assert.True(t, errors.Is(Restart(rootCtx, "root.missing"), ErrRunnableNotFound))
+	assert.True(t, errors.Is(Restart(rootCtx, "root"), ErrRunnableNotFound))
+	assert.True(t, errors.Is(Restart(rootCtx, "root.done"), ErrRunnableNotRunning))
+
+	// Restarting again right away doesn't back off.
+	require.NoError(t, Restart(rootCtx, "root.watcher"))
+	require.Eventually(t, func() bool { return watcherStarts.Load() == 3 }, 100*time.Millisecond, time.Millisecond)
+}
+
+func TestDoneWaitsForRunnables(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var exited atomic.Bool
+	s := New(ctx, zap.NewNop(), func(ctx context.Context) error {
+		if err := Run(ctx, "slow", func(ctx context.Context) error {
+			Signal(ctx, SignalHealthy)
+			<-ctx.Done()
+			time.Sleep(50 * time.Millisecond)
+			exited.Store(true)
+			return ctx.Err()
+		}); err != nil {
+			return err
+		}
+		Signal(ctx, SignalHealthy)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	s.waitSettleError(ctx, t)
+
+	select {
+	case <-s.Done():
+		t.Fatal("supervisor done before its context was canceled")
+	default:
+	}
+
+	cancel()
+	select {
+	case <-s.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("supervisor did not finish after its context was canceled")
+	}
+	assert.True(t, exited.Load())
+}
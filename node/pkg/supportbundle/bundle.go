@@ -0,0 +1,51 @@
+// Package supportbundle collects diagnostics from a running guardian into a single tarball for bug reports, taking care not to
+// include secrets.
+package supportbundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"time"
+)
+
+// Writer writes files to a gzip compressed tarball.
+type Writer struct {
+	gz  *gzip.Writer
+	tw  *tar.Writer
+	now time.Time
+}
+
+func NewWriter(w io.Writer, now time.Time) *Writer {
+	gz := gzip.NewWriter(w)
+	return &Writer{gz: gz, tw: tar.NewWriter(gz), now: now}
+}
+
+// AddFile adds a file to the tarball.
+func (w *Writer) AddFile(name string, data []byte) error {
+	if err := w.tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(data)),
+		ModTime: w.now,
+	}); err != nil {
+		return err
+	}
+	_, err := w.tw.Write(data)
+	return err
+}
+
+// Close finishes the tarball. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	if err := w.tw.Close(); err != nil {
+		return err
+	}
+	return w.gz.Close()
+}
+
+// Sources are the diagnostics a running guardian keeps for support bundles. They are served over the admin socket.
+type Sources struct {
+	// Config is the guardian's configuration, with secrets redacted.
+	Config map[string]string
+	Logs   *LogBuffer
+}
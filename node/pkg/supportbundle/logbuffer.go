@@ -0,0 +1,167 @@
+package supportbundle
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// LogBufferDefaultSize is the default number of log lines kept for support bundles.
+const LogBufferDefaultSize = 5000
+
+// LogBuffer keeps the most recent log lines in memory, so they can be included in a support bundle without access to wherever the
+// guardian's output is collected. The lines can also be followed as they are logged, see Tail.
+type LogBuffer struct {
+	mu      sync.Mutex
+	entries []LogEntry
+	next    int
+	tails   map[*logTail]struct{}
+}
+
+// LogEntry is a buffered log line along with the fields it is filtered by.
+type LogEntry struct {
+	Time   time.Time
+	Level  zapcore.Level
+	Logger string
+	// Line is the JSON encoded entry. URLs are redacted by the methods returning entries.
+	Line string
+	// Dropped is the number of entries that were dropped right before this one because the tail didn't keep up. It is only set on
+	// entries received through Tail.
+	Dropped uint64
+}
+
+type logTail struct {
+	c       chan LogEntry
+	match   func(*LogEntry) bool
+	dropped uint64
+}
+
+func NewLogBuffer(size int) *LogBuffer {
+	return &LogBuffer{entries: make([]LogEntry, 0, size), tails: make(map[*logTail]struct{})}
+}
+
+// Core returns a core that writes JSON encoded entries to the buffer. It is meant to be teed with the logger's core.
+func (b *LogBuffer) Core(level zapcore.LevelEnabler) zapcore.Core {
+	return &logBufferCore{
+		LevelEnabler: level,
+		enc:          zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+		buf:          b,
+	}
+}
+
+// Lines returns the buffered log lines, oldest first. URLs are redacted, since RPC endpoints often contain API keys.
+func (b *LogBuffer) Lines() []string {
+	b.mu.Lock()
+	entries := b.recentLocked(nil)
+	b.mu.Unlock()
+
+	lines := make([]string, len(entries))
+	for i, e := range entries {
+		lines[i] = RedactURLs(e.Line)
+	}
+	return lines
+}
+
+// Tail returns the buffered entries for which match returns true, oldest first, and a channel receiving the matching entries logged
+// afterwards. A nil match matches every entry. The channel holds up to size entries; entries logged while it is full are dropped
+// rather than blocking the logger, and counted in the Dropped field of the next entry received. URLs are redacted, like in Lines.
+// The returned function stops the tail, after which the channel receives no more entries.
+func (b *LogBuffer) Tail(match func(*LogEntry) bool, size int) ([]LogEntry, <-chan LogEntry, func()) {
+	t := &logTail{c: make(chan LogEntry, size), match: match}
+
+	b.mu.Lock()
+	recent := b.recentLocked(match)
+	b.tails[t] = struct{}{}
+	b.mu.Unlock()
+
+	for i := range recent {
+		recent[i].Line = RedactURLs(recent[i].Line)
+	}
+	stop := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.tails, t)
+	}
+	return recent, t.c, stop
+}
+
+// recentLocked returns the buffered entries for which match returns true, oldest first.
+func (b *LogBuffer) recentLocked(match func(*LogEntry) bool) []LogEntry {
+	entries := make([]LogEntry, 0, len(b.entries))
+	for _, part := range [][]LogEntry{b.entries[b.next:], b.entries[:b.next]} {
+		for i := range part {
+			if match == nil || match(&part[i]) {
+				entries = append(entries, part[i])
+			}
+		}
+	}
+	return entries
+}
+
+func (b *LogBuffer) add(e LogEntry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for t := range b.tails {
+		if t.match != nil && !t.match(&e) {
+			continue
+		}
+		tailed := e
+		tailed.Line = RedactURLs(e.Line)
+		tailed.Dropped = t.dropped
+		select {
+		case t.c <- tailed:
+			t.dropped = 0
+		default:
+			t.dropped++
+		}
+	}
+
+	if len(b.entries) < cap(b.entries) {
+		b.entries = append(b.entries, e)
+		return
+	}
+	if len(b.entries) == 0 {
+		return
+	}
+	b.entries[b.next] = e
+	b.next = (b.next + 1) % len(b.entries)
+}
+
+type logBufferCore struct {
+	zapcore.LevelEnabler
+	enc zapcore.Encoder
+	buf *LogBuffer
+}
+
+func (c *logBufferCore) With(fields []zapcore.Field) zapcore.Core {
+	enc := c.enc.Clone()
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+	return &logBufferCore{LevelEnabler: c.LevelEnabler, enc: enc, buf: c.buf}
+}
+
+func (c *logBufferCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *logBufferCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	b, err := c.enc.EncodeEntry(ent, fields)
+	if err != nil {
+		return err
+	}
+	c.buf.add(LogEntry{Time: ent.Time, Level: ent.Level, Logger: ent.LoggerName, Line: strings.TrimSuffix(b.String(), "\n")})
+	b.Free()
+	return nil
+}
+
+func (c *logBufferCore) Sync() error {
+	return nil
+}
@@ -0,0 +1,102 @@
+package supportbundle
+
+import (
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// Redacted replaces secret values in support bundles.
+const Redacted = "<redacted>"
+
+// secretFlagWords mark flags whose values are redacted entirely.
+var secretFlagWords = []string{"secret", "password", "token", "apikey", "mnemonic"}
+
+var urlPattern = regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://[^\s"'<>,]+`)
+
+// RedactURL strips everything but the scheme, host and port from a URL. RPC providers commonly put API keys in the user info,
+// path or query.
+func RedactURL(s string) string {
+	u, err := url.Parse(s)
+	if err != nil || u.Host == "" {
+		return Redacted
+	}
+	if u.User == nil && (u.Path == "" || u.Path == "/") && u.RawQuery == "" && u.Fragment == "" {
+		return s
+	}
+	return u.Scheme + "://" + u.Host + "/" + Redacted
+}
+
+// RedactURLs redacts every URL in s.
+func RedactURLs(s string) string {
+	return urlPattern.ReplaceAllStringFunc(s, RedactURL)
+}
+
+// RedactFlags returns the values of the flags in a flag set with secrets redacted. Flags that were not set are included with their
+// default values.
+func RedactFlags(flags *pflag.FlagSet) map[string]string {
+	config := make(map[string]string)
+	flags.VisitAll(func(f *pflag.Flag) {
+		config[f.Name] = redactFlag(f.Name, f.Value.String())
+	})
+	return config
+}
+
+// RedactJSON returns v as its generic JSON representation with secrets redacted, like RedactFlags does for flags: fields whose name
+// marks them as secret are redacted entirely and URLs in other strings are stripped.
+func RedactJSON(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(b, &generic); err != nil {
+		return nil, err
+	}
+	return redactJSONValue("", generic), nil
+}
+
+func redactJSONValue(name string, v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, field := range v {
+			v[k] = redactJSONValue(k, field)
+		}
+		return v
+	case []interface{}:
+		for i, elem := range v {
+			v[i] = redactJSONValue(name, elem)
+		}
+		return v
+	case string:
+		return redactFlag(name, v)
+	default:
+		if v != nil && isSecretName(name) {
+			return Redacted
+		}
+		return v
+	}
+}
+
+func isSecretName(name string) bool {
+	lower := strings.ToLower(name)
+	for _, w := range secretFlagWords {
+		if strings.Contains(lower, w) {
+			return true
+		}
+	}
+	return false
+}
+
+func redactFlag(name string, value string) string {
+	if isSecretName(name) {
+		if value == "" {
+			return ""
+		}
+		return Redacted
+	}
+	return RedactURLs(value)
+}
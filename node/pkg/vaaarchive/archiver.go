@@ -0,0 +1,278 @@
+// Package vaaarchive moves signed VAAs out of the database once they are older than a retention period. They are written to
+// compressed segment files, which can be uploaded to object storage, and can be restored into the database on demand.
+package vaaarchive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/db"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// segmentMaxVAAs is the maximum number of VAAs written to a single segment.
+const segmentMaxVAAs = 10000
+
+var (
+	vaasArchived = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "wormhole_vaa_archive_vaas_archived_total",
+			Help: "Total number of VAAs written to archive segments and pruned from the database",
+		})
+	vaasRestored = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "wormhole_vaa_archive_vaas_restored_total",
+			Help: "Total number of VAAs restored from archive segments",
+		})
+	archiveFailures = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "wormhole_vaa_archive_failures_total",
+			Help: "Total number of archive runs that failed",
+		})
+	uploadFailures = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "wormhole_vaa_archive_upload_failures_total",
+			Help: "Total number of segment uploads that failed",
+		})
+)
+
+type Config struct {
+	// Dir is the directory segments are written to.
+	Dir string
+	// Retention is how long VAAs are kept in the database, based on their timestamp.
+	Retention time.Duration
+	// Interval is the time between archive runs.
+	Interval time.Duration
+	// RestoreHold is how long restored VAAs are kept in the database before they are pruned again.
+	RestoreHold time.Duration
+	// UploadURL is optional. If set, each segment is uploaded with an HTTP PUT to UploadURL/<segment name>.
+	UploadURL string
+}
+
+type Archiver struct {
+	logger     *zap.Logger
+	db         *db.Database
+	cfg        Config
+	httpClient *http.Client
+
+	// mutex serializes archive runs and restores, so a VAA being restored is never pruned before it is marked as restored.
+	mutex sync.Mutex
+}
+
+func NewArchiver(logger *zap.Logger, database *db.Database, cfg Config) (*Archiver, error) {
+	if cfg.Dir == "" {
+		return nil, errors.New("archive directory must be set")
+	}
+	if cfg.Retention <= 0 {
+		return nil, errors.New("retention must be positive")
+	}
+	if cfg.Interval <= 0 {
+		return nil, errors.New("interval must be positive")
+	}
+	if cfg.RestoreHold <= 0 {
+		return nil, errors.New("restore hold must be positive")
+	}
+	if err := os.MkdirAll(cfg.Dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create archive directory: %w", err)
+	}
+
+	return &Archiver{
+		logger:     logger,
+		db:         database,
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: 5 * time.Minute},
+	}, nil
+}
+
+// Run archives VAAs every interval until the context is cancelled. Failures are logged and retried on the next run rather than
+// returned, since the guardian works fine with a database that has not been pruned.
+func (a *Archiver) Run(ctx context.Context) error {
+	ticker := time.NewTicker(a.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		archived, err := a.Archive(time.Now())
+		if err != nil {
+			archiveFailures.Inc()
+			a.logger.Error("failed to archive VAAs", zap.Int("archived", archived), zap.Error(err))
+		} else if archived != 0 {
+			a.logger.Info("archived VAAs", zap.Int("archived", archived))
+		}
+
+		if a.cfg.UploadURL != "" {
+			a.uploadSegments(ctx)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Archive writes the VAAs older than the retention period to segments and prunes them from the database. It returns the number of
+// VAAs pruned, including restored VAAs whose hold expired, which are not written again.
+func (a *Archiver) Archive(now time.Time) (int, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	pruned := 0
+	err := a.db.ScanArchivableVAAs(now.Add(-a.cfg.Retention), now, segmentMaxVAAs, func(batch []*db.ArchivableVAA) error {
+		ids := make([]db.VAAID, 0, len(batch))
+		var toWrite [][]byte
+		var earliest, latest time.Time
+		for _, v := range batch {
+			ids = append(ids, v.ID)
+			if v.Restored {
+				continue
+			}
+			if len(toWrite) == 0 || v.Timestamp.Before(earliest) {
+				earliest = v.Timestamp
+			}
+			if len(toWrite) == 0 || v.Timestamp.After(latest) {
+				latest = v.Timestamp
+			}
+			toWrite = append(toWrite, v.Bytes)
+		}
+
+		if len(toWrite) != 0 {
+			if err := writeSegment(a.cfg.Dir, segmentName(earliest, latest, now), toWrite); err != nil {
+				return fmt.Errorf("failed to write segment: %w", err)
+			}
+		}
+		if err := a.db.PruneVAAs(ids); err != nil {
+			return err
+		}
+
+		pruned += len(ids)
+		vaasArchived.Add(float64(len(toWrite)))
+		return nil
+	})
+	return pruned, err
+}
+
+// Restore stores the archived VAAs with a timestamp in [from, to] back into the database, where they are kept for the restore hold.
+// If chain is not zero, only VAAs from that chain are restored, and if emitter is also set, only VAAs from that emitter.
+func (a *Archiver) Restore(from, to time.Time, chain vaa.ChainID, emitter *vaa.Address, now time.Time) (int, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	segments, err := listSegments(a.cfg.Dir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list segments: %w", err)
+	}
+
+	restored := 0
+	var batch []*vaa.VAA
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := a.db.RestoreVAAs(batch, now.Add(a.cfg.RestoreHold)); err != nil {
+			return err
+		}
+		restored += len(batch)
+		vaasRestored.Add(float64(len(batch)))
+		batch = batch[:0]
+		return nil
+	}
+
+	for _, s := range segments {
+		if s.latest.Before(from) || s.earliest.After(to) {
+			continue
+		}
+		err := readSegment(filepath.Join(a.cfg.Dir, s.name), func(b []byte) error {
+			v, err := vaa.Unmarshal(b)
+			if err != nil {
+				return fmt.Errorf("failed to unmarshal VAA: %w", err)
+			}
+			if v.Timestamp.Before(from) || v.Timestamp.After(to) {
+				return nil
+			}
+			if chain != vaa.ChainIDUnset && v.EmitterChain != chain {
+				return nil
+			}
+			if emitter != nil && v.EmitterAddress != *emitter {
+				return nil
+			}
+			batch = append(batch, v)
+			if len(batch) == segmentMaxVAAs {
+				return flush()
+			}
+			return nil
+		})
+		if err != nil {
+			return restored, fmt.Errorf("failed to restore from segment %s: %w", s.name, err)
+		}
+	}
+
+	return restored, flush()
+}
+
+// uploadSegments uploads the segments that have not been uploaded yet. A marker file is written next to each uploaded segment.
+func (a *Archiver) uploadSegments(ctx context.Context) {
+	segments, err := listSegments(a.cfg.Dir)
+	if err != nil {
+		a.logger.Error("failed to list segments", zap.Error(err))
+		return
+	}
+
+	for _, s := range segments {
+		marker := filepath.Join(a.cfg.Dir, s.name+uploadedSuffix)
+		if _, err := os.Stat(marker); err == nil {
+			continue
+		}
+		if err := a.upload(ctx, s.name); err != nil {
+			uploadFailures.Inc()
+			a.logger.Error("failed to upload segment", zap.String("segment", s.name), zap.Error(err))
+			continue
+		}
+		if err := os.WriteFile(marker, nil, 0600); err != nil {
+			a.logger.Error("failed to mark segment as uploaded", zap.String("segment", s.name), zap.Error(err))
+			continue
+		}
+		a.logger.Info("uploaded segment", zap.String("segment", s.name))
+	}
+}
+
+func (a *Archiver) upload(ctx context.Context, name string) error {
+	f, err := os.Open(filepath.Join(a.cfg.Dir, name))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, strings.TrimSuffix(a.cfg.UploadURL, "/")+"/"+name, f)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = fi.Size()
+	req.Header.Set("Content-Type", "application/gzip")
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected status %s: %s", resp.Status, string(body))
+	}
+	return nil
+}
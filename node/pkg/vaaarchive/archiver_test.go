@@ -0,0 +1,141 @@
+package vaaarchive
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+var testEmitter = vaa.Address{1, 2, 3}
+
+// otherChain stands in for a second chain, since this network only knows Solana.
+const otherChain vaa.ChainID = 2
+
+func storeTestVAA(t *testing.T, database *db.Database, chain vaa.ChainID, sequence uint64, timestamp time.Time) {
+	t.Helper()
+	v := &vaa.VAA{
+		Version:          vaa.SupportedVAAVersion,
+		GuardianSetIndex: 1,
+		Signatures:       []*vaa.Signature{{Index: 0}, {Index: 1}},
+		Timestamp:        timestamp,
+		EmitterChain:     chain,
+		EmitterAddress:   testEmitter,
+		Sequence:         sequence,
+		Payload:          []byte{byte(sequence)},
+	}
+	require.NoError(t, database.StoreSignedVAA(v))
+}
+
+func hasVAA(t *testing.T, database *db.Database, chain vaa.ChainID, sequence uint64) bool {
+	t.Helper()
+	ok, err := database.HasVAA(db.VAAID{EmitterChain: chain, EmitterAddress: testEmitter, Sequence: sequence})
+	require.NoError(t, err)
+	return ok
+}
+
+func newTestArchiver(t *testing.T, uploadURL string) (*Archiver, *db.Database) {
+	database, err := db.OpenInMemory(zap.NewNop())
+	require.NoError(t, err)
+	t.Cleanup(func() { database.Close() })
+
+	a, err := NewArchiver(zap.NewNop(), database, Config{
+		Dir:         t.TempDir(),
+		Retention:   30 * 24 * time.Hour,
+		Interval:    time.Hour,
+		RestoreHold: 24 * time.Hour,
+		UploadURL:   uploadURL,
+	})
+	require.NoError(t, err)
+	return a, database
+}
+
+func TestArchiveAndRestore(t *testing.T) {
+	a, database := newTestArchiver(t, "")
+	now := time.Unix(1700000000, 0)
+	old := now.Add(-40 * 24 * time.Hour)
+
+	storeTestVAA(t, database, vaa.ChainIDSolana, 1, old)
+	storeTestVAA(t, database, vaa.ChainIDSolana, 2, old.Add(time.Hour))
+	storeTestVAA(t, database, otherChain, 3, old)
+	storeTestVAA(t, database, vaa.ChainIDSolana, 4, now.Add(-time.Hour))
+
+	pruned, err := a.Archive(now)
+	require.NoError(t, err)
+	assert.Equal(t, 3, pruned)
+	assert.False(t, hasVAA(t, database, vaa.ChainIDSolana, 1))
+	assert.False(t, hasVAA(t, database, otherChain, 3))
+	assert.True(t, hasVAA(t, database, vaa.ChainIDSolana, 4))
+
+	segments, err := listSegments(a.cfg.Dir)
+	require.NoError(t, err)
+	require.Len(t, segments, 1)
+	assert.Equal(t, old.Unix(), segments[0].earliest.Unix())
+	assert.Equal(t, old.Add(time.Hour).Unix(), segments[0].latest.Unix())
+
+	// Only the requested range and chain are restored.
+	restored, err := a.Restore(old, old.Add(time.Minute), vaa.ChainIDSolana, nil, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, restored)
+	assert.True(t, hasVAA(t, database, vaa.ChainIDSolana, 1))
+	assert.False(t, hasVAA(t, database, vaa.ChainIDSolana, 2))
+	assert.False(t, hasVAA(t, database, otherChain, 3))
+
+	// Restored VAAs are kept for the hold, then pruned without being archived again.
+	pruned, err = a.Archive(now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 0, pruned)
+	assert.True(t, hasVAA(t, database, vaa.ChainIDSolana, 1))
+
+	pruned, err = a.Archive(now.Add(25 * time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, pruned)
+	assert.False(t, hasVAA(t, database, vaa.ChainIDSolana, 1))
+	segments, err = listSegments(a.cfg.Dir)
+	require.NoError(t, err)
+	assert.Len(t, segments, 1)
+}
+
+func TestUploadSegments(t *testing.T) {
+	var mutex sync.Mutex
+	uploaded := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		mutex.Lock()
+		uploaded[r.URL.Path] = len(body)
+		mutex.Unlock()
+	}))
+	defer srv.Close()
+
+	a, database := newTestArchiver(t, srv.URL+"/bucket/")
+	now := time.Unix(1700000000, 0)
+	storeTestVAA(t, database, vaa.ChainIDSolana, 1, now.Add(-40*24*time.Hour))
+	_, err := a.Archive(now)
+	require.NoError(t, err)
+
+	a.uploadSegments(context.Background())
+	segments, err := listSegments(a.cfg.Dir)
+	require.NoError(t, err)
+	require.Len(t, segments, 1)
+	fi, err := os.Stat(filepath.Join(a.cfg.Dir, segments[0].name))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"/bucket/" + segments[0].name: int(fi.Size())}, uploaded)
+
+	// Uploaded segments are not uploaded again.
+	uploaded = map[string]int{}
+	a.uploadSegments(context.Background())
+	assert.Empty(t, uploaded)
+}
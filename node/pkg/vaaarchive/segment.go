@@ -0,0 +1,142 @@
+package vaaarchive
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A segment is a gzip compressed file of VAAs, each preceded by its length as a big endian uint32. Its name records the range of
+// VAA timestamps in it, so restores only need to read the segments that overlap the requested range.
+const (
+	segmentPrefix = "segment-"
+	segmentSuffix = ".vaas.gz"
+	// uploadedSuffix is appended to the name of a segment to mark it as uploaded.
+	uploadedSuffix = ".uploaded"
+)
+
+// segment describes a segment file.
+type segment struct {
+	name     string
+	earliest time.Time
+	latest   time.Time
+}
+
+func segmentName(earliest, latest, created time.Time) string {
+	return fmt.Sprintf("%s%d-%d-%d%s", segmentPrefix, earliest.Unix(), latest.Unix(), created.UnixNano(), segmentSuffix)
+}
+
+func parseSegmentName(name string) (*segment, bool) {
+	if !strings.HasPrefix(name, segmentPrefix) || !strings.HasSuffix(name, segmentSuffix) {
+		return nil, false
+	}
+	parts := strings.Split(strings.TrimSuffix(strings.TrimPrefix(name, segmentPrefix), segmentSuffix), "-")
+	if len(parts) != 3 {
+		return nil, false
+	}
+	earliest, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, false
+	}
+	latest, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, false
+	}
+	return &segment{name: name, earliest: time.Unix(earliest, 0), latest: time.Unix(latest, 0)}, true
+}
+
+// listSegments returns the segments in dir.
+func listSegments(dir string) ([]*segment, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var segments []*segment
+	for _, e := range entries {
+		if s, ok := parseSegmentName(e.Name()); ok && e.Type().IsRegular() {
+			segments = append(segments, s)
+		}
+	}
+	return segments, nil
+}
+
+// writeSegment writes VAAs to a new segment in dir. The segment is synced to disk before it is given its final name, so a segment
+// that exists is complete.
+func writeSegment(dir string, name string, vaas [][]byte) (err error) {
+	f, err := os.CreateTemp(dir, ".tmp-"+name)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}()
+
+	bw := bufio.NewWriter(f)
+	zw := gzip.NewWriter(bw)
+	var length [4]byte
+	for _, b := range vaas {
+		binary.BigEndian.PutUint32(length[:], uint32(len(b)))
+		if _, err = zw.Write(length[:]); err != nil {
+			return err
+		}
+		if _, err = zw.Write(b); err != nil {
+			return err
+		}
+	}
+	if err = zw.Close(); err != nil {
+		return err
+	}
+	if err = bw.Flush(); err != nil {
+		return err
+	}
+	if err = f.Sync(); err != nil {
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), filepath.Join(dir, name))
+}
+
+// readSegment calls fn with every VAA in a segment.
+func readSegment(path string, fn func([]byte) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(bufio.NewReader(f))
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+
+	var length [4]byte
+	for {
+		if _, err := io.ReadFull(zr, length[:]); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		b := make([]byte, binary.BigEndian.Uint32(length[:]))
+		if _, err := io.ReadFull(zr, b); err != nil {
+			return fmt.Errorf("truncated segment: %w", err)
+		}
+		if err := fn(b); err != nil {
+			return err
+		}
+	}
+}
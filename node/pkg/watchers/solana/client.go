@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"encoding/base64"
@@ -39,12 +40,18 @@ type (
 		rawContract string
 		rpcUrl      string
 		wsUrl       *string
-		commitment  rpc.CommitmentType
-		msgC        chan<- *common.MessagePublication
-		obsvReqC    <-chan *gossipv1.ObservationRequest
-		errC        chan error
-		pumpData    chan []byte
-		rpcClient   *rpc.Client
+		// geyserUrl is the optional URL of a Geyser gRPC endpoint, which replaces fetching every block if set.
+		geyserUrl   string
+		geyserToken string
+		// geyserSlot is the last slot the Geyser stream reported at the commitment of the watcher. It is kept across restarts of
+		// the watcher so that the slots missed while the stream was down can be fetched.
+		geyserSlot atomic.Uint64
+		commitment rpc.CommitmentType
+		msgC       chan<- *common.MessagePublication
+		obsvReqC   <-chan *gossipv1.ObservationRequest
+		errC       chan error
+		pumpData   chan []byte
+		rpcClient  *rpc.Client
 		// Readiness component
 		readinessSync readiness.Component // TBDel
 		// VAA ChainID of the network we're connecting to.
@@ -206,6 +213,8 @@ type PostMessageData struct {
 func NewSolanaWatcher(
 	rpcUrl string,
 	wsUrl *string,
+	geyserUrl string,
+	geyserToken string,
 	contractAddress solana.PublicKey,
 	rawContract string,
 	msgC chan<- *common.MessagePublication,
@@ -218,6 +227,8 @@ func NewSolanaWatcher(
 	return &SolanaWatcher{
 		rpcUrl:         rpcUrl,
 		wsUrl:          wsUrl,
+		geyserUrl:      geyserUrl,
+		geyserToken:    geyserToken,
 		contract:       contractAddress,
 		rawContract:    rawContract,
 		msgC:           msgC,
@@ -318,6 +329,7 @@ func (s *SolanaWatcher) Run(ctx context.Context) error {
 		zap.String("watcher_name", "solana"),
 		zap.String("rpcUrl", s.rpcUrl),
 		zap.String("wsUrl", wsUrl),
+		zap.String("geyserUrl", s.geyserUrl),
 		zap.String("contract", contractAddr),
 		zap.String("rawContract", s.rawContract),
 	)
@@ -336,6 +348,14 @@ func (s *SolanaWatcher) Run(ctx context.Context) error {
 		}
 	}
 
+	useGeyser := s.geyserUrl != ""
+	if useGeyser {
+		if useWs {
+			return errGeyserUnsupportedWithWebsocket
+		}
+		common.RunWithScissors(ctx, s.errC, "SolanaWatcherGeyser", s.runGeyserSubscription)
+	}
+
 	common.RunWithScissors(ctx, s.errC, "SolanaWatcher", func(ctx context.Context) error {
 		timer := time.NewTicker(time.Second * 1)
 		defer timer.Stop()
@@ -361,7 +381,7 @@ func (s *SolanaWatcher) Run(ctx context.Context) error {
 				logger.Info("received observation request", zap.String("account", acc.String()))
 
 				rCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
-				s.fetchMessageAccount(rCtx, logger, acc, 0, true)
+				s.fetchMessageAccount(rCtx, logger, acc, solana.Signature{}, 0, true)
 				cancel()
 			case <-timer.C:
 				// Get current slot height
@@ -388,7 +408,7 @@ func (s *SolanaWatcher) Run(ctx context.Context) error {
 					ContractAddress: contractAddr,
 				})
 
-				if !useWs {
+				if !useWs && !useGeyser {
 					rangeStart := lastSlot + 1
 					rangeEnd := slot
 
@@ -664,7 +684,7 @@ func (s *SolanaWatcher) processInstruction(ctx context.Context, logger *zap.Logg
 		return false, fmt.Errorf("failed to determine commitment: %w", err)
 	}
 
-	if level != s.commitment {
+	if level != s.messageCommitment() {
 		return true, nil
 	}
 
@@ -675,15 +695,15 @@ func (s *SolanaWatcher) processInstruction(ctx context.Context, logger *zap.Logg
 		zap.Stringer("signature", signature), zap.Uint64("slot", slot), zap.Int("idx", idx))
 
 	common.RunWithScissors(ctx, s.errC, "retryFetchMessageAccount", func(ctx context.Context) error {
-		s.retryFetchMessageAccount(ctx, logger, acc, slot, 0, isReobservation)
+		s.retryFetchMessageAccount(ctx, logger, acc, signature, slot, 0, isReobservation)
 		return nil
 	})
 
 	return true, nil
 }
 
-func (s *SolanaWatcher) retryFetchMessageAccount(ctx context.Context, logger *zap.Logger, acc solana.PublicKey, slot uint64, retry uint, isReobservation bool) {
-	retryable := s.fetchMessageAccount(ctx, logger, acc, slot, isReobservation)
+func (s *SolanaWatcher) retryFetchMessageAccount(ctx context.Context, logger *zap.Logger, acc solana.PublicKey, signature solana.Signature, slot uint64, retry uint, isReobservation bool) {
+	retryable := s.fetchMessageAccount(ctx, logger, acc, signature, slot, isReobservation)
 
 	if retryable {
 		if retry >= maxRetries {
@@ -704,13 +724,14 @@ func (s *SolanaWatcher) retryFetchMessageAccount(ctx context.Context, logger *za
 			zap.Uint("retry", retry))
 
 		common.RunWithScissors(ctx, s.errC, "retryFetchMessageAccount", func(ctx context.Context) error {
-			s.retryFetchMessageAccount(ctx, logger, acc, slot, retry+1, isReobservation)
+			s.retryFetchMessageAccount(ctx, logger, acc, signature, slot, retry+1, isReobservation)
 			return nil
 		})
 	}
 }
 
-func (s *SolanaWatcher) fetchMessageAccount(ctx context.Context, logger *zap.Logger, acc solana.PublicKey, slot uint64, isReobservation bool) (retryable bool) {
+// fetchMessageAccount observes the message held by acc. signature is the transaction which posted it, or zero if it is not known.
+func (s *SolanaWatcher) fetchMessageAccount(ctx context.Context, logger *zap.Logger, acc solana.PublicKey, signature solana.Signature, slot uint64, isReobservation bool) (retryable bool) {
 	// Fetching account
 	rCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
 	defer cancel()
@@ -759,7 +780,7 @@ func (s *SolanaWatcher) fetchMessageAccount(ctx context.Context, logger *zap.Log
 		zap.Stringer("account", acc),
 		zap.Binary("data", data))
 
-	s.processMessageAccount(logger, data, acc, isReobservation)
+	s.processMessageAccount(logger, data, acc, signature, isReobservation)
 	return false
 }
 
@@ -813,7 +834,7 @@ func (s *SolanaWatcher) processAccountSubscriptionData(_ context.Context, logger
 	switch string(data[:3]) {
 	case accountPrefixReliable, accountPrefixUnreliable:
 		acc := solana.PublicKeyFromBytes([]byte(value.Pubkey))
-		s.processMessageAccount(logger, data, acc, isReobservation)
+		s.processMessageAccount(logger, data, acc, solana.Signature{}, isReobservation)
 	default:
 		break
 	}
@@ -821,7 +842,8 @@ func (s *SolanaWatcher) processAccountSubscriptionData(_ context.Context, logger
 	return nil
 }
 
-func (s *SolanaWatcher) processMessageAccount(logger *zap.Logger, data []byte, acc solana.PublicKey, isReobservation bool) {
+// processMessageAccount observes the message held by acc. signature is the transaction which posted it, or zero if it is not known.
+func (s *SolanaWatcher) processMessageAccount(logger *zap.Logger, data []byte, acc solana.PublicKey, signature solana.Signature, isReobservation bool) {
 	proposal, err := ParseMessagePublicationAccount(data)
 	if err != nil {
 		solanaAccountSkips.WithLabelValues(s.networkName, "parse_transfer_out").Inc()
@@ -842,7 +864,7 @@ func (s *SolanaWatcher) processMessageAccount(logger *zap.Logger, data []byte, a
 			zap.Error(err))
 		return
 	}
-	if commitment != s.commitment {
+	if commitment != s.messageCommitment() {
 		if isReobservation && s.commitment == rpc.CommitmentFinalized {
 			// There is only a single reobservation request channel for each chain, which is assigned to the finalized watcher.
 			// If someone requests reobservation of a confirmed message, we should allow the observation to go through.
@@ -856,7 +878,7 @@ func (s *SolanaWatcher) processMessageAccount(logger *zap.Logger, data []byte, a
 	}
 
 	// SECURITY: ensure these fields are zeroed out. in the legacy solana program they were always zero, and in the 2023 rewrite they are zeroed once the account is finalized
-	if !bytes.Equal(proposal.EmitterAuthority.Bytes(), emptyAddressBytes) || proposal.MessageStatus != 0 || !bytes.Equal(proposal.Gap[:], emptyGapBytes) {
+	if !messageAccountFinalized(proposal) {
 		solanaAccountSkips.WithLabelValues(s.networkName, "unfinalized_account").Inc()
 		logger.Error(
 			"account is not finalized",
@@ -889,12 +911,20 @@ func (s *SolanaWatcher) processMessageAccount(logger *zap.Logger, data []byte, a
 		ConsistencyLevel: proposal.ConsistencyLevel,
 		IsReobservation:  isReobservation,
 		Unreliable:       !reliable,
+		Confidence:       string(s.commitment),
+	}
+	if isReobservation {
+		observation.Confidence = common.ConfidenceReobserved
+	}
+	if !signature.IsZero() {
+		observation.TxID = signature[:]
 	}
 
 	solanaMessagesConfirmed.WithLabelValues(s.networkName).Inc()
 
 	logger.Debug("message observed",
 		zap.Stringer("account", acc),
+		zap.Stringer("signature", signature),
 		zap.Time("timestamp", observation.Timestamp),
 		zap.Uint32("nonce", observation.Nonce),
 		zap.Uint64("sequence", observation.Sequence),
@@ -902,11 +932,21 @@ func (s *SolanaWatcher) processMessageAccount(logger *zap.Logger, data []byte, a
 		zap.Stringer("emitter_address", observation.EmitterAddress),
 		zap.Binary("payload", observation.Payload),
 		zap.Uint8("consistency_level", observation.ConsistencyLevel),
+		zap.String("confidence", observation.Confidence),
 	)
 
 	s.msgC <- observation
 }
 
+// messageCommitment returns the commitment requested by the messages this watcher observes. Messages cannot request the processed
+// commitment, so a processed watcher observes the messages requesting confirmation as soon as they are processed.
+func (s *SolanaWatcher) messageCommitment() rpc.CommitmentType {
+	if s.commitment == rpc.CommitmentProcessed {
+		return rpc.CommitmentConfirmed
+	}
+	return s.commitment
+}
+
 // updateLatestBlock() updates the latest block number if the slot passed in is greater than the previous value.
 // This check is necessary because blocks can be posted out of order, due to multi threading in this watcher.
 func (s *SolanaWatcher) updateLatestBlock(slot uint64) {
@@ -924,6 +964,12 @@ func (s *SolanaWatcher) GetLatestFinalizedBlockNumber() uint64 {
 	return s.latestBlockNumber
 }
 
+// messageAccountFinalized returns whether the fields which the 2023 rewrite of the program only zeroes once the message is written are
+// zeroed. They were always zero in the legacy program.
+func messageAccountFinalized(proposal *MessagePublicationAccount) bool {
+	return bytes.Equal(proposal.EmitterAuthority.Bytes(), emptyAddressBytes) && proposal.MessageStatus == 0 && bytes.Equal(proposal.Gap[:], emptyGapBytes)
+}
+
 func ParseMessagePublicationAccount(data []byte) (*MessagePublicationAccount, error) {
 	prop := &MessagePublicationAccount{}
 	// Skip the b"msg" prefix
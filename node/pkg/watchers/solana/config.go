@@ -1,6 +1,9 @@
 package solana
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/certusone/wormhole/node/pkg/common"
 	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
 	"github.com/certusone/wormhole/node/pkg/query"
@@ -13,13 +16,64 @@ import (
 )
 
 type WatcherConfig struct {
-	NetworkID     watchers.NetworkID // unique identifier of the network
-	ChainID       vaa.ChainID        // ChainID
-	ReceiveObsReq bool               // if false, this watcher will not get access to the observation request channel
-	Rpc           string             // RPC URL
-	Websocket     string             // Websocket URL
-	Contract      string             // hex representation of the contract address
-	Commitment    solana_rpc.CommitmentType
+	NetworkID     watchers.NetworkID        // unique identifier of the network
+	ChainID       vaa.ChainID               // ChainID
+	ReceiveObsReq bool                      // if false, this watcher will not get access to the observation request channel
+	Rpc           string                    // RPC URL
+	Websocket     string                    // Websocket URL
+	Geyser        string                    // Geyser gRPC URL, streams the message accounts instead of fetching every block if set
+	GeyserToken   string                    // x-token sent to the Geyser endpoint
+	Contract      string                    // hex representation of the contract address
+	Commitment    solana_rpc.CommitmentType // processed (devnet only, requires Geyser), confirmed or finalized
+	// ExpectedContractHash is the optional hex SHA-256 hash of the deployed program. It is only used by Validate.
+	ExpectedContractHash string
+}
+
+// ParseCommitments parses a comma separated list of the commitment levels to run a Solana watcher at. The list must include finalized,
+// as the finalized watcher handles re-observation requests and cross chain queries.
+func ParseCommitments(str string) ([]solana_rpc.CommitmentType, error) {
+	var commitments []solana_rpc.CommitmentType
+	hasFinalized := false
+	for _, s := range strings.Split(str, ",") {
+		c := solana_rpc.CommitmentType(strings.TrimSpace(s))
+		switch c {
+		case solana_rpc.CommitmentProcessed, solana_rpc.CommitmentConfirmed:
+		case solana_rpc.CommitmentFinalized:
+			hasFinalized = true
+		default:
+			return nil, fmt.Errorf("invalid commitment %q, must be processed, confirmed or finalized", c)
+		}
+		for _, prev := range commitments {
+			if prev == c {
+				return nil, fmt.Errorf("duplicate commitment %q", c)
+			}
+		}
+		commitments = append(commitments, c)
+	}
+	if !hasFinalized {
+		return nil, fmt.Errorf("commitments must include %q", solana_rpc.CommitmentFinalized)
+	}
+	return commitments, nil
+}
+
+// checkCommitment returns an error if the commitment of the watcher is not supported, or not safe in env. A processed watcher observes
+// the messages requesting confirmation from blocks which may still be skipped, so it is only allowed on devnet. It needs a Geyser
+// endpoint, as getBlock does not serve processed blocks.
+func (wc *WatcherConfig) checkCommitment(env common.Environment) error {
+	switch wc.Commitment {
+	case solana_rpc.CommitmentConfirmed, solana_rpc.CommitmentFinalized:
+		return nil
+	case solana_rpc.CommitmentProcessed:
+		if env != common.UnsafeDevNet && env != common.GoTest {
+			return fmt.Errorf("commitment %s is only allowed in devnet", wc.Commitment)
+		}
+		if wc.Geyser == "" {
+			return fmt.Errorf("commitment %s requires a Geyser endpoint", wc.Commitment)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported commitment %q", wc.Commitment)
+	}
 }
 
 func (wc *WatcherConfig) GetNetworkID() watchers.NetworkID {
@@ -46,6 +100,10 @@ func (wc *WatcherConfig) Create(
 	_ chan<- *common.GuardianSet,
 	env common.Environment,
 ) (interfaces.L1Finalizer, supervisor.Runnable, error) {
+	if err := wc.checkCommitment(env); err != nil {
+		return nil, nil, err
+	}
+
 	solAddress, err := solana_types.PublicKeyFromBase58(wc.Contract)
 	if err != nil {
 		return nil, nil, err
@@ -55,7 +113,7 @@ func (wc *WatcherConfig) Create(
 		obsvReqC = nil
 	}
 
-	watcher := NewSolanaWatcher(wc.Rpc, &wc.Websocket, solAddress, wc.Contract, msgC, obsvReqC, wc.Commitment, wc.ChainID, queryReqC, queryResponseC)
+	watcher := NewSolanaWatcher(wc.Rpc, &wc.Websocket, wc.Geyser, wc.GeyserToken, solAddress, wc.Contract, msgC, obsvReqC, wc.Commitment, wc.ChainID, queryReqC, queryResponseC)
 
 	return watcher, watcher.Run, nil
 }
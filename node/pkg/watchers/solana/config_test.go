@@ -0,0 +1,44 @@
+package solana
+
+import (
+	"testing"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	solana_rpc "github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCommitments(t *testing.T) {
+	commitments, err := ParseCommitments("confirmed,finalized")
+	require.NoError(t, err)
+	assert.Equal(t, []solana_rpc.CommitmentType{solana_rpc.CommitmentConfirmed, solana_rpc.CommitmentFinalized}, commitments)
+
+	commitments, err = ParseCommitments(" finalized ")
+	require.NoError(t, err)
+	assert.Equal(t, []solana_rpc.CommitmentType{solana_rpc.CommitmentFinalized}, commitments)
+
+	for _, str := range []string{"", "confirmed", "finalized,recent", "finalized,finalized"} {
+		_, err := ParseCommitments(str)
+		assert.Error(t, err, str)
+	}
+}
+
+func TestCheckCommitment(t *testing.T) {
+	wc := &WatcherConfig{Commitment: solana_rpc.CommitmentConfirmed}
+	assert.NoError(t, wc.checkCommitment(common.MainNet))
+
+	// Processed watchers are only allowed on devnet, with a Geyser endpoint.
+	wc.Commitment = solana_rpc.CommitmentProcessed
+	assert.ErrorContains(t, wc.checkCommitment(common.UnsafeDevNet), "requires a Geyser endpoint")
+	wc.Geyser = "http://solana-geyser:10000"
+	assert.NoError(t, wc.checkCommitment(common.UnsafeDevNet))
+	assert.ErrorContains(t, wc.checkCommitment(common.TestNet), "only allowed in devnet")
+	assert.ErrorContains(t, wc.checkCommitment(common.MainNet), "only allowed in devnet")
+
+	wc.Commitment = solana_rpc.CommitmentRecent
+	assert.Error(t, wc.checkCommitment(common.UnsafeDevNet))
+
+	_, _, err := (&WatcherConfig{Commitment: solana_rpc.CommitmentProcessed, Geyser: "http://solana-geyser:10000"}).Create(nil, nil, nil, nil, nil, common.MainNet)
+	assert.Error(t, err)
+}
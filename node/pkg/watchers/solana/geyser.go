@@ -0,0 +1,222 @@
+package solana
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sync/atomic"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/p2p"
+	geyserv1 "github.com/certusone/wormhole/node/pkg/proto/geyser"
+	"github.com/certusone/wormhole/node/pkg/supervisor"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// geyserStallTimeout is how long the Geyser stream may go without any update before it is considered stalled. The stream
+	// carries a slot update for every slot, so it is never idle for long.
+	geyserStallTimeout = 60 * time.Second
+
+	// geyserFilterName is the name of the filters of the subscription, which updates refer to.
+	geyserFilterName = "wormhole"
+)
+
+var errGeyserUnsupportedWithWebsocket = errors.New("the Geyser stream and the websocket subscription cannot both be enabled")
+
+// geyserCommitment returns the Geyser commitment level matching a watcher commitment.
+func geyserCommitment(commitment rpc.CommitmentType) (geyserv1.CommitmentLevel, error) {
+	switch commitment {
+	case rpc.CommitmentProcessed:
+		return geyserv1.CommitmentLevel_PROCESSED, nil
+	case rpc.CommitmentConfirmed:
+		return geyserv1.CommitmentLevel_CONFIRMED, nil
+	case rpc.CommitmentFinalized:
+		return geyserv1.CommitmentLevel_FINALIZED, nil
+	default:
+		return 0, fmt.Errorf("unsupported commitment %s", commitment)
+	}
+}
+
+// dialGeyser connects to the Geyser gRPC endpoint at rawUrl, using TLS if its scheme is https.
+func dialGeyser(ctx context.Context, rawUrl string) (*grpc.ClientConn, error) {
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Geyser URL: %w", err)
+	}
+	var creds credentials.TransportCredentials
+	port := u.Port()
+	switch u.Scheme {
+	case "https":
+		creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+		if port == "" {
+			port = "443"
+		}
+	case "http":
+		creds = insecure.NewCredentials()
+		if port == "" {
+			port = "80"
+		}
+	default:
+		return nil, fmt.Errorf("invalid Geyser URL scheme %q, must be http or https", u.Scheme)
+	}
+
+	return grpc.DialContext(ctx, net.JoinHostPort(u.Hostname(), port),
+		grpc.WithTransportCredentials(creds),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{Time: 30 * time.Second, Timeout: 10 * time.Second, PermitWithoutStream: true}),
+	)
+}
+
+// runGeyserSubscription streams the accounts of the core bridge program from the Geyser endpoint, at the commitment of the watcher,
+// and observes the messages they hold. This replaces fetching every block, which is only needed to find the message accounts. It
+// returns when the stream fails or ctx is done.
+func (s *SolanaWatcher) runGeyserSubscription(ctx context.Context) error {
+	logger := supervisor.Logger(ctx)
+	// The blocks missed while the stream was down are fetched with the context of the watcher, since the stream context is
+	// canceled when the stream fails.
+	fetchCtx := ctx
+
+	commitment, err := geyserCommitment(s.commitment)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Solana watcher connecting to Geyser endpoint", zap.String("url", s.geyserUrl))
+	conn, err := dialGeyser(ctx, s.geyserUrl)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if s.geyserToken != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-token", s.geyserToken)
+	}
+
+	stream, err := geyserv1.NewGeyserClient(conn).Subscribe(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to Geyser stream: %w", err)
+	}
+	filterByCommitment := true
+	err = stream.Send(&geyserv1.SubscribeRequest{
+		Accounts:   map[string]*geyserv1.SubscribeRequestFilterAccounts{geyserFilterName: {Owner: []string{s.rawContract}}},
+		Slots:      map[string]*geyserv1.SubscribeRequestFilterSlots{geyserFilterName: {FilterByCommitment: &filterByCommitment}},
+		Commitment: &commitment,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send Geyser subscription: %w", err)
+	}
+
+	var stalled atomic.Bool
+	stallTimer := time.AfterFunc(geyserStallTimeout, func() {
+		stalled.Store(true)
+		cancel()
+	})
+	defer stallTimer.Stop()
+
+	firstSlot := true
+	for {
+		update, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil && !stalled.Load() {
+				return nil
+			}
+			p2p.DefaultRegistry.AddErrorCount(s.chainID, 1)
+			solanaConnectionErrors.WithLabelValues(s.networkName, string(s.commitment), "geyser_stream_error").Inc()
+			if stalled.Load() {
+				return fmt.Errorf("Geyser stream received no update for %s", geyserStallTimeout)
+			}
+			return fmt.Errorf("Geyser stream failed: %w", err)
+		}
+		stallTimer.Reset(geyserStallTimeout)
+
+		switch u := update.UpdateOneof.(type) {
+		case *geyserv1.SubscribeUpdate_Account:
+			s.processGeyserAccountUpdate(logger, u.Account)
+		case *geyserv1.SubscribeUpdate_Slot:
+			if u.Slot.Status == commitment {
+				s.processGeyserSlotUpdate(fetchCtx, logger, u.Slot.Slot, firstSlot)
+				firstSlot = false
+			}
+		case *geyserv1.SubscribeUpdate_Ping:
+			// Load balancers in front of Geyser endpoints close streams on which the client sends nothing.
+			if err := stream.Send(&geyserv1.SubscribeRequest{Ping: &geyserv1.SubscribeRequestPing{Id: 1}}); err != nil {
+				return fmt.Errorf("failed to answer Geyser ping: %w", err)
+			}
+		}
+	}
+}
+
+// processGeyserSlotUpdate records that the Geyser stream reached slot. On the first slot of a stream, the blocks since the last slot
+// reported by the previous stream are fetched, since the accounts written in them were not streamed. The first slot is fetched as
+// well, as the stream may have started after some of its accounts were sent. A processed watcher does not fetch them, since getBlock
+// does not serve processed blocks and the confirmed watcher observes the same messages.
+func (s *SolanaWatcher) processGeyserSlotUpdate(ctx context.Context, logger *zap.Logger, slot uint64, first bool) {
+	last := s.geyserSlot.Load()
+	if slot <= last {
+		return
+	}
+	if first && last != 0 && s.commitment != rpc.CommitmentProcessed {
+		logger.Info("fetching the slots missed while the Geyser stream was down", zap.Uint64("from", last+1), zap.Uint64("to", slot))
+		for missed := last + 1; missed <= slot; missed++ {
+			_slot := missed
+			common.RunWithScissors(ctx, s.errC, "SolanaWatcherSlotFetcher", func(ctx context.Context) error {
+				s.retryFetchBlock(ctx, logger, _slot, 0, false)
+				return nil
+			})
+		}
+	}
+	s.geyserSlot.Store(slot)
+}
+
+// processGeyserAccountUpdate observes the message held by an account of the core bridge program, if it holds a finalized one.
+func (s *SolanaWatcher) processGeyserAccountUpdate(logger *zap.Logger, update *geyserv1.SubscribeUpdateAccount) {
+	info := update.GetAccount()
+	// Accounts sent when the validator starts do not hold new messages.
+	if info == nil || update.IsStartup {
+		return
+	}
+	if len(info.Owner) != solana.PublicKeyLength || !solana.PublicKeyFromBytes(info.Owner).Equals(s.contract) {
+		solanaConnectionErrors.WithLabelValues(s.networkName, string(s.commitment), "invalid_geyser_account").Inc()
+		logger.Error("Geyser update for account with wrong owner", zap.Binary("owner", info.Owner))
+		return
+	}
+	if len(info.Pubkey) != solana.PublicKeyLength {
+		logger.Error("Geyser update for account with invalid address", zap.Binary("pubkey", info.Pubkey))
+		return
+	}
+	acc := solana.PublicKeyFromBytes(info.Pubkey)
+
+	// Other accounts owned by the core bridge program are updated as well.
+	data := info.Data
+	if len(data) < 3 || (string(data[:3]) != accountPrefixReliable && string(data[:3]) != accountPrefixUnreliable) {
+		return
+	}
+
+	// Unlike a getBlock poll, the stream also sees the message accounts which are still being written.
+	if proposal, err := ParseMessagePublicationAccount(data); err == nil && !messageAccountFinalized(proposal) {
+		logger.Debug("skipping message account which is not finalized yet", zap.Stringer("account", acc), zap.Uint64("slot", update.Slot))
+		return
+	}
+
+	// The plugin sends the signature of the transaction which wrote the account, if it knows it.
+	var signature solana.Signature
+	if len(info.TxnSignature) == solana.SignatureLength {
+		copy(signature[:], info.TxnSignature)
+	}
+
+	s.updateLatestBlock(update.Slot)
+	s.processMessageAccount(logger, data, acc, signature, false)
+}
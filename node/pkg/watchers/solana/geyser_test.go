@@ -0,0 +1,347 @@
+package solana
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	geyserv1 "github.com/certusone/wormhole/node/pkg/proto/geyser"
+	"github.com/certusone/wormhole/node/pkg/supervisor"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/near/borsh-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// fakeGeyser sends its updates to the first subscriber, then waits for the answer to its ping.
+type fakeGeyser struct {
+	geyserv1.UnimplementedGeyserServer
+	updates []*geyserv1.SubscribeUpdate
+	subC    chan *geyserv1.SubscribeRequest
+	pongC   chan struct{}
+}
+
+func (f *fakeGeyser) Subscribe(stream geyserv1.Geyser_SubscribeServer) error {
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	if token := md.Get("x-token"); len(token) != 1 || token[0] != "secret" {
+		return status.Error(codes.Unauthenticated, "invalid token")
+	}
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	f.subC <- req
+
+	for _, u := range f.updates {
+		if err := stream.Send(u); err != nil {
+			return err
+		}
+	}
+	req, err = stream.Recv()
+	if err != nil {
+		return err
+	}
+	if req.Ping != nil {
+		close(f.pongC)
+	}
+	<-stream.Context().Done()
+	return nil
+}
+
+// testSignature is the signature of the transactions posting the messages of the tests.
+var testSignature = solana.Signature{1}
+
+func messageAccountUpdate(t *testing.T, owner solana.PublicKey, acc solana.PublicKey, msg MessagePublicationAccount, isStartup bool) *geyserv1.SubscribeUpdate {
+	t.Helper()
+	data, err := borsh.Serialize(msg)
+	require.NoError(t, err)
+	return &geyserv1.SubscribeUpdate{
+		Filters: []string{geyserFilterName},
+		UpdateOneof: &geyserv1.SubscribeUpdate_Account{Account: &geyserv1.SubscribeUpdateAccount{
+			Account: &geyserv1.SubscribeUpdateAccountInfo{
+				Pubkey:       acc.Bytes(),
+				Owner:        owner.Bytes(),
+				Data:         append([]byte(accountPrefixReliable), data...),
+				TxnSignature: testSignature[:],
+			},
+			Slot:      100,
+			IsStartup: isStartup,
+		}},
+	}
+}
+
+func TestGeyserSubscription(t *testing.T) {
+	contract := solana.NewWallet().PublicKey()
+	acc := solana.NewWallet().PublicKey()
+	msg := MessagePublicationAccount{
+		ConsistencyLevel: 32,
+		SubmissionTime:   1700000000,
+		Nonce:            7,
+		Sequence:         42,
+		EmitterChain:     uint16(vaa.ChainIDSolana),
+		EmitterAddress:   vaa.Address{1, 2, 3},
+		Payload:          []byte("payload"),
+	}
+	unfinalized := msg
+	unfinalized.Sequence = 43
+	unfinalized.MessageStatus = 1
+
+	fake := &fakeGeyser{
+		updates: []*geyserv1.SubscribeUpdate{
+			messageAccountUpdate(t, contract, acc, msg, true),
+			messageAccountUpdate(t, contract, solana.NewWallet().PublicKey(), unfinalized, false),
+			messageAccountUpdate(t, solana.NewWallet().PublicKey(), acc, msg, false),
+			messageAccountUpdate(t, contract, acc, msg, false),
+			{UpdateOneof: &geyserv1.SubscribeUpdate_Ping{Ping: &geyserv1.SubscribeUpdatePing{}}},
+		},
+		subC:  make(chan *geyserv1.SubscribeRequest, 1),
+		pongC: make(chan struct{}),
+	}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	geyserv1.RegisterGeyserServer(server, fake)
+	go func() { _ = server.Serve(lis) }()
+	defer server.Stop()
+
+	msgC := make(chan *common.MessagePublication, 10)
+	w := NewSolanaWatcher("http://127.0.0.1:1", nil, "http://"+lis.Addr().String(), "secret", contract, contract.String(), msgC, nil, rpc.CommitmentFinalized, vaa.ChainIDSolana, nil, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	errC := make(chan error, 1)
+	supervisor.New(ctx, zap.NewNop(), func(ctx context.Context) error {
+		supervisor.Signal(ctx, supervisor.SignalHealthy)
+		errC <- w.runGeyserSubscription(ctx)
+		<-ctx.Done()
+		return nil
+	})
+
+	select {
+	case req := <-fake.subC:
+		require.Contains(t, req.Accounts, geyserFilterName)
+		assert.Equal(t, []string{contract.String()}, req.Accounts[geyserFilterName].Owner)
+		assert.Equal(t, geyserv1.CommitmentLevel_FINALIZED, req.GetCommitment())
+	case <-ctx.Done():
+		t.Fatal("no subscription received")
+	}
+
+	// Only the finalized update of an account owned by the contract is observed.
+	select {
+	case m := <-msgC:
+		assert.Equal(t, uint64(42), m.Sequence)
+		assert.Equal(t, acc.Bytes(), m.TxHash.Bytes())
+		assert.Equal(t, testSignature[:], m.TxID)
+		assert.Equal(t, vaa.Address{1, 2, 3}, m.EmitterAddress)
+		assert.Equal(t, []byte("payload"), m.Payload)
+		assert.False(t, m.Unreliable)
+	case <-ctx.Done():
+		t.Fatal("no message observed")
+	}
+
+	select {
+	case <-fake.pongC:
+	case <-ctx.Done():
+		t.Fatal("ping not answered")
+	}
+	assert.Empty(t, msgC)
+	assert.Equal(t, uint64(100), w.GetLatestFinalizedBlockNumber())
+
+	cancel()
+	select {
+	case err := <-errC:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription did not stop")
+	}
+}
+
+// droppingGeyser sends each of its streams the updates for it. Every stream but the last one then fails.
+type droppingGeyser struct {
+	geyserv1.UnimplementedGeyserServer
+	streams [][]*geyserv1.SubscribeUpdate
+	next    atomic.Int32
+}
+
+func (f *droppingGeyser) Subscribe(stream geyserv1.Geyser_SubscribeServer) error {
+	if _, err := stream.Recv(); err != nil {
+		return err
+	}
+	n := int(f.next.Add(1)) - 1
+	for _, u := range f.streams[n] {
+		if err := stream.Send(u); err != nil {
+			return err
+		}
+	}
+	if n < len(f.streams)-1 {
+		return status.Error(codes.Unavailable, "stream dropped")
+	}
+	<-stream.Context().Done()
+	return nil
+}
+
+func slotUpdate(slot uint64) *geyserv1.SubscribeUpdate {
+	return &geyserv1.SubscribeUpdate{
+		Filters:     []string{geyserFilterName},
+		UpdateOneof: &geyserv1.SubscribeUpdate_Slot{Slot: &geyserv1.SubscribeUpdateSlot{Slot: slot, Status: geyserv1.CommitmentLevel_FINALIZED}},
+	}
+}
+
+// newFakeBlockRPC serves the blocks in blocks, and the message account acc holding msg.
+func newFakeBlockRPC(t *testing.T, contract solana.PublicKey, acc solana.PublicKey, msg MessagePublicationAccount, blocks map[uint64]*rpc.GetBlockResult) *httptest.Server {
+	t.Helper()
+	data, err := borsh.Serialize(msg)
+	require.NoError(t, err)
+	data = append([]byte(accountPrefixReliable), data...)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     any               `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		var result any
+		switch req.Method {
+		case "getBlock":
+			var slot uint64
+			require.NoError(t, json.Unmarshal(req.Params[0], &slot))
+			block, ok := blocks[slot]
+			if !ok {
+				t.Errorf("unexpected block %d requested", slot)
+			}
+			result = block
+		case "getAccountInfo":
+			var addr string
+			require.NoError(t, json.Unmarshal(req.Params[0], &addr))
+			assert.Equal(t, acc.String(), addr)
+			result = map[string]any{
+				"context": map[string]any{"slot": 102},
+				"value": map[string]any{
+					"data":       []string{base64.StdEncoding.EncodeToString(data), "base64"},
+					"executable": false,
+					"lamports":   1,
+					"owner":      contract.String(),
+					"rentEpoch":  0,
+				},
+			}
+		default:
+			t.Errorf("unexpected method %s", req.Method)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": result}))
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+// postMessageBlock returns a block with a transaction posting a finalized message to acc.
+func postMessageBlock(t *testing.T, contract solana.PublicKey, acc solana.PublicKey) *rpc.GetBlockResult {
+	t.Helper()
+	data, err := borsh.Serialize(PostMessageData{Nonce: 7, Payload: []byte("payload"), ConsistencyLevel: consistencyLevelFinalized})
+	require.NoError(t, err)
+	keys := []solana.PublicKey{solana.NewWallet().PublicKey(), acc, contract}
+	for len(keys) < postMessageInstructionMinNumAccounts+1 {
+		keys = append(keys, solana.NewWallet().PublicKey())
+	}
+	tx := solana.Transaction{
+		Signatures: []solana.Signature{testSignature},
+		Message: solana.Message{
+			Header:      solana.MessageHeader{NumRequiredSignatures: 1},
+			AccountKeys: keys,
+			Instructions: []solana.CompiledInstruction{{
+				ProgramIDIndex: 2,
+				Accounts:       []uint16{0, 1, 3, 4, 5, 6, 7, 8},
+				Data:           append([]byte{postMessageInstructionID}, data...),
+			}},
+		},
+	}
+	b, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	return &rpc.GetBlockResult{
+		Transactions: []rpc.TransactionWithMeta{{
+			Transaction: rpc.DataBytesOrJSONFromBytes(b),
+			Meta:        &rpc.TransactionMeta{LogMessages: []string{fmt.Sprintf("Program %s invoke [1]", contract)}},
+		}},
+	}
+}
+
+func TestGeyserSubscriptionBackfillsAfterReconnect(t *testing.T) {
+	contract := solana.NewWallet().PublicKey()
+	acc := solana.NewWallet().PublicKey()
+	msg := MessagePublicationAccount{
+		ConsistencyLevel: 32,
+		SubmissionTime:   1700000000,
+		Nonce:            7,
+		Sequence:         42,
+		EmitterChain:     uint16(vaa.ChainIDSolana),
+		EmitterAddress:   vaa.Address{1, 2, 3},
+		Payload:          []byte("payload"),
+	}
+
+	// The first stream fails after slot 100, and the second one starts at slot 103. The message was posted in between.
+	fake := &droppingGeyser{streams: [][]*geyserv1.SubscribeUpdate{{slotUpdate(100)}, {slotUpdate(103)}}}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	geyserv1.RegisterGeyserServer(server, fake)
+	go func() { _ = server.Serve(lis) }()
+	defer server.Stop()
+
+	ts := newFakeBlockRPC(t, contract, acc, msg, map[uint64]*rpc.GetBlockResult{
+		101: {},
+		102: postMessageBlock(t, contract, acc),
+		103: {},
+	})
+
+	msgC := make(chan *common.MessagePublication, 10)
+	w := NewSolanaWatcher(ts.URL, nil, "http://"+lis.Addr().String(), "", contract, contract.String(), msgC, nil, rpc.CommitmentFinalized, vaa.ChainIDSolana, nil, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	errC := make(chan error, 2)
+	supervisor.New(ctx, zap.NewNop(), func(ctx context.Context) error {
+		supervisor.Signal(ctx, supervisor.SignalHealthy)
+		// The watcher is restarted when its stream fails.
+		errC <- w.runGeyserSubscription(ctx)
+		errC <- w.runGeyserSubscription(ctx)
+		<-ctx.Done()
+		return nil
+	})
+
+	select {
+	case err := <-errC:
+		assert.ErrorContains(t, err, "stream dropped")
+	case <-ctx.Done():
+		t.Fatal("first stream did not fail")
+	}
+
+	select {
+	case m := <-msgC:
+		assert.Equal(t, uint64(42), m.Sequence)
+		assert.Equal(t, acc.Bytes(), m.TxHash.Bytes())
+		assert.Equal(t, testSignature[:], m.TxID)
+		assert.Equal(t, vaa.Address{1, 2, 3}, m.EmitterAddress)
+		assert.Equal(t, []byte("payload"), m.Payload)
+	case <-ctx.Done():
+		t.Fatal("message posted while the stream was down not observed")
+	}
+	assert.Equal(t, uint64(103), w.geyserSlot.Load())
+}
@@ -0,0 +1,112 @@
+package solana
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/watchers"
+	solana_types "github.com/gagliardetto/solana-go"
+	solana_rpc "github.com/gagliardetto/solana-go/rpc"
+)
+
+// Genesis hashes of the Solana clusters used by the guardian network. Testnet guardians watch Solana devnet.
+var solanaGenesisHashes = map[common.Environment]string{
+	common.MainNet: "5eykt4UsFv8P8NJdTREpY1vzqKqZKvdpKuc147dyrgT",
+	common.TestNet: "EtWTRABZaYq6iMfeYKouRu166VU2xqa1wcaWoxPkrZBG",
+}
+
+// upgradeableProgramDataOffset is the size of the header of a BPF upgradeable loader program data account, which precedes the executable.
+const upgradeableProgramDataOffset = 4 + 8 + 1 + 32
+
+// Validate checks that the RPC endpoint is reachable and serves the expected cluster, that the contract is a deployed program
+// (matching ExpectedContractHash, if set) and that the configured commitment level is allowed in env and supported by the endpoint.
+func (wc *WatcherConfig) Validate(ctx context.Context, env common.Environment) []watchers.ValidationResult {
+	var results []watchers.ValidationResult
+	pass := func(check string, format string, args ...any) {
+		results = append(results, watchers.ValidationResult{Check: check, Passed: true, Detail: fmt.Sprintf(format, args...)})
+	}
+	fail := func(check string, format string, args ...any) {
+		results = append(results, watchers.ValidationResult{Check: check, Passed: false, Detail: fmt.Sprintf(format, args...)})
+	}
+
+	client := solana_rpc.New(wc.Rpc)
+
+	version, err := client.GetVersion(ctx)
+	if err != nil {
+		fail("rpc connectivity", "failed to query %s: %v", wc.Rpc, err)
+		// Nothing else is going to work.
+		return results
+	}
+	pass("rpc connectivity", "solana-core %s", version.SolanaCore)
+
+	genesis, err := client.GetGenesisHash(ctx)
+	if err != nil {
+		fail("chain id", "failed to query genesis hash: %v", err)
+	} else if expected, exists := solanaGenesisHashes[env]; !exists {
+		pass("chain id", "genesis hash %s (not checked in %s)", genesis, env)
+	} else if genesis.String() != expected {
+		fail("chain id", "genesis hash %s does not match the expected %s for %s", genesis, expected, env)
+	} else {
+		pass("chain id", "genesis hash %s", genesis)
+	}
+
+	if hash, err := wc.programHash(ctx, client); err != nil {
+		fail("contract", "%v", err)
+	} else if wc.ExpectedContractHash != "" && !strings.EqualFold(strings.TrimPrefix(wc.ExpectedContractHash, "0x"), hash) {
+		fail("contract", "program %s has hash %s, expected %s", wc.Contract, hash, wc.ExpectedContractHash)
+	} else {
+		pass("contract", "program %s has hash %s", wc.Contract, hash)
+	}
+
+	if err := wc.checkCommitment(env); err != nil {
+		fail("finality", "%v", err)
+	} else if slot, err := client.GetSlot(ctx, wc.Commitment); err != nil {
+		fail("finality", "failed to query slot at commitment %s: %v", wc.Commitment, err)
+	} else {
+		pass("finality", "commitment %s is at slot %d", wc.Commitment, slot)
+	}
+
+	return results
+}
+
+// programHash returns the hex encoded SHA-256 hash of the executable of the contract, with trailing zero padding removed.
+func (wc *WatcherConfig) programHash(ctx context.Context, client *solana_rpc.Client) (string, error) {
+	programID, err := solana_types.PublicKeyFromBase58(wc.Contract)
+	if err != nil {
+		return "", fmt.Errorf("invalid contract address %s: %w", wc.Contract, err)
+	}
+
+	info, err := client.GetAccountInfo(ctx, programID)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up contract %s: %w", wc.Contract, err)
+	}
+	if !info.Value.Executable {
+		return "", fmt.Errorf("account %s is not an executable program", wc.Contract)
+	}
+
+	data := info.Value.Data.GetBinary()
+	if info.Value.Owner.Equals(solana_types.BPFLoaderUpgradeableProgramID) {
+		// The program account only points at the program data account, which holds the executable.
+		if len(data) < 36 {
+			return "", fmt.Errorf("program account %s is too short", wc.Contract)
+		}
+		programData := solana_types.PublicKeyFromBytes(data[4:36])
+		pdInfo, err := client.GetAccountInfo(ctx, programData)
+		if err != nil {
+			return "", fmt.Errorf("failed to look up program data account %s: %w", programData, err)
+		}
+		data = pdInfo.Value.Data.GetBinary()
+		if len(data) < upgradeableProgramDataOffset {
+			return "", fmt.Errorf("program data account %s is too short", programData)
+		}
+		data = data[upgradeableProgramDataOffset:]
+	}
+
+	digest := sha256.Sum256(bytes.TrimRight(data, "\x00"))
+	return hex.EncodeToString(digest[:]), nil
+}
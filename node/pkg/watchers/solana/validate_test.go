@@ -0,0 +1,132 @@
+package solana
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/watchers"
+	solana_types "github.com/gagliardetto/solana-go"
+	solana_rpc "github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFakeSolanaRPC serves just enough of the Solana JSON-RPC API for Validate, with an upgradeable program at programID.
+func newFakeSolanaRPC(t *testing.T, genesis string, programID, programData solana_types.PublicKey, executable []byte) *httptest.Server {
+	t.Helper()
+
+	programAccount := append([]byte{2, 0, 0, 0}, programData.Bytes()...)
+	programDataAccount := append(make([]byte, upgradeableProgramDataOffset), executable...)
+	// The executable is padded with zeros up to the maximum program size.
+	programDataAccount = append(programDataAccount, make([]byte, 64)...)
+
+	account := func(data []byte, executable bool) map[string]any {
+		return map[string]any{
+			"context": map[string]any{"slot": 100},
+			"value": map[string]any{
+				"data":       []string{base64.StdEncoding.EncodeToString(data), "base64"},
+				"executable": executable,
+				"lamports":   1,
+				"owner":      solana_types.BPFLoaderUpgradeableProgramID.String(),
+				"rentEpoch":  0,
+			},
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     any               `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		var result any
+		switch req.Method {
+		case "getVersion":
+			result = map[string]any{"solana-core": "1.17.0", "feature-set": 1}
+		case "getGenesisHash":
+			result = genesis
+		case "getSlot":
+			result = 100
+		case "getAccountInfo":
+			var addr string
+			require.NoError(t, json.Unmarshal(req.Params[0], &addr))
+			switch addr {
+			case programID.String():
+				result = account(programAccount, true)
+			case programData.String():
+				result = account(programDataAccount, false)
+			default:
+				result = map[string]any{"context": map[string]any{"slot": 100}, "value": nil}
+			}
+		default:
+			t.Errorf("unexpected method %s", req.Method)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": result}))
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func resultsByCheck(results []watchers.ValidationResult) map[string]bool {
+	m := make(map[string]bool)
+	for _, r := range results {
+		m[r.Check] = r.Passed
+	}
+	return m
+}
+
+func TestValidate(t *testing.T) {
+	programID := solana_types.NewWallet().PublicKey()
+	programData := solana_types.NewWallet().PublicKey()
+	executable := []byte("\x7fELF not really a program")
+	digest := sha256.Sum256(executable)
+	expectedHash := hex.EncodeToString(digest[:])
+
+	ts := newFakeSolanaRPC(t, solanaGenesisHashes[common.MainNet], programID, programData, executable)
+	wc := &WatcherConfig{Rpc: ts.URL, Contract: programID.String(), Commitment: solana_rpc.CommitmentFinalized}
+
+	results := resultsByCheck(wc.Validate(context.Background(), common.MainNet))
+	assert.Equal(t, map[string]bool{"rpc connectivity": true, "chain id": true, "contract": true, "finality": true}, results)
+
+	wc.ExpectedContractHash = "0x" + expectedHash
+	assert.True(t, resultsByCheck(wc.Validate(context.Background(), common.MainNet))["contract"])
+
+	wc.ExpectedContractHash = hex.EncodeToString(make([]byte, 32))
+	assert.False(t, resultsByCheck(wc.Validate(context.Background(), common.MainNet))["contract"])
+
+	// The fake serves the mainnet genesis hash, which is not what testnet guardians watch.
+	assert.False(t, resultsByCheck(wc.Validate(context.Background(), common.TestNet))["chain id"])
+	assert.True(t, resultsByCheck(wc.Validate(context.Background(), common.UnsafeDevNet))["chain id"])
+
+	// Processed watchers are only allowed on devnet.
+	wc.Commitment = solana_rpc.CommitmentProcessed
+	wc.Geyser = "http://solana-geyser:10000"
+	assert.False(t, resultsByCheck(wc.Validate(context.Background(), common.MainNet))["finality"])
+	assert.True(t, resultsByCheck(wc.Validate(context.Background(), common.UnsafeDevNet))["finality"])
+	wc.Commitment = solana_rpc.CommitmentFinalized
+
+	wc.Contract = solana_types.NewWallet().PublicKey().String()
+	assert.False(t, resultsByCheck(wc.Validate(context.Background(), common.MainNet))["contract"])
+}
+
+func TestValidateUnreachable(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	ts.Close()
+
+	wc := &WatcherConfig{Rpc: ts.URL, Contract: solana_types.NewWallet().PublicKey().String(), Commitment: solana_rpc.CommitmentFinalized}
+	results := wc.Validate(context.Background(), common.MainNet)
+	require.Equal(t, 1, len(results))
+	assert.Equal(t, "rpc connectivity", results[0].Check)
+	assert.False(t, results[0].Passed)
+}
@@ -1,6 +1,11 @@
 package watchers
 
 import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
 	"github.com/certusone/wormhole/node/pkg/common"
 	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
 	"github.com/certusone/wormhole/node/pkg/query"
@@ -27,3 +32,66 @@ type WatcherConfig interface {
 		env common.Environment,
 	) (interfaces.L1Finalizer, supervisor.Runnable, error)
 }
+
+// ValidationResult is the outcome of a single check performed while validating a watcher config.
+type ValidationResult struct {
+	Check  string
+	Passed bool
+	Detail string
+}
+
+// ValidatableConfig is implemented by watcher configs that can check their settings against the live chain before they are deployed.
+type ValidatableConfig interface {
+	WatcherConfig
+	Validate(ctx context.Context, env common.Environment) []ValidationResult
+}
+
+// Configs holds the current config of each watcher, by NetworkID. The guardian builds a watcher from its current config each time the
+// supervisor starts it, so a config replaced with Set is used once the watcher is restarted.
+type Configs struct {
+	mu      sync.RWMutex
+	configs map[NetworkID]WatcherConfig
+}
+
+func NewConfigs(configs []WatcherConfig) *Configs {
+	c := &Configs{configs: make(map[NetworkID]WatcherConfig, len(configs))}
+	for _, wc := range configs {
+		c.configs[wc.GetNetworkID()] = wc
+	}
+	return c
+}
+
+// Get returns the current config of the watcher, or nil if there is no such watcher.
+func (c *Configs) Get(id NetworkID) WatcherConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.configs[id]
+}
+
+// Set replaces the config of a watcher of the same NetworkID. The previous config may still be used by the running watcher, so it
+// must not be modified in place.
+func (c *Configs) Set(wc WatcherConfig) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	prev, ok := c.configs[wc.GetNetworkID()]
+	if !ok {
+		return fmt.Errorf("no watcher for network %s", wc.GetNetworkID())
+	}
+	if prev.GetChainID() != wc.GetChainID() {
+		return fmt.Errorf("the watcher for network %s watches chain %s, not %s", wc.GetNetworkID(), prev.GetChainID(), wc.GetChainID())
+	}
+	c.configs[wc.GetNetworkID()] = wc
+	return nil
+}
+
+// All returns the current configs, ordered by NetworkID.
+func (c *Configs) All() []WatcherConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	configs := make([]WatcherConfig, 0, len(c.configs))
+	for _, wc := range c.configs {
+		configs = append(configs, wc)
+	}
+	sort.Slice(configs, func(i, j int) bool { return configs[i].GetNetworkID() < configs[j].GetNetworkID() })
+	return configs
+}
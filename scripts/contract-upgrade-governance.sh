@@ -59,6 +59,9 @@ if ! command -v worm >/dev/null 2>&1; then
   exit 1
 fi
 
+# guardiand fetches the current guardian set index for the prototxt from here.
+public_rpc="${WORMHOLE_PUBLIC_RPC:-https://api.wormholescan.io}"
+
 ### Parse command line options
 address=""
 module=""
@@ -261,25 +264,29 @@ function create_governance() {
     echo "\
 guardiand template contract-upgrade \\
   --chain-id $chain \\
-  --new-address $address"
+  --new-address $address \\
+  --publicRpc $public_rpc"
     ;;
   token_bridge)
     echo "\
 guardiand template token-bridge-upgrade-contract \\
   --chain-id $chain --module \"TokenBridge\" \\
-  --new-address $address"
+  --new-address $address \\
+  --publicRpc $public_rpc"
     ;;
   nft_bridge)
     echo "\
 guardiand template token-bridge-upgrade-contract \\
   --chain-id $chain --module \"NFTBridge\" \\
-  --new-address $address"
+  --new-address $address \\
+  --publicRpc $public_rpc"
     ;;
   wormhole_relayer)
     echo "\
 guardiand template token-bridge-upgrade-contract \\
   --chain-id $chain --module \"WormholeRelayer\" \\
-  --new-address $address"
+  --new-address $address \\
+  --publicRpc $public_rpc"
     ;;
   *) echo "unknown module $module" >&2
      usage
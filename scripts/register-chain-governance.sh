@@ -56,6 +56,9 @@ if ! command -v worm >/dev/null 2>&1; then
   exit 1
 fi
 
+# guardiand fetches the current guardian set index for the prototxt from here.
+public_rpc="${WORMHOLE_PUBLIC_RPC:-https://api.wormholescan.io}"
+
 ### Parse command line options
 address=""
 module=""
@@ -116,19 +119,22 @@ function create_governance() {
     echo "\
 guardiand template token-bridge-register-chain \\
   --chain-id $chain --module \"TokenBridge\" \\
-  --new-address $address"
+  --new-address $address \\
+  --publicRpc $public_rpc"
     ;;
   NFTBridge)
     echo "\
 guardiand template token-bridge-register-chain \\
   --chain-id $chain --module \"NFTBridge\" \\
-  --new-address $address"
+  --new-address $address \\
+  --publicRpc $public_rpc"
     ;;
   WormholeRelayer)
     echo "\
 guardiand template token-bridge-register-chain \\
   --chain-id $chain --module \"WormholeRelayer\" \\
-  --new-address $address"
+  --new-address $address \\
+  --publicRpc $public_rpc"
     ;;
   *) echo "unknown module $module" >&2
      usage
@@ -3,6 +3,7 @@ package vaa
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -20,6 +21,9 @@ var WormholeRelayerModule = [32]byte{
 }
 var WormholeRelayerModuleStr = string(WormholeRelayerModule[:])
 
+// ChainGovernorModule is the identifier of the guardians' chain governor (which is used for governance messages).
+const ChainGovernorModule = "ChainGovernor"
+
 type GovernanceAction uint8
 
 var (
@@ -38,6 +42,9 @@ var (
 
 	// Wormhole relayer governance actions
 	WormholeRelayerSetDefaultDeliveryProvider GovernanceAction = 3
+
+	// Chain governor governance actions
+	ActionChainGovernorSetChainLimit GovernanceAction = 1
 )
 
 type (
@@ -53,6 +60,21 @@ type (
 		NewIndex uint32
 	}
 
+	// BodyCoreSetMessageFee is a governance message to set the fee charged for publishing a message on the core contract of a chain.
+	BodyCoreSetMessageFee struct {
+		ChainID ChainID
+		// MessageFee is in the smallest unit of the native token of the chain.
+		MessageFee *uint256.Int
+	}
+
+	// BodyCoreTransferFees is a governance message to transfer message fees collected by the core contract of a chain.
+	BodyCoreTransferFees struct {
+		ChainID ChainID
+		// Amount is in the smallest unit of the native token of the chain.
+		Amount    *uint256.Int
+		Recipient Address
+	}
+
 	// BodyTokenBridgeRegisterChain is a governance message to register a chain on the token bridge
 	BodyTokenBridgeRegisterChain struct {
 		Module         string
@@ -79,6 +101,14 @@ type (
 		ChainID                           ChainID
 		NewDefaultDeliveryProviderAddress Address
 	}
+
+	// BodyChainGovernorSetChainLimit is a governance message to set the limits the guardians' chain governor enforces for an emitter
+	// chain. It is addressed to all guardians, so the target chain of the message is always ChainIDUnset.
+	BodyChainGovernorSetChainLimit struct {
+		EmitterChainID     ChainID
+		DailyLimit         uint64
+		BigTransactionSize uint64
+	}
 )
 
 func (b BodyContractUpgrade) Serialize() []byte {
@@ -115,6 +145,46 @@ func (b BodyGuardianSetUpdate) Serialize() []byte {
 	return buf.Bytes()
 }
 
+// Deserialize parses a governance payload created by Serialize.
+func (b *BodyGuardianSetUpdate) Deserialize(payload []byte) error {
+	const headerLength = 32 + 1 + 2 + 4 + 1
+	if len(payload) < headerLength {
+		return fmt.Errorf("guardian set update payload has length %d, expected at least %d", len(payload), headerLength)
+	}
+	if !bytes.Equal(payload[:32], CoreModule) {
+		return errors.New("payload is not for the Core module")
+	}
+	if action := GovernanceAction(payload[32]); action != ActionGuardianSetUpdate {
+		return fmt.Errorf("unexpected core action %d", action)
+	}
+	if target := ChainID(binary.BigEndian.Uint16(payload[33:35])); target != ChainIDUnset {
+		return fmt.Errorf("unexpected target chain %d", target)
+	}
+	numKeys := int(payload[39])
+	if len(payload) != headerLength+numKeys*common.AddressLength {
+		return fmt.Errorf("guardian set update payload has length %d, expected %d for %d keys", len(payload), headerLength+numKeys*common.AddressLength, numKeys)
+	}
+	b.NewIndex = binary.BigEndian.Uint32(payload[35:39])
+	b.Keys = make([]common.Address, numKeys)
+	for i := range b.Keys {
+		copy(b.Keys[i][:], payload[headerLength+i*common.AddressLength:])
+	}
+	return nil
+}
+
+func (b BodyCoreSetMessageFee) Serialize() []byte {
+	payload := &bytes.Buffer{}
+	MustWrite(payload, binary.BigEndian, b.MessageFee.Bytes32())
+	return serializeBridgeGovernanceVaa("Core", ActionCoreSetMessageFee, b.ChainID, payload.Bytes())
+}
+
+func (b BodyCoreTransferFees) Serialize() []byte {
+	payload := &bytes.Buffer{}
+	MustWrite(payload, binary.BigEndian, b.Amount.Bytes32())
+	payload.Write(b.Recipient[:])
+	return serializeBridgeGovernanceVaa("Core", ActionCoreTransferFees, b.ChainID, payload.Bytes())
+}
+
 func (r BodyTokenBridgeRegisterChain) Serialize() []byte {
 	payload := &bytes.Buffer{}
 	MustWrite(payload, binary.BigEndian, r.ChainID)
@@ -152,6 +222,35 @@ func (r BodyWormholeRelayerSetDefaultDeliveryProvider) Serialize() []byte {
 	return serializeBridgeGovernanceVaa(WormholeRelayerModuleStr, WormholeRelayerSetDefaultDeliveryProvider, r.ChainID, payload.Bytes())
 }
 
+func (r BodyChainGovernorSetChainLimit) Serialize() []byte {
+	payload := &bytes.Buffer{}
+	MustWrite(payload, binary.BigEndian, r.EmitterChainID)
+	MustWrite(payload, binary.BigEndian, r.DailyLimit)
+	MustWrite(payload, binary.BigEndian, r.BigTransactionSize)
+	return serializeBridgeGovernanceVaa(ChainGovernorModule, ActionChainGovernorSetChainLimit, ChainIDUnset, payload.Bytes())
+}
+
+// Deserialize parses a governance payload created by Serialize.
+func (r *BodyChainGovernorSetChainLimit) Deserialize(payload []byte) error {
+	const length = 32 + 1 + 2 + 2 + 8 + 8
+	if len(payload) != length {
+		return fmt.Errorf("chain governor set chain limit payload has length %d, expected %d", len(payload), length)
+	}
+	if !bytes.Equal(payload[:32], LeftPadBytes(ChainGovernorModule, 32).Bytes()) {
+		return fmt.Errorf("payload is not for the %s module", ChainGovernorModule)
+	}
+	if action := GovernanceAction(payload[32]); action != ActionChainGovernorSetChainLimit {
+		return fmt.Errorf("unexpected chain governor action %d", action)
+	}
+	if target := ChainID(binary.BigEndian.Uint16(payload[33:35])); target != ChainIDUnset {
+		return fmt.Errorf("unexpected target chain %d", target)
+	}
+	r.EmitterChainID = ChainID(binary.BigEndian.Uint16(payload[35:37]))
+	r.DailyLimit = binary.BigEndian.Uint64(payload[37:45])
+	r.BigTransactionSize = binary.BigEndian.Uint64(payload[45:53])
+	return nil
+}
+
 func EmptyPayloadVaa(module string, actionId GovernanceAction, chainId ChainID) []byte {
 	return serializeBridgeGovernanceVaa(module, actionId, chainId, []byte{})
 }
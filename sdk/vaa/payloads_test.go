@@ -66,6 +66,13 @@ func TestBodyGuardianSetUpdateSerialize(t *testing.T) {
 	expected := "00000000000000000000000000000000000000000000000000000000436f726502000000000001025aaeb6053f3e94c9b9a09f33669435e7ef1beaed5aaeb6053f3e94c9b9a09f33669435e7ef1beaee"
 	serializedBodyGuardianSetUpdate := bodyGuardianSetUpdate.Serialize()
 	assert.Equal(t, expected, hex.EncodeToString(serializedBodyGuardianSetUpdate))
+
+	var decoded BodyGuardianSetUpdate
+	assert.NoError(t, decoded.Deserialize(serializedBodyGuardianSetUpdate))
+	assert.Equal(t, bodyGuardianSetUpdate, decoded)
+
+	assert.ErrorContains(t, decoded.Deserialize(serializedBodyGuardianSetUpdate[:len(serializedBodyGuardianSetUpdate)-1]), "expected 80 for 2 keys")
+	assert.ErrorContains(t, decoded.Deserialize(BodyContractUpgrade{ChainID: ChainIDSolana, NewContract: addr}.Serialize()), "unexpected core action 1")
 }
 
 func TestBodyTokenBridgeRegisterChainSerialize(t *testing.T) {
@@ -145,6 +152,26 @@ func FuzzLeftPadBytes(f *testing.F) {
 	})
 }
 
+func TestBodyCoreSetMessageFeeSerialize(t *testing.T) {
+	expected := "00000000000000000000000000000000000000000000000000000000436f726503000100000000000000000000000000000000000000000000000000000000000003e8"
+	bodyCoreSetMessageFee := BodyCoreSetMessageFee{
+		ChainID:    ChainIDSolana,
+		MessageFee: uint256.NewInt(1000),
+	}
+	assert.Equal(t, expected, hex.EncodeToString(bodyCoreSetMessageFee.Serialize()))
+}
+
+func TestBodyCoreTransferFeesSerialize(t *testing.T) {
+	expected := "00000000000000000000000000000000000000000000000000000000436f72650400010000000000000000000000000000000000000000000000000000000000000064" +
+		"0000000000000000000000000000000000000000000000000000000000000004"
+	bodyCoreTransferFees := BodyCoreTransferFees{
+		ChainID:   ChainIDSolana,
+		Amount:    uint256.NewInt(100),
+		Recipient: addr,
+	}
+	assert.Equal(t, expected, hex.EncodeToString(bodyCoreTransferFees.Serialize()))
+}
+
 func TestBodyWormholeRelayerSetDefaultDeliveryProviderSerialize(t *testing.T) {
 	expected := "0000000000000000000000000000000000576f726d686f6c6552656c617965720300040000000000000000000000000000000000000000000000000000000000000004"
 	bodyWormholeRelayerSetDefaultDeliveryProvider := BodyWormholeRelayerSetDefaultDeliveryProvider{
@@ -173,3 +200,21 @@ func TestBodyTokenBridgeRecoverChainIdSerialize(t *testing.T) {
 	}
 	assert.Equal(t, expected, hex.EncodeToString(BodyRecoverChainId.Serialize()))
 }
+
+func TestBodyChainGovernorSetChainLimitSerialize(t *testing.T) {
+	expected := "00000000000000000000000000000000000000436861696e476f7665726e6f72010000000100000000000003e80000000000000064"
+	body := BodyChainGovernorSetChainLimit{
+		EmitterChainID:     ChainIDSolana,
+		DailyLimit:         1000,
+		BigTransactionSize: 100,
+	}
+	serialized := body.Serialize()
+	assert.Equal(t, expected, hex.EncodeToString(serialized))
+
+	var decoded BodyChainGovernorSetChainLimit
+	assert.NoError(t, decoded.Deserialize(serialized))
+	assert.Equal(t, body, decoded)
+
+	assert.ErrorContains(t, decoded.Deserialize(serialized[1:]), "has length")
+	assert.ErrorContains(t, decoded.Deserialize(BodyTokenBridgeUpgradeContract{Module: "TokenBridge", NewContract: addr}.Serialize()[:53]), "is not for the ChainGovernor module")
+}